@@ -10,7 +10,8 @@ import (
 
 	"github.com/eralove/eralove-backend/internal/app"
 	"github.com/eralove/eralove-backend/internal/config"
-	"github.com/rs/zerolog"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"github.com/eralove/eralove-backend/internal/infrastructure/pii"
 	"go.uber.org/zap"
 )
 
@@ -38,11 +39,20 @@ func main() {
 	}
 
 	// Initialize logger
-	logger, err := initLogger(cfg)
+	logger, err := logging.New(cfg.Environment)
 	if err != nil {
 		log.Fatalf("Error initializing logger: %v", err)
 	}
 	zap.ReplaceGlobals(logger)
+	pii.Configure(pii.Mode(cfg.LogRedaction), cfg.LogRedactionKey)
+	logger.Info("Configuration loaded",
+		zap.String("environment", cfg.Environment),
+		zap.Any("settings", cfg.Summary()))
+
+	// "migrate" syncs the database indexes and exits instead of serving
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(runMigrate(cfg, logger, os.Args[2:]))
+	}
 
 	// Create application with Wire DI
 	application, err := app.InitializeApp(cfg, logger)
@@ -73,28 +83,3 @@ func main() {
 
 	logger.Info("Server exiting")
 }
-
-func initLogger(cfg *config.Config) (*zap.Logger, error) {
-	var logger *zap.Logger
-	var err error
-
-	if cfg.Environment == "production" {
-		logger, err = zap.NewProduction()
-	} else {
-		logger, err = zap.NewDevelopment()
-	}
-
-	if err != nil {
-		return nil, err
-	}
-
-	// Initialize zerolog for structured logging
-	zerolog.TimeFieldFormat = time.RFC3339Nano
-	zerolog.SetGlobalLevel(zerolog.InfoLevel)
-
-	if cfg.Environment != "production" {
-		zerolog.SetGlobalLevel(zerolog.DebugLevel)
-	}
-
-	return logger, nil
-}
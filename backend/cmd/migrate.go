@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/config"
+	"github.com/eralove/eralove-backend/internal/infrastructure/database"
+	"go.uber.org/zap"
+)
+
+// migrateTimeout bounds a migration; building indexes on large collections takes a while
+const migrateTimeout = 30 * time.Minute
+
+// runMigrate syncs the database indexes with their declarations and returns the exit
+// code. With -prune, indexes that are no longer declared are dropped.
+func runMigrate(cfg *config.Config, logger *zap.Logger, args []string) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	prune := flags.Bool("prune", false, "drop indexes that are not declared")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	db, err := database.NewMongoDB(cfg.MongoURI, cfg.DatabaseName, logger)
+	if err != nil {
+		logger.Error("Failed to connect to database", zap.Error(err))
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), migrateTimeout)
+	defer cancel()
+	defer db.Close(context.Background())
+
+	if _, err := db.SyncIndexes(ctx, *prune); err != nil {
+		logger.Error("Migration failed", zap.Error(err))
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,120 @@
+package docs
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/swaggo/swag"
+)
+
+// successResponseRef is the definition of the envelope /api/v2 wraps responses in
+const successResponseRef = "#/definitions/handler.SuccessResponse"
+
+// rawPathPrefixes are the paths whose responses follow an external format, such as
+// OpenID Connect or a badge service, and are never wrapped
+var rawPathPrefixes = []string{"/oauth/", "/public/"}
+
+// SwaggerInfoV2 holds the Swagger info of /api/v2. Its document is derived from the
+// generated one, so it is not regenerated separately.
+var SwaggerInfoV2 = &swag.Spec{
+	Version:          "2.0",
+	Host:             SwaggerInfo.Host,
+	BasePath:         "/api/v2",
+	Schemes:          SwaggerInfo.Schemes,
+	Title:            SwaggerInfo.Title,
+	Description:      SwaggerInfo.Description + " Version 2 serves the same endpoints as version 1 and wraps every successful JSON response in a SuccessResponse envelope, with the payload in data and the pagination of lists in meta. OpenID Connect and public badge responses keep their own format.",
+	InfoInstanceName: "v2",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        SwaggerInfo.LeftDelim,
+	RightDelim:       SwaggerInfo.RightDelim,
+}
+
+// envelopedDoc is a Swagger document whose successful responses are wrapped in the
+// SuccessResponse envelope
+type envelopedDoc struct {
+	spec *swag.Spec
+}
+
+// ReadDoc renders the document of the spec and wraps its successful responses. The
+// unwrapped document is returned if it cannot be parsed.
+func (d *envelopedDoc) ReadDoc() string {
+	raw := d.spec.ReadDoc()
+
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		return raw
+	}
+
+	paths, _ := doc["paths"].(map[string]interface{})
+	for name, path := range paths {
+		if isRawPath(name) {
+			continue
+		}
+		operations, _ := path.(map[string]interface{})
+		for _, operation := range operations {
+			operation, _ := operation.(map[string]interface{})
+			responses, _ := operation["responses"].(map[string]interface{})
+			for code, response := range responses {
+				response, ok := response.(map[string]interface{})
+				if !ok || !strings.HasPrefix(code, "2") || code == "204" {
+					continue
+				}
+				wrapResponseSchema(response)
+			}
+		}
+	}
+
+	wrapped, err := json.MarshalIndent(doc, "", "    ")
+	if err != nil {
+		return raw
+	}
+	return string(wrapped)
+}
+
+// isRawPath reports whether the responses of a path are never wrapped
+func isRawPath(path string) bool {
+	for _, prefix := range rawPathPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapResponseSchema puts the schema of a response in the data of a SuccessResponse,
+// unless the response is already one
+func wrapResponseSchema(response map[string]interface{}) {
+	schema, ok := response["schema"].(map[string]interface{})
+	if !ok || refersTo(schema, successResponseRef) {
+		return
+	}
+
+	response["schema"] = map[string]interface{}{
+		"allOf": []interface{}{
+			map[string]interface{}{"$ref": successResponseRef},
+			map[string]interface{}{
+				"type":       "object",
+				"properties": map[string]interface{}{"data": schema},
+			},
+		},
+	}
+}
+
+// refersTo reports whether a schema is the definition at ref, or composed from it
+func refersTo(schema map[string]interface{}, ref string) bool {
+	if schema["$ref"] == ref {
+		return true
+	}
+
+	allOf, _ := schema["allOf"].([]interface{})
+	for _, part := range allOf {
+		if part, ok := part.(map[string]interface{}); ok && part["$ref"] == ref {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	swag.Register(SwaggerInfoV2.InstanceName(), &envelopedDoc{spec: SwaggerInfoV2})
+}
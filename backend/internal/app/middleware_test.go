@@ -0,0 +1,152 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/handler"
+	"github.com/eralove/eralove-backend/internal/infrastructure/auth"
+	"github.com/eralove/eralove-backend/internal/infrastructure/idempotency"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// newJWTTestApp serves a route behind jwtMiddleware that answers with the ID of the
+// authenticated user
+func newJWTTestApp(jwtManager *auth.JWTManager) *fiber.App {
+	app := fiber.New()
+	app.Use(requestMetaMiddleware())
+	app.Get("/me", jwtMiddleware(jwtManager, zap.NewNop()), func(c *fiber.Ctx) error {
+		return c.SendString(c.Locals("user_id").(primitive.ObjectID).Hex())
+	})
+	return app
+}
+
+func TestJWTMiddlewareAcceptsAccessToken(t *testing.T) {
+	jwtManager := auth.NewJWTManager(testJWTSecret, "", nil, 15, 168)
+	app := newJWTTestApp(jwtManager)
+
+	tokens, err := jwtManager.GenerateTokenPair(primitive.NewObjectID(), "alex@example.com", "Alex", "en", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		setup func(req *http.Request)
+	}{
+		{"bearer header", func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+tokens.AccessToken) }},
+		{"cookie", func(req *http.Request) { req.Header.Set("Cookie", handler.AccessTokenCookie+"="+tokens.AccessToken) }},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(fiber.MethodGet, "/me", nil)
+		tt.setup(req)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("%s: status = %d, want %d", tt.name, resp.StatusCode, fiber.StatusOK)
+		}
+	}
+}
+
+func TestJWTMiddlewareRejectsRefreshToken(t *testing.T) {
+	jwtManager := auth.NewJWTManager(testJWTSecret, "", nil, 15, 168)
+	app := newJWTTestApp(jwtManager)
+
+	tokens, err := jwtManager.GenerateTokenPair(primitive.NewObjectID(), "alex@example.com", "Alex", "en", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		setup func(req *http.Request)
+	}{
+		{"bearer header", func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+tokens.RefreshToken) }},
+		{"cookie", func(req *http.Request) { req.Header.Set("Cookie", handler.AccessTokenCookie+"="+tokens.RefreshToken) }},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(fiber.MethodGet, "/me", nil)
+		tt.setup(req)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != fiber.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want %d", tt.name, resp.StatusCode, fiber.StatusUnauthorized)
+		}
+	}
+}
+
+// newIdempotencyTestApp serves a route behind idempotencyMiddleware that fails with err
+// and counts how often it is handled. Errors are rendered with their status.
+func newIdempotencyTestApp(err error, calls *int) *fiber.App {
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			var appErr *domain.AppError
+			if errors.As(err, &appErr) {
+				return c.Status(appErr.StatusCode).JSON(fiber.Map{"error": appErr.Message})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		},
+	})
+	app.Post("/events", idempotencyMiddleware(idempotency.NewMemoryStore(), time.Hour, zap.NewNop()), func(c *fiber.Ctx) error {
+		*calls++
+		return err
+	})
+	return app
+}
+
+// postTwice sends the same request twice with one idempotency key and returns the
+// response to the retry
+func postTwice(t *testing.T, app *fiber.App) *http.Response {
+	t.Helper()
+	var resp *http.Response
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(fiber.MethodPost, "/events", nil)
+		req.Header.Set(idempotency.Header, "retry-key")
+		var err error
+		if resp, err = app.Test(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return resp
+}
+
+func TestIdempotencyReplaysClientError(t *testing.T) {
+	calls := 0
+	app := newIdempotencyTestApp(domain.NewAppError(domain.ErrCodeUserAlreadyExists, "Already exists", fiber.StatusConflict), &calls)
+
+	resp := postTwice(t, app)
+
+	if resp.StatusCode != fiber.StatusConflict {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusConflict)
+	}
+	if resp.Header.Get("Idempotent-Replayed") != "true" {
+		t.Error("retry was not replayed")
+	}
+	if calls != 1 {
+		t.Errorf("handled %d times, want 1", calls)
+	}
+}
+
+func TestIdempotencyRetriesServerError(t *testing.T) {
+	calls := 0
+	app := newIdempotencyTestApp(errors.New("database unavailable"), &calls)
+
+	resp := postTwice(t, app)
+
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusInternalServerError)
+	}
+	if calls != 2 {
+		t.Errorf("handled %d times, want 2", calls)
+	}
+}
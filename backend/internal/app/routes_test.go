@@ -0,0 +1,81 @@
+package app
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/config"
+	"github.com/eralove/eralove-backend/internal/handler"
+	"github.com/eralove/eralove-backend/internal/infrastructure/auth"
+	"github.com/eralove/eralove-backend/internal/infrastructure/idempotency"
+	"github.com/eralove/eralove-backend/internal/infrastructure/origins"
+	"github.com/eralove/eralove-backend/internal/infrastructure/ratelimit"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	testAdminKey  = "test-admin-key-0123456789abcdefghij"
+	testJWTSecret = "test-jwt-secret-0123456789abcdefghij"
+)
+
+// newTestAPI serves the API routes under /api/v1. Only the handlers of the routes the
+// tests call are set.
+func newTestAPI(t *testing.T) (*fiber.App, *auth.JWTManager) {
+	t.Helper()
+
+	registry, err := origins.NewRegistry(nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := zap.NewNop()
+	cfg := &config.Config{
+		AdminAPIKeys:      []string{testAdminKey},
+		RateLimitRequests: 1000,
+		RateLimitWindow:   60,
+		IdempotencyTTL:    24,
+	}
+	jwtManager := auth.NewJWTManager(testJWTSecret, "", nil, 15, 168)
+	deps := &Dependencies{
+		CORSHandler:   handler.NewCORSHandler(registry, logger),
+		JWTKeyHandler: handler.NewJWTKeyHandler(jwtManager, nil, logger),
+	}
+
+	app := fiber.New()
+	app.Use(requestMetaMiddleware())
+	rateLimitStore := ratelimit.NewMemoryStore()
+	rateLimiter := ratelimit.NewLimiter(rateLimitStore, "api", cfg.RateLimitRequests, time.Minute)
+	registerAPIRoutes(app.Group("/api/v1"), cfg, deps, jwtManager, rateLimiter, rateLimitStore, idempotency.NewMemoryStore(), logger)
+
+	return app, jwtManager
+}
+
+func TestAdminRouteAcceptsAdminKey(t *testing.T) {
+	app, _ := newTestAPI(t)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v1/admin/cors/origins", nil)
+	req.Header.Set("X-Admin-Key", testAdminKey)
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+}
+
+func TestAdminRouteRejectsInvalidAdminKey(t *testing.T) {
+	app, _ := newTestAPI(t)
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v1/admin/cors/origins", nil)
+	req.Header.Set("X-Admin-Key", "not-the-admin-key")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/sanitize"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// sanitizeMiddleware bounds the size of JSON request bodies and sanitizes their string
+// fields before handlers parse them: values are trimmed and stripped of control
+// characters, and email addresses lowercased. The limit is that of the longest prefix
+// in routeLimits matching the path after prefix, or defaultLimit. Credentials are
+// left as they were sent, and bodies that are not valid JSON are left for the
+// handlers to reject.
+func sanitizeMiddleware(prefix string, defaultLimit int, routeLimits map[string]int, logger *zap.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !strings.HasPrefix(string(c.Request().Header.ContentType()), fiber.MIMEApplicationJSON) {
+			return c.Next()
+		}
+
+		body := c.Body()
+		if len(body) == 0 {
+			return c.Next()
+		}
+
+		limit := jsonBodyLimit(strings.TrimPrefix(c.Path(), prefix), defaultLimit, routeLimits)
+		if len(body) > limit {
+			return domain.ErrBodyTooLargeError(limit)
+		}
+
+		sanitized, changed, err := sanitize.JSON(body, preservedField)
+		if err != nil {
+			logger.Debug("Request body left unsanitized", zap.Error(err), zap.String("path", c.Path()))
+			return c.Next()
+		}
+		if changed {
+			c.Request().SetBody(sanitized)
+		}
+		return c.Next()
+	}
+}
+
+// jsonBodyLimit returns the largest JSON body accepted on path: the limit of the
+// longest matching route prefix, or defaultLimit
+func jsonBodyLimit(path string, defaultLimit int, routeLimits map[string]int) int {
+	limit, matched := defaultLimit, ""
+	for route, routeLimit := range routeLimits {
+		if len(route) > len(matched) && (path == route || strings.HasPrefix(path, strings.TrimSuffix(route, "/")+"/")) {
+			limit, matched = routeLimit, route
+		}
+	}
+	return limit
+}
+
+// preservedField reports whether a JSON field holds a credential, which must be
+// checked exactly as it was sent
+func preservedField(field string) bool {
+	return strings.Contains(field, "password") || strings.Contains(field, "token") || strings.Contains(field, "secret")
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"github.com/eralove/eralove-backend/docs"
+	"github.com/eralove/eralove-backend/internal/handler"
+	"github.com/gofiber/fiber/v2"
+	"github.com/swaggo/swag"
+)
+
+// APIVersion is a version of the HTTP API, served under /api/<version>
+type APIVersion string
+
+// API versions. Every version serves the same routes; they differ in how responses
+// are shaped.
+const (
+	// APIV1 returns each handler's response body as is
+	APIV1 APIVersion = "v1"
+	// APIV2 returns successful responses in a SuccessResponse envelope, with the
+	// pagination of lists in its meta
+	APIV2 APIVersion = "v2"
+)
+
+// apiVersions lists the served API versions, oldest first
+var apiVersions = []APIVersion{APIV1, APIV2}
+
+// Prefix returns the path the version's routes are served under
+func (v APIVersion) Prefix() string {
+	return "/api/" + string(v)
+}
+
+// docsInstance returns the name the version's Swagger document is registered under
+func (v APIVersion) docsInstance() string {
+	if v == APIV2 {
+		return docs.SwaggerInfoV2.InstanceName()
+	}
+	return docs.SwaggerInfo.InstanceName()
+}
+
+// parseAPIVersion returns the served API version named s
+func parseAPIVersion(s string) (APIVersion, bool) {
+	for _, version := range apiVersions {
+		if string(version) == s {
+			return version, true
+		}
+	}
+	return "", false
+}
+
+// openAPIHandler serves the OpenAPI (Swagger 2.0) document of an API version for
+// client code generation: the version named by the version query, or the latest
+func openAPIHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		version := apiVersions[len(apiVersions)-1]
+		if name := c.Query("version"); name != "" {
+			var ok bool
+			if version, ok = parseAPIVersion(name); !ok {
+				return c.Status(fiber.StatusNotFound).JSON(handler.ErrorResponse{
+					Error:   "Unknown API version",
+					Message: "API version " + name + " is not served",
+				})
+			}
+		}
+
+		doc, err := swag.ReadDoc(version.docsInstance())
+		if err != nil {
+			return err
+		}
+
+		c.Set("API-Version", string(version))
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.SendString(doc)
+	}
+}
+
+// apiVersionMiddleware records the API version of the request, which handlers shape
+// their responses by
+func apiVersionMiddleware(version APIVersion) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(handler.APIVersionKey, string(version))
+		c.Set("API-Version", string(version))
+		return c.Next()
+	}
+}
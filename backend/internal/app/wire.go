@@ -4,10 +4,18 @@
 package app
 
 import (
+	_ "github.com/eralove/eralove-backend/docs"
 	"github.com/eralove/eralove-backend/internal/config"
 	"github.com/eralove/eralove-backend/internal/domain"
+	grpcserver "github.com/eralove/eralove-backend/internal/grpc/server"
 	"github.com/eralove/eralove-backend/internal/handler"
 	"github.com/eralove/eralove-backend/internal/infrastructure"
+	"github.com/eralove/eralove-backend/internal/infrastructure/auth"
+	"github.com/eralove/eralove-backend/internal/infrastructure/email"
+	"github.com/eralove/eralove-backend/internal/infrastructure/origins"
+	"github.com/eralove/eralove-backend/internal/infrastructure/scheduler"
+	"github.com/eralove/eralove-backend/internal/infrastructure/secrets"
+	"github.com/eralove/eralove-backend/internal/infrastructure/webhook"
 	"github.com/eralove/eralove-backend/internal/repository"
 	"github.com/eralove/eralove-backend/internal/service"
 	"github.com/google/wire"
@@ -38,18 +46,139 @@ func ProvideDependencies(
 	storageService domain.StorageService,
 	eventHandler *handler.EventHandler,
 	matchRequestHandler *handler.MatchRequestHandler,
-	// TODO: Add when implemented
-	// messageHandler *handler.MessageHandler,
+	insightHandler *handler.InsightHandler,
+	goalHandler *handler.GoalHandler,
+	albumHandler *handler.AlbumHandler,
+	notificationHandler *handler.NotificationHandler,
+	affirmationHandler *handler.AffirmationHandler,
+	coupleSettingsHandler *handler.CoupleSettingsHandler,
+	shareLinkHandler *handler.ShareLinkHandler,
+	searchHandler *handler.SearchHandler,
+	trashHandler *handler.TrashHandler,
+	errorHandler *handler.ErrorHandler,
+	timelineHandler *handler.TimelineHandler,
+	feedbackHandler *handler.FeedbackHandler,
+	calendarHandler *handler.CalendarHandler,
+	clientErrorHandler *handler.ClientErrorHandler,
+	changelogHandler *handler.ChangelogHandler,
+	retentionHandler *handler.RetentionHandler,
+	pendingActionHandler *handler.PendingActionHandler,
+	coupleKeyHandler *handler.CoupleKeyHandler,
+	messageHandler *handler.MessageHandler,
+	messageSearchHandler *handler.MessageSearchHandler,
+	oidcHandler *handler.OIDCHandler,
+	storageIntegrityHandler *handler.StorageIntegrityHandler,
+	coupleBadgeHandler *handler.CoupleBadgeHandler,
+	presenceHandler *handler.PresenceHandler,
+	corsHandler *handler.CORSHandler,
+	jwtKeyHandler *handler.JWTKeyHandler,
+	accountMergeHandler *handler.AccountMergeHandler,
+	usageHandler *handler.UsageHandler,
+	photoCommentHandler *handler.PhotoCommentHandler,
+	journalHandler *handler.JournalHandler,
+	bucketListHandler *handler.BucketListHandler,
+	moodHandler *handler.MoodHandler,
+	autoMilestoneHandler *handler.AutoMilestoneHandler,
+	countdownHandler *handler.CountdownHandler,
+	adminHandler *handler.AdminHandler,
+	auditHandler *handler.AuditHandler,
+	memoriesHandler *handler.MemoriesHandler,
+	placeHandler *handler.PlaceHandler,
+	fileHandler *handler.FileHandler,
+	engagementHandler *handler.EngagementHandler,
+	dailyQuestionHandler *handler.DailyQuestionHandler,
+	wishlistHandler *handler.WishlistHandler,
+	datePlanHandler *handler.DatePlanHandler,
+	eventService domain.EventService,
+	goalService domain.GoalService,
+	affirmationService domain.AffirmationService,
+	trashService domain.TrashService,
+	queue *email.Queue,
+	retentionService domain.RetentionService,
+	coupleKeyService domain.CoupleKeyService,
+	storageIntegrityService domain.StorageIntegrityService,
+	userService domain.UserService,
+	registry *origins.Registry,
+	usageService domain.UsageService,
+	autoMilestoneService domain.AutoMilestoneService,
+	memoriesService domain.MemoriesService,
+	engagementService domain.EngagementService,
+	jobScheduler *scheduler.Scheduler,
+	dispatcher *webhook.Dispatcher,
+	jwtManager *auth.JWTManager,
+	reloader *secrets.Reloader,
+	grpcServer *grpcserver.Server,
+
 ) *Dependencies {
 	return &Dependencies{
-		UserHandler:         userHandler,
-		PhotoHandler:        photoHandler,
-		UploadHandler:       uploadHandler,
-		StorageService:      storageService,
-		EventHandler:        eventHandler,
-		MatchRequestHandler: matchRequestHandler,
-		// TODO: Add when implemented
-		// MessageHandler: messageHandler,
+		UserHandler:             userHandler,
+		PhotoHandler:            photoHandler,
+		UploadHandler:           uploadHandler,
+		StorageService:          storageService,
+		EventHandler:            eventHandler,
+		MatchRequestHandler:     matchRequestHandler,
+		InsightHandler:          insightHandler,
+		GoalHandler:             goalHandler,
+		AlbumHandler:            albumHandler,
+		NotificationHandler:     notificationHandler,
+		AffirmationHandler:      affirmationHandler,
+		CoupleSettingsHandler:   coupleSettingsHandler,
+		ShareLinkHandler:        shareLinkHandler,
+		SearchHandler:           searchHandler,
+		TrashHandler:            trashHandler,
+		ErrorHandler:            errorHandler,
+		TimelineHandler:         timelineHandler,
+		FeedbackHandler:         feedbackHandler,
+		CalendarHandler:         calendarHandler,
+		ClientErrorHandler:      clientErrorHandler,
+		ChangelogHandler:        changelogHandler,
+		RetentionHandler:        retentionHandler,
+		PendingActionHandler:    pendingActionHandler,
+		CoupleKeyHandler:        coupleKeyHandler,
+		MessageHandler:          messageHandler,
+		MessageSearchHandler:    messageSearchHandler,
+		OIDCHandler:             oidcHandler,
+		StorageIntegrityHandler: storageIntegrityHandler,
+		CoupleBadgeHandler:      coupleBadgeHandler,
+		PresenceHandler:         presenceHandler,
+		CORSHandler:             corsHandler,
+		JWTKeyHandler:           jwtKeyHandler,
+		AccountMergeHandler:     accountMergeHandler,
+		UsageHandler:            usageHandler,
+		PhotoCommentHandler:     photoCommentHandler,
+		JournalHandler:          journalHandler,
+		BucketListHandler:       bucketListHandler,
+		MoodHandler:             moodHandler,
+		AutoMilestoneHandler:    autoMilestoneHandler,
+		CountdownHandler:        countdownHandler,
+		AdminHandler:            adminHandler,
+		AuditHandler:            auditHandler,
+		MemoriesHandler:         memoriesHandler,
+		PlaceHandler:            placeHandler,
+		FileHandler:             fileHandler,
+		EngagementHandler:       engagementHandler,
+		DailyQuestionHandler:    dailyQuestionHandler,
+		WishlistHandler:         wishlistHandler,
+		DatePlanHandler:         datePlanHandler,
+		EventService:            eventService,
+		GoalService:             goalService,
+		AffirmationService:      affirmationService,
+		TrashService:            trashService,
+		EmailQueue:              queue,
+		RetentionService:        retentionService,
+		CoupleKeyService:        coupleKeyService,
+		StorageIntegrityService: storageIntegrityService,
+		UserService:             userService,
+		OriginRegistry:          registry,
+		UsageService:            usageService,
+		AutoMilestoneService:    autoMilestoneService,
+		MemoriesService:         memoriesService,
+		EngagementService:       engagementService,
+		Scheduler:               jobScheduler,
+		WebhookDispatcher:       dispatcher,
+		JWTManager:              jwtManager,
+		JWTSecretReloader:       reloader,
+		GRPCServer:              grpcServer,
 	}
 }
 
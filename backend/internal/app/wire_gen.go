@@ -9,8 +9,15 @@ package app
 import (
 	"github.com/eralove/eralove-backend/internal/config"
 	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/grpc/server"
 	"github.com/eralove/eralove-backend/internal/handler"
 	"github.com/eralove/eralove-backend/internal/infrastructure"
+	"github.com/eralove/eralove-backend/internal/infrastructure/auth"
+	"github.com/eralove/eralove-backend/internal/infrastructure/email"
+	"github.com/eralove/eralove-backend/internal/infrastructure/origins"
+	"github.com/eralove/eralove-backend/internal/infrastructure/scheduler"
+	"github.com/eralove/eralove-backend/internal/infrastructure/secrets"
+	"github.com/eralove/eralove-backend/internal/infrastructure/webhook"
 	"github.com/eralove/eralove-backend/internal/repository"
 	"github.com/eralove/eralove-backend/internal/service"
 	"github.com/google/wire"
@@ -31,27 +38,192 @@ func InitializeApp(cfg *config.Config, logger *zap.Logger) (*App, error) {
 	}
 	userRepository := repository.ProvideUserRepository(mongoDB, logger)
 	eventRepository := repository.ProvideEventRepository(mongoDB, logger)
-	photoRepository := repository.ProvidePhotoRepository(mongoDB, logger)
+	photoRepository := repository.ProvidePhotoRepository(cfg, mongoDB, logger)
+	storageService, err := infrastructure.ProvideStorageService(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
 	passwordManager := infrastructure.ProvidePasswordManager()
 	jwtManager := infrastructure.ProvideJWTManager(cfg)
-	emailService := infrastructure.ProvideEmailService(cfg, logger)
-	userService := service.ProvideUserService(userRepository, eventRepository, photoRepository, passwordManager, jwtManager, emailService, logger)
-	validate := infrastructure.ProvideValidator()
+	emailSender, err := infrastructure.ProvideEmailSender(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	queue := infrastructure.ProvideEmailQueue(cfg, emailSender, logger)
 	i18n := infrastructure.ProvideI18n(logger)
-	userHandler := handler.ProvideUserHandler(userService, validate, i18n, logger)
-	storageService, err := infrastructure.ProvideStorageService(cfg, logger)
+	emailService := infrastructure.ProvideEmailService(cfg, queue, i18n, logger)
+	coupleSettingsRepository := repository.ProvideCoupleSettingsRepository(mongoDB, logger)
+	notificationRepository := repository.ProvideNotificationRepository(mongoDB, logger)
+	renderer := infrastructure.ProvidePushRenderer(i18n)
+	notificationService := service.ProvideNotificationService(notificationRepository, userRepository, renderer)
+	coupleSettingsService := service.ProvideCoupleSettingsService(coupleSettingsRepository, userRepository, notificationService)
+	autoMilestoneService := service.ProvideAutoMilestoneService(eventRepository, userRepository, coupleSettingsRepository, coupleSettingsService, i18n)
+	tokenFamilyRepository := repository.ProvideTokenFamilyRepository(cfg, logger)
+	auditLogRepository := repository.ProvideAuditLogRepository(mongoDB, logger)
+	auditService := service.ProvideAuditService(auditLogRepository)
+	dispatcher := infrastructure.ProvideWebhookDispatcher(cfg, logger)
+	bus := infrastructure.ProvideEventBus(cfg, dispatcher, logger)
+	eventPublisher := infrastructure.ProvideEventPublisher(bus)
+	userService := service.ProvideUserService(userRepository, eventRepository, photoRepository, storageService, passwordManager, jwtManager, emailService, autoMilestoneService, tokenFamilyRepository, auditService, eventPublisher)
+	sessionCookies := handler.ProvideSessionCookies(cfg)
+	validate := infrastructure.ProvideValidator()
+	userHandler := handler.ProvideUserHandler(userService, sessionCookies, validate, i18n, logger)
+	albumRepository := repository.ProvideAlbumRepository(mongoDB, logger)
+	fileScanner, err := infrastructure.ProvideFileScanner(cfg, logger)
 	if err != nil {
 		return nil, err
 	}
-	photoService := service.ProvidePhotoService(photoRepository, userRepository, storageService, logger)
+	uploadScanService := service.ProvideUploadScanService(fileScanner, storageService, cfg)
+	imageService := service.ProvideImageService(storageService)
+	videoService := service.ProvideVideoService(storageService, cfg)
+	watermarkService := service.ProvideWatermarkService(coupleSettingsService, storageService)
+	uploadPolicy := service.ProvideUploadPolicy(cfg)
+	photoService := service.ProvidePhotoService(photoRepository, userRepository, albumRepository, storageService, uploadScanService, imageService, videoService, watermarkService, eventPublisher, uploadPolicy, cfg)
 	photoHandler := handler.ProvidePhotoHandler(photoService, validate, i18n, logger)
-	uploadHandler := handler.ProvideUploadHandler(storageService, i18n, logger)
-	eventService := service.ProvideEventService(eventRepository, userRepository, logger)
-	eventHandler := handler.ProvideEventHandler(eventService, validate, i18n, logger)
+	uploadSessionRepository := repository.ProvideUploadSessionRepository(cfg, logger)
+	uploadSessionService := service.ProvideUploadSessionService(uploadSessionRepository, storageService, uploadScanService, cfg)
+	uploadHandler := handler.ProvideUploadHandler(storageService, uploadSessionService, uploadScanService, uploadPolicy, validate, i18n, logger)
+	eventService := service.ProvideEventService(eventRepository, userRepository, coupleSettingsService, notificationService)
+	eventHandler := handler.ProvideEventHandler(eventService, coupleSettingsService, validate, i18n, logger)
 	matchRequestRepository := repository.ProvideMatchRequestRepository(mongoDB, logger)
-	matchRequestService := service.ProvideMatchRequestService(matchRequestRepository, userRepository, logger)
+	matchInviteRepository := repository.ProvideMatchInviteRepository(mongoDB, logger)
+	matchRequestService := service.ProvideMatchRequestService(matchRequestRepository, matchInviteRepository, userRepository, emailService, autoMilestoneService, eventPublisher, cfg)
 	matchRequestHandler := handler.ProvideMatchRequestHandler(matchRequestService, validate, i18n, logger)
-	dependencies := ProvideDependencies(userHandler, photoHandler, uploadHandler, storageService, eventHandler, matchRequestHandler)
+	contentSource := infrastructure.ProvideContentSource(cfg, logger)
+	insightService := service.ProvideInsightService(userRepository, contentSource, cfg)
+	insightHandler := handler.ProvideInsightHandler(insightService, i18n, logger)
+	goalRepository := repository.ProvideGoalRepository(mongoDB, logger)
+	goalService := service.ProvideGoalService(goalRepository, userRepository, emailService)
+	goalHandler := handler.ProvideGoalHandler(goalService, validate, i18n, logger)
+	pendingActionRepository := repository.ProvidePendingActionRepository(mongoDB, logger)
+	pendingActionService := service.ProvidePendingActionService(pendingActionRepository, userRepository, coupleSettingsService, notificationService, cfg)
+	albumService := service.ProvideAlbumService(albumRepository, photoRepository, userRepository, pendingActionService)
+	albumHandler := handler.ProvideAlbumHandler(albumService, validate, i18n, logger)
+	notificationHandler := handler.ProvideNotificationHandler(notificationService, i18n, logger)
+	affirmationRepository := repository.ProvideAffirmationRepository(mongoDB, logger)
+	affirmationService := service.ProvideAffirmationService(affirmationRepository, userRepository, storageService, uploadScanService, notificationService)
+	affirmationHandler := handler.ProvideAffirmationHandler(affirmationService, validate, i18n, logger)
+	coupleSettingsHandler := handler.ProvideCoupleSettingsHandler(coupleSettingsService, validate, i18n, logger)
+	shareLinkRepository := repository.ProvideShareLinkRepository(mongoDB, logger)
+	shareLinkService := service.ProvideShareLinkService(shareLinkRepository, photoRepository, albumRepository, userRepository, storageService, watermarkService, passwordManager)
+	shareLinkHandler := handler.ProvideShareLinkHandler(shareLinkService, validate, i18n, logger)
+	messageRepository := repository.ProvideMessageRepository(mongoDB, logger)
+	journalRepository := repository.ProvideJournalRepository(mongoDB, logger)
+	searchService := service.ProvideSearchService(photoRepository, eventRepository, messageRepository, journalRepository, userRepository)
+	searchHandler := handler.ProvideSearchHandler(searchService, i18n, logger)
+	trashService := service.ProvideTrashService(photoRepository, eventRepository, messageRepository, journalRepository, userRepository, storageService)
+	trashHandler := handler.ProvideTrashHandler(trashService, validate, i18n, logger)
+	requestTraceRepository := repository.ProvideRequestTraceRepository(mongoDB, logger)
+	errorHandler := handler.ProvideErrorHandler(i18n, requestTraceRepository, logger)
+	timelineService := service.ProvideTimelineService(photoRepository, eventRepository, albumRepository, journalRepository, userRepository, coupleSettingsService)
+	timelineHandler := handler.ProvideTimelineHandler(timelineService, i18n, logger)
+	feedbackRepository := repository.ProvideFeedbackRepository(mongoDB, logger)
+	feedbackMirror := infrastructure.ProvideFeedbackMirror(cfg, logger)
+	feedbackService := service.ProvideFeedbackService(feedbackRepository, userRepository, feedbackMirror, cfg)
+	feedbackHandler := handler.ProvideFeedbackHandler(feedbackService, validate, i18n, logger)
+	calendarFeedRepository := repository.ProvideCalendarFeedRepository(mongoDB, logger)
+	calendarService := service.ProvideCalendarService(eventRepository, userRepository, calendarFeedRepository)
+	calendarHandler := handler.ProvideCalendarHandler(calendarService, i18n, logger)
+	clientErrorRepository := repository.ProvideClientErrorRepository(mongoDB, logger)
+	clientErrorService := service.ProvideClientErrorService(clientErrorRepository, requestTraceRepository, cfg)
+	clientErrorHandler := handler.ProvideClientErrorHandler(clientErrorService, validate, i18n, logger)
+	releaseSource := infrastructure.ProvideReleaseSource(cfg, logger)
+	changelogSeenRepository := repository.ProvideChangelogSeenRepository(mongoDB, logger)
+	changelogService := service.ProvideChangelogService(releaseSource, changelogSeenRepository, cfg)
+	changelogHandler := handler.ProvideChangelogHandler(changelogService, validate, i18n, logger)
+	retentionAuditRepository := repository.ProvideRetentionAuditRepository(mongoDB, logger)
+	retentionService := service.ProvideRetentionService(userRepository, matchRequestRepository, photoRepository, storageService, retentionAuditRepository, cfg)
+	retentionHandler := handler.ProvideRetentionHandler(retentionService, validate, i18n, logger)
+	pendingActionHandler := handler.ProvidePendingActionHandler(pendingActionService, i18n, logger)
+	keyManager, err := infrastructure.ProvideKeyManager(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	coupleKeyService := service.ProvideCoupleKeyService(coupleSettingsRepository, userRepository, keyManager)
+	coupleKeyHandler := handler.ProvideCoupleKeyHandler(coupleKeyService, i18n, logger)
+	messageReceiptRepository := repository.ProvideMessageReceiptRepository(cfg, logger)
+	messageService := service.ProvideMessageService(messageRepository, userRepository, pendingActionService, storageService, notificationService, coupleKeyService, messageReceiptRepository, eventPublisher, cfg)
+	messageHandler := handler.ProvideMessageHandler(messageService, validate, i18n, logger)
+	messageSearchService := service.ProvideMessageSearchService(messageRepository, userRepository)
+	messageSearchHandler := handler.ProvideMessageSearchHandler(messageSearchService, i18n, logger)
+	authorizationCodeRepository := repository.ProvideAuthorizationCodeRepository(mongoDB, logger)
+	idTokenSigner, err := infrastructure.ProvideIDTokenSigner(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	oidcService := service.ProvideOIDCService(authorizationCodeRepository, userRepository, jwtManager, idTokenSigner, cfg)
+	oidcHandler := handler.ProvideOIDCHandler(oidcService, logger)
+	storageIntegrityRepository := repository.ProvideStorageIntegrityRepository(mongoDB, logger)
+	storageReconciliationRepository := repository.ProvideStorageReconciliationRepository(mongoDB, logger)
+	storageIntegrityService := service.ProvideStorageIntegrityService(photoRepository, storageIntegrityRepository, storageReconciliationRepository, storageService, cfg)
+	storageIntegrityHandler := handler.ProvideStorageIntegrityHandler(storageIntegrityService, logger)
+	coupleBadgeRepository := repository.ProvideCoupleBadgeRepository(mongoDB, logger)
+	coupleBadgeService := service.ProvideCoupleBadgeService(coupleBadgeRepository, userRepository, cfg)
+	coupleBadgeHandler := handler.ProvideCoupleBadgeHandler(coupleBadgeService, cfg, logger)
+	presenceRepository := repository.ProvidePresenceRepository(cfg, logger)
+	presenceService := service.ProvidePresenceService(presenceRepository, userRepository, coupleSettingsService, cfg)
+	presenceHandler := handler.ProvidePresenceHandler(presenceService, validate, i18n, logger)
+	registry, err := infrastructure.ProvideOriginRegistry(cfg)
+	if err != nil {
+		return nil, err
+	}
+	corsHandler := handler.ProvideCORSHandler(registry, logger)
+	reloader, err := infrastructure.ProvideJWTSecretReloader(cfg, jwtManager, logger)
+	if err != nil {
+		return nil, err
+	}
+	jwtKeyHandler := handler.ProvideJWTKeyHandler(jwtManager, reloader, logger)
+	accountMergeRepository := repository.ProvideAccountMergeRepository(mongoDB, logger)
+	accountMergeService := service.ProvideAccountMergeService(accountMergeRepository, userRepository, photoRepository, eventRepository, messageRepository, passwordManager, emailService)
+	accountMergeHandler := handler.ProvideAccountMergeHandler(accountMergeService, validate, i18n, logger)
+	usageRepository := repository.ProvideUsageRepository(mongoDB, logger)
+	usageService := service.ProvideUsageService(usageRepository, userRepository, photoRepository, eventRepository, messageRepository)
+	usageHandler := handler.ProvideUsageHandler(usageService, logger)
+	photoCommentRepository := repository.ProvidePhotoCommentRepository(mongoDB, logger)
+	photoCommentService := service.ProvidePhotoCommentService(photoCommentRepository, photoRepository, userRepository, notificationService)
+	photoCommentHandler := handler.ProvidePhotoCommentHandler(photoCommentService, validate, i18n, logger)
+	journalService := service.ProvideJournalService(journalRepository, userRepository)
+	journalHandler := handler.ProvideJournalHandler(journalService, validate, i18n, logger)
+	bucketListRepository := repository.ProvideBucketListRepository(mongoDB, logger)
+	bucketListService := service.ProvideBucketListService(bucketListRepository, photoRepository, eventRepository, userRepository)
+	bucketListHandler := handler.ProvideBucketListHandler(bucketListService, validate, i18n, logger)
+	moodRepository := repository.ProvideMoodRepository(mongoDB, logger)
+	moodService := service.ProvideMoodService(moodRepository, userRepository, eventPublisher)
+	moodHandler := handler.ProvideMoodHandler(moodService, validate, i18n, logger)
+	autoMilestoneHandler := handler.ProvideAutoMilestoneHandler(autoMilestoneService, validate, i18n, logger)
+	countdownRepository := repository.ProvideCountdownRepository(mongoDB, logger)
+	countdownService := service.ProvideCountdownService(countdownRepository, eventRepository, userRepository)
+	countdownHandler := handler.ProvideCountdownHandler(countdownService, validate, i18n, logger)
+	adminService := service.ProvideAdminService(userRepository, matchRequestRepository, tokenFamilyRepository, userService, auditService)
+	adminHandler := handler.ProvideAdminHandler(adminService, validate, i18n, logger)
+	auditHandler := handler.ProvideAuditHandler(auditService, logger)
+	memoriesService := service.ProvideMemoriesService(photoRepository, eventRepository, albumRepository, userRepository, coupleSettingsService, notificationService, emailService)
+	memoriesHandler := handler.ProvideMemoriesHandler(memoriesService, i18n, logger)
+	placeProvider, err := infrastructure.ProvidePlaceProvider(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	placeService := service.ProvidePlaceService(placeProvider, cfg)
+	placeHandler := handler.ProvidePlaceHandler(placeService, i18n, logger)
+	fileService := service.ProvideFileService(photoRepository, userRepository, storageService)
+	fileHandler := handler.ProvideFileHandler(fileService, cfg, logger)
+	engagementRepository := repository.ProvideEngagementRepository(mongoDB, logger)
+	engagementService := service.ProvideEngagementService(engagementRepository, userRepository, coupleSettingsService, bus)
+	engagementHandler := handler.ProvideEngagementHandler(engagementService, logger)
+	dailyQuestionRepository := repository.ProvideDailyQuestionRepository(mongoDB, logger)
+	questionSource := infrastructure.ProvideQuestionSource(cfg, logger)
+	dailyQuestionService := service.ProvideDailyQuestionService(dailyQuestionRepository, userRepository, coupleSettingsService, notificationService, questionSource, cfg)
+	dailyQuestionHandler := handler.ProvideDailyQuestionHandler(dailyQuestionService, validate, i18n, logger)
+	wishlistRepository := repository.ProvideWishlistRepository(mongoDB, logger)
+	wishlistService := service.ProvideWishlistService(wishlistRepository, userRepository)
+	wishlistHandler := handler.ProvideWishlistHandler(wishlistService, validate, i18n, logger)
+	datePlanRepository := repository.ProvideDatePlanRepository(mongoDB, logger)
+	dateIdeaSource := infrastructure.ProvideDateIdeaSource(cfg, logger)
+	datePlanService := service.ProvideDatePlanService(datePlanRepository, userRepository, eventService, dateIdeaSource, cfg)
+	datePlanHandler := handler.ProvideDatePlanHandler(datePlanService, validate, i18n, logger)
+	scheduler := infrastructure.ProvideScheduler(logger)
+	server := infrastructure.ProvideGRPCServer(cfg, userService, photoService, eventService, logger)
+	dependencies := ProvideDependencies(userHandler, photoHandler, uploadHandler, storageService, eventHandler, matchRequestHandler, insightHandler, goalHandler, albumHandler, notificationHandler, affirmationHandler, coupleSettingsHandler, shareLinkHandler, searchHandler, trashHandler, errorHandler, timelineHandler, feedbackHandler, calendarHandler, clientErrorHandler, changelogHandler, retentionHandler, pendingActionHandler, coupleKeyHandler, messageHandler, messageSearchHandler, oidcHandler, storageIntegrityHandler, coupleBadgeHandler, presenceHandler, corsHandler, jwtKeyHandler, accountMergeHandler, usageHandler, photoCommentHandler, journalHandler, bucketListHandler, moodHandler, autoMilestoneHandler, countdownHandler, adminHandler, auditHandler, memoriesHandler, placeHandler, fileHandler, engagementHandler, dailyQuestionHandler, wishlistHandler, datePlanHandler, eventService, goalService, affirmationService, trashService, queue, retentionService, coupleKeyService, storageIntegrityService, userService, registry, usageService, autoMilestoneService, memoriesService, engagementService, scheduler, dispatcher, jwtManager, reloader, server)
 	app, err := ProvideApp(cfg, logger, dependencies)
 	if err != nil {
 		return nil, err
@@ -74,15 +246,139 @@ func ProvideDependencies(
 	storageService domain.StorageService,
 	eventHandler *handler.EventHandler,
 	matchRequestHandler *handler.MatchRequestHandler,
+	insightHandler *handler.InsightHandler,
+	goalHandler *handler.GoalHandler,
+	albumHandler *handler.AlbumHandler,
+	notificationHandler *handler.NotificationHandler,
+	affirmationHandler *handler.AffirmationHandler,
+	coupleSettingsHandler *handler.CoupleSettingsHandler,
+	shareLinkHandler *handler.ShareLinkHandler,
+	searchHandler *handler.SearchHandler,
+	trashHandler *handler.TrashHandler,
+	errorHandler *handler.ErrorHandler,
+	timelineHandler *handler.TimelineHandler,
+	feedbackHandler *handler.FeedbackHandler,
+	calendarHandler *handler.CalendarHandler,
+	clientErrorHandler *handler.ClientErrorHandler,
+	changelogHandler *handler.ChangelogHandler,
+	retentionHandler *handler.RetentionHandler,
+	pendingActionHandler *handler.PendingActionHandler,
+	coupleKeyHandler *handler.CoupleKeyHandler,
+	messageHandler *handler.MessageHandler,
+	messageSearchHandler *handler.MessageSearchHandler,
+	oidcHandler *handler.OIDCHandler,
+	storageIntegrityHandler *handler.StorageIntegrityHandler,
+	coupleBadgeHandler *handler.CoupleBadgeHandler,
+	presenceHandler *handler.PresenceHandler,
+	corsHandler *handler.CORSHandler,
+	jwtKeyHandler *handler.JWTKeyHandler,
+	accountMergeHandler *handler.AccountMergeHandler,
+	usageHandler *handler.UsageHandler,
+	photoCommentHandler *handler.PhotoCommentHandler,
+	journalHandler *handler.JournalHandler,
+	bucketListHandler *handler.BucketListHandler,
+	moodHandler *handler.MoodHandler,
+	autoMilestoneHandler *handler.AutoMilestoneHandler,
+	countdownHandler *handler.CountdownHandler,
+	adminHandler *handler.AdminHandler,
+	auditHandler *handler.AuditHandler,
+	memoriesHandler *handler.MemoriesHandler,
+	placeHandler *handler.PlaceHandler,
+	fileHandler *handler.FileHandler,
+	engagementHandler *handler.EngagementHandler,
+	dailyQuestionHandler *handler.DailyQuestionHandler,
+	wishlistHandler *handler.WishlistHandler,
+	datePlanHandler *handler.DatePlanHandler,
+	eventService domain.EventService,
+	goalService domain.GoalService,
+	affirmationService domain.AffirmationService,
+	trashService domain.TrashService,
+	queue *email.Queue,
+	retentionService domain.RetentionService,
+	coupleKeyService domain.CoupleKeyService,
+	storageIntegrityService domain.StorageIntegrityService,
+	userService domain.UserService,
+	registry *origins.Registry,
+	usageService domain.UsageService,
+	autoMilestoneService domain.AutoMilestoneService,
+	memoriesService domain.MemoriesService,
+	engagementService domain.EngagementService,
+	jobScheduler *scheduler.Scheduler,
+	dispatcher *webhook.Dispatcher,
+	jwtManager *auth.JWTManager,
+	reloader *secrets.Reloader,
+	grpcServer *server.Server,
 
 ) *Dependencies {
 	return &Dependencies{
-		UserHandler:         userHandler,
-		PhotoHandler:        photoHandler,
-		UploadHandler:       uploadHandler,
-		StorageService:      storageService,
-		EventHandler:        eventHandler,
-		MatchRequestHandler: matchRequestHandler,
+		UserHandler:             userHandler,
+		PhotoHandler:            photoHandler,
+		UploadHandler:           uploadHandler,
+		StorageService:          storageService,
+		EventHandler:            eventHandler,
+		MatchRequestHandler:     matchRequestHandler,
+		InsightHandler:          insightHandler,
+		GoalHandler:             goalHandler,
+		AlbumHandler:            albumHandler,
+		NotificationHandler:     notificationHandler,
+		AffirmationHandler:      affirmationHandler,
+		CoupleSettingsHandler:   coupleSettingsHandler,
+		ShareLinkHandler:        shareLinkHandler,
+		SearchHandler:           searchHandler,
+		TrashHandler:            trashHandler,
+		ErrorHandler:            errorHandler,
+		TimelineHandler:         timelineHandler,
+		FeedbackHandler:         feedbackHandler,
+		CalendarHandler:         calendarHandler,
+		ClientErrorHandler:      clientErrorHandler,
+		ChangelogHandler:        changelogHandler,
+		RetentionHandler:        retentionHandler,
+		PendingActionHandler:    pendingActionHandler,
+		CoupleKeyHandler:        coupleKeyHandler,
+		MessageHandler:          messageHandler,
+		MessageSearchHandler:    messageSearchHandler,
+		OIDCHandler:             oidcHandler,
+		StorageIntegrityHandler: storageIntegrityHandler,
+		CoupleBadgeHandler:      coupleBadgeHandler,
+		PresenceHandler:         presenceHandler,
+		CORSHandler:             corsHandler,
+		JWTKeyHandler:           jwtKeyHandler,
+		AccountMergeHandler:     accountMergeHandler,
+		UsageHandler:            usageHandler,
+		PhotoCommentHandler:     photoCommentHandler,
+		JournalHandler:          journalHandler,
+		BucketListHandler:       bucketListHandler,
+		MoodHandler:             moodHandler,
+		AutoMilestoneHandler:    autoMilestoneHandler,
+		CountdownHandler:        countdownHandler,
+		AdminHandler:            adminHandler,
+		AuditHandler:            auditHandler,
+		MemoriesHandler:         memoriesHandler,
+		PlaceHandler:            placeHandler,
+		FileHandler:             fileHandler,
+		EngagementHandler:       engagementHandler,
+		DailyQuestionHandler:    dailyQuestionHandler,
+		WishlistHandler:         wishlistHandler,
+		DatePlanHandler:         datePlanHandler,
+		EventService:            eventService,
+		GoalService:             goalService,
+		AffirmationService:      affirmationService,
+		TrashService:            trashService,
+		EmailQueue:              queue,
+		RetentionService:        retentionService,
+		CoupleKeyService:        coupleKeyService,
+		StorageIntegrityService: storageIntegrityService,
+		UserService:             userService,
+		OriginRegistry:          registry,
+		UsageService:            usageService,
+		AutoMilestoneService:    autoMilestoneService,
+		MemoriesService:         memoriesService,
+		EngagementService:       engagementService,
+		Scheduler:               jobScheduler,
+		WebhookDispatcher:       dispatcher,
+		JWTManager:              jwtManager,
+		JWTSecretReloader:       reloader,
+		GRPCServer:              grpcServer,
 	}
 }
 
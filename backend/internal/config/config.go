@@ -1,22 +1,67 @@
 package config
 
 import (
+	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/caarlos0/env/v6"
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/origins"
+	"github.com/eralove/eralove-backend/internal/infrastructure/pii"
 	"github.com/joho/godotenv"
 )
 
+// Environments
+const (
+	EnvironmentDevelopment = "development"
+	EnvironmentTest        = "test"
+	EnvironmentStaging     = "staging"
+	EnvironmentProduction  = "production"
+)
+
+// Auth cookie modes
+const (
+	AuthCookieModeDisabled = "disabled"
+	AuthCookieModeOptional = "optional"
+	AuthCookieModeAlways   = "always"
+)
+
+// Request signing modes
+const (
+	RequestSigningDisabled = "disabled"
+	RequestSigningOptional = "optional"
+	RequestSigningRequired = "required"
+)
+
 // Config holds all configuration for our application
 type Config struct {
 	Environment string `env:"ENVIRONMENT" envDefault:"development"`
 	Port        string `env:"PORT" envDefault:"8080"`
+
+	// Internal gRPC API, served on its own port to services inside the cluster. It is
+	// only started when GRPC_SERVICE_TOKENS lists the tokens callers authenticate with.
+	GRPCPort          string   `env:"GRPC_PORT" envDefault:"9090"`
+	GRPCServiceTokens []string `env:"GRPC_SERVICE_TOKENS" envSeparator:","`
+	
+	// Time each dependency gets to answer the readiness probe at /health/ready
+	HealthCheckTimeout int `env:"HEALTH_CHECK_TIMEOUT" envDefault:"2000"` // milliseconds
+
+	// Personal data in logs: "mask" keeps the first character of email addresses and
+	// names, "hash" replaces them with a hash keyed with LOG_REDACTION_KEY, the same in
+	// every log line, and "off" logs them as they are. Credentials are never logged.
+	LogRedaction    string `env:"LOG_REDACTION" envDefault:"mask"`
+	LogRedactionKey string `env:"LOG_REDACTION_KEY"`
 	
 	// Database
 	MongoURI      string `env:"MONGO_URI" envDefault:"mongodb://localhost:27017"`
 	DatabaseName  string `env:"DATABASE_NAME" envDefault:"eralove"`
+	// Sync indexes when an instance starts. Deployments that run "eralove migrate"
+	// beforehand can turn it off.
+	MigrateOnStartup bool `env:"MIGRATE_ON_STARTUP" envDefault:"true"`
 	
 	// Redis
 	RedisAddr     string `env:"REDIS_ADDR" envDefault:"localhost:6379"`
@@ -27,21 +72,208 @@ type Config struct {
 	JWTSecret              string `env:"JWT_SECRET" envDefault:"your-secret-key"`
 	JWTAccessExpiration    int    `env:"JWT_ACCESS_EXPIRATION" envDefault:"15"`    // minutes
 	JWTRefreshExpiration   int    `env:"JWT_REFRESH_EXPIRATION" envDefault:"168"`  // hours (7 days)
+	// Keys that only verify tokens, so that JWT_SECRET can be rotated without signing
+	// everyone out: list the new secret here on every instance first, then swap it with
+	// JWT_SECRET once they all run with it
+	JWTPreviousSecrets     []string `env:"JWT_PREVIOUS_SECRETS" envSeparator:","`
+	// Secret that signed the tokens issued before tokens named their key, which verify
+	// with it until they expire; JWT_SECRET when empty
+	JWTLegacySecret        string   `env:"JWT_LEGACY_SECRET"`
+	
+	// Secret manager: vault (KV version 2), aws (Secrets Manager) or gcp (Secret
+	// Manager), or empty to read every secret from the environment. The settings below
+	// whose SECRETS_ reference is set are read from it at startup instead; a reference
+	// is the name of a secret, followed by #field for a field of a JSON secret, which
+	// Vault secrets always are. The JWT secret is read again every
+	// SECRETS_REFRESH_INTERVAL so that it can be rotated without a restart.
+	SecretsProvider        string `env:"SECRETS_PROVIDER" envDefault:""`
+	SecretsRefreshInterval int    `env:"SECRETS_REFRESH_INTERVAL" envDefault:"5"` // minutes
+	JWTSecretRef           string `env:"SECRETS_JWT_SECRET"`
+	SMTPPasswordRef        string `env:"SECRETS_SMTP_PASSWORD"`
+	StorageAccessKeyIDRef  string `env:"SECRETS_STORAGE_ACCESS_KEY_ID"`
+	StorageSecretKeyRef    string `env:"SECRETS_STORAGE_SECRET_KEY"`
+	VaultAddr              string `env:"VAULT_ADDR" envDefault:""`
+	VaultToken             string `env:"VAULT_TOKEN" envDefault:""`
+	VaultMount             string `env:"VAULT_MOUNT" envDefault:"secret"`
+	VaultNamespace         string `env:"VAULT_NAMESPACE" envDefault:""`
+	AWSRegion              string `env:"AWS_REGION" envDefault:""`
+	AWSAccessKeyID         string `env:"AWS_ACCESS_KEY_ID" envDefault:""`
+	AWSSecretAccessKey     string `env:"AWS_SECRET_ACCESS_KEY" envDefault:""`
+	AWSSessionToken        string `env:"AWS_SESSION_TOKEN" envDefault:""`
+	GCPProject             string `env:"GCP_PROJECT" envDefault:""`
+	
+	// Browser session cookies
+	AuthCookieMode     string `env:"AUTH_COOKIE_MODE" envDefault:"optional"` // disabled, optional (client sends X-Auth-Mode: cookie), always
+	AuthCookieDomain   string `env:"AUTH_COOKIE_DOMAIN" envDefault:""`
+	AuthCookieSecure   bool   `env:"AUTH_COOKIE_SECURE" envDefault:"true"`
+	AuthCookieSameSite string `env:"AUTH_COOKIE_SAME_SITE" envDefault:"Lax"` // Strict, Lax, None
+	
+	// OpenID Connect provider for the companion apps, disabled when OIDC_ISSUER is empty.
+	// The issuer is the public URL of /api/v1/oauth.
+	OIDCIssuer         string   `env:"OIDC_ISSUER" envDefault:""`
+	OIDCClients        []string `env:"OIDC_CLIENTS" envSeparator:","`       // clientID=redirectURI|redirectURI entries
+	OIDCSigningKeyFile string   `env:"OIDC_SIGNING_KEY_FILE" envDefault:""` // PEM RSA key signing ID tokens
+	
+	// Request signing for the official mobile apps
+	RequestSigningMode    string   `env:"REQUEST_SIGNING_MODE" envDefault:"disabled"` // disabled, optional (verify signed requests), required
+	RequestSigningKeys    []string `env:"REQUEST_SIGNING_KEYS" envSeparator:","`      // keyID:secret pairs
+	RequestSigningMaxSkew int      `env:"REQUEST_SIGNING_MAX_SKEW" envDefault:"300"`  // seconds
 	
-	// CORS
-	CORSOrigins string `env:"CORS_ORIGINS" envDefault:"http://localhost:5173,http://localhost:3000"`
+	// IP filtering
+	ProxyHeader          string   `env:"PROXY_HEADER" envDefault:""` // e.g. X-Real-IP or CF-Connecting-IP when behind a proxy
+	TrustedProxies       []string `env:"TRUSTED_PROXIES" envSeparator:","` // CIDRs or addresses of the proxies allowed to set PROXY_HEADER and GEOIP_COUNTRY_HEADER
+	IPFilterEnabled      bool     `env:"IP_FILTER_ENABLED" envDefault:"true"`
+	AdminAllowedCIDRs    []string `env:"ADMIN_ALLOWED_CIDRS" envSeparator:"," envDefault:"127.0.0.1/32,::1/128"`
+	AdminDeniedCIDRs     []string `env:"ADMIN_DENIED_CIDRS" envSeparator:","`
+	AuthBlockedCountries []string `env:"AUTH_BLOCKED_COUNTRIES" envSeparator:","`        // ISO country codes, e.g. KP,IR
+	GeoIPCountryHeader   string   `env:"GEOIP_COUNTRY_HEADER" envDefault:"CF-IPCountry"` // country header set by the CDN/edge
+	
+	// Envelope encryption of couple data. Each entry is keyID:base64 of 32 random bytes;
+	// the first wraps new data keys and the others are kept to unwrap older ones.
+	EncryptionMasterKeys []string `env:"ENCRYPTION_MASTER_KEYS" envSeparator:","`
+	
+	// Admin API
+	AdminAPIKeys []string `env:"ADMIN_API_KEYS" envSeparator:","` // keys accepted in X-Admin-Key; otherwise only users with a staff role reach admin routes
+	
+	// CORS. Origins may use wildcard subdomains such as https://*.example.com. Those in
+	// CORSOriginsFile, one per line, are added and can be reloaded at runtime.
+	CORSOrigins     []string `env:"CORS_ORIGINS" envSeparator:"," envDefault:"http://localhost:3000,http://localhost:5173,http://localhost:8080,http://127.0.0.1:3000,http://127.0.0.1:5173,http://127.0.0.1:8080"`
+	CORSOriginsFile string   `env:"CORS_ORIGINS_FILE"`
+
+	// Idempotency keys. Responses to requests sent with an Idempotency-Key are
+	// replayed to retries for IdempotencyTTL.
+	IdempotencyTTL int `env:"IDEMPOTENCY_TTL" envDefault:"24"` // hours
+
+	// Response compression with brotli, gzip or deflate, as the client accepts. -1
+	// disables it, 0 is the default level, 1 favors speed and 2 size.
+	CompressionLevel int `env:"COMPRESSION_LEVEL" envDefault:"0"`
+
+	// JSON request bodies are refused above JSONBodyLimit, or the limit of the longest
+	// route prefix in JSON_BODY_ROUTE_LIMITS that matches, after /api/<version>. File
+	// uploads are bounded by their own limits.
+	JSONBodyLimit       int      `env:"JSON_BODY_LIMIT" envDefault:"256"`                                           // KB
+	JSONBodyRouteLimits []string `env:"JSON_BODY_ROUTE_LIMITS" envSeparator:"," envDefault:"/auth=16,/messages=32"` // route=KB entries
 	
 	// File Upload
 	MaxFileSize   int64  `env:"MAX_FILE_SIZE" envDefault:"10485760"` // 10MB
 	UploadPath    string `env:"UPLOAD_PATH" envDefault:"./uploads"`
+
+	// Files uploaded through the API must have one of UPLOAD_ALLOWED_TYPES, with a
+	// matching extension, and fit MAX_FILE_SIZE or their folder's limit in
+	// UPLOAD_FOLDER_MAX_SIZES
+	UploadAllowedTypes   []string `env:"UPLOAD_ALLOWED_TYPES" envSeparator:"," envDefault:"image/jpeg,image/png,image/gif,image/webp,application/pdf,application/msword,application/vnd.openxmlformats-officedocument.wordprocessingml.document,video/mp4,video/quicktime,video/x-msvideo"`
+	UploadFolderMaxSizes []string `env:"UPLOAD_FOLDER_MAX_SIZES" envSeparator:"," envDefault:"avatars=5"` // folder=MB entries
 	
 	// i18n
 	DefaultLanguage string `env:"DEFAULT_LANGUAGE" envDefault:"en"`
 	
-	// Rate Limiting
+	// Rate Limiting. Each user, and each address on the auth routes, may send
+	// RateLimitRequests per window.
 	RateLimitRequests int `env:"RATE_LIMIT_REQUESTS" envDefault:"100"`
 	RateLimitWindow   int `env:"RATE_LIMIT_WINDOW" envDefault:"60"` // seconds
 	
+	// Feedback
+	FeedbackRateLimit  int `env:"FEEDBACK_RATE_LIMIT" envDefault:"5"`     // feedback a user may send per window
+	FeedbackRateWindow int `env:"FEEDBACK_RATE_WINDOW" envDefault:"3600"` // seconds
+	
+	// Client error reports
+	ClientErrorSampleRate float64 `env:"CLIENT_ERROR_SAMPLE_RATE" envDefault:"1"`  // share of non-fatal reports stored, 0-1
+	ClientErrorRateLimit  int     `env:"CLIENT_ERROR_RATE_LIMIT" envDefault:"30"`  // reports a client may send per window
+	ClientErrorRateWindow int     `env:"CLIENT_ERROR_RATE_WINDOW" envDefault:"60"` // seconds
+	
+	// Public relationship badges. Counters are cached for BadgeCacheTTL by this server
+	// and by browsers and proxies; each address may fetch BadgeRateLimit per window.
+	BadgeCacheTTL   int `env:"BADGE_CACHE_TTL" envDefault:"3600"` // seconds
+	BadgeRateLimit  int `env:"BADGE_RATE_LIMIT" envDefault:"60"`  // requests an address may send per window
+	BadgeRateWindow int `env:"BADGE_RATE_WINDOW" envDefault:"60"` // seconds
+	
+	// Public share links. Each address may open ShareLinkRateLimit links per window,
+	// which bounds how fast link passwords can be guessed.
+	ShareLinkRateLimit  int `env:"SHARE_LINK_RATE_LIMIT" envDefault:"20"`  // requests an address may send per window
+	ShareLinkRateWindow int `env:"SHARE_LINK_RATE_WINDOW" envDefault:"60"` // seconds
+	
+	// Download URLs of photos. Clients choose how long a URL stays valid, between
+	// PhotoURLMinExpiry and PhotoURLMaxExpiry, and get PhotoURLExpiry otherwise.
+	PhotoURLExpiry    int `env:"PHOTO_URL_EXPIRY" envDefault:"300"`      // seconds
+	PhotoURLMinExpiry int `env:"PHOTO_URL_MIN_EXPIRY" envDefault:"60"`   // seconds
+	PhotoURLMaxExpiry int `env:"PHOTO_URL_MAX_EXPIRY" envDefault:"3600"` // seconds
+	
+	// Resumable and presigned uploads. Resumable uploads are sent in chunks of
+	// UPLOAD_CHUNK_SIZE, which S3 requires to be at least 5 MB, presigned ones straight
+	// to the storage within UPLOAD_PRESIGN_EXPIRY. Both must be completed within
+	// UPLOAD_SESSION_TTL. Only the content types in UPLOAD_MAX_SIZES can be uploaded
+	// this way.
+	UploadChunkSize     int      `env:"UPLOAD_CHUNK_SIZE" envDefault:"8"`                                     // MB
+	UploadSessionTTL    int      `env:"UPLOAD_SESSION_TTL" envDefault:"24"`                                   // hours
+	UploadPresignExpiry int      `env:"UPLOAD_PRESIGN_EXPIRY" envDefault:"15"`                                // minutes
+	UploadMaxSizes      []string `env:"UPLOAD_MAX_SIZES" envSeparator:"," envDefault:"video/*=2048,image/*=10"` // contentType=MB entries, type/* matches any subtype
+
+	// Video memories. Uploaded mp4 and mov videos are limited to VIDEO_MAX_SIZE and
+	// VIDEO_MAX_DURATION, and a poster frame is extracted from each with ffmpeg
+	VideoMaxSize     int    `env:"VIDEO_MAX_SIZE" envDefault:"500"`     // MB
+	VideoMaxDuration int    `env:"VIDEO_MAX_DURATION" envDefault:"180"` // seconds
+	FFmpegPath       string `env:"FFMPEG_PATH" envDefault:"ffmpeg"`
+	FFprobePath      string `env:"FFPROBE_PATH" envDefault:"ffprobe"`
+
+	// Content scanning of uploads. When UPLOAD_SCANNER is set, uploaded files are
+	// scanned before they are used; rejected files are moved to
+	// UPLOAD_QUARANTINE_FOLDER, or deleted when it is empty.
+	UploadScanner          string `env:"UPLOAD_SCANNER" envDefault:""` // clamav, http, or empty to disable
+	ClamAVAddr             string `env:"CLAMAV_ADDR" envDefault:"localhost:3310"`
+	UploadScannerURL       string `env:"UPLOAD_SCANNER_URL" envDefault:""`
+	UploadScannerToken     string `env:"UPLOAD_SCANNER_TOKEN" envDefault:""`
+	UploadScanTimeout      int    `env:"UPLOAD_SCAN_TIMEOUT" envDefault:"60"` // seconds
+	UploadQuarantineFolder string `env:"UPLOAD_QUARANTINE_FOLDER" envDefault:"quarantine"`
+	
+	// Partner presence. A user is shown as viewing a photo or event for PresenceTTL
+	// after they last reported it.
+	PresenceTTL int `env:"PRESENCE_TTL" envDefault:"30"` // seconds
+	
+	// Data retention, applied daily. Scheduled runs only report what they would purge
+	// while RETENTION_DRY_RUN is set. A policy with 0 days is disabled.
+	RetentionDryRun                bool `env:"RETENTION_DRY_RUN" envDefault:"true"`
+	RetentionUnverifiedAccountDays int  `env:"RETENTION_UNVERIFIED_ACCOUNT_DAYS" envDefault:"90"`
+	RetentionMatchRequestDays      int  `env:"RETENTION_MATCH_REQUEST_DAYS" envDefault:"30"`
+	RetentionDeletedAccountDays    int  `env:"RETENTION_DELETED_ACCOUNT_DAYS" envDefault:"30"`
+	
+	// Partner approval of destructive actions
+	PendingActionTTL int `env:"PENDING_ACTION_TTL" envDefault:"72"` // hours the partner has to approve
+	
+	// Stored photo verification: each run backfills and verifies this many photos
+	StorageIntegritySampleSize int `env:"STORAGE_INTEGRITY_SAMPLE_SIZE" envDefault:"100"`
+	StorageIntegrityInterval   int `env:"STORAGE_INTEGRITY_INTERVAL" envDefault:"24"` // hours between runs
+	
+	// Stored photo reconciliation: files under photos/ that no photo refers to are
+	// deleted once older than StorageReconcileGracePeriod, which leaves time to create
+	// the photo of an upload. Scheduled runs only report orphans while
+	// STORAGE_RECONCILE_DRY_RUN is set.
+	StorageReconcileInterval    int  `env:"STORAGE_RECONCILE_INTERVAL" envDefault:"24"`     // hours between runs
+	StorageReconcileGracePeriod int  `env:"STORAGE_RECONCILE_GRACE_PERIOD" envDefault:"24"` // hours
+	StorageReconcileDryRun      bool `env:"STORAGE_RECONCILE_DRY_RUN" envDefault:"true"`
+	
+	// Messages can be edited for this long after they were sent
+	MessageEditWindow int `env:"MESSAGE_EDIT_WINDOW" envDefault:"15"` // minutes
+	
+	// Partner invite codes
+	MatchInviteTTL int `env:"MATCH_INVITE_TTL" envDefault:"24"` // hours an invite code stays valid
+	
+	// Place autocomplete, disabled when PlacesProvider is empty
+	PlacesProvider  string `env:"PLACES_PROVIDER" envDefault:""` // nominatim, or empty to disable
+	PlacesURL       string `env:"PLACES_URL" envDefault:"https://nominatim.openstreetmap.org"`
+	PlacesUserAgent string `env:"PLACES_USER_AGENT" envDefault:"EraLove/1.0 (support@eralove.com)"`
+	PlacesCacheTTL  int    `env:"PLACES_CACHE_TTL" envDefault:"86400"` // seconds
+	
+	// Directus, optional mirror of user feedback and source of release notes, copy, daily questions and date ideas
+	DirectusURL                 string `env:"DIRECTUS_URL" envDefault:""`
+	DirectusToken               string `env:"DIRECTUS_TOKEN" envDefault:""`
+	DirectusFeedbackCollection  string `env:"DIRECTUS_FEEDBACK_COLLECTION" envDefault:"feedback"`
+	DirectusChangelogCollection string `env:"DIRECTUS_CHANGELOG_COLLECTION" envDefault:"changelog"`
+	DirectusContentCollection   string `env:"DIRECTUS_CONTENT_COLLECTION" envDefault:"content_blocks"`
+	DirectusQuestionCollection  string `env:"DIRECTUS_QUESTION_COLLECTION" envDefault:"daily_questions"`
+	DirectusDateIdeaCollection  string `env:"DIRECTUS_DATE_IDEA_COLLECTION" envDefault:"date_ideas"`
+	ChangelogCacheTTL           int    `env:"CHANGELOG_CACHE_TTL" envDefault:"300"` // seconds
+	ContentCacheTTL             int    `env:"CONTENT_CACHE_TTL" envDefault:"300"`   // seconds
+	
 	// Email Configuration
 	SMTPHost           string `env:"SMTP_HOST" envDefault:"smtp.gmail.com"`
 	SMTPPort           int    `env:"SMTP_PORT" envDefault:"587"`
@@ -51,11 +283,37 @@ type Config struct {
 	FromName           string `env:"FROM_NAME" envDefault:"EraLove"`
 	EnableEmailVerify  bool   `env:"ENABLE_EMAIL_VERIFY" envDefault:"false"`
 	
+	// Email provider: smtp, sendgrid or ses. In sandbox mode nothing is delivered:
+	// SendGrid only validates messages, SES sends them to its mailbox simulator and
+	// SMTP logs them.
+	EmailProvider      string `env:"EMAIL_PROVIDER" envDefault:"smtp"`
+	EmailSandbox       bool   `env:"EMAIL_SANDBOX" envDefault:"false"`
+	SendGridAPIKey     string `env:"SENDGRID_API_KEY" envDefault:""`
+	SESRegion          string `env:"SES_REGION" envDefault:"us-east-1"`
+	SESAccessKeyID     string `env:"SES_ACCESS_KEY_ID" envDefault:""`
+	SESSecretAccessKey string `env:"SES_SECRET_ACCESS_KEY" envDefault:""`
+	
+	// Email queue: emails are sent in the background and temporary failures retried
+	EmailQueueWorkers int `env:"EMAIL_QUEUE_WORKERS" envDefault:"2"`
+	EmailQueueSize    int `env:"EMAIL_QUEUE_SIZE" envDefault:"1000"`
+	EmailMaxAttempts  int `env:"EMAIL_MAX_ATTEMPTS" envDefault:"5"`
+	EmailRetryDelay   int `env:"EMAIL_RETRY_DELAY" envDefault:"5"` // seconds before the first retry, doubled for each further one
+	
+	// Webhooks: domain events are POSTed to each URL, signed with WEBHOOK_SECRET
+	WebhookURLs        []string `env:"WEBHOOK_URLS" envSeparator:","`
+	WebhookSecret      string   `env:"WEBHOOK_SECRET" envDefault:""`
+	WebhookEvents      []string `env:"WEBHOOK_EVENTS" envSeparator:","` // event types sent, all when empty
+	WebhookWorkers     int      `env:"WEBHOOK_WORKERS" envDefault:"2"`
+	WebhookQueueSize   int      `env:"WEBHOOK_QUEUE_SIZE" envDefault:"1000"`
+	WebhookMaxAttempts int      `env:"WEBHOOK_MAX_ATTEMPTS" envDefault:"5"`
+	WebhookRetryDelay  int      `env:"WEBHOOK_RETRY_DELAY" envDefault:"5"` // seconds before the first retry, doubled for each further one
+	WebhookTimeout     int      `env:"WEBHOOK_TIMEOUT" envDefault:"10"`    // seconds an endpoint has to respond
+	
 	// Frontend URL for email links
 	FrontendURL string `env:"FRONTEND_URL" envDefault:"http://localhost:3000"`
 	
 	// Storage Configuration
-	StorageProvider     string `env:"STORAGE_PROVIDER" envDefault:"local"`        // local, s3
+	StorageProvider     string `env:"STORAGE_PROVIDER" envDefault:"local"`        // local, minio, s3, gcs, azure
 	StorageRegion       string `env:"STORAGE_REGION" envDefault:"us-east-1"`
 	StorageBucket       string `env:"STORAGE_BUCKET" envDefault:"eralove-uploads"`
 	StorageAccessKeyID  string `env:"STORAGE_ACCESS_KEY_ID" envDefault:""`
@@ -63,6 +321,12 @@ type Config struct {
 	StorageEndpoint     string `env:"STORAGE_ENDPOINT" envDefault:""`             // For MinIO or custom S3
 	StorageUseSSL       bool   `env:"STORAGE_USE_SSL" envDefault:"true"`
 	StorageBaseURL      string `env:"STORAGE_BASE_URL" envDefault:"http://localhost:8080"` // For public file access
+
+	// Image CDN. When CDNBaseURL is set, photo and upload URLs point at the CDN, which
+	// fronts the file proxy, and carry the time the file last changed so that caches
+	// can keep versioned thumbnails for CDNCacheMaxAge
+	CDNBaseURL     string `env:"CDN_BASE_URL" envDefault:""`                // e.g. https://cdn.example.com/api/v1/files
+	CDNCacheMaxAge int    `env:"CDN_CACHE_MAX_AGE" envDefault:"31536000"` // seconds
 }
 
 // Load loads configuration from environment variables
@@ -78,35 +342,488 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to parse environment variables: %w", err)
 	}
 
-	// Validate required fields
-	if err := cfg.validate(); err != nil {
+	if err := cfg.loadSecrets(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to load secrets: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("configuration validation failed: %w", err)
 	}
 
 	return cfg, nil
 }
 
-// validate checks if all required configuration is present
-func (c *Config) validate() error {
-	if c.JWTSecret == "your-secret-key" && c.Environment == "production" {
-		return fmt.Errorf("JWT_SECRET must be set in production")
+// Validate checks the configuration so that the server fails to start rather than run
+// with it: every setting must be well-formed, and staging and production deployments
+// must not rely on development defaults or weak secrets
+func (c *Config) Validate() error {
+	switch c.Environment {
+	case EnvironmentDevelopment, EnvironmentTest, EnvironmentStaging, EnvironmentProduction:
+	default:
+		return fmt.Errorf("ENVIRONMENT must be one of development, test, staging, production")
+	}
+
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	if !c.IsDeployed() {
+		return nil
 	}
+	if err := c.validateSecrets(); err != nil {
+		return err
+	}
+	if c.IsProduction() {
+		return c.validateProduction()
+	}
+	return nil
+}
 
+// validate checks if all required configuration is present
+func (c *Config) validate() error {
 	if c.MongoURI == "" {
 		return fmt.Errorf("MONGO_URI is required")
 	}
 
+	if len(c.GRPCServiceTokens) > 0 && c.GRPCPort == c.Port {
+		return fmt.Errorf("GRPC_PORT must differ from PORT")
+	}
+
+	switch c.AuthCookieMode {
+	case AuthCookieModeDisabled, AuthCookieModeOptional, AuthCookieModeAlways:
+	default:
+		return fmt.Errorf("AUTH_COOKIE_MODE must be one of disabled, optional, always")
+	}
+
+	// Forwarded headers can be set by any client, so they are only read from known proxies
+	if c.ProxyHeader != "" && len(c.TrustedProxies) == 0 {
+		return fmt.Errorf("TRUSTED_PROXIES is required when PROXY_HEADER is set")
+	}
+	if len(c.AuthBlockedCountries) > 0 && len(c.TrustedProxies) == 0 {
+		return fmt.Errorf("TRUSTED_PROXIES is required when AUTH_BLOCKED_COUNTRIES is set")
+	}
+
+	if _, err := origins.ParseList(c.CORSOrigins); err != nil {
+		return fmt.Errorf("CORS_ORIGINS: %w", err)
+	}
+
+	switch c.RequestSigningMode {
+	case RequestSigningDisabled:
+	case RequestSigningOptional, RequestSigningRequired:
+		if len(c.RequestSigningKeys) == 0 {
+			return fmt.Errorf("REQUEST_SIGNING_KEYS is required when request signing is enabled")
+		}
+		for _, key := range c.RequestSigningKeys {
+			if parts := strings.SplitN(key, ":", 2); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return fmt.Errorf("REQUEST_SIGNING_KEYS entries must be in the form keyID:secret")
+			}
+		}
+	default:
+		return fmt.Errorf("REQUEST_SIGNING_MODE must be one of disabled, optional, required")
+	}
+
+	if strings.EqualFold(c.AuthCookieSameSite, "None") && !c.AuthCookieSecure {
+		return fmt.Errorf("AUTH_COOKIE_SECURE must be enabled when AUTH_COOKIE_SAME_SITE is None")
+	}
+
+	if c.OIDCIssuer != "" {
+		if issuer, err := url.Parse(c.OIDCIssuer); err != nil || issuer.Scheme == "" || issuer.Host == "" {
+			return fmt.Errorf("OIDC_ISSUER must be an absolute URL")
+		}
+		if len(c.OIDCClients) == 0 {
+			return fmt.Errorf("OIDC_CLIENTS is required when OIDC_ISSUER is set")
+		}
+		for _, client := range c.OIDCClients {
+			if clientID, redirectURIs, ok := strings.Cut(client, "="); !ok || clientID == "" || redirectURIs == "" {
+				return fmt.Errorf("OIDC_CLIENTS entries must be in the form clientID=redirectURI|redirectURI")
+			}
+		}
+	}
+
+	// S3 presigned URLs are valid for at most 7 days
+	if c.PhotoURLMinExpiry < 1 || c.PhotoURLMinExpiry > c.PhotoURLExpiry || c.PhotoURLExpiry > c.PhotoURLMaxExpiry || c.PhotoURLMaxExpiry > 7*24*3600 {
+		return fmt.Errorf("PHOTO_URL_MIN_EXPIRY, PHOTO_URL_EXPIRY and PHOTO_URL_MAX_EXPIRY must be positive, in increasing order and at most 7 days")
+	}
+
+	if c.UploadChunkSize < 5 || c.UploadSessionTTL < 1 || c.UploadPresignExpiry < 1 {
+		return fmt.Errorf("UPLOAD_CHUNK_SIZE must be at least 5, UPLOAD_SESSION_TTL and UPLOAD_PRESIGN_EXPIRY positive")
+	}
+	if c.MaxFileSize < 1 {
+		return fmt.Errorf("MAX_FILE_SIZE must be positive")
+	}
+	for _, contentType := range c.UploadAllowedTypes {
+		if !domain.IsKnownUploadType(contentType) {
+			return fmt.Errorf("UPLOAD_ALLOWED_TYPES contains unsupported type %s", contentType)
+		}
+	}
+	for _, entry := range c.UploadFolderMaxSizes {
+		folder, size, ok := strings.Cut(entry, "=")
+		megabytes, err := strconv.Atoi(strings.TrimSpace(size))
+		if !ok || strings.TrimSpace(folder) == "" || err != nil || megabytes < 1 {
+			return fmt.Errorf("UPLOAD_FOLDER_MAX_SIZES entries must be in the form folder=MB")
+		}
+	}
+	for _, entry := range c.UploadMaxSizes {
+		contentType, size, ok := strings.Cut(entry, "=")
+		megabytes, err := strconv.Atoi(strings.TrimSpace(size))
+		if !ok || !strings.Contains(contentType, "/") || err != nil || megabytes < 1 {
+			return fmt.Errorf("UPLOAD_MAX_SIZES entries must be in the form contentType=MB")
+		}
+		// S3 joins at most 10000 parts
+		if megabytes > c.UploadChunkSize*10000 {
+			return fmt.Errorf("UPLOAD_MAX_SIZES allows %s larger than 10000 chunks of UPLOAD_CHUNK_SIZE", contentType)
+		}
+	}
+
+	switch strings.ToLower(c.UploadScanner) {
+	case "":
+	case "clamav":
+		if c.ClamAVAddr == "" {
+			return fmt.Errorf("CLAMAV_ADDR is required when UPLOAD_SCANNER is clamav")
+		}
+	case "http":
+		if c.UploadScannerURL == "" {
+			return fmt.Errorf("UPLOAD_SCANNER_URL is required when UPLOAD_SCANNER is http")
+		}
+	default:
+		return fmt.Errorf("unsupported UPLOAD_SCANNER: %s", c.UploadScanner)
+	}
+	if c.UploadScanTimeout < 1 {
+		return fmt.Errorf("UPLOAD_SCAN_TIMEOUT must be positive")
+	}
+
+	if c.SecretsRefreshInterval < 1 {
+		return fmt.Errorf("SECRETS_REFRESH_INTERVAL must be positive")
+	}
+
+	if c.HealthCheckTimeout < 1 {
+		return fmt.Errorf("HEALTH_CHECK_TIMEOUT must be positive")
+	}
+
+	switch pii.Mode(c.LogRedaction) {
+	case pii.ModeOff, pii.ModeMask:
+	case pii.ModeHash:
+		if c.LogRedactionKey == "" {
+			return fmt.Errorf("LOG_REDACTION_KEY is required when LOG_REDACTION is hash")
+		}
+	default:
+		return fmt.Errorf("LOG_REDACTION must be one of off, mask, hash")
+	}
+
+	if c.IdempotencyTTL < 1 {
+		return fmt.Errorf("IDEMPOTENCY_TTL must be positive")
+	}
+
+	if c.CompressionLevel < -1 || c.CompressionLevel > 2 {
+		return fmt.Errorf("COMPRESSION_LEVEL must be between -1 and 2")
+	}
+
+	if c.JSONBodyLimit < 1 {
+		return fmt.Errorf("JSON_BODY_LIMIT must be positive")
+	}
+	for _, entry := range c.JSONBodyRouteLimits {
+		route, size, ok := strings.Cut(entry, "=")
+		kilobytes, err := strconv.Atoi(strings.TrimSpace(size))
+		if !ok || !strings.HasPrefix(strings.TrimSpace(route), "/") || err != nil || kilobytes < 1 {
+			return fmt.Errorf("JSON_BODY_ROUTE_LIMITS entries must be in the form /route=KB")
+		}
+	}
+
+	if c.RateLimitRequests < 1 || c.RateLimitWindow < 1 {
+		return fmt.Errorf("RATE_LIMIT_REQUESTS and RATE_LIMIT_WINDOW must be positive")
+	}
+
+	if c.FeedbackRateLimit < 1 || c.FeedbackRateWindow < 1 {
+		return fmt.Errorf("FEEDBACK_RATE_LIMIT and FEEDBACK_RATE_WINDOW must be positive")
+	}
+
+	if c.ClientErrorSampleRate < 0 || c.ClientErrorSampleRate > 1 {
+		return fmt.Errorf("CLIENT_ERROR_SAMPLE_RATE must be between 0 and 1")
+	}
+
+	if c.ClientErrorRateLimit < 1 || c.ClientErrorRateWindow < 1 {
+		return fmt.Errorf("CLIENT_ERROR_RATE_LIMIT and CLIENT_ERROR_RATE_WINDOW must be positive")
+	}
+
+	switch strings.ToLower(c.EmailProvider) {
+	case "smtp":
+	case "sendgrid":
+		if c.SendGridAPIKey == "" {
+			return fmt.Errorf("SENDGRID_API_KEY is required when EMAIL_PROVIDER is sendgrid")
+		}
+	case "ses":
+		if c.SESRegion == "" || c.SESAccessKeyID == "" || c.SESSecretAccessKey == "" {
+			return fmt.Errorf("SES_REGION, SES_ACCESS_KEY_ID and SES_SECRET_ACCESS_KEY are required when EMAIL_PROVIDER is ses")
+		}
+	default:
+		return fmt.Errorf("EMAIL_PROVIDER must be one of smtp, sendgrid, ses")
+	}
+
+	if c.EmailQueueWorkers < 1 || c.EmailQueueSize < 1 || c.EmailMaxAttempts < 1 || c.EmailRetryDelay < 1 {
+		return fmt.Errorf("EMAIL_QUEUE_WORKERS, EMAIL_QUEUE_SIZE, EMAIL_MAX_ATTEMPTS and EMAIL_RETRY_DELAY must be positive")
+	}
+
+	if len(c.WebhookURLs) > 0 {
+		if c.WebhookSecret == "" {
+			return fmt.Errorf("WEBHOOK_SECRET is required when WEBHOOK_URLS is set")
+		}
+		for _, webhookURL := range c.WebhookURLs {
+			if parsed, err := url.Parse(webhookURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				return fmt.Errorf("WEBHOOK_URLS entries must be absolute http or https URLs")
+			}
+		}
+	}
+
+	for _, eventType := range c.WebhookEvents {
+		if !domain.IsValidDomainEventType(domain.DomainEventType(eventType)) {
+			return fmt.Errorf("WEBHOOK_EVENTS contains unknown event type %q", eventType)
+		}
+	}
+
+	if c.WebhookWorkers < 1 || c.WebhookQueueSize < 1 || c.WebhookMaxAttempts < 1 || c.WebhookRetryDelay < 1 || c.WebhookTimeout < 1 {
+		return fmt.Errorf("WEBHOOK_WORKERS, WEBHOOK_QUEUE_SIZE, WEBHOOK_MAX_ATTEMPTS, WEBHOOK_RETRY_DELAY and WEBHOOK_TIMEOUT must be positive")
+	}
+
+	if c.BadgeCacheTTL < 1 || c.BadgeRateLimit < 1 || c.BadgeRateWindow < 1 {
+		return fmt.Errorf("BADGE_CACHE_TTL, BADGE_RATE_LIMIT and BADGE_RATE_WINDOW must be positive")
+	}
+
+	if c.ShareLinkRateLimit < 1 || c.ShareLinkRateWindow < 1 {
+		return fmt.Errorf("SHARE_LINK_RATE_LIMIT and SHARE_LINK_RATE_WINDOW must be positive")
+	}
+
+	if c.PresenceTTL < 2 {
+		return fmt.Errorf("PRESENCE_TTL must be at least 2 seconds")
+	}
+
+	if c.RetentionUnverifiedAccountDays < 0 || c.RetentionMatchRequestDays < 0 || c.RetentionDeletedAccountDays < 0 {
+		return fmt.Errorf("RETENTION_UNVERIFIED_ACCOUNT_DAYS, RETENTION_MATCH_REQUEST_DAYS and RETENTION_DELETED_ACCOUNT_DAYS must not be negative")
+	}
+
+	if c.PendingActionTTL < 1 {
+		return fmt.Errorf("PENDING_ACTION_TTL must be positive")
+	}
+
+	if c.VideoMaxSize < 1 || c.VideoMaxDuration < 1 {
+		return fmt.Errorf("VIDEO_MAX_SIZE and VIDEO_MAX_DURATION must be positive")
+	}
+	if c.FFmpegPath == "" || c.FFprobePath == "" {
+		return fmt.Errorf("FFMPEG_PATH and FFPROBE_PATH are required")
+	}
+
+	if c.StorageIntegritySampleSize < 1 || c.StorageIntegrityInterval < 1 {
+		return fmt.Errorf("STORAGE_INTEGRITY_SAMPLE_SIZE and STORAGE_INTEGRITY_INTERVAL must be positive")
+	}
+
+	if c.StorageReconcileInterval < 1 || c.StorageReconcileGracePeriod < 1 {
+		return fmt.Errorf("STORAGE_RECONCILE_INTERVAL and STORAGE_RECONCILE_GRACE_PERIOD must be positive")
+	}
+
+	if c.CDNBaseURL != "" {
+		if parsed, err := url.Parse(c.CDNBaseURL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("CDN_BASE_URL must be an http or https URL")
+		}
+	}
+	if c.CDNCacheMaxAge < 1 {
+		return fmt.Errorf("CDN_CACHE_MAX_AGE must be positive")
+	}
+
+	if c.MessageEditWindow < 1 {
+		return fmt.Errorf("MESSAGE_EDIT_WINDOW must be positive")
+	}
+
+	if c.MatchInviteTTL < 1 {
+		return fmt.Errorf("MATCH_INVITE_TTL must be positive")
+	}
+
+	switch strings.ToLower(c.PlacesProvider) {
+	case "":
+	case "nominatim":
+		if c.PlacesURL == "" || c.PlacesUserAgent == "" {
+			return fmt.Errorf("PLACES_URL and PLACES_USER_AGENT are required when PLACES_PROVIDER is nominatim")
+		}
+	default:
+		return fmt.Errorf("unsupported PLACES_PROVIDER: %s", c.PlacesProvider)
+	}
+	if c.PlacesCacheTTL < 0 {
+		return fmt.Errorf("PLACES_CACHE_TTL must not be negative")
+	}
+
+	if c.ChangelogCacheTTL < 0 {
+		return fmt.Errorf("CHANGELOG_CACHE_TTL must not be negative")
+	}
+
+	if c.ContentCacheTTL < 0 {
+		return fmt.Errorf("CONTENT_CACHE_TTL must not be negative")
+	}
+
+	if c.DirectusURL != "" && c.DirectusToken == "" {
+		return fmt.Errorf("DIRECTUS_TOKEN is required when DIRECTUS_URL is set")
+	}
+
+	switch strings.ToLower(c.StorageProvider) {
+	case "local":
+	case "minio", "s3", "gcs", "azure":
+		if c.StorageAccessKeyID == "" || c.StorageSecretKey == "" {
+			return fmt.Errorf("STORAGE_ACCESS_KEY_ID and STORAGE_SECRET_KEY are required when STORAGE_PROVIDER is %s", c.StorageProvider)
+		}
+	default:
+		return fmt.Errorf("STORAGE_PROVIDER must be one of local, minio, s3, gcs, azure")
+	}
+
 	return nil
 }
 
+// validateSecrets checks that the secrets of a deployed environment are strong enough
+// to withstand guessing and are not the placeholders of the examples
+func (c *Config) validateSecrets() error {
+	if err := checkSecret("JWT_SECRET", c.JWTSecret); err != nil {
+		return err
+	}
+	for _, secret := range c.JWTPreviousSecrets {
+		if err := checkSecret("JWT_PREVIOUS_SECRETS", secret); err != nil {
+			return err
+		}
+	}
+	if c.JWTLegacySecret != "" {
+		if err := checkSecret("JWT_LEGACY_SECRET", c.JWTLegacySecret); err != nil {
+			return err
+		}
+	}
+	if len(c.WebhookURLs) > 0 {
+		if err := checkSecret("WEBHOOK_SECRET", c.WebhookSecret); err != nil {
+			return err
+		}
+	}
+	if pii.Mode(c.LogRedaction) == pii.ModeHash {
+		if err := checkSecret("LOG_REDACTION_KEY", c.LogRedactionKey); err != nil {
+			return err
+		}
+	}
+	for _, key := range c.AdminAPIKeys {
+		if err := checkSecret("ADMIN_API_KEYS", key); err != nil {
+			return err
+		}
+	}
+	for _, token := range c.GRPCServiceTokens {
+		if err := checkSecret("GRPC_SERVICE_TOKENS", token); err != nil {
+			return err
+		}
+	}
+	if c.RequestSigningMode != RequestSigningDisabled {
+		for _, key := range c.RequestSigningKeys {
+			_, secret, _ := strings.Cut(key, ":")
+			if err := checkSecret("REQUEST_SIGNING_KEYS", secret); err != nil {
+				return err
+			}
+		}
+	}
+	// Storage keys are issued by the provider, only the development ones are refused
+	if isPlaceholderSecret(c.StorageSecretKey) {
+		return fmt.Errorf("STORAGE_SECRET_KEY must not be a development default")
+	}
+	return nil
+}
+
+// validateProduction checks the settings production requires on top of those of every
+// deployed environment
+func (c *Config) validateProduction() error {
+	if !c.AuthCookieSecure {
+		return fmt.Errorf("AUTH_COOKIE_SECURE must be enabled in production")
+	}
+	if frontendURL, err := url.Parse(c.FrontendURL); err != nil || frontendURL.Scheme != "https" || frontendURL.Host == "" {
+		return fmt.Errorf("FRONTEND_URL must be an https URL in production")
+	}
+	if c.OIDCIssuer != "" && c.OIDCSigningKeyFile == "" {
+		return fmt.Errorf("OIDC_SIGNING_KEY_FILE must be set in production when OIDC_ISSUER is set")
+	}
+	return nil
+}
+
+// minSecretLength is the length below which a secret is refused in deployed environments
+const minSecretLength = 32
+
+// placeholderSecrets are the secrets of the development setups, and
+// placeholderMarkers appear in those of the examples, such as .env.example
+var (
+	placeholderSecrets = []string{"secret", "password", "minioadmin", "minioadmin123"}
+	placeholderMarkers = []string{"your-", "change", "example"}
+)
+
+// checkSecret returns an error naming the setting when a secret is missing, a
+// placeholder, too short, or made of too few different characters
+func checkSecret(name, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s is required", name)
+	}
+	if isPlaceholderSecret(value) {
+		return fmt.Errorf("%s must not be a placeholder", name)
+	}
+	if len(value) < minSecretLength {
+		return fmt.Errorf("%s must be at least %d characters", name, minSecretLength)
+	}
+
+	distinct := make(map[rune]struct{})
+	for _, r := range value {
+		distinct[r] = struct{}{}
+	}
+	if len(distinct) < minSecretLength/4 {
+		return fmt.Errorf("%s must be random, not a repeated pattern", name)
+	}
+	return nil
+}
+
+// isPlaceholderSecret reports whether a secret is a placeholder
+func isPlaceholderSecret(value string) bool {
+	for _, placeholder := range placeholderSecrets {
+		if strings.EqualFold(value, placeholder) {
+			return true
+		}
+	}
+	lower := strings.ToLower(value)
+	for _, marker := range placeholderMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsDevelopment returns true if the environment is development
 func (c *Config) IsDevelopment() bool {
-	return c.Environment == "development"
+	return c.Environment == EnvironmentDevelopment
 }
 
 // IsProduction returns true if the environment is production
 func (c *Config) IsProduction() bool {
-	return c.Environment == "production"
+	return c.Environment == EnvironmentProduction
+}
+
+// IsDeployed returns true if the environment is a deployment rather than a developer's
+// machine: staging or production
+func (c *Config) IsDeployed() bool {
+	return c.Environment == EnvironmentStaging || c.Environment == EnvironmentProduction
+}
+
+// BodyLimit returns the largest request body accepted, in bytes. It fits an upload
+// chunk or a 10 MB image with room for the multipart encoding.
+func (c *Config) BodyLimit() int {
+	limit := 10
+	if c.UploadChunkSize > limit {
+		limit = c.UploadChunkSize
+	}
+	return (limit + 1) * 1024 * 1024
+}
+
+// JSONBodyRouteLimitBytes returns the largest JSON body accepted by route prefix, in bytes
+func (c *Config) JSONBodyRouteLimitBytes() map[string]int {
+	limits := make(map[string]int, len(c.JSONBodyRouteLimits))
+	for _, entry := range c.JSONBodyRouteLimits {
+		route, size, _ := strings.Cut(entry, "=")
+		kilobytes, _ := strconv.Atoi(strings.TrimSpace(size))
+		limits[strings.TrimSpace(route)] = kilobytes * 1024
+	}
+	return limits
 }
 
 // GetPort returns the port with colon prefix
@@ -114,6 +831,29 @@ func (c *Config) GetPort() string {
 	return ":" + c.Port
 }
 
+// GetGRPCPort returns the port of the gRPC API with colon prefix
+func (c *Config) GetGRPCPort() string {
+	return ":" + c.GRPCPort
+}
+
+// StorageURLPrefixes returns the prefixes the storage providers put in front of keys
+// to build the public URLs older photos stored instead of keys
+func (c *Config) StorageURLPrefixes() []string {
+	baseURL := strings.TrimRight(c.StorageBaseURL, "/")
+	// Local storage serves files under /files
+	prefixes := []string{baseURL + "/", baseURL + "/files/"}
+
+	scheme := "https"
+	if !c.StorageUseSSL {
+		scheme = "http"
+	}
+	endpoint := c.StorageEndpoint
+	if endpoint == "" {
+		endpoint = "s3." + c.StorageRegion + ".amazonaws.com"
+	}
+	return append(prefixes, scheme+"://"+endpoint+"/"+c.StorageBucket+"/")
+}
+
 // GetRedisDB returns Redis DB as integer
 func (c *Config) GetRedisDB() int {
 	if db, err := strconv.Atoi(os.Getenv("REDIS_DB")); err == nil {
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eralove/eralove-backend/internal/infrastructure/secrets"
+)
+
+// SecretsManager returns the client of the secret manager of SECRETS_PROVIDER, or nil
+// when secrets are only read from the environment
+func (c *Config) SecretsManager() (secrets.Provider, error) {
+	return secrets.New(secrets.Options{
+		Provider:           c.SecretsProvider,
+		VaultAddr:          c.VaultAddr,
+		VaultToken:         c.VaultToken,
+		VaultMount:         c.VaultMount,
+		VaultNamespace:     c.VaultNamespace,
+		AWSRegion:          c.AWSRegion,
+		AWSAccessKeyID:     c.AWSAccessKeyID,
+		AWSSecretAccessKey: c.AWSSecretAccessKey,
+		AWSSessionToken:    c.AWSSessionToken,
+		GCPProject:         c.GCPProject,
+	})
+}
+
+// loadSecrets replaces the settings that reference a secret with its value in the
+// secret manager
+func (c *Config) loadSecrets(ctx context.Context) error {
+	settings := []struct {
+		name   string
+		ref    string
+		target *string
+	}{
+		{"JWT_SECRET", c.JWTSecretRef, &c.JWTSecret},
+		{"SMTP_PASSWORD", c.SMTPPasswordRef, &c.SMTPPassword},
+		{"STORAGE_ACCESS_KEY_ID", c.StorageAccessKeyIDRef, &c.StorageAccessKeyID},
+		{"STORAGE_SECRET_KEY", c.StorageSecretKeyRef, &c.StorageSecretKey},
+	}
+
+	var provider secrets.Provider
+	for _, setting := range settings {
+		if setting.ref == "" {
+			continue
+		}
+		if provider == nil {
+			var err error
+			if provider, err = c.SecretsManager(); err != nil {
+				return err
+			}
+			if provider == nil {
+				return fmt.Errorf("SECRETS_PROVIDER is required when SECRETS_%s is set", setting.name)
+			}
+		}
+
+		value, err := secrets.Resolve(ctx, provider, setting.ref)
+		if err != nil {
+			return fmt.Errorf("%s: %w", setting.name, err)
+		}
+		*setting.target = value
+	}
+	return nil
+}
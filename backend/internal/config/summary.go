@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/eralove/eralove-backend/internal/infrastructure/pii"
+)
+
+// Summary returns the effective value of every setting by environment variable, for
+// the startup log. Secrets are replaced with a marker when set, and the passwords of
+// URLs removed, so the summary tells what is configured without revealing it.
+func (c *Config) Summary() map[string]string {
+	summary := make(map[string]string)
+	value := reflect.ValueOf(c).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		name := value.Type().Field(i).Tag.Get("env")
+		if name == "" {
+			continue
+		}
+		summary[name] = redactSetting(name, settingString(value.Field(i)))
+	}
+	return summary
+}
+
+// settingString formats the value of a setting as it would be written in the environment
+func settingString(field reflect.Value) string {
+	if field.Kind() == reflect.Slice {
+		items := make([]string, field.Len())
+		for i := range items {
+			items[i] = fmt.Sprint(field.Index(i).Interface())
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(field.Interface())
+}
+
+// redactSetting hides the value of a secret setting, and the password of a URL
+func redactSetting(name, value string) string {
+	if value == "" {
+		return value
+	}
+	if isSecretSetting(name) {
+		return pii.Redacted
+	}
+	if strings.Contains(value, "://") {
+		if parsed, err := url.Parse(value); err == nil && parsed.User != nil {
+			if _, hasPassword := parsed.User.Password(); hasPassword {
+				parsed.User = url.UserPassword(parsed.User.Username(), "redacted")
+				return parsed.String()
+			}
+		}
+	}
+	return value
+}
+
+// isSecretSetting reports whether a setting holds a secret, going by its name. The
+// SECRETS_ settings only name secrets in the secret manager.
+func isSecretSetting(name string) bool {
+	if strings.HasPrefix(name, "SECRETS_") {
+		return false
+	}
+	for _, marker := range []string{"SECRET", "PASSWORD", "TOKEN"} {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return strings.HasSuffix(name, "_KEY") || strings.HasSuffix(name, "_KEYS")
+}
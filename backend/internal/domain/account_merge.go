@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Account merge statuses
+const (
+	AccountMergeStatusPending   = "pending"
+	AccountMergeStatusCompleted = "completed"
+)
+
+const (
+	// AccountMergeCodeTTL is how long the code emailed to the merged account is valid
+	AccountMergeCodeTTL = 30 * time.Minute
+	// AccountMergeMaxAttempts is how many wrong codes end a pending merge
+	AccountMergeMaxAttempts = 5
+)
+
+// AccountMerge merges a duplicate account into the account of the user who started
+// it. The merged account is tombstoned and the completed merge kept as audit record.
+type AccountMerge struct {
+	ID              primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	SurvivingUserID primitive.ObjectID  `json:"surviving_user_id" bson:"surviving_user_id"`
+	MergedUserID    primitive.ObjectID  `json:"merged_user_id" bson:"merged_user_id"`
+	MergedEmail     string              `json:"merged_email" bson:"merged_email"`
+	Status          string              `json:"status" bson:"status"`
+	CodeHash        string              `json:"-" bson:"code_hash,omitempty"` // hex SHA-256 of the emailed code
+	Attempts        int                 `json:"-" bson:"attempts"`
+	ExpiresAt       time.Time           `json:"expires_at" bson:"expires_at"`
+	Moved           *AccountMergeCounts `json:"moved,omitempty" bson:"moved,omitempty"`
+	CreatedAt       time.Time           `json:"created_at" bson:"created_at"`
+	CompletedAt     *time.Time          `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+}
+
+// AccountMergeCounts records what a merge moved to the surviving account
+type AccountMergeCounts struct {
+	Photos     int64 `json:"photos" bson:"photos"`
+	Events     int64 `json:"events" bson:"events"`
+	Messages   int64 `json:"messages" bson:"messages"`
+	MatchMoved bool  `json:"match_moved" bson:"match_moved"`
+}
+
+// StartAccountMergeRequest proves both identities: the password of the signed-in
+// account and the credentials of the account to merge into it
+type StartAccountMergeRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	Email           string `json:"email" validate:"required,email"`
+	Password        string `json:"password" validate:"required"`
+}
+
+// ConfirmAccountMergeRequest carries the code emailed to the account being merged
+type ConfirmAccountMergeRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+// AccountMergeRepository defines the interface for account merge data access
+type AccountMergeRepository interface {
+	// Replace deletes the surviving user's pending merges and stores the new one
+	Replace(ctx context.Context, merge *AccountMerge) error
+	GetPending(ctx context.Context, survivingUserID primitive.ObjectID, now time.Time) (*AccountMerge, error)
+	// IncrementAttempts counts a wrong code and returns the attempts made so far
+	IncrementAttempts(ctx context.Context, id primitive.ObjectID) (int, error)
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	Complete(ctx context.Context, id primitive.ObjectID, moved *AccountMergeCounts, completedAt time.Time) error
+}
+
+// AccountMergeService defines the interface for merging duplicate accounts
+type AccountMergeService interface {
+	StartMerge(ctx context.Context, userID primitive.ObjectID, req *StartAccountMergeRequest) (*AccountMerge, error)
+	ConfirmMerge(ctx context.Context, userID primitive.ObjectID, req *ConfirmAccountMergeRequest) (*AccountMerge, error)
+}
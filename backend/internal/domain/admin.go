@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UserSearchStatus narrows a user search to active or soft-deleted accounts
+type UserSearchStatus string
+
+const (
+	UserSearchStatusActive  UserSearchStatus = "active"
+	UserSearchStatusDeleted UserSearchStatus = "deleted"
+)
+
+// UserSearchFilter narrows the admin user search. Empty fields match everything.
+type UserSearchFilter struct {
+	Query  string // part of the name or email, case insensitive
+	Status UserSearchStatus
+}
+
+// AdminUserResponse represents a user as support staff see it, including the
+// account state hidden from the user themselves
+type AdminUserResponse struct {
+	*UserResponse
+	DeletedAt           *time.Time          `json:"deleted_at,omitempty"`
+	MergedInto          *primitive.ObjectID `json:"merged_into,omitempty"`
+	PasswordResetExpiry *time.Time          `json:"password_reset_expiry,omitempty"` // set while a reset link is outstanding
+	VerificationExpiry  *time.Time          `json:"verification_expiry,omitempty"`   // set while a verification link is outstanding
+}
+
+// ToAdminResponse converts User to AdminUserResponse
+func (u *User) ToAdminResponse() *AdminUserResponse {
+	return &AdminUserResponse{
+		UserResponse:        u.ToResponse(),
+		DeletedAt:           u.DeletedAt,
+		MergedInto:          u.MergedInto,
+		PasswordResetExpiry: u.PasswordResetExpiry,
+		VerificationExpiry:  u.EmailVerificationExpiry,
+	}
+}
+
+// AdminUserListResponse represents a page of users, newest first
+type AdminUserListResponse struct {
+	Users      []*AdminUserResponse `json:"users"`
+	Limit      int                  `json:"limit"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+}
+
+// AdminMatchStateResponse describes a user's match for support staff: the partner,
+// whether both partners point at each other and the match requests still pending
+type AdminMatchStateResponse struct {
+	User             *AdminUserResponse      `json:"user"`
+	Partner          *AdminUserResponse      `json:"partner,omitempty"`
+	MatchCode        string                  `json:"match_code,omitempty"`
+	MatchedAt        *time.Time              `json:"matched_at,omitempty"`
+	AnniversaryDate  *Date                   `json:"anniversary_date,omitempty"`
+	Unmatch          *PendingUnmatch         `json:"unmatch,omitempty"`
+	Consistent       bool                    `json:"consistent"` // false when the partner is missing or linked elsewhere
+	SentRequests     []*MatchRequestResponse `json:"sent_requests"`
+	ReceivedRequests []*MatchRequestResponse `json:"received_requests"`
+}
+
+// AdminService defines the interface for the support staff operations on accounts
+type AdminService interface {
+	SearchUsers(ctx context.Context, filter UserSearchFilter, cursor *Cursor, limit int) (*AdminUserListResponse, error)
+	GetUser(ctx context.Context, userID primitive.ObjectID) (*AdminUserResponse, error)
+	RestoreUser(ctx context.Context, userID primitive.ObjectID) (*AdminUserResponse, error)
+	// ForcePasswordReset clears the user's password, signs out every session and
+	// emails a reset link
+	ForcePasswordReset(ctx context.Context, userID primitive.ObjectID) error
+	ResendVerificationEmail(ctx context.Context, userID primitive.ObjectID) error
+	GetMatchState(ctx context.Context, userID primitive.ObjectID) (*AdminMatchStateResponse, error)
+	// SetRoles replaces the user's roles, which reach access tokens from the next
+	// login or token refresh
+	SetRoles(ctx context.Context, userID primitive.ObjectID, req *SetRolesRequest) (*AdminUserResponse, error)
+}
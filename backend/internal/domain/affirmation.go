@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AffirmationStatus represents the delivery status of an affirmation
+type AffirmationStatus string
+
+const (
+	AffirmationStatusQueued    AffirmationStatus = "queued"
+	AffirmationStatusDelivered AffirmationStatus = "delivered"
+)
+
+// AffirmationBox selects which side of the affirmation library to list
+type AffirmationBox string
+
+const (
+	AffirmationBoxReceived AffirmationBox = "received"
+	AffirmationBoxSent     AffirmationBox = "sent"
+)
+
+// Affirmation represents a short recorded audio message from one partner to the other,
+// delivered on a random morning
+type Affirmation struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	MatchCode       string             `json:"match_code" bson:"match_code"`
+	CreatedBy       primitive.ObjectID `json:"created_by" bson:"created_by"`
+	RecipientID     primitive.ObjectID `json:"recipient_id" bson:"recipient_id"`
+	Title           string             `json:"title" bson:"title"`
+	AudioKey        string             `json:"audio_key" bson:"audio_key"`
+	ContentType     string             `json:"content_type" bson:"content_type"`
+	Size            int64              `json:"size" bson:"size"`
+	DurationSeconds int                `json:"duration_seconds" bson:"duration_seconds"`
+	Status          AffirmationStatus  `json:"status" bson:"status"`
+	ScheduledFor    time.Time          `json:"scheduled_for" bson:"scheduled_for"`
+	DeliveredAt     *time.Time         `json:"delivered_at,omitempty" bson:"delivered_at,omitempty"`
+	PlayCount       int                `json:"play_count" bson:"play_count"`
+	LastPlayedAt    *time.Time         `json:"last_played_at,omitempty" bson:"last_played_at,omitempty"`
+	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt       time.Time          `json:"updated_at" bson:"updated_at"`
+	DeletedAt       *time.Time         `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+}
+
+// CreateAffirmationRequest represents the request to upload an affirmation
+type CreateAffirmationRequest struct {
+	Title           string    `json:"title" validate:"required,min=1,max=100"`
+	DurationSeconds int       `json:"duration_seconds" validate:"min=0,max=120"`
+	File            io.Reader `json:"-"`
+	Filename        string    `json:"-"`
+	ContentType     string    `json:"-"`
+	Size            int64     `json:"-"`
+}
+
+// AffirmationResponse represents the API response for an affirmation
+type AffirmationResponse struct {
+	ID              string            `json:"id"`
+	CreatedBy       string            `json:"created_by"`
+	RecipientID     string            `json:"recipient_id"`
+	Title           string            `json:"title"`
+	AudioURL        string            `json:"audio_url,omitempty"`
+	ContentType     string            `json:"content_type"`
+	DurationSeconds int               `json:"duration_seconds"`
+	Status          AffirmationStatus `json:"status"`
+	ScheduledFor    *time.Time        `json:"scheduled_for,omitempty"`
+	DeliveredAt     *time.Time        `json:"delivered_at,omitempty"`
+	PlayCount       int               `json:"play_count"`
+	LastPlayedAt    *time.Time        `json:"last_played_at,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+// ToResponse converts Affirmation to AffirmationResponse.
+// The scheduled delivery time is only shown to the sender so the surprise is kept.
+func (a *Affirmation) ToResponse(viewerID primitive.ObjectID) *AffirmationResponse {
+	response := &AffirmationResponse{
+		ID:              a.ID.Hex(),
+		CreatedBy:       a.CreatedBy.Hex(),
+		RecipientID:     a.RecipientID.Hex(),
+		Title:           a.Title,
+		ContentType:     a.ContentType,
+		DurationSeconds: a.DurationSeconds,
+		Status:          a.Status,
+		DeliveredAt:     a.DeliveredAt,
+		PlayCount:       a.PlayCount,
+		LastPlayedAt:    a.LastPlayedAt,
+		CreatedAt:       a.CreatedAt,
+	}
+
+	if a.CreatedBy == viewerID {
+		scheduledFor := a.ScheduledFor
+		response.ScheduledFor = &scheduledFor
+	}
+
+	return response
+}
+
+// AffirmationListResponse represents a list of affirmations response
+type AffirmationListResponse struct {
+	Affirmations []*AffirmationResponse `json:"affirmations"`
+	Total        int64                  `json:"total"`
+	Page         int                    `json:"page"`
+	Limit        int                    `json:"limit"`
+}
+
+// AffirmationRepository defines the interface for affirmation data access
+type AffirmationRepository interface {
+	Create(ctx context.Context, affirmation *Affirmation) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Affirmation, error)
+	GetByRecipient(ctx context.Context, recipientID primitive.ObjectID, limit, offset int) ([]*Affirmation, error)
+	CountByRecipient(ctx context.Context, recipientID primitive.ObjectID) (int64, error)
+	GetByCreator(ctx context.Context, creatorID primitive.ObjectID, limit, offset int) ([]*Affirmation, error)
+	CountByCreator(ctx context.Context, creatorID primitive.ObjectID) (int64, error)
+	GetDueForDelivery(ctx context.Context, before time.Time) ([]*Affirmation, error)
+	MarkDelivered(ctx context.Context, id primitive.ObjectID, deliveredAt time.Time) error
+	RecordPlay(ctx context.Context, id primitive.ObjectID, playedAt time.Time) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// AffirmationService defines the interface for affirmation business logic
+type AffirmationService interface {
+	CreateAffirmation(ctx context.Context, userID primitive.ObjectID, req *CreateAffirmationRequest) (*AffirmationResponse, error)
+	GetAffirmation(ctx context.Context, affirmationID, userID primitive.ObjectID) (*AffirmationResponse, error)
+	GetLibrary(ctx context.Context, userID primitive.ObjectID, box AffirmationBox, page, limit int) (*AffirmationListResponse, error)
+	RecordPlay(ctx context.Context, affirmationID, userID primitive.ObjectID) (*AffirmationResponse, error)
+	DeleteAffirmation(ctx context.Context, affirmationID, userID primitive.ObjectID) error
+	DeliverDue(ctx context.Context) error
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Album represents a collection of a couple's photos
+type Album struct {
+	ID           primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	MatchCode    string              `json:"match_code" bson:"match_code" validate:"required"`
+	CreatedBy    primitive.ObjectID  `json:"created_by" bson:"created_by" validate:"required"`
+	Name         string              `json:"name" bson:"name" validate:"required,min=1,max=100"`
+	Description  string              `json:"description,omitempty" bson:"description,omitempty"`
+	CoverPhotoID *primitive.ObjectID `json:"cover_photo_id,omitempty" bson:"cover_photo_id,omitempty"`
+	Position     int                 `json:"position" bson:"position"`
+	CreatedAt    time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at" bson:"updated_at"`
+	DeletedAt    *time.Time          `json:"-" bson:"deleted_at,omitempty"`
+}
+
+// CreateAlbumRequest represents the request to create a new album
+type CreateAlbumRequest struct {
+	Name        string `json:"name" validate:"required,min=1,max=100"`
+	Description string `json:"description,omitempty" validate:"omitempty,max=500"`
+}
+
+// UpdateAlbumRequest represents the request to rename or describe an album
+type UpdateAlbumRequest struct {
+	Name        string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Description string `json:"description,omitempty" validate:"omitempty,max=500"`
+}
+
+// ReorderAlbumsRequest represents the request to reorder a couple's albums
+type ReorderAlbumsRequest struct {
+	AlbumIDs []string `json:"album_ids" validate:"required,min=1,dive,required"`
+}
+
+// SetAlbumCoverRequest represents the request to set an album's cover photo
+type SetAlbumCoverRequest struct {
+	PhotoID string `json:"photo_id" validate:"required"`
+}
+
+// AlbumPhotosRequest represents the request to add photos to an album
+type AlbumPhotosRequest struct {
+	PhotoIDs []string `json:"photo_ids" validate:"required,min=1,max=100,dive,required"`
+}
+
+// AlbumResponse represents the API response for an album
+type AlbumResponse struct {
+	ID            string    `json:"id"`
+	MatchCode     string    `json:"match_code"`
+	CreatedBy     string    `json:"created_by"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description,omitempty"`
+	CoverPhotoID  string    `json:"cover_photo_id,omitempty"`
+	CoverImageURL string    `json:"cover_image_url,omitempty"`
+	Position      int       `json:"position"`
+	PhotoCount    int64     `json:"photo_count"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ToResponse converts Album to AlbumResponse
+func (a *Album) ToResponse() *AlbumResponse {
+	response := &AlbumResponse{
+		ID:          a.ID.Hex(),
+		MatchCode:   a.MatchCode,
+		CreatedBy:   a.CreatedBy.Hex(),
+		Name:        a.Name,
+		Description: a.Description,
+		Position:    a.Position,
+		CreatedAt:   a.CreatedAt,
+		UpdatedAt:   a.UpdatedAt,
+	}
+	if a.CoverPhotoID != nil {
+		response.CoverPhotoID = a.CoverPhotoID.Hex()
+	}
+	return response
+}
+
+// AlbumListResponse represents a list of albums response
+type AlbumListResponse struct {
+	Albums []*AlbumResponse `json:"albums"`
+	Total  int              `json:"total"`
+}
+
+// AlbumRepository defines the interface for album data access
+type AlbumRepository interface {
+	Create(ctx context.Context, album *Album) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Album, error)
+	GetByMatchCode(ctx context.Context, matchCode string) ([]*Album, error)
+	CountByMatchCode(ctx context.Context, matchCode string) (int64, error)
+	Update(ctx context.Context, id primitive.ObjectID, album *Album) error
+	UpdatePositions(ctx context.Context, matchCode string, albumIDs []primitive.ObjectID) error
+	ClearCover(ctx context.Context, id primitive.ObjectID) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// AlbumService defines the interface for album business logic
+type AlbumService interface {
+	CreateAlbum(ctx context.Context, userID primitive.ObjectID, req *CreateAlbumRequest) (*AlbumResponse, error)
+	GetAlbum(ctx context.Context, albumID, userID primitive.ObjectID) (*AlbumResponse, error)
+	GetCoupleAlbums(ctx context.Context, userID primitive.ObjectID) ([]*AlbumResponse, error)
+	UpdateAlbum(ctx context.Context, albumID, userID primitive.ObjectID, req *UpdateAlbumRequest) (*AlbumResponse, error)
+	// DeleteAlbum returns the pending action holding the deletion when it needs the partner's approval
+	DeleteAlbum(ctx context.Context, albumID, userID primitive.ObjectID) (*PendingActionResponse, error)
+	ReorderAlbums(ctx context.Context, userID primitive.ObjectID, req *ReorderAlbumsRequest) ([]*AlbumResponse, error)
+	SetCoverPhoto(ctx context.Context, albumID, userID primitive.ObjectID, req *SetAlbumCoverRequest) (*AlbumResponse, error)
+	GetAlbumPhotos(ctx context.Context, albumID, userID primitive.ObjectID, page, limit int) ([]*PhotoResponse, int64, error)
+	AddPhotos(ctx context.Context, albumID, userID primitive.ObjectID, req *AlbumPhotosRequest) (*AlbumResponse, error)
+	RemovePhoto(ctx context.Context, albumID, photoID, userID primitive.ObjectID) error
+}
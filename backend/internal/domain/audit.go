@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditAction is a security-sensitive action recorded in the audit log
+type AuditAction string
+
+const (
+	AuditLogin                  AuditAction = "login"
+	AuditLoginFailed            AuditAction = "login_failed"
+	AuditLogout                 AuditAction = "logout"
+	AuditRefreshTokenReused     AuditAction = "refresh_token_reused"
+	AuditPasswordChanged        AuditAction = "password_changed"
+	AuditPasswordResetRequested AuditAction = "password_reset_requested"
+	AuditPasswordReset          AuditAction = "password_reset"
+	AuditUnmatchRequested       AuditAction = "unmatch_requested"
+	AuditUnmatchCancelled       AuditAction = "unmatch_cancelled"
+	AuditAccountDeleted         AuditAction = "account_deleted"
+	AuditAccountRestored        AuditAction = "account_restored"
+	AuditPasswordResetForced    AuditAction = "password_reset_forced"
+	AuditVerificationResent     AuditAction = "verification_email_resent"
+	AuditRolesChanged           AuditAction = "roles_changed"
+)
+
+// AuditActorType tells who performed an audited action
+type AuditActorType string
+
+const (
+	AuditActorUser    AuditActorType = "user"    // the account owner
+	AuditActorSupport AuditActorType = "support" // an admin, by account or API key
+	AuditActorSystem  AuditActorType = "system"  // a background job
+)
+
+// AuditLog records a security-sensitive action on an account
+type AuditLog struct {
+	ID        primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	UserID    primitive.ObjectID  `json:"user_id" bson:"user_id"` // the account acted on
+	Action    AuditAction         `json:"action" bson:"action"`
+	ActorType AuditActorType      `json:"actor_type" bson:"actor_type"`
+	ActorID   *primitive.ObjectID `json:"actor_id,omitempty" bson:"actor_id,omitempty"` // unset for API keys and jobs
+	IP        string              `json:"ip,omitempty" bson:"ip,omitempty"`
+	UserAgent string              `json:"user_agent,omitempty" bson:"user_agent,omitempty"`
+	TraceID   string              `json:"trace_id,omitempty" bson:"trace_id,omitempty"`
+	Details   map[string]string   `json:"details,omitempty" bson:"details,omitempty"`
+	CreatedAt time.Time           `json:"created_at" bson:"created_at"`
+}
+
+// AuditLogResponse represents an audit log entry shown to the account owner. The
+// address and device of support staff are not shown.
+type AuditLogResponse struct {
+	ID        string            `json:"id"`
+	Action    AuditAction       `json:"action"`
+	ActorType AuditActorType    `json:"actor_type"`
+	IP        string            `json:"ip,omitempty"`
+	UserAgent string            `json:"user_agent,omitempty"`
+	Details   map[string]string `json:"details,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// ToResponse converts AuditLog to AuditLogResponse
+func (l *AuditLog) ToResponse() *AuditLogResponse {
+	response := &AuditLogResponse{
+		ID:        l.ID.Hex(),
+		Action:    l.Action,
+		ActorType: l.ActorType,
+		Details:   l.Details,
+		CreatedAt: l.CreatedAt,
+	}
+	if l.ActorType == AuditActorUser {
+		response.IP = l.IP
+		response.UserAgent = l.UserAgent
+	}
+	return response
+}
+
+// AuditLogListResponse represents a page of audit log entries, newest first
+type AuditLogListResponse struct {
+	Entries    []*AuditLogResponse `json:"entries"`
+	Limit      int                 `json:"limit"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// RequestMeta describes the HTTP request an action is performed in, for the audit log
+type RequestMeta struct {
+	IP        string
+	UserAgent string
+	TraceID   string
+	ActorID   *primitive.ObjectID // the authenticated user, if any
+	Support   bool                // authenticated on an admin route
+}
+
+// requestMetaKey is the context key of the RequestMeta of a request
+type requestMetaKey struct{}
+
+// RequestMetaKey is the key the request metadata is stored under in the request's
+// locals, which the request's context exposes
+var RequestMetaKey = requestMetaKey{}
+
+// RequestMetaFrom returns the metadata of the request ctx belongs to, or nil outside
+// of a request
+func RequestMetaFrom(ctx context.Context) *RequestMeta {
+	meta, _ := ctx.Value(RequestMetaKey).(*RequestMeta)
+	return meta
+}
+
+// AuditLogRepository defines the interface for audit log data access
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *AuditLog) error
+	// ListByUser lists the entries of an account, newest first, starting after cursor
+	ListByUser(ctx context.Context, userID primitive.ObjectID, cursor *Cursor, limit int) ([]*AuditLog, error)
+}
+
+// AuditService defines the interface for the audit log
+type AuditService interface {
+	// Record adds an entry for an action on userID, taking the actor, address, user
+	// agent and trace ID from the request ctx belongs to. Failures are only logged,
+	// so that auditing never fails the action itself.
+	Record(ctx context.Context, userID primitive.ObjectID, action AuditAction, details map[string]string)
+	ListForUser(ctx context.Context, userID primitive.ObjectID, cursor *Cursor, limit int) (*AuditLogListResponse, error)
+}
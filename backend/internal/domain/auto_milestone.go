@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AutoMilestoneKind identifies a kind of milestone event generated from a couple's
+// anniversary date
+type AutoMilestoneKind string
+
+const (
+	AutoMilestoneDays    AutoMilestoneKind = "days"    // day counts: 100 days, 1000 days...
+	AutoMilestoneYearly  AutoMilestoneKind = "yearly"  // yearly anniversaries
+	AutoMilestoneMonthly AutoMilestoneKind = "monthly" // monthly anniversaries between the yearly ones
+)
+
+// AutoMilestoneKinds lists every kind of generated milestone event
+var AutoMilestoneKinds = []AutoMilestoneKind{AutoMilestoneDays, AutoMilestoneYearly, AutoMilestoneMonthly}
+
+// AutoMilestoneSetting tells whether one kind of milestone event is generated
+type AutoMilestoneSetting struct {
+	Kind    AutoMilestoneKind `json:"kind" validate:"required,oneof=days yearly monthly"`
+	Enabled bool              `json:"enabled"`
+}
+
+// AutoMilestonesResponse lists which milestone events are generated for the couple
+type AutoMilestonesResponse struct {
+	Milestones []*AutoMilestoneSetting `json:"milestones"`
+}
+
+// UpdateAutoMilestonesRequest enables or disables kinds of generated milestone events.
+// Kinds left out are unchanged.
+type UpdateAutoMilestonesRequest struct {
+	Milestones []*AutoMilestoneSetting `json:"milestones" validate:"required,min=1,dive"`
+}
+
+// AutoMilestoneService generates the system milestone events of couples from their
+// anniversary date. Events are kept generated for a rolling year ahead.
+type AutoMilestoneService interface {
+	// GenerateForCouple creates the couple's missing milestone events of the coming year
+	GenerateForCouple(ctx context.Context, matchCode string, anniversary time.Time) error
+	// RegenerateForCouple replaces the couple's upcoming milestone events after their
+	// anniversary date changed; a nil anniversary only removes them
+	RegenerateForCouple(ctx context.Context, matchCode string, anniversary *time.Time) error
+	GetSettings(ctx context.Context, userID primitive.ObjectID) (*AutoMilestonesResponse, error)
+	UpdateSettings(ctx context.Context, userID primitive.ObjectID, req *UpdateAutoMilestonesRequest) (*AutoMilestonesResponse, error)
+	// ExtendAll keeps every couple's milestone events generated a year ahead. It is run
+	// periodically by the scheduler.
+	ExtendAll(ctx context.Context) error
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CoupleBadge is a couple's opt-in public badge showing how long they have been
+// together, for embedding in blogs. Anyone holding the slug can read the counters, so
+// a couple has at most one badge and either partner can rotate or revoke its slug.
+type CoupleBadge struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	MatchCode string             `json:"-" bson:"match_code"`
+	Slug      string             `json:"slug" bson:"slug"`
+	CreatedBy primitive.ObjectID `json:"created_by" bson:"created_by"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// CoupleBadgeResponse represents the API response for a couple's badge
+type CoupleBadgeResponse struct {
+	Slug      string    `json:"slug"`
+	JSONURL   string    `json:"json_url"`
+	SVGURL    string    `json:"svg_url"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BadgeCounters are the relationship counters shown on a public badge. They are
+// computed from the anniversary date, which is not exposed itself.
+type BadgeCounters struct {
+	DaysTogether         int       `json:"days_together"`
+	YearsTogether        int       `json:"years_together"`
+	DaysUntilAnniversary int       `json:"days_until_anniversary"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// CoupleBadgeRepository defines the interface for couple badge data access
+type CoupleBadgeRepository interface {
+	GetByMatchCode(ctx context.Context, matchCode string) (*CoupleBadge, error)
+	GetBySlug(ctx context.Context, slug string) (*CoupleBadge, error)
+	// Replace stores badge as the couple's only badge, replacing any previous slug
+	Replace(ctx context.Context, badge *CoupleBadge) error
+	DeleteByMatchCode(ctx context.Context, matchCode string) error
+}
+
+// CoupleBadgeService defines the interface for public relationship badges
+type CoupleBadgeService interface {
+	GetBadge(ctx context.Context, userID primitive.ObjectID) (*CoupleBadge, error)
+	RotateBadge(ctx context.Context, userID primitive.ObjectID) (*CoupleBadge, error)
+	RevokeBadge(ctx context.Context, userID primitive.ObjectID) error
+	GetCounters(ctx context.Context, slug string) (*BadgeCounters, error)
+}
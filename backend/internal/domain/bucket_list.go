@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// BucketListCategory groups bucket list items
+type BucketListCategory string
+
+const (
+	BucketListCategoryTravel     BucketListCategory = "travel"
+	BucketListCategoryAdventure  BucketListCategory = "adventure"
+	BucketListCategoryFood       BucketListCategory = "food"
+	BucketListCategoryExperience BucketListCategory = "experience"
+	BucketListCategoryHome       BucketListCategory = "home"
+	BucketListCategoryLearning   BucketListCategory = "learning"
+	BucketListCategoryOther      BucketListCategory = "other"
+)
+
+// BucketListCategories lists every bucket list category
+var BucketListCategories = []BucketListCategory{
+	BucketListCategoryTravel,
+	BucketListCategoryAdventure,
+	BucketListCategoryFood,
+	BucketListCategoryExperience,
+	BucketListCategoryHome,
+	BucketListCategoryLearning,
+	BucketListCategoryOther,
+}
+
+// BucketListStatus filters bucket list items by completion
+type BucketListStatus string
+
+const (
+	BucketListStatusOpen      BucketListStatus = "open"
+	BucketListStatusCompleted BucketListStatus = "completed"
+)
+
+// MaxBucketListPhotos is the maximum number of photos attached to a bucket list item
+const MaxBucketListPhotos = 20
+
+// BucketListItem is something a couple wants to do together. Either partner can
+// tick it off; completing an item records it as a milestone event.
+type BucketListItem struct {
+	ID          primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	MatchCode   string               `json:"match_code" bson:"match_code"`
+	CreatedBy   primitive.ObjectID   `json:"created_by" bson:"created_by"`
+	Title       string               `json:"title" bson:"title"`
+	Description string               `json:"description,omitempty" bson:"description,omitempty"`
+	Category    BucketListCategory   `json:"category" bson:"category"`
+	TargetDate  *time.Time           `json:"target_date,omitempty" bson:"target_date,omitempty"`
+	PhotoIDs    []primitive.ObjectID `json:"photo_ids" bson:"photo_ids"`
+	CompletedAt *time.Time           `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+	CompletedBy *primitive.ObjectID  `json:"completed_by,omitempty" bson:"completed_by,omitempty"`
+	EventID     *primitive.ObjectID  `json:"event_id,omitempty" bson:"event_id,omitempty"`
+	EventIsOwn  bool                 `json:"-" bson:"event_is_own,omitempty"` // the event was created on completion
+	CreatedAt   time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at" bson:"updated_at"`
+	DeletedAt   *time.Time           `json:"-" bson:"deleted_at,omitempty"`
+}
+
+// IsCompleted reports whether the item has been ticked off
+func (b *BucketListItem) IsCompleted() bool {
+	return b.CompletedAt != nil
+}
+
+// CreateBucketListItemRequest represents the request to add a bucket list item
+type CreateBucketListItemRequest struct {
+	Title       string             `json:"title" validate:"required,min=1,max=200"`
+	Description string             `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Category    BucketListCategory `json:"category" validate:"required,oneof=travel adventure food experience home learning other"`
+	TargetDate  *Date              `json:"target_date,omitempty"`
+	PhotoIDs    []string           `json:"photo_ids,omitempty" validate:"omitempty,max=20,dive,required"`
+}
+
+// UpdateBucketListItemRequest represents the request to update a bucket list item.
+// PhotoIDs replaces the attached photos when present.
+type UpdateBucketListItemRequest struct {
+	Title       string             `json:"title,omitempty" validate:"omitempty,min=1,max=200"`
+	Description string             `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Category    BucketListCategory `json:"category,omitempty" validate:"omitempty,oneof=travel adventure food experience home learning other"`
+	TargetDate  *Date              `json:"target_date,omitempty"`
+	PhotoIDs    []string           `json:"photo_ids,omitempty" validate:"omitempty,max=20,dive,required"`
+}
+
+// CompleteBucketListItemRequest represents the request to tick off a bucket list item.
+// The item is linked to EventID when given; otherwise a milestone event is created.
+type CompleteBucketListItemRequest struct {
+	Date    *Date  `json:"date,omitempty"` // defaults to today
+	EventID string `json:"event_id,omitempty"`
+}
+
+// BucketListItemResponse represents the API response for a bucket list item
+type BucketListItemResponse struct {
+	ID          string             `json:"id"`
+	MatchCode   string             `json:"match_code"`
+	CreatedBy   string             `json:"created_by"`
+	Title       string             `json:"title"`
+	Description string             `json:"description,omitempty"`
+	Category    BucketListCategory `json:"category"`
+	TargetDate  *Date              `json:"target_date,omitempty"`
+	PhotoIDs    []string           `json:"photo_ids"`
+	IsCompleted bool               `json:"is_completed"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+	CompletedBy string             `json:"completed_by,omitempty"`
+	EventID     string             `json:"event_id,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// ToResponse converts BucketListItem to BucketListItemResponse
+func (b *BucketListItem) ToResponse() *BucketListItemResponse {
+	photoIDs := make([]string, len(b.PhotoIDs))
+	for i, id := range b.PhotoIDs {
+		photoIDs[i] = id.Hex()
+	}
+
+	response := &BucketListItemResponse{
+		ID:          b.ID.Hex(),
+		MatchCode:   b.MatchCode,
+		CreatedBy:   b.CreatedBy.Hex(),
+		Title:       b.Title,
+		Description: b.Description,
+		Category:    b.Category,
+		TargetDate:  DateFromTimePtr(b.TargetDate),
+		PhotoIDs:    photoIDs,
+		IsCompleted: b.IsCompleted(),
+		CompletedAt: b.CompletedAt,
+		CreatedAt:   b.CreatedAt,
+		UpdatedAt:   b.UpdatedAt,
+	}
+	if b.CompletedBy != nil {
+		response.CompletedBy = b.CompletedBy.Hex()
+	}
+	if b.EventID != nil {
+		response.EventID = b.EventID.Hex()
+	}
+
+	return response
+}
+
+// BucketListResponse represents a page of the couple bucket list
+type BucketListResponse struct {
+	Items []*BucketListItemResponse `json:"items"`
+	Total int64                     `json:"total"`
+	Page  int                       `json:"page"`
+	Limit int                       `json:"limit"`
+}
+
+// BucketListCategoryStats counts the items of one category
+type BucketListCategoryStats struct {
+	Category  BucketListCategory `json:"category"`
+	Total     int                `json:"total"`
+	Completed int                `json:"completed"`
+}
+
+// BucketListStatsResponse summarizes the couple's progress through their bucket list
+type BucketListStatsResponse struct {
+	Total             int                        `json:"total"`
+	Completed         int                        `json:"completed"`
+	Open              int                        `json:"open"`
+	Overdue           int                        `json:"overdue"`
+	CompletionPercent int                        `json:"completion_percent"`
+	CompletedThisYear int                        `json:"completed_this_year"`
+	ByCategory        []*BucketListCategoryStats `json:"by_category"`
+}
+
+// BucketListRepository defines the interface for bucket list data access
+type BucketListRepository interface {
+	Create(ctx context.Context, item *BucketListItem) error
+	GetByID(ctx context.Context, matchCode string, id primitive.ObjectID) (*BucketListItem, error)
+	GetByMatchCode(ctx context.Context, matchCode string, status BucketListStatus, category BucketListCategory, limit, offset int) ([]*BucketListItem, error)
+	CountByMatchCode(ctx context.Context, matchCode string, status BucketListStatus, category BucketListCategory) (int64, error)
+	Update(ctx context.Context, item *BucketListItem) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// BucketListService defines the interface for bucket list business logic
+type BucketListService interface {
+	CreateItem(ctx context.Context, userID primitive.ObjectID, req *CreateBucketListItemRequest) (*BucketListItemResponse, error)
+	GetItem(ctx context.Context, itemID, userID primitive.ObjectID) (*BucketListItemResponse, error)
+	GetItems(ctx context.Context, userID primitive.ObjectID, status BucketListStatus, category BucketListCategory, page, limit int) (*BucketListResponse, error)
+	UpdateItem(ctx context.Context, itemID, userID primitive.ObjectID, req *UpdateBucketListItemRequest) (*BucketListItemResponse, error)
+	DeleteItem(ctx context.Context, itemID, userID primitive.ObjectID) error
+	CompleteItem(ctx context.Context, itemID, userID primitive.ObjectID, req *CompleteBucketListItemRequest) (*BucketListItemResponse, error)
+	ReopenItem(ctx context.Context, itemID, userID primitive.ObjectID) (*BucketListItemResponse, error)
+	GetStats(ctx context.Context, userID primitive.ObjectID) (*BucketListStatsResponse, error)
+}
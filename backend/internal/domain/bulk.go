@@ -0,0 +1,87 @@
+package domain
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// BulkItemStatus represents the outcome of a bulk operation for a single item
+type BulkItemStatus string
+
+const (
+	BulkItemSucceeded BulkItemStatus = "succeeded"
+	BulkItemNotFound  BulkItemStatus = "not_found"
+	BulkItemInvalidID BulkItemStatus = "invalid_id"
+)
+
+// BulkIDsRequest represents a bulk request on a list of items
+type BulkIDsRequest struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100,dive,required"`
+}
+
+// BulkTagRequest represents the request to add and remove tags on several photos
+type BulkTagRequest struct {
+	IDs    []string `json:"ids" validate:"required,min=1,max=100,dive,required"`
+	Add    []string `json:"add,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+	Remove []string `json:"remove,omitempty" validate:"omitempty,max=20,dive,min=1,max=50"`
+}
+
+// BulkItemResult reports the outcome for one requested item
+type BulkItemResult struct {
+	ID     string         `json:"id"`
+	Status BulkItemStatus `json:"status"`
+}
+
+// BulkResponse represents the per-item results of a bulk operation
+type BulkResponse struct {
+	Results   []BulkItemResult `json:"results"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+}
+
+// ParseBulkIDs returns the valid ObjectIDs of a bulk request without duplicates
+func ParseBulkIDs(ids []string) []primitive.ObjectID {
+	seen := make(map[primitive.ObjectID]bool, len(ids))
+	parsed := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil || seen[objectID] {
+			continue
+		}
+		seen[objectID] = true
+		parsed = append(parsed, objectID)
+	}
+	return parsed
+}
+
+// NewBulkResponse reports the outcome of every requested ID given the IDs the
+// operation was applied to. Requested IDs that were not applied are either not
+// valid ObjectIDs or not found among the couple's items.
+func NewBulkResponse(ids []string, applied []primitive.ObjectID) *BulkResponse {
+	appliedSet := make(map[primitive.ObjectID]bool, len(applied))
+	for _, id := range applied {
+		appliedSet[id] = true
+	}
+
+	response := &BulkResponse{Results: make([]BulkItemResult, 0, len(ids))}
+	reported := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if reported[id] {
+			continue
+		}
+		reported[id] = true
+
+		status := BulkItemNotFound
+		if objectID, err := primitive.ObjectIDFromHex(id); err != nil {
+			status = BulkItemInvalidID
+		} else if appliedSet[objectID] {
+			status = BulkItemSucceeded
+		}
+
+		if status == BulkItemSucceeded {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+		response.Results = append(response.Results, BulkItemResult{ID: id, Status: status})
+	}
+
+	return response
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CalendarFeed is a user's read-only calendar subscription. Anyone holding the token
+// can read the couple's events, so each user has at most one feed and can rotate or
+// revoke it at any time.
+type CalendarFeed struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID         primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Token          string             `json:"token" bson:"token"`
+	LastAccessedAt *time.Time         `json:"last_accessed_at,omitempty" bson:"last_accessed_at,omitempty"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// CalendarFeedResponse represents the API response for a calendar subscription
+type CalendarFeedResponse struct {
+	Token          string     `json:"token"`
+	URL            string     `json:"url"`
+	WebcalURL      string     `json:"webcal_url"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// CalendarFeedRepository defines the interface for calendar feed data access
+type CalendarFeedRepository interface {
+	GetByUserID(ctx context.Context, userID primitive.ObjectID) (*CalendarFeed, error)
+	GetByToken(ctx context.Context, token string) (*CalendarFeed, error)
+	Replace(ctx context.Context, feed *CalendarFeed) error
+	DeleteByUserID(ctx context.Context, userID primitive.ObjectID) error
+	TouchAccessed(ctx context.Context, id primitive.ObjectID, at time.Time) error
+}
+
+// CalendarService defines the interface for calendar export and subscriptions
+type CalendarService interface {
+	ExportEvents(ctx context.Context, userID primitive.ObjectID) ([]byte, error)
+	GetFeed(ctx context.Context, userID primitive.ObjectID) (*CalendarFeed, error)
+	RotateFeed(ctx context.Context, userID primitive.ObjectID) (*CalendarFeed, error)
+	RevokeFeed(ctx context.Context, userID primitive.ObjectID) error
+	RenderFeed(ctx context.Context, token string) ([]byte, error)
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Release is a published app release with its notes, as written by the team in Directus
+type Release struct {
+	Version     string    `json:"version"`
+	Title       string    `json:"title"`
+	Notes       string    `json:"notes"` // Markdown
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// ReleaseSource provides the published releases
+type ReleaseSource interface {
+	ListReleases(ctx context.Context) ([]*Release, error)
+}
+
+// ChangelogSeen records which releases a user has already been shown
+type ChangelogSeen struct {
+	ID           primitive.ObjectID `json:"-" bson:"_id,omitempty"`
+	UserID       primitive.ObjectID `json:"user_id" bson:"user_id"`
+	SeenVersions []string           `json:"seen_versions" bson:"seen_versions"`
+	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// ReleaseResponse represents a release and whether the user has seen it
+type ReleaseResponse struct {
+	*Release
+	Seen bool `json:"seen"`
+}
+
+// ChangelogResponse represents the releases newer than the version the app asked
+// from, newest first. The app shows a what's-new modal while UnseenCount is not zero.
+type ChangelogResponse struct {
+	Releases      []*ReleaseResponse `json:"releases"`
+	LatestVersion string             `json:"latest_version,omitempty"`
+	UnseenCount   int                `json:"unseen_count"`
+}
+
+// MarkChangelogSeenRequest represents the releases the app has shown to the user
+type MarkChangelogSeenRequest struct {
+	Versions []string `json:"versions" validate:"required,min=1,max=50,dive,required,max=50"`
+}
+
+// ChangelogSeenRepository defines the interface for changelog seen tracking data access
+type ChangelogSeenRepository interface {
+	GetByUserID(ctx context.Context, userID primitive.ObjectID) (*ChangelogSeen, error)
+	AddSeenVersions(ctx context.Context, userID primitive.ObjectID, versions []string) error
+}
+
+// ChangelogService defines the interface for the in-app changelog
+type ChangelogService interface {
+	GetChangelog(ctx context.Context, userID primitive.ObjectID, sinceVersion string) (*ChangelogResponse, error)
+	MarkSeen(ctx context.Context, userID primitive.ObjectID, req *MarkChangelogSeenRequest) error
+}
+
+// CompareVersions compares two dotted release versions such as "1.4.2" or "v2.0.0-beta.1"
+// and returns -1, 0 or 1. Missing parts count as zero and a pre-release sorts before
+// the release it precedes. ok is false when either version is malformed.
+func CompareVersions(a, b string) (result int, ok bool) {
+	partsA, preA, okA := parseVersion(a)
+	partsB, preB, okB := parseVersion(b)
+	if !okA || !okB {
+		return 0, false
+	}
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var x, y int
+		if i < len(partsA) {
+			x = partsA[i]
+		}
+		if i < len(partsB) {
+			y = partsB[i]
+		}
+		if x != y {
+			if x < y {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+
+	switch {
+	case preA == preB:
+		return 0, true
+	case preA == "":
+		return 1, true
+	case preB == "":
+		return -1, true
+	case preA < preB:
+		return -1, true
+	default:
+		return 1, true
+	}
+}
+
+// ValidVersion reports whether version can be compared with CompareVersions
+func ValidVersion(version string) bool {
+	_, _, ok := parseVersion(version)
+	return ok
+}
+
+// parseVersion splits a version into its numeric parts and pre-release suffix
+func parseVersion(version string) ([]int, string, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, pre, _ := strings.Cut(version, "-")
+	if version == "" {
+		return nil, "", false
+	}
+
+	fields := strings.Split(version, ".")
+	parts := make([]int, 0, len(fields))
+	for _, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return nil, "", false
+		}
+		parts = append(parts, n)
+	}
+	return parts, pre, true
+}
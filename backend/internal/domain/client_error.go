@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ClientPlatform identifies the app a client error report comes from
+type ClientPlatform string
+
+const (
+	ClientPlatformIOS     ClientPlatform = "ios"
+	ClientPlatformAndroid ClientPlatform = "android"
+	ClientPlatformWeb     ClientPlatform = "web"
+)
+
+// RequestTrace records an API request that failed with a server error, keyed by the trace ID returned
+// to the client in X-Request-ID and in error responses
+type RequestTrace struct {
+	ID        primitive.ObjectID  `json:"-" bson:"_id,omitempty"`
+	TraceID   string              `json:"trace_id" bson:"trace_id"`
+	Method    string              `json:"method" bson:"method"`
+	Path      string              `json:"path" bson:"path"`
+	Status    int                 `json:"status" bson:"status"`
+	Code      ErrorCode           `json:"code" bson:"code"`
+	UserID    *primitive.ObjectID `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	CreatedAt time.Time           `json:"created_at" bson:"created_at"`
+}
+
+// RequestTraceRepository defines the interface for request trace data access
+type RequestTraceRepository interface {
+	Record(ctx context.Context, trace *RequestTrace) error
+	GetByTraceIDs(ctx context.Context, traceIDs []string) (map[string]*RequestTrace, error)
+}
+
+// ClientError is an error or crash reported by a mobile or web client
+type ClientError struct {
+	ID         primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	UserID     *primitive.ObjectID `json:"user_id,omitempty" bson:"user_id,omitempty"`
+	Platform   ClientPlatform      `json:"platform" bson:"platform"`
+	AppVersion string              `json:"app_version,omitempty" bson:"app_version,omitempty"`
+	OSVersion  string              `json:"os_version,omitempty" bson:"os_version,omitempty"`
+	Device     string              `json:"device,omitempty" bson:"device,omitempty"`
+	ErrorType  string              `json:"error_type,omitempty" bson:"error_type,omitempty"`
+	Message    string              `json:"message" bson:"message"`
+	Stack      string              `json:"stack,omitempty" bson:"stack,omitempty"`
+	Screen     string              `json:"screen,omitempty" bson:"screen,omitempty"`
+	TraceID    string              `json:"trace_id,omitempty" bson:"trace_id,omitempty"`
+	Fatal      bool                `json:"fatal" bson:"fatal"`
+	Context    map[string]string   `json:"context,omitempty" bson:"context,omitempty"`
+	IP         string              `json:"ip,omitempty" bson:"ip,omitempty"`
+	UserAgent  string              `json:"user_agent,omitempty" bson:"user_agent,omitempty"`
+	OccurredAt time.Time           `json:"occurred_at" bson:"occurred_at"`
+	CreatedAt  time.Time           `json:"created_at" bson:"created_at"`
+}
+
+// ReportClientErrorRequest represents an error report sent by a client. TraceID is
+// the trace_id of the failed API response that led to the error, if any.
+type ReportClientErrorRequest struct {
+	Platform   ClientPlatform    `json:"platform" validate:"required,oneof=ios android web"`
+	AppVersion string            `json:"app_version,omitempty" validate:"omitempty,max=50"`
+	OSVersion  string            `json:"os_version,omitempty" validate:"omitempty,max=50"`
+	Device     string            `json:"device,omitempty" validate:"omitempty,max=100"`
+	ErrorType  string            `json:"error_type,omitempty" validate:"omitempty,max=200"`
+	Message    string            `json:"message" validate:"required,max=2000"`
+	Stack      string            `json:"stack,omitempty" validate:"omitempty,max=20000"`
+	Screen     string            `json:"screen,omitempty" validate:"omitempty,max=500"`
+	TraceID    string            `json:"trace_id,omitempty" validate:"omitempty,max=100"`
+	Fatal      bool              `json:"fatal"`
+	Context    map[string]string `json:"context,omitempty" validate:"omitempty,max=20,dive,keys,max=64,endkeys,max=1000"`
+	OccurredAt *time.Time        `json:"occurred_at,omitempty"`
+}
+
+// ClientErrorSource describes where a report was received from
+type ClientErrorSource struct {
+	UserID    primitive.ObjectID
+	IP        string
+	UserAgent string
+}
+
+// ClientErrorReceipt is the response to an error report. Accepted is false when the
+// report was dropped by sampling.
+type ClientErrorReceipt struct {
+	Accepted bool   `json:"accepted"`
+	ID       string `json:"id,omitempty"`
+}
+
+// ClientErrorResponse represents a client error for support, with the failed API
+// request it was correlated with through its trace ID
+type ClientErrorResponse struct {
+	*ClientError
+	Request *RequestTrace `json:"request,omitempty"`
+}
+
+// ClientErrorFilter narrows the admin client error listing. Empty fields match everything.
+type ClientErrorFilter struct {
+	Platform   ClientPlatform
+	AppVersion string
+	TraceID    string
+	UserID     *primitive.ObjectID
+	FatalOnly  bool
+}
+
+// ClientErrorListResponse represents a page of client errors, newest first
+type ClientErrorListResponse struct {
+	Errors     []*ClientErrorResponse `json:"errors"`
+	Limit      int                    `json:"limit"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+// ClientErrorRepository defines the interface for client error data access
+type ClientErrorRepository interface {
+	Create(ctx context.Context, clientError *ClientError) error
+	List(ctx context.Context, filter ClientErrorFilter, cursor *Cursor, limit int) ([]*ClientError, error)
+}
+
+// ClientErrorService defines the interface for client error reporting
+type ClientErrorService interface {
+	ReportError(ctx context.Context, source ClientErrorSource, req *ReportClientErrorRequest) (*ClientErrorReceipt, error)
+	ListClientErrors(ctx context.Context, filter ClientErrorFilter, cursor *Cursor, limit int) (*ClientErrorListResponse, error)
+}
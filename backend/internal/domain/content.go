@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+)
+
+// ContentSource provides the editable copy published in the CMS
+type ContentSource interface {
+	// GetContent retrieves the bodies of the content blocks of a locale, keyed by
+	// content key. Keys without a published block are omitted from the result.
+	GetContent(ctx context.Context, keys []string, locale string) (map[string]string, error)
+}
@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MaxCountdowns is the maximum number of custom countdowns a couple can keep
+const MaxCountdowns = 50
+
+// Countdown counts the days to a date the couple is looking forward to, such as a
+// trip or a concert
+type Countdown struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	MatchCode  string             `json:"match_code" bson:"match_code"`
+	CreatedBy  primitive.ObjectID `json:"created_by" bson:"created_by"`
+	Title      string             `json:"title" bson:"title"`
+	Emoji      string             `json:"emoji,omitempty" bson:"emoji,omitempty"`
+	TargetDate time.Time          `json:"target_date" bson:"target_date"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at" bson:"updated_at"`
+	DeletedAt  *time.Time         `json:"-" bson:"deleted_at,omitempty"`
+}
+
+// CreateCountdownRequest represents the request to create a custom countdown
+type CreateCountdownRequest struct {
+	Title      string `json:"title" validate:"required,min=1,max=100"`
+	Emoji      string `json:"emoji,omitempty" validate:"omitempty,max=16"`
+	TargetDate Date   `json:"target_date" validate:"required"`
+}
+
+// UpdateCountdownRequest represents the request to update a custom countdown
+type UpdateCountdownRequest struct {
+	Title      string `json:"title,omitempty" validate:"omitempty,min=1,max=100"`
+	Emoji      string `json:"emoji,omitempty" validate:"omitempty,max=16"`
+	TargetDate *Date  `json:"target_date,omitempty"`
+}
+
+// CountdownResponse represents the API response for a custom countdown
+type CountdownResponse struct {
+	ID         string    `json:"id"`
+	CreatedBy  string    `json:"created_by"`
+	Title      string    `json:"title"`
+	Emoji      string    `json:"emoji,omitempty"`
+	TargetDate Date      `json:"target_date"`
+	DaysUntil  int       `json:"days_until"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ToResponse converts Countdown to CountdownResponse with the days left from today
+func (c *Countdown) ToResponse(today time.Time) *CountdownResponse {
+	return &CountdownResponse{
+		ID:         c.ID.Hex(),
+		CreatedBy:  c.CreatedBy.Hex(),
+		Title:      c.Title,
+		Emoji:      c.Emoji,
+		TargetDate: NewDate(c.TargetDate),
+		DaysUntil:  DaysBetween(today, c.TargetDate),
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+// AnniversaryCountdown counts the days to the couple's next yearly anniversary
+type AnniversaryCountdown struct {
+	Date      Date `json:"date"`
+	DaysUntil int  `json:"days_until"`
+	Years     int  `json:"years"` // the anniversary being counted down to
+}
+
+// EventCountdown counts the days to an upcoming event with a reminder
+type EventCountdown struct {
+	ID         string     `json:"id"`
+	Title      string     `json:"title"`
+	EventType  string     `json:"event_type"`
+	Date       Date       `json:"date"`
+	Time       string     `json:"time,omitempty"`
+	DaysUntil  int        `json:"days_until"`
+	ReminderAt *time.Time `json:"reminder_at,omitempty"`
+}
+
+// CountdownsResponse gathers everything the couple is counting down to, kept small
+// for home-screen widgets
+type CountdownsResponse struct {
+	Today       Date                  `json:"today"`
+	Anniversary *AnniversaryCountdown `json:"anniversary,omitempty"`
+	Events      []*EventCountdown     `json:"events"`
+	Countdowns  []*CountdownResponse  `json:"countdowns"`
+}
+
+// DaysBetween returns the number of whole days from one day to another, negative
+// when to is earlier
+func DaysBetween(from, to time.Time) int {
+	return int(NewDate(to).Sub(NewDate(from).Time).Hours() / 24)
+}
+
+// CountdownRepository defines the interface for countdown data access
+type CountdownRepository interface {
+	Create(ctx context.Context, countdown *Countdown) error
+	GetByID(ctx context.Context, matchCode string, id primitive.ObjectID) (*Countdown, error)
+	// GetUpcoming lists a couple's countdowns whose target date is not before from,
+	// soonest first
+	GetUpcoming(ctx context.Context, matchCode string, from time.Time) ([]*Countdown, error)
+	CountByMatchCode(ctx context.Context, matchCode string) (int64, error)
+	Update(ctx context.Context, countdown *Countdown) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// CountdownService defines the interface for countdown business logic
+type CountdownService interface {
+	GetCountdowns(ctx context.Context, userID primitive.ObjectID) (*CountdownsResponse, error)
+	CreateCountdown(ctx context.Context, userID primitive.ObjectID, req *CreateCountdownRequest) (*CountdownResponse, error)
+	UpdateCountdown(ctx context.Context, countdownID, userID primitive.ObjectID, req *UpdateCountdownRequest) (*CountdownResponse, error)
+	DeleteCountdown(ctx context.Context, countdownID, userID primitive.ObjectID) error
+}
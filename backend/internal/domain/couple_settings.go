@@ -0,0 +1,276 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CoupleSettings holds preferences shared by both partners of a couple
+type CoupleSettings struct {
+	ID                     primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	MatchCode              string              `json:"match_code" bson:"match_code"`
+	WatermarkEnabled       bool                `json:"watermark_enabled" bson:"watermark_enabled"`
+	WatermarkText          string              `json:"watermark_text,omitempty" bson:"watermark_text,omitempty"`
+	RequirePartnerApproval bool                `json:"require_partner_approval" bson:"require_partner_approval"` // album deletions and conversation exports wait for the other partner's approval
+	EncryptionKeys         []*CoupleDataKey    `json:"-" bson:"encryption_keys,omitempty"`                       // data key versions, oldest first
+	Locale                 string              `json:"locale,omitempty" bson:"locale,omitempty"`
+	DateFormat             string              `json:"date_format,omitempty" bson:"date_format,omitempty"`
+	FirstDayOfWeek         string              `json:"first_day_of_week,omitempty" bson:"first_day_of_week,omitempty"`
+	HidePresence           bool                `json:"hide_presence" bson:"hide_presence"` // partners are not told when they view the same photo or event
+	DisabledAutoMilestones []AutoMilestoneKind `json:"disabled_auto_milestones,omitempty" bson:"disabled_auto_milestones,omitempty"`
+	Theme                  string              `json:"theme,omitempty" bson:"theme,omitempty"`
+	AnniversaryDisplay     string              `json:"anniversary_display,omitempty" bson:"anniversary_display,omitempty"`
+	ReminderDefaults       *ReminderDefaults   `json:"reminder_defaults,omitempty" bson:"reminder_defaults,omitempty"`
+	Timezone               string              `json:"timezone,omitempty" bson:"timezone,omitempty"` // IANA name, e.g. "Asia/Ho_Chi_Minh"
+	Revision               int                 `json:"revision" bson:"revision"`                     // incremented on every change, so that concurrent edits by both partners are detected
+	UpdatedBy              primitive.ObjectID  `json:"updated_by" bson:"updated_by"`
+	CreatedAt              time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt              time.Time           `json:"updated_at" bson:"updated_at"`
+}
+
+// DefaultWatermarkText is used when watermarking is enabled without custom text
+const DefaultWatermarkText = "EraLove"
+
+// DefaultTheme is the app theme of couples who have not chosen one
+const DefaultTheme = "classic"
+
+// DefaultAnniversaryDisplay is how the time together is shown to couples who have not
+// chosen: as a number of days
+const DefaultAnniversaryDisplay = "days"
+
+// DefaultTimezone is used for couples who have not chosen a time zone
+const DefaultTimezone = "UTC"
+
+// DefaultLocale is used when neither the couple nor the user has chosen a language
+const DefaultLocale = "en"
+
+// localeFormats holds the date format and first day of the week of each supported
+// locale, used unless the couple chose otherwise
+var localeFormats = map[string]FormattingHints{
+	"en": {Locale: "en", DateFormat: "MM/DD/YYYY", FirstDayOfWeek: "sunday"},
+	"es": {Locale: "es", DateFormat: "DD/MM/YYYY", FirstDayOfWeek: "monday"},
+	"fr": {Locale: "fr", DateFormat: "DD/MM/YYYY", FirstDayOfWeek: "monday"},
+	"vi": {Locale: "vi", DateFormat: "DD/MM/YYYY", FirstDayOfWeek: "monday"},
+	"ja": {Locale: "ja", DateFormat: "YYYY-MM-DD", FirstDayOfWeek: "sunday"},
+	"ko": {Locale: "ko", DateFormat: "YYYY-MM-DD", FirstDayOfWeek: "sunday"},
+}
+
+// FormattingHints tell clients how to render the dates of calendar-related responses,
+// so that both partners see weeks and dates the same way. DateFormat uses the
+// YYYY, MM and DD tokens.
+type FormattingHints struct {
+	Locale         string `json:"locale"`
+	DateFormat     string `json:"date_format"`
+	FirstDayOfWeek string `json:"first_day_of_week"`
+}
+
+// ReminderDefaults are the reminders that new events of the couple get unless they set
+// their own
+type ReminderDefaults struct {
+	Enabled    bool   `json:"enabled" bson:"enabled"`
+	DaysBefore int    `json:"days_before" bson:"days_before" validate:"min=0,max=30"`
+	Time       string `json:"time" bson:"time" validate:"omitempty,datetime=15:04"` // time of day in the couple's time zone, e.g. "09:00"
+}
+
+// defaultReminderDefaults are used for couples who have not set reminder defaults
+var defaultReminderDefaults = ReminderDefaults{Enabled: true, DaysBefore: 1, Time: "09:00"}
+
+// UpdateCoupleSettingsRequest represents the request to update couple settings. An
+// empty locale, date format, first day of the week, theme, anniversary display or time
+// zone reverts to the default. Revision is the revision the changes were made from;
+// when set and the partner has saved changes since, the update is rejected.
+type UpdateCoupleSettingsRequest struct {
+	Revision               *int              `json:"revision,omitempty"`
+	WatermarkEnabled       *bool             `json:"watermark_enabled,omitempty"`
+	WatermarkText          *string           `json:"watermark_text,omitempty" validate:"omitempty,max=60"`
+	RequirePartnerApproval *bool             `json:"require_partner_approval,omitempty"`
+	Locale                 *string           `json:"locale,omitempty" validate:"omitempty,oneof=en es fr vi ja ko"`
+	DateFormat             *string           `json:"date_format,omitempty" validate:"omitempty,oneof=YYYY-MM-DD DD/MM/YYYY MM/DD/YYYY DD.MM.YYYY"`
+	FirstDayOfWeek         *string           `json:"first_day_of_week,omitempty" validate:"omitempty,oneof=monday sunday saturday"`
+	HidePresence           *bool             `json:"hide_presence,omitempty"`
+	Theme                  *string           `json:"theme,omitempty" validate:"omitempty,oneof=classic rose ocean forest midnight"`
+	AnniversaryDisplay     *string           `json:"anniversary_display,omitempty" validate:"omitempty,oneof=days weeks months years full"`
+	ReminderDefaults       *ReminderDefaults `json:"reminder_defaults,omitempty"`
+	Timezone               *string           `json:"timezone,omitempty" validate:"omitempty,timezone"`
+}
+
+// CoupleSettingsResponse represents the API response for couple settings
+type CoupleSettingsResponse struct {
+	WatermarkEnabled       bool             `json:"watermark_enabled"`
+	WatermarkText          string           `json:"watermark_text"`
+	RequirePartnerApproval bool             `json:"require_partner_approval"`
+	HidePresence           bool             `json:"hide_presence"`
+	Theme                  string           `json:"theme"`
+	AnniversaryDisplay     string           `json:"anniversary_display"`
+	ReminderDefaults       ReminderDefaults `json:"reminder_defaults"`
+	Timezone               string           `json:"timezone"`
+	Formatting             *FormattingHints `json:"formatting"`
+	Revision               int              `json:"revision"`
+	UpdatedBy              string           `json:"updated_by,omitempty"`
+	UpdatedAt              time.Time        `json:"updated_at,omitempty"`
+}
+
+// EffectiveWatermarkText returns the text to stamp on shared photos
+func (s *CoupleSettings) EffectiveWatermarkText() string {
+	if s.WatermarkText == "" {
+		return DefaultWatermarkText
+	}
+	return s.WatermarkText
+}
+
+// EffectiveTheme returns the couple's app theme
+func (s *CoupleSettings) EffectiveTheme() string {
+	if s.Theme == "" {
+		return DefaultTheme
+	}
+	return s.Theme
+}
+
+// EffectiveAnniversaryDisplay returns how the couple's time together is shown
+func (s *CoupleSettings) EffectiveAnniversaryDisplay() string {
+	if s.AnniversaryDisplay == "" {
+		return DefaultAnniversaryDisplay
+	}
+	return s.AnniversaryDisplay
+}
+
+// EffectiveReminderDefaults returns the reminders new events of the couple get
+func (s *CoupleSettings) EffectiveReminderDefaults() ReminderDefaults {
+	if s.ReminderDefaults == nil {
+		return defaultReminderDefaults
+	}
+	return *s.ReminderDefaults
+}
+
+// Location returns the couple's time zone, or UTC when it has none or it is unknown
+func (s *CoupleSettings) Location() *time.Location {
+	return LoadLocation(s.Timezone)
+}
+
+// EffectiveTimezone returns the name of the couple's time zone
+func (s *CoupleSettings) EffectiveTimezone() string {
+	if s.Timezone == "" {
+		return DefaultTimezone
+	}
+	return s.Timezone
+}
+
+// FormattingHints returns the couple's date formatting, filling what the couple has
+// not chosen from the defaults of its locale. Couples without a locale use
+// fallbackLocale, normally the user's own.
+func (s *CoupleSettings) FormattingHints(fallbackLocale string) *FormattingHints {
+	locale := s.Locale
+	if locale == "" {
+		locale = fallbackLocale
+	}
+	return DefaultFormattingHints(locale).with(s.DateFormat, s.FirstDayOfWeek)
+}
+
+// DefaultFormattingHints returns the formatting of a locale, or of DefaultLocale when
+// it is not supported
+func DefaultFormattingHints(locale string) *FormattingHints {
+	hints, ok := localeFormats[locale]
+	if !ok {
+		hints = localeFormats[DefaultLocale]
+	}
+	return &hints
+}
+
+// with overrides the date format and first day of the week where they are set
+func (h *FormattingHints) with(dateFormat, firstDayOfWeek string) *FormattingHints {
+	if dateFormat != "" {
+		h.DateFormat = dateFormat
+	}
+	if firstDayOfWeek != "" {
+		h.FirstDayOfWeek = firstDayOfWeek
+	}
+	return h
+}
+
+// AutoMilestoneEnabled reports whether milestone events of kind are generated for the couple
+func (s *CoupleSettings) AutoMilestoneEnabled(kind AutoMilestoneKind) bool {
+	for _, disabled := range s.DisabledAutoMilestones {
+		if disabled == kind {
+			return false
+		}
+	}
+	return true
+}
+
+// ActiveEncryptionKey returns the latest version of the couple's data key, or nil
+// when the couple has none yet
+func (s *CoupleSettings) ActiveEncryptionKey() *CoupleDataKey {
+	var active *CoupleDataKey
+	for _, key := range s.EncryptionKeys {
+		if active == nil || key.Version > active.Version {
+			active = key
+		}
+	}
+	return active
+}
+
+// EncryptionKey returns the given version of the couple's data key, or nil
+func (s *CoupleSettings) EncryptionKey(version int) *CoupleDataKey {
+	for _, key := range s.EncryptionKeys {
+		if key.Version == version {
+			return key
+		}
+	}
+	return nil
+}
+
+// ToResponse converts CoupleSettings to CoupleSettingsResponse
+func (s *CoupleSettings) ToResponse() *CoupleSettingsResponse {
+	response := &CoupleSettingsResponse{
+		WatermarkEnabled:       s.WatermarkEnabled,
+		WatermarkText:          s.EffectiveWatermarkText(),
+		RequirePartnerApproval: s.RequirePartnerApproval,
+		HidePresence:           s.HidePresence,
+		Theme:                  s.EffectiveTheme(),
+		AnniversaryDisplay:     s.EffectiveAnniversaryDisplay(),
+		ReminderDefaults:       s.EffectiveReminderDefaults(),
+		Timezone:               s.EffectiveTimezone(),
+		Formatting:             s.FormattingHints(DefaultLocale),
+		Revision:               s.Revision,
+		UpdatedAt:              s.UpdatedAt,
+	}
+	if !s.UpdatedBy.IsZero() {
+		response.UpdatedBy = s.UpdatedBy.Hex()
+	}
+	return response
+}
+
+// CoupleSettingsRepository defines the interface for couple settings data access
+type CoupleSettingsRepository interface {
+	GetByMatchCode(ctx context.Context, matchCode string) (*CoupleSettings, error)
+	Upsert(ctx context.Context, settings *CoupleSettings) error
+	// UpdateAtRevision saves settings only when the stored ones are still at revision,
+	// failing with "couple settings revision changed" otherwise
+	UpdateAtRevision(ctx context.Context, settings *CoupleSettings, revision int) error
+	// AddEncryptionKey adds a data key version, failing when the couple already has that version
+	AddEncryptionKey(ctx context.Context, matchCode string, key *CoupleDataKey) error
+	// UpdateWrappedKey replaces a data key version wrapped by another master key
+	UpdateWrappedKey(ctx context.Context, matchCode string, version int, masterKeyID string, wrapped []byte) error
+	// ListWithOtherMasterKey lists up to limit couples, in ID order after afterID, with a
+	// data key wrapped by a master key other than masterKeyID
+	ListWithOtherMasterKey(ctx context.Context, masterKeyID string, afterID primitive.ObjectID, limit int) ([]*CoupleSettings, error)
+}
+
+// CoupleSettingsService defines the interface for couple settings business logic
+type CoupleSettingsService interface {
+	GetSettings(ctx context.Context, userID primitive.ObjectID) (*CoupleSettingsResponse, error)
+	UpdateSettings(ctx context.Context, userID primitive.ObjectID, req *UpdateCoupleSettingsRequest) (*CoupleSettingsResponse, error)
+	GetByMatchCode(ctx context.Context, matchCode string) (*CoupleSettings, error)
+	// FormattingHints returns the date formatting for a user's responses. It falls back
+	// to the defaults of the user's locale rather than failing.
+	FormattingHints(ctx context.Context, userID primitive.ObjectID) *FormattingHints
+}
+
+// WatermarkService renders and caches watermarked variants of photos exposed outside the couple
+type WatermarkService interface {
+	// SharedImageKey returns the storage key to serve for a photo shown on a shared or public page.
+	// It is the watermarked variant when the couple has watermarking enabled, otherwise the original.
+	SharedImageKey(ctx context.Context, photo *Photo) (string, error)
+}
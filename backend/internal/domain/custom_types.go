@@ -2,19 +2,58 @@ package domain
 
 import (
 	"encoding/json"
+	"fmt"
+	"reflect"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 )
 
-// Date represents a date in YYYY-MM-DD format
+// DateLayout is the wire format of Date values
+const DateLayout = "2006-01-02"
+
+// Date represents a calendar date in YYYY-MM-DD format.
+//
+// A date has no time of day or timezone: it is always held as midnight UTC of the
+// calendar day. Timestamps sent by older clients are accepted and reduced to the
+// calendar day in the offset they were written in, so "2024-02-14T23:30:00-05:00"
+// is February 14th, not the 15th it would be in UTC.
 type Date struct {
 	time.Time
 }
 
+// dateInputLayouts lists the accepted input formats, the canonical one first
+var dateInputLayouts = []string{
+	DateLayout,
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// NewDate creates the Date of the calendar day of t in t's location
+func NewDate(t time.Time) Date {
+	if t.IsZero() {
+		return Date{}
+	}
+	return Date{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// ParseDate parses a date in any of the accepted input formats
+func ParseDate(s string) (Date, error) {
+	for _, layout := range dateInputLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return NewDate(t), nil
+		}
+	}
+	return Date{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", s)
+}
+
 // UnmarshalJSON implements json.Unmarshaler interface for Date
 func (d *Date) UnmarshalJSON(b []byte) error {
 	var s string
 	if err := json.Unmarshal(b, &s); err != nil {
-		return err
+		return fmt.Errorf("invalid date, expected a YYYY-MM-DD string")
 	}
 
 	if s == "" || s == "null" {
@@ -22,23 +61,13 @@ func (d *Date) UnmarshalJSON(b []byte) error {
 		return nil
 	}
 
-	// Try parsing different date formats
-	formats := []string{
-		"2006-01-02",                 // YYYY-MM-DD
-		"2006-01-02T15:04:05Z07:00",  // RFC3339
-		"2006-01-02T15:04:05Z",       // RFC3339 without timezone
-		"2006-01-02 15:04:05",        // DateTime
-	}
-
-	var err error
-	for _, format := range formats {
-		d.Time, err = time.Parse(format, s)
-		if err == nil {
-			return nil
-		}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
 	}
 
-	return err
+	*d = parsed
+	return nil
 }
 
 // MarshalJSON implements json.Marshaler interface for Date
@@ -46,7 +75,32 @@ func (d Date) MarshalJSON() ([]byte, error) {
 	if d.Time.IsZero() {
 		return []byte("null"), nil
 	}
-	return json.Marshal(d.Time.Format("2006-01-02"))
+	return json.Marshal(d.String())
+}
+
+// MarshalBSONValue stores the date as a BSON datetime at midnight UTC
+func (d Date) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if d.Time.IsZero() {
+		return bson.MarshalValue(nil)
+	}
+	return bson.MarshalValue(NewDate(d.Time.UTC()).Time)
+}
+
+// UnmarshalBSONValue reads a date stored as a BSON datetime
+func (d *Date) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	if t == bsontype.Null || t == bsontype.Undefined {
+		d.Time = time.Time{}
+		return nil
+	}
+
+	var value time.Time
+	raw := bson.RawValue{Type: t, Value: data}
+	if err := raw.Unmarshal(&value); err != nil {
+		return fmt.Errorf("invalid date: %w", err)
+	}
+
+	*d = NewDate(value.UTC())
+	return nil
 }
 
 // String returns the date in YYYY-MM-DD format
@@ -54,7 +108,7 @@ func (d Date) String() string {
 	if d.Time.IsZero() {
 		return ""
 	}
-	return d.Time.Format("2006-01-02")
+	return d.Time.UTC().Format(DateLayout)
 }
 
 // ToTimePtr converts Date to *time.Time
@@ -66,9 +120,10 @@ func (d *Date) ToTimePtr() *time.Time {
 	return &t
 }
 
-// DateFromTime creates a Date from time.Time
+// DateFromTime creates a Date from a stored time.Time. Stored dates are read in UTC
+// so a value written as midnight UTC always maps back to the same calendar day.
 func DateFromTime(t time.Time) Date {
-	return Date{Time: t}
+	return NewDate(t.UTC())
 }
 
 // DateFromTimePtr creates a Date from *time.Time
@@ -76,6 +131,16 @@ func DateFromTimePtr(t *time.Time) *Date {
 	if t == nil {
 		return nil
 	}
-	d := Date{Time: *t}
+	d := DateFromTime(*t)
 	return &d
 }
+
+// DateValue lets the validator treat Date fields like time.Time, so that tags such
+// as required, lte and gte work on them. Register it with RegisterCustomTypeFunc.
+func DateValue(field reflect.Value) interface{} {
+	d, ok := field.Interface().(Date)
+	if !ok || d.Time.IsZero() {
+		return nil
+	}
+	return d.Time
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// QuestionPrompt is a question of the daily question bank, as written by the team in
+// Directus or built in
+type QuestionPrompt struct {
+	Key      string `json:"key"`
+	Text     string `json:"text"`
+	Category string `json:"category,omitempty"`
+}
+
+// QuestionSource provides the daily questions published in the CMS
+type QuestionSource interface {
+	// ListQuestions retrieves the published questions of a locale
+	ListQuestions(ctx context.Context, locale string) ([]*QuestionPrompt, error)
+}
+
+// DailyQuestion is the question a couple is asked on one day of the couple's time zone.
+// Each partner answers privately; the answers are revealed to both once both have
+// answered, and can no longer be changed.
+type DailyQuestion struct {
+	ID          primitive.ObjectID        `json:"id" bson:"_id,omitempty"`
+	MatchCode   string                    `json:"match_code" bson:"match_code"`
+	Day         time.Time                 `json:"day" bson:"day"` // midnight UTC of the local day
+	QuestionKey string                    `json:"question_key" bson:"question_key"`
+	Text        string                    `json:"text" bson:"text"` // in the default locale, as asked
+	Category    string                    `json:"category,omitempty" bson:"category,omitempty"`
+	Answers     map[string]QuestionAnswer `json:"-" bson:"answers,omitempty"` // by user ID
+	RevealedAt  *time.Time                `json:"revealed_at,omitempty" bson:"revealed_at,omitempty"`
+	CreatedAt   time.Time                 `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time                 `json:"updated_at" bson:"updated_at"`
+}
+
+// QuestionAnswer is a partner's answer to a daily question
+type QuestionAnswer struct {
+	Answer     string    `json:"answer" bson:"answer"`
+	AnsweredAt time.Time `json:"answered_at" bson:"answered_at"`
+}
+
+// AnswerQuestionRequest represents the request to answer today's question
+type AnswerQuestionRequest struct {
+	Answer string `json:"answer" validate:"required,min=1,max=2000"`
+}
+
+// QuestionAnswerResponse represents a partner's answer in API responses
+type QuestionAnswerResponse struct {
+	Answer     string    `json:"answer"`
+	AnsweredAt time.Time `json:"answered_at"`
+}
+
+// DailyQuestionResponse represents a daily question as a partner sees it. The
+// partner's answer is only included once both have answered.
+type DailyQuestionResponse struct {
+	ID              string                  `json:"id"`
+	Date            Date                    `json:"date"`
+	Key             string                  `json:"key"`
+	Text            string                  `json:"text"`
+	Category        string                  `json:"category,omitempty"`
+	MyAnswer        *QuestionAnswerResponse `json:"my_answer,omitempty"`
+	PartnerAnswer   *QuestionAnswerResponse `json:"partner_answer,omitempty"`
+	PartnerAnswered bool                    `json:"partner_answered"`
+	Revealed        bool                    `json:"revealed"`
+	RevealedAt      *time.Time              `json:"revealed_at,omitempty"`
+}
+
+// ToResponse converts DailyQuestion to DailyQuestionResponse as the viewer sees it,
+// with the question in text
+func (q *DailyQuestion) ToResponse(viewerID primitive.ObjectID, text string) *DailyQuestionResponse {
+	response := &DailyQuestionResponse{
+		ID:         q.ID.Hex(),
+		Date:       DateFromTime(q.Day),
+		Key:        q.QuestionKey,
+		Text:       text,
+		Category:   q.Category,
+		Revealed:   q.RevealedAt != nil,
+		RevealedAt: q.RevealedAt,
+	}
+
+	viewer := viewerID.Hex()
+	for userID, answer := range q.Answers {
+		answer := &QuestionAnswerResponse{Answer: answer.Answer, AnsweredAt: answer.AnsweredAt}
+		if userID == viewer {
+			response.MyAnswer = answer
+			continue
+		}
+		response.PartnerAnswered = true
+		if response.Revealed {
+			response.PartnerAnswer = answer
+		}
+	}
+
+	return response
+}
+
+// DailyQuestionListResponse represents a page of the couple's past questions
+type DailyQuestionListResponse struct {
+	Questions []*DailyQuestionResponse `json:"questions"`
+	Total     int64                    `json:"total"`
+	Page      int                      `json:"page"`
+	Limit     int                      `json:"limit"`
+}
+
+// DailyQuestionRepository defines the interface for daily question data access
+type DailyQuestionRepository interface {
+	// GetOrCreate returns the couple's question of question.Day, creating it from
+	// question unless it already exists
+	GetOrCreate(ctx context.Context, question *DailyQuestion) (*DailyQuestion, error)
+	GetByDay(ctx context.Context, matchCode string, day time.Time) (*DailyQuestion, error)
+	// SetAnswer saves a partner's answer unless the answers were revealed, and returns
+	// the question as updated
+	SetAnswer(ctx context.Context, id, userID primitive.ObjectID, answer QuestionAnswer) (*DailyQuestion, error)
+	// MarkRevealed marks the answers revealed and reports whether they were not already
+	MarkRevealed(ctx context.Context, id primitive.ObjectID, revealedAt time.Time) (bool, error)
+	GetByMatchCode(ctx context.Context, matchCode string, limit, offset int) ([]*DailyQuestion, error)
+	CountByMatchCode(ctx context.Context, matchCode string) (int64, error)
+}
+
+// DailyQuestionService defines the interface for daily question business logic.
+// Questions are shown in locale when the CMS has a translation.
+type DailyQuestionService interface {
+	GetToday(ctx context.Context, userID primitive.ObjectID, locale string) (*DailyQuestionResponse, error)
+	AnswerToday(ctx context.Context, userID primitive.ObjectID, locale string, req *AnswerQuestionRequest) (*DailyQuestionResponse, error)
+	GetHistory(ctx context.Context, userID primitive.ObjectID, locale string, page, limit int) (*DailyQuestionListResponse, error)
+}
@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DatePlanStatus represents where a date plan stands
+type DatePlanStatus string
+
+const (
+	DatePlanStatusIdea      DatePlanStatus = "idea"
+	DatePlanStatusPlanned   DatePlanStatus = "planned"
+	DatePlanStatusDone      DatePlanStatus = "done"
+	DatePlanStatusCancelled DatePlanStatus = "cancelled"
+)
+
+// DateIdea is a curated date night idea, as written by the team in Directus or built in.
+// Ideas without a location fit anywhere; Cost is the estimate for the couple in Currency.
+type DateIdea struct {
+	Key         string   `json:"key"`
+	Title       string   `json:"title"`
+	Description string   `json:"description,omitempty"`
+	Location    string   `json:"location,omitempty"`
+	Category    string   `json:"category,omitempty"`
+	Cost        *float64 `json:"cost,omitempty"`
+	Currency    string   `json:"currency,omitempty"`
+}
+
+// DateIdeaFilter selects the date ideas to suggest
+type DateIdeaFilter struct {
+	Location  string
+	MaxBudget *float64
+	Category  string
+}
+
+// Matches reports whether the idea fits the filter: it can be done at the location, or
+// anywhere, and its cost is known and within the budget
+func (i *DateIdea) Matches(filter DateIdeaFilter) bool {
+	if filter.Location != "" && i.Location != "" &&
+		!strings.Contains(strings.ToLower(filter.Location), strings.ToLower(i.Location)) &&
+		!strings.Contains(strings.ToLower(i.Location), strings.ToLower(filter.Location)) {
+		return false
+	}
+	if filter.MaxBudget != nil && (i.Cost == nil || *i.Cost > *filter.MaxBudget) {
+		return false
+	}
+	if filter.Category != "" && !strings.EqualFold(i.Category, filter.Category) {
+		return false
+	}
+	return true
+}
+
+// DateIdeaSource provides the date ideas published in the CMS
+type DateIdeaSource interface {
+	ListDateIdeas(ctx context.Context) ([]*DateIdea, error)
+}
+
+// DateIdeasResponse represents the date ideas suggested to a couple
+type DateIdeasResponse struct {
+	Ideas []*DateIdea `json:"ideas"`
+}
+
+// DatePlan is a date night a couple is planning. Once scheduled it can be turned into
+// an event on the couple's calendar.
+type DatePlan struct {
+	ID            primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	MatchCode     string              `json:"match_code" bson:"match_code"`
+	CreatedBy     primitive.ObjectID  `json:"created_by" bson:"created_by"`
+	Idea          string              `json:"idea" bson:"idea"`
+	Description   string              `json:"description,omitempty" bson:"description,omitempty"`
+	IdeaKey       string              `json:"idea_key,omitempty" bson:"idea_key,omitempty"` // the suggested idea the plan came from
+	Location      string              `json:"location,omitempty" bson:"location,omitempty"`
+	ScheduledDate *time.Time          `json:"scheduled_date,omitempty" bson:"scheduled_date,omitempty"` // midnight UTC of the day
+	Time          string              `json:"time,omitempty" bson:"time,omitempty"`
+	Budget        *float64            `json:"budget,omitempty" bson:"budget,omitempty"`
+	Currency      string              `json:"currency,omitempty" bson:"currency,omitempty"` // ISO 4217 code
+	Status        DatePlanStatus      `json:"status" bson:"status"`
+	EventID       *primitive.ObjectID `json:"event_id,omitempty" bson:"event_id,omitempty"`
+	CreatedAt     time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at" bson:"updated_at"`
+	DeletedAt     *time.Time          `json:"-" bson:"deleted_at,omitempty"`
+}
+
+// CreateDatePlanRequest represents the request to plan a date. The status defaults to
+// planned when a date is scheduled, and to idea otherwise.
+type CreateDatePlanRequest struct {
+	Idea          string         `json:"idea" validate:"required,min=1,max=200"`
+	Description   string         `json:"description,omitempty" validate:"omitempty,max=2000"`
+	IdeaKey       string         `json:"idea_key,omitempty" validate:"omitempty,max=100"`
+	Location      string         `json:"location,omitempty" validate:"omitempty,max=200"`
+	ScheduledDate *Date          `json:"scheduled_date,omitempty"`
+	Time          string         `json:"time,omitempty" validate:"omitempty,datetime=15:04"`
+	Budget        *float64       `json:"budget,omitempty" validate:"omitempty,min=0"`
+	Currency      string         `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	Status        DatePlanStatus `json:"status,omitempty" validate:"omitempty,oneof=idea planned done cancelled"`
+}
+
+// UpdateDatePlanRequest represents the request to update a date plan
+type UpdateDatePlanRequest struct {
+	Idea          string         `json:"idea,omitempty" validate:"omitempty,min=1,max=200"`
+	Description   *string        `json:"description,omitempty" validate:"omitempty,max=2000"`
+	Location      *string        `json:"location,omitempty" validate:"omitempty,max=200"`
+	ScheduledDate *Date          `json:"scheduled_date,omitempty"`
+	Time          *string        `json:"time,omitempty" validate:"omitempty,max=8"`
+	Budget        *float64       `json:"budget,omitempty" validate:"omitempty,min=0"`
+	Currency      string         `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	Status        DatePlanStatus `json:"status,omitempty" validate:"omitempty,oneof=idea planned done cancelled"`
+}
+
+// ConvertDatePlanRequest represents the options of the event a date plan is turned into
+type ConvertDatePlanRequest struct {
+	IsPrivate bool           `json:"is_private"`
+	Reminder  *EventReminder `json:"reminder,omitempty"` // defaults to the couple's reminder defaults
+}
+
+// DatePlanResponse represents the API response for a date plan
+type DatePlanResponse struct {
+	ID            string         `json:"id"`
+	CreatedBy     string         `json:"created_by"`
+	Idea          string         `json:"idea"`
+	Description   string         `json:"description,omitempty"`
+	IdeaKey       string         `json:"idea_key,omitempty"`
+	Location      string         `json:"location,omitempty"`
+	ScheduledDate *Date          `json:"scheduled_date,omitempty"`
+	Time          string         `json:"time,omitempty"`
+	Budget        *float64       `json:"budget,omitempty"`
+	Currency      string         `json:"currency,omitempty"`
+	Status        DatePlanStatus `json:"status"`
+	EventID       string         `json:"event_id,omitempty"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// ToResponse converts DatePlan to DatePlanResponse
+func (p *DatePlan) ToResponse() *DatePlanResponse {
+	response := &DatePlanResponse{
+		ID:            p.ID.Hex(),
+		CreatedBy:     p.CreatedBy.Hex(),
+		Idea:          p.Idea,
+		Description:   p.Description,
+		IdeaKey:       p.IdeaKey,
+		Location:      p.Location,
+		ScheduledDate: DateFromTimePtr(p.ScheduledDate),
+		Time:          p.Time,
+		Budget:        p.Budget,
+		Currency:      p.Currency,
+		Status:        p.Status,
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
+	}
+	if p.EventID != nil {
+		response.EventID = p.EventID.Hex()
+	}
+
+	return response
+}
+
+// ConvertDatePlanResponse represents a date plan and the event it was turned into
+type ConvertDatePlanResponse struct {
+	Plan  *DatePlanResponse `json:"plan"`
+	Event *EventResponse    `json:"event"`
+}
+
+// DatePlanListResponse represents a page of the couple's date plans
+type DatePlanListResponse struct {
+	Plans []*DatePlanResponse `json:"plans"`
+	Total int64               `json:"total"`
+	Page  int                 `json:"page"`
+	Limit int                 `json:"limit"`
+}
+
+// DatePlanRepository defines the interface for date plan data access
+type DatePlanRepository interface {
+	Create(ctx context.Context, plan *DatePlan) error
+	GetByID(ctx context.Context, matchCode string, id primitive.ObjectID) (*DatePlan, error)
+	GetByMatchCode(ctx context.Context, matchCode string, status DatePlanStatus, limit, offset int) ([]*DatePlan, error)
+	CountByMatchCode(ctx context.Context, matchCode string, status DatePlanStatus) (int64, error)
+	Update(ctx context.Context, plan *DatePlan) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// DatePlanService defines the interface for date planning business logic
+type DatePlanService interface {
+	SuggestIdeas(ctx context.Context, userID primitive.ObjectID, filter DateIdeaFilter, limit int) (*DateIdeasResponse, error)
+	CreatePlan(ctx context.Context, userID primitive.ObjectID, req *CreateDatePlanRequest) (*DatePlanResponse, error)
+	GetPlan(ctx context.Context, planID, userID primitive.ObjectID) (*DatePlanResponse, error)
+	GetPlans(ctx context.Context, userID primitive.ObjectID, status DatePlanStatus, page, limit int) (*DatePlanListResponse, error)
+	UpdatePlan(ctx context.Context, planID, userID primitive.ObjectID, req *UpdateDatePlanRequest) (*DatePlanResponse, error)
+	DeletePlan(ctx context.Context, planID, userID primitive.ObjectID) error
+	// ConvertToEvent adds a scheduled date plan to the couple's calendar as a date event
+	ConvertToEvent(ctx context.Context, planID, userID primitive.ObjectID, req *ConvertDatePlanRequest) (*ConvertDatePlanResponse, error)
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// DomainEventType names something that happened in the app, as integrations see it
+type DomainEventType string
+
+const (
+	DomainEventUserRegistered DomainEventType = "user.registered"
+	DomainEventMatchAccepted  DomainEventType = "match.accepted"
+	DomainEventPhotoCreated   DomainEventType = "photo.created"
+	DomainEventMessageSent    DomainEventType = "message.sent"
+	DomainEventMoodRecorded   DomainEventType = "mood.recorded"
+)
+
+// DomainEventTypes lists every event type that is published
+var DomainEventTypes = []DomainEventType{
+	DomainEventUserRegistered,
+	DomainEventMatchAccepted,
+	DomainEventPhotoCreated,
+	DomainEventMessageSent,
+	DomainEventMoodRecorded,
+}
+
+// IsValidDomainEventType reports whether eventType is a published event type
+func IsValidDomainEventType(eventType DomainEventType) bool {
+	for _, known := range DomainEventTypes {
+		if known == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// DomainEvent is an event as delivered to subscribers and webhooks. Data is one of
+// the event data types below, which only carry IDs so that integrations never
+// receive emails or message contents.
+type DomainEvent struct {
+	ID         string          `json:"id"`
+	Type       DomainEventType `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       interface{}     `json:"data"`
+}
+
+// UserRegisteredData is the data of a user.registered event
+type UserRegisteredData struct {
+	UserID string `json:"user_id"`
+	Locale string `json:"locale,omitempty"`
+}
+
+// MatchAcceptedData is the data of a match.accepted event
+type MatchAcceptedData struct {
+	MatchCode       string   `json:"match_code"`
+	UserIDs         []string `json:"user_ids"` // the sender of the request first
+	AnniversaryDate *Date    `json:"anniversary_date,omitempty"`
+}
+
+// PhotoCreatedData is the data of a photo.created event
+type PhotoCreatedData struct {
+	PhotoID   string `json:"photo_id"`
+	MatchCode string `json:"match_code"`
+	CreatedBy string `json:"created_by"`
+	IsPrivate bool   `json:"is_private"`
+}
+
+// MessageSentData is the data of a message.sent event
+type MessageSentData struct {
+	MessageID   string `json:"message_id"`
+	SenderID    string `json:"sender_id"`
+	ReceiverID  string `json:"receiver_id"`
+	MessageType string `json:"message_type"`
+}
+
+// MoodRecordedData is the data of a mood.recorded event
+type MoodRecordedData struct {
+	UserID    string `json:"user_id"`
+	MatchCode string `json:"match_code,omitempty"`
+	Date      Date   `json:"date"`
+}
+
+// EventPublisher publishes domain events to the subscribers of the event bus
+type EventPublisher interface {
+	// Publish hands the event to every subscriber before returning. Subscribers
+	// must not block, so publishing never slows down or fails the action itself.
+	Publish(ctx context.Context, eventType DomainEventType, data interface{})
+}
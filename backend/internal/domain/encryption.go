@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// KeyManager holds the master keys that wrap the per-couple data keys. Data keys are
+// stored wrapped and only unwrapped in memory to encrypt or decrypt.
+type KeyManager interface {
+	// CurrentKeyID returns the ID of the master key that wraps new data keys
+	CurrentKeyID() string
+	// GenerateDataKey returns a new data key, in plaintext and wrapped by the current master key
+	GenerateDataKey(ctx context.Context) (plaintext, wrapped []byte, masterKeyID string, err error)
+	// WrapDataKey wraps a data key with the current master key
+	WrapDataKey(ctx context.Context, plaintext []byte) (wrapped []byte, masterKeyID string, err error)
+	// UnwrapDataKey unwraps a data key wrapped by the given master key
+	UnwrapDataKey(ctx context.Context, masterKeyID string, wrapped []byte) ([]byte, error)
+}
+
+// CoupleDataKey is a version of a couple's data key, wrapped by a master key. The
+// latest version encrypts new data; older versions are kept to decrypt data
+// encrypted before a rotation.
+type CoupleDataKey struct {
+	Version     int                `json:"version" bson:"version"`
+	WrappedKey  []byte             `json:"-" bson:"wrapped_key"`
+	MasterKeyID string             `json:"master_key_id" bson:"master_key_id"`
+	CreatedBy   primitive.ObjectID `json:"created_by,omitempty" bson:"created_by,omitempty"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// CoupleKeyVersionResponse describes a version of a couple's data key
+type CoupleKeyVersionResponse struct {
+	Version   int       `json:"version"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CoupleKeyResponse describes a couple's data key. Versions is empty until the
+// couple first encrypts something or rotates the key.
+type CoupleKeyResponse struct {
+	ActiveVersion int                         `json:"active_version"`
+	Versions      []*CoupleKeyVersionResponse `json:"versions"`
+}
+
+// CoupleKeyService encrypts a couple's data, such as exports, time capsules and vault
+// entries, with the couple's data key
+type CoupleKeyService interface {
+	// Encrypt encrypts plaintext with the couple's active data key, creating the key on
+	// first use. purpose names what the data is for and must be given again to decrypt it.
+	Encrypt(ctx context.Context, matchCode, purpose string, plaintext []byte) ([]byte, error)
+	// Decrypt decrypts data encrypted by Encrypt with any version of the couple's data key
+	Decrypt(ctx context.Context, matchCode, purpose string, ciphertext []byte) ([]byte, error)
+	GetKey(ctx context.Context, userID primitive.ObjectID) (*CoupleKeyResponse, error)
+	// RotateKey creates a new version of the couple's data key for new data
+	RotateKey(ctx context.Context, userID primitive.ObjectID) (*CoupleKeyResponse, error)
+	// RewrapKeys wraps the data keys wrapped by a previous master key with the current
+	// one, so the previous master key can be retired. It is run periodically by the scheduler.
+	RewrapKeys(ctx context.Context) error
+}
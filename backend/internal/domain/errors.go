@@ -1,6 +1,10 @@
 package domain
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // ErrorCode represents a unique error code
 // Format: HTTPCODE + 3 digits (e.g., 400001 = Bad Request + Invalid Credentials)
@@ -18,6 +22,9 @@ const (
 	ErrCodeUnsupportedFileType ErrorCode = 400008 // Unsupported file type
 	ErrCodeFileTooLarge        ErrorCode = 400009 // File size exceeds limit
 	ErrCodeInvalidMatchRequest ErrorCode = 400010 // Invalid match request
+	ErrCodeNotMatched          ErrorCode = 400011 // User is not matched with anyone
+	ErrCodeFileRejected        ErrorCode = 400012 // File rejected by the content scan
+	ErrCodeVideoTooLong        ErrorCode = 400013 // Video runs longer than allowed
 
 	// 401xxx - Unauthorized Errors
 	ErrCodeUnauthorized             ErrorCode = 401001 // Unauthorized access
@@ -26,10 +33,13 @@ const (
 	ErrCodeTokenExpired             ErrorCode = 401004 // Token has expired
 	ErrCodeInvalidVerificationToken ErrorCode = 401005 // Invalid verification token
 	ErrCodeInvalidResetToken        ErrorCode = 401006 // Invalid reset token
+	ErrCodePasswordRequired         ErrorCode = 401007 // Share link password required
+	ErrCodeInvalidSharePassword     ErrorCode = 401008 // Share link password is wrong
 
 	// 403xxx - Forbidden Errors
 	ErrCodeForbidden        ErrorCode = 403001 // Access forbidden
 	ErrCodeEmailNotVerified ErrorCode = 403002 // Email not verified
+	ErrCodeEditWindowPassed ErrorCode = 403003 // Message can no longer be edited
 
 	// 404xxx - Not Found Errors
 	ErrCodeNotFound             ErrorCode = 404001 // Resource not found
@@ -45,9 +55,27 @@ const (
 	ErrCodeUserAlreadyExists    ErrorCode = 409001 // User already exists
 	ErrCodeEmailAlreadyVerified ErrorCode = 409002 // Email already verified
 	ErrCodeMatchRequestExists   ErrorCode = 409003 // Match request already exists
+	ErrCodeInvalidStatusChange  ErrorCode = 409004 // Status change not allowed from the current status
+	ErrCodeOperationInProgress  ErrorCode = 409005 // The same operation is already running
+	ErrCodeAlreadyMatched       ErrorCode = 409006 // User is already matched with a partner
+	ErrCodeSettingsConflict     ErrorCode = 409007 // Settings were changed by the partner since they were read
+	ErrCodeAnswersRevealed      ErrorCode = 409008 // Daily question answers were revealed and can no longer change
 
 	// 410xxx - Gone Errors
-	ErrCodeMatchRequestExpired ErrorCode = 410001 // Match request expired
+	ErrCodeMatchRequestExpired  ErrorCode = 410001 // Match request expired
+	ErrCodeShareLinkExpired     ErrorCode = 410002 // Share link expired or revoked
+	ErrCodePendingActionExpired ErrorCode = 410003 // Pending action expired before it was decided
+	ErrCodeMatchInviteExpired   ErrorCode = 410004 // Match invite code expired, redeemed or unknown
+
+	// 413xxx - Payload Too Large Errors
+	ErrCodeBodyTooLarge ErrorCode = 413001 // Request body exceeds the limit of the route
+
+	// 422xxx - Unprocessable Errors
+	ErrCodeIdempotencyKeyReused ErrorCode = 422001 // Idempotency key sent with a different request
+
+	// 429xxx - Too Many Requests Errors
+	ErrCodeTooManyRequests   ErrorCode = 429001 // Rate limit exceeded
+	ErrCodeRateLimitExceeded ErrorCode = 429002 // API rate limit of the user or address exceeded
 
 	// 500xxx - Internal Server Errors
 	ErrCodeInternalError          ErrorCode = 500001 // Internal server error
@@ -66,6 +94,9 @@ const (
 	ErrCodeProfileUpdateFailed    ErrorCode = 500014 // Profile update failed
 	ErrCodeAccountDeletionFailed  ErrorCode = 500015 // Account deletion failed
 	ErrCodeOperationFailed        ErrorCode = 500016 // General operation failed
+
+	// 503xxx - Service Unavailable Errors
+	ErrCodeServiceUnavailable ErrorCode = 503001 // Optional service is not configured
 )
 
 // AppError represents an application error with code and message
@@ -217,5 +248,213 @@ func ErrFileTooLargeError(maxSize int64) *AppError {
 	)
 }
 
+func ErrBodyTooLargeError(maxSize int) *AppError {
+	return NewAppError(
+		ErrCodeBodyTooLarge,
+		fmt.Sprintf("Request body exceeds maximum allowed size of %d bytes", maxSize),
+		413,
+	)
+}
+
+func ErrFileRejectedError() *AppError {
+	return NewAppError(
+		ErrCodeFileRejected,
+		"File was rejected by the content scan",
+		400,
+	)
+}
+
+func ErrVideoTooLongError(maxDuration time.Duration) *AppError {
+	return NewAppError(
+		ErrCodeVideoTooLong,
+		fmt.Sprintf("Video exceeds maximum allowed length of %d seconds", int(maxDuration.Seconds())),
+		400,
+	)
+}
+
+func ErrNotMatchedError() *AppError {
+	return NewAppError(
+		ErrCodeNotMatched,
+		"User is not matched with anyone",
+		400,
+	)
+}
+
+func ErrOperationFailedError(message string) *AppError {
+	return NewAppError(
+		ErrCodeOperationFailed,
+		message,
+		500,
+	)
+}
+
+func ErrShareLinkExpiredError() *AppError {
+	return NewAppError(
+		ErrCodeShareLinkExpired,
+		"Share link has expired or been revoked",
+		410,
+	)
+}
+
+func ErrPendingActionExpiredError() *AppError {
+	return NewAppError(
+		ErrCodePendingActionExpired,
+		"The request expired before it was approved",
+		410,
+	)
+}
+
+func ErrEditWindowPassedError() *AppError {
+	return NewAppError(
+		ErrCodeEditWindowPassed,
+		"Message can no longer be edited",
+		403,
+	)
+}
+
+func ErrMatchInviteExpiredError() *AppError {
+	return NewAppError(
+		ErrCodeMatchInviteExpired,
+		"Invite code is invalid or has expired",
+		410,
+	)
+}
+
+func ErrPasswordRequiredError() *AppError {
+	return NewAppError(
+		ErrCodePasswordRequired,
+		"Password required",
+		401,
+	)
+}
+
+func ErrInvalidSharePasswordError() *AppError {
+	return NewAppError(
+		ErrCodeInvalidSharePassword,
+		"Invalid password",
+		401,
+	)
+}
+
+func ErrInvalidStatusChangeError(from, to string) *AppError {
+	return NewAppError(
+		ErrCodeInvalidStatusChange,
+		fmt.Sprintf("Cannot change status from %s to %s", from, to),
+		409,
+	)
+}
+
+func ErrOperationInProgressError(operation string) *AppError {
+	return NewAppError(
+		ErrCodeOperationInProgress,
+		fmt.Sprintf("%s is already in progress", operation),
+		409,
+	)
+}
+
+func ErrIdempotencyKeyReusedError() *AppError {
+	return NewAppError(
+		ErrCodeIdempotencyKeyReused,
+		"Idempotency-Key was already used for a different request",
+		422,
+	)
+}
+
+func ErrAlreadyMatchedError() *AppError {
+	return NewAppError(
+		ErrCodeAlreadyMatched,
+		"User is already matched with a partner",
+		409,
+	)
+}
+
+// ErrSettingsConflictError reports that the partner changed the couple's settings since
+// they were read, with the current settings as details to merge with
+func ErrSettingsConflictError(current *CoupleSettingsResponse) *AppError {
+	return NewAppError(
+		ErrCodeSettingsConflict,
+		"Settings were changed by your partner, review them and try again",
+		409,
+	).WithDetails(current)
+}
+
+func ErrAnswersRevealedError() *AppError {
+	return NewAppError(
+		ErrCodeAnswersRevealed,
+		"Both answers were revealed and can no longer be changed",
+		409,
+	)
+}
+
+func ErrMatchRequestExistsError() *AppError {
+	return NewAppError(
+		ErrCodeMatchRequestExists,
+		"A match request to this user is already pending",
+		409,
+	)
+}
+
+func ErrInvalidVerificationTokenError() *AppError {
+	return NewAppError(
+		ErrCodeInvalidVerificationToken,
+		"Invalid or expired verification token",
+		401,
+	)
+}
+
+func ErrInvalidResetTokenError() *AppError {
+	return NewAppError(
+		ErrCodeInvalidResetToken,
+		"Invalid or expired password reset token",
+		401,
+	)
+}
+
+func ErrEmailAlreadyVerifiedError() *AppError {
+	return NewAppError(
+		ErrCodeEmailAlreadyVerified,
+		"Email is already verified",
+		409,
+	)
+}
+
+func ErrTooManyRequestsError() *AppError {
+	return NewAppError(
+		ErrCodeTooManyRequests,
+		"Too many requests, please try again later",
+		429,
+	)
+}
+
+func ErrRateLimitExceededError() *AppError {
+	return NewAppError(
+		ErrCodeRateLimitExceeded,
+		"Rate limit exceeded, retry after the time in the Retry-After header",
+		429,
+	)
+}
+
+func ErrServiceUnavailableError(service string) *AppError {
+	return NewAppError(
+		ErrCodeServiceUnavailable,
+		fmt.Sprintf("%s is not available", service),
+		503,
+	)
+}
+
 // ErrUnauthorized is a simple error for unauthorized access
 var ErrUnauthorized = ErrUnauthorizedError()
+
+// Errors the repositories return for outcomes the services act on. Match them with
+// errors.Is.
+var (
+	ErrMessageNotFound               = errors.New("message not found")
+	ErrNotificationNotFound          = errors.New("notification not found")
+	ErrFeedbackNotFound              = errors.New("feedback not found")
+	ErrCoupleStreakNotFound          = errors.New("couple streak not found")
+	ErrCoupleSettingsNotFound        = errors.New("couple settings not found")
+	ErrCoupleSettingsRevisionChanged = errors.New("couple settings revision changed")
+	ErrEncryptionKeyVersionExists    = errors.New("encryption key version already exists")
+	ErrDailyQuestionNotFound         = errors.New("daily question not found")
+	ErrDailyQuestionAnswersRevealed  = errors.New("daily question answers revealed")
+)
@@ -16,12 +16,16 @@ type Event struct {
 	Description string             `json:"description,omitempty" bson:"description,omitempty"`
 	Date        time.Time          `json:"date" bson:"date"`
 	Time        string             `json:"time,omitempty" bson:"time,omitempty"`
-	Location    string             `json:"location,omitempty" bson:"location,omitempty"`
+	Timezone    string             `json:"timezone,omitempty" bson:"timezone,omitempty"` // IANA zone of Date and Time; UTC when empty
+	Location    string             `json:"location,omitempty" bson:"location,omitempty"` // display name, the place's name when it has one
+	Place       *Place             `json:"place,omitempty" bson:"place,omitempty"`
 	EventType   string             `json:"event_type" bson:"event_type" validate:"required,oneof=anniversary date milestone celebration other"`
 	IsRecurring bool               `json:"is_recurring" bson:"is_recurring"`
 	RecurrenceRule string          `json:"recurrence_rule,omitempty" bson:"recurrence_rule,omitempty"`
-	IsPrivate   bool               `json:"is_private" bson:"is_private"`
+	IsPrivate   bool               `json:"is_private" bson:"is_private"` // only visible to the partner who created it
 	Reminder    *EventReminder     `json:"reminder,omitempty" bson:"reminder,omitempty"`
+	AutoMilestone    AutoMilestoneKind `json:"auto_milestone,omitempty" bson:"auto_milestone,omitempty"` // set on system events generated from the anniversary date
+	AutoMilestoneKey string            `json:"-" bson:"auto_milestone_key,omitempty"`                    // identifies the generated milestone within the couple
 	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
 	DeletedAt   *time.Time         `json:"-" bson:"deleted_at,omitempty"`
@@ -39,9 +43,11 @@ type EventReminder struct {
 type CreateEventRequest struct {
 	Title          string         `json:"title" validate:"required,min=1,max=200"`
 	Description    string         `json:"description,omitempty"`
-	Date           time.Time      `json:"date" validate:"required"`
+	Date           Date           `json:"date" validate:"required"`
 	Time           string         `json:"time,omitempty"`
+	Timezone       string         `json:"timezone,omitempty" validate:"omitempty,timezone"` // defaults to the creator's, then the couple's time zone
 	Location       string         `json:"location,omitempty"`
+	Place          *Place         `json:"place,omitempty"` // takes precedence over location
 	EventType      string         `json:"event_type" validate:"required,oneof=anniversary date milestone celebration other"`
 	IsRecurring    bool           `json:"is_recurring"`
 	RecurrenceRule string         `json:"recurrence_rule,omitempty"`
@@ -53,9 +59,11 @@ type CreateEventRequest struct {
 type UpdateEventRequest struct {
 	Title          string         `json:"title,omitempty" validate:"omitempty,min=1,max=200"`
 	Description    string         `json:"description,omitempty"`
-	Date           *time.Time     `json:"date,omitempty"`
+	Date           *Date          `json:"date,omitempty"`
 	Time           string         `json:"time,omitempty"`
+	Timezone       string         `json:"timezone,omitempty" validate:"omitempty,timezone"`
 	Location       string         `json:"location,omitempty"`
+	Place          *Place         `json:"place,omitempty"` // takes precedence over location
 	EventType      string         `json:"event_type,omitempty" validate:"omitempty,oneof=anniversary date milestone celebration other"`
 	IsRecurring    *bool          `json:"is_recurring,omitempty"`
 	RecurrenceRule string         `json:"recurrence_rule,omitempty"`
@@ -70,18 +78,48 @@ type EventResponse struct {
 	CreatedBy      string         `json:"created_by"` // User ID who created this event
 	Title          string         `json:"title"`
 	Description    string         `json:"description,omitempty"`
-	Date           time.Time      `json:"date"`
+	Date           Date           `json:"date"`
 	Time           string         `json:"time,omitempty"`
+	Timezone       string         `json:"timezone"`
 	Location       string         `json:"location,omitempty"`
+	Place          *Place         `json:"place,omitempty"`
 	EventType      string         `json:"event_type"`
 	IsRecurring    bool           `json:"is_recurring"`
 	RecurrenceRule string         `json:"recurrence_rule,omitempty"`
 	IsPrivate      bool           `json:"is_private"`
 	Reminder       *EventReminder `json:"reminder,omitempty"`
+	AutoMilestone  AutoMilestoneKind `json:"auto_milestone,omitempty"`
+	IsSystem       bool           `json:"is_system"`
 	CreatedAt      time.Time      `json:"created_at"`
 	UpdatedAt      time.Time      `json:"updated_at"`
 }
 
+// IsVisibleTo reports whether the viewer may see the event: private events are only
+// visible to the partner who created them
+func (e *Event) IsVisibleTo(viewerID primitive.ObjectID) bool {
+	return e.CreatedBy == viewerID || !e.IsPrivate
+}
+
+// Zone returns the time zone of the event's date and time
+func (e *Event) Zone() *time.Location {
+	return LoadLocation(e.Timezone)
+}
+
+// EffectiveTimezone returns the name of the time zone of the event's date and time
+func (e *Event) EffectiveTimezone() string {
+	if e.Timezone == "" {
+		return DefaultTimezone
+	}
+	return e.Timezone
+}
+
+// StartsAt returns the instant the event starts: its date at its time of day, or at
+// midnight when it has none, in its time zone
+func (e *Event) StartsAt() time.Time {
+	offset, _ := ParseTimeOfDay(e.Time)
+	return AtTimeOfDay(e.Date, offset, e.Zone())
+}
+
 // ToResponse converts Event to EventResponse
 func (e *Event) ToResponse() *EventResponse {
 	return &EventResponse{
@@ -90,14 +128,18 @@ func (e *Event) ToResponse() *EventResponse {
 		CreatedBy:      e.CreatedBy.Hex(),
 		Title:          e.Title,
 		Description:    e.Description,
-		Date:           e.Date,
+		Date:           DateFromTime(e.Date),
 		Time:           e.Time,
+		Timezone:       e.EffectiveTimezone(),
 		Location:       e.Location,
+		Place:          e.Place,
 		EventType:      e.EventType,
 		IsRecurring:    e.IsRecurring,
 		RecurrenceRule: e.RecurrenceRule,
 		IsPrivate:      e.IsPrivate,
 		Reminder:       e.Reminder,
+		AutoMilestone:  e.AutoMilestone,
+		IsSystem:       e.AutoMilestone != "",
 		CreatedAt:      e.CreatedAt,
 		UpdatedAt:      e.UpdatedAt,
 	}
@@ -105,23 +147,68 @@ func (e *Event) ToResponse() *EventResponse {
 
 // EventListResponse represents a list of events response
 type EventListResponse struct {
-	Events []*EventResponse `json:"events"`
-	Total  int64            `json:"total"`
-	Page   int              `json:"page"`
-	Limit  int              `json:"limit"`
+	Events     []*EventResponse `json:"events"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page"`
+	Limit      int              `json:"limit"`
+	Formatting *FormattingHints `json:"formatting"`
 }
 
-// EventRepository defines the interface for event data access
+// EventRepository defines the interface for event data access. The couple's listings
+// only include the events visible to the viewer; GetByID does not filter, callers check
+// Event.IsVisibleTo.
 type EventRepository interface {
 	Create(event *Event) error
 	GetByID(id primitive.ObjectID) (*Event, error)
-	GetByMatchCode(matchCode string, limit, offset int) ([]*Event, error)
-	GetByMatchCodeAndDateRange(matchCode string, startDate, endDate time.Time) ([]*Event, error)
-	GetByMatchCodeAndDate(matchCode string, date time.Time) ([]*Event, error)
-	GetUpcomingByMatchCode(matchCode string, limit int) ([]*Event, error)
+	GetByMatchCode(matchCode string, viewerID primitive.ObjectID, limit, offset int) ([]*Event, error)
+	CountByMatchCode(matchCode string, viewerID primitive.ObjectID) (int64, error)
+	GetByMatchCodeAndDateRange(matchCode string, viewerID primitive.ObjectID, startDate, endDate time.Time) ([]*Event, error)
+	GetByMatchCodeAndDate(matchCode string, viewerID primitive.ObjectID, date time.Time) ([]*Event, error)
+	ListByDate(matchCode string, viewerID primitive.ObjectID, from, to *time.Time, cursor *Cursor, limit int) ([]*Event, error)
+	// GetOnThisDay lists the couple's events dated on the calendar day of date in
+	// earlier years, newest first, without generated milestone events
+	GetOnThisDay(matchCode string, viewerID primitive.ObjectID, date time.Time) ([]*Event, error)
+	// ListMatchCodesOnThisDay lists the couples with events dated on the calendar day
+	// of date in earlier years, without generated milestone events
+	ListMatchCodesOnThisDay(date time.Time) ([]string, error)
+	GetUpcomingByMatchCode(matchCode string, viewerID primitive.ObjectID, limit int) ([]*Event, error)
+	// GetUpcomingWithReminders lists the couple's events dated from onwards that have
+	// a reminder enabled, soonest first
+	GetUpcomingWithReminders(matchCode string, viewerID primitive.ObjectID, from time.Time, limit int) ([]*Event, error)
+	// GetDueReminders lists up to limit events whose enabled reminder is due by before
+	// and was not sent yet, oldest reminder first
+	GetDueReminders(before time.Time, limit int) ([]*Event, error)
+	// MarkReminderNotified records that the event's reminder was sent, and reports
+	// whether it was not recorded already
+	MarkReminderNotified(id primitive.ObjectID) (bool, error)
+	SearchByMatchCode(matchCode string, viewerID primitive.ObjectID, query string, limit int) ([]*Event, error)
 	DeleteByMatchCode(matchCode string) error
 	Update(id primitive.ObjectID, event *Event) error
 	Delete(id primitive.ObjectID) error
+
+	// Soft delete management
+	Restore(id primitive.ObjectID) error
+	HardDelete(id primitive.ObjectID) error
+	GetDeleted(matchCode string, id primitive.ObjectID) (*Event, error)
+	ListDeleted(matchCode string, viewerID primitive.ObjectID, limit, offset int) ([]*Event, error)
+	PurgeDeletedBefore(cutoff time.Time) (int64, error)
+
+	// Bulk operations
+	BulkDelete(matchCode string, viewerID primitive.ObjectID, ids []primitive.ObjectID) ([]primitive.ObjectID, error)
+
+	// Generated milestones
+	// CreateAutoMilestone stores a generated milestone event unless the couple already
+	// has one with its key, even in the trash, and reports whether it did
+	CreateAutoMilestone(event *Event) (bool, error)
+	// DeleteAutoMilestones permanently deletes the couple's generated milestone events
+	// of the given kinds dated from onwards
+	DeleteAutoMilestones(matchCode string, kinds []AutoMilestoneKind, from time.Time) (int64, error)
+
+	// Account merges
+	ReassignCreator(fromUserID, toUserID primitive.ObjectID) (int64, error)
+
+	// Usage analytics, across couples and including events in the trash
+	CountCreatedPerDay(from, to time.Time) ([]*DailyCount, error)
 }
 
 // EventService defines the interface for event business logic
@@ -131,4 +218,7 @@ type EventService interface {
 	GetCoupleEvents(ctx context.Context, userID primitive.ObjectID, year, month, page, limit int) ([]*EventResponse, int64, error)
 	UpdateEvent(ctx context.Context, eventID, userID primitive.ObjectID, req *UpdateEventRequest) (*EventResponse, error)
 	DeleteEvent(ctx context.Context, eventID, userID primitive.ObjectID) error
+	BulkDeleteEvents(ctx context.Context, userID primitive.ObjectID, req *BulkIDsRequest) (*BulkResponse, error)
+	// SendDueReminders notifies couples of the events whose reminder is due
+	SendDueReminders(ctx context.Context) error
 }
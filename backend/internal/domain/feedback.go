@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FeedbackCategory represents what a piece of user feedback is about
+type FeedbackCategory string
+
+const (
+	FeedbackCategoryBug     FeedbackCategory = "bug"
+	FeedbackCategoryFeature FeedbackCategory = "feature"
+	FeedbackCategoryGeneral FeedbackCategory = "general"
+	FeedbackCategoryNPS     FeedbackCategory = "nps"
+)
+
+// FeedbackStatus represents where a piece of feedback is in the team's triage workflow
+type FeedbackStatus string
+
+const (
+	FeedbackStatusNew        FeedbackStatus = "new"
+	FeedbackStatusTriaged    FeedbackStatus = "triaged"
+	FeedbackStatusInProgress FeedbackStatus = "in_progress"
+	FeedbackStatusResolved   FeedbackStatus = "resolved"
+	FeedbackStatusClosed     FeedbackStatus = "closed"
+)
+
+// feedbackTransitions lists the statuses each status may move to. Resolved and
+// closed feedback can be reopened by moving it back to triaged.
+var feedbackTransitions = map[FeedbackStatus][]FeedbackStatus{
+	FeedbackStatusNew:        {FeedbackStatusTriaged, FeedbackStatusClosed},
+	FeedbackStatusTriaged:    {FeedbackStatusInProgress, FeedbackStatusResolved, FeedbackStatusClosed},
+	FeedbackStatusInProgress: {FeedbackStatusResolved, FeedbackStatusClosed},
+	FeedbackStatusResolved:   {FeedbackStatusClosed, FeedbackStatusTriaged},
+	FeedbackStatusClosed:     {FeedbackStatusTriaged},
+}
+
+// CanTransitionTo reports whether feedback in status s may be moved to next
+func (s FeedbackStatus) CanTransitionTo(next FeedbackStatus) bool {
+	for _, allowed := range feedbackTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Feedback represents feedback or an NPS answer sent by a user
+type Feedback struct {
+	ID            primitive.ObjectID     `json:"id" bson:"_id,omitempty"`
+	UserID        primitive.ObjectID     `json:"user_id" bson:"user_id"`
+	Category      FeedbackCategory       `json:"category" bson:"category"`
+	Rating        *int                   `json:"rating,omitempty" bson:"rating,omitempty"`
+	Text          string                 `json:"text,omitempty" bson:"text,omitempty"`
+	AppVersion    string                 `json:"app_version,omitempty" bson:"app_version,omitempty"`
+	Attachments   []string               `json:"attachments,omitempty" bson:"attachments,omitempty"`
+	Status        FeedbackStatus         `json:"status" bson:"status"`
+	StatusHistory []FeedbackStatusChange `json:"status_history,omitempty" bson:"status_history,omitempty"`
+	MirrorID      string                 `json:"mirror_id,omitempty" bson:"mirror_id,omitempty"`
+	CreatedAt     time.Time              `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at" bson:"updated_at"`
+}
+
+// FeedbackStatusChange records a move of a piece of feedback between statuses
+type FeedbackStatusChange struct {
+	From      FeedbackStatus `json:"from" bson:"from"`
+	To        FeedbackStatus `json:"to" bson:"to"`
+	Note      string         `json:"note,omitempty" bson:"note,omitempty"`
+	ChangedAt time.Time      `json:"changed_at" bson:"changed_at"`
+}
+
+// CreateFeedbackRequest represents the request to send feedback. Rating is 0-10 for
+// NPS answers and 1-5 for other categories; NPS answers need a rating and every
+// other category needs text. Attachments are keys of files uploaded through /upload.
+type CreateFeedbackRequest struct {
+	Category    FeedbackCategory `json:"category" validate:"required,oneof=bug feature general nps"`
+	Rating      *int             `json:"rating,omitempty" validate:"omitempty,min=0,max=10"`
+	Text        string           `json:"text,omitempty" validate:"omitempty,max=5000"`
+	AppVersion  string           `json:"app_version,omitempty" validate:"omitempty,max=50"`
+	Attachments []string         `json:"attachments,omitempty" validate:"omitempty,max=5,dive,required,max=512"`
+}
+
+// UpdateFeedbackStatusRequest represents the request to move feedback to another status
+type UpdateFeedbackStatusRequest struct {
+	Status FeedbackStatus `json:"status" validate:"required,oneof=new triaged in_progress resolved closed"`
+	Note   string         `json:"note,omitempty" validate:"omitempty,max=2000"`
+}
+
+// FeedbackResponse represents the API response for a piece of feedback
+type FeedbackResponse struct {
+	ID            string                 `json:"id"`
+	UserID        string                 `json:"user_id"`
+	Category      FeedbackCategory       `json:"category"`
+	Rating        *int                   `json:"rating,omitempty"`
+	Text          string                 `json:"text,omitempty"`
+	AppVersion    string                 `json:"app_version,omitempty"`
+	Attachments   []string               `json:"attachments,omitempty"`
+	Status        FeedbackStatus         `json:"status"`
+	StatusHistory []FeedbackStatusChange `json:"status_history,omitempty"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
+
+// ToResponse converts Feedback to FeedbackResponse
+func (f *Feedback) ToResponse() *FeedbackResponse {
+	return &FeedbackResponse{
+		ID:            f.ID.Hex(),
+		UserID:        f.UserID.Hex(),
+		Category:      f.Category,
+		Rating:        f.Rating,
+		Text:          f.Text,
+		AppVersion:    f.AppVersion,
+		Attachments:   f.Attachments,
+		Status:        f.Status,
+		StatusHistory: f.StatusHistory,
+		CreatedAt:     f.CreatedAt,
+		UpdatedAt:     f.UpdatedAt,
+	}
+}
+
+// FeedbackFilter narrows the admin feedback listing. Empty fields match everything.
+type FeedbackFilter struct {
+	Status   FeedbackStatus
+	Category FeedbackCategory
+}
+
+// FeedbackListResponse represents a page of feedback, newest first
+type FeedbackListResponse struct {
+	Feedback   []*FeedbackResponse `json:"feedback"`
+	Limit      int                 `json:"limit"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+// FeedbackRepository defines the interface for feedback data access
+type FeedbackRepository interface {
+	Create(ctx context.Context, feedback *Feedback) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Feedback, error)
+	CountByUserSince(ctx context.Context, userID primitive.ObjectID, since time.Time) (int64, error)
+	List(ctx context.Context, filter FeedbackFilter, cursor *Cursor, limit int) ([]*Feedback, error)
+	UpdateStatus(ctx context.Context, id primitive.ObjectID, from FeedbackStatus, change FeedbackStatusChange) error
+	SetMirrorID(ctx context.Context, id primitive.ObjectID, mirrorID string) error
+}
+
+// FeedbackMirror copies feedback to the tool the team triages it in
+type FeedbackMirror interface {
+	MirrorFeedback(ctx context.Context, feedback *Feedback) (string, error)
+}
+
+// FeedbackService defines the interface for feedback business logic
+type FeedbackService interface {
+	SubmitFeedback(ctx context.Context, userID primitive.ObjectID, req *CreateFeedbackRequest) (*FeedbackResponse, error)
+	ListFeedback(ctx context.Context, filter FeedbackFilter, cursor *Cursor, limit int) (*FeedbackListResponse, error)
+	UpdateFeedbackStatus(ctx context.Context, feedbackID primitive.ObjectID, req *UpdateFeedbackStatusRequest) (*FeedbackResponse, error)
+}
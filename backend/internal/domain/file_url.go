@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cdnBaseURL is the URL of the CDN that fronts the file proxy, or empty when files
+// are loaded from the proxy directly
+var cdnBaseURL string
+
+// SetCDNBaseURL makes FileURL point at the CDN at baseURL. It is called once at
+// startup; an empty baseURL turns the CDN off.
+func SetCDNBaseURL(baseURL string) {
+	cdnBaseURL = strings.TrimRight(baseURL, "/")
+}
+
+// FileURL returns the URL clients load the file stored under key from. Without a CDN
+// it is the key itself, which clients prepend the file proxy path to. With a CDN it
+// is the file's CDN URL, versioned with the time the file last changed so that
+// caches can keep it for good.
+func FileURL(key string, version time.Time) string {
+	if cdnBaseURL == "" || key == "" || strings.HasPrefix(key, "http://") || strings.HasPrefix(key, "https://") {
+		return key
+	}
+
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	fileURL := cdnBaseURL + "/" + strings.Join(segments, "/")
+	if !version.IsZero() {
+		fileURL += "?v=" + strconv.FormatInt(version.Unix(), 10)
+	}
+	return fileURL
+}
+
+// cdnFileKey returns the storage key of a CDN URL built by FileURL, and false when
+// fileURL is not one
+func cdnFileKey(fileURL string) (string, bool) {
+	if cdnBaseURL == "" || !strings.HasPrefix(fileURL, cdnBaseURL+"/") {
+		return "", false
+	}
+
+	key, _, _ := strings.Cut(strings.TrimPrefix(fileURL, cdnBaseURL+"/"), "?")
+	if unescaped, err := url.PathUnescape(key); err == nil {
+		key = unescaped
+	}
+	return key, true
+}
+
+// IsImageVariantKey reports whether key holds a resized variant of an image rather
+// than the image itself. Variants are stored next to it as "<name>_<variant>.<ext>".
+func IsImageVariantKey(key string) bool {
+	name := path.Base(key)
+	name = strings.TrimSuffix(name, path.Ext(name))
+	for variant := range ImageVariantSizes {
+		if strings.HasSuffix(name, "_"+string(variant)) {
+			return true
+		}
+	}
+	return false
+}
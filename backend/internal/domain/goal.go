@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GoalStatus represents the status of a couple goal
+type GoalStatus string
+
+const (
+	GoalStatusActive    GoalStatus = "active"
+	GoalStatusCompleted GoalStatus = "completed"
+	GoalStatusArchived  GoalStatus = "archived"
+)
+
+// Goal represents a shared couple goal tracked through progress check-ins
+type Goal struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	MatchCode      string             `json:"match_code" bson:"match_code" validate:"required"`
+	CreatedBy      primitive.ObjectID `json:"created_by" bson:"created_by" validate:"required"`
+	Title          string             `json:"title" bson:"title" validate:"required,min=1,max=200"`
+	Description    string             `json:"description,omitempty" bson:"description,omitempty"`
+	TargetDate     *time.Time         `json:"target_date,omitempty" bson:"target_date,omitempty"`
+	Progress       int                `json:"progress" bson:"progress" validate:"min=0,max=100"`
+	Status         GoalStatus         `json:"status" bson:"status"`
+	CheckIns       []GoalCheckIn      `json:"check_ins" bson:"check_ins"`
+	LastReminderAt *time.Time         `json:"-" bson:"last_reminder_at,omitempty"`
+	CompletedAt    *time.Time         `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at" bson:"updated_at"`
+	DeletedAt      *time.Time         `json:"-" bson:"deleted_at,omitempty"`
+}
+
+// GoalCheckIn represents a progress update on a goal
+type GoalCheckIn struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Progress  int                `json:"progress" bson:"progress"`
+	Note      string             `json:"note,omitempty" bson:"note,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// CreateGoalRequest represents the request to create a new goal
+type CreateGoalRequest struct {
+	Title       string `json:"title" validate:"required,min=1,max=200"`
+	Description string `json:"description,omitempty" validate:"omitempty,max=2000"`
+	TargetDate  *Date  `json:"target_date,omitempty"`
+	Progress    int    `json:"progress" validate:"min=0,max=100"`
+}
+
+// UpdateGoalRequest represents the request to update a goal
+type UpdateGoalRequest struct {
+	Title       string     `json:"title,omitempty" validate:"omitempty,min=1,max=200"`
+	Description string     `json:"description,omitempty" validate:"omitempty,max=2000"`
+	TargetDate  *Date      `json:"target_date,omitempty"`
+	Status      GoalStatus `json:"status,omitempty" validate:"omitempty,oneof=active completed archived"`
+}
+
+// GoalCheckInRequest represents the request to record progress on a goal
+type GoalCheckInRequest struct {
+	Progress *int   `json:"progress" validate:"required,min=0,max=100"`
+	Note     string `json:"note,omitempty" validate:"omitempty,max=1000"`
+}
+
+// GoalCheckInResponse represents the API response for a goal check-in
+type GoalCheckInResponse struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Progress  int       `json:"progress"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GoalResponse represents the API response for a goal
+type GoalResponse struct {
+	ID          string                 `json:"id"`
+	MatchCode   string                 `json:"match_code"`
+	CreatedBy   string                 `json:"created_by"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description,omitempty"`
+	TargetDate  *Date                  `json:"target_date,omitempty"`
+	Progress    int                    `json:"progress"`
+	Status      GoalStatus             `json:"status"`
+	CheckIns    []*GoalCheckInResponse `json:"check_ins"`
+	CompletedAt *time.Time             `json:"completed_at,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+// ToResponse converts Goal to GoalResponse
+func (g *Goal) ToResponse() *GoalResponse {
+	checkIns := make([]*GoalCheckInResponse, 0, len(g.CheckIns))
+	for _, checkIn := range g.CheckIns {
+		checkIns = append(checkIns, &GoalCheckInResponse{
+			ID:        checkIn.ID.Hex(),
+			UserID:    checkIn.UserID.Hex(),
+			Progress:  checkIn.Progress,
+			Note:      checkIn.Note,
+			CreatedAt: checkIn.CreatedAt,
+		})
+	}
+
+	return &GoalResponse{
+		ID:          g.ID.Hex(),
+		MatchCode:   g.MatchCode,
+		CreatedBy:   g.CreatedBy.Hex(),
+		Title:       g.Title,
+		Description: g.Description,
+		TargetDate:  DateFromTimePtr(g.TargetDate),
+		Progress:    g.Progress,
+		Status:      g.Status,
+		CheckIns:    checkIns,
+		CompletedAt: g.CompletedAt,
+		CreatedAt:   g.CreatedAt,
+		UpdatedAt:   g.UpdatedAt,
+	}
+}
+
+// GoalListResponse represents a list of goals response
+type GoalListResponse struct {
+	Goals []*GoalResponse `json:"goals"`
+	Total int64           `json:"total"`
+	Page  int             `json:"page"`
+	Limit int             `json:"limit"`
+}
+
+// GoalSummaryResponse represents the dashboard summary of a couple's goals
+type GoalSummaryResponse struct {
+	ActiveCount     int64           `json:"active_count"`
+	CompletedCount  int64           `json:"completed_count"`
+	OverdueCount    int             `json:"overdue_count"`
+	AverageProgress int             `json:"average_progress"`
+	DueSoon         []*GoalResponse `json:"due_soon"`
+	ActiveGoals     []*GoalResponse `json:"active_goals"`
+}
+
+// GoalRepository defines the interface for goal data access
+type GoalRepository interface {
+	Create(ctx context.Context, goal *Goal) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*Goal, error)
+	GetByMatchCode(ctx context.Context, matchCode string, status GoalStatus, limit, offset int) ([]*Goal, error)
+	CountByMatchCode(ctx context.Context, matchCode string, status GoalStatus) (int64, error)
+	GetDueForReminder(ctx context.Context, dueBefore, remindedBefore time.Time) ([]*Goal, error)
+	Update(ctx context.Context, id primitive.ObjectID, goal *Goal) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// GoalService defines the interface for goal business logic
+type GoalService interface {
+	CreateGoal(ctx context.Context, userID primitive.ObjectID, req *CreateGoalRequest) (*GoalResponse, error)
+	GetGoal(ctx context.Context, goalID, userID primitive.ObjectID) (*GoalResponse, error)
+	GetCoupleGoals(ctx context.Context, userID primitive.ObjectID, status GoalStatus, page, limit int) ([]*GoalResponse, int64, error)
+	UpdateGoal(ctx context.Context, goalID, userID primitive.ObjectID, req *UpdateGoalRequest) (*GoalResponse, error)
+	DeleteGoal(ctx context.Context, goalID, userID primitive.ObjectID) error
+	CheckIn(ctx context.Context, goalID, userID primitive.ObjectID, req *GoalCheckInRequest) (*GoalResponse, error)
+	GetSummary(ctx context.Context, userID primitive.ObjectID) (*GoalSummaryResponse, error)
+	SendDueReminders(ctx context.Context) error
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PersonInsight represents the zodiac and numerology profile of one partner
+type PersonInsight struct {
+	Name           string        `json:"name"`
+	ZodiacSign     ZodiacSign    `json:"zodiac_sign,omitempty"`
+	ZodiacElement  ZodiacElement `json:"zodiac_element,omitempty"`
+	ZodiacText     string        `json:"zodiac_text,omitempty"`
+	LifePathNumber int           `json:"life_path_number,omitempty"`
+	LifePathText   string        `json:"life_path_text,omitempty"`
+}
+
+// CompatibilityInsight represents a compatibility score with its description
+type CompatibilityInsight struct {
+	Score int    `json:"score"`
+	Text  string `json:"text"`
+}
+
+// DayMilestone represents a number-of-days milestone counted from the anniversary date
+type DayMilestone struct {
+	Days      int    `json:"days"`
+	Date      Date   `json:"date"`
+	DaysUntil int    `json:"days_until"`
+	Text      string `json:"text"`
+}
+
+// FunInsightsResponse represents the fun insights for a couple
+type FunInsightsResponse struct {
+	User                    PersonInsight         `json:"user"`
+	Partner                 *PersonInsight        `json:"partner,omitempty"`
+	ZodiacCompatibility     *CompatibilityInsight `json:"zodiac_compatibility,omitempty"`
+	NumerologyCompatibility *CompatibilityInsight `json:"numerology_compatibility,omitempty"`
+	AnniversaryDate         *Date                 `json:"anniversary_date,omitempty"`
+	DaysTogether            int                   `json:"days_together"`
+	ReachedMilestones       []DayMilestone        `json:"reached_milestones"`
+	NextMilestone           *DayMilestone         `json:"next_milestone,omitempty"`
+}
+
+// InsightService defines the interface for fun insights business logic
+type InsightService interface {
+	GetFunInsights(ctx context.Context, userID primitive.ObjectID, locale string) (*FunInsightsResponse, error)
+}
@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// JournalEntry is a note or letter one partner writes in the couple journal.
+// Private entries are only visible to their author. Entries with an unlock date
+// ("open when...") are shown to the partner sealed, without their content, until
+// that day.
+type JournalEntry struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	MatchCode string             `json:"match_code" bson:"match_code"`
+	AuthorID  primitive.ObjectID `json:"author_id" bson:"author_id"`
+	Title     string             `json:"title" bson:"title"`
+	Content   string             `json:"content" bson:"content"`
+	Date      time.Time          `json:"date" bson:"date"`
+	IsPrivate bool               `json:"is_private" bson:"is_private"`
+	UnlockAt  *time.Time         `json:"unlock_at,omitempty" bson:"unlock_at,omitempty"` // midnight UTC of the unlock day
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+	DeletedAt *time.Time         `json:"-" bson:"deleted_at,omitempty"`
+}
+
+// IsVisibleTo reports whether the viewer may see the entry at all
+func (e *JournalEntry) IsVisibleTo(viewerID primitive.ObjectID) bool {
+	return e.AuthorID == viewerID || !e.IsPrivate
+}
+
+// IsLockedFor reports whether the entry is still sealed for the viewer. Authors can
+// always read their own entries.
+func (e *JournalEntry) IsLockedFor(viewerID primitive.ObjectID, now time.Time) bool {
+	return e.AuthorID != viewerID && e.UnlockAt != nil && now.Before(*e.UnlockAt)
+}
+
+// CreateJournalEntryRequest represents the request to write a journal entry
+type CreateJournalEntryRequest struct {
+	Title     string `json:"title" validate:"required,min=1,max=200"`
+	Content   string `json:"content" validate:"required,min=1,max=20000"`
+	Date      *Date  `json:"date"` // defaults to today
+	IsPrivate bool   `json:"is_private"`
+	UnlockAt  *Date  `json:"unlock_at"` // day the partner can open the entry
+}
+
+// UpdateJournalEntryRequest represents the request to update a journal entry
+type UpdateJournalEntryRequest struct {
+	Title     string `json:"title,omitempty" validate:"omitempty,min=1,max=200"`
+	Content   string `json:"content,omitempty" validate:"omitempty,max=20000"`
+	Date      *Date  `json:"date,omitempty"`
+	IsPrivate *bool  `json:"is_private,omitempty"`
+	UnlockAt  *Date  `json:"unlock_at,omitempty"` // an empty string unseals the entry
+}
+
+// JournalEntryResponse represents the API response for a journal entry. The
+// content of a sealed entry is left out.
+type JournalEntryResponse struct {
+	ID        string    `json:"id"`
+	AuthorID  string    `json:"author_id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content,omitempty"`
+	Date      Date      `json:"date"`
+	IsPrivate bool      `json:"is_private"`
+	UnlockAt  *Date     `json:"unlock_at,omitempty"`
+	IsLocked  bool      `json:"is_locked"`
+	IsMine    bool      `json:"is_mine"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ToResponse converts JournalEntry to JournalEntryResponse as the viewer sees it
+func (e *JournalEntry) ToResponse(viewerID primitive.ObjectID, now time.Time) *JournalEntryResponse {
+	response := &JournalEntryResponse{
+		ID:        e.ID.Hex(),
+		AuthorID:  e.AuthorID.Hex(),
+		Title:     e.Title,
+		Content:   e.Content,
+		Date:      DateFromTime(e.Date),
+		IsPrivate: e.IsPrivate,
+		UnlockAt:  DateFromTimePtr(e.UnlockAt),
+		IsLocked:  e.IsLockedFor(viewerID, now),
+		IsMine:    e.AuthorID == viewerID,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+	}
+
+	if response.IsLocked {
+		response.Content = ""
+	}
+
+	return response
+}
+
+// JournalListResponse represents a page of the couple journal
+type JournalListResponse struct {
+	Entries []*JournalEntryResponse `json:"entries"`
+	Total   int64                   `json:"total"`
+	Page    int                     `json:"page"`
+	Limit   int                     `json:"limit"`
+}
+
+// JournalRepository defines the interface for journal data access. Listings only
+// include the entries visible to the viewer.
+type JournalRepository interface {
+	Create(ctx context.Context, entry *JournalEntry) error
+	GetByID(ctx context.Context, matchCode string, id primitive.ObjectID) (*JournalEntry, error)
+	GetVisible(ctx context.Context, matchCode string, viewerID primitive.ObjectID, limit, offset int) ([]*JournalEntry, error)
+	CountVisible(ctx context.Context, matchCode string, viewerID primitive.ObjectID) (int64, error)
+	ListByDate(ctx context.Context, matchCode string, viewerID primitive.ObjectID, from, to *time.Time, cursor *Cursor, limit int) ([]*JournalEntry, error)
+	Update(ctx context.Context, entry *JournalEntry) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+
+	// Search retrieves the couple's entries the viewer can see whose title, or content
+	// when it is not sealed for the viewer, contains query
+	Search(ctx context.Context, matchCode string, viewerID primitive.ObjectID, query string, now time.Time, limit int) ([]*JournalEntry, error)
+
+	// Soft delete management. Deleted entries are only listed to their author.
+	Restore(ctx context.Context, id primitive.ObjectID) error
+	HardDelete(ctx context.Context, id primitive.ObjectID) error
+	GetDeleted(ctx context.Context, matchCode string, id primitive.ObjectID) (*JournalEntry, error)
+	ListDeleted(ctx context.Context, matchCode string, authorID primitive.ObjectID, limit int) ([]*JournalEntry, error)
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// JournalService defines the interface for journal business logic
+type JournalService interface {
+	CreateEntry(ctx context.Context, userID primitive.ObjectID, req *CreateJournalEntryRequest) (*JournalEntryResponse, error)
+	GetEntry(ctx context.Context, entryID, userID primitive.ObjectID) (*JournalEntryResponse, error)
+	GetEntries(ctx context.Context, userID primitive.ObjectID, page, limit int) (*JournalListResponse, error)
+	UpdateEntry(ctx context.Context, entryID, userID primitive.ObjectID, req *UpdateJournalEntryRequest) (*JournalEntryResponse, error)
+	DeleteEntry(ctx context.Context, entryID, userID primitive.ObjectID) error
+}
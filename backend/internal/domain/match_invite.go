@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MatchInvite is a short-lived code a user shares with their partner, as a link or a
+// QR code, so the partner can match with them without knowing their email. Only the
+// hash of the code is stored, and a user has at most one invite at a time.
+type MatchInvite struct {
+	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	CodeHash        string             `json:"-" bson:"code_hash"`
+	SenderID        primitive.ObjectID `json:"sender_id" bson:"sender_id"`
+	AnniversaryDate time.Time          `json:"anniversary_date" bson:"anniversary_date"`
+	Message         string             `json:"message,omitempty" bson:"message,omitempty"`
+	ExpiresAt       time.Time          `json:"expires_at" bson:"expires_at"`
+	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// CreateMatchInviteRequest represents the request to create a match invite
+type CreateMatchInviteRequest struct {
+	AnniversaryDate Date   `json:"anniversary_date" validate:"required,lte"`
+	Message         string `json:"message,omitempty" validate:"max=500"`
+}
+
+// AcceptMatchInviteRequest represents the request to redeem a match invite
+type AcceptMatchInviteRequest struct {
+	Code            string `json:"code" validate:"required"`
+	AnniversaryDate *Date  `json:"anniversary_date,omitempty" validate:"omitempty,lte"`
+}
+
+// MatchInviteResponse represents a newly created match invite. The code is only
+// returned here; creating another invite replaces it.
+type MatchInviteResponse struct {
+	Code      string    `json:"code"`
+	Link      string    `json:"link"`
+	QRCode    string    `json:"qr_code"` // PNG of the link, as a data URL
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// MatchInviteRepository defines the interface for match invite data access
+type MatchInviteRepository interface {
+	// Replace stores an invite in place of the sender's previous ones
+	Replace(ctx context.Context, invite *MatchInvite) error
+	GetByCodeHash(ctx context.Context, codeHash string, now time.Time) (*MatchInvite, error)
+	// Consume deletes an invite and reports whether it was still there, so an invite
+	// is redeemed only once
+	Consume(ctx context.Context, id primitive.ObjectID) (bool, error)
+}
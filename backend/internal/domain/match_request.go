@@ -34,14 +34,14 @@ type MatchRequest struct {
 // CreateMatchRequestRequest represents the request to create a match request
 type CreateMatchRequestRequest struct {
 	ReceiverEmail   string    `json:"receiver_email" validate:"required,email"`
-	AnniversaryDate time.Time `json:"anniversary_date" validate:"required"`
+	AnniversaryDate Date      `json:"anniversary_date" validate:"required,lte"`
 	Message         string    `json:"message,omitempty"`
 }
 
 // RespondToMatchRequestRequest represents the request to respond to a match request
 type RespondToMatchRequestRequest struct {
 	Action          string     `json:"action" validate:"required,oneof=accept reject"`
-	AnniversaryDate *time.Time `json:"anniversary_date,omitempty"`
+	AnniversaryDate *Date      `json:"anniversary_date,omitempty" validate:"omitempty,lte"`
 }
 
 // MatchRequestResponse represents the match request response
@@ -52,7 +52,7 @@ type MatchRequestResponse struct {
 	SenderEmail     string              `json:"sender_email,omitempty"`
 	ReceiverID      primitive.ObjectID  `json:"receiver_id"`
 	ReceiverEmail   string              `json:"receiver_email"`
-	AnniversaryDate time.Time           `json:"anniversary_date"`
+	AnniversaryDate Date                `json:"anniversary_date"`
 	Message         string              `json:"message,omitempty"`
 	Status          MatchRequestStatus  `json:"status"`
 	CreatedAt       time.Time           `json:"created_at"`
@@ -67,7 +67,7 @@ func (mr *MatchRequest) ToResponse() *MatchRequestResponse {
 		SenderID:        mr.SenderID,
 		ReceiverID:      mr.ReceiverID,
 		ReceiverEmail:   mr.ReceiverEmail,
-		AnniversaryDate: mr.AnniversaryDate,
+		AnniversaryDate: DateFromTime(mr.AnniversaryDate),
 		Message:         mr.Message,
 		Status:          mr.Status,
 		CreatedAt:       mr.CreatedAt,
@@ -82,11 +82,19 @@ type MatchRequestRepository interface {
 	GetByID(id primitive.ObjectID) (*MatchRequest, error)
 	GetBySenderID(senderID primitive.ObjectID, limit, offset int) ([]*MatchRequest, error)
 	GetByReceiverID(receiverID primitive.ObjectID, limit, offset int) ([]*MatchRequest, error)
+	GetBySenderIDAndStatus(senderID primitive.ObjectID, status MatchRequestStatus, limit, offset int) ([]*MatchRequest, error)
+	GetByReceiverIDAndStatus(receiverID primitive.ObjectID, status MatchRequestStatus, limit, offset int) ([]*MatchRequest, error)
+	CountBySenderIDAndStatus(senderID primitive.ObjectID, status MatchRequestStatus) (int64, error)
+	CountByReceiverIDAndStatus(receiverID primitive.ObjectID, status MatchRequestStatus) (int64, error)
 	GetByReceiverEmail(email string, limit, offset int) ([]*MatchRequest, error)
 	GetPendingByReceiverID(receiverID primitive.ObjectID) ([]*MatchRequest, error)
 	Update(id primitive.ObjectID, matchRequest *MatchRequest) error
 	Delete(id primitive.ObjectID) error
 	ExistsPendingRequest(senderID, receiverID primitive.ObjectID) (bool, error)
+
+	// Data retention
+	ListStaleIDs(before time.Time, afterID primitive.ObjectID, limit int) ([]primitive.ObjectID, error)
+	PurgeStale(ids []primitive.ObjectID, before time.Time) (int64, error)
 }
 
 // MatchRequestListResponse represents a list of match requests response
@@ -105,4 +113,8 @@ type MatchRequestService interface {
 	GetReceivedRequests(ctx context.Context, userID primitive.ObjectID, status string, page, limit int) ([]*MatchRequestResponse, int64, error)
 	RespondToMatchRequest(ctx context.Context, requestID, userID primitive.ObjectID, req *RespondToMatchRequestRequest) (*MatchRequestResponse, error)
 	CancelMatchRequest(ctx context.Context, requestID, userID primitive.ObjectID) error
+	// CreateInvite creates an invite code the user can share with their partner
+	CreateInvite(ctx context.Context, senderID primitive.ObjectID, req *CreateMatchInviteRequest) (*MatchInviteResponse, error)
+	// AcceptInvite redeems an invite code, matching the user with its sender
+	AcceptInvite(ctx context.Context, userID primitive.ObjectID, req *AcceptMatchInviteRequest) (*MatchRequestResponse, error)
 }
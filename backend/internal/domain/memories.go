@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MemoryYear holds the photos and events of one earlier year that fall on the
+// requested calendar day
+type MemoryYear struct {
+	Year     int              `json:"year"`
+	YearsAgo int              `json:"years_ago"`
+	Photos   []*PhotoResponse `json:"photos"`
+	Events   []*EventResponse `json:"events"`
+}
+
+// MemoriesResponse represents the couple's memories from the same calendar day in
+// earlier years, most recent year first
+type MemoriesResponse struct {
+	Date       Date             `json:"date"`
+	Years      []*MemoryYear    `json:"years"`
+	Total      int              `json:"total"` // photos and events of every year
+	Formatting *FormattingHints `json:"formatting"`
+}
+
+// MemoriesService defines the interface for "on this day" memories
+type MemoriesService interface {
+	// GetOnThisDay lists the couple's photos and events from the calendar day of date
+	// in earlier years
+	GetOnThisDay(ctx context.Context, userID primitive.ObjectID, date time.Time) (*MemoriesResponse, error)
+	// SendDigests sends today's digest to the partners who opted into it and have
+	// memories from this day, once a day
+	SendDigests(ctx context.Context) error
+}
@@ -18,8 +18,11 @@ type Message struct {
 	IsDeleted  bool               `bson:"is_deleted" json:"is_deleted"`
 	CreatedAt  time.Time          `bson:"created_at" json:"created_at"`
 	UpdatedAt  time.Time          `bson:"updated_at" json:"updated_at"`
+	DeliveredAt *time.Time        `bson:"delivered_at,omitempty" json:"delivered_at,omitempty"` // set once the receiver's app acknowledged it
 	ReadAt     *time.Time         `bson:"read_at,omitempty" json:"read_at,omitempty"`
+	EditedAt   *time.Time         `bson:"edited_at,omitempty" json:"edited_at,omitempty"`
 	DeletedAt  *time.Time         `json:"-" bson:"deleted_at,omitempty"`
+	HiddenFor  []primitive.ObjectID `json:"-" bson:"hidden_for,omitempty"` // users who deleted it for themselves only
 }
 
 // Conversation represents a conversation summary
@@ -39,11 +42,51 @@ type CreateMessageRequest struct {
 	MessageType string             `json:"message_type" validate:"omitempty,oneof=text image"`
 }
 
+// UpdateMessageRequest represents the request to edit a message
+type UpdateMessageRequest struct {
+	Content string `json:"content" validate:"required,min=1,max=1000"`
+}
+
+// MessageDeleteMode tells who a message is deleted for
+type MessageDeleteMode string
+
+const (
+	// MessageDeleteForEveryone deletes a message the user sent for both partners
+	MessageDeleteForEveryone MessageDeleteMode = "everyone"
+	// MessageDeleteForMe hides a message the user sent or received from the user only
+	MessageDeleteForMe MessageDeleteMode = "me"
+)
+
 // MarkAsReadRequest represents the request to mark messages as read
 type MarkAsReadRequest struct {
 	PartnerID primitive.ObjectID `json:"partner_id" validate:"required"`
 }
 
+// MessageReceiptStatus is how far a message got to its receiver
+type MessageReceiptStatus string
+
+const (
+	MessageReceiptDelivered MessageReceiptStatus = "delivered"
+	MessageReceiptRead      MessageReceiptStatus = "read"
+)
+
+// AckMessagesRequest represents the request to acknowledge messages the user received
+// as delivered or read. Reading a message also delivers it.
+type AckMessagesRequest struct {
+	Status     MessageReceiptStatus `json:"status" validate:"required,oneof=delivered read"`
+	MessageIDs []primitive.ObjectID `json:"message_ids" validate:"required,min=1,max=100"`
+}
+
+// MessageReceipt tells the sender of messages that their receiver got or read them.
+// It is returned to the receiver acknowledging them and sent to the sender over the
+// message WebSocket.
+type MessageReceipt struct {
+	Status     MessageReceiptStatus `json:"status"`
+	MessageIDs []primitive.ObjectID `json:"message_ids"` // only those that changed state
+	ReceiverID primitive.ObjectID   `json:"receiver_id"`
+	At         time.Time            `json:"at"`
+}
+
 // MessageResponse represents a message response
 type MessageResponse struct {
 	ID          primitive.ObjectID `json:"id"`
@@ -53,15 +96,18 @@ type MessageResponse struct {
 	MessageType string             `json:"message_type"`
 	IsRead      bool               `json:"is_read"`
 	CreatedAt   time.Time          `json:"created_at"`
+	DeliveredAt *time.Time         `json:"delivered_at,omitempty"`
 	ReadAt      *time.Time         `json:"read_at,omitempty"`
+	EditedAt    *time.Time         `json:"edited_at,omitempty"`
 }
 
 // MessageListResponse represents a list of messages response
 type MessageListResponse struct {
-	Messages []*MessageResponse `json:"messages"`
-	Total    int64              `json:"total"`
-	Page     int                `json:"page"`
-	Limit    int                `json:"limit"`
+	Messages   []*MessageResponse `json:"messages"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page,omitempty"`
+	Limit      int                `json:"limit"`
+	NextCursor string             `json:"next_cursor,omitempty"`
 }
 
 // ConversationListResponse represents a list of conversations response
@@ -72,13 +118,52 @@ type ConversationListResponse struct {
 	Limit         int             `json:"limit"`
 }
 
+// ConversationExport is the document a couple's conversation is exported to, with
+// the messages oldest first
+type ConversationExport struct {
+	ExportedAt   time.Time                        `json:"exported_at"`
+	ExportedBy   primitive.ObjectID               `json:"exported_by"`
+	Participants []*ConversationExportParticipant `json:"participants"`
+	Messages     []*MessageResponse               `json:"messages"`
+}
+
+// ConversationExportParticipant is one of the partners of an exported conversation
+type ConversationExportParticipant struct {
+	ID   primitive.ObjectID `json:"id"`
+	Name string             `json:"name"`
+}
+
+// ConversationExportResponse represents a requested conversation export. When the
+// couple requires partner approval, only PendingAction is set until it is approved;
+// the export is then made and its ID sent to the requester in a notification.
+type ConversationExportResponse struct {
+	ID            string                 `json:"id,omitempty"`
+	MessageCount  int                    `json:"message_count,omitempty"`
+	CreatedAt     *time.Time             `json:"created_at,omitempty"`
+	PendingAction *PendingActionResponse `json:"pending_action,omitempty"`
+}
+
 // MessageService defines the interface for message operations
 type MessageService interface {
 	SendMessage(ctx context.Context, senderID primitive.ObjectID, req *CreateMessageRequest) (*MessageResponse, error)
 	GetConversation(ctx context.Context, userID, partnerID primitive.ObjectID, page, limit int) ([]*MessageResponse, int64, error)
+	GetConversationCursor(ctx context.Context, userID, partnerID primitive.ObjectID, cursor *Cursor, limit int) ([]*MessageResponse, string, error)
 	GetUserConversations(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]*Conversation, int64, error)
 	MarkAsRead(ctx context.Context, userID, partnerID primitive.ObjectID) error
-	DeleteMessage(ctx context.Context, messageID, userID primitive.ObjectID) error
+	// AckMessages marks messages sent to the user as delivered or read and lets their
+	// sender know
+	AckMessages(ctx context.Context, userID primitive.ObjectID, req *AckMessagesRequest) (*MessageReceipt, error)
+	// SubscribeReceipts delivers the receipts of the messages the user sent until ctx is done
+	SubscribeReceipts(ctx context.Context, userID primitive.ObjectID) (<-chan *MessageReceipt, error)
+	// EditMessage changes the content of a text message the user sent, within the edit
+	// window after sending it
+	EditMessage(ctx context.Context, messageID, userID primitive.ObjectID, req *UpdateMessageRequest) (*MessageResponse, error)
+	DeleteMessage(ctx context.Context, messageID, userID primitive.ObjectID, mode MessageDeleteMode) error
+	// ExportConversation exports the user's conversation with their partner, or asks the
+	// partner to approve it first when the couple requires it
+	ExportConversation(ctx context.Context, userID primitive.ObjectID) (*ConversationExportResponse, error)
+	// GetConversationExport returns an export of the user's couple as JSON
+	GetConversationExport(ctx context.Context, userID, exportID primitive.ObjectID) ([]byte, error)
 }
 
 // MessageRepository defines the interface for message data operations
@@ -86,8 +171,71 @@ type MessageRepository interface {
 	Create(ctx context.Context, message *Message) error
 	FindByID(ctx context.Context, id primitive.ObjectID) (*Message, error)
 	FindConversation(ctx context.Context, userID, partnerID primitive.ObjectID, page, limit int) ([]*Message, int64, error)
+	FindConversationCursor(ctx context.Context, userID, partnerID primitive.ObjectID, cursor *Cursor, limit int) ([]*Message, error)
 	FindUserConversations(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]*Conversation, int64, error)
-	MarkAsRead(ctx context.Context, userID, partnerID primitive.ObjectID) error
+	// MarkAsRead marks the messages the partner sent to the user as read and returns
+	// those that were unread
+	MarkAsRead(ctx context.Context, userID, partnerID primitive.ObjectID) ([]*Message, error)
+	// MarkDelivered marks the given messages sent to the user as delivered and returns
+	// those that were not yet
+	MarkDelivered(ctx context.Context, userID primitive.ObjectID, messageIDs []primitive.ObjectID) ([]*Message, error)
+	// MarkRead marks the given messages sent to the user as read, and delivered if they
+	// were not yet, and returns those that were unread
+	MarkRead(ctx context.Context, userID primitive.ObjectID, messageIDs []primitive.ObjectID) ([]*Message, error)
 	SoftDelete(ctx context.Context, messageID, userID primitive.ObjectID) error
+	// HideForUser hides a message the user sent or received from the user only
+	HideForUser(ctx context.Context, messageID, userID primitive.ObjectID) error
 	Update(ctx context.Context, message *Message) error
+	// Search lists the messages between two users whose content contains every term of
+	// query, newest first
+	Search(ctx context.Context, userID, partnerID primitive.ObjectID, query string, cursor *Cursor, limit int) ([]*Message, error)
+	// ReassignUser moves the messages sent and received by one user to another and
+	// returns how many were moved
+	ReassignUser(ctx context.Context, fromUserID, toUserID primitive.ObjectID) (int64, error)
+	// ListDeleted lists the messages the sender deleted, most recently deleted first
+	ListDeleted(ctx context.Context, senderID primitive.ObjectID, limit int) ([]*Message, error)
+	Restore(ctx context.Context, messageID, senderID primitive.ObjectID) error
+	// PurgeDeletedBefore permanently deletes the messages deleted before cutoff
+	PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+	// CountCreatedPerDay counts the messages sent from from up to to per couple of the
+	// sender and day, including deleted ones
+	CountCreatedPerDay(ctx context.Context, from, to time.Time) ([]*DailyCount, error)
+}
+
+// ToResponse converts Message to MessageResponse
+func (m *Message) ToResponse() *MessageResponse {
+	return &MessageResponse{
+		ID:          m.ID,
+		SenderID:    m.SenderID,
+		ReceiverID:  m.ReceiverID,
+		Content:     m.Content,
+		MessageType: m.MessageType,
+		IsRead:      m.IsRead,
+		CreatedAt:   m.CreatedAt,
+		DeliveredAt: m.DeliveredAt,
+		ReadAt:      m.ReadAt,
+		EditedAt:    m.EditedAt,
+	}
+}
+
+// MessageSearchResponse represents a page of messages matching a search, newest first
+type MessageSearchResponse struct {
+	Messages   []*MessageResponse `json:"messages"`
+	Query      string             `json:"query"`
+	Limit      int                `json:"limit"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// MessageSearchService defines the interface for searching the couple's conversation
+type MessageSearchService interface {
+	SearchMessages(ctx context.Context, userID primitive.ObjectID, query string, cursor *Cursor, limit int) (*MessageSearchResponse, error)
+}
+
+// MessageReceiptRepository defines the interface for passing message receipts to the
+// sender's connections
+type MessageReceiptRepository interface {
+	// Publish delivers receipt to the subscribers of the user on any instance
+	Publish(ctx context.Context, userID primitive.ObjectID, receipt *MessageReceipt) error
+	// Subscribe delivers the receipts published to the user until ctx is done
+	Subscribe(ctx context.Context, userID primitive.ObjectID) (<-chan *MessageReceipt, error)
 }
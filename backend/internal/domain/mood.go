@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MoodCheckIn is a partner's mood for one day. Recording a mood again on the same
+// day replaces it.
+type MoodCheckIn struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	MatchCode string             `json:"match_code" bson:"match_code"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Date      time.Time          `json:"date" bson:"date"` // midnight UTC of the day
+	Score     int                `json:"score" bson:"score"`
+	Emoji     string             `json:"emoji,omitempty" bson:"emoji,omitempty"`
+	Note      string             `json:"note,omitempty" bson:"note,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// RecordMoodRequest represents the request to record a mood check-in
+type RecordMoodRequest struct {
+	Score int    `json:"score" validate:"required,min=1,max=5"`
+	Emoji string `json:"emoji,omitempty" validate:"omitempty,max=32"`
+	Note  string `json:"note,omitempty" validate:"omitempty,max=500"`
+	Date  *Date  `json:"date,omitempty"` // defaults to today
+}
+
+// MoodCheckInResponse represents the API response for a mood check-in
+type MoodCheckInResponse struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Date      Date      `json:"date"`
+	Score     int       `json:"score"`
+	Emoji     string    `json:"emoji,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ToResponse converts MoodCheckIn to MoodCheckInResponse
+func (m *MoodCheckIn) ToResponse() *MoodCheckInResponse {
+	return &MoodCheckInResponse{
+		ID:        m.ID.Hex(),
+		UserID:    m.UserID.Hex(),
+		Date:      DateFromTime(m.Date),
+		Score:     m.Score,
+		Emoji:     m.Emoji,
+		Note:      m.Note,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// MoodHistoryResponse represents a user's mood check-ins, newest first
+type MoodHistoryResponse struct {
+	CheckIns     []*MoodCheckInResponse `json:"check_ins"`
+	AverageScore float64                `json:"average_score"`
+}
+
+// CoupleMoodDay holds both partners' moods for one day; either may be missing
+type CoupleMoodDay struct {
+	Date    Date                 `json:"date"`
+	Mine    *MoodCheckInResponse `json:"mine,omitempty"`
+	Partner *MoodCheckInResponse `json:"partner,omitempty"`
+}
+
+// CoupleMoodsResponse shows both partners' moods side by side for every day of a month
+type CoupleMoodsResponse struct {
+	Month               string           `json:"month"` // YYYY-MM
+	PartnerID           string           `json:"partner_id,omitempty"`
+	Days                []*CoupleMoodDay `json:"days"`
+	MyAverageScore      float64          `json:"my_average_score"`
+	PartnerAverageScore float64          `json:"partner_average_score"`
+}
+
+// MoodRepository defines the interface for mood check-in data access
+type MoodRepository interface {
+	// Upsert records the user's mood for checkIn.Date, replacing an earlier one
+	Upsert(ctx context.Context, checkIn *MoodCheckIn) error
+	GetByUser(ctx context.Context, userID primitive.ObjectID, from, to *time.Time, limit int) ([]*MoodCheckIn, error)
+	GetByMatchCode(ctx context.Context, matchCode string, from, to time.Time) ([]*MoodCheckIn, error)
+}
+
+// MoodService defines the interface for mood check-in business logic
+type MoodService interface {
+	RecordMood(ctx context.Context, userID primitive.ObjectID, req *RecordMoodRequest) (*MoodCheckInResponse, error)
+	GetHistory(ctx context.Context, userID primitive.ObjectID, from, to *Date, limit int) (*MoodHistoryResponse, error)
+	GetCoupleMoods(ctx context.Context, userID primitive.ObjectID, month time.Time) (*CoupleMoodsResponse, error)
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NotificationType represents the kind of in-app notification
+type NotificationType string
+
+const (
+	NotificationTypeAffirmation      NotificationType = "affirmation"
+	NotificationTypeApprovalRequest  NotificationType = "approval_request"
+	NotificationTypeApprovalDecision NotificationType = "approval_decision"
+	NotificationTypeExportReady      NotificationType = "export_ready"
+	NotificationTypePhotoComment     NotificationType = "photo_comment"
+	NotificationTypeMemories         NotificationType = "memories"
+	NotificationTypeCoupleSettings   NotificationType = "couple_settings"
+	NotificationTypeEventReminder    NotificationType = "event_reminder"
+	NotificationTypeDailyQuestion    NotificationType = "daily_question"
+)
+
+// Notification represents an in-app notification for a user
+type Notification struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	UserID    primitive.ObjectID `json:"user_id" bson:"user_id"`
+	Type      NotificationType   `json:"type" bson:"type"`
+	Title     string             `json:"title" bson:"title"`
+	Body      string             `json:"body" bson:"body"`
+	Data      map[string]string  `json:"data,omitempty" bson:"data,omitempty"`
+	IsRead    bool               `json:"is_read" bson:"is_read"`
+	ReadAt    *time.Time         `json:"read_at,omitempty" bson:"read_at,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// NotificationTemplate names the messages a notification is rendered from in the
+// recipient's language, notification_<Key>_title and notification_<Key>_body
+type NotificationTemplate struct {
+	Key    string
+	Params map[string]interface{}
+}
+
+// NotificationMessage is a template param that is itself a message ID, translated
+// before it is interpolated
+type NotificationMessage string
+
+// NotificationResponse represents the API response for a notification
+type NotificationResponse struct {
+	ID        string            `json:"id"`
+	Type      NotificationType  `json:"type"`
+	Title     string            `json:"title"`
+	Body      string            `json:"body"`
+	Data      map[string]string `json:"data,omitempty"`
+	IsRead    bool              `json:"is_read"`
+	ReadAt    *time.Time        `json:"read_at,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// ToResponse converts Notification to NotificationResponse
+func (n *Notification) ToResponse() *NotificationResponse {
+	return &NotificationResponse{
+		ID:        n.ID.Hex(),
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		Data:      n.Data,
+		IsRead:    n.IsRead,
+		ReadAt:    n.ReadAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+// NotificationListResponse represents a list of notifications response
+type NotificationListResponse struct {
+	Notifications []*NotificationResponse `json:"notifications"`
+	Total         int64                   `json:"total"`
+	UnreadCount   int64                   `json:"unread_count"`
+	Page          int                     `json:"page"`
+	Limit         int                     `json:"limit"`
+}
+
+// NotificationRepository defines the interface for notification data access
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *Notification) error
+	GetByUserID(ctx context.Context, userID primitive.ObjectID, limit, offset int) ([]*Notification, error)
+	CountByUserID(ctx context.Context, userID primitive.ObjectID, unreadOnly bool) (int64, error)
+	MarkAsRead(ctx context.Context, id, userID primitive.ObjectID) error
+	MarkAllAsRead(ctx context.Context, userID primitive.ObjectID) error
+}
+
+// NotificationService defines the interface for notification business logic
+type NotificationService interface {
+	// Notify renders tmpl in the user's language and creates an in-app notification
+	Notify(ctx context.Context, userID primitive.ObjectID, notificationType NotificationType, tmpl NotificationTemplate, data map[string]string) error
+	GetNotifications(ctx context.Context, userID primitive.ObjectID, page, limit int) (*NotificationListResponse, error)
+	MarkAsRead(ctx context.Context, notificationID, userID primitive.ObjectID) error
+	MarkAllAsRead(ctx context.Context, userID primitive.ObjectID) error
+}
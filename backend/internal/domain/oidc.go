@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OAuth 2.0 error codes (RFC 6749 section 4.1.2.1 and 5.2, OpenID Connect Core 3.1.2.6)
+const (
+	OAuthErrInvalidRequest          = "invalid_request"
+	OAuthErrInvalidClient           = "invalid_client"
+	OAuthErrInvalidGrant            = "invalid_grant"
+	OAuthErrInvalidScope            = "invalid_scope"
+	OAuthErrUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthErrUnsupportedResponseType = "unsupported_response_type"
+	OAuthErrLoginRequired           = "login_required"
+	OAuthErrAccessDenied            = "access_denied"
+	OAuthErrServerError             = "server_error"
+)
+
+// OAuthError is an error reported to an OAuth client in the format of RFC 6749
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+// Error implements the error interface
+func (e *OAuthError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// NewOAuthError creates an OAuth error
+func NewOAuthError(code, description string) *OAuthError {
+	return &OAuthError{Code: code, Description: description}
+}
+
+// OAuthClient is an application allowed to sign users in through the OpenID Connect
+// provider, such as the watch app or the desktop widget. Clients are public: they
+// hold no secret and must use PKCE.
+type OAuthClient struct {
+	ID           string
+	RedirectURIs []string
+}
+
+// AuthorizationCode is a code issued to a client after the user signed in. It is
+// exchanged once for tokens; only its hash is stored.
+type AuthorizationCode struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	CodeHash      string             `bson:"code_hash"`
+	ClientID      string             `bson:"client_id"`
+	RedirectURI   string             `bson:"redirect_uri"`
+	UserID        primitive.ObjectID `bson:"user_id"`
+	Scopes        []string           `bson:"scopes"`
+	Nonce         string             `bson:"nonce,omitempty"`
+	CodeChallenge string             `bson:"code_challenge"`
+	ExpiresAt     time.Time          `bson:"expires_at"`
+	CreatedAt     time.Time          `bson:"created_at"`
+}
+
+// AuthorizeRequest represents an OpenID Connect authentication request
+type AuthorizeRequest struct {
+	ResponseType        string `query:"response_type"`
+	ClientID            string `query:"client_id"`
+	RedirectURI         string `query:"redirect_uri"`
+	Scope               string `query:"scope"`
+	State               string `query:"state"`
+	Nonce               string `query:"nonce"`
+	CodeChallenge       string `query:"code_challenge"`
+	CodeChallengeMethod string `query:"code_challenge_method"`
+	Prompt              string `query:"prompt"`
+}
+
+// TokenRequest represents a request to the token endpoint
+type TokenRequest struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	ClientID     string `form:"client_id"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	Scope        string `form:"scope"` // refresh_token grant: a subset of the scopes first granted
+}
+
+// OIDCTokenResponse represents the tokens issued to a client. The access token is
+// accepted by the whole API, like the one returned at login.
+type OIDCTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// UserInfoResponse represents the claims about the signed in user
+type UserInfoResponse struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture,omitempty"`
+}
+
+// OIDCDiscoveryResponse represents the OpenID Provider metadata
+type OIDCDiscoveryResponse struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// JSONWebKey is the public half of a signing key (RFC 7517)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// JSONWebKeySet lists the keys that verify ID tokens
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// AuthorizationCodeRepository defines the interface for authorization code storage
+type AuthorizationCodeRepository interface {
+	Create(ctx context.Context, code *AuthorizationCode) error
+	// Consume removes and returns the unexpired code with the given hash, so a code
+	// can be exchanged only once
+	Consume(ctx context.Context, codeHash string, now time.Time) (*AuthorizationCode, error)
+}
+
+// OIDCService is a minimal OpenID Connect provider backed by the user accounts. It
+// supports the authorization code flow with PKCE for the first-party companion apps.
+type OIDCService interface {
+	Discovery() *OIDCDiscoveryResponse
+	JWKS() *JSONWebKeySet
+	// Authorize answers an authentication request and returns where to redirect the
+	// browser: the login page when userID is zero, otherwise the client's redirect URI
+	// with a code or an error. Requests from an unknown client or to an unregistered
+	// redirect URI return an OAuthError instead, and must not be redirected.
+	Authorize(ctx context.Context, userID primitive.ObjectID, req *AuthorizeRequest, rawQuery string) (string, error)
+	// Token exchanges an authorization code or a refresh token for tokens
+	Token(ctx context.Context, req *TokenRequest) (*OIDCTokenResponse, error)
+	UserInfo(ctx context.Context, userID primitive.ObjectID) (*UserInfoResponse, error)
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// DefaultCursorLimit is the page size used when a cursor request has no limit
+	DefaultCursorLimit = 20
+	// MaxCursorLimit caps the page size of cursor requests
+	MaxCursorLimit = 100
+)
+
+// Cursor marks a position in a list sorted newest first by created_at, then by ID.
+// Unlike page/offset, a cursor stays stable when new items arrive while the client scrolls.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        primitive.ObjectID
+}
+
+// NewCursor creates the cursor pointing just past the given item
+func NewCursor(createdAt time.Time, id primitive.ObjectID) *Cursor {
+	return &Cursor{CreatedAt: createdAt, ID: id}
+}
+
+// Encode returns the opaque string form of the cursor sent to clients
+func (c *Cursor) Encode() string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixMilli(), 10) + "_" + c.ID.Hex()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor previously returned as next_cursor.
+// An empty string means "start from the newest item" and returns nil.
+func DecodeCursor(value string) (*Cursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	parts := strings.SplitN(string(raw), "_", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	millis, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	id, err := primitive.ObjectIDFromHex(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return NewCursor(time.UnixMilli(millis), id), nil
+}
+
+// NormalizeCursorLimit applies the default and maximum page size for cursor requests
+func NormalizeCursorLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultCursorLimit
+	}
+	if limit > MaxCursorLimit {
+		return MaxCursorLimit
+	}
+	return limit
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PendingActionType represents a destructive or sensitive action that needs the
+// partner's approval
+type PendingActionType string
+
+const (
+	PendingActionAlbumDelete        PendingActionType = "album_delete"
+	PendingActionConversationExport PendingActionType = "conversation_export"
+)
+
+// PendingActionStatus represents the status of a pending action
+type PendingActionStatus string
+
+const (
+	PendingActionStatusPending   PendingActionStatus = "pending"
+	PendingActionStatusApproved  PendingActionStatus = "approved" // approved and carried out
+	PendingActionStatusRejected  PendingActionStatus = "rejected"
+	PendingActionStatusCancelled PendingActionStatus = "cancelled"
+	PendingActionStatusFailed    PendingActionStatus = "failed" // approved but could not be carried out
+	PendingActionStatusExpired   PendingActionStatus = "expired"
+)
+
+// PendingAction is a destructive action one partner asked for, held until the other
+// partner approves it. It expires when it is not decided on in time.
+type PendingAction struct {
+	ID          primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	MatchCode   string              `json:"match_code" bson:"match_code"`
+	Type        PendingActionType   `json:"type" bson:"type"`
+	TargetID    primitive.ObjectID  `json:"target_id" bson:"target_id"`
+	Summary     string              `json:"summary" bson:"summary"` // e.g. the album name, shown to the partner
+	RequestedBy primitive.ObjectID  `json:"requested_by" bson:"requested_by"`
+	Status      PendingActionStatus `json:"status" bson:"status"`
+	DecidedBy   *primitive.ObjectID `json:"decided_by,omitempty" bson:"decided_by,omitempty"`
+	DecidedAt   *time.Time          `json:"decided_at,omitempty" bson:"decided_at,omitempty"`
+	ExpiresAt   time.Time           `json:"expires_at" bson:"expires_at"`
+	CreatedAt   time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at" bson:"updated_at"`
+}
+
+// EffectiveStatus returns the status of the action, reporting a pending action past
+// its expiry as expired
+func (a *PendingAction) EffectiveStatus(now time.Time) PendingActionStatus {
+	if a.Status == PendingActionStatusPending && !now.Before(a.ExpiresAt) {
+		return PendingActionStatusExpired
+	}
+	return a.Status
+}
+
+// PendingActionResponse represents the API response for a pending action
+type PendingActionResponse struct {
+	ID          string              `json:"id"`
+	Type        PendingActionType   `json:"type"`
+	TargetID    string              `json:"target_id"`
+	Summary     string              `json:"summary"`
+	RequestedBy string              `json:"requested_by"`
+	Status      PendingActionStatus `json:"status"`
+	CanApprove  bool                `json:"can_approve"` // whether the current user is the partner who decides
+	DecidedAt   *time.Time          `json:"decided_at,omitempty"`
+	ExpiresAt   time.Time           `json:"expires_at"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+// ToResponse converts PendingAction to PendingActionResponse for the given user
+func (a *PendingAction) ToResponse(userID primitive.ObjectID) *PendingActionResponse {
+	status := a.EffectiveStatus(time.Now())
+	return &PendingActionResponse{
+		ID:          a.ID.Hex(),
+		Type:        a.Type,
+		TargetID:    a.TargetID.Hex(),
+		Summary:     a.Summary,
+		RequestedBy: a.RequestedBy.Hex(),
+		Status:      status,
+		CanApprove:  status == PendingActionStatusPending && a.RequestedBy != userID,
+		DecidedAt:   a.DecidedAt,
+		ExpiresAt:   a.ExpiresAt,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// PendingActionListResponse represents the couple's undecided actions
+type PendingActionListResponse struct {
+	Actions []*PendingActionResponse `json:"actions"`
+}
+
+// PendingActionExecutor carries out a pending action once the partner approved it
+type PendingActionExecutor interface {
+	ExecutePendingAction(ctx context.Context, action *PendingAction) error
+}
+
+// PendingActionRepository defines the interface for pending action data access
+type PendingActionRepository interface {
+	Create(ctx context.Context, action *PendingAction) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*PendingAction, error)
+	// GetOpen retrieves the undecided, unexpired action of a couple on a target
+	GetOpen(ctx context.Context, matchCode string, actionType PendingActionType, targetID primitive.ObjectID, now time.Time) (*PendingAction, error)
+	// ListOpen retrieves the undecided, unexpired actions of a couple, newest first
+	ListOpen(ctx context.Context, matchCode string, now time.Time) ([]*PendingAction, error)
+	// UpdateStatus moves an action from one status to another, failing when it is no
+	// longer in the from status
+	UpdateStatus(ctx context.Context, id primitive.ObjectID, from, to PendingActionStatus, decidedBy primitive.ObjectID) error
+}
+
+// PendingActionService defines the interface for the partner approval workflow
+type PendingActionService interface {
+	// RegisterExecutor sets what carries out approved actions of a type
+	RegisterExecutor(actionType PendingActionType, executor PendingActionExecutor)
+	// ApprovalRequired reports whether the couple asked for destructive actions to be approved
+	ApprovalRequired(ctx context.Context, matchCode string) (bool, error)
+	// Request holds an action until the requester's partner approves it, and notifies the partner
+	Request(ctx context.Context, requesterID primitive.ObjectID, actionType PendingActionType, targetID primitive.ObjectID, summary string) (*PendingActionResponse, error)
+	ListPending(ctx context.Context, userID primitive.ObjectID) (*PendingActionListResponse, error)
+	Approve(ctx context.Context, actionID, userID primitive.ObjectID) (*PendingActionResponse, error)
+	Reject(ctx context.Context, actionID, userID primitive.ObjectID) (*PendingActionResponse, error)
+	Cancel(ctx context.Context, actionID, userID primitive.ObjectID) (*PendingActionResponse, error)
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -10,31 +11,78 @@ import (
 
 // Photo represents a photo in the system
 type Photo struct {
-	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	MatchCode   string             `json:"match_code" bson:"match_code" validate:"required"`
-	CreatedBy   primitive.ObjectID `json:"created_by" bson:"created_by" validate:"required"` // For audit trail and notifications
-	Title       string             `json:"title" bson:"title" validate:"required,min=1,max=200"`
-	Description string             `json:"description,omitempty" bson:"description,omitempty"`
-	ImageURL    string             `json:"image_url" bson:"image_url" validate:"required"`
-	Date        time.Time          `json:"date" bson:"date"`
-	Location    string             `json:"location,omitempty" bson:"location,omitempty"`
-	Tags        []string           `json:"tags,omitempty" bson:"tags,omitempty"`
-	IsPrivate   bool               `json:"is_private" bson:"is_private"`
-	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
-	DeletedAt   *time.Time         `json:"-" bson:"deleted_at,omitempty"`
+	ID           primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	MatchCode    string               `json:"match_code" bson:"match_code" validate:"required"`
+	CreatedBy    primitive.ObjectID   `json:"created_by" bson:"created_by" validate:"required"` // For audit trail and notifications
+	Title        string               `json:"title" bson:"title" validate:"required,min=1,max=200"`
+	Description  string               `json:"description,omitempty" bson:"description,omitempty"`
+	ImageURL     string               `json:"image_url" bson:"image_url" validate:"required"`
+	ThumbnailKey string               `json:"thumbnail_key,omitempty" bson:"thumbnail_key,omitempty"`
+	MediumKey    string               `json:"medium_key,omitempty" bson:"medium_key,omitempty"`
+	PublicKey    string               `json:"-" bson:"public_key,omitempty"` // original without metadata, served outside the couple
+	Checksum     string               `json:"-" bson:"checksum,omitempty"`   // hex SHA-256 of the original image
+	ImageHash    string               `json:"-" bson:"image_hash,omitempty"` // perceptual hash, close for near-identical images
+	Date         time.Time            `json:"date" bson:"date"`
+	Location     string               `json:"location,omitempty" bson:"location,omitempty"` // display name, the place's name when it has one
+	Place        *Place               `json:"place,omitempty" bson:"place,omitempty"`
+	Tags         []string             `json:"tags,omitempty" bson:"tags,omitempty"`
+	IsPrivate    bool                 `json:"is_private" bson:"is_private"` // only visible to the partner who uploaded it
+	AlbumID      *primitive.ObjectID  `json:"album_id,omitempty" bson:"album_id,omitempty"`
+	Metadata     *PhotoMetadata       `json:"metadata,omitempty" bson:"metadata,omitempty"`
+	MediaType    MediaType            `json:"media_type,omitempty" bson:"media_type,omitempty"` // empty for photos uploaded before videos were supported
+	PosterKey    string               `json:"poster_key,omitempty" bson:"poster_key,omitempty"` // frame of a video shown in its place
+	Video        *VideoMetadata       `json:"video,omitempty" bson:"video,omitempty"`
+	FavoritedBy  []primitive.ObjectID `json:"-" bson:"favorited_by,omitempty"` // users who marked the photo as a favorite
+	CreatedAt    time.Time            `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at" bson:"updated_at"`
+	DeletedAt    *time.Time           `json:"-" bson:"deleted_at,omitempty"`
+}
+
+// PhotoMetadata is what the camera recorded about a photo
+type PhotoMetadata struct {
+	TakenAt      *time.Time `json:"taken_at,omitempty" bson:"taken_at,omitempty"`
+	Latitude     *float64   `json:"latitude,omitempty" bson:"latitude,omitempty"`
+	Longitude    *float64   `json:"longitude,omitempty" bson:"longitude,omitempty"`
+	CameraMake   string     `json:"camera_make,omitempty" bson:"camera_make,omitempty"`
+	CameraModel  string     `json:"camera_model,omitempty" bson:"camera_model,omitempty"`
+	LensModel    string     `json:"lens_model,omitempty" bson:"lens_model,omitempty"`
+	FocalLength  float64    `json:"focal_length,omitempty" bson:"focal_length,omitempty"` // mm
+	FNumber      float64    `json:"f_number,omitempty" bson:"f_number,omitempty"`
+	ExposureTime string     `json:"exposure_time,omitempty" bson:"exposure_time,omitempty"` // e.g. "1/125"
+	ISO          int        `json:"iso,omitempty" bson:"iso,omitempty"`
+}
+
+// HasLocation reports whether the camera recorded where the photo was taken
+func (m *PhotoMetadata) HasLocation() bool {
+	return m.Latitude != nil && m.Longitude != nil
+}
+
+// Location formats the recorded coordinates, to about 10 meters
+func (m *PhotoMetadata) Location() string {
+	return fmt.Sprintf("%.4f, %.4f", *m.Latitude, *m.Longitude)
+}
+
+// Place returns the recorded coordinates as a place named after them
+func (m *PhotoMetadata) Place() *Place {
+	return &Place{
+		Name:      m.Location(),
+		Latitude:  *m.Latitude,
+		Longitude: *m.Longitude,
+	}
 }
 
 // CreatePhotoRequest represents the request to create a new photo
 type CreatePhotoRequest struct {
-	Title       string  `json:"title" validate:"required,min=1,max=200"`
-	Description string  `json:"description,omitempty"`
-	FilePath    string  `json:"file_path" validate:"required"` // Path from upload endpoint
-	ImageURL    string  `json:"image_url,omitempty"`           // Will be generated from FilePath
-	Date        *Date   `json:"date"`
-	Location    string  `json:"location,omitempty"`
+	Title       string   `json:"title" validate:"required,min=1,max=200"`
+	Description string   `json:"description,omitempty"`
+	FilePath    string   `json:"file_path" validate:"required"` // Path from upload endpoint
+	ImageURL    string   `json:"image_url,omitempty"`           // Will be generated from FilePath
+	Date        *Date    `json:"date"`
+	Location    string   `json:"location,omitempty"`
+	Place       *Place   `json:"place,omitempty"` // takes precedence over location
 	Tags        []string `json:"tags,omitempty"`
-	IsPrivate   bool    `json:"is_private"`
+	IsPrivate   bool     `json:"is_private"`
+	AlbumID     string   `json:"album_id,omitempty"`
 }
 
 // UpdatePhotoRequest represents the request to update a photo
@@ -44,32 +92,48 @@ type UpdatePhotoRequest struct {
 	ImageURL    string   `json:"image_url,omitempty"`
 	Date        *Date    `json:"date,omitempty"`
 	Location    string   `json:"location,omitempty"`
+	Place       *Place   `json:"place,omitempty"` // takes precedence over location
 	Tags        []string `json:"tags,omitempty"`
-	IsPrivate   *bool     `json:"is_private,omitempty"`
+	IsPrivate   *bool    `json:"is_private,omitempty"`
+	AlbumID     *string  `json:"album_id,omitempty"` // Empty string removes the photo from its album
 }
 
 // PhotoResponse represents the API response for a photo
 type PhotoResponse struct {
-	ID          string    `json:"id"`
-	MatchCode   string    `json:"match_code"`
-	CreatedBy   string    `json:"created_by"` // User ID who uploaded this photo
-	Title       string    `json:"title"`
-	Description string    `json:"description,omitempty"`
-	ImageURL    string    `json:"image_url"`
-	Date        time.Time `json:"date"`
-	Location    string    `json:"location,omitempty"`
-	Tags        []string  `json:"tags,omitempty"`
-	IsPrivate   bool      `json:"is_private"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID            string         `json:"id"`
+	MatchCode     string         `json:"match_code"`
+	CreatedBy     string         `json:"created_by"` // User ID who uploaded this photo
+	Title         string         `json:"title"`
+	Description   string         `json:"description,omitempty"`
+	MediaType     MediaType      `json:"media_type"`
+	ImageURL      string         `json:"image_url"`            // Original size, the video itself for videos
+	ThumbnailURL  string         `json:"thumbnail_url"`        // Small variant for grids and lists
+	MediumURL     string         `json:"medium_url"`           // Screen-sized variant for viewing
+	PosterURL     string         `json:"poster_url,omitempty"` // Full-size frame of a video
+	Video         *VideoMetadata `json:"video,omitempty"`
+	Date          Date           `json:"date"`
+	Location      string         `json:"location,omitempty"`
+	Place         *Place         `json:"place,omitempty"`
+	Tags          []string       `json:"tags,omitempty"`
+	IsPrivate     bool           `json:"is_private"`
+	AlbumID       string         `json:"album_id,omitempty"`
+	Metadata      *PhotoMetadata `json:"metadata,omitempty"`
+	FavoriteCount int            `json:"favorite_count"`
+	IsFavorite    bool           `json:"is_favorite"` // whether the requesting user marked it as a favorite
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
 }
 
-// ToResponse converts Photo to PhotoResponse
-func (p *Photo) ToResponse() *PhotoResponse {
-	// Handle both old (full MinIO URL) and new (storage key) formats
+// StorageKey returns the storage key of the photo's image.
+// Older photos stored a full MinIO URL; the key is extracted from those.
+func (p *Photo) StorageKey() string {
 	imageURL := p.ImageURL
-	originalURL := imageURL // For debugging
-	
+
+	// Clients may send back the CDN URL they were given
+	if key, ok := cdnFileKey(imageURL); ok {
+		return key
+	}
+
 	// If it's a full URL (old format), extract the key
 	// Examples:
 	// - "http://localhost:9000/photos/userid/file.jpg" (BaseURL without bucket)
@@ -81,55 +145,198 @@ func (p *Photo) ToResponse() *PhotoResponse {
 		parts := strings.SplitN(imageURL, "/", 4)
 		if len(parts) >= 4 {
 			keyPart := parts[3] // Everything after domain
-			
+
 			// Remove bucket name if present (common bucket names)
 			keyPart = strings.TrimPrefix(keyPart, "eralove-uploads/")
-			
+
 			// Now keyPart should be the storage key
 			imageURL = keyPart
 		}
 	}
-	
-	// Debug log
-	if originalURL != imageURL {
-		// URL was transformed
-		_ = originalURL // Avoid unused variable warning
+
+	return imageURL
+}
+
+// IsVideo reports whether the photo is a video memory
+func (p *Photo) IsVideo() bool {
+	return p.MediaType == MediaTypeVideo
+}
+
+// PreviewKey returns the storage key of the image that stands for the photo: its
+// image, or the poster frame of a video
+func (p *Photo) PreviewKey() string {
+	if p.IsVideo() && p.PosterKey != "" {
+		return p.PosterKey
+	}
+	return p.StorageKey()
+}
+
+// IsVisibleTo reports whether the viewer may see the photo: private photos are only
+// visible to the partner who uploaded them
+func (p *Photo) IsVisibleTo(viewerID primitive.ObjectID) bool {
+	return p.CreatedBy == viewerID || !p.IsPrivate
+}
+
+// IsFavoriteOf reports whether the user marked the photo as a favorite
+func (p *Photo) IsFavoriteOf(userID primitive.ObjectID) bool {
+	for _, id := range p.FavoritedBy {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetVariants records the storage keys of the photo's resized variants.
+// Missing variants point at the original image, or a video's poster, but for the
+// public one, which is left empty.
+func (p *Photo) SetVariants(variants map[ImageVariant]string) {
+	p.ThumbnailKey = p.PreviewKey()
+	p.MediumKey = p.PreviewKey()
+	p.PublicKey = variants[ImageVariantPublic]
+
+	if key, ok := variants[ImageVariantThumb]; ok {
+		p.ThumbnailKey = key
+	}
+	if key, ok := variants[ImageVariantMedium]; ok {
+		p.MediumKey = key
+	}
+}
+
+// ThumbnailStorageKey returns the storage key of the photo's thumbnail. Photos
+// uploaded before variants were generated fall back to the original.
+func (p *Photo) ThumbnailStorageKey() string {
+	if p.ThumbnailKey == "" {
+		return p.PreviewKey()
+	}
+	return p.ThumbnailKey
+}
+
+// MediumStorageKey returns the storage key of the photo's screen-sized variant,
+// falling back to the original like ThumbnailStorageKey
+func (p *Photo) MediumStorageKey() string {
+	if p.MediumKey == "" {
+		return p.PreviewKey()
+	}
+	return p.MediumKey
+}
+
+// ToResponse converts Photo to PhotoResponse
+func (p *Photo) ToResponse() *PhotoResponse {
+	// Handle both old (full MinIO URL) and new (storage key) formats.
+	// Without a CDN the URLs are storage keys (e.g., "photos/userid/file.jpg");
+	// frontend will prepend /api/v1/files/ to make them backend proxy URLs
+	imageURL := FileURL(p.StorageKey(), p.UpdatedAt)
+	thumbnailURL := FileURL(p.ThumbnailStorageKey(), p.UpdatedAt)
+	mediumURL := FileURL(p.MediumStorageKey(), p.UpdatedAt)
+
+	mediaType := MediaTypePhoto
+	var posterURL string
+	if p.IsVideo() {
+		mediaType = MediaTypeVideo
+		posterURL = FileURL(p.PosterKey, p.UpdatedAt)
+	}
+
+	var albumID string
+	if p.AlbumID != nil {
+		albumID = p.AlbumID.Hex()
 	}
-	
-	// Now imageURL is the storage key (e.g., "photos/userid/file.jpg")
-	// Frontend will prepend /api/v1/files/ to make it a backend proxy URL
-	
+
 	return &PhotoResponse{
-		ID:          p.ID.Hex(),
-		MatchCode:   p.MatchCode,
-		CreatedBy:   p.CreatedBy.Hex(),
-		Title:       p.Title,
-		Description: p.Description,
-		ImageURL:    imageURL,
-		Date:        p.Date,
-		Location:    p.Location,
-		Tags:        p.Tags,
-		IsPrivate:   p.IsPrivate,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
+		ID:            p.ID.Hex(),
+		MatchCode:     p.MatchCode,
+		CreatedBy:     p.CreatedBy.Hex(),
+		Title:         p.Title,
+		Description:   p.Description,
+		MediaType:     mediaType,
+		ImageURL:      imageURL,
+		ThumbnailURL:  thumbnailURL,
+		MediumURL:     mediumURL,
+		PosterURL:     posterURL,
+		Video:         p.Video,
+		Date:          DateFromTime(p.Date),
+		Location:      p.Location,
+		Place:         p.Place,
+		Tags:          p.Tags,
+		IsPrivate:     p.IsPrivate,
+		AlbumID:       albumID,
+		Metadata:      p.Metadata,
+		FavoriteCount: len(p.FavoritedBy),
+		CreatedAt:     p.CreatedAt,
+		UpdatedAt:     p.UpdatedAt,
 	}
 }
 
-// PhotoRepository defines the interface for photo data access
+// PhotoRepository defines the interface for photo data access. The couple's listings
+// only include the photos visible to the viewer; GetByID does not filter, callers check
+// Photo.IsVisibleTo.
 type PhotoRepository interface {
 	Create(ctx context.Context, photo *Photo) error
 	GetByID(ctx context.Context, id primitive.ObjectID) (*Photo, error)
-	GetByMatchCode(ctx context.Context, matchCode string, limit, offset int) ([]*Photo, error)
-	GetByMatchCodeAndDate(ctx context.Context, matchCode string, date time.Time) ([]*Photo, error)
+	GetByMatchCode(ctx context.Context, matchCode string, viewerID primitive.ObjectID, limit, offset int) ([]*Photo, error)
+	GetByMatchCodeCursor(ctx context.Context, matchCode string, viewerID primitive.ObjectID, cursor *Cursor, limit int) ([]*Photo, error)
+	CountByMatchCode(ctx context.Context, matchCode string, viewerID primitive.ObjectID) (int64, error)
+	GetByMatchCodeAndDate(ctx context.Context, matchCode string, viewerID primitive.ObjectID, date time.Time) ([]*Photo, error)
+	ListByDate(ctx context.Context, matchCode string, viewerID primitive.ObjectID, from, to *time.Time, cursor *Cursor, limit int) ([]*Photo, error)
+	// GetOnThisDay lists the couple's photos taken on the calendar day of date in
+	// earlier years, newest first
+	GetOnThisDay(ctx context.Context, matchCode string, viewerID primitive.ObjectID, date time.Time) ([]*Photo, error)
+	// ListMatchCodesOnThisDay lists the couples with photos taken on the calendar day
+	// of date in earlier years
+	ListMatchCodesOnThisDay(ctx context.Context, date time.Time) ([]string, error)
 	DeleteByMatchCode(ctx context.Context, matchCode string) error
 	Update(ctx context.Context, id primitive.ObjectID, photo *Photo) error
 	Delete(ctx context.Context, id primitive.ObjectID) error
-	SearchByMatchCode(ctx context.Context, matchCode string, query string, limit, offset int) ([]*Photo, error)
-	
+	SearchByMatchCode(ctx context.Context, matchCode string, viewerID primitive.ObjectID, query string, limit, offset int) ([]*Photo, error)
+	// Search lists the couple's photos matching filter, best text matches first when
+	// filter has a query and newest first otherwise, along with the number of matching
+	// photos and how many of them carry each tag
+	Search(ctx context.Context, matchCode string, viewerID primitive.ObjectID, filter PhotoSearchFilter, limit, offset int) (*PhotoSearchResult, error)
+	// ClusterByLocation groups the couple's geo-tagged photos into cells of cellSize
+	// degrees, optionally limited to an area and to dates within [from, to)
+	ClusterByLocation(ctx context.Context, matchCode string, viewerID primitive.ObjectID, cellSize float64, bounds *BoundingBox, from, to *time.Time, limit int) ([]*PhotoCluster, error)
+
+	// Favorites of each partner
+	SetFavorite(ctx context.Context, matchCode string, id, userID primitive.ObjectID, favorite bool) (*Photo, error)
+	GetFavorites(ctx context.Context, matchCode string, userID primitive.ObjectID, limit, offset int) ([]*Photo, error)
+	CountFavorites(ctx context.Context, matchCode string, userID primitive.ObjectID) (int64, error)
+
+	// Duplicate detection
+	GetWithImageHash(ctx context.Context, matchCode string, viewerID primitive.ObjectID) ([]*Photo, error)
+
+	// Album membership
+	GetByAlbumID(ctx context.Context, albumID, viewerID primitive.ObjectID, limit, offset int) ([]*Photo, error)
+	CountByAlbumID(ctx context.Context, albumID, viewerID primitive.ObjectID) (int64, error)
+	CountByAlbumIDs(ctx context.Context, albumIDs []primitive.ObjectID, viewerID primitive.ObjectID) (map[primitive.ObjectID]int64, error)
+	SetAlbum(ctx context.Context, matchCode string, photoIDs []primitive.ObjectID, albumID *primitive.ObjectID) (int64, error)
+
 	// Soft delete management
 	Restore(ctx context.Context, id primitive.ObjectID) error
 	HardDelete(ctx context.Context, id primitive.ObjectID) error
-	ListDeleted(ctx context.Context, matchCode string, limit, offset int) ([]*Photo, error)
+	GetDeleted(ctx context.Context, matchCode string, id primitive.ObjectID) (*Photo, error)
+	ListDeleted(ctx context.Context, matchCode string, viewerID primitive.ObjectID, limit, offset int) ([]*Photo, error)
+	ListDeletedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*Photo, error)
+
+	// Bulk operations
+	BulkDelete(ctx context.Context, matchCode string, viewerID primitive.ObjectID, ids []primitive.ObjectID) ([]primitive.ObjectID, error)
+	BulkUpdateTags(ctx context.Context, matchCode string, viewerID primitive.ObjectID, ids []primitive.ObjectID, add, remove []string) ([]primitive.ObjectID, error)
+
+	// GetByStorageKey retrieves the photo, including photos in the trash, whose image or
+	// one of its variants is stored under key, or nil when there is none
+	GetByStorageKey(ctx context.Context, key string) (*Photo, error)
+
+	// Storage integrity, across couples and including photos in the trash
+	SampleWithChecksum(ctx context.Context, size int) ([]*Photo, error)
+	ListWithoutChecksum(ctx context.Context, afterID primitive.ObjectID, limit int) ([]*Photo, error)
+	// ListAfter lists photos in ID order after afterID
+	ListAfter(ctx context.Context, afterID primitive.ObjectID, limit int) ([]*Photo, error)
+	SetChecksum(ctx context.Context, id primitive.ObjectID, checksum string) error
+
+	// Account merges
+	ReassignCreator(ctx context.Context, fromUserID, toUserID primitive.ObjectID) (int64, error)
+
+	// Usage analytics, across couples and including photos in the trash
+	CountCreatedPerDay(ctx context.Context, from, to time.Time) ([]*DailyCount, error)
 }
 
 // PhotoService defines the interface for photo business logic
@@ -137,15 +344,108 @@ type PhotoService interface {
 	CreatePhoto(ctx context.Context, userID primitive.ObjectID, req *CreatePhotoRequest, file interface{}) (*PhotoResponse, error)
 	CreatePhotoWithPath(ctx context.Context, userID primitive.ObjectID, req *CreatePhotoRequest) (*PhotoResponse, error)
 	GetPhoto(ctx context.Context, photoID, userID primitive.ObjectID) (*PhotoResponse, error)
+	GetSharedPreview(ctx context.Context, photoID, userID primitive.ObjectID) (*PhotoResponse, error)
 	GetCouplePhotos(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]*PhotoResponse, int64, error)
+	GetCouplePhotosCursor(ctx context.Context, userID primitive.ObjectID, cursor *Cursor, limit int) ([]*PhotoResponse, string, error)
 	UpdatePhoto(ctx context.Context, photoID, userID primitive.ObjectID, req *UpdatePhotoRequest) (*PhotoResponse, error)
 	DeletePhoto(ctx context.Context, photoID, userID primitive.ObjectID) error
+	BulkDeletePhotos(ctx context.Context, userID primitive.ObjectID, req *BulkIDsRequest) (*BulkResponse, error)
+	BulkTagPhotos(ctx context.Context, userID primitive.ObjectID, req *BulkTagRequest) (*BulkResponse, error)
+	FavoritePhoto(ctx context.Context, photoID, userID primitive.ObjectID) (*PhotoResponse, error)
+	UnfavoritePhoto(ctx context.Context, photoID, userID primitive.ObjectID) (*PhotoResponse, error)
+	GetFavoritePhotos(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]*PhotoResponse, int64, error)
+	GetDuplicatePhotos(ctx context.Context, userID primitive.ObjectID) (*DuplicatePhotosResponse, error)
+	SearchPhotos(ctx context.Context, userID primitive.ObjectID, req *PhotoSearchRequest, page, limit int) (*PhotoSearchResponse, error)
+	GetPhotoMap(ctx context.Context, userID primitive.ObjectID, query *PhotoMapQuery) (*PhotoMapResponse, error)
+	GetPhotoURL(ctx context.Context, photoID, userID primitive.ObjectID, req *PhotoURLRequest) (*PhotoURLResponse, error)
 }
 
 // PhotoListResponse represents a list of photos response
 type PhotoListResponse struct {
+	Photos     []*PhotoResponse `json:"photos"`
+	Total      int64            `json:"total"`
+	Page       int              `json:"page,omitempty"`
+	Limit      int              `json:"limit"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// DuplicatePhotoGroup is a set of the couple's photos that look near-identical,
+// newest first
+type DuplicatePhotoGroup struct {
+	Photos []*PhotoResponse `json:"photos"`
+}
+
+// DuplicatePhotosResponse represents the groups of near-identical photos of a couple
+type DuplicatePhotosResponse struct {
+	Groups []*DuplicatePhotoGroup `json:"groups"`
+	Total  int                    `json:"total"` // number of groups
+}
+
+// PhotoSearchRequest holds the filters of a photo search as sent by the client. Empty
+// fields match every photo.
+type PhotoSearchRequest struct {
+	Query     string   // words that must all appear in the title, tags, location or description
+	Tags      []string // tags the photos must all carry
+	From      *Date    // first photo date, inclusive
+	To        *Date    // last photo date, inclusive
+	Location  string   // part of the location, ignoring case
+	AlbumID   string
+	IsPrivate *bool
+}
+
+// PhotoSearchFilter narrows a photo search. Empty fields match everything.
+type PhotoSearchFilter struct {
+	Query     string
+	Tags      []string
+	From      *time.Time // inclusive
+	To        *time.Time // exclusive
+	Location  string
+	AlbumID   *primitive.ObjectID
+	IsPrivate *bool
+}
+
+// PhotoTagCount is the number of matching photos carrying a tag
+type PhotoTagCount struct {
+	Tag   string `json:"tag" bson:"_id"`
+	Count int64  `json:"count" bson:"count"`
+}
+
+// PhotoSearchResult is a page of the photos matching a search with the facets of all
+// of them
+type PhotoSearchResult struct {
+	Photos []*Photo
+	Total  int64
+	Tags   []*PhotoTagCount
+}
+
+// PhotoSearchResponse represents a page of the couple's photos matching a search
+type PhotoSearchResponse struct {
 	Photos []*PhotoResponse `json:"photos"`
 	Total  int64            `json:"total"`
 	Page   int              `json:"page"`
 	Limit  int              `json:"limit"`
+	Tags   []*PhotoTagCount `json:"tags"` // tags of every matching photo, most used first
+}
+
+// PhotoVariantOriginal names the uploaded image itself among the image variants
+const PhotoVariantOriginal = "original"
+
+// PhotoURLExpiry bounds how long the download URLs of photos stay valid
+type PhotoURLExpiry struct {
+	Default time.Duration // when the client does not choose
+	Min     time.Duration
+	Max     time.Duration
+}
+
+// PhotoURLRequest asks for a short-lived download URL of a photo
+type PhotoURLRequest struct {
+	Variant   string // original, medium or thumb; the original when empty
+	ExpiresIn int    // seconds; the default expiry when 0
+}
+
+// PhotoURLResponse is a short-lived download URL of a photo
+type PhotoURLResponse struct {
+	URL       string    `json:"url"`
+	Variant   string    `json:"variant"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PhotoComment represents a comment one partner left on one of the couple's photos
+type PhotoComment struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	MatchCode string             `json:"match_code" bson:"match_code"`
+	PhotoID   primitive.ObjectID `json:"photo_id" bson:"photo_id"`
+	AuthorID  primitive.ObjectID `json:"author_id" bson:"author_id"`
+	Body      string             `json:"body" bson:"body"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+	DeletedAt *time.Time         `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+}
+
+// CreatePhotoCommentRequest represents the request to comment on a photo
+type CreatePhotoCommentRequest struct {
+	Body string `json:"body" validate:"required,min=1,max=1000"`
+}
+
+// PhotoCommentResponse represents the API response for a photo comment
+type PhotoCommentResponse struct {
+	ID        string    `json:"id"`
+	PhotoID   string    `json:"photo_id"`
+	AuthorID  string    `json:"author_id"`
+	Body      string    `json:"body"`
+	IsMine    bool      `json:"is_mine"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToResponse converts PhotoComment to PhotoCommentResponse
+func (c *PhotoComment) ToResponse(viewerID primitive.ObjectID) *PhotoCommentResponse {
+	return &PhotoCommentResponse{
+		ID:        c.ID.Hex(),
+		PhotoID:   c.PhotoID.Hex(),
+		AuthorID:  c.AuthorID.Hex(),
+		Body:      c.Body,
+		IsMine:    c.AuthorID == viewerID,
+		CreatedAt: c.CreatedAt,
+	}
+}
+
+// PhotoCommentListResponse represents a page of a photo's comment thread
+type PhotoCommentListResponse struct {
+	Comments []*PhotoCommentResponse `json:"comments"`
+	Total    int64                   `json:"total"`
+	Page     int                     `json:"page"`
+	Limit    int                     `json:"limit"`
+}
+
+// PhotoCommentRepository defines the interface for photo comment data access
+type PhotoCommentRepository interface {
+	Create(ctx context.Context, comment *PhotoComment) error
+	GetByID(ctx context.Context, matchCode string, id primitive.ObjectID) (*PhotoComment, error)
+	// GetByPhoto retrieves the comments on a photo, oldest first
+	GetByPhoto(ctx context.Context, matchCode string, photoID primitive.ObjectID, limit, offset int) ([]*PhotoComment, error)
+	CountByPhoto(ctx context.Context, matchCode string, photoID primitive.ObjectID) (int64, error)
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// PhotoCommentService defines the interface for photo comment business logic
+type PhotoCommentService interface {
+	AddComment(ctx context.Context, photoID, userID primitive.ObjectID, req *CreatePhotoCommentRequest) (*PhotoCommentResponse, error)
+	GetComments(ctx context.Context, photoID, userID primitive.ObjectID, page, limit int) (*PhotoCommentListResponse, error)
+	DeleteComment(ctx context.Context, photoID, commentID, userID primitive.ObjectID) error
+}
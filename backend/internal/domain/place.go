@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Place is a geocoded location attached to a photo or event
+type Place struct {
+	Name       string  `json:"name" bson:"name" validate:"required,max=200"`
+	Address    string  `json:"address,omitempty" bson:"address,omitempty" validate:"max=500"`
+	Latitude   float64 `json:"latitude" bson:"latitude" validate:"gte=-90,lte=90"`
+	Longitude  float64 `json:"longitude" bson:"longitude" validate:"gte=-180,lte=180"`
+	ProviderID string  `json:"provider_id,omitempty" bson:"provider_id,omitempty"` // identifies the place at the places provider
+}
+
+// PlaceProvider looks up places by name, such as a geocoding API
+type PlaceProvider interface {
+	// SearchPlaces lists up to limit places matching query, best match first, with
+	// names in lang where the provider has them
+	SearchPlaces(ctx context.Context, query, lang string, limit int) ([]*Place, error)
+}
+
+// PlaceSearchResponse represents the places matching a search, best match first
+type PlaceSearchResponse struct {
+	Places []*Place `json:"places"`
+}
+
+// PlaceService defines the interface for looking up places
+type PlaceService interface {
+	SearchPlaces(ctx context.Context, query, lang string, limit int) (*PlaceSearchResponse, error)
+}
+
+// BoundingBox is an area of the map. West may be greater than East when the area
+// crosses the antimeridian.
+type BoundingBox struct {
+	South float64
+	West  float64
+	North float64
+	East  float64
+}
+
+// PhotoCluster is a group of the couple's photos taken close to each other
+type PhotoCluster struct {
+	Latitude  float64 `bson:"latitude"`  // average of the photos' latitudes
+	Longitude float64 `bson:"longitude"` // average of the photos' longitudes
+	Count     int64   `bson:"count"`
+	Cover     *Photo  `bson:"cover"` // newest photo of the cluster
+}
+
+// PhotoMapCluster represents a point of the photo map, standing for one or more photos
+type PhotoMapCluster struct {
+	Latitude  float64        `json:"latitude"`
+	Longitude float64        `json:"longitude"`
+	Count     int64          `json:"count"`
+	Cover     *PhotoResponse `json:"cover"` // newest photo of the cluster
+}
+
+// PhotoMapResponse represents the couple's geo-tagged photos clustered for a zoom level
+type PhotoMapResponse struct {
+	Zoom     int                `json:"zoom"`
+	Clusters []*PhotoMapCluster `json:"clusters"`
+	Total    int64              `json:"total"` // photos in every cluster
+}
+
+// PhotoMapQuery holds the area and zoom level of a photo map request
+type PhotoMapQuery struct {
+	Zoom   int
+	Bounds *BoundingBox
+	From   *time.Time
+	To     *time.Time
+}
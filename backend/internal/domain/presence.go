@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Resources a partner can be shown to be viewing
+const (
+	PresenceResourcePhoto = "photo"
+	PresenceResourceEvent = "event"
+)
+
+// Types of the messages clients send over the presence WebSocket
+const (
+	PresenceMessageViewing = "viewing"
+	PresenceMessageStop    = "stop"
+)
+
+// PresenceMessage is sent by clients over the presence WebSocket: "viewing" with the
+// photo or event they opened, or "stop" once they close it
+type PresenceMessage struct {
+	Type           string         `json:"type" validate:"required,oneof=viewing stop"`
+	ViewingRequest `validate:"-"` // validated on its own for "viewing" messages
+}
+
+// ViewingRequest names the photo or event a user has open
+type ViewingRequest struct {
+	ResourceType string `json:"resource_type" validate:"required,oneof=photo event"`
+	ResourceID   string `json:"resource_id" validate:"required,len=24,hexadecimal"`
+}
+
+// Resource returns the key identifying the viewed resource
+func (r *ViewingRequest) Resource() string {
+	return r.ResourceType + ":" + r.ResourceID
+}
+
+// PresenceResponse tells whether the partner has the same resource open. It is sent
+// over the presence WebSocket whenever that changes.
+type PresenceResponse struct {
+	PartnerViewing bool `json:"partner_viewing"`
+}
+
+// PresenceUpdate is published to a user whenever their partner opens or closes a
+// resource. Resource is empty once the partner viewed nothing.
+type PresenceUpdate struct {
+	Resource string `json:"resource"`
+}
+
+// PresenceRepository defines the interface for short-lived presence entries. A user
+// views at most one resource at a time and entries expire on their own.
+type PresenceRepository interface {
+	// SetViewing records that the user views resource for ttl, replacing any previous entry
+	SetViewing(ctx context.Context, userID primitive.ObjectID, resource string, ttl time.Duration) error
+	// GetViewing returns the resource the user views, or an empty string when none
+	GetViewing(ctx context.Context, userID primitive.ObjectID) (string, error)
+	ClearViewing(ctx context.Context, userID primitive.ObjectID) error
+	// Publish delivers update to the subscribers of the user on any instance
+	Publish(ctx context.Context, userID primitive.ObjectID, update *PresenceUpdate) error
+	// Subscribe delivers the updates published to the user until ctx is done
+	Subscribe(ctx context.Context, userID primitive.ObjectID) (<-chan *PresenceUpdate, error)
+}
+
+// PresenceService defines the interface for showing partners viewing the same resource
+type PresenceService interface {
+	Subscribe(ctx context.Context, userID primitive.ObjectID) (<-chan *PresenceUpdate, error)
+	Viewing(ctx context.Context, userID primitive.ObjectID, req *ViewingRequest) (*PresenceResponse, error)
+	StopViewing(ctx context.Context, userID primitive.ObjectID) error
+	// RefreshInterval is how often open connections report their resource again so
+	// that it does not expire
+	RefreshInterval() time.Duration
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Retention policy names
+const (
+	RetentionPolicyUnverifiedAccounts = "unverified_accounts"
+	RetentionPolicyStaleMatchRequests = "stale_match_requests"
+	RetentionPolicyDeletedAccounts    = "deleted_accounts"
+)
+
+// RetentionPolicy purges one kind of record once it is older than MaxAge
+type RetentionPolicy interface {
+	Name() string
+	Description() string
+	MaxAge() time.Duration
+	// FindExpired lists up to limit IDs, in ascending order and after afterID, of
+	// records that expired before cutoff
+	FindExpired(ctx context.Context, cutoff time.Time, afterID primitive.ObjectID, limit int) ([]primitive.ObjectID, error)
+	// Purge permanently deletes those of ids that are still expired at cutoff
+	Purge(ctx context.Context, ids []primitive.ObjectID, cutoff time.Time) (int64, error)
+}
+
+// RetentionFilePolicy is implemented by the retention policies whose records own stored
+// files. The files of a batch of records are deleted before the records are purged.
+type RetentionFilePolicy interface {
+	// FindFiles lists the storage keys of the files the records of ids own
+	FindFiles(ctx context.Context, ids []primitive.ObjectID) ([]string, error)
+	// DeleteFiles deletes the files stored under keys and returns how many it deleted
+	DeleteFiles(ctx context.Context, keys []string) (int64, error)
+}
+
+// RetentionAuditEntry records a purge made by a retention policy, or what a dry run
+// would have purged
+type RetentionAuditEntry struct {
+	ID          primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	RunID       string               `json:"run_id" bson:"run_id"`
+	Policy      string               `json:"policy" bson:"policy"`
+	DryRun      bool                 `json:"dry_run" bson:"dry_run"`
+	Cutoff      time.Time            `json:"cutoff" bson:"cutoff"`
+	Matched     int                  `json:"matched" bson:"matched"`
+	Purged      int64                `json:"purged" bson:"purged"`
+	Files       int                  `json:"files,omitempty" bson:"files,omitempty"`               // stored files the records own
+	FilesPurged int64                `json:"files_purged,omitempty" bson:"files_purged,omitempty"` // of which deleted
+	RecordIDs   []primitive.ObjectID `json:"record_ids" bson:"record_ids"`
+	Trigger     string               `json:"trigger" bson:"trigger"` // schedule or admin
+	CreatedAt   time.Time            `json:"created_at" bson:"created_at"`
+}
+
+// RetentionAuditFilter narrows the retention audit listing. Empty fields match everything.
+type RetentionAuditFilter struct {
+	Policy string
+	RunID  string
+}
+
+// RetentionAuditListResponse represents a page of retention audit entries, newest first
+type RetentionAuditListResponse struct {
+	Entries    []*RetentionAuditEntry `json:"entries"`
+	Limit      int                    `json:"limit"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+// RetentionPolicyInfo describes a configured retention policy
+type RetentionPolicyInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MaxAgeDays  int    `json:"max_age_days"`
+}
+
+// RunRetentionRequest represents a manual retention run. DryRun defaults to true;
+// an empty Policies list runs every policy.
+type RunRetentionRequest struct {
+	DryRun   *bool    `json:"dry_run,omitempty"`
+	Policies []string `json:"policies,omitempty" validate:"omitempty,max=10,dive,required,max=50"`
+}
+
+// RetentionPolicyReport is the outcome of one policy in a retention run
+type RetentionPolicyReport struct {
+	Policy      string    `json:"policy"`
+	Cutoff      time.Time `json:"cutoff"`
+	Matched     int       `json:"matched"`
+	Purged      int64     `json:"purged"`
+	Files       int       `json:"files,omitempty"`        // stored files the matched records own
+	FilesPurged int64     `json:"files_purged,omitempty"` // of which deleted
+	SampleIDs   []string  `json:"sample_ids,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
+
+// RetentionReport is the outcome of a retention run
+type RetentionReport struct {
+	RunID      string                   `json:"run_id"`
+	DryRun     bool                     `json:"dry_run"`
+	Trigger    string                   `json:"trigger"`
+	StartedAt  time.Time                `json:"started_at"`
+	FinishedAt time.Time                `json:"finished_at"`
+	Policies   []*RetentionPolicyReport `json:"policies"`
+}
+
+// RetentionAuditRepository defines the interface for retention audit data access
+type RetentionAuditRepository interface {
+	Create(ctx context.Context, entry *RetentionAuditEntry) error
+	List(ctx context.Context, filter RetentionAuditFilter, cursor *Cursor, limit int) ([]*RetentionAuditEntry, error)
+}
+
+// RetentionService defines the interface for the data retention engine
+type RetentionService interface {
+	ListPolicies(ctx context.Context) []*RetentionPolicyInfo
+	Run(ctx context.Context, req *RunRetentionRequest) (*RetentionReport, error)
+	RunScheduled(ctx context.Context) error
+	ListAudit(ctx context.Context, filter RetentionAuditFilter, cursor *Cursor, limit int) (*RetentionAuditListResponse, error)
+}
@@ -0,0 +1,40 @@
+package domain
+
+// Role grants a user access to the routes restricted to it
+type Role string
+
+const (
+	RoleUser      Role = "user"      // every account, whether or not stored
+	RoleModerator Role = "moderator" // triages feedback and client errors
+	RoleAdmin     Role = "admin"     // every admin operation
+)
+
+// SetRolesRequest represents the request to replace the roles of a user
+type SetRolesRequest struct {
+	Roles []Role `json:"roles" validate:"required,dive,oneof=user admin moderator"`
+}
+
+// HasRole reports whether the user has role. Every user has RoleUser.
+func (u *User) HasRole(role Role) bool {
+	if role == RoleUser {
+		return true
+	}
+	for _, have := range u.Roles {
+		if have == role {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleNames returns the names of the user's roles, RoleUser first, as they are put in
+// access tokens
+func (u *User) RoleNames() []string {
+	names := []string{string(RoleUser)}
+	for _, role := range u.Roles {
+		if role != RoleUser {
+			names = append(names, string(role))
+		}
+	}
+	return names
+}
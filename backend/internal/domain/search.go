@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SearchResultType labels which module a search result comes from
+type SearchResultType string
+
+const (
+	SearchResultPhoto   SearchResultType = "photo"
+	SearchResultEvent   SearchResultType = "event"
+	SearchResultMessage SearchResultType = "message"
+	SearchResultJournal SearchResultType = "journal"
+)
+
+// SearchResultTypes lists every searchable type in display order
+var SearchResultTypes = []SearchResultType{SearchResultPhoto, SearchResultEvent, SearchResultMessage, SearchResultJournal}
+
+// SearchResult is a single hit of the global search
+type SearchResult struct {
+	Type         SearchResultType `json:"type"`
+	ID           string           `json:"id"`
+	Title        string           `json:"title"`
+	Snippet      string           `json:"snippet,omitempty"`
+	ThumbnailURL string           `json:"thumbnail_url,omitempty"`
+	Date         Date             `json:"date"`
+	Score        int              `json:"score"`
+}
+
+// SearchResponse represents the global search response
+type SearchResponse struct {
+	Query   string                   `json:"query"`
+	Results []*SearchResult          `json:"results"`
+	Counts  map[SearchResultType]int `json:"counts"`
+	Total   int                      `json:"total"`
+	Page    int                      `json:"page"`
+	Limit   int                      `json:"limit"`
+}
+
+// SearchService defines the interface for searching across the couple's content
+type SearchService interface {
+	Search(ctx context.Context, userID primitive.ObjectID, query string, types []SearchResultType, page, limit int) (*SearchResponse, error)
+}
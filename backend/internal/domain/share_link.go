@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ShareTargetType represents the kind of content a share link exposes
+type ShareTargetType string
+
+const (
+	ShareTargetPhoto ShareTargetType = "photo"
+	ShareTargetAlbum ShareTargetType = "album"
+)
+
+// ShareLink represents an expiring, revocable public link to a single photo or album
+type ShareLink struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Token        string             `json:"token" bson:"token"`
+	MatchCode    string             `json:"match_code" bson:"match_code"`
+	CreatedBy    primitive.ObjectID `json:"created_by" bson:"created_by"`
+	TargetType   ShareTargetType    `json:"target_type" bson:"target_type"`
+	TargetID     primitive.ObjectID `json:"target_id" bson:"target_id"`
+	PasswordHash string             `json:"-" bson:"password_hash,omitempty"`
+	ExpiresAt    time.Time          `json:"expires_at" bson:"expires_at"`
+	RevokedAt    *time.Time         `json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
+	ViewCount    int                `json:"view_count" bson:"view_count"`
+	LastViewedAt *time.Time         `json:"last_viewed_at,omitempty" bson:"last_viewed_at,omitempty"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// IsActive reports whether the link can still be opened
+func (l *ShareLink) IsActive(now time.Time) bool {
+	return l.RevokedAt == nil && now.Before(l.ExpiresAt)
+}
+
+// CreateShareLinkRequest represents the request to create a share link
+type CreateShareLinkRequest struct {
+	ExpiresInHours int    `json:"expires_in_hours" validate:"omitempty,min=1,max=720"`
+	Password       string `json:"password,omitempty" validate:"omitempty,min=4,max=64"`
+}
+
+// ShareLinkResponse represents the API response for a share link
+type ShareLinkResponse struct {
+	ID           string          `json:"id"`
+	Token        string          `json:"token"`
+	TargetType   ShareTargetType `json:"target_type"`
+	TargetID     string          `json:"target_id"`
+	HasPassword  bool            `json:"has_password"`
+	IsActive     bool            `json:"is_active"`
+	ExpiresAt    time.Time       `json:"expires_at"`
+	RevokedAt    *time.Time      `json:"revoked_at,omitempty"`
+	ViewCount    int             `json:"view_count"`
+	LastViewedAt *time.Time      `json:"last_viewed_at,omitempty"`
+	CreatedBy    string          `json:"created_by"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// ToResponse converts ShareLink to ShareLinkResponse
+func (l *ShareLink) ToResponse() *ShareLinkResponse {
+	return &ShareLinkResponse{
+		ID:           l.ID.Hex(),
+		Token:        l.Token,
+		TargetType:   l.TargetType,
+		TargetID:     l.TargetID.Hex(),
+		HasPassword:  l.PasswordHash != "",
+		IsActive:     l.IsActive(time.Now()),
+		ExpiresAt:    l.ExpiresAt,
+		RevokedAt:    l.RevokedAt,
+		ViewCount:    l.ViewCount,
+		LastViewedAt: l.LastViewedAt,
+		CreatedBy:    l.CreatedBy.Hex(),
+		CreatedAt:    l.CreatedAt,
+	}
+}
+
+// ShareLinkListResponse represents a list of share links response
+type ShareLinkListResponse struct {
+	ShareLinks []*ShareLinkResponse `json:"share_links"`
+	Total      int                  `json:"total"`
+}
+
+// SharedPhotoResponse is the read-only view of a photo opened through a share link.
+// It deliberately omits couple identifiers and privacy flags.
+type SharedPhotoResponse struct {
+	Title        string `json:"title"`
+	Description  string `json:"description,omitempty"`
+	ImageURL     string `json:"image_url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+	Date         Date   `json:"date"`
+	Location     string `json:"location,omitempty"`
+}
+
+// SharedAlbumResponse is the read-only view of an album opened through a share link
+type SharedAlbumResponse struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Photos      []*SharedPhotoResponse `json:"photos"`
+}
+
+// SharedContentResponse represents the content served for a share link
+type SharedContentResponse struct {
+	TargetType ShareTargetType      `json:"target_type"`
+	Photo      *SharedPhotoResponse `json:"photo,omitempty"`
+	Album      *SharedAlbumResponse `json:"album,omitempty"`
+	ExpiresAt  time.Time            `json:"expires_at"`
+}
+
+// ShareLinkRepository defines the interface for share link data access
+type ShareLinkRepository interface {
+	Create(ctx context.Context, link *ShareLink) error
+	GetByID(ctx context.Context, id primitive.ObjectID) (*ShareLink, error)
+	GetByToken(ctx context.Context, token string) (*ShareLink, error)
+	GetByMatchCode(ctx context.Context, matchCode string) ([]*ShareLink, error)
+	Revoke(ctx context.Context, id primitive.ObjectID, revokedAt time.Time) error
+	RecordView(ctx context.Context, id primitive.ObjectID, viewedAt time.Time) error
+}
+
+// ShareLinkService defines the interface for share link business logic
+type ShareLinkService interface {
+	CreateShareLink(ctx context.Context, userID primitive.ObjectID, targetType ShareTargetType, targetID primitive.ObjectID, req *CreateShareLinkRequest) (*ShareLinkResponse, error)
+	GetShareLinks(ctx context.Context, userID primitive.ObjectID) ([]*ShareLinkResponse, error)
+	RevokeShareLink(ctx context.Context, linkID, userID primitive.ObjectID) error
+	OpenShareLink(ctx context.Context, token, password string) (*SharedContentResponse, error)
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"io"
 	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // FileInfo represents information about an uploaded file
@@ -16,6 +18,7 @@ type FileInfo struct {
 	Size        int64     `json:"size"`         // File size in bytes
 	UploadedAt  time.Time `json:"uploaded_at"`  // Upload timestamp
 	Bucket      string    `json:"bucket"`       // S3 bucket name
+	Checksum    string    `json:"checksum"`     // Hex SHA-256 of the content, set when the file is stored
 }
 
 // UploadRequest represents a file upload request
@@ -48,19 +51,42 @@ type StorageService interface {
 	// GetFileInfo retrieves file information
 	GetFileInfo(ctx context.Context, key string) (*FileInfo, error)
 
-	// ListFiles lists files in a folder
-	ListFiles(ctx context.Context, folder string, limit int) ([]*FileInfo, error)
+	// ListFiles lists up to limit files in a folder in key order, starting after the
+	// key startAfter when it is not empty
+	ListFiles(ctx context.Context, folder string, startAfter string, limit int) ([]*FileInfo, error)
 
 	// GeneratePresignedUploadURL generates a presigned URL for direct upload
 	GeneratePresignedUploadURL(ctx context.Context, key string, contentType string, expiry time.Duration) (string, error)
 
 	// GeneratePresignedDownloadURL generates a presigned URL for direct download
 	GeneratePresignedDownloadURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+
+	// GetObject opens a stored file for reading
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// PutObject stores a file under an exact key, replacing any existing file
+	PutObject(ctx context.Context, key string, file io.Reader, size int64, contentType string) (*FileInfo, error)
+
+	// Ping checks that the storage can be reached
+	Ping(ctx context.Context) error
+
+	// CreateMultipartUpload starts storing a file sent in parts under key and returns
+	// the ID of the upload
+	CreateMultipartUpload(ctx context.Context, key string, contentType string) (string, error)
+
+	// UploadPart stores a part of a multipart upload, replacing any part with its number
+	UploadPart(ctx context.Context, key, uploadID string, partNumber int, data io.Reader, size int64) (*UploadedPart, error)
+
+	// CompleteMultipartUpload joins the parts, in order, into the file
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []*UploadedPart) (*FileInfo, error)
+
+	// AbortMultipartUpload discards a multipart upload and its parts
+	AbortMultipartUpload(ctx context.Context, key, uploadID string) error
 }
 
 // StorageConfig represents storage configuration
 type StorageConfig struct {
-	Provider        string `json:"provider"`          // aws, gcp, azure, local
+	Provider        string `json:"provider"`          // local, minio, s3, gcs, azure
 	Region          string `json:"region"`            // AWS region
 	Bucket          string `json:"bucket"`            // S3 bucket name
 	AccessKeyID     string `json:"access_key_id"`     // AWS access key
@@ -77,6 +103,7 @@ const (
 	FileTypeImage    FileType = "image"
 	FileTypeVideo    FileType = "video"
 	FileTypeDocument FileType = "document"
+	FileTypeAudio    FileType = "audio"
 	FileTypeOther    FileType = "other"
 )
 
@@ -85,15 +112,20 @@ func GetFileType(contentType string) FileType {
 	switch {
 	case contentType == "image/jpeg" || contentType == "image/png" || contentType == "image/gif" || contentType == "image/webp":
 		return FileTypeImage
-	case contentType == "video/mp4" || contentType == "video/avi" || contentType == "video/mov":
+	case contentType == "video/mp4" || contentType == "video/quicktime" || contentType == "video/avi" || contentType == "video/mov":
 		return FileTypeVideo
 	case contentType == "application/pdf" || contentType == "application/msword":
 		return FileTypeDocument
+	case contentType == "audio/mpeg" || contentType == "audio/mp4" || contentType == "audio/aac" || contentType == "audio/ogg" || contentType == "audio/webm" || contentType == "audio/wav":
+		return FileTypeAudio
 	default:
 		return FileTypeOther
 	}
 }
 
+// MaxImageSize is the largest image that can be uploaded, in bytes
+const MaxImageSize = 10 * 1024 * 1024
+
 // ValidateImageFile validates if the file is a supported image
 func ValidateImageFile(contentType string, size int64) error {
 	// Check content type
@@ -101,8 +133,23 @@ func ValidateImageFile(contentType string, size int64) error {
 		return ErrUnsupportedFileType
 	}
 
-	// Check file size (max 10MB for images)
-	maxSize := int64(10 * 1024 * 1024) // 10MB
+	// Check file size
+	if size > MaxImageSize {
+		return ErrFileTooLarge
+	}
+
+	return nil
+}
+
+// ValidateAudioFile validates if the file is a supported audio clip
+func ValidateAudioFile(contentType string, size int64) error {
+	// Check content type
+	if GetFileType(contentType) != FileTypeAudio {
+		return ErrUnsupportedFileType
+	}
+
+	// Check file size (max 5MB for audio clips)
+	maxSize := int64(5 * 1024 * 1024) // 5MB
 	if size > maxSize {
 		return ErrFileTooLarge
 	}
@@ -110,11 +157,62 @@ func ValidateImageFile(contentType string, size int64) error {
 	return nil
 }
 
+// ImageVariant identifies a resized rendition of an uploaded image. Variants are
+// turned upright and carry no metadata.
+type ImageVariant string
+
+const (
+	ImageVariantThumb  ImageVariant = "thumb"
+	ImageVariantMedium ImageVariant = "medium"
+	// ImageVariantPublic is served in place of the original outside the couple, so
+	// that its location and camera details are not shared
+	ImageVariantPublic ImageVariant = "public"
+)
+
+// ImageVariantSizes maps each variant to the maximum length in pixels of its longer side
+var ImageVariantSizes = map[ImageVariant]int{
+	ImageVariantThumb:  320,
+	ImageVariantMedium: 1280,
+	ImageVariantPublic: 4096,
+}
+
+// ImageService generates resized variants of images held in storage
+type ImageService interface {
+	// GenerateVariants renders every variant of the image stored under key and
+	// returns the storage key of each one
+	GenerateVariants(ctx context.Context, key string) (map[ImageVariant]string, error)
+
+	// ReadMetadata returns what the camera recorded in the image stored under key, or
+	// nil when it recorded nothing
+	ReadMetadata(ctx context.Context, key string) (*PhotoMetadata, error)
+
+	// PerceptualHash returns a hash of the image stored under key that is close to
+	// the hash of near-identical images
+	PerceptualHash(ctx context.Context, key string) (string, error)
+}
+
+// StoredFile is a stored file opened for reading
+type StoredFile struct {
+	Content     io.ReadCloser
+	ContentType string // empty when the storage does not record it
+	Size        int64
+	ModifiedAt  time.Time
+}
+
+// FileService defines the interface for serving stored files to users
+type FileService interface {
+	// OpenFile opens the file stored under key, if the user may read it: avatars are
+	// readable by everyone, photos and their variants by the couple they belong to and
+	// their uploader, and other files by their uploader and the uploader's partner
+	OpenFile(ctx context.Context, userID primitive.ObjectID, key string) (*StoredFile, error)
+}
+
 // Storage errors
 var (
 	ErrFileNotFound        = errors.New("file not found")
 	ErrUnsupportedFileType = errors.New("unsupported file type")
 	ErrFileTooLarge        = errors.New("file size exceeds limit")
+	ErrVideoTooLong        = errors.New("video exceeds length limit")
 	ErrUploadFailed        = errors.New("failed to upload file")
 	ErrDownloadFailed      = errors.New("failed to download file")
 	ErrDeleteFailed        = errors.New("failed to delete file")
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Storage integrity issue statuses
+const (
+	StorageIssueCorrupt = "corrupt"
+	StorageIssueMissing = "missing"
+)
+
+// StorageIntegrityIssue records a photo whose stored image no longer matches its
+// checksum, or is gone. An issue is removed once a later check finds the object intact.
+type StorageIntegrityIssue struct {
+	ID               primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	PhotoID          primitive.ObjectID `json:"photo_id" bson:"photo_id"`
+	MatchCode        string             `json:"match_code" bson:"match_code"`
+	Key              string             `json:"key" bson:"key"`
+	Status           string             `json:"status" bson:"status"`
+	ExpectedChecksum string             `json:"expected_checksum,omitempty" bson:"expected_checksum,omitempty"`
+	ActualChecksum   string             `json:"actual_checksum,omitempty" bson:"actual_checksum,omitempty"`
+	DetectedAt       time.Time          `json:"detected_at" bson:"detected_at"`
+	CheckedAt        time.Time          `json:"checked_at" bson:"checked_at"`
+}
+
+// StorageIntegrityListResponse represents a page of storage integrity issues, most
+// recently detected first, with the number of open issues of each status
+type StorageIntegrityListResponse struct {
+	Issues     []*StorageIntegrityIssue `json:"issues"`
+	Counts     map[string]int64         `json:"counts"`
+	Limit      int                      `json:"limit"`
+	NextCursor string                   `json:"next_cursor,omitempty"`
+}
+
+// StorageReconciliation records a reconciliation of the stored photo files with the
+// photo records: files no photo refers to are orphans and deleted, unless the run is a
+// dry run, and photos whose image is gone are recorded as missing issues
+type StorageReconciliation struct {
+	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	DryRun         bool               `json:"dry_run" bson:"dry_run"`
+	Trigger        string             `json:"trigger" bson:"trigger"` // schedule or admin
+	FilesScanned   int                `json:"files_scanned" bson:"files_scanned"`
+	Orphans        int                `json:"orphans" bson:"orphans"`
+	OrphansDeleted int                `json:"orphans_deleted" bson:"orphans_deleted"`
+	OrphanKeys     []string           `json:"orphan_keys" bson:"orphan_keys"` // the first orphans found
+	PhotosScanned  int                `json:"photos_scanned" bson:"photos_scanned"`
+	Missing        int                `json:"missing" bson:"missing"`
+	Error          string             `json:"error,omitempty" bson:"error,omitempty"`
+	StartedAt      time.Time          `json:"started_at" bson:"started_at"`
+	FinishedAt     time.Time          `json:"finished_at" bson:"finished_at"`
+	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// RunStorageReconciliationRequest represents a manual reconciliation. DryRun defaults
+// to true.
+type RunStorageReconciliationRequest struct {
+	DryRun *bool `json:"dry_run,omitempty"`
+}
+
+// StorageReconciliationListResponse represents a page of reconciliations, newest first
+type StorageReconciliationListResponse struct {
+	Reconciliations []*StorageReconciliation `json:"reconciliations"`
+	Limit           int                      `json:"limit"`
+	NextCursor      string                   `json:"next_cursor,omitempty"`
+}
+
+// StorageReconciliationRepository defines the interface for reconciliation report data access
+type StorageReconciliationRepository interface {
+	Create(ctx context.Context, reconciliation *StorageReconciliation) error
+	List(ctx context.Context, cursor *Cursor, limit int) ([]*StorageReconciliation, error)
+}
+
+// StorageIntegrityRepository defines the interface for storage integrity issue data access
+type StorageIntegrityRepository interface {
+	// Upsert records an issue for its photo, keeping the original detection time
+	Upsert(ctx context.Context, issue *StorageIntegrityIssue) error
+	Resolve(ctx context.Context, photoID primitive.ObjectID) error
+	List(ctx context.Context, status string, cursor *Cursor, limit int) ([]*StorageIntegrityIssue, error)
+	CountByStatus(ctx context.Context) (map[string]int64, error)
+}
+
+// StorageIntegrityService defines the interface for verifying stored photos
+type StorageIntegrityService interface {
+	// CheckSample verifies a random sample of stored photos against their checksums.
+	// It is run periodically by the scheduler.
+	CheckSample(ctx context.Context) error
+	ListIssues(ctx context.Context, status string, cursor *Cursor, limit int) (*StorageIntegrityListResponse, error)
+
+	// Reconcile cross-references the stored photo files with the photo records now. It
+	// is a dry run unless DryRun is false.
+	Reconcile(ctx context.Context, req *RunStorageReconciliationRequest) (*StorageReconciliation, error)
+	// ReconcileScheduled is run periodically by the scheduler
+	ReconcileScheduled(ctx context.Context) error
+	ListReconciliations(ctx context.Context, cursor *Cursor, limit int) (*StorageReconciliationListResponse, error)
+}
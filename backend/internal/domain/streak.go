@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EngagementKind is a daily interaction of a partner that keeps the couple's streak going
+type EngagementKind string
+
+const (
+	EngagementMessage EngagementKind = "message"
+	EngagementPhoto   EngagementKind = "photo"
+	EngagementCheckIn EngagementKind = "check_in"
+)
+
+// CoupleActivity records the interactions of a couple on one day of the couple's time zone
+type CoupleActivity struct {
+	ID        primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	MatchCode string               `json:"match_code" bson:"match_code"`
+	Day       time.Time            `json:"day" bson:"day"` // midnight UTC of the local day
+	Kinds     []EngagementKind     `json:"kinds" bson:"kinds"`
+	UserIDs   []primitive.ObjectID `json:"user_ids" bson:"user_ids"` // partners who interacted
+	ExpiresAt time.Time            `json:"-" bson:"expires_at"`
+	CreatedAt time.Time            `json:"created_at" bson:"created_at"`
+}
+
+// CoupleStreak counts the consecutive days a couple interacted on. Days are counted once
+// they are over in the couple's time zone; FinalizeAt is when the day after FinalizedDay
+// ends and can be counted.
+type CoupleStreak struct {
+	ID            primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	MatchCode     string             `json:"match_code" bson:"match_code"`
+	Current       int                `json:"current" bson:"current"` // days up to FinalizedDay
+	Longest       int                `json:"longest" bson:"longest"`
+	LastActiveDay *time.Time         `json:"last_active_day,omitempty" bson:"last_active_day,omitempty"`
+	FinalizedDay  time.Time          `json:"finalized_day" bson:"finalized_day"`
+	FinalizeAt    time.Time          `json:"finalize_at" bson:"finalize_at"`
+	CreatedAt     time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// StreakResponse represents the API response for a couple's streak. Current includes
+// today once the couple has interacted today; until then a streak that lasted until
+// yesterday is still current and can be kept going.
+type StreakResponse struct {
+	Current       int              `json:"current"`
+	Longest       int              `json:"longest"`
+	ActiveToday   bool             `json:"active_today"`
+	TodayKinds    []EngagementKind `json:"today_kinds"`
+	LastActiveDay *Date            `json:"last_active_day,omitempty"`
+}
+
+// CoupleStatsResponse represents the engagement statistics of a couple
+type CoupleStatsResponse struct {
+	Today    Date           `json:"today"` // in the couple's time zone
+	Timezone string         `json:"timezone"`
+	Streak   StreakResponse `json:"streak"`
+}
+
+// EngagementRepository defines the interface for couple activity and streak data access
+type EngagementRepository interface {
+	// RecordActivity adds an interaction of userID to the couple's activity of day
+	RecordActivity(ctx context.Context, matchCode string, day time.Time, userID primitive.ObjectID, kind EngagementKind) error
+	// GetActivity returns the couple's activity of day, or nil when they did not interact
+	GetActivity(ctx context.Context, matchCode string, day time.Time) (*CoupleActivity, error)
+	GetStreak(ctx context.Context, matchCode string) (*CoupleStreak, error)
+	// CreateStreak creates the couple's streak unless it already exists
+	CreateStreak(ctx context.Context, streak *CoupleStreak) error
+	UpdateStreak(ctx context.Context, streak *CoupleStreak) error
+	// ListStreaksToFinalize lists up to limit streaks with a day over by now that was not counted yet
+	ListStreaksToFinalize(ctx context.Context, now time.Time, limit int) ([]*CoupleStreak, error)
+}
+
+// EngagementService defines the interface for daily engagement tracking
+type EngagementService interface {
+	// Record records an interaction of a partner of the couple today
+	Record(ctx context.Context, matchCode string, userID primitive.ObjectID, kind EngagementKind) error
+	GetStats(ctx context.Context, userID primitive.ObjectID) (*CoupleStatsResponse, error)
+	// FinalizeStreaks counts the days that ended in each couple's time zone
+	FinalizeStreaks(ctx context.Context) error
+	// HandleEvent records the interaction of a published domain event
+	HandleEvent(ctx context.Context, event *DomainEvent)
+}
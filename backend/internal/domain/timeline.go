@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TimelineItemType labels the kind of entry in the couple timeline
+type TimelineItemType string
+
+const (
+	TimelineItemPhoto     TimelineItemType = "photo"
+	TimelineItemEvent     TimelineItemType = "event"
+	TimelineItemMilestone TimelineItemType = "milestone"
+	TimelineItemJournal   TimelineItemType = "journal"
+)
+
+// TimelineItemTypes lists every timeline item type
+var TimelineItemTypes = []TimelineItemType{TimelineItemPhoto, TimelineItemEvent, TimelineItemMilestone, TimelineItemJournal}
+
+// MilestoneKind identifies which relationship milestone a timeline entry marks
+type MilestoneKind string
+
+const (
+	MilestoneMatched     MilestoneKind = "matched"
+	MilestoneAnniversary MilestoneKind = "anniversary"
+	MilestoneDays        MilestoneKind = "days"
+)
+
+// TimelineMilestone describes a milestone entry. Value is the number of years for
+// anniversaries and the number of days for day milestones.
+type TimelineMilestone struct {
+	Kind  MilestoneKind `json:"kind"`
+	Value int           `json:"value,omitempty"`
+}
+
+// TimelineItem is a single entry of the couple timeline. Exactly one of Photo,
+// Event, Milestone and Journal is set, matching Type.
+type TimelineItem struct {
+	Type      TimelineItemType      `json:"type"`
+	ID        string                `json:"id"`
+	Date      Date                  `json:"date"`
+	Title     string                `json:"title"`
+	Photo     *PhotoResponse        `json:"photo,omitempty"`
+	Event     *EventResponse        `json:"event,omitempty"`
+	Milestone *TimelineMilestone    `json:"milestone,omitempty"`
+	Journal   *JournalEntryResponse `json:"journal,omitempty"`
+}
+
+// TimelineQuery holds the filters and position of a timeline request
+type TimelineQuery struct {
+	Types  []TimelineItemType
+	From   *Date
+	To     *Date
+	Cursor *TimelineCursor
+	Limit  int
+}
+
+// TimelineResponse represents a page of the couple timeline, newest first
+type TimelineResponse struct {
+	Items      []*TimelineItem  `json:"items"`
+	Limit      int              `json:"limit"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+	Formatting *FormattingHints `json:"formatting"`
+}
+
+// TimelineCursor marks a position in the timeline. Items are sorted newest first by
+// date, then by type and ID so that items sharing a date keep a stable order.
+type TimelineCursor struct {
+	Date time.Time
+	Type TimelineItemType
+	ID   string
+}
+
+// Key returns the tie-breaking sort key of the cursor position
+func (c *TimelineCursor) Key() string {
+	return TimelineSortKey(c.Type, c.ID)
+}
+
+// TimelineSortKey returns the key that orders items sharing the same date
+func TimelineSortKey(itemType TimelineItemType, id string) string {
+	return string(itemType) + ":" + id
+}
+
+// Encode returns the opaque string form of the cursor sent to clients
+func (c *TimelineCursor) Encode() string {
+	raw := strconv.FormatInt(c.Date.UnixMilli(), 10) + "_" + c.Key()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeTimelineCursor parses a cursor previously returned as next_cursor.
+// An empty string means "start from the newest item" and returns nil.
+func DecodeTimelineCursor(value string) (*TimelineCursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	parts := strings.SplitN(string(raw), "_", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	millis, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	keyParts := strings.SplitN(parts[1], ":", 2)
+	if len(keyParts) != 2 || keyParts[1] == "" || !IsTimelineItemType(TimelineItemType(keyParts[0])) {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return &TimelineCursor{
+		Date: time.UnixMilli(millis).UTC(),
+		Type: TimelineItemType(keyParts[0]),
+		ID:   keyParts[1],
+	}, nil
+}
+
+// IsTimelineItemType reports whether t is a known timeline item type
+func IsTimelineItemType(t TimelineItemType) bool {
+	for _, known := range TimelineItemTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// TimelineService defines the interface for the shared couple timeline
+type TimelineService interface {
+	GetTimeline(ctx context.Context, userID primitive.ObjectID, query *TimelineQuery) (*TimelineResponse, error)
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// timeOfDayLayouts lists the accepted formats of times of day, such as Event.Time
+var timeOfDayLayouts = []string{"15:04", "15:04:05"}
+
+// LoadLocation returns the time zone named by an IANA name, or UTC when the name is
+// empty or unknown
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Today returns the calendar day it is now in location, as midnight UTC like the dates
+// of events and other day-based records are stored
+func Today(location *time.Location) time.Time {
+	return DayIn(time.Now(), location)
+}
+
+// DayIn returns the calendar day of t in location, as midnight UTC
+func DayIn(t time.Time, location *time.Location) time.Time {
+	t = t.In(location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// AtTimeOfDay returns the instant a calendar day, stored as midnight UTC, reaches the
+// offset from midnight in location
+func AtTimeOfDay(day time.Time, offset time.Duration, location *time.Location) time.Time {
+	day = day.UTC()
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, location)
+	return midnight.Add(offset)
+}
+
+// ParseTimeOfDay parses a time of day such as "19:30" into the offset from midnight
+func ParseTimeOfDay(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	for _, layout := range timeOfDayLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second, true
+		}
+	}
+	return 0, false
+}
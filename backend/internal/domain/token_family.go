@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TokenFamilyRepository tracks the rotation families of session refresh tokens. A
+// login starts a family; each refresh redeems the presented token and issues the
+// next one of its family. A token presented a second time was stolen or leaked, so
+// its whole family is revoked.
+type TokenFamilyRepository interface {
+	// Start records a new family of the user, active for ttl
+	Start(ctx context.Context, familyID string, userID primitive.ObjectID, ttl time.Duration) error
+	// IsActive reports whether the family has neither been revoked nor expired
+	IsActive(ctx context.Context, familyID string) (bool, error)
+	// Extend keeps the family active for ttl from now
+	Extend(ctx context.Context, familyID string, ttl time.Duration) error
+	// Revoke ends the family, invalidating every token in it
+	Revoke(ctx context.Context, familyID string) error
+	// RevokeAll ends every family of the user, signing out all of their sessions
+	RevokeAll(ctx context.Context, userID primitive.ObjectID) error
+	// Redeem marks a refresh token as used, remembering it for ttl, and reports
+	// whether this is the first time it is redeemed
+	Redeem(ctx context.Context, tokenID string, ttl time.Duration) (bool, error)
+}
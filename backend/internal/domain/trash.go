@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TrashRetention is how long soft-deleted content stays restorable before it is purged
+const TrashRetention = 30 * 24 * time.Hour
+
+// TrashItemType represents the kind of content in the trash
+type TrashItemType string
+
+const (
+	TrashItemPhoto   TrashItemType = "photo"
+	TrashItemEvent   TrashItemType = "event"
+	TrashItemMessage TrashItemType = "message"
+	TrashItemJournal TrashItemType = "journal"
+)
+
+// TrashItem is a soft-deleted item shown in the recently deleted overview. Messages and
+// journal entries are only listed to their sender or author; messages use the start of
+// their content as title.
+type TrashItem struct {
+	Type           TrashItemType `json:"type"`
+	ID             string        `json:"id"`
+	Title          string        `json:"title"`
+	ThumbnailURL   string        `json:"thumbnail_url,omitempty"`
+	DeletedAt      time.Time     `json:"deleted_at"`
+	PurgeAt        time.Time     `json:"purge_at"`
+	DaysUntilPurge int           `json:"days_until_purge"`
+}
+
+// NewTrashItem creates a trash item with its purge countdown
+func NewTrashItem(itemType TrashItemType, id primitive.ObjectID, title string, deletedAt, now time.Time) *TrashItem {
+	purgeAt := deletedAt.Add(TrashRetention)
+
+	days := int(purgeAt.Sub(now).Hours() / 24)
+	if days < 0 {
+		days = 0
+	}
+
+	return &TrashItem{
+		Type:           itemType,
+		ID:             id.Hex(),
+		Title:          title,
+		DeletedAt:      deletedAt,
+		PurgeAt:        purgeAt,
+		DaysUntilPurge: days,
+	}
+}
+
+// TrashResponse represents the recently deleted overview
+type TrashResponse struct {
+	Items         []*TrashItem `json:"items"`
+	Total         int          `json:"total"`
+	RetentionDays int          `json:"retention_days"`
+}
+
+// TrashItemRef identifies a single item in the trash
+type TrashItemRef struct {
+	Type TrashItemType `json:"type" validate:"required,oneof=photo event message journal"`
+	ID   string        `json:"id" validate:"required"`
+}
+
+// RestoreTrashRequest represents the request to restore items from the trash
+type RestoreTrashRequest struct {
+	Items []TrashItemRef `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+// RestoreTrashResponse reports which items were restored
+type RestoreTrashResponse struct {
+	Restored int            `json:"restored"`
+	Failed   []TrashItemRef `json:"failed"`
+}
+
+// TrashService defines the interface for the recently deleted overview
+type TrashService interface {
+	GetTrash(ctx context.Context, userID primitive.ObjectID) (*TrashResponse, error)
+	Restore(ctx context.Context, userID primitive.ObjectID, req *RestoreTrashRequest) (*RestoreTrashResponse, error)
+	PurgeExpired(ctx context.Context) error
+
+	// Photos, events and journal entries in the trash, one type at a time
+	GetTrashOfType(ctx context.Context, userID primitive.ObjectID, itemType TrashItemType) (*TrashResponse, error)
+	RestoreItem(ctx context.Context, userID primitive.ObjectID, itemType TrashItemType, id primitive.ObjectID) error
+	PurgeItem(ctx context.Context, userID primitive.ObjectID, itemType TrashItemType, id primitive.ObjectID) error
+}
@@ -0,0 +1,176 @@
+package domain
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// SniffLength is how much of a file is read to detect its content type
+const SniffLength = 512
+
+// uploadTypeExtensions maps the content types that uploads can be allowed for to the
+// file extensions of each
+var uploadTypeExtensions = map[string][]string{
+	"image/jpeg":         {".jpg", ".jpeg"},
+	"image/png":          {".png"},
+	"image/gif":          {".gif"},
+	"image/webp":         {".webp"},
+	"application/pdf":    {".pdf"},
+	"application/msword": {".doc"},
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": {".docx"},
+	"video/mp4":       {".mp4"},
+	"video/quicktime": {".mov"},
+	"video/x-msvideo": {".avi"},
+}
+
+// IsKnownUploadType reports whether uploads can be allowed for contentType
+func IsKnownUploadType(contentType string) bool {
+	_, ok := uploadTypeExtensions[normalizeContentType(contentType)]
+	return ok
+}
+
+// UploadPolicy decides which files can be uploaded through the API
+type UploadPolicy struct {
+	maxSize        int64
+	folderMaxSizes map[string]int64
+	allowedTypes   map[string]bool
+}
+
+// NewUploadPolicy creates a policy accepting files of allowedTypes up to maxSize
+// bytes, or up to the size folderMaxSizes sets for their folder
+func NewUploadPolicy(maxSize int64, folderMaxSizes map[string]int64, allowedTypes []string) *UploadPolicy {
+	allowed := make(map[string]bool, len(allowedTypes))
+	for _, contentType := range allowedTypes {
+		allowed[normalizeContentType(contentType)] = true
+	}
+
+	return &UploadPolicy{
+		maxSize:        maxSize,
+		folderMaxSizes: folderMaxSizes,
+		allowedTypes:   allowed,
+	}
+}
+
+// MaxSize returns the largest file that can be uploaded to folder, in bytes
+func (p *UploadPolicy) MaxSize(folder string) int64 {
+	if size, ok := p.folderMaxSizes[strings.ToLower(folder)]; ok {
+		return size
+	}
+	return p.maxSize
+}
+
+// Validate checks a file about to be uploaded to folder: its content type must be
+// allowed, its extension one of that type's, and its size within the folder's limit.
+// Files are rejected with ErrUnsupportedFileTypeError or ErrFileTooLargeError.
+func (p *UploadPolicy) Validate(folder, filename, contentType string, size int64) error {
+	contentType = normalizeContentType(contentType)
+	if !p.allowedTypes[contentType] {
+		return ErrUnsupportedFileTypeError(contentType)
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	matches := false
+	for _, allowedExt := range uploadTypeExtensions[contentType] {
+		if ext == allowedExt {
+			matches = true
+			break
+		}
+	}
+	if !matches {
+		return ErrUnsupportedFileTypeError(ext)
+	}
+
+	if maxSize := p.MaxSize(folder); size > maxSize {
+		return ErrFileTooLargeError(maxSize)
+	}
+
+	return nil
+}
+
+// ValidateContent checks the first bytes of a file declared as contentType: the type
+// they show must be allowed and match the declared one. Files are rejected with
+// ErrUnsupportedFileTypeError.
+func (p *UploadPolicy) ValidateContent(contentType string, head []byte) error {
+	if sniffed := SniffContentType(head); !p.allowedTypes[sniffed] {
+		return ErrUnsupportedFileTypeError(sniffed)
+	}
+	return CheckContentType(contentType, head)
+}
+
+// CheckContentType checks that the first bytes of a file show the content type it was
+// declared as, and rejects it with ErrUnsupportedFileTypeError otherwise
+func CheckContentType(contentType string, head []byte) error {
+	declared := normalizeContentType(contentType)
+	sniffed := SniffContentType(head)
+
+	switch {
+	case declared == sniffed:
+		return nil
+	case isISOMedia(declared) && isISOMedia(sniffed):
+		// mp4 and QuickTime files share their format, and are often labelled as each other
+		return nil
+	case declared == "application/vnd.openxmlformats-officedocument.wordprocessingml.document" && sniffed == "application/zip":
+		return nil
+	}
+	return ErrUnsupportedFileTypeError(sniffed)
+}
+
+// SniffContentType detects the content type of a file from its first bytes
+func SniffContentType(head []byte) string {
+	if len(head) > SniffLength {
+		head = head[:SniffLength]
+	}
+
+	// http.DetectContentType only recognizes mp4 brands of ISO media files
+	if len(head) >= 12 && bytes.Equal(head[4:8], []byte("ftyp")) {
+		switch string(head[8:12]) {
+		case "qt  ":
+			return "video/quicktime"
+		case "heic", "heix", "mif1":
+			return "image/heic"
+		default:
+			return "video/mp4"
+		}
+	}
+	// Compound files, which older Word documents are
+	if bytes.HasPrefix(head, []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}) {
+		return "application/msword"
+	}
+
+	switch sniffed := normalizeContentType(http.DetectContentType(head)); sniffed {
+	case "video/avi":
+		return "video/x-msvideo"
+	default:
+		return sniffed
+	}
+}
+
+// ReadHead reads the first bytes of a file for SniffContentType and rewinds it
+func ReadHead(file io.ReadSeeker) ([]byte, error) {
+	head := make([]byte, SniffLength)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return head[:n], nil
+}
+
+// isISOMedia reports whether contentType is stored in the ISO base media file format
+func isISOMedia(contentType string) bool {
+	return contentType == "video/mp4" || contentType == "video/quicktime"
+}
+
+// normalizeContentType lowercases a content type and drops its parameters
+func normalizeContentType(contentType string) string {
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return strings.ToLower(strings.TrimSpace(contentType))
+}
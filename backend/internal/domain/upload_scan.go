@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+// ScanResult is the verdict of a content scan
+type ScanResult struct {
+	Clean  bool
+	Threat string // what was found, when the file is not clean
+}
+
+// FileScanner checks the content of files, such as for malware
+type FileScanner interface {
+	Scan(ctx context.Context, file io.Reader) (*ScanResult, error)
+}
+
+// UploadScanService scans uploaded files before they are used
+type UploadScanService interface {
+	// ScanUpload scans a stored file. A rejected file is quarantined or deleted and
+	// ErrFileRejectedError returned; a file that cannot be scanned is deleted.
+	ScanUpload(ctx context.Context, file *FileInfo) error
+}
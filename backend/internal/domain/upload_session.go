@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UploadSession is a resumable upload. The file is sent in chunks of ChunkSize bytes,
+// the last one holding the remainder, which may be sent in any order and again until
+// the upload is completed. A presigned session is instead uploaded by the client
+// straight to the storage in one request, then confirmed.
+type UploadSession struct {
+	ID              string             `json:"id"`
+	UserID          primitive.ObjectID `json:"user_id"`
+	Key             string             `json:"key"`
+	StorageUploadID string             `json:"storage_upload_id"`
+	Filename        string             `json:"filename"`
+	ContentType     string             `json:"content_type"`
+	Size            int64              `json:"size"`
+	ChunkSize       int64              `json:"chunk_size"`
+	Presigned       bool               `json:"presigned"`
+	CreatedAt       time.Time          `json:"created_at"`
+	ExpiresAt       time.Time          `json:"expires_at"`
+}
+
+// ChunkCount returns the number of chunks the file is sent in
+func (s *UploadSession) ChunkCount() int {
+	return int((s.Size + s.ChunkSize - 1) / s.ChunkSize)
+}
+
+// ChunkLength returns the size in bytes of the chunk at index
+func (s *UploadSession) ChunkLength(index int) int64 {
+	if index == s.ChunkCount()-1 {
+		return s.Size - int64(index)*s.ChunkSize
+	}
+	return s.ChunkSize
+}
+
+// UploadedPart is a stored part of a multipart upload. Parts are numbered from 1.
+type UploadedPart struct {
+	Number int    `json:"number"`
+	ETag   string `json:"etag"`
+}
+
+// UploadSizeLimits maps content types to the largest file of that type that can be
+// uploaded in chunks or straight to the storage, in bytes. A "type/*" entry applies
+// to every subtype.
+type UploadSizeLimits map[string]int64
+
+// MaxSize returns the largest file of contentType that can be uploaded, and false when
+// the type cannot be uploaded this way
+func (l UploadSizeLimits) MaxSize(contentType string) (int64, bool) {
+	contentType = strings.ToLower(strings.TrimSpace(contentType))
+	if size, ok := l[contentType]; ok {
+		return size, true
+	}
+	if mediaType, _, ok := strings.Cut(contentType, "/"); ok {
+		if size, ok := l[mediaType+"/*"]; ok {
+			return size, true
+		}
+	}
+	return 0, false
+}
+
+// InitUploadRequest represents the request to start a resumable or presigned upload
+type InitUploadRequest struct {
+	Filename    string `json:"filename" validate:"required,max=255"`
+	ContentType string `json:"content_type" validate:"required,max=100"`
+	Size        int64  `json:"size" validate:"required,min=1"`
+	Folder      string `json:"folder,omitempty" validate:"omitempty,alphanum,max=50"` // defaults to uploads
+}
+
+// UploadSessionResponse describes a resumable upload and the chunks received so far
+type UploadSessionResponse struct {
+	ID             string    `json:"id"`
+	Key            string    `json:"key"`
+	Filename       string    `json:"filename"`
+	ContentType    string    `json:"content_type"`
+	Size           int64     `json:"size"`
+	ChunkSize      int64     `json:"chunk_size"`
+	ChunkCount     int       `json:"chunk_count"`
+	ReceivedChunks []int     `json:"received_chunks"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// PresignedUploadResponse describes where to upload a file straight to the storage.
+// The file must be sent with a PUT request to UploadURL, with the Content-Type it was
+// declared with, before ExpiresAt, and then confirmed.
+type PresignedUploadResponse struct {
+	ID          string    `json:"id"`
+	Key         string    `json:"key"`
+	UploadURL   string    `json:"upload_url"`
+	Method      string    `json:"method"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// UploadSessionRepository keeps resumable and presigned uploads until they expire
+type UploadSessionRepository interface {
+	Create(ctx context.Context, session *UploadSession, ttl time.Duration) error
+	Get(ctx context.Context, id string) (*UploadSession, error)
+	SetPart(ctx context.Context, id string, part *UploadedPart, ttl time.Duration) error
+	ListParts(ctx context.Context, id string) ([]*UploadedPart, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// UploadSessionService defines the interface for resumable and presigned uploads
+type UploadSessionService interface {
+	Init(ctx context.Context, userID primitive.ObjectID, req *InitUploadRequest) (*UploadSessionResponse, error)
+	GetStatus(ctx context.Context, userID primitive.ObjectID, sessionID string) (*UploadSessionResponse, error)
+	UploadChunk(ctx context.Context, userID primitive.ObjectID, sessionID string, index int, data io.Reader, size int64) (*UploadSessionResponse, error)
+	Complete(ctx context.Context, userID primitive.ObjectID, sessionID string) (*FileInfo, error)
+	Abort(ctx context.Context, userID primitive.ObjectID, sessionID string) error
+	Presign(ctx context.Context, userID primitive.ObjectID, req *InitUploadRequest) (*PresignedUploadResponse, error)
+	ConfirmPresigned(ctx context.Context, userID primitive.ObjectID, sessionID string) (*FileInfo, error)
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Usage metrics rolled up per couple and day
+const (
+	UsageMetricPhotos   = "photos"
+	UsageMetricEvents   = "events"
+	UsageMetricMessages = "messages"
+)
+
+// Usage ranges accepted by the usage endpoint, in days
+const (
+	DefaultUsageRangeDays = 30
+	MaxUsageRangeDays     = 365
+)
+
+// DailyCount is the number of items a couple created on a day (UTC)
+type DailyCount struct {
+	MatchCode string `bson:"match_code"`
+	Day       Date   `bson:"day"`
+	Count     int64  `bson:"count"`
+}
+
+// CoupleUsageDay is the rollup of what a couple created on one day (UTC). Items count
+// on the day they were created, even when they were deleted since.
+type CoupleUsageDay struct {
+	Day      Date  `json:"day" bson:"day"`
+	Photos   int64 `json:"photos" bson:"photos"`
+	Events   int64 `json:"events" bson:"events"`
+	Messages int64 `json:"messages" bson:"messages"`
+}
+
+// CoupleUsageTotals sums the usage of every day in a range
+type CoupleUsageTotals struct {
+	Photos   int64 `json:"photos"`
+	Events   int64 `json:"events"`
+	Messages int64 `json:"messages"`
+}
+
+// CoupleUsageResponse is a couple's daily usage over a range of days, oldest first.
+// Every day of the range is listed, days without activity with zero counts.
+type CoupleUsageResponse struct {
+	From   Date              `json:"from"`
+	To     Date              `json:"to"`
+	Days   []*CoupleUsageDay `json:"days"`
+	Totals CoupleUsageTotals `json:"totals"`
+	// UpdatedAt is when the rollup last ran; activity since then is not counted yet
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// ParseUsageRange parses a range of days such as "90d"
+func ParseUsageRange(s string) (int, error) {
+	if s == "" {
+		return DefaultUsageRangeDays, nil
+	}
+
+	days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+	if err != nil || !strings.HasSuffix(s, "d") || days < 1 || days > MaxUsageRangeDays {
+		return 0, fmt.Errorf("invalid range %q, expected 1d to %dd", s, MaxUsageRangeDays)
+	}
+	return days, nil
+}
+
+// UsageRepository defines the interface for couple usage rollups
+type UsageRepository interface {
+	// SetDailyCounts stores the counts of one metric, replacing those already stored
+	// for the same couple and day
+	SetDailyCounts(ctx context.Context, metric string, counts []*DailyCount) error
+	// ListByMatchCode returns the stored days of a couple from from to to, inclusive
+	ListByMatchCode(ctx context.Context, matchCode string, from, to Date) ([]*CoupleUsageDay, error)
+	// GetRolledUpTo returns when the rollup last ran, or the zero time if it never did
+	GetRolledUpTo(ctx context.Context) (time.Time, error)
+	SetRolledUpTo(ctx context.Context, t time.Time) error
+}
+
+// UsageService defines the interface for couple usage analytics
+type UsageService interface {
+	// RollUp counts what couples created since the last run. It is run periodically by
+	// the scheduler.
+	RollUp(ctx context.Context) error
+	GetCoupleUsage(ctx context.Context, userID primitive.ObjectID, days int) (*CoupleUsageResponse, error)
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -16,11 +17,20 @@ type User struct {
 	DateOfBirth           *time.Time         `json:"date_of_birth,omitempty" bson:"date_of_birth,omitempty"`
 	Gender                string             `json:"gender,omitempty" bson:"gender,omitempty" validate:"omitempty,oneof=male female other"`
 	Avatar                string             `json:"avatar,omitempty" bson:"avatar,omitempty"`
+	AvatarSmall           string             `json:"avatar_small,omitempty" bson:"avatar_small,omitempty"`
+	AvatarKeys            []string           `json:"-" bson:"avatar_keys,omitempty"` // stored files of an uploaded avatar
 	PartnerID             *primitive.ObjectID `json:"partner_id,omitempty" bson:"partner_id,omitempty"`
 	PartnerName           string             `json:"partner_name,omitempty" bson:"partner_name,omitempty"`
 	MatchCode             string             `json:"match_code,omitempty" bson:"match_code,omitempty"`
 	MatchedAt             *time.Time         `json:"matched_at,omitempty" bson:"matched_at,omitempty"`
 	AnniversaryDate       *time.Time         `json:"anniversary_date,omitempty" bson:"anniversary_date,omitempty"`
+	Locale                string             `json:"locale,omitempty" bson:"locale,omitempty"` // language of the emails sent to the user
+	Timezone              string             `json:"timezone,omitempty" bson:"timezone,omitempty"` // IANA name, e.g. "Europe/Paris"; UTC when empty
+	MemoriesDigest        MemoriesDigest     `json:"memories_digest,omitempty" bson:"memories_digest,omitempty"`
+	MemoriesDigestSentOn  *time.Time         `json:"-" bson:"memories_digest_sent_on,omitempty"` // day the last memories digest was sent
+	UnmatchRequestedAt    *time.Time         `json:"-" bson:"unmatch_requested_at,omitempty"` // set on both partners while an unmatch is pending
+	UnmatchRequestedBy    *primitive.ObjectID `json:"-" bson:"unmatch_requested_by,omitempty"`
+	Roles                 []Role             `json:"roles,omitempty" bson:"roles,omitempty"` // roles besides RoleUser
 	IsActive              bool               `json:"is_active" bson:"is_active"`
 	IsEmailVerified       bool               `json:"is_email_verified" bson:"is_email_verified"`
 	EmailVerificationToken string            `json:"-" bson:"email_verification_token,omitempty"`
@@ -30,32 +40,50 @@ type User struct {
 	CreatedAt             time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt             time.Time          `json:"updated_at" bson:"updated_at"`
 	DeletedAt             *time.Time         `json:"-" bson:"deleted_at,omitempty"`
+	MergedInto            *primitive.ObjectID `json:"-" bson:"merged_into,omitempty"` // set when the account was merged into another one
 }
 
 // CreateUserRequest represents the request to create a new user
 type CreateUserRequest struct {
-	Name        string  `json:"name" validate:"required,min=2,max=100"`
-	Email       string  `json:"email" validate:"required,email"`
-	Password    string  `json:"password" validate:"required,min=6"`
-	DateOfBirth *Date   `json:"date_of_birth,omitempty"`
-	Gender      string  `json:"gender,omitempty" validate:"omitempty,oneof=male female other"`
+	Name        string  `json:"name" validate:"required,min=2,max=100" example:"Alex Nguyen"`
+	Email       string  `json:"email" validate:"required,email" example:"alex@example.com"`
+	Password    string  `json:"password" validate:"required,min=6" example:"correct-horse-battery"`
+	DateOfBirth *Date   `json:"date_of_birth,omitempty" validate:"omitempty,lte" example:"1995-04-21"`
+	Gender      string  `json:"gender,omitempty" validate:"omitempty,oneof=male female other" example:"female"`
 	Avatar      string  `json:"avatar,omitempty"`
+	Locale      string  `json:"locale,omitempty" validate:"omitempty,oneof=en es fr vi ja ko" example:"en"` // defaults to the Accept-Language header
 }
 
 // LoginRequest represents the login request
 type LoginRequest struct {
-	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required"`
+	Email    string `json:"email" validate:"required,email" example:"alex@example.com"`
+	Password string `json:"password" validate:"required" example:"correct-horse-battery"`
 }
 
 // UpdateUserRequest represents the request to update user information
 type UpdateUserRequest struct {
 	Name            string  `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
-	DateOfBirth     *Date   `json:"date_of_birth,omitempty"`
+	DateOfBirth     *Date   `json:"date_of_birth,omitempty" validate:"omitempty,lte"`
 	Gender          string  `json:"gender,omitempty" validate:"omitempty,oneof=male female other"`
 	Avatar          string  `json:"avatar,omitempty"`
 	PartnerName     string  `json:"partner_name,omitempty"`
-	AnniversaryDate *Date   `json:"anniversary_date,omitempty"` // Allow updating anniversary date
+	AnniversaryDate *Date   `json:"anniversary_date,omitempty" validate:"omitempty,lte"` // Allow updating anniversary date
+	Locale          string  `json:"locale,omitempty" validate:"omitempty,oneof=en es fr vi ja ko"`
+	Timezone        string  `json:"timezone,omitempty" validate:"omitempty,timezone"`
+	MemoriesDigest  MemoriesDigest `json:"memories_digest,omitempty" validate:"omitempty,oneof=off push email all"`
+}
+
+// Sizes in pixels of the sides of an uploaded avatar, which is cropped to a square
+const (
+	AvatarSize      = 512
+	AvatarSmallSize = 128
+)
+
+// UploadAvatarRequest represents an avatar image uploaded as multipart form data
+type UploadAvatarRequest struct {
+	File        io.Reader `json:"-"`
+	ContentType string    `json:"-"`
+	Size        int64     `json:"-"`
 }
 
 // UserResponse represents the user response (without sensitive data)
@@ -63,14 +91,20 @@ type UserResponse struct {
 	ID              primitive.ObjectID `json:"id"`
 	Name            string             `json:"name"`
 	Email           string             `json:"email"`
-	DateOfBirth     *time.Time         `json:"date_of_birth,omitempty"`
+	DateOfBirth     *Date              `json:"date_of_birth,omitempty"`
 	Gender          string             `json:"gender,omitempty"`
 	Avatar          string             `json:"avatar,omitempty"`
+	AvatarSmall     string             `json:"avatar_small,omitempty"`
 	PartnerID       *primitive.ObjectID `json:"partner_id,omitempty"`
 	PartnerName     string             `json:"partner_name,omitempty"`
 	MatchCode       string             `json:"match_code,omitempty"`
 	MatchedAt       *time.Time         `json:"matched_at,omitempty"`
-	AnniversaryDate *time.Time         `json:"anniversary_date,omitempty"`
+	AnniversaryDate *Date              `json:"anniversary_date,omitempty"`
+	Locale          string             `json:"locale,omitempty"`
+	Timezone        string             `json:"timezone,omitempty"`
+	MemoriesDigest  MemoriesDigest     `json:"memories_digest"`
+	Unmatch         *PendingUnmatch    `json:"unmatch,omitempty"`
+	Roles           []string           `json:"roles"`
 	IsActive        bool               `json:"is_active"`
 	IsEmailVerified bool               `json:"is_email_verified"`
 	CreatedAt       time.Time          `json:"created_at"`
@@ -83,14 +117,20 @@ func (u *User) ToResponse() *UserResponse {
 		ID:              u.ID,
 		Name:            u.Name,
 		Email:           u.Email,
-		DateOfBirth:     u.DateOfBirth,
+		DateOfBirth:     DateFromTimePtr(u.DateOfBirth),
 		Gender:          u.Gender,
 		Avatar:          u.Avatar,
+		AvatarSmall:     u.AvatarSmall,
 		PartnerID:       u.PartnerID,
 		PartnerName:     u.PartnerName,
 		MatchCode:       u.MatchCode,
 		MatchedAt:       u.MatchedAt,
-		AnniversaryDate: u.AnniversaryDate,
+		AnniversaryDate: DateFromTimePtr(u.AnniversaryDate),
+		Locale:          u.Locale,
+		Timezone:        u.Timezone,
+		MemoriesDigest:  u.MemoriesDigestOrDefault(),
+		Unmatch:         u.PendingUnmatch(),
+		Roles:           u.RoleNames(),
 		IsActive:        u.IsActive,
 		IsEmailVerified: u.IsEmailVerified,
 		CreatedAt:       u.CreatedAt,
@@ -98,6 +138,64 @@ func (u *User) ToResponse() *UserResponse {
 	}
 }
 
+// MemoriesDigest is how a user is sent the daily digest of the couple's memories
+// from the same day in earlier years
+type MemoriesDigest string
+
+const (
+	MemoriesDigestOff   MemoriesDigest = "off"
+	MemoriesDigestPush  MemoriesDigest = "push"
+	MemoriesDigestEmail MemoriesDigest = "email"
+	MemoriesDigestAll   MemoriesDigest = "all" // push and email
+)
+
+// Location returns the user's time zone, used to tell which day it is for them
+func (u *User) Location() *time.Location {
+	return LoadLocation(u.Timezone)
+}
+
+// MemoriesDigestOrDefault returns the user's digest choice; users who never chose
+// are not sent one
+func (u *User) MemoriesDigestOrDefault() MemoriesDigest {
+	if u.MemoriesDigest == "" {
+		return MemoriesDigestOff
+	}
+	return u.MemoriesDigest
+}
+
+// Push reports whether the digest is sent as an in-app notification
+func (d MemoriesDigest) Push() bool {
+	return d == MemoriesDigestPush || d == MemoriesDigestAll
+}
+
+// Email reports whether the digest is sent by email
+func (d MemoriesDigest) Email() bool {
+	return d == MemoriesDigestEmail || d == MemoriesDigestAll
+}
+
+// UnmatchGracePeriod is how long an unmatch can be cancelled before the couple's
+// shared photos and events are deleted
+const UnmatchGracePeriod = 7 * 24 * time.Hour
+
+// PendingUnmatch describes an unmatch waiting for its grace period to end
+type PendingUnmatch struct {
+	RequestedBy primitive.ObjectID `json:"requested_by"`
+	RequestedAt time.Time          `json:"requested_at"`
+	PurgeAt     time.Time          `json:"purge_at"`
+}
+
+// PendingUnmatch returns the user's pending unmatch, or nil when there is none
+func (u *User) PendingUnmatch() *PendingUnmatch {
+	if u.UnmatchRequestedAt == nil || u.UnmatchRequestedBy == nil {
+		return nil
+	}
+	return &PendingUnmatch{
+		RequestedBy: *u.UnmatchRequestedBy,
+		RequestedAt: *u.UnmatchRequestedAt,
+		PurgeAt:     u.UnmatchRequestedAt.Add(UnmatchGracePeriod),
+	}
+}
+
 // UserRepository defines the interface for user data access
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
@@ -113,6 +211,43 @@ type UserRepository interface {
 	Restore(ctx context.Context, id primitive.ObjectID) error
 	HardDelete(ctx context.Context, id primitive.ObjectID) error
 	ListDeleted(ctx context.Context, limit, offset int) ([]*User, error)
+	// GetByIDIncludingDeleted retrieves a user by ID whether or not the account is deleted
+	GetByIDIncludingDeleted(ctx context.Context, id primitive.ObjectID) (*User, error)
+	// Search lists users matching filter, newest first
+	Search(ctx context.Context, filter UserSearchFilter, cursor *Cursor, limit int) ([]*User, error)
+
+	// Data retention
+	ListUnverifiedInactiveIDs(ctx context.Context, before time.Time, afterID primitive.ObjectID, limit int) ([]primitive.ObjectID, error)
+	PurgeUnverifiedInactive(ctx context.Context, ids []primitive.ObjectID, before time.Time) (int64, error)
+	ListDeletedIDs(ctx context.Context, before time.Time, afterID primitive.ObjectID, limit int) ([]primitive.ObjectID, error)
+	PurgeDeleted(ctx context.Context, ids []primitive.ObjectID, before time.Time) (int64, error)
+	SetAvatar(ctx context.Context, id primitive.ObjectID, avatar, avatarSmall string, keys []string) ([]string, error)
+
+	// Unmatching
+	// SetUnmatchRequest marks both partners of a couple as unmatching, or clears the
+	// mark when requestedBy is nil
+	SetUnmatchRequest(ctx context.Context, matchCode string, requestedBy *primitive.ObjectID, requestedAt time.Time) error
+	// ListUnmatchesRequestedBefore lists the match codes of couples whose unmatch was requested before before
+	ListUnmatchesRequestedBefore(ctx context.Context, before time.Time) ([]string, error)
+	// LinkPartner saves the partner fields of user, only if the stored user has no
+	// partner yet, and reports whether it did
+	LinkPartner(ctx context.Context, user *User) (bool, error)
+	// UnlinkPartner removes the partner of a user, only if it is still partnerID
+	UnlinkPartner(ctx context.Context, id, partnerID primitive.ObjectID) error
+	// ClearMatch removes the match of both partners of a couple
+	ClearMatch(ctx context.Context, matchCode string) error
+	// ListByMatchCode lists the partners of a couple
+	ListByMatchCode(ctx context.Context, matchCode string) ([]*User, error)
+	// MarkMemoriesDigestSent records that the user was sent the memories digest of day,
+	// unless they already were, and reports whether it did
+	MarkMemoriesDigestSent(ctx context.Context, id primitive.ObjectID, day time.Time) (bool, error)
+	// ListMatched lists up to limit matched users with an anniversary date, in ID order
+	// after afterID
+	ListMatched(ctx context.Context, afterID primitive.ObjectID, limit int) ([]*User, error)
+
+	// Account merges
+	// Tombstone deactivates an account merged into another one and removes its match
+	Tombstone(ctx context.Context, id, mergedInto primitive.ObjectID) error
 }
 
 // RefreshTokenRequest represents the request to refresh token
@@ -132,7 +267,7 @@ type EmailVerificationRequest struct {
 
 // ForgotPasswordRequest represents the request to reset password
 type ForgotPasswordRequest struct {
-	Email string `json:"email" validate:"required,email"`
+	Email string `json:"email" validate:"required,email" example:"alex@example.com"`
 }
 
 // ResetPasswordRequest represents the request to reset password with token
@@ -141,9 +276,15 @@ type ResetPasswordRequest struct {
 	NewPassword string `json:"new_password" validate:"required,min=6"`
 }
 
+// ChangePasswordRequest represents the request to change the password of a logged-in user
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=6"`
+}
+
 // ResendVerificationRequest represents the request to resend verification email
 type ResendVerificationRequest struct {
-	Email string `json:"email" validate:"required,email"`
+	Email string `json:"email" validate:"required,email" example:"alex@example.com"`
 }
 
 // UserService defines the interface for user operations
@@ -156,6 +297,7 @@ type UserService interface {
 	Logout(ctx context.Context, refreshToken string) error
 	GetProfile(ctx context.Context, userID primitive.ObjectID) (*UserResponse, error)
 	UpdateProfile(ctx context.Context, userID primitive.ObjectID, req *UpdateUserRequest) (*UserResponse, error)
+	UploadAvatar(ctx context.Context, userID primitive.ObjectID, req *UploadAvatarRequest) (*UserResponse, error)
 	DeleteAccount(ctx context.Context, userID primitive.ObjectID) error
 	
 	// Email verification
@@ -165,9 +307,13 @@ type UserService interface {
 	// Password reset
 	ForgotPassword(ctx context.Context, req *ForgotPasswordRequest) error
 	ResetPassword(ctx context.Context, req *ResetPasswordRequest) error
+	// ChangePassword signs out every session of the user and returns a new one
+	ChangePassword(ctx context.Context, userID primitive.ObjectID, req *ChangePasswordRequest) (*UserResponse, *TokenPair, error)
 	
 	// Match management
-	UnmatchPartner(ctx context.Context, userID primitive.ObjectID) error
+	UnmatchPartner(ctx context.Context, userID primitive.ObjectID) (*PendingUnmatch, error)
+	CancelUnmatch(ctx context.Context, userID primitive.ObjectID) error
+	PurgeExpiredUnmatches(ctx context.Context) error
 }
 
 // TokenPair represents access and refresh token pair
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+)
+
+// MediaType tells photos and videos apart in the gallery
+type MediaType string
+
+const (
+	MediaTypePhoto MediaType = "photo"
+	MediaTypeVideo MediaType = "video"
+)
+
+// videoExtensions maps the content types of videos that can be uploaded as video
+// memories to their file extensions
+var videoExtensions = map[string]string{
+	"video/mp4":       ".mp4",
+	"video/quicktime": ".mov",
+}
+
+// VideoMetadata describes a video memory
+type VideoMetadata struct {
+	Duration float64 `json:"duration" bson:"duration"` // seconds
+	Width    int     `json:"width,omitempty" bson:"width,omitempty"`
+	Height   int     `json:"height,omitempty" bson:"height,omitempty"`
+}
+
+// VideoLimits bounds the video memories that can be uploaded
+type VideoLimits struct {
+	MaxSize     int64 // bytes
+	MaxDuration time.Duration
+}
+
+// VideoService inspects uploaded videos
+type VideoService interface {
+	// ProcessVideo reads the video stored under key, rejects it with ErrVideoTooLong
+	// when it runs longer than allowed, and stores a frame of it as its poster image.
+	// It returns the video's metadata and the key of the poster.
+	ProcessVideo(ctx context.Context, key string) (*VideoMetadata, string, error)
+}
+
+// IsVideoContentType reports whether files of contentType can be uploaded as video
+// memories
+func IsVideoContentType(contentType string) bool {
+	_, ok := videoExtensions[strings.ToLower(contentType)]
+	return ok
+}
+
+// IsVideoKey reports whether the file stored under key is a video memory, going by
+// its extension
+func IsVideoKey(key string) bool {
+	ext := strings.ToLower(path.Ext(key))
+	for _, videoExt := range videoExtensions {
+		if ext == videoExt {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateVideoFile validates if the file is a supported video no larger than maxSize
+func ValidateVideoFile(contentType string, size, maxSize int64) error {
+	if !IsVideoContentType(contentType) {
+		return ErrUnsupportedFileType
+	}
+	if size > maxSize {
+		return ErrFileTooLarge
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// WishlistOwner filters wishlist items by whose list they are on
+type WishlistOwner string
+
+const (
+	WishlistOwnerMine    WishlistOwner = "mine"
+	WishlistOwnerPartner WishlistOwner = "partner"
+)
+
+// WishlistStatus filters wishlist items by purchase
+type WishlistStatus string
+
+const (
+	WishlistStatusOpen      WishlistStatus = "open"
+	WishlistStatusPurchased WishlistStatus = "purchased"
+)
+
+// WishlistItem is a gift idea a partner keeps on their wishlist. Secret items ("surprise
+// mode") are only visible to their owner until they are marked purchased.
+type WishlistItem struct {
+	ID          primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	MatchCode   string              `json:"match_code" bson:"match_code"`
+	OwnerID     primitive.ObjectID  `json:"owner_id" bson:"owner_id"`
+	Title       string              `json:"title" bson:"title"`
+	URL         string              `json:"url,omitempty" bson:"url,omitempty"`
+	Price       *float64            `json:"price,omitempty" bson:"price,omitempty"`
+	Currency    string              `json:"currency,omitempty" bson:"currency,omitempty"` // ISO 4217 code
+	Notes       string              `json:"notes,omitempty" bson:"notes,omitempty"`
+	IsSecret    bool                `json:"is_secret" bson:"is_secret"`
+	PurchasedAt *time.Time          `json:"purchased_at,omitempty" bson:"purchased_at,omitempty"`
+	PurchasedBy *primitive.ObjectID `json:"purchased_by,omitempty" bson:"purchased_by,omitempty"`
+	CreatedAt   time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at" bson:"updated_at"`
+	DeletedAt   *time.Time          `json:"-" bson:"deleted_at,omitempty"`
+}
+
+// IsPurchased reports whether the item has been marked purchased
+func (w *WishlistItem) IsPurchased() bool {
+	return w.PurchasedAt != nil
+}
+
+// IsVisibleTo reports whether the viewer may see the item: owners always see their
+// own items, and the partner sees secret items once they are purchased
+func (w *WishlistItem) IsVisibleTo(viewerID primitive.ObjectID) bool {
+	return w.OwnerID == viewerID || !w.IsSecret || w.IsPurchased()
+}
+
+// CreateWishlistItemRequest represents the request to add a wishlist item
+type CreateWishlistItemRequest struct {
+	Title    string   `json:"title" validate:"required,min=1,max=200"`
+	URL      string   `json:"url,omitempty" validate:"omitempty,url,max=2000"`
+	Price    *float64 `json:"price,omitempty" validate:"omitempty,min=0"`
+	Currency string   `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	Notes    string   `json:"notes,omitempty" validate:"omitempty,max=2000"`
+	IsSecret bool     `json:"is_secret"`
+}
+
+// UpdateWishlistItemRequest represents the request to update a wishlist item. An empty
+// url or notes clears them.
+type UpdateWishlistItemRequest struct {
+	Title    string   `json:"title,omitempty" validate:"omitempty,min=1,max=200"`
+	URL      *string  `json:"url,omitempty" validate:"omitempty,max=2000"`
+	Price    *float64 `json:"price,omitempty" validate:"omitempty,min=0"`
+	Currency string   `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	Notes    *string  `json:"notes,omitempty" validate:"omitempty,max=2000"`
+	IsSecret *bool    `json:"is_secret,omitempty"`
+}
+
+// WishlistItemResponse represents the API response for a wishlist item
+type WishlistItemResponse struct {
+	ID          string     `json:"id"`
+	OwnerID     string     `json:"owner_id"`
+	Title       string     `json:"title"`
+	URL         string     `json:"url,omitempty"`
+	Price       *float64   `json:"price,omitempty"`
+	Currency    string     `json:"currency,omitempty"`
+	Notes       string     `json:"notes,omitempty"`
+	IsSecret    bool       `json:"is_secret"`
+	IsMine      bool       `json:"is_mine"`
+	IsPurchased bool       `json:"is_purchased"`
+	PurchasedAt *time.Time `json:"purchased_at,omitempty"`
+	PurchasedBy string     `json:"purchased_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ToResponse converts WishlistItem to WishlistItemResponse as the viewer sees it
+func (w *WishlistItem) ToResponse(viewerID primitive.ObjectID) *WishlistItemResponse {
+	response := &WishlistItemResponse{
+		ID:          w.ID.Hex(),
+		OwnerID:     w.OwnerID.Hex(),
+		Title:       w.Title,
+		URL:         w.URL,
+		Price:       w.Price,
+		Currency:    w.Currency,
+		Notes:       w.Notes,
+		IsSecret:    w.IsSecret,
+		IsMine:      w.OwnerID == viewerID,
+		IsPurchased: w.IsPurchased(),
+		PurchasedAt: w.PurchasedAt,
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
+	}
+	if w.PurchasedBy != nil {
+		response.PurchasedBy = w.PurchasedBy.Hex()
+	}
+
+	return response
+}
+
+// WishlistResponse represents a page of the couple's wishlists
+type WishlistResponse struct {
+	Items []*WishlistItemResponse `json:"items"`
+	Total int64                   `json:"total"`
+	Page  int                     `json:"page"`
+	Limit int                     `json:"limit"`
+}
+
+// WishlistFilter selects the wishlist items of a couple to list
+type WishlistFilter struct {
+	OwnerID *primitive.ObjectID
+	Status  WishlistStatus
+}
+
+// WishlistRepository defines the interface for wishlist data access. Listings only
+// include the items visible to the viewer.
+type WishlistRepository interface {
+	Create(ctx context.Context, item *WishlistItem) error
+	GetByID(ctx context.Context, matchCode string, id primitive.ObjectID) (*WishlistItem, error)
+	GetVisible(ctx context.Context, matchCode string, viewerID primitive.ObjectID, filter WishlistFilter, limit, offset int) ([]*WishlistItem, error)
+	CountVisible(ctx context.Context, matchCode string, viewerID primitive.ObjectID, filter WishlistFilter) (int64, error)
+	Update(ctx context.Context, item *WishlistItem) error
+	Delete(ctx context.Context, id primitive.ObjectID) error
+}
+
+// WishlistService defines the interface for wishlist business logic
+type WishlistService interface {
+	CreateItem(ctx context.Context, userID primitive.ObjectID, req *CreateWishlistItemRequest) (*WishlistItemResponse, error)
+	GetItem(ctx context.Context, itemID, userID primitive.ObjectID) (*WishlistItemResponse, error)
+	GetItems(ctx context.Context, userID primitive.ObjectID, owner WishlistOwner, status WishlistStatus, page, limit int) (*WishlistResponse, error)
+	UpdateItem(ctx context.Context, itemID, userID primitive.ObjectID, req *UpdateWishlistItemRequest) (*WishlistItemResponse, error)
+	DeleteItem(ctx context.Context, itemID, userID primitive.ObjectID) error
+	MarkPurchased(ctx context.Context, itemID, userID primitive.ObjectID) (*WishlistItemResponse, error)
+	UnmarkPurchased(ctx context.Context, itemID, userID primitive.ObjectID) (*WishlistItemResponse, error)
+}
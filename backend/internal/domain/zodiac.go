@@ -0,0 +1,150 @@
+package domain
+
+import "time"
+
+// ZodiacSign represents a western zodiac sign
+type ZodiacSign string
+
+const (
+	ZodiacAries       ZodiacSign = "aries"
+	ZodiacTaurus      ZodiacSign = "taurus"
+	ZodiacGemini      ZodiacSign = "gemini"
+	ZodiacCancer      ZodiacSign = "cancer"
+	ZodiacLeo         ZodiacSign = "leo"
+	ZodiacVirgo       ZodiacSign = "virgo"
+	ZodiacLibra       ZodiacSign = "libra"
+	ZodiacScorpio     ZodiacSign = "scorpio"
+	ZodiacSagittarius ZodiacSign = "sagittarius"
+	ZodiacCapricorn   ZodiacSign = "capricorn"
+	ZodiacAquarius    ZodiacSign = "aquarius"
+	ZodiacPisces      ZodiacSign = "pisces"
+)
+
+// ZodiacElement represents the classical element of a zodiac sign
+type ZodiacElement string
+
+const (
+	ElementFire  ZodiacElement = "fire"
+	ElementEarth ZodiacElement = "earth"
+	ElementAir   ZodiacElement = "air"
+	ElementWater ZodiacElement = "water"
+)
+
+// zodiacBoundaries lists the first day of each sign; dates before January 20 are Capricorn
+var zodiacBoundaries = []struct {
+	month time.Month
+	day   int
+	sign  ZodiacSign
+}{
+	{time.January, 20, ZodiacAquarius},
+	{time.February, 19, ZodiacPisces},
+	{time.March, 21, ZodiacAries},
+	{time.April, 20, ZodiacTaurus},
+	{time.May, 21, ZodiacGemini},
+	{time.June, 21, ZodiacCancer},
+	{time.July, 23, ZodiacLeo},
+	{time.August, 23, ZodiacVirgo},
+	{time.September, 23, ZodiacLibra},
+	{time.October, 23, ZodiacScorpio},
+	{time.November, 22, ZodiacSagittarius},
+	{time.December, 22, ZodiacCapricorn},
+}
+
+// GetZodiacSign returns the zodiac sign for a birth date
+func GetZodiacSign(birthDate time.Time) ZodiacSign {
+	sign := ZodiacCapricorn
+	for _, b := range zodiacBoundaries {
+		if birthDate.Month() > b.month || (birthDate.Month() == b.month && birthDate.Day() >= b.day) {
+			sign = b.sign
+		}
+	}
+	return sign
+}
+
+// Element returns the classical element of the zodiac sign
+func (z ZodiacSign) Element() ZodiacElement {
+	switch z {
+	case ZodiacAries, ZodiacLeo, ZodiacSagittarius:
+		return ElementFire
+	case ZodiacTaurus, ZodiacVirgo, ZodiacCapricorn:
+		return ElementEarth
+	case ZodiacGemini, ZodiacLibra, ZodiacAquarius:
+		return ElementAir
+	default:
+		return ElementWater
+	}
+}
+
+// ZodiacCompatibilityScore returns a 0-100 compatibility score based on the signs' elements
+func ZodiacCompatibilityScore(a, b ZodiacSign) int {
+	ea, eb := a.Element(), b.Element()
+
+	switch {
+	case a == b:
+		return 80
+	case ea == eb:
+		return 90
+	case complementaryElements(ea, eb):
+		return 85
+	case (ea == ElementFire && eb == ElementWater) || (ea == ElementWater && eb == ElementFire):
+		return 45
+	case (ea == ElementEarth && eb == ElementAir) || (ea == ElementAir && eb == ElementEarth):
+		return 50
+	default:
+		return 65
+	}
+}
+
+// complementaryElements reports whether two elements traditionally support each other
+func complementaryElements(a, b ZodiacElement) bool {
+	return (a == ElementFire && b == ElementAir) || (a == ElementAir && b == ElementFire) ||
+		(a == ElementEarth && b == ElementWater) || (a == ElementWater && b == ElementEarth)
+}
+
+// LifePathNumber returns the numerology life path number for a birth date.
+// Master numbers 11, 22 and 33 are kept as-is.
+func LifePathNumber(birthDate time.Time) int {
+	sum := reduceNumber(birthDate.Year()) + reduceNumber(int(birthDate.Month())) + reduceNumber(birthDate.Day())
+	return reduceNumber(sum)
+}
+
+// reduceNumber sums the digits of n until it is a single digit or a master number
+func reduceNumber(n int) int {
+	for n > 9 && n != 11 && n != 22 && n != 33 {
+		sum := 0
+		for n > 0 {
+			sum += n % 10
+			n /= 10
+		}
+		n = sum
+	}
+	return n
+}
+
+// NumerologyCompatibilityScore returns a 0-100 compatibility score for two life path numbers
+func NumerologyCompatibilityScore(a, b int) int {
+	// Master numbers behave like their reduced root for compatibility purposes
+	ra, rb := a, b
+	if ra > 9 {
+		ra = reduceNumber(ra/10 + ra%10)
+	}
+	if rb > 9 {
+		rb = reduceNumber(rb/10 + rb%10)
+	}
+
+	diff := ra - rb
+	if diff < 0 {
+		diff = -diff
+	}
+
+	switch {
+	case ra == rb:
+		return 85
+	case ra%2 == rb%2:
+		return 75
+	case diff == 1:
+		return 60
+	default:
+		return 55
+	}
+}
@@ -0,0 +1,181 @@
+// Package server serves the internal gRPC API of api/proto/eralove/v1 on top of the
+// service layer the HTTP API uses
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/grpc/eralovev1"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Server is the gRPC server of the internal API
+type Server struct {
+	server *grpc.Server
+	logger *zap.Logger
+}
+
+// New creates the gRPC server of the internal API. Callers authenticate with one of
+// tokens in the "authorization" metadata.
+func New(tokens []string, userService domain.UserService, photoService domain.PhotoService, eventService domain.EventService, logger *zap.Logger) *Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		requestInterceptor(logger),
+		authInterceptor(tokens),
+	))
+
+	eralovev1.RegisterUserServiceServer(server, &userServer{users: userService})
+	eralovev1.RegisterPhotoServiceServer(server, &photoServer{photos: photoService})
+	eralovev1.RegisterEventServiceServer(server, &eventServer{events: eventService})
+
+	return &Server{server: server, logger: logger}
+}
+
+// Serve serves the API on listener until the server is shut down
+func (s *Server) Serve(listener net.Listener) error {
+	if err := s.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops accepting calls and waits for the running ones to finish, or cancels
+// them when ctx is done first
+func (s *Server) Shutdown(ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.server.Stop()
+	}
+}
+
+// requestInterceptor gives each call the request metadata and trace-tagged logger the
+// services find in an HTTP request's context, and logs the call
+func requestInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		traceID := firstValue(md, "x-request-id")
+		if traceID == "" {
+			traceID = uuid.NewString()
+		}
+		meta := &domain.RequestMeta{
+			UserAgent: firstValue(md, "user-agent"),
+			TraceID:   traceID,
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+				meta.IP = host
+			}
+		}
+
+		callLogger := logger.With(zap.String("trace_id", traceID))
+		ctx = context.WithValue(ctx, domain.RequestMetaKey, meta)
+		ctx = logging.NewContext(ctx, callLogger)
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		fields := []zap.Field{
+			zap.String("method", info.FullMethod),
+			zap.String("code", code.String()),
+			zap.Duration("latency", time.Since(start)),
+		}
+		switch code {
+		case codes.OK:
+			callLogger.Info("gRPC call handled", fields...)
+		case codes.Internal, codes.Unknown, codes.Unavailable:
+			callLogger.Error("gRPC call handled", append(fields, zap.Error(err))...)
+		default:
+			callLogger.Warn("gRPC call handled", fields...)
+		}
+		return resp, err
+	}
+}
+
+// authInterceptor only lets calls through that send one of tokens as a bearer token in
+// the "authorization" metadata
+func authInterceptor(tokens []string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		provided := strings.TrimPrefix(firstValue(md, "authorization"), "Bearer ")
+		if provided != "" {
+			for _, token := range tokens {
+				if token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1 {
+					return handler(ctx, req)
+				}
+			}
+		}
+		return nil, status.Error(codes.Unauthenticated, "a valid service token is required")
+	}
+}
+
+// firstValue returns the first value of a metadata key, or an empty string
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// actingUser parses the ID of the user a call acts on behalf of and records it as the
+// actor of the call
+func actingUser(ctx context.Context, userID string) (primitive.ObjectID, error) {
+	id, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return primitive.NilObjectID, status.Error(codes.InvalidArgument, "user_id must be a valid ID")
+	}
+	if meta := domain.RequestMetaFrom(ctx); meta != nil {
+		meta.ActorID = &id
+	}
+	return id, nil
+}
+
+// toStatus converts an error of the service layer into the status of a call. Errors
+// that are not application errors are not described to the caller.
+func toStatus(err error) error {
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) {
+		return status.Error(codes.Internal, "internal error")
+	}
+
+	code := codes.Internal
+	switch appErr.StatusCode {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict, http.StatusGone:
+		code = codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	}
+	return status.Error(code, appErr.Message)
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/grpc/eralovev1"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testServiceToken = "test-service-token-0123456789abcdef"
+
+// fakeUserService serves the profile of a single user. The other methods are not
+// called by the tests.
+type fakeUserService struct {
+	domain.UserService
+	user *domain.UserResponse
+}
+
+func (s *fakeUserService) GetProfile(ctx context.Context, userID primitive.ObjectID) (*domain.UserResponse, error) {
+	if s.user == nil || s.user.ID != userID {
+		return nil, domain.ErrUserNotFoundError()
+	}
+	return s.user, nil
+}
+
+// newTestClient serves the API on an in-memory listener and returns a client of it
+func newTestClient(t *testing.T, users domain.UserService) eralovev1.UserServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := New([]string{testServiceToken}, users, nil, nil, zap.NewNop())
+	go server.Serve(listener)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	})
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return eralovev1.NewUserServiceClient(conn)
+}
+
+// withToken returns a context that sends token as the bearer token of a call
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestGetUserRequiresServiceToken(t *testing.T) {
+	client := newTestClient(t, &fakeUserService{})
+	userID := primitive.NewObjectID().Hex()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"no token", context.Background()},
+		{"invalid token", withToken("not-the-service-token")},
+	}
+	for _, tt := range tests {
+		_, err := client.GetUser(tt.ctx, &eralovev1.GetUserRequest{UserId: userID})
+		if got := status.Code(err); got != codes.Unauthenticated {
+			t.Errorf("%s: code = %s, want %s", tt.name, got, codes.Unauthenticated)
+		}
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	partnerID := primitive.NewObjectID()
+	anniversary := domain.NewDate(time.Date(2020, time.February, 14, 0, 0, 0, 0, time.UTC))
+	user := &domain.UserResponse{
+		ID:              primitive.NewObjectID(),
+		Name:            "Alex",
+		Email:           "alex@example.com",
+		PartnerID:       &partnerID,
+		MatchCode:       "ABC123",
+		AnniversaryDate: &anniversary,
+		CreatedAt:       time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC),
+	}
+	client := newTestClient(t, &fakeUserService{user: user})
+
+	got, err := client.GetUser(withToken(testServiceToken), &eralovev1.GetUserRequest{UserId: user.ID.Hex()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GetId() != user.ID.Hex() {
+		t.Errorf("id = %q, want %q", got.GetId(), user.ID.Hex())
+	}
+	if got.GetPartnerId() != partnerID.Hex() {
+		t.Errorf("partner_id = %q, want %q", got.GetPartnerId(), partnerID.Hex())
+	}
+	if got.GetAnniversaryDate().GetValue() != "2020-02-14" {
+		t.Errorf("anniversary_date = %q, want %q", got.GetAnniversaryDate().GetValue(), "2020-02-14")
+	}
+	if !got.GetCreatedAt().AsTime().Equal(user.CreatedAt) {
+		t.Errorf("created_at = %v, want %v", got.GetCreatedAt().AsTime(), user.CreatedAt)
+	}
+}
+
+func TestGetUserErrors(t *testing.T) {
+	client := newTestClient(t, &fakeUserService{})
+
+	tests := []struct {
+		name   string
+		userID string
+		want   codes.Code
+	}{
+		{"invalid user_id", "not-an-id", codes.InvalidArgument},
+		{"unknown user", primitive.NewObjectID().Hex(), codes.NotFound},
+	}
+	for _, tt := range tests {
+		_, err := client.GetUser(withToken(testServiceToken), &eralovev1.GetUserRequest{UserId: tt.userID})
+		if got := status.Code(err); got != tt.want {
+			t.Errorf("%s: code = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"invalid request", domain.ErrInvalidRequestError("bad"), codes.InvalidArgument},
+		{"not found", domain.ErrNotFoundError("Photo"), codes.NotFound},
+		{"other error", context.DeadlineExceeded, codes.Internal},
+	}
+	for _, tt := range tests {
+		if got := status.Code(toStatus(tt.err)); got != tt.want {
+			t.Errorf("%s: code = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
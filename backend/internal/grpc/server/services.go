@@ -0,0 +1,300 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/grpc/eralovev1"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// searchMaxQueryLength caps the length of a photo search query, as on the HTTP API
+	searchMaxQueryLength = 200
+	// defaultEventLimit is the page size of event lists without a limit, as on the HTTP API
+	defaultEventLimit = 10
+)
+
+// userServer implements eralovev1.UserServiceServer
+type userServer struct {
+	eralovev1.UnimplementedUserServiceServer
+	users domain.UserService
+}
+
+// GetUser returns the profile of a user
+func (s *userServer) GetUser(ctx context.Context, req *eralovev1.GetUserRequest) (*eralovev1.User, error) {
+	userID, err := actingUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toUser(user), nil
+}
+
+// photoServer implements eralovev1.PhotoServiceServer
+type photoServer struct {
+	eralovev1.UnimplementedPhotoServiceServer
+	photos domain.PhotoService
+}
+
+// GetPhoto returns one of the couple's photos
+func (s *photoServer) GetPhoto(ctx context.Context, req *eralovev1.GetPhotoRequest) (*eralovev1.Photo, error) {
+	userID, err := actingUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	photoID, err := primitive.ObjectIDFromHex(req.GetPhotoId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "photo_id must be a valid ID")
+	}
+
+	photo, err := s.photos.GetPhoto(ctx, photoID, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPhoto(photo), nil
+}
+
+// ListPhotos lists the couple's photos, newest first
+func (s *photoServer) ListPhotos(ctx context.Context, req *eralovev1.ListPhotosRequest) (*eralovev1.ListPhotosResponse, error) {
+	userID, err := actingUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := domain.DecodeCursor(req.GetCursor())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "cursor must be a next_cursor value returned by a previous call")
+	}
+
+	photos, nextCursor, err := s.photos.GetCouplePhotosCursor(ctx, userID, cursor, domain.NormalizeCursorLimit(int(req.GetLimit())))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &eralovev1.ListPhotosResponse{
+		Photos:     make([]*eralovev1.Photo, 0, len(photos)),
+		NextCursor: nextCursor,
+	}
+	for _, photo := range photos {
+		resp.Photos = append(resp.Photos, toPhoto(photo))
+	}
+	return resp, nil
+}
+
+// SearchPhotos searches the couple's photos
+func (s *photoServer) SearchPhotos(ctx context.Context, req *eralovev1.SearchPhotosRequest) (*eralovev1.SearchPhotosResponse, error) {
+	userID, err := actingUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	search := &domain.PhotoSearchRequest{
+		Query:    strings.TrimSpace(req.GetQuery()),
+		Tags:     req.GetTags(),
+		Location: req.GetLocation(),
+		AlbumID:  req.GetAlbumId(),
+	}
+	if utf8.RuneCountInString(search.Query) > searchMaxQueryLength {
+		return nil, status.Error(codes.InvalidArgument, "query must be at most 200 characters")
+	}
+	if search.From, err = fromDate(req.GetFrom(), "from"); err != nil {
+		return nil, err
+	}
+	if search.To, err = fromDate(req.GetTo(), "to"); err != nil {
+		return nil, err
+	}
+	if req.IsPrivate != nil {
+		isPrivate := req.GetIsPrivate()
+		search.IsPrivate = &isPrivate
+	}
+
+	page := int(req.GetPage())
+	if page < 1 {
+		page = 1
+	}
+	results, err := s.photos.SearchPhotos(ctx, userID, search, page, domain.NormalizeCursorLimit(int(req.GetLimit())))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &eralovev1.SearchPhotosResponse{
+		Photos: make([]*eralovev1.Photo, 0, len(results.Photos)),
+		Total:  results.Total,
+		Tags:   make([]*eralovev1.TagCount, 0, len(results.Tags)),
+	}
+	for _, photo := range results.Photos {
+		resp.Photos = append(resp.Photos, toPhoto(photo))
+	}
+	for _, tag := range results.Tags {
+		resp.Tags = append(resp.Tags, &eralovev1.TagCount{Tag: tag.Tag, Count: tag.Count})
+	}
+	return resp, nil
+}
+
+// eventServer implements eralovev1.EventServiceServer
+type eventServer struct {
+	eralovev1.UnimplementedEventServiceServer
+	events domain.EventService
+}
+
+// GetEvent returns one of the couple's events
+func (s *eventServer) GetEvent(ctx context.Context, req *eralovev1.GetEventRequest) (*eralovev1.Event, error) {
+	userID, err := actingUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	eventID, err := primitive.ObjectIDFromHex(req.GetEventId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "event_id must be a valid ID")
+	}
+
+	event, err := s.events.GetEvent(ctx, eventID, userID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toEvent(event), nil
+}
+
+// ListEvents lists the couple's events
+func (s *eventServer) ListEvents(ctx context.Context, req *eralovev1.ListEventsRequest) (*eralovev1.ListEventsResponse, error) {
+	userID, err := actingUser(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	page, limit := int(req.GetPage()), int(req.GetLimit())
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = defaultEventLimit
+	}
+	events, total, err := s.events.GetCoupleEvents(ctx, userID, int(req.GetYear()), int(req.GetMonth()), page, limit)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &eralovev1.ListEventsResponse{
+		Events: make([]*eralovev1.Event, 0, len(events)),
+		Total:  total,
+	}
+	for _, event := range events {
+		resp.Events = append(resp.Events, toEvent(event))
+	}
+	return resp, nil
+}
+
+// toUser converts a user profile into its message
+func toUser(user *domain.UserResponse) *eralovev1.User {
+	message := &eralovev1.User{
+		Id:              user.ID.Hex(),
+		Email:           user.Email,
+		Name:            user.Name,
+		Avatar:          user.Avatar,
+		Locale:          user.Locale,
+		MatchCode:       user.MatchCode,
+		AnniversaryDate: toDate(user.AnniversaryDate),
+		CreatedAt:       toTimestamp(user.CreatedAt),
+	}
+	if user.PartnerID != nil {
+		message.PartnerId = user.PartnerID.Hex()
+	}
+	return message
+}
+
+// toPhoto converts a photo into its message
+func toPhoto(photo *domain.PhotoResponse) *eralovev1.Photo {
+	return &eralovev1.Photo{
+		Id:            photo.ID,
+		MatchCode:     photo.MatchCode,
+		CreatedBy:     photo.CreatedBy,
+		Title:         photo.Title,
+		Description:   photo.Description,
+		ImageUrl:      photo.ImageURL,
+		ThumbnailUrl:  photo.ThumbnailURL,
+		MediumUrl:     photo.MediumURL,
+		Date:          toDate(&photo.Date),
+		Location:      photo.Location,
+		Place:         toPlace(photo.Place),
+		Tags:          photo.Tags,
+		IsPrivate:     photo.IsPrivate,
+		AlbumId:       photo.AlbumID,
+		FavoriteCount: int32(photo.FavoriteCount),
+		CreatedAt:     toTimestamp(photo.CreatedAt),
+		UpdatedAt:     toTimestamp(photo.UpdatedAt),
+	}
+}
+
+// toEvent converts an event into its message
+func toEvent(event *domain.EventResponse) *eralovev1.Event {
+	return &eralovev1.Event{
+		Id:             event.ID,
+		MatchCode:      event.MatchCode,
+		CreatedBy:      event.CreatedBy,
+		Title:          event.Title,
+		Description:    event.Description,
+		Date:           toDate(&event.Date),
+		Time:           event.Time,
+		Location:       event.Location,
+		Place:          toPlace(event.Place),
+		EventType:      event.EventType,
+		IsRecurring:    event.IsRecurring,
+		RecurrenceRule: event.RecurrenceRule,
+		IsPrivate:      event.IsPrivate,
+		IsSystem:       event.IsSystem,
+		CreatedAt:      toTimestamp(event.CreatedAt),
+		UpdatedAt:      toTimestamp(event.UpdatedAt),
+	}
+}
+
+// toPlace converts a place into its message
+func toPlace(place *domain.Place) *eralovev1.Place {
+	if place == nil {
+		return nil
+	}
+	return &eralovev1.Place{
+		Name:       place.Name,
+		Address:    place.Address,
+		Latitude:   place.Latitude,
+		Longitude:  place.Longitude,
+		ProviderId: place.ProviderID,
+	}
+}
+
+// toDate converts a calendar day into its message, nil for a missing or zero day
+func toDate(date *domain.Date) *eralovev1.Date {
+	if date == nil || date.IsZero() {
+		return nil
+	}
+	return &eralovev1.Date{Value: date.String()}
+}
+
+// fromDate parses the calendar day of a request field, nil when it is not set
+func fromDate(date *eralovev1.Date, field string) (*domain.Date, error) {
+	if date.GetValue() == "" {
+		return nil, nil
+	}
+	parsed, err := domain.ParseDate(date.GetValue())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s must be a date formatted YYYY-MM-DD", field)
+	}
+	return &parsed, nil
+}
+
+// toTimestamp converts an instant into its message, nil for the zero time
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
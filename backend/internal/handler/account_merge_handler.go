@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// AccountMergeHandler handles merging duplicate accounts
+type AccountMergeHandler struct {
+	mergeService domain.AccountMergeService
+	validator    *validator.Validate
+	i18n         *i18n.I18n
+	logger       *zap.Logger
+}
+
+// NewAccountMergeHandler creates a new account merge handler
+func NewAccountMergeHandler(
+	mergeService domain.AccountMergeService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *AccountMergeHandler {
+	return &AccountMergeHandler{
+		mergeService: mergeService,
+		validator:    validator,
+		i18n:         i18n,
+		logger:       logger,
+	}
+}
+
+// StartMerge godoc
+// @Summary Start an account merge
+// @Description Start merging a duplicate account into the signed-in one. Both accounts' passwords are checked and a confirmation code is emailed to the duplicate account. Only one of the accounts may be matched.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body domain.StartAccountMergeRequest true "Credentials of both accounts"
+// @Security BearerAuth
+// @Success 202 {object} SuccessResponse{data=domain.AccountMerge}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /users/merge [post]
+func (h *AccountMergeHandler) StartMerge(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.StartAccountMergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	merge, err := h.mergeService.StartMerge(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Start account merge")
+		return err
+	}
+
+	LogServiceSuccess(c, "Start account merge")
+
+	return RespondMessage(c, fiber.StatusAccepted, merge, h.i18n.Translate(getLocale(c), "account_merge_started", nil))
+}
+
+// ConfirmMerge godoc
+// @Summary Confirm an account merge
+// @Description Confirm the pending merge with the code emailed to the duplicate account. Its photos, events, messages and match move to the signed-in account and the duplicate is closed. The merge is cancelled after 5 wrong codes.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body domain.ConfirmAccountMergeRequest true "Confirmation code"
+// @Security BearerAuth
+// @Success 200 {object} SuccessResponse{data=domain.AccountMerge}
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /users/merge/confirm [post]
+func (h *AccountMergeHandler) ConfirmMerge(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.ConfirmAccountMergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	merge, err := h.mergeService.ConfirmMerge(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Confirm account merge")
+		return err
+	}
+
+	LogServiceSuccess(c, "Confirm account merge")
+
+	return RespondMessage(c, fiber.StatusOK, merge, h.i18n.Translate(getLocale(c), "account_merge_completed", nil))
+}
@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// AdminHandler handles the support staff HTTP requests on accounts
+type AdminHandler struct {
+	adminService domain.AdminService
+	validator    *validator.Validate
+	i18n         *i18n.I18n
+	logger       *zap.Logger
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(adminService domain.AdminService, validator *validator.Validate, i18n *i18n.I18n, logger *zap.Logger) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+		validator:    validator,
+		i18n:         i18n,
+		logger:       logger,
+	}
+}
+
+// SearchUsers handles searching users
+// @Summary Search users
+// @Description Search users by part of their name or email, newest first, including deleted accounts unless status is given. Admin only. Pass next_cursor back as cursor to get the next page.
+// @Tags admin
+// @Produce json
+// @Param q query string false "Part of the name or email"
+// @Param status query string false "Filter by status: active or deleted"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Items per page" default(20)
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} domain.AdminUserListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/users [get]
+func (h *AdminHandler) SearchUsers(c *fiber.Ctx) error {
+	cursor, err := domain.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Cursor must be a next_cursor value returned by a previous request")
+	}
+
+	filter := domain.UserSearchFilter{
+		Query:  c.Query("q"),
+		Status: domain.UserSearchStatus(c.Query("status")),
+	}
+	limit := domain.NormalizeCursorLimit(c.QueryInt("limit", domain.DefaultCursorLimit))
+
+	users, err := h.adminService.SearchUsers(c.Context(), filter, cursor, limit)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, users)
+}
+
+// GetUser handles getting a user
+// @Summary Get user
+// @Description Get a user, including a deleted or merged account. Admin only.
+// @Tags admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} domain.AdminUserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *fiber.Ctx) error {
+	userID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	user, err := h.adminService.GetUser(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, user)
+}
+
+// RestoreUser handles restoring a deleted account
+// @Summary Restore user
+// @Description Restore a soft-deleted account. Admin only. Fails when another account now uses its email.
+// @Tags admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} domain.AdminUserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/users/{id}/restore [post]
+func (h *AdminHandler) RestoreUser(c *fiber.Ctx) error {
+	userID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	user, err := h.adminService.RestoreUser(c.Context(), userID)
+	if err != nil {
+		LogServiceError(c, err, "Restore user", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, user)
+}
+
+// ForcePasswordReset handles forcing a user to choose a new password
+// @Summary Force password reset
+// @Description Clear the user's password, sign out every session and email a link to choose a new password. Admin only.
+// @Tags admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/users/{id}/password-reset [post]
+func (h *AdminHandler) ForcePasswordReset(c *fiber.Ctx) error {
+	userID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	if err := h.adminService.ForcePasswordReset(c.Context(), userID); err != nil {
+		LogServiceError(c, err, "Force password reset", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return RespondMessage(c, fiber.StatusOK, nil, h.i18n.Translate(getLocale(c), "password_reset_email_sent", nil))
+}
+
+// ResendVerificationEmail handles resending a user's verification email
+// @Summary Resend verification email
+// @Description Send the user a new email verification link. Admin only.
+// @Tags admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/users/{id}/verification-email [post]
+func (h *AdminHandler) ResendVerificationEmail(c *fiber.Ctx) error {
+	userID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	if err := h.adminService.ResendVerificationEmail(c.Context(), userID); err != nil {
+		LogServiceError(c, err, "Resend verification email", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return RespondMessage(c, fiber.StatusOK, nil, h.i18n.Translate(getLocale(c), "verification_email_sent", nil))
+}
+
+// GetMatchState handles inspecting a user's match
+// @Summary Get match state
+// @Description Get the user's partner, whether both partners are linked to each other, any pending unmatch and the match requests still pending. Admin only.
+// @Tags admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} domain.AdminMatchStateResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /admin/users/{id}/match [get]
+func (h *AdminHandler) GetMatchState(c *fiber.Ctx) error {
+	userID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	state, err := h.adminService.GetMatchState(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, state)
+}
+
+// SetRoles handles replacing a user's roles
+// @Summary Set user roles
+// @Description Replace the roles of a user: user, moderator or admin. Every user has the user role. Admin only. Tokens already issued keep their roles until they expire; the new roles apply from the next login or token refresh.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body domain.SetRolesRequest true "Roles"
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} domain.AdminUserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/users/{id}/roles [put]
+func (h *AdminHandler) SetRoles(c *fiber.Ctx) error {
+	userID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidUserID(c)
+	}
+
+	var req domain.SetRolesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	user, err := h.adminService.SetRoles(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Set user roles", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, user)
+}
+
+// invalidUserID returns the error for a malformed user ID
+func (h *AdminHandler) invalidUserID(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Invalid user ID")
+}
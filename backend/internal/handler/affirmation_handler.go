@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// AffirmationHandler handles partner audio affirmation HTTP requests
+type AffirmationHandler struct {
+	affirmationService domain.AffirmationService
+	validator          *validator.Validate
+	i18n               *i18n.I18n
+	logger             *zap.Logger
+}
+
+// NewAffirmationHandler creates a new affirmation handler
+func NewAffirmationHandler(
+	affirmationService domain.AffirmationService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *AffirmationHandler {
+	return &AffirmationHandler{
+		affirmationService: affirmationService,
+		validator:          validator,
+		i18n:               i18n,
+		logger:             logger,
+	}
+}
+
+// CreateAffirmation handles uploading an audio affirmation
+// @Summary Upload an affirmation
+// @Description Upload a short audio affirmation that is delivered to the partner on a random morning
+// @Tags affirmations
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Audio file"
+// @Param title formData string true "Affirmation title"
+// @Param duration_seconds formData int false "Clip duration in seconds"
+// @Security BearerAuth
+// @Success 201 {object} domain.AffirmationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /affirmations [post]
+func (h *AffirmationHandler) CreateAffirmation(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return domain.ErrInvalidRequestError("File is required")
+	}
+
+	duration, _ := strconv.Atoi(c.FormValue("duration_seconds", "0"))
+	req := domain.CreateAffirmationRequest{
+		Title:           c.FormValue("title"),
+		DurationSeconds: duration,
+		Filename:        file.Filename,
+		ContentType:     file.Header.Get("Content-Type"),
+		Size:            file.Size,
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	fileContent, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer fileContent.Close()
+	req.File = fileContent
+
+	affirmation, err := h.affirmationService.CreateAffirmation(c.Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, affirmation)
+}
+
+// GetLibrary handles listing the user's affirmation library
+// @Summary Get affirmation library
+// @Description Get affirmations received from (default) or sent to the partner
+// @Tags affirmations
+// @Produce json
+// @Param box query string false "received or sent" default(received)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Security BearerAuth
+// @Success 200 {object} domain.AffirmationListResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /affirmations [get]
+func (h *AffirmationHandler) GetLibrary(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	box := domain.AffirmationBox(c.Query("box", string(domain.AffirmationBoxReceived)))
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	library, err := h.affirmationService.GetLibrary(c.Context(), userID, box, page, limit)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, library)
+}
+
+// GetAffirmation handles getting a specific affirmation
+// @Summary Get affirmation by ID
+// @Description Get an affirmation with a playback URL
+// @Tags affirmations
+// @Produce json
+// @Param id path string true "Affirmation ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.AffirmationResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /affirmations/{id} [get]
+func (h *AffirmationHandler) GetAffirmation(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	affirmationID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidAffirmationID(c)
+	}
+
+	affirmation, err := h.affirmationService.GetAffirmation(c.Context(), affirmationID, userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, affirmation)
+}
+
+// RecordPlay handles tracking a playback of an affirmation
+// @Summary Record affirmation play
+// @Description Record that the recipient played an affirmation
+// @Tags affirmations
+// @Produce json
+// @Param id path string true "Affirmation ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.AffirmationResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /affirmations/{id}/play [post]
+func (h *AffirmationHandler) RecordPlay(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	affirmationID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidAffirmationID(c)
+	}
+
+	affirmation, err := h.affirmationService.RecordPlay(c.Context(), affirmationID, userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, affirmation)
+}
+
+// DeleteAffirmation handles affirmation deletion
+// @Summary Delete affirmation
+// @Description Delete an affirmation you recorded
+// @Tags affirmations
+// @Produce json
+// @Param id path string true "Affirmation ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /affirmations/{id} [delete]
+func (h *AffirmationHandler) DeleteAffirmation(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	affirmationID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidAffirmationID(c)
+	}
+
+	if err := h.affirmationService.DeleteAffirmation(c.Context(), affirmationID, userID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// invalidAffirmationID returns the error for a malformed affirmation ID
+func (h *AffirmationHandler) invalidAffirmationID(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Affirmation ID must be a valid ObjectID")
+}
@@ -0,0 +1,359 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// AlbumHandler handles photo album HTTP requests
+type AlbumHandler struct {
+	albumService domain.AlbumService
+	validator    *validator.Validate
+	i18n         *i18n.I18n
+	logger       *zap.Logger
+}
+
+// NewAlbumHandler creates a new album handler
+func NewAlbumHandler(
+	albumService domain.AlbumService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *AlbumHandler {
+	return &AlbumHandler{
+		albumService: albumService,
+		validator:    validator,
+		i18n:         i18n,
+		logger:       logger,
+	}
+}
+
+// CreateAlbum handles album creation
+// @Summary Create a new album
+// @Description Create a photo album for the couple
+// @Tags albums
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateAlbumRequest true "Album data"
+// @Security BearerAuth
+// @Success 201 {object} domain.AlbumResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /albums [post]
+func (h *AlbumHandler) CreateAlbum(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.CreateAlbumRequest
+	if err := h.parseAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	album, err := h.albumService.CreateAlbum(c.Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, album)
+}
+
+// GetAlbums handles listing the couple's albums
+// @Summary Get albums
+// @Description Get all albums of the couple in display order, with photo counts
+// @Tags albums
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.AlbumListResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /albums [get]
+func (h *AlbumHandler) GetAlbums(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	albums, err := h.albumService.GetCoupleAlbums(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, domain.AlbumListResponse{
+		Albums: albums,
+		Total:  len(albums),
+	})
+}
+
+// GetAlbum handles getting a specific album
+// @Summary Get album by ID
+// @Description Get a specific album with its photo count
+// @Tags albums
+// @Produce json
+// @Param id path string true "Album ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.AlbumResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /albums/{id} [get]
+func (h *AlbumHandler) GetAlbum(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	albumID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidAlbumID(c)
+	}
+
+	album, err := h.albumService.GetAlbum(c.Context(), albumID, userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, album)
+}
+
+// UpdateAlbum handles renaming an album
+// @Summary Update album
+// @Description Rename an album or update its description
+// @Tags albums
+// @Accept json
+// @Produce json
+// @Param id path string true "Album ID"
+// @Param request body domain.UpdateAlbumRequest true "Album update data"
+// @Security BearerAuth
+// @Success 200 {object} domain.AlbumResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /albums/{id} [put]
+func (h *AlbumHandler) UpdateAlbum(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	albumID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidAlbumID(c)
+	}
+
+	var req domain.UpdateAlbumRequest
+	if err := h.parseAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	album, err := h.albumService.UpdateAlbum(c.Context(), albumID, userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, album)
+}
+
+// DeleteAlbum handles album deletion
+// @Summary Delete album
+// @Description Delete an album; its photos are kept. When the couple requires partner approval, the deletion waits for the partner to approve it and the pending action is returned with 202.
+// @Tags albums
+// @Produce json
+// @Param id path string true "Album ID"
+// @Security BearerAuth
+// @Success 202 {object} domain.PendingActionResponse
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /albums/{id} [delete]
+func (h *AlbumHandler) DeleteAlbum(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	albumID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidAlbumID(c)
+	}
+
+	pending, err := h.albumService.DeleteAlbum(c.Context(), albumID, userID)
+	if err != nil {
+		return err
+	}
+
+	if pending != nil {
+		return Respond(c, fiber.StatusAccepted, pending)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ReorderAlbums handles reordering the couple's albums
+// @Summary Reorder albums
+// @Description Set the display order of albums
+// @Tags albums
+// @Accept json
+// @Produce json
+// @Param request body domain.ReorderAlbumsRequest true "Album IDs in the desired order"
+// @Security BearerAuth
+// @Success 200 {object} domain.AlbumListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /albums/reorder [put]
+func (h *AlbumHandler) ReorderAlbums(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.ReorderAlbumsRequest
+	if err := h.parseAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	albums, err := h.albumService.ReorderAlbums(c.Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, domain.AlbumListResponse{
+		Albums: albums,
+		Total:  len(albums),
+	})
+}
+
+// SetAlbumCover handles setting an album's cover photo
+// @Summary Set album cover
+// @Description Set the cover photo of an album
+// @Tags albums
+// @Accept json
+// @Produce json
+// @Param id path string true "Album ID"
+// @Param request body domain.SetAlbumCoverRequest true "Cover photo"
+// @Security BearerAuth
+// @Success 200 {object} domain.AlbumResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /albums/{id}/cover [put]
+func (h *AlbumHandler) SetAlbumCover(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	albumID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidAlbumID(c)
+	}
+
+	var req domain.SetAlbumCoverRequest
+	if err := h.parseAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	album, err := h.albumService.SetCoverPhoto(c.Context(), albumID, userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, album)
+}
+
+// GetAlbumPhotos handles listing photos in an album
+// @Summary Get album photos
+// @Description Get the photos of an album
+// @Tags albums
+// @Produce json
+// @Param id path string true "Album ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Param fields query string false "Comma-separated fields to keep in each item of the list; id is always kept"
+// @Security BearerAuth
+// @Success 200 {object} domain.PhotoListResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /albums/{id}/photos [get]
+func (h *AlbumHandler) GetAlbumPhotos(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	albumID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidAlbumID(c)
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	photos, total, err := h.albumService.GetAlbumPhotos(c.Context(), albumID, userID, page, limit)
+	if err != nil {
+		return err
+	}
+
+	return RespondPage(c, domain.PhotoListResponse{
+		Photos: photos,
+		Total:  total,
+		Page:   page,
+		Limit:  limit,
+	}, PageMeta(total, page, limit))
+}
+
+// AddAlbumPhotos handles adding photos to an album
+// @Summary Add photos to album
+// @Description Move photos into an album
+// @Tags albums
+// @Accept json
+// @Produce json
+// @Param id path string true "Album ID"
+// @Param request body domain.AlbumPhotosRequest true "Photo IDs"
+// @Security BearerAuth
+// @Success 200 {object} domain.AlbumResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /albums/{id}/photos [post]
+func (h *AlbumHandler) AddAlbumPhotos(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	albumID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidAlbumID(c)
+	}
+
+	var req domain.AlbumPhotosRequest
+	if err := h.parseAndValidate(c, &req); err != nil {
+		return err
+	}
+
+	album, err := h.albumService.AddPhotos(c.Context(), albumID, userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, album)
+}
+
+// RemoveAlbumPhoto handles removing a photo from an album
+// @Summary Remove photo from album
+// @Description Remove a photo from an album without deleting it
+// @Tags albums
+// @Produce json
+// @Param id path string true "Album ID"
+// @Param photoId path string true "Photo ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /albums/{id}/photos/{photoId} [delete]
+func (h *AlbumHandler) RemoveAlbumPhoto(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	albumID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidAlbumID(c)
+	}
+
+	photoID, err := primitive.ObjectIDFromHex(c.Params("photoId"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Photo ID must be a valid ObjectID")
+	}
+
+	if err := h.albumService.RemovePhoto(c.Context(), albumID, photoID, userID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// parseAndValidate parses the request body into req and validates it
+func (h *AlbumHandler) parseAndValidate(c *fiber.Ctx, req interface{}) error {
+	if err := c.BodyParser(req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	return nil
+}
+
+// invalidAlbumID returns the error for a malformed album ID
+func (h *AlbumHandler) invalidAlbumID(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Album ID must be a valid ObjectID")
+}
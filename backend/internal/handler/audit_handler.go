@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// AuditHandler handles audit log HTTP requests
+type AuditHandler struct {
+	auditService domain.AuditService
+	logger       *zap.Logger
+}
+
+// NewAuditHandler creates a new audit log handler
+func NewAuditHandler(auditService domain.AuditService, logger *zap.Logger) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
+
+// ListAuditLog handles listing the current user's account activity
+// @Summary List account activity
+// @Description List the security-sensitive actions on the current user's account, newest first: logins, failed logins, logouts, password changes and resets, unmatches and the operations of support staff. The address and device are only shown for the user's own actions. Entries are kept for a year. Pass next_cursor back as cursor to get the next page.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} domain.AuditLogListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /users/audit-log [get]
+func (h *AuditHandler) ListAuditLog(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	cursor, err := domain.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Cursor must be a next_cursor value returned by a previous request")
+	}
+
+	limit := domain.NormalizeCursorLimit(c.QueryInt("limit", domain.DefaultCursorLimit))
+
+	entries, err := h.auditService.ListForUser(c.Context(), userID, cursor, limit)
+	if err != nil {
+		LogServiceError(c, err, "List audit log")
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, entries)
+}
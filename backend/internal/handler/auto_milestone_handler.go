@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// AutoMilestoneHandler handles milestone event settings HTTP requests
+type AutoMilestoneHandler struct {
+	autoMilestoneService domain.AutoMilestoneService
+	validator            *validator.Validate
+	i18n                 *i18n.I18n
+	logger               *zap.Logger
+}
+
+// NewAutoMilestoneHandler creates a new milestone event settings handler
+func NewAutoMilestoneHandler(
+	autoMilestoneService domain.AutoMilestoneService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *AutoMilestoneHandler {
+	return &AutoMilestoneHandler{
+		autoMilestoneService: autoMilestoneService,
+		validator:            validator,
+		i18n:                 i18n,
+		logger:               logger,
+	}
+}
+
+// GetSettings handles getting which milestone events are generated
+// @Summary Get milestone settings
+// @Description List the kinds of milestone events generated from the anniversary date (day counts, yearly and monthly anniversaries) and whether each is enabled
+// @Tags couple
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.AutoMilestonesResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /couple/milestones [get]
+func (h *AutoMilestoneHandler) GetSettings(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	settings, err := h.autoMilestoneService.GetSettings(c.Context(), userID)
+	if err != nil {
+		LogServiceError(c, err, "Get milestone settings", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, settings)
+}
+
+// UpdateSettings handles enabling or disabling kinds of milestone events
+// @Summary Update milestone settings
+// @Description Enable or disable kinds of generated milestone events. Upcoming events of a disabled kind are removed; enabling a kind generates them again.
+// @Tags couple
+// @Accept json
+// @Produce json
+// @Param request body domain.UpdateAutoMilestonesRequest true "Milestone settings"
+// @Security BearerAuth
+// @Success 200 {object} domain.AutoMilestonesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /couple/milestones [put]
+func (h *AutoMilestoneHandler) UpdateSettings(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.UpdateAutoMilestonesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	settings, err := h.autoMilestoneService.UpdateSettings(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Update milestone settings", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, settings)
+}
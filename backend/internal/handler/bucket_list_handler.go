@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// BucketListHandler handles couple bucket list HTTP requests
+type BucketListHandler struct {
+	bucketListService domain.BucketListService
+	validator         *validator.Validate
+	i18n              *i18n.I18n
+	logger            *zap.Logger
+}
+
+// NewBucketListHandler creates a new bucket list handler
+func NewBucketListHandler(
+	bucketListService domain.BucketListService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *BucketListHandler {
+	return &BucketListHandler{
+		bucketListService: bucketListService,
+		validator:         validator,
+		i18n:              i18n,
+		logger:            logger,
+	}
+}
+
+// CreateItem handles adding a bucket list item
+// @Summary Add a bucket list item
+// @Description Add something the couple wants to do together, optionally with photos attached
+// @Tags bucket-list
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateBucketListItemRequest true "Bucket list item"
+// @Security BearerAuth
+// @Success 201 {object} domain.BucketListItemResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /bucket-list [post]
+func (h *BucketListHandler) CreateItem(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.CreateBucketListItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.invalidBody(c)
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return h.validationFailed(c, err)
+	}
+
+	item, err := h.bucketListService.CreateItem(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Create bucket list item", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, item)
+}
+
+// GetItems handles listing the couple bucket list
+// @Summary Get bucket list
+// @Description Get the couple's bucket list, open items first
+// @Tags bucket-list
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Param status query string false "Filter by status (open, completed)"
+// @Param category query string false "Filter by category"
+// @Security BearerAuth
+// @Success 200 {object} domain.BucketListResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /bucket-list [get]
+func (h *BucketListHandler) GetItems(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	status := domain.BucketListStatus(c.Query("status"))
+	category := domain.BucketListCategory(c.Query("category"))
+
+	items, err := h.bucketListService.GetItems(c.Context(), userID, status, category, page, limit)
+	if err != nil {
+		LogServiceError(c, err, "Get bucket list", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, items)
+}
+
+// GetStats handles getting bucket list progress
+// @Summary Get bucket list stats
+// @Description Get how far the couple is through their bucket list, overall and per category
+// @Tags bucket-list
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.BucketListStatsResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /bucket-list/stats [get]
+func (h *BucketListHandler) GetStats(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	stats, err := h.bucketListService.GetStats(c.Context(), userID)
+	if err != nil {
+		LogServiceError(c, err, "Get bucket list stats", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, stats)
+}
+
+// GetItem handles getting a bucket list item
+// @Summary Get bucket list item by ID
+// @Description Get a bucket list item
+// @Tags bucket-list
+// @Produce json
+// @Param id path string true "Bucket list item ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.BucketListItemResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /bucket-list/{id} [get]
+func (h *BucketListHandler) GetItem(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	itemID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidItemID(c)
+	}
+
+	item, err := h.bucketListService.GetItem(c.Context(), itemID, userID)
+	if err != nil {
+		LogServiceError(c, err, "Get bucket list item",
+			zap.String("user_id", userID.Hex()),
+			zap.String("item_id", itemID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, item)
+}
+
+// UpdateItem handles updating a bucket list item
+// @Summary Update bucket list item
+// @Description Update a bucket list item. photo_ids replaces the attached photos.
+// @Tags bucket-list
+// @Accept json
+// @Produce json
+// @Param id path string true "Bucket list item ID"
+// @Param request body domain.UpdateBucketListItemRequest true "Changes"
+// @Security BearerAuth
+// @Success 200 {object} domain.BucketListItemResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /bucket-list/{id} [put]
+func (h *BucketListHandler) UpdateItem(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	itemID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidItemID(c)
+	}
+
+	var req domain.UpdateBucketListItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.invalidBody(c)
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return h.validationFailed(c, err)
+	}
+
+	item, err := h.bucketListService.UpdateItem(c.Context(), itemID, userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Update bucket list item",
+			zap.String("user_id", userID.Hex()),
+			zap.String("item_id", itemID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, item)
+}
+
+// DeleteItem handles deleting a bucket list item
+// @Summary Delete bucket list item
+// @Description Delete a bucket list item. An event recorded on completion is kept.
+// @Tags bucket-list
+// @Produce json
+// @Param id path string true "Bucket list item ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /bucket-list/{id} [delete]
+func (h *BucketListHandler) DeleteItem(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	itemID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidItemID(c)
+	}
+
+	if err := h.bucketListService.DeleteItem(c.Context(), itemID, userID); err != nil {
+		LogServiceError(c, err, "Delete bucket list item",
+			zap.String("user_id", userID.Hex()),
+			zap.String("item_id", itemID.Hex()))
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// CompleteItem handles ticking off a bucket list item
+// @Summary Complete bucket list item
+// @Description Mark a bucket list item as done. It is linked to event_id when given; otherwise a milestone event is created on the completion date.
+// @Tags bucket-list
+// @Accept json
+// @Produce json
+// @Param id path string true "Bucket list item ID"
+// @Param request body domain.CompleteBucketListItemRequest false "Completion details"
+// @Security BearerAuth
+// @Success 200 {object} domain.BucketListItemResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /bucket-list/{id}/complete [post]
+func (h *BucketListHandler) CompleteItem(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	itemID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidItemID(c)
+	}
+
+	var req domain.CompleteBucketListItemRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return h.invalidBody(c)
+		}
+	}
+
+	item, err := h.bucketListService.CompleteItem(c.Context(), itemID, userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Complete bucket list item",
+			zap.String("user_id", userID.Hex()),
+			zap.String("item_id", itemID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, item)
+}
+
+// ReopenItem handles putting a completed bucket list item back on the list
+// @Summary Reopen bucket list item
+// @Description Mark a completed bucket list item as not done. The milestone event created on completion is deleted.
+// @Tags bucket-list
+// @Produce json
+// @Param id path string true "Bucket list item ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.BucketListItemResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /bucket-list/{id}/complete [delete]
+func (h *BucketListHandler) ReopenItem(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	itemID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidItemID(c)
+	}
+
+	item, err := h.bucketListService.ReopenItem(c.Context(), itemID, userID)
+	if err != nil {
+		LogServiceError(c, err, "Reopen bucket list item",
+			zap.String("user_id", userID.Hex()),
+			zap.String("item_id", itemID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, item)
+}
+
+// invalidItemID returns the error for a malformed bucket list item ID
+func (h *BucketListHandler) invalidItemID(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Invalid bucket list item ID")
+}
+
+// invalidBody returns the error for a request body that cannot be parsed
+func (h *BucketListHandler) invalidBody(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Invalid request body")
+}
+
+// validationFailed returns the error listing the invalid fields
+func (h *BucketListHandler) validationFailed(c *fiber.Ctx, err error) error {
+	return domain.ErrValidationFailedError(getValidationErrors(err))
+}
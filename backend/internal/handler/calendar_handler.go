@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// calendarContentType is the media type of iCalendar responses
+const calendarContentType = "text/calendar; charset=utf-8"
+
+// CalendarHandler handles calendar export and subscription HTTP requests
+type CalendarHandler struct {
+	calendarService domain.CalendarService
+	i18n            *i18n.I18n
+	logger          *zap.Logger
+}
+
+// NewCalendarHandler creates a new calendar handler
+func NewCalendarHandler(
+	calendarService domain.CalendarService,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+		i18n:            i18n,
+		logger:          logger,
+	}
+}
+
+// ExportEvents handles downloading the couple's events as an iCalendar file
+// @Summary Export events
+// @Description Download the couple's events, including recurring ones, as an iCalendar (.ics) file
+// @Tags events
+// @Produce text/calendar
+// @Security BearerAuth
+// @Success 200 {string} string "iCalendar file"
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /events/export.ics [get]
+func (h *CalendarHandler) ExportEvents(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	calendar, err := h.calendarService.ExportEvents(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, calendarContentType)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="eralove-events.ics"`)
+	return c.Send(calendar)
+}
+
+// GetFeed handles getting the calendar subscription of the user
+// @Summary Get calendar subscription
+// @Description Get the read-only calendar subscription URL of the authenticated user
+// @Tags calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.CalendarFeedResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /calendar/feed [get]
+func (h *CalendarHandler) GetFeed(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	feed, err := h.calendarService.GetFeed(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, h.feedResponse(c, feed))
+}
+
+// RotateFeed handles creating the calendar subscription or replacing its URL
+// @Summary Create or rotate calendar subscription
+// @Description Create a read-only calendar subscription URL for Google or Apple Calendar. Calling it again issues a new URL and the previous one stops working.
+// @Tags calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} domain.CalendarFeedResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /calendar/feed [post]
+func (h *CalendarHandler) RotateFeed(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	feed, err := h.calendarService.RotateFeed(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, h.feedResponse(c, feed))
+}
+
+// RevokeFeed handles revoking the calendar subscription
+// @Summary Revoke calendar subscription
+// @Description Revoke the calendar subscription URL of the authenticated user
+// @Tags calendar
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /calendar/feed [delete]
+func (h *CalendarHandler) RevokeFeed(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	if err := h.calendarService.RevokeFeed(c.Context(), userID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetFeedCalendar handles calendar apps fetching a subscription
+// @Summary Calendar subscription feed
+// @Description Read-only iCalendar feed of the couple's events, authenticated by the token in the URL
+// @Tags calendar
+// @Produce text/calendar
+// @Param feed_token path string true "Calendar feed token"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 404 {object} ErrorResponse
+// @Router /calendar/{feed_token}.ics [get]
+func (h *CalendarHandler) GetFeedCalendar(c *fiber.Ctx) error {
+	calendar, err := h.calendarService.RenderFeed(c.Context(), c.Params("feed_token"))
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, calendarContentType)
+	c.Set(fiber.HeaderCacheControl, "private, max-age=900")
+	return c.Send(calendar)
+}
+
+// feedResponse builds the subscription response with URLs on the host the request came in on
+func (h *CalendarHandler) feedResponse(c *fiber.Ctx, feed *domain.CalendarFeed) *domain.CalendarFeedResponse {
+	url := c.BaseURL() + "/api/v1/calendar/" + feed.Token + ".ics"
+
+	return &domain.CalendarFeedResponse{
+		Token:          feed.Token,
+		URL:            url,
+		WebcalURL:      "webcal://" + url[strings.Index(url, "://")+3:],
+		LastAccessedAt: feed.LastAccessedAt,
+		CreatedAt:      feed.CreatedAt,
+	}
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// ChangelogHandler handles in-app changelog HTTP requests
+type ChangelogHandler struct {
+	changelogService domain.ChangelogService
+	validator        *validator.Validate
+	i18n             *i18n.I18n
+	logger           *zap.Logger
+}
+
+// NewChangelogHandler creates a new changelog handler
+func NewChangelogHandler(
+	changelogService domain.ChangelogService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *ChangelogHandler {
+	return &ChangelogHandler{
+		changelogService: changelogService,
+		validator:        validator,
+		i18n:             i18n,
+		logger:           logger,
+	}
+}
+
+// GetChangelog handles getting the release notes
+// @Summary Get changelog
+// @Description Get the release notes newer than since_version, newest first, each marked with whether the user has already been shown it. Show a what's-new modal while unseen_count is not zero, then mark the shown releases as seen.
+// @Tags changelog
+// @Produce json
+// @Param since_version query string false "Only releases newer than this version, usually the version the app was updated from"
+// @Security BearerAuth
+// @Success 200 {object} domain.ChangelogResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /changelog [get]
+func (h *ChangelogHandler) GetChangelog(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	changelog, err := h.changelogService.GetChangelog(c.Context(), userID, c.Query("since_version"))
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, changelog)
+}
+
+// MarkSeen handles recording the releases shown to the user
+// @Summary Mark releases as seen
+// @Description Record that the what's-new modal of the given releases has been shown, so it is not shown again on any device
+// @Tags changelog
+// @Accept json
+// @Param request body domain.MarkChangelogSeenRequest true "Shown release versions"
+// @Security BearerAuth
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /changelog/seen [post]
+func (h *ChangelogHandler) MarkSeen(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.MarkChangelogSeenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	if err := h.changelogService.MarkSeen(c.Context(), userID, &req); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
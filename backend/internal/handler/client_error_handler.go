@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// ClientErrorHandler handles client error report HTTP requests
+type ClientErrorHandler struct {
+	clientErrorService domain.ClientErrorService
+	validator          *validator.Validate
+	i18n               *i18n.I18n
+	logger             *zap.Logger
+}
+
+// NewClientErrorHandler creates a new client error handler
+func NewClientErrorHandler(
+	clientErrorService domain.ClientErrorService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *ClientErrorHandler {
+	return &ClientErrorHandler{
+		clientErrorService: clientErrorService,
+		validator:          validator,
+		i18n:               i18n,
+		logger:             logger,
+	}
+}
+
+// ReportError handles an error or crash report from a client
+// @Summary Report a client error
+// @Description Report an error or crash from the mobile or web app. A token is optional; when a valid one is sent the report is linked to the user. Set trace_id to the trace_id of the failed API response, if any. Non-fatal reports may be sampled out, in which case accepted is false.
+// @Tags client-errors
+// @Accept json
+// @Produce json
+// @Param request body domain.ReportClientErrorRequest true "Error report"
+// @Success 202 {object} domain.ClientErrorReceipt
+// @Failure 400 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /client-errors [post]
+func (h *ClientErrorHandler) ReportError(c *fiber.Ctx) error {
+	var req domain.ReportClientErrorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	source := domain.ClientErrorSource{
+		UserID:    getUserIDFromContext(c),
+		IP:        c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	}
+
+	receipt, err := h.clientErrorService.ReportError(c.Context(), source, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusAccepted, receipt)
+}
+
+// ListClientErrors handles listing client errors for support
+// @Summary List client errors
+// @Description List reported client errors newest first, each with the failed API request its trace_id points to when that request is still recorded. Admins and moderators only. Pass next_cursor back as cursor to get the next page.
+// @Tags admin
+// @Produce json
+// @Param platform query string false "Filter by platform (ios, android, web)"
+// @Param app_version query string false "Filter by app version"
+// @Param trace_id query string false "Filter by the trace ID of the failed API request"
+// @Param user_id query string false "Filter by user ID"
+// @Param fatal query bool false "Only list fatal errors"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Items per page" default(20)
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} domain.ClientErrorListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/client-errors [get]
+func (h *ClientErrorHandler) ListClientErrors(c *fiber.Ctx) error {
+	cursor, err := domain.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Cursor must be a next_cursor value returned by a previous request")
+	}
+
+	filter := domain.ClientErrorFilter{
+		Platform:   domain.ClientPlatform(c.Query("platform")),
+		AppVersion: c.Query("app_version"),
+		TraceID:    c.Query("trace_id"),
+		FatalOnly:  c.QueryBool("fatal"),
+	}
+
+	if raw := c.Query("user_id"); raw != "" {
+		userID, err := primitive.ObjectIDFromHex(raw)
+		if err != nil {
+			return domain.ErrInvalidRequestError("Invalid user ID")
+		}
+		filter.UserID = &userID
+	}
+
+	limit := domain.NormalizeCursorLimit(c.QueryInt("limit", domain.DefaultCursorLimit))
+
+	clientErrors, err := h.clientErrorService.ListClientErrors(c.Context(), filter, cursor, limit)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, clientErrors)
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
@@ -26,6 +28,22 @@ func getUserIDFromContext(c *fiber.Ctx) primitive.ObjectID {
 	return primitive.ObjectID{}
 }
 
+// LocaleKey is the key of the language of the response in the request's locals
+const LocaleKey = "locale"
+
+// APIVersionKey is the key of the API version the request was made to in the
+// request's locals
+const APIVersionKey = "api_version"
+
+// getLocale returns the language of the response, resolved by the locale middleware
+// from the user's preferred language or the Accept-Language header
+func getLocale(c *fiber.Ctx) string {
+	if locale, ok := c.Locals(LocaleKey).(string); ok && locale != "" {
+		return locale
+	}
+	return i18n.ParseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage))
+}
+
 // ErrorResponse represents an error response
 // @Description Error response structure
 type ErrorResponse struct {
@@ -39,10 +57,11 @@ type ErrorResponse struct {
 // SuccessResponse represents a success response
 // @Description Success response structure
 type SuccessResponse struct {
-	Success bool        `json:"success" example:"true"`                                  // Success status
-	Data    interface{} `json:"data,omitempty"`                                          // Response data (optional)
-	Message string      `json:"message" example:"Operation completed successfully"`      // Success message
-	TraceID string      `json:"trace_id" example:"550e8400-e29b-41d4-a716-446655440000"` // Request trace ID
+	Success bool            `json:"success" example:"true"`                                  // Success status
+	Data    interface{}     `json:"data,omitempty"`                                          // Response data (optional)
+	Message string          `json:"message" example:"Operation completed successfully"`      // Success message
+	Meta    *PaginationMeta `json:"meta,omitempty"`                                          // Pagination of list responses (optional)
+	TraceID string          `json:"trace_id" example:"550e8400-e29b-41d4-a716-446655440000"` // Request trace ID
 }
 
 // getTraceID extracts trace ID from fiber context
@@ -54,7 +73,8 @@ func getTraceID(c *fiber.Ctx) string {
 	return traceID.(string)
 }
 
-// getLoggerWithTrace returns a logger with trace_id field
-func getLoggerWithTrace(logger *zap.Logger, c *fiber.Ctx) *zap.Logger {
-	return logger.With(zap.String("trace_id", getTraceID(c)))
+// requestLogger returns the logger of the request, which tags every entry with its
+// trace ID and authenticated user
+func requestLogger(c *fiber.Ctx) *zap.Logger {
+	return logging.FromContext(c.Context())
 }
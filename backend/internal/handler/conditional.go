@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// resourceVersion identifies the state a response is built from, so that clients
+// can revalidate their copy instead of downloading it again
+type resourceVersion struct {
+	hash         hash.Hash
+	lastModified time.Time
+}
+
+// newResourceVersion starts the version of a response to the request. The API version
+// and the selected fields are part of it, as they shape the same data differently.
+func newResourceVersion(c *fiber.Ctx) *resourceVersion {
+	v := &resourceVersion{hash: sha256.New()}
+	version, _ := c.Locals(APIVersionKey).(string)
+	v.add(version, c.Query("fields"))
+	return v
+}
+
+// add mixes values the response depends on into the version
+func (v *resourceVersion) add(values ...interface{}) {
+	for _, value := range values {
+		if t, ok := value.(time.Time); ok {
+			value = t.UnixNano()
+		}
+		fmt.Fprintf(v.hash, "%v|", value)
+	}
+}
+
+// modifiedAt records when the response last changed, for If-Modified-Since. It is
+// only set for single resources: removing an item from a list does not move the
+// last update of the remaining ones.
+func (v *resourceVersion) modifiedAt(t time.Time) {
+	if t.After(v.lastModified) {
+		v.lastModified = t
+	}
+}
+
+// etag returns the weak entity tag of the version
+func (v *resourceVersion) etag() string {
+	return `W/"` + hex.EncodeToString(v.hash.Sum(nil)[:16]) + `"`
+}
+
+// notModified sets the ETag and Last-Modified headers of a response and reports
+// whether the client's copy, named by If-None-Match or If-Modified-Since, is still
+// current. If-Modified-Since is ignored when If-None-Match is sent.
+func notModified(c *fiber.Ctx, v *resourceVersion) bool {
+	etag := v.etag()
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+	if !v.lastModified.IsZero() {
+		c.Set(fiber.HeaderLastModified, v.lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if noneMatch := c.Get(fiber.HeaderIfNoneMatch); noneMatch != "" {
+		return etagMatches(noneMatch, etag)
+	}
+
+	modifiedSince := c.Get(fiber.HeaderIfModifiedSince)
+	if modifiedSince == "" || v.lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(modifiedSince)
+	if err != nil {
+		return false
+	}
+	// Last-Modified only has a precision of seconds
+	return !v.lastModified.Truncate(time.Second).After(since)
+}
+
+// etagMatches reports whether an If-None-Match header names etag, comparing weakly
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/origins"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// CORSOriginsResponse lists the origins allowed to make cross-origin requests
+type CORSOriginsResponse struct {
+	Origins []string `json:"origins"`
+}
+
+// CORSHandler handles CORS administration HTTP requests
+type CORSHandler struct {
+	registry *origins.Registry
+	logger   *zap.Logger
+}
+
+// NewCORSHandler creates a new CORS handler
+func NewCORSHandler(registry *origins.Registry, logger *zap.Logger) *CORSHandler {
+	return &CORSHandler{
+		registry: registry,
+		logger:   logger,
+	}
+}
+
+// ListOrigins handles listing the allowed origins
+// @Summary List CORS origins
+// @Description List the origins allowed to make cross-origin requests, from CORS_ORIGINS and CORS_ORIGINS_FILE. Admin only.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} CORSOriginsResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/cors/origins [get]
+func (h *CORSHandler) ListOrigins(c *fiber.Ctx) error {
+	return Respond(c, fiber.StatusOK, CORSOriginsResponse{Origins: h.registry.List().Entries()})
+}
+
+// ReloadOrigins handles reloading the allowed origins
+// @Summary Reload CORS origins
+// @Description Read CORS_ORIGINS_FILE again and allow the origins it lists along with CORS_ORIGINS. When the file cannot be read or has an invalid origin, the previous origins stay in effect. Each server instance must be reloaded. Admin only.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} CORSOriginsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/cors/reload [post]
+func (h *CORSHandler) ReloadOrigins(c *fiber.Ctx) error {
+	list, err := h.registry.Reload()
+	if err != nil {
+		h.logger.Warn("Failed to reload CORS origins", zap.Error(err))
+		return domain.ErrInvalidRequestError(err.Error())
+	}
+
+	entries := list.Entries()
+	h.logger.Info("CORS origins reloaded", zap.Strings("origins", entries))
+
+	return Respond(c, fiber.StatusOK, CORSOriginsResponse{Origins: entries})
+}
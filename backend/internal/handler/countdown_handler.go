@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// CountdownHandler handles countdown HTTP requests
+type CountdownHandler struct {
+	countdownService domain.CountdownService
+	validator        *validator.Validate
+	i18n             *i18n.I18n
+	logger           *zap.Logger
+}
+
+// NewCountdownHandler creates a new countdown handler
+func NewCountdownHandler(
+	countdownService domain.CountdownService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *CountdownHandler {
+	return &CountdownHandler{
+		countdownService: countdownService,
+		validator:        validator,
+		i18n:             i18n,
+		logger:           logger,
+	}
+}
+
+// GetCountdowns handles getting everything the couple is counting down to
+// @Summary Get countdowns
+// @Description Get the days until the next anniversary, upcoming events with a reminder and the couple's custom countdowns, for home-screen widgets. Send the ETag back in If-None-Match to get 304 Not Modified while nothing changed.
+// @Tags couple
+// @Produce json
+// @Param If-None-Match header string false "ETag of the last response"
+// @Security BearerAuth
+// @Success 200 {object} domain.CountdownsResponse
+// @Success 304
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /couple/countdowns [get]
+func (h *CountdownHandler) GetCountdowns(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	countdowns, err := h.countdownService.GetCountdowns(c.Context(), userID)
+	if err != nil {
+		LogServiceError(c, err, "Get countdowns", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	// Widgets revalidate with the ETag instead of reusing a stale day count
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+	return Respond(c, fiber.StatusOK, countdowns)
+}
+
+// CreateCountdown handles creating a custom countdown
+// @Summary Create countdown
+// @Description Count down the days to a date the couple is looking forward to
+// @Tags couple
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateCountdownRequest true "Countdown"
+// @Security BearerAuth
+// @Success 201 {object} domain.CountdownResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /couple/countdowns [post]
+func (h *CountdownHandler) CreateCountdown(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.CreateCountdownRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.invalidBody(c)
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return h.validationFailed(c, err)
+	}
+
+	countdown, err := h.countdownService.CreateCountdown(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Create countdown", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, countdown)
+}
+
+// UpdateCountdown handles updating a custom countdown
+// @Summary Update countdown
+// @Description Update a custom countdown
+// @Tags couple
+// @Accept json
+// @Produce json
+// @Param id path string true "Countdown ID"
+// @Param request body domain.UpdateCountdownRequest true "Countdown changes"
+// @Security BearerAuth
+// @Success 200 {object} domain.CountdownResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /couple/countdowns/{id} [put]
+func (h *CountdownHandler) UpdateCountdown(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	countdownID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidCountdownID(c)
+	}
+
+	var req domain.UpdateCountdownRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.invalidBody(c)
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return h.validationFailed(c, err)
+	}
+
+	countdown, err := h.countdownService.UpdateCountdown(c.Context(), countdownID, userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Update countdown",
+			zap.String("user_id", userID.Hex()),
+			zap.String("countdown_id", countdownID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, countdown)
+}
+
+// DeleteCountdown handles deleting a custom countdown
+// @Summary Delete countdown
+// @Description Delete a custom countdown
+// @Tags couple
+// @Produce json
+// @Param id path string true "Countdown ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /couple/countdowns/{id} [delete]
+func (h *CountdownHandler) DeleteCountdown(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	countdownID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidCountdownID(c)
+	}
+
+	if err := h.countdownService.DeleteCountdown(c.Context(), countdownID, userID); err != nil {
+		LogServiceError(c, err, "Delete countdown",
+			zap.String("user_id", userID.Hex()),
+			zap.String("countdown_id", countdownID.Hex()))
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// invalidCountdownID returns the error for a malformed countdown ID
+func (h *CountdownHandler) invalidCountdownID(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Invalid countdown ID")
+}
+
+// invalidBody returns the error for a request body that cannot be parsed
+func (h *CountdownHandler) invalidBody(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Invalid request body")
+}
+
+// validationFailed returns the error listing the invalid fields
+func (h *CountdownHandler) validationFailed(c *fiber.Ctx, err error) error {
+	return domain.ErrValidationFailedError(getValidationErrors(err))
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	badgeLabel = "together"
+	// badgeCharWidth approximates the width in pixels of a character in the badge font
+	badgeCharWidth = 7
+	badgePadding   = 10
+)
+
+// CoupleBadgeHandler handles public relationship badge HTTP requests
+type CoupleBadgeHandler struct {
+	badgeService domain.CoupleBadgeService
+	cacheTTL     time.Duration
+	logger       *zap.Logger
+}
+
+// NewCoupleBadgeHandler creates a new couple badge handler. Public badge responses
+// may be cached by browsers and proxies for cacheTTL.
+func NewCoupleBadgeHandler(badgeService domain.CoupleBadgeService, cacheTTL time.Duration, logger *zap.Logger) *CoupleBadgeHandler {
+	return &CoupleBadgeHandler{
+		badgeService: badgeService,
+		cacheTTL:     cacheTTL,
+		logger:       logger,
+	}
+}
+
+// GetBadge handles getting the couple's public badge
+// @Summary Get public badge
+// @Description Get the URLs of the couple's public days-together badge
+// @Tags couple
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.CoupleBadgeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /couple/badge [get]
+func (h *CoupleBadgeHandler) GetBadge(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	badge, err := h.badgeService.GetBadge(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, h.badgeResponse(c, badge))
+}
+
+// RotateBadge handles enabling the public badge or replacing its URLs
+// @Summary Enable or rotate public badge
+// @Description Publish the couple's days-together counters at public URLs for embedding in blogs. The couple's anniversary date must be set. Calling it again issues new URLs and the previous ones stop working.
+// @Tags couple
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} domain.CoupleBadgeResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /couple/badge [post]
+func (h *CoupleBadgeHandler) RotateBadge(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	badge, err := h.badgeService.RotateBadge(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, h.badgeResponse(c, badge))
+}
+
+// RevokeBadge handles disabling the public badge
+// @Summary Revoke public badge
+// @Description Stop publishing the couple's badge. Copies cached by browsers and proxies may be shown until they expire.
+// @Tags couple
+// @Security BearerAuth
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /couple/badge [delete]
+func (h *CoupleBadgeHandler) RevokeBadge(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	if err := h.badgeService.RevokeBadge(c.Context(), userID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetBadgeJSON handles reading a public badge's counters
+// @Summary Public badge counters
+// @Description Days-together counters of a couple that published a badge. Rate limited per address and cached.
+// @Tags public
+// @Produce json
+// @Param slug path string true "Badge slug"
+// @Success 200 {object} domain.BadgeCounters
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /public/couples/{slug}/badge.json [get]
+func (h *CoupleBadgeHandler) GetBadgeJSON(c *fiber.Ctx) error {
+	counters, err := h.badgeService.GetCounters(c.Context(), c.Params("slug"))
+	if err != nil {
+		return err
+	}
+
+	h.setPublicHeaders(c)
+	return c.JSON(counters)
+}
+
+// GetBadgeSVG handles rendering a public badge
+// @Summary Public badge image
+// @Description SVG badge with the number of days a couple that published a badge has been together. Rate limited per address and cached.
+// @Tags public
+// @Produce image/svg+xml
+// @Param slug path string true "Badge slug"
+// @Success 200 {string} string "SVG image"
+// @Failure 404 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /public/couples/{slug}/badge.svg [get]
+func (h *CoupleBadgeHandler) GetBadgeSVG(c *fiber.Ctx) error {
+	counters, err := h.badgeService.GetCounters(c.Context(), c.Params("slug"))
+	if err != nil {
+		return err
+	}
+
+	h.setPublicHeaders(c)
+	c.Set(fiber.HeaderContentType, "image/svg+xml; charset=utf-8")
+	return c.SendString(badgeSVG(badgeLabel, formatDays(counters.DaysTogether)))
+}
+
+// setPublicHeaders lets any site embed the badge and any cache keep it for the cache TTL
+func (h *CoupleBadgeHandler) setPublicHeaders(c *fiber.Ctx) {
+	c.Set(fiber.HeaderAccessControlAllowOrigin, "*")
+	c.Set(fiber.HeaderCacheControl, "public, max-age="+strconv.Itoa(int(h.cacheTTL.Seconds())))
+}
+
+// badgeResponse builds the badge response with URLs on the host the request came in on
+func (h *CoupleBadgeHandler) badgeResponse(c *fiber.Ctx, badge *domain.CoupleBadge) *domain.CoupleBadgeResponse {
+	base := c.BaseURL() + "/api/v1/public/couples/" + badge.Slug
+
+	return &domain.CoupleBadgeResponse{
+		Slug:      badge.Slug,
+		JSONURL:   base + "/badge.json",
+		SVGURL:    base + "/badge.svg",
+		CreatedBy: badge.CreatedBy.Hex(),
+		CreatedAt: badge.CreatedAt,
+	}
+}
+
+// formatDays renders a day count with thousands separators, such as "1,234 days"
+func formatDays(days int) string {
+	digits := strconv.Itoa(days)
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+
+	if days == 1 {
+		return digits + " day"
+	}
+	return digits + " days"
+}
+
+// badgeSVG renders a two-part badge in the style of common README badges. Both texts
+// are fixed or numeric, so they need no escaping.
+func badgeSVG(label, value string) string {
+	labelWidth := len(label)*badgeCharWidth + badgePadding
+	valueWidth := len(value)*badgeCharWidth + badgePadding
+	width := labelWidth + valueWidth
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[4]s: %[5]s">`+
+		`<title>%[4]s: %[5]s</title>`+
+		`<clipPath id="r"><rect width="%[1]d" height="20" rx="3"/></clipPath>`+
+		`<g clip-path="url(#r)">`+
+		`<rect width="%[2]d" height="20" fill="#555"/>`+
+		`<rect x="%[2]d" width="%[3]d" height="20" fill="#e0457b"/>`+
+		`</g>`+
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`+
+		`<text x="%[6]d" y="14">%[4]s</text>`+
+		`<text x="%[7]d" y="14">%[5]s</text>`+
+		`</g></svg>`,
+		width, labelWidth, valueWidth, label, value, labelWidth/2, labelWidth+valueWidth/2)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// CoupleKeyHandler handles couple data key HTTP requests
+type CoupleKeyHandler struct {
+	coupleKeyService domain.CoupleKeyService
+	i18n             *i18n.I18n
+	logger           *zap.Logger
+}
+
+// NewCoupleKeyHandler creates a new couple key handler
+func NewCoupleKeyHandler(
+	coupleKeyService domain.CoupleKeyService,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *CoupleKeyHandler {
+	return &CoupleKeyHandler{
+		coupleKeyService: coupleKeyService,
+		i18n:             i18n,
+		logger:           logger,
+	}
+}
+
+// GetKey handles describing the couple's data key
+// @Summary Get couple encryption key
+// @Description Describe the versions of the key that encrypts the couple's exports, time capsules and vault entries. The key itself is never returned.
+// @Tags couple
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.CoupleKeyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /couple/encryption-key [get]
+func (h *CoupleKeyHandler) GetKey(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	key, err := h.coupleKeyService.GetKey(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, key)
+}
+
+// RotateKey handles rotating the couple's data key
+// @Summary Rotate couple encryption key
+// @Description Create a new version of the couple's encryption key. New data is encrypted with it; data encrypted before stays readable.
+// @Tags couple
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.CoupleKeyResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /couple/encryption-key/rotate [post]
+func (h *CoupleKeyHandler) RotateKey(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	key, err := h.coupleKeyService.RotateKey(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, key)
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// CoupleSettingsHandler handles couple-wide settings HTTP requests
+type CoupleSettingsHandler struct {
+	settingsService domain.CoupleSettingsService
+	validator       *validator.Validate
+	i18n            *i18n.I18n
+	logger          *zap.Logger
+}
+
+// NewCoupleSettingsHandler creates a new couple settings handler
+func NewCoupleSettingsHandler(
+	settingsService domain.CoupleSettingsService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *CoupleSettingsHandler {
+	return &CoupleSettingsHandler{
+		settingsService: settingsService,
+		validator:       validator,
+		i18n:            i18n,
+		logger:          logger,
+	}
+}
+
+// GetSettings handles getting the couple's settings
+// @Summary Get couple settings
+// @Description Get settings shared by both partners, such as photo watermarking, partner approval of album deletions and conversation exports, theme, anniversary display, reminder defaults and time zone, along with the resulting date formatting and the revision to update them from
+// @Tags couple
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.CoupleSettingsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /couple/settings [get]
+func (h *CoupleSettingsHandler) GetSettings(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	settings, err := h.settingsService.GetSettings(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, settings)
+}
+
+// UpdateSettings handles updating the couple's settings
+// @Summary Update couple settings
+// @Description Update settings shared by both partners, such as photo watermarking, partner approval of album deletions and conversation exports, theme, anniversary display, reminder defaults and time zone, and the locale, date format and first day of week clients use to render calendars. When a revision is sent and the partner has saved changes since, the update is rejected with the current settings as details. The partner is notified of the changes.
+// @Tags couple
+// @Accept json
+// @Produce json
+// @Param request body domain.UpdateCoupleSettingsRequest true "Settings to update"
+// @Security BearerAuth
+// @Success 200 {object} domain.CoupleSettingsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /couple/settings [put]
+func (h *CoupleSettingsHandler) UpdateSettings(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.UpdateCoupleSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	settings, err := h.settingsService.UpdateSettings(c.Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, settings)
+}
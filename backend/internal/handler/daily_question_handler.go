@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// DailyQuestionHandler handles daily question HTTP requests
+type DailyQuestionHandler struct {
+	questionService domain.DailyQuestionService
+	validator       *validator.Validate
+	i18n            *i18n.I18n
+	logger          *zap.Logger
+}
+
+// NewDailyQuestionHandler creates a new daily question handler
+func NewDailyQuestionHandler(
+	questionService domain.DailyQuestionService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *DailyQuestionHandler {
+	return &DailyQuestionHandler{
+		questionService: questionService,
+		validator:       validator,
+		i18n:            i18n,
+		logger:          logger,
+	}
+}
+
+// GetToday handles getting today's question
+// @Summary Get today's question
+// @Description Get the question both partners are asked today, in the couple's time zone, with your answer. Your partner's answer is only included once you have both answered.
+// @Tags questions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.DailyQuestionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /questions/today [get]
+func (h *DailyQuestionHandler) GetToday(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	question, err := h.questionService.GetToday(c.Context(), userID, getLocale(c))
+	if err != nil {
+		LogServiceError(c, err, "Get today's question", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, question)
+}
+
+// AnswerToday handles answering today's question
+// @Summary Answer today's question
+// @Description Answer today's question privately. The answer can be changed until your partner answers too, which reveals both answers to both of you. Your partner is notified.
+// @Tags questions
+// @Accept json
+// @Produce json
+// @Param request body domain.AnswerQuestionRequest true "Answer"
+// @Security BearerAuth
+// @Success 200 {object} domain.DailyQuestionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /questions/today/answer [put]
+func (h *DailyQuestionHandler) AnswerToday(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.AnswerQuestionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	question, err := h.questionService.AnswerToday(c.Context(), userID, getLocale(c), &req)
+	if err != nil {
+		LogServiceError(c, err, "Answer today's question", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, question)
+}
+
+// GetHistory handles listing past questions
+// @Summary Get past questions
+// @Description Get the questions the couple was asked, newest first. Your partner's answers are only included for questions you have both answered.
+// @Tags questions
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Security BearerAuth
+// @Success 200 {object} domain.DailyQuestionListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /questions [get]
+func (h *DailyQuestionHandler) GetHistory(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	questions, err := h.questionService.GetHistory(c.Context(), userID, getLocale(c), page, limit)
+	if err != nil {
+		LogServiceError(c, err, "Get past questions", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, questions)
+}
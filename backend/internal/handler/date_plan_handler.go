@@ -0,0 +1,297 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// DatePlanHandler handles date planning HTTP requests
+type DatePlanHandler struct {
+	planService domain.DatePlanService
+	validator   *validator.Validate
+	i18n        *i18n.I18n
+	logger      *zap.Logger
+}
+
+// NewDatePlanHandler creates a new date plan handler
+func NewDatePlanHandler(
+	planService domain.DatePlanService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *DatePlanHandler {
+	return &DatePlanHandler{
+		planService: planService,
+		validator:   validator,
+		i18n:        i18n,
+		logger:      logger,
+	}
+}
+
+// SuggestIdeas handles suggesting date ideas
+// @Summary Suggest date ideas
+// @Description Get curated date ideas for a location and budget, ideas for the location first and then the cheapest. Ideas without a location can be done anywhere.
+// @Tags date-plans
+// @Produce json
+// @Param location query string false "City or area of the date"
+// @Param max_budget query number false "Maximum cost of the date"
+// @Param category query string false "Category of the ideas"
+// @Param limit query int false "Number of ideas" default(10)
+// @Security BearerAuth
+// @Success 200 {object} domain.DateIdeasResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /date-plans/suggestions [get]
+func (h *DatePlanHandler) SuggestIdeas(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if limit < 1 || limit > 50 {
+		limit = 10
+	}
+
+	filter := domain.DateIdeaFilter{
+		Location: c.Query("location"),
+		Category: c.Query("category"),
+	}
+	if value := c.Query("max_budget"); value != "" {
+		budget, err := strconv.ParseFloat(value, 64)
+		if err != nil || budget < 0 {
+			return domain.ErrInvalidRequestError("Invalid max_budget")
+		}
+		filter.MaxBudget = &budget
+	}
+
+	ideas, err := h.planService.SuggestIdeas(c.Context(), userID, filter, limit)
+	if err != nil {
+		LogServiceError(c, err, "Suggest date ideas", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, ideas)
+}
+
+// CreatePlan handles planning a date
+// @Summary Plan a date
+// @Description Plan a date night, from scratch or from a suggested idea. Without a status it is planned when a date is scheduled and an idea otherwise.
+// @Tags date-plans
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateDatePlanRequest true "Date plan"
+// @Security BearerAuth
+// @Success 201 {object} domain.DatePlanResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /date-plans [post]
+func (h *DatePlanHandler) CreatePlan(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.CreateDatePlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.invalidBody(c)
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return h.validationFailed(c, err)
+	}
+
+	plan, err := h.planService.CreatePlan(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Create date plan", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, plan)
+}
+
+// GetPlans handles listing the couple's date plans
+// @Summary Get date plans
+// @Description Get the couple's date plans, latest scheduled first and unscheduled ideas last
+// @Tags date-plans
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Param status query string false "Filter by status (idea, planned, done, cancelled)"
+// @Security BearerAuth
+// @Success 200 {object} domain.DatePlanListResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /date-plans [get]
+func (h *DatePlanHandler) GetPlans(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	status := domain.DatePlanStatus(c.Query("status"))
+
+	plans, err := h.planService.GetPlans(c.Context(), userID, status, page, limit)
+	if err != nil {
+		LogServiceError(c, err, "Get date plans", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, plans)
+}
+
+// GetPlan handles getting a date plan
+// @Summary Get date plan by ID
+// @Description Get one of the couple's date plans
+// @Tags date-plans
+// @Produce json
+// @Param id path string true "Date plan ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.DatePlanResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /date-plans/{id} [get]
+func (h *DatePlanHandler) GetPlan(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	planID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidPlanID(c)
+	}
+
+	plan, err := h.planService.GetPlan(c.Context(), planID, userID)
+	if err != nil {
+		LogServiceError(c, err, "Get date plan",
+			zap.String("user_id", userID.Hex()),
+			zap.String("plan_id", planID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, plan)
+}
+
+// UpdatePlan handles updating a date plan
+// @Summary Update date plan
+// @Description Update one of the couple's date plans. An empty description, location or time clears them. The event the plan was added to the calendar as is not changed.
+// @Tags date-plans
+// @Accept json
+// @Produce json
+// @Param id path string true "Date plan ID"
+// @Param request body domain.UpdateDatePlanRequest true "Changes"
+// @Security BearerAuth
+// @Success 200 {object} domain.DatePlanResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /date-plans/{id} [put]
+func (h *DatePlanHandler) UpdatePlan(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	planID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidPlanID(c)
+	}
+
+	var req domain.UpdateDatePlanRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.invalidBody(c)
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return h.validationFailed(c, err)
+	}
+
+	plan, err := h.planService.UpdatePlan(c.Context(), planID, userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Update date plan",
+			zap.String("user_id", userID.Hex()),
+			zap.String("plan_id", planID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, plan)
+}
+
+// DeletePlan handles deleting a date plan
+// @Summary Delete date plan
+// @Description Delete one of the couple's date plans. The event it was added to the calendar as is kept.
+// @Tags date-plans
+// @Produce json
+// @Param id path string true "Date plan ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Router /date-plans/{id} [delete]
+func (h *DatePlanHandler) DeletePlan(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	planID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidPlanID(c)
+	}
+
+	if err := h.planService.DeletePlan(c.Context(), planID, userID); err != nil {
+		LogServiceError(c, err, "Delete date plan",
+			zap.String("user_id", userID.Hex()),
+			zap.String("plan_id", planID.Hex()))
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ConvertToEvent handles adding a date plan to the calendar
+// @Summary Add date plan to calendar
+// @Description Create a date event on the couple's calendar from a scheduled date plan and link it to the plan. The body is optional.
+// @Tags date-plans
+// @Accept json
+// @Produce json
+// @Param id path string true "Date plan ID"
+// @Param request body domain.ConvertDatePlanRequest false "Event options"
+// @Security BearerAuth
+// @Success 201 {object} domain.ConvertDatePlanResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /date-plans/{id}/event [post]
+func (h *DatePlanHandler) ConvertToEvent(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	planID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidPlanID(c)
+	}
+
+	var req domain.ConvertDatePlanRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return h.invalidBody(c)
+		}
+
+		if err := h.validator.Struct(&req); err != nil {
+			return h.validationFailed(c, err)
+		}
+	}
+
+	result, err := h.planService.ConvertToEvent(c.Context(), planID, userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Convert date plan to event",
+			zap.String("user_id", userID.Hex()),
+			zap.String("plan_id", planID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, result)
+}
+
+// invalidPlanID returns the error for a malformed date plan ID
+func (h *DatePlanHandler) invalidPlanID(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Invalid date plan ID")
+}
+
+// invalidBody returns the error for a request body that cannot be parsed
+func (h *DatePlanHandler) invalidBody(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Invalid request body")
+}
+
+// validationFailed returns the error listing the invalid fields
+func (h *DatePlanHandler) validationFailed(c *fiber.Ctx, err error) error {
+	return domain.ErrValidationFailedError(getValidationErrors(err))
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// EngagementHandler handles couple engagement statistics HTTP requests
+type EngagementHandler struct {
+	engagementService domain.EngagementService
+	logger            *zap.Logger
+}
+
+// NewEngagementHandler creates a new engagement handler
+func NewEngagementHandler(engagementService domain.EngagementService, logger *zap.Logger) *EngagementHandler {
+	return &EngagementHandler{
+		engagementService: engagementService,
+		logger:            logger,
+	}
+}
+
+// GetStats handles getting the couple's engagement statistics
+// @Summary Get couple stats
+// @Description Get the couple's current and longest streaks of days on which either partner sent a message, added a photo or checked in their mood. Days follow the couple's time zone and are counted once they end; today counts as soon as the couple has interacted, and a streak that lasted until yesterday stays current until today ends.
+// @Tags couple
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.CoupleStatsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /couple/stats [get]
+func (h *EngagementHandler) GetStats(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	stats, err := h.engagementService.GetStats(c.Context(), userID)
+	if err != nil {
+		LogServiceError(c, err, "Get couple stats", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, stats)
+}
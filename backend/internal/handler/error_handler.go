@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// errorMessageKeys maps error codes to the i18n key of their user-facing message.
+// Codes without a key fall back to the English message of the AppError.
+var errorMessageKeys = map[domain.ErrorCode]string{
+	domain.ErrCodeInvalidRequest:           "invalid_request",
+	domain.ErrCodeValidationFailed:         "validation_failed",
+	domain.ErrCodeRequiredField:            "required_field",
+	domain.ErrCodeWeakPassword:             "weak_password",
+	domain.ErrCodePasswordMismatch:         "password_mismatch",
+	domain.ErrCodeInvalidEmail:             "invalid_email",
+	domain.ErrCodeNotMatched:               "not_matched",
+	domain.ErrCodeAlreadyMatched:           "already_matched",
+	domain.ErrCodeUnauthorized:             "unauthorized",
+	domain.ErrCodeInvalidCredentials:       "invalid_credentials",
+	domain.ErrCodeInvalidToken:             "invalid_token",
+	domain.ErrCodeTokenExpired:             "token_expired",
+	domain.ErrCodeInvalidVerificationToken: "invalid_verification_token",
+	domain.ErrCodeInvalidResetToken:        "invalid_reset_token",
+	domain.ErrCodePasswordRequired:         "password_required",
+	domain.ErrCodeInvalidSharePassword:     "invalid_share_password",
+	domain.ErrCodeForbidden:                "forbidden",
+	domain.ErrCodeEmailNotVerified:         "email_not_verified",
+	domain.ErrCodeEditWindowPassed:         "edit_window_passed",
+	domain.ErrCodeNotFound:                 "not_found",
+	domain.ErrCodeUserNotFound:             "user_not_found",
+	domain.ErrCodeUserAlreadyExists:        "user_already_exists",
+	domain.ErrCodeEmailAlreadyVerified:     "email_already_verified",
+	domain.ErrCodeShareLinkExpired:         "share_link_expired",
+	domain.ErrCodePendingActionExpired:     "pending_action_expired",
+	domain.ErrCodeMatchInviteExpired:       "match_invite_expired",
+	domain.ErrCodeBodyTooLarge:             "body_too_large",
+	domain.ErrCodeIdempotencyKeyReused:     "idempotency_key_reused",
+	domain.ErrCodeTooManyRequests:          "too_many_requests",
+	domain.ErrCodeRateLimitExceeded:        "rate_limit_exceeded",
+	domain.ErrCodeInternalError:            "internal_error",
+	domain.ErrCodeOperationFailed:          "operation_failed",
+	domain.ErrCodeServiceUnavailable:       "service_unavailable",
+}
+
+// ErrorHandler is the central Fiber error handler. Handlers return service errors as
+// they are and the error handler turns them into an ErrorResponse with the AppError
+// code and status, a message translated to the request language and the trace ID.
+// Requests that failed with a server error are also recorded by trace ID so that
+// errors reported by the clients can be matched with them.
+type ErrorHandler struct {
+	i18n      *i18n.I18n
+	traceRepo domain.RequestTraceRepository
+	logger    *zap.Logger
+	traces    chan *domain.RequestTrace
+}
+
+// traceQueueSize is the number of traces that can wait to be recorded. Traces of
+// failures beyond that are dropped rather than piling up inserts during an outage.
+const traceQueueSize = 256
+
+// NewErrorHandler creates a new error handler and starts its trace recorder
+func NewErrorHandler(i18n *i18n.I18n, traceRepo domain.RequestTraceRepository, logger *zap.Logger) *ErrorHandler {
+	h := &ErrorHandler{
+		i18n:      i18n,
+		traceRepo: traceRepo,
+		logger:    logger,
+		traces:    make(chan *domain.RequestTrace, traceQueueSize),
+	}
+	go h.recordTraces()
+	return h
+}
+
+// Handle writes the error response for an error returned by a route handler
+func (h *ErrorHandler) Handle(c *fiber.Ctx, err error) error {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return c.Status(fiberErr.Code).JSON(ErrorResponse{
+			Code:    fiberErr.Code,
+			Error:   fiberErr.Message,
+			Message: fiberErr.Message,
+			TraceID: getTraceID(c),
+		})
+	}
+
+	appErr := toAppError(err)
+	status := appErr.StatusCode
+	if status == 0 {
+		status = fiber.StatusInternalServerError
+	}
+
+	fields := []zap.Field{
+		zap.String("trace_id", getTraceID(c)),
+		zap.String("method", c.Method()),
+		zap.String("path", c.Path()),
+		zap.Int("code", int(appErr.Code)),
+		zap.Error(err),
+	}
+	userID := getUserIDFromContext(c)
+	if !userID.IsZero() {
+		fields = append(fields, zap.String("user_id", userID.Hex()))
+	}
+
+	if status >= fiber.StatusInternalServerError {
+		h.logger.Error("Request failed", fields...)
+
+		trace := &domain.RequestTrace{
+			TraceID:   getTraceID(c),
+			Method:    c.Method(),
+			Path:      c.Path(),
+			Status:    status,
+			Code:      appErr.Code,
+			CreatedAt: time.Now(),
+		}
+		if !userID.IsZero() {
+			trace.UserID = &userID
+		}
+		h.queueTrace(trace)
+	} else {
+		h.logger.Warn("Request failed", fields...)
+	}
+
+	return c.Status(status).JSON(ErrorResponse{
+		Code:    int(appErr.Code),
+		Error:   appErr.Message,
+		Message: translateError(h.i18n, getLocale(c), appErr),
+		TraceID: getTraceID(c),
+		Details: appErr.Details,
+	})
+}
+
+// queueTrace hands a failed request to the trace recorder without holding up the
+// response, dropping it when the queue is full
+func (h *ErrorHandler) queueTrace(trace *domain.RequestTrace) {
+	select {
+	case h.traces <- trace:
+	default:
+		h.logger.Warn("Request trace queue is full, dropping trace",
+			zap.String("trace_id", trace.TraceID))
+	}
+}
+
+// recordTraces stores the queued traces one at a time
+func (h *ErrorHandler) recordTraces() {
+	for trace := range h.traces {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := h.traceRepo.Record(ctx, trace); err != nil {
+			h.logger.Warn("Failed to record request trace",
+				zap.String("trace_id", trace.TraceID),
+				zap.Error(err))
+		}
+		cancel()
+	}
+}
+
+// translateError returns the message of the error in lang
+func translateError(i18n *i18n.I18n, lang string, appErr *domain.AppError) string {
+	key, ok := errorMessageKeys[appErr.Code]
+	if !ok {
+		return appErr.Message
+	}
+
+	message := i18n.Translate(lang, key, nil)
+	if message == key {
+		return appErr.Message
+	}
+	return message
+}
+
+// toAppError returns the AppError wrapped in err. Any other error becomes an
+// internal error so its text is never exposed to clients.
+func toAppError(err error) *domain.AppError {
+	var appErr *domain.AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	return domain.ErrInternalServerError()
+}
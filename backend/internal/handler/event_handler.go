@@ -13,34 +13,38 @@ import (
 
 // EventHandler handles event-related HTTP requests
 type EventHandler struct {
-	eventService domain.EventService
-	validator    *validator.Validate
-	i18n         *i18n.I18n
-	logger       *zap.Logger
+	eventService    domain.EventService
+	settingsService domain.CoupleSettingsService
+	validator       *validator.Validate
+	i18n            *i18n.I18n
+	logger          *zap.Logger
 }
 
 // NewEventHandler creates a new event handler
 func NewEventHandler(
 	eventService domain.EventService,
+	settingsService domain.CoupleSettingsService,
 	validator *validator.Validate,
 	i18n *i18n.I18n,
 	logger *zap.Logger,
 ) *EventHandler {
 	return &EventHandler{
-		eventService: eventService,
-		validator:    validator,
-		i18n:         i18n,
-		logger:       logger,
+		eventService:    eventService,
+		settingsService: settingsService,
+		validator:       validator,
+		i18n:            i18n,
+		logger:          logger,
 	}
 }
 
 // CreateEvent handles event creation
 // @Summary Create a new event
-// @Description Create a new event/milestone
+// @Description Create a new event/milestone. Its date and time are in its time zone, which defaults to the creator's and then the couple's. Events created without a reminder get the couple's default one.
 // @Tags events
 // @Accept json
 // @Produce json
 // @Param request body domain.CreateEventRequest true "Event creation data"
+// @Param Idempotency-Key header string false "Unique key of the request, the same on retries, whose first response is replayed to them"
 // @Security BearerAuth
 // @Success 201 {object} domain.EventResponse
 // @Failure 400 {object} ErrorResponse
@@ -64,39 +68,27 @@ func (h *EventHandler) CreateEvent(c *fiber.Ctx) error {
 		h.logger.Error("Failed to parse event request body",
 			zap.String("trace_id", getTraceID(c)),
 			zap.Error(err))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: err.Error(),
-		})
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 	
 	h.logger.Info("Event request parsed",
 		zap.String("trace_id", getTraceID(c)),
 		zap.String("title", req.Title),
 		zap.String("event_type", req.EventType),
-		zap.Time("date", req.Date))
+		zap.String("date", req.Date.String()))
 
 	if err := h.validator.Struct(req); err != nil {
 		h.logger.Error("Event validation failed",
 			zap.String("trace_id", getTraceID(c)),
 			zap.Error(err),
 			zap.Any("request", req))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: err.Error(),
-		})
+		return domain.ErrValidationFailedError(getValidationErrors(err))
 	}
 
 	event, err := h.eventService.CreateEvent(c.Context(), userID, &req)
 	if err != nil {
-		h.logger.Error("Failed to create event",
-			zap.String("trace_id", getTraceID(c)),
-			zap.String("user_id", userID.Hex()),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to create event",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Create event", zap.String("user_id", userID.Hex()))
+		return err
 	}
 	
 	h.logger.Info("Event created successfully",
@@ -104,12 +96,12 @@ func (h *EventHandler) CreateEvent(c *fiber.Ctx) error {
 		zap.String("event_id", event.ID),
 		zap.String("title", event.Title))
 
-	return c.Status(fiber.StatusCreated).JSON(event)
+	return Respond(c, fiber.StatusCreated, event)
 }
 
 // GetEvents handles getting user events
 // @Summary Get user events
-// @Description Get events for the authenticated user
+// @Description Get events for the authenticated user, with the couple's date formatting preferences for rendering them. Send the ETag back in If-None-Match to get 304 Not Modified while nothing changed.
 // @Tags events
 // @Produce json
 // @Param page query int false "Page number" default(1)
@@ -117,8 +109,11 @@ func (h *EventHandler) CreateEvent(c *fiber.Ctx) error {
 // @Param partner_id query string false "Partner ID to filter shared events"
 // @Param year query int false "Filter by year"
 // @Param month query int false "Filter by month"
+// @Param If-None-Match header string false "ETag of the last response"
+// @Param fields query string false "Comma-separated fields to keep in each item of the list; id is always kept"
 // @Security BearerAuth
 // @Success 200 {object} domain.EventListResponse
+// @Success 304
 // @Failure 401 {object} ErrorResponse
 // @Router /events [get]
 func (h *EventHandler) GetEvents(c *fiber.Ctx) error {
@@ -140,14 +135,8 @@ func (h *EventHandler) GetEvents(c *fiber.Ctx) error {
 
 	events, total, err := h.eventService.GetCoupleEvents(c.Context(), userID, year, month, page, limit)
 	if err != nil {
-		h.logger.Error("Failed to get events",
-			zap.String("trace_id", getTraceID(c)),
-			zap.String("user_id", userID.Hex()),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to get events",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Get events", zap.String("user_id", userID.Hex()))
+		return err
 	}
 	
 	h.logger.Info("Events retrieved successfully",
@@ -155,12 +144,24 @@ func (h *EventHandler) GetEvents(c *fiber.Ctx) error {
 		zap.Int64("total", total),
 		zap.Int("count", len(events)))
 
-	return c.JSON(domain.EventListResponse{
-		Events: events,
-		Total:  total,
-		Page:   page,
-		Limit:  limit,
-	})
+	formatting := h.settingsService.FormattingHints(c.Context(), userID)
+
+	version := newResourceVersion(c)
+	version.add(total, page, limit, *formatting)
+	for _, event := range events {
+		version.add(event.ID, event.UpdatedAt)
+	}
+	if notModified(c, version) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	return RespondPage(c, domain.EventListResponse{
+		Events:     events,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		Formatting: formatting,
+	}, PageMeta(total, page, limit))
 }
 
 // GetEvent handles getting a specific event
@@ -178,24 +179,16 @@ func (h *EventHandler) GetEvent(c *fiber.Ctx) error {
 	userID := getUserIDFromContext(c)
 	eventID, err := primitive.ObjectIDFromHex(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid event ID",
-			Message: "Event ID must be a valid ObjectID",
-		})
+		return domain.ErrInvalidRequestError("Event ID must be a valid ObjectID")
 	}
 
 	event, err := h.eventService.GetEvent(c.Context(), eventID, userID)
 	if err != nil {
-		h.logger.Error("Failed to get event",
-			zap.String("trace_id", getTraceID(c)),
-			zap.Error(err))
-		return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{
-			Error:   "Event not found",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Get event")
+		return err
 	}
 
-	return c.JSON(event)
+	return Respond(c, fiber.StatusOK, event)
 }
 
 // UpdateEvent handles event updates
@@ -219,10 +212,7 @@ func (h *EventHandler) UpdateEvent(c *fiber.Ctx) error {
 			zap.String("trace_id", getTraceID(c)),
 			zap.String("id", c.Params("id")),
 			zap.Error(err))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid event ID",
-			Message: "Event ID must be a valid ObjectID",
-		})
+		return domain.ErrInvalidRequestError("Event ID must be a valid ObjectID")
 	}
 	
 	h.logger.Info("Updating event",
@@ -235,39 +225,27 @@ func (h *EventHandler) UpdateEvent(c *fiber.Ctx) error {
 		h.logger.Error("Failed to parse update request",
 			zap.String("trace_id", getTraceID(c)),
 			zap.Error(err))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: err.Error(),
-		})
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
 	if err := h.validator.Struct(req); err != nil {
 		h.logger.Error("Update validation failed",
 			zap.String("trace_id", getTraceID(c)),
 			zap.Error(err))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: err.Error(),
-		})
+		return domain.ErrValidationFailedError(getValidationErrors(err))
 	}
 
 	event, err := h.eventService.UpdateEvent(c.Context(), eventID, userID, &req)
 	if err != nil {
-		h.logger.Error("Failed to update event",
-			zap.String("trace_id", getTraceID(c)),
-			zap.String("event_id", eventID.Hex()),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to update event",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Update event", zap.String("event_id", eventID.Hex()))
+		return err
 	}
 	
 	h.logger.Info("Event updated successfully",
 		zap.String("trace_id", getTraceID(c)),
 		zap.String("event_id", eventID.Hex()))
 
-	return c.JSON(event)
+	return Respond(c, fiber.StatusOK, event)
 }
 
 // DeleteEvent handles event deletion
@@ -289,10 +267,7 @@ func (h *EventHandler) DeleteEvent(c *fiber.Ctx) error {
 			zap.String("trace_id", getTraceID(c)),
 			zap.String("id", c.Params("id")),
 			zap.Error(err))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid event ID",
-			Message: "Event ID must be a valid ObjectID",
-		})
+		return domain.ErrInvalidRequestError("Event ID must be a valid ObjectID")
 	}
 	
 	h.logger.Info("Deleting event",
@@ -302,14 +277,8 @@ func (h *EventHandler) DeleteEvent(c *fiber.Ctx) error {
 
 	err = h.eventService.DeleteEvent(c.Context(), eventID, userID)
 	if err != nil {
-		h.logger.Error("Failed to delete event",
-			zap.String("trace_id", getTraceID(c)),
-			zap.String("event_id", eventID.Hex()),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to delete event",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Delete event", zap.String("event_id", eventID.Hex()))
+		return err
 	}
 	
 	h.logger.Info("Event deleted successfully",
@@ -318,3 +287,35 @@ func (h *EventHandler) DeleteEvent(c *fiber.Ctx) error {
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// BulkDeleteEvents handles bulk event deletion
+// @Summary Bulk event deletion
+// @Description Move several events to the trash at once. Each requested ID is reported as succeeded, not_found or invalid_id.
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param request body domain.BulkIDsRequest true "Bulk request"
+// @Security BearerAuth
+// @Success 200 {object} domain.BulkResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /events/bulk-delete [post]
+func (h *EventHandler) BulkDeleteEvents(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.BulkIDsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	result, err := h.eventService.BulkDeleteEvents(c.Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, result)
+}
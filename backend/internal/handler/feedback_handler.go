@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// FeedbackHandler handles user feedback HTTP requests
+type FeedbackHandler struct {
+	feedbackService domain.FeedbackService
+	validator       *validator.Validate
+	i18n            *i18n.I18n
+	logger          *zap.Logger
+}
+
+// NewFeedbackHandler creates a new feedback handler
+func NewFeedbackHandler(
+	feedbackService domain.FeedbackService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *FeedbackHandler {
+	return &FeedbackHandler{
+		feedbackService: feedbackService,
+		validator:       validator,
+		i18n:            i18n,
+		logger:          logger,
+	}
+}
+
+// SubmitFeedback handles sending feedback or an NPS answer
+// @Summary Send feedback
+// @Description Send feedback about the app. NPS answers (category nps) need a rating from 0 to 10, every other category needs text and may have a rating from 1 to 5. Users can only send a few pieces of feedback per hour.
+// @Tags feedback
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateFeedbackRequest true "Feedback"
+// @Security BearerAuth
+// @Success 201 {object} domain.FeedbackResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /feedback [post]
+func (h *FeedbackHandler) SubmitFeedback(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.CreateFeedbackRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	feedback, err := h.feedbackService.SubmitFeedback(c.Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, feedback)
+}
+
+// ListFeedback handles listing feedback for the team
+// @Summary List feedback
+// @Description List user feedback newest first. Admins and moderators only. Pass next_cursor back as cursor to get the next page.
+// @Tags admin
+// @Produce json
+// @Param status query string false "Filter by status (new, triaged, in_progress, resolved, closed)"
+// @Param category query string false "Filter by category (bug, feature, general, nps)"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Items per page" default(20)
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} domain.FeedbackListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/feedback [get]
+func (h *FeedbackHandler) ListFeedback(c *fiber.Ctx) error {
+	cursor, err := domain.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Cursor must be a next_cursor value returned by a previous request")
+	}
+
+	filter := domain.FeedbackFilter{
+		Status:   domain.FeedbackStatus(c.Query("status")),
+		Category: domain.FeedbackCategory(c.Query("category")),
+	}
+	limit := domain.NormalizeCursorLimit(c.QueryInt("limit", domain.DefaultCursorLimit))
+
+	feedback, err := h.feedbackService.ListFeedback(c.Context(), filter, cursor, limit)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, feedback)
+}
+
+// UpdateFeedbackStatus handles moving feedback along the triage workflow
+// @Summary Update feedback status
+// @Description Move feedback to another status. Admins and moderators only. New feedback can be triaged or closed, triaged feedback can be started, resolved or closed, and resolved or closed feedback can be reopened as triaged.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Feedback ID"
+// @Param request body domain.UpdateFeedbackStatusRequest true "New status"
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} domain.FeedbackResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/feedback/{id}/status [put]
+func (h *FeedbackHandler) UpdateFeedbackStatus(c *fiber.Ctx) error {
+	feedbackID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Invalid feedback ID")
+	}
+
+	var req domain.UpdateFeedbackStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	feedback, err := h.feedbackService.UpdateFeedbackStatus(c.Context(), feedbackID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, feedback)
+}
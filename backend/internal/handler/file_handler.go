@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// FileHandler handles downloads of stored files
+type FileHandler struct {
+	fileService domain.FileService
+	// immutableMaxAge is how long files whose content never changes under their URL
+	// are cached for
+	immutableMaxAge time.Duration
+	logger          *zap.Logger
+}
+
+// NewFileHandler creates a new file handler
+func NewFileHandler(fileService domain.FileService, immutableMaxAge time.Duration, logger *zap.Logger) *FileHandler {
+	return &FileHandler{
+		fileService:     fileService,
+		immutableMaxAge: immutableMaxAge,
+		logger:          logger,
+	}
+}
+
+// GetFile handles downloading a stored file
+// @Summary Download file
+// @Description Stream a stored file. Photos and their variants are readable by the couple they belong to, other files by their uploader and the uploader's partner. A single byte range can be requested with the Range header. Image variants requested with a version are cached as immutable.
+// @Tags files
+// @Produce octet-stream
+// @Param key path string true "Storage key, e.g. photos/{user_id}/{file}"
+// @Param v query string false "Version of the file, as set in photo URLs"
+// @Param Range header string false "Byte range, e.g. bytes=0-1023"
+// @Security BearerAuth
+// @Success 200 {file} binary
+// @Success 206 {file} binary
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 416 {object} ErrorResponse
+// @Router /files/{key} [get]
+func (h *FileHandler) GetFile(c *fiber.Ctx) error {
+	key := c.Params("*")
+
+	// A versioned URL of a derived image changes whenever the image does
+	cacheControl := "private, max-age=3600"
+	if c.Query("v") != "" && domain.IsImageVariantKey(key) {
+		cacheControl = fmt.Sprintf("private, max-age=%d, immutable", int(h.immutableMaxAge.Seconds()))
+	}
+
+	return h.sendFile(c, getUserIDFromContext(c), key, cacheControl)
+}
+
+// GetAvatar handles downloading a profile picture, which needs no authentication so
+// that it can be shown in <img> tags. Every uploaded avatar gets new keys, so avatars
+// are cached as immutable.
+// @Summary Download avatar
+// @Description Stream a profile picture. A single byte range can be requested with the Range header.
+// @Tags files
+// @Produce octet-stream
+// @Param key path string true "Avatar key below avatars/"
+// @Success 200 {file} binary
+// @Success 206 {file} binary
+// @Failure 404 {object} ErrorResponse
+// @Router /files/avatars/{key} [get]
+func (h *FileHandler) GetAvatar(c *fiber.Ctx) error {
+	cacheControl := fmt.Sprintf("public, max-age=%d, immutable", int(h.immutableMaxAge.Seconds()))
+	return h.sendFile(c, primitive.NilObjectID, "avatars/"+c.Params("*"), cacheControl)
+}
+
+// sendFile streams the file stored under key to the user, or the byte range the
+// request asks for
+func (h *FileHandler) sendFile(c *fiber.Ctx, userID primitive.ObjectID, key, cacheControl string) error {
+	file, err := h.fileService.OpenFile(c.Context(), userID, key)
+	if err != nil {
+		return err
+	}
+
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(key))
+	}
+	if contentType == "" {
+		contentType = fiber.MIMEOctetStream
+	}
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	c.Set(fiber.HeaderCacheControl, cacheControl)
+	if !file.ModifiedAt.IsZero() {
+		c.Set(fiber.HeaderLastModified, file.ModifiedAt.UTC().Format(http.TimeFormat))
+	}
+
+	byteRange := c.Get(fiber.HeaderRange)
+	if byteRange == "" {
+		// The stream is closed once it has been sent
+		return c.Status(fiber.StatusOK).SendStream(file.Content, int(file.Size))
+	}
+
+	start, end, err := fasthttp.ParseByteRange([]byte(byteRange), int(file.Size))
+	if err != nil {
+		file.Content.Close()
+		c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", file.Size))
+		return domain.NewAppError(domain.ErrCodeInvalidRequest, "The requested range is not satisfiable", fiber.StatusRequestedRangeNotSatisfiable)
+	}
+
+	if err := skipTo(file.Content, int64(start)); err != nil {
+		file.Content.Close()
+		requestLogger(c).Error("Failed to seek file", zap.Error(err), zap.String("key", key))
+		return domain.ErrOperationFailedError("Failed to get file")
+	}
+
+	length := end - start + 1
+	c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", start, end, file.Size))
+	return c.Status(fiber.StatusPartialContent).SendStream(&rangeReader{
+		Reader: io.LimitReader(file.Content, int64(length)),
+		Closer: file.Content,
+	}, length)
+}
+
+// rangeReader reads a range of a file and closes the whole file
+type rangeReader struct {
+	io.Reader
+	io.Closer
+}
+
+// skipTo moves a file to offset, seeking when the storage supports it
+func skipTo(content io.Reader, offset int64) error {
+	if offset == 0 {
+		return nil
+	}
+	if seeker, ok := content.(io.Seeker); ok {
+		_, err := seeker.Seek(offset, io.SeekStart)
+		return err
+	}
+	_, err := io.CopyN(io.Discard, content, offset)
+	return err
+}
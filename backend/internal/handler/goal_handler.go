@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// GoalHandler handles couple goal HTTP requests
+type GoalHandler struct {
+	goalService domain.GoalService
+	validator   *validator.Validate
+	i18n        *i18n.I18n
+	logger      *zap.Logger
+}
+
+// NewGoalHandler creates a new goal handler
+func NewGoalHandler(
+	goalService domain.GoalService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *GoalHandler {
+	return &GoalHandler{
+		goalService: goalService,
+		validator:   validator,
+		i18n:        i18n,
+		logger:      logger,
+	}
+}
+
+// CreateGoal handles goal creation
+// @Summary Create a new goal
+// @Description Create a shared couple goal
+// @Tags goals
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateGoalRequest true "Goal creation data"
+// @Security BearerAuth
+// @Success 201 {object} domain.GoalResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /goals [post]
+func (h *GoalHandler) CreateGoal(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.CreateGoalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	goal, err := h.goalService.CreateGoal(c.Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, goal)
+}
+
+// GetGoals handles getting couple goals
+// @Summary Get couple goals
+// @Description Get goals for the authenticated user's couple
+// @Tags goals
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param status query string false "Filter by status (active, completed, archived)"
+// @Param fields query string false "Comma-separated fields to keep in each item of the list; id is always kept"
+// @Security BearerAuth
+// @Success 200 {object} domain.GoalListResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /goals [get]
+func (h *GoalHandler) GetGoals(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	status := domain.GoalStatus(c.Query("status"))
+
+	goals, total, err := h.goalService.GetCoupleGoals(c.Context(), userID, status, page, limit)
+	if err != nil {
+		LogServiceError(c, err, "Get goals", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return RespondPage(c, domain.GoalListResponse{
+		Goals: goals,
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}, PageMeta(total, page, limit))
+}
+
+// GetGoalSummary handles getting the goals dashboard summary
+// @Summary Get goals summary
+// @Description Get a dashboard summary of the couple's active goals
+// @Tags goals
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.GoalSummaryResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /goals/summary [get]
+func (h *GoalHandler) GetGoalSummary(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	summary, err := h.goalService.GetSummary(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, summary)
+}
+
+// GetGoal handles getting a specific goal
+// @Summary Get goal by ID
+// @Description Get a specific goal with its check-ins
+// @Tags goals
+// @Produce json
+// @Param id path string true "Goal ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.GoalResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /goals/{id} [get]
+func (h *GoalHandler) GetGoal(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	goalID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Goal ID must be a valid ObjectID")
+	}
+
+	goal, err := h.goalService.GetGoal(c.Context(), goalID, userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, goal)
+}
+
+// UpdateGoal handles goal updates
+// @Summary Update goal
+// @Description Update goal information or status
+// @Tags goals
+// @Accept json
+// @Produce json
+// @Param id path string true "Goal ID"
+// @Param request body domain.UpdateGoalRequest true "Goal update data"
+// @Security BearerAuth
+// @Success 200 {object} domain.GoalResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /goals/{id} [put]
+func (h *GoalHandler) UpdateGoal(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	goalID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Goal ID must be a valid ObjectID")
+	}
+
+	var req domain.UpdateGoalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	goal, err := h.goalService.UpdateGoal(c.Context(), goalID, userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, goal)
+}
+
+// DeleteGoal handles goal deletion
+// @Summary Delete goal
+// @Description Delete a goal
+// @Tags goals
+// @Produce json
+// @Param id path string true "Goal ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /goals/{id} [delete]
+func (h *GoalHandler) DeleteGoal(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	goalID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Goal ID must be a valid ObjectID")
+	}
+
+	if err := h.goalService.DeleteGoal(c.Context(), goalID, userID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// CheckInGoal handles recording progress on a goal
+// @Summary Check in on goal
+// @Description Record a progress update with an optional note
+// @Tags goals
+// @Accept json
+// @Produce json
+// @Param id path string true "Goal ID"
+// @Param request body domain.GoalCheckInRequest true "Check-in data"
+// @Security BearerAuth
+// @Success 200 {object} domain.GoalResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /goals/{id}/check-ins [post]
+func (h *GoalHandler) CheckInGoal(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	goalID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Goal ID must be a valid ObjectID")
+	}
+
+	var req domain.GoalCheckInRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	goal, err := h.goalService.CheckIn(c.Context(), goalID, userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, goal)
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// InsightHandler handles fun insights HTTP requests
+type InsightHandler struct {
+	insightService domain.InsightService
+	i18n           *i18n.I18n
+	logger         *zap.Logger
+}
+
+// NewInsightHandler creates a new insight handler
+func NewInsightHandler(
+	insightService domain.InsightService,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *InsightHandler {
+	return &InsightHandler{
+		insightService: insightService,
+		i18n:           i18n,
+		logger:         logger,
+	}
+}
+
+// GetFunInsights handles getting zodiac, numerology and milestone insights
+// @Summary Get fun insights
+// @Description Get zodiac compatibility, numerology and number-of-days milestones for the couple
+// @Tags insights
+// @Produce json
+// @Param Accept-Language header string false "Preferred language for insight copy" default(en)
+// @Security BearerAuth
+// @Success 200 {object} domain.FunInsightsResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /insights [get]
+func (h *InsightHandler) GetFunInsights(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	lang := getLocale(c)
+
+	insights, err := h.insightService.GetFunInsights(c.Context(), userID, lang)
+	if err != nil {
+		LogServiceError(c, err, "Get fun insights", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, insights)
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// JournalHandler handles couple journal HTTP requests
+type JournalHandler struct {
+	journalService domain.JournalService
+	validator      *validator.Validate
+	i18n           *i18n.I18n
+	logger         *zap.Logger
+}
+
+// NewJournalHandler creates a new journal handler
+func NewJournalHandler(
+	journalService domain.JournalService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *JournalHandler {
+	return &JournalHandler{
+		journalService: journalService,
+		validator:      validator,
+		i18n:           i18n,
+		logger:         logger,
+	}
+}
+
+// CreateEntry handles writing a journal entry
+// @Summary Write a journal entry
+// @Description Write a note or letter in the couple journal. Private entries are only visible to you; entries with an unlock date stay sealed for your partner until that day.
+// @Tags journal
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateJournalEntryRequest true "Journal entry"
+// @Security BearerAuth
+// @Success 201 {object} domain.JournalEntryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /journal [post]
+func (h *JournalHandler) CreateEntry(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.CreateJournalEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.invalidBody(c)
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return h.validationFailed(c, err)
+	}
+
+	entry, err := h.journalService.CreateEntry(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Create journal entry", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, entry)
+}
+
+// GetEntries handles listing the couple journal
+// @Summary Get journal entries
+// @Description Get the journal entries you can see, newest first. Sealed entries are listed without their content.
+// @Tags journal
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Security BearerAuth
+// @Success 200 {object} domain.JournalListResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /journal [get]
+func (h *JournalHandler) GetEntries(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	entries, err := h.journalService.GetEntries(c.Context(), userID, page, limit)
+	if err != nil {
+		LogServiceError(c, err, "Get journal entries", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, entries)
+}
+
+// GetEntry handles getting a journal entry
+// @Summary Get journal entry by ID
+// @Description Get a journal entry. A sealed entry is returned without its content.
+// @Tags journal
+// @Produce json
+// @Param id path string true "Journal entry ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.JournalEntryResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /journal/{id} [get]
+func (h *JournalHandler) GetEntry(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	entryID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidEntryID(c)
+	}
+
+	entry, err := h.journalService.GetEntry(c.Context(), entryID, userID)
+	if err != nil {
+		LogServiceError(c, err, "Get journal entry",
+			zap.String("user_id", userID.Hex()),
+			zap.String("entry_id", entryID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, entry)
+}
+
+// UpdateEntry handles updating a journal entry
+// @Summary Update journal entry
+// @Description Update a journal entry you wrote. An empty unlock_at unseals the entry.
+// @Tags journal
+// @Accept json
+// @Produce json
+// @Param id path string true "Journal entry ID"
+// @Param request body domain.UpdateJournalEntryRequest true "Changes"
+// @Security BearerAuth
+// @Success 200 {object} domain.JournalEntryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /journal/{id} [put]
+func (h *JournalHandler) UpdateEntry(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	entryID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidEntryID(c)
+	}
+
+	var req domain.UpdateJournalEntryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.invalidBody(c)
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return h.validationFailed(c, err)
+	}
+
+	entry, err := h.journalService.UpdateEntry(c.Context(), entryID, userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Update journal entry",
+			zap.String("user_id", userID.Hex()),
+			zap.String("entry_id", entryID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, entry)
+}
+
+// DeleteEntry handles deleting a journal entry
+// @Summary Delete journal entry
+// @Description Delete a journal entry you wrote
+// @Tags journal
+// @Produce json
+// @Param id path string true "Journal entry ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /journal/{id} [delete]
+func (h *JournalHandler) DeleteEntry(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	entryID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidEntryID(c)
+	}
+
+	if err := h.journalService.DeleteEntry(c.Context(), entryID, userID); err != nil {
+		LogServiceError(c, err, "Delete journal entry",
+			zap.String("user_id", userID.Hex()),
+			zap.String("entry_id", entryID.Hex()))
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// invalidEntryID returns the error for a malformed journal entry ID
+func (h *JournalHandler) invalidEntryID(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Invalid journal entry ID")
+}
+
+// invalidBody returns the error for a request body that cannot be parsed
+func (h *JournalHandler) invalidBody(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Invalid request body")
+}
+
+// validationFailed returns the error listing the invalid fields
+func (h *JournalHandler) validationFailed(c *fiber.Ctx, err error) error {
+	return domain.ErrValidationFailedError(getValidationErrors(err))
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/auth"
+	"github.com/eralove/eralove-backend/internal/infrastructure/secrets"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// JWTKeysResponse lists the keys tokens are signed and verified with
+type JWTKeysResponse struct {
+	Keys []auth.SigningKeyInfo `json:"keys"`
+}
+
+// JWTKeyHandler handles JWT signing key administration HTTP requests
+type JWTKeyHandler struct {
+	jwtManager *auth.JWTManager
+	reloader   *secrets.Reloader
+	logger     *zap.Logger
+}
+
+// NewJWTKeyHandler creates a new JWT key handler. reloader is nil when the JWT secret
+// is not read from a secret manager.
+func NewJWTKeyHandler(jwtManager *auth.JWTManager, reloader *secrets.Reloader, logger *zap.Logger) *JWTKeyHandler {
+	return &JWTKeyHandler{
+		jwtManager: jwtManager,
+		reloader:   reloader,
+		logger:     logger,
+	}
+}
+
+// ListKeys handles listing the JWT signing keys
+// @Summary List JWT signing keys
+// @Description List the IDs of the keys tokens are verified with: the current key, which signs new tokens, then the previous keys from JWT_PREVIOUS_SECRETS or a rotation. A key retired by a rotation verifies tokens until expires_at. Admin only.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} JWTKeysResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/auth/keys [get]
+func (h *JWTKeyHandler) ListKeys(c *fiber.Ctx) error {
+	return Respond(c, fiber.StatusOK, JWTKeysResponse{Keys: h.jwtManager.Keys()})
+}
+
+// RotateKey handles rotating the JWT signing key
+// @Summary Rotate JWT signing key
+// @Description Read the JWT secret again from the secret manager and sign new tokens with it when it changed. Tokens signed with the previous key keep verifying until they expire, so nobody is signed out. Other server instances pick the secret up as soon as a token signed with it reaches them, and within SECRETS_REFRESH_INTERVAL otherwise. Admin only.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} JWTKeysResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/auth/keys/rotate [post]
+func (h *JWTKeyHandler) RotateKey(c *fiber.Ctx) error {
+	if h.reloader == nil {
+		return domain.ErrInvalidRequestError("The JWT secret is not read from a secret manager; rotate it with JWT_SECRET and JWT_PREVIOUS_SECRETS")
+	}
+
+	if err := h.reloader.Reload(c.Context()); err != nil {
+		h.logger.Warn("Failed to rotate JWT signing key", zap.Error(err))
+		return domain.ErrInvalidRequestError("Failed to read the JWT secret from the secret manager")
+	}
+
+	keys := h.jwtManager.Keys()
+	h.logger.Info("JWT signing key checked", zap.String("kid", keys[0].ID))
+
+	return Respond(c, fiber.StatusOK, JWTKeysResponse{Keys: keys})
+}
@@ -51,31 +51,20 @@ func (h *MatchRequestHandler) SendMatchRequest(c *fiber.Ctx) error {
 
 	var req domain.CreateMatchRequestRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: err.Error(),
-		})
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
 	if err := h.validator.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: err.Error(),
-		})
+		return domain.ErrValidationFailedError(getValidationErrors(err))
 	}
 
 	matchRequest, err := h.matchRequestService.SendMatchRequest(c.Context(), userID, &req)
 	if err != nil {
-		h.logger.Error("Failed to send match request",
-			zap.String("trace_id", getTraceID(c)),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to send match request",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Send match request")
+		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(matchRequest)
+	return Respond(c, fiber.StatusCreated, matchRequest)
 }
 
 // GetSentRequests handles getting sent match requests
@@ -85,7 +74,8 @@ func (h *MatchRequestHandler) SendMatchRequest(c *fiber.Ctx) error {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
-// @Param status query string false "Filter by status (pending, accepted, rejected)"
+// @Param status query string false "Filter by status (pending, accepted, declined, ignored)"
+// @Param fields query string false "Comma-separated fields to keep in each item of the list; id is always kept"
 // @Security BearerAuth
 // @Success 200 {object} domain.MatchRequestListResponse
 // @Failure 401 {object} ErrorResponse
@@ -100,21 +90,16 @@ func (h *MatchRequestHandler) GetSentRequests(c *fiber.Ctx) error {
 
 	requests, total, err := h.matchRequestService.GetSentRequests(c.Context(), userID, status, page, limit)
 	if err != nil {
-		h.logger.Error("Failed to get sent requests",
-			zap.String("trace_id", getTraceID(c)),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to get sent requests",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Get sent requests")
+		return err
 	}
 
-	return c.JSON(domain.MatchRequestListResponse{
+	return RespondPage(c, domain.MatchRequestListResponse{
 		MatchRequests: requests,
 		Total:         total,
 		Page:          page,
 		Limit:         limit,
-	})
+	}, PageMeta(total, page, limit))
 }
 
 // GetReceivedRequests handles getting received match requests
@@ -124,7 +109,8 @@ func (h *MatchRequestHandler) GetSentRequests(c *fiber.Ctx) error {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
-// @Param status query string false "Filter by status (pending, accepted, rejected)"
+// @Param status query string false "Filter by status (pending, accepted, declined, ignored)"
+// @Param fields query string false "Comma-separated fields to keep in each item of the list; id is always kept"
 // @Security BearerAuth
 // @Success 200 {object} domain.MatchRequestListResponse
 // @Failure 401 {object} ErrorResponse
@@ -139,21 +125,16 @@ func (h *MatchRequestHandler) GetReceivedRequests(c *fiber.Ctx) error {
 
 	requests, total, err := h.matchRequestService.GetReceivedRequests(c.Context(), userID, status, page, limit)
 	if err != nil {
-		h.logger.Error("Failed to get received requests",
-			zap.String("trace_id", getTraceID(c)),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to get received requests",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Get received requests")
+		return err
 	}
 
-	return c.JSON(domain.MatchRequestListResponse{
+	return RespondPage(c, domain.MatchRequestListResponse{
 		MatchRequests: requests,
 		Total:         total,
 		Page:          page,
 		Limit:         limit,
-	})
+	}, PageMeta(total, page, limit))
 }
 
 // RespondToMatchRequest handles responding to match requests
@@ -173,39 +154,25 @@ func (h *MatchRequestHandler) RespondToMatchRequest(c *fiber.Ctx) error {
 	userID := getUserIDFromContext(c)
 	requestID, err := primitive.ObjectIDFromHex(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request ID",
-			Message: "Request ID must be a valid ObjectID",
-		})
+		return domain.ErrInvalidRequestError("Request ID must be a valid ObjectID")
 	}
 
 	var req domain.RespondToMatchRequestRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: err.Error(),
-		})
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
 	if err := h.validator.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: err.Error(),
-		})
+		return domain.ErrValidationFailedError(getValidationErrors(err))
 	}
 
 	matchRequest, err := h.matchRequestService.RespondToMatchRequest(c.Context(), requestID, userID, &req)
 	if err != nil {
-		h.logger.Error("Failed to respond to match request",
-			zap.String("trace_id", getTraceID(c)),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to respond to match request",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Respond to match request")
+		return err
 	}
 
-	return c.JSON(matchRequest)
+	return Respond(c, fiber.StatusOK, matchRequest)
 }
 
 // GetMatchRequest handles getting a specific match request
@@ -223,24 +190,16 @@ func (h *MatchRequestHandler) GetMatchRequest(c *fiber.Ctx) error {
 	userID := getUserIDFromContext(c)
 	requestID, err := primitive.ObjectIDFromHex(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request ID",
-			Message: "Request ID must be a valid ObjectID",
-		})
+		return domain.ErrInvalidRequestError("Request ID must be a valid ObjectID")
 	}
 
 	matchRequest, err := h.matchRequestService.GetMatchRequest(c.Context(), requestID, userID)
 	if err != nil {
-		h.logger.Error("Failed to get match request",
-			zap.String("trace_id", getTraceID(c)),
-			zap.Error(err))
-		return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{
-			Error:   "Match request not found",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Get match request")
+		return err
 	}
 
-	return c.JSON(matchRequest)
+	return Respond(c, fiber.StatusOK, matchRequest)
 }
 
 // CancelMatchRequest handles canceling match requests
@@ -258,22 +217,81 @@ func (h *MatchRequestHandler) CancelMatchRequest(c *fiber.Ctx) error {
 	userID := getUserIDFromContext(c)
 	requestID, err := primitive.ObjectIDFromHex(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request ID",
-			Message: "Request ID must be a valid ObjectID",
-		})
+		return domain.ErrInvalidRequestError("Request ID must be a valid ObjectID")
 	}
 
 	err = h.matchRequestService.CancelMatchRequest(c.Context(), requestID, userID)
 	if err != nil {
-		h.logger.Error("Failed to cancel match request",
-			zap.String("trace_id", getTraceID(c)),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to cancel match request",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Cancel match request")
+		return err
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// CreateInvite handles creating a partner invite
+// @Summary Create a partner invite
+// @Description Create a short-lived invite code, with a link and a QR code of it, that the partner redeems to match without knowing the user's email. Creating an invite replaces the previous one.
+// @Tags match-requests
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateMatchInviteRequest true "Invite data"
+// @Security BearerAuth
+// @Success 201 {object} domain.MatchInviteResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /match-requests/invite [post]
+func (h *MatchRequestHandler) CreateInvite(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.CreateMatchInviteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	invite, err := h.matchRequestService.CreateInvite(c.Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, invite)
+}
+
+// AcceptInvite handles redeeming a partner invite
+// @Summary Accept a partner invite
+// @Description Redeem an invite code to match with the user who created it. The anniversary date of the invite can be overridden.
+// @Tags match-requests
+// @Accept json
+// @Produce json
+// @Param request body domain.AcceptMatchInviteRequest true "Invite code"
+// @Security BearerAuth
+// @Success 200 {object} domain.MatchRequestResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Router /match-requests/accept-invite [post]
+func (h *MatchRequestHandler) AcceptInvite(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.AcceptMatchInviteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	matchRequest, err := h.matchRequestService.AcceptInvite(c.Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, matchRequest)
+}
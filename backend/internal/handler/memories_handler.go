@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// MemoriesHandler handles "on this day" memories HTTP requests
+type MemoriesHandler struct {
+	memoriesService domain.MemoriesService
+	i18n            *i18n.I18n
+	logger          *zap.Logger
+}
+
+// NewMemoriesHandler creates a new memories handler
+func NewMemoriesHandler(
+	memoriesService domain.MemoriesService,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *MemoriesHandler {
+	return &MemoriesHandler{
+		memoriesService: memoriesService,
+		i18n:            i18n,
+		logger:          logger,
+	}
+}
+
+// GetToday handles retrieving the couple's memories from this day in earlier years
+// @Summary Get memories from this day
+// @Description Get the couple's photos and events from the same calendar day in earlier years, grouped by year, most recent year first. The day is today in the user's profile time zone unless clients pass their local date. On February 28th of a common year, February 29th memories are included.
+// @Tags memories
+// @Produce json
+// @Param date query string false "Calendar day to look back from (YYYY-MM-DD), today in the user's time zone by default"
+// @Security BearerAuth
+// @Success 200 {object} domain.MemoriesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /memories/today [get]
+func (h *MemoriesHandler) GetToday(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	date, err := parseDateQuery(c, "date")
+	if err != nil {
+		return domain.ErrInvalidRequestError("Invalid date: " + err.Error())
+	}
+
+	var day time.Time
+	if date != nil {
+		day = date.Time
+	}
+
+	memories, err := h.memoriesService.GetOnThisDay(c.Context(), userID, day)
+	if err != nil {
+		LogServiceError(c, err, "Get memories", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, memories)
+}
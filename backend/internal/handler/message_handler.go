@@ -1,16 +1,23 @@
 package handler
 
 import (
+	"context"
+	"encoding/json"
 	"strconv"
+	"time"
 
 	"github.com/eralove/eralove-backend/internal/domain"
 	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/eralove/eralove-backend/internal/infrastructure/websocket"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
 )
 
+// receiptPingInterval is how often the message receipt WebSocket pings its client
+const receiptPingInterval = 30 * time.Second
+
 // MessageHandler handles message-related HTTP requests
 type MessageHandler struct {
 	messageService domain.MessageService
@@ -41,41 +48,32 @@ func NewMessageHandler(
 // @Accept json
 // @Produce json
 // @Param request body domain.CreateMessageRequest true "Message data"
+// @Param Idempotency-Key header string false "Unique key of the request, the same on retries, whose first response is replayed to them"
 // @Security BearerAuth
 // @Success 201 {object} domain.MessageResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Router /messages [post]
 func (h *MessageHandler) SendMessage(c *fiber.Ctx) error {
 	userID := getUserIDFromContext(c)
-	
+
 	var req domain.CreateMessageRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: err.Error(),
-		})
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
-	if err := h.validator.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: err.Error(),
-		})
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
 	}
 
 	message, err := h.messageService.SendMessage(c.Context(), userID, &req)
 	if err != nil {
-		h.logger.Error("Failed to send message",
-			zap.String("trace_id", getTraceID(c)),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to send message",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Send message")
+		return err
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(message)
+	return Respond(c, fiber.StatusCreated, message)
 }
 
 // GetMessages handles getting conversation messages
@@ -86,6 +84,8 @@ func (h *MessageHandler) SendMessage(c *fiber.Ctx) error {
 // @Param partner_id query string true "Partner ID"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(20)
+// @Param cursor query string false "Cursor from next_cursor; pass an empty cursor to start cursor pagination"
+// @Param fields query string false "Comma-separated fields to keep in each item of the list; id is always kept"
 // @Security BearerAuth
 // @Success 200 {object} domain.MessageListResponse
 // @Failure 400 {object} ErrorResponse
@@ -93,21 +93,19 @@ func (h *MessageHandler) SendMessage(c *fiber.Ctx) error {
 // @Router /messages [get]
 func (h *MessageHandler) GetMessages(c *fiber.Ctx) error {
 	userID := getUserIDFromContext(c)
-	
+
 	partnerIDStr := c.Query("partner_id")
 	if partnerIDStr == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Partner ID is required",
-			Message: "Please provide partner_id query parameter",
-		})
+		return domain.ErrInvalidRequestError("Please provide partner_id query parameter")
 	}
 
 	partnerID, err := primitive.ObjectIDFromHex(partnerIDStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid partner ID",
-			Message: "Partner ID must be a valid ObjectID",
-		})
+		return domain.ErrInvalidRequestError("Partner ID must be a valid ObjectID")
+	}
+
+	if c.Context().QueryArgs().Has("cursor") {
+		return h.getMessagesByCursor(c, userID, partnerID)
 	}
 
 	// Parse query parameters
@@ -116,21 +114,40 @@ func (h *MessageHandler) GetMessages(c *fiber.Ctx) error {
 
 	messages, total, err := h.messageService.GetConversation(c.Context(), userID, partnerID, page, limit)
 	if err != nil {
-		h.logger.Error("Failed to get messages",
-			zap.String("trace_id", getTraceID(c)),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to get messages",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Get messages")
+		return err
 	}
 
-	return c.JSON(domain.MessageListResponse{
+	return RespondPage(c, domain.MessageListResponse{
 		Messages: messages,
 		Total:    total,
 		Page:     page,
 		Limit:    limit,
-	})
+	}, PageMeta(total, page, limit))
+}
+
+// getMessagesByCursor serves GetMessages in cursor pagination mode, which stays
+// stable while new messages arrive
+func (h *MessageHandler) getMessagesByCursor(c *fiber.Ctx, userID, partnerID primitive.ObjectID) error {
+	cursor, err := domain.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Cursor must be a next_cursor value returned by a previous request")
+	}
+
+	limit := domain.NormalizeCursorLimit(c.QueryInt("limit", domain.DefaultCursorLimit))
+
+	messages, nextCursor, err := h.messageService.GetConversationCursor(c.Context(), userID, partnerID, cursor, limit)
+	if err != nil {
+		LogServiceError(c, err, "Get messages")
+		return err
+	}
+
+	return RespondPage(c, domain.MessageListResponse{
+		Messages:   messages,
+		Total:      int64(len(messages)),
+		Limit:      limit,
+		NextCursor: nextCursor,
+	}, CursorMeta(limit, nextCursor))
 }
 
 // GetConversations handles getting user conversations
@@ -140,39 +157,35 @@ func (h *MessageHandler) GetMessages(c *fiber.Ctx) error {
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param fields query string false "Comma-separated fields to keep in each item of the list; id is always kept"
 // @Security BearerAuth
 // @Success 200 {object} domain.ConversationListResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /messages/conversations [get]
 func (h *MessageHandler) GetConversations(c *fiber.Ctx) error {
 	userID := getUserIDFromContext(c)
-	
+
 	// Parse query parameters
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 
 	conversations, total, err := h.messageService.GetUserConversations(c.Context(), userID, page, limit)
 	if err != nil {
-		h.logger.Error("Failed to get conversations",
-			zap.String("trace_id", getTraceID(c)),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to get conversations",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Get conversations")
+		return err
 	}
 
-	return c.JSON(domain.ConversationListResponse{
+	return RespondPage(c, domain.ConversationListResponse{
 		Conversations: conversations,
 		Total:         total,
 		Page:          page,
 		Limit:         limit,
-	})
+	}, PageMeta(total, page, limit))
 }
 
 // MarkAsRead handles marking messages as read
 // @Summary Mark messages as read
-// @Description Mark messages in a conversation as read
+// @Description Mark the messages received from the partner as read. The partner is sent a read receipt over the message WebSocket.
 // @Tags messages
 // @Accept json
 // @Produce json
@@ -184,47 +197,191 @@ func (h *MessageHandler) GetConversations(c *fiber.Ctx) error {
 // @Router /messages/mark-read [post]
 func (h *MessageHandler) MarkAsRead(c *fiber.Ctx) error {
 	userID := getUserIDFromContext(c)
-	
+
 	var req domain.MarkAsReadRequest
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: err.Error(),
-		})
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
-	if err := h.validator.Struct(req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: err.Error(),
-		})
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
 	}
 
 	err := h.messageService.MarkAsRead(c.Context(), userID, req.PartnerID)
 	if err != nil {
-		h.logger.Error("Failed to mark messages as read",
-			zap.String("trace_id", getTraceID(c)),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to mark messages as read",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Mark messages as read")
+		return err
 	}
 
-	return c.JSON(SuccessResponse{
-		Success: true,
-		Message: "Messages marked as read",
+	return RespondMessage(c, fiber.StatusOK, nil, "Messages marked as read")
+}
+
+// AckMessages handles acknowledging received messages
+// @Summary Acknowledge messages
+// @Description Mark messages received from the partner as delivered, once the app got them, or read, once they were shown. Reading a message also delivers it. The sender is sent a receipt over the message WebSocket. Only the messages whose state changed are listed in the response; acknowledging a message again is harmless.
+// @Tags messages
+// @Accept json
+// @Produce json
+// @Param request body domain.AckMessagesRequest true "Messages and their new state"
+// @Security BearerAuth
+// @Success 200 {object} domain.MessageReceipt
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /messages/receipts [post]
+func (h *MessageHandler) AckMessages(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.AckMessagesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	receipt, err := h.messageService.AckMessages(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Acknowledge messages")
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, receipt)
+}
+
+// ConnectReceipts handles the message receipt WebSocket
+// @Summary Message receipts
+// @Description Open a WebSocket that receives a receipt {"status":"delivered"|"read","message_ids":[...],"receiver_id":"...","at":"..."} whenever the partner acknowledges messages the user sent, including when the whole conversation is marked as read. Nothing needs to be sent; the server pings the client to keep the connection alive.
+// @Tags messages
+// @Security BearerAuth
+// @Success 101
+// @Failure 401 {object} ErrorResponse
+// @Failure 426 {object} ErrorResponse
+// @Router /messages/ws [get]
+func (h *MessageHandler) ConnectReceipts(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	lang := getLocale(c)
+
+	return websocket.Upgrade(c, func(conn *websocket.Conn) {
+		h.serveReceipts(conn, userID, lang)
 	})
 }
 
+// serveReceipts relays the receipts of the user's messages to the connection until
+// the client goes away
+func (h *MessageHandler) serveReceipts(conn *websocket.Conn, userID primitive.ObjectID, lang string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	receipts, err := h.messageService.SubscribeReceipts(ctx, userID)
+	if err != nil {
+		appErr := toAppError(err)
+		h.writeSocket(conn, ErrorResponse{
+			Code:    int(appErr.Code),
+			Error:   appErr.Message,
+			Message: translateError(h.i18n, lang, appErr),
+		})
+		conn.Close(websocket.CloseInternalError)
+		return
+	}
+
+	conn.SetReadTimeout(2 * receiptPingInterval)
+
+	// Clients send nothing; reading only notices when they go away
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		for {
+			if _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	defer func() {
+		cancel()
+		conn.Close(websocket.CloseGoingAway)
+		<-readDone
+	}()
+
+	ticker := time.NewTicker(receiptPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-readDone:
+			return
+		case receipt, ok := <-receipts:
+			if !ok || !h.writeSocket(conn, receipt) {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.Ping(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeSocket sends a JSON message and reports whether the connection is still usable
+func (h *MessageHandler) writeSocket(conn *websocket.Conn, message interface{}) bool {
+	data, err := json.Marshal(message)
+	if err != nil {
+		h.logger.Error("Failed to encode message receipt", zap.Error(err))
+		return false
+	}
+	return conn.WriteMessage(data) == nil
+}
+
+// EditMessage handles editing a message
+// @Summary Edit message
+// @Description Change the content of a text message the user sent. Messages can only be edited for MESSAGE_EDIT_WINDOW minutes after they were sent; edited messages have edited_at set.
+// @Tags messages
+// @Accept json
+// @Produce json
+// @Param id path string true "Message ID"
+// @Param request body domain.UpdateMessageRequest true "New content"
+// @Security BearerAuth
+// @Success 200 {object} domain.MessageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /messages/{id} [put]
+func (h *MessageHandler) EditMessage(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	messageID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Message ID must be a valid ObjectID")
+	}
+
+	var req domain.UpdateMessageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	message, err := h.messageService.EditMessage(c.Context(), messageID, userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Edit message")
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, message)
+}
+
 // DeleteMessage handles message deletion
 // @Summary Delete message
-// @Description Delete a message (soft delete)
+// @Description Delete a message. With mode everyone, the default, a message the user sent is deleted for both partners (soft delete, restorable from the trash). With mode me, a message the user sent or received is only hidden from the user.
 // @Tags messages
 // @Produce json
 // @Param id path string true "Message ID"
+// @Param mode query string false "Who to delete the message for: everyone or me" default(everyone)
 // @Security BearerAuth
 // @Success 204
+// @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /messages/{id} [delete]
@@ -232,22 +389,71 @@ func (h *MessageHandler) DeleteMessage(c *fiber.Ctx) error {
 	userID := getUserIDFromContext(c)
 	messageID, err := primitive.ObjectIDFromHex(c.Params("id"))
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid message ID",
-			Message: "Message ID must be a valid ObjectID",
-		})
+		return domain.ErrInvalidRequestError("Message ID must be a valid ObjectID")
 	}
 
-	err = h.messageService.DeleteMessage(c.Context(), messageID, userID)
+	mode := domain.MessageDeleteMode(c.Query("mode", string(domain.MessageDeleteForEveryone)))
+	err = h.messageService.DeleteMessage(c.Context(), messageID, userID, mode)
 	if err != nil {
-		h.logger.Error("Failed to delete message",
-			zap.String("trace_id", getTraceID(c)),
-			zap.Error(err))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to delete message",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Delete message")
+		return err
 	}
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// ExportConversation handles exporting the conversation with the partner
+// @Summary Export conversation
+// @Description Export every message with the partner as JSON, stored encrypted with the couple's data key. When the couple requires partner approval, the export waits for the partner to approve it and the pending action is returned with 202; the requester is notified with the export ID once it is made.
+// @Tags messages
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} domain.ConversationExportResponse
+// @Success 202 {object} domain.ConversationExportResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /messages/export [post]
+func (h *MessageHandler) ExportConversation(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	export, err := h.messageService.ExportConversation(c.Context(), userID)
+	if err != nil {
+		LogServiceError(c, err, "Export conversation")
+		return err
+	}
+
+	if export.PendingAction != nil {
+		return Respond(c, fiber.StatusAccepted, export)
+	}
+
+	return Respond(c, fiber.StatusCreated, export)
+}
+
+// DownloadConversationExport handles downloading a conversation export
+// @Summary Download conversation export
+// @Description Download a conversation export of the couple as a JSON file
+// @Tags messages
+// @Produce json
+// @Param id path string true "Export ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.ConversationExport
+// @Failure 404 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /messages/exports/{id} [get]
+func (h *MessageHandler) DownloadConversationExport(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	exportID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Export ID must be a valid ObjectID")
+	}
+
+	export, err := h.messageService.GetConversationExport(c.Context(), userID, exportID)
+	if err != nil {
+		LogServiceError(c, err, "Download conversation export")
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="eralove-conversation-`+exportID.Hex()+`.json"`)
+	return c.Send(export)
+}
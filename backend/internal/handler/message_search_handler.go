@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// messageSearchMaxQueryLength is the longest message search query accepted
+const messageSearchMaxQueryLength = 200
+
+// MessageSearchHandler handles conversation search HTTP requests
+type MessageSearchHandler struct {
+	searchService domain.MessageSearchService
+	i18n          *i18n.I18n
+	logger        *zap.Logger
+}
+
+// NewMessageSearchHandler creates a new message search handler
+func NewMessageSearchHandler(
+	searchService domain.MessageSearchService,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *MessageSearchHandler {
+	return &MessageSearchHandler{
+		searchService: searchService,
+		i18n:          i18n,
+		logger:        logger,
+	}
+}
+
+// SearchMessages handles searching the couple's conversation
+// @Summary Search messages
+// @Description Search the conversation with the partner for messages containing every word of the query, newest first. Matching ignores case and accents.
+// @Tags messages
+// @Produce json
+// @Param q query string true "Search query (at least 2 characters)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Items per page" default(20)
+// @Security BearerAuth
+// @Success 200 {object} domain.MessageSearchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /messages/search [get]
+func (h *MessageSearchHandler) SearchMessages(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	query := strings.TrimSpace(c.Query("q"))
+	if length := utf8.RuneCountInString(query); length < searchMinQueryLength || length > messageSearchMaxQueryLength {
+		return domain.ErrInvalidRequestError("Search query must be between 2 and 200 characters")
+	}
+
+	cursor, err := domain.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Cursor must be a next_cursor value returned by a previous request")
+	}
+	limit := domain.NormalizeCursorLimit(c.QueryInt("limit", domain.DefaultCursorLimit))
+
+	results, err := h.searchService.SearchMessages(c.Context(), userID, query, cursor, limit)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, results)
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// MoodHandler handles mood check-in HTTP requests
+type MoodHandler struct {
+	moodService domain.MoodService
+	validator   *validator.Validate
+	i18n        *i18n.I18n
+	logger      *zap.Logger
+}
+
+// NewMoodHandler creates a new mood handler
+func NewMoodHandler(
+	moodService domain.MoodService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *MoodHandler {
+	return &MoodHandler{
+		moodService: moodService,
+		validator:   validator,
+		i18n:        i18n,
+		logger:      logger,
+	}
+}
+
+// RecordMood handles the daily mood check-in
+// @Summary Record mood
+// @Description Record how you feel today on a 1-5 scale, with an optional emoji and note. Recording again on the same day replaces the earlier check-in.
+// @Tags moods
+// @Accept json
+// @Produce json
+// @Param request body domain.RecordMoodRequest true "Mood check-in"
+// @Security BearerAuth
+// @Success 200 {object} domain.MoodCheckInResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /moods [put]
+func (h *MoodHandler) RecordMood(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.RecordMoodRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	checkIn, err := h.moodService.RecordMood(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Record mood", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, checkIn)
+}
+
+// GetHistory handles getting the user's mood history
+// @Summary Get mood history
+// @Description Get your own mood check-ins, newest first
+// @Tags moods
+// @Produce json
+// @Param from query string false "Earliest day to include (YYYY-MM-DD)"
+// @Param to query string false "Latest day to include (YYYY-MM-DD)"
+// @Param limit query int false "Maximum number of check-ins" default(30)
+// @Security BearerAuth
+// @Success 200 {object} domain.MoodHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /moods [get]
+func (h *MoodHandler) GetHistory(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		return h.invalidQuery(c, "Invalid from date")
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		return h.invalidQuery(c, "Invalid to date")
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "30"))
+
+	history, err := h.moodService.GetHistory(c.Context(), userID, from, to, limit)
+	if err != nil {
+		LogServiceError(c, err, "Get mood history", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, history)
+}
+
+// GetCoupleMoods handles getting both partners' moods for a month
+// @Summary Get couple moods
+// @Description Get both partners' moods side by side for every day of a month
+// @Tags moods
+// @Produce json
+// @Param month query string false "Month (YYYY-MM), defaults to the current month"
+// @Security BearerAuth
+// @Success 200 {object} domain.CoupleMoodsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /couple/moods [get]
+func (h *MoodHandler) GetCoupleMoods(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	month := time.Now().UTC()
+	if raw := c.Query("month"); raw != "" {
+		parsed, err := time.Parse("2006-01", raw)
+		if err != nil {
+			return h.invalidQuery(c, "Invalid month")
+		}
+		month = parsed
+	}
+
+	moods, err := h.moodService.GetCoupleMoods(c.Context(), userID, month)
+	if err != nil {
+		LogServiceError(c, err, "Get couple moods", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, moods)
+}
+
+// invalidQuery returns the error for a malformed query parameter
+func (h *MoodHandler) invalidQuery(c *fiber.Ctx, message string) error {
+	return domain.ErrInvalidRequestError(message)
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// NotificationHandler handles in-app notification HTTP requests
+type NotificationHandler struct {
+	notificationService domain.NotificationService
+	i18n                *i18n.I18n
+	logger              *zap.Logger
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(
+	notificationService domain.NotificationService,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		i18n:                i18n,
+		logger:              logger,
+	}
+}
+
+// GetNotifications handles listing the user's notifications
+// @Summary Get notifications
+// @Description Get in-app notifications for the authenticated user
+// @Tags notifications
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Security BearerAuth
+// @Success 200 {object} domain.NotificationListResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /notifications [get]
+func (h *NotificationHandler) GetNotifications(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	notifications, err := h.notificationService.GetNotifications(c.Context(), userID, page, limit)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, notifications)
+}
+
+// MarkAsRead handles marking a notification as read
+// @Summary Mark notification as read
+// @Description Mark a single notification as read
+// @Tags notifications
+// @Produce json
+// @Param id path string true "Notification ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 404 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /notifications/{id}/read [post]
+func (h *NotificationHandler) MarkAsRead(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	notificationID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Notification ID must be a valid ObjectID")
+	}
+
+	if err := h.notificationService.MarkAsRead(c.Context(), notificationID, userID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// MarkAllAsRead handles marking all notifications as read
+// @Summary Mark all notifications as read
+// @Description Mark all of the user's notifications as read
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Router /notifications/read-all [post]
+func (h *NotificationHandler) MarkAllAsRead(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	if err := h.notificationService.MarkAllAsRead(c.Context(), userID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
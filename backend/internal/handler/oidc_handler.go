@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// OIDCHandler handles the OpenID Connect provider endpoints used by the companion apps
+type OIDCHandler struct {
+	oidcService domain.OIDCService
+	logger      *zap.Logger
+}
+
+// NewOIDCHandler creates a new OpenID Connect handler
+func NewOIDCHandler(oidcService domain.OIDCService, logger *zap.Logger) *OIDCHandler {
+	return &OIDCHandler{
+		oidcService: oidcService,
+		logger:      logger,
+	}
+}
+
+// Discovery handles the OpenID Provider metadata request
+// @Summary OpenID Connect discovery
+// @Description Describe the OpenID Connect provider endpoints and capabilities
+// @Tags oauth
+// @Produce json
+// @Success 200 {object} domain.OIDCDiscoveryResponse
+// @Router /oauth/.well-known/openid-configuration [get]
+func (h *OIDCHandler) Discovery(c *fiber.Ctx) error {
+	return c.JSON(h.oidcService.Discovery())
+}
+
+// JWKS handles the signing key set request
+// @Summary OpenID Connect signing keys
+// @Description List the public keys that verify ID tokens
+// @Tags oauth
+// @Produce json
+// @Success 200 {object} domain.JSONWebKeySet
+// @Router /oauth/jwks [get]
+func (h *OIDCHandler) JWKS(c *fiber.Ctx) error {
+	return c.JSON(h.oidcService.JWKS())
+}
+
+// Authorize handles an OpenID Connect authentication request
+// @Summary Authorize a companion app
+// @Description Start the authorization code flow. Users who are not signed in are sent to the login page first; then the browser is redirected to the app with a code. PKCE with S256 is required.
+// @Tags oauth
+// @Param response_type query string true "Must be code"
+// @Param client_id query string true "Client ID"
+// @Param redirect_uri query string true "Registered redirect URI"
+// @Param scope query string true "Space-separated scopes, must include openid"
+// @Param state query string false "Opaque value returned to the client"
+// @Param nonce query string false "Value copied into the ID token"
+// @Param code_challenge query string true "Base64url SHA-256 of the code verifier"
+// @Param code_challenge_method query string true "Must be S256"
+// @Param prompt query string false "none to fail instead of asking the user to sign in"
+// @Success 302
+// @Failure 400 {object} domain.OAuthError
+// @Router /oauth/authorize [get]
+func (h *OIDCHandler) Authorize(c *fiber.Ctx) error {
+	var req domain.AuthorizeRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.NewOAuthError(domain.OAuthErrInvalidRequest, "Invalid query parameters"))
+	}
+
+	redirectURL, err := h.oidcService.Authorize(c.Context(), getUserIDFromContext(c), &req, string(c.Request().URI().QueryString()))
+	if err != nil {
+		return h.oauthError(c, err)
+	}
+
+	return c.Redirect(redirectURL, fiber.StatusFound)
+}
+
+// Token handles a token request
+// @Summary Exchange an authorization code or refresh token
+// @Description Exchange an authorization code and its PKCE verifier, or a refresh token, for an access token, a refresh token and an ID token
+// @Tags oauth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "authorization_code or refresh_token"
+// @Param client_id formData string true "Client ID"
+// @Param code formData string false "Authorization code"
+// @Param redirect_uri formData string false "Redirect URI the code was issued to"
+// @Param code_verifier formData string false "PKCE code verifier"
+// @Param refresh_token formData string false "Refresh token"
+// @Param scope formData string false "Scopes for the refreshed tokens, a subset of those first granted"
+// @Success 200 {object} domain.OIDCTokenResponse
+// @Failure 400 {object} domain.OAuthError
+// @Failure 401 {object} domain.OAuthError
+// @Router /oauth/token [post]
+func (h *OIDCHandler) Token(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	var req domain.TokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(domain.NewOAuthError(domain.OAuthErrInvalidRequest, "Invalid request body"))
+	}
+
+	tokens, err := h.oidcService.Token(c.Context(), &req)
+	if err != nil {
+		return h.oauthError(c, err)
+	}
+
+	return c.JSON(tokens)
+}
+
+// UserInfo handles the user info request
+// @Summary OpenID Connect user info
+// @Description Get the claims about the user the access token was issued to
+// @Tags oauth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.UserInfoResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /oauth/userinfo [get]
+func (h *OIDCHandler) UserInfo(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	userInfo, err := h.oidcService.UserInfo(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(userInfo)
+}
+
+// oauthError writes OAuth errors in the format clients expect; other errors go to
+// the global error handler
+func (h *OIDCHandler) oauthError(c *fiber.Ctx, err error) error {
+	var oauthErr *domain.OAuthError
+	if !errors.As(err, &oauthErr) {
+		return err
+	}
+
+	status := fiber.StatusBadRequest
+	switch oauthErr.Code {
+	case domain.OAuthErrInvalidClient:
+		status = fiber.StatusUnauthorized
+	case domain.OAuthErrServerError:
+		status = fiber.StatusInternalServerError
+	}
+
+	return c.Status(status).JSON(oauthErr)
+}
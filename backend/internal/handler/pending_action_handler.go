@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// PendingActionHandler handles partner approval HTTP requests
+type PendingActionHandler struct {
+	pendingActionService domain.PendingActionService
+	i18n                 *i18n.I18n
+	logger               *zap.Logger
+}
+
+// NewPendingActionHandler creates a new pending action handler
+func NewPendingActionHandler(
+	pendingActionService domain.PendingActionService,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *PendingActionHandler {
+	return &PendingActionHandler{
+		pendingActionService: pendingActionService,
+		i18n:                 i18n,
+		logger:               logger,
+	}
+}
+
+// ListPendingActions handles listing the couple's actions waiting for approval
+// @Summary List pending actions
+// @Description List the album deletions and conversation exports either partner asked for that are waiting for the other partner's approval
+// @Tags pending-actions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.PendingActionListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /pending-actions [get]
+func (h *PendingActionHandler) ListPendingActions(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	actions, err := h.pendingActionService.ListPending(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, actions)
+}
+
+// ApprovePendingAction handles approving the partner's action
+// @Summary Approve pending action
+// @Description Approve an action the partner asked for; it is carried out right away
+// @Tags pending-actions
+// @Produce json
+// @Param id path string true "Pending action ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.PendingActionResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Router /pending-actions/{id}/approve [post]
+func (h *PendingActionHandler) ApprovePendingAction(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	actionID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidActionID(c)
+	}
+
+	action, err := h.pendingActionService.Approve(c.Context(), actionID, userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, action)
+}
+
+// RejectPendingAction handles rejecting the partner's action
+// @Summary Reject pending action
+// @Description Reject an action the partner asked for
+// @Tags pending-actions
+// @Produce json
+// @Param id path string true "Pending action ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.PendingActionResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Router /pending-actions/{id}/reject [post]
+func (h *PendingActionHandler) RejectPendingAction(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	actionID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidActionID(c)
+	}
+
+	action, err := h.pendingActionService.Reject(c.Context(), actionID, userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, action)
+}
+
+// CancelPendingAction handles withdrawing an action the user asked for
+// @Summary Cancel pending action
+// @Description Withdraw an action the user asked for before the partner decides on it
+// @Tags pending-actions
+// @Produce json
+// @Param id path string true "Pending action ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.PendingActionResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /pending-actions/{id} [delete]
+func (h *PendingActionHandler) CancelPendingAction(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	actionID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidActionID(c)
+	}
+
+	action, err := h.pendingActionService.Cancel(c.Context(), actionID, userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, action)
+}
+
+// invalidActionID returns the error for a malformed pending action ID
+func (h *PendingActionHandler) invalidActionID(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Pending action ID must be a valid ObjectID")
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// PhotoCommentHandler handles photo comment HTTP requests
+type PhotoCommentHandler struct {
+	commentService domain.PhotoCommentService
+	validator      *validator.Validate
+	i18n           *i18n.I18n
+	logger         *zap.Logger
+}
+
+// NewPhotoCommentHandler creates a new photo comment handler
+func NewPhotoCommentHandler(
+	commentService domain.PhotoCommentService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *PhotoCommentHandler {
+	return &PhotoCommentHandler{
+		commentService: commentService,
+		validator:      validator,
+		i18n:           i18n,
+		logger:         logger,
+	}
+}
+
+// CreateComment handles commenting on a photo
+// @Summary Comment on a photo
+// @Description Add a comment to one of the couple's photos. The partner is notified.
+// @Tags photos
+// @Accept json
+// @Produce json
+// @Param id path string true "Photo ID"
+// @Param request body domain.CreatePhotoCommentRequest true "Comment"
+// @Security BearerAuth
+// @Success 201 {object} domain.PhotoCommentResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /photos/{id}/comments [post]
+func (h *PhotoCommentHandler) CreateComment(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	photoID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidID(c, "Invalid photo ID")
+	}
+
+	var req domain.CreatePhotoCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(nil)
+	}
+
+	comment, err := h.commentService.AddComment(c.Context(), photoID, userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Create photo comment",
+			zap.String("user_id", userID.Hex()),
+			zap.String("photo_id", photoID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, comment)
+}
+
+// GetComments handles listing the comments on a photo
+// @Summary Get photo comments
+// @Description Get the comment thread of one of the couple's photos, oldest first
+// @Tags photos
+// @Produce json
+// @Param id path string true "Photo ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(50)
+// @Security BearerAuth
+// @Success 200 {object} domain.PhotoCommentListResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /photos/{id}/comments [get]
+func (h *PhotoCommentHandler) GetComments(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	photoID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidID(c, "Invalid photo ID")
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "50"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 50
+	}
+
+	comments, err := h.commentService.GetComments(c.Context(), photoID, userID, page, limit)
+	if err != nil {
+		LogServiceError(c, err, "Get photo comments",
+			zap.String("user_id", userID.Hex()),
+			zap.String("photo_id", photoID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, comments)
+}
+
+// DeleteComment handles deleting a comment on a photo
+// @Summary Delete photo comment
+// @Description Delete a comment you left on a photo
+// @Tags photos
+// @Produce json
+// @Param id path string true "Photo ID"
+// @Param commentId path string true "Comment ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /photos/{id}/comments/{commentId} [delete]
+func (h *PhotoCommentHandler) DeleteComment(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	photoID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidID(c, "Invalid photo ID")
+	}
+	commentID, err := primitive.ObjectIDFromHex(c.Params("commentId"))
+	if err != nil {
+		return h.invalidID(c, "Invalid comment ID")
+	}
+
+	if err := h.commentService.DeleteComment(c.Context(), photoID, commentID, userID); err != nil {
+		LogServiceError(c, err, "Delete photo comment",
+			zap.String("user_id", userID.Hex()),
+			zap.String("comment_id", commentID.Hex()))
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// invalidID returns the error for a malformed photo or comment ID
+func (h *PhotoCommentHandler) invalidID(c *fiber.Ctx, message string) error {
+	return domain.ErrInvalidRequestError(message)
+}
@@ -1,8 +1,10 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/eralove/eralove-backend/internal/domain"
 	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
@@ -37,119 +39,148 @@ func NewPhotoHandler(
 
 // CreatePhoto handles photo creation
 // @Summary Create a new photo
-// @Description Create a new photo with uploaded file path
+// @Description Create a new photo with uploaded file path. A file_path ending in .mp4 or .mov creates a video memory: the video is checked against the configured size and length limits and a poster frame is extracted to stand in for it in the gallery.
 // @Tags photos
 // @Accept json
 // @Produce json
 // @Param request body domain.CreatePhotoRequest true "Photo data with file_path"
+// @Param Idempotency-Key header string false "Unique key of the request, the same on retries, whose first response is replayed to them"
 // @Security BearerAuth
 // @Success 201 {object} domain.PhotoResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /photos [post]
 func (h *PhotoHandler) CreatePhoto(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Create photo")
+	LogRequestStart(c, "Create photo")
 	
 	userID := getUserIDFromContext(c)
 
 	// Parse JSON request
 	var req domain.CreatePhotoRequest
 	if err := c.BodyParser(&req); err != nil {
-		LogParsingError(h.logger, err, c, "Create photo")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		LogParsingError(err, c, "Create photo")
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
-	LogRequestParsed(h.logger, c, "Create photo", 
+	LogRequestParsed(c, "Create photo", 
 		zap.String("user_id", userID.Hex()),
 		zap.String("title", req.Title),
 		zap.String("file_path", req.FilePath))
 
 	// Validate request
 	if err := h.validator.Struct(&req); err != nil {
-		LogValidationError(h.logger, c, err, "Create photo", 
+		LogValidationError(c, err, "Create photo", 
 			zap.String("user_id", userID.Hex()))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "validation_failed", nil),
-			Details: getValidationErrors(err),
-		})
+		return domain.ErrValidationFailedError(getValidationErrors(err))
 	}
 
-	LogServiceCall(h.logger, c, "Create photo", 
+	LogServiceCall(c, "Create photo", 
 		zap.String("user_id", userID.Hex()),
 		zap.String("file_path", req.FilePath))
 
 	// Create photo
 	photo, err := h.photoService.CreatePhotoWithPath(c.Context(), userID, &req)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Create photo", 
+		LogServiceError(c, err, "Create photo", 
 			zap.String("user_id", userID.Hex()))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to create photo",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "internal_error", nil),
-		})
+		return err
 	}
 
-	LogServiceSuccess(h.logger, c, "Create photo", 
+	LogServiceSuccess(c, "Create photo", 
 		zap.String("user_id", userID.Hex()),
 		zap.String("photo_id", photo.ID))
 
-	return c.Status(fiber.StatusCreated).JSON(photo)
+	return Respond(c, fiber.StatusCreated, photo)
 }
 
 // GetPhotos handles getting user photos
 // @Summary Get user photos
-// @Description Get photos for the authenticated user
+// @Description Get photos for the authenticated user. Send the ETag back in If-None-Match to get 304 Not Modified while nothing changed.
 // @Tags photos
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Cursor from next_cursor; pass an empty cursor to start cursor pagination"
 // @Param partner_id query string false "Partner ID to filter shared photos"
+// @Param If-None-Match header string false "ETag of the last response"
+// @Param fields query string false "Comma-separated fields to keep in each item of the list; id is always kept"
 // @Security BearerAuth
 // @Success 200 {object} domain.PhotoListResponse
+// @Success 304
+// @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /photos [get]
 func (h *PhotoHandler) GetPhotos(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Get photos")
+	LogRequestStart(c, "Get photos")
 	
 	userID := getUserIDFromContext(c)
 
+	if c.Context().QueryArgs().Has("cursor") {
+		return h.getPhotosByCursor(c, userID)
+	}
+
 	// Parse query parameters
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 
-	LogRequestParsed(h.logger, c, "Get photos", 
+	LogRequestParsed(c, "Get photos", 
 		zap.String("user_id", userID.Hex()),
 		zap.Int("page", page),
 		zap.Int("limit", limit))
 
-	LogServiceCall(h.logger, c, "Get photos", 
+	LogServiceCall(c, "Get photos", 
 		zap.String("user_id", userID.Hex()))
 
 	photos, total, err := h.photoService.GetCouplePhotos(c.Context(), userID, page, limit)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Get photos", 
+		LogServiceError(c, err, "Get photos", 
 			zap.String("user_id", userID.Hex()))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to get photos",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "internal_error", nil),
-		})
+		return err
 	}
 
-	LogServiceSuccess(h.logger, c, "Get photos", 
+	LogServiceSuccess(c, "Get photos", 
 		zap.String("user_id", userID.Hex()),
 		zap.Int64("total", total),
 		zap.Int("count", len(photos)))
 
-	return c.JSON(domain.PhotoListResponse{
+	if notModified(c, photoListVersion(c, photos, total, page, limit)) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	return RespondPage(c, domain.PhotoListResponse{
 		Photos: photos,
 		Total:  total,
 		Page:   page,
 		Limit:  limit,
-	})
+	}, PageMeta(total, page, limit))
+}
+
+// getPhotosByCursor serves GetPhotos in cursor pagination mode
+func (h *PhotoHandler) getPhotosByCursor(c *fiber.Ctx, userID primitive.ObjectID) error {
+	cursor, err := domain.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Cursor must be a next_cursor value returned by a previous request")
+	}
+
+	limit := domain.NormalizeCursorLimit(c.QueryInt("limit", domain.DefaultCursorLimit))
+
+	photos, nextCursor, err := h.photoService.GetCouplePhotosCursor(c.Context(), userID, cursor, limit)
+	if err != nil {
+		LogServiceError(c, err, "Get photos",
+			zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	if notModified(c, photoListVersion(c, photos, c.Query("cursor"), limit, nextCursor)) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	return RespondPage(c, domain.PhotoListResponse{
+		Photos:     photos,
+		Total:      int64(len(photos)),
+		Limit:      limit,
+		NextCursor: nextCursor,
+	}, CursorMeta(limit, nextCursor))
 }
 
 // GetPhoto handles getting a specific photo
@@ -164,40 +195,105 @@ func (h *PhotoHandler) GetPhotos(c *fiber.Ctx) error {
 // @Failure 401 {object} ErrorResponse
 // @Router /photos/{id} [get]
 func (h *PhotoHandler) GetPhoto(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Get photo")
+	LogRequestStart(c, "Get photo")
 	
 	userID := getUserIDFromContext(c)
 	photoID, err := primitive.ObjectIDFromHex(c.Params("id"))
 	if err != nil {
-		LogValidationError(h.logger, c, err, "Get photo", 
+		LogValidationError(c, err, "Get photo", 
 			zap.String("user_id", userID.Hex()),
 			zap.String("photo_id_param", c.Params("id")))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid photo ID",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		return domain.ErrInvalidRequestError("Invalid photo ID")
 	}
 
-	LogServiceCall(h.logger, c, "Get photo", 
+	LogServiceCall(c, "Get photo", 
 		zap.String("user_id", userID.Hex()),
 		zap.String("photo_id", photoID.Hex()))
 
 	photo, err := h.photoService.GetPhoto(c.Context(), photoID, userID)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Get photo", 
+		LogServiceError(c, err, "Get photo", 
 			zap.String("user_id", userID.Hex()),
 			zap.String("photo_id", photoID.Hex()))
-		return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{
-			Error:   "Photo not found",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "not_found", nil),
-		})
+		return err
 	}
 
-	LogServiceSuccess(h.logger, c, "Get photo", 
+	LogServiceSuccess(c, "Get photo", 
 		zap.String("user_id", userID.Hex()),
 		zap.String("photo_id", photoID.Hex()))
 
-	return c.JSON(photo)
+	return Respond(c, fiber.StatusOK, photo)
+}
+
+// GetSharedPreview handles previewing a photo as it is shown outside the couple
+// @Summary Preview shared photo
+// @Description Get a photo as it appears on shared and public pages, watermarked if enabled in couple settings
+// @Tags photos
+// @Produce json
+// @Param id path string true "Photo ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.PhotoResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /photos/{id}/shared-preview [get]
+func (h *PhotoHandler) GetSharedPreview(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	photoID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Invalid photo ID")
+	}
+
+	photo, err := h.photoService.GetSharedPreview(c.Context(), photoID, userID)
+	if err != nil {
+		LogServiceError(c, err, "Get shared preview",
+			zap.String("user_id", userID.Hex()),
+			zap.String("photo_id", photoID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, photo)
+}
+
+// GetPhotoURL handles generating a short-lived download URL for a photo
+// @Summary Get photo download URL
+// @Description Get a presigned URL to download a photo's image or one of its variants, valid for expires_in seconds within the bounds configured on the server
+// @Tags photos
+// @Produce json
+// @Param id path string true "Photo ID"
+// @Param variant query string false "Image variant: original, medium or thumb" default(original)
+// @Param expires_in query int false "Seconds the URL stays valid, the server default when omitted"
+// @Security BearerAuth
+// @Success 200 {object} domain.PhotoURLResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /photos/{id}/url [get]
+func (h *PhotoHandler) GetPhotoURL(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	photoID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Invalid photo ID")
+	}
+
+	req := &domain.PhotoURLRequest{
+		Variant:   c.Query("variant"),
+		ExpiresIn: c.QueryInt("expires_in", 0),
+	}
+
+	url, err := h.photoService.GetPhotoURL(c.Context(), photoID, userID, req)
+	if err != nil {
+		LogServiceError(c, err, "Get photo URL",
+			zap.String("user_id", userID.Hex()),
+			zap.String("photo_id", photoID.Hex()))
+		return err
+	}
+
+	// The URL must not outlive its expiry in any cache
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	return Respond(c, fiber.StatusOK, url)
 }
 
 // UpdatePhoto handles photo updates
@@ -214,64 +310,52 @@ func (h *PhotoHandler) GetPhoto(c *fiber.Ctx) error {
 // @Failure 404 {object} ErrorResponse
 // @Router /photos/{id} [put]
 func (h *PhotoHandler) UpdatePhoto(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Update photo")
+	LogRequestStart(c, "Update photo")
 	
 	userID := getUserIDFromContext(c)
 	photoID, err := primitive.ObjectIDFromHex(c.Params("id"))
 	if err != nil {
-		LogValidationError(h.logger, c, err, "Update photo", 
+		LogValidationError(c, err, "Update photo", 
 			zap.String("user_id", userID.Hex()),
 			zap.String("photo_id_param", c.Params("id")))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid photo ID",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		return domain.ErrInvalidRequestError("Invalid photo ID")
 	}
 
 	var req domain.UpdatePhotoRequest
 	if err := c.BodyParser(&req); err != nil {
-		LogParsingError(h.logger, err, c, "Update photo")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		LogParsingError(err, c, "Update photo")
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
-	LogRequestParsed(h.logger, c, "Update photo", 
+	LogRequestParsed(c, "Update photo", 
 		zap.String("user_id", userID.Hex()),
 		zap.String("photo_id", photoID.Hex()),
 		zap.String("title", req.Title))
 
 	if err := h.validator.Struct(&req); err != nil {
-		LogValidationError(h.logger, c, err, "Update photo", 
+		LogValidationError(c, err, "Update photo", 
 			zap.String("user_id", userID.Hex()),
 			zap.String("photo_id", photoID.Hex()))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "validation_failed", nil),
-		})
+		return domain.ErrValidationFailedError(nil)
 	}
 
-	LogServiceCall(h.logger, c, "Update photo", 
+	LogServiceCall(c, "Update photo", 
 		zap.String("user_id", userID.Hex()),
 		zap.String("photo_id", photoID.Hex()))
 
 	photo, err := h.photoService.UpdatePhoto(c.Context(), photoID, userID, &req)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Update photo", 
+		LogServiceError(c, err, "Update photo", 
 			zap.String("user_id", userID.Hex()),
 			zap.String("photo_id", photoID.Hex()))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to update photo",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "internal_error", nil),
-		})
+		return err
 	}
 
-	LogServiceSuccess(h.logger, c, "Update photo", 
+	LogServiceSuccess(c, "Update photo", 
 		zap.String("user_id", userID.Hex()),
 		zap.String("photo_id", photoID.Hex()))
 
-	return c.JSON(photo)
+	return Respond(c, fiber.StatusOK, photo)
 }
 
 // DeletePhoto handles photo deletion
@@ -286,42 +370,336 @@ func (h *PhotoHandler) UpdatePhoto(c *fiber.Ctx) error {
 // @Failure 401 {object} ErrorResponse
 // @Router /photos/{id} [delete]
 func (h *PhotoHandler) DeletePhoto(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Delete photo")
+	LogRequestStart(c, "Delete photo")
 	
 	userID := getUserIDFromContext(c)
 	photoID, err := primitive.ObjectIDFromHex(c.Params("id"))
 	if err != nil {
-		LogValidationError(h.logger, c, err, "Delete photo", 
+		LogValidationError(c, err, "Delete photo", 
 			zap.String("user_id", userID.Hex()),
 			zap.String("photo_id_param", c.Params("id")))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid photo ID",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		return domain.ErrInvalidRequestError("Invalid photo ID")
 	}
 
-	LogServiceCall(h.logger, c, "Delete photo", 
+	LogServiceCall(c, "Delete photo", 
 		zap.String("user_id", userID.Hex()),
 		zap.String("photo_id", photoID.Hex()))
 
 	err = h.photoService.DeletePhoto(c.Context(), photoID, userID)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Delete photo", 
+		LogServiceError(c, err, "Delete photo", 
 			zap.String("user_id", userID.Hex()),
 			zap.String("photo_id", photoID.Hex()))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to delete photo",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "internal_error", nil),
-		})
+		return err
 	}
 
-	LogServiceSuccess(h.logger, c, "Delete photo", 
+	LogServiceSuccess(c, "Delete photo", 
 		zap.String("user_id", userID.Hex()),
 		zap.String("photo_id", photoID.Hex()))
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
+// BulkDeletePhotos handles bulk photo deletion
+// @Summary Bulk photo deletion
+// @Description Move several photos to the trash at once. Each requested ID is reported as succeeded, not_found or invalid_id.
+// @Tags photos
+// @Accept json
+// @Produce json
+// @Param request body domain.BulkIDsRequest true "Bulk request"
+// @Security BearerAuth
+// @Success 200 {object} domain.BulkResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /photos/bulk-delete [post]
+func (h *PhotoHandler) BulkDeletePhotos(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.BulkIDsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	result, err := h.photoService.BulkDeletePhotos(c.Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, result)
+}
+
+// BulkTagPhotos handles bulk photo tagging
+// @Summary Bulk photo tagging
+// @Description Add and remove tags on several photos at once. Each requested ID is reported as succeeded, not_found or invalid_id.
+// @Tags photos
+// @Accept json
+// @Produce json
+// @Param request body domain.BulkTagRequest true "Bulk request"
+// @Security BearerAuth
+// @Success 200 {object} domain.BulkResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /photos/bulk-tag [post]
+func (h *PhotoHandler) BulkTagPhotos(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.BulkTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	result, err := h.photoService.BulkTagPhotos(c.Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, result)
+}
+
+// FavoritePhoto handles marking a photo as a favorite
+// @Summary Favorite photo
+// @Description Mark a photo as a favorite of the authenticated user. Each partner has their own favorites; marking a favorite again has no effect.
+// @Tags photos
+// @Produce json
+// @Param id path string true "Photo ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.PhotoResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /photos/{id}/favorite [post]
+func (h *PhotoHandler) FavoritePhoto(c *fiber.Ctx) error {
+	return h.setFavorite(c, true)
+}
+
+// UnfavoritePhoto handles removing a photo from the favorites
+// @Summary Unfavorite photo
+// @Description Remove a photo from the favorites of the authenticated user
+// @Tags photos
+// @Produce json
+// @Param id path string true "Photo ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.PhotoResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /photos/{id}/favorite [delete]
+func (h *PhotoHandler) UnfavoritePhoto(c *fiber.Ctx) error {
+	return h.setFavorite(c, false)
+}
+
+// setFavorite serves FavoritePhoto and UnfavoritePhoto
+func (h *PhotoHandler) setFavorite(c *fiber.Ctx, favorite bool) error {
+	userID := getUserIDFromContext(c)
+	photoID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Invalid photo ID")
+	}
+
+	var photo *domain.PhotoResponse
+	if favorite {
+		photo, err = h.photoService.FavoritePhoto(c.Context(), photoID, userID)
+	} else {
+		photo, err = h.photoService.UnfavoritePhoto(c.Context(), photoID, userID)
+	}
+	if err != nil {
+		LogServiceError(c, err, "Set photo favorite",
+			zap.String("user_id", userID.Hex()),
+			zap.String("photo_id", photoID.Hex()),
+			zap.Bool("favorite", favorite))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, photo)
+}
+
+// GetFavoritePhotos handles listing the favorite photos
+// @Summary Get favorite photos
+// @Description Get the couple's photos the authenticated user marked as favorites, newest first
+// @Tags photos
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Param fields query string false "Comma-separated fields to keep in each item of the list; id is always kept"
+// @Security BearerAuth
+// @Success 200 {object} domain.PhotoListResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /photos/favorites [get]
+func (h *PhotoHandler) GetFavoritePhotos(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+
+	photos, total, err := h.photoService.GetFavoritePhotos(c.Context(), userID, page, limit)
+	if err != nil {
+		LogServiceError(c, err, "Get favorite photos", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return RespondPage(c, domain.PhotoListResponse{
+		Photos: photos,
+		Total:  total,
+		Page:   page,
+		Limit:  limit,
+	}, PageMeta(total, page, limit))
+}
+
+// GetDuplicatePhotos handles listing groups of near-identical photos
+// @Summary Get duplicate photos
+// @Description Get the couple's photos grouped by near-identical images, to clean up the gallery
+// @Tags photos
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.DuplicatePhotosResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /photos/duplicates [get]
+func (h *PhotoHandler) GetDuplicatePhotos(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	duplicates, err := h.photoService.GetDuplicatePhotos(c.Context(), userID)
+	if err != nil {
+		LogServiceError(c, err, "Get duplicate photos", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, duplicates)
+}
+
+// photoSearchMaxQueryLength is the longest photo search query accepted
+const photoSearchMaxQueryLength = 200
+
+// SearchPhotos handles searching the couple's photos
+// @Summary Search photos
+// @Description Search the couple's photos by words, tags, date range, location, album and privacy. Filters combine, and every word of the query must appear in the title, tags, location or description. Results are sorted by relevance when a query is given and newest first otherwise. The tags facet counts the most used tags of every matching photo, not just the returned page.
+// @Tags photos
+// @Produce json
+// @Param q query string false "Words to search for"
+// @Param tags query string false "Comma-separated tags the photos must all carry"
+// @Param from query string false "First photo date (YYYY-MM-DD)"
+// @Param to query string false "Last photo date (YYYY-MM-DD)"
+// @Param location query string false "Part of the location"
+// @Param album_id query string false "Album ID"
+// @Param is_private query bool false "Only private or only shared photos"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Security BearerAuth
+// @Success 200 {object} domain.PhotoSearchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /photos/search [get]
+func (h *PhotoHandler) SearchPhotos(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	req := domain.PhotoSearchRequest{
+		Query:    strings.TrimSpace(c.Query("q")),
+		Tags:     parseCommaSeparatedTags(c.Query("tags")),
+		Location: c.Query("location"),
+		AlbumID:  c.Query("album_id"),
+	}
+	if utf8.RuneCountInString(req.Query) > photoSearchMaxQueryLength {
+		return domain.ErrInvalidRequestError("Search query must be at most 200 characters")
+	}
+
+	var err error
+	if req.From, err = parseDateQuery(c, "from"); err != nil {
+		return domain.ErrInvalidRequestError("Invalid from: " + err.Error())
+	}
+	if req.To, err = parseDateQuery(c, "to"); err != nil {
+		return domain.ErrInvalidRequestError("Invalid to: " + err.Error())
+	}
+
+	if raw := c.Query("is_private"); raw != "" {
+		isPrivate, err := strconv.ParseBool(raw)
+		if err != nil {
+			return domain.ErrInvalidRequestError("is_private must be true or false")
+		}
+		req.IsPrivate = &isPrivate
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	limit := domain.NormalizeCursorLimit(c.QueryInt("limit", domain.DefaultCursorLimit))
+
+	results, err := h.photoService.SearchPhotos(c.Context(), userID, &req, page, limit)
+	if err != nil {
+		LogServiceError(c, err, "Search photos", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, results)
+}
+
+// GetPhotoMap handles listing the couple's geo-tagged photos as map clusters
+// @Summary Get photo map
+// @Description Get the couple's photos that have a place, grouped into clusters of photos taken close to each other for the map's zoom level, largest clusters first. Each cluster has the average position of its photos and its newest photo as cover. Limit the clusters to the visible area with bbox, and to a period with from and to.
+// @Tags photos
+// @Produce json
+// @Param zoom query int false "Map zoom level, from 0 (whole world) to 20" default(2)
+// @Param bbox query string false "Visible area as west,south,east,north in degrees; west may exceed east across the antimeridian"
+// @Param from query string false "First photo date (YYYY-MM-DD)"
+// @Param to query string false "Last photo date (YYYY-MM-DD)"
+// @Security BearerAuth
+// @Success 200 {object} domain.PhotoMapResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /photos/map [get]
+func (h *PhotoHandler) GetPhotoMap(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	query := domain.PhotoMapQuery{Zoom: c.QueryInt("zoom", 2)}
+
+	if raw := c.Query("bbox"); raw != "" {
+		bounds, err := parseBoundingBox(raw)
+		if err != nil {
+			return domain.ErrInvalidRequestError("Invalid bbox: " + err.Error())
+		}
+		query.Bounds = bounds
+	}
+
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		return domain.ErrInvalidRequestError("Invalid from: " + err.Error())
+	}
+	if from != nil {
+		query.From = &from.Time
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		return domain.ErrInvalidRequestError("Invalid to: " + err.Error())
+	}
+	if to != nil {
+		end := to.Time.AddDate(0, 0, 1)
+		query.To = &end
+	}
+
+	photoMap, err := h.photoService.GetPhotoMap(c.Context(), userID, &query)
+	if err != nil {
+		LogServiceError(c, err, "Get photo map", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, photoMap)
+}
+
 // Helper functions
 func parseCommaSeparatedTags(tagsStr string) []string {
 	if tagsStr == "" {
@@ -336,3 +714,40 @@ func parseCommaSeparatedTags(tagsStr string) []string {
 	}
 	return tags
 }
+
+// parseBoundingBox parses a west,south,east,north bounding box in degrees
+func parseBoundingBox(raw string) (*domain.BoundingBox, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != 4 {
+		return nil, errors.New("bbox must be west,south,east,north")
+	}
+
+	values := make([]float64, len(parts))
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, errors.New("bbox must be west,south,east,north")
+		}
+		values[i] = value
+	}
+
+	bounds := &domain.BoundingBox{West: values[0], South: values[1], East: values[2], North: values[3]}
+	if bounds.South < -90 || bounds.North > 90 || bounds.South > bounds.North {
+		return nil, errors.New("bbox latitudes must be between -90 and 90, south first")
+	}
+	if bounds.West < -180 || bounds.West > 180 || bounds.East < -180 || bounds.East > 180 {
+		return nil, errors.New("bbox longitudes must be between -180 and 180")
+	}
+	return bounds, nil
+}
+
+// photoListVersion returns the version of a page of photos. Favorites are part of
+// it, as they do not update the photos.
+func photoListVersion(c *fiber.Ctx, photos []*domain.PhotoResponse, page ...interface{}) *resourceVersion {
+	version := newResourceVersion(c)
+	version.add(page...)
+	for _, photo := range photos {
+		version.add(photo.ID, photo.UpdatedAt, photo.FavoriteCount, photo.IsFavorite)
+	}
+	return version
+}
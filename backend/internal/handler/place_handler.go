@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// Place search limits: the query length accepted and the number of places returned
+const (
+	placeSearchMaxQueryLength = 200
+	placeSearchDefaultLimit   = 5
+	placeSearchMaxLimit       = 10
+)
+
+// PlaceHandler handles place autocomplete HTTP requests
+type PlaceHandler struct {
+	placeService domain.PlaceService
+	i18n         *i18n.I18n
+	logger       *zap.Logger
+}
+
+// NewPlaceHandler creates a new place handler
+func NewPlaceHandler(
+	placeService domain.PlaceService,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *PlaceHandler {
+	return &PlaceHandler{
+		placeService: placeService,
+		i18n:         i18n,
+		logger:       logger,
+	}
+}
+
+// SearchPlaces handles looking up places to tag photos and events with
+// @Summary Search places
+// @Description Look up places by name or address, best match first, with names in the user's language where available. Pass a returned place as the place of a photo or event to store its coordinates. Returns 503 when place search is not configured.
+// @Tags places
+// @Produce json
+// @Param q query string true "Place name or address (at least 2 characters)"
+// @Param limit query int false "Number of places" default(5)
+// @Security BearerAuth
+// @Success 200 {object} domain.PlaceSearchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /places/search [get]
+func (h *PlaceHandler) SearchPlaces(c *fiber.Ctx) error {
+	query := strings.TrimSpace(c.Query("q"))
+	if length := utf8.RuneCountInString(query); length < searchMinQueryLength || length > placeSearchMaxQueryLength {
+		return domain.ErrInvalidRequestError("Search query must be between 2 and 200 characters")
+	}
+
+	limit := c.QueryInt("limit", placeSearchDefaultLimit)
+	if limit < 1 || limit > placeSearchMaxLimit {
+		limit = placeSearchDefaultLimit
+	}
+
+	places, err := h.placeService.SearchPlaces(c.Context(), query, getLocale(c), limit)
+	if err != nil {
+		LogServiceError(c, err, "Search places")
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, places)
+}
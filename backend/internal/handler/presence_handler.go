@@ -0,0 +1,234 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/eralove/eralove-backend/internal/infrastructure/websocket"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// PresenceHandler handles partner presence HTTP requests
+type PresenceHandler struct {
+	presenceService domain.PresenceService
+	validator       *validator.Validate
+	i18n            *i18n.I18n
+	logger          *zap.Logger
+}
+
+// NewPresenceHandler creates a new presence handler
+func NewPresenceHandler(
+	presenceService domain.PresenceService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *PresenceHandler {
+	return &PresenceHandler{
+		presenceService: presenceService,
+		validator:       validator,
+		i18n:            i18n,
+		logger:          logger,
+	}
+}
+
+// Connect handles the presence WebSocket
+// @Summary Partner presence
+// @Description Open a WebSocket that shows whether the partner has the same photo or event open. Send {"type":"viewing","resource_type":"photo","resource_id":"..."} when opening a resource and {"type":"stop"} when closing it. The server sends {"partner_viewing":true|false} whenever that changes, and an error response followed by a close frame when the connection cannot be served, e.g. for users without a partner. Nothing is shared when the couple hides its presence in the couple settings.
+// @Tags couple
+// @Security BearerAuth
+// @Success 101
+// @Failure 401 {object} ErrorResponse
+// @Failure 426 {object} ErrorResponse
+// @Router /couple/presence/ws [get]
+func (h *PresenceHandler) Connect(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	lang := getLocale(c)
+
+	return websocket.Upgrade(c, func(conn *websocket.Conn) {
+		h.serve(conn, userID, lang)
+	})
+}
+
+// serve relays presence between the connection and the user's partner until either
+// side goes away. Everything but reading runs on this goroutine.
+func (h *PresenceHandler) serve(conn *websocket.Conn, userID primitive.ObjectID, lang string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updates, err := h.presenceService.Subscribe(ctx, userID)
+	if err != nil {
+		h.closeWithError(conn, lang, err)
+		return
+	}
+
+	interval := h.presenceService.RefreshInterval()
+	conn.SetReadTimeout(2 * interval)
+
+	messages := make(chan []byte)
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		defer close(messages)
+		for {
+			message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			select {
+			case messages <- message:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	defer func() {
+		cancel()
+		conn.Close(websocket.CloseGoingAway)
+		<-readDone
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var viewing *domain.ViewingRequest
+	var partnerViewing *bool
+	send := func(response *domain.PresenceResponse) bool {
+		if partnerViewing != nil && *partnerViewing == response.PartnerViewing {
+			return true
+		}
+		partnerViewing = &response.PartnerViewing
+		return h.write(conn, response)
+	}
+	stop := func(ctx context.Context) {
+		if viewing == nil {
+			return
+		}
+		viewing = nil
+		if err := h.presenceService.StopViewing(ctx, userID); err != nil {
+			h.logger.Warn("Failed to stop presence", zap.Error(err), zap.String("user_id", userID.Hex()))
+		}
+	}
+	// ctx is cancelled by the time the connection is gone
+	defer stop(context.Background())
+
+	for {
+		select {
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			var msg domain.PresenceMessage
+			if err := json.Unmarshal(message, &msg); err != nil {
+				if !h.write(conn, ErrorResponse{
+					Error:   "Invalid request body",
+					Message: h.i18n.Translate(lang, "invalid_request", nil),
+				}) {
+					return
+				}
+				continue
+			}
+			if err := h.validatePresenceMessage(&msg); err != nil {
+				if !h.write(conn, ErrorResponse{
+					Error:   "Validation failed",
+					Message: h.i18n.Translate(lang, "validation_failed", nil),
+					Details: getValidationErrors(err),
+				}) {
+					return
+				}
+				continue
+			}
+
+			if msg.Type == domain.PresenceMessageStop {
+				stop(ctx)
+				if !send(&domain.PresenceResponse{}) {
+					return
+				}
+				continue
+			}
+
+			viewing = &msg.ViewingRequest
+			response, err := h.presenceService.Viewing(ctx, userID, viewing)
+			if err != nil {
+				h.closeWithError(conn, lang, err)
+				return
+			}
+			if !send(response) {
+				return
+			}
+
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			if !send(&domain.PresenceResponse{
+				PartnerViewing: viewing != nil && update.Resource == viewing.Resource(),
+			}) {
+				return
+			}
+
+		case <-ticker.C:
+			if err := conn.Ping(); err != nil {
+				return
+			}
+			if viewing == nil {
+				continue
+			}
+			// Keep the entry from expiring for as long as the resource stays open
+			response, err := h.presenceService.Viewing(ctx, userID, viewing)
+			if err != nil {
+				h.closeWithError(conn, lang, err)
+				return
+			}
+			if !send(response) {
+				return
+			}
+		}
+	}
+}
+
+// validatePresenceMessage validates a message and, for "viewing", the resource it names
+func (h *PresenceHandler) validatePresenceMessage(msg *domain.PresenceMessage) error {
+	if err := h.validator.Struct(msg); err != nil {
+		return err
+	}
+	if msg.Type == domain.PresenceMessageViewing {
+		return h.validator.Struct(&msg.ViewingRequest)
+	}
+	return nil
+}
+
+// write sends a JSON message and reports whether the connection is still usable
+func (h *PresenceHandler) write(conn *websocket.Conn, message interface{}) bool {
+	data, err := json.Marshal(message)
+	if err != nil {
+		h.logger.Error("Failed to encode presence message", zap.Error(err))
+		return false
+	}
+	return conn.WriteMessage(data) == nil
+}
+
+// closeWithError sends the error the way the error handler would respond with it and
+// closes the connection
+func (h *PresenceHandler) closeWithError(conn *websocket.Conn, lang string, err error) {
+	appErr := toAppError(err)
+	code := websocket.ClosePolicyViolation
+	if appErr.StatusCode == 0 || appErr.StatusCode >= fiber.StatusInternalServerError {
+		h.logger.Error("Presence connection failed", zap.Error(err))
+		code = websocket.CloseInternalError
+	}
+
+	h.write(conn, ErrorResponse{
+		Code:    int(appErr.Code),
+		Error:   appErr.Message,
+		Message: translateError(h.i18n, lang, appErr),
+		Details: appErr.Details,
+	})
+	conn.Close(code)
+}
@@ -1,8 +1,14 @@
 package handler
 
 import (
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/config"
 	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/auth"
 	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/eralove/eralove-backend/internal/infrastructure/origins"
+	"github.com/eralove/eralove-backend/internal/infrastructure/secrets"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/wire"
 	"go.uber.org/zap"
@@ -10,23 +16,72 @@ import (
 
 // HandlerSet provides all handler dependencies
 var HandlerSet = wire.NewSet(
+	ProvideSessionCookies,
 	ProvideUserHandler,
 	ProvidePhotoHandler,
 	ProvideEventHandler,
 	ProvideMatchRequestHandler,
 	ProvideUploadHandler,
+	ProvideInsightHandler,
+	ProvideGoalHandler,
+	ProvideAlbumHandler,
+	ProvideNotificationHandler,
+	ProvideAffirmationHandler,
+	ProvideCoupleSettingsHandler,
+	ProvideShareLinkHandler,
+	ProvideSearchHandler,
+	ProvideTrashHandler,
+	ProvideErrorHandler,
+	ProvideTimelineHandler,
+	ProvideFeedbackHandler,
+	ProvideCalendarHandler,
+	ProvideClientErrorHandler,
+	ProvideChangelogHandler,
+	ProvideRetentionHandler,
+	ProvidePendingActionHandler,
+	ProvideCoupleKeyHandler,
+	ProvideMessageSearchHandler,
+	ProvideOIDCHandler,
+	ProvideStorageIntegrityHandler,
+	ProvideCoupleBadgeHandler,
+	ProvidePresenceHandler,
+	ProvideAccountMergeHandler,
+	ProvideCORSHandler,
+	ProvideJWTKeyHandler,
+	ProvideUsageHandler,
+	ProvidePhotoCommentHandler,
+	ProvideJournalHandler,
+	ProvideBucketListHandler,
+	ProvideMoodHandler,
+	ProvideAutoMilestoneHandler,
+	ProvideCountdownHandler,
+	ProvideAdminHandler,
+	ProvideAuditHandler,
+	ProvideMemoriesHandler,
+	ProvidePlaceHandler,
+	ProvideFileHandler,
+	ProvideEngagementHandler,
+	ProvideDailyQuestionHandler,
+	ProvideWishlistHandler,
+	ProvideDatePlanHandler,
 	// TODO: Uncomment when services are implemented
-	// ProvideMessageHandler,
+	ProvideMessageHandler,
 )
 
+// ProvideSessionCookies provides the browser session cookie manager
+func ProvideSessionCookies(cfg *config.Config) *SessionCookies {
+	return NewSessionCookies(cfg)
+}
+
 // ProvideUserHandler provides a user handler
 func ProvideUserHandler(
 	userService domain.UserService,
+	cookies *SessionCookies,
 	validator *validator.Validate,
 	i18nService *i18n.I18n,
 	logger *zap.Logger,
 ) *UserHandler {
-	return NewUserHandler(userService, validator, i18nService, logger)
+	return NewUserHandler(userService, cookies, validator, i18nService, logger)
 }
 
 // ProvidePhotoHandler provides a photo handler
@@ -42,22 +97,23 @@ func ProvidePhotoHandler(
 // ProvideEventHandler provides an event handler
 func ProvideEventHandler(
 	eventService domain.EventService,
+	settingsService domain.CoupleSettingsService,
 	validator *validator.Validate,
 	i18nService *i18n.I18n,
 	logger *zap.Logger,
 ) *EventHandler {
-	return NewEventHandler(eventService, validator, i18nService, logger)
+	return NewEventHandler(eventService, settingsService, validator, i18nService, logger)
 }
 
-// // ProvideMessageHandler provides a message handler
-// func ProvideMessageHandler(
-// 	messageService domain.MessageService,
-// 	validator *validator.Validate,
-// 	i18nService *i18n.I18n,
-// 	logger *zap.Logger,
-// ) *MessageHandler {
-// 	return NewMessageHandler(messageService, validator, i18nService, logger)
-// }
+// ProvideMessageHandler provides a message handler
+func ProvideMessageHandler(
+	messageService domain.MessageService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *MessageHandler {
+	return NewMessageHandler(messageService, validator, i18nService, logger)
+}
 
 // ProvideMatchRequestHandler provides a match request handler
 func ProvideMatchRequestHandler(
@@ -72,8 +128,363 @@ func ProvideMatchRequestHandler(
 // ProvideUploadHandler provides an upload handler
 func ProvideUploadHandler(
 	storageService domain.StorageService,
+	uploadSessionService domain.UploadSessionService,
+	scanService domain.UploadScanService,
+	uploadPolicy *domain.UploadPolicy,
+	validator *validator.Validate,
 	i18nService *i18n.I18n,
 	logger *zap.Logger,
 ) *UploadHandler {
-	return NewUploadHandler(storageService, i18nService, logger)
+	return NewUploadHandler(storageService, uploadSessionService, scanService, uploadPolicy, validator, i18nService, logger)
+}
+
+// ProvideInsightHandler provides a fun insights handler
+func ProvideInsightHandler(
+	insightService domain.InsightService,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *InsightHandler {
+	return NewInsightHandler(insightService, i18nService, logger)
+}
+
+// ProvideGoalHandler provides a goal handler
+func ProvideGoalHandler(
+	goalService domain.GoalService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *GoalHandler {
+	return NewGoalHandler(goalService, validator, i18nService, logger)
+}
+
+// ProvideAlbumHandler provides an album handler
+func ProvideAlbumHandler(
+	albumService domain.AlbumService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *AlbumHandler {
+	return NewAlbumHandler(albumService, validator, i18nService, logger)
+}
+
+// ProvideNotificationHandler provides a notification handler
+func ProvideNotificationHandler(
+	notificationService domain.NotificationService,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *NotificationHandler {
+	return NewNotificationHandler(notificationService, i18nService, logger)
+}
+
+// ProvideAffirmationHandler provides an affirmation handler
+func ProvideAffirmationHandler(
+	affirmationService domain.AffirmationService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *AffirmationHandler {
+	return NewAffirmationHandler(affirmationService, validator, i18nService, logger)
+}
+
+// ProvideCoupleSettingsHandler provides a couple settings handler
+func ProvideCoupleSettingsHandler(
+	settingsService domain.CoupleSettingsService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *CoupleSettingsHandler {
+	return NewCoupleSettingsHandler(settingsService, validator, i18nService, logger)
+}
+
+// ProvideShareLinkHandler provides a share link handler
+func ProvideShareLinkHandler(
+	shareLinkService domain.ShareLinkService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *ShareLinkHandler {
+	return NewShareLinkHandler(shareLinkService, validator, i18nService, logger)
+}
+
+// ProvideSearchHandler provides a search handler
+func ProvideSearchHandler(
+	searchService domain.SearchService,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *SearchHandler {
+	return NewSearchHandler(searchService, i18nService, logger)
+}
+
+// ProvideTrashHandler provides a trash handler
+func ProvideTrashHandler(
+	trashService domain.TrashService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *TrashHandler {
+	return NewTrashHandler(trashService, validator, i18nService, logger)
+}
+
+// ProvideTimelineHandler provides a timeline handler
+func ProvideTimelineHandler(
+	timelineService domain.TimelineService,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *TimelineHandler {
+	return NewTimelineHandler(timelineService, i18nService, logger)
+}
+
+// ProvideFeedbackHandler provides a feedback handler
+func ProvideFeedbackHandler(
+	feedbackService domain.FeedbackService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *FeedbackHandler {
+	return NewFeedbackHandler(feedbackService, validator, i18nService, logger)
+}
+
+// ProvideCalendarHandler provides a calendar handler
+func ProvideCalendarHandler(
+	calendarService domain.CalendarService,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *CalendarHandler {
+	return NewCalendarHandler(calendarService, i18nService, logger)
+}
+
+// ProvideClientErrorHandler provides a client error handler
+func ProvideClientErrorHandler(
+	clientErrorService domain.ClientErrorService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *ClientErrorHandler {
+	return NewClientErrorHandler(clientErrorService, validator, i18nService, logger)
+}
+
+// ProvideChangelogHandler provides a changelog handler
+func ProvideChangelogHandler(
+	changelogService domain.ChangelogService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *ChangelogHandler {
+	return NewChangelogHandler(changelogService, validator, i18nService, logger)
+}
+
+// ProvideRetentionHandler provides a retention handler
+func ProvideRetentionHandler(
+	retentionService domain.RetentionService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *RetentionHandler {
+	return NewRetentionHandler(retentionService, validator, i18nService, logger)
+}
+
+// ProvidePendingActionHandler provides a pending action handler
+func ProvidePendingActionHandler(
+	pendingActionService domain.PendingActionService,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *PendingActionHandler {
+	return NewPendingActionHandler(pendingActionService, i18nService, logger)
+}
+
+// ProvideCoupleKeyHandler provides a couple key handler
+func ProvideCoupleKeyHandler(
+	coupleKeyService domain.CoupleKeyService,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *CoupleKeyHandler {
+	return NewCoupleKeyHandler(coupleKeyService, i18nService, logger)
+}
+
+// ProvideMessageSearchHandler provides a message search handler
+func ProvideMessageSearchHandler(
+	searchService domain.MessageSearchService,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *MessageSearchHandler {
+	return NewMessageSearchHandler(searchService, i18nService, logger)
+}
+
+// ProvideOIDCHandler provides the OpenID Connect handler
+func ProvideOIDCHandler(oidcService domain.OIDCService, logger *zap.Logger) *OIDCHandler {
+	return NewOIDCHandler(oidcService, logger)
+}
+
+// ProvideStorageIntegrityHandler provides a storage integrity handler
+func ProvideStorageIntegrityHandler(integrityService domain.StorageIntegrityService, logger *zap.Logger) *StorageIntegrityHandler {
+	return NewStorageIntegrityHandler(integrityService, logger)
+}
+
+// ProvideCoupleBadgeHandler provides a couple badge handler
+func ProvideCoupleBadgeHandler(badgeService domain.CoupleBadgeService, cfg *config.Config, logger *zap.Logger) *CoupleBadgeHandler {
+	return NewCoupleBadgeHandler(badgeService, time.Duration(cfg.BadgeCacheTTL)*time.Second, logger)
+}
+
+// ProvideErrorHandler provides the central error handler
+func ProvideErrorHandler(
+	i18nService *i18n.I18n,
+	traceRepo domain.RequestTraceRepository,
+	logger *zap.Logger,
+) *ErrorHandler {
+	return NewErrorHandler(i18nService, traceRepo, logger)
+}
+
+// ProvidePresenceHandler provides a presence handler
+func ProvidePresenceHandler(
+	presenceService domain.PresenceService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *PresenceHandler {
+	return NewPresenceHandler(presenceService, validator, i18nService, logger)
+}
+
+// ProvideCORSHandler provides a CORS administration handler
+func ProvideCORSHandler(registry *origins.Registry, logger *zap.Logger) *CORSHandler {
+	return NewCORSHandler(registry, logger)
+}
+
+// ProvideJWTKeyHandler provides a JWT signing key administration handler
+func ProvideJWTKeyHandler(jwtManager *auth.JWTManager, reloader *secrets.Reloader, logger *zap.Logger) *JWTKeyHandler {
+	return NewJWTKeyHandler(jwtManager, reloader, logger)
+}
+
+// ProvideAccountMergeHandler provides an account merge handler
+func ProvideAccountMergeHandler(
+	mergeService domain.AccountMergeService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *AccountMergeHandler {
+	return NewAccountMergeHandler(mergeService, validator, i18nService, logger)
+}
+
+// ProvideUsageHandler provides a couple usage handler
+func ProvideUsageHandler(usageService domain.UsageService, logger *zap.Logger) *UsageHandler {
+	return NewUsageHandler(usageService, logger)
+}
+
+// ProvidePhotoCommentHandler provides a photo comment handler
+func ProvidePhotoCommentHandler(
+	commentService domain.PhotoCommentService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *PhotoCommentHandler {
+	return NewPhotoCommentHandler(commentService, validator, i18nService, logger)
+}
+
+// ProvideJournalHandler provides a couple journal handler
+func ProvideJournalHandler(
+	journalService domain.JournalService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *JournalHandler {
+	return NewJournalHandler(journalService, validator, i18nService, logger)
+}
+
+// ProvideBucketListHandler provides a bucket list handler
+func ProvideBucketListHandler(
+	bucketListService domain.BucketListService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *BucketListHandler {
+	return NewBucketListHandler(bucketListService, validator, i18nService, logger)
+}
+
+// ProvideMoodHandler provides a mood check-in handler
+func ProvideMoodHandler(
+	moodService domain.MoodService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *MoodHandler {
+	return NewMoodHandler(moodService, validator, i18nService, logger)
+}
+
+// ProvideAutoMilestoneHandler provides a milestone event settings handler
+func ProvideAutoMilestoneHandler(
+	autoMilestoneService domain.AutoMilestoneService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *AutoMilestoneHandler {
+	return NewAutoMilestoneHandler(autoMilestoneService, validator, i18nService, logger)
+}
+
+// ProvideCountdownHandler provides a countdown handler
+func ProvideCountdownHandler(
+	countdownService domain.CountdownService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *CountdownHandler {
+	return NewCountdownHandler(countdownService, validator, i18nService, logger)
+}
+
+// ProvideAdminHandler provides the handler for the support staff operations on accounts
+func ProvideAdminHandler(adminService domain.AdminService, validator *validator.Validate, i18nService *i18n.I18n, logger *zap.Logger) *AdminHandler {
+	return NewAdminHandler(adminService, validator, i18nService, logger)
+}
+
+// ProvideAuditHandler provides an audit log handler
+func ProvideAuditHandler(auditService domain.AuditService, logger *zap.Logger) *AuditHandler {
+	return NewAuditHandler(auditService, logger)
+}
+
+// ProvideMemoriesHandler provides an "on this day" memories handler
+func ProvideMemoriesHandler(memoriesService domain.MemoriesService, i18nService *i18n.I18n, logger *zap.Logger) *MemoriesHandler {
+	return NewMemoriesHandler(memoriesService, i18nService, logger)
+}
+
+// ProvidePlaceHandler provides a place search handler
+func ProvidePlaceHandler(placeService domain.PlaceService, i18nService *i18n.I18n, logger *zap.Logger) *PlaceHandler {
+	return NewPlaceHandler(placeService, i18nService, logger)
+}
+
+// ProvideFileHandler provides a file download handler
+func ProvideFileHandler(fileService domain.FileService, cfg *config.Config, logger *zap.Logger) *FileHandler {
+	return NewFileHandler(fileService, time.Duration(cfg.CDNCacheMaxAge)*time.Second, logger)
+}
+
+// ProvideEngagementHandler provides a couple engagement statistics handler
+func ProvideEngagementHandler(engagementService domain.EngagementService, logger *zap.Logger) *EngagementHandler {
+	return NewEngagementHandler(engagementService, logger)
+}
+
+// ProvideDailyQuestionHandler provides a daily question handler
+func ProvideDailyQuestionHandler(
+	questionService domain.DailyQuestionService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *DailyQuestionHandler {
+	return NewDailyQuestionHandler(questionService, validator, i18nService, logger)
+}
+
+// ProvideWishlistHandler provides a wishlist handler
+func ProvideWishlistHandler(
+	wishlistService domain.WishlistService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *WishlistHandler {
+	return NewWishlistHandler(wishlistService, validator, i18nService, logger)
+}
+
+// ProvideDatePlanHandler provides a date plan handler
+func ProvideDatePlanHandler(
+	planService domain.DatePlanService,
+	validator *validator.Validate,
+	i18nService *i18n.I18n,
+	logger *zap.Logger,
+) *DatePlanHandler {
+	return NewDatePlanHandler(planService, validator, i18nService, logger)
 }
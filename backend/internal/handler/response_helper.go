@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// apiVersion1 is the API version whose handlers return their data as is, kept for the
+// clients written against it
+const apiVersion1 = "v1"
+
+// PaginationMeta describes the page of a list response
+type PaginationMeta struct {
+	Total      *int64 `json:"total,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PageMeta describes a page of a paginated list
+func PageMeta(total int64, page, limit int) *PaginationMeta {
+	return &PaginationMeta{Total: &total, Page: page, Limit: limit}
+}
+
+// CursorMeta describes a page of a list paginated by cursor
+func CursorMeta(limit int, nextCursor string) *PaginationMeta {
+	return &PaginationMeta{Limit: limit, NextCursor: nextCursor}
+}
+
+// Respond writes a successful response with data. Requests to /api/v1 get data as
+// it always was; later versions get it in a SuccessResponse envelope.
+func Respond(c *fiber.Ctx, status int, data interface{}) error {
+	return respond(c, status, data, nil)
+}
+
+// RespondPage writes a page of a list. The page's pagination is repeated in the
+// envelope's meta, outside of /api/v1. With ?fields=, the items of the list only
+// have the requested fields and their id, for clients such as gallery grids that
+// do not show the rest.
+func RespondPage(c *fiber.Ctx, data interface{}, meta *PaginationMeta) error {
+	if fields := selectedFields(c); fields != nil {
+		selected, err := selectItemFields(data, fields)
+		if err != nil {
+			return err
+		}
+		data = selected
+	}
+	return respond(c, fiber.StatusOK, data, meta)
+}
+
+// RespondMessage writes a SuccessResponse with a message and optional data, in every
+// API version
+func RespondMessage(c *fiber.Ctx, status int, data interface{}, message string) error {
+	return c.Status(status).JSON(SuccessResponse{
+		Success: true,
+		Data:    data,
+		Message: message,
+		TraceID: getTraceID(c),
+	})
+}
+
+// respond writes data as is to /api/v1 requests, and in an envelope otherwise
+func respond(c *fiber.Ctx, status int, data interface{}, meta *PaginationMeta) error {
+	if isAPIVersion1(c) {
+		return c.Status(status).JSON(data)
+	}
+
+	return c.Status(status).JSON(SuccessResponse{
+		Success: true,
+		Data:    data,
+		Meta:    meta,
+		TraceID: getTraceID(c),
+	})
+}
+
+// isAPIVersion1 reports whether the request was made to /api/v1. Requests outside of
+// a versioned group are answered as version 1 requests.
+func isAPIVersion1(c *fiber.Ctx) bool {
+	version, _ := c.Locals(APIVersionKey).(string)
+	return version == "" || version == apiVersion1
+}
+
+// selectedFields returns the fields requested with ?fields=, or nil to keep every
+// field. The id is always kept.
+func selectedFields(c *fiber.Ctx) map[string]bool {
+	query := strings.TrimSpace(c.Query("fields"))
+	if query == "" {
+		return nil
+	}
+
+	fields := map[string]bool{"id": true}
+	for _, field := range strings.Split(query, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields[field] = true
+		}
+	}
+	return fields
+}
+
+// selectItemFields trims the items of the lists of a response to fields. Lists are the
+// arrays of objects among the response's fields; the rest of the response, such as
+// its total, is kept.
+func selectItemFields(data interface{}, fields map[string]bool) (interface{}, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(body, &response); err != nil {
+		return data, nil
+	}
+
+	for name, value := range response {
+		var items []map[string]json.RawMessage
+		if err := json.Unmarshal(value, &items); err != nil {
+			continue
+		}
+
+		for _, item := range items {
+			for field := range item {
+				if !fields[field] {
+					delete(item, field)
+				}
+			}
+		}
+
+		trimmed, err := json.Marshal(items)
+		if err != nil {
+			return nil, err
+		}
+		response[name] = trimmed
+	}
+	return response, nil
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// RetentionHandler handles data retention HTTP requests
+type RetentionHandler struct {
+	retentionService domain.RetentionService
+	validator        *validator.Validate
+	i18n             *i18n.I18n
+	logger           *zap.Logger
+}
+
+// NewRetentionHandler creates a new retention handler
+func NewRetentionHandler(
+	retentionService domain.RetentionService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+		validator:        validator,
+		i18n:             i18n,
+		logger:           logger,
+	}
+}
+
+// ListPolicies handles listing the retention policies
+// @Summary List retention policies
+// @Description List the enabled data retention policies and how old records must be before they are purged. Admin only.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {array} domain.RetentionPolicyInfo
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/retention/policies [get]
+func (h *RetentionHandler) ListPolicies(c *fiber.Ctx) error {
+	return Respond(c, fiber.StatusOK, h.retentionService.ListPolicies(c.Context()))
+}
+
+// RunRetention handles running the retention policies on demand
+// @Summary Run retention policies
+// @Description Apply the retention policies now and report what was purged. Runs are dry runs, which only report what would be purged, unless dry_run is false. Every purge, and every dry run, is recorded in the retention audit. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body domain.RunRetentionRequest false "Run options"
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} domain.RetentionReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/retention/runs [post]
+func (h *RetentionHandler) RunRetention(c *fiber.Ctx) error {
+	var req domain.RunRetentionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return domain.ErrInvalidRequestError("Invalid request body")
+		}
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	report, err := h.retentionService.Run(c.Context(), &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, report)
+}
+
+// ListAudit handles listing the retention audit
+// @Summary List retention audit
+// @Description List retention purges and dry runs newest first, with the IDs of the records each one covered. Admin only. Pass next_cursor back as cursor to get the next page.
+// @Tags admin
+// @Produce json
+// @Param policy query string false "Filter by policy name"
+// @Param run_id query string false "Filter by run ID"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Items per page" default(20)
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} domain.RetentionAuditListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/retention/audit [get]
+func (h *RetentionHandler) ListAudit(c *fiber.Ctx) error {
+	cursor, err := domain.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Cursor must be a next_cursor value returned by a previous request")
+	}
+
+	filter := domain.RetentionAuditFilter{
+		Policy: c.Query("policy"),
+		RunID:  c.Query("run_id"),
+	}
+	limit := domain.NormalizeCursorLimit(c.QueryInt("limit", domain.DefaultCursorLimit))
+
+	entries, err := h.retentionService.ListAudit(c.Context(), filter, cursor, limit)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, entries)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	// searchMinQueryLength is the shortest query the search accepts
+	searchMinQueryLength = 2
+	// searchMaxLimit caps the page size of search results
+	searchMaxLimit = 50
+)
+
+// SearchHandler handles global search HTTP requests
+type SearchHandler struct {
+	searchService domain.SearchService
+	i18n          *i18n.I18n
+	logger        *zap.Logger
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(
+	searchService domain.SearchService,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+		i18n:          i18n,
+		logger:        logger,
+	}
+}
+
+// Search handles searching across the couple's content
+// @Summary Global search
+// @Description Search the couple's photos, events, messages and journal entries at once. Results are ranked and labelled with their type.
+// @Tags search
+// @Produce json
+// @Param q query string true "Search query (at least 2 characters)"
+// @Param types query string false "Comma separated result types to include (photo,event,message,journal)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Security BearerAuth
+// @Success 200 {object} domain.SearchResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /search [get]
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	query := strings.TrimSpace(c.Query("q"))
+	if utf8.RuneCountInString(query) < searchMinQueryLength {
+		return domain.ErrInvalidRequestError("Search query must be at least 2 characters")
+	}
+
+	var types []domain.SearchResultType
+	if raw := c.Query("types"); raw != "" {
+		for _, value := range strings.Split(raw, ",") {
+			resultType := domain.SearchResultType(strings.TrimSpace(value))
+			if !isSearchResultType(resultType) {
+				return domain.ErrInvalidRequestError("Unknown search type: " + string(resultType))
+			}
+			types = append(types, resultType)
+		}
+	}
+
+	page := c.QueryInt("page", 1)
+	if page < 1 {
+		page = 1
+	}
+	limit := c.QueryInt("limit", 20)
+	if limit < 1 || limit > searchMaxLimit {
+		limit = searchMaxLimit
+	}
+
+	response, err := h.searchService.Search(c.Context(), userID, query, types, page, limit)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, response)
+}
+
+// isSearchResultType reports whether t is a known search result type
+func isSearchResultType(t domain.SearchResultType) bool {
+	for _, known := range domain.SearchResultTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"strings"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/config"
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// AuthModeHeader lets a browser client ask for cookie sessions instead of tokens in the body
+	AuthModeHeader = "X-Auth-Mode"
+	// AccessTokenCookie holds the access token in cookie mode
+	AccessTokenCookie = "eralove_access"
+	// RefreshTokenCookie holds the refresh token in cookie mode
+	RefreshTokenCookie = "eralove_refresh"
+
+	authModeCookie = "cookie"
+	// accessCookiePath scopes the access token to the API, every version included
+	accessCookiePath = "/api"
+	// apiPathPrefix is the path every API version is served under
+	apiPathPrefix = "/api/"
+)
+
+// SessionCookies manages httpOnly auth cookies for the web app, so browsers never
+// have to keep JWTs in localStorage
+type SessionCookies struct {
+	mode            string
+	domain          string
+	secure          bool
+	sameSite        string
+	refreshLifetime time.Duration
+}
+
+// NewSessionCookies creates the session cookie manager from configuration
+func NewSessionCookies(cfg *config.Config) *SessionCookies {
+	sameSite := fiber.CookieSameSiteLaxMode
+	switch strings.ToLower(cfg.AuthCookieSameSite) {
+	case fiber.CookieSameSiteStrictMode:
+		sameSite = fiber.CookieSameSiteStrictMode
+	case fiber.CookieSameSiteNoneMode:
+		sameSite = fiber.CookieSameSiteNoneMode
+	}
+
+	return &SessionCookies{
+		mode:            cfg.AuthCookieMode,
+		domain:          cfg.AuthCookieDomain,
+		secure:          cfg.AuthCookieSecure,
+		sameSite:        sameSite,
+		refreshLifetime: time.Duration(cfg.JWTRefreshExpiration) * time.Hour,
+	}
+}
+
+// Enabled reports whether the request should use cookie sessions, either because the
+// server always does or because the client asked for it with the X-Auth-Mode header
+func (s *SessionCookies) Enabled(c *fiber.Ctx) bool {
+	switch s.mode {
+	case config.AuthCookieModeAlways:
+		return true
+	case config.AuthCookieModeOptional:
+		return strings.EqualFold(c.Get(AuthModeHeader), authModeCookie)
+	default:
+		return false
+	}
+}
+
+// Set writes the token pair as httpOnly cookies
+func (s *SessionCookies) Set(c *fiber.Ctx, tokenPair *domain.TokenPair) {
+	now := time.Now()
+	c.Cookie(s.cookie(AccessTokenCookie, tokenPair.AccessToken, accessCookiePath,
+		now.Add(time.Duration(tokenPair.ExpiresIn)*time.Second)))
+	c.Cookie(s.cookie(RefreshTokenCookie, tokenPair.RefreshToken, refreshCookiePath(c),
+		now.Add(s.refreshLifetime)))
+}
+
+// Clear expires both auth cookies
+func (s *SessionCookies) Clear(c *fiber.Ctx) {
+	expired := time.Unix(0, 0)
+	c.Cookie(s.cookie(AccessTokenCookie, "", accessCookiePath, expired))
+	c.Cookie(s.cookie(RefreshTokenCookie, "", refreshCookiePath(c), expired))
+}
+
+// RefreshToken returns the refresh token sent in the session cookie, if any
+func (s *SessionCookies) RefreshToken(c *fiber.Ctx) string {
+	if s.mode == config.AuthCookieModeDisabled {
+		return ""
+	}
+	return c.Cookies(RefreshTokenCookie)
+}
+
+// refreshCookiePath scopes the refresh token to the auth endpoints of the API version
+// the request was made to, so it is not sent on every request
+func refreshCookiePath(c *fiber.Ctx) string {
+	path := c.Path()
+	if !strings.HasPrefix(path, apiPathPrefix) {
+		return accessCookiePath
+	}
+	version, _, _ := strings.Cut(strings.TrimPrefix(path, apiPathPrefix), "/")
+	return apiPathPrefix + version + "/auth"
+}
+
+// cookie builds an auth cookie with the configured attributes
+func (s *SessionCookies) cookie(name, value, path string, expires time.Time) *fiber.Cookie {
+	return &fiber.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   s.domain,
+		Expires:  expires,
+		Secure:   s.secure,
+		HTTPOnly: true,
+		SameSite: s.sameSite,
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eralove/eralove-backend/internal/config"
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestSessionCookiePaths(t *testing.T) {
+	cookies := NewSessionCookies(&config.Config{AuthCookieMode: config.AuthCookieModeAlways, JWTRefreshExpiration: 168})
+
+	app := fiber.New()
+	app.Post("/api/:version/auth/login", func(c *fiber.Ctx) error {
+		cookies.Set(c, &domain.TokenPair{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 900})
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	for _, version := range []string{"v1", "v2"} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/api/"+version+"/auth/login", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := map[string]string{
+			AccessTokenCookie:  "/api",
+			RefreshTokenCookie: "/api/" + version + "/auth",
+		}
+		for _, cookie := range resp.Cookies() {
+			if path, ok := want[cookie.Name]; ok && cookie.Path != path {
+				t.Errorf("%s: %s path = %q, want %q", version, cookie.Name, cookie.Path, path)
+			}
+			delete(want, cookie.Name)
+		}
+		for name := range want {
+			t.Errorf("%s: %s cookie not set", version, name)
+		}
+	}
+}
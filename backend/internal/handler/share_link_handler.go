@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// ShareLinkHandler handles public share link HTTP requests
+type ShareLinkHandler struct {
+	shareLinkService domain.ShareLinkService
+	validator        *validator.Validate
+	i18n             *i18n.I18n
+	logger           *zap.Logger
+}
+
+// NewShareLinkHandler creates a new share link handler
+func NewShareLinkHandler(
+	shareLinkService domain.ShareLinkService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *ShareLinkHandler {
+	return &ShareLinkHandler{
+		shareLinkService: shareLinkService,
+		validator:        validator,
+		i18n:             i18n,
+		logger:           logger,
+	}
+}
+
+// CreatePhotoShareLink handles creating a share link for a photo
+// @Summary Share a photo
+// @Description Create an expiring, revocable public link to a single photo, optionally password protected
+// @Tags share-links
+// @Accept json
+// @Produce json
+// @Param id path string true "Photo ID"
+// @Param request body domain.CreateShareLinkRequest false "Link options"
+// @Security BearerAuth
+// @Success 201 {object} domain.ShareLinkResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /photos/{id}/share-link [post]
+func (h *ShareLinkHandler) CreatePhotoShareLink(c *fiber.Ctx) error {
+	return h.createShareLink(c, domain.ShareTargetPhoto)
+}
+
+// CreateAlbumShareLink handles creating a share link for an album
+// @Summary Share an album
+// @Description Create an expiring, revocable public link to an album, optionally password protected
+// @Tags share-links
+// @Accept json
+// @Produce json
+// @Param id path string true "Album ID"
+// @Param request body domain.CreateShareLinkRequest false "Link options"
+// @Security BearerAuth
+// @Success 201 {object} domain.ShareLinkResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /albums/{id}/share-link [post]
+func (h *ShareLinkHandler) CreateAlbumShareLink(c *fiber.Ctx) error {
+	return h.createShareLink(c, domain.ShareTargetAlbum)
+}
+
+// GetShareLinks handles listing the couple's share links
+// @Summary Get share links
+// @Description Get all share links created by the couple with their view counts
+// @Tags share-links
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.ShareLinkListResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /share-links [get]
+func (h *ShareLinkHandler) GetShareLinks(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	links, err := h.shareLinkService.GetShareLinks(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, domain.ShareLinkListResponse{
+		ShareLinks: links,
+		Total:      len(links),
+	})
+}
+
+// RevokeShareLink handles revoking a share link
+// @Summary Revoke share link
+// @Description Revoke a share link so it can no longer be opened
+// @Tags share-links
+// @Produce json
+// @Param id path string true "Share link ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /share-links/{id} [delete]
+func (h *ShareLinkHandler) RevokeShareLink(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	linkID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Share link ID must be a valid ObjectID")
+	}
+
+	if err := h.shareLinkService.RevokeShareLink(c.Context(), linkID, userID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// OpenShareLink handles opening a public share link
+// @Summary Open share link
+// @Description Get the read-only content behind a share link. Password protected links take the password in the X-Share-Password header.
+// @Tags share-links
+// @Produce json
+// @Param token path string true "Share token"
+// @Param X-Share-Password header string false "Link password"
+// @Success 200 {object} domain.SharedContentResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 410 {object} ErrorResponse
+// @Failure 429 {object} ErrorResponse
+// @Router /shared/{token} [get]
+func (h *ShareLinkHandler) OpenShareLink(c *fiber.Ctx) error {
+	content, err := h.shareLinkService.OpenShareLink(c.Context(), c.Params("token"), c.Get("X-Share-Password"))
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, content)
+}
+
+// createShareLink parses the link options and creates a link to the target in the :id param
+func (h *ShareLinkHandler) createShareLink(c *fiber.Ctx, targetType domain.ShareTargetType) error {
+	userID := getUserIDFromContext(c)
+	targetID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("ID must be a valid ObjectID")
+	}
+
+	var req domain.CreateShareLinkRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return domain.ErrInvalidRequestError("Invalid request body")
+		}
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	link, err := h.shareLinkService.CreateShareLink(c.Context(), userID, targetType, targetID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, link)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// StorageIntegrityHandler handles storage integrity HTTP requests
+type StorageIntegrityHandler struct {
+	integrityService domain.StorageIntegrityService
+	logger           *zap.Logger
+}
+
+// NewStorageIntegrityHandler creates a new storage integrity handler
+func NewStorageIntegrityHandler(integrityService domain.StorageIntegrityService, logger *zap.Logger) *StorageIntegrityHandler {
+	return &StorageIntegrityHandler{
+		integrityService: integrityService,
+		logger:           logger,
+	}
+}
+
+// ListIssues handles listing storage integrity issues
+// @Summary List storage integrity issues
+// @Description List stored photos found corrupt or missing by the periodic integrity check, most recently detected first, with the number of open issues of each status. An issue disappears once a later check finds the object intact. Admin only. Pass next_cursor back as cursor to get the next page.
+// @Tags admin
+// @Produce json
+// @Param status query string false "Filter by status: corrupt or missing"
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Items per page" default(20)
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} domain.StorageIntegrityListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/storage/integrity [get]
+func (h *StorageIntegrityHandler) ListIssues(c *fiber.Ctx) error {
+	cursor, err := domain.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Cursor must be a next_cursor value returned by a previous request")
+	}
+
+	limit := domain.NormalizeCursorLimit(c.QueryInt("limit", domain.DefaultCursorLimit))
+
+	issues, err := h.integrityService.ListIssues(c.Context(), c.Query("status"), cursor, limit)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, issues)
+}
+
+// RunReconciliation handles reconciling the stored photo files with the photo records
+// @Summary Reconcile stored photos
+// @Description Cross-reference the stored photo files with the photo records now. Files no photo refers to, including photos in the trash, are orphans and deleted once older than the grace period; photos whose file is gone are recorded as missing integrity issues. Runs are dry runs, which only report orphans, unless dry_run is false. Every run is recorded. Admin only.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body domain.RunStorageReconciliationRequest false "Run options"
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} domain.StorageReconciliation
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /admin/storage/reconciliations [post]
+func (h *StorageIntegrityHandler) RunReconciliation(c *fiber.Ctx) error {
+	var req domain.RunStorageReconciliationRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return domain.ErrInvalidRequestError("Request body must be a JSON object")
+		}
+	}
+
+	report, err := h.integrityService.Reconcile(c.Context(), &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, report)
+}
+
+// ListReconciliations handles listing storage reconciliation reports
+// @Summary List storage reconciliations
+// @Description List the reports of scheduled and manual storage reconciliations newest first, with the first orphaned files each one found. Admin only. Pass next_cursor back as cursor to get the next page.
+// @Tags admin
+// @Produce json
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Items per page" default(20)
+// @Param X-Admin-Key header string false "Admin API key"
+// @Success 200 {object} domain.StorageReconciliationListResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /admin/storage/reconciliations [get]
+func (h *StorageIntegrityHandler) ListReconciliations(c *fiber.Ctx) error {
+	cursor, err := domain.DecodeCursor(c.Query("cursor"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Cursor must be a next_cursor value returned by a previous request")
+	}
+
+	limit := domain.NormalizeCursorLimit(c.QueryInt("limit", domain.DefaultCursorLimit))
+
+	reconciliations, err := h.integrityService.ListReconciliations(c.Context(), cursor, limit)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, reconciliations)
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// timelineTypeAliases maps the accepted values of the types filter to item types
+var timelineTypeAliases = map[string]domain.TimelineItemType{
+	"photo":      domain.TimelineItemPhoto,
+	"photos":     domain.TimelineItemPhoto,
+	"event":      domain.TimelineItemEvent,
+	"events":     domain.TimelineItemEvent,
+	"milestone":  domain.TimelineItemMilestone,
+	"milestones": domain.TimelineItemMilestone,
+	"journal":    domain.TimelineItemJournal,
+}
+
+// TimelineHandler handles couple timeline HTTP requests
+type TimelineHandler struct {
+	timelineService domain.TimelineService
+	i18n            *i18n.I18n
+	logger          *zap.Logger
+}
+
+// NewTimelineHandler creates a new timeline handler
+func NewTimelineHandler(
+	timelineService domain.TimelineService,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *TimelineHandler {
+	return &TimelineHandler{
+		timelineService: timelineService,
+		i18n:            i18n,
+		logger:          logger,
+	}
+}
+
+// GetTimeline handles retrieving the shared couple timeline
+// @Summary Get couple timeline
+// @Description Get the couple's photos, events, journal entries and relationship milestones as one chronological feed, newest first. Pass next_cursor back as cursor to get the next page. The couple's date formatting preferences are included for rendering dates.
+// @Tags couple
+// @Produce json
+// @Param cursor query string false "Cursor from the previous page"
+// @Param limit query int false "Items per page" default(20)
+// @Param types query string false "Comma separated item types to include (photos,events,journal,milestones)"
+// @Param from query string false "Earliest date to include (YYYY-MM-DD)"
+// @Param to query string false "Latest date to include (YYYY-MM-DD)"
+// @Security BearerAuth
+// @Success 200 {object} domain.TimelineResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /couple/timeline [get]
+func (h *TimelineHandler) GetTimeline(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	cursor, err := domain.DecodeTimelineCursor(c.Query("cursor"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Cursor must be a next_cursor value from a previous page")
+	}
+
+	query := &domain.TimelineQuery{
+		Cursor: cursor,
+		Limit:  domain.NormalizeCursorLimit(c.QueryInt("limit", domain.DefaultCursorLimit)),
+	}
+
+	if raw := c.Query("types"); raw != "" {
+		seen := make(map[domain.TimelineItemType]bool)
+		for _, value := range strings.Split(raw, ",") {
+			itemType, ok := timelineTypeAliases[strings.TrimSpace(value)]
+			if !ok {
+				return domain.ErrInvalidRequestError("Unknown timeline type: " + strings.TrimSpace(value))
+			}
+			if !seen[itemType] {
+				seen[itemType] = true
+				query.Types = append(query.Types, itemType)
+			}
+		}
+	}
+
+	if query.From, err = parseDateQuery(c, "from"); err != nil {
+		return domain.ErrInvalidRequestError("Invalid from date: " + err.Error())
+	}
+	if query.To, err = parseDateQuery(c, "to"); err != nil {
+		return domain.ErrInvalidRequestError("Invalid to date: " + err.Error())
+	}
+
+	if query.From != nil && query.To != nil && query.To.Before(query.From.Time) {
+		return domain.ErrInvalidRequestError("to must not be before from")
+	}
+
+	response, err := h.timelineService.GetTimeline(c.Context(), userID, query)
+	if err != nil {
+		return err
+	}
+
+	lang := getLocale(c)
+	for _, item := range response.Items {
+		if item.Milestone != nil {
+			item.Title = h.milestoneTitle(lang, item.Milestone)
+		}
+	}
+
+	return Respond(c, fiber.StatusOK, response)
+}
+
+// milestoneTitle returns the localized title of a milestone
+func (h *TimelineHandler) milestoneTitle(lang string, milestone *domain.TimelineMilestone) string {
+	switch milestone.Kind {
+	case domain.MilestoneMatched:
+		return h.i18n.Translate(lang, "timeline_matched", nil)
+	case domain.MilestoneAnniversary:
+		if milestone.Value == 0 {
+			return h.i18n.Translate(lang, "timeline_together", nil)
+		}
+		return h.i18n.TranslatePlural(lang, "timeline_anniversary", milestone.Value, map[string]interface{}{"Years": milestone.Value})
+	default:
+		return h.i18n.TranslatePlural(lang, "timeline_days", milestone.Value, map[string]interface{}{"Days": milestone.Value})
+	}
+}
+
+// parseDateQuery parses an optional YYYY-MM-DD query parameter
+func parseDateQuery(c *fiber.Ctx, name string) (*domain.Date, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	date, err := domain.ParseDate(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &date, nil
+}
@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// TrashHandler handles recently deleted content HTTP requests
+type TrashHandler struct {
+	trashService domain.TrashService
+	validator    *validator.Validate
+	i18n         *i18n.I18n
+	logger       *zap.Logger
+}
+
+// NewTrashHandler creates a new trash handler
+func NewTrashHandler(
+	trashService domain.TrashService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+		validator:    validator,
+		i18n:         i18n,
+		logger:       logger,
+	}
+}
+
+// GetTrash handles listing recently deleted content
+// @Summary Get recently deleted content
+// @Description Get the couple's soft-deleted photos and events and the messages and journal entries the user deleted, with the number of days left before each is purged
+// @Tags trash
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.TrashResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /trash [get]
+func (h *TrashHandler) GetTrash(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	trash, err := h.trashService.GetTrash(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, trash)
+}
+
+// RestoreTrash handles restoring items from the trash
+// @Summary Restore deleted content
+// @Description Restore several soft-deleted photos, events, messages and journal entries at once. Items that cannot be restored are listed in failed.
+// @Tags trash
+// @Accept json
+// @Produce json
+// @Param request body domain.RestoreTrashRequest true "Items to restore"
+// @Security BearerAuth
+// @Success 200 {object} domain.RestoreTrashResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /trash/restore [post]
+func (h *TrashHandler) RestoreTrash(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.RestoreTrashRequest
+	if err := c.BodyParser(&req); err != nil {
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	result, err := h.trashService.Restore(c.Context(), userID, &req)
+	if err != nil {
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, result)
+}
+
+// GetPhotoTrash handles listing deleted photos
+// @Summary Get deleted photos
+// @Description Get the couple's soft-deleted photos, with the number of days left before each is purged
+// @Tags trash
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.TrashResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /photos/trash [get]
+func (h *TrashHandler) GetPhotoTrash(c *fiber.Ctx) error {
+	return h.getTrashOfType(c, domain.TrashItemPhoto)
+}
+
+// RestorePhoto handles restoring a deleted photo
+// @Summary Restore deleted photo
+// @Description Restore one of the couple's photos from the trash
+// @Tags trash
+// @Produce json
+// @Param id path string true "Photo ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /photos/{id}/restore [post]
+func (h *TrashHandler) RestorePhoto(c *fiber.Ctx) error {
+	return h.restoreItem(c, domain.TrashItemPhoto)
+}
+
+// PurgePhoto handles permanently deleting a photo from the trash
+// @Summary Purge deleted photo
+// @Description Permanently delete one of the couple's photos from the trash, with its files, without waiting for the retention period
+// @Tags trash
+// @Produce json
+// @Param id path string true "Photo ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /photos/{id}/purge [delete]
+func (h *TrashHandler) PurgePhoto(c *fiber.Ctx) error {
+	return h.purgeItem(c, domain.TrashItemPhoto)
+}
+
+// GetEventTrash handles listing deleted events
+// @Summary Get deleted events
+// @Description Get the couple's soft-deleted events, with the number of days left before each is purged
+// @Tags trash
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.TrashResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /events/trash [get]
+func (h *TrashHandler) GetEventTrash(c *fiber.Ctx) error {
+	return h.getTrashOfType(c, domain.TrashItemEvent)
+}
+
+// RestoreEvent handles restoring a deleted event
+// @Summary Restore deleted event
+// @Description Restore one of the couple's events from the trash
+// @Tags trash
+// @Produce json
+// @Param id path string true "Event ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /events/{id}/restore [post]
+func (h *TrashHandler) RestoreEvent(c *fiber.Ctx) error {
+	return h.restoreItem(c, domain.TrashItemEvent)
+}
+
+// PurgeEvent handles permanently deleting an event from the trash
+// @Summary Purge deleted event
+// @Description Permanently delete one of the couple's events from the trash without waiting for the retention period
+// @Tags trash
+// @Produce json
+// @Param id path string true "Event ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /events/{id}/purge [delete]
+func (h *TrashHandler) PurgeEvent(c *fiber.Ctx) error {
+	return h.purgeItem(c, domain.TrashItemEvent)
+}
+
+// GetJournalTrash handles listing deleted journal entries
+// @Summary Get deleted journal entries
+// @Description Get the journal entries the user deleted, with the number of days left before each is purged
+// @Tags trash
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.TrashResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /journal/trash [get]
+func (h *TrashHandler) GetJournalTrash(c *fiber.Ctx) error {
+	return h.getTrashOfType(c, domain.TrashItemJournal)
+}
+
+// RestoreJournalEntry handles restoring a deleted journal entry
+// @Summary Restore deleted journal entry
+// @Description Restore one of the user's journal entries from the trash
+// @Tags trash
+// @Produce json
+// @Param id path string true "Journal entry ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /journal/{id}/restore [post]
+func (h *TrashHandler) RestoreJournalEntry(c *fiber.Ctx) error {
+	return h.restoreItem(c, domain.TrashItemJournal)
+}
+
+// PurgeJournalEntry handles permanently deleting a journal entry from the trash
+// @Summary Purge deleted journal entry
+// @Description Permanently delete one of the user's journal entries from the trash without waiting for the retention period
+// @Tags trash
+// @Produce json
+// @Param id path string true "Journal entry ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /journal/{id}/purge [delete]
+func (h *TrashHandler) PurgeJournalEntry(c *fiber.Ctx) error {
+	return h.purgeItem(c, domain.TrashItemJournal)
+}
+
+// getTrashOfType lists the deleted items of one type
+func (h *TrashHandler) getTrashOfType(c *fiber.Ctx, itemType domain.TrashItemType) error {
+	userID := getUserIDFromContext(c)
+
+	trash, err := h.trashService.GetTrashOfType(c.Context(), userID, itemType)
+	if err != nil {
+		LogServiceError(c, err, "Get trash",
+			zap.String("user_id", userID.Hex()),
+			zap.String("type", string(itemType)))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, trash)
+}
+
+// restoreItem restores the deleted item of the given type named by the id parameter
+func (h *TrashHandler) restoreItem(c *fiber.Ctx, itemType domain.TrashItemType) error {
+	userID := getUserIDFromContext(c)
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidItemID(c, itemType)
+	}
+
+	if err := h.trashService.RestoreItem(c.Context(), userID, itemType, id); err != nil {
+		LogServiceError(c, err, "Restore trash item",
+			zap.String("user_id", userID.Hex()),
+			zap.String("type", string(itemType)),
+			zap.String("id", id.Hex()))
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// purgeItem permanently deletes the deleted item of the given type named by the id
+// parameter
+func (h *TrashHandler) purgeItem(c *fiber.Ctx, itemType domain.TrashItemType) error {
+	userID := getUserIDFromContext(c)
+	id, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidItemID(c, itemType)
+	}
+
+	if err := h.trashService.PurgeItem(c.Context(), userID, itemType, id); err != nil {
+		LogServiceError(c, err, "Purge trash item",
+			zap.String("user_id", userID.Hex()),
+			zap.String("type", string(itemType)),
+			zap.String("id", id.Hex()))
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// invalidItemID returns the error for a malformed photo, event or journal entry ID
+func (h *TrashHandler) invalidItemID(c *fiber.Ctx, itemType domain.TrashItemType) error {
+	message := "Invalid photo ID"
+	switch itemType {
+	case domain.TrashItemEvent:
+		message = "Invalid event ID"
+	case domain.TrashItemJournal:
+		message = "Invalid journal entry ID"
+	}
+
+	return domain.ErrInvalidRequestError(message)
+}
@@ -1,35 +1,48 @@
 package handler
 
 import (
+	"bytes"
 	"fmt"
-	"mime/multipart"
 	"path/filepath"
-	"strings"
+	"strconv"
 	"time"
 
 	"github.com/eralove/eralove-backend/internal/domain"
 	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 )
 
 // UploadHandler handles file upload requests
 type UploadHandler struct {
-	storageService domain.StorageService
-	i18n           *i18n.I18n
-	logger         *zap.Logger
+	storageService       domain.StorageService
+	uploadSessionService domain.UploadSessionService
+	scanService          domain.UploadScanService
+	uploadPolicy         *domain.UploadPolicy
+	validator            *validator.Validate
+	i18n                 *i18n.I18n
+	logger               *zap.Logger
 }
 
 // NewUploadHandler creates a new upload handler
 func NewUploadHandler(
 	storageService domain.StorageService,
+	uploadSessionService domain.UploadSessionService,
+	scanService domain.UploadScanService,
+	uploadPolicy *domain.UploadPolicy,
+	validator *validator.Validate,
 	i18n *i18n.I18n,
 	logger *zap.Logger,
 ) *UploadHandler {
 	return &UploadHandler{
-		storageService: storageService,
-		i18n:           i18n,
-		logger:         logger,
+		storageService:       storageService,
+		uploadSessionService: uploadSessionService,
+		scanService:          scanService,
+		uploadPolicy:         uploadPolicy,
+		validator:            validator,
+		i18n:                 i18n,
+		logger:               logger,
 	}
 }
 
@@ -43,32 +56,57 @@ type UploadFileResponse struct {
 	Message     string `json:"message"`
 }
 
+// UploadMultipleFilesResponse represents the response after uploading several files.
+// Files that were not uploaded are listed in errors with the reason.
+type UploadMultipleFilesResponse struct {
+	Files   []UploadFileResponse `json:"files"`
+	Total   int                  `json:"total" example:"3"`
+	Success int                  `json:"success" example:"2"`
+	Failed  int                  `json:"failed" example:"1"`
+	Errors  []string             `json:"errors,omitempty" example:"clip.exe: unsupported file type"`
+}
+
+// DeleteFileRequest represents the request to delete an uploaded file
+type DeleteFileRequest struct {
+	FilePath string `json:"file_path" example:"photos/64b7f0c2e4b0a1a2b3c4d5e6_1700000000000000000.jpg"`
+}
+
+// PhotoUploadURLRequest represents the request for a URL to upload a photo image to
+type PhotoUploadURLRequest struct {
+	Filename string `json:"filename" example:"beach.jpg"`
+}
+
+// PhotoUploadURLResponse represents a URL to upload a photo image to with a PUT
+// request, and the storage key to create the photo with
+type PhotoUploadURLResponse struct {
+	UploadURL string `json:"upload_url"`
+	Key       string `json:"key" example:"photos/64b7f0c2e4b0a1a2b3c4d5e6/beach.jpg"`
+}
+
 // UploadFile handles single file upload
 // @Summary Upload a file
-// @Description Upload a single file and get the file path
+// @Description Upload a single file and get the file path. The file must have one of the configured content types, with a matching extension, and fit the size limit of its folder.
 // @Tags upload
 // @Accept multipart/form-data
 // @Produce json
 // @Param file formData file true "File to upload"
 // @Param folder formData string false "Folder name (photos, avatars, documents)"
+// @Param Idempotency-Key header string false "Unique key of the request, the same on retries, whose first response is replayed to them"
 // @Security BearerAuth
 // @Success 200 {object} UploadFileResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /upload [post]
 func (h *UploadHandler) UploadFile(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Upload file")
+	LogRequestStart(c, "Upload file")
 
 	userID := getUserIDFromContext(c)
 
 	// Get file from form
 	file, err := c.FormFile("file")
 	if err != nil {
-		LogRequestError(h.logger, c, "File upload failed", err)
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "File is required",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		LogRequestError(c, "File upload failed", err)
+		return domain.ErrInvalidRequestError("File is required")
 	}
 
 	// Get optional folder parameter
@@ -78,15 +116,12 @@ func (h *UploadHandler) UploadFile(c *fiber.Ctx) error {
 	}
 
 	// Validate file
-	if err := h.validateFile(file); err != nil {
-		LogRequestError(h.logger, c, "File validation failed", err)
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid file",
-			Message: err.Error(),
-		})
+	if err := h.uploadPolicy.Validate(folder, file.Filename, file.Header.Get("Content-Type"), file.Size); err != nil {
+		LogRequestError(c, "File validation failed", err)
+		return err
 	}
 
-	LogRequestParsed(h.logger, c, "Upload file",
+	LogRequestParsed(c, "Upload file",
 		zap.String("user_id", userID.Hex()),
 		zap.String("filename", file.Filename),
 		zap.Int64("size", file.Size),
@@ -101,16 +136,24 @@ func (h *UploadHandler) UploadFile(c *fiber.Ctx) error {
 	// Open file
 	fileContent, err := file.Open()
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Upload file", zap.String("user_id", userID.Hex()))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to read file",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "internal_error", nil),
-		})
+		LogServiceError(c, err, "Upload file", zap.String("user_id", userID.Hex()))
+		return domain.ErrFileUploadFailedError("could not read the file")
 	}
 	defer fileContent.Close()
 
+	// Check that the content is what it was declared as before storing it
+	head, err := domain.ReadHead(fileContent)
+	if err != nil {
+		LogServiceError(c, err, "Upload file", zap.String("user_id", userID.Hex()))
+		return domain.ErrFileUploadFailedError("could not read the file")
+	}
+	if err := h.uploadPolicy.ValidateContent(file.Header.Get("Content-Type"), head); err != nil {
+		LogRequestError(c, "File content validation failed", err)
+		return err
+	}
+
 	// Upload to storage
-	LogServiceCall(h.logger, c, "Upload file", zap.String("file_path", filePath))
+	LogServiceCall(c, "Upload file", zap.String("file_path", filePath))
 	
 	uploadReq := &domain.UploadRequest{
 		File:        fileContent,
@@ -123,21 +166,23 @@ func (h *UploadHandler) UploadFile(c *fiber.Ctx) error {
 	
 	fileInfo, err := h.storageService.Upload(c.Context(), uploadReq)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Upload file", zap.String("file_path", filePath))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to upload file",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "internal_error", nil),
-		})
+		LogServiceError(c, err, "Upload file", zap.String("file_path", filePath))
+		return domain.ErrFileUploadFailedError("could not store the file")
+	}
+
+	if err := h.scanService.ScanUpload(c.Context(), fileInfo); err != nil {
+		LogServiceError(c, err, "Upload file", zap.String("file_path", filePath))
+		return err
 	}
 	
-	url := fileInfo.URL
+	url := domain.FileURL(fileInfo.URL, fileInfo.UploadedAt)
 
-	LogServiceSuccess(h.logger, c, "Upload file",
+	LogServiceSuccess(c, "Upload file",
 		zap.String("user_id", userID.Hex()),
 		zap.String("file_path", filePath),
 		zap.String("url", url))
 
-	return c.JSON(UploadFileResponse{
+	return Respond(c, fiber.StatusOK, UploadFileResponse{
 		FilePath:    filePath,
 		FileName:    file.Filename,
 		FileSize:    file.Size,
@@ -149,38 +194,33 @@ func (h *UploadHandler) UploadFile(c *fiber.Ctx) error {
 
 // UploadMultipleFiles handles multiple file uploads
 // @Summary Upload multiple files
-// @Description Upload multiple files and get the file paths
+// @Description Upload multiple files and get the file paths. Each file must have one of the configured content types, with a matching extension, and fit the size limit of its folder; files that do not are reported in errors.
 // @Tags upload
 // @Accept multipart/form-data
 // @Produce json
 // @Param files formData file true "Files to upload" multiple
 // @Param folder formData string false "Folder name (photos, avatars, documents)"
+// @Param Idempotency-Key header string false "Unique key of the request, the same on retries, whose first response is replayed to them"
 // @Security BearerAuth
-// @Success 200 {object} map[string][]UploadFileResponse
+// @Success 200 {object} UploadMultipleFilesResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /upload/multiple [post]
 func (h *UploadHandler) UploadMultipleFiles(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Upload multiple files")
+	LogRequestStart(c, "Upload multiple files")
 
 	userID := getUserIDFromContext(c)
 
 	// Get files from form
 	form, err := c.MultipartForm()
 	if err != nil {
-		LogRequestError(h.logger, c, "Failed to parse multipart form", err)
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid form data",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		LogRequestError(c, "Failed to parse multipart form", err)
+		return domain.ErrInvalidRequestError("Invalid form data")
 	}
 
 	files := form.File["files"]
 	if len(files) == 0 {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "No files provided",
-			Message: "Please provide at least one file",
-		})
+		return domain.ErrInvalidRequestError("Please provide at least one file")
 	}
 
 	folder := c.FormValue("folder")
@@ -188,13 +228,17 @@ func (h *UploadHandler) UploadMultipleFiles(c *fiber.Ctx) error {
 		folder = "uploads"
 	}
 
-	var responses []UploadFileResponse
+	responses := make([]UploadFileResponse, 0, len(files))
 	var errors []string
 
 	for _, file := range files {
 		// Validate file
-		if err := h.validateFile(file); err != nil {
-			errors = append(errors, fmt.Sprintf("%s: %s", file.Filename, err.Error()))
+		if err := h.uploadPolicy.Validate(folder, file.Filename, file.Header.Get("Content-Type"), file.Size); err != nil {
+			reason := err.Error()
+			if appErr, ok := err.(*domain.AppError); ok {
+				reason = appErr.Message
+			}
+			errors = append(errors, fmt.Sprintf("%s: %s", file.Filename, reason))
 			continue
 		}
 
@@ -211,6 +255,22 @@ func (h *UploadHandler) UploadMultipleFiles(c *fiber.Ctx) error {
 			continue
 		}
 
+		head, err := domain.ReadHead(fileContent)
+		if err != nil {
+			fileContent.Close()
+			errors = append(errors, fmt.Sprintf("%s: failed to read", file.Filename))
+			continue
+		}
+		if err := h.uploadPolicy.ValidateContent(file.Header.Get("Content-Type"), head); err != nil {
+			fileContent.Close()
+			reason := err.Error()
+			if appErr, ok := err.(*domain.AppError); ok {
+				reason = appErr.Message
+			}
+			errors = append(errors, fmt.Sprintf("%s: %s", file.Filename, reason))
+			continue
+		}
+
 		// Upload to storage
 		uploadReq := &domain.UploadRequest{
 			File:        fileContent,
@@ -228,8 +288,17 @@ func (h *UploadHandler) UploadMultipleFiles(c *fiber.Ctx) error {
 			errors = append(errors, fmt.Sprintf("%s: upload failed", file.Filename))
 			continue
 		}
+
+		if err := h.scanService.ScanUpload(c.Context(), fileInfo); err != nil {
+			reason := "upload failed"
+			if appErr, ok := err.(*domain.AppError); ok {
+				reason = appErr.Message
+			}
+			errors = append(errors, fmt.Sprintf("%s: %s", file.Filename, reason))
+			continue
+		}
 		
-		url := fileInfo.URL
+		url := domain.FileURL(fileInfo.URL, fileInfo.UploadedAt)
 
 		responses = append(responses, UploadFileResponse{
 			FilePath:    filePath,
@@ -241,23 +310,18 @@ func (h *UploadHandler) UploadMultipleFiles(c *fiber.Ctx) error {
 		})
 	}
 
-	LogServiceSuccess(h.logger, c, "Upload multiple files",
+	LogServiceSuccess(c, "Upload multiple files",
 		zap.String("user_id", userID.Hex()),
 		zap.Int("uploaded", len(responses)),
 		zap.Int("failed", len(errors)))
 
-	result := map[string]interface{}{
-		"files":   responses,
-		"total":   len(files),
-		"success": len(responses),
-		"failed":  len(errors),
-	}
-
-	if len(errors) > 0 {
-		result["errors"] = errors
-	}
-
-	return c.JSON(result)
+	return Respond(c, fiber.StatusOK, UploadMultipleFilesResponse{
+		Files:   responses,
+		Total:   len(files),
+		Success: len(responses),
+		Failed:  len(errors),
+		Errors:  errors,
+	})
 }
 
 // DeleteFile handles file deletion
@@ -266,84 +330,288 @@ func (h *UploadHandler) UploadMultipleFiles(c *fiber.Ctx) error {
 // @Tags upload
 // @Accept json
 // @Produce json
-// @Param request body map[string]string true "File path"
+// @Param request body DeleteFileRequest true "File path"
 // @Security BearerAuth
 // @Success 200 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /upload [delete]
 func (h *UploadHandler) DeleteFile(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Delete file")
+	LogRequestStart(c, "Delete file")
 
 	userID := getUserIDFromContext(c)
 
-	var req struct {
-		FilePath string `json:"file_path"`
-	}
-
+	var req DeleteFileRequest
 	if err := c.BodyParser(&req); err != nil {
-		LogParsingError(h.logger, err, c, "Delete file")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		LogParsingError(err, c, "Delete file")
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
 	if req.FilePath == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "File path is required",
-			Message: "Please provide file_path",
-		})
+		return domain.ErrInvalidRequestError("Please provide file_path")
 	}
 
-	LogServiceCall(h.logger, c, "Delete file",
+	LogServiceCall(c, "Delete file",
 		zap.String("user_id", userID.Hex()),
 		zap.String("file_path", req.FilePath))
 
 	if err := h.storageService.Delete(c.Context(), req.FilePath); err != nil {
-		LogServiceError(h.logger, c, err, "Delete file", zap.String("file_path", req.FilePath))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to delete file",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "internal_error", nil),
-		})
+		LogServiceError(c, err, "Delete file", zap.String("file_path", req.FilePath))
+		return domain.ErrOperationFailedError("Failed to delete file")
 	}
 
-	LogServiceSuccess(h.logger, c, "Delete file",
+	LogServiceSuccess(c, "Delete file",
 		zap.String("user_id", userID.Hex()),
 		zap.String("file_path", req.FilePath))
 
-	return c.JSON(SuccessResponse{
-		Message: "File deleted successfully",
+	return RespondMessage(c, fiber.StatusOK, nil, "File deleted successfully")
+}
+
+// InitUpload handles starting a resumable upload
+// @Summary Start a resumable upload
+// @Description Start uploading a large file, such as a video, in chunks. The response gives the chunk size and count; send each chunk to the chunks endpoint, in any order and again after an interruption, then complete the upload. Uploads not completed before expires_at are discarded. Only the content types configured in UPLOAD_MAX_SIZES are accepted, each up to its own size.
+// @Tags upload
+// @Accept json
+// @Produce json
+// @Param request body domain.InitUploadRequest true "File to upload"
+// @Security BearerAuth
+// @Success 201 {object} domain.UploadSessionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /upload/sessions [post]
+func (h *UploadHandler) InitUpload(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.InitUploadRequest
+	if err := c.BodyParser(&req); err != nil {
+		LogParsingError(err, c, "Init upload")
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	session, err := h.uploadSessionService.Init(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Init upload", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, session)
+}
+
+// GetUploadSession handles describing a resumable upload
+// @Summary Get a resumable upload
+// @Description Get a resumable upload with the indexes of the chunks received so far, to resume it after an interruption
+// @Tags upload
+// @Produce json
+// @Param id path string true "Upload session ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.UploadSessionResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /upload/sessions/{id} [get]
+func (h *UploadHandler) GetUploadSession(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	session, err := h.uploadSessionService.GetStatus(c.Context(), userID, c.Params("id"))
+	if err != nil {
+		LogServiceError(c, err, "Get upload session", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, session)
+}
+
+// UploadChunk handles receiving a chunk of a resumable upload
+// @Summary Upload a chunk
+// @Description Send the chunk at index, counted from 0, as the raw request body. Every chunk but the last must be chunk_size bytes. Sending a chunk again replaces it.
+// @Tags upload
+// @Accept application/octet-stream
+// @Produce json
+// @Param id path string true "Upload session ID"
+// @Param index path int true "Chunk index"
+// @Security BearerAuth
+// @Success 200 {object} domain.UploadSessionResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /upload/sessions/{id}/chunks/{index} [put]
+func (h *UploadHandler) UploadChunk(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	index, err := strconv.Atoi(c.Params("index"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Invalid chunk index")
+	}
+
+	body := c.Body()
+	session, err := h.uploadSessionService.UploadChunk(c.Context(), userID, c.Params("id"), index, bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		LogServiceError(c, err, "Upload chunk",
+			zap.String("user_id", userID.Hex()),
+			zap.Int("index", index))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, session)
+}
+
+// CompleteUpload handles completing a resumable upload
+// @Summary Complete a resumable upload
+// @Description Join the chunks into the file once all of them were received. The missing chunks are listed otherwise.
+// @Tags upload
+// @Produce json
+// @Param id path string true "Upload session ID"
+// @Security BearerAuth
+// @Success 200 {object} UploadFileResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /upload/sessions/{id}/complete [post]
+func (h *UploadHandler) CompleteUpload(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	fileInfo, err := h.uploadSessionService.Complete(c.Context(), userID, c.Params("id"))
+	if err != nil {
+		LogServiceError(c, err, "Complete upload", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, UploadFileResponse{
+		FilePath:    fileInfo.Key,
+		FileName:    fileInfo.Filename,
+		FileSize:    fileInfo.Size,
+		ContentType: fileInfo.ContentType,
+		URL:         domain.FileURL(fileInfo.URL, fileInfo.UploadedAt),
+		Message:     "File uploaded successfully",
 	})
 }
 
-// validateFile validates the uploaded file
-func (h *UploadHandler) validateFile(file *multipart.FileHeader) error {
-	// Check file size (max 10MB)
-	maxSize := int64(10 * 1024 * 1024) // 10MB
-	if file.Size > maxSize {
-		return fmt.Errorf("file size exceeds maximum allowed size of 10MB")
+// AbortUpload handles discarding a resumable upload
+// @Summary Abort a resumable upload
+// @Description Discard a resumable upload and the chunks received so far
+// @Tags upload
+// @Produce json
+// @Param id path string true "Upload session ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /upload/sessions/{id} [delete]
+func (h *UploadHandler) AbortUpload(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	if err := h.uploadSessionService.Abort(c.Context(), userID, c.Params("id")); err != nil {
+		LogServiceError(c, err, "Abort upload", zap.String("user_id", userID.Hex()))
+		return err
 	}
 
-	// Check file extension
-	ext := strings.ToLower(filepath.Ext(file.Filename))
-	allowedExts := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-		".gif":  true,
-		".webp": true,
-		".pdf":  true,
-		".doc":  true,
-		".docx": true,
-		".mp4":  true,
-		".mov":  true,
-		".avi":  true,
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// PresignUpload handles starting an upload straight to the storage
+// @Summary Start a presigned upload
+// @Description Get a URL to upload a large file straight to the storage, without going through the API. Send the file with a PUT request to upload_url, with the declared Content-Type, before expires_at, then confirm the upload. Only the content types configured in UPLOAD_MAX_SIZES are accepted, each up to its own size.
+// @Tags upload
+// @Accept json
+// @Produce json
+// @Param request body domain.InitUploadRequest true "File to upload"
+// @Security BearerAuth
+// @Success 201 {object} domain.PresignedUploadResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /upload/presign [post]
+func (h *UploadHandler) PresignUpload(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.InitUploadRequest
+	if err := c.BodyParser(&req); err != nil {
+		LogParsingError(err, c, "Presign upload")
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
-	if !allowedExts[ext] {
-		return fmt.Errorf("file type %s is not allowed", ext)
+	if err := h.validator.Struct(&req); err != nil {
+		return domain.ErrValidationFailedError(getValidationErrors(err))
 	}
 
-	return nil
+	presigned, err := h.uploadSessionService.Presign(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Presign upload", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, presigned)
+}
+
+// PhotoUploadURL handles getting a URL to upload a photo image straight to the storage
+// @Summary Get a photo upload URL
+// @Description Get a URL, valid for 15 minutes, to upload a JPEG photo image straight to the storage with a PUT request. Create the photo with the returned key afterwards.
+// @Tags photos
+// @Accept json
+// @Produce json
+// @Param request body PhotoUploadURLRequest true "Photo image file name"
+// @Security BearerAuth
+// @Success 200 {object} PhotoUploadURLResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /photos/presigned-upload [post]
+func (h *UploadHandler) PhotoUploadURL(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req PhotoUploadURLRequest
+	if err := c.BodyParser(&req); err != nil {
+		LogParsingError(err, c, "Photo upload URL")
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	// Generate unique key for the file
+	key := fmt.Sprintf("photos/%s/%s", userID.Hex(), req.Filename)
+
+	uploadURL, err := h.storageService.GeneratePresignedUploadURL(c.Context(), key, "image/jpeg", 15*time.Minute)
+	if err != nil {
+		LogServiceError(c, err, "Photo upload URL", zap.String("user_id", userID.Hex()))
+		return domain.ErrOperationFailedError("Failed to generate upload URL")
+	}
+
+	LogServiceSuccess(c, "Photo upload URL",
+		zap.String("key", key),
+		zap.String("user_id", userID.Hex()))
+
+	return Respond(c, fiber.StatusOK, PhotoUploadURLResponse{
+		UploadURL: uploadURL,
+		Key:       key,
+	})
+}
+
+// ConfirmPresignedUpload handles registering a file uploaded straight to the storage
+// @Summary Confirm a presigned upload
+// @Description Check that the file was uploaded with the declared size and content type and register it. A file that does not match is deleted.
+// @Tags upload
+// @Produce json
+// @Param id path string true "Upload session ID"
+// @Security BearerAuth
+// @Success 200 {object} UploadFileResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /upload/presign/{id}/confirm [post]
+func (h *UploadHandler) ConfirmPresignedUpload(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	fileInfo, err := h.uploadSessionService.ConfirmPresigned(c.Context(), userID, c.Params("id"))
+	if err != nil {
+		LogServiceError(c, err, "Confirm presigned upload", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, UploadFileResponse{
+		FilePath:    fileInfo.Key,
+		FileName:    fileInfo.Filename,
+		FileSize:    fileInfo.Size,
+		ContentType: fileInfo.ContentType,
+		URL:         domain.FileURL(fileInfo.URL, fileInfo.UploadedAt),
+		Message:     "File uploaded successfully",
+	})
 }
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+)
+
+// UsageHandler handles couple usage analytics HTTP requests
+type UsageHandler struct {
+	usageService domain.UsageService
+	logger       *zap.Logger
+}
+
+// NewUsageHandler creates a new usage handler
+func NewUsageHandler(usageService domain.UsageService, logger *zap.Logger) *UsageHandler {
+	return &UsageHandler{
+		usageService: usageService,
+		logger:       logger,
+	}
+}
+
+// GetUsage handles retrieving the couple's usage over time
+// @Summary Get couple usage
+// @Description Get how many photos the couple added, events they created and messages they sent per day (UTC), oldest day first, for charting. Counts are rolled up hourly, so the latest activity may be missing; updated_at tells when they were last rolled up.
+// @Tags couple
+// @Produce json
+// @Param range query string false "Number of days up to today, e.g. 90d (1d to 365d)" default(30d)
+// @Security BearerAuth
+// @Success 200 {object} domain.CoupleUsageResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /couple/usage [get]
+func (h *UsageHandler) GetUsage(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	days, err := domain.ParseUsageRange(c.Query("range"))
+	if err != nil {
+		return domain.ErrInvalidRequestError("Invalid range: " + err.Error())
+	}
+
+	usage, err := h.usageService.GetCoupleUsage(c.Context(), userID, days)
+	if err != nil {
+		LogServiceError(c, err, "Get couple usage")
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, usage)
+}
@@ -1,10 +1,11 @@
 package handler
 
 import (
-	"strings"
+	"errors"
 
 	"github.com/eralove/eralove-backend/internal/domain"
 	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/eralove/eralove-backend/internal/infrastructure/pii"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
@@ -21,6 +22,7 @@ func min(a, b int) int {
 // UserHandler handles user-related HTTP requests
 type UserHandler struct {
 	userService domain.UserService
+	cookies     *SessionCookies
 	validator   *validator.Validate
 	i18n        *i18n.I18n
 	logger      *zap.Logger
@@ -29,12 +31,14 @@ type UserHandler struct {
 // NewUserHandler creates a new user handler
 func NewUserHandler(
 	userService domain.UserService,
+	cookies *SessionCookies,
 	validator *validator.Validate,
 	i18n *i18n.I18n,
 	logger *zap.Logger,
 ) *UserHandler {
 	return &UserHandler{
 		userService: userService,
+		cookies:     cookies,
 		validator:   validator,
 		i18n:        i18n,
 		logger:      logger,
@@ -47,89 +51,55 @@ func NewUserHandler(
 // @Tags auth
 // @Accept json
 // @Produce json
+// @Param request body domain.CreateUserRequest true "New account"
+// @Param Accept-Language header string false "Locale of the account when the request does not set one"
 // @Success 201 {object} domain.UserResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 409 {object} ErrorResponse
 // @Router /auth/register [post]
 func (h *UserHandler) Register(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Registration")
+	LogRequestStart(c, "Registration")
 
 	var req domain.CreateUserRequest
 	if err := c.BodyParser(&req); err != nil {
-		LogParsingError(h.logger, err, c, "Registration")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Code:    int(domain.ErrCodeInvalidRequest),
-			Error:   "Invalid request body",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-			TraceID: getTraceID(c),
-		})
+		LogParsingError(err, c, "Registration")
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
-	LogRequestParsed(h.logger, c, "Registration",
-		zap.String("email", req.Email),
-		zap.String("name", req.Name))
+	LogRequestParsed(c, "Registration",
+		pii.Field("email", req.Email),
+		pii.Field("name", req.Name))
 
 	if err := h.validator.Struct(&req); err != nil {
-		LogValidationError(h.logger, c, err, "Registration",
-			zap.String("email", req.Email),
+		LogValidationError(c, err, "Registration",
+			pii.Field("email", req.Email),
 			zap.Any("validation_errors", getValidationErrors(err)))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "validation_failed", nil),
-			Details: getValidationErrors(err),
-		})
+		return domain.ErrValidationFailedError(getValidationErrors(err))
 	}
 
-	LogServiceCall(h.logger, c, "Registration", zap.String("email", req.Email))
+	if req.Locale == "" {
+		req.Locale = getLocale(c)
+	}
+
+	LogServiceCall(c, "Registration", pii.Field("email", req.Email))
 
 	user, err := h.userService.Register(c.Context(), &req)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Registration", zap.String("email", req.Email))
-
-		// Check for specific error types
-		if strings.Contains(err.Error(), "already exists") {
-			h.logger.Warn("Registration failed: email already exists",
-				zap.String("trace_id", getTraceID(c)),
-				zap.String("email", req.Email))
-			return c.Status(fiber.StatusConflict).JSON(ErrorResponse{
-				Code:    int(domain.ErrCodeUserAlreadyExists),
-				Error:   "Email already exists",
-				Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "user_already_exists", nil),
-				TraceID: getTraceID(c),
-			})
-		}
+		LogServiceError(c, err, "Registration", pii.Field("email", req.Email))
 
-		if strings.Contains(err.Error(), "invalid password") {
-			h.logger.Warn("Registration failed: invalid password",
-				zap.String("trace_id", getTraceID(c)),
-				zap.String("email", req.Email))
-			return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-				Code:    int(domain.ErrCodeWeakPassword),
-				Error:   "Invalid password",
-				Message: err.Error(),
-				TraceID: getTraceID(c),
-			})
+		// Conflicts and weak passwords are mapped by the central error handler
+		var appErr *domain.AppError
+		if errors.As(err, &appErr) {
+			return err
 		}
-
-		// Generic server error
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Code:    int(domain.ErrCodeInternalError),
-			Error:   "Registration failed",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "internal_error", nil),
-			TraceID: getTraceID(c),
-		})
+		return domain.ErrOperationFailedError("Registration failed")
 	}
 
-	LogServiceSuccess(h.logger, c, "Registration",
-		zap.String("email", req.Email),
+	LogServiceSuccess(c, "Registration",
+		pii.Field("email", req.Email),
 		zap.String("user_id", user.ID.Hex()))
 
-	return c.Status(fiber.StatusCreated).JSON(SuccessResponse{
-		Success: true,
-		Data:    user,
-		Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "registration_success", nil),
-		TraceID: getTraceID(c),
-	})
+	return RespondMessage(c, fiber.StatusCreated, user, h.i18n.Translate(getLocale(c), "registration_success", nil))
 }
 
 // Login handles user login
@@ -139,96 +109,85 @@ func (h *UserHandler) Register(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param request body domain.LoginRequest true "User login credentials"
+// @Param X-Auth-Mode header string false "Set to cookie to receive httpOnly session cookies instead of tokens"
 // @Success 200 {object} LoginResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /auth/login [post]
 func (h *UserHandler) Login(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Login")
+	LogRequestStart(c, "Login")
 
 	var req domain.LoginRequest
 	if err := c.BodyParser(&req); err != nil {
-		LogParsingError(h.logger, err, c, "Login")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		LogParsingError(err, c, "Login")
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
-	LogRequestParsed(h.logger, c, "Login", zap.String("email", req.Email))
+	LogRequestParsed(c, "Login", pii.Field("email", req.Email))
 
 	if err := h.validator.Struct(&req); err != nil {
-		LogValidationError(h.logger, c, err, "Login",
-			zap.String("email", req.Email),
+		LogValidationError(c, err, "Login",
+			pii.Field("email", req.Email),
 			zap.Any("validation_errors", getValidationErrors(err)))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "validation_failed", nil),
-			Details: getValidationErrors(err),
-		})
+		return domain.ErrValidationFailedError(getValidationErrors(err))
 	}
 
-	LogServiceCall(h.logger, c, "Login", zap.String("email", req.Email))
+	LogServiceCall(c, "Login", pii.Field("email", req.Email))
 
 	user, tokenPair, err := h.userService.Login(c.Context(), &req)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Login", zap.String("email", req.Email))
-		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{
-			Error:   "Invalid credentials",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_credentials", nil),
-		})
+		LogServiceError(c, err, "Login", pii.Field("email", req.Email))
+		return domain.ErrInvalidCredentials()
 	}
 
-	LogServiceSuccess(h.logger, c, "Login",
-		zap.String("email", req.Email),
+	LogServiceSuccess(c, "Login",
+		pii.Field("email", req.Email),
 		zap.String("user_id", user.ID.Hex()))
 
-	return c.JSON(LoginResponse{
-		User:         user,
-		AccessToken:  tokenPair.AccessToken,
-		RefreshToken: tokenPair.RefreshToken,
-		TokenType:    tokenPair.TokenType,
-		ExpiresIn:    tokenPair.ExpiresIn,
-		Message:      h.i18n.Translate(c.Get("Accept-Language", "en"), "login_successful", nil),
-	})
+	return h.sessionResponse(c, user, tokenPair, "login_successful")
 }
 
 // GetProfile handles getting user profile
 // @Summary Get user profile
-// @Description Get current user's profile information
+// @Description Get current user's profile information. Send the ETag back in If-None-Match, or Last-Modified in If-Modified-Since, to get 304 Not Modified while nothing changed.
 // @Tags users
 // @Produce json
+// @Param If-None-Match header string false "ETag of the last response"
+// @Param If-Modified-Since header string false "Last-Modified of the last response"
 // @Security BearerAuth
 // @Success 200 {object} domain.UserResponse
+// @Success 304
 // @Failure 401 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /users/profile [get]
 func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Get profile")
+	LogRequestStart(c, "Get profile")
 
 	userID := getUserIDFromContext(c)
-	LogServiceCall(h.logger, c, "Get profile", zap.String("user_id", userID.Hex()))
+	LogServiceCall(c, "Get profile", zap.String("user_id", userID.Hex()))
 
 	user, err := h.userService.GetProfile(c.Context(), userID)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Get profile", zap.String("user_id", userID.Hex()))
-		return c.Status(fiber.StatusNotFound).JSON(ErrorResponse{
-			Error:   "User not found",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "not_found", nil),
-		})
+		LogServiceError(c, err, "Get profile", zap.String("user_id", userID.Hex()))
+		return err
 	}
 
-	LogServiceSuccess(h.logger, c, "Get profile", zap.String("user_id", userID.Hex()))
+	LogServiceSuccess(c, "Get profile", zap.String("user_id", userID.Hex()))
 
-	return c.JSON(SuccessResponse{
-		Data:    user,
-		Message: "Profile retrieved successfully",
-	})
+	// The partner's name is not part of the profile's own updates
+	version := newResourceVersion(c)
+	version.add(user.ID.Hex(), user.UpdatedAt, user.PartnerName)
+	version.modifiedAt(user.UpdatedAt)
+	if notModified(c, version) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	return RespondMessage(c, fiber.StatusOK, user, "Profile retrieved successfully")
 }
 
 // UpdateProfile handles updating user profile
 // @Summary Update user profile
-// @Description Update current user's profile information
+// @Description Update current user's profile information, including the time zone that tells which day it is for the user
 // @Tags users
 // @Accept json
 // @Produce json
@@ -239,51 +198,87 @@ func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
 // @Failure 401 {object} ErrorResponse
 // @Router /users/profile [put]
 func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Update profile")
+	LogRequestStart(c, "Update profile")
 
 	userID := getUserIDFromContext(c)
 
 	var req domain.UpdateUserRequest
 	if err := c.BodyParser(&req); err != nil {
-		LogParsingError(h.logger, err, c, "Update profile")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		LogParsingError(err, c, "Update profile")
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
-	LogRequestParsed(h.logger, c, "Update profile",
+	LogRequestParsed(c, "Update profile",
 		zap.String("user_id", userID.Hex()),
-		zap.String("name", req.Name))
+		pii.Field("name", req.Name))
 
 	if err := h.validator.Struct(&req); err != nil {
-		LogValidationError(h.logger, c, err, "Update profile",
+		LogValidationError(c, err, "Update profile",
 			zap.String("user_id", userID.Hex()),
 			zap.Any("validation_errors", getValidationErrors(err)))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "validation_failed", nil),
-			Details: getValidationErrors(err),
-		})
+		return domain.ErrValidationFailedError(getValidationErrors(err))
 	}
 
-	LogServiceCall(h.logger, c, "Update profile", zap.String("user_id", userID.Hex()))
+	LogServiceCall(c, "Update profile", zap.String("user_id", userID.Hex()))
 
 	user, err := h.userService.UpdateProfile(c.Context(), userID, &req)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Update profile", zap.String("user_id", userID.Hex()))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to update profile",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "internal_error", nil),
-		})
+		LogServiceError(c, err, "Update profile", zap.String("user_id", userID.Hex()))
+		return err
 	}
 
-	LogServiceSuccess(h.logger, c, "Update profile", zap.String("user_id", userID.Hex()))
+	LogServiceSuccess(c, "Update profile", zap.String("user_id", userID.Hex()))
+
+	return RespondMessage(c, fiber.StatusOK, user, h.i18n.Translate(getLocale(c), "profile_updated", nil))
+}
 
-	return c.JSON(SuccessResponse{
-		Data:    user,
-		Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "profile_updated", nil),
+// UploadAvatar handles replacing the user's avatar with an uploaded image
+// @Summary Upload avatar
+// @Description Upload an image as the current user's avatar. It is cropped to a square around its center and stored in two sizes, returned as avatar and avatar_small. The previous avatar is deleted.
+// @Tags users
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "Image file (JPEG, PNG, GIF or WebP)"
+// @Success 200 {object} domain.UserResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /users/avatar [post]
+func (h *UserHandler) UploadAvatar(c *fiber.Ctx) error {
+	LogRequestStart(c, "Upload avatar")
+
+	userID := getUserIDFromContext(c)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		LogRequestError(c, "Avatar upload failed", err)
+		return domain.ErrInvalidRequestError("File is required")
+	}
+
+	fileContent, err := file.Open()
+	if err != nil {
+		LogServiceError(c, err, "Upload avatar", zap.String("user_id", userID.Hex()))
+		return err
+	}
+	defer fileContent.Close()
+
+	LogServiceCall(c, "Upload avatar",
+		zap.String("user_id", userID.Hex()),
+		zap.Int64("size", file.Size))
+
+	user, err := h.userService.UploadAvatar(c.Context(), userID, &domain.UploadAvatarRequest{
+		File:        fileContent,
+		ContentType: file.Header.Get("Content-Type"),
+		Size:        file.Size,
 	})
+	if err != nil {
+		LogServiceError(c, err, "Upload avatar", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	LogServiceSuccess(c, "Upload avatar", zap.String("user_id", userID.Hex()))
+
+	return RespondMessage(c, fiber.StatusOK, user, h.i18n.Translate(getLocale(c), "profile_updated", nil))
 }
 
 // DeleteAccount handles account deletion
@@ -296,24 +291,19 @@ func (h *UserHandler) UpdateProfile(c *fiber.Ctx) error {
 // @Failure 401 {object} ErrorResponse
 // @Router /users/account [delete]
 func (h *UserHandler) DeleteAccount(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Delete account")
+	LogRequestStart(c, "Delete account")
 
 	userID := getUserIDFromContext(c)
-	LogServiceCall(h.logger, c, "Delete account", zap.String("user_id", userID.Hex()))
+	LogServiceCall(c, "Delete account", zap.String("user_id", userID.Hex()))
 
 	if err := h.userService.DeleteAccount(c.Context(), userID); err != nil {
-		LogServiceError(h.logger, c, err, "Delete account", zap.String("user_id", userID.Hex()))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to delete account",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "internal_error", nil),
-		})
+		LogServiceError(c, err, "Delete account", zap.String("user_id", userID.Hex()))
+		return domain.ErrOperationFailedError("Failed to delete account")
 	}
 
-	LogServiceSuccess(h.logger, c, "Delete account", zap.String("user_id", userID.Hex()))
+	LogServiceSuccess(c, "Delete account", zap.String("user_id", userID.Hex()))
 
-	return c.JSON(SuccessResponse{
-		Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "account_deleted", nil),
-	})
+	return RespondMessage(c, fiber.StatusOK, nil, h.i18n.Translate(getLocale(c), "account_deleted", nil))
 }
 
 // getValidationErrors converts validator errors to readable format
@@ -330,121 +320,156 @@ func getValidationErrors(err error) []string {
 // LoginResponse represents the login response
 // @Description Login response with user data and authentication tokens
 type LoginResponse struct {
-	User         *domain.UserResponse `json:"user"`                                                            // User information
-	AccessToken  string               `json:"access_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`  // JWT access token
-	RefreshToken string               `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // JWT refresh token
-	TokenType    string               `json:"token_type" example:"Bearer"`                                     // Token type
-	ExpiresIn    int64                `json:"expires_in" example:"3600"`                                       // Token expiration time in seconds
-	Message      string               `json:"message" example:"Login successful"`                              // Success message
+	User         *domain.UserResponse `json:"user"`                                                                      // User information
+	AccessToken  string               `json:"access_token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`  // JWT access token
+	RefreshToken string               `json:"refresh_token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // JWT refresh token
+	TokenType    string               `json:"token_type" example:"Bearer"`                                               // Token type
+	ExpiresIn    int64                `json:"expires_in" example:"3600"`                                                 // Token expiration time in seconds
+	Message      string               `json:"message" example:"Login successful"`                                        // Success message
 }
 
 // RefreshToken handles token refresh
 // @Summary Refresh access token
-// @Description Refresh access token using refresh token
+// @Description Refresh access token using refresh token. Cookie sessions may omit the body.
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Param request body domain.RefreshTokenRequest true "Refresh token data"
+// @Param request body domain.RefreshTokenRequest false "Refresh token data"
+// @Param X-Auth-Mode header string false "Set to cookie to receive httpOnly session cookies instead of tokens"
 // @Success 200 {object} LoginResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
 // @Router /auth/refresh [post]
 func (h *UserHandler) RefreshToken(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Refresh token")
+	LogRequestStart(c, "Refresh token")
 
 	var req domain.RefreshTokenRequest
-	if err := c.BodyParser(&req); err != nil {
-		LogParsingError(h.logger, err, c, "Refresh token")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			LogParsingError(err, c, "Refresh token")
+			return domain.ErrInvalidRequestError("Invalid request body")
+		}
+	}
+	if req.RefreshToken == "" {
+		req.RefreshToken = h.cookies.RefreshToken(c)
 	}
 
-	LogRequestParsed(h.logger, c, "Refresh token",
-		zap.String("token_prefix", SafeTokenLog(req.RefreshToken)))
+	LogRequestParsed(c, "Refresh token")
 
 	if err := h.validator.Struct(&req); err != nil {
-		LogValidationError(h.logger, c, err, "Refresh token")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "validation_failed", nil),
-		})
+		LogValidationError(c, err, "Refresh token")
+		return domain.ErrValidationFailedError(nil)
 	}
 
-	LogServiceCall(h.logger, c, "Refresh token")
+	LogServiceCall(c, "Refresh token")
 
 	tokenPair, user, err := h.userService.RefreshToken(c.Context(), req.RefreshToken)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Refresh token")
-		return c.Status(fiber.StatusUnauthorized).JSON(ErrorResponse{
-			Error:   "Invalid refresh token",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_token", nil),
-		})
-	}
-
-	LogServiceSuccess(h.logger, c, "Refresh token", zap.String("user_id", user.ID.Hex()))
-
-	return c.JSON(LoginResponse{
-		User:         user,
-		AccessToken:  tokenPair.AccessToken,
-		RefreshToken: tokenPair.RefreshToken,
-		TokenType:    tokenPair.TokenType,
-		ExpiresIn:    tokenPair.ExpiresIn,
-		Message:      h.i18n.Translate(c.Get("Accept-Language", "en"), "login_successful", nil),
+		LogServiceError(c, err, "Refresh token")
+		return domain.ErrInvalidTokenError()
+	}
+
+	LogServiceSuccess(c, "Refresh token", zap.String("user_id", user.ID.Hex()))
+
+	return h.sessionResponse(c, user, tokenPair, "login_successful")
+}
+
+// SilentRefresh handles refreshing a cookie session
+// @Summary Silently refresh browser session
+// @Description Rotate the session cookies using the httpOnly refresh token cookie. Intended to be called in the background by the web app.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} LoginResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /auth/session/refresh [post]
+func (h *UserHandler) SilentRefresh(c *fiber.Ctx) error {
+	refreshToken := h.cookies.RefreshToken(c)
+	if refreshToken == "" {
+		return domain.ErrInvalidTokenError()
+	}
+
+	tokenPair, user, err := h.userService.RefreshToken(c.Context(), refreshToken)
+	if err != nil {
+		LogServiceError(c, err, "Silent refresh")
+		h.cookies.Clear(c)
+		return domain.ErrInvalidTokenError()
+	}
+
+	h.cookies.Set(c, tokenPair)
+
+	return Respond(c, fiber.StatusOK, LoginResponse{
+		User:      user,
+		TokenType: tokenPair.TokenType,
+		ExpiresIn: tokenPair.ExpiresIn,
+		Message:   h.i18n.Translate(getLocale(c), "login_successful", nil),
 	})
 }
 
+// sessionResponse writes the login response with the message messageID. In cookie
+// mode the tokens are set as httpOnly cookies and left out of the body.
+func (h *UserHandler) sessionResponse(c *fiber.Ctx, user *domain.UserResponse, tokenPair *domain.TokenPair, messageID string) error {
+	response := LoginResponse{
+		User:      user,
+		TokenType: tokenPair.TokenType,
+		ExpiresIn: tokenPair.ExpiresIn,
+		Message:   h.i18n.Translate(getLocale(c), messageID, nil),
+	}
+
+	if h.cookies.Enabled(c) {
+		h.cookies.Set(c, tokenPair)
+	} else {
+		response.AccessToken = tokenPair.AccessToken
+		response.RefreshToken = tokenPair.RefreshToken
+	}
+
+	return Respond(c, fiber.StatusOK, response)
+}
+
 // Logout handles user logout
 // @Summary Logout user
 // @Description Logout user and revoke refresh token
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Param request body domain.LogoutRequest true "Logout data"
+// @Param request body domain.LogoutRequest false "Logout data"
 // @Security BearerAuth
 // @Success 200 {object} SuccessResponse
 // @Failure 400 {object} ErrorResponse
 // @Router /auth/logout [post]
 func (h *UserHandler) Logout(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Logout")
+	LogRequestStart(c, "Logout")
 
 	var req domain.LogoutRequest
-	if err := c.BodyParser(&req); err != nil {
-		LogParsingError(h.logger, err, c, "Logout")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			LogParsingError(err, c, "Logout")
+			return domain.ErrInvalidRequestError("Invalid request body")
+		}
+	}
+	if req.RefreshToken == "" {
+		req.RefreshToken = h.cookies.RefreshToken(c)
 	}
 
-	LogRequestParsed(h.logger, c, "Logout",
-		zap.String("token_prefix", SafeTokenLog(req.RefreshToken)))
+	LogRequestParsed(c, "Logout")
 
 	if err := h.validator.Struct(&req); err != nil {
-		LogValidationError(h.logger, c, err, "Logout")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "validation_failed", nil),
-		})
+		LogValidationError(c, err, "Logout")
+		return domain.ErrValidationFailedError(nil)
 	}
 
-	LogServiceCall(h.logger, c, "Logout")
+	LogServiceCall(c, "Logout")
 
 	err := h.userService.Logout(c.Context(), req.RefreshToken)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Logout")
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to logout",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "internal_error", nil),
-		})
+		LogServiceError(c, err, "Logout")
+		return domain.ErrOperationFailedError("Failed to logout")
 	}
 
-	LogServiceSuccess(h.logger, c, "Logout")
+	LogServiceSuccess(c, "Logout")
 
-	return c.JSON(SuccessResponse{
-		Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "logout_successful", nil),
-	})
+	h.cookies.Clear(c)
+
+	return RespondMessage(c, fiber.StatusOK, nil, h.i18n.Translate(getLocale(c), "logout_successful", nil))
 }
 
 // VerifyEmail handles email verification
@@ -459,44 +484,32 @@ func (h *UserHandler) Logout(c *fiber.Ctx) error {
 // @Failure 404 {object} ErrorResponse
 // @Router /auth/verify-email [post]
 func (h *UserHandler) VerifyEmail(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Email verification")
+	LogRequestStart(c, "Email verification")
 
 	var req domain.EmailVerificationRequest
 	if err := c.BodyParser(&req); err != nil {
-		LogParsingError(h.logger, err, c, "Email verification")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		LogParsingError(err, c, "Email verification")
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
-	LogRequestParsed(h.logger, c, "Email verification",
-		zap.String("token_prefix", SafeTokenLog(req.Token)))
+	LogRequestParsed(c, "Email verification")
 
 	if err := h.validator.Struct(&req); err != nil {
-		LogValidationError(h.logger, c, err, "Email verification")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "validation_failed", nil),
-		})
+		LogValidationError(c, err, "Email verification")
+		return domain.ErrValidationFailedError(nil)
 	}
 
-	LogServiceCall(h.logger, c, "Email verification")
+	LogServiceCall(c, "Email verification")
 
 	err := h.userService.VerifyEmail(c.Context(), &req)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Email verification")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Email verification failed",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Email verification")
+		return err
 	}
 
-	LogServiceSuccess(h.logger, c, "Email verification")
+	LogServiceSuccess(c, "Email verification")
 
-	return c.JSON(SuccessResponse{
-		Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "email_verified", nil),
-	})
+	return RespondMessage(c, fiber.StatusOK, nil, h.i18n.Translate(getLocale(c), "email_verified", nil))
 }
 
 // ResendVerificationEmail handles resending verification email
@@ -510,45 +523,34 @@ func (h *UserHandler) VerifyEmail(c *fiber.Ctx) error {
 // @Failure 400 {object} ErrorResponse
 // @Router /auth/resend-verification [post]
 func (h *UserHandler) ResendVerificationEmail(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Resend verification email")
+	LogRequestStart(c, "Resend verification email")
 
 	var req domain.ResendVerificationRequest
 	if err := c.BodyParser(&req); err != nil {
-		LogParsingError(h.logger, err, c, "Resend verification email")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		LogParsingError(err, c, "Resend verification email")
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
-	LogRequestParsed(h.logger, c, "Resend verification email",
-		zap.String("email", req.Email))
+	LogRequestParsed(c, "Resend verification email",
+		pii.Field("email", req.Email))
 
 	if err := h.validator.Struct(&req); err != nil {
-		LogValidationError(h.logger, c, err, "Resend verification email",
-			zap.String("email", req.Email))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "validation_failed", nil),
-		})
+		LogValidationError(c, err, "Resend verification email",
+			pii.Field("email", req.Email))
+		return domain.ErrValidationFailedError(nil)
 	}
 
-	LogServiceCall(h.logger, c, "Resend verification email", zap.String("email", req.Email))
+	LogServiceCall(c, "Resend verification email", pii.Field("email", req.Email))
 
 	err := h.userService.ResendVerificationEmail(c.Context(), &req)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Resend verification email", zap.String("email", req.Email))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Failed to resend verification email",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Resend verification email", pii.Field("email", req.Email))
+		return err
 	}
 
-	LogServiceSuccess(h.logger, c, "Resend verification email", zap.String("email", req.Email))
+	LogServiceSuccess(c, "Resend verification email", pii.Field("email", req.Email))
 
-	return c.JSON(SuccessResponse{
-		Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "verification_email_sent", nil),
-	})
+	return RespondMessage(c, fiber.StatusOK, nil, h.i18n.Translate(getLocale(c), "verification_email_sent", nil))
 }
 
 // ForgotPassword handles password reset request
@@ -562,45 +564,34 @@ func (h *UserHandler) ResendVerificationEmail(c *fiber.Ctx) error {
 // @Failure 400 {object} ErrorResponse
 // @Router /auth/forgot-password [post]
 func (h *UserHandler) ForgotPassword(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Forgot password")
+	LogRequestStart(c, "Forgot password")
 
 	var req domain.ForgotPasswordRequest
 	if err := c.BodyParser(&req); err != nil {
-		LogParsingError(h.logger, err, c, "Forgot password")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		LogParsingError(err, c, "Forgot password")
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
-	LogRequestParsed(h.logger, c, "Forgot password",
-		zap.String("email", req.Email))
+	LogRequestParsed(c, "Forgot password",
+		pii.Field("email", req.Email))
 
 	if err := h.validator.Struct(&req); err != nil {
-		LogValidationError(h.logger, c, err, "Forgot password",
-			zap.String("email", req.Email))
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "validation_failed", nil),
-		})
+		LogValidationError(c, err, "Forgot password",
+			pii.Field("email", req.Email))
+		return domain.ErrValidationFailedError(nil)
 	}
 
-	LogServiceCall(h.logger, c, "Forgot password", zap.String("email", req.Email))
+	LogServiceCall(c, "Forgot password", pii.Field("email", req.Email))
 
 	err := h.userService.ForgotPassword(c.Context(), &req)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Forgot password", zap.String("email", req.Email))
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to process request",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "internal_error", nil),
-		})
+		LogServiceError(c, err, "Forgot password", pii.Field("email", req.Email))
+		return domain.ErrOperationFailedError("Failed to process request")
 	}
 
-	LogServiceSuccess(h.logger, c, "Forgot password", zap.String("email", req.Email))
+	LogServiceSuccess(c, "Forgot password", pii.Field("email", req.Email))
 
-	return c.JSON(SuccessResponse{
-		Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "password_reset_email_sent", nil),
-	})
+	return RespondMessage(c, fiber.StatusOK, nil, h.i18n.Translate(getLocale(c), "password_reset_email_sent", nil))
 }
 
 // ResetPassword handles password reset
@@ -614,57 +605,86 @@ func (h *UserHandler) ForgotPassword(c *fiber.Ctx) error {
 // @Failure 400 {object} ErrorResponse
 // @Router /auth/reset-password [post]
 func (h *UserHandler) ResetPassword(c *fiber.Ctx) error {
-	LogRequestStart(h.logger, c, "Reset password")
+	LogRequestStart(c, "Reset password")
 
 	var req domain.ResetPasswordRequest
 	if err := c.BodyParser(&req); err != nil {
-		LogParsingError(h.logger, err, c, "Reset password")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Invalid request body",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "invalid_request", nil),
-		})
+		LogParsingError(err, c, "Reset password")
+		return domain.ErrInvalidRequestError("Invalid request body")
 	}
 
-	LogRequestParsed(h.logger, c, "Reset password",
-		zap.String("token_prefix", SafeTokenLog(req.Token)))
+	LogRequestParsed(c, "Reset password")
 
 	if err := h.validator.Struct(&req); err != nil {
-		LogValidationError(h.logger, c, err, "Reset password")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Validation failed",
-			Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "validation_failed", nil),
-		})
+		LogValidationError(c, err, "Reset password")
+		return domain.ErrValidationFailedError(nil)
 	}
 
-	LogServiceCall(h.logger, c, "Reset password")
+	LogServiceCall(c, "Reset password")
 
 	err := h.userService.ResetPassword(c.Context(), &req)
 	if err != nil {
-		LogServiceError(h.logger, c, err, "Reset password")
-		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{
-			Error:   "Password reset failed",
-			Message: err.Error(),
-		})
+		LogServiceError(c, err, "Reset password")
+		return err
 	}
 
-	LogServiceSuccess(h.logger, c, "Reset password")
+	LogServiceSuccess(c, "Reset password")
 
-	return c.JSON(SuccessResponse{
-		Message: h.i18n.Translate(c.Get("Accept-Language", "en"), "password_reset_successful", nil),
-	})
+	return RespondMessage(c, fiber.StatusOK, nil, h.i18n.Translate(getLocale(c), "password_reset_successful", nil))
+}
+
+// ChangePassword handles changing the password of the logged-in user
+// @Summary Change password
+// @Description Change the password, confirming the current one. Every session is signed out, and a new session is returned for this device.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body domain.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} LoginResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /users/password [put]
+func (h *UserHandler) ChangePassword(c *fiber.Ctx) error {
+	LogRequestStart(c, "Change password")
+
+	userID := getUserIDFromContext(c)
+
+	var req domain.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		LogParsingError(err, c, "Change password")
+		return domain.ErrInvalidRequestError("Invalid request body")
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		LogValidationError(c, err, "Change password", zap.String("user_id", userID.Hex()))
+		return domain.ErrValidationFailedError(getValidationErrors(err))
+	}
+
+	LogServiceCall(c, "Change password", zap.String("user_id", userID.Hex()))
+
+	user, tokenPair, err := h.userService.ChangePassword(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Change password", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	LogServiceSuccess(c, "Change password", zap.String("user_id", userID.Hex()))
+
+	return h.sessionResponse(c, user, tokenPair, "password_changed")
 }
 
 // UnmatchPartner godoc
 // @Summary Unmatch from partner
-// @Description Break match with partner and delete all shared data (events and photos)
+// @Description Schedule breaking the match with the partner. The couple stays matched for a 7-day grace period, during which the user can cancel; afterwards all shared data (events and photos) is deleted.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Success 200 {object} SuccessResponse
+// @Success 202 {object} domain.PendingUnmatch
 // @Failure 400 {object} ErrorResponse
 // @Failure 401 {object} ErrorResponse
-// @Failure 500 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Router /users/unmatch [post]
 func (h *UserHandler) UnmatchPartner(c *fiber.Ctx) error {
 	userID := getUserIDFromContext(c)
@@ -673,17 +693,37 @@ func (h *UserHandler) UnmatchPartner(c *fiber.Ctx) error {
 		zap.String("trace_id", getTraceID(c)),
 		zap.String("user_id", userID.Hex()))
 	
-	if err := h.userService.UnmatchPartner(c.Context(), userID); err != nil {
-		LogServiceError(h.logger, c, err, "Unmatch partner")
-		return c.Status(fiber.StatusInternalServerError).JSON(ErrorResponse{
-			Error:   "Failed to unmatch",
-			Message: err.Error(),
-		})
+	pending, err := h.userService.UnmatchPartner(c.Context(), userID)
+	if err != nil {
+		LogServiceError(c, err, "Unmatch partner")
+		return err
 	}
 	
-	LogServiceSuccess(h.logger, c, "Unmatch partner")
+	LogServiceSuccess(c, "Unmatch partner")
 	
-	return c.JSON(SuccessResponse{
-		Message: "Successfully unmatched from partner. All shared data has been deleted.",
-	})
+	return Respond(c, fiber.StatusAccepted, pending)
+}
+
+// CancelUnmatch godoc
+// @Summary Cancel unmatch
+// @Description Cancel a pending unmatch during its grace period, keeping the match and all shared data. Only the partner who asked to unmatch can cancel it.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Router /users/unmatch/cancel [post]
+func (h *UserHandler) CancelUnmatch(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	if err := h.userService.CancelUnmatch(c.Context(), userID); err != nil {
+		LogServiceError(c, err, "Cancel unmatch")
+		return err
+	}
+
+	LogServiceSuccess(c, "Cancel unmatch")
+
+	return RespondMessage(c, fiber.StatusOK, nil, h.i18n.Translate(getLocale(c), "unmatch_cancelled", nil))
 }
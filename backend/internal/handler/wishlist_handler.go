@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// WishlistHandler handles wishlist HTTP requests
+type WishlistHandler struct {
+	wishlistService domain.WishlistService
+	validator       *validator.Validate
+	i18n            *i18n.I18n
+	logger          *zap.Logger
+}
+
+// NewWishlistHandler creates a new wishlist handler
+func NewWishlistHandler(
+	wishlistService domain.WishlistService,
+	validator *validator.Validate,
+	i18n *i18n.I18n,
+	logger *zap.Logger,
+) *WishlistHandler {
+	return &WishlistHandler{
+		wishlistService: wishlistService,
+		validator:       validator,
+		i18n:            i18n,
+		logger:          logger,
+	}
+}
+
+// CreateItem handles adding a wishlist item
+// @Summary Add a wishlist item
+// @Description Add a gift idea to your wishlist. Secret items are hidden from your partner until they are marked purchased.
+// @Tags wishlist
+// @Accept json
+// @Produce json
+// @Param request body domain.CreateWishlistItemRequest true "Wishlist item"
+// @Security BearerAuth
+// @Success 201 {object} domain.WishlistItemResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /wishlist [post]
+func (h *WishlistHandler) CreateItem(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	var req domain.CreateWishlistItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.invalidBody(c)
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return h.validationFailed(c, err)
+	}
+
+	item, err := h.wishlistService.CreateItem(c.Context(), userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Create wishlist item", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusCreated, item)
+}
+
+// GetItems handles listing the couple's wishlists
+// @Summary Get wishlist items
+// @Description Get the wishlist items you can see, open items first: your own, and your partner's except their secret items that are not purchased yet
+// @Tags wishlist
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Param owner query string false "Filter by owner (mine, partner)"
+// @Param status query string false "Filter by status (open, purchased)"
+// @Security BearerAuth
+// @Success 200 {object} domain.WishlistResponse
+// @Failure 401 {object} ErrorResponse
+// @Router /wishlist [get]
+func (h *WishlistHandler) GetItems(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	owner := domain.WishlistOwner(c.Query("owner"))
+	status := domain.WishlistStatus(c.Query("status"))
+
+	items, err := h.wishlistService.GetItems(c.Context(), userID, owner, status, page, limit)
+	if err != nil {
+		LogServiceError(c, err, "Get wishlist", zap.String("user_id", userID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, items)
+}
+
+// GetItem handles getting a wishlist item
+// @Summary Get wishlist item by ID
+// @Description Get a wishlist item. Your partner's secret items are not found until they are purchased.
+// @Tags wishlist
+// @Produce json
+// @Param id path string true "Wishlist item ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.WishlistItemResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /wishlist/{id} [get]
+func (h *WishlistHandler) GetItem(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	itemID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidItemID(c)
+	}
+
+	item, err := h.wishlistService.GetItem(c.Context(), itemID, userID)
+	if err != nil {
+		LogServiceError(c, err, "Get wishlist item",
+			zap.String("user_id", userID.Hex()),
+			zap.String("item_id", itemID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, item)
+}
+
+// UpdateItem handles updating a wishlist item
+// @Summary Update wishlist item
+// @Description Update one of your wishlist items. An empty url or notes clears them.
+// @Tags wishlist
+// @Accept json
+// @Produce json
+// @Param id path string true "Wishlist item ID"
+// @Param request body domain.UpdateWishlistItemRequest true "Changes"
+// @Security BearerAuth
+// @Success 200 {object} domain.WishlistItemResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /wishlist/{id} [put]
+func (h *WishlistHandler) UpdateItem(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	itemID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidItemID(c)
+	}
+
+	var req domain.UpdateWishlistItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return h.invalidBody(c)
+	}
+
+	if err := h.validator.Struct(&req); err != nil {
+		return h.validationFailed(c, err)
+	}
+
+	item, err := h.wishlistService.UpdateItem(c.Context(), itemID, userID, &req)
+	if err != nil {
+		LogServiceError(c, err, "Update wishlist item",
+			zap.String("user_id", userID.Hex()),
+			zap.String("item_id", itemID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, item)
+}
+
+// DeleteItem handles deleting a wishlist item
+// @Summary Delete wishlist item
+// @Description Delete one of your wishlist items
+// @Tags wishlist
+// @Produce json
+// @Param id path string true "Wishlist item ID"
+// @Security BearerAuth
+// @Success 204
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /wishlist/{id} [delete]
+func (h *WishlistHandler) DeleteItem(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	itemID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidItemID(c)
+	}
+
+	if err := h.wishlistService.DeleteItem(c.Context(), itemID, userID); err != nil {
+		LogServiceError(c, err, "Delete wishlist item",
+			zap.String("user_id", userID.Hex()),
+			zap.String("item_id", itemID.Hex()))
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// MarkPurchased handles marking a wishlist item purchased
+// @Summary Mark wishlist item purchased
+// @Description Mark a wishlist item as purchased by you. A secret item becomes visible to your partner.
+// @Tags wishlist
+// @Produce json
+// @Param id path string true "Wishlist item ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.WishlistItemResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /wishlist/{id}/purchase [post]
+func (h *WishlistHandler) MarkPurchased(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	itemID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidItemID(c)
+	}
+
+	item, err := h.wishlistService.MarkPurchased(c.Context(), itemID, userID)
+	if err != nil {
+		LogServiceError(c, err, "Mark wishlist item purchased",
+			zap.String("user_id", userID.Hex()),
+			zap.String("item_id", itemID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, item)
+}
+
+// UnmarkPurchased handles putting a purchased wishlist item back on the list
+// @Summary Unmark wishlist item purchased
+// @Description Mark a purchased wishlist item as not purchased. Only its owner or the partner who purchased it can. A secret item is hidden from the partner again.
+// @Tags wishlist
+// @Produce json
+// @Param id path string true "Wishlist item ID"
+// @Security BearerAuth
+// @Success 200 {object} domain.WishlistItemResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /wishlist/{id}/purchase [delete]
+func (h *WishlistHandler) UnmarkPurchased(c *fiber.Ctx) error {
+	userID := getUserIDFromContext(c)
+	itemID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return h.invalidItemID(c)
+	}
+
+	item, err := h.wishlistService.UnmarkPurchased(c.Context(), itemID, userID)
+	if err != nil {
+		LogServiceError(c, err, "Unmark wishlist item purchased",
+			zap.String("user_id", userID.Hex()),
+			zap.String("item_id", itemID.Hex()))
+		return err
+	}
+
+	return Respond(c, fiber.StatusOK, item)
+}
+
+// invalidItemID returns the error for a malformed wishlist item ID
+func (h *WishlistHandler) invalidItemID(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Invalid wishlist item ID")
+}
+
+// invalidBody returns the error for a request body that cannot be parsed
+func (h *WishlistHandler) invalidBody(c *fiber.Ctx) error {
+	return domain.ErrInvalidRequestError("Invalid request body")
+}
+
+// validationFailed returns the error listing the invalid fields
+func (h *WishlistHandler) validationFailed(c *fiber.Ctx, err error) error {
+	return domain.ErrValidationFailedError(getValidationErrors(err))
+}
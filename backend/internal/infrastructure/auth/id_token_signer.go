@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// idTokenKeyBits is the size of the signing keys generated when none is configured
+const idTokenKeyBits = 2048
+
+// IDTokenSigner signs OpenID Connect ID tokens with an RSA key. Clients verify them
+// with the public key, so they never need a shared secret.
+type IDTokenSigner struct {
+	key   *rsa.PrivateKey
+	keyID string
+}
+
+// NewIDTokenSigner creates a signer from a PEM encoded RSA private key (PKCS#1 or PKCS#8)
+func NewIDTokenSigner(pemData []byte) (*IDTokenSigner, error) {
+	block, _ := pem.Decode(pemData)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return newIDTokenSigner(key), nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an RSA key")
+	}
+	return newIDTokenSigner(key), nil
+}
+
+// GenerateIDTokenSigner creates a signer with a new random key. Tokens it signs can
+// no longer be verified once the process exits.
+func GenerateIDTokenSigner() (*IDTokenSigner, error) {
+	key, err := rsa.GenerateKey(rand.Reader, idTokenKeyBits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return newIDTokenSigner(key), nil
+}
+
+// newIDTokenSigner derives the key ID from the public modulus, so it only changes
+// with the key
+func newIDTokenSigner(key *rsa.PrivateKey) *IDTokenSigner {
+	sum := sha256.Sum256(key.PublicKey.N.Bytes())
+	return &IDTokenSigner{
+		key:   key,
+		keyID: base64.RawURLEncoding.EncodeToString(sum[:12]),
+	}
+}
+
+// Sign signs claims with RS256, naming the key in the token header
+func (s *IDTokenSigner) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = s.keyID
+
+	tokenString, err := token.SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token: %w", err)
+	}
+	return tokenString, nil
+}
+
+// KeyID returns the ID of the signing key
+func (s *IDTokenSigner) KeyID() string {
+	return s.keyID
+}
+
+// PublicKey returns the key that verifies the signed tokens
+func (s *IDTokenSigner) PublicKey() *rsa.PublicKey {
+	return &s.key.PublicKey
+}
@@ -1,38 +1,105 @@
 package auth
 
 import (
+	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	// keyReloadInterval is how often, at most, a token naming a key the keyring lacks
+	// makes the keyring read again
+	keyReloadInterval = 10 * time.Second
+	keyReloadTimeout  = 5 * time.Second
+)
+
 // JWTClaims represents the JWT claims
 type JWTClaims struct {
 	UserID    primitive.ObjectID `json:"user_id"`
 	Email     string             `json:"email"`
 	Name      string             `json:"name"`
-	TokenType string             `json:"token_type"` // "access" or "refresh"
+	TokenType string             `json:"token_type"`          // "access" or "refresh"
+	ClientID  string             `json:"client_id,omitempty"` // OAuth client a refresh token was issued to
+	Scope     string             `json:"scope,omitempty"`     // scopes granted to that client
+	FamilyID  string             `json:"fid,omitempty"`       // rotation family of a session refresh token
+	Roles     []string           `json:"roles,omitempty"`     // roles of the user an access token was issued to
+	Locale    string             `json:"locale,omitempty"`    // preferred language of that user
 	jwt.RegisteredClaims
 }
 
-// JWTManager handles JWT operations
+// JWTManager handles JWT operations. Tokens are signed with the current key of a
+// keyring and name it in their kid header; they verify with any key of the ring, so
+// that rotating the key does not sign everyone out.
 type JWTManager struct {
-	secretKey            string
-	accessExpiration     time.Duration
-	refreshExpiration    time.Duration
+	mu                sync.RWMutex
+	current           signingKey
+	previous          []signingKey
+	legacy            signingKey // key that signed the tokens issued before keys had IDs
+	legacyUntil       time.Time  // when the last of those tokens has expired
+	accessExpiration  time.Duration
+	refreshExpiration time.Duration
+
+	reloadMu   sync.Mutex
+	reload     func(ctx context.Context) error
+	lastReload time.Time
+}
+
+// signingKey is a key of the keyring. Keys retired by a rotation are dropped once the
+// tokens they signed have expired; configured previous keys have no retiredAt and stay.
+type signingKey struct {
+	id        string
+	secret    []byte
+	retiredAt time.Time
+}
+
+// SigningKeyInfo describes a key of the keyring, without its secret
+type SigningKeyInfo struct {
+	ID        string     `json:"kid"`
+	Current   bool       `json:"current"`
+	RetiredAt *time.Time `json:"retired_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // when tokens stop verifying with a retired key
 }
 
-// NewJWTManager creates a new JWT manager
-func NewJWTManager(secretKey string, accessExpirationMinutes, refreshExpirationHours int) *JWTManager {
-	return &JWTManager{
-		secretKey:         secretKey,
+// NewJWTManager creates a new JWT manager that signs with secretKey and also verifies
+// tokens signed with previousKeys. Tokens without a kid header verify with legacySecret,
+// or secretKey when it is empty.
+func NewJWTManager(secretKey, legacySecret string, previousKeys []string, accessExpirationMinutes, refreshExpirationHours int) *JWTManager {
+	if legacySecret == "" {
+		legacySecret = secretKey
+	}
+
+	j := &JWTManager{
+		current:           newSigningKey(secretKey),
+		legacy:            newSigningKey(legacySecret),
 		accessExpiration:  time.Duration(accessExpirationMinutes) * time.Minute,
 		refreshExpiration: time.Duration(refreshExpirationHours) * time.Hour,
 	}
+	j.legacyUntil = time.Now().Add(j.refreshExpiration)
+	for _, secret := range previousKeys {
+		if key := newSigningKey(secret); secret != "" && key.id != j.current.id {
+			j.previous = append(j.previous, key)
+		}
+	}
+	return j
+}
+
+// newSigningKey returns the key of secret. Its ID is derived from the secret, so that
+// every instance names the same key alike.
+func newSigningKey(secret string) signingKey {
+	return signingKey{id: KeyID(secret), secret: []byte(secret)}
+}
+
+// KeyID returns the kid of the key of secret
+func KeyID(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:8])
 }
 
 // TokenPair represents access and refresh token pair
@@ -41,18 +108,65 @@ type TokenPair struct {
 	RefreshToken string `json:"refresh_token"`
 	TokenType    string `json:"token_type"`
 	ExpiresIn    int64  `json:"expires_in"` // seconds
+	FamilyID     string `json:"-"`          // rotation family of the refresh token
+}
+
+// GenerateTokenPair generates both access and refresh tokens, the access token carrying
+// the user's preferred language and roles. The refresh token starts a new rotation family.
+func (j *JWTManager) GenerateTokenPair(userID primitive.ObjectID, email, name, locale string, roles []string) (*TokenPair, error) {
+	familyID, err := j.GenerateRefreshTokenString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token family: %w", err)
+	}
+
+	return j.GenerateFamilyTokenPair(userID, email, name, locale, familyID, roles)
 }
 
-// GenerateTokenPair generates both access and refresh tokens
-func (j *JWTManager) GenerateTokenPair(userID primitive.ObjectID, email, name string) (*TokenPair, error) {
+// GenerateFamilyTokenPair generates both access and refresh tokens, the refresh token
+// continuing the rotation family familyID. Each refresh token has its own ID, so
+// that it can be redeemed only once.
+func (j *JWTManager) GenerateFamilyTokenPair(userID primitive.ObjectID, email, name, locale, familyID string, roles []string) (*TokenPair, error) {
 	// Generate access token
-	accessToken, err := j.generateToken(userID, email, name, "access", j.accessExpiration)
+	accessToken, err := j.generateToken(userID, email, name, locale, roles, "access", j.accessExpiration)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
 	// Generate refresh token
-	refreshToken, err := j.generateToken(userID, email, name, "refresh", j.refreshExpiration)
+	tokenID, err := j.GenerateRefreshTokenString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token ID: %w", err)
+	}
+	claims := j.newClaims(userID, email, name, "refresh", j.refreshExpiration)
+	claims.ID = tokenID
+	claims.FamilyID = familyID
+	refreshToken, err := j.sign(claims)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(j.accessExpiration.Seconds()),
+		FamilyID:     familyID,
+	}, nil
+}
+
+// GenerateClientTokenPair generates tokens for an OAuth client. The refresh token is
+// bound to the client and the scopes it was granted, and can only be redeemed by it.
+// Client tokens never carry the user's roles.
+func (j *JWTManager) GenerateClientTokenPair(userID primitive.ObjectID, email, name, clientID, scope string) (*TokenPair, error) {
+	accessToken, err := j.generateToken(userID, email, name, "", nil, "access", j.accessExpiration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	claims := j.newClaims(userID, email, name, "refresh", j.refreshExpiration)
+	claims.ClientID = clientID
+	claims.Scope = scope
+	refreshToken, err := j.sign(claims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
@@ -67,12 +181,20 @@ func (j *JWTManager) GenerateTokenPair(userID primitive.ObjectID, email, name st
 
 // GenerateToken generates a new JWT access token (for backward compatibility)
 func (j *JWTManager) GenerateToken(userID primitive.ObjectID, email, name string) (string, error) {
-	return j.generateToken(userID, email, name, "access", j.accessExpiration)
+	return j.generateToken(userID, email, name, "", nil, "access", j.accessExpiration)
+}
+
+// generateToken generates a JWT token with specified locale, roles, type and expiration
+func (j *JWTManager) generateToken(userID primitive.ObjectID, email, name, locale string, roles []string, tokenType string, expiration time.Duration) (string, error) {
+	claims := j.newClaims(userID, email, name, tokenType, expiration)
+	claims.Locale = locale
+	claims.Roles = roles
+	return j.sign(claims)
 }
 
-// generateToken generates a JWT token with specified type and expiration
-func (j *JWTManager) generateToken(userID primitive.ObjectID, email, name, tokenType string, expiration time.Duration) (string, error) {
-	claims := &JWTClaims{
+// newClaims returns the claims of a token of the given type and expiration
+func (j *JWTManager) newClaims(userID primitive.ObjectID, email, name, tokenType string, expiration time.Duration) *JWTClaims {
+	return &JWTClaims{
 		UserID:    userID,
 		Email:     email,
 		Name:      name,
@@ -85,9 +207,17 @@ func (j *JWTManager) generateToken(userID primitive.ObjectID, email, name, token
 			Subject:   userID.Hex(),
 		},
 	}
+}
+
+// sign signs claims into a token string
+func (j *JWTManager) sign(claims *JWTClaims) (string, error) {
+	j.mu.RLock()
+	key := j.current
+	j.mu.RUnlock()
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(j.secretKey))
+	token.Header["kid"] = key.id
+	tokenString, err := token.SignedString(key.secret)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -106,12 +236,7 @@ func (j *JWTManager) GenerateRefreshTokenString() (string, error) {
 
 // ValidateToken validates a JWT token and returns the claims
 func (j *JWTManager) ValidateToken(tokenString string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(j.secretKey), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, j.KeyFunc)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -138,7 +263,7 @@ func (j *JWTManager) RefreshTokenPair(refreshTokenString string) (*TokenPair, er
 	}
 
 	// Generate new token pair
-	return j.GenerateTokenPair(claims.UserID, claims.Email, claims.Name)
+	return j.GenerateTokenPair(claims.UserID, claims.Email, claims.Name, claims.Locale, claims.Roles)
 }
 
 // RefreshToken generates a new access token from refresh token (for backward compatibility)
@@ -190,10 +315,140 @@ func (j *JWTManager) GetUserIDFromToken(tokenString string) (primitive.ObjectID,
 
 // GenerateRefreshToken generates a new JWT refresh token
 func (j *JWTManager) GenerateRefreshToken(userID primitive.ObjectID, email, name string) (string, error) {
-	return j.generateToken(userID, email, name, "refresh", j.refreshExpiration)
+	return j.generateToken(userID, email, name, "", nil, "refresh", j.refreshExpiration)
+}
+
+// RefreshExpiration returns how long refresh tokens are valid
+func (j *JWTManager) RefreshExpiration() time.Duration {
+	return j.refreshExpiration
+}
+
+// KeyFunc returns the key a token is verified with, for middleware that parses tokens
+// itself. Tokens without a kid header were issued before keys had IDs; they verify with
+// the legacy secret until they have all expired, and are rejected afterwards. A token
+// naming a key the keyring lacks may have been signed by an instance that rotated the
+// key already, so the keyring is read again before the token is rejected.
+func (j *JWTManager) KeyFunc(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		j.mu.RLock()
+		defer j.mu.RUnlock()
+		if !time.Now().Before(j.legacyUntil) {
+			return nil, fmt.Errorf("token has no signing key ID")
+		}
+		return j.legacy.secret, nil
+	}
+
+	if secret, ok := j.lookup(kid); ok {
+		return secret, nil
+	}
+	if j.reloadKeys() {
+		if secret, ok := j.lookup(kid); ok {
+			return secret, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key: %s", kid)
+}
+
+// SetKeyReloader sets how the keyring is read again when a token names a key it lacks,
+// typically by reading the JWT secret from the secret manager
+func (j *JWTManager) SetKeyReloader(reload func(ctx context.Context) error) {
+	j.reloadMu.Lock()
+	defer j.reloadMu.Unlock()
+	j.reload = reload
+}
+
+// lookup returns the secret of the key of the keyring named kid
+func (j *JWTManager) lookup(kid string) ([]byte, bool) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	if kid == j.current.id {
+		return j.current.secret, true
+	}
+	now := time.Now()
+	for _, key := range j.previous {
+		if key.id == kid && !j.expired(key, now) {
+			return key.secret, true
+		}
+	}
+	return nil, false
+}
+
+// reloadKeys reads the keyring again, unless it was read less than keyReloadInterval
+// ago, and reports whether the keyring may have changed. Concurrent callers wait for
+// the one reading it.
+func (j *JWTManager) reloadKeys() bool {
+	j.reloadMu.Lock()
+	defer j.reloadMu.Unlock()
+
+	if j.reload == nil {
+		return false
+	}
+	if time.Since(j.lastReload) < keyReloadInterval {
+		return true
+	}
+	j.lastReload = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), keyReloadTimeout)
+	defer cancel()
+	return j.reload(ctx) == nil
+}
+
+// RotateKey makes secretKey the key new tokens are signed with. The key it replaces
+// keeps verifying the tokens it signed until they expire. Rotating to the current key
+// does nothing.
+func (j *JWTManager) RotateKey(secretKey string) {
+	key := newSigningKey(secretKey)
+	now := time.Now()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if key.id == j.current.id {
+		return
+	}
+
+	retired := j.current
+	retired.retiredAt = now
+	previous := []signingKey{retired}
+	for _, existing := range j.previous {
+		if existing.id != key.id && existing.id != retired.id && !j.expired(existing, now) {
+			previous = append(previous, existing)
+		}
+	}
+	j.current = key
+	j.previous = previous
+}
+
+// Keys describes the keys of the keyring, the current one first
+func (j *JWTManager) Keys() []SigningKeyInfo {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	now := time.Now()
+	keys := []SigningKeyInfo{{ID: j.current.id, Current: true}}
+	for _, key := range j.previous {
+		if j.expired(key, now) {
+			continue
+		}
+		info := SigningKeyInfo{ID: key.id}
+		if !key.retiredAt.IsZero() {
+			retiredAt := key.retiredAt
+			expiresAt := key.retiredAt.Add(j.refreshExpiration)
+			info.RetiredAt = &retiredAt
+			info.ExpiresAt = &expiresAt
+		}
+		keys = append(keys, info)
+	}
+	return keys
 }
 
-// GetSecretKey returns the secret key (for middleware)
-func (j *JWTManager) GetSecretKey() string {
-	return j.secretKey
+// expired tells whether every token a retired key signed has expired by now
+func (j *JWTManager) expired(key signingKey, now time.Time) bool {
+	return !key.retiredAt.IsZero() && now.After(key.retiredAt.Add(j.refreshExpiration))
 }
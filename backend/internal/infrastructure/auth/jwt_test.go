@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	testSecret     = "test-jwt-secret-0123456789abcdefghij"
+	testNextSecret = "test-jwt-secret-next-0123456789abcdef"
+)
+
+// signWithKid signs an access token with secret and the given kid header, leaving the
+// header out when kid is empty
+func signWithKid(t *testing.T, j *JWTManager, secret, kid string) string {
+	t.Helper()
+	claims := j.newClaims(primitive.NewObjectID(), "alex@example.com", "Alex", "access", j.accessExpiration)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	tokenString, err := token.SignedString([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tokenString
+}
+
+func TestValidateTokenWithoutKid(t *testing.T) {
+	j := NewJWTManager(testSecret, "", nil, 15, 168)
+	token := signWithKid(t, j, testSecret, "")
+
+	if _, err := j.ValidateToken(token); err != nil {
+		t.Fatalf("within the grace period: err = %v, want nil", err)
+	}
+
+	j.RotateKey(testNextSecret)
+	if _, err := j.ValidateToken(token); err != nil {
+		t.Errorf("after rotation: err = %v, want nil", err)
+	}
+	if _, err := j.ValidateToken(signWithKid(t, j, testNextSecret, "")); err == nil {
+		t.Error("token signed by the new key without a kid was accepted")
+	}
+
+	j.legacyUntil = time.Now().Add(-time.Second)
+	if _, err := j.ValidateToken(token); err == nil {
+		t.Error("after the grace period: token without a kid was accepted")
+	}
+}
+
+func TestValidateTokenWithoutKidAfterRestart(t *testing.T) {
+	// An instance started after JWT_SECRET was rotated, with the old secret as legacy one
+	j := NewJWTManager(testNextSecret, testSecret, []string{testSecret}, 15, 168)
+
+	if _, err := j.ValidateToken(signWithKid(t, j, testSecret, "")); err != nil {
+		t.Errorf("token of the legacy secret: err = %v, want nil", err)
+	}
+	if _, err := j.ValidateToken(signWithKid(t, j, testNextSecret, "")); err == nil {
+		t.Error("token signed by the current key without a kid was accepted")
+	}
+}
+
+func TestValidateTokenUnknownKid(t *testing.T) {
+	j := NewJWTManager(testSecret, "", nil, 15, 168)
+
+	if _, err := j.ValidateToken(signWithKid(t, j, testSecret, "unknown")); err == nil {
+		t.Error("token with an unknown kid was accepted")
+	}
+}
+
+func TestValidateTokenRetiredKey(t *testing.T) {
+	j := NewJWTManager(testSecret, "", nil, 15, 168)
+	token, err := j.GenerateToken(primitive.NewObjectID(), "alex@example.com", "Alex")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j.RotateKey(testNextSecret)
+	if _, err := j.ValidateToken(token); err != nil {
+		t.Fatalf("after rotation: err = %v, want nil", err)
+	}
+
+	j.previous[0].retiredAt = time.Now().Add(-j.refreshExpiration - time.Second)
+	if _, err := j.ValidateToken(token); err == nil {
+		t.Error("token of an expired key was accepted")
+	}
+}
+
+func TestValidateTokenRotatedElsewhere(t *testing.T) {
+	rotated := NewJWTManager(testSecret, "", nil, 15, 168)
+	rotated.RotateKey(testNextSecret)
+	token, err := rotated.GenerateToken(primitive.NewObjectID(), "alex@example.com", "Alex")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j := NewJWTManager(testSecret, "", nil, 15, 168)
+	reloads := 0
+	j.SetKeyReloader(func(ctx context.Context) error {
+		reloads++
+		j.RotateKey(testNextSecret)
+		return nil
+	})
+
+	if _, err := j.ValidateToken(token); err != nil {
+		t.Fatalf("err = %v, want nil", err)
+	}
+	if _, err := j.ValidateToken(signWithKid(t, j, testSecret, "unknown")); err == nil {
+		t.Error("token with an unknown kid was accepted")
+	}
+	if _, err := j.ValidateToken(signWithKid(t, j, testSecret, "other")); err == nil {
+		t.Error("token with an unknown kid was accepted")
+	}
+	if reloads != 1 {
+		t.Errorf("keyring read %d times, want 1", reloads)
+	}
+}
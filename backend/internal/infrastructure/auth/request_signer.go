@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/infrastructure/cache"
+)
+
+// Request signing headers sent by the official mobile apps
+const (
+	SignatureKeyHeader       = "X-Signature-Key"
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+	SignatureHeader          = "X-Signature"
+)
+
+// ErrSignatureStoreUnavailable is returned by Verify when a valid signature could not
+// be recorded. The request is rejected, as it could otherwise be replayed.
+var ErrSignatureStoreUnavailable = errors.New("signature store unavailable")
+
+// SignatureStore records the signatures already accepted
+type SignatureStore interface {
+	// MarkSeen records key for ttl and reports false if it is already recorded
+	MarkSeen(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// RequestSigner verifies HMAC-SHA256 request signatures made with keys embedded in
+// the official apps. The signature covers the method, the path with its query string,
+// the unix timestamp and the SHA-256 of the body, one per line:
+//
+//	hex(HMAC-SHA256(secret, METHOD + "\n" + URI + "\n" + TIMESTAMP + "\n" + hex(SHA256(body))))
+//
+// Requests outside the allowed clock skew are rejected, and a signature is only
+// accepted once within that window so captured requests cannot be replayed.
+type RequestSigner struct {
+	keys    map[string][]byte
+	maxSkew time.Duration
+	seen    SignatureStore
+}
+
+// NewRequestSigner creates a request signer from "keyID:secret" pairs.
+// Several keys can be active at once so app keys can be rotated between releases.
+func NewRequestSigner(keys []string, maxSkewSeconds int, seen SignatureStore) *RequestSigner {
+	parsed := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		if keyID, secret, ok := parseSigningKey(key); ok {
+			parsed[keyID] = []byte(secret)
+		}
+	}
+
+	return &RequestSigner{
+		keys:    parsed,
+		maxSkew: time.Duration(maxSkewSeconds) * time.Second,
+		seen:    seen,
+	}
+}
+
+// parseSigningKey splits a "keyID:secret" pair
+func parseSigningKey(key string) (keyID, secret string, ok bool) {
+	parts := strings.SplitN(strings.TrimSpace(key), ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// Verify checks the signature, the timestamp freshness and that the signature has not
+// been seen before
+func (s *RequestSigner) Verify(ctx context.Context, keyID, method, uri, timestamp, signature string, body []byte, now time.Time) error {
+	secret, ok := s.keys[keyID]
+	if !ok {
+		return fmt.Errorf("unknown signing key")
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid signature timestamp")
+	}
+
+	signedAt := time.Unix(unix, 0)
+	if now.Sub(signedAt) > s.maxSkew || signedAt.Sub(now) > s.maxSkew {
+		return fmt.Errorf("signature timestamp expired")
+	}
+
+	expected := sign(secret, method, uri, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return fmt.Errorf("invalid signature")
+	}
+
+	// A timestamp passes for maxSkew on either side of it, so a signature first seen at
+	// the start of that window must be remembered for twice the skew
+	fresh, err := s.seen.MarkSeen(ctx, "signature:"+keyID+":"+expected, 2*s.maxSkew)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrSignatureStoreUnavailable, err)
+	}
+	if !fresh {
+		return fmt.Errorf("signature already used")
+	}
+
+	return nil
+}
+
+// RedisSignatureStore records signatures in Redis, shared by all instances
+type RedisSignatureStore struct {
+	cache cache.Cache
+}
+
+// NewRedisSignatureStore creates a new Redis signature store
+func NewRedisSignatureStore(cache cache.Cache) SignatureStore {
+	return &RedisSignatureStore{cache: cache}
+}
+
+// MarkSeen records key unless it is already recorded
+func (s *RedisSignatureStore) MarkSeen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return s.cache.SetIfNotExists(ctx, key, 1, ttl)
+}
+
+// MemorySignatureStore records signatures in memory, for deployments without Redis.
+// A signature can then be replayed once against each other instance.
+type MemorySignatureStore struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewMemorySignatureStore creates a new in-memory signature store. Prune must be run
+// periodically to drop expired signatures.
+func NewMemorySignatureStore() *MemorySignatureStore {
+	return &MemorySignatureStore{seen: make(map[string]time.Time)}
+}
+
+// MarkSeen records key unless it is already recorded and not expired
+func (s *MemorySignatureStore) MarkSeen(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if expiresAt, ok := s.seen[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+
+	s.seen[key] = now.Add(ttl)
+	return true, nil
+}
+
+// Prune drops the expired signatures
+func (s *MemorySignatureStore) Prune(ctx context.Context) error {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, expiresAt := range s.seen {
+		if !now.Before(expiresAt) {
+			delete(s.seen, key)
+		}
+	}
+	return nil
+}
+
+// sign computes the hex HMAC-SHA256 of the canonical request
+func sign(secret []byte, method, uri, timestamp string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.ToUpper(method) + "\n" + uri + "\n" + timestamp + "\n" + hex.EncodeToString(bodyHash[:])))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -53,6 +53,11 @@ func (r *Redis) Close() error {
 	return nil
 }
 
+// Ping checks that Redis can be reached
+func (r *Redis) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
 // Set stores a value in Redis with expiration
 func (r *Redis) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	data, err := json.Marshal(value)
@@ -84,6 +89,22 @@ func (r *Redis) Get(ctx context.Context, key string, dest interface{}) error {
 	return nil
 }
 
+// SetIfNotExists stores a value with expiration unless the key exists, and reports
+// whether it was stored
+func (r *Redis) SetIfNotExists(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	stored, err := r.client.SetNX(ctx, key, data, expiration).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to set value in Redis: %w", err)
+	}
+
+	return stored, nil
+}
+
 // Delete removes a key from Redis
 func (r *Redis) Delete(ctx context.Context, key string) error {
 	if err := r.client.Del(ctx, key).Err(); err != nil {
@@ -135,6 +156,78 @@ func (r *Redis) IncrementWithExpiration(ctx context.Context, key string, expirat
 	return incrCmd.Val(), nil
 }
 
+// HashSet sets field of the hash at key and resets the expiration of the hash
+func (r *Redis) HashSet(ctx context.Context, key, field, value string, expiration time.Duration) error {
+	pipe := r.client.Pipeline()
+	pipe.HSet(ctx, key, field, value)
+	pipe.Expire(ctx, key, expiration)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to set hash field: %w", err)
+	}
+
+	return nil
+}
+
+// HashGetAll returns every field of the hash at key, which is empty when it does not exist
+func (r *Redis) HashGetAll(ctx context.Context, key string) (map[string]string, error) {
+	fields, err := r.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hash: %w", err)
+	}
+
+	return fields, nil
+}
+
+// Publish sends a value to the current subscribers of channel
+func (r *Redis) Publish(ctx context.Context, channel string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	if err := r.client.Publish(ctx, channel, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish to Redis: %w", err)
+	}
+
+	return nil
+}
+
+// Subscribe delivers the messages published to channel until ctx is done, when the
+// returned channel is closed
+func (r *Redis) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	pubsub := r.client.Subscribe(ctx, channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe in Redis: %w", err)
+	}
+
+	messages := make(chan []byte)
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		received := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-received:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- []byte(msg.Payload):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
 // GetClient returns the underlying Redis client
 func (r *Redis) GetClient() *redis.Client {
 	return r.client
@@ -144,9 +237,14 @@ func (r *Redis) GetClient() *redis.Client {
 type Cache interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	Get(ctx context.Context, key string, dest interface{}) error
+	SetIfNotExists(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
 	Delete(ctx context.Context, key string) error
 	Exists(ctx context.Context, key string) (bool, error)
 	SetExpiration(ctx context.Context, key string, expiration time.Duration) error
 	Increment(ctx context.Context, key string) (int64, error)
 	IncrementWithExpiration(ctx context.Context, key string, expiration time.Duration) (int64, error)
+	HashSet(ctx context.Context, key, field, value string, expiration time.Duration) error
+	HashGetAll(ctx context.Context, key string) (map[string]string, error)
+	Publish(ctx context.Context, channel string, value interface{}) error
+	Subscribe(ctx context.Context, channel string) (<-chan []byte, error)
 }
@@ -0,0 +1,800 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// CollectionIndexes lists the indexes a collection should have
+type CollectionIndexes struct {
+	Collection string
+	Indexes    []mongo.IndexModel
+}
+
+// IndexSyncResult counts what SyncIndexes changed
+type IndexSyncResult struct {
+	Created   int
+	Rebuilt   int
+	Unmanaged int // indexes not declared, dropped when pruning
+}
+
+// collectionIndexes declares the indexes of every collection. SyncIndexes creates the
+// missing ones and rebuilds those whose options changed.
+var collectionIndexes = []CollectionIndexes{
+	// Users collection indexes
+	{
+		Collection: "users",
+		Indexes: []mongo.IndexModel{
+			{
+				// Only active accounts hold their email, so a deleted or merged one
+				// does not block registering again
+				Keys:    bson.D{{Key: "email", Value: 1}},
+				Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{"is_active": true}),
+			},
+			{
+				Keys: bson.D{{Key: "created_at", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "is_email_verified", Value: 1}, {Key: "updated_at", Value: 1}},
+			},
+			{
+				Keys:    bson.D{{Key: "unmatch_requested_at", Value: 1}},
+				Options: options.Index().SetSparse(true),
+			},
+			{
+				Keys:    bson.D{{Key: "deleted_at", Value: 1}},
+				Options: options.Index().SetSparse(true),
+			},
+		},
+	},
+	// Photos collection indexes. The text index backs photo search, ranking title
+	// matches above tags, location and description.
+	{
+		Collection: "photos",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "date", Value: -1}, {Key: "deleted_at", Value: 1}},
+			},
+			{
+				// Backs moving the photos of a merged account to the surviving one
+				Keys: bson.D{{Key: "created_by", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "created_at", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "album_id", Value: 1}, {Key: "date", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "date", Value: -1}, {Key: "_id", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "favorited_by", Value: 1}, {Key: "match_code", Value: 1}, {Key: "date", Value: -1}},
+			},
+			{
+				Keys:    bson.D{{Key: "match_code", Value: 1}, {Key: "date", Value: -1}, {Key: "image_hash", Value: 1}},
+				Options: options.Index().SetPartialFilterExpression(bson.M{"image_hash": bson.M{"$exists": true}}),
+			},
+			{
+				Keys:    bson.D{{Key: "deleted_at", Value: 1}},
+				Options: options.Index().SetSparse(true),
+			},
+			{
+				Keys:    bson.D{{Key: "checksum", Value: 1}},
+				Options: options.Index().SetSparse(true),
+			},
+			// Storage key lookups of the file proxy and the storage integrity checks
+			{
+				Keys: bson.D{{Key: "image_url", Value: 1}},
+			},
+			{
+				Keys:    bson.D{{Key: "thumbnail_key", Value: 1}},
+				Options: options.Index().SetSparse(true),
+			},
+			{
+				Keys:    bson.D{{Key: "medium_key", Value: 1}},
+				Options: options.Index().SetSparse(true),
+			},
+			{
+				Keys:    bson.D{{Key: "public_key", Value: 1}},
+				Options: options.Index().SetSparse(true),
+			},
+			{
+				Keys:    bson.D{{Key: "poster_key", Value: 1}},
+				Options: options.Index().SetSparse(true),
+			},
+			{
+				Keys:    bson.D{{Key: "match_code", Value: 1}, {Key: "place.latitude", Value: 1}, {Key: "place.longitude", Value: 1}},
+				Options: options.Index().SetPartialFilterExpression(bson.M{"place": bson.M{"$exists": true}}),
+			},
+			{
+				Keys: bson.D{
+					{Key: "title", Value: "text"},
+					{Key: "tags", Value: "text"},
+					{Key: "location", Value: "text"},
+					{Key: "description", Value: "text"},
+				},
+				Options: options.Index().
+					SetDefaultLanguage("none").
+					SetWeights(bson.D{{Key: "title", Value: 10}, {Key: "tags", Value: 5}, {Key: "location", Value: 3}, {Key: "description", Value: 1}}),
+			},
+		},
+	},
+	// Events collection indexes
+	{
+		Collection: "events",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "date", Value: -1}, {Key: "deleted_at", Value: 1}},
+			},
+			{
+				// Backs moving the events of a merged account to the surviving one
+				Keys: bson.D{{Key: "created_by", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "date", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "date", Value: -1}, {Key: "_id", Value: -1}},
+			},
+			{
+				// Each generated milestone event exists once per couple
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "auto_milestone_key", Value: 1}},
+				Options: options.Index().SetUnique(true).
+					SetPartialFilterExpression(bson.M{"auto_milestone_key": bson.M{"$exists": true}}),
+			},
+			{
+				Keys: bson.D{{Key: "created_at", Value: 1}},
+			},
+			{
+				Keys:    bson.D{{Key: "deleted_at", Value: 1}},
+				Options: options.Index().SetSparse(true),
+			},
+			{
+				// Backs the search for due reminders, which only looks at those not sent yet
+				Keys: bson.D{{Key: "reminder.reminder_at", Value: 1}},
+				Options: options.Index().SetPartialFilterExpression(bson.M{
+					"reminder.enabled":     true,
+					"reminder.is_notified": false,
+				}),
+			},
+		},
+	},
+	// Match requests collection indexes
+	{
+		Collection: "match_requests",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "sender_id", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "receiver_id", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "receiver_email", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "sender_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "receiver_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "status", Value: 1}, {Key: "updated_at", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "status", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "created_at", Value: -1}},
+			},
+		},
+	},
+	// Goals collection indexes
+	{
+		Collection: "goals",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "status", Value: 1}, {Key: "target_date", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "status", Value: 1}, {Key: "target_date", Value: 1}},
+			},
+		},
+	},
+	// Albums collection indexes
+	{
+		Collection: "albums",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "position", Value: 1}},
+			},
+		},
+	},
+	// Notifications collection indexes
+	{
+		Collection: "notifications",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "is_read", Value: 1}},
+			},
+		},
+	},
+	// Photo comments collection indexes
+	{
+		Collection: "photo_comments",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "photo_id", Value: 1}, {Key: "match_code", Value: 1}, {Key: "created_at", Value: 1}},
+			},
+		},
+	},
+	// Bucket list collection indexes
+	{
+		Collection: "bucket_list_items",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "completed_at", Value: 1}, {Key: "target_date", Value: 1}},
+			},
+		},
+	},
+	// Mood check-ins collection indexes
+	{
+		Collection: "mood_check_ins",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "date", Value: -1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "date", Value: 1}},
+			},
+		},
+	},
+	// Couple activity collection indexes
+	{
+		Collection: "couple_activity",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "match_code", Value: 1}, {Key: "day", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "expires_at", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(0),
+			},
+		},
+	},
+	// Couple streaks collection indexes
+	{
+		Collection: "couple_streaks",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "match_code", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{{Key: "finalize_at", Value: 1}},
+			},
+		},
+	},
+	// Wishlist items collection indexes
+	{
+		Collection: "wishlist_items",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "owner_id", Value: 1}, {Key: "created_at", Value: -1}},
+			},
+		},
+	},
+	// Date plans collection indexes
+	{
+		Collection: "date_plans",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "status", Value: 1}, {Key: "scheduled_date", Value: 1}},
+			},
+		},
+	},
+	// Daily questions collection indexes
+	{
+		Collection: "daily_questions",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "match_code", Value: 1}, {Key: "day", Value: -1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+	},
+	// Countdowns collection indexes
+	{
+		Collection: "countdowns",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "target_date", Value: 1}},
+			},
+		},
+	},
+	// Journal entries collection indexes
+	{
+		Collection: "journal_entries",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "date", Value: -1}, {Key: "_id", Value: -1}},
+			},
+			{
+				Keys:    bson.D{{Key: "deleted_at", Value: 1}},
+				Options: options.Index().SetSparse(true),
+			},
+		},
+	},
+	// Affirmations collection indexes
+	{
+		Collection: "affirmations",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "status", Value: 1}, {Key: "scheduled_for", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "recipient_id", Value: 1}, {Key: "status", Value: 1}, {Key: "delivered_at", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "created_by", Value: 1}, {Key: "created_at", Value: -1}},
+			},
+		},
+	},
+	// Couple settings collection indexes
+	{
+		Collection: "couple_settings",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "match_code", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+	},
+	// Messages collection indexes. The text index backs message search; it is built
+	// without a language so that no words are stemmed or dropped as stop words.
+	{
+		Collection: "messages",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "sender_id", Value: 1}, {Key: "receiver_id", Value: 1}, {Key: "created_at", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "receiver_id", Value: 1}, {Key: "is_read", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "created_at", Value: 1}},
+			},
+			{
+				Keys:    bson.D{{Key: "content", Value: "text"}},
+				Options: options.Index().SetDefaultLanguage("none"),
+			},
+		},
+	},
+	// Pending actions collection indexes
+	{
+		Collection: "pending_actions",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: -1}},
+			},
+		},
+	},
+	// Match invites collection indexes. Invites are looked up by code hash, replaced
+	// per sender and removed by the TTL index once expired.
+	{
+		Collection: "match_invites",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "code_hash", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{{Key: "sender_id", Value: 1}},
+			},
+			{
+				Keys:    bson.D{{Key: "expires_at", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(0),
+			},
+		},
+	},
+	// Account merges collection indexes. Pending merges are looked up per surviving
+	// user and removed by the TTL index once expired; completed ones are kept for audit.
+	{
+		Collection: "account_merges",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "surviving_user_id", Value: 1}, {Key: "status", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "merged_user_id", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "expires_at", Value: 1}},
+				Options: options.Index().
+					SetExpireAfterSeconds(0).
+					SetPartialFilterExpression(bson.M{"status": "pending"}),
+			},
+		},
+	},
+	// OAuth authorization codes collection indexes. Codes are looked up by hash and
+	// removed by the TTL index once expired.
+	{
+		Collection: "oauth_authorization_codes",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "code_hash", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "expires_at", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(0),
+			},
+		},
+	},
+	// Share links collection indexes
+	{
+		Collection: "share_links",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "token", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{{Key: "match_code", Value: 1}, {Key: "created_at", Value: -1}},
+			},
+		},
+	},
+	// Feedback collection indexes
+	{
+		Collection: "feedback",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			},
+		},
+	},
+	// Calendar feeds collection indexes
+	{
+		Collection: "calendar_feeds",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "user_id", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "token", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+	},
+	// Changelog seen collection indexes
+	{
+		Collection: "changelog_seen",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "user_id", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+	},
+	// Retention audit collection indexes
+	{
+		Collection: "retention_audit",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "policy", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "run_id", Value: 1}},
+			},
+		},
+	},
+	// Couple badges collection indexes
+	{
+		Collection: "couple_badges",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "match_code", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "slug", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+	},
+	// Storage integrity issues collection indexes
+	{
+		Collection: "storage_integrity_issues",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "photo_id", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+			{
+				Keys: bson.D{{Key: "detected_at", Value: -1}, {Key: "_id", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "status", Value: 1}, {Key: "detected_at", Value: -1}, {Key: "_id", Value: -1}},
+			},
+		},
+	},
+	// Storage reconciliations collection indexes
+	{
+		Collection: "storage_reconciliations",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			},
+		},
+	},
+	// Audit logs collection indexes. Entries are kept for a year.
+	{
+		Collection: "audit_logs",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			},
+			{
+				Keys:    bson.D{{Key: "created_at", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(365 * 24 * 60 * 60),
+			},
+		},
+	},
+	// Request traces collection indexes. Failed requests are kept for two weeks,
+	// long enough to correlate the client errors reported about them.
+	{
+		Collection: "request_traces",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "trace_id", Value: 1}},
+			},
+			{
+				Keys:    bson.D{{Key: "created_at", Value: 1}},
+				Options: options.Index().SetExpireAfterSeconds(14 * 24 * 60 * 60),
+			},
+		},
+	},
+	// Client errors collection indexes
+	{
+		Collection: "client_errors",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "trace_id", Value: 1}},
+			},
+			{
+				Keys: bson.D{{Key: "platform", Value: 1}, {Key: "app_version", Value: 1}, {Key: "created_at", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}},
+			},
+		},
+	},
+	// Couple usage collection indexes
+	{
+		Collection: "couple_usage",
+		Indexes: []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "match_code", Value: 1}, {Key: "day", Value: 1}},
+				Options: options.Index().SetUnique(true),
+			},
+		},
+	},
+}
+
+// existingIndex is an index as listed by MongoDB
+type existingIndex struct {
+	Name                    string `bson:"name"`
+	Unique                  bool   `bson:"unique"`
+	Sparse                  bool   `bson:"sparse"`
+	ExpireAfterSeconds      *int64 `bson:"expireAfterSeconds"`
+	DefaultLanguage         string `bson:"default_language"`
+	PartialFilterExpression bson.M `bson:"partialFilterExpression"`
+}
+
+// SyncIndexes makes the indexes of every collection match collectionIndexes. Missing
+// indexes are created and indexes whose options changed are dropped and created again.
+// Indexes that are not declared are reported, and dropped when prune is set.
+func (m *MongoDB) SyncIndexes(ctx context.Context, prune bool) (*IndexSyncResult, error) {
+	result := &IndexSyncResult{}
+
+	for _, spec := range collectionIndexes {
+		if err := m.syncCollectionIndexes(ctx, spec, prune, result); err != nil {
+			return result, fmt.Errorf("failed to sync %s indexes: %w", spec.Collection, err)
+		}
+	}
+
+	m.logger.Info("Database indexes synced",
+		zap.Int("created", result.Created),
+		zap.Int("rebuilt", result.Rebuilt),
+		zap.Int("unmanaged", result.Unmanaged),
+		zap.Bool("prune", prune))
+	return result, nil
+}
+
+// syncCollectionIndexes syncs the indexes of one collection
+func (m *MongoDB) syncCollectionIndexes(ctx context.Context, spec CollectionIndexes, prune bool, result *IndexSyncResult) error {
+	indexes := m.Collection(spec.Collection).Indexes()
+
+	existing, err := listIndexes(ctx, indexes)
+	if err != nil {
+		return err
+	}
+
+	declared := make(map[string]bool, len(spec.Indexes))
+	var missing []mongo.IndexModel
+	for _, model := range spec.Indexes {
+		name := indexName(model)
+		declared[name] = true
+
+		current, ok := existing[name]
+		if !ok {
+			missing = append(missing, withName(model, name))
+			result.Created++
+			continue
+		}
+		if indexMatches(model, current) {
+			continue
+		}
+
+		m.logger.Info("Rebuilding index with changed options",
+			zap.String("collection", spec.Collection),
+			zap.String("index", name))
+		if _, err := indexes.DropOne(ctx, name); err != nil {
+			return fmt.Errorf("failed to drop index %s: %w", name, err)
+		}
+		missing = append(missing, withName(model, name))
+		result.Rebuilt++
+	}
+
+	if len(missing) > 0 {
+		if _, err := indexes.CreateMany(ctx, missing); err != nil {
+			return fmt.Errorf("failed to create indexes: %w", err)
+		}
+	}
+
+	for name := range existing {
+		if name == "_id_" || declared[name] {
+			continue
+		}
+
+		result.Unmanaged++
+		if !prune {
+			m.logger.Warn("Index is not declared",
+				zap.String("collection", spec.Collection),
+				zap.String("index", name))
+			continue
+		}
+
+		m.logger.Info("Dropping undeclared index",
+			zap.String("collection", spec.Collection),
+			zap.String("index", name))
+		if _, err := indexes.DropOne(ctx, name); err != nil {
+			return fmt.Errorf("failed to drop index %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// listIndexes returns the indexes of a collection by name. A collection that does not
+// exist yet has none.
+func listIndexes(ctx context.Context, indexes mongo.IndexView) (map[string]*existingIndex, error) {
+	cursor, err := indexes.List(ctx)
+	if err != nil {
+		if cmdErr, ok := err.(mongo.CommandError); ok && cmdErr.Name == "NamespaceNotFound" {
+			return map[string]*existingIndex{}, nil
+		}
+		return nil, fmt.Errorf("failed to list indexes: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	existing := make(map[string]*existingIndex)
+	for cursor.Next(ctx) {
+		var index existingIndex
+		if err := cursor.Decode(&index); err != nil {
+			return nil, fmt.Errorf("failed to decode index: %w", err)
+		}
+		existing[index.Name] = &index
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list indexes: %w", err)
+	}
+
+	return existing, nil
+}
+
+// indexName returns the name of a declared index: its own, or the one MongoDB derives
+// from its keys, such as "match_code_1_created_at_-1"
+func indexName(model mongo.IndexModel) string {
+	if model.Options != nil && model.Options.Name != nil {
+		return *model.Options.Name
+	}
+
+	keys := model.Keys.(bson.D)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s_%v", key.Key, key.Value))
+	}
+	return strings.Join(parts, "_")
+}
+
+// withName returns model with its name set, so that it is created under the name it
+// is looked up by
+func withName(model mongo.IndexModel, name string) mongo.IndexModel {
+	opts := options.Index()
+	if model.Options != nil {
+		copied := *model.Options
+		opts = &copied
+	}
+	opts.SetName(name)
+	return mongo.IndexModel{Keys: model.Keys, Options: opts}
+}
+
+// indexMatches reports whether an existing index has the options declared for it
+func indexMatches(model mongo.IndexModel, current *existingIndex) bool {
+	opts := model.Options
+	if opts == nil {
+		opts = options.Index()
+	}
+
+	if derefBool(opts.Unique) != current.Unique || derefBool(opts.Sparse) != current.Sparse {
+		return false
+	}
+
+	if (opts.ExpireAfterSeconds == nil) != (current.ExpireAfterSeconds == nil) {
+		return false
+	}
+	if opts.ExpireAfterSeconds != nil && int64(*opts.ExpireAfterSeconds) != *current.ExpireAfterSeconds {
+		return false
+	}
+
+	if opts.DefaultLanguage != nil && *opts.DefaultLanguage != current.DefaultLanguage {
+		return false
+	}
+
+	var partial bson.M
+	if opts.PartialFilterExpression != nil {
+		data, err := bson.Marshal(opts.PartialFilterExpression)
+		if err != nil || bson.Unmarshal(data, &partial) != nil {
+			return false
+		}
+	}
+	if len(partial) != len(current.PartialFilterExpression) {
+		return false
+	}
+	return len(partial) == 0 || reflect.DeepEqual(partial, current.PartialFilterExpression)
+}
+
+// derefBool returns the value of an optional flag, false when unset
+func derefBool(b *bool) bool {
+	return b != nil && *b
+}
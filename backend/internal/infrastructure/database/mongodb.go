@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"time"
 
-	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
@@ -61,89 +60,13 @@ func (m *MongoDB) Close(ctx context.Context) error {
 	return nil
 }
 
+// Ping checks that the primary can be reached
+func (m *MongoDB) Ping(ctx context.Context) error {
+	return m.Client.Ping(ctx, nil)
+}
+
 // Collection returns a collection from the database
 func (m *MongoDB) Collection(name string) *mongo.Collection {
 	return m.Database.Collection(name)
 }
 
-// CreateIndexes creates necessary indexes for the collections
-func (m *MongoDB) CreateIndexes(ctx context.Context) error {
-	// Users collection indexes
-	usersCollection := m.Collection("users")
-	userIndexes := []mongo.IndexModel{
-		{
-			Keys:    bson.D{{"email", 1}},
-			Options: options.Index().SetUnique(true),
-		},
-		{
-			Keys: bson.D{{"created_at", 1}},
-		},
-	}
-
-	if _, err := usersCollection.Indexes().CreateMany(ctx, userIndexes); err != nil {
-		return fmt.Errorf("failed to create user indexes: %w", err)
-	}
-
-	// Photos collection indexes
-	photosCollection := m.Collection("photos")
-	photoIndexes := []mongo.IndexModel{
-		{
-			Keys: bson.D{{"user_id", 1}, {"date", -1}},
-		},
-		{
-			Keys: bson.D{{"user_id", 1}, {"partner_id", 1}, {"date", -1}},
-		},
-		{
-			Keys: bson.D{{"created_at", -1}},
-		},
-	}
-
-	if _, err := photosCollection.Indexes().CreateMany(ctx, photoIndexes); err != nil {
-		return fmt.Errorf("failed to create photo indexes: %w", err)
-	}
-
-	// Events collection indexes
-	eventsCollection := m.Collection("events")
-	eventIndexes := []mongo.IndexModel{
-		{
-			Keys: bson.D{{"user_id", 1}, {"date", 1}},
-		},
-		{
-			Keys: bson.D{{"user_id", 1}, {"partner_id", 1}, {"date", 1}},
-		},
-		{
-			Keys: bson.D{{"date", 1}},
-		},
-	}
-
-	if _, err := eventsCollection.Indexes().CreateMany(ctx, eventIndexes); err != nil {
-		return fmt.Errorf("failed to create event indexes: %w", err)
-	}
-
-	// Match requests collection indexes
-	matchRequestsCollection := m.Collection("match_requests")
-	matchRequestIndexes := []mongo.IndexModel{
-		{
-			Keys: bson.D{{"sender_id", 1}},
-		},
-		{
-			Keys: bson.D{{"receiver_id", 1}},
-		},
-		{
-			Keys: bson.D{{"receiver_email", 1}},
-		},
-		{
-			Keys: bson.D{{"status", 1}},
-		},
-		{
-			Keys: bson.D{{"created_at", -1}},
-		},
-	}
-
-	if _, err := matchRequestsCollection.Indexes().CreateMany(ctx, matchRequestIndexes); err != nil {
-		return fmt.Errorf("failed to create match request indexes: %w", err)
-	}
-
-	m.logger.Info("Database indexes created successfully")
-	return nil
-}
@@ -0,0 +1,292 @@
+package directus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+// maxReleases caps the number of releases read from the changelog collection
+const maxReleases = 100
+
+// maxQuestions caps the number of questions of a locale read from the question collection
+const maxQuestions = 1000
+
+// maxDateIdeas caps the number of ideas read from the date idea collection
+const maxDateIdeas = 1000
+
+// Client reads and writes items of a Directus instance through its REST API
+type Client struct {
+	baseURL             string
+	token               string
+	feedbackCollection  string
+	changelogCollection string
+	contentCollection   string
+	questionCollection  string
+	dateIdeaCollection  string
+	httpClient          *http.Client
+	logger              *zap.Logger
+}
+
+// NewClient creates a new Directus client. The token is a static token of a
+// Directus user allowed to create items in the feedback collection and to read
+// the changelog, content, question and date idea collections.
+func NewClient(baseURL, token, feedbackCollection, changelogCollection, contentCollection, questionCollection, dateIdeaCollection string, logger *zap.Logger) *Client {
+	return &Client{
+		baseURL:             strings.TrimRight(baseURL, "/"),
+		token:               token,
+		feedbackCollection:  feedbackCollection,
+		changelogCollection: changelogCollection,
+		contentCollection:   contentCollection,
+		questionCollection:  questionCollection,
+		dateIdeaCollection:  dateIdeaCollection,
+		httpClient:          &http.Client{Timeout: 10 * time.Second},
+		logger:              logger,
+	}
+}
+
+// feedbackItem is the shape of a feedback item in the Directus collection
+type feedbackItem struct {
+	FeedbackID  string    `json:"feedback_id"`
+	UserID      string    `json:"user_id"`
+	Category    string    `json:"category"`
+	Rating      *int      `json:"rating"`
+	Text        string    `json:"text"`
+	AppVersion  string    `json:"app_version"`
+	Attachments []string  `json:"attachments"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// MirrorFeedback creates an item for the feedback in the feedback collection and
+// returns the ID Directus gave it
+func (c *Client) MirrorFeedback(ctx context.Context, feedback *domain.Feedback) (string, error) {
+	return c.createItem(ctx, c.feedbackCollection, feedbackItem{
+		FeedbackID:  feedback.ID.Hex(),
+		UserID:      feedback.UserID.Hex(),
+		Category:    string(feedback.Category),
+		Rating:      feedback.Rating,
+		Text:        feedback.Text,
+		AppVersion:  feedback.AppVersion,
+		Attachments: feedback.Attachments,
+		Status:      string(feedback.Status),
+		CreatedAt:   feedback.CreatedAt,
+	})
+}
+
+// releaseItem is the shape of a release in the changelog collection
+type releaseItem struct {
+	Version     string    `json:"version"`
+	Title       string    `json:"title"`
+	Notes       string    `json:"notes"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// ListReleases reads the published releases of the changelog collection, newest first
+func (c *Client) ListReleases(ctx context.Context) ([]*domain.Release, error) {
+	query := url.Values{}
+	query.Set("fields", "version,title,notes,published_at")
+	query.Set("filter[status][_eq]", "published")
+	query.Set("sort", "-published_at")
+	query.Set("limit", fmt.Sprint(maxReleases))
+
+	var items []releaseItem
+	if err := c.getItems(ctx, c.changelogCollection, query, &items); err != nil {
+		return nil, err
+	}
+
+	releases := make([]*domain.Release, 0, len(items))
+	for _, item := range items {
+		releases = append(releases, &domain.Release{
+			Version:     item.Version,
+			Title:       item.Title,
+			Notes:       item.Notes,
+			PublishedAt: item.PublishedAt,
+		})
+	}
+	return releases, nil
+}
+
+// contentItem is the shape of a content block in the content collection
+type contentItem struct {
+	Key  string `json:"key"`
+	Body string `json:"body"`
+}
+
+// GetContent reads the published content blocks of a locale, keyed by content key
+func (c *Client) GetContent(ctx context.Context, keys []string, locale string) (map[string]string, error) {
+	query := url.Values{}
+	query.Set("fields", "key,body")
+	query.Set("filter[key][_in]", strings.Join(keys, ","))
+	query.Set("filter[locale][_eq]", locale)
+	query.Set("filter[status][_eq]", "published")
+	query.Set("limit", "-1")
+
+	var items []contentItem
+	if err := c.getItems(ctx, c.contentCollection, query, &items); err != nil {
+		return nil, err
+	}
+
+	contents := make(map[string]string, len(items))
+	for _, item := range items {
+		contents[item.Key] = item.Body
+	}
+	return contents, nil
+}
+
+// questionItem is the shape of a daily question in the question collection
+type questionItem struct {
+	Key      string `json:"key"`
+	Text     string `json:"text"`
+	Category string `json:"category"`
+}
+
+// ListQuestions reads the published daily questions of a locale
+func (c *Client) ListQuestions(ctx context.Context, locale string) ([]*domain.QuestionPrompt, error) {
+	query := url.Values{}
+	query.Set("fields", "key,text,category")
+	query.Set("filter[locale][_eq]", locale)
+	query.Set("filter[status][_eq]", "published")
+	query.Set("sort", "key")
+	query.Set("limit", fmt.Sprint(maxQuestions))
+
+	var items []questionItem
+	if err := c.getItems(ctx, c.questionCollection, query, &items); err != nil {
+		return nil, err
+	}
+
+	questions := make([]*domain.QuestionPrompt, 0, len(items))
+	for _, item := range items {
+		if item.Key == "" || item.Text == "" {
+			continue
+		}
+		questions = append(questions, &domain.QuestionPrompt{
+			Key:      item.Key,
+			Text:     item.Text,
+			Category: item.Category,
+		})
+	}
+	return questions, nil
+}
+
+// dateIdeaItem is the shape of a date idea in the date idea collection
+type dateIdeaItem struct {
+	Key         string   `json:"key"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Location    string   `json:"location"`
+	Category    string   `json:"category"`
+	Cost        *float64 `json:"cost"`
+	Currency    string   `json:"currency"`
+}
+
+// ListDateIdeas reads the published date ideas
+func (c *Client) ListDateIdeas(ctx context.Context) ([]*domain.DateIdea, error) {
+	query := url.Values{}
+	query.Set("fields", "key,title,description,location,category,cost,currency")
+	query.Set("filter[status][_eq]", "published")
+	query.Set("sort", "key")
+	query.Set("limit", fmt.Sprint(maxDateIdeas))
+
+	var items []dateIdeaItem
+	if err := c.getItems(ctx, c.dateIdeaCollection, query, &items); err != nil {
+		return nil, err
+	}
+
+	ideas := make([]*domain.DateIdea, 0, len(items))
+	for _, item := range items {
+		if item.Key == "" || item.Title == "" {
+			continue
+		}
+		ideas = append(ideas, &domain.DateIdea{
+			Key:         item.Key,
+			Title:       item.Title,
+			Description: item.Description,
+			Location:    item.Location,
+			Category:    item.Category,
+			Cost:        item.Cost,
+			Currency:    strings.ToUpper(item.Currency),
+		})
+	}
+	return ideas, nil
+}
+
+// createItem creates an item in collection and returns its ID
+func (c *Client) createItem(ctx context.Context, collection string, item interface{}) (string, error) {
+	body, err := json.Marshal(item)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode directus item: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/items/%s", c.baseURL, collection)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create directus request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("directus request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		c.logger.Warn("Directus rejected item",
+			zap.String("collection", collection),
+			zap.Int("status", resp.StatusCode))
+		return "", fmt.Errorf("directus returned status %d", resp.StatusCode)
+	}
+
+	var created struct {
+		Data struct {
+			ID interface{} `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", fmt.Errorf("failed to decode directus response: %w", err)
+	}
+
+	return fmt.Sprint(created.Data.ID), nil
+}
+
+// getItems reads the items of collection matching query into out
+func (c *Client) getItems(ctx context.Context, collection string, query url.Values, out interface{}) error {
+	endpoint := fmt.Sprintf("%s/items/%s?%s", c.baseURL, collection, query.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create directus request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("directus request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		c.logger.Warn("Directus refused to list items",
+			zap.String("collection", collection),
+			zap.Int("status", resp.StatusCode))
+		return fmt.Errorf("directus returned status %d", resp.StatusCode)
+	}
+
+	listed := struct {
+		Data interface{} `json:"data"`
+	}{Data: out}
+	if err := json.NewDecoder(resp.Body).Decode(&listed); err != nil {
+		return fmt.Errorf("failed to decode directus response: %w", err)
+	}
+
+	return nil
+}
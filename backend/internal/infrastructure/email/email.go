@@ -2,24 +2,35 @@ package email
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"html/template"
-	"net/smtp"
+	"time"
 
 	"github.com/eralove/eralove-backend/internal/config"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/eralove/eralove-backend/internal/infrastructure/pii"
 	"go.uber.org/zap"
 )
 
-// EmailService handles email sending
+// sendTimeout bounds how long sending a single email may take
+const sendTimeout = 30 * time.Second
+
+// EmailService renders emails and sends them through an EmailSender, normally a Queue
 type EmailService struct {
 	config *config.Config
+	sender EmailSender
+	i18n   *i18n.I18n
 	logger *zap.Logger
 }
 
-// NewEmailService creates a new email service
-func NewEmailService(config *config.Config, logger *zap.Logger) *EmailService {
+// NewEmailService creates a new email service. Emails that take a locale are
+// translated with the i18n messages.
+func NewEmailService(config *config.Config, sender EmailSender, i18n *i18n.I18n, logger *zap.Logger) *EmailService {
 	return &EmailService{
 		config: config,
+		sender: sender,
+		i18n:   i18n,
 		logger: logger,
 	}
 }
@@ -33,6 +44,21 @@ type EmailData struct {
 	ResetURL     string
 	FrontendURL  string
 	SupportEmail string
+	GoalTitle    string
+	TargetDate   string
+	Progress     int
+	GoalsURL     string
+
+	// Localized notification emails
+	Lang       string
+	Heading    string
+	Greeting   string
+	Body       string
+	QuoteLabel string
+	Quote      string
+	ActionText string
+	ActionURL  string
+	HelpText   string
 }
 
 // SendVerificationEmail sends email verification email
@@ -79,35 +105,143 @@ func (s *EmailService) SendPasswordResetEmail(name, email, token string) error {
 	return s.sendEmail(email, subject, body)
 }
 
-// sendEmail sends an email using SMTP
-func (s *EmailService) sendEmail(to, subject, body string) error {
-	// Skip sending email if SMTP is not configured
-	if s.config.SMTPUsername == "" || s.config.SMTPPassword == "" {
-		s.logger.Warn("SMTP not configured, skipping email send", 
-			zap.String("to", to), 
-			zap.String("subject", subject))
-		return nil
+// SendGoalReminderEmail sends a reminder about a couple goal approaching its target date
+func (s *EmailService) SendGoalReminderEmail(name, email, goalTitle string, targetDate time.Time, progress int) error {
+	subject := "Goal Reminder - EraLove"
+
+	data := EmailData{
+		Name:         name,
+		Email:        email,
+		GoalTitle:    goalTitle,
+		TargetDate:   targetDate.Format("January 2, 2006"),
+		Progress:     progress,
+		GoalsURL:     fmt.Sprintf("%s/goals", s.config.FrontendURL),
+		FrontendURL:  s.config.FrontendURL,
+		SupportEmail: s.config.FromEmail,
 	}
 
-	auth := smtp.PlainAuth("", s.config.SMTPUsername, s.config.SMTPPassword, s.config.SMTPHost)
+	body, err := s.renderTemplate(goalReminderEmailTemplate, data)
+	if err != nil {
+		s.logger.Error("Failed to render goal reminder email template", zap.Error(err))
+		return fmt.Errorf("failed to render email template: %w", err)
+	}
 
-	msg := []byte(fmt.Sprintf("To: %s\r\nSubject: %s\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n%s", to, subject, body))
+	return s.sendEmail(email, subject, body)
+}
 
-	addr := fmt.Sprintf("%s:%d", s.config.SMTPHost, s.config.SMTPPort)
-	
-	err := smtp.SendMail(addr, auth, s.config.FromEmail, []string{to}, msg)
+// SendMatchRequestReceivedEmail tells a user that someone sent them a match request
+func (s *EmailService) SendMatchRequestReceivedEmail(locale, name, email, senderName, message string) error {
+	params := map[string]interface{}{"Name": name, "PartnerName": senderName}
+
+	data := s.notificationData(locale, "email_match_request_received", name, email, params)
+	data.ActionURL = fmt.Sprintf("%s/match-requests", s.config.FrontendURL)
+	if message != "" {
+		data.QuoteLabel = s.i18n.Translate(locale, "email_match_request_received_message", params)
+		data.Quote = message
+	}
+
+	return s.sendNotification(locale, "email_match_request_received", params, data)
+}
+
+// SendMatchAcceptedEmail tells a user that their partner accepted their match request
+func (s *EmailService) SendMatchAcceptedEmail(locale, name, email, partnerName string) error {
+	params := map[string]interface{}{"Name": name, "PartnerName": partnerName}
+
+	data := s.notificationData(locale, "email_match_accepted", name, email, params)
+	data.ActionURL = s.config.FrontendURL
+
+	return s.sendNotification(locale, "email_match_accepted", params, data)
+}
+
+// SendMatchDeclinedEmail tells a user that their match request was declined
+func (s *EmailService) SendMatchDeclinedEmail(locale, name, email, receiverName string) error {
+	params := map[string]interface{}{"Name": name, "PartnerName": receiverName}
+
+	data := s.notificationData(locale, "email_match_declined", name, email, params)
+	data.ActionURL = fmt.Sprintf("%s/match-requests", s.config.FrontendURL)
+
+	return s.sendNotification(locale, "email_match_declined", params, data)
+}
+
+// SendAccountMergeCodeEmail sends the code confirming that an account may be merged
+// into the account of survivingName
+func (s *EmailService) SendAccountMergeCodeEmail(locale, name, email, survivingName, survivingEmail, code string, validFor time.Duration) error {
+	params := map[string]interface{}{
+		"Name":           name,
+		"SurvivingName":  survivingName,
+		"SurvivingEmail": survivingEmail,
+		"Minutes":        int(validFor.Minutes()),
+	}
+
+	data := s.notificationData(locale, "email_account_merge", name, email, params)
+	data.ActionURL = s.config.FrontendURL
+	data.QuoteLabel = s.i18n.TranslatePlural(locale, "email_account_merge_code", int(validFor.Minutes()), params)
+	data.Quote = code
+
+	return s.sendNotification(locale, "email_account_merge", params, data)
+}
+
+// SendMemoriesDigestEmail tells a user that the couple has memories from date, a
+// YYYY-MM-DD day, in earlier years
+func (s *EmailService) SendMemoriesDigestEmail(locale, name, email, date string) error {
+	params := map[string]interface{}{"Name": name}
+
+	data := s.notificationData(locale, "email_memories_digest", name, email, params)
+	data.ActionURL = fmt.Sprintf("%s/memories?date=%s", s.config.FrontendURL, date)
+
+	return s.sendNotification(locale, "email_memories_digest", params, data)
+}
+
+// notificationData translates the texts of a notification email. Its messages are
+// keyed by prefix with _heading, _body and _action suffixes.
+func (s *EmailService) notificationData(locale, prefix, name, email string, params map[string]interface{}) EmailData {
+	return EmailData{
+		Name:         name,
+		Email:        email,
+		FrontendURL:  s.config.FrontendURL,
+		SupportEmail: s.config.FromEmail,
+		Lang:         s.i18n.ParseAcceptLanguage(locale),
+		Heading:      s.i18n.Translate(locale, prefix+"_heading", params),
+		Greeting:     s.i18n.Translate(locale, "email_greeting", params),
+		Body:         s.i18n.Translate(locale, prefix+"_body", params),
+		ActionText:   s.i18n.Translate(locale, prefix+"_action", params),
+		HelpText:     s.i18n.Translate(locale, "email_footer_help", nil),
+	}
+}
+
+// sendNotification renders a notification email and sends it with the translated
+// subject of prefix
+func (s *EmailService) sendNotification(locale, prefix string, params map[string]interface{}, data EmailData) error {
+	subject := s.i18n.Translate(locale, prefix+"_subject", params)
+
+	body, err := s.renderTemplate(notificationEmailTemplate, data)
+	if err != nil {
+		s.logger.Error("Failed to render notification email template", zap.Error(err), zap.String("email", prefix))
+		return fmt.Errorf("failed to render email template: %w", err)
+	}
+
+	return s.sendEmail(data.Email, subject, body)
+}
+
+// sendEmail hands an email to the sender. With the queue as sender this returns as
+// soon as the email is queued; delivery failures are logged by the queue.
+func (s *EmailService) sendEmail(to, subject, body string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+
+	err := s.sender.Send(ctx, &Message{
+		To:      to,
+		Subject: subject,
+		HTML:    body,
+	})
 	if err != nil {
-		s.logger.Error("Failed to send email", 
+		s.logger.Error("Failed to send email",
 			zap.Error(err),
-			zap.String("to", to),
+			pii.Field("to", to),
 			zap.String("subject", subject))
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
-	s.logger.Info("Email sent successfully", 
-		zap.String("to", to),
-		zap.String("subject", subject))
-	
 	return nil
 }
 
@@ -215,3 +349,87 @@ const passwordResetEmailTemplate = `
 </body>
 </html>
 `
+
+const goalReminderEmailTemplate = `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Goal Reminder</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #ff6b9d; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #ff6b9d; color: white; text-decoration: none; border-radius: 5px; margin: 20px 0; }
+        .progress { background-color: #eee; border-radius: 5px; height: 16px; overflow: hidden; }
+        .progress-bar { background-color: #ff6b9d; height: 16px; }
+        .footer { padding: 20px; text-align: center; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>Your Goal Is Coming Up! 🎯</h1>
+        </div>
+        <div class="content">
+            <h2>Hi {{.Name}},</h2>
+            <p>Your shared goal <strong>{{.GoalTitle}}</strong> is due on <strong>{{.TargetDate}}</strong>.</p>
+            <p>You're currently at {{.Progress}}% - check in together and keep the momentum going!</p>
+            <div class="progress">
+                <div class="progress-bar" style="width: {{.Progress}}%;"></div>
+            </div>
+            <p style="text-align: center;">
+                <a href="{{.GoalsURL}}" class="button">Check In Now</a>
+            </p>
+        </div>
+        <div class="footer">
+            <p>Need help? Contact us at <a href="mailto:{{.SupportEmail}}">{{.SupportEmail}}</a></p>
+            <p>&copy; 2024 EraLove. All rights reserved.</p>
+        </div>
+    </div>
+</body>
+</html>
+`
+
+// notificationEmailTemplate lays out the localized notification emails
+const notificationEmailTemplate = `
+<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+    <meta charset="UTF-8">
+    <title>{{.Heading}}</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #ff6b9d; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #ff6b9d; color: white; text-decoration: none; border-radius: 5px; margin: 20px 0; }
+        .quote { border-left: 4px solid #ff6b9d; padding: 10px 15px; background-color: white; font-style: italic; }
+        .footer { padding: 20px; text-align: center; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.Heading}}</h1>
+        </div>
+        <div class="content">
+            <h2>{{.Greeting}}</h2>
+            <p>{{.Body}}</p>
+            {{if .Quote}}
+            <p>{{.QuoteLabel}}</p>
+            <p class="quote">{{.Quote}}</p>
+            {{end}}
+            <p style="text-align: center;">
+                <a href="{{.ActionURL}}" class="button">{{.ActionText}}</a>
+            </p>
+        </div>
+        <div class="footer">
+            <p>{{.HelpText}} <a href="mailto:{{.SupportEmail}}">{{.SupportEmail}}</a></p>
+            <p>&copy; 2024 EraLove. All rights reserved.</p>
+        </div>
+    </div>
+</body>
+</html>
+`
@@ -0,0 +1,208 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/infrastructure/pii"
+	"go.uber.org/zap"
+)
+
+// maxRetryDelay caps the wait between two attempts to send a message
+const maxRetryDelay = 5 * time.Minute
+
+var (
+	// ErrQueueFull is returned when a message is sent while the queue is full
+	ErrQueueFull = errors.New("email queue is full")
+	// ErrQueueClosed is returned when a message is sent after the queue was shut down
+	ErrQueueClosed = errors.New("email queue is closed")
+)
+
+// QueueConfig configures an email queue
+type QueueConfig struct {
+	Workers     int           // messages sent at the same time
+	Size        int           // messages that can wait to be sent
+	MaxAttempts int           // attempts before a message is given up on
+	RetryDelay  time.Duration // wait before the first retry, doubled for each further one
+}
+
+// queuedMessage is a message waiting in the queue
+type queuedMessage struct {
+	msg      *Message
+	queuedAt time.Time
+}
+
+// Queue sends messages in the background through another EmailSender, retrying
+// temporary failures with exponential backoff. Messages that cannot be delivered are
+// logged as dead letters. It is itself an EmailSender whose Send only queues.
+type Queue struct {
+	sender EmailSender
+	config QueueConfig
+	logger *zap.Logger
+
+	jobs   chan *queuedMessage
+	mu     sync.RWMutex
+	closed bool
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewQueue creates a queue and starts its workers
+func NewQueue(sender EmailSender, config QueueConfig, logger *zap.Logger) *Queue {
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &Queue{
+		sender: sender,
+		config: config,
+		logger: logger,
+		jobs:   make(chan *queuedMessage, config.Size),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	for i := 0; i < config.Workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+
+	logger.Info("Email queue started",
+		zap.Int("workers", config.Workers),
+		zap.Int("size", config.Size))
+
+	return q
+}
+
+// Send queues the message without waiting for it to be sent
+func (q *Queue) Send(ctx context.Context, msg *Message) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	select {
+	case q.jobs <- &queuedMessage{msg: msg, queuedAt: time.Now()}:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Shutdown stops accepting messages and waits for the queued ones to be sent. When
+// ctx is done first, the messages still waiting are given up on as dead letters.
+func (q *Queue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return nil
+	}
+	q.closed = true
+	close(q.jobs)
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancel()
+		q.logger.Info("Email queue drained")
+		return nil
+	case <-ctx.Done():
+		// Workers stop retrying and dead-letter what is left
+		q.cancel()
+		<-done
+		q.logger.Warn("Email queue shut down before it was drained")
+		return ctx.Err()
+	}
+}
+
+// work sends queued messages until the queue is closed and empty
+func (q *Queue) work() {
+	defer q.wg.Done()
+
+	for job := range q.jobs {
+		q.deliver(job)
+	}
+}
+
+// deliver sends a message, retrying temporary failures until MaxAttempts is reached
+func (q *Queue) deliver(job *queuedMessage) {
+	for attempt := 1; ; attempt++ {
+		if q.ctx.Err() != nil {
+			q.deadLetter(job, attempt-1, errors.New("queue shut down"))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(q.ctx, sendTimeout)
+		err := q.sender.Send(ctx, job.msg)
+		cancel()
+
+		if err == nil {
+			q.logger.Info("Email sent successfully",
+				pii.Field("to", job.msg.To),
+				zap.String("subject", job.msg.Subject),
+				zap.Int("attempt", attempt),
+				zap.Duration("queued_for", time.Since(job.queuedAt)))
+			return
+		}
+
+		var sendErr *SendError
+		temporary := !errors.As(err, &sendErr) || sendErr.Temporary
+		if !temporary || attempt >= q.config.MaxAttempts {
+			q.deadLetter(job, attempt, err)
+			return
+		}
+
+		delay := q.retryDelay(attempt)
+		q.logger.Warn("Failed to send email, retrying",
+			zap.Error(err),
+			pii.Field("to", job.msg.To),
+			zap.Int("attempt", attempt),
+			zap.Duration("retry_in", delay))
+
+		select {
+		case <-time.After(delay):
+		case <-q.ctx.Done():
+		}
+	}
+}
+
+// retryDelay returns the wait after the given failed attempt: RetryDelay doubled for
+// each previous attempt, capped at maxRetryDelay, with up to 20% jitter
+func (q *Queue) retryDelay(attempt int) time.Duration {
+	delay := q.config.RetryDelay
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay + time.Duration(rand.Int63n(int64(delay)/5+1))
+}
+
+// deadLetter logs a message that will not be sent
+func (q *Queue) deadLetter(job *queuedMessage, attempts int, err error) {
+	fields := []zap.Field{
+		zap.Error(err),
+		pii.Field("to", job.msg.To),
+		zap.String("subject", job.msg.Subject),
+		zap.Int("attempts", attempts),
+		zap.Time("queued_at", job.queuedAt),
+	}
+	var sendErr *SendError
+	if errors.As(err, &sendErr) {
+		fields = append(fields,
+			zap.String("provider", string(sendErr.Provider)),
+			zap.Int("status", sendErr.StatusCode),
+			zap.String("code", sendErr.Code))
+	}
+	q.logger.Error("Email dead-lettered", fields...)
+}
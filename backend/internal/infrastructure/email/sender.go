@@ -0,0 +1,107 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/eralove/eralove-backend/internal/config"
+	"github.com/eralove/eralove-backend/internal/infrastructure/pii"
+	"go.uber.org/zap"
+)
+
+// Provider identifies the service emails are sent through
+type Provider string
+
+const (
+	ProviderSMTP     Provider = "smtp"
+	ProviderSendGrid Provider = "sendgrid"
+	ProviderSES      Provider = "ses"
+)
+
+// Message is a single HTML email to one recipient
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+}
+
+// EmailSender delivers messages through an email provider
+type EmailSender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// SendError is returned by senders when the provider refuses or fails to send a message
+type SendError struct {
+	Provider   Provider
+	StatusCode int    // HTTP or SMTP status code, 0 when the provider could not be reached
+	Code       string // provider specific error code, if any
+	Message    string
+	Temporary  bool // whether sending the same message again later may succeed
+}
+
+// Error implements the error interface
+func (e *SendError) Error() string {
+	var b strings.Builder
+	b.WriteString(string(e.Provider))
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, ": status %d", e.StatusCode)
+	}
+	if e.Code != "" {
+		fmt.Fprintf(&b, ": %s", e.Code)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	return b.String()
+}
+
+// NewSender creates the sender of the configured provider. In sandbox mode SendGrid
+// validates messages without delivering them, SES sends them to its mailbox simulator
+// and SMTP only logs them.
+func NewSender(cfg *config.Config, logger *zap.Logger) (EmailSender, error) {
+	from := mail.Address{Name: cfg.FromName, Address: cfg.FromEmail}
+	provider := Provider(strings.ToLower(cfg.EmailProvider))
+
+	logger.Info("Creating email sender",
+		zap.String("provider", string(provider)),
+		zap.Bool("sandbox", cfg.EmailSandbox))
+
+	switch provider {
+	case ProviderSMTP:
+		if cfg.EmailSandbox {
+			return NewLogSender(logger), nil
+		}
+		// Keep development setups without SMTP credentials working
+		if cfg.SMTPUsername == "" || cfg.SMTPPassword == "" {
+			logger.Warn("SMTP not configured, emails will be logged instead of sent")
+			return NewLogSender(logger), nil
+		}
+		return NewSMTPSender(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, from), nil
+	case ProviderSendGrid:
+		return NewSendGridSender(cfg.SendGridAPIKey, from, cfg.EmailSandbox), nil
+	case ProviderSES:
+		return NewSESSender(cfg.SESRegion, cfg.SESAccessKeyID, cfg.SESSecretAccessKey, from, cfg.EmailSandbox), nil
+	default:
+		return nil, fmt.Errorf("unsupported email provider: %s", provider)
+	}
+}
+
+// LogSender logs messages instead of sending them
+type LogSender struct {
+	logger *zap.Logger
+}
+
+// NewLogSender creates a sender that only logs messages
+func NewLogSender(logger *zap.Logger) *LogSender {
+	return &LogSender{logger: logger}
+}
+
+// Send logs the message
+func (s *LogSender) Send(ctx context.Context, msg *Message) error {
+	s.logger.Info("Email not sent (sandbox)",
+		pii.Field("to", msg.To),
+		zap.String("subject", msg.Subject))
+	return nil
+}
@@ -0,0 +1,141 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+const sendGridSendURL = "https://api.sendgrid.com/v3/mail/send"
+
+// SendGridSender sends messages through the SendGrid v3 Mail Send API
+type SendGridSender struct {
+	apiKey     string
+	from       mail.Address
+	sandbox    bool
+	httpClient *http.Client
+}
+
+// NewSendGridSender creates a new SendGrid sender. In sandbox mode SendGrid validates
+// each message but does not deliver it.
+func NewSendGridSender(apiKey string, from mail.Address, sandbox bool) *SendGridSender {
+	return &SendGridSender{
+		apiKey:     apiKey,
+		from:       from,
+		sandbox:    sandbox,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridSetting struct {
+	Enable bool `json:"enable"`
+}
+
+type sendGridMailSettings struct {
+	SandboxMode sendGridSetting `json:"sandbox_mode"`
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+	MailSettings     *sendGridMailSettings     `json:"mail_settings,omitempty"`
+}
+
+// Send sends the message
+func (s *SendGridSender) Send(ctx context.Context, msg *Message) error {
+	payload := sendGridRequest{
+		Personalizations: []sendGridPersonalization{{To: []sendGridAddress{{Email: msg.To}}}},
+		From:             sendGridAddress{Email: s.from.Address, Name: s.from.Name},
+		Subject:          msg.Subject,
+		Content:          []sendGridContent{{Type: "text/html", Value: msg.HTML}},
+	}
+	if s.sandbox {
+		payload.MailSettings = &sendGridMailSettings{SandboxMode: sendGridSetting{Enable: true}}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode sendgrid request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sendGridSendURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create sendgrid request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return &SendError{Provider: ProviderSendGrid, Message: err.Error(), Temporary: true}
+	}
+	defer resp.Body.Close()
+
+	// 202 when the message is queued, 200 when it was only validated in sandbox mode
+	if resp.StatusCode == http.StatusAccepted || resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return s.sendError(resp)
+}
+
+// sendError turns an unsuccessful Mail Send response into a SendError. Rate limits
+// and server errors are temporary; anything else, including a revoked API key or an
+// unverified sender, needs a fix before the message can go out.
+func (s *SendGridSender) sendError(resp *http.Response) error {
+	var body struct {
+		Errors []struct {
+			Message string `json:"message"`
+			Field   string `json:"field"`
+		} `json:"errors"`
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	_ = json.Unmarshal(raw, &body)
+
+	messages := make([]string, 0, len(body.Errors))
+	for _, e := range body.Errors {
+		if e.Field != "" {
+			messages = append(messages, e.Field+": "+e.Message)
+		} else {
+			messages = append(messages, e.Message)
+		}
+	}
+
+	sendErr := &SendError{
+		Provider:   ProviderSendGrid,
+		StatusCode: resp.StatusCode,
+		Message:    strings.Join(messages, "; "),
+		Temporary:  resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500,
+	}
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		sendErr.Code = "unauthorized"
+	case http.StatusRequestEntityTooLarge:
+		sendErr.Code = "payload_too_large"
+	case http.StatusTooManyRequests:
+		sendErr.Code = "rate_limited"
+	}
+	return sendErr
+}
@@ -0,0 +1,182 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+const (
+	sesSendPath = "/v2/email/outbound-emails"
+	// sesSimulatorAddress accepts mail through SES without delivering it anywhere
+	sesSimulatorAddress = "success@simulator.amazonses.com"
+)
+
+// sesTemporaryErrors lists the SES error codes after which sending again later may succeed
+var sesTemporaryErrors = map[string]bool{
+	"TooManyRequestsException": true,
+	"LimitExceededException":   true,
+	"InternalFailure":          true,
+	"ServiceUnavailable":       true,
+	"ThrottlingException":      true,
+}
+
+// SESSender sends messages through the Amazon SES v2 SendEmail API
+type SESSender struct {
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	from            mail.Address
+	sandbox         bool
+	httpClient      *http.Client
+}
+
+// NewSESSender creates a new SES sender. In sandbox mode every message is sent to the
+// SES mailbox simulator instead of its recipient.
+func NewSESSender(region, accessKeyID, secretAccessKey string, from mail.Address, sandbox bool) *SESSender {
+	return &SESSender{
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		from:            from,
+		sandbox:         sandbox,
+		httpClient:      &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+type sesContent struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+type sesRequest struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	Content struct {
+		Simple struct {
+			Subject sesContent `json:"Subject"`
+			Body    struct {
+				Html sesContent `json:"Html"`
+			} `json:"Body"`
+		} `json:"Simple"`
+	} `json:"Content"`
+}
+
+// Send sends the message
+func (s *SESSender) Send(ctx context.Context, msg *Message) error {
+	to := msg.To
+	if s.sandbox {
+		to = sesSimulatorAddress
+	}
+
+	var payload sesRequest
+	payload.FromEmailAddress = s.from.String()
+	payload.Destination.ToAddresses = []string{to}
+	payload.Content.Simple.Subject = sesContent{Data: msg.Subject, Charset: "UTF-8"}
+	payload.Content.Simple.Body.Html = sesContent{Data: msg.HTML, Charset: "UTF-8"}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode ses request: %w", err)
+	}
+
+	host := fmt.Sprintf("email.%s.amazonaws.com", s.region)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+host+sesSendPath, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create ses request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	s.sign(req, host, body, time.Now().UTC())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return &SendError{Provider: ProviderSES, Message: err.Error(), Temporary: true}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	return s.sendError(resp)
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to the request
+func (s *SESSender) sign(req *http.Request, host string, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := date + "/" + s.region + "/ses/aws4_request"
+	payloadHash := sha256.Sum256(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	const signedHeaders = "content-type;host;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		sesSendPath,
+		"",
+		"content-type:" + req.Header.Get("Content-Type"),
+		"host:" + host,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), date)
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "ses")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKeyID, scope, signedHeaders, signature))
+}
+
+// sendError turns an unsuccessful SendEmail response into a SendError. Throttling and
+// service failures are temporary; rejected messages, unverified identities and a
+// paused or suspended account are not.
+func (s *SESSender) sendError(resp *http.Response) error {
+	var body struct {
+		Message string `json:"message"`
+	}
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	_ = json.Unmarshal(raw, &body)
+
+	// The error type header looks like "MessageRejected:http://internal.amazon.com/..."
+	code, _, _ := strings.Cut(resp.Header.Get("X-Amzn-ErrorType"), ":")
+
+	return &SendError{
+		Provider:   ProviderSES,
+		StatusCode: resp.StatusCode,
+		Code:       code,
+		Message:    body.Message,
+		Temporary:  sesTemporaryErrors[code] || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500,
+	}
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data with key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,108 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+)
+
+// SMTPSender sends messages through an SMTP server using STARTTLS and PLAIN auth
+type SMTPSender struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     mail.Address
+}
+
+// NewSMTPSender creates a new SMTP sender
+func NewSMTPSender(host string, port int, username, password string, from mail.Address) *SMTPSender {
+	return &SMTPSender{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+// Send sends the message, giving up when ctx is done
+func (s *SMTPSender) Send(ctx context.Context, msg *Message) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(s.host, strconv.Itoa(s.port)))
+	if err != nil {
+		return s.sendError(err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return s.sendError(err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return s.sendError(err)
+		}
+	}
+	if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+		return s.sendError(err)
+	}
+	if err := client.Mail(s.from.Address); err != nil {
+		return s.sendError(err)
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return s.sendError(err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return s.sendError(err)
+	}
+	if _, err := w.Write(s.format(msg)); err != nil {
+		return s.sendError(err)
+	}
+	if err := w.Close(); err != nil {
+		return s.sendError(err)
+	}
+
+	return client.Quit()
+}
+
+// format renders the message with its headers
+func (s *SMTPSender) format(msg *Message) []byte {
+	return []byte(fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/html; charset=UTF-8\r\n\r\n%s",
+		s.from.String(), msg.To, mime.QEncoding.Encode("UTF-8", msg.Subject), msg.HTML))
+}
+
+// sendError classifies an SMTP failure. Replies in the 4xx range and connection
+// problems are temporary; 5xx replies are permanent.
+func (s *SMTPSender) sendError(err error) error {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) {
+		return &SendError{
+			Provider:   ProviderSMTP,
+			StatusCode: protoErr.Code,
+			Message:    protoErr.Msg,
+			Temporary:  protoErr.Code >= 400 && protoErr.Code < 500,
+		}
+	}
+
+	return &SendError{
+		Provider:  ProviderSMTP,
+		Message:   err.Error(),
+		Temporary: true,
+	}
+}
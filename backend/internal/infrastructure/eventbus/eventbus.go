@@ -0,0 +1,86 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// Handler receives the events it subscribed to. It runs on the publisher's goroutine,
+// with the context of the action that published the event, and must not block.
+type Handler func(ctx context.Context, event *domain.DomainEvent)
+
+// subscription is a handler and the event types it receives
+type subscription struct {
+	handler Handler
+	types   map[domain.DomainEventType]bool // nil for every type
+}
+
+// Bus is an in-process event bus. Services publish domain events to it and
+// integrations subscribe to them, so services need not know about integrations.
+type Bus struct {
+	mu            sync.RWMutex
+	subscriptions []subscription
+	logger        *zap.Logger
+}
+
+// NewBus creates an event bus without subscribers
+func NewBus(logger *zap.Logger) *Bus {
+	return &Bus{logger: logger}
+}
+
+// Subscribe registers handler for the given event types, or for every type when
+// none is given
+func (b *Bus) Subscribe(handler Handler, types ...domain.DomainEventType) {
+	sub := subscription{handler: handler}
+	if len(types) > 0 {
+		sub.types = make(map[domain.DomainEventType]bool, len(types))
+		for _, eventType := range types {
+			sub.types[eventType] = true
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions = append(b.subscriptions, sub)
+}
+
+// Publish creates an event and hands it to the subscribers of its type. A panicking
+// subscriber is logged and does not affect the others.
+func (b *Bus) Publish(ctx context.Context, eventType domain.DomainEventType, data interface{}) {
+	event := &domain.DomainEvent{
+		ID:         primitive.NewObjectID().Hex(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+
+	b.mu.RLock()
+	subscriptions := b.subscriptions
+	b.mu.RUnlock()
+
+	for _, sub := range subscriptions {
+		if sub.types != nil && !sub.types[eventType] {
+			continue
+		}
+		b.deliver(ctx, sub.handler, event)
+	}
+}
+
+// deliver calls a handler, recovering from its panics
+func (b *Bus) deliver(ctx context.Context, handler Handler, event *domain.DomainEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("Event handler panicked",
+				zap.Any("panic", r),
+				zap.String("event_id", event.ID),
+				zap.String("event_type", string(event.Type)))
+		}
+	}()
+
+	handler(ctx, event)
+}
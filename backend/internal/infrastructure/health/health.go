@@ -0,0 +1,128 @@
+// Package health reports whether the dependencies of the API can be reached.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Statuses of a component and of a report
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusDegraded = "degraded"
+)
+
+// CheckFunc checks a dependency, returning an error when it cannot be reached
+type CheckFunc func(ctx context.Context) error
+
+// component is a dependency checked by a Checker
+type component struct {
+	name     string
+	critical bool
+	check    CheckFunc
+}
+
+// ComponentStatus is the outcome of checking one dependency
+type ComponentStatus struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"-"` // logged, not exposed on the public probe
+}
+
+// Report is the outcome of checking every dependency. Its status is down when a
+// critical dependency is down and degraded when only others are.
+type Report struct {
+	Status     string             `json:"status"`
+	Time       time.Time          `json:"time"`
+	Components []*ComponentStatus `json:"components"`
+}
+
+// Checker checks dependencies, each with a timeout
+type Checker struct {
+	timeout    time.Duration
+	components []component
+}
+
+// NewChecker creates a checker that gives each dependency timeout to answer
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{timeout: timeout}
+}
+
+// Add registers a dependency. The API is not ready while a critical one is down.
+func (c *Checker) Add(name string, critical bool, check CheckFunc) {
+	c.components = append(c.components, component{name: name, critical: critical, check: check})
+}
+
+// Check checks every dependency concurrently and reports in the order they were added
+func (c *Checker) Check(ctx context.Context) *Report {
+	report := &Report{
+		Status:     StatusUp,
+		Time:       time.Now().UTC(),
+		Components: make([]*ComponentStatus, len(c.components)),
+	}
+
+	var wg sync.WaitGroup
+	for i, comp := range c.components {
+		wg.Add(1)
+		go func(i int, comp component) {
+			defer wg.Done()
+			report.Components[i] = c.checkComponent(ctx, comp)
+		}(i, comp)
+	}
+	wg.Wait()
+
+	for _, status := range report.Components {
+		if status.Status == StatusUp {
+			continue
+		}
+		if status.Critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+	}
+
+	return report
+}
+
+// checkComponent runs one check within the timeout
+func (c *Checker) checkComponent(ctx context.Context, comp component) *ComponentStatus {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := runCheck(ctx, comp.check)
+	latency := time.Since(start)
+
+	status := &ComponentStatus{
+		Name:      comp.name,
+		Status:    StatusUp,
+		Critical:  comp.critical,
+		LatencyMs: float64(latency.Microseconds()) / 1000,
+	}
+	if err != nil {
+		status.Status = StatusDown
+		status.Error = err.Error()
+	}
+	return status
+}
+
+// runCheck returns once the check returns or the context is done, whichever comes
+// first, so that a check ignoring its context cannot hold up the report
+func runCheck(ctx context.Context, check CheckFunc) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -9,6 +9,23 @@ import (
 	"golang.org/x/text/language"
 )
 
+// DefaultLanguage is used when no supported language was asked for
+const DefaultLanguage = "en"
+
+// SupportedLanguages lists the languages messages are translated to. Each has a
+// <lang>.json file in the messages directory.
+var SupportedLanguages = []string{"en", "es", "fr", "vi", "ja", "ko"}
+
+// matcher picks the best supported language for an Accept-Language header
+var matcher = language.NewMatcher([]language.Tag{
+	language.English,
+	language.Spanish,
+	language.French,
+	language.Vietnamese,
+	language.Japanese,
+	language.Korean,
+})
+
 // I18n handles internationalization
 type I18n struct {
 	bundle   *i18n.Bundle
@@ -28,24 +45,13 @@ func NewI18n(logger *zap.Logger) *I18n {
 	}
 }
 
-// LoadMessages loads translation messages from files
+// LoadMessages loads the translation messages of every supported language
 func (i *I18n) LoadMessages(messagesDir string) error {
-	// Load English messages
-	enFile := filepath.Join(messagesDir, "en.json")
-	if _, err := i.bundle.LoadMessageFile(enFile); err != nil {
-		i.logger.Warn("Failed to load English messages", zap.Error(err))
-	}
-
-	// Load Spanish messages
-	esFile := filepath.Join(messagesDir, "es.json")
-	if _, err := i.bundle.LoadMessageFile(esFile); err != nil {
-		i.logger.Warn("Failed to load Spanish messages", zap.Error(err))
-	}
-
-	// Load French messages
-	frFile := filepath.Join(messagesDir, "fr.json")
-	if _, err := i.bundle.LoadMessageFile(frFile); err != nil {
-		i.logger.Warn("Failed to load French messages", zap.Error(err))
+	for _, lang := range SupportedLanguages {
+		file := filepath.Join(messagesDir, lang+".json")
+		if _, err := i.bundle.LoadMessageFile(file); err != nil {
+			i.logger.Warn("Failed to load messages", zap.String("lang", lang), zap.Error(err))
+		}
 	}
 
 	i.logger.Info("Translation messages loaded")
@@ -69,24 +75,35 @@ func (i *I18n) GetLocalizer(lang string) *i18n.Localizer {
 
 // Translate translates a message key with optional template data
 func (i *I18n) Translate(lang, messageID string, templateData map[string]interface{}) string {
-	// Parse Accept-Language header if it contains multiple languages
-	parsedLang := i.ParseAcceptLanguage(lang)
-	
-	localizer := i.GetLocalizer(parsedLang)
+	return i.localize(lang, &i18n.LocalizeConfig{
+		MessageID:    messageID,
+		TemplateData: templateData,
+	})
+}
 
-	config := &i18n.LocalizeConfig{
+// TranslatePlural translates a message key whose plural form depends on count, such
+// as "one" and "other" in English. Count is not added to the template data.
+func (i *I18n) TranslatePlural(lang, messageID string, count int, templateData map[string]interface{}) string {
+	return i.localize(lang, &i18n.LocalizeConfig{
 		MessageID:    messageID,
 		TemplateData: templateData,
-	}
+		PluralCount:  count,
+	})
+}
 
-	translation, err := localizer.Localize(config)
+// localize translates a message to the best match for lang, which may be an
+// Accept-Language header
+func (i *I18n) localize(lang string, config *i18n.LocalizeConfig) string {
+	parsedLang := i.ParseAcceptLanguage(lang)
+
+	translation, err := i.GetLocalizer(parsedLang).Localize(config)
 	if err != nil {
 		i.logger.Warn("Translation not found",
-			zap.String("messageID", messageID),
+			zap.String("messageID", config.MessageID),
 			zap.String("lang", lang),
 			zap.String("parsed_lang", parsedLang),
 			zap.Error(err))
-		return messageID // Return the message ID as fallback
+		return config.MessageID // Return the message ID as fallback
 	}
 
 	return translation
@@ -94,13 +111,22 @@ func (i *I18n) Translate(lang, messageID string, templateData map[string]interfa
 
 // GetSupportedLanguages returns list of supported languages
 func (i *I18n) GetSupportedLanguages() []string {
-	return []string{"en", "es", "fr"}
+	return SupportedLanguages
 }
 
 // IsLanguageSupported checks if a language is supported
 func (i *I18n) IsLanguageSupported(lang string) bool {
-	supported := i.GetSupportedLanguages()
-	for _, supportedLang := range supported {
+	return IsSupported(lang)
+}
+
+// ParseAcceptLanguage parses Accept-Language header and returns best match
+func (i *I18n) ParseAcceptLanguage(acceptLang string) string {
+	return ParseAcceptLanguage(acceptLang)
+}
+
+// IsSupported checks if a language is supported
+func IsSupported(lang string) bool {
+	for _, supportedLang := range SupportedLanguages {
 		if supportedLang == lang {
 			return true
 		}
@@ -108,28 +134,26 @@ func (i *I18n) IsLanguageSupported(lang string) bool {
 	return false
 }
 
-// ParseAcceptLanguage parses Accept-Language header and returns best match
-func (i *I18n) ParseAcceptLanguage(acceptLang string) string {
+// ParseAcceptLanguage parses an Accept-Language header and returns the best
+// supported match, or DefaultLanguage when nothing matches
+func ParseAcceptLanguage(acceptLang string) string {
 	tags, _, err := language.ParseAcceptLanguage(acceptLang)
-	if err != nil {
-		return "en" // Default to English
+	if err != nil || len(tags) == 0 {
+		return DefaultLanguage
 	}
 
-	supported := []language.Tag{
-		language.English,
-		language.Spanish,
-		language.French,
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLanguage
 	}
+	return SupportedLanguages[index]
+}
 
-	matcher := language.NewMatcher(supported)
-	tag, _, _ := matcher.Match(tags...)
-
-	switch tag {
-	case language.Spanish:
-		return "es"
-	case language.French:
-		return "fr"
-	default:
-		return "en"
+// Resolve returns the language of a response: the user's preferred language when it
+// is supported, else the best match for the Accept-Language header
+func Resolve(preferred, acceptLang string) string {
+	if IsSupported(preferred) {
+		return preferred
 	}
+	return ParseAcceptLanguage(acceptLang)
 }
@@ -0,0 +1,181 @@
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	dateLayout      = "20060102"
+	localTimeLayout = "20060102T150405"
+	utcTimeLayout   = "20060102T150405Z"
+
+	// maxLineOctets is the longest content line allowed before folding (RFC 5545 3.1)
+	maxLineOctets = 75
+)
+
+// Calendar is an iCalendar (RFC 5545) object holding a list of events
+type Calendar struct {
+	ProductID string
+	Name      string
+	Events    []*Event
+}
+
+// Event is a VEVENT. All-day events use only the date of Start; other events use
+// Start as a floating local time, shown at that wall-clock time in every timezone,
+// unless UTC is set and Start is written as an instant.
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Categories  []string
+	Start       time.Time
+	AllDay      bool
+	UTC         bool
+	Duration    time.Duration
+	RRule       string
+	Private     bool
+	Created     time.Time
+	Modified    time.Time
+	Alarm       *Alarm
+}
+
+// Alarm is a VALARM that displays Description Before the start of its event
+type Alarm struct {
+	Before      time.Duration
+	Description string
+}
+
+// Encode renders the calendar as an iCalendar stream
+func (c *Calendar) Encode() []byte {
+	w := &writer{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", c.ProductID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		w.line("X-WR-CALNAME", escapeText(c.Name))
+	}
+
+	for _, event := range c.Events {
+		w.event(event)
+	}
+
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// writer writes folded content lines
+type writer struct {
+	buf bytes.Buffer
+}
+
+// event writes a VEVENT component
+func (w *writer) event(e *Event) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", e.UID)
+	stamp := e.Modified
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	w.line("DTSTAMP", stamp.UTC().Format(utcTimeLayout))
+	if !e.Created.IsZero() {
+		w.line("CREATED", e.Created.UTC().Format(utcTimeLayout))
+	}
+	if !e.Modified.IsZero() {
+		w.line("LAST-MODIFIED", e.Modified.UTC().Format(utcTimeLayout))
+	}
+
+	if e.AllDay {
+		w.line("DTSTART;VALUE=DATE", e.Start.Format(dateLayout))
+		w.line("DTEND;VALUE=DATE", e.Start.AddDate(0, 0, 1).Format(dateLayout))
+	} else {
+		if e.UTC {
+			w.line("DTSTART", e.Start.UTC().Format(utcTimeLayout))
+		} else {
+			w.line("DTSTART", e.Start.Format(localTimeLayout))
+		}
+		if e.Duration > 0 {
+			w.line("DURATION", formatDuration(e.Duration))
+		}
+	}
+
+	if e.RRule != "" {
+		w.line("RRULE", e.RRule)
+	}
+
+	w.line("SUMMARY", escapeText(e.Summary))
+	if e.Description != "" {
+		w.line("DESCRIPTION", escapeText(e.Description))
+	}
+	if e.Location != "" {
+		w.line("LOCATION", escapeText(e.Location))
+	}
+	if len(e.Categories) > 0 {
+		escaped := make([]string, len(e.Categories))
+		for i, category := range e.Categories {
+			escaped[i] = escapeText(category)
+		}
+		w.line("CATEGORIES", strings.Join(escaped, ","))
+	}
+	if e.Private {
+		w.line("CLASS", "PRIVATE")
+	}
+
+	if e.Alarm != nil {
+		w.line("BEGIN", "VALARM")
+		w.line("ACTION", "DISPLAY")
+		w.line("TRIGGER", "-"+formatDuration(e.Alarm.Before))
+		w.line("DESCRIPTION", escapeText(e.Alarm.Description))
+		w.line("END", "VALARM")
+	}
+
+	w.line("END", "VEVENT")
+}
+
+// line writes "name:value" folded at 75 octets, without splitting UTF-8 characters
+func (w *writer) line(name, value string) {
+	content := name + ":" + value
+
+	width := 0
+	for len(content) > 0 {
+		_, size := utf8.DecodeRuneInString(content)
+		if width+size > maxLineOctets {
+			w.buf.WriteString("\r\n ")
+			width = 1
+		}
+		w.buf.WriteString(content[:size])
+		width += size
+		content = content[size:]
+	}
+	w.buf.WriteString("\r\n")
+}
+
+// escapeText escapes a TEXT value (RFC 5545 3.3.11)
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\r", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// formatDuration formats a positive duration as an iCalendar DURATION value
+func formatDuration(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("P%dD", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("PT%dH", d/time.Hour)
+	default:
+		return fmt.Sprintf("PT%dM", d/time.Minute)
+	}
+}
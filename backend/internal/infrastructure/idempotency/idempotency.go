@@ -0,0 +1,165 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/infrastructure/cache"
+)
+
+// Header is the request header clients send a unique key in, the same on every retry
+// of a request
+const Header = "Idempotency-Key"
+
+// ErrNotFound is returned by Get when no request was recorded for a key
+var ErrNotFound = errors.New("idempotency key not found")
+
+// Record is the state of a request made with an idempotency key. A record without a
+// status is a request still being handled.
+type Record struct {
+	Fingerprint string `json:"fingerprint"` // identifies the method, path and body of the request
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// Completed reports whether the request has been handled and its response recorded
+func (r *Record) Completed() bool {
+	return r.Status != 0
+}
+
+// Store records the requests made with idempotency keys
+type Store interface {
+	// Reserve records a request being handled for ttl and reports false if a request
+	// is already recorded for key
+	Reserve(ctx context.Context, key string, record *Record, ttl time.Duration) (bool, error)
+	// Complete replaces the record of key with the request's response
+	Complete(ctx context.Context, key string, record *Record, ttl time.Duration) error
+	// Get returns the record of key, or ErrNotFound
+	Get(ctx context.Context, key string) (*Record, error)
+	// Release drops the record of key, so that the request can be made again
+	Release(ctx context.Context, key string) error
+}
+
+// RedisStore records requests in Redis, shared by all instances
+type RedisStore struct {
+	cache cache.Cache
+}
+
+// NewRedisStore creates a new Redis idempotency store
+func NewRedisStore(cache cache.Cache) Store {
+	return &RedisStore{cache: cache}
+}
+
+// Reserve records a request unless one is already recorded for key
+func (s *RedisStore) Reserve(ctx context.Context, key string, record *Record, ttl time.Duration) (bool, error) {
+	return s.cache.SetIfNotExists(ctx, key, record, ttl)
+}
+
+// Complete records the response of a request
+func (s *RedisStore) Complete(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	return s.cache.Set(ctx, key, record, ttl)
+}
+
+// Get returns the record of key
+func (s *RedisStore) Get(ctx context.Context, key string) (*Record, error) {
+	exists, err := s.cache.Exists(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	var record Record
+	if err := s.cache.Get(ctx, key, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Release drops the record of key
+func (s *RedisStore) Release(ctx context.Context, key string) error {
+	return s.cache.Delete(ctx, key)
+}
+
+// memoryRecord is a record held by MemoryStore
+type memoryRecord struct {
+	record    *Record
+	expiresAt time.Time
+}
+
+// MemoryStore records requests in memory, for deployments without Redis. A retry
+// reaching another instance is then handled again.
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]*memoryRecord
+}
+
+// NewMemoryStore creates a new in-memory idempotency store. Prune must be run
+// periodically to drop expired records.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]*memoryRecord)}
+}
+
+// Reserve records a request unless one is already recorded for key and not expired
+func (s *MemoryStore) Reserve(ctx context.Context, key string, record *Record, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.records[key]; ok && now.Before(stored.expiresAt) {
+		return false, nil
+	}
+
+	s.records[key] = &memoryRecord{record: record, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+// Complete records the response of a request
+func (s *MemoryStore) Complete(ctx context.Context, key string, record *Record, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[key] = &memoryRecord{record: record, expiresAt: time.Now().Add(ttl)}
+	return nil
+}
+
+// Get returns the record of key
+func (s *MemoryStore) Get(ctx context.Context, key string) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.records[key]
+	if !ok || !time.Now().Before(stored.expiresAt) {
+		return nil, ErrNotFound
+	}
+	return stored.record, nil
+}
+
+// Release drops the record of key
+func (s *MemoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// Prune drops the expired records
+func (s *MemoryStore) Prune(ctx context.Context) error {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, stored := range s.records {
+		if !now.Before(stored.expiresAt) {
+			delete(s.records, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,333 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ErrNoMetadata is returned when an image carries no EXIF metadata
+var ErrNoMetadata = errors.New("image has no exif metadata")
+
+// Metadata is the EXIF metadata a camera recorded in a JPEG image
+type Metadata struct {
+	Orientation  int        // 1 to 8, 0 when unknown
+	TakenAt      *time.Time // UTC when the offset was not recorded
+	Latitude     *float64
+	Longitude    *float64
+	Make         string
+	Model        string
+	LensModel    string
+	FocalLength  float64 // mm
+	FNumber      float64
+	ExposureTime string // e.g. "1/125"
+	ISO          int
+}
+
+// EXIF tags read from the image, by IFD
+const (
+	tagMake         = 0x010F
+	tagModel        = 0x0110
+	tagOrientation  = 0x0112
+	tagDateTime     = 0x0132
+	tagExifIFD      = 0x8769
+	tagGPSIFD       = 0x8825
+	tagExposureTime = 0x829A
+	tagFNumber      = 0x829D
+	tagISO          = 0x8827
+	tagDateOriginal = 0x9003
+	tagOffsetOrig   = 0x9011
+	tagFocalLength  = 0x920A
+	tagLensModel    = 0xA434
+	tagGPSLatRef    = 0x0001
+	tagGPSLat       = 0x0002
+	tagGPSLonRef    = 0x0003
+	tagGPSLon       = 0x0004
+)
+
+// exifDateLayout is the layout of EXIF date and time values
+const exifDateLayout = "2006:01:02 15:04:05"
+
+// ReadMetadata reads the EXIF metadata of a JPEG image. Other formats, and JPEG
+// images without metadata, return ErrNoMetadata.
+func ReadMetadata(data []byte) (*Metadata, error) {
+	payload, err := findEXIF(data)
+	if err != nil {
+		return nil, err
+	}
+
+	tiff, err := newTIFFReader(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ifd0, err := tiff.readIFD(tiff.firstIFD)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := &Metadata{
+		Orientation: int(ifd0.uint(tagOrientation)),
+		Make:        ifd0.string(tagMake),
+		Model:       ifd0.string(tagModel),
+	}
+	takenAt := ifd0.string(tagDateTime)
+	offset := ""
+
+	if exifOffset, ok := ifd0.offset(tagExifIFD); ok {
+		if exif, err := tiff.readIFD(exifOffset); err == nil {
+			if original := exif.string(tagDateOriginal); original != "" {
+				takenAt = original
+				offset = exif.string(tagOffsetOrig)
+			}
+			meta.LensModel = exif.string(tagLensModel)
+			meta.FocalLength = exif.rational(tagFocalLength, 0)
+			meta.FNumber = exif.rational(tagFNumber, 0)
+			meta.ExposureTime = exif.exposure(tagExposureTime)
+			meta.ISO = int(exif.uint(tagISO))
+		}
+	}
+
+	if gpsOffset, ok := ifd0.offset(tagGPSIFD); ok {
+		if gps, err := tiff.readIFD(gpsOffset); err == nil {
+			meta.Latitude = gps.coordinate(tagGPSLat, tagGPSLatRef, "S")
+			meta.Longitude = gps.coordinate(tagGPSLon, tagGPSLonRef, "W")
+		}
+	}
+
+	meta.TakenAt = parseEXIFTime(takenAt, offset)
+
+	return meta, nil
+}
+
+// findEXIF returns the TIFF payload of the EXIF APP1 segment of a JPEG image
+func findEXIF(data []byte) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, ErrNoMetadata
+	}
+
+	for pos := 2; pos+4 <= len(data); {
+		if data[pos] != 0xFF {
+			return nil, ErrNoMetadata
+		}
+		marker := data[pos+1]
+		// Metadata segments all come before the image data
+		if marker == 0xDA || marker == 0xD9 {
+			return nil, ErrNoMetadata
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2:]))
+		end := pos + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, ErrNoMetadata
+		}
+
+		segment := data[pos+4 : end]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return segment[6:], nil
+		}
+		pos = end
+	}
+
+	return nil, ErrNoMetadata
+}
+
+// tiffReader reads the IFDs of a TIFF structure
+type tiffReader struct {
+	data     []byte
+	order    binary.ByteOrder
+	firstIFD uint32
+}
+
+// newTIFFReader reads the header of a TIFF structure
+func newTIFFReader(data []byte) (*tiffReader, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("exif header is truncated")
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("exif byte order is invalid")
+	}
+	if order.Uint16(data[2:]) != 42 {
+		return nil, fmt.Errorf("exif header is invalid")
+	}
+
+	return &tiffReader{data: data, order: order, firstIFD: order.Uint32(data[4:])}, nil
+}
+
+// tiffEntry is a field of an IFD
+type tiffEntry struct {
+	typ   uint16
+	count uint32
+	value []byte
+}
+
+// tiffIFD is the fields of an IFD, by tag
+type tiffIFD struct {
+	order   binary.ByteOrder
+	entries map[uint16]tiffEntry
+}
+
+// tiffTypeSizes maps the TIFF field types read to the size of one value
+var tiffTypeSizes = map[uint16]uint32{
+	1:  1, // BYTE
+	2:  1, // ASCII
+	3:  2, // SHORT
+	4:  4, // LONG
+	5:  8, // RATIONAL
+	7:  1, // UNDEFINED
+	9:  4, // SLONG
+	10: 8, // SRATIONAL
+}
+
+// readIFD reads the IFD at offset. Fields of unknown types are skipped.
+func (t *tiffReader) readIFD(offset uint32) (*tiffIFD, error) {
+	if uint64(offset)+2 > uint64(len(t.data)) {
+		return nil, fmt.Errorf("exif directory is out of range")
+	}
+	count := int(t.order.Uint16(t.data[offset:]))
+
+	ifd := &tiffIFD{order: t.order, entries: make(map[uint16]tiffEntry, count)}
+	for i := 0; i < count; i++ {
+		start := uint64(offset) + 2 + uint64(i)*12
+		if start+12 > uint64(len(t.data)) {
+			break
+		}
+		raw := t.data[start : start+12]
+
+		typ := t.order.Uint16(raw[2:])
+		size, ok := tiffTypeSizes[typ]
+		if !ok {
+			continue
+		}
+		valueCount := t.order.Uint32(raw[4:])
+		length := uint64(size) * uint64(valueCount)
+
+		value := raw[8:12]
+		if length > 4 {
+			valueOffset := uint64(t.order.Uint32(raw[8:]))
+			if valueOffset+length > uint64(len(t.data)) {
+				continue
+			}
+			value = t.data[valueOffset : valueOffset+length]
+		} else {
+			value = value[:length]
+		}
+
+		ifd.entries[t.order.Uint16(raw)] = tiffEntry{typ: typ, count: valueCount, value: value}
+	}
+
+	return ifd, nil
+}
+
+// string returns an ASCII field, trimmed
+func (d *tiffIFD) string(tag uint16) string {
+	entry, ok := d.entries[tag]
+	if !ok || entry.typ != 2 {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimRight(string(entry.value), "\x00"))
+}
+
+// uint returns the first value of a SHORT or LONG field, or 0
+func (d *tiffIFD) uint(tag uint16) uint32 {
+	entry, ok := d.entries[tag]
+	if !ok || entry.count == 0 {
+		return 0
+	}
+	switch entry.typ {
+	case 3:
+		return uint32(d.order.Uint16(entry.value))
+	case 4:
+		return d.order.Uint32(entry.value)
+	default:
+		return 0
+	}
+}
+
+// offset returns the offset held by a pointer to another IFD
+func (d *tiffIFD) offset(tag uint16) (uint32, bool) {
+	entry, ok := d.entries[tag]
+	if !ok || entry.typ != 4 || entry.count == 0 {
+		return 0, false
+	}
+	return d.order.Uint32(entry.value), true
+}
+
+// ratio returns the numerator and denominator of the value at index of a RATIONAL
+// field
+func (d *tiffIFD) ratio(tag uint16, index int) (uint32, uint32, bool) {
+	entry, ok := d.entries[tag]
+	if !ok || entry.typ != 5 || uint32(index) >= entry.count {
+		return 0, 0, false
+	}
+	value := entry.value[index*8:]
+	return d.order.Uint32(value), d.order.Uint32(value[4:]), true
+}
+
+// rational returns the value at index of a RATIONAL field, or 0
+func (d *tiffIFD) rational(tag uint16, index int) float64 {
+	num, den, ok := d.ratio(tag, index)
+	if !ok || den == 0 {
+		return 0
+	}
+	return float64(num) / float64(den)
+}
+
+// exposure formats an exposure time as a fraction of a second, or in seconds when
+// it is longer
+func (d *tiffIFD) exposure(tag uint16) string {
+	num, den, ok := d.ratio(tag, 0)
+	if !ok || num == 0 || den == 0 {
+		return ""
+	}
+	if num >= den {
+		return fmt.Sprintf("%g", math.Round(float64(num)/float64(den)*10)/10)
+	}
+	return fmt.Sprintf("1/%d", int(math.Round(float64(den)/float64(num))))
+}
+
+// coordinate returns a GPS coordinate recorded as degrees, minutes and seconds,
+// negated when its reference is negativeRef
+func (d *tiffIFD) coordinate(tag, refTag uint16, negativeRef string) *float64 {
+	if entry, ok := d.entries[tag]; !ok || entry.count < 3 {
+		return nil
+	}
+	value := d.rational(tag, 0) + d.rational(tag, 1)/60 + d.rational(tag, 2)/3600
+	if strings.EqualFold(d.string(refTag), negativeRef) {
+		value = -value
+	}
+	return &value
+}
+
+// parseEXIFTime parses an EXIF date and time, in the given offset such as "+02:00"
+// or in UTC when there is none. Zeroed dates are treated as missing.
+func parseEXIFTime(value, offset string) *time.Time {
+	if value == "" || strings.HasPrefix(value, "0000") {
+		return nil
+	}
+
+	location := time.UTC
+	if offset != "" {
+		if zone, err := time.Parse("-07:00", offset); err == nil {
+			_, seconds := zone.Zone()
+			location = time.FixedZone("", seconds)
+		}
+	}
+
+	t, err := time.ParseInLocation(exifDateLayout, value, location)
+	if err != nil {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
@@ -0,0 +1,220 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	// Register additional decoders so uploads in these formats can be processed
+	_ "image/gif"
+
+	_ "golang.org/x/image/webp"
+)
+
+// Supported output formats
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+)
+
+const (
+	// jpegQuality is the quality used when re-encoding JPEG images
+	jpegQuality = 85
+	// watermarkWidthRatio is the share of the image width the watermark text spans
+	watermarkWidthRatio = 0.35
+	// watermarkMarginRatio is the margin from the image edges relative to the shorter side
+	watermarkMarginRatio = 0.03
+	// watermarkOpacity is the alpha of the watermark text (0-255)
+	watermarkOpacity = 140
+)
+
+// Decode decodes an image and returns it with the output format it should be re-encoded in.
+// Formats that cannot be encoded (GIF, WebP) are re-encoded as JPEG.
+func Decode(r io.Reader) (image.Image, string, error) {
+	img, format, err := image.Decode(r)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	if format != FormatPNG {
+		format = FormatJPEG
+	}
+
+	return img, format, nil
+}
+
+// Encode encodes an image in the given format and returns the bytes with their content type
+func Encode(img image.Image, format string) ([]byte, string, error) {
+	var buf bytes.Buffer
+
+	switch format {
+	case FormatPNG:
+		if err := png.Encode(&buf, img); err != nil {
+			return nil, "", fmt.Errorf("failed to encode png: %w", err)
+		}
+		return buf.Bytes(), "image/png", nil
+	default:
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, "", fmt.Errorf("failed to encode jpeg: %w", err)
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+}
+
+// Resize scales img down so that neither side exceeds maxDimension, preserving the aspect ratio.
+// Images that already fit are returned unchanged.
+func Resize(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width <= maxDimension && height <= maxDimension {
+		return img
+	}
+
+	if width >= height {
+		height = height * maxDimension / width
+		width = maxDimension
+	} else {
+		width = width * maxDimension / height
+		height = maxDimension
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	out := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(out, out.Bounds(), img, bounds, draw.Src, nil)
+
+	return out
+}
+
+// CropSquare crops img to the largest square centered on it. Square images are
+// returned unchanged.
+func CropSquare(img image.Image) image.Image {
+	bounds := img.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	if bounds.Dx() == side && bounds.Dy() == side {
+		return img
+	}
+
+	min := image.Point{
+		X: bounds.Min.X + (bounds.Dx()-side)/2,
+		Y: bounds.Min.Y + (bounds.Dy()-side)/2,
+	}
+	out := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(out, out.Bounds(), img, min, draw.Src)
+
+	return out
+}
+
+// Orient turns img upright according to its EXIF orientation, as re-encoded images
+// lose the tag. Images that are already upright, or of unknown orientation, are
+// returned unchanged.
+func Orient(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	// Orientations 5 to 8 are rotated by a quarter turn
+	outWidth, outHeight := width, height
+	if orientation >= 5 {
+		outWidth, outHeight = height, width
+	}
+
+	out := image.NewRGBA(image.Rect(0, 0, outWidth, outHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored
+				dx, dy = width-1-x, y
+			case 3: // rotated 180
+				dx, dy = width-1-x, height-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, height-1-y
+			case 5: // mirrored and rotated 90 counter-clockwise
+				dx, dy = y, x
+			case 6: // rotated 90 clockwise
+				dx, dy = height-1-y, x
+			case 7: // mirrored and rotated 90 clockwise
+				dx, dy = height-1-y, width-1-x
+			case 8: // rotated 90 counter-clockwise
+				dx, dy = y, width-1-x
+			}
+			out.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return out
+}
+
+// Watermark returns a copy of img with text drawn semi-transparently in the bottom-right corner.
+// The text is scaled with the image so it stays legible on both small and large photos.
+func Watermark(img image.Image, text string) image.Image {
+	bounds := img.Bounds()
+	out := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(out, out.Bounds(), img, bounds.Min, draw.Src)
+
+	if text == "" {
+		return out
+	}
+
+	// Render the text at the font's native size into an alpha mask
+	face := basicfont.Face7x13
+	textWidth := font.MeasureString(face, text).Ceil()
+	metrics := face.Metrics()
+	textHeight := (metrics.Ascent + metrics.Descent).Ceil()
+
+	mask := image.NewAlpha(image.Rect(0, 0, textWidth, textHeight))
+	drawer := &font.Drawer{
+		Dst:  mask,
+		Src:  image.NewUniform(color.Alpha{A: watermarkOpacity}),
+		Face: face,
+		Dot:  fixed.P(0, metrics.Ascent.Ceil()),
+	}
+	drawer.DrawString(text)
+
+	// Scale the mask to a fixed share of the image width
+	targetWidth := int(float64(out.Bounds().Dx()) * watermarkWidthRatio)
+	if targetWidth < textWidth {
+		targetWidth = textWidth
+	}
+	targetHeight := textHeight * targetWidth / textWidth
+
+	shorter := out.Bounds().Dx()
+	if out.Bounds().Dy() < shorter {
+		shorter = out.Bounds().Dy()
+	}
+	margin := int(float64(shorter) * watermarkMarginRatio)
+
+	dst := image.Rect(
+		out.Bounds().Dx()-targetWidth-margin,
+		out.Bounds().Dy()-targetHeight-margin,
+		out.Bounds().Dx()-margin,
+		out.Bounds().Dy()-margin,
+	)
+
+	scaled := image.NewAlpha(image.Rect(0, 0, dst.Dx(), dst.Dy()))
+	draw.ApproxBiLinear.Scale(scaled, scaled.Bounds(), mask, mask.Bounds(), draw.Src, nil)
+
+	draw.DrawMask(out, dst, image.White, image.Point{}, scaled, image.Point{}, draw.Over)
+
+	return out
+}
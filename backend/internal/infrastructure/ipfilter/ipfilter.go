@@ -0,0 +1,76 @@
+package ipfilter
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// List is a set of IP ranges. Entries may be CIDR ranges or single addresses.
+type List []*net.IPNet
+
+// ParseList parses CIDR ranges and single IP addresses, ignoring blank entries
+func ParseList(values []string) (List, error) {
+	list := make(List, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address %q", value)
+			}
+			bits := 32
+			if ip.To4() == nil {
+				bits = 128
+			}
+			value = fmt.Sprintf("%s/%d", value, bits)
+		}
+
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q", value)
+		}
+		list = append(list, network)
+	}
+
+	return list, nil
+}
+
+// Contains reports whether ip falls in any range of the list
+func (l List) Contains(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	for _, network := range l {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// CountrySet is a set of upper-case ISO 3166-1 alpha-2 country codes
+type CountrySet map[string]bool
+
+// ParseCountries parses country codes such as "KP,IR"
+func ParseCountries(values []string) CountrySet {
+	set := make(CountrySet, len(values))
+	for _, value := range values {
+		value = strings.ToUpper(strings.TrimSpace(value))
+		if value != "" {
+			set[value] = true
+		}
+	}
+	return set
+}
+
+// Contains reports whether country is in the set
+func (s CountrySet) Contains(country string) bool {
+	return s[strings.ToUpper(strings.TrimSpace(country))]
+}
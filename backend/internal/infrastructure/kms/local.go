@@ -0,0 +1,122 @@
+package kms
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// dataKeySize is the size of generated data keys, for AES-256
+const dataKeySize = 32
+
+// LocalKeyManager wraps data keys with AES-256-GCM master keys held in the
+// configuration. The first master key wraps new data keys; the others are only used
+// to unwrap data keys wrapped before a master key rotation.
+type LocalKeyManager struct {
+	keys      map[string]cipher.AEAD
+	currentID string
+}
+
+// NewLocalKeyManager creates a key manager from "keyID:base64key" pairs, where each
+// key is 32 random bytes. The first pair is the current master key.
+func NewLocalKeyManager(masterKeys []string) (*LocalKeyManager, error) {
+	if len(masterKeys) == 0 {
+		return nil, fmt.Errorf("no master key configured")
+	}
+
+	m := &LocalKeyManager{keys: make(map[string]cipher.AEAD, len(masterKeys))}
+	for i, pair := range masterKeys {
+		keyID, encoded, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || keyID == "" || encoded == "" {
+			return nil, fmt.Errorf("master key %d is not a keyID:base64key pair", i+1)
+		}
+		if _, exists := m.keys[keyID]; exists {
+			return nil, fmt.Errorf("master key %s is configured twice", keyID)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("master key %s is not valid base64: %w", keyID, err)
+		}
+		if len(key) != dataKeySize {
+			return nil, fmt.Errorf("master key %s must be %d bytes, got %d", keyID, dataKeySize, len(key))
+		}
+
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("master key %s: %w", keyID, err)
+		}
+		m.keys[keyID] = aead
+		if i == 0 {
+			m.currentID = keyID
+		}
+	}
+
+	return m, nil
+}
+
+// CurrentKeyID returns the ID of the master key that wraps new data keys
+func (m *LocalKeyManager) CurrentKeyID() string {
+	return m.currentID
+}
+
+// GenerateDataKey returns a new random data key, in plaintext and wrapped by the
+// current master key
+func (m *LocalKeyManager) GenerateDataKey(ctx context.Context) (plaintext, wrapped []byte, masterKeyID string, err error) {
+	plaintext = make([]byte, dataKeySize)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, nil, "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	wrapped, masterKeyID, err = m.WrapDataKey(ctx, plaintext)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return plaintext, wrapped, masterKeyID, nil
+}
+
+// WrapDataKey wraps a data key with the current master key
+func (m *LocalKeyManager) WrapDataKey(ctx context.Context, plaintext []byte) ([]byte, string, error) {
+	aead := m.keys[m.currentID]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	// The master key ID is authenticated so a wrapped key cannot be passed off as
+	// wrapped by another master key
+	return aead.Seal(nonce, nonce, plaintext, []byte(m.currentID)), m.currentID, nil
+}
+
+// UnwrapDataKey unwraps a data key wrapped by the given master key
+func (m *LocalKeyManager) UnwrapDataKey(ctx context.Context, masterKeyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := m.keys[masterKeyID]
+	if !ok {
+		return nil, fmt.Errorf("master key %s is not configured", masterKeyID)
+	}
+
+	if len(wrapped) < aead.NonceSize() {
+		return nil, fmt.Errorf("wrapped data key is too short")
+	}
+
+	nonce, sealed := wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, sealed, []byte(masterKeyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	return plaintext, nil
+}
+
+// newAEAD creates an AES-GCM cipher for a 32-byte key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
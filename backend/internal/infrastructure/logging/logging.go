@@ -0,0 +1,41 @@
+package logging
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// contextKey is the type of Key, private so no other package can collide with it
+type contextKey struct{}
+
+// Key is the key of the request-scoped logger in a request's locals, where the
+// request's context finds it
+var Key = contextKey{}
+
+// New creates the application's logger: JSON output from info level in production,
+// readable output from debug level everywhere else
+func New(environment string) (*zap.Logger, error) {
+	if environment == "production" {
+		return zap.NewProduction()
+	}
+	return zap.NewDevelopment()
+}
+
+// FromContext returns the logger of the request ctx belongs to, which tags every entry
+// with the request's trace ID and, once authenticated, its user. Outside of a request,
+// such as in scheduled jobs, it returns the global logger.
+func FromContext(ctx context.Context) *zap.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(Key).(*zap.Logger); ok && logger != nil {
+			return logger
+		}
+	}
+	return zap.L()
+}
+
+// NewContext returns a copy of ctx whose logger is logger, for work that outlives the
+// request it was started by
+func NewContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, Key, logger)
+}
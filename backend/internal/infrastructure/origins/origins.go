@@ -0,0 +1,158 @@
+package origins
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// List is a set of allowed browser origins. Entries are full origins such as
+// https://app.example.com, or wildcards such as https://*.example.com, which match
+// any subdomain but not example.com itself.
+type List struct {
+	exact     map[string]struct{}
+	wildcards []wildcard
+	entries   []string
+}
+
+// wildcard matches the subdomains of a host for one scheme and port
+type wildcard struct {
+	prefix string // scheme and "://"
+	suffix string // "." followed by the parent domain, and the port if any
+}
+
+// ParseList parses origins and wildcard origins, ignoring blank entries
+func ParseList(values []string) (*List, error) {
+	list := &List{exact: make(map[string]struct{})}
+	seen := make(map[string]bool)
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		origin, err := normalize(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[origin] {
+			continue
+		}
+		seen[origin] = true
+		list.entries = append(list.entries, origin)
+
+		if i := strings.Index(origin, "://*."); i != -1 {
+			list.wildcards = append(list.wildcards, wildcard{prefix: origin[:i+3], suffix: origin[i+4:]})
+		} else {
+			list.exact[origin] = struct{}{}
+		}
+	}
+
+	sort.Strings(list.entries)
+	return list, nil
+}
+
+// Allows reports whether origin is in the list
+func (l *List) Allows(origin string) bool {
+	origin = strings.ToLower(origin)
+	if _, ok := l.exact[origin]; ok {
+		return true
+	}
+
+	for _, w := range l.wildcards {
+		if !strings.HasPrefix(origin, w.prefix) || !strings.HasSuffix(origin, w.suffix) {
+			continue
+		}
+		sub := origin[len(w.prefix) : len(origin)-len(w.suffix)]
+		if sub != "" && !strings.ContainsAny(sub, ":/*") {
+			return true
+		}
+	}
+	return false
+}
+
+// Entries returns the origins of the list, sorted
+func (l *List) Entries() []string {
+	return append([]string(nil), l.entries...)
+}
+
+// normalize checks that value is an http(s) origin without path, and lowercases it
+func normalize(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSuffix(value, "/"))
+	if value == "*" {
+		return "", fmt.Errorf("origin * is not allowed, list the origins or use wildcard subdomains")
+	}
+
+	u, err := url.Parse(strings.Replace(value, "://*.", "://wildcard.", 1))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid origin %q", value)
+	}
+	if u.Path != "" || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", fmt.Errorf("origin %q must not have a path, query or credentials", value)
+	}
+	if strings.Contains(u.Hostname(), "*") {
+		return "", fmt.Errorf("origin %q may only use * as its first label", value)
+	}
+
+	return value, nil
+}
+
+// Registry holds the origins allowed for cross-origin requests. They are the
+// configured origins plus those listed in a file, one per line, which can be
+// reloaded while the server runs.
+type Registry struct {
+	configured []string
+	file       string
+	list       atomic.Pointer[List]
+}
+
+// NewRegistry creates a registry with the configured origins and those in file,
+// which may be empty
+func NewRegistry(configured []string, file string) (*Registry, error) {
+	registry := &Registry{configured: configured, file: file}
+	if _, err := registry.Reload(); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// Reload reads the origins file again. The previous origins stay in effect when it
+// cannot be read or has invalid entries.
+func (r *Registry) Reload() (*List, error) {
+	values := append([]string(nil), r.configured...)
+
+	if r.file != "" {
+		data, err := os.ReadFile(r.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read origins file: %w", err)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				values = append(values, line)
+			}
+		}
+	}
+
+	list, err := ParseList(values)
+	if err != nil {
+		return nil, err
+	}
+
+	r.list.Store(list)
+	return list, nil
+}
+
+// Allows reports whether origin may make cross-origin requests
+func (r *Registry) Allows(origin string) bool {
+	return r.list.Load().Allows(origin)
+}
+
+// List returns the origins in effect
+func (r *Registry) List() *List {
+	return r.list.Load()
+}
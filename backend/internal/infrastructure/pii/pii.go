@@ -0,0 +1,109 @@
+package pii
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+)
+
+// Mode is how personal data is written to logs
+type Mode string
+
+// Redaction modes
+const (
+	// ModeOff logs personal data as it is
+	ModeOff Mode = "off"
+	// ModeMask keeps the first character of names and of the local part of email
+	// addresses, and the domain of the addresses
+	ModeMask Mode = "mask"
+	// ModeHash replaces personal data with a keyed hash, the same in every log line, so
+	// entries about one person can be found without logging who they are
+	ModeHash Mode = "hash"
+)
+
+// Redacted replaces credentials, which are never logged
+const Redacted = "[REDACTED]"
+
+// hashLength is the number of hex characters of hashes kept in logs
+const hashLength = 16
+
+// redactor is the configured redaction, replaced as a whole by Configure
+type redactor struct {
+	mode Mode
+	key  []byte
+}
+
+var current atomic.Pointer[redactor]
+
+func init() {
+	current.Store(&redactor{mode: ModeMask})
+}
+
+// Configure sets how Field redacts personal data. Key is the secret hashes are computed
+// with in ModeHash.
+func Configure(mode Mode, key string) {
+	current.Store(&redactor{mode: mode, key: []byte(key)})
+}
+
+// Field returns a zap field for a value holding personal data, redacted according to
+// its key: credentials (keys containing token, password or secret) are never logged,
+// and any other value, such as an email address or a name, is redacted per the
+// configured mode
+func Field(key, value string) zap.Field {
+	return zap.String(key, Redact(key, value))
+}
+
+// Redact returns value as Field logs it under key
+func Redact(key, value string) string {
+	key = strings.ToLower(key)
+	if isCredential(key) {
+		return Redacted
+	}
+	if value == "" {
+		return value
+	}
+
+	r := current.Load()
+	switch r.mode {
+	case ModeOff:
+		return value
+	case ModeHash:
+		return r.hash(value)
+	}
+
+	return mask(value)
+}
+
+// isCredential reports whether a field key names a credential
+func isCredential(key string) bool {
+	return strings.Contains(key, "token") || strings.Contains(key, "password") || strings.Contains(key, "secret")
+}
+
+// hash returns the keyed hash of value. Values are compared without case, so an email
+// address hashes the same however it was typed.
+func (r *redactor) hash(value string) string {
+	mac := hmac.New(sha256.New, r.key)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(value))))
+	return "h:" + hex.EncodeToString(mac.Sum(nil))[:hashLength]
+}
+
+// mask keeps the first character of a value, or of the local part of an email address
+// along with its domain
+func mask(value string) string {
+	local, domain, ok := strings.Cut(value, "@")
+	if !ok {
+		return maskText(value)
+	}
+	return maskText(local) + "@" + domain
+}
+
+// maskText keeps the first character of a value
+func maskText(value string) string {
+	first, _ := utf8.DecodeRuneInString(value)
+	return string(first) + "***"
+}
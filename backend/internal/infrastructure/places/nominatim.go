@@ -0,0 +1,140 @@
+package places
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+)
+
+// nominatimInterval is the shortest time between two requests, as the usage policy of
+// the public Nominatim instance allows one request per second
+const nominatimInterval = time.Second
+
+// NominatimProvider searches places with the Nominatim API of OpenStreetMap
+type NominatimProvider struct {
+	baseURL    string
+	userAgent  string
+	httpClient *http.Client
+
+	mu   sync.Mutex
+	next time.Time // when the next request may be sent
+}
+
+// NewNominatimProvider creates a provider for the Nominatim API at baseURL. Requests
+// are identified by userAgent, which the usage policy requires.
+func NewNominatimProvider(baseURL, userAgent string) *NominatimProvider {
+	return &NominatimProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		userAgent:  userAgent,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// nominatimPlace is a search result of the Nominatim API
+type nominatimPlace struct {
+	OSMType     string `json:"osm_type"`
+	OSMID       int64  `json:"osm_id"`
+	Lat         string `json:"lat"`
+	Lon         string `json:"lon"`
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+}
+
+// SearchPlaces looks up the places matching query, with names in lang
+func (p *NominatimProvider) SearchPlaces(ctx context.Context, query, lang string, limit int) ([]*domain.Place, error) {
+	if err := p.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("format", "jsonv2")
+	params.Set("limit", strconv.Itoa(limit))
+	if lang != "" {
+		params.Set("accept-language", lang)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.baseURL+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create places request: %w", err)
+	}
+	req.Header.Set("User-Agent", p.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach Nominatim: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Nominatim returned status %d", resp.StatusCode)
+	}
+
+	var results []nominatimPlace
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode Nominatim response: %w", err)
+	}
+
+	places := make([]*domain.Place, 0, len(results))
+	for _, result := range results {
+		latitude, err := strconv.ParseFloat(result.Lat, 64)
+		if err != nil {
+			continue
+		}
+		longitude, err := strconv.ParseFloat(result.Lon, 64)
+		if err != nil {
+			continue
+		}
+
+		name := result.Name
+		if name == "" {
+			name, _, _ = strings.Cut(result.DisplayName, ",")
+		}
+
+		places = append(places, &domain.Place{
+			Name:       name,
+			Address:    result.DisplayName,
+			Latitude:   latitude,
+			Longitude:  longitude,
+			ProviderID: fmt.Sprintf("osm:%s/%d", result.OSMType, result.OSMID),
+		})
+	}
+	return places, nil
+}
+
+// wait blocks until a request may be sent without exceeding the rate of the usage
+// policy, or until ctx is done
+func (p *NominatimProvider) wait(ctx context.Context) error {
+	p.mu.Lock()
+	now := time.Now()
+	at := p.next
+	if at.Before(now) {
+		at = now
+	}
+	p.next = at.Add(nominatimInterval)
+	p.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,30 @@
+// Package places looks up places for location autocomplete with a geocoding API.
+package places
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eralove/eralove-backend/internal/config"
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+// Providers that can be configured
+const (
+	ProviderNominatim = "nominatim"
+)
+
+// NewProvider creates the configured places provider, or returns nil when place
+// search is disabled
+func NewProvider(cfg *config.Config, logger *zap.Logger) (domain.PlaceProvider, error) {
+	switch name := strings.ToLower(cfg.PlacesProvider); name {
+	case "":
+		return nil, nil
+	case ProviderNominatim:
+		logger.Info("Searching places with Nominatim", zap.String("url", cfg.PlacesURL))
+		return NewNominatimProvider(cfg.PlacesURL, cfg.PlacesUserAgent), nil
+	default:
+		return nil, fmt.Errorf("unsupported places provider: %s", name)
+	}
+}
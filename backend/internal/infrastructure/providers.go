@@ -1,14 +1,29 @@
 package infrastructure
 
 import (
+	"fmt"
+	"os"
+	"time"
+
 	"github.com/eralove/eralove-backend/internal/config"
 	"github.com/eralove/eralove-backend/internal/domain"
+	grpcserver "github.com/eralove/eralove-backend/internal/grpc/server"
 	"github.com/eralove/eralove-backend/internal/infrastructure/auth"
 	"github.com/eralove/eralove-backend/internal/infrastructure/cache"
 	"github.com/eralove/eralove-backend/internal/infrastructure/database"
+	"github.com/eralove/eralove-backend/internal/infrastructure/directus"
 	"github.com/eralove/eralove-backend/internal/infrastructure/email"
+	"github.com/eralove/eralove-backend/internal/infrastructure/eventbus"
 	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/eralove/eralove-backend/internal/infrastructure/kms"
+	"github.com/eralove/eralove-backend/internal/infrastructure/origins"
+	"github.com/eralove/eralove-backend/internal/infrastructure/places"
+	"github.com/eralove/eralove-backend/internal/infrastructure/push"
+	"github.com/eralove/eralove-backend/internal/infrastructure/scanner"
+	"github.com/eralove/eralove-backend/internal/infrastructure/scheduler"
+	"github.com/eralove/eralove-backend/internal/infrastructure/secrets"
 	"github.com/eralove/eralove-backend/internal/infrastructure/storage"
+	"github.com/eralove/eralove-backend/internal/infrastructure/webhook"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/wire"
 	"go.uber.org/zap"
@@ -20,15 +35,36 @@ var InfrastructureSet = wire.NewSet(
 	ProvideI18n,
 	ProvidePasswordManager,
 	ProvideJWTManager,
+	ProvideJWTSecretReloader,
+	ProvideGRPCServer,
+	ProvideEmailSender,
+	ProvideEmailQueue,
 	ProvideEmailService,
 	ProvideMongoDB,
 	ProvideRedis,
 	ProvideStorageService,
+	ProvideScheduler,
+	ProvideFeedbackMirror,
+	ProvideReleaseSource,
+	ProvideContentSource,
+	ProvideQuestionSource,
+	ProvideDateIdeaSource,
+	ProvideKeyManager,
+	ProvideIDTokenSigner,
+	ProvideOriginRegistry,
+	ProvidePushRenderer,
+	ProvideFileScanner,
+	ProvideWebhookDispatcher,
+	ProvideEventBus,
+	ProvideEventPublisher,
+	ProvidePlaceProvider,
 )
 
-// ProvideValidator provides a validator instance
+// ProvideValidator provides a validator instance that understands domain.Date fields
 func ProvideValidator() *validator.Validate {
-	return validator.New()
+	v := validator.New()
+	v.RegisterCustomTypeFunc(domain.DateValue, domain.Date{})
+	return v
 }
 
 // ProvideI18n provides an i18n service
@@ -67,7 +103,33 @@ func ProvidePasswordManager() *auth.PasswordManager {
 
 // ProvideJWTManager provides a JWT manager
 func ProvideJWTManager(cfg *config.Config) *auth.JWTManager {
-	return auth.NewJWTManager(cfg.JWTSecret, cfg.JWTAccessExpiration, cfg.JWTRefreshExpiration)
+	return auth.NewJWTManager(cfg.JWTSecret, cfg.JWTLegacySecret, cfg.JWTPreviousSecrets, cfg.JWTAccessExpiration, cfg.JWTRefreshExpiration)
+}
+
+// ProvideJWTSecretReloader provides the reloader that picks up a rotated JWT secret in
+// the secret manager, or nil when the secret comes from the environment
+func ProvideJWTSecretReloader(cfg *config.Config, jwtManager *auth.JWTManager, logger *zap.Logger) (*secrets.Reloader, error) {
+	if cfg.JWTSecretRef == "" {
+		return nil, nil
+	}
+
+	provider, err := cfg.SecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	reloader := secrets.NewReloader(provider, cfg.JWTSecretRef, cfg.JWTSecret, jwtManager.RotateKey, logger)
+	// Pick up a secret another instance rotated to as soon as a token signed with it comes in
+	jwtManager.SetKeyReloader(reloader.Reload)
+	return reloader, nil
+}
+
+// ProvideGRPCServer provides the server of the internal gRPC API, nil when no service
+// token is configured
+func ProvideGRPCServer(cfg *config.Config, userService domain.UserService, photoService domain.PhotoService, eventService domain.EventService, logger *zap.Logger) *grpcserver.Server {
+	if len(cfg.GRPCServiceTokens) == 0 {
+		return nil
+	}
+	return grpcserver.New(cfg.GRPCServiceTokens, userService, photoService, eventService, logger)
 }
 
 // ProvideMongoDB provides a MongoDB connection
@@ -80,9 +142,24 @@ func ProvideRedis(cfg *config.Config, logger *zap.Logger) (*cache.Redis, error)
 	return cache.NewRedis(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB, logger)
 }
 
-// ProvideEmailService provides an email service
-func ProvideEmailService(cfg *config.Config, logger *zap.Logger) *email.EmailService {
-	return email.NewEmailService(cfg, logger)
+// ProvideEmailSender provides the sender of the configured email provider
+func ProvideEmailSender(cfg *config.Config, logger *zap.Logger) (email.EmailSender, error) {
+	return email.NewSender(cfg, logger)
+}
+
+// ProvideEmailQueue provides the queue emails are sent through in the background
+func ProvideEmailQueue(cfg *config.Config, sender email.EmailSender, logger *zap.Logger) *email.Queue {
+	return email.NewQueue(sender, email.QueueConfig{
+		Workers:     cfg.EmailQueueWorkers,
+		Size:        cfg.EmailQueueSize,
+		MaxAttempts: cfg.EmailMaxAttempts,
+		RetryDelay:  time.Duration(cfg.EmailRetryDelay) * time.Second,
+	}, logger)
+}
+
+// ProvideEmailService provides an email service that queues its emails
+func ProvideEmailService(cfg *config.Config, queue *email.Queue, i18nService *i18n.I18n, logger *zap.Logger) *email.EmailService {
+	return email.NewEmailService(cfg, queue, i18nService, logger)
 }
 
 // ProvideStorageService provides a storage service
@@ -105,3 +182,155 @@ func ProvideStorageService(cfg *config.Config, logger *zap.Logger) (domain.Stora
 	// Create and return storage service
 	return factory.CreateStorage(storageConfig)
 }
+
+// ProvideScheduler provides the background job scheduler
+func ProvideScheduler(logger *zap.Logger) *scheduler.Scheduler {
+	return scheduler.NewScheduler(logger)
+}
+
+// ProvideFeedbackMirror provides the Directus mirror for user feedback, or nil when
+// Directus is not configured
+func ProvideFeedbackMirror(cfg *config.Config, logger *zap.Logger) domain.FeedbackMirror {
+	if cfg.DirectusURL == "" {
+		return nil
+	}
+	return newDirectusClient(cfg, logger)
+}
+
+// ProvideReleaseSource provides the Directus changelog collection as the source of
+// release notes, or nil when Directus is not configured
+func ProvideReleaseSource(cfg *config.Config, logger *zap.Logger) domain.ReleaseSource {
+	if cfg.DirectusURL == "" {
+		return nil
+	}
+	return newDirectusClient(cfg, logger)
+}
+
+// ProvideContentSource provides the Directus content collection as the source of the
+// app's editable copy, or nil when Directus is not configured
+func ProvideContentSource(cfg *config.Config, logger *zap.Logger) domain.ContentSource {
+	if cfg.DirectusURL == "" {
+		return nil
+	}
+	return newDirectusClient(cfg, logger)
+}
+
+// ProvideQuestionSource provides the Directus question collection as the source of
+// daily questions, or nil when Directus is not configured
+func ProvideQuestionSource(cfg *config.Config, logger *zap.Logger) domain.QuestionSource {
+	if cfg.DirectusURL == "" {
+		return nil
+	}
+	return newDirectusClient(cfg, logger)
+}
+
+// ProvideDateIdeaSource provides the Directus date idea collection as the source of
+// date night suggestions, or nil when Directus is not configured
+func ProvideDateIdeaSource(cfg *config.Config, logger *zap.Logger) domain.DateIdeaSource {
+	if cfg.DirectusURL == "" {
+		return nil
+	}
+	return newDirectusClient(cfg, logger)
+}
+
+// ProvideKeyManager provides the master keys that wrap the couples' data keys, or nil
+// when no master key is configured
+func ProvideKeyManager(cfg *config.Config, logger *zap.Logger) (domain.KeyManager, error) {
+	if len(cfg.EncryptionMasterKeys) == 0 {
+		logger.Warn("No encryption master key configured, couple data cannot be encrypted")
+		return nil, nil
+	}
+
+	keyManager, err := kms.NewLocalKeyManager(cfg.EncryptionMasterKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ENCRYPTION_MASTER_KEYS: %w", err)
+	}
+	return keyManager, nil
+}
+
+// ProvideIDTokenSigner provides the key that signs OpenID Connect ID tokens, or nil
+// when the OpenID Connect provider is disabled
+func ProvideIDTokenSigner(cfg *config.Config, logger *zap.Logger) (*auth.IDTokenSigner, error) {
+	if cfg.OIDCIssuer == "" {
+		return nil, nil
+	}
+
+	if cfg.OIDCSigningKeyFile == "" {
+		logger.Warn("No OIDC signing key configured, using a temporary key; ID tokens stop verifying after a restart")
+		return auth.GenerateIDTokenSigner()
+	}
+
+	pemData, err := os.ReadFile(cfg.OIDCSigningKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OIDC_SIGNING_KEY_FILE: %w", err)
+	}
+
+	signer, err := auth.NewIDTokenSigner(pemData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid OIDC_SIGNING_KEY_FILE: %w", err)
+	}
+	return signer, nil
+}
+
+// newDirectusClient creates a Directus client from the configuration
+func newDirectusClient(cfg *config.Config, logger *zap.Logger) *directus.Client {
+	return directus.NewClient(cfg.DirectusURL, cfg.DirectusToken, cfg.DirectusFeedbackCollection, cfg.DirectusChangelogCollection, cfg.DirectusContentCollection, cfg.DirectusQuestionCollection, cfg.DirectusDateIdeaCollection, logger)
+}
+
+// ProvideOriginRegistry provides the origins allowed to make cross-origin requests
+func ProvideOriginRegistry(cfg *config.Config) (*origins.Registry, error) {
+	return origins.NewRegistry(cfg.CORSOrigins, cfg.CORSOriginsFile)
+}
+
+// ProvidePushRenderer provides the renderer of localized notification texts
+func ProvidePushRenderer(i18nService *i18n.I18n) *push.Renderer {
+	return push.NewRenderer(i18nService)
+}
+
+// ProvideFileScanner provides the scanner of uploaded files, or nil when scanning is
+// disabled
+func ProvideFileScanner(cfg *config.Config, logger *zap.Logger) (domain.FileScanner, error) {
+	return scanner.NewScanner(cfg, logger)
+}
+
+// ProvideWebhookDispatcher provides the dispatcher that POSTs domain events to the
+// configured webhooks, or nil when no webhook is configured
+func ProvideWebhookDispatcher(cfg *config.Config, logger *zap.Logger) *webhook.Dispatcher {
+	if len(cfg.WebhookURLs) == 0 {
+		return nil
+	}
+	return webhook.NewDispatcher(webhook.Config{
+		URLs:        cfg.WebhookURLs,
+		Secret:      cfg.WebhookSecret,
+		Workers:     cfg.WebhookWorkers,
+		Size:        cfg.WebhookQueueSize,
+		MaxAttempts: cfg.WebhookMaxAttempts,
+		RetryDelay:  time.Duration(cfg.WebhookRetryDelay) * time.Second,
+		Timeout:     time.Duration(cfg.WebhookTimeout) * time.Second,
+	}, logger)
+}
+
+// ProvideEventBus provides the bus domain events are published to, with the webhook
+// dispatcher subscribed to the configured event types
+func ProvideEventBus(cfg *config.Config, dispatcher *webhook.Dispatcher, logger *zap.Logger) *eventbus.Bus {
+	bus := eventbus.NewBus(logger)
+	if dispatcher != nil {
+		types := make([]domain.DomainEventType, 0, len(cfg.WebhookEvents))
+		for _, eventType := range cfg.WebhookEvents {
+			types = append(types, domain.DomainEventType(eventType))
+		}
+		bus.Subscribe(dispatcher.Handle, types...)
+	}
+	return bus
+}
+
+// ProvideEventPublisher provides the event bus as the publisher services use
+func ProvideEventPublisher(bus *eventbus.Bus) domain.EventPublisher {
+	return bus
+}
+
+// ProvidePlaceProvider provides the geocoding API places are searched with, or nil when
+// place search is disabled
+func ProvidePlaceProvider(cfg *config.Config, logger *zap.Logger) (domain.PlaceProvider, error) {
+	return places.NewProvider(cfg, logger)
+}
@@ -0,0 +1,110 @@
+package push
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+)
+
+// Platform is where a notification is displayed
+type Platform string
+
+const (
+	PlatformInApp   Platform = "in_app"
+	PlatformAPNs    Platform = "apns"
+	PlatformFCM     Platform = "fcm"
+	PlatformWebPush Platform = "web_push"
+)
+
+// ellipsis marks a truncated title or body
+const ellipsis = "…"
+
+// Limits are the longest title and body, in characters, a platform displays
+// without cutting them off itself
+type Limits struct {
+	Title int
+	Body  int
+}
+
+// platformLimits follow what lock screens and browsers show before truncating;
+// the in-app limits only keep the notification list readable
+var platformLimits = map[Platform]Limits{
+	PlatformInApp:   {Title: 120, Body: 500},
+	PlatformAPNs:    {Title: 50, Body: 178},
+	PlatformFCM:     {Title: 65, Body: 240},
+	PlatformWebPush: {Title: 50, Body: 120},
+}
+
+// Payload is a notification rendered for one user on one platform
+type Payload struct {
+	Title string
+	Body  string
+}
+
+// Renderer renders notification templates from the i18n messages. A template with
+// key K uses the messages notification_K_title and notification_K_body.
+type Renderer struct {
+	i18n *i18n.I18n
+}
+
+// NewRenderer creates a new notification renderer
+func NewRenderer(i18n *i18n.I18n) *Renderer {
+	return &Renderer{i18n: i18n}
+}
+
+// Render translates a template in locale and truncates it to the limits of platform.
+// Params of type domain.NotificationMessage are translated before they are
+// interpolated.
+func (r *Renderer) Render(locale string, platform Platform, tmpl domain.NotificationTemplate) Payload {
+	params := make(map[string]interface{}, len(tmpl.Params))
+	for name, value := range tmpl.Params {
+		if message, ok := value.(domain.NotificationMessage); ok {
+			value = r.i18n.Translate(locale, string(message), nil)
+		}
+		params[name] = value
+	}
+
+	limits, ok := platformLimits[platform]
+	if !ok {
+		limits = platformLimits[PlatformInApp]
+	}
+
+	prefix := "notification_" + tmpl.Key
+	return Payload{
+		Title: Truncate(r.i18n.Translate(locale, prefix+"_title", params), limits.Title),
+		Body:  Truncate(r.i18n.Translate(locale, prefix+"_body", params), limits.Body),
+	}
+}
+
+// Truncate shortens text to at most max characters, cutting at the last space when
+// there is one close enough and ending with an ellipsis
+func Truncate(text string, max int) string {
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+
+	cut := text[:runeOffset(text, max-utf8.RuneCountInString(ellipsis))]
+	if i := strings.LastIndexAny(cut, " \n\t"); i > len(cut)*3/4 {
+		cut = cut[:i]
+	}
+
+	return strings.TrimRight(cut, " \n\t.,;:") + ellipsis
+}
+
+// runeOffset returns the byte offset of the nth character of text
+func runeOffset(text string, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	count := 0
+	for i := range text {
+		if count == n {
+			return i
+		}
+		count++
+	}
+	return len(text)
+}
@@ -0,0 +1,407 @@
+package qrcode
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// goldenCases cover a symbol without alignment patterns, one with a single alignment
+// pattern, one with version information and one with blocks of two sizes and a
+// 16-bit character count
+var goldenCases = []struct {
+	name    string
+	data    string
+	version int
+}{
+	{"version1", "ERALOVE-7KQ2", 1},
+	{"version4", "https://eralove.app/invite/7KQ2M9XP?source=qr&medium=app", 4},
+	{"version7", "https://eralove.app/invite/7KQ2M9XP?utm_source=qr&utm_medium=app&utm_campaign=partner-link&lang=en&ref=" + strings.Repeat("x", 12), 7},
+	{"version10", "https://eralove.app/invite/7KQ2M9XP?ref=" + strings.Repeat("0123456789", 16), 10},
+}
+
+// formatBitsM are the format information sequences of level M for masks 0 to 7,
+// from ISO/IEC 18004 table C.1
+var formatBitsM = [8]int{0x5412, 0x5125, 0x5E7C, 0x5B4B, 0x45F9, 0x40CE, 0x4F97, 0x4AA0}
+
+// versionBits are the version information sequences of versions 7 to 10, from
+// ISO/IEC 18004 table D.1
+var versionBits = map[int]int{7: 0x07C94, 8: 0x085BC, 9: 0x09A99, 10: 0x0A4D3}
+
+// blocksM lists, per version, the number of codewords of each block at level M as
+// (total, data) pairs, from ISO/IEC 18004 table 9
+var blocksM = map[int][][2]int{
+	1:  {{26, 16}},
+	2:  {{44, 28}},
+	3:  {{70, 44}},
+	4:  {{50, 32}, {50, 32}},
+	5:  {{67, 43}, {67, 43}},
+	6:  {{43, 27}, {43, 27}, {43, 27}, {43, 27}},
+	7:  {{49, 31}, {49, 31}, {49, 31}, {49, 31}},
+	8:  {{60, 38}, {60, 38}, {61, 39}, {61, 39}},
+	9:  {{58, 36}, {58, 36}, {58, 36}, {59, 37}, {59, 37}},
+	10: {{69, 43}, {69, 43}, {69, 43}, {69, 43}, {70, 44}},
+}
+
+// masks are the data mask conditions of ISO/IEC 18004 table 10, with i the row and
+// j the column
+var masks = [8]func(i, j int) bool{
+	func(i, j int) bool { return (i+j)%2 == 0 },
+	func(i, j int) bool { return i%2 == 0 },
+	func(i, j int) bool { return j%3 == 0 },
+	func(i, j int) bool { return (i+j)%3 == 0 },
+	func(i, j int) bool { return (i/2+j/3)%2 == 0 },
+	func(i, j int) bool { return i*j%2+i*j%3 == 0 },
+	func(i, j int) bool { return (i*j%2+i*j%3)%2 == 0 },
+	func(i, j int) bool { return ((i*j)%3+(i+j)%2)%2 == 0 },
+}
+
+func TestEncodeGolden(t *testing.T) {
+	for _, tc := range goldenCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := Encode([]byte(tc.data))
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+
+			got := render(code)
+			path := filepath.Join("testdata", tc.name+".golden")
+			if *update {
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatalf("write golden file: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read golden file: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("symbol differs from %s:\n%s", path, got)
+			}
+		})
+	}
+}
+
+// TestEncodeDecodes reads the symbols back with a decoder written from the standard,
+// so the golden files are known to hold valid symbols
+func TestEncodeDecodes(t *testing.T) {
+	for _, tc := range goldenCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := Encode([]byte(tc.data))
+			if err != nil {
+				t.Fatalf("Encode: %v", err)
+			}
+
+			if want := 17 + 4*tc.version; code.Size() != want {
+				t.Fatalf("size = %d, want %d (version %d)", code.Size(), want, tc.version)
+			}
+
+			if got := decode(t, code, tc.version); got != tc.data {
+				t.Errorf("decoded %q, want %q", got, tc.data)
+			}
+		})
+	}
+}
+
+func TestEncodeTooLong(t *testing.T) {
+	if _, err := Encode(make([]byte, byteCapacity(maxVersion)+1)); err == nil {
+		t.Error("Encode accepted data longer than version 10 holds")
+	}
+}
+
+// render draws a symbol as text, one row per line
+func render(code *Code) []byte {
+	var buf bytes.Buffer
+	for y := 0; y < code.Size(); y++ {
+		for x := 0; x < code.Size(); x++ {
+			if code.Dark(x, y) {
+				buf.WriteByte('#')
+			} else {
+				buf.WriteByte('.')
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// decode checks the patterns, format and version information of a symbol, corrects
+// nothing but verifies every block against its error correction codewords, and
+// returns the byte mode payload
+func decode(t *testing.T, code *Code, version int) string {
+	t.Helper()
+	size := code.Size()
+	dark := func(x, y int) bool { return code.Dark(x, y) }
+
+	// Finder patterns
+	for _, corner := range [][2]int{{0, 0}, {size - 7, 0}, {0, size - 7}} {
+		for dy := 0; dy < 7; dy++ {
+			for dx := 0; dx < 7; dx++ {
+				ring := max(abs(dx-3), abs(dy-3))
+				if want := ring != 2; dark(corner[0]+dx, corner[1]+dy) != want {
+					t.Fatalf("finder pattern at %v is wrong at (%d, %d)", corner, dx, dy)
+				}
+			}
+		}
+	}
+
+	// Timing patterns
+	for i := 8; i < size-8; i++ {
+		if dark(i, 6) != (i%2 == 0) || dark(6, i) != (i%2 == 0) {
+			t.Fatalf("timing pattern is wrong at %d", i)
+		}
+	}
+
+	// Format information, both copies
+	var first, second int
+	firstPositions := [][2]int{{8, 0}, {8, 1}, {8, 2}, {8, 3}, {8, 4}, {8, 5}, {8, 7}, {8, 8}, {7, 8}, {5, 8}, {4, 8}, {3, 8}, {2, 8}, {1, 8}, {0, 8}}
+	for i, p := range firstPositions {
+		if dark(p[0], p[1]) {
+			first |= 1 << i
+		}
+	}
+	for i := 0; i < 8; i++ {
+		if dark(size-1-i, 8) {
+			second |= 1 << i
+		}
+	}
+	for i := 8; i < 15; i++ {
+		if dark(8, size-15+i) {
+			second |= 1 << i
+		}
+	}
+	if first != second {
+		t.Fatalf("format information copies differ: %015b and %015b", first, second)
+	}
+	mask := -1
+	for m, bits := range formatBitsM {
+		if bits == first {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		t.Fatalf("format information %015b is not level M", first)
+	}
+	if !dark(8, size-8) {
+		t.Fatal("dark module is light")
+	}
+
+	// Version information, both copies
+	if version >= 7 {
+		var below, right int
+		for i := 0; i < 18; i++ {
+			if dark(i/3, size-11+i%3) {
+				below |= 1 << i
+			}
+			if dark(size-11+i%3, i/3) {
+				right |= 1 << i
+			}
+		}
+		if below != versionBits[version] || right != versionBits[version] {
+			t.Fatalf("version information is %018b and %018b, want %018b", below, right, versionBits[version])
+		}
+	}
+
+	// Read the codewords in zigzag order, unmasking the data modules
+	reserved := functionModules(version)
+	var bits []bool
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := ((size-1-right)/2)%2 == 0
+		if right < 6 {
+			upward = ((size-2-right)/2)%2 == 0
+		}
+		for vert := 0; vert < size; vert++ {
+			y := vert
+			if upward {
+				y = size - 1 - vert
+			}
+			for x := right; x > right-2; x-- {
+				if reserved[y][x] {
+					continue
+				}
+				bits = append(bits, dark(x, y) != masks[mask](y, x))
+			}
+		}
+	}
+	codewords := make([]byte, len(bits)/8)
+	for i := range codewords {
+		for j := 0; j < 8; j++ {
+			if bits[i*8+j] {
+				codewords[i] |= 1 << (7 - j)
+			}
+		}
+	}
+
+	// De-interleave the blocks and check each one
+	blocks := blocksM[version]
+	total := 0
+	for _, b := range blocks {
+		total += b[0]
+	}
+	if len(codewords) != total {
+		t.Fatalf("symbol holds %d codewords, want %d", len(codewords), total)
+	}
+	data := make([][]byte, len(blocks))
+	ec := make([][]byte, len(blocks))
+	next := 0
+	for i := 0; ; i++ {
+		placed := false
+		for b, block := range blocks {
+			if i < block[1] {
+				data[b] = append(data[b], codewords[next])
+				next++
+				placed = true
+			}
+		}
+		if !placed {
+			break
+		}
+	}
+	ecPerBlock := blocks[0][0] - blocks[0][1]
+	for i := 0; i < ecPerBlock; i++ {
+		for b := range blocks {
+			ec[b] = append(ec[b], codewords[next])
+			next++
+		}
+	}
+	var payload []byte
+	for b := range blocks {
+		block := append(append([]byte{}, data[b]...), ec[b]...)
+		for i := 0; i < ecPerBlock; i++ {
+			if s := syndrome(block, i); s != 0 {
+				t.Fatalf("block %d has syndrome %d = %d", b, i, s)
+			}
+		}
+		payload = append(payload, data[b]...)
+	}
+
+	// Parse the byte mode segment, terminator and padding
+	reader := bitReader{data: payload}
+	if m := reader.read(4); m != 0b0100 {
+		t.Fatalf("mode = %04b, want byte mode", m)
+	}
+	countBits := 8
+	if version >= 10 {
+		countBits = 16
+	}
+	length := reader.read(countBits)
+	text := make([]byte, length)
+	for i := range text {
+		text[i] = byte(reader.read(8))
+	}
+	if rest := len(payload)*8 - reader.pos; rest >= 4 {
+		if terminator := reader.read(4); terminator != 0 {
+			t.Fatalf("terminator = %04b", terminator)
+		}
+	}
+	reader.pos = (reader.pos + 7) / 8 * 8
+	for i, pad := 0, 0xEC; reader.pos < len(payload)*8; i, pad = i+1, pad^0xEC^0x11 {
+		if got := reader.read(8); got != pad {
+			t.Fatalf("pad codeword %d = %#x, want %#x", i, got, pad)
+		}
+	}
+
+	return string(text)
+}
+
+// functionModules marks the modules of the function patterns and of the format and
+// version information, which hold no data
+func functionModules(version int) [][]bool {
+	size := 17 + 4*version
+	reserved := make([][]bool, size)
+	for y := range reserved {
+		reserved[y] = make([]bool, size)
+	}
+	fill := func(x0, y0, w, h int) {
+		for y := y0; y < y0+h; y++ {
+			for x := x0; x < x0+w; x++ {
+				reserved[y][x] = true
+			}
+		}
+	}
+
+	// Finder patterns with their separators and format information
+	fill(0, 0, 9, 9)
+	fill(size-8, 0, 8, 9)
+	fill(0, size-8, 9, 8)
+	// Timing patterns
+	fill(6, 0, 1, size)
+	fill(0, 6, size, 1)
+	// Alignment patterns, centred on the combinations of the positions of table E.1
+	// except the three corners of the finder patterns
+	centres := map[int][]int{
+		2: {6, 18}, 3: {6, 22}, 4: {6, 26}, 5: {6, 30}, 6: {6, 34},
+		7: {6, 22, 38}, 8: {6, 24, 42}, 9: {6, 26, 46}, 10: {6, 28, 50},
+	}[version]
+	for _, cx := range centres {
+		for _, cy := range centres {
+			if (cx == 6 && cy == 6) || (cx == 6 && cy == size-7) || (cx == size-7 && cy == 6) {
+				continue
+			}
+			fill(cx-2, cy-2, 5, 5)
+		}
+	}
+	// Version information
+	if version >= 7 {
+		fill(size-11, 0, 3, 6)
+		fill(0, size-11, 6, 3)
+	}
+	return reserved
+}
+
+// syndrome evaluates a block, as a polynomial with its first codeword as the highest
+// power, at alpha^i. Every syndrome of a valid block is zero.
+func syndrome(block []byte, i int) byte {
+	exp, log := gfTables()
+	x := exp[i]
+	var result byte
+	for _, coef := range block {
+		// result = result*x + coef
+		if result != 0 {
+			result = exp[(int(log[result])+int(log[x]))%255]
+		}
+		result ^= coef
+	}
+	return result
+}
+
+// gfTables returns the exponent and logarithm tables of GF(2^8) with the primitive
+// polynomial x^8 + x^4 + x^3 + x^2 + 1
+func gfTables() (exp [256]byte, log [256]byte) {
+	v := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(v)
+		log[v] = byte(i)
+		v <<= 1
+		if v&0x100 != 0 {
+			v ^= 0x11D
+		}
+	}
+	exp[255] = exp[0]
+	return exp, log
+}
+
+// bitReader reads bits from bytes, most significant first
+type bitReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *bitReader) read(n int) int {
+	value := 0
+	for i := 0; i < n; i++ {
+		bit := (r.data[r.pos/8] >> (7 - r.pos%8)) & 1
+		value = value<<1 | int(bit)
+		r.pos++
+	}
+	return value
+}
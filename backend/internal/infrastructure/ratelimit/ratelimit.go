@@ -0,0 +1,113 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/infrastructure/cache"
+)
+
+// Store counts requests per key. Counts expire on their own after the given window.
+type Store interface {
+	Increment(ctx context.Context, key string, window time.Duration) (int64, error)
+}
+
+// Limiter allows a number of requests per key in fixed windows. Windows are aligned to
+// the clock, so every instance sharing a store counts in the same window.
+type Limiter struct {
+	store  Store
+	name   string
+	limit  int
+	window time.Duration
+}
+
+// NewLimiter creates a limiter that allows limit requests per window. Limiters sharing
+// a store count apart as long as their names differ.
+func NewLimiter(store Store, name string, limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		store:  store,
+		name:   name,
+		limit:  limit,
+		window: window,
+	}
+}
+
+// Allow counts a request for key and reports whether it is within the limit. When it
+// is not, retryAfter is the time left until the window ends.
+func (l *Limiter) Allow(ctx context.Context, key string) (allowed bool, retryAfter time.Duration, err error) {
+	now := time.Now()
+	start := now.Truncate(l.window)
+
+	count, err := l.store.Increment(ctx, "ratelimit:"+l.name+":"+key+":"+strconv.FormatInt(start.Unix(), 10), l.window)
+	if err != nil {
+		return true, 0, fmt.Errorf("failed to count request: %w", err)
+	}
+
+	if count <= int64(l.limit) {
+		return true, 0, nil
+	}
+	return false, start.Add(l.window).Sub(now), nil
+}
+
+// RedisStore counts requests in Redis, shared by all instances
+type RedisStore struct {
+	cache cache.Cache
+}
+
+// NewRedisStore creates a new Redis request counter
+func NewRedisStore(cache cache.Cache) Store {
+	return &RedisStore{cache: cache}
+}
+
+// Increment counts a request for key
+func (s *RedisStore) Increment(ctx context.Context, key string, window time.Duration) (int64, error) {
+	return s.cache.IncrementWithExpiration(ctx, key, window)
+}
+
+// memoryCount is a request count held by MemoryStore
+type memoryCount struct {
+	count     int64
+	expiresAt time.Time
+}
+
+// MemoryStore counts requests in memory, for deployments without Redis. Each instance
+// then enforces the limit on its own.
+type MemoryStore struct {
+	mu        sync.Mutex
+	counts    map[string]*memoryCount
+	lastSweep time.Time
+}
+
+// NewMemoryStore creates a new in-memory request counter
+func NewMemoryStore() Store {
+	return &MemoryStore{counts: make(map[string]*memoryCount)}
+}
+
+// Increment counts a request for key. Expired counts are dropped at most once per
+// second, as every request goes through here.
+func (s *MemoryStore) Increment(ctx context.Context, key string, window time.Duration) (int64, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= time.Second {
+		for k, c := range s.counts {
+			if !now.Before(c.expiresAt) {
+				delete(s.counts, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	c, ok := s.counts[key]
+	if !ok || !now.Before(c.expiresAt) {
+		c = &memoryCount{expiresAt: now.Add(window)}
+		s.counts[key] = c
+	}
+	c.count++
+	return c.count, nil
+}
@@ -0,0 +1,133 @@
+package sanitize
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+)
+
+// Text trims surrounding white space and removes control characters, keeping line
+// breaks and tabs. Windows line breaks become "\n".
+func Text(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
+}
+
+// Email sanitizes an email address and lowercases it, as addresses are compared
+// without case
+func Email(s string) string {
+	return strings.ToLower(Text(s))
+}
+
+// HTML turns free text that may contain markup into plain text: tags and comments are
+// removed, along with the content of script and style elements. Text is kept as
+// written, entities included, so it cannot become markup when served back.
+func HTML(s string) string {
+	if !strings.ContainsAny(s, "<>") {
+		return Text(s)
+	}
+
+	var b strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(s))
+	skipping := ""
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			// The end of the text, or markup that cannot be read, which is dropped
+			return Text(b.String())
+		case html.TextToken:
+			if skipping == "" {
+				b.Write(tokenizer.Raw())
+			}
+		case html.StartTagToken:
+			name, _ := tokenizer.TagName()
+			if tag := string(name); skipping == "" && (tag == "script" || tag == "style") {
+				skipping = tag
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if string(name) == skipping {
+				skipping = ""
+			}
+		}
+	}
+}
+
+// JSON sanitizes the string values of a JSON document with Text, and those of email
+// fields with Email. Fields for which keep reports true are left as they are, as are
+// documents that are not objects or arrays. It reports whether anything changed.
+func JSON(body []byte, keep func(field string) bool) ([]byte, bool, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 || (body[0] != '{' && body[0] != '[') {
+		return body, false, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return body, false, err
+	}
+
+	doc, changed := sanitizeValue("", doc, keep)
+	if !changed {
+		return body, false, nil
+	}
+
+	var out bytes.Buffer
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return body, false, err
+	}
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n")), true, nil
+}
+
+// sanitizeValue sanitizes the strings in a decoded JSON value held by field
+func sanitizeValue(field string, value interface{}, keep func(field string) bool) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		if keep != nil && keep(field) {
+			return v, false
+		}
+		sanitized := Text(v)
+		if field == "email" || strings.HasSuffix(field, "_email") {
+			sanitized = Email(v)
+		}
+		return sanitized, sanitized != v
+	case map[string]interface{}:
+		changed := false
+		for key, item := range v {
+			sanitized, itemChanged := sanitizeValue(key, item, keep)
+			if itemChanged {
+				v[key] = sanitized
+				changed = true
+			}
+		}
+		return v, changed
+	case []interface{}:
+		changed := false
+		for i, item := range v {
+			sanitized, itemChanged := sanitizeValue(field, item, keep)
+			if itemChanged {
+				v[i] = sanitized
+				changed = true
+			}
+		}
+		return v, changed
+	default:
+		return value, false
+	}
+}
@@ -0,0 +1,87 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+)
+
+// clamAVChunkSize is the size of the chunks a file is streamed to clamd in
+const clamAVChunkSize = 64 * 1024
+
+// ClamAVScanner scans files with a clamd daemon, streaming them with the INSTREAM
+// command. Files larger than the daemon's StreamMaxLength cannot be scanned.
+type ClamAVScanner struct {
+	addr   string
+	dialer net.Dialer
+}
+
+// NewClamAVScanner creates a scanner for the clamd daemon listening on addr, as
+// host:port
+func NewClamAVScanner(addr string) *ClamAVScanner {
+	return &ClamAVScanner{addr: addr}
+}
+
+// Scan streams the file to clamd and reports what it found
+func (s *ClamAVScanner) Scan(ctx context.Context, file io.Reader) (*domain.ScanResult, error) {
+	conn, err := s.dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := io.WriteString(conn, "zINSTREAM\x00"); err != nil {
+		return nil, fmt.Errorf("failed to send to clamd: %w", err)
+	}
+
+	// The file is sent as length-prefixed chunks ended by an empty one
+	buf := make([]byte, 4+clamAVChunkSize)
+	for {
+		n, readErr := io.ReadFull(file, buf[4:])
+		if n > 0 {
+			binary.BigEndian.PutUint32(buf, uint32(n))
+			if _, err := conn.Write(buf[:4+n]); err != nil {
+				return nil, fmt.Errorf("failed to send to clamd: %w", err)
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read file: %w", readErr)
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return nil, fmt.Errorf("failed to send to clamd: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		return nil, fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseClamAVReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+// parseClamAVReply reads a reply such as "stream: OK" or
+// "stream: Eicar-Signature FOUND"
+func parseClamAVReply(reply string) (*domain.ScanResult, error) {
+	result := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case result == "OK":
+		return &domain.ScanResult{Clean: true}, nil
+	case strings.HasSuffix(result, " FOUND"):
+		return &domain.ScanResult{Threat: strings.TrimSuffix(result, " FOUND")}, nil
+	default:
+		return nil, fmt.Errorf("clamd: %s", result)
+	}
+}
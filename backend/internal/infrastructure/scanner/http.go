@@ -0,0 +1,64 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+)
+
+// HTTPScanner scans files with an external API. The file is POSTed as the request
+// body and the API answers with a JSON verdict.
+type HTTPScanner struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// NewHTTPScanner creates a scanner for the API at url, authenticated with token as a
+// bearer token when it is set. Scans are bounded by the context rather than a
+// client timeout, as large files take long to send.
+func NewHTTPScanner(url, token string) *HTTPScanner {
+	return &HTTPScanner{
+		url:        url,
+		token:      token,
+		httpClient: &http.Client{},
+	}
+}
+
+// httpScanResponse is the verdict of the scanning API
+type httpScanResponse struct {
+	Clean  bool   `json:"clean"`
+	Threat string `json:"threat"`
+}
+
+// Scan sends the file to the API and reports its verdict
+func (s *HTTPScanner) Scan(ctx context.Context, file io.Reader) (*domain.ScanResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scan request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach scanner: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scanner returned status %d", resp.StatusCode)
+	}
+
+	var verdict httpScanResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&verdict); err != nil {
+		return nil, fmt.Errorf("failed to decode scanner response: %w", err)
+	}
+	return &domain.ScanResult{Clean: verdict.Clean, Threat: verdict.Threat}, nil
+}
@@ -0,0 +1,34 @@
+// Package scanner checks the content of uploaded files with ClamAV or an external
+// scanning API.
+package scanner
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eralove/eralove-backend/internal/config"
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+// Scanners that can be configured
+const (
+	ScannerClamAV = "clamav"
+	ScannerHTTP   = "http"
+)
+
+// NewScanner creates the configured scanner, or returns nil when scanning is disabled
+func NewScanner(cfg *config.Config, logger *zap.Logger) (domain.FileScanner, error) {
+	switch name := strings.ToLower(cfg.UploadScanner); name {
+	case "":
+		return nil, nil
+	case ScannerClamAV:
+		logger.Info("Scanning uploads with ClamAV", zap.String("address", cfg.ClamAVAddr))
+		return NewClamAVScanner(cfg.ClamAVAddr), nil
+	case ScannerHTTP:
+		logger.Info("Scanning uploads with an external API", zap.String("url", cfg.UploadScannerURL))
+		return NewHTTPScanner(cfg.UploadScannerURL, cfg.UploadScannerToken), nil
+	default:
+		return nil, fmt.Errorf("unsupported upload scanner: %s", name)
+	}
+}
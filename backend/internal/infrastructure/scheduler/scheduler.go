@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// JobFunc is a unit of background work run by the scheduler
+type JobFunc func(ctx context.Context) error
+
+// job represents a registered periodic job
+type job struct {
+	name     string
+	interval time.Duration
+	run      JobFunc
+}
+
+// Scheduler runs registered jobs at fixed intervals in the background
+type Scheduler struct {
+	jobs    []*job
+	logger  *zap.Logger
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	running bool
+}
+
+// NewScheduler creates a new scheduler
+func NewScheduler(logger *zap.Logger) *Scheduler {
+	return &Scheduler{
+		logger: logger,
+	}
+}
+
+// Register adds a job that runs every interval once the scheduler is started
+func (s *Scheduler) Register(name string, interval time.Duration, run JobFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, &job{
+		name:     name,
+		interval: interval,
+		run:      run,
+	})
+
+	s.logger.Info("Registered scheduled job",
+		zap.String("job", name),
+		zap.Duration("interval", interval))
+}
+
+// Start starts running all registered jobs
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.running = true
+
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(ctx, j)
+	}
+
+	s.logger.Info("Scheduler started", zap.Int("jobs", len(s.jobs)))
+}
+
+// Stop stops all jobs and waits for running ones to finish
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.cancel()
+	s.running = false
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	s.logger.Info("Scheduler stopped")
+}
+
+// loop runs a job on its interval until the context is cancelled
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.runJob(ctx, j)
+		}
+	}
+}
+
+// runJob executes a single job run, recovering from panics
+func (s *Scheduler) runJob(ctx context.Context, j *job) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Error("Scheduled job panicked",
+				zap.String("job", j.name),
+				zap.Any("panic", r))
+		}
+	}()
+
+	start := time.Now()
+	if err := j.run(ctx); err != nil {
+		s.logger.Error("Scheduled job failed",
+			zap.String("job", j.name),
+			zap.Error(err))
+		return
+	}
+
+	s.logger.Debug("Scheduled job completed",
+		zap.String("job", j.name),
+		zap.Duration("duration", time.Since(start)))
+}
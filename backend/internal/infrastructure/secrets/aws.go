@@ -0,0 +1,139 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const awsGetSecretValueTarget = "secretsmanager.GetSecretValue"
+
+// AWSProvider reads secrets from AWS Secrets Manager
+type AWSProvider struct {
+	region          string
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+	httpClient      *http.Client
+}
+
+// NewAWSProvider creates a Secrets Manager client. The session token is only needed
+// with temporary credentials.
+func NewAWSProvider(region, accessKeyID, secretAccessKey, sessionToken string, httpClient *http.Client) *AWSProvider {
+	return &AWSProvider{
+		region:          region,
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		sessionToken:    sessionToken,
+		httpClient:      httpClient,
+	}
+}
+
+// GetSecret returns the current string value of the secret called name, its name or ARN
+func (p *AWSProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	body, err := json.Marshal(map[string]string{"SecretId": name})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode secrets manager request: %w", err)
+	}
+
+	host := fmt.Sprintf("secretsmanager.%s.amazonaws.com", p.region)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+host+"/", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create secrets manager request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", awsGetSecretValueTarget)
+	p.sign(req, host, body, time.Now().UTC())
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("secrets manager request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		// The error type looks like "com.amazonaws.secretsmanager#ResourceNotFoundException"
+		var failure struct {
+			Type string `json:"__type"`
+		}
+		_ = json.Unmarshal(raw, &failure)
+		return "", fmt.Errorf("secrets manager returned status %d %s", resp.StatusCode, failure.Type)
+	}
+
+	var result struct {
+		SecretString *string `json:"SecretString"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return "", fmt.Errorf("failed to decode secrets manager response: %w", err)
+	}
+	if result.SecretString == nil {
+		return "", fmt.Errorf("secret %s has no string value", name)
+	}
+	return *result.SecretString, nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header to the request
+func (p *AWSProvider) sign(req *http.Request, host string, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := date + "/" + p.region + "/secretsmanager/aws4_request"
+	payloadHash := sha256.Sum256(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	headers := []string{
+		"content-type:" + req.Header.Get("Content-Type"),
+		"host:" + host,
+		"x-amz-date:" + amzDate,
+	}
+	signedHeaders := "content-type;host;x-amz-date"
+	if p.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", p.sessionToken)
+		headers = append(headers, "x-amz-security-token:"+p.sessionToken)
+		signedHeaders += ";x-amz-security-token"
+	}
+	headers = append(headers, "x-amz-target:"+req.Header.Get("X-Amz-Target"))
+	signedHeaders += ";x-amz-target"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		"/",
+		"",
+		strings.Join(headers, "\n"),
+		"",
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	canonicalHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(canonicalHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+p.secretAccessKey), date)
+	key = hmacSHA256(key, p.region)
+	key = hmacSHA256(key, "secretsmanager")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		p.accessKeyID, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data with key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
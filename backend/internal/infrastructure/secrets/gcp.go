@@ -0,0 +1,99 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const (
+	gcpSecretManagerURL = "https://secretmanager.googleapis.com/v1"
+	// gcpTokenURL hands out access tokens of the service account the server runs as,
+	// on Compute Engine, GKE and Cloud Run
+	gcpTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+)
+
+// GCPProvider reads secrets from Google Cloud Secret Manager, as the service account
+// the server runs as
+type GCPProvider struct {
+	project    string
+	httpClient *http.Client
+}
+
+// NewGCPProvider creates a Secret Manager client for the secrets of project
+func NewGCPProvider(project string, httpClient *http.Client) *GCPProvider {
+	return &GCPProvider{
+		project:    project,
+		httpClient: httpClient,
+	}
+}
+
+// GetSecret returns the latest version of the secret called name
+func (p *GCPProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	token, err := p.accessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := fmt.Sprintf("%s/projects/%s/secrets/%s/versions/latest:access", gcpSecretManagerURL, url.PathEscape(p.project), url.PathEscape(name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create secret manager request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("secret manager request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("secret manager returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode secret manager response: %w", err)
+	}
+	value, err := base64.StdEncoding.DecodeString(body.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode secret payload: %w", err)
+	}
+	return string(value), nil
+}
+
+// accessToken returns an access token of the server's service account
+func (p *GCPProvider) accessToken(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpTokenURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create metadata request: %w", err)
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("metadata server request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("metadata server returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.AccessToken == "" {
+		return "", fmt.Errorf("metadata server returned no access token")
+	}
+	return body.AccessToken, nil
+}
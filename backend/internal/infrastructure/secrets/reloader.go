@@ -0,0 +1,56 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Reloader reads a secret again each time it runs and hands the value to apply when it
+// changed, so that the secret can be rotated in the secret manager without a restart
+type Reloader struct {
+	provider Provider
+	ref      string
+	apply    func(value string)
+	logger   *zap.Logger
+
+	mu      sync.Mutex
+	current string
+}
+
+// NewReloader creates a reloader of the secret ref points at, whose value is current
+func NewReloader(provider Provider, ref, current string, apply func(value string), logger *zap.Logger) *Reloader {
+	return &Reloader{
+		provider: provider,
+		ref:      ref,
+		apply:    apply,
+		logger:   logger,
+		current:  current,
+	}
+}
+
+// Reload reads the secret and applies its value if it changed. A failed read keeps the
+// current value.
+func (r *Reloader) Reload(ctx context.Context) error {
+	value, err := Resolve(ctx, r.provider, r.ref)
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		return fmt.Errorf("secret %s is empty", r.ref)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if value == r.current {
+		return nil
+	}
+	r.apply(value)
+	r.current = value
+
+	r.logger.Info("Secret reloaded", zap.String("secret", r.ref))
+	return nil
+}
@@ -0,0 +1,95 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Provider kinds
+const (
+	ProviderVault = "vault"
+	ProviderAWS   = "aws"
+	ProviderGCP   = "gcp"
+)
+
+// requestTimeout bounds each call to a secret manager
+const requestTimeout = 10 * time.Second
+
+// Provider reads secrets from a secret manager
+type Provider interface {
+	// GetSecret returns the current value of the secret called name
+	GetSecret(ctx context.Context, name string) (string, error)
+}
+
+// Options configures the secret manager of New
+type Options struct {
+	Provider string // vault, aws or gcp
+
+	VaultAddr      string
+	VaultToken     string
+	VaultMount     string // mount of the KV version 2 engine
+	VaultNamespace string
+
+	AWSRegion          string
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	AWSSessionToken    string
+
+	GCPProject string
+}
+
+// New creates the secret manager client of options, or returns nil when no provider is
+// configured
+func New(options Options) (Provider, error) {
+	httpClient := &http.Client{Timeout: requestTimeout}
+
+	switch strings.ToLower(options.Provider) {
+	case "":
+		return nil, nil
+	case ProviderVault:
+		if options.VaultAddr == "" || options.VaultToken == "" {
+			return nil, fmt.Errorf("VAULT_ADDR and VAULT_TOKEN are required for the vault secrets provider")
+		}
+		return NewVaultProvider(options.VaultAddr, options.VaultToken, options.VaultMount, options.VaultNamespace, httpClient), nil
+	case ProviderAWS:
+		if options.AWSRegion == "" || options.AWSAccessKeyID == "" || options.AWSSecretAccessKey == "" {
+			return nil, fmt.Errorf("AWS_REGION, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required for the aws secrets provider")
+		}
+		return NewAWSProvider(options.AWSRegion, options.AWSAccessKeyID, options.AWSSecretAccessKey, options.AWSSessionToken, httpClient), nil
+	case ProviderGCP:
+		if options.GCPProject == "" {
+			return nil, fmt.Errorf("GCP_PROJECT is required for the gcp secrets provider")
+		}
+		return NewGCPProvider(options.GCPProject, httpClient), nil
+	default:
+		return nil, fmt.Errorf("unsupported secrets provider: %s", options.Provider)
+	}
+}
+
+// Resolve returns the value of the secret ref points at. A reference is the name of a
+// secret, followed by #field to read a field of a secret holding a JSON object, as
+// Vault secrets always do.
+func Resolve(ctx context.Context, provider Provider, ref string) (string, error) {
+	name, field, hasField := strings.Cut(ref, "#")
+	value, err := provider.GetSecret(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret %s: %w", name, err)
+	}
+	if !hasField {
+		return value, nil
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(value), &fields); err != nil {
+		return "", fmt.Errorf("secret %s is not a JSON object", name)
+	}
+	fieldValue, ok := fields[field].(string)
+	if !ok {
+		return "", fmt.Errorf("secret %s has no field %s", name, field)
+	}
+	return fieldValue, nil
+}
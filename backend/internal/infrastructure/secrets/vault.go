@@ -0,0 +1,78 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// VaultProvider reads secrets from the KV version 2 secrets engine of HashiCorp Vault
+type VaultProvider struct {
+	addr       string
+	token      string
+	mount      string
+	namespace  string
+	httpClient *http.Client
+}
+
+// NewVaultProvider creates a Vault client authenticating with token. Mount is the path
+// the KV engine is mounted at, "secret" when empty.
+func NewVaultProvider(addr, token, mount, namespace string, httpClient *http.Client) *VaultProvider {
+	if mount == "" {
+		mount = "secret"
+	}
+	return &VaultProvider{
+		addr:       strings.TrimSuffix(addr, "/"),
+		token:      token,
+		mount:      strings.Trim(mount, "/"),
+		namespace:  namespace,
+		httpClient: httpClient,
+	}
+}
+
+// GetSecret returns the latest version of the secret at path name, as a JSON object of
+// its fields
+func (p *VaultProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	endpoint := fmt.Sprintf("%s/v1/%s/data/%s", p.addr, p.mount, escapePath(name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	return string(body.Data.Data), nil
+}
+
+// escapePath escapes each segment of a secret path
+func escapePath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
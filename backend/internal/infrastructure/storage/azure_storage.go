@@ -0,0 +1,629 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+const (
+	// azureAPIVersion is the Blob service REST API version requests and SAS tokens use
+	azureAPIVersion = "2021-08-06"
+	// azureListPageSize is the most blobs one List Blobs call returns
+	azureListPageSize = 5000
+	// azureSASTimeFormat is the format of SAS start and expiry times
+	azureSASTimeFormat = "2006-01-02T15:04:05Z"
+)
+
+// AzureStorage implements StorageService using Azure Blob Storage. The account name
+// and key are read from the access key ID and secret key of the configuration, and
+// the bucket is the container.
+type AzureStorage struct {
+	account    string
+	key        []byte
+	endpoint   string
+	config     *domain.StorageConfig
+	httpClient *http.Client
+	logger     *zap.Logger
+}
+
+// NewAzureStorage creates a new Azure Blob storage service. The endpoint defaults to
+// the account's public blob endpoint; a custom one such as Azurite's
+// http://127.0.0.1:10000/devstoreaccount1 can be set instead.
+func NewAzureStorage(config *domain.StorageConfig, logger *zap.Logger) (domain.StorageService, error) {
+	key, err := base64.StdEncoding.DecodeString(config.SecretAccessKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Azure account key: %w", err)
+	}
+
+	endpoint := strings.TrimRight(config.Endpoint, "/")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net", config.AccessKeyID)
+	} else if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		protocol := "https"
+		if !config.UseSSL {
+			protocol = "http"
+		}
+		endpoint = fmt.Sprintf("%s://%s", protocol, endpoint)
+	}
+
+	a := &AzureStorage{
+		account:    config.AccessKeyID,
+		key:        key,
+		endpoint:   endpoint,
+		config:     config,
+		httpClient: &http.Client{},
+		logger:     logger,
+	}
+
+	// Ensure container exists
+	resp, err := a.do(context.Background(), http.MethodPut, "", url.Values{"restype": {"container"}}, nil, 0, nil)
+	if err != nil {
+		logger.Warn("Failed to create container", zap.Error(err))
+	} else {
+		resp.Body.Close()
+		switch resp.StatusCode {
+		case http.StatusCreated:
+			logger.Info("Created container", zap.String("container", config.Bucket))
+		case http.StatusConflict:
+			// The container already exists
+		default:
+			logger.Warn("Failed to create container", zap.Int("status", resp.StatusCode))
+		}
+	}
+
+	return a, nil
+}
+
+// Upload uploads a file to Azure Blob Storage
+func (a *AzureStorage) Upload(ctx context.Context, req *domain.UploadRequest) (*domain.FileInfo, error) {
+	key := a.generateKey(req.Folder, req.UserID, req.Filename)
+
+	a.logger.Info("Starting Azure upload",
+		zap.String("key", key),
+		zap.String("filename", req.Filename),
+		zap.String("content_type", req.ContentType),
+		zap.Int64("size", req.Size))
+
+	fileInfo, err := a.PutObject(ctx, key, req.File, req.Size, req.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	fileInfo.Filename = req.Filename
+
+	a.logger.Info("Azure upload successful",
+		zap.String("key", key),
+		zap.Int64("size", fileInfo.Size))
+
+	return fileInfo, nil
+}
+
+// Download generates a SAS URL for downloading
+func (a *AzureStorage) Download(ctx context.Context, req *domain.DownloadRequest) (string, error) {
+	return a.GeneratePresignedDownloadURL(ctx, req.Key, req.Expiry)
+}
+
+// Delete removes a blob from Azure Blob Storage
+func (a *AzureStorage) Delete(ctx context.Context, key string) error {
+	a.logger.Info("Deleting file from Azure", zap.String("key", key))
+
+	resp, err := a.do(ctx, http.MethodDelete, key, nil, nil, 0, nil)
+	if err != nil {
+		a.logger.Error("Failed to delete from Azure", zap.Error(err), zap.String("key", key))
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Deleting a missing blob succeeds, as it does on S3
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusNotFound {
+		err := azureError(resp)
+		a.logger.Error("Failed to delete from Azure", zap.Error(err), zap.String("key", key))
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	a.logger.Info("File deleted from Azure successfully", zap.String("key", key))
+	return nil
+}
+
+// GetFileInfo retrieves blob properties from Azure Blob Storage
+func (a *AzureStorage) GetFileInfo(ctx context.Context, key string) (*domain.FileInfo, error) {
+	resp, err := a.do(ctx, http.MethodHead, key, nil, nil, 0, nil)
+	if err != nil {
+		a.logger.Error("Failed to get file info from Azure", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to get file info: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, domain.ErrFileNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("azure returned status %d", resp.StatusCode)
+		a.logger.Error("Failed to get file info from Azure", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	lastModified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+
+	return &domain.FileInfo{
+		Key:         key,
+		URL:         a.generatePublicURL(key),
+		Filename:    filepath.Base(key),
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        resp.ContentLength,
+		UploadedAt:  lastModified,
+		Bucket:      a.config.Bucket,
+	}, nil
+}
+
+type azureListResult struct {
+	Blobs []struct {
+		Name       string `xml:"Name"`
+		Properties struct {
+			LastModified  string `xml:"Last-Modified"`
+			ContentLength int64  `xml:"Content-Length"`
+			ContentType   string `xml:"Content-Type"`
+		} `xml:"Properties"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+// ListFiles lists blobs in a folder, starting after the key startAfter. Azure only
+// continues listings from its own markers, so the blobs up to startAfter are paged
+// past.
+func (a *AzureStorage) ListFiles(ctx context.Context, folder string, startAfter string, limit int) ([]*domain.FileInfo, error) {
+	var files []*domain.FileInfo
+	marker := ""
+
+	for len(files) < limit {
+		query := url.Values{
+			"restype":    {"container"},
+			"comp":       {"list"},
+			"prefix":     {folder},
+			"maxresults": {strconv.Itoa(azureListPageSize)},
+		}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+
+		resp, err := a.do(ctx, http.MethodGet, "", query, nil, 0, nil)
+		if err != nil {
+			a.logger.Error("Failed to list files from Azure", zap.Error(err), zap.String("folder", folder))
+			return nil, fmt.Errorf("failed to list files: %w", err)
+		}
+
+		var result azureListResult
+		if resp.StatusCode != http.StatusOK {
+			err = azureError(resp)
+		} else {
+			err = xml.NewDecoder(resp.Body).Decode(&result)
+		}
+		resp.Body.Close()
+		if err != nil {
+			a.logger.Error("Failed to list files from Azure", zap.Error(err), zap.String("folder", folder))
+			return nil, fmt.Errorf("failed to list files: %w", err)
+		}
+
+		for _, blob := range result.Blobs {
+			if blob.Name <= startAfter {
+				continue
+			}
+			if len(files) >= limit {
+				break
+			}
+
+			lastModified, _ := http.ParseTime(blob.Properties.LastModified)
+			files = append(files, &domain.FileInfo{
+				Key:         blob.Name,
+				URL:         a.generatePublicURL(blob.Name),
+				Filename:    filepath.Base(blob.Name),
+				ContentType: blob.Properties.ContentType,
+				Size:        blob.Properties.ContentLength,
+				UploadedAt:  lastModified,
+				Bucket:      a.config.Bucket,
+			})
+		}
+
+		if result.NextMarker == "" {
+			break
+		}
+		marker = result.NextMarker
+	}
+
+	return files, nil
+}
+
+// GeneratePresignedUploadURL generates a SAS URL for direct upload. Clients must send
+// the x-ms-blob-type: BlockBlob header with the upload.
+func (a *AzureStorage) GeneratePresignedUploadURL(ctx context.Context, key string, contentType string, expiry time.Duration) (string, error) {
+	signedURL, err := a.generateSASURL(key, "cw", expiry)
+	if err != nil {
+		a.logger.Error("Failed to generate SAS upload URL", zap.Error(err), zap.String("key", key))
+		return "", fmt.Errorf("failed to generate presigned upload URL: %w", err)
+	}
+
+	a.logger.Info("Generated SAS upload URL", zap.String("key", key), zap.Duration("expiry", expiry))
+	return signedURL, nil
+}
+
+// GeneratePresignedDownloadURL generates a SAS URL for direct download
+func (a *AzureStorage) GeneratePresignedDownloadURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	signedURL, err := a.generateSASURL(key, "r", expiry)
+	if err != nil {
+		a.logger.Error("Failed to generate SAS download URL", zap.Error(err), zap.String("key", key))
+		return "", fmt.Errorf("failed to generate presigned download URL: %w", err)
+	}
+
+	a.logger.Info("Generated SAS download URL", zap.String("key", key), zap.Duration("expiry", expiry))
+	return signedURL, nil
+}
+
+// GetObject opens a blob stored in Azure for reading
+func (a *AzureStorage) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := a.do(ctx, http.MethodGet, key, nil, nil, 0, nil)
+	if err != nil {
+		a.logger.Error("Failed to get object from Azure", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, domain.ErrFileNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		err := azureError(resp)
+		resp.Body.Close()
+		a.logger.Error("Failed to get object from Azure", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	return resp.Body, nil
+}
+
+// PutObject stores a blob in Azure under an exact key
+func (a *AzureStorage) PutObject(ctx context.Context, key string, file io.Reader, size int64, contentType string) (*domain.FileInfo, error) {
+	hash := sha256.New()
+	counter := &countingReader{Reader: io.TeeReader(file, hash)}
+
+	headers := map[string]string{
+		"x-ms-blob-type": "BlockBlob",
+		"Content-Type":   contentType,
+	}
+	resp, err := a.do(ctx, http.MethodPut, key, nil, counter, size, headers)
+	if err != nil {
+		a.logger.Error("Failed to put object to Azure", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		err := azureError(resp)
+		a.logger.Error("Failed to put object to Azure", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	return &domain.FileInfo{
+		Key:         key,
+		URL:         key,
+		Filename:    filepath.Base(key),
+		ContentType: contentType,
+		Size:        counter.n,
+		UploadedAt:  time.Now(),
+		Bucket:      a.config.Bucket,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// Ping checks that the container can be reached
+func (a *AzureStorage) Ping(ctx context.Context) error {
+	resp, err := a.do(ctx, http.MethodHead, "", url.Values{"restype": {"container"}}, nil, 0, nil)
+	if err != nil {
+		return fmt.Errorf("failed to reach container: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("container %s does not exist", a.config.Bucket)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to reach container: azure returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// CreateMultipartUpload starts a multipart upload. Azure stages blocks on the blob
+// itself rather than on an upload, so the upload ID only scopes the block IDs, and
+// carries the content type that the blob gets once its blocks are committed.
+func (a *AzureStorage) CreateMultipartUpload(ctx context.Context, key string, contentType string) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate upload ID: %w", err)
+	}
+	return hex.EncodeToString(id) + "." + base64.RawURLEncoding.EncodeToString([]byte(contentType)), nil
+}
+
+// UploadPart stages a block of a multipart upload
+func (a *AzureStorage) UploadPart(ctx context.Context, key, uploadID string, partNumber int, data io.Reader, size int64) (*domain.UploadedPart, error) {
+	blockID := azureBlockID(uploadID, partNumber)
+
+	query := url.Values{"comp": {"block"}, "blockid": {blockID}}
+	resp, err := a.do(ctx, http.MethodPut, key, query, data, size, nil)
+	if err != nil {
+		a.logger.Error("Failed to upload part to Azure", zap.Error(err), zap.String("key", key), zap.Int("part", partNumber))
+		return nil, fmt.Errorf("failed to upload part: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		err := azureError(resp)
+		a.logger.Error("Failed to upload part to Azure", zap.Error(err), zap.String("key", key), zap.Int("part", partNumber))
+		return nil, fmt.Errorf("failed to upload part: %w", err)
+	}
+
+	// The block ID identifies the part when the blocks are committed
+	return &domain.UploadedPart{Number: partNumber, ETag: blockID}, nil
+}
+
+// CompleteMultipartUpload commits the staged blocks, in order, as the blob
+func (a *AzureStorage) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []*domain.UploadedPart) (*domain.FileInfo, error) {
+	var body bytes.Buffer
+	body.WriteString(xml.Header)
+	body.WriteString("<BlockList>")
+	for _, part := range parts {
+		body.WriteString("<Latest>")
+		xml.EscapeText(&body, []byte(part.ETag))
+		body.WriteString("</Latest>")
+	}
+	body.WriteString("</BlockList>")
+
+	headers := map[string]string{"Content-Type": "application/xml"}
+	if _, encoded, ok := strings.Cut(uploadID, "."); ok {
+		if contentType, err := base64.RawURLEncoding.DecodeString(encoded); err == nil && len(contentType) > 0 {
+			headers["x-ms-blob-content-type"] = string(contentType)
+		}
+	}
+
+	resp, err := a.do(ctx, http.MethodPut, key, url.Values{"comp": {"blocklist"}}, &body, int64(body.Len()), headers)
+	if err != nil {
+		a.logger.Error("Failed to complete multipart upload in Azure", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to complete upload: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		err := azureError(resp)
+		a.logger.Error("Failed to complete multipart upload in Azure", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to complete upload: %w", err)
+	}
+
+	// The blob is assembled by Azure, so its size is read back rather than counted
+	fileInfo, err := a.GetFileInfo(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	fileInfo.URL = key
+
+	return fileInfo, nil
+}
+
+// AbortMultipartUpload aborts a multipart upload. Azure has no call to discard
+// staged blocks; it deletes uncommitted blocks by itself after a week.
+func (a *AzureStorage) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	return nil
+}
+
+// do sends a request signed with the account key for the blob stored under key, or
+// for the container when key is empty
+func (a *AzureStorage) do(ctx context.Context, method, key string, query url.Values, body io.Reader, size int64, headers map[string]string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, a.resourceURL(key), body)
+	if err != nil {
+		return nil, err
+	}
+	if query != nil {
+		req.URL.RawQuery = query.Encode()
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", azureAPIVersion)
+
+	req.Header.Set("Authorization", fmt.Sprintf("SharedKey %s:%s", a.account, a.sign(a.stringToSign(req))))
+
+	return a.httpClient.Do(req)
+}
+
+// stringToSign builds the Shared Key string to sign of a request
+func (a *AzureStorage) stringToSign(req *http.Request) string {
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	var msHeaders []string
+	for name := range req.Header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-ms-") {
+			msHeaders = append(msHeaders, lower)
+		}
+	}
+	sort.Strings(msHeaders)
+
+	var canonical strings.Builder
+	for _, name := range msHeaders {
+		canonical.WriteString(name + ":" + strings.TrimSpace(req.Header.Get(name)) + "\n")
+	}
+
+	canonical.WriteString("/" + a.account + req.URL.EscapedPath())
+	query := req.URL.Query()
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values := query[name]
+		sort.Strings(values)
+		canonical.WriteString("\n" + strings.ToLower(name) + ":" + strings.Join(values, ","))
+	}
+
+	return strings.Join([]string{
+		req.Method,
+		req.Header.Get("Content-Encoding"),
+		req.Header.Get("Content-Language"),
+		contentLength,
+		req.Header.Get("Content-MD5"),
+		req.Header.Get("Content-Type"),
+		"", // Date is sent as x-ms-date
+		req.Header.Get("If-Modified-Since"),
+		req.Header.Get("If-Match"),
+		req.Header.Get("If-None-Match"),
+		req.Header.Get("If-Unmodified-Since"),
+		req.Header.Get("Range"),
+		canonical.String(),
+	}, "\n")
+}
+
+// generateSASURL signs a service SAS granting permissions on the blob stored under key
+func (a *AzureStorage) generateSASURL(key, permissions string, expiry time.Duration) (string, error) {
+	if expiry <= 0 {
+		return "", fmt.Errorf("expiry must be positive")
+	}
+
+	// Allow for clock skew between the server and Azure
+	start := time.Now().UTC().Add(-5 * time.Minute).Format(azureSASTimeFormat)
+	end := time.Now().UTC().Add(expiry).Format(azureSASTimeFormat)
+
+	stringToSign := strings.Join([]string{
+		permissions,
+		start,
+		end,
+		fmt.Sprintf("/blob/%s/%s/%s", a.account, a.config.Bucket, key),
+		"", // signed identifier
+		"", // signed IP
+		"", // signed protocol
+		azureAPIVersion,
+		"b", // signed resource: blob
+		"",  // snapshot time
+		"",  // encryption scope
+		"",  // Cache-Control
+		"",  // Content-Disposition
+		"",  // Content-Encoding
+		"",  // Content-Language
+		"",  // Content-Type
+	}, "\n")
+
+	query := url.Values{
+		"sv":  {azureAPIVersion},
+		"sp":  {permissions},
+		"st":  {start},
+		"se":  {end},
+		"sr":  {"b"},
+		"sig": {a.sign(stringToSign)},
+	}
+
+	return a.resourceURL(key) + "?" + query.Encode(), nil
+}
+
+// sign signs a string with the account key
+func (a *AzureStorage) sign(stringToSign string) string {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// resourceURL returns the URL of the blob stored under key, or of the container when
+// key is empty
+func (a *AzureStorage) resourceURL(key string) string {
+	resource := a.endpoint + "/" + url.PathEscape(a.config.Bucket)
+	if key == "" {
+		return resource
+	}
+
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return resource + "/" + strings.Join(segments, "/")
+}
+
+// generateKey creates a unique key for the file
+func (a *AzureStorage) generateKey(folder, userID, filename string) string {
+	// Clean filename
+	cleanFilename := strings.ReplaceAll(filename, " ", "_")
+
+	// Add timestamp to make it unique
+	timestamp := time.Now().Format("20060102_150405")
+
+	// Extract file extension
+	ext := filepath.Ext(cleanFilename)
+	nameWithoutExt := strings.TrimSuffix(cleanFilename, ext)
+
+	// Create unique filename
+	uniqueFilename := fmt.Sprintf("%s_%s%s", nameWithoutExt, timestamp, ext)
+
+	// Construct full key
+	if folder == "" {
+		folder = "uploads"
+	}
+
+	return fmt.Sprintf("%s/%s/%s", folder, userID, uniqueFilename)
+}
+
+// generatePublicURL creates a public URL for the file
+func (a *AzureStorage) generatePublicURL(key string) string {
+	if a.config.BaseURL != "" {
+		return fmt.Sprintf("%s/%s", strings.TrimRight(a.config.BaseURL, "/"), key)
+	}
+	return a.resourceURL(key)
+}
+
+// azureBlockID returns the ID of a part's block. Block IDs of a blob must all have the
+// same length, so the part number is zero-padded.
+func azureBlockID(uploadID string, partNumber int) string {
+	id, _, _ := strings.Cut(uploadID, ".")
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s-%06d", id, partNumber)))
+}
+
+// azureError reads the error code Azure sent with a failed response
+func azureError(resp *http.Response) error {
+	var body struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&body); err != nil || body.Code == "" {
+		return fmt.Errorf("azure returned status %d", resp.StatusCode)
+	}
+	return fmt.Errorf("azure returned status %d: %s", resp.StatusCode, body.Code)
+}
+
+// countingReader counts the bytes read through it
+type countingReader struct {
+	io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
@@ -15,6 +15,8 @@ const (
 	ProviderLocal StorageProvider = "local"
 	ProviderMinIO StorageProvider = "minio"
 	ProviderS3    StorageProvider = "s3"
+	ProviderGCS   StorageProvider = "gcs"
+	ProviderAzure StorageProvider = "azure"
 )
 
 // Factory creates storage services based on configuration
@@ -43,6 +45,10 @@ func (f *Factory) CreateStorage(config *domain.StorageConfig) (domain.StorageSer
 		return f.createLocalStorage(config)
 	case ProviderMinIO, ProviderS3:
 		return f.createMinIOStorage(config)
+	case ProviderGCS:
+		return f.createGCSStorage(config)
+	case ProviderAzure:
+		return f.createAzureStorage(config)
 	default:
 		return nil, fmt.Errorf("unsupported storage provider: %s", provider)
 	}
@@ -90,6 +96,41 @@ func (f *Factory) createMinIOStorage(config *domain.StorageConfig) (domain.Stora
 	return NewMinIOStorage(config, f.logger)
 }
 
+// createGCSStorage creates a Google Cloud Storage service
+func (f *Factory) createGCSStorage(config *domain.StorageConfig) (domain.StorageService, error) {
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("HMAC access ID and secret are required for GCS storage")
+	}
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("bucket name is required for GCS storage")
+	}
+
+	f.logger.Info("Creating GCS storage",
+		zap.String("bucket", config.Bucket))
+
+	return NewGCSStorage(config, f.logger)
+}
+
+// createAzureStorage creates an Azure Blob storage service
+func (f *Factory) createAzureStorage(config *domain.StorageConfig) (domain.StorageService, error) {
+	if config.AccessKeyID == "" {
+		return nil, fmt.Errorf("account name is required for Azure storage")
+	}
+	if config.SecretAccessKey == "" {
+		return nil, fmt.Errorf("account key is required for Azure storage")
+	}
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("container name is required for Azure storage")
+	}
+
+	f.logger.Info("Creating Azure Blob storage",
+		zap.String("account", config.AccessKeyID),
+		zap.String("container", config.Bucket),
+		zap.String("endpoint", config.Endpoint))
+
+	return NewAzureStorage(config, f.logger)
+}
+
 // GetStorageConfig creates storage config from individual parameters
 func GetStorageConfig(provider, region, bucket, accessKey, secretKey, endpoint, baseURL string, useSSL bool) *domain.StorageConfig {
 	return &domain.StorageConfig{
@@ -130,6 +171,25 @@ func ValidateConfig(config *domain.StorageConfig) error {
 			return fmt.Errorf("either region or endpoint is required for %s storage", provider)
 		}
 		return nil
+	case ProviderGCS:
+		if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+			return fmt.Errorf("HMAC access ID and secret are required for %s storage", provider)
+		}
+		if config.Bucket == "" {
+			return fmt.Errorf("bucket name is required for %s storage", provider)
+		}
+		return nil
+	case ProviderAzure:
+		if config.AccessKeyID == "" {
+			return fmt.Errorf("account name is required for %s storage", provider)
+		}
+		if config.SecretAccessKey == "" {
+			return fmt.Errorf("account key is required for %s storage", provider)
+		}
+		if config.Bucket == "" {
+			return fmt.Errorf("container name is required for %s storage", provider)
+		}
+		return nil
 	default:
 		return fmt.Errorf("unsupported storage provider: %s", provider)
 	}
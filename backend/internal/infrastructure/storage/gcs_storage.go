@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+// gcsEndpoint is the Cloud Storage XML API endpoint, which is interoperable with S3
+const gcsEndpoint = "storage.googleapis.com"
+
+// NewGCSStorage creates a new Google Cloud Storage service. It talks to the
+// S3-interoperable XML API with an HMAC key, so the access key ID and secret key are
+// the HMAC key's access ID and secret, and presigned URLs are Cloud Storage V4 signed
+// URLs.
+func NewGCSStorage(config *domain.StorageConfig, logger *zap.Logger) (domain.StorageService, error) {
+	gcsConfig := *config
+	if gcsConfig.Endpoint == "" {
+		gcsConfig.Endpoint = gcsEndpoint
+		gcsConfig.UseSSL = true
+	}
+	if gcsConfig.Region == "" {
+		// Cloud Storage accepts "auto" as the region of every bucket
+		gcsConfig.Region = "auto"
+	}
+
+	return NewMinIOStorage(&gcsConfig, logger)
+}
@@ -2,6 +2,9 @@ package storage
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -60,8 +63,9 @@ func (l *LocalStorage) Upload(ctx context.Context, req *domain.UploadRequest) (*
 	}
 	defer file.Close()
 
-	// Copy content
-	written, err := io.Copy(file, req.File)
+	// Copy content, hashing it on the way
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(file, hash), req.File)
 	if err != nil {
 		l.logger.Error("Failed to write file", zap.Error(err), zap.String("path", filePath))
 		return nil, fmt.Errorf("failed to write file: %w", err)
@@ -78,6 +82,7 @@ func (l *LocalStorage) Upload(ctx context.Context, req *domain.UploadRequest) (*
 		Size:        written,
 		UploadedAt:  time.Now(),
 		Bucket:      "local",
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
 	}
 
 	l.logger.Info("Local upload successful",
@@ -138,8 +143,9 @@ func (l *LocalStorage) GetFileInfo(ctx context.Context, key string) (*domain.Fil
 	return fileInfo, nil
 }
 
-// ListFiles lists files in a folder
-func (l *LocalStorage) ListFiles(ctx context.Context, folder string, limit int) ([]*domain.FileInfo, error) {
+// ListFiles lists files in a folder, starting after the key startAfter. Directories
+// are walked in lexical order, which is the order of the keys.
+func (l *LocalStorage) ListFiles(ctx context.Context, folder string, startAfter string, limit int) ([]*domain.FileInfo, error) {
 	folderPath := filepath.Join(l.basePath, folder)
 	
 	var files []*domain.FileInfo
@@ -147,6 +153,10 @@ func (l *LocalStorage) ListFiles(ctx context.Context, folder string, limit int)
 
 	err := filepath.Walk(folderPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
+			// A folder nothing was stored in yet is empty
+			if os.IsNotExist(err) && path == folderPath {
+				return nil
+			}
 			return err
 		}
 
@@ -162,6 +172,9 @@ func (l *LocalStorage) ListFiles(ctx context.Context, folder string, limit int)
 
 		// Convert to forward slashes for consistency
 		key := filepath.ToSlash(relPath)
+		if startAfter != "" && key <= startAfter {
+			return nil
+		}
 
 		fileInfo := &domain.FileInfo{
 			Key:        key,
@@ -197,6 +210,180 @@ func (l *LocalStorage) GeneratePresignedDownloadURL(ctx context.Context, key str
 	return l.generatePublicURL(key), nil
 }
 
+// GetObject opens a file from local storage for reading
+func (l *LocalStorage) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	filePath := filepath.Join(l.basePath, key)
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, domain.ErrFileNotFound
+		}
+		l.logger.Error("Failed to open local file", zap.Error(err), zap.String("path", filePath))
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	return file, nil
+}
+
+// PutObject stores a file in local storage under an exact key
+func (l *LocalStorage) PutObject(ctx context.Context, key string, file io.Reader, size int64, contentType string) (*domain.FileInfo, error) {
+	filePath := filepath.Join(l.basePath, key)
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		l.logger.Error("Failed to create directory", zap.Error(err), zap.String("path", filePath))
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	out, err := os.Create(filePath)
+	if err != nil {
+		l.logger.Error("Failed to create file", zap.Error(err), zap.String("path", filePath))
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer out.Close()
+
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(out, hash), file)
+	if err != nil {
+		l.logger.Error("Failed to write file", zap.Error(err), zap.String("path", filePath))
+		return nil, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return &domain.FileInfo{
+		Key:         key,
+		URL:         l.generatePublicURL(key),
+		Filename:    filepath.Base(key),
+		ContentType: contentType,
+		Size:        written,
+		UploadedAt:  time.Now(),
+		Bucket:      "local",
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// Ping checks that the base directory is still there
+func (l *LocalStorage) Ping(ctx context.Context) error {
+	info, err := os.Stat(l.basePath)
+	if err != nil {
+		return fmt.Errorf("failed to reach storage directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("storage path %s is not a directory", l.basePath)
+	}
+	return nil
+}
+
+// CreateMultipartUpload starts a multipart upload, whose parts are kept in their own
+// directory until the upload is completed or aborted
+func (l *LocalStorage) CreateMultipartUpload(ctx context.Context, key string, contentType string) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate upload ID: %w", err)
+	}
+	uploadID := hex.EncodeToString(id)
+
+	if err := os.MkdirAll(l.partsDir(uploadID), 0755); err != nil {
+		l.logger.Error("Failed to create parts directory", zap.Error(err), zap.String("key", key))
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	return uploadID, nil
+}
+
+// UploadPart stores a part of a multipart upload as a file of its own
+func (l *LocalStorage) UploadPart(ctx context.Context, key, uploadID string, partNumber int, data io.Reader, size int64) (*domain.UploadedPart, error) {
+	partPath := filepath.Join(l.partsDir(uploadID), fmt.Sprintf("%05d", partNumber))
+
+	out, err := os.Create(partPath)
+	if err != nil {
+		l.logger.Error("Failed to create part file", zap.Error(err), zap.String("path", partPath))
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer out.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(out, hash), data); err != nil {
+		l.logger.Error("Failed to write part file", zap.Error(err), zap.String("path", partPath))
+		return nil, fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return &domain.UploadedPart{Number: partNumber, ETag: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+// CompleteMultipartUpload joins the part files into the file and removes them
+func (l *LocalStorage) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []*domain.UploadedPart) (*domain.FileInfo, error) {
+	filePath := filepath.Join(l.basePath, key)
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		l.logger.Error("Failed to create directory", zap.Error(err), zap.String("path", filePath))
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	out, err := os.Create(filePath)
+	if err != nil {
+		l.logger.Error("Failed to create file", zap.Error(err), zap.String("path", filePath))
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+	defer out.Close()
+
+	hash := sha256.New()
+	var written int64
+	for _, part := range parts {
+		n, err := l.appendPart(io.MultiWriter(out, hash), uploadID, part.Number)
+		if err != nil {
+			os.Remove(filePath)
+			return nil, err
+		}
+		written += n
+	}
+
+	if err := os.RemoveAll(l.partsDir(uploadID)); err != nil {
+		l.logger.Warn("Failed to remove parts directory", zap.Error(err), zap.String("upload_id", uploadID))
+	}
+
+	return &domain.FileInfo{
+		Key:         key,
+		URL:         l.generatePublicURL(key),
+		Filename:    filepath.Base(key),
+		Size:        written,
+		UploadedAt:  time.Now(),
+		Bucket:      "local",
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// AbortMultipartUpload removes the part files of a multipart upload
+func (l *LocalStorage) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	if err := os.RemoveAll(l.partsDir(uploadID)); err != nil {
+		l.logger.Error("Failed to remove parts directory", zap.Error(err), zap.String("upload_id", uploadID))
+		return fmt.Errorf("failed to abort upload: %w", err)
+	}
+	return nil
+}
+
+// appendPart copies a part file of a multipart upload to w
+func (l *LocalStorage) appendPart(w io.Writer, uploadID string, partNumber int) (int64, error) {
+	partPath := filepath.Join(l.partsDir(uploadID), fmt.Sprintf("%05d", partNumber))
+
+	part, err := os.Open(partPath)
+	if err != nil {
+		l.logger.Error("Failed to open part file", zap.Error(err), zap.String("path", partPath))
+		return 0, fmt.Errorf("failed to open part %d: %w", partNumber, err)
+	}
+	defer part.Close()
+
+	n, err := io.Copy(w, part)
+	if err != nil {
+		return 0, fmt.Errorf("failed to write part %d: %w", partNumber, err)
+	}
+	return n, nil
+}
+
+// partsDir returns the directory holding the parts of a multipart upload
+func (l *LocalStorage) partsDir(uploadID string) string {
+	return filepath.Join(l.basePath, ".multipart", uploadID)
+}
+
 // generateKey creates a unique key for the file
 func (l *LocalStorage) generateKey(folder, userID, filename string) string {
 	// Clean filename
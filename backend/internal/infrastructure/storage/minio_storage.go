@@ -2,7 +2,10 @@ package storage
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"path/filepath"
 	"strings"
 	"time"
@@ -73,8 +76,9 @@ func (m *MinIOStorage) Upload(ctx context.Context, req *domain.UploadRequest) (*
 		zap.String("content_type", req.ContentType),
 		zap.Int64("size", req.Size))
 
-	// Upload to MinIO/S3
-	info, err := m.client.PutObject(ctx, m.config.Bucket, key, req.File, req.Size, minio.PutObjectOptions{
+	// Upload to MinIO/S3, hashing the content on the way
+	hash := sha256.New()
+	info, err := m.client.PutObject(ctx, m.config.Bucket, key, io.TeeReader(req.File, hash), req.Size, minio.PutObjectOptions{
 		ContentType: req.ContentType,
 	})
 	if err != nil {
@@ -95,6 +99,7 @@ func (m *MinIOStorage) Upload(ctx context.Context, req *domain.UploadRequest) (*
 		Size:        info.Size,
 		UploadedAt:  time.Now(),
 		Bucket:      m.config.Bucket,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
 	}
 
 	m.logger.Info("MinIO upload successful",
@@ -128,6 +133,9 @@ func (m *MinIOStorage) Delete(ctx context.Context, key string) error {
 func (m *MinIOStorage) GetFileInfo(ctx context.Context, key string) (*domain.FileInfo, error) {
 	objInfo, err := m.client.StatObject(ctx, m.config.Bucket, key, minio.StatObjectOptions{})
 	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, domain.ErrFileNotFound
+		}
 		m.logger.Error("Failed to get file info from MinIO", zap.Error(err), zap.String("key", key))
 		return nil, fmt.Errorf("failed to get file info: %w", err)
 	}
@@ -148,11 +156,16 @@ func (m *MinIOStorage) GetFileInfo(ctx context.Context, key string) (*domain.Fil
 	return fileInfo, nil
 }
 
-// ListFiles lists files in a folder
-func (m *MinIOStorage) ListFiles(ctx context.Context, folder string, limit int) ([]*domain.FileInfo, error) {
+// ListFiles lists files in a folder, starting after the key startAfter
+func (m *MinIOStorage) ListFiles(ctx context.Context, folder string, startAfter string, limit int) ([]*domain.FileInfo, error) {
+	// Stop listing once enough objects were read
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	objectCh := m.client.ListObjects(ctx, m.config.Bucket, minio.ListObjectsOptions{
-		Prefix:    folder,
-		Recursive: true,
+		Prefix:     folder,
+		Recursive:  true,
+		StartAfter: startAfter,
 	})
 
 	var files []*domain.FileInfo
@@ -209,6 +222,129 @@ func (m *MinIOStorage) GeneratePresignedDownloadURL(ctx context.Context, key str
 	return url.String(), nil
 }
 
+// GetObject opens a file stored in MinIO/S3 for reading
+func (m *MinIOStorage) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := m.client.GetObject(ctx, m.config.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		m.logger.Error("Failed to get object from MinIO", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	// GetObject is lazy; stat the object so a missing key fails here rather than on first read
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, domain.ErrFileNotFound
+		}
+		m.logger.Error("Failed to stat object in MinIO", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+
+	return object, nil
+}
+
+// PutObject stores a file in MinIO/S3 under an exact key
+func (m *MinIOStorage) PutObject(ctx context.Context, key string, file io.Reader, size int64, contentType string) (*domain.FileInfo, error) {
+	hash := sha256.New()
+	info, err := m.client.PutObject(ctx, m.config.Bucket, key, io.TeeReader(file, hash), size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		m.logger.Error("Failed to put object to MinIO", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	return &domain.FileInfo{
+		Key:         key,
+		URL:         key,
+		Filename:    filepath.Base(key),
+		ContentType: contentType,
+		Size:        info.Size,
+		UploadedAt:  time.Now(),
+		Bucket:      m.config.Bucket,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// Ping checks that the bucket can be reached
+func (m *MinIOStorage) Ping(ctx context.Context) error {
+	exists, err := m.client.BucketExists(ctx, m.config.Bucket)
+	if err != nil {
+		return fmt.Errorf("failed to reach bucket: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("bucket %s does not exist", m.config.Bucket)
+	}
+	return nil
+}
+
+// CreateMultipartUpload starts an S3 multipart upload
+func (m *MinIOStorage) CreateMultipartUpload(ctx context.Context, key string, contentType string) (string, error) {
+	uploadID, err := m.core().NewMultipartUpload(ctx, m.config.Bucket, key, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	if err != nil {
+		m.logger.Error("Failed to start multipart upload in MinIO", zap.Error(err), zap.String("key", key))
+		return "", fmt.Errorf("failed to start upload: %w", err)
+	}
+	return uploadID, nil
+}
+
+// UploadPart uploads a part of an S3 multipart upload
+func (m *MinIOStorage) UploadPart(ctx context.Context, key, uploadID string, partNumber int, data io.Reader, size int64) (*domain.UploadedPart, error) {
+	part, err := m.core().PutObjectPart(ctx, m.config.Bucket, key, uploadID, partNumber, data, size, minio.PutObjectPartOptions{})
+	if err != nil {
+		m.logger.Error("Failed to upload part to MinIO", zap.Error(err), zap.String("key", key), zap.Int("part", partNumber))
+		return nil, fmt.Errorf("failed to upload part: %w", err)
+	}
+	return &domain.UploadedPart{Number: part.PartNumber, ETag: part.ETag}, nil
+}
+
+// CompleteMultipartUpload completes an S3 multipart upload
+func (m *MinIOStorage) CompleteMultipartUpload(ctx context.Context, key, uploadID string, parts []*domain.UploadedPart) (*domain.FileInfo, error) {
+	completeParts := make([]minio.CompletePart, 0, len(parts))
+	for _, part := range parts {
+		completeParts = append(completeParts, minio.CompletePart{PartNumber: part.Number, ETag: part.ETag})
+	}
+
+	info, err := m.core().CompleteMultipartUpload(ctx, m.config.Bucket, key, uploadID, completeParts, minio.PutObjectOptions{})
+	if err != nil {
+		m.logger.Error("Failed to complete multipart upload in MinIO", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to complete upload: %w", err)
+	}
+
+	// The object is assembled by MinIO, so its size is read back rather than counted
+	objInfo, err := m.client.StatObject(ctx, m.config.Bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		m.logger.Error("Failed to stat uploaded object in MinIO", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to get file info: %w", err)
+	}
+
+	return &domain.FileInfo{
+		Key:         key,
+		URL:         key,
+		Filename:    filepath.Base(key),
+		ContentType: objInfo.ContentType,
+		Size:        objInfo.Size,
+		UploadedAt:  info.LastModified,
+		Bucket:      m.config.Bucket,
+	}, nil
+}
+
+// AbortMultipartUpload aborts an S3 multipart upload, deleting its parts
+func (m *MinIOStorage) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	if err := m.core().AbortMultipartUpload(ctx, m.config.Bucket, key, uploadID); err != nil {
+		m.logger.Error("Failed to abort multipart upload in MinIO", zap.Error(err), zap.String("key", key))
+		return fmt.Errorf("failed to abort upload: %w", err)
+	}
+	return nil
+}
+
+// core exposes the low-level S3 calls that multipart uploads need
+func (m *MinIOStorage) core() minio.Core {
+	return minio.Core{Client: m.client}
+}
+
 // generateKey creates a unique key for the file
 func (m *MinIOStorage) generateKey(folder, userID, filename string) string {
 	// Clean filename
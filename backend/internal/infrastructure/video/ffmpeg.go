@@ -0,0 +1,133 @@
+// Package video inspects videos and extracts frames from them with the ffprobe and
+// ffmpeg command line tools.
+package video
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Info describes a video
+type Info struct {
+	Duration time.Duration
+	Width    int // as displayed, after rotation
+	Height   int
+}
+
+// FFmpeg runs the ffprobe and ffmpeg binaries
+type FFmpeg struct {
+	ffmpegPath  string
+	ffprobePath string
+}
+
+// NewFFmpeg creates a new FFmpeg with the paths of the binaries, which may be bare
+// names looked up in PATH
+func NewFFmpeg(ffmpegPath, ffprobePath string) *FFmpeg {
+	return &FFmpeg{
+		ffmpegPath:  ffmpegPath,
+		ffprobePath: ffprobePath,
+	}
+}
+
+type probeOutput struct {
+	Streams []struct {
+		Width        int               `json:"width"`
+		Height       int               `json:"height"`
+		Duration     string            `json:"duration"`
+		Tags         map[string]string `json:"tags"`
+		SideDataList []struct {
+			Rotation float64 `json:"rotation"`
+		} `json:"side_data_list"`
+	} `json:"streams"`
+	Format struct {
+		Duration string `json:"duration"`
+	} `json:"format"`
+}
+
+// Probe reads the duration and dimensions of the video file at path
+func (f *FFmpeg) Probe(ctx context.Context, path string) (*Info, error) {
+	out, err := f.run(ctx, f.ffprobePath,
+		"-v", "error",
+		"-select_streams", "v:0",
+		"-show_entries", "stream=width,height,duration:stream_tags=rotate:stream_side_data=rotation:format=duration",
+		"-of", "json",
+		path)
+	if err != nil {
+		return nil, err
+	}
+
+	var probe probeOutput
+	if err := json.Unmarshal(out, &probe); err != nil {
+		return nil, fmt.Errorf("failed to parse ffprobe output: %w", err)
+	}
+	if len(probe.Streams) == 0 {
+		return nil, fmt.Errorf("file has no video stream")
+	}
+	stream := probe.Streams[0]
+
+	// The container's duration covers every stream; fall back to the video stream's
+	duration := probe.Format.Duration
+	if duration == "" || duration == "N/A" {
+		duration = stream.Duration
+	}
+	seconds, err := strconv.ParseFloat(duration, 64)
+	if err != nil {
+		return nil, fmt.Errorf("video has no duration")
+	}
+
+	info := &Info{
+		Duration: time.Duration(seconds * float64(time.Second)),
+		Width:    stream.Width,
+		Height:   stream.Height,
+	}
+
+	// Phones record portrait videos as rotated landscape ones
+	rotation, _ := strconv.ParseFloat(stream.Tags["rotate"], 64)
+	for _, sideData := range stream.SideDataList {
+		if sideData.Rotation != 0 {
+			rotation = sideData.Rotation
+		}
+	}
+	if int(rotation)%180 != 0 {
+		info.Width, info.Height = info.Height, info.Width
+	}
+
+	return info, nil
+}
+
+// Frame returns the frame of the video file at path shown at offset, turned upright
+// and encoded as JPEG
+func (f *FFmpeg) Frame(ctx context.Context, path string, offset time.Duration) ([]byte, error) {
+	return f.run(ctx, f.ffmpegPath,
+		"-v", "error",
+		"-ss", strconv.FormatFloat(offset.Seconds(), 'f', 3, 64),
+		"-i", path,
+		"-frames:v", "1",
+		"-f", "image2pipe",
+		"-c:v", "mjpeg",
+		"-q:v", "3",
+		"pipe:1")
+}
+
+// run runs a binary and returns what it wrote to stdout
+func (f *FFmpeg) run(ctx context.Context, name string, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%s failed: %w: %s", name, err, message)
+		}
+		return nil, fmt.Errorf("%s failed: %w", name, err)
+	}
+
+	return stdout.Bytes(), nil
+}
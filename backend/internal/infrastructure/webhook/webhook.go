@@ -0,0 +1,296 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+// Headers sent with every delivery
+const (
+	EventHeader     = "X-EraLove-Event"
+	DeliveryHeader  = "X-EraLove-Delivery"
+	TimestampHeader = "X-EraLove-Timestamp"
+	SignatureHeader = "X-EraLove-Signature"
+)
+
+// maxRetryDelay caps the wait between two attempts to deliver an event
+const maxRetryDelay = 5 * time.Minute
+
+// Config configures a webhook dispatcher
+type Config struct {
+	URLs        []string      // endpoints every event is POSTed to
+	Secret      string        // key the payloads are signed with
+	Workers     int           // deliveries made at the same time
+	Size        int           // deliveries that can wait to be made
+	MaxAttempts int           // attempts before a delivery is given up on
+	RetryDelay  time.Duration // wait before the first retry, doubled for each further one
+	Timeout     time.Duration // time an endpoint has to respond
+}
+
+// delivery is an event waiting to be POSTed to one endpoint
+type delivery struct {
+	url      string
+	event    *domain.DomainEvent
+	body     []byte
+	queuedAt time.Time
+}
+
+// deliveryError is a failed delivery attempt
+type deliveryError struct {
+	statusCode int
+	temporary  bool
+	err        error
+}
+
+func (e *deliveryError) Error() string {
+	if e.err != nil {
+		return e.err.Error()
+	}
+	return fmt.Sprintf("endpoint responded with status %d", e.statusCode)
+}
+
+// Dispatcher POSTs domain events to the configured endpoints in the background,
+// retrying failures with exponential backoff. Each payload is signed so endpoints can
+// check that it comes from the app:
+//
+//	X-EraLove-Signature: sha256=hex(HMAC-SHA256(secret, TIMESTAMP + "." + body))
+//
+// where TIMESTAMP is the unix time sent in X-EraLove-Timestamp. Endpoints should
+// reject old timestamps and use X-EraLove-Delivery to ignore redeliveries.
+type Dispatcher struct {
+	config     Config
+	httpClient *http.Client
+	logger     *zap.Logger
+
+	jobs   chan *delivery
+	mu     sync.RWMutex
+	closed bool
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDispatcher creates a dispatcher and starts its workers
+func NewDispatcher(config Config, logger *zap.Logger) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &Dispatcher{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+		logger:     logger,
+		jobs:       make(chan *delivery, config.Size),
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+
+	for i := 0; i < config.Workers; i++ {
+		d.wg.Add(1)
+		go d.work()
+	}
+
+	logger.Info("Webhook dispatcher started",
+		zap.Int("endpoints", len(config.URLs)),
+		zap.Int("workers", config.Workers))
+
+	return d
+}
+
+// Handle queues the event for every endpoint without waiting for it to be delivered.
+// It is meant to be subscribed to the event bus.
+func (d *Dispatcher) Handle(ctx context.Context, event *domain.DomainEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		d.logger.Error("Failed to encode webhook payload",
+			zap.Error(err),
+			zap.String("event_id", event.ID),
+			zap.String("event_type", string(event.Type)))
+		return
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		d.logger.Warn("Webhook dispatcher is closed, event dropped",
+			zap.String("event_id", event.ID),
+			zap.String("event_type", string(event.Type)))
+		return
+	}
+
+	for _, url := range d.config.URLs {
+		select {
+		case d.jobs <- &delivery{url: url, event: event, body: body, queuedAt: time.Now()}:
+		default:
+			d.logger.Error("Webhook queue is full, event dropped",
+				zap.String("event_id", event.ID),
+				zap.String("event_type", string(event.Type)),
+				zap.String("url", url))
+		}
+	}
+}
+
+// Shutdown stops accepting events and waits for the queued ones to be delivered.
+// When ctx is done first, the deliveries still waiting are given up on.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return nil
+	}
+	d.closed = true
+	close(d.jobs)
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		d.cancel()
+		d.logger.Info("Webhook dispatcher drained")
+		return nil
+	case <-ctx.Done():
+		// Workers stop retrying and give up on what is left
+		d.cancel()
+		<-done
+		d.logger.Warn("Webhook dispatcher shut down before it was drained")
+		return ctx.Err()
+	}
+}
+
+// work makes queued deliveries until the dispatcher is closed and empty
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+
+	for job := range d.jobs {
+		d.deliver(job)
+	}
+}
+
+// deliver POSTs an event, retrying temporary failures until MaxAttempts is reached
+func (d *Dispatcher) deliver(job *delivery) {
+	for attempt := 1; ; attempt++ {
+		if d.ctx.Err() != nil {
+			d.giveUp(job, attempt-1, errors.New("dispatcher shut down"))
+			return
+		}
+
+		err := d.post(job)
+		if err == nil {
+			d.logger.Info("Webhook delivered",
+				zap.String("event_id", job.event.ID),
+				zap.String("event_type", string(job.event.Type)),
+				zap.String("url", job.url),
+				zap.Int("attempt", attempt))
+			return
+		}
+
+		var deliveryErr *deliveryError
+		temporary := !errors.As(err, &deliveryErr) || deliveryErr.temporary
+		if !temporary || attempt >= d.config.MaxAttempts {
+			d.giveUp(job, attempt, err)
+			return
+		}
+
+		delay := d.retryDelay(attempt)
+		d.logger.Warn("Failed to deliver webhook, retrying",
+			zap.Error(err),
+			zap.String("event_id", job.event.ID),
+			zap.String("url", job.url),
+			zap.Int("attempt", attempt),
+			zap.Duration("retry_in", delay))
+
+		select {
+		case <-time.After(delay):
+		case <-d.ctx.Done():
+		}
+	}
+}
+
+// post makes one delivery attempt. Any 2xx response is a success; other client
+// errors are permanent, except for timeouts and rate limiting.
+func (d *Dispatcher) post(job *delivery) error {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, job.url, bytes.NewReader(job.body))
+	if err != nil {
+		return &deliveryError{err: err}
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "EraLove-Webhooks/1.0")
+	req.Header.Set(EventHeader, string(job.event.Type))
+	req.Header.Set(DeliveryHeader, job.event.ID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Sign([]byte(d.config.Secret), timestamp, job.body))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return &deliveryError{temporary: true, err: err}
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	return &deliveryError{
+		statusCode: resp.StatusCode,
+		temporary: resp.StatusCode >= 500 ||
+			resp.StatusCode == http.StatusRequestTimeout ||
+			resp.StatusCode == http.StatusTooManyRequests,
+	}
+}
+
+// retryDelay returns the wait after the given failed attempt: RetryDelay doubled for
+// each previous attempt, capped at maxRetryDelay, with up to 20% jitter
+func (d *Dispatcher) retryDelay(attempt int) time.Duration {
+	delay := d.config.RetryDelay
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay + time.Duration(rand.Int63n(int64(delay)/5+1))
+}
+
+// giveUp logs a delivery that will not be made
+func (d *Dispatcher) giveUp(job *delivery, attempts int, err error) {
+	fields := []zap.Field{
+		zap.Error(err),
+		zap.String("event_id", job.event.ID),
+		zap.String("event_type", string(job.event.Type)),
+		zap.String("url", job.url),
+		zap.Int("attempts", attempts),
+		zap.Time("queued_at", job.queuedAt),
+	}
+	var deliveryErr *deliveryError
+	if errors.As(err, &deliveryErr) && deliveryErr.statusCode != 0 {
+		fields = append(fields, zap.Int("status", deliveryErr.statusCode))
+	}
+	d.logger.Error("Webhook delivery given up", fields...)
+}
+
+// Sign computes the hex HMAC-SHA256 of a payload and the timestamp it is sent at
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
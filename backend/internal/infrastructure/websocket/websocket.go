@@ -0,0 +1,301 @@
+// Package websocket serves WebSocket connections (RFC 6455) from Fiber handlers.
+// Only what the app needs is supported: text messages of limited size, without
+// extensions or subprotocols.
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// acceptGUID is appended to the client key to compute Sec-WebSocket-Accept
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Frame opcodes
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+// Close status codes
+const (
+	CloseNormal          = 1000
+	CloseGoingAway       = 1001
+	CloseProtocol        = 1002
+	ClosePolicyViolation = 1008
+	CloseTooLarge        = 1009
+	CloseInternalError   = 1011
+)
+
+const (
+	// MaxMessageSize is the largest message read from a client, in bytes
+	MaxMessageSize = 4096
+	// writeTimeout bounds how long a write may block on a slow client
+	writeTimeout = 10 * time.Second
+)
+
+var (
+	// ErrClosed is returned by ReadMessage once the client closed the connection
+	ErrClosed = errors.New("websocket: connection closed")
+	// ErrMessageTooLarge is returned when a client message exceeds MaxMessageSize
+	ErrMessageTooLarge = errors.New("websocket: message too large")
+)
+
+// IsUpgrade reports whether the request asks to upgrade to a WebSocket connection
+func IsUpgrade(c *fiber.Ctx) bool {
+	return headerContains(c.Get(fiber.HeaderConnection), "upgrade") &&
+		strings.EqualFold(c.Get(fiber.HeaderUpgrade), "websocket")
+}
+
+// Upgrade completes the opening handshake and serves the connection with handler once
+// the Fiber handler returns. handler must not use c, which is released by then, and
+// the connection is closed when handler returns.
+func Upgrade(c *fiber.Ctx, handler func(*Conn)) error {
+	if !IsUpgrade(c) {
+		return fiber.ErrUpgradeRequired
+	}
+
+	key := c.Get("Sec-WebSocket-Key")
+	if c.Method() != fiber.MethodGet || key == "" || c.Get("Sec-WebSocket-Version") != "13" {
+		c.Set("Sec-WebSocket-Version", "13")
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid WebSocket handshake")
+	}
+
+	c.Set(fiber.HeaderUpgrade, "websocket")
+	c.Set(fiber.HeaderConnection, fiber.HeaderUpgrade)
+	c.Set("Sec-WebSocket-Accept", acceptKey(key))
+	c.Status(fiber.StatusSwitchingProtocols)
+
+	c.Context().Hijack(func(netConn net.Conn) {
+		handler(&Conn{conn: netConn, reader: bufio.NewReader(netConn)})
+	})
+	return nil
+}
+
+// Conn is a server side WebSocket connection. Reads must come from one goroutine;
+// writes may come from any.
+type Conn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	readTimeout time.Duration
+
+	writeMu sync.Mutex
+	closed  bool
+}
+
+// SetReadTimeout makes ReadMessage fail once no frame, pongs included, arrived for
+// timeout. Zero waits forever.
+func (c *Conn) SetReadTimeout(timeout time.Duration) {
+	c.readTimeout = timeout
+}
+
+// ReadMessage returns the next text or binary message. Pings are answered and pongs
+// skipped on the way. Once the client closes the connection ErrClosed is returned.
+func (c *Conn) ReadMessage() ([]byte, error) {
+	var message []byte
+	fragmented := false
+
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			if errors.Is(err, ErrMessageTooLarge) {
+				c.Close(CloseTooLarge)
+			} else if !errors.Is(err, io.EOF) && !errors.Is(err, ErrClosed) && !isTimeout(err) {
+				c.Close(CloseProtocol)
+			}
+			return nil, err
+		}
+
+		switch opcode {
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return nil, err
+			}
+		case opPong:
+		case opClose:
+			c.Close(CloseNormal)
+			return nil, ErrClosed
+		case opText, opBinary, opContinuation:
+			if (opcode == opContinuation) != fragmented {
+				c.Close(CloseProtocol)
+				return nil, fmt.Errorf("websocket: unexpected opcode %#x", opcode)
+			}
+			if len(message)+len(payload) > MaxMessageSize {
+				c.Close(CloseTooLarge)
+				return nil, ErrMessageTooLarge
+			}
+			message = append(message, payload...)
+			if fin {
+				return message, nil
+			}
+			fragmented = true
+		default:
+			c.Close(CloseProtocol)
+			return nil, fmt.Errorf("websocket: unknown opcode %#x", opcode)
+		}
+	}
+}
+
+// WriteMessage sends a text message
+func (c *Conn) WriteMessage(data []byte) error {
+	return c.writeFrame(opText, data)
+}
+
+// Ping sends a ping, which clients answer with a pong
+func (c *Conn) Ping() error {
+	return c.writeFrame(opPing, nil)
+}
+
+// Close sends a close frame with the status code, once, and fails pending and later
+// reads. The connection itself is closed when the handler passed to Upgrade returns.
+func (c *Conn) Close(code int) {
+	payload := make([]byte, 2)
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	_ = c.writeFrame(opClose, payload)
+
+	c.writeMu.Lock()
+	c.closed = true
+	c.writeMu.Unlock()
+	_ = c.conn.SetReadDeadline(time.Now())
+}
+
+// isClosed reports whether Close was called
+func (c *Conn) isClosed() bool {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.closed
+}
+
+// readFrame reads one frame and unmasks its payload. Client frames must be masked.
+func (c *Conn) readFrame() (fin bool, opcode byte, payload []byte, err error) {
+	if c.readTimeout > 0 {
+		if err := c.conn.SetReadDeadline(time.Now().Add(c.readTimeout)); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	// Checked after the deadline is set so that a concurrent Close cannot be missed
+	if c.isClosed() {
+		return false, 0, nil, ErrClosed
+	}
+
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	fin = header[0]&0x80 != 0
+	if header[0]&0x70 != 0 {
+		return false, 0, nil, errors.New("websocket: reserved bits set without an extension")
+	}
+	opcode = header[0] & 0x0F
+	if header[1]&0x80 == 0 {
+		return false, 0, nil, errors.New("websocket: client frame is not masked")
+	}
+
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(ext[:])
+	}
+
+	// Control frames carry at most 125 bytes and are never fragmented
+	if opcode >= opClose && (length > 125 || !fin) {
+		return false, 0, nil, errors.New("websocket: invalid control frame")
+	}
+	if length > MaxMessageSize {
+		return false, 0, nil, ErrMessageTooLarge
+	}
+
+	var mask [4]byte
+	if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+		return false, 0, nil, err
+	}
+
+	payload = make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+
+	return fin, opcode, payload, nil
+}
+
+// writeFrame sends a single unmasked frame
+func (c *Conn) writeFrame(opcode byte, payload []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if c.closed {
+		return ErrClosed
+	}
+
+	frame := make([]byte, 0, 10+len(payload))
+	frame = append(frame, 0x80|opcode)
+	switch {
+	case len(payload) < 126:
+		frame = append(frame, byte(len(payload)))
+	case len(payload) <= 0xFFFF:
+		frame = append(frame, 126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(len(payload)))
+	default:
+		frame = append(frame, 127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(len(payload)))
+	}
+	frame = append(frame, payload...)
+
+	if err := c.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+// acceptKey returns the Sec-WebSocket-Accept value answering a Sec-WebSocket-Key
+func acceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// headerContains reports whether a comma-separated header lists token
+func headerContains(header, token string) bool {
+	for _, value := range strings.Split(header, ",") {
+		if strings.EqualFold(strings.TrimSpace(value), token) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTimeout reports whether err is a deadline being reached
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
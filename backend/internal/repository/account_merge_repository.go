@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// AccountMergeRepository implements domain.AccountMergeRepository
+type AccountMergeRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewAccountMergeRepository creates a new account merge repository
+func NewAccountMergeRepository(db *mongo.Database, logger *zap.Logger) domain.AccountMergeRepository {
+	return &AccountMergeRepository{
+		collection: db.Collection("account_merges"),
+		logger:     logger,
+	}
+}
+
+// Replace deletes the surviving user's pending merges and stores the new one
+func (r *AccountMergeRepository) Replace(ctx context.Context, merge *domain.AccountMerge) error {
+	filter := bson.M{"surviving_user_id": merge.SurvivingUserID, "status": domain.AccountMergeStatusPending}
+	if _, err := r.collection.DeleteMany(ctx, filter); err != nil {
+		r.logger.Error("Failed to delete previous account merges", zap.Error(err))
+		return fmt.Errorf("failed to delete previous account merges: %w", err)
+	}
+
+	merge.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, merge)
+	if err != nil {
+		r.logger.Error("Failed to create account merge", zap.Error(err))
+		return fmt.Errorf("failed to create account merge: %w", err)
+	}
+
+	merge.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetPending retrieves the surviving user's pending merge that has not expired
+func (r *AccountMergeRepository) GetPending(ctx context.Context, survivingUserID primitive.ObjectID, now time.Time) (*domain.AccountMerge, error) {
+	filter := bson.M{
+		"surviving_user_id": survivingUserID,
+		"status":            domain.AccountMergeStatusPending,
+		"expires_at":        bson.M{"$gt": now},
+	}
+
+	var merge domain.AccountMerge
+	if err := r.collection.FindOne(ctx, filter).Decode(&merge); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("account merge not found")
+		}
+		r.logger.Error("Failed to get account merge", zap.Error(err))
+		return nil, fmt.Errorf("failed to get account merge: %w", err)
+	}
+
+	return &merge, nil
+}
+
+// IncrementAttempts counts a wrong code and returns the attempts made so far
+func (r *AccountMergeRepository) IncrementAttempts(ctx context.Context, id primitive.ObjectID) (int, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var merge domain.AccountMerge
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"attempts": 1}}, opts).Decode(&merge)
+	if err != nil {
+		r.logger.Error("Failed to count account merge attempt", zap.Error(err))
+		return 0, fmt.Errorf("failed to count account merge attempt: %w", err)
+	}
+
+	return merge.Attempts, nil
+}
+
+// Delete removes a pending merge
+func (r *AccountMergeRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		r.logger.Error("Failed to delete account merge", zap.Error(err))
+		return fmt.Errorf("failed to delete account merge: %w", err)
+	}
+	return nil
+}
+
+// Complete records what a merge moved. The code hash is dropped, as the record is only
+// kept for auditing.
+func (r *AccountMergeRepository) Complete(ctx context.Context, id primitive.ObjectID, moved *domain.AccountMergeCounts, completedAt time.Time) error {
+	update := bson.M{
+		"$set": bson.M{
+			"status":       domain.AccountMergeStatusCompleted,
+			"moved":        moved,
+			"completed_at": completedAt,
+		},
+		"$unset": bson.M{"code_hash": ""},
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		r.logger.Error("Failed to complete account merge", zap.Error(err))
+		return fmt.Errorf("failed to complete account merge: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// AffirmationRepository implements domain.AffirmationRepository
+type AffirmationRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewAffirmationRepository creates a new affirmation repository
+func NewAffirmationRepository(db *mongo.Database, logger *zap.Logger) domain.AffirmationRepository {
+	return &AffirmationRepository{
+		collection: db.Collection("affirmations"),
+		logger:     logger,
+	}
+}
+
+// Create creates a new affirmation
+func (r *AffirmationRepository) Create(ctx context.Context, affirmation *domain.Affirmation) error {
+	affirmation.CreatedAt = time.Now()
+	affirmation.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, affirmation)
+	if err != nil {
+		r.logger.Error("Failed to create affirmation", zap.Error(err))
+		return fmt.Errorf("failed to create affirmation: %w", err)
+	}
+
+	affirmation.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves an affirmation by ID
+func (r *AffirmationRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.Affirmation, error) {
+	var affirmation domain.Affirmation
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&affirmation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("affirmation not found")
+		}
+		r.logger.Error("Failed to get affirmation by ID", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to get affirmation: %w", err)
+	}
+
+	return &affirmation, nil
+}
+
+// GetByRecipient retrieves delivered affirmations for a recipient, newest first
+func (r *AffirmationRepository) GetByRecipient(ctx context.Context, recipientID primitive.ObjectID, limit, offset int) ([]*domain.Affirmation, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "delivered_at", Value: -1}})
+
+	return r.find(ctx, r.recipientFilter(recipientID), opts)
+}
+
+// CountByRecipient counts delivered affirmations for a recipient
+func (r *AffirmationRepository) CountByRecipient(ctx context.Context, recipientID primitive.ObjectID) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, r.recipientFilter(recipientID))
+	if err != nil {
+		r.logger.Error("Failed to count received affirmations", zap.Error(err), zap.String("recipient_id", recipientID.Hex()))
+		return 0, fmt.Errorf("failed to count affirmations: %w", err)
+	}
+	return count, nil
+}
+
+// GetByCreator retrieves affirmations recorded by a user, newest first
+func (r *AffirmationRepository) GetByCreator(ctx context.Context, creatorID primitive.ObjectID, limit, offset int) ([]*domain.Affirmation, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	return r.find(ctx, r.creatorFilter(creatorID), opts)
+}
+
+// CountByCreator counts affirmations recorded by a user
+func (r *AffirmationRepository) CountByCreator(ctx context.Context, creatorID primitive.ObjectID) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, r.creatorFilter(creatorID))
+	if err != nil {
+		r.logger.Error("Failed to count sent affirmations", zap.Error(err), zap.String("created_by", creatorID.Hex()))
+		return 0, fmt.Errorf("failed to count affirmations: %w", err)
+	}
+	return count, nil
+}
+
+// GetDueForDelivery retrieves queued affirmations scheduled at or before the given time
+func (r *AffirmationRepository) GetDueForDelivery(ctx context.Context, before time.Time) ([]*domain.Affirmation, error) {
+	filter := bson.M{
+		"status":        domain.AffirmationStatusQueued,
+		"scheduled_for": bson.M{"$lte": before},
+		"deleted_at":    bson.M{"$exists": false},
+	}
+
+	return r.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "scheduled_for", Value: 1}}))
+}
+
+// MarkDelivered marks a queued affirmation as delivered
+func (r *AffirmationRepository) MarkDelivered(ctx context.Context, id primitive.ObjectID, deliveredAt time.Time) error {
+	filter := bson.M{
+		"_id":        id,
+		"status":     domain.AffirmationStatusQueued,
+		"deleted_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       domain.AffirmationStatusDelivered,
+			"delivered_at": deliveredAt,
+			"updated_at":   time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to mark affirmation as delivered", zap.Error(err))
+		return fmt.Errorf("failed to mark affirmation as delivered: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("affirmation not found")
+	}
+
+	return nil
+}
+
+// RecordPlay increments the play count of an affirmation
+func (r *AffirmationRepository) RecordPlay(ctx context.Context, id primitive.ObjectID, playedAt time.Time) error {
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$inc": bson.M{"play_count": 1},
+		"$set": bson.M{
+			"last_played_at": playedAt,
+			"updated_at":     time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to record affirmation play", zap.Error(err))
+		return fmt.Errorf("failed to record affirmation play: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("affirmation not found")
+	}
+
+	return nil
+}
+
+// Delete soft deletes an affirmation
+func (r *AffirmationRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"deleted_at": now,
+			"updated_at": now,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to delete affirmation", zap.Error(err))
+		return fmt.Errorf("failed to delete affirmation: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("affirmation not found")
+	}
+
+	return nil
+}
+
+// find runs a query and decodes the matching affirmations
+func (r *AffirmationRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*domain.Affirmation, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get affirmations", zap.Error(err))
+		return nil, fmt.Errorf("failed to get affirmations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var affirmations []*domain.Affirmation
+	if err := cursor.All(ctx, &affirmations); err != nil {
+		r.logger.Error("Failed to decode affirmations", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode affirmations: %w", err)
+	}
+
+	return affirmations, nil
+}
+
+// recipientFilter builds the filter for affirmations already delivered to a recipient
+func (r *AffirmationRepository) recipientFilter(recipientID primitive.ObjectID) bson.M {
+	return bson.M{
+		"recipient_id": recipientID,
+		"status":       domain.AffirmationStatusDelivered,
+		"deleted_at":   bson.M{"$exists": false},
+	}
+}
+
+// creatorFilter builds the filter for affirmations recorded by a user
+func (r *AffirmationRepository) creatorFilter(creatorID primitive.ObjectID) bson.M {
+	return bson.M{
+		"created_by": creatorID,
+		"deleted_at": bson.M{"$exists": false},
+	}
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// AlbumRepository implements domain.AlbumRepository
+type AlbumRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewAlbumRepository creates a new album repository
+func NewAlbumRepository(db *mongo.Database, logger *zap.Logger) domain.AlbumRepository {
+	return &AlbumRepository{
+		collection: db.Collection("albums"),
+		logger:     logger,
+	}
+}
+
+// Create creates a new album
+func (r *AlbumRepository) Create(ctx context.Context, album *domain.Album) error {
+	album.CreatedAt = time.Now()
+	album.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, album)
+	if err != nil {
+		r.logger.Error("Failed to create album", zap.Error(err))
+		return fmt.Errorf("failed to create album: %w", err)
+	}
+
+	album.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves an album by ID
+func (r *AlbumRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.Album, error) {
+	var album domain.Album
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&album)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("album not found")
+		}
+		r.logger.Error("Failed to get album by ID", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to get album: %w", err)
+	}
+
+	return &album, nil
+}
+
+// GetByMatchCode retrieves all albums of a couple ordered by position
+func (r *AlbumRepository) GetByMatchCode(ctx context.Context, matchCode string) ([]*domain.Album, error) {
+	filter := bson.M{
+		"match_code": matchCode,
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "position", Value: 1}, {Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get albums by match code", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get albums: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var albums []*domain.Album
+	if err := cursor.All(ctx, &albums); err != nil {
+		r.logger.Error("Failed to decode albums", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode albums: %w", err)
+	}
+
+	return albums, nil
+}
+
+// CountByMatchCode counts albums of a couple
+func (r *AlbumRepository) CountByMatchCode(ctx context.Context, matchCode string) (int64, error) {
+	filter := bson.M{
+		"match_code": matchCode,
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to count albums", zap.Error(err), zap.String("match_code", matchCode))
+		return 0, fmt.Errorf("failed to count albums: %w", err)
+	}
+
+	return count, nil
+}
+
+// Update updates an album
+func (r *AlbumRepository) Update(ctx context.Context, id primitive.ObjectID, album *domain.Album) error {
+	album.UpdatedAt = time.Now()
+
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	update := bson.M{
+		"$set": album,
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to update album", zap.Error(err))
+		return fmt.Errorf("failed to update album: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("album not found")
+	}
+
+	return nil
+}
+
+// UpdatePositions sets each album's position to its index in albumIDs
+func (r *AlbumRepository) UpdatePositions(ctx context.Context, matchCode string, albumIDs []primitive.ObjectID) error {
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(albumIDs))
+	for position, id := range albumIDs {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{
+				"_id":        id,
+				"match_code": matchCode,
+				"deleted_at": bson.M{"$exists": false},
+			}).
+			SetUpdate(bson.M{
+				"$set": bson.M{"position": position, "updated_at": now},
+			}))
+	}
+
+	if len(models) == 0 {
+		return nil
+	}
+
+	if _, err := r.collection.BulkWrite(ctx, models); err != nil {
+		r.logger.Error("Failed to update album positions", zap.Error(err), zap.String("match_code", matchCode))
+		return fmt.Errorf("failed to reorder albums: %w", err)
+	}
+
+	return nil
+}
+
+// ClearCover removes the cover photo of an album
+func (r *AlbumRepository) ClearCover(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	update := bson.M{
+		"$unset": bson.M{"cover_photo_id": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+		r.logger.Error("Failed to clear album cover", zap.Error(err), zap.String("album_id", id.Hex()))
+		return fmt.Errorf("failed to clear album cover: %w", err)
+	}
+
+	return nil
+}
+
+// Delete soft deletes an album
+func (r *AlbumRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"deleted_at": now,
+			"updated_at": now,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to delete album", zap.Error(err))
+		return fmt.Errorf("failed to delete album: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("album not found")
+	}
+
+	return nil
+}
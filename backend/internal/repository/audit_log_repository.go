@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// AuditLogRepository implements domain.AuditLogRepository
+type AuditLogRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewAuditLogRepository creates a new audit log repository
+func NewAuditLogRepository(db *mongo.Database, logger *zap.Logger) domain.AuditLogRepository {
+	return &AuditLogRepository{
+		collection: db.Collection("audit_logs"),
+		logger:     logger,
+	}
+}
+
+// Create stores an audit log entry
+func (r *AuditLogRepository) Create(ctx context.Context, entry *domain.AuditLog) error {
+	entry.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, entry)
+	if err != nil {
+		r.logger.Error("Failed to create audit log entry", zap.Error(err))
+		return fmt.Errorf("failed to create audit log entry: %w", err)
+	}
+
+	entry.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// ListByUser retrieves the audit log entries of an account, newest first, starting
+// after cursor
+func (r *AuditLogRepository) ListByUser(ctx context.Context, userID primitive.ObjectID, cursor *domain.Cursor, limit int) ([]*domain.AuditLog, error) {
+	query := applyCursor(bson.M{"user_id": userID}, cursor)
+
+	result, err := r.collection.Find(ctx, query, cursorFindOptions(limit))
+	if err != nil {
+		r.logger.Error("Failed to list audit log entries", zap.Error(err))
+		return nil, fmt.Errorf("failed to list audit log entries: %w", err)
+	}
+	defer result.Close(ctx)
+
+	var entries []*domain.AuditLog
+	if err := result.All(ctx, &entries); err != nil {
+		r.logger.Error("Failed to decode audit log entries", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode audit log entries: %w", err)
+	}
+
+	return entries, nil
+}
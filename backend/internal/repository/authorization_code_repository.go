@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// AuthorizationCodeRepository implements domain.AuthorizationCodeRepository
+type AuthorizationCodeRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewAuthorizationCodeRepository creates a new authorization code repository
+func NewAuthorizationCodeRepository(db *mongo.Database, logger *zap.Logger) domain.AuthorizationCodeRepository {
+	return &AuthorizationCodeRepository{
+		collection: db.Collection("oauth_authorization_codes"),
+		logger:     logger,
+	}
+}
+
+// Create stores a new authorization code
+func (r *AuthorizationCodeRepository) Create(ctx context.Context, code *domain.AuthorizationCode) error {
+	code.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, code)
+	if err != nil {
+		r.logger.Error("Failed to create authorization code", zap.Error(err))
+		return fmt.Errorf("failed to create authorization code: %w", err)
+	}
+
+	code.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// Consume deletes and returns an unexpired authorization code. Expired codes are
+// removed by a TTL index, which may lag behind, so expiry is checked here too.
+func (r *AuthorizationCodeRepository) Consume(ctx context.Context, codeHash string, now time.Time) (*domain.AuthorizationCode, error) {
+	var code domain.AuthorizationCode
+
+	filter := bson.M{
+		"code_hash":  codeHash,
+		"expires_at": bson.M{"$gt": now},
+	}
+
+	err := r.collection.FindOneAndDelete(ctx, filter).Decode(&code)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("authorization code not found")
+		}
+		r.logger.Error("Failed to consume authorization code", zap.Error(err))
+		return nil, fmt.Errorf("failed to consume authorization code: %w", err)
+	}
+
+	return &code, nil
+}
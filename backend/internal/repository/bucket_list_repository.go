@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// BucketListRepository implements domain.BucketListRepository
+type BucketListRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewBucketListRepository creates a new bucket list repository
+func NewBucketListRepository(db *mongo.Database, logger *zap.Logger) domain.BucketListRepository {
+	return &BucketListRepository{
+		collection: db.Collection("bucket_list_items"),
+		logger:     logger,
+	}
+}
+
+// Create creates a new bucket list item
+func (r *BucketListRepository) Create(ctx context.Context, item *domain.BucketListItem) error {
+	item.CreatedAt = time.Now()
+	item.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, item)
+	if err != nil {
+		r.logger.Error("Failed to create bucket list item", zap.Error(err))
+		return fmt.Errorf("failed to create bucket list item: %w", err)
+	}
+
+	item.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves one of a couple's bucket list items by ID
+func (r *BucketListRepository) GetByID(ctx context.Context, matchCode string, id primitive.ObjectID) (*domain.BucketListItem, error) {
+	var item domain.BucketListItem
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{
+		"_id":        id,
+		"match_code": matchCode,
+	})
+
+	err := r.collection.FindOne(ctx, filter).Decode(&item)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("bucket list item not found")
+		}
+		r.logger.Error("Failed to get bucket list item by ID", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to get bucket list item: %w", err)
+	}
+
+	return &item, nil
+}
+
+// GetByMatchCode retrieves a couple's bucket list items with optional status and
+// category filters, open items first and then by target date. A limit of 0 returns
+// every item.
+func (r *BucketListRepository) GetByMatchCode(ctx context.Context, matchCode string, status domain.BucketListStatus, category domain.BucketListCategory, limit, offset int) ([]*domain.BucketListItem, error) {
+	opts := options.Find().
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "completed_at", Value: 1}, {Key: "target_date", Value: 1}, {Key: "created_at", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, r.matchCodeFilter(matchCode, status, category), opts)
+	if err != nil {
+		r.logger.Error("Failed to get bucket list items", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get bucket list items: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var items []*domain.BucketListItem
+	if err := cursor.All(ctx, &items); err != nil {
+		r.logger.Error("Failed to decode bucket list items", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode bucket list items: %w", err)
+	}
+
+	return items, nil
+}
+
+// CountByMatchCode counts a couple's bucket list items with optional status and
+// category filters
+func (r *BucketListRepository) CountByMatchCode(ctx context.Context, matchCode string, status domain.BucketListStatus, category domain.BucketListCategory) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, r.matchCodeFilter(matchCode, status, category))
+	if err != nil {
+		r.logger.Error("Failed to count bucket list items", zap.Error(err), zap.String("match_code", matchCode))
+		return 0, fmt.Errorf("failed to count bucket list items: %w", err)
+	}
+
+	return count, nil
+}
+
+// Update updates a bucket list item
+func (r *BucketListRepository) Update(ctx context.Context, item *domain.BucketListItem) error {
+	item.UpdatedAt = time.Now()
+
+	set := bson.M{
+		"title":        item.Title,
+		"description":  item.Description,
+		"category":     item.Category,
+		"photo_ids":    item.PhotoIDs,
+		"event_is_own": item.EventIsOwn,
+		"updated_at":   item.UpdatedAt,
+	}
+	unset := bson.M{}
+	setOrUnset := func(field string, value interface{}, present bool) {
+		if present {
+			set[field] = value
+		} else {
+			unset[field] = ""
+		}
+	}
+	setOrUnset("target_date", item.TargetDate, item.TargetDate != nil)
+	setOrUnset("completed_at", item.CompletedAt, item.CompletedAt != nil)
+	setOrUnset("completed_by", item.CompletedBy, item.CompletedBy != nil)
+	setOrUnset("event_id", item.EventID, item.EventID != nil)
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{"_id": item.ID})
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to update bucket list item", zap.Error(err))
+		return fmt.Errorf("failed to update bucket list item: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("bucket list item not found")
+	}
+
+	return nil
+}
+
+// Delete soft deletes a bucket list item
+func (r *BucketListRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{"_id": id})
+
+	result, err := r.collection.UpdateOne(ctx, filter, SoftDelete.CreateSoftDeleteUpdate())
+	if err != nil {
+		r.logger.Error("Failed to delete bucket list item", zap.Error(err))
+		return fmt.Errorf("failed to delete bucket list item: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("bucket list item not found")
+	}
+
+	return nil
+}
+
+// matchCodeFilter builds the filter for a couple's active bucket list items with
+// optional status and category
+func (r *BucketListRepository) matchCodeFilter(matchCode string, status domain.BucketListStatus, category domain.BucketListCategory) bson.M {
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{"match_code": matchCode})
+	switch status {
+	case domain.BucketListStatusOpen:
+		filter["completed_at"] = bson.M{"$exists": false}
+	case domain.BucketListStatusCompleted:
+		filter["completed_at"] = bson.M{"$exists": true}
+	}
+	if category != "" {
+		filter["category"] = category
+	}
+	return filter
+}
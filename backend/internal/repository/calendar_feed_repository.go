@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// CalendarFeedRepository implements domain.CalendarFeedRepository
+type CalendarFeedRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewCalendarFeedRepository creates a new calendar feed repository
+func NewCalendarFeedRepository(db *mongo.Database, logger *zap.Logger) domain.CalendarFeedRepository {
+	return &CalendarFeedRepository{
+		collection: db.Collection("calendar_feeds"),
+		logger:     logger,
+	}
+}
+
+// GetByUserID retrieves the calendar feed of a user
+func (r *CalendarFeedRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID) (*domain.CalendarFeed, error) {
+	return r.findOne(ctx, bson.M{"user_id": userID})
+}
+
+// GetByToken retrieves a calendar feed by its public token
+func (r *CalendarFeedRepository) GetByToken(ctx context.Context, token string) (*domain.CalendarFeed, error) {
+	return r.findOne(ctx, bson.M{"token": token})
+}
+
+// Replace stores feed as the user's only calendar feed, replacing any previous one
+// and with it the previous token
+func (r *CalendarFeedRepository) Replace(ctx context.Context, feed *domain.CalendarFeed) error {
+	feed.CreatedAt = time.Now()
+	feed.LastAccessedAt = nil
+
+	update := bson.M{
+		"$set": bson.M{
+			"token":      feed.Token,
+			"created_at": feed.CreatedAt,
+		},
+		"$unset": bson.M{"last_accessed_at": ""},
+	}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var stored domain.CalendarFeed
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"user_id": feed.UserID}, update, opts).Decode(&stored)
+	if err != nil {
+		r.logger.Error("Failed to replace calendar feed", zap.Error(err), zap.String("user_id", feed.UserID.Hex()))
+		return fmt.Errorf("failed to replace calendar feed: %w", err)
+	}
+
+	feed.ID = stored.ID
+	return nil
+}
+
+// DeleteByUserID removes the calendar feed of a user
+func (r *CalendarFeedRepository) DeleteByUserID(ctx context.Context, userID primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		r.logger.Error("Failed to delete calendar feed", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return fmt.Errorf("failed to delete calendar feed: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("calendar feed not found")
+	}
+
+	return nil
+}
+
+// TouchAccessed records when a calendar feed was last fetched
+func (r *CalendarFeedRepository) TouchAccessed(ctx context.Context, id primitive.ObjectID, at time.Time) error {
+	update := bson.M{
+		"$set": bson.M{"last_accessed_at": at},
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		r.logger.Error("Failed to record calendar feed access", zap.Error(err), zap.String("id", id.Hex()))
+		return fmt.Errorf("failed to record calendar feed access: %w", err)
+	}
+
+	return nil
+}
+
+// findOne retrieves a single calendar feed matching filter
+func (r *CalendarFeedRepository) findOne(ctx context.Context, filter bson.M) (*domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+	err := r.collection.FindOne(ctx, filter).Decode(&feed)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("calendar feed not found")
+		}
+		r.logger.Error("Failed to get calendar feed", zap.Error(err))
+		return nil, fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+
+	return &feed, nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// ChangelogSeenRepository implements domain.ChangelogSeenRepository
+type ChangelogSeenRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewChangelogSeenRepository creates a new changelog seen repository
+func NewChangelogSeenRepository(db *mongo.Database, logger *zap.Logger) domain.ChangelogSeenRepository {
+	return &ChangelogSeenRepository{
+		collection: db.Collection("changelog_seen"),
+		logger:     logger,
+	}
+}
+
+// GetByUserID retrieves the releases a user has seen
+func (r *ChangelogSeenRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID) (*domain.ChangelogSeen, error) {
+	var seen domain.ChangelogSeen
+	err := r.collection.FindOne(ctx, bson.M{"user_id": userID}).Decode(&seen)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("changelog seen not found")
+		}
+		r.logger.Error("Failed to get changelog seen", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return nil, fmt.Errorf("failed to get changelog seen: %w", err)
+	}
+
+	return &seen, nil
+}
+
+// AddSeenVersions records that a user has seen the given releases. Versions already
+// recorded are kept once, so concurrent calls from several devices are safe.
+func (r *ChangelogSeenRepository) AddSeenVersions(ctx context.Context, userID primitive.ObjectID, versions []string) error {
+	update := bson.M{
+		"$addToSet": bson.M{"seen_versions": bson.M{"$each": versions}},
+		"$set":      bson.M{"updated_at": time.Now()},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"user_id": userID}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		r.logger.Error("Failed to record seen releases", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return fmt.Errorf("failed to record seen releases: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// ClientErrorRepository implements domain.ClientErrorRepository
+type ClientErrorRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewClientErrorRepository creates a new client error repository
+func NewClientErrorRepository(db *mongo.Database, logger *zap.Logger) domain.ClientErrorRepository {
+	return &ClientErrorRepository{
+		collection: db.Collection("client_errors"),
+		logger:     logger,
+	}
+}
+
+// Create stores a client error report
+func (r *ClientErrorRepository) Create(ctx context.Context, clientError *domain.ClientError) error {
+	clientError.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, clientError)
+	if err != nil {
+		r.logger.Error("Failed to create client error", zap.Error(err))
+		return fmt.Errorf("failed to create client error: %w", err)
+	}
+
+	clientError.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// List retrieves client errors matching filter, newest first, starting after cursor
+func (r *ClientErrorRepository) List(ctx context.Context, filter domain.ClientErrorFilter, cursor *domain.Cursor, limit int) ([]*domain.ClientError, error) {
+	query := bson.M{}
+	if filter.Platform != "" {
+		query["platform"] = filter.Platform
+	}
+	if filter.AppVersion != "" {
+		query["app_version"] = filter.AppVersion
+	}
+	if filter.TraceID != "" {
+		query["trace_id"] = filter.TraceID
+	}
+	if filter.UserID != nil {
+		query["user_id"] = *filter.UserID
+	}
+	if filter.FatalOnly {
+		query["fatal"] = true
+	}
+	query = applyCursor(query, cursor)
+
+	result, err := r.collection.Find(ctx, query, cursorFindOptions(limit))
+	if err != nil {
+		r.logger.Error("Failed to list client errors", zap.Error(err))
+		return nil, fmt.Errorf("failed to list client errors: %w", err)
+	}
+	defer result.Close(ctx)
+
+	var clientErrors []*domain.ClientError
+	if err := result.All(ctx, &clientErrors); err != nil {
+		r.logger.Error("Failed to decode client errors", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode client errors: %w", err)
+	}
+
+	return clientErrors, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// CountdownRepository implements domain.CountdownRepository
+type CountdownRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewCountdownRepository creates a new countdown repository
+func NewCountdownRepository(db *mongo.Database, logger *zap.Logger) domain.CountdownRepository {
+	return &CountdownRepository{
+		collection: db.Collection("countdowns"),
+		logger:     logger,
+	}
+}
+
+// Create creates a new countdown
+func (r *CountdownRepository) Create(ctx context.Context, countdown *domain.Countdown) error {
+	countdown.CreatedAt = time.Now()
+	countdown.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, countdown)
+	if err != nil {
+		r.logger.Error("Failed to create countdown", zap.Error(err))
+		return fmt.Errorf("failed to create countdown: %w", err)
+	}
+
+	countdown.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves one of a couple's countdowns by ID
+func (r *CountdownRepository) GetByID(ctx context.Context, matchCode string, id primitive.ObjectID) (*domain.Countdown, error) {
+	var countdown domain.Countdown
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{
+		"_id":        id,
+		"match_code": matchCode,
+	})
+
+	err := r.collection.FindOne(ctx, filter).Decode(&countdown)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("countdown not found")
+		}
+		r.logger.Error("Failed to get countdown by ID", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to get countdown: %w", err)
+	}
+
+	return &countdown, nil
+}
+
+// GetUpcoming lists a couple's countdowns whose target date is not before from,
+// soonest first
+func (r *CountdownRepository) GetUpcoming(ctx context.Context, matchCode string, from time.Time) ([]*domain.Countdown, error) {
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{
+		"match_code":  matchCode,
+		"target_date": bson.M{"$gte": from},
+	})
+	opts := options.Find().SetSort(bson.D{{Key: "target_date", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get upcoming countdowns", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get upcoming countdowns: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var countdowns []*domain.Countdown
+	if err := cursor.All(ctx, &countdowns); err != nil {
+		r.logger.Error("Failed to decode countdowns", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode countdowns: %w", err)
+	}
+
+	return countdowns, nil
+}
+
+// CountByMatchCode counts a couple's countdowns
+func (r *CountdownRepository) CountByMatchCode(ctx context.Context, matchCode string) (int64, error) {
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{"match_code": matchCode})
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to count countdowns", zap.Error(err), zap.String("match_code", matchCode))
+		return 0, fmt.Errorf("failed to count countdowns: %w", err)
+	}
+
+	return count, nil
+}
+
+// Update updates a countdown
+func (r *CountdownRepository) Update(ctx context.Context, countdown *domain.Countdown) error {
+	countdown.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"title":       countdown.Title,
+			"emoji":       countdown.Emoji,
+			"target_date": countdown.TargetDate,
+			"updated_at":  countdown.UpdatedAt,
+		},
+	}
+
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{"_id": countdown.ID})
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to update countdown", zap.Error(err))
+		return fmt.Errorf("failed to update countdown: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("countdown not found")
+	}
+
+	return nil
+}
+
+// Delete soft deletes a countdown
+func (r *CountdownRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{"_id": id})
+
+	result, err := r.collection.UpdateOne(ctx, filter, SoftDelete.CreateSoftDeleteUpdate())
+	if err != nil {
+		r.logger.Error("Failed to delete countdown", zap.Error(err))
+		return fmt.Errorf("failed to delete countdown: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("countdown not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// CoupleBadgeRepository implements domain.CoupleBadgeRepository
+type CoupleBadgeRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewCoupleBadgeRepository creates a new couple badge repository
+func NewCoupleBadgeRepository(db *mongo.Database, logger *zap.Logger) domain.CoupleBadgeRepository {
+	return &CoupleBadgeRepository{
+		collection: db.Collection("couple_badges"),
+		logger:     logger,
+	}
+}
+
+// GetByMatchCode retrieves the badge of a couple
+func (r *CoupleBadgeRepository) GetByMatchCode(ctx context.Context, matchCode string) (*domain.CoupleBadge, error) {
+	return r.findOne(ctx, bson.M{"match_code": matchCode})
+}
+
+// GetBySlug retrieves a badge by its public slug
+func (r *CoupleBadgeRepository) GetBySlug(ctx context.Context, slug string) (*domain.CoupleBadge, error) {
+	return r.findOne(ctx, bson.M{"slug": slug})
+}
+
+// Replace stores badge as the couple's only badge, replacing any previous one and
+// with it the previous slug
+func (r *CoupleBadgeRepository) Replace(ctx context.Context, badge *domain.CoupleBadge) error {
+	badge.CreatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"slug":       badge.Slug,
+			"created_by": badge.CreatedBy,
+			"created_at": badge.CreatedAt,
+		},
+	}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var stored domain.CoupleBadge
+	err := r.collection.FindOneAndUpdate(ctx, bson.M{"match_code": badge.MatchCode}, update, opts).Decode(&stored)
+	if err != nil {
+		r.logger.Error("Failed to replace couple badge", zap.Error(err), zap.String("match_code", badge.MatchCode))
+		return fmt.Errorf("failed to replace couple badge: %w", err)
+	}
+
+	badge.ID = stored.ID
+	return nil
+}
+
+// DeleteByMatchCode removes the badge of a couple
+func (r *CoupleBadgeRepository) DeleteByMatchCode(ctx context.Context, matchCode string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"match_code": matchCode})
+	if err != nil {
+		r.logger.Error("Failed to delete couple badge", zap.Error(err), zap.String("match_code", matchCode))
+		return fmt.Errorf("failed to delete couple badge: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("couple badge not found")
+	}
+
+	return nil
+}
+
+// findOne retrieves a single couple badge matching filter
+func (r *CoupleBadgeRepository) findOne(ctx context.Context, filter bson.M) (*domain.CoupleBadge, error) {
+	var badge domain.CoupleBadge
+	err := r.collection.FindOne(ctx, filter).Decode(&badge)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("couple badge not found")
+		}
+		r.logger.Error("Failed to get couple badge", zap.Error(err))
+		return nil, fmt.Errorf("failed to get couple badge: %w", err)
+	}
+
+	return &badge, nil
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// CoupleSettingsRepository implements domain.CoupleSettingsRepository
+type CoupleSettingsRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewCoupleSettingsRepository creates a new couple settings repository
+func NewCoupleSettingsRepository(db *mongo.Database, logger *zap.Logger) domain.CoupleSettingsRepository {
+	return &CoupleSettingsRepository{
+		collection: db.Collection("couple_settings"),
+		logger:     logger,
+	}
+}
+
+// GetByMatchCode retrieves the settings of a couple
+func (r *CoupleSettingsRepository) GetByMatchCode(ctx context.Context, matchCode string) (*domain.CoupleSettings, error) {
+	var settings domain.CoupleSettings
+
+	err := r.collection.FindOne(ctx, bson.M{"match_code": matchCode}).Decode(&settings)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrCoupleSettingsNotFound
+		}
+		r.logger.Error("Failed to get couple settings", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get couple settings: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// Upsert creates or replaces the settings of a couple
+func (r *CoupleSettingsRepository) Upsert(ctx context.Context, settings *domain.CoupleSettings) error {
+	filter := bson.M{"match_code": settings.MatchCode}
+
+	if _, err := r.collection.UpdateOne(ctx, filter, r.settingsUpdate(settings), options.Update().SetUpsert(true)); err != nil {
+		r.logger.Error("Failed to upsert couple settings", zap.Error(err), zap.String("match_code", settings.MatchCode))
+		return fmt.Errorf("failed to save couple settings: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateAtRevision creates or replaces the settings of a couple only when the stored
+// ones are still at revision. Settings saved before revisions were kept are at
+// revision 0.
+func (r *CoupleSettingsRepository) UpdateAtRevision(ctx context.Context, settings *domain.CoupleSettings, revision int) error {
+	filter := bson.M{"match_code": settings.MatchCode, "revision": revision}
+	if revision == 0 {
+		filter["revision"] = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	// When the revision changed the filter matches nothing and the upsert collides
+	// with the unique match_code index
+	if _, err := r.collection.UpdateOne(ctx, filter, r.settingsUpdate(settings), options.Update().SetUpsert(true)); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrCoupleSettingsRevisionChanged
+		}
+		r.logger.Error("Failed to update couple settings", zap.Error(err), zap.String("match_code", settings.MatchCode))
+		return fmt.Errorf("failed to save couple settings: %w", err)
+	}
+
+	settings.Revision = revision + 1
+	return nil
+}
+
+// settingsUpdate sets the fields of settings and moves them to the next revision
+func (r *CoupleSettingsRepository) settingsUpdate(settings *domain.CoupleSettings) bson.M {
+	now := time.Now()
+	settings.UpdatedAt = now
+
+	return bson.M{
+		"$set": bson.M{
+			"watermark_enabled":        settings.WatermarkEnabled,
+			"watermark_text":           settings.WatermarkText,
+			"require_partner_approval": settings.RequirePartnerApproval,
+			"locale":                   settings.Locale,
+			"date_format":              settings.DateFormat,
+			"first_day_of_week":        settings.FirstDayOfWeek,
+			"hide_presence":            settings.HidePresence,
+			"disabled_auto_milestones": settings.DisabledAutoMilestones,
+			"theme":                    settings.Theme,
+			"anniversary_display":      settings.AnniversaryDisplay,
+			"reminder_defaults":        settings.ReminderDefaults,
+			"timezone":                 settings.Timezone,
+			"updated_by":               settings.UpdatedBy,
+			"updated_at":               now,
+		},
+		"$inc": bson.M{
+			"revision": 1,
+		},
+		"$setOnInsert": bson.M{
+			"created_at": now,
+		},
+	}
+}
+
+// AddEncryptionKey adds a data key version, failing when the couple already has that
+// version. The couple's settings document is created when it does not exist yet.
+func (r *CoupleSettingsRepository) AddEncryptionKey(ctx context.Context, matchCode string, key *domain.CoupleDataKey) error {
+	now := time.Now()
+	key.CreatedAt = now
+
+	filter := bson.M{
+		"match_code":              matchCode,
+		"encryption_keys.version": bson.M{"$ne": key.Version},
+	}
+	update := bson.M{
+		"$push": bson.M{"encryption_keys": key},
+		"$set":  bson.M{"updated_at": now},
+		"$setOnInsert": bson.M{
+			"created_at": now,
+		},
+	}
+
+	// When the version already exists the filter matches nothing and the upsert
+	// collides with the unique match_code index
+	if _, err := r.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrEncryptionKeyVersionExists
+		}
+		r.logger.Error("Failed to add encryption key", zap.Error(err), zap.String("match_code", matchCode))
+		return fmt.Errorf("failed to add encryption key: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateWrappedKey replaces a data key version wrapped by another master key
+func (r *CoupleSettingsRepository) UpdateWrappedKey(ctx context.Context, matchCode string, version int, masterKeyID string, wrapped []byte) error {
+	filter := bson.M{
+		"match_code":              matchCode,
+		"encryption_keys.version": version,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"encryption_keys.$.wrapped_key":   wrapped,
+			"encryption_keys.$.master_key_id": masterKeyID,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to update wrapped key", zap.Error(err), zap.String("match_code", matchCode))
+		return fmt.Errorf("failed to update wrapped key: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("encryption key not found")
+	}
+
+	return nil
+}
+
+// ListWithOtherMasterKey lists up to limit couples, in ID order after afterID, with a
+// data key wrapped by a master key other than masterKeyID
+func (r *CoupleSettingsRepository) ListWithOtherMasterKey(ctx context.Context, masterKeyID string, afterID primitive.ObjectID, limit int) ([]*domain.CoupleSettings, error) {
+	filter := bson.M{
+		"encryption_keys": bson.M{"$elemMatch": bson.M{"master_key_id": bson.M{"$ne": masterKeyID}}},
+	}
+	if !afterID.IsZero() {
+		filter["_id"] = bson.M{"$gt": afterID}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to list couple settings by master key", zap.Error(err))
+		return nil, fmt.Errorf("failed to list couple settings: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var settings []*domain.CoupleSettings
+	if err := cursor.All(ctx, &settings); err != nil {
+		r.logger.Error("Failed to decode couple settings", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode couple settings: %w", err)
+	}
+
+	return settings, nil
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// applyCursor narrows filter to the documents that come after cursor when sorted
+// newest first by created_at, then _id. A nil cursor leaves filter untouched.
+func applyCursor(filter bson.M, cursor *domain.Cursor) bson.M {
+	return applyCursorOn(filter, "created_at", cursor)
+}
+
+// cursorFindOptions returns the find options matching the order applyCursor expects
+func cursorFindOptions(limit int) *options.FindOptions {
+	return cursorFindOptionsOn("created_at", limit)
+}
+
+// applyCursorOn is applyCursor for lists sorted by another time field. The cursor's
+// CreatedAt then holds the value of that field.
+func applyCursorOn(filter bson.M, field string, cursor *domain.Cursor) bson.M {
+	if cursor == nil {
+		return filter
+	}
+
+	filter["$or"] = bson.A{
+		bson.M{field: bson.M{"$lt": cursor.CreatedAt}},
+		bson.M{field: cursor.CreatedAt, "_id": bson.M{"$lt": cursor.ID}},
+	}
+	return filter
+}
+
+// cursorFindOptionsOn returns the find options matching the order applyCursorOn expects
+func cursorFindOptionsOn(field string, limit int) *options.FindOptions {
+	return options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: field, Value: -1}, {Key: "_id", Value: -1}})
+}
+
+// applyDateRange narrows filter to documents whose field is within [from, to).
+// Either bound may be nil.
+func applyDateRange(filter bson.M, field string, from, to *time.Time) bson.M {
+	if from == nil && to == nil {
+		return filter
+	}
+
+	bounds := bson.M{}
+	if from != nil {
+		bounds["$gte"] = *from
+	}
+	if to != nil {
+		bounds["$lt"] = *to
+	}
+	filter[field] = bounds
+	return filter
+}
+
+// findIDsAfter lists up to limit IDs of the documents matching filter, in ascending
+// order and after afterID, for batch jobs that walk a whole collection
+func findIDsAfter(ctx context.Context, collection *mongo.Collection, filter bson.M, afterID primitive.ObjectID, limit int) ([]primitive.ObjectID, error) {
+	if !afterID.IsZero() {
+		filter["_id"] = bson.M{"$gt": afterID}
+	}
+
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1}).
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// DailyQuestionRepository implements domain.DailyQuestionRepository
+type DailyQuestionRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewDailyQuestionRepository creates a new daily question repository
+func NewDailyQuestionRepository(db *mongo.Database, logger *zap.Logger) domain.DailyQuestionRepository {
+	return &DailyQuestionRepository{
+		collection: db.Collection("daily_questions"),
+		logger:     logger,
+	}
+}
+
+// GetOrCreate returns the couple's question of question.Day, creating it from question
+// unless it already exists. Both partners opening the day's question at once get the
+// same one.
+func (r *DailyQuestionRepository) GetOrCreate(ctx context.Context, question *domain.DailyQuestion) (*domain.DailyQuestion, error) {
+	now := time.Now()
+
+	filter := bson.M{"match_code": question.MatchCode, "day": question.Day}
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"question_key": question.QuestionKey,
+			"text":         question.Text,
+			"category":     question.Category,
+			"created_at":   now,
+			"updated_at":   now,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var created domain.DailyQuestion
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&created)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			// Created concurrently by the partner
+			return r.GetByDay(ctx, question.MatchCode, question.Day)
+		}
+		r.logger.Error("Failed to create daily question", zap.Error(err), zap.String("match_code", question.MatchCode))
+		return nil, fmt.Errorf("failed to create daily question: %w", err)
+	}
+
+	return &created, nil
+}
+
+// GetByDay retrieves the couple's question of a day
+func (r *DailyQuestionRepository) GetByDay(ctx context.Context, matchCode string, day time.Time) (*domain.DailyQuestion, error) {
+	var question domain.DailyQuestion
+
+	err := r.collection.FindOne(ctx, bson.M{"match_code": matchCode, "day": day}).Decode(&question)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrDailyQuestionNotFound
+		}
+		r.logger.Error("Failed to get daily question", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get daily question: %w", err)
+	}
+
+	return &question, nil
+}
+
+// SetAnswer saves a partner's answer unless the answers were revealed, and returns the
+// question as updated
+func (r *DailyQuestionRepository) SetAnswer(ctx context.Context, id, userID primitive.ObjectID, answer domain.QuestionAnswer) (*domain.DailyQuestion, error) {
+	filter := bson.M{"_id": id, "revealed_at": bson.M{"$exists": false}}
+	update := bson.M{
+		"$set": bson.M{
+			"answers." + userID.Hex(): answer,
+			"updated_at":              time.Now(),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var question domain.DailyQuestion
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&question)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrDailyQuestionAnswersRevealed
+		}
+		r.logger.Error("Failed to save daily question answer", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to save daily question answer: %w", err)
+	}
+
+	return &question, nil
+}
+
+// MarkRevealed marks the answers of a question revealed and reports whether they were
+// not already
+func (r *DailyQuestionRepository) MarkRevealed(ctx context.Context, id primitive.ObjectID, revealedAt time.Time) (bool, error) {
+	filter := bson.M{"_id": id, "revealed_at": bson.M{"$exists": false}}
+	update := bson.M{
+		"$set": bson.M{
+			"revealed_at": revealedAt,
+			"updated_at":  revealedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to reveal daily question answers", zap.Error(err), zap.String("id", id.Hex()))
+		return false, fmt.Errorf("failed to reveal daily question answers: %w", err)
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// GetByMatchCode retrieves the couple's questions, newest day first
+func (r *DailyQuestionRepository) GetByMatchCode(ctx context.Context, matchCode string, limit, offset int) ([]*domain.DailyQuestion, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "day", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"match_code": matchCode}, opts)
+	if err != nil {
+		r.logger.Error("Failed to get daily questions", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get daily questions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var questions []*domain.DailyQuestion
+	if err := cursor.All(ctx, &questions); err != nil {
+		r.logger.Error("Failed to decode daily questions", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode daily questions: %w", err)
+	}
+
+	return questions, nil
+}
+
+// CountByMatchCode counts the couple's questions
+func (r *DailyQuestionRepository) CountByMatchCode(ctx context.Context, matchCode string) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"match_code": matchCode})
+	if err != nil {
+		r.logger.Error("Failed to count daily questions", zap.Error(err), zap.String("match_code", matchCode))
+		return 0, fmt.Errorf("failed to count daily questions: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// DatePlanRepository implements domain.DatePlanRepository
+type DatePlanRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewDatePlanRepository creates a new date plan repository
+func NewDatePlanRepository(db *mongo.Database, logger *zap.Logger) domain.DatePlanRepository {
+	return &DatePlanRepository{
+		collection: db.Collection("date_plans"),
+		logger:     logger,
+	}
+}
+
+// Create creates a new date plan
+func (r *DatePlanRepository) Create(ctx context.Context, plan *domain.DatePlan) error {
+	plan.CreatedAt = time.Now()
+	plan.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, plan)
+	if err != nil {
+		r.logger.Error("Failed to create date plan", zap.Error(err))
+		return fmt.Errorf("failed to create date plan: %w", err)
+	}
+
+	plan.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves one of a couple's date plans by ID
+func (r *DatePlanRepository) GetByID(ctx context.Context, matchCode string, id primitive.ObjectID) (*domain.DatePlan, error) {
+	var plan domain.DatePlan
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{
+		"_id":        id,
+		"match_code": matchCode,
+	})
+
+	err := r.collection.FindOne(ctx, filter).Decode(&plan)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("date plan not found")
+		}
+		r.logger.Error("Failed to get date plan by ID", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to get date plan: %w", err)
+	}
+
+	return &plan, nil
+}
+
+// GetByMatchCode retrieves a couple's date plans, optionally with a status, latest
+// scheduled first and unscheduled ideas last
+func (r *DatePlanRepository) GetByMatchCode(ctx context.Context, matchCode string, status domain.DatePlanStatus, limit, offset int) ([]*domain.DatePlan, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "scheduled_date", Value: -1}, {Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, r.coupleFilter(matchCode, status), opts)
+	if err != nil {
+		r.logger.Error("Failed to get date plans", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get date plans: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var plans []*domain.DatePlan
+	if err := cursor.All(ctx, &plans); err != nil {
+		r.logger.Error("Failed to decode date plans", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode date plans: %w", err)
+	}
+
+	return plans, nil
+}
+
+// CountByMatchCode counts a couple's date plans, optionally with a status
+func (r *DatePlanRepository) CountByMatchCode(ctx context.Context, matchCode string, status domain.DatePlanStatus) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, r.coupleFilter(matchCode, status))
+	if err != nil {
+		r.logger.Error("Failed to count date plans", zap.Error(err), zap.String("match_code", matchCode))
+		return 0, fmt.Errorf("failed to count date plans: %w", err)
+	}
+
+	return count, nil
+}
+
+// Update updates a date plan
+func (r *DatePlanRepository) Update(ctx context.Context, plan *domain.DatePlan) error {
+	plan.UpdatedAt = time.Now()
+
+	set := bson.M{
+		"idea":        plan.Idea,
+		"description": plan.Description,
+		"location":    plan.Location,
+		"time":        plan.Time,
+		"currency":    plan.Currency,
+		"status":      plan.Status,
+		"updated_at":  plan.UpdatedAt,
+	}
+	unset := bson.M{}
+	setOrUnset := func(field string, value interface{}, present bool) {
+		if present {
+			set[field] = value
+		} else {
+			unset[field] = ""
+		}
+	}
+	setOrUnset("scheduled_date", plan.ScheduledDate, plan.ScheduledDate != nil)
+	setOrUnset("budget", plan.Budget, plan.Budget != nil)
+	setOrUnset("event_id", plan.EventID, plan.EventID != nil)
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{"_id": plan.ID})
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to update date plan", zap.Error(err))
+		return fmt.Errorf("failed to update date plan: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("date plan not found")
+	}
+
+	return nil
+}
+
+// Delete soft deletes a date plan
+func (r *DatePlanRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{"_id": id})
+
+	result, err := r.collection.UpdateOne(ctx, filter, SoftDelete.CreateSoftDeleteUpdate())
+	if err != nil {
+		r.logger.Error("Failed to delete date plan", zap.Error(err))
+		return fmt.Errorf("failed to delete date plan: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("date plan not found")
+	}
+
+	return nil
+}
+
+// coupleFilter builds the filter for a couple's active date plans
+func (r *DatePlanRepository) coupleFilter(matchCode string, status domain.DatePlanStatus) bson.M {
+	condition := bson.M{"match_code": matchCode}
+	if status != "" {
+		condition["status"] = status
+	}
+	return SoftDelete.GetActiveFilterWithCondition(condition)
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// coupleActivityRetention is how long the activity of a day is kept, well past the
+// day being counted into the streak
+const coupleActivityRetention = 90 * 24 * time.Hour
+
+// EngagementRepository implements domain.EngagementRepository
+type EngagementRepository struct {
+	activity *mongo.Collection
+	streaks  *mongo.Collection
+	logger   *zap.Logger
+}
+
+// NewEngagementRepository creates a new engagement repository
+func NewEngagementRepository(db *mongo.Database, logger *zap.Logger) domain.EngagementRepository {
+	return &EngagementRepository{
+		activity: db.Collection("couple_activity"),
+		streaks:  db.Collection("couple_streaks"),
+		logger:   logger,
+	}
+}
+
+// RecordActivity adds an interaction of userID to the couple's activity of day,
+// creating the day's activity on the first one
+func (r *EngagementRepository) RecordActivity(ctx context.Context, matchCode string, day time.Time, userID primitive.ObjectID, kind domain.EngagementKind) error {
+	now := time.Now()
+
+	filter := bson.M{"match_code": matchCode, "day": day}
+	update := bson.M{
+		"$addToSet": bson.M{
+			"kinds":    kind,
+			"user_ids": userID,
+		},
+		"$setOnInsert": bson.M{
+			"expires_at": day.Add(coupleActivityRetention),
+			"created_at": now,
+		},
+	}
+
+	if _, err := r.activity.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		r.logger.Error("Failed to record couple activity", zap.Error(err), zap.String("match_code", matchCode))
+		return fmt.Errorf("failed to record couple activity: %w", err)
+	}
+
+	return nil
+}
+
+// GetActivity retrieves the couple's activity of day, or nil when they did not interact
+func (r *EngagementRepository) GetActivity(ctx context.Context, matchCode string, day time.Time) (*domain.CoupleActivity, error) {
+	var activity domain.CoupleActivity
+
+	err := r.activity.FindOne(ctx, bson.M{"match_code": matchCode, "day": day}).Decode(&activity)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get couple activity", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get couple activity: %w", err)
+	}
+
+	return &activity, nil
+}
+
+// GetStreak retrieves the streak of a couple
+func (r *EngagementRepository) GetStreak(ctx context.Context, matchCode string) (*domain.CoupleStreak, error) {
+	var streak domain.CoupleStreak
+
+	err := r.streaks.FindOne(ctx, bson.M{"match_code": matchCode}).Decode(&streak)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrCoupleStreakNotFound
+		}
+		r.logger.Error("Failed to get couple streak", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get couple streak: %w", err)
+	}
+
+	return &streak, nil
+}
+
+// CreateStreak creates the streak of a couple unless it already exists
+func (r *EngagementRepository) CreateStreak(ctx context.Context, streak *domain.CoupleStreak) error {
+	now := time.Now()
+
+	filter := bson.M{"match_code": streak.MatchCode}
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"current":       streak.Current,
+			"longest":       streak.Longest,
+			"finalized_day": streak.FinalizedDay,
+			"finalize_at":   streak.FinalizeAt,
+			"created_at":    now,
+			"updated_at":    now,
+		},
+	}
+
+	if _, err := r.streaks.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			// Created concurrently by the partner's interaction
+			return nil
+		}
+		r.logger.Error("Failed to create couple streak", zap.Error(err), zap.String("match_code", streak.MatchCode))
+		return fmt.Errorf("failed to create couple streak: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStreak saves the counted days of a couple's streak
+func (r *EngagementRepository) UpdateStreak(ctx context.Context, streak *domain.CoupleStreak) error {
+	streak.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"current":         streak.Current,
+			"longest":         streak.Longest,
+			"last_active_day": streak.LastActiveDay,
+			"finalized_day":   streak.FinalizedDay,
+			"finalize_at":     streak.FinalizeAt,
+			"updated_at":      streak.UpdatedAt,
+		},
+	}
+
+	result, err := r.streaks.UpdateOne(ctx, bson.M{"_id": streak.ID}, update)
+	if err != nil {
+		r.logger.Error("Failed to update couple streak", zap.Error(err), zap.String("match_code", streak.MatchCode))
+		return fmt.Errorf("failed to update couple streak: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrCoupleStreakNotFound
+	}
+
+	return nil
+}
+
+// ListStreaksToFinalize retrieves up to limit streaks with a day over by now that was
+// not counted yet, those waiting longest first
+func (r *EngagementRepository) ListStreaksToFinalize(ctx context.Context, now time.Time, limit int) ([]*domain.CoupleStreak, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "finalize_at", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.streaks.Find(ctx, bson.M{"finalize_at": bson.M{"$lte": now}}, opts)
+	if err != nil {
+		r.logger.Error("Failed to list streaks to finalize", zap.Error(err))
+		return nil, fmt.Errorf("failed to list streaks to finalize: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var streaks []*domain.CoupleStreak
+	if err := cursor.All(ctx, &streaks); err != nil {
+		r.logger.Error("Failed to decode streaks", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode streaks: %w", err)
+	}
+
+	return streaks, nil
+}
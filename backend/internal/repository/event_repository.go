@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/eralove/eralove-backend/internal/domain"
@@ -63,15 +64,15 @@ func (r *EventRepository) GetByID(id primitive.ObjectID) (*domain.Event, error)
 	return &event, nil
 }
 
-// GetByMatchCode retrieves events for a match code
-func (r *EventRepository) GetByMatchCode(matchCode string, limit, offset int) ([]*domain.Event, error) {
+// GetByMatchCode retrieves the events of a match code the viewer can see
+func (r *EventRepository) GetByMatchCode(matchCode string, viewerID primitive.ObjectID, limit, offset int) ([]*domain.Event, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	filter := bson.M{
+	filter := applyVisibleTo(bson.M{
 		"match_code": matchCode,
 		"deleted_at": bson.M{"$exists": false},
-	}
+	}, viewerID)
 
 	opts := options.Find().
 		SetSort(bson.D{{Key: "date", Value: -1}}).
@@ -94,19 +95,121 @@ func (r *EventRepository) GetByMatchCode(matchCode string, limit, offset int) ([
 	return events, nil
 }
 
-// GetByMatchCodeAndDateRange retrieves events within a date range for a match code
-func (r *EventRepository) GetByMatchCodeAndDateRange(matchCode string, startDate, endDate time.Time) ([]*domain.Event, error) {
+// CountByMatchCode counts the active events of a match code the viewer can see
+func (r *EventRepository) CountByMatchCode(matchCode string, viewerID primitive.ObjectID) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	filter := bson.M{
+	filter := applyVisibleTo(bson.M{
+		"match_code": matchCode,
+		"deleted_at": bson.M{"$exists": false},
+	}, viewerID)
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to count events by match code", zap.Error(err))
+		return 0, fmt.Errorf("failed to count events by match code: %w", err)
+	}
+
+	return count, nil
+}
+
+// ListByDate retrieves the events of a match code the viewer can see, newest event
+// date first, optionally limited to dates within [from, to). The cursor positions on
+// the event date. Generated milestone events are left out; the timeline derives its
+// own milestones.
+func (r *EventRepository) ListByDate(matchCode string, viewerID primitive.ObjectID, from, to *time.Time, cursor *domain.Cursor, limit int) ([]*domain.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := applyVisibleTo(bson.M{
+		"match_code":     matchCode,
+		"auto_milestone": bson.M{"$exists": false},
+		"deleted_at":     bson.M{"$exists": false},
+	}, viewerID)
+	filter = applyDateRange(filter, "date", from, to)
+	filter = applyCursorOn(filter, "date", cursor)
+
+	result, err := r.collection.Find(ctx, filter, cursorFindOptionsOn("date", limit))
+	if err != nil {
+		r.logger.Error("Failed to list events by date", zap.Error(err))
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	defer result.Close(ctx)
+
+	var events []*domain.Event
+	if err := result.All(ctx, &events); err != nil {
+		r.logger.Error("Failed to decode events", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetOnThisDay retrieves the events of a couple the viewer can see dated on the
+// calendar day of date in earlier years, newest first. Generated milestone events are
+// left out.
+func (r *EventRepository) GetOnThisDay(matchCode string, viewerID primitive.ObjectID, date time.Time) ([]*domain.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := applyOnThisDay(applyVisibleTo(bson.M{
+		"match_code":     matchCode,
+		"auto_milestone": bson.M{"$exists": false},
+		"deleted_at":     bson.M{"$exists": false},
+	}, viewerID), "date", date)
+
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get events on this day", zap.Error(err))
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []*domain.Event
+	if err := cursor.All(ctx, &events); err != nil {
+		r.logger.Error("Failed to decode events", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+
+	return events, nil
+}
+
+// ListMatchCodesOnThisDay lists the couples with events dated on the calendar day of
+// date in earlier years, leaving out generated milestone events
+func (r *EventRepository) ListMatchCodesOnThisDay(date time.Time) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	filter := applyOnThisDay(bson.M{
+		"auto_milestone": bson.M{"$exists": false},
+		"deleted_at":     bson.M{"$exists": false},
+	}, "date", date)
+
+	matchCodes, err := distinctMatchCodes(ctx, r.collection, filter)
+	if err != nil {
+		r.logger.Error("Failed to list couples with events on this day", zap.Error(err))
+		return nil, fmt.Errorf("failed to list couples: %w", err)
+	}
+	return matchCodes, nil
+}
+
+// GetByMatchCodeAndDateRange retrieves the events of a match code the viewer can see
+// within a date range
+func (r *EventRepository) GetByMatchCodeAndDateRange(matchCode string, viewerID primitive.ObjectID, startDate, endDate time.Time) ([]*domain.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := applyVisibleTo(bson.M{
 		"match_code": matchCode,
 		"date": bson.M{
 			"$gte": startDate,
 			"$lte": endDate,
 		},
 		"deleted_at": bson.M{"$exists": false},
-	}
+	}, viewerID)
 
 	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
 
@@ -126,8 +229,9 @@ func (r *EventRepository) GetByMatchCodeAndDateRange(matchCode string, startDate
 	return events, nil
 }
 
-// GetByMatchCodeAndDate retrieves events for a specific date for a match code
-func (r *EventRepository) GetByMatchCodeAndDate(matchCode string, date time.Time) ([]*domain.Event, error) {
+// GetByMatchCodeAndDate retrieves the events of a match code the viewer can see for a
+// specific date
+func (r *EventRepository) GetByMatchCodeAndDate(matchCode string, viewerID primitive.ObjectID, date time.Time) ([]*domain.Event, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
@@ -135,14 +239,14 @@ func (r *EventRepository) GetByMatchCodeAndDate(matchCode string, date time.Time
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 	endOfDay := startOfDay.AddDate(0, 0, 1).Add(-time.Second)
 
-	filter := bson.M{
+	filter := applyVisibleTo(bson.M{
 		"match_code": matchCode,
 		"date": bson.M{
 			"$gte": startOfDay,
 			"$lte": endOfDay,
 		},
 		"deleted_at": bson.M{"$exists": false},
-	}
+	}, viewerID)
 
 	opts := options.Find().SetSort(bson.D{{Key: "time", Value: 1}})
 
@@ -162,18 +266,18 @@ func (r *EventRepository) GetByMatchCodeAndDate(matchCode string, date time.Time
 	return events, nil
 }
 
-// GetUpcomingByMatchCode retrieves upcoming events for a match code
-func (r *EventRepository) GetUpcomingByMatchCode(matchCode string, limit int) ([]*domain.Event, error) {
+// GetUpcomingByMatchCode retrieves the upcoming events of a match code the viewer can see
+func (r *EventRepository) GetUpcomingByMatchCode(matchCode string, viewerID primitive.ObjectID, limit int) ([]*domain.Event, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	now := time.Now()
 
-	filter := bson.M{
+	filter := applyVisibleTo(bson.M{
 		"match_code": matchCode,
 		"date":       bson.M{"$gte": now},
 		"deleted_at": bson.M{"$exists": false},
-	}
+	}, viewerID)
 
 	opts := options.Find().
 		SetSort(bson.D{{Key: "date", Value: 1}}).
@@ -195,6 +299,132 @@ func (r *EventRepository) GetUpcomingByMatchCode(matchCode string, limit int) ([
 	return events, nil
 }
 
+// GetDueReminders retrieves up to limit events whose enabled reminder is due by before
+// and was not sent yet, oldest reminder first
+func (r *EventRepository) GetDueReminders(before time.Time, limit int) ([]*domain.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"reminder.enabled":     true,
+		"reminder.is_notified": false,
+		"reminder.reminder_at": bson.M{"$lte": before},
+		"deleted_at":           bson.M{"$exists": false},
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "reminder.reminder_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get due reminders", zap.Error(err))
+		return nil, fmt.Errorf("failed to get due reminders: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []*domain.Event
+	if err := cursor.All(ctx, &events); err != nil {
+		r.logger.Error("Failed to decode events", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+
+	return events, nil
+}
+
+// MarkReminderNotified records that the event's reminder was sent, and reports whether
+// it was not recorded already, so that concurrent runs send each reminder once
+func (r *EventRepository) MarkReminderNotified(id primitive.ObjectID) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":                  id,
+		"reminder.is_notified": false,
+	}
+	update := bson.M{
+		"$set": bson.M{"reminder.is_notified": true},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to mark reminder notified", zap.Error(err), zap.String("id", id.Hex()))
+		return false, fmt.Errorf("failed to mark reminder notified: %w", err)
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// GetUpcomingWithReminders retrieves the couple's events the viewer can see dated from
+// onwards that have a reminder enabled, soonest first
+func (r *EventRepository) GetUpcomingWithReminders(matchCode string, viewerID primitive.ObjectID, from time.Time, limit int) ([]*domain.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := applyVisibleTo(bson.M{
+		"match_code":       matchCode,
+		"date":             bson.M{"$gte": from},
+		"reminder.enabled": true,
+		"deleted_at":       bson.M{"$exists": false},
+	}, viewerID)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "date", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get upcoming events with reminders", zap.Error(err))
+		return nil, fmt.Errorf("failed to get upcoming events with reminders: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []*domain.Event
+	if err := cursor.All(ctx, &events); err != nil {
+		r.logger.Error("Failed to decode events", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+
+	return events, nil
+}
+
+// SearchByMatchCode searches the couple's events the viewer can see by title,
+// description and location
+func (r *EventRepository) SearchByMatchCode(matchCode string, viewerID primitive.ObjectID, query string, limit int) ([]*domain.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	pattern := bson.M{"$regex": regexp.QuoteMeta(query), "$options": "i"}
+	filter := applyVisibleTo(bson.M{
+		"match_code": matchCode,
+		"$or": []bson.M{
+			{"title": pattern},
+			{"description": pattern},
+			{"location": pattern},
+		},
+		"deleted_at": bson.M{"$exists": false},
+	}, viewerID)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "date", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to search events", zap.Error(err))
+		return nil, fmt.Errorf("failed to search events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []*domain.Event
+	if err := cursor.All(ctx, &events); err != nil {
+		r.logger.Error("Failed to decode events", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+
+	return events, nil
+}
+
 // DeleteByMatchCode deletes all events for a match code (for unmatch)
 func (r *EventRepository) DeleteByMatchCode(matchCode string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -226,6 +456,9 @@ func (r *EventRepository) Update(id primitive.ObjectID, event *domain.Event) err
 	update := bson.M{
 		"$set": event,
 	}
+	if event.Place == nil {
+		update["$unset"] = bson.M{"place": ""}
+	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -270,3 +503,206 @@ func (r *EventRepository) Delete(id primitive.ObjectID) error {
 
 	return nil
 }
+
+// BulkDelete soft deletes the couple's events the viewer can see among ids in a single
+// write and returns the IDs of the events that were deleted
+func (r *EventRepository) BulkDelete(matchCode string, viewerID primitive.ObjectID, ids []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	found, err := findIDs(ctx, r.collection, applyVisibleTo(activeIDsFilter(matchCode, ids), viewerID))
+	if err != nil {
+		r.logger.Error("Failed to find events for bulk delete", zap.Error(err))
+		return nil, fmt.Errorf("failed to find events: %w", err)
+	}
+
+	if len(found) == 0 {
+		return found, nil
+	}
+
+	if _, err := r.collection.UpdateMany(ctx, activeIDsFilter(matchCode, found), SoftDelete.CreateSoftDeleteUpdate()); err != nil {
+		r.logger.Error("Failed to bulk delete events", zap.Error(err))
+		return nil, fmt.Errorf("failed to delete events: %w", err)
+	}
+
+	return found, nil
+}
+
+// CreateAutoMilestone stores a generated milestone event unless the couple already has
+// one with its key, even in the trash, so that a milestone the couple deleted is not
+// generated again. It reports whether the event was stored.
+func (r *EventRepository) CreateAutoMilestone(event *domain.Event) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"match_code":         event.MatchCode,
+		"auto_milestone_key": event.AutoMilestoneKey,
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": event}, options.Update().SetUpsert(true))
+	if err != nil {
+		r.logger.Error("Failed to create milestone event", zap.Error(err), zap.String("key", event.AutoMilestoneKey))
+		return false, fmt.Errorf("failed to create milestone event: %w", err)
+	}
+
+	return result.UpsertedCount > 0, nil
+}
+
+// DeleteAutoMilestones permanently deletes the couple's generated milestone events of
+// the given kinds dated from onwards, including those in the trash
+func (r *EventRepository) DeleteAutoMilestones(matchCode string, kinds []domain.AutoMilestoneKind, from time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"match_code":     matchCode,
+		"auto_milestone": bson.M{"$in": kinds},
+		"date":           bson.M{"$gte": from},
+	}
+
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to delete milestone events", zap.Error(err), zap.String("match_code", matchCode))
+		return 0, fmt.Errorf("failed to delete milestone events: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+// Restore restores a soft-deleted event
+func (r *EventRepository) Restore(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": true},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, SoftDelete.CreateRestoreUpdate())
+	if err != nil {
+		r.logger.Error("Failed to restore event", zap.Error(err))
+		return fmt.Errorf("failed to restore event: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("event not found or not deleted")
+	}
+
+	return nil
+}
+
+// GetDeleted retrieves one of the couple's soft-deleted events by ID
+func (r *EventRepository) GetDeleted(matchCode string, id primitive.ObjectID) (*domain.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var event domain.Event
+	filter := SoftDelete.GetDeletedFilterWithCondition(bson.M{
+		"_id":        id,
+		"match_code": matchCode,
+	})
+
+	err := r.collection.FindOne(ctx, filter).Decode(&event)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("event not found or not deleted")
+		}
+		r.logger.Error("Failed to get deleted event", zap.Error(err))
+		return nil, fmt.Errorf("failed to get deleted event: %w", err)
+	}
+
+	return &event, nil
+}
+
+// HardDelete permanently deletes an event
+func (r *EventRepository) HardDelete(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		r.logger.Error("Failed to hard delete event", zap.Error(err))
+		return fmt.Errorf("failed to hard delete event: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("event not found")
+	}
+
+	return nil
+}
+
+// ListDeleted retrieves the soft-deleted events of a match code the viewer can see,
+// most recently deleted first
+func (r *EventRepository) ListDeleted(matchCode string, viewerID primitive.ObjectID, limit, offset int) ([]*domain.Event, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := applyVisibleTo(SoftDelete.GetDeletedFilterWithCondition(bson.M{"match_code": matchCode}), viewerID)
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "deleted_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to list deleted events", zap.Error(err))
+		return nil, fmt.Errorf("failed to list deleted events: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var events []*domain.Event
+	if err := cursor.All(ctx, &events); err != nil {
+		r.logger.Error("Failed to decode events", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+
+	return events, nil
+}
+
+// PurgeDeletedBefore permanently deletes events soft-deleted before cutoff
+func (r *EventRepository) PurgeDeletedBefore(cutoff time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := r.collection.DeleteMany(ctx, bson.M{"deleted_at": bson.M{"$lt": cutoff}})
+	if err != nil {
+		r.logger.Error("Failed to purge deleted events", zap.Error(err))
+		return 0, fmt.Errorf("failed to purge deleted events: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+// CountCreatedPerDay counts the events created from from up to to per couple and day
+func (r *EventRepository) CountCreatedPerDay(from, to time.Time) ([]*domain.DailyCount, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	counts, err := countCreatedPerDay(ctx, r.collection, from, to, "$match_code")
+	if err != nil {
+		r.logger.Error("Failed to count events per day", zap.Error(err))
+		return nil, fmt.Errorf("failed to count events: %w", err)
+	}
+	return counts, nil
+}
+
+// ReassignCreator moves the events created by one user to another, including events
+// in the trash
+func (r *EventRepository) ReassignCreator(fromUserID, toUserID primitive.ObjectID) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	update := bson.M{"$set": bson.M{"created_by": toUserID, "updated_at": time.Now()}}
+
+	result, err := r.collection.UpdateMany(ctx, bson.M{"created_by": fromUserID}, update)
+	if err != nil {
+		r.logger.Error("Failed to reassign events", zap.Error(err), zap.String("from_user_id", fromUserID.Hex()))
+		return 0, fmt.Errorf("failed to reassign events: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
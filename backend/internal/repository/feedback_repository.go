@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// FeedbackRepository implements domain.FeedbackRepository
+type FeedbackRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewFeedbackRepository creates a new feedback repository
+func NewFeedbackRepository(db *mongo.Database, logger *zap.Logger) domain.FeedbackRepository {
+	return &FeedbackRepository{
+		collection: db.Collection("feedback"),
+		logger:     logger,
+	}
+}
+
+// Create creates a new piece of feedback
+func (r *FeedbackRepository) Create(ctx context.Context, feedback *domain.Feedback) error {
+	now := time.Now()
+	feedback.CreatedAt = now
+	feedback.UpdatedAt = now
+
+	result, err := r.collection.InsertOne(ctx, feedback)
+	if err != nil {
+		r.logger.Error("Failed to create feedback", zap.Error(err))
+		return fmt.Errorf("failed to create feedback: %w", err)
+	}
+
+	feedback.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves a piece of feedback by ID
+func (r *FeedbackRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.Feedback, error) {
+	var feedback domain.Feedback
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&feedback)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrFeedbackNotFound
+		}
+		r.logger.Error("Failed to get feedback by ID", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to get feedback: %w", err)
+	}
+
+	return &feedback, nil
+}
+
+// CountByUserSince counts the feedback a user has sent since the given time
+func (r *FeedbackRepository) CountByUserSince(ctx context.Context, userID primitive.ObjectID, since time.Time) (int64, error) {
+	filter := bson.M{
+		"user_id":    userID,
+		"created_at": bson.M{"$gte": since},
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to count feedback", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return 0, fmt.Errorf("failed to count feedback: %w", err)
+	}
+
+	return count, nil
+}
+
+// List retrieves feedback matching filter, newest first, starting after cursor
+func (r *FeedbackRepository) List(ctx context.Context, filter domain.FeedbackFilter, cursor *domain.Cursor, limit int) ([]*domain.Feedback, error) {
+	query := bson.M{}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+	if filter.Category != "" {
+		query["category"] = filter.Category
+	}
+	query = applyCursor(query, cursor)
+
+	result, err := r.collection.Find(ctx, query, cursorFindOptions(limit))
+	if err != nil {
+		r.logger.Error("Failed to list feedback", zap.Error(err))
+		return nil, fmt.Errorf("failed to list feedback: %w", err)
+	}
+	defer result.Close(ctx)
+
+	var feedback []*domain.Feedback
+	if err := result.All(ctx, &feedback); err != nil {
+		r.logger.Error("Failed to decode feedback", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode feedback: %w", err)
+	}
+
+	return feedback, nil
+}
+
+// UpdateStatus moves a piece of feedback from status from to change.To and records
+// the change. It only applies while the feedback is still in status from, so two
+// concurrent changes cannot both succeed; the loser gets a not found error.
+func (r *FeedbackRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, from domain.FeedbackStatus, change domain.FeedbackStatusChange) error {
+	filter := bson.M{
+		"_id":    id,
+		"status": from,
+	}
+	update := bson.M{
+		"$set":  bson.M{"status": change.To, "updated_at": change.ChangedAt},
+		"$push": bson.M{"status_history": change},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to update feedback status", zap.Error(err), zap.String("id", id.Hex()))
+		return fmt.Errorf("failed to update feedback status: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrFeedbackNotFound
+	}
+
+	return nil
+}
+
+// SetMirrorID stores the ID the feedback was given by the mirror it was copied to
+func (r *FeedbackRepository) SetMirrorID(ctx context.Context, id primitive.ObjectID, mirrorID string) error {
+	update := bson.M{
+		"$set": bson.M{"mirror_id": mirrorID},
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		r.logger.Error("Failed to set feedback mirror ID", zap.Error(err), zap.String("id", id.Hex()))
+		return fmt.Errorf("failed to set feedback mirror ID: %w", err)
+	}
+
+	return nil
+}
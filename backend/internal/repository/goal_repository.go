@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// GoalRepository implements domain.GoalRepository
+type GoalRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewGoalRepository creates a new goal repository
+func NewGoalRepository(db *mongo.Database, logger *zap.Logger) domain.GoalRepository {
+	return &GoalRepository{
+		collection: db.Collection("goals"),
+		logger:     logger,
+	}
+}
+
+// Create creates a new goal
+func (r *GoalRepository) Create(ctx context.Context, goal *domain.Goal) error {
+	goal.CreatedAt = time.Now()
+	goal.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, goal)
+	if err != nil {
+		r.logger.Error("Failed to create goal", zap.Error(err))
+		return fmt.Errorf("failed to create goal: %w", err)
+	}
+
+	goal.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves a goal by ID
+func (r *GoalRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.Goal, error) {
+	var goal domain.Goal
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&goal)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("goal not found")
+		}
+		r.logger.Error("Failed to get goal by ID", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to get goal: %w", err)
+	}
+
+	return &goal, nil
+}
+
+// GetByMatchCode retrieves goals by match code with optional status filter and pagination
+func (r *GoalRepository) GetByMatchCode(ctx context.Context, matchCode string, status domain.GoalStatus, limit, offset int) ([]*domain.Goal, error) {
+	opts := options.Find().
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "target_date", Value: 1}, {Key: "created_at", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+
+	cursor, err := r.collection.Find(ctx, r.matchCodeFilter(matchCode, status), opts)
+	if err != nil {
+		r.logger.Error("Failed to get goals by match code", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get goals: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var goals []*domain.Goal
+	if err := cursor.All(ctx, &goals); err != nil {
+		r.logger.Error("Failed to decode goals", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode goals: %w", err)
+	}
+
+	return goals, nil
+}
+
+// CountByMatchCode counts goals by match code with optional status filter
+func (r *GoalRepository) CountByMatchCode(ctx context.Context, matchCode string, status domain.GoalStatus) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, r.matchCodeFilter(matchCode, status))
+	if err != nil {
+		r.logger.Error("Failed to count goals", zap.Error(err), zap.String("match_code", matchCode))
+		return 0, fmt.Errorf("failed to count goals: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetDueForReminder retrieves active goals whose target date is before dueBefore
+// and that have not been reminded since remindedBefore
+func (r *GoalRepository) GetDueForReminder(ctx context.Context, dueBefore, remindedBefore time.Time) ([]*domain.Goal, error) {
+	filter := bson.M{
+		"status":      domain.GoalStatusActive,
+		"target_date": bson.M{"$lte": dueBefore},
+		"$or": []bson.M{
+			{"last_reminder_at": bson.M{"$exists": false}},
+			{"last_reminder_at": bson.M{"$lt": remindedBefore}},
+		},
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to get goals due for reminder", zap.Error(err))
+		return nil, fmt.Errorf("failed to get goals: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var goals []*domain.Goal
+	if err := cursor.All(ctx, &goals); err != nil {
+		r.logger.Error("Failed to decode goals", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode goals: %w", err)
+	}
+
+	return goals, nil
+}
+
+// Update updates a goal
+func (r *GoalRepository) Update(ctx context.Context, id primitive.ObjectID, goal *domain.Goal) error {
+	goal.UpdatedAt = time.Now()
+
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	update := bson.M{
+		"$set": goal,
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to update goal", zap.Error(err))
+		return fmt.Errorf("failed to update goal: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("goal not found")
+	}
+
+	return nil
+}
+
+// Delete soft deletes a goal
+func (r *GoalRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":        id,
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"deleted_at": now,
+			"updated_at": now,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to delete goal", zap.Error(err))
+		return fmt.Errorf("failed to delete goal: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("goal not found")
+	}
+
+	return nil
+}
+
+// matchCodeFilter builds the filter for a couple's non-deleted goals with an optional status
+func (r *GoalRepository) matchCodeFilter(matchCode string, status domain.GoalStatus) bson.M {
+	filter := bson.M{
+		"match_code": matchCode,
+		"deleted_at": bson.M{"$exists": false},
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+	return filter
+}
@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// JournalRepository implements domain.JournalRepository
+type JournalRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewJournalRepository creates a new journal repository
+func NewJournalRepository(db *mongo.Database, logger *zap.Logger) domain.JournalRepository {
+	return &JournalRepository{
+		collection: db.Collection("journal_entries"),
+		logger:     logger,
+	}
+}
+
+// Create creates a new journal entry
+func (r *JournalRepository) Create(ctx context.Context, entry *domain.JournalEntry) error {
+	entry.CreatedAt = time.Now()
+	entry.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, entry)
+	if err != nil {
+		r.logger.Error("Failed to create journal entry", zap.Error(err))
+		return fmt.Errorf("failed to create journal entry: %w", err)
+	}
+
+	entry.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves one of a couple's journal entries by ID
+func (r *JournalRepository) GetByID(ctx context.Context, matchCode string, id primitive.ObjectID) (*domain.JournalEntry, error) {
+	var entry domain.JournalEntry
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{
+		"_id":        id,
+		"match_code": matchCode,
+	})
+
+	err := r.collection.FindOne(ctx, filter).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("journal entry not found")
+		}
+		r.logger.Error("Failed to get journal entry by ID", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to get journal entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// GetVisible retrieves the couple's journal entries the viewer can see, newest date
+// first
+func (r *JournalRepository) GetVisible(ctx context.Context, matchCode string, viewerID primitive.ObjectID, limit, offset int) ([]*domain.JournalEntry, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "date", Value: -1}, {Key: "_id", Value: -1}})
+
+	return r.find(ctx, r.visibleFilter(matchCode, viewerID), opts)
+}
+
+// CountVisible counts the couple's journal entries the viewer can see
+func (r *JournalRepository) CountVisible(ctx context.Context, matchCode string, viewerID primitive.ObjectID) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, r.visibleFilter(matchCode, viewerID))
+	if err != nil {
+		r.logger.Error("Failed to count journal entries", zap.Error(err), zap.String("match_code", matchCode))
+		return 0, fmt.Errorf("failed to count journal entries: %w", err)
+	}
+	return count, nil
+}
+
+// ListByDate retrieves the couple's journal entries the viewer can see, newest date
+// first, optionally limited to dates within [from, to). The cursor positions on the
+// entry date.
+func (r *JournalRepository) ListByDate(ctx context.Context, matchCode string, viewerID primitive.ObjectID, from, to *time.Time, cursor *domain.Cursor, limit int) ([]*domain.JournalEntry, error) {
+	filter := r.visibleFilter(matchCode, viewerID)
+	filter = applyDateRange(filter, "date", from, to)
+	filter = applyCursorOn(filter, "date", cursor)
+
+	return r.find(ctx, filter, cursorFindOptionsOn("date", limit))
+}
+
+// Update updates a journal entry
+func (r *JournalRepository) Update(ctx context.Context, entry *domain.JournalEntry) error {
+	entry.UpdatedAt = time.Now()
+
+	set := bson.M{
+		"title":      entry.Title,
+		"content":    entry.Content,
+		"date":       entry.Date,
+		"is_private": entry.IsPrivate,
+		"updated_at": entry.UpdatedAt,
+	}
+	update := bson.M{"$set": set}
+	if entry.UnlockAt != nil {
+		set["unlock_at"] = *entry.UnlockAt
+	} else {
+		update["$unset"] = bson.M{"unlock_at": ""}
+	}
+
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{"_id": entry.ID})
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to update journal entry", zap.Error(err))
+		return fmt.Errorf("failed to update journal entry: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("journal entry not found")
+	}
+
+	return nil
+}
+
+// Delete soft deletes a journal entry
+func (r *JournalRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{"_id": id})
+
+	result, err := r.collection.UpdateOne(ctx, filter, SoftDelete.CreateSoftDeleteUpdate())
+	if err != nil {
+		r.logger.Error("Failed to delete journal entry", zap.Error(err))
+		return fmt.Errorf("failed to delete journal entry: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("journal entry not found")
+	}
+
+	return nil
+}
+
+// Search retrieves the couple's journal entries the viewer can see whose title, or
+// content when the entry is not sealed for the viewer, contains query, newest date first
+func (r *JournalRepository) Search(ctx context.Context, matchCode string, viewerID primitive.ObjectID, query string, now time.Time, limit int) ([]*domain.JournalEntry, error) {
+	pattern := bson.M{"$regex": regexp.QuoteMeta(query), "$options": "i"}
+
+	filter := r.visibleFilter(matchCode, viewerID)
+	filter["$or"] = bson.A{
+		bson.M{"title": pattern},
+		bson.M{
+			"content": pattern,
+			"$or": bson.A{
+				bson.M{"author_id": viewerID},
+				bson.M{"unlock_at": bson.M{"$exists": false}},
+				bson.M{"unlock_at": bson.M{"$lte": now}},
+			},
+		},
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "date", Value: -1}}).
+		SetLimit(int64(limit))
+
+	return r.find(ctx, filter, opts)
+}
+
+// Restore restores a soft-deleted journal entry
+func (r *JournalRepository) Restore(ctx context.Context, id primitive.ObjectID) error {
+	filter := SoftDelete.GetDeletedFilterWithCondition(bson.M{"_id": id})
+
+	result, err := r.collection.UpdateOne(ctx, filter, SoftDelete.CreateRestoreUpdate())
+	if err != nil {
+		r.logger.Error("Failed to restore journal entry", zap.Error(err))
+		return fmt.Errorf("failed to restore journal entry: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("journal entry not found or not deleted")
+	}
+
+	return nil
+}
+
+// HardDelete permanently deletes a journal entry
+func (r *JournalRepository) HardDelete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		r.logger.Error("Failed to hard delete journal entry", zap.Error(err))
+		return fmt.Errorf("failed to hard delete journal entry: %w", err)
+	}
+
+	if result.DeletedCount == 0 {
+		return fmt.Errorf("journal entry not found")
+	}
+
+	return nil
+}
+
+// GetDeleted retrieves one of the couple's soft-deleted journal entries by ID
+func (r *JournalRepository) GetDeleted(ctx context.Context, matchCode string, id primitive.ObjectID) (*domain.JournalEntry, error) {
+	var entry domain.JournalEntry
+	filter := SoftDelete.GetDeletedFilterWithCondition(bson.M{
+		"_id":        id,
+		"match_code": matchCode,
+	})
+
+	err := r.collection.FindOne(ctx, filter).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("journal entry not found or not deleted")
+		}
+		r.logger.Error("Failed to get deleted journal entry", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to get deleted journal entry: %w", err)
+	}
+
+	return &entry, nil
+}
+
+// ListDeleted retrieves the couple's soft-deleted journal entries of an author, most
+// recently deleted first
+func (r *JournalRepository) ListDeleted(ctx context.Context, matchCode string, authorID primitive.ObjectID, limit int) ([]*domain.JournalEntry, error) {
+	filter := SoftDelete.GetDeletedFilterWithCondition(bson.M{
+		"match_code": matchCode,
+		"author_id":  authorID,
+	})
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "deleted_at", Value: -1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+// PurgeDeletedBefore permanently deletes journal entries soft-deleted before cutoff
+func (r *JournalRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"deleted_at": bson.M{"$lt": cutoff}})
+	if err != nil {
+		r.logger.Error("Failed to purge deleted journal entries", zap.Error(err))
+		return 0, fmt.Errorf("failed to purge deleted journal entries: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+// find runs a query and decodes the matching journal entries
+func (r *JournalRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*domain.JournalEntry, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get journal entries", zap.Error(err))
+		return nil, fmt.Errorf("failed to get journal entries: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var entries []*domain.JournalEntry
+	if err := cursor.All(ctx, &entries); err != nil {
+		r.logger.Error("Failed to decode journal entries", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode journal entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// visibleFilter builds the filter for the couple's active journal entries the viewer
+// can see: their own, and the partner's that are not private. It avoids $or so that
+// applyCursorOn can add its own.
+func (r *JournalRepository) visibleFilter(matchCode string, viewerID primitive.ObjectID) bson.M {
+	return SoftDelete.GetActiveFilterWithCondition(bson.M{
+		"match_code": matchCode,
+		"$nor": bson.A{
+			bson.M{"is_private": true, "author_id": bson.M{"$ne": viewerID}},
+		},
+	})
+}
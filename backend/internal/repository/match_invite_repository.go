@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// MatchInviteRepository implements domain.MatchInviteRepository
+type MatchInviteRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewMatchInviteRepository creates a new match invite repository
+func NewMatchInviteRepository(db *mongo.Database, logger *zap.Logger) domain.MatchInviteRepository {
+	return &MatchInviteRepository{
+		collection: db.Collection("match_invites"),
+		logger:     logger,
+	}
+}
+
+// Replace deletes the sender's invites and stores the new one
+func (r *MatchInviteRepository) Replace(ctx context.Context, invite *domain.MatchInvite) error {
+	if _, err := r.collection.DeleteMany(ctx, bson.M{"sender_id": invite.SenderID}); err != nil {
+		r.logger.Error("Failed to delete previous match invites", zap.Error(err))
+		return fmt.Errorf("failed to delete previous match invites: %w", err)
+	}
+
+	invite.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, invite)
+	if err != nil {
+		r.logger.Error("Failed to create match invite", zap.Error(err))
+		return fmt.Errorf("failed to create match invite: %w", err)
+	}
+
+	invite.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByCodeHash retrieves an unexpired invite by the hash of its code. Expired invites
+// are removed by a TTL index, which may lag behind, so expiry is checked here too.
+func (r *MatchInviteRepository) GetByCodeHash(ctx context.Context, codeHash string, now time.Time) (*domain.MatchInvite, error) {
+	var invite domain.MatchInvite
+
+	filter := bson.M{
+		"code_hash":  codeHash,
+		"expires_at": bson.M{"$gt": now},
+	}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&invite)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("match invite not found")
+		}
+		r.logger.Error("Failed to get match invite", zap.Error(err))
+		return nil, fmt.Errorf("failed to get match invite: %w", err)
+	}
+
+	return &invite, nil
+}
+
+// Consume deletes an invite, reporting false when it was already gone
+func (r *MatchInviteRepository) Consume(ctx context.Context, id primitive.ObjectID) (bool, error) {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		r.logger.Error("Failed to consume match invite", zap.Error(err))
+		return false, fmt.Errorf("failed to consume match invite: %w", err)
+	}
+
+	return result.DeletedCount == 1, nil
+}
@@ -111,6 +111,76 @@ func (r *MatchRequestRepository) GetByReceiverID(receiverID primitive.ObjectID,
 	return matchRequests, nil
 }
 
+// GetBySenderIDAndStatus retrieves match requests sent by a user with the given status.
+// An empty status matches every status.
+func (r *MatchRequestRepository) GetBySenderIDAndStatus(senderID primitive.ObjectID, status domain.MatchRequestStatus, limit, offset int) ([]*domain.MatchRequest, error) {
+	return r.findPage(statusFilter(bson.M{"sender_id": senderID}, status), limit, offset)
+}
+
+// GetByReceiverIDAndStatus retrieves match requests received by a user with the given status.
+// An empty status matches every status.
+func (r *MatchRequestRepository) GetByReceiverIDAndStatus(receiverID primitive.ObjectID, status domain.MatchRequestStatus, limit, offset int) ([]*domain.MatchRequest, error) {
+	return r.findPage(statusFilter(bson.M{"receiver_id": receiverID}, status), limit, offset)
+}
+
+// CountBySenderIDAndStatus counts match requests sent by a user with the given status
+func (r *MatchRequestRepository) CountBySenderIDAndStatus(senderID primitive.ObjectID, status domain.MatchRequestStatus) (int64, error) {
+	return r.count(statusFilter(bson.M{"sender_id": senderID}, status))
+}
+
+// CountByReceiverIDAndStatus counts match requests received by a user with the given status
+func (r *MatchRequestRepository) CountByReceiverIDAndStatus(receiverID primitive.ObjectID, status domain.MatchRequestStatus) (int64, error) {
+	return r.count(statusFilter(bson.M{"receiver_id": receiverID}, status))
+}
+
+// findPage retrieves a page of match requests matching filter, newest first
+func (r *MatchRequestRepository) findPage(filter bson.M, limit, offset int) ([]*domain.MatchRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get match requests", zap.Error(err))
+		return nil, fmt.Errorf("failed to get match requests: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var matchRequests []*domain.MatchRequest
+	if err := cursor.All(ctx, &matchRequests); err != nil {
+		r.logger.Error("Failed to decode match requests", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode match requests: %w", err)
+	}
+
+	return matchRequests, nil
+}
+
+// count counts the match requests matching filter
+func (r *MatchRequestRepository) count(filter bson.M) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to count match requests", zap.Error(err))
+		return 0, fmt.Errorf("failed to count match requests: %w", err)
+	}
+
+	return count, nil
+}
+
+// statusFilter adds the status condition to filter unless status is empty
+func statusFilter(filter bson.M, status domain.MatchRequestStatus) bson.M {
+	if status != "" {
+		filter["status"] = status
+	}
+	return filter
+}
+
 // GetByReceiverEmail retrieves match requests by receiver email
 func (r *MatchRequestRepository) GetByReceiverEmail(email string, limit, offset int) ([]*domain.MatchRequest, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -221,3 +291,43 @@ func (r *MatchRequestRepository) ExistsPendingRequest(senderID, receiverID primi
 
 	return count > 0, nil
 }
+
+// staleMatchRequestFilter matches match requests that were not accepted and have not changed
+// since before. Accepted requests are kept as the record of the match.
+func staleMatchRequestFilter(before time.Time) bson.M {
+	return bson.M{
+		"status":     bson.M{"$ne": domain.MatchRequestStatusAccepted},
+		"updated_at": bson.M{"$lt": before},
+	}
+}
+
+// ListStaleIDs lists up to limit IDs, after afterID, of match requests that were not
+// accepted and have not changed since before
+func (r *MatchRequestRepository) ListStaleIDs(before time.Time, afterID primitive.ObjectID, limit int) ([]primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	ids, err := findIDsAfter(ctx, r.collection, staleMatchRequestFilter(before), afterID, limit)
+	if err != nil {
+		r.logger.Error("Failed to list stale match requests", zap.Error(err))
+		return nil, fmt.Errorf("failed to list stale match requests: %w", err)
+	}
+	return ids, nil
+}
+
+// PurgeStale permanently deletes those of ids that are still stale at before
+func (r *MatchRequestRepository) PurgeStale(ids []primitive.ObjectID, before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	filter := staleMatchRequestFilter(before)
+	filter["_id"] = bson.M{"$in": ids}
+
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to purge stale match requests", zap.Error(err))
+		return 0, fmt.Errorf("failed to purge stale match requests: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
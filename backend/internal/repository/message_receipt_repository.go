@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/cache"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// MessageReceiptRepository implements domain.MessageReceiptRepository on Redis pub/sub,
+// so that receipts reach senders connected to any instance
+type MessageReceiptRepository struct {
+	cache  cache.Cache
+	logger *zap.Logger
+}
+
+// NewMessageReceiptRepository creates a new Redis message receipt repository
+func NewMessageReceiptRepository(cache cache.Cache, logger *zap.Logger) domain.MessageReceiptRepository {
+	return &MessageReceiptRepository{
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// Publish delivers receipt to the user's subscribers through Redis pub/sub
+func (r *MessageReceiptRepository) Publish(ctx context.Context, userID primitive.ObjectID, receipt *domain.MessageReceipt) error {
+	return r.cache.Publish(ctx, messageReceiptChannel(userID), receipt)
+}
+
+// Subscribe delivers the receipts published to the user until ctx is done
+func (r *MessageReceiptRepository) Subscribe(ctx context.Context, userID primitive.ObjectID) (<-chan *domain.MessageReceipt, error) {
+	messages, err := r.cache.Subscribe(ctx, messageReceiptChannel(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := make(chan *domain.MessageReceipt)
+	go func() {
+		defer close(receipts)
+		for message := range messages {
+			var receipt domain.MessageReceipt
+			if err := json.Unmarshal(message, &receipt); err != nil {
+				r.logger.Warn("Failed to decode message receipt", zap.Error(err), zap.String("user_id", userID.Hex()))
+				continue
+			}
+			select {
+			case receipts <- &receipt:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return receipts, nil
+}
+
+// messageReceiptChannel returns the Redis channel the receipts for a sender go through
+func messageReceiptChannel(userID primitive.ObjectID) string {
+	return "message-receipts:" + userID.Hex()
+}
+
+// MemoryMessageReceiptRepository implements domain.MessageReceiptRepository in memory,
+// for deployments without Redis. Receipts only reach senders connected to the
+// instance the receiver acknowledged the messages on.
+type MemoryMessageReceiptRepository struct {
+	mu          sync.Mutex
+	subscribers map[primitive.ObjectID]map[chan *domain.MessageReceipt]struct{}
+}
+
+// NewMemoryMessageReceiptRepository creates a new in-memory message receipt repository
+func NewMemoryMessageReceiptRepository() domain.MessageReceiptRepository {
+	return &MemoryMessageReceiptRepository{
+		subscribers: make(map[primitive.ObjectID]map[chan *domain.MessageReceipt]struct{}),
+	}
+}
+
+// Publish delivers receipt to the user's subscribers on this instance. A subscriber
+// that does not keep up misses the receipt rather than blocking the publisher.
+func (r *MemoryMessageReceiptRepository) Publish(ctx context.Context, userID primitive.ObjectID, receipt *domain.MessageReceipt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for subscriber := range r.subscribers[userID] {
+		select {
+		case subscriber <- receipt:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe delivers the receipts published to the user until ctx is done
+func (r *MemoryMessageReceiptRepository) Subscribe(ctx context.Context, userID primitive.ObjectID) (<-chan *domain.MessageReceipt, error) {
+	receipts := make(chan *domain.MessageReceipt, memorySubscriberBuffer)
+
+	r.mu.Lock()
+	if r.subscribers[userID] == nil {
+		r.subscribers[userID] = make(map[chan *domain.MessageReceipt]struct{})
+	}
+	r.subscribers[userID][receipts] = struct{}{}
+	r.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		r.mu.Lock()
+		delete(r.subscribers[userID], receipts)
+		if len(r.subscribers[userID]) == 0 {
+			delete(r.subscribers, userID)
+		}
+		r.mu.Unlock()
+		close(receipts)
+	}()
+
+	return receipts, nil
+}
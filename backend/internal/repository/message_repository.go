@@ -0,0 +1,496 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// MessageRepository implements domain.MessageRepository
+type MessageRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewMessageRepository creates a new message repository
+func NewMessageRepository(db *mongo.Database, logger *zap.Logger) domain.MessageRepository {
+	return &MessageRepository{
+		collection: db.Collection("messages"),
+		logger:     logger,
+	}
+}
+
+// Create creates a new message
+func (r *MessageRepository) Create(ctx context.Context, message *domain.Message) error {
+	now := time.Now()
+	message.CreatedAt = now
+	message.UpdatedAt = now
+
+	result, err := r.collection.InsertOne(ctx, message)
+	if err != nil {
+		r.logger.Error("Failed to create message", zap.Error(err))
+		return fmt.Errorf("failed to create message: %w", err)
+	}
+
+	message.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindByID retrieves a message that has not been deleted
+func (r *MessageRepository) FindByID(ctx context.Context, id primitive.ObjectID) (*domain.Message, error) {
+	filter := bson.M{"_id": id, "is_deleted": bson.M{"$ne": true}}
+
+	var message domain.Message
+	if err := r.collection.FindOne(ctx, filter).Decode(&message); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, domain.ErrMessageNotFound
+		}
+		r.logger.Error("Failed to get message", zap.Error(err))
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	return &message, nil
+}
+
+// FindConversation lists a page of the messages between two users, newest first
+func (r *MessageRepository) FindConversation(ctx context.Context, userID, partnerID primitive.ObjectID, page, limit int) ([]*domain.Message, int64, error) {
+	filter := conversationFilter(userID, partnerID)
+
+	total, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to count messages", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to count messages: %w", err)
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64((page - 1) * limit)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}})
+
+	messages, err := r.find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return messages, total, nil
+}
+
+// FindConversationCursor lists the messages between two users after cursor, newest first
+func (r *MessageRepository) FindConversationCursor(ctx context.Context, userID, partnerID primitive.ObjectID, cursor *domain.Cursor, limit int) ([]*domain.Message, error) {
+	filter := applyCursor(conversationFilter(userID, partnerID), cursor)
+	return r.find(ctx, filter, cursorFindOptions(limit))
+}
+
+// FindUserConversations lists a page of the user's conversations, most recently
+// active first. Partner names are left for the caller to fill in.
+func (r *MessageRepository) FindUserConversations(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]*domain.Conversation, int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"$or":        bson.A{bson.M{"sender_id": userID}, bson.M{"receiver_id": userID}},
+			"is_deleted": bson.M{"$ne": true},
+			"hidden_for": bson.M{"$ne": userID},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$sender_id", userID}}, "$receiver_id", "$sender_id",
+			}},
+			"last_message": bson.M{"$first": "$$ROOT"},
+			"unread_count": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$and": bson.A{
+					bson.M{"$eq": bson.A{"$receiver_id", userID}},
+					bson.M{"$ne": bson.A{"$is_read", true}},
+				}}, 1, 0,
+			}}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "last_message.created_at", Value: -1}}}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "count"}},
+			"conversations": bson.A{
+				bson.M{"$skip": (page - 1) * limit},
+				bson.M{"$limit": limit},
+			},
+		}}},
+	}
+
+	mongoCursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("Failed to get conversations", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return nil, 0, fmt.Errorf("failed to get conversations: %w", err)
+	}
+	defer mongoCursor.Close(ctx)
+
+	var results []struct {
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Conversations []struct {
+			PartnerID   primitive.ObjectID `bson:"_id"`
+			LastMessage *domain.Message    `bson:"last_message"`
+			UnreadCount int64              `bson:"unread_count"`
+		} `bson:"conversations"`
+	}
+	if err := mongoCursor.All(ctx, &results); err != nil {
+		r.logger.Error("Failed to decode conversations", zap.Error(err))
+		return nil, 0, fmt.Errorf("failed to decode conversations: %w", err)
+	}
+
+	conversations := []*domain.Conversation{}
+	if len(results) == 0 {
+		return conversations, 0, nil
+	}
+
+	var total int64
+	if len(results[0].Total) > 0 {
+		total = results[0].Total[0].Count
+	}
+	for _, result := range results[0].Conversations {
+		conversations = append(conversations, &domain.Conversation{
+			PartnerID:   result.PartnerID,
+			LastMessage: result.LastMessage,
+			UnreadCount: result.UnreadCount,
+			UpdatedAt:   result.LastMessage.CreatedAt,
+		})
+	}
+
+	return conversations, total, nil
+}
+
+// MarkAsRead marks the messages the partner sent to the user as read and returns
+// those that were unread
+func (r *MessageRepository) MarkAsRead(ctx context.Context, userID, partnerID primitive.ObjectID) ([]*domain.Message, error) {
+	return r.markRead(ctx, bson.M{
+		"sender_id":   partnerID,
+		"receiver_id": userID,
+	})
+}
+
+// MarkDelivered marks the given messages sent to the user as delivered and returns
+// those that were not yet
+func (r *MessageRepository) MarkDelivered(ctx context.Context, userID primitive.ObjectID, messageIDs []primitive.ObjectID) ([]*domain.Message, error) {
+	messages, err := r.findReceived(ctx, bson.M{
+		"_id":          bson.M{"$in": messageIDs},
+		"receiver_id":  userID,
+		"delivered_at": nil,
+	})
+	if err != nil || len(messages) == 0 {
+		return messages, err
+	}
+
+	now := time.Now()
+	if err := r.setDelivered(ctx, messageIDsOf(messages), now); err != nil {
+		return nil, err
+	}
+	for _, message := range messages {
+		message.DeliveredAt = &now
+	}
+
+	return messages, nil
+}
+
+// MarkRead marks the given messages sent to the user as read, and delivered if they
+// were not yet, and returns those that were unread
+func (r *MessageRepository) MarkRead(ctx context.Context, userID primitive.ObjectID, messageIDs []primitive.ObjectID) ([]*domain.Message, error) {
+	return r.markRead(ctx, bson.M{
+		"_id":         bson.M{"$in": messageIDs},
+		"receiver_id": userID,
+	})
+}
+
+// markRead marks the unread messages matching filter as read, and delivered if they
+// were not yet, and returns them
+func (r *MessageRepository) markRead(ctx context.Context, filter bson.M) ([]*domain.Message, error) {
+	filter["is_read"] = false
+	messages, err := r.findReceived(ctx, filter)
+	if err != nil || len(messages) == 0 {
+		return messages, err
+	}
+
+	ids := messageIDsOf(messages)
+	now := time.Now()
+
+	// Messages read before the app acknowledged them were delivered at the same time
+	if err := r.setDelivered(ctx, ids, now); err != nil {
+		return nil, err
+	}
+
+	update := bson.M{
+		"$set": bson.M{"is_read": true, "read_at": now, "updated_at": now},
+	}
+	if _, err := r.collection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}, "is_read": false}, update); err != nil {
+		r.logger.Error("Failed to mark messages as read", zap.Error(err))
+		return nil, fmt.Errorf("failed to mark messages as read: %w", err)
+	}
+
+	for _, message := range messages {
+		message.IsRead = true
+		message.ReadAt = &now
+	}
+
+	return messages, nil
+}
+
+// setDelivered sets the delivery time of the given messages that do not have one
+func (r *MessageRepository) setDelivered(ctx context.Context, ids []primitive.ObjectID, at time.Time) error {
+	filter := bson.M{"_id": bson.M{"$in": ids}, "delivered_at": nil}
+	update := bson.M{"$set": bson.M{"delivered_at": at, "updated_at": at}}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		r.logger.Error("Failed to mark messages as delivered", zap.Error(err))
+		return fmt.Errorf("failed to mark messages as delivered: %w", err)
+	}
+	return nil
+}
+
+// findReceived finds the messages matching filter that have not been deleted, with
+// only the fields receipts need
+func (r *MessageRepository) findReceived(ctx context.Context, filter bson.M) ([]*domain.Message, error) {
+	filter["is_deleted"] = bson.M{"$ne": true}
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "sender_id": 1, "receiver_id": 1})
+	return r.find(ctx, filter, opts)
+}
+
+// SoftDelete marks a message sent by the user as deleted
+func (r *MessageRepository) SoftDelete(ctx context.Context, messageID, userID primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":        messageID,
+		"sender_id":  userID,
+		"is_deleted": bson.M{"$ne": true},
+	}
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{"is_deleted": true, "deleted_at": now, "updated_at": now},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to delete message", zap.Error(err))
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrMessageNotFound
+	}
+
+	return nil
+}
+
+// HideForUser hides a message the user sent or received from the user only
+func (r *MessageRepository) HideForUser(ctx context.Context, messageID, userID primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":        messageID,
+		"$or":        bson.A{bson.M{"sender_id": userID}, bson.M{"receiver_id": userID}},
+		"is_deleted": bson.M{"$ne": true},
+		"hidden_for": bson.M{"$ne": userID},
+	}
+	update := bson.M{
+		"$addToSet": bson.M{"hidden_for": userID},
+		"$set":      bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to hide message", zap.Error(err))
+		return fmt.Errorf("failed to hide message: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrMessageNotFound
+	}
+
+	return nil
+}
+
+// Update saves the content, edit time and read state of a message
+func (r *MessageRepository) Update(ctx context.Context, message *domain.Message) error {
+	message.UpdatedAt = time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"content":      message.Content,
+			"message_type": message.MessageType,
+			"is_read":      message.IsRead,
+			"read_at":      message.ReadAt,
+			"edited_at":    message.EditedAt,
+			"updated_at":   message.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": message.ID}, update)
+	if err != nil {
+		r.logger.Error("Failed to update message", zap.Error(err))
+		return fmt.Errorf("failed to update message: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrMessageNotFound
+	}
+
+	return nil
+}
+
+// Search lists the messages between two users whose content contains every term of
+// query, newest first. Matching ignores case and diacritics.
+func (r *MessageRepository) Search(ctx context.Context, userID, partnerID primitive.ObjectID, query string, cursor *domain.Cursor, limit int) ([]*domain.Message, error) {
+	filter := conversationFilter(userID, partnerID)
+	filter["$text"] = bson.M{"$search": textSearchAllTerms(query)}
+	filter = applyCursor(filter, cursor)
+
+	return r.find(ctx, filter, cursorFindOptions(limit))
+}
+
+// ReassignUser moves the messages sent and received by one user to another, along with
+// the messages the user deleted for themselves
+func (r *MessageRepository) ReassignUser(ctx context.Context, fromUserID, toUserID primitive.ObjectID) (int64, error) {
+	var moved int64
+	for _, field := range []string{"sender_id", "receiver_id"} {
+		result, err := r.collection.UpdateMany(ctx, bson.M{field: fromUserID}, bson.M{"$set": bson.M{field: toUserID}})
+		if err != nil {
+			r.logger.Error("Failed to move messages", zap.Error(err), zap.String("field", field))
+			return moved, fmt.Errorf("failed to move messages: %w", err)
+		}
+		moved += result.ModifiedCount
+	}
+
+	if _, err := r.collection.UpdateMany(ctx,
+		bson.M{"hidden_for": fromUserID},
+		bson.M{"$set": bson.M{"hidden_for.$": toUserID}},
+	); err != nil {
+		r.logger.Error("Failed to move hidden messages", zap.Error(err))
+		return moved, fmt.Errorf("failed to move hidden messages: %w", err)
+	}
+
+	return moved, nil
+}
+
+// ListDeleted lists the messages the sender deleted, most recently deleted first
+func (r *MessageRepository) ListDeleted(ctx context.Context, senderID primitive.ObjectID, limit int) ([]*domain.Message, error) {
+	filter := bson.M{"sender_id": senderID, "is_deleted": true}
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "deleted_at", Value: -1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+// Restore undeletes a message the sender deleted
+func (r *MessageRepository) Restore(ctx context.Context, messageID, senderID primitive.ObjectID) error {
+	filter := bson.M{"_id": messageID, "sender_id": senderID, "is_deleted": true}
+	update := bson.M{
+		"$set":   bson.M{"is_deleted": false, "updated_at": time.Now()},
+		"$unset": bson.M{"deleted_at": ""},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to restore message", zap.Error(err))
+		return fmt.Errorf("failed to restore message: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrMessageNotFound
+	}
+
+	return nil
+}
+
+// PurgeDeletedBefore permanently deletes the messages deleted before cutoff
+func (r *MessageRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	filter := bson.M{"is_deleted": true, "deleted_at": bson.M{"$lt": cutoff}}
+
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to purge deleted messages", zap.Error(err))
+		return 0, fmt.Errorf("failed to purge deleted messages: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+// CountCreatedPerDay counts the messages sent from from up to to per couple and day.
+// Messages carry no match code, so the sender's current one is used.
+func (r *MessageRepository) CountCreatedPerDay(ctx context.Context, from, to time.Time) ([]*domain.DailyCount, error) {
+	lookupSender := bson.D{{Key: "$lookup", Value: bson.M{
+		"from":         "users",
+		"localField":   "sender_id",
+		"foreignField": "_id",
+		"pipeline":     bson.A{bson.M{"$project": bson.M{"match_code": 1}}},
+		"as":           "sender",
+	}}}
+
+	counts, err := countCreatedPerDay(ctx, r.collection, from, to, bson.M{"$first": "$sender.match_code"}, lookupSender)
+	if err != nil {
+		r.logger.Error("Failed to count messages per day", zap.Error(err))
+		return nil, fmt.Errorf("failed to count messages: %w", err)
+	}
+	return counts, nil
+}
+
+// find runs a query and decodes the messages it returns
+func (r *MessageRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*domain.Message, error) {
+	mongoCursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get messages", zap.Error(err))
+		return nil, fmt.Errorf("failed to get messages: %w", err)
+	}
+	defer mongoCursor.Close(ctx)
+
+	var messages []*domain.Message
+	if err := mongoCursor.All(ctx, &messages); err != nil {
+		r.logger.Error("Failed to decode messages", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode messages: %w", err)
+	}
+
+	return messages, nil
+}
+
+// messageIDsOf returns the IDs of messages
+func messageIDsOf(messages []*domain.Message) []primitive.ObjectID {
+	ids := make([]primitive.ObjectID, 0, len(messages))
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+	}
+	return ids
+}
+
+// conversationFilter matches the messages between two users that have not been
+// deleted, neither for both nor by userID for themselves. The conversation is matched
+// inside $and, as cursors take the top-level $or.
+func conversationFilter(userID, partnerID primitive.ObjectID) bson.M {
+	return bson.M{
+		"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"sender_id": userID, "receiver_id": partnerID},
+				bson.M{"sender_id": partnerID, "receiver_id": userID},
+			}},
+		},
+		"is_deleted": bson.M{"$ne": true},
+		"hidden_for": bson.M{"$ne": userID},
+	}
+}
+
+// textSearchAllTerms turns a query into a $text search that requires every term.
+// A plain $text search matches documents with any of the terms; quoting each term
+// makes them all required.
+func textSearchAllTerms(query string) string {
+	terms := strings.FieldsFunc(query, func(r rune) bool {
+		return r == '"' || unicode.IsSpace(r)
+	})
+
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		// A leading hyphen would negate the term
+		if term = strings.TrimLeft(term, "-"); term != "" {
+			quoted = append(quoted, `"`+term+`"`)
+		}
+	}
+	return strings.Join(quoted, " ")
+}
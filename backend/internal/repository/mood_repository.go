@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// MoodRepository implements domain.MoodRepository
+type MoodRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewMoodRepository creates a new mood repository
+func NewMoodRepository(db *mongo.Database, logger *zap.Logger) domain.MoodRepository {
+	return &MoodRepository{
+		collection: db.Collection("mood_check_ins"),
+		logger:     logger,
+	}
+}
+
+// Upsert records the user's mood for the day of checkIn, replacing an earlier
+// check-in of that day
+func (r *MoodRepository) Upsert(ctx context.Context, checkIn *domain.MoodCheckIn) error {
+	now := time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"match_code": checkIn.MatchCode,
+			"score":      checkIn.Score,
+			"emoji":      checkIn.Emoji,
+			"note":       checkIn.Note,
+			"updated_at": now,
+		},
+		"$setOnInsert": bson.M{"created_at": now},
+	}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	filter := bson.M{"user_id": checkIn.UserID, "date": checkIn.Date}
+
+	var stored domain.MoodCheckIn
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&stored); err != nil {
+		r.logger.Error("Failed to record mood check-in", zap.Error(err), zap.String("user_id", checkIn.UserID.Hex()))
+		return fmt.Errorf("failed to record mood check-in: %w", err)
+	}
+
+	*checkIn = stored
+	return nil
+}
+
+// GetByUser retrieves a user's mood check-ins, newest first, optionally limited to
+// days within [from, to]
+func (r *MoodRepository) GetByUser(ctx context.Context, userID primitive.ObjectID, from, to *time.Time, limit int) ([]*domain.MoodCheckIn, error) {
+	filter := bson.M{"user_id": userID}
+	dateRange := bson.M{}
+	if from != nil {
+		dateRange["$gte"] = *from
+	}
+	if to != nil {
+		dateRange["$lte"] = *to
+	}
+	if len(dateRange) > 0 {
+		filter["date"] = dateRange
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "date", Value: -1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+// GetByMatchCode retrieves both partners' mood check-ins for the days within
+// [from, to), oldest first
+func (r *MoodRepository) GetByMatchCode(ctx context.Context, matchCode string, from, to time.Time) ([]*domain.MoodCheckIn, error) {
+	filter := bson.M{
+		"match_code": matchCode,
+		"date":       bson.M{"$gte": from, "$lt": to},
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}})
+
+	return r.find(ctx, filter, opts)
+}
+
+// find runs a query and decodes the matching mood check-ins
+func (r *MoodRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]*domain.MoodCheckIn, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get mood check-ins", zap.Error(err))
+		return nil, fmt.Errorf("failed to get mood check-ins: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var checkIns []*domain.MoodCheckIn
+	if err := cursor.All(ctx, &checkIns); err != nil {
+		r.logger.Error("Failed to decode mood check-ins", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode mood check-ins: %w", err)
+	}
+
+	return checkIns, nil
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// NotificationRepository implements domain.NotificationRepository
+type NotificationRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *mongo.Database, logger *zap.Logger) domain.NotificationRepository {
+	return &NotificationRepository{
+		collection: db.Collection("notifications"),
+		logger:     logger,
+	}
+}
+
+// Create creates a new notification
+func (r *NotificationRepository) Create(ctx context.Context, notification *domain.Notification) error {
+	notification.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, notification)
+	if err != nil {
+		r.logger.Error("Failed to create notification", zap.Error(err))
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	notification.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByUserID retrieves a user's notifications, newest first
+func (r *NotificationRepository) GetByUserID(ctx context.Context, userID primitive.ObjectID, limit, offset int) ([]*domain.Notification, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		r.logger.Error("Failed to get notifications", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var notifications []*domain.Notification
+	if err := cursor.All(ctx, &notifications); err != nil {
+		r.logger.Error("Failed to decode notifications", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// CountByUserID counts a user's notifications, optionally only unread ones
+func (r *NotificationRepository) CountByUserID(ctx context.Context, userID primitive.ObjectID, unreadOnly bool) (int64, error) {
+	filter := bson.M{"user_id": userID}
+	if unreadOnly {
+		filter["is_read"] = false
+	}
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to count notifications", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+// MarkAsRead marks a single notification of the user as read
+func (r *NotificationRepository) MarkAsRead(ctx context.Context, id, userID primitive.ObjectID) error {
+	filter := bson.M{
+		"_id":     id,
+		"user_id": userID,
+	}
+	update := bson.M{
+		"$set": bson.M{"is_read": true, "read_at": time.Now()},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to mark notification as read", zap.Error(err))
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return domain.ErrNotificationNotFound
+	}
+
+	return nil
+}
+
+// MarkAllAsRead marks all unread notifications of the user as read
+func (r *NotificationRepository) MarkAllAsRead(ctx context.Context, userID primitive.ObjectID) error {
+	filter := bson.M{
+		"user_id": userID,
+		"is_read": false,
+	}
+	update := bson.M{
+		"$set": bson.M{"is_read": true, "read_at": time.Now()},
+	}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		r.logger.Error("Failed to mark all notifications as read", zap.Error(err))
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return nil
+}
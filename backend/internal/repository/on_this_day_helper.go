@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// applyOnThisDay limits filter to documents whose field falls on the calendar day of
+// date (UTC) in an earlier year. On February 28th of a common year, February 29th
+// counts as the same day, so leap day memories are not skipped for three years.
+func applyOnThisDay(filter bson.M, field string, date time.Time) bson.M {
+	date = date.UTC()
+	days := bson.A{date.Day()}
+	if date.Month() == time.February && date.Day() == 28 && !isLeapYear(date.Year()) {
+		days = append(days, 29)
+	}
+
+	startOfYear := time.Date(date.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	filter[field] = bson.M{"$lt": startOfYear}
+	filter["$expr"] = bson.M{"$and": bson.A{
+		bson.M{"$eq": bson.A{bson.M{"$month": "$" + field}, int(date.Month())}},
+		bson.M{"$in": bson.A{bson.M{"$dayOfMonth": "$" + field}, days}},
+	}}
+	return filter
+}
+
+// distinctMatchCodes lists the distinct non-empty match codes of the documents of
+// collection matching filter
+func distinctMatchCodes(ctx context.Context, collection *mongo.Collection, filter bson.M) ([]string, error) {
+	values, err := collection.Distinct(ctx, "match_code", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	matchCodes := make([]string, 0, len(values))
+	for _, value := range values {
+		if matchCode, ok := value.(string); ok && matchCode != "" {
+			matchCodes = append(matchCodes, matchCode)
+		}
+	}
+	return matchCodes, nil
+}
+
+// isLeapYear reports whether year has a February 29th
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// PendingActionRepository implements domain.PendingActionRepository
+type PendingActionRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewPendingActionRepository creates a new pending action repository
+func NewPendingActionRepository(db *mongo.Database, logger *zap.Logger) domain.PendingActionRepository {
+	return &PendingActionRepository{
+		collection: db.Collection("pending_actions"),
+		logger:     logger,
+	}
+}
+
+// Create creates a new pending action
+func (r *PendingActionRepository) Create(ctx context.Context, action *domain.PendingAction) error {
+	action.CreatedAt = time.Now()
+	action.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, action)
+	if err != nil {
+		r.logger.Error("Failed to create pending action", zap.Error(err))
+		return fmt.Errorf("failed to create pending action: %w", err)
+	}
+
+	action.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves a pending action by ID
+func (r *PendingActionRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.PendingAction, error) {
+	var action domain.PendingAction
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&action)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("pending action not found")
+		}
+		r.logger.Error("Failed to get pending action", zap.Error(err))
+		return nil, fmt.Errorf("failed to get pending action: %w", err)
+	}
+
+	return &action, nil
+}
+
+// GetOpen retrieves the undecided, unexpired action of a couple on a target
+func (r *PendingActionRepository) GetOpen(ctx context.Context, matchCode string, actionType domain.PendingActionType, targetID primitive.ObjectID, now time.Time) (*domain.PendingAction, error) {
+	var action domain.PendingAction
+
+	filter := openPendingActionFilter(matchCode, now)
+	filter["type"] = actionType
+	filter["target_id"] = targetID
+
+	err := r.collection.FindOne(ctx, filter).Decode(&action)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("pending action not found")
+		}
+		r.logger.Error("Failed to get open pending action", zap.Error(err))
+		return nil, fmt.Errorf("failed to get pending action: %w", err)
+	}
+
+	return &action, nil
+}
+
+// ListOpen retrieves the undecided, unexpired actions of a couple, newest first
+func (r *PendingActionRepository) ListOpen(ctx context.Context, matchCode string, now time.Time) ([]*domain.PendingAction, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, openPendingActionFilter(matchCode, now), opts)
+	if err != nil {
+		r.logger.Error("Failed to list pending actions", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to list pending actions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var actions []*domain.PendingAction
+	if err := cursor.All(ctx, &actions); err != nil {
+		r.logger.Error("Failed to decode pending actions", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode pending actions: %w", err)
+	}
+
+	return actions, nil
+}
+
+// UpdateStatus moves an action from one status to another, failing when it is no
+// longer in the from status. A pending action past its expiry can no longer be moved.
+func (r *PendingActionRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, from, to domain.PendingActionStatus, decidedBy primitive.ObjectID) error {
+	now := time.Now()
+	filter := bson.M{
+		"_id":    id,
+		"status": from,
+	}
+	if from == domain.PendingActionStatusPending {
+		filter["expires_at"] = bson.M{"$gt": now}
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     to,
+			"decided_by": decidedBy,
+			"decided_at": now,
+			"updated_at": now,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to update pending action status", zap.Error(err))
+		return fmt.Errorf("failed to update pending action: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("pending action not found")
+	}
+
+	return nil
+}
+
+// openPendingActionFilter matches the undecided, unexpired actions of a couple
+func openPendingActionFilter(matchCode string, now time.Time) bson.M {
+	return bson.M{
+		"match_code": matchCode,
+		"status":     domain.PendingActionStatusPending,
+		"expires_at": bson.M{"$gt": now},
+	}
+}
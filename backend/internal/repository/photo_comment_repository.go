@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// PhotoCommentRepository implements domain.PhotoCommentRepository
+type PhotoCommentRepository struct {
+	collection *mongo.Collection
+	softDelete *SoftDeleteFilter
+	logger     *zap.Logger
+}
+
+// NewPhotoCommentRepository creates a new photo comment repository
+func NewPhotoCommentRepository(db *mongo.Database, logger *zap.Logger) domain.PhotoCommentRepository {
+	return &PhotoCommentRepository{
+		collection: db.Collection("photo_comments"),
+		softDelete: NewSoftDeleteFilter(),
+		logger:     logger,
+	}
+}
+
+// Create creates a new photo comment
+func (r *PhotoCommentRepository) Create(ctx context.Context, comment *domain.PhotoComment) error {
+	comment.CreatedAt = time.Now()
+	comment.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, comment)
+	if err != nil {
+		r.logger.Error("Failed to create photo comment", zap.Error(err))
+		return fmt.Errorf("failed to create photo comment: %w", err)
+	}
+
+	comment.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves one of a couple's photo comments by ID
+func (r *PhotoCommentRepository) GetByID(ctx context.Context, matchCode string, id primitive.ObjectID) (*domain.PhotoComment, error) {
+	var comment domain.PhotoComment
+	filter := r.softDelete.GetActiveFilterWithCondition(bson.M{
+		"_id":        id,
+		"match_code": matchCode,
+	})
+
+	err := r.collection.FindOne(ctx, filter).Decode(&comment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("photo comment not found")
+		}
+		r.logger.Error("Failed to get photo comment by ID", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to get photo comment: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// GetByPhoto retrieves the comments on a photo, oldest first
+func (r *PhotoCommentRepository) GetByPhoto(ctx context.Context, matchCode string, photoID primitive.ObjectID, limit, offset int) ([]*domain.PhotoComment, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, r.photoFilter(matchCode, photoID), opts)
+	if err != nil {
+		r.logger.Error("Failed to get photo comments", zap.Error(err), zap.String("photo_id", photoID.Hex()))
+		return nil, fmt.Errorf("failed to get photo comments: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var comments []*domain.PhotoComment
+	if err := cursor.All(ctx, &comments); err != nil {
+		r.logger.Error("Failed to decode photo comments", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photo comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// CountByPhoto counts the comments on a photo
+func (r *PhotoCommentRepository) CountByPhoto(ctx context.Context, matchCode string, photoID primitive.ObjectID) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, r.photoFilter(matchCode, photoID))
+	if err != nil {
+		r.logger.Error("Failed to count photo comments", zap.Error(err), zap.String("photo_id", photoID.Hex()))
+		return 0, fmt.Errorf("failed to count photo comments: %w", err)
+	}
+	return count, nil
+}
+
+// Delete soft deletes a photo comment
+func (r *PhotoCommentRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	filter := r.softDelete.GetActiveFilterWithCondition(bson.M{"_id": id})
+
+	result, err := r.collection.UpdateOne(ctx, filter, r.softDelete.CreateSoftDeleteUpdate())
+	if err != nil {
+		r.logger.Error("Failed to delete photo comment", zap.Error(err))
+		return fmt.Errorf("failed to delete photo comment: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("photo comment not found")
+	}
+
+	return nil
+}
+
+// photoFilter builds the filter for the comments on one of a couple's photos
+func (r *PhotoCommentRepository) photoFilter(matchCode string, photoID primitive.ObjectID) bson.M {
+	return r.softDelete.GetActiveFilterWithCondition(bson.M{
+		"photo_id":   photoID,
+		"match_code": matchCode,
+	})
+}
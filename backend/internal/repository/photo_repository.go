@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/eralove/eralove-backend/internal/domain"
@@ -15,15 +16,18 @@ import (
 
 // PhotoRepositoryNew implements domain.PhotoRepository with MatchCode
 type PhotoRepositoryNew struct {
-	collection *mongo.Collection
-	logger     *zap.Logger
+	collection  *mongo.Collection
+	urlPrefixes []string
+	logger      *zap.Logger
 }
 
-// NewPhotoRepositoryWithMatchCode creates a new photo repository
-func NewPhotoRepositoryWithMatchCode(db *mongo.Database, logger *zap.Logger) domain.PhotoRepository {
+// NewPhotoRepositoryWithMatchCode creates a new photo repository. urlPrefixes are the
+// prefixes of the full URLs older photos stored instead of storage keys.
+func NewPhotoRepositoryWithMatchCode(db *mongo.Database, urlPrefixes []string, logger *zap.Logger) domain.PhotoRepository {
 	return &PhotoRepositoryNew{
-		collection: db.Collection("photos"),
-		logger:     logger,
+		collection:  db.Collection("photos"),
+		urlPrefixes: urlPrefixes,
+		logger:      logger,
 	}
 }
 
@@ -62,17 +66,17 @@ func (r *PhotoRepositoryNew) GetByID(ctx context.Context, id primitive.ObjectID)
 	return &photo, nil
 }
 
-// GetByMatchCode retrieves photos by match code with pagination
-func (r *PhotoRepositoryNew) GetByMatchCode(ctx context.Context, matchCode string, limit, offset int) ([]*domain.Photo, error) {
+// GetByMatchCode retrieves the photos of a match code the viewer can see with pagination
+func (r *PhotoRepositoryNew) GetByMatchCode(ctx context.Context, matchCode string, viewerID primitive.ObjectID, limit, offset int) ([]*domain.Photo, error) {
 	opts := options.Find().
 		SetLimit(int64(limit)).
 		SetSkip(int64(offset)).
 		SetSort(bson.D{{Key: "date", Value: -1}})
 
-	filter := bson.M{
+	filter := applyVisibleTo(bson.M{
 		"match_code": matchCode,
 		"deleted_at": bson.M{"$exists": false},
-	}
+	}, viewerID)
 	
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -90,20 +94,87 @@ func (r *PhotoRepositoryNew) GetByMatchCode(ctx context.Context, matchCode strin
 	return photos, nil
 }
 
-// GetByMatchCodeAndDate retrieves photos by match code and date
-func (r *PhotoRepositoryNew) GetByMatchCodeAndDate(ctx context.Context, matchCode string, date time.Time) ([]*domain.Photo, error) {
+// CountByMatchCode counts the active photos of a match code the viewer can see
+func (r *PhotoRepositoryNew) CountByMatchCode(ctx context.Context, matchCode string, viewerID primitive.ObjectID) (int64, error) {
+	filter := applyVisibleTo(bson.M{
+		"match_code": matchCode,
+		"deleted_at": bson.M{"$exists": false},
+	}, viewerID)
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to count photos by match code", zap.Error(err), zap.String("match_code", matchCode))
+		return 0, fmt.Errorf("failed to count photos: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetByMatchCodeCursor retrieves the photos of a match code the viewer can see, newest
+// first, starting after cursor
+func (r *PhotoRepositoryNew) GetByMatchCodeCursor(ctx context.Context, matchCode string, viewerID primitive.ObjectID, cursor *domain.Cursor, limit int) ([]*domain.Photo, error) {
+	filter := applyCursor(applyVisibleTo(bson.M{
+		"match_code": matchCode,
+		"deleted_at": bson.M{"$exists": false},
+	}, viewerID), cursor)
+
+	result, err := r.collection.Find(ctx, filter, cursorFindOptions(limit))
+	if err != nil {
+		r.logger.Error("Failed to get photos by cursor", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+	defer result.Close(ctx)
+
+	var photos []*domain.Photo
+	if err := result.All(ctx, &photos); err != nil {
+		r.logger.Error("Failed to decode photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photos: %w", err)
+	}
+
+	return photos, nil
+}
+
+// ListByDate retrieves the photos of a match code the viewer can see, newest photo date
+// first, optionally limited to dates within [from, to). The cursor positions on the
+// photo date.
+func (r *PhotoRepositoryNew) ListByDate(ctx context.Context, matchCode string, viewerID primitive.ObjectID, from, to *time.Time, cursor *domain.Cursor, limit int) ([]*domain.Photo, error) {
+	filter := applyVisibleTo(bson.M{
+		"match_code": matchCode,
+		"deleted_at": bson.M{"$exists": false},
+	}, viewerID)
+	filter = applyDateRange(filter, "date", from, to)
+	filter = applyCursorOn(filter, "date", cursor)
+
+	result, err := r.collection.Find(ctx, filter, cursorFindOptionsOn("date", limit))
+	if err != nil {
+		r.logger.Error("Failed to list photos by date", zap.Error(err))
+		return nil, fmt.Errorf("failed to list photos: %w", err)
+	}
+	defer result.Close(ctx)
+
+	var photos []*domain.Photo
+	if err := result.All(ctx, &photos); err != nil {
+		r.logger.Error("Failed to decode photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photos: %w", err)
+	}
+
+	return photos, nil
+}
+
+// GetByMatchCodeAndDate retrieves the photos of a match code the viewer can see by date
+func (r *PhotoRepositoryNew) GetByMatchCodeAndDate(ctx context.Context, matchCode string, viewerID primitive.ObjectID, date time.Time) ([]*domain.Photo, error) {
 	// Get start and end of the day
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
 	endOfDay := startOfDay.AddDate(0, 0, 1).Add(-time.Second)
 
-	filter := bson.M{
+	filter := applyVisibleTo(bson.M{
 		"match_code": matchCode,
 		"date": bson.M{
 			"$gte": startOfDay,
 			"$lte": endOfDay,
 		},
 		"deleted_at": bson.M{"$exists": false},
-	}
+	}, viewerID)
 
 	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
 	
@@ -123,6 +194,45 @@ func (r *PhotoRepositoryNew) GetByMatchCodeAndDate(ctx context.Context, matchCod
 	return photos, nil
 }
 
+// GetOnThisDay retrieves the photos of a couple the viewer can see taken on the
+// calendar day of date in earlier years, newest first
+func (r *PhotoRepositoryNew) GetOnThisDay(ctx context.Context, matchCode string, viewerID primitive.ObjectID, date time.Time) ([]*domain.Photo, error) {
+	filter := applyOnThisDay(applyVisibleTo(bson.M{
+		"match_code": matchCode,
+		"deleted_at": bson.M{"$exists": false},
+	}, viewerID), "date", date)
+
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get photos on this day", zap.Error(err))
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var photos []*domain.Photo
+	if err := cursor.All(ctx, &photos); err != nil {
+		r.logger.Error("Failed to decode photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photos: %w", err)
+	}
+
+	return photos, nil
+}
+
+// ListMatchCodesOnThisDay lists the couples with photos taken on the calendar day of
+// date in earlier years
+func (r *PhotoRepositoryNew) ListMatchCodesOnThisDay(ctx context.Context, date time.Time) ([]string, error) {
+	filter := applyOnThisDay(bson.M{"deleted_at": bson.M{"$exists": false}}, "date", date)
+
+	matchCodes, err := distinctMatchCodes(ctx, r.collection, filter)
+	if err != nil {
+		r.logger.Error("Failed to list couples with photos on this day", zap.Error(err))
+		return nil, fmt.Errorf("failed to list couples: %w", err)
+	}
+	return matchCodes, nil
+}
+
 // DeleteByMatchCode deletes all photos for a match code (for unmatch)
 func (r *PhotoRepositoryNew) DeleteByMatchCode(ctx context.Context, matchCode string) error {
 	filter := bson.M{
@@ -150,6 +260,9 @@ func (r *PhotoRepositoryNew) Update(ctx context.Context, id primitive.ObjectID,
 	update := bson.M{
 		"$set": photo,
 	}
+	if photo.Place == nil {
+		update["$unset"] = bson.M{"place": ""}
+	}
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
@@ -192,17 +305,18 @@ func (r *PhotoRepositoryNew) Delete(ctx context.Context, id primitive.ObjectID)
 	return nil
 }
 
-// SearchByMatchCode searches photos by match code and query
-func (r *PhotoRepositoryNew) SearchByMatchCode(ctx context.Context, matchCode string, query string, limit, offset int) ([]*domain.Photo, error) {
-	filter := bson.M{
+// SearchByMatchCode searches the photos of a match code the viewer can see
+func (r *PhotoRepositoryNew) SearchByMatchCode(ctx context.Context, matchCode string, viewerID primitive.ObjectID, query string, limit, offset int) ([]*domain.Photo, error) {
+	pattern := bson.M{"$regex": regexp.QuoteMeta(query), "$options": "i"}
+	filter := applyVisibleTo(bson.M{
 		"match_code": matchCode,
 		"$or": []bson.M{
-			{"title": bson.M{"$regex": query, "$options": "i"}},
-			{"description": bson.M{"$regex": query, "$options": "i"}},
+			{"title": pattern},
+			{"description": pattern},
 			{"tags": bson.M{"$in": []string{query}}},
 		},
 		"deleted_at": bson.M{"$exists": false},
-	}
+	}, viewerID)
 
 	opts := options.Find().
 		SetLimit(int64(limit)).
@@ -225,6 +339,361 @@ func (r *PhotoRepositoryNew) SearchByMatchCode(ctx context.Context, matchCode st
 	return photos, nil
 }
 
+// photoSearchTagFacetLimit is the number of most used tags counted for a photo search
+const photoSearchTagFacetLimit = 50
+
+// Search lists the couple's photos the viewer can see matching filter with the number
+// of matching photos and their most used tags, counted in a single aggregation
+func (r *PhotoRepositoryNew) Search(ctx context.Context, matchCode string, viewerID primitive.ObjectID, filter domain.PhotoSearchFilter, limit, offset int) (*domain.PhotoSearchResult, error) {
+	match := applyVisibleTo(bson.M{
+		"match_code": matchCode,
+		"deleted_at": bson.M{"$exists": false},
+	}, viewerID)
+	match = applyDateRange(match, "date", filter.From, filter.To)
+	if filter.Query != "" {
+		match["$text"] = bson.M{"$search": textSearchAllTerms(filter.Query)}
+	}
+	if len(filter.Tags) > 0 {
+		match["tags"] = bson.M{"$all": filter.Tags}
+	}
+	if filter.Location != "" {
+		match["location"] = bson.M{"$regex": regexp.QuoteMeta(filter.Location), "$options": "i"}
+	}
+	if filter.AlbumID != nil {
+		match["album_id"] = *filter.AlbumID
+	}
+	if filter.IsPrivate != nil {
+		match["is_private"] = *filter.IsPrivate
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: match}}}
+	sort := bson.D{{Key: "date", Value: -1}, {Key: "_id", Value: -1}}
+	if filter.Query != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}})
+		sort = append(bson.D{{Key: "score", Value: -1}}, sort...)
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: bson.M{
+		"photos": bson.A{
+			bson.M{"$sort": sort},
+			bson.M{"$skip": offset},
+			bson.M{"$limit": limit},
+		},
+		"total": bson.A{
+			bson.M{"$count": "count"},
+		},
+		"tags": bson.A{
+			bson.M{"$unwind": "$tags"},
+			bson.M{"$group": bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}},
+			bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+			bson.M{"$limit": photoSearchTagFacetLimit},
+		},
+	}}})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("Failed to search photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to search photos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Photos []*domain.Photo `bson:"photos"`
+		Total  []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Tags []*domain.PhotoTagCount `bson:"tags"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		r.logger.Error("Failed to decode photo search", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photo search: %w", err)
+	}
+
+	result := &domain.PhotoSearchResult{
+		Photos: []*domain.Photo{},
+		Tags:   []*domain.PhotoTagCount{},
+	}
+	if len(results) == 0 {
+		return result, nil
+	}
+	if results[0].Photos != nil {
+		result.Photos = results[0].Photos
+	}
+	if len(results[0].Total) > 0 {
+		result.Total = results[0].Total[0].Count
+	}
+	if results[0].Tags != nil {
+		result.Tags = results[0].Tags
+	}
+
+	return result, nil
+}
+
+// ClusterByLocation groups the couple's geo-tagged photos the viewer can see into
+// square cells of cellSize degrees, optionally limited to an area and to dates within
+// [from, to). Up to limit clusters are returned, largest first.
+func (r *PhotoRepositoryNew) ClusterByLocation(ctx context.Context, matchCode string, viewerID primitive.ObjectID, cellSize float64, bounds *domain.BoundingBox, from, to *time.Time, limit int) ([]*domain.PhotoCluster, error) {
+	match := applyVisibleTo(bson.M{
+		"match_code": matchCode,
+		"place":      bson.M{"$exists": true},
+		"deleted_at": bson.M{"$exists": false},
+	}, viewerID)
+	match = applyDateRange(match, "date", from, to)
+	if bounds != nil {
+		match["place.latitude"] = bson.M{"$gte": bounds.South, "$lte": bounds.North}
+		if bounds.West <= bounds.East {
+			match["place.longitude"] = bson.M{"$gte": bounds.West, "$lte": bounds.East}
+		} else {
+			match["$or"] = bson.A{
+				bson.M{"place.longitude": bson.M{"$gte": bounds.West}},
+				bson.M{"place.longitude": bson.M{"$lte": bounds.East}},
+			}
+		}
+	}
+
+	cell := func(field string) bson.M {
+		return bson.M{"$floor": bson.M{"$divide": bson.A{"$" + field, cellSize}}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$sort", Value: bson.D{{Key: "date", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       bson.M{"lat": cell("place.latitude"), "lng": cell("place.longitude")},
+			"count":     bson.M{"$sum": 1},
+			"latitude":  bson.M{"$avg": "$place.latitude"},
+			"longitude": bson.M{"$avg": "$place.longitude"},
+			"cover":     bson.M{"$first": "$$ROOT"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("Failed to cluster photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to cluster photos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var clusters []*domain.PhotoCluster
+	if err := cursor.All(ctx, &clusters); err != nil {
+		r.logger.Error("Failed to decode photo clusters", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photo clusters: %w", err)
+	}
+
+	return clusters, nil
+}
+
+// SetFavorite marks or unmarks one of the couple's photos as a favorite of the user
+// and returns the updated photo, or nil when the couple has no such photo the user
+// can see
+func (r *PhotoRepositoryNew) SetFavorite(ctx context.Context, matchCode string, id, userID primitive.ObjectID, favorite bool) (*domain.Photo, error) {
+	filter := applyVisibleTo(activeIDsFilter(matchCode, []primitive.ObjectID{id}), userID)
+
+	update := bson.M{"$pull": bson.M{"favorited_by": userID}}
+	if favorite {
+		update = bson.M{"$addToSet": bson.M{"favorited_by": userID}}
+	}
+
+	var photo domain.Photo
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&photo)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		r.logger.Error("Failed to set photo favorite", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to set photo favorite: %w", err)
+	}
+
+	return &photo, nil
+}
+
+// GetFavorites retrieves the couple's photos the user marked as favorites and can still
+// see, with pagination
+func (r *PhotoRepositoryNew) GetFavorites(ctx context.Context, matchCode string, userID primitive.ObjectID, limit, offset int) ([]*domain.Photo, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "date", Value: -1}, {Key: "_id", Value: -1}})
+
+	filter := applyVisibleTo(SoftDelete.GetActiveFilterWithCondition(bson.M{
+		"match_code":   matchCode,
+		"favorited_by": userID,
+	}), userID)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get favorite photos", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return nil, fmt.Errorf("failed to get favorite photos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var photos []*domain.Photo
+	if err := cursor.All(ctx, &photos); err != nil {
+		r.logger.Error("Failed to decode photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photos: %w", err)
+	}
+
+	return photos, nil
+}
+
+// CountFavorites counts the couple's active photos the user marked as favorites and
+// can still see
+func (r *PhotoRepositoryNew) CountFavorites(ctx context.Context, matchCode string, userID primitive.ObjectID) (int64, error) {
+	filter := applyVisibleTo(SoftDelete.GetActiveFilterWithCondition(bson.M{
+		"match_code":   matchCode,
+		"favorited_by": userID,
+	}), userID)
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to count favorite photos", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return 0, fmt.Errorf("failed to count favorite photos: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetWithImageHash retrieves the couple's active photos the viewer can see that have an
+// image hash, by date
+func (r *PhotoRepositoryNew) GetWithImageHash(ctx context.Context, matchCode string, viewerID primitive.ObjectID) ([]*domain.Photo, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: -1}, {Key: "_id", Value: -1}})
+
+	filter := applyVisibleTo(SoftDelete.GetActiveFilterWithCondition(bson.M{
+		"match_code": matchCode,
+		"image_hash": bson.M{"$exists": true},
+	}), viewerID)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get photos with image hash", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var photos []*domain.Photo
+	if err := cursor.All(ctx, &photos); err != nil {
+		r.logger.Error("Failed to decode photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photos: %w", err)
+	}
+
+	return photos, nil
+}
+
+// GetByAlbumID retrieves the photos in an album the viewer can see with pagination
+func (r *PhotoRepositoryNew) GetByAlbumID(ctx context.Context, albumID, viewerID primitive.ObjectID, limit, offset int) ([]*domain.Photo, error) {
+	filter := applyVisibleTo(bson.M{
+		"album_id":   albumID,
+		"deleted_at": bson.M{"$exists": false},
+	}, viewerID)
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "date", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to get photos by album", zap.Error(err), zap.String("album_id", albumID.Hex()))
+		return nil, fmt.Errorf("failed to get photos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var photos []*domain.Photo
+	if err := cursor.All(ctx, &photos); err != nil {
+		r.logger.Error("Failed to decode photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photos: %w", err)
+	}
+
+	return photos, nil
+}
+
+// CountByAlbumID counts the photos in an album the viewer can see
+func (r *PhotoRepositoryNew) CountByAlbumID(ctx context.Context, albumID, viewerID primitive.ObjectID) (int64, error) {
+	filter := applyVisibleTo(bson.M{
+		"album_id":   albumID,
+		"deleted_at": bson.M{"$exists": false},
+	}, viewerID)
+
+	count, err := r.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to count photos by album", zap.Error(err), zap.String("album_id", albumID.Hex()))
+		return 0, fmt.Errorf("failed to count photos: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountByAlbumIDs counts the photos the viewer can see for several albums in a single query
+func (r *PhotoRepositoryNew) CountByAlbumIDs(ctx context.Context, albumIDs []primitive.ObjectID, viewerID primitive.ObjectID) (map[primitive.ObjectID]int64, error) {
+	counts := make(map[primitive.ObjectID]int64, len(albumIDs))
+	if len(albumIDs) == 0 {
+		return counts, nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: applyVisibleTo(bson.M{
+			"album_id":   bson.M{"$in": albumIDs},
+			"deleted_at": bson.M{"$exists": false},
+		}, viewerID)}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$album_id",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("Failed to count photos by albums", zap.Error(err))
+		return nil, fmt.Errorf("failed to count photos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		AlbumID primitive.ObjectID `bson:"_id"`
+		Count   int64              `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		r.logger.Error("Failed to decode photo counts", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photo counts: %w", err)
+	}
+
+	for _, result := range results {
+		counts[result.AlbumID] = result.Count
+	}
+
+	return counts, nil
+}
+
+// SetAlbum moves a couple's photos into an album, or out of any album when albumID is nil
+func (r *PhotoRepositoryNew) SetAlbum(ctx context.Context, matchCode string, photoIDs []primitive.ObjectID, albumID *primitive.ObjectID) (int64, error) {
+	filter := bson.M{
+		"_id":        bson.M{"$in": photoIDs},
+		"match_code": matchCode,
+		"deleted_at": bson.M{"$exists": false},
+	}
+
+	var update bson.M
+	if albumID != nil {
+		update = bson.M{
+			"$set": bson.M{"album_id": *albumID, "updated_at": time.Now()},
+		}
+	} else {
+		update = bson.M{
+			"$unset": bson.M{"album_id": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		}
+	}
+
+	result, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to set photo album", zap.Error(err))
+		return 0, fmt.Errorf("failed to set photo album: %w", err)
+	}
+
+	return result.MatchedCount, nil
+}
+
 // Restore restores a soft-deleted photo
 func (r *PhotoRepositoryNew) Restore(ctx context.Context, id primitive.ObjectID) error {
 	filter := bson.M{
@@ -250,6 +719,74 @@ func (r *PhotoRepositoryNew) Restore(ctx context.Context, id primitive.ObjectID)
 	return nil
 }
 
+// BulkDelete soft deletes the couple's photos the viewer can see among ids in a single
+// write and returns the IDs of the photos that were deleted
+func (r *PhotoRepositoryNew) BulkDelete(ctx context.Context, matchCode string, viewerID primitive.ObjectID, ids []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	found, err := findIDs(ctx, r.collection, applyVisibleTo(activeIDsFilter(matchCode, ids), viewerID))
+	if err != nil {
+		r.logger.Error("Failed to find photos for bulk delete", zap.Error(err))
+		return nil, fmt.Errorf("failed to find photos: %w", err)
+	}
+
+	if len(found) == 0 {
+		return found, nil
+	}
+
+	if _, err := r.collection.UpdateMany(ctx, activeIDsFilter(matchCode, found), SoftDelete.CreateSoftDeleteUpdate()); err != nil {
+		r.logger.Error("Failed to bulk delete photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to delete photos: %w", err)
+	}
+
+	return found, nil
+}
+
+// BulkUpdateTags adds and removes tags on the couple's photos the viewer can see among
+// ids in a single bulk write and returns the IDs of the photos that were updated
+func (r *PhotoRepositoryNew) BulkUpdateTags(ctx context.Context, matchCode string, viewerID primitive.ObjectID, ids []primitive.ObjectID, add, remove []string) ([]primitive.ObjectID, error) {
+	found, err := findIDs(ctx, r.collection, applyVisibleTo(activeIDsFilter(matchCode, ids), viewerID))
+	if err != nil {
+		r.logger.Error("Failed to find photos for bulk tag", zap.Error(err))
+		return nil, fmt.Errorf("failed to find photos: %w", err)
+	}
+
+	if len(found) == 0 {
+		return found, nil
+	}
+
+	// $addToSet and $pull cannot target the same field in one update, so the two
+	// changes are sent as ordered operations of one bulk write
+	filter := activeIDsFilter(matchCode, found)
+	now := time.Now()
+	var models []mongo.WriteModel
+	if len(add) > 0 {
+		models = append(models, mongo.NewUpdateManyModel().
+			SetFilter(filter).
+			SetUpdate(bson.M{
+				"$addToSet": bson.M{"tags": bson.M{"$each": add}},
+				"$set":      bson.M{"updated_at": now},
+			}))
+	}
+	if len(remove) > 0 {
+		models = append(models, mongo.NewUpdateManyModel().
+			SetFilter(filter).
+			SetUpdate(bson.M{
+				"$pull": bson.M{"tags": bson.M{"$in": remove}},
+				"$set":  bson.M{"updated_at": now},
+			}))
+	}
+
+	if len(models) == 0 {
+		return found, nil
+	}
+
+	if _, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(true)); err != nil {
+		r.logger.Error("Failed to bulk update photo tags", zap.Error(err))
+		return nil, fmt.Errorf("failed to update photo tags: %w", err)
+	}
+
+	return found, nil
+}
+
 // HardDelete permanently deletes a photo
 func (r *PhotoRepositoryNew) HardDelete(ctx context.Context, id primitive.ObjectID) error {
 	filter := bson.M{"_id": id}
@@ -267,13 +804,33 @@ func (r *PhotoRepositoryNew) HardDelete(ctx context.Context, id primitive.Object
 	return nil
 }
 
-// ListDeleted retrieves soft-deleted photos by match code
-func (r *PhotoRepositoryNew) ListDeleted(ctx context.Context, matchCode string, limit, offset int) ([]*domain.Photo, error) {
-	filter := bson.M{
+// GetDeleted retrieves one of the couple's soft-deleted photos by ID
+func (r *PhotoRepositoryNew) GetDeleted(ctx context.Context, matchCode string, id primitive.ObjectID) (*domain.Photo, error) {
+	var photo domain.Photo
+	filter := SoftDelete.GetDeletedFilterWithCondition(bson.M{
+		"_id":        id,
 		"match_code": matchCode,
-		"deleted_at": bson.M{"$exists": true},
+	})
+
+	err := r.collection.FindOne(ctx, filter).Decode(&photo)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("photo not found or not deleted")
+		}
+		r.logger.Error("Failed to get deleted photo", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to get deleted photo: %w", err)
 	}
 
+	return &photo, nil
+}
+
+// ListDeleted retrieves the soft-deleted photos of a match code the viewer can see
+func (r *PhotoRepositoryNew) ListDeleted(ctx context.Context, matchCode string, viewerID primitive.ObjectID, limit, offset int) ([]*domain.Photo, error) {
+	filter := applyVisibleTo(bson.M{
+		"match_code": matchCode,
+		"deleted_at": bson.M{"$exists": true},
+	}, viewerID)
+
 	opts := options.Find().
 		SetLimit(int64(limit)).
 		SetSkip(int64(offset)).
@@ -294,3 +851,180 @@ func (r *PhotoRepositoryNew) ListDeleted(ctx context.Context, matchCode string,
 
 	return photos, nil
 }
+
+// ListDeletedBefore retrieves photos soft-deleted before cutoff, oldest first
+func (r *PhotoRepositoryNew) ListDeletedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Photo, error) {
+	filter := bson.M{
+		"deleted_at": bson.M{"$lt": cutoff},
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "deleted_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to list expired deleted photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to list deleted photos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var photos []*domain.Photo
+	if err := cursor.All(ctx, &photos); err != nil {
+		r.logger.Error("Failed to decode photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photos: %w", err)
+	}
+
+	return photos, nil
+}
+
+// CountCreatedPerDay counts the photos added from from up to to per couple and day
+func (r *PhotoRepositoryNew) CountCreatedPerDay(ctx context.Context, from, to time.Time) ([]*domain.DailyCount, error) {
+	counts, err := countCreatedPerDay(ctx, r.collection, from, to, "$match_code")
+	if err != nil {
+		r.logger.Error("Failed to count photos per day", zap.Error(err))
+		return nil, fmt.Errorf("failed to count photos: %w", err)
+	}
+	return counts, nil
+}
+
+// SampleWithChecksum retrieves a random sample of the photos that have a checksum
+func (r *PhotoRepositoryNew) SampleWithChecksum(ctx context.Context, size int) ([]*domain.Photo, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"checksum": bson.M{"$exists": true}}}},
+		{{Key: "$sample", Value: bson.M{"size": size}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("Failed to sample photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to sample photos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var photos []*domain.Photo
+	if err := cursor.All(ctx, &photos); err != nil {
+		r.logger.Error("Failed to decode photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photos: %w", err)
+	}
+
+	return photos, nil
+}
+
+// ListWithoutChecksum retrieves photos stored before checksums were recorded, in ID
+// order after afterID
+func (r *PhotoRepositoryNew) ListWithoutChecksum(ctx context.Context, afterID primitive.ObjectID, limit int) ([]*domain.Photo, error) {
+	filter := bson.M{
+		"checksum": bson.M{"$exists": false},
+		"_id":      bson.M{"$gt": afterID},
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to list photos without checksum", zap.Error(err))
+		return nil, fmt.Errorf("failed to list photos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var photos []*domain.Photo
+	if err := cursor.All(ctx, &photos); err != nil {
+		r.logger.Error("Failed to decode photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photos: %w", err)
+	}
+
+	return photos, nil
+}
+
+// ListAfter lists photos across couples, including photos in the trash, in ID order
+// after afterID
+func (r *PhotoRepositoryNew) ListAfter(ctx context.Context, afterID primitive.ObjectID, limit int) ([]*domain.Photo, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$gt": afterID}}, opts)
+	if err != nil {
+		r.logger.Error("Failed to list photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to list photos: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var photos []*domain.Photo
+	if err := cursor.All(ctx, &photos); err != nil {
+		r.logger.Error("Failed to decode photos", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode photos: %w", err)
+	}
+
+	return photos, nil
+}
+
+// SetChecksum records the checksum of a photo's image
+func (r *PhotoRepositoryNew) SetChecksum(ctx context.Context, id primitive.ObjectID, checksum string) error {
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"checksum": checksum}})
+	if err != nil {
+		r.logger.Error("Failed to set photo checksum", zap.Error(err))
+		return fmt.Errorf("failed to set photo checksum: %w", err)
+	}
+
+	return nil
+}
+
+// ReassignCreator moves the photos uploaded by one user to another, including photos
+// in the trash, and the user's favorites with them
+func (r *PhotoRepositoryNew) ReassignCreator(ctx context.Context, fromUserID, toUserID primitive.ObjectID) (int64, error) {
+	update := bson.M{"$set": bson.M{"created_by": toUserID, "updated_at": time.Now()}}
+
+	result, err := r.collection.UpdateMany(ctx, bson.M{"created_by": fromUserID}, update)
+	if err != nil {
+		r.logger.Error("Failed to reassign photos", zap.Error(err), zap.String("from_user_id", fromUserID.Hex()))
+		return 0, fmt.Errorf("failed to reassign photos: %w", err)
+	}
+
+	// $addToSet and $pull cannot target the same field in one update
+	favorited := bson.M{"favorited_by": fromUserID}
+	models := []mongo.WriteModel{
+		mongo.NewUpdateManyModel().SetFilter(favorited).SetUpdate(bson.M{"$addToSet": bson.M{"favorited_by": toUserID}}),
+		mongo.NewUpdateManyModel().SetFilter(favorited).SetUpdate(bson.M{"$pull": bson.M{"favorited_by": fromUserID}}),
+	}
+	if _, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(true)); err != nil {
+		r.logger.Error("Failed to reassign photo favorites", zap.Error(err), zap.String("from_user_id", fromUserID.Hex()))
+		return 0, fmt.Errorf("failed to reassign photo favorites: %w", err)
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// GetByStorageKey retrieves the photo, including photos in the trash, whose image,
+// video poster or one of their variants is stored under key. Older photos stored the
+// full URL of the key, one of the configured URL prefixes followed by the key.
+func (r *PhotoRepositoryNew) GetByStorageKey(ctx context.Context, key string) (*domain.Photo, error) {
+	imageURLs := bson.A{key}
+	for _, prefix := range r.urlPrefixes {
+		imageURLs = append(imageURLs, prefix+key)
+	}
+
+	// Every field is indexed, so each clause is an exact index lookup
+	filter := bson.M{"$or": bson.A{
+		bson.M{"image_url": bson.M{"$in": imageURLs}},
+		bson.M{"thumbnail_key": key},
+		bson.M{"medium_key": key},
+		bson.M{"public_key": key},
+		bson.M{"poster_key": key},
+	}}
+
+	var photo domain.Photo
+	err := r.collection.FindOne(ctx, filter).Decode(&photo)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		r.logger.Error("Failed to get photo by storage key", zap.Error(err), zap.String("key", key))
+		return nil, fmt.Errorf("failed to get photo: %w", err)
+	}
+
+	return &photo, nil
+}
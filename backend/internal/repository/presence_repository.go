@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/cache"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// PresenceRepository implements domain.PresenceRepository on Redis, so that partners
+// connected to different instances see each other
+type PresenceRepository struct {
+	cache  cache.Cache
+	logger *zap.Logger
+}
+
+// NewPresenceRepository creates a new Redis presence repository
+func NewPresenceRepository(cache cache.Cache, logger *zap.Logger) domain.PresenceRepository {
+	return &PresenceRepository{
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// SetViewing records that the user views resource for ttl
+func (r *PresenceRepository) SetViewing(ctx context.Context, userID primitive.ObjectID, resource string, ttl time.Duration) error {
+	return r.cache.Set(ctx, presenceKey(userID), resource, ttl)
+}
+
+// GetViewing returns the resource the user views, or an empty string when none
+func (r *PresenceRepository) GetViewing(ctx context.Context, userID primitive.ObjectID) (string, error) {
+	key := presenceKey(userID)
+
+	exists, err := r.cache.Exists(ctx, key)
+	if err != nil || !exists {
+		return "", err
+	}
+
+	var resource string
+	if err := r.cache.Get(ctx, key, &resource); err != nil {
+		// The entry may have expired since it was checked
+		return "", nil
+	}
+	return resource, nil
+}
+
+// ClearViewing removes the user's presence entry
+func (r *PresenceRepository) ClearViewing(ctx context.Context, userID primitive.ObjectID) error {
+	return r.cache.Delete(ctx, presenceKey(userID))
+}
+
+// Publish delivers update to the user's subscribers through Redis pub/sub
+func (r *PresenceRepository) Publish(ctx context.Context, userID primitive.ObjectID, update *domain.PresenceUpdate) error {
+	return r.cache.Publish(ctx, presenceChannel(userID), update)
+}
+
+// Subscribe delivers the updates published to the user until ctx is done
+func (r *PresenceRepository) Subscribe(ctx context.Context, userID primitive.ObjectID) (<-chan *domain.PresenceUpdate, error) {
+	messages, err := r.cache.Subscribe(ctx, presenceChannel(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(chan *domain.PresenceUpdate)
+	go func() {
+		defer close(updates)
+		for message := range messages {
+			var update domain.PresenceUpdate
+			if err := json.Unmarshal(message, &update); err != nil {
+				r.logger.Warn("Failed to decode presence update", zap.Error(err), zap.String("user_id", userID.Hex()))
+				continue
+			}
+			select {
+			case updates <- &update:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return updates, nil
+}
+
+// presenceKey returns the Redis key of a user's presence entry
+func presenceKey(userID primitive.ObjectID) string {
+	return "presence:" + userID.Hex()
+}
+
+// presenceChannel returns the Redis channel presence updates for a user go through
+func presenceChannel(userID primitive.ObjectID) string {
+	return "presence-updates:" + userID.Hex()
+}
+
+// memoryPresenceEntry is a presence entry held by MemoryPresenceRepository
+type memoryPresenceEntry struct {
+	resource  string
+	expiresAt time.Time
+}
+
+// MemoryPresenceRepository implements domain.PresenceRepository in memory, for
+// deployments without Redis. Partners only see each other when they are connected to
+// the same instance.
+type MemoryPresenceRepository struct {
+	mu          sync.Mutex
+	entries     map[primitive.ObjectID]memoryPresenceEntry
+	subscribers map[primitive.ObjectID]map[chan *domain.PresenceUpdate]struct{}
+}
+
+// NewMemoryPresenceRepository creates a new in-memory presence repository
+func NewMemoryPresenceRepository() domain.PresenceRepository {
+	return &MemoryPresenceRepository{
+		entries:     make(map[primitive.ObjectID]memoryPresenceEntry),
+		subscribers: make(map[primitive.ObjectID]map[chan *domain.PresenceUpdate]struct{}),
+	}
+}
+
+// SetViewing records that the user views resource for ttl, dropping expired entries
+func (r *MemoryPresenceRepository) SetViewing(ctx context.Context, userID primitive.ObjectID, resource string, ttl time.Duration) error {
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, entry := range r.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(r.entries, id)
+		}
+	}
+	r.entries[userID] = memoryPresenceEntry{resource: resource, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+// GetViewing returns the resource the user views, or an empty string when none
+func (r *MemoryPresenceRepository) GetViewing(ctx context.Context, userID primitive.ObjectID) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[userID]
+	if !ok || !time.Now().Before(entry.expiresAt) {
+		return "", nil
+	}
+	return entry.resource, nil
+}
+
+// ClearViewing removes the user's presence entry
+func (r *MemoryPresenceRepository) ClearViewing(ctx context.Context, userID primitive.ObjectID) error {
+	r.mu.Lock()
+	delete(r.entries, userID)
+	r.mu.Unlock()
+	return nil
+}
+
+// memorySubscriberBuffer is how many updates a subscriber may fall behind before
+// further updates to it are dropped
+const memorySubscriberBuffer = 16
+
+// Publish delivers update to the user's subscribers on this instance. A subscriber
+// that does not keep up misses the update rather than blocking the publisher.
+func (r *MemoryPresenceRepository) Publish(ctx context.Context, userID primitive.ObjectID, update *domain.PresenceUpdate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for subscriber := range r.subscribers[userID] {
+		select {
+		case subscriber <- update:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe delivers the updates published to the user until ctx is done
+func (r *MemoryPresenceRepository) Subscribe(ctx context.Context, userID primitive.ObjectID) (<-chan *domain.PresenceUpdate, error) {
+	updates := make(chan *domain.PresenceUpdate, memorySubscriberBuffer)
+
+	r.mu.Lock()
+	if r.subscribers[userID] == nil {
+		r.subscribers[userID] = make(map[chan *domain.PresenceUpdate]struct{})
+	}
+	r.subscribers[userID][updates] = struct{}{}
+	r.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		r.mu.Lock()
+		delete(r.subscribers[userID], updates)
+		if len(r.subscribers[userID]) == 0 {
+			delete(r.subscribers, userID)
+		}
+		r.mu.Unlock()
+		close(updates)
+	}()
+
+	return updates, nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// applyVisibleTo narrows filter to the documents the viewer may see: the shared ones
+// and the viewer's own private ones, created_by naming the partner who made them. A
+// nil viewer sees no private document. It uses $nor so that applyCursorOn and the
+// searches can add their own $or.
+func applyVisibleTo(filter bson.M, viewerID primitive.ObjectID) bson.M {
+	filter["$nor"] = bson.A{
+		bson.M{"is_private": true, "created_by": bson.M{"$ne": viewerID}},
+	}
+	return filter
+}
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"github.com/eralove/eralove-backend/internal/config"
 	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/cache"
 	"github.com/eralove/eralove-backend/internal/infrastructure/database"
 	"github.com/google/wire"
 	"go.uber.org/zap"
@@ -13,8 +15,41 @@ var RepositorySet = wire.NewSet(
 	ProvidePhotoRepository,
 	ProvideEventRepository,
 	ProvideMatchRequestRepository,
-	// TODO: Uncomment when repositories are implemented
-	// ProvideMessageRepository,
+	ProvideGoalRepository,
+	ProvideAlbumRepository,
+	ProvideNotificationRepository,
+	ProvideAffirmationRepository,
+	ProvideCoupleSettingsRepository,
+	ProvideShareLinkRepository,
+	ProvideFeedbackRepository,
+	ProvideCalendarFeedRepository,
+	ProvideRequestTraceRepository,
+	ProvideClientErrorRepository,
+	ProvideChangelogSeenRepository,
+	ProvideRetentionAuditRepository,
+	ProvidePendingActionRepository,
+	ProvideMessageRepository,
+	ProvideAuthorizationCodeRepository,
+	ProvideMatchInviteRepository,
+	ProvideStorageIntegrityRepository,
+	ProvideStorageReconciliationRepository,
+	ProvideCoupleBadgeRepository,
+	ProvidePresenceRepository,
+	ProvideAccountMergeRepository,
+	ProvideUsageRepository,
+	ProvideUploadSessionRepository,
+	ProvidePhotoCommentRepository,
+	ProvideJournalRepository,
+	ProvideBucketListRepository,
+	ProvideMoodRepository,
+	ProvideCountdownRepository,
+	ProvideTokenFamilyRepository,
+	ProvideAuditLogRepository,
+	ProvideMessageReceiptRepository,
+	ProvideEngagementRepository,
+	ProvideDailyQuestionRepository,
+	ProvideWishlistRepository,
+	ProvideDatePlanRepository,
 )
 
 // ProvideUserRepository provides a user repository
@@ -23,8 +58,8 @@ func ProvideUserRepository(db *database.MongoDB, logger *zap.Logger) domain.User
 }
 
 // ProvidePhotoRepository provides a photo repository
-func ProvidePhotoRepository(db *database.MongoDB, logger *zap.Logger) domain.PhotoRepository {
-	return NewPhotoRepositoryWithMatchCode(db.Database, logger)
+func ProvidePhotoRepository(cfg *config.Config, db *database.MongoDB, logger *zap.Logger) domain.PhotoRepository {
+	return NewPhotoRepositoryWithMatchCode(db.Database, cfg.StorageURLPrefixes(), logger)
 }
 
 // ProvideEventRepository provides an event repository
@@ -32,12 +67,209 @@ func ProvideEventRepository(db *database.MongoDB, logger *zap.Logger) domain.Eve
 	return NewEventRepository(db.Database, logger)
 }
 
-// // ProvideMessageRepository provides a message repository
-// func ProvideMessageRepository(db *database.MongoDB, logger *zap.Logger) domain.MessageRepository {
-// 	return NewMessageRepository(db.Database, logger)
-// }
+// ProvideMessageRepository provides a message repository
+func ProvideMessageRepository(db *database.MongoDB, logger *zap.Logger) domain.MessageRepository {
+	return NewMessageRepository(db.Database, logger)
+}
 
 // ProvideMatchRequestRepository provides a match request repository
 func ProvideMatchRequestRepository(db *database.MongoDB, logger *zap.Logger) domain.MatchRequestRepository {
 	return NewMatchRequestRepository(db.Database, logger)
 }
+
+// ProvideGoalRepository provides a goal repository
+func ProvideGoalRepository(db *database.MongoDB, logger *zap.Logger) domain.GoalRepository {
+	return NewGoalRepository(db.Database, logger)
+}
+
+// ProvideAlbumRepository provides an album repository
+func ProvideAlbumRepository(db *database.MongoDB, logger *zap.Logger) domain.AlbumRepository {
+	return NewAlbumRepository(db.Database, logger)
+}
+
+// ProvideNotificationRepository provides a notification repository
+func ProvideNotificationRepository(db *database.MongoDB, logger *zap.Logger) domain.NotificationRepository {
+	return NewNotificationRepository(db.Database, logger)
+}
+
+// ProvideAffirmationRepository provides an affirmation repository
+func ProvideAffirmationRepository(db *database.MongoDB, logger *zap.Logger) domain.AffirmationRepository {
+	return NewAffirmationRepository(db.Database, logger)
+}
+
+// ProvideCoupleSettingsRepository provides a couple settings repository
+func ProvideCoupleSettingsRepository(db *database.MongoDB, logger *zap.Logger) domain.CoupleSettingsRepository {
+	return NewCoupleSettingsRepository(db.Database, logger)
+}
+
+// ProvideShareLinkRepository provides a share link repository
+func ProvideShareLinkRepository(db *database.MongoDB, logger *zap.Logger) domain.ShareLinkRepository {
+	return NewShareLinkRepository(db.Database, logger)
+}
+
+// ProvideFeedbackRepository provides a feedback repository
+func ProvideFeedbackRepository(db *database.MongoDB, logger *zap.Logger) domain.FeedbackRepository {
+	return NewFeedbackRepository(db.Database, logger)
+}
+
+// ProvideCalendarFeedRepository provides a calendar feed repository
+func ProvideCalendarFeedRepository(db *database.MongoDB, logger *zap.Logger) domain.CalendarFeedRepository {
+	return NewCalendarFeedRepository(db.Database, logger)
+}
+
+// ProvideRequestTraceRepository provides a request trace repository
+func ProvideRequestTraceRepository(db *database.MongoDB, logger *zap.Logger) domain.RequestTraceRepository {
+	return NewRequestTraceRepository(db.Database, logger)
+}
+
+// ProvideClientErrorRepository provides a client error repository
+func ProvideClientErrorRepository(db *database.MongoDB, logger *zap.Logger) domain.ClientErrorRepository {
+	return NewClientErrorRepository(db.Database, logger)
+}
+
+// ProvideChangelogSeenRepository provides a changelog seen repository
+func ProvideChangelogSeenRepository(db *database.MongoDB, logger *zap.Logger) domain.ChangelogSeenRepository {
+	return NewChangelogSeenRepository(db.Database, logger)
+}
+
+// ProvideRetentionAuditRepository provides a retention audit repository
+func ProvideRetentionAuditRepository(db *database.MongoDB, logger *zap.Logger) domain.RetentionAuditRepository {
+	return NewRetentionAuditRepository(db.Database, logger)
+}
+
+// ProvidePendingActionRepository provides a pending action repository
+func ProvidePendingActionRepository(db *database.MongoDB, logger *zap.Logger) domain.PendingActionRepository {
+	return NewPendingActionRepository(db.Database, logger)
+}
+
+// ProvideAuthorizationCodeRepository provides an OAuth authorization code repository
+func ProvideAuthorizationCodeRepository(db *database.MongoDB, logger *zap.Logger) domain.AuthorizationCodeRepository {
+	return NewAuthorizationCodeRepository(db.Database, logger)
+}
+
+// ProvideMatchInviteRepository provides a match invite repository
+func ProvideMatchInviteRepository(db *database.MongoDB, logger *zap.Logger) domain.MatchInviteRepository {
+	return NewMatchInviteRepository(db.Database, logger)
+}
+
+// ProvideStorageIntegrityRepository provides a storage integrity repository
+func ProvideStorageIntegrityRepository(db *database.MongoDB, logger *zap.Logger) domain.StorageIntegrityRepository {
+	return NewStorageIntegrityRepository(db.Database, logger)
+}
+
+// ProvideStorageReconciliationRepository provides a storage reconciliation repository
+func ProvideStorageReconciliationRepository(db *database.MongoDB, logger *zap.Logger) domain.StorageReconciliationRepository {
+	return NewStorageReconciliationRepository(db.Database, logger)
+}
+
+// ProvideCoupleBadgeRepository provides a couple badge repository
+func ProvideCoupleBadgeRepository(db *database.MongoDB, logger *zap.Logger) domain.CoupleBadgeRepository {
+	return NewCoupleBadgeRepository(db.Database, logger)
+}
+
+// ProvidePresenceRepository provides the presence repository. Presence is kept in Redis
+// so that all instances share it, or in memory when Redis is unavailable.
+func ProvidePresenceRepository(cfg *config.Config, logger *zap.Logger) domain.PresenceRepository {
+	redis, err := cache.NewRedis(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB, logger)
+	if err != nil {
+		logger.Warn("Failed to connect to Redis, keeping presence in memory", zap.Error(err))
+		return NewMemoryPresenceRepository()
+	}
+	return NewPresenceRepository(redis, logger)
+}
+
+// ProvideAccountMergeRepository provides an account merge repository
+func ProvideAccountMergeRepository(db *database.MongoDB, logger *zap.Logger) domain.AccountMergeRepository {
+	return NewAccountMergeRepository(db.Database, logger)
+}
+
+// ProvideUsageRepository provides a couple usage repository
+func ProvideUsageRepository(db *database.MongoDB, logger *zap.Logger) domain.UsageRepository {
+	return NewUsageRepository(db.Database, logger)
+}
+
+// ProvideUploadSessionRepository provides the resumable upload session repository.
+// Sessions are kept in Redis so that chunks can be sent to any instance, or in memory
+// when Redis is unavailable.
+func ProvideUploadSessionRepository(cfg *config.Config, logger *zap.Logger) domain.UploadSessionRepository {
+	redis, err := cache.NewRedis(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB, logger)
+	if err != nil {
+		logger.Warn("Failed to connect to Redis, keeping upload sessions in memory", zap.Error(err))
+		return NewMemoryUploadSessionRepository()
+	}
+	return NewUploadSessionRepository(redis, logger)
+}
+
+// ProvidePhotoCommentRepository provides a photo comment repository
+func ProvidePhotoCommentRepository(db *database.MongoDB, logger *zap.Logger) domain.PhotoCommentRepository {
+	return NewPhotoCommentRepository(db.Database, logger)
+}
+
+// ProvideJournalRepository provides a couple journal repository
+func ProvideJournalRepository(db *database.MongoDB, logger *zap.Logger) domain.JournalRepository {
+	return NewJournalRepository(db.Database, logger)
+}
+
+// ProvideBucketListRepository provides a bucket list repository
+func ProvideBucketListRepository(db *database.MongoDB, logger *zap.Logger) domain.BucketListRepository {
+	return NewBucketListRepository(db.Database, logger)
+}
+
+// ProvideMoodRepository provides a mood check-in repository
+func ProvideMoodRepository(db *database.MongoDB, logger *zap.Logger) domain.MoodRepository {
+	return NewMoodRepository(db.Database, logger)
+}
+
+// ProvideCountdownRepository provides a countdown repository
+func ProvideCountdownRepository(db *database.MongoDB, logger *zap.Logger) domain.CountdownRepository {
+	return NewCountdownRepository(db.Database, logger)
+}
+
+// ProvideTokenFamilyRepository provides the refresh token family repository. Families
+// are kept in Redis so that all instances share them, or in memory when Redis is
+// unavailable.
+func ProvideTokenFamilyRepository(cfg *config.Config, logger *zap.Logger) domain.TokenFamilyRepository {
+	redis, err := cache.NewRedis(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB, logger)
+	if err != nil {
+		logger.Warn("Failed to connect to Redis, keeping refresh token families in memory", zap.Error(err))
+		return NewMemoryTokenFamilyRepository()
+	}
+	return NewTokenFamilyRepository(redis, logger)
+}
+
+// ProvideAuditLogRepository provides an audit log repository
+func ProvideAuditLogRepository(db *database.MongoDB, logger *zap.Logger) domain.AuditLogRepository {
+	return NewAuditLogRepository(db.Database, logger)
+}
+
+// ProvideMessageReceiptRepository provides the message receipt repository. Receipts go
+// through Redis so that they reach senders on every instance, or stay in memory when
+// Redis is unavailable.
+func ProvideMessageReceiptRepository(cfg *config.Config, logger *zap.Logger) domain.MessageReceiptRepository {
+	redis, err := cache.NewRedis(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB, logger)
+	if err != nil {
+		logger.Warn("Failed to connect to Redis, passing message receipts in memory", zap.Error(err))
+		return NewMemoryMessageReceiptRepository()
+	}
+	return NewMessageReceiptRepository(redis, logger)
+}
+
+// ProvideEngagementRepository provides the couple activity and streak repository
+func ProvideEngagementRepository(db *database.MongoDB, logger *zap.Logger) domain.EngagementRepository {
+	return NewEngagementRepository(db.Database, logger)
+}
+
+// ProvideDailyQuestionRepository provides a daily question repository
+func ProvideDailyQuestionRepository(db *database.MongoDB, logger *zap.Logger) domain.DailyQuestionRepository {
+	return NewDailyQuestionRepository(db.Database, logger)
+}
+
+// ProvideWishlistRepository provides a wishlist repository
+func ProvideWishlistRepository(db *database.MongoDB, logger *zap.Logger) domain.WishlistRepository {
+	return NewWishlistRepository(db.Database, logger)
+}
+
+// ProvideDatePlanRepository provides a date plan repository
+func ProvideDatePlanRepository(db *database.MongoDB, logger *zap.Logger) domain.DatePlanRepository {
+	return NewDatePlanRepository(db.Database, logger)
+}
@@ -99,7 +99,7 @@ func (r *RefreshTokenRepository) RevokeToken(ctx context.Context, token string)
 		return fmt.Errorf("refresh token not found")
 	}
 
-	r.logger.Info("Refresh token revoked", zap.String("token", token))
+	r.logger.Info("Refresh token revoked")
 	return nil
 }
 
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// RequestTraceRepository implements domain.RequestTraceRepository
+type RequestTraceRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewRequestTraceRepository creates a new request trace repository
+func NewRequestTraceRepository(db *mongo.Database, logger *zap.Logger) domain.RequestTraceRepository {
+	return &RequestTraceRepository{
+		collection: db.Collection("request_traces"),
+		logger:     logger,
+	}
+}
+
+// Record stores a failed request
+func (r *RequestTraceRepository) Record(ctx context.Context, trace *domain.RequestTrace) error {
+	if trace.CreatedAt.IsZero() {
+		trace.CreatedAt = time.Now()
+	}
+
+	if _, err := r.collection.InsertOne(ctx, trace); err != nil {
+		r.logger.Error("Failed to record request trace", zap.Error(err), zap.String("trace_id", trace.TraceID))
+		return fmt.Errorf("failed to record request trace: %w", err)
+	}
+
+	return nil
+}
+
+// GetByTraceIDs retrieves the recorded requests with the given trace IDs, keyed by
+// trace ID. Trace IDs without a recorded request are left out.
+func (r *RequestTraceRepository) GetByTraceIDs(ctx context.Context, traceIDs []string) (map[string]*domain.RequestTrace, error) {
+	traces := make(map[string]*domain.RequestTrace)
+	if len(traceIDs) == 0 {
+		return traces, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"trace_id": bson.M{"$in": traceIDs}})
+	if err != nil {
+		r.logger.Error("Failed to get request traces", zap.Error(err))
+		return nil, fmt.Errorf("failed to get request traces: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var found []*domain.RequestTrace
+	if err := cursor.All(ctx, &found); err != nil {
+		r.logger.Error("Failed to decode request traces", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode request traces: %w", err)
+	}
+
+	for _, trace := range found {
+		traces[trace.TraceID] = trace
+	}
+	return traces, nil
+}
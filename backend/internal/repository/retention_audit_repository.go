@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// RetentionAuditRepository implements domain.RetentionAuditRepository
+type RetentionAuditRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewRetentionAuditRepository creates a new retention audit repository
+func NewRetentionAuditRepository(db *mongo.Database, logger *zap.Logger) domain.RetentionAuditRepository {
+	return &RetentionAuditRepository{
+		collection: db.Collection("retention_audit"),
+		logger:     logger,
+	}
+}
+
+// Create stores a retention audit entry
+func (r *RetentionAuditRepository) Create(ctx context.Context, entry *domain.RetentionAuditEntry) error {
+	entry.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, entry)
+	if err != nil {
+		r.logger.Error("Failed to create retention audit entry", zap.Error(err), zap.String("policy", entry.Policy))
+		return fmt.Errorf("failed to create retention audit entry: %w", err)
+	}
+
+	entry.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// List retrieves retention audit entries matching filter, newest first, starting after cursor
+func (r *RetentionAuditRepository) List(ctx context.Context, filter domain.RetentionAuditFilter, cursor *domain.Cursor, limit int) ([]*domain.RetentionAuditEntry, error) {
+	query := bson.M{}
+	if filter.Policy != "" {
+		query["policy"] = filter.Policy
+	}
+	if filter.RunID != "" {
+		query["run_id"] = filter.RunID
+	}
+	query = applyCursor(query, cursor)
+
+	result, err := r.collection.Find(ctx, query, cursorFindOptions(limit))
+	if err != nil {
+		r.logger.Error("Failed to list retention audit entries", zap.Error(err))
+		return nil, fmt.Errorf("failed to list retention audit entries: %w", err)
+	}
+	defer result.Close(ctx)
+
+	var entries []*domain.RetentionAuditEntry
+	if err := result.All(ctx, &entries); err != nil {
+		r.logger.Error("Failed to decode retention audit entries", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode retention audit entries: %w", err)
+	}
+
+	return entries, nil
+}
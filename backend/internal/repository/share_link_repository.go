@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// ShareLinkRepository implements domain.ShareLinkRepository
+type ShareLinkRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewShareLinkRepository creates a new share link repository
+func NewShareLinkRepository(db *mongo.Database, logger *zap.Logger) domain.ShareLinkRepository {
+	return &ShareLinkRepository{
+		collection: db.Collection("share_links"),
+		logger:     logger,
+	}
+}
+
+// Create creates a new share link
+func (r *ShareLinkRepository) Create(ctx context.Context, link *domain.ShareLink) error {
+	link.CreatedAt = time.Now()
+	link.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, link)
+	if err != nil {
+		r.logger.Error("Failed to create share link", zap.Error(err))
+		return fmt.Errorf("failed to create share link: %w", err)
+	}
+
+	link.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves a share link by ID
+func (r *ShareLinkRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.ShareLink, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
+
+// GetByToken retrieves a share link by its public token
+func (r *ShareLinkRepository) GetByToken(ctx context.Context, token string) (*domain.ShareLink, error) {
+	return r.findOne(ctx, bson.M{"token": token})
+}
+
+// GetByMatchCode retrieves all share links of a couple, newest first
+func (r *ShareLinkRepository) GetByMatchCode(ctx context.Context, matchCode string) ([]*domain.ShareLink, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"match_code": matchCode}, opts)
+	if err != nil {
+		r.logger.Error("Failed to get share links", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get share links: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var links []*domain.ShareLink
+	if err := cursor.All(ctx, &links); err != nil {
+		r.logger.Error("Failed to decode share links", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode share links: %w", err)
+	}
+
+	return links, nil
+}
+
+// Revoke marks a share link as revoked
+func (r *ShareLinkRepository) Revoke(ctx context.Context, id primitive.ObjectID, revokedAt time.Time) error {
+	filter := bson.M{
+		"_id":        id,
+		"revoked_at": bson.M{"$exists": false},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"revoked_at": revokedAt,
+			"updated_at": time.Now(),
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to revoke share link", zap.Error(err))
+		return fmt.Errorf("failed to revoke share link: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("share link not found")
+	}
+
+	return nil
+}
+
+// RecordView increments the view count of a share link
+func (r *ShareLinkRepository) RecordView(ctx context.Context, id primitive.ObjectID, viewedAt time.Time) error {
+	update := bson.M{
+		"$inc": bson.M{"view_count": 1},
+		"$set": bson.M{"last_viewed_at": viewedAt},
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update); err != nil {
+		r.logger.Error("Failed to record share link view", zap.Error(err))
+		return fmt.Errorf("failed to record share link view: %w", err)
+	}
+
+	return nil
+}
+
+// findOne retrieves a single share link matching filter
+func (r *ShareLinkRepository) findOne(ctx context.Context, filter bson.M) (*domain.ShareLink, error) {
+	var link domain.ShareLink
+
+	err := r.collection.FindOne(ctx, filter).Decode(&link)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("share link not found")
+		}
+		r.logger.Error("Failed to get share link", zap.Error(err))
+		return nil, fmt.Errorf("failed to get share link: %w", err)
+	}
+
+	return &link, nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// StorageIntegrityRepository implements domain.StorageIntegrityRepository
+type StorageIntegrityRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewStorageIntegrityRepository creates a new storage integrity repository
+func NewStorageIntegrityRepository(db *mongo.Database, logger *zap.Logger) domain.StorageIntegrityRepository {
+	return &StorageIntegrityRepository{
+		collection: db.Collection("storage_integrity_issues"),
+		logger:     logger,
+	}
+}
+
+// Upsert records an issue for its photo, replacing the status and checksums of a
+// previous one but keeping when it was first detected
+func (r *StorageIntegrityRepository) Upsert(ctx context.Context, issue *domain.StorageIntegrityIssue) error {
+	update := bson.M{
+		"$set": bson.M{
+			"match_code":        issue.MatchCode,
+			"key":               issue.Key,
+			"status":            issue.Status,
+			"expected_checksum": issue.ExpectedChecksum,
+			"actual_checksum":   issue.ActualChecksum,
+			"checked_at":        issue.CheckedAt,
+		},
+		"$setOnInsert": bson.M{
+			"detected_at": issue.DetectedAt,
+		},
+	}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"photo_id": issue.PhotoID}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		r.logger.Error("Failed to record storage integrity issue", zap.Error(err), zap.String("photo_id", issue.PhotoID.Hex()))
+		return fmt.Errorf("failed to record storage integrity issue: %w", err)
+	}
+
+	return nil
+}
+
+// Resolve removes the issue recorded for a photo, if any
+func (r *StorageIntegrityRepository) Resolve(ctx context.Context, photoID primitive.ObjectID) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"photo_id": photoID}); err != nil {
+		r.logger.Error("Failed to resolve storage integrity issue", zap.Error(err), zap.String("photo_id", photoID.Hex()))
+		return fmt.Errorf("failed to resolve storage integrity issue: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves issues with the given status, or all of them, most recently detected
+// first, starting after cursor
+func (r *StorageIntegrityRepository) List(ctx context.Context, status string, cursor *domain.Cursor, limit int) ([]*domain.StorageIntegrityIssue, error) {
+	query := bson.M{}
+	if status != "" {
+		query["status"] = status
+	}
+	query = applyCursorOn(query, "detected_at", cursor)
+
+	result, err := r.collection.Find(ctx, query, cursorFindOptionsOn("detected_at", limit))
+	if err != nil {
+		r.logger.Error("Failed to list storage integrity issues", zap.Error(err))
+		return nil, fmt.Errorf("failed to list storage integrity issues: %w", err)
+	}
+	defer result.Close(ctx)
+
+	var issues []*domain.StorageIntegrityIssue
+	if err := result.All(ctx, &issues); err != nil {
+		r.logger.Error("Failed to decode storage integrity issues", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode storage integrity issues: %w", err)
+	}
+
+	return issues, nil
+}
+
+// CountByStatus counts the open issues of each status
+func (r *StorageIntegrityRepository) CountByStatus(ctx context.Context) (map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{"_id": "$status", "count": bson.M{"$sum": 1}}}},
+	}
+
+	result, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		r.logger.Error("Failed to count storage integrity issues", zap.Error(err))
+		return nil, fmt.Errorf("failed to count storage integrity issues: %w", err)
+	}
+	defer result.Close(ctx)
+
+	var groups []struct {
+		Status string `bson:"_id"`
+		Count  int64  `bson:"count"`
+	}
+	if err := result.All(ctx, &groups); err != nil {
+		r.logger.Error("Failed to decode storage integrity counts", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode storage integrity counts: %w", err)
+	}
+
+	counts := map[string]int64{
+		domain.StorageIssueCorrupt: 0,
+		domain.StorageIssueMissing: 0,
+	}
+	for _, group := range groups {
+		counts[group.Status] = group.Count
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.uber.org/zap"
+)
+
+// StorageReconciliationRepository implements domain.StorageReconciliationRepository
+type StorageReconciliationRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewStorageReconciliationRepository creates a new storage reconciliation repository
+func NewStorageReconciliationRepository(db *mongo.Database, logger *zap.Logger) domain.StorageReconciliationRepository {
+	return &StorageReconciliationRepository{
+		collection: db.Collection("storage_reconciliations"),
+		logger:     logger,
+	}
+}
+
+// Create stores the report of a reconciliation
+func (r *StorageReconciliationRepository) Create(ctx context.Context, reconciliation *domain.StorageReconciliation) error {
+	reconciliation.CreatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, reconciliation)
+	if err != nil {
+		r.logger.Error("Failed to create storage reconciliation", zap.Error(err))
+		return fmt.Errorf("failed to create storage reconciliation: %w", err)
+	}
+
+	reconciliation.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// List retrieves reconciliation reports, newest first, starting after cursor
+func (r *StorageReconciliationRepository) List(ctx context.Context, cursor *domain.Cursor, limit int) ([]*domain.StorageReconciliation, error) {
+	result, err := r.collection.Find(ctx, applyCursor(bson.M{}, cursor), cursorFindOptions(limit))
+	if err != nil {
+		r.logger.Error("Failed to list storage reconciliations", zap.Error(err))
+		return nil, fmt.Errorf("failed to list storage reconciliations: %w", err)
+	}
+	defer result.Close(ctx)
+
+	var reconciliations []*domain.StorageReconciliation
+	if err := result.All(ctx, &reconciliations); err != nil {
+		r.logger.Error("Failed to decode storage reconciliations", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode storage reconciliations: %w", err)
+	}
+
+	return reconciliations, nil
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/cache"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// TokenFamilyRepository implements domain.TokenFamilyRepository on Redis, so that a
+// refresh token redeemed on one instance cannot be redeemed again on another
+type TokenFamilyRepository struct {
+	cache  cache.Cache
+	logger *zap.Logger
+}
+
+// NewTokenFamilyRepository creates a new Redis token family repository
+func NewTokenFamilyRepository(cache cache.Cache, logger *zap.Logger) domain.TokenFamilyRepository {
+	return &TokenFamilyRepository{
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// Start records a new family of the user, active for ttl. The family is also listed
+// under the user so that all of them can be revoked at once.
+func (r *TokenFamilyRepository) Start(ctx context.Context, familyID string, userID primitive.ObjectID, ttl time.Duration) error {
+	if err := r.cache.HashSet(ctx, userTokenFamiliesKey(userID.Hex()), familyID, "", ttl); err != nil {
+		return err
+	}
+	return r.cache.Set(ctx, tokenFamilyKey(familyID), userID.Hex(), ttl)
+}
+
+// IsActive reports whether the family has neither been revoked nor expired
+func (r *TokenFamilyRepository) IsActive(ctx context.Context, familyID string) (bool, error) {
+	return r.cache.Exists(ctx, tokenFamilyKey(familyID))
+}
+
+// Extend keeps the family, and the list of its user's families, active for ttl from now
+func (r *TokenFamilyRepository) Extend(ctx context.Context, familyID string, ttl time.Duration) error {
+	var userID string
+	if err := r.cache.Get(ctx, tokenFamilyKey(familyID), &userID); err != nil {
+		return err
+	}
+
+	if err := r.cache.SetExpiration(ctx, userTokenFamiliesKey(userID), ttl); err != nil {
+		return err
+	}
+	return r.cache.SetExpiration(ctx, tokenFamilyKey(familyID), ttl)
+}
+
+// Revoke ends the family, invalidating every token in it
+func (r *TokenFamilyRepository) Revoke(ctx context.Context, familyID string) error {
+	return r.cache.Delete(ctx, tokenFamilyKey(familyID))
+}
+
+// RevokeAll ends every family of the user, signing out all of their sessions
+func (r *TokenFamilyRepository) RevokeAll(ctx context.Context, userID primitive.ObjectID) error {
+	key := userTokenFamiliesKey(userID.Hex())
+
+	families, err := r.cache.HashGetAll(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	for familyID := range families {
+		if err := r.cache.Delete(ctx, tokenFamilyKey(familyID)); err != nil {
+			return err
+		}
+	}
+	return r.cache.Delete(ctx, key)
+}
+
+// Redeem marks a refresh token as used and reports whether it was unused. Setting the
+// marker only when absent makes concurrent redemptions of a token succeed once.
+func (r *TokenFamilyRepository) Redeem(ctx context.Context, tokenID string, ttl time.Duration) (bool, error) {
+	return r.cache.SetIfNotExists(ctx, redeemedTokenKey(tokenID), true, ttl)
+}
+
+// tokenFamilyKey returns the Redis key of a refresh token family
+func tokenFamilyKey(familyID string) string {
+	return "token-family:" + familyID
+}
+
+// userTokenFamiliesKey returns the Redis key of the hash listing a user's families
+func userTokenFamiliesKey(userID string) string {
+	return "token-families:" + userID
+}
+
+// redeemedTokenKey returns the Redis key marking a refresh token as used
+func redeemedTokenKey(tokenID string) string {
+	return "token-redeemed:" + tokenID
+}
+
+// MemoryTokenFamilyRepository implements domain.TokenFamilyRepository in memory, for
+// deployments without Redis. Families only hold on the instance they were used on.
+type MemoryTokenFamilyRepository struct {
+	mu       sync.Mutex
+	families map[string]*memoryTokenFamily
+	redeemed map[string]time.Time // expiry by token ID
+}
+
+// memoryTokenFamily is a family held by MemoryTokenFamilyRepository
+type memoryTokenFamily struct {
+	userID    primitive.ObjectID
+	expiresAt time.Time
+}
+
+// NewMemoryTokenFamilyRepository creates a new in-memory token family repository
+func NewMemoryTokenFamilyRepository() domain.TokenFamilyRepository {
+	return &MemoryTokenFamilyRepository{
+		families: make(map[string]*memoryTokenFamily),
+		redeemed: make(map[string]time.Time),
+	}
+}
+
+// Start records a new family of the user, active for ttl, dropping expired entries
+func (r *MemoryTokenFamilyRepository) Start(ctx context.Context, familyID string, userID primitive.ObjectID, ttl time.Duration) error {
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, family := range r.families {
+		if !now.Before(family.expiresAt) {
+			delete(r.families, id)
+		}
+	}
+	r.families[familyID] = &memoryTokenFamily{userID: userID, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+// IsActive reports whether the family has neither been revoked nor expired
+func (r *MemoryTokenFamilyRepository) IsActive(ctx context.Context, familyID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	family, ok := r.families[familyID]
+	return ok && time.Now().Before(family.expiresAt), nil
+}
+
+// Extend keeps the family active for ttl from now
+func (r *MemoryTokenFamilyRepository) Extend(ctx context.Context, familyID string, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if family, ok := r.families[familyID]; ok {
+		family.expiresAt = time.Now().Add(ttl)
+	}
+	return nil
+}
+
+// Revoke ends the family, invalidating every token in it
+func (r *MemoryTokenFamilyRepository) Revoke(ctx context.Context, familyID string) error {
+	r.mu.Lock()
+	delete(r.families, familyID)
+	r.mu.Unlock()
+	return nil
+}
+
+// RevokeAll ends every family of the user, signing out all of their sessions
+func (r *MemoryTokenFamilyRepository) RevokeAll(ctx context.Context, userID primitive.ObjectID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, family := range r.families {
+		if family.userID == userID {
+			delete(r.families, id)
+		}
+	}
+	return nil
+}
+
+// Redeem marks a refresh token as used and reports whether it was unused, dropping
+// expired markers
+func (r *MemoryTokenFamilyRepository) Redeem(ctx context.Context, tokenID string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if expiresAt, ok := r.redeemed[tokenID]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	for id, expiresAt := range r.redeemed {
+		if !now.Before(expiresAt) {
+			delete(r.redeemed, id)
+		}
+	}
+	r.redeemed[tokenID] = now.Add(ttl)
+	return true, nil
+}
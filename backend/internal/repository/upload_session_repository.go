@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/cache"
+	"go.uber.org/zap"
+)
+
+// UploadSessionRepository implements domain.UploadSessionRepository on Redis, so that
+// the chunks of an upload can be sent to any instance
+type UploadSessionRepository struct {
+	cache  cache.Cache
+	logger *zap.Logger
+}
+
+// NewUploadSessionRepository creates a new Redis upload session repository
+func NewUploadSessionRepository(cache cache.Cache, logger *zap.Logger) domain.UploadSessionRepository {
+	return &UploadSessionRepository{
+		cache:  cache,
+		logger: logger,
+	}
+}
+
+// Create stores a session for ttl
+func (r *UploadSessionRepository) Create(ctx context.Context, session *domain.UploadSession, ttl time.Duration) error {
+	return r.cache.Set(ctx, uploadSessionKey(session.ID), session, ttl)
+}
+
+// Get returns the session with the given ID, or nil when it does not exist or expired
+func (r *UploadSessionRepository) Get(ctx context.Context, id string) (*domain.UploadSession, error) {
+	key := uploadSessionKey(id)
+
+	exists, err := r.cache.Exists(ctx, key)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	var session domain.UploadSession
+	if err := r.cache.Get(ctx, key, &session); err != nil {
+		// The session may have expired since it was checked
+		return nil, nil
+	}
+	return &session, nil
+}
+
+// SetPart records a stored part of the session's upload. Parts are kept in a hash so
+// that chunks sent concurrently do not overwrite each other.
+func (r *UploadSessionRepository) SetPart(ctx context.Context, id string, part *domain.UploadedPart, ttl time.Duration) error {
+	return r.cache.HashSet(ctx, uploadSessionPartsKey(id), strconv.Itoa(part.Number), part.ETag, ttl)
+}
+
+// ListParts returns the stored parts of the session's upload, by part number
+func (r *UploadSessionRepository) ListParts(ctx context.Context, id string) ([]*domain.UploadedPart, error) {
+	fields, err := r.cache.HashGetAll(ctx, uploadSessionPartsKey(id))
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]*domain.UploadedPart, 0, len(fields))
+	for field, etag := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil {
+			r.logger.Warn("Skipping invalid upload part", zap.String("session_id", id), zap.String("part", field))
+			continue
+		}
+		parts = append(parts, &domain.UploadedPart{Number: number, ETag: etag})
+	}
+	sortUploadedParts(parts)
+	return parts, nil
+}
+
+// Delete removes a session and its parts
+func (r *UploadSessionRepository) Delete(ctx context.Context, id string) error {
+	if err := r.cache.Delete(ctx, uploadSessionPartsKey(id)); err != nil {
+		return err
+	}
+	return r.cache.Delete(ctx, uploadSessionKey(id))
+}
+
+// uploadSessionKey returns the Redis key of an upload session
+func uploadSessionKey(id string) string {
+	return "upload-session:" + id
+}
+
+// uploadSessionPartsKey returns the Redis key of the hash of an upload session's parts
+func uploadSessionPartsKey(id string) string {
+	return "upload-session:" + id + ":parts"
+}
+
+// sortUploadedParts sorts parts by part number
+func sortUploadedParts(parts []*domain.UploadedPart) {
+	sort.Slice(parts, func(i, j int) bool { return parts[i].Number < parts[j].Number })
+}
+
+// memoryUploadSession is an upload session held by MemoryUploadSessionRepository
+type memoryUploadSession struct {
+	session   domain.UploadSession
+	parts     map[int]string
+	expiresAt time.Time
+}
+
+// MemoryUploadSessionRepository implements domain.UploadSessionRepository in memory,
+// for deployments without Redis. The chunks of an upload must all be sent to the
+// instance it was started on.
+type MemoryUploadSessionRepository struct {
+	mu       sync.Mutex
+	sessions map[string]*memoryUploadSession
+}
+
+// NewMemoryUploadSessionRepository creates a new in-memory upload session repository
+func NewMemoryUploadSessionRepository() domain.UploadSessionRepository {
+	return &MemoryUploadSessionRepository{
+		sessions: make(map[string]*memoryUploadSession),
+	}
+}
+
+// Create stores a session for ttl, dropping expired sessions
+func (r *MemoryUploadSessionRepository) Create(ctx context.Context, session *domain.UploadSession, ttl time.Duration) error {
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, stored := range r.sessions {
+		if !now.Before(stored.expiresAt) {
+			delete(r.sessions, id)
+		}
+	}
+	r.sessions[session.ID] = &memoryUploadSession{
+		session:   *session,
+		parts:     make(map[int]string),
+		expiresAt: now.Add(ttl),
+	}
+	return nil
+}
+
+// Get returns the session with the given ID, or nil when it does not exist or expired
+func (r *MemoryUploadSessionRepository) Get(ctx context.Context, id string) (*domain.UploadSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.live(id)
+	if stored == nil {
+		return nil, nil
+	}
+	session := stored.session
+	return &session, nil
+}
+
+// SetPart records a stored part of the session's upload
+func (r *MemoryUploadSessionRepository) SetPart(ctx context.Context, id string, part *domain.UploadedPart, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored := r.live(id); stored != nil {
+		stored.parts[part.Number] = part.ETag
+	}
+	return nil
+}
+
+// ListParts returns the stored parts of the session's upload, by part number
+func (r *MemoryUploadSessionRepository) ListParts(ctx context.Context, id string) ([]*domain.UploadedPart, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.live(id)
+	if stored == nil {
+		return nil, nil
+	}
+
+	parts := make([]*domain.UploadedPart, 0, len(stored.parts))
+	for number, etag := range stored.parts {
+		parts = append(parts, &domain.UploadedPart{Number: number, ETag: etag})
+	}
+	sortUploadedParts(parts)
+	return parts, nil
+}
+
+// Delete removes a session and its parts
+func (r *MemoryUploadSessionRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	delete(r.sessions, id)
+	r.mu.Unlock()
+	return nil
+}
+
+// live returns the session with the given ID unless it expired. r.mu must be held.
+func (r *MemoryUploadSessionRepository) live(id string) *memoryUploadSession {
+	stored, ok := r.sessions[id]
+	if !ok || !time.Now().Before(stored.expiresAt) {
+		return nil
+	}
+	return stored
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// countCreatedPerDay counts the documents of collection created from from up to to,
+// per couple and day (UTC), including deleted ones. matchCode is the expression
+// holding a document's match code once the stages in join ran; documents without one
+// are skipped.
+func countCreatedPerDay(ctx context.Context, collection *mongo.Collection, from, to time.Time, matchCode interface{}, join ...bson.D) ([]*domain.DailyCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"created_at": bson.M{"$gte": from, "$lt": to}}}},
+	}
+	pipeline = append(pipeline, join...)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"match_code": matchCode,
+				"day":        bson.M{"$dateTrunc": bson.M{"date": "$created_at", "unit": "day"}},
+			},
+			"count": bson.M{"$sum": 1},
+		}}},
+		bson.D{{Key: "$match", Value: bson.M{"_id.match_code": bson.M{"$nin": bson.A{nil, ""}}}}},
+		bson.D{{Key: "$project", Value: bson.M{
+			"_id":        0,
+			"match_code": "$_id.match_code",
+			"day":        "$_id.day",
+			"count":      1,
+		}}},
+	)
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var counts []*domain.DailyCount
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
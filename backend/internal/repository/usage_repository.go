@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// usageRollupStateID is the ID of the document recording how far the rollup got
+const usageRollupStateID = "daily"
+
+// UsageRepository implements domain.UsageRepository
+type UsageRepository struct {
+	collection *mongo.Collection
+	state      *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewUsageRepository creates a new usage repository
+func NewUsageRepository(db *mongo.Database, logger *zap.Logger) domain.UsageRepository {
+	return &UsageRepository{
+		collection: db.Collection("couple_usage"),
+		state:      db.Collection("usage_rollup_state"),
+		logger:     logger,
+	}
+}
+
+// SetDailyCounts stores the counts of one metric, creating the days not stored yet
+func (r *UsageRepository) SetDailyCounts(ctx context.Context, metric string, counts []*domain.DailyCount) error {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(counts))
+	for _, count := range counts {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"match_code": count.MatchCode, "day": count.Day}).
+			SetUpdate(bson.M{"$set": bson.M{metric: count.Count, "updated_at": now}}).
+			SetUpsert(true))
+	}
+
+	if _, err := r.collection.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+		r.logger.Error("Failed to store usage counts", zap.Error(err), zap.String("metric", metric))
+		return fmt.Errorf("failed to store usage counts: %w", err)
+	}
+
+	return nil
+}
+
+// ListByMatchCode returns the stored days of a couple from from to to, oldest first
+func (r *UsageRepository) ListByMatchCode(ctx context.Context, matchCode string, from, to domain.Date) ([]*domain.CoupleUsageDay, error) {
+	filter := bson.M{
+		"match_code": matchCode,
+		"day":        bson.M{"$gte": from, "$lte": to},
+	}
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "day", Value: 1}}))
+	if err != nil {
+		r.logger.Error("Failed to get usage", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var days []*domain.CoupleUsageDay
+	if err := cursor.All(ctx, &days); err != nil {
+		r.logger.Error("Failed to decode usage", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode usage: %w", err)
+	}
+
+	return days, nil
+}
+
+// GetRolledUpTo returns when the rollup last ran, or the zero time if it never did
+func (r *UsageRepository) GetRolledUpTo(ctx context.Context) (time.Time, error) {
+	var state struct {
+		RolledUpTo time.Time `bson:"rolled_up_to"`
+	}
+
+	err := r.state.FindOne(ctx, bson.M{"_id": usageRollupStateID}).Decode(&state)
+	if err == mongo.ErrNoDocuments {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		r.logger.Error("Failed to get usage rollup state", zap.Error(err))
+		return time.Time{}, fmt.Errorf("failed to get usage rollup state: %w", err)
+	}
+
+	return state.RolledUpTo, nil
+}
+
+// SetRolledUpTo records when the rollup last ran
+func (r *UsageRepository) SetRolledUpTo(ctx context.Context, t time.Time) error {
+	update := bson.M{"$set": bson.M{"rolled_up_to": t}}
+
+	_, err := r.state.UpdateOne(ctx, bson.M{"_id": usageRollupStateID}, update, options.Update().SetUpsert(true))
+	if err != nil {
+		r.logger.Error("Failed to record usage rollup state", zap.Error(err))
+		return fmt.Errorf("failed to record usage rollup state: %w", err)
+	}
+
+	return nil
+}
@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/pii"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -52,8 +54,12 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	user.IsActive = true
 	// Email verification defaults are set in service layer
 
+	// The unique email index settles concurrent registrations of the same email
 	result, err := r.collection.InsertOne(ctx, user)
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrUserAlreadyExists(user.Email)
+		}
 		r.logger.Error("Failed to create user", zap.Error(err))
 		return fmt.Errorf("failed to create user: %w", err)
 	}
@@ -79,17 +85,22 @@ func (r *UserRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*d
 	return &user, nil
 }
 
-// GetByEmail retrieves a user by email
+// GetByEmail retrieves a user by email. Emails are stored lowercase; those stored
+// before are found by a case-insensitive lookup when there is no exact match.
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
 	filter := getActiveUserFilterWithCondition(bson.M{"email": email})
 	
 	err := r.collection.FindOne(ctx, filter).Decode(&user)
+	if err == mongo.ErrNoDocuments {
+		caseInsensitive := options.FindOne().SetCollation(&options.Collation{Locale: "en", Strength: 2})
+		err = r.collection.FindOne(ctx, filter, caseInsensitive).Decode(&user)
+	}
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, fmt.Errorf("user not found")
 		}
-		r.logger.Error("Failed to get user by email", zap.Error(err), zap.String("email", email))
+		r.logger.Error("Failed to get user by email", zap.Error(err), pii.Field("email", email))
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
@@ -118,6 +129,34 @@ func (r *UserRepository) Update(ctx context.Context, id primitive.ObjectID, user
 	return nil
 }
 
+// SetAvatar replaces the user's avatar in a single update and returns the keys of the
+// files of the avatar it replaced
+func (r *UserRepository) SetAvatar(ctx context.Context, id primitive.ObjectID, avatar, avatarSmall string, keys []string) ([]string, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"avatar":       avatar,
+			"avatar_small": avatarSmall,
+			"avatar_keys":  keys,
+			"updated_at":   time.Now(),
+		},
+	}
+	opts := options.FindOneAndUpdate().
+		SetProjection(bson.M{"avatar_keys": 1}).
+		SetReturnDocument(options.Before)
+
+	var previous domain.User
+	err := r.collection.FindOneAndUpdate(ctx, getActiveUserFilterWithCondition(bson.M{"_id": id}), update, opts).Decode(&previous)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("user not found")
+		}
+		r.logger.Error("Failed to set user avatar", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to set user avatar: %w", err)
+	}
+
+	return previous.AvatarKeys, nil
+}
+
 // Delete soft deletes a user
 func (r *UserRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
 	now := time.Now()
@@ -229,6 +268,10 @@ func (r *UserRepository) Restore(ctx context.Context, id primitive.ObjectID) err
 
 	result, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
+		// Another account took the email while this one was deleted
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.NewAppError(domain.ErrCodeUserAlreadyExists, "Email is in use by another account", 409)
+		}
 		r.logger.Error("Failed to restore user", zap.Error(err), zap.String("id", id.Hex()))
 		return fmt.Errorf("failed to restore user: %w", err)
 	}
@@ -283,3 +326,334 @@ func (r *UserRepository) ListDeleted(ctx context.Context, limit, offset int) ([]
 
 	return users, nil
 }
+
+// GetByIDIncludingDeleted retrieves a user by ID whether or not the account is deleted
+func (r *UserRepository) GetByIDIncludingDeleted(ctx context.Context, id primitive.ObjectID) (*domain.User, error) {
+	var user domain.User
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("user not found")
+		}
+		r.logger.Error("Failed to get user by ID", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return &user, nil
+}
+
+// Search lists users whose name or email contains the filter's query, newest first
+func (r *UserRepository) Search(ctx context.Context, filter domain.UserSearchFilter, cursor *domain.Cursor, limit int) ([]*domain.User, error) {
+	query := bson.M{}
+	switch filter.Status {
+	case domain.UserSearchStatusActive:
+		query["deleted_at"] = bson.M{"$exists": false}
+	case domain.UserSearchStatusDeleted:
+		query["deleted_at"] = bson.M{"$exists": true}
+	}
+	if filter.Query != "" {
+		pattern := bson.M{"$regex": regexp.QuoteMeta(filter.Query), "$options": "i"}
+		query["$and"] = bson.A{
+			bson.M{"$or": bson.A{bson.M{"name": pattern}, bson.M{"email": pattern}}},
+		}
+	}
+	query = applyCursor(query, cursor)
+
+	result, err := r.collection.Find(ctx, query, cursorFindOptions(limit))
+	if err != nil {
+		r.logger.Error("Failed to search users", zap.Error(err))
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+	defer result.Close(ctx)
+
+	var users []*domain.User
+	if err := result.All(ctx, &users); err != nil {
+		r.logger.Error("Failed to decode users", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
+
+	return users, nil
+}
+
+// unverifiedInactiveFilter matches unmatched accounts whose email was never verified
+// and that have not changed since before
+func unverifiedInactiveFilter(before time.Time) bson.M {
+	return bson.M{
+		"is_email_verified": false,
+		"partner_id":        bson.M{"$exists": false},
+		"updated_at":        bson.M{"$lt": before},
+	}
+}
+
+// ListUnverifiedInactiveIDs lists up to limit IDs, after afterID, of unmatched accounts
+// that were never verified and have been inactive since before
+func (r *UserRepository) ListUnverifiedInactiveIDs(ctx context.Context, before time.Time, afterID primitive.ObjectID, limit int) ([]primitive.ObjectID, error) {
+	ids, err := findIDsAfter(ctx, r.collection, unverifiedInactiveFilter(before), afterID, limit)
+	if err != nil {
+		r.logger.Error("Failed to list unverified inactive users", zap.Error(err))
+		return nil, fmt.Errorf("failed to list unverified inactive users: %w", err)
+	}
+	return ids, nil
+}
+
+// PurgeUnverifiedInactive permanently deletes those of ids that are still unverified,
+// unmatched and inactive since before
+func (r *UserRepository) PurgeUnverifiedInactive(ctx context.Context, ids []primitive.ObjectID, before time.Time) (int64, error) {
+	filter := unverifiedInactiveFilter(before)
+	filter["_id"] = bson.M{"$in": ids}
+
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to purge unverified inactive users", zap.Error(err))
+		return 0, fmt.Errorf("failed to purge unverified inactive users: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+// ListDeletedIDs lists up to limit IDs, after afterID, of accounts deleted or merged
+// into another one before before
+func (r *UserRepository) ListDeletedIDs(ctx context.Context, before time.Time, afterID primitive.ObjectID, limit int) ([]primitive.ObjectID, error) {
+	ids, err := findIDsAfter(ctx, r.collection, bson.M{"deleted_at": bson.M{"$lt": before}}, afterID, limit)
+	if err != nil {
+		r.logger.Error("Failed to list deleted users", zap.Error(err))
+		return nil, fmt.Errorf("failed to list deleted users: %w", err)
+	}
+	return ids, nil
+}
+
+// PurgeDeleted permanently deletes those of ids that are still deleted since before,
+// so that an account restored in the meantime is kept
+func (r *UserRepository) PurgeDeleted(ctx context.Context, ids []primitive.ObjectID, before time.Time) (int64, error) {
+	filter := bson.M{
+		"_id":        bson.M{"$in": ids},
+		"deleted_at": bson.M{"$lt": before},
+	}
+
+	result, err := r.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		r.logger.Error("Failed to purge deleted users", zap.Error(err))
+		return 0, fmt.Errorf("failed to purge deleted users: %w", err)
+	}
+
+	return result.DeletedCount, nil
+}
+
+// SetUnmatchRequest marks both partners of a couple as unmatching, or clears the mark
+// when requestedBy is nil
+func (r *UserRepository) SetUnmatchRequest(ctx context.Context, matchCode string, requestedBy *primitive.ObjectID, requestedAt time.Time) error {
+	var update bson.M
+	if requestedBy != nil {
+		update = bson.M{"$set": bson.M{
+			"unmatch_requested_at": requestedAt,
+			"unmatch_requested_by": *requestedBy,
+			"updated_at":           time.Now(),
+		}}
+	} else {
+		update = bson.M{
+			"$unset": bson.M{"unmatch_requested_at": "", "unmatch_requested_by": ""},
+			"$set":   bson.M{"updated_at": time.Now()},
+		}
+	}
+
+	if _, err := r.collection.UpdateMany(ctx, bson.M{"match_code": matchCode}, update); err != nil {
+		r.logger.Error("Failed to update unmatch request", zap.Error(err), zap.String("match_code", matchCode))
+		return fmt.Errorf("failed to update unmatch request: %w", err)
+	}
+
+	return nil
+}
+
+// ListUnmatchesRequestedBefore lists the match codes of couples whose unmatch was
+// requested before before
+func (r *UserRepository) ListUnmatchesRequestedBefore(ctx context.Context, before time.Time) ([]string, error) {
+	values, err := r.collection.Distinct(ctx, "match_code", bson.M{"unmatch_requested_at": bson.M{"$lt": before}})
+	if err != nil {
+		r.logger.Error("Failed to list expired unmatches", zap.Error(err))
+		return nil, fmt.Errorf("failed to list expired unmatches: %w", err)
+	}
+
+	matchCodes := make([]string, 0, len(values))
+	for _, value := range values {
+		if matchCode, ok := value.(string); ok && matchCode != "" {
+			matchCodes = append(matchCodes, matchCode)
+		}
+	}
+
+	return matchCodes, nil
+}
+
+// ClearMatch removes the match of both partners of a couple, along with any pending
+// unmatch request
+func (r *UserRepository) ClearMatch(ctx context.Context, matchCode string) error {
+	update := bson.M{
+		"$unset": bson.M{
+			"partner_id":           "",
+			"match_code":           "",
+			"matched_at":           "",
+			"anniversary_date":     "",
+			"unmatch_requested_at": "",
+			"unmatch_requested_by": "",
+		},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	if _, err := r.collection.UpdateMany(ctx, bson.M{"match_code": matchCode}, update); err != nil {
+		r.logger.Error("Failed to clear match", zap.Error(err), zap.String("match_code", matchCode))
+		return fmt.Errorf("failed to clear match: %w", err)
+	}
+
+	return nil
+}
+
+// ListByMatchCode lists the active partners of a couple
+func (r *UserRepository) ListByMatchCode(ctx context.Context, matchCode string) ([]*domain.User, error) {
+	cursor, err := r.collection.Find(ctx, getActiveUserFilterWithCondition(bson.M{"match_code": matchCode}))
+	if err != nil {
+		r.logger.Error("Failed to list users by match code", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var users []*domain.User
+	if err := cursor.All(ctx, &users); err != nil {
+		r.logger.Error("Failed to decode users", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
+
+	return users, nil
+}
+
+// MarkMemoriesDigestSent records that the user was sent the memories digest of day.
+// The update only applies when no digest was recorded for day yet, so instances
+// running the job at the same time send it once.
+func (r *UserRepository) MarkMemoriesDigestSent(ctx context.Context, id primitive.ObjectID, day time.Time) (bool, error) {
+	filter := bson.M{
+		"_id": id,
+		"$or": bson.A{
+			bson.M{"memories_digest_sent_on": bson.M{"$exists": false}},
+			bson.M{"memories_digest_sent_on": bson.M{"$lt": day}},
+		},
+	}
+	update := bson.M{"$set": bson.M{"memories_digest_sent_on": day}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to mark memories digest sent", zap.Error(err), zap.String("id", id.Hex()))
+		return false, fmt.Errorf("failed to mark memories digest sent: %w", err)
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// ListMatched lists up to limit matched users with an anniversary date, in ID order
+// after afterID
+func (r *UserRepository) ListMatched(ctx context.Context, afterID primitive.ObjectID, limit int) ([]*domain.User, error) {
+	filter := bson.M{
+		"match_code":       bson.M{"$nin": bson.A{"", nil}},
+		"anniversary_date": bson.M{"$ne": nil},
+		"deleted_at":       bson.M{"$exists": false},
+	}
+	if !afterID.IsZero() {
+		filter["_id"] = bson.M{"$gt": afterID}
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		r.logger.Error("Failed to list matched users", zap.Error(err))
+		return nil, fmt.Errorf("failed to list matched users: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var users []*domain.User
+	if err := cursor.All(ctx, &users); err != nil {
+		r.logger.Error("Failed to decode users", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode users: %w", err)
+	}
+
+	return users, nil
+}
+
+// LinkPartner saves the partner fields of user. The update only applies while the
+// stored user has no partner, so two concurrent matches cannot both link them.
+func (r *UserRepository) LinkPartner(ctx context.Context, user *domain.User) (bool, error) {
+	filter := bson.M{"_id": user.ID, "partner_id": nil}
+	update := bson.M{
+		"$set": bson.M{
+			"partner_id":       user.PartnerID,
+			"partner_name":     user.PartnerName,
+			"match_code":       user.MatchCode,
+			"matched_at":       user.MatchedAt,
+			"anniversary_date": user.AnniversaryDate,
+			"updated_at":       user.UpdatedAt,
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to link partner", zap.Error(err), zap.String("user_id", user.ID.Hex()))
+		return false, fmt.Errorf("failed to link partner: %w", err)
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// UnlinkPartner removes the partner of a user, only if it is still partnerID
+func (r *UserRepository) UnlinkPartner(ctx context.Context, id, partnerID primitive.ObjectID) error {
+	update := bson.M{
+		"$unset": bson.M{
+			"partner_id":       "",
+			"partner_name":     "",
+			"match_code":       "",
+			"matched_at":       "",
+			"anniversary_date": "",
+		},
+		"$set": bson.M{"updated_at": time.Now()},
+	}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "partner_id": partnerID}, update); err != nil {
+		r.logger.Error("Failed to unlink partner", zap.Error(err), zap.String("user_id", id.Hex()))
+		return fmt.Errorf("failed to unlink partner: %w", err)
+	}
+
+	return nil
+}
+
+// Tombstone deactivates an account merged into another one and removes its match.
+// The document is kept so the merge can be traced back.
+func (r *UserRepository) Tombstone(ctx context.Context, id, mergedInto primitive.ObjectID) error {
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"is_active":   false,
+			"deleted_at":  now,
+			"merged_into": mergedInto,
+			"updated_at":  now,
+		},
+		"$unset": bson.M{
+			"partner_id":           "",
+			"partner_name":         "",
+			"match_code":           "",
+			"matched_at":           "",
+			"anniversary_date":     "",
+			"unmatch_requested_at": "",
+			"unmatch_requested_by": "",
+		},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	if err != nil {
+		r.logger.Error("Failed to tombstone user", zap.Error(err), zap.String("id", id.Hex()))
+		return fmt.Errorf("failed to tombstone user: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
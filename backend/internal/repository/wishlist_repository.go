@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+)
+
+// WishlistRepository implements domain.WishlistRepository
+type WishlistRepository struct {
+	collection *mongo.Collection
+	logger     *zap.Logger
+}
+
+// NewWishlistRepository creates a new wishlist repository
+func NewWishlistRepository(db *mongo.Database, logger *zap.Logger) domain.WishlistRepository {
+	return &WishlistRepository{
+		collection: db.Collection("wishlist_items"),
+		logger:     logger,
+	}
+}
+
+// Create creates a new wishlist item
+func (r *WishlistRepository) Create(ctx context.Context, item *domain.WishlistItem) error {
+	item.CreatedAt = time.Now()
+	item.UpdatedAt = time.Now()
+
+	result, err := r.collection.InsertOne(ctx, item)
+	if err != nil {
+		r.logger.Error("Failed to create wishlist item", zap.Error(err))
+		return fmt.Errorf("failed to create wishlist item: %w", err)
+	}
+
+	item.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID retrieves one of a couple's wishlist items by ID
+func (r *WishlistRepository) GetByID(ctx context.Context, matchCode string, id primitive.ObjectID) (*domain.WishlistItem, error) {
+	var item domain.WishlistItem
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{
+		"_id":        id,
+		"match_code": matchCode,
+	})
+
+	err := r.collection.FindOne(ctx, filter).Decode(&item)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, fmt.Errorf("wishlist item not found")
+		}
+		r.logger.Error("Failed to get wishlist item by ID", zap.Error(err), zap.String("id", id.Hex()))
+		return nil, fmt.Errorf("failed to get wishlist item: %w", err)
+	}
+
+	return &item, nil
+}
+
+// GetVisible retrieves the couple's wishlist items the viewer can see, open items
+// first and then newest first
+func (r *WishlistRepository) GetVisible(ctx context.Context, matchCode string, viewerID primitive.ObjectID, filter domain.WishlistFilter, limit, offset int) ([]*domain.WishlistItem, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "purchased_at", Value: 1}, {Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, r.visibleFilter(matchCode, viewerID, filter), opts)
+	if err != nil {
+		r.logger.Error("Failed to get wishlist items", zap.Error(err), zap.String("match_code", matchCode))
+		return nil, fmt.Errorf("failed to get wishlist items: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var items []*domain.WishlistItem
+	if err := cursor.All(ctx, &items); err != nil {
+		r.logger.Error("Failed to decode wishlist items", zap.Error(err))
+		return nil, fmt.Errorf("failed to decode wishlist items: %w", err)
+	}
+
+	return items, nil
+}
+
+// CountVisible counts the couple's wishlist items the viewer can see
+func (r *WishlistRepository) CountVisible(ctx context.Context, matchCode string, viewerID primitive.ObjectID, filter domain.WishlistFilter) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, r.visibleFilter(matchCode, viewerID, filter))
+	if err != nil {
+		r.logger.Error("Failed to count wishlist items", zap.Error(err), zap.String("match_code", matchCode))
+		return 0, fmt.Errorf("failed to count wishlist items: %w", err)
+	}
+
+	return count, nil
+}
+
+// Update updates a wishlist item
+func (r *WishlistRepository) Update(ctx context.Context, item *domain.WishlistItem) error {
+	item.UpdatedAt = time.Now()
+
+	set := bson.M{
+		"title":      item.Title,
+		"url":        item.URL,
+		"currency":   item.Currency,
+		"notes":      item.Notes,
+		"is_secret":  item.IsSecret,
+		"updated_at": item.UpdatedAt,
+	}
+	unset := bson.M{}
+	setOrUnset := func(field string, value interface{}, present bool) {
+		if present {
+			set[field] = value
+		} else {
+			unset[field] = ""
+		}
+	}
+	setOrUnset("price", item.Price, item.Price != nil)
+	setOrUnset("purchased_at", item.PurchasedAt, item.PurchasedAt != nil)
+	setOrUnset("purchased_by", item.PurchasedBy, item.PurchasedBy != nil)
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{"_id": item.ID})
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		r.logger.Error("Failed to update wishlist item", zap.Error(err))
+		return fmt.Errorf("failed to update wishlist item: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("wishlist item not found")
+	}
+
+	return nil
+}
+
+// Delete soft deletes a wishlist item
+func (r *WishlistRepository) Delete(ctx context.Context, id primitive.ObjectID) error {
+	filter := SoftDelete.GetActiveFilterWithCondition(bson.M{"_id": id})
+
+	result, err := r.collection.UpdateOne(ctx, filter, SoftDelete.CreateSoftDeleteUpdate())
+	if err != nil {
+		r.logger.Error("Failed to delete wishlist item", zap.Error(err))
+		return fmt.Errorf("failed to delete wishlist item: %w", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("wishlist item not found")
+	}
+
+	return nil
+}
+
+// visibleFilter builds the filter for the couple's active wishlist items the viewer
+// can see: their own, and the partner's that are not secret or already purchased
+func (r *WishlistRepository) visibleFilter(matchCode string, viewerID primitive.ObjectID, filter domain.WishlistFilter) bson.M {
+	query := SoftDelete.GetActiveFilterWithCondition(bson.M{
+		"match_code": matchCode,
+		"$nor": bson.A{
+			bson.M{
+				"owner_id":     bson.M{"$ne": viewerID},
+				"is_secret":    true,
+				"purchased_at": bson.M{"$exists": false},
+			},
+		},
+	})
+	if filter.OwnerID != nil {
+		query["owner_id"] = *filter.OwnerID
+	}
+	switch filter.Status {
+	case domain.WishlistStatusOpen:
+		query["purchased_at"] = bson.M{"$exists": false}
+	case domain.WishlistStatusPurchased:
+		query["purchased_at"] = bson.M{"$exists": true}
+	}
+	return query
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"math/big"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/auth"
+	"github.com/eralove/eralove-backend/internal/infrastructure/email"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// accountMergeCodeDigits is the length of the code emailed to the merged account
+const accountMergeCodeDigits = 6
+
+// AccountMergeService implements domain.AccountMergeService
+type AccountMergeService struct {
+	mergeRepo       domain.AccountMergeRepository
+	userRepo        domain.UserRepository
+	photoRepo       domain.PhotoRepository
+	eventRepo       domain.EventRepository
+	messageRepo     domain.MessageRepository
+	passwordManager *auth.PasswordManager
+	emailService    *email.EmailService
+}
+
+// NewAccountMergeService creates a new account merge service
+func NewAccountMergeService(
+	mergeRepo domain.AccountMergeRepository,
+	userRepo domain.UserRepository,
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	messageRepo domain.MessageRepository,
+	passwordManager *auth.PasswordManager,
+	emailService *email.EmailService,
+) domain.AccountMergeService {
+	return &AccountMergeService{
+		mergeRepo:       mergeRepo,
+		userRepo:        userRepo,
+		photoRepo:       photoRepo,
+		eventRepo:       eventRepo,
+		messageRepo:     messageRepo,
+		passwordManager: passwordManager,
+		emailService:    emailService,
+	}
+}
+
+// StartMerge checks the credentials of both accounts and emails a code to the account
+// being merged. Starting a new merge replaces the user's pending one.
+func (s *AccountMergeService) StartMerge(ctx context.Context, userID primitive.ObjectID, req *domain.StartAccountMergeRequest) (*domain.AccountMerge, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if err := s.passwordManager.VerifyPassword(user.PasswordHash, req.CurrentPassword); err != nil {
+		logging.FromContext(ctx).Warn("Account merge with wrong current password", zap.String("user_id", userID.Hex()))
+		return nil, domain.ErrInvalidCredentials()
+	}
+
+	merged, err := s.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, domain.ErrInvalidCredentials()
+	}
+	if err := s.passwordManager.VerifyPassword(merged.PasswordHash, req.Password); err != nil {
+		logging.FromContext(ctx).Warn("Account merge with wrong password for merged account", zap.String("user_id", userID.Hex()))
+		return nil, domain.ErrInvalidCredentials()
+	}
+
+	if err := checkMergeable(user, merged); err != nil {
+		return nil, err
+	}
+
+	code, err := newAccountMergeCode()
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to generate account merge code", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to start account merge")
+	}
+
+	merge := &domain.AccountMerge{
+		SurvivingUserID: user.ID,
+		MergedUserID:    merged.ID,
+		MergedEmail:     merged.Email,
+		Status:          domain.AccountMergeStatusPending,
+		CodeHash:        hashInviteCode(code),
+		ExpiresAt:       time.Now().Add(domain.AccountMergeCodeTTL),
+	}
+
+	if err := s.mergeRepo.Replace(ctx, merge); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to start account merge")
+	}
+
+	if err := s.emailService.SendAccountMergeCodeEmail(merged.Locale, merged.Name, merged.Email, user.Name, user.Email, code, domain.AccountMergeCodeTTL); err != nil {
+		logging.FromContext(ctx).Error("Failed to send account merge code", zap.Error(err), zap.String("merge_id", merge.ID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to send the confirmation code")
+	}
+
+	logging.FromContext(ctx).Info("Account merge started",
+		zap.String("merge_id", merge.ID.Hex()),
+		zap.String("surviving_user_id", user.ID.Hex()),
+		zap.String("merged_user_id", merged.ID.Hex()))
+
+	return merge, nil
+}
+
+// ConfirmMerge checks the emailed code, moves the photos, events, messages and match of
+// the merged account to the user and tombstones the merged account
+func (s *AccountMergeService) ConfirmMerge(ctx context.Context, userID primitive.ObjectID, req *domain.ConfirmAccountMergeRequest) (*domain.AccountMerge, error) {
+	merge, err := s.mergeRepo.GetPending(ctx, userID, time.Now())
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Account merge")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashInviteCode(req.Code)), []byte(merge.CodeHash)) != 1 {
+		attempts, err := s.mergeRepo.IncrementAttempts(ctx, merge.ID)
+		if err == nil && attempts >= domain.AccountMergeMaxAttempts {
+			if err := s.mergeRepo.Delete(ctx, merge.ID); err != nil {
+				logging.FromContext(ctx).Error("Failed to cancel account merge", zap.Error(err), zap.String("merge_id", merge.ID.Hex()))
+			}
+			logging.FromContext(ctx).Warn("Account merge cancelled after too many wrong codes", zap.String("merge_id", merge.ID.Hex()))
+		}
+		return nil, domain.ErrInvalidRequestError("Invalid confirmation code")
+	}
+
+	// Both accounts are checked again, as either may have changed since the merge started
+	user, err := s.userRepo.GetByID(ctx, merge.SurvivingUserID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+	merged, err := s.userRepo.GetByID(ctx, merge.MergedUserID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+	if err := checkMergeable(user, merged); err != nil {
+		return nil, err
+	}
+
+	moved := &domain.AccountMergeCounts{}
+
+	if moved.Photos, err = s.photoRepo.ReassignCreator(ctx, merged.ID, user.ID); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to move photos")
+	}
+	if moved.Events, err = s.eventRepo.ReassignCreator(merged.ID, user.ID); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to move events")
+	}
+	if moved.Messages, err = s.messageRepo.ReassignUser(ctx, merged.ID, user.ID); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to move messages")
+	}
+
+	if merged.MatchCode != "" {
+		if err := s.moveMatch(ctx, merged, user); err != nil {
+			return nil, err
+		}
+		moved.MatchMoved = true
+	}
+
+	if err := s.userRepo.Tombstone(ctx, merged.ID, user.ID); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to close the merged account")
+	}
+
+	completedAt := time.Now()
+	if err := s.mergeRepo.Complete(ctx, merge.ID, moved, completedAt); err != nil {
+		// The merge went through; only its audit record is behind
+		logging.FromContext(ctx).Error("Failed to record completed account merge", zap.Error(err), zap.String("merge_id", merge.ID.Hex()))
+	}
+
+	merge.Status = domain.AccountMergeStatusCompleted
+	merge.Moved = moved
+	merge.CompletedAt = &completedAt
+
+	logging.FromContext(ctx).Info("Accounts merged",
+		zap.String("merge_id", merge.ID.Hex()),
+		zap.String("surviving_user_id", user.ID.Hex()),
+		zap.String("merged_user_id", merged.ID.Hex()),
+		zap.Int64("photos", moved.Photos),
+		zap.Int64("events", moved.Events),
+		zap.Int64("messages", moved.Messages),
+		zap.Bool("match_moved", moved.MatchMoved))
+
+	return merge, nil
+}
+
+// moveMatch hands the match of the merged account to the surviving user. The couple's
+// photos and events are keyed by match code, so they follow the match.
+func (s *AccountMergeService) moveMatch(ctx context.Context, merged, user *domain.User) error {
+	user.PartnerID = merged.PartnerID
+	user.PartnerName = merged.PartnerName
+	user.MatchCode = merged.MatchCode
+	user.MatchedAt = merged.MatchedAt
+	user.AnniversaryDate = merged.AnniversaryDate
+	user.UpdatedAt = time.Now()
+
+	if err := s.userRepo.Update(ctx, user.ID, user); err != nil {
+		return domain.ErrOperationFailedError("Failed to move the match")
+	}
+
+	if merged.PartnerID == nil {
+		return nil
+	}
+
+	partner, err := s.userRepo.GetByID(ctx, *merged.PartnerID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Partner of merged account not found", zap.String("partner_id", merged.PartnerID.Hex()))
+		return nil
+	}
+
+	partner.PartnerID = &user.ID
+	partner.PartnerName = user.Name
+	partner.UpdatedAt = time.Now()
+
+	if err := s.userRepo.Update(ctx, partner.ID, partner); err != nil {
+		return domain.ErrOperationFailedError("Failed to move the match")
+	}
+
+	return nil
+}
+
+// checkMergeable rejects merges that would lose or mix up a couple: only one of the
+// accounts may be matched, and not to the other one
+func checkMergeable(user, merged *domain.User) error {
+	if user.ID == merged.ID {
+		return domain.ErrInvalidRequestError("Cannot merge an account into itself")
+	}
+
+	if user.MatchCode != "" && merged.MatchCode != "" {
+		if user.MatchCode == merged.MatchCode {
+			return domain.ErrInvalidRequestError("Cannot merge partners' accounts")
+		}
+		return domain.ErrInvalidRequestError("Both accounts are matched; unmatch one of them first")
+	}
+
+	if user.PendingUnmatch() != nil || merged.PendingUnmatch() != nil {
+		return domain.ErrOperationInProgressError("Unmatch")
+	}
+
+	return nil
+}
+
+// newAccountMergeCode returns a random numeric code
+func newAccountMergeCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < accountMergeCodeDigits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", accountMergeCodeDigits, n), nil
+}
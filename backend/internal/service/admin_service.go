@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"strings"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// adminMaxMatchRequests is how many pending match requests of each direction the
+// match state shows
+const adminMaxMatchRequests = 20
+
+// AdminService implements domain.AdminService
+type AdminService struct {
+	userRepo         domain.UserRepository
+	matchRequestRepo domain.MatchRequestRepository
+	tokenFamilies    domain.TokenFamilyRepository
+	userService      domain.UserService
+	audit            domain.AuditService
+}
+
+// NewAdminService creates a new service for the support staff operations on accounts
+func NewAdminService(
+	userRepo domain.UserRepository,
+	matchRequestRepo domain.MatchRequestRepository,
+	tokenFamilies domain.TokenFamilyRepository,
+	userService domain.UserService,
+	audit domain.AuditService,
+) domain.AdminService {
+	return &AdminService{
+		userRepo:         userRepo,
+		matchRequestRepo: matchRequestRepo,
+		tokenFamilies:    tokenFamilies,
+		userService:      userService,
+		audit:            audit,
+	}
+}
+
+// SearchUsers lists the users whose name or email contains the filter's query,
+// newest first
+func (s *AdminService) SearchUsers(ctx context.Context, filter domain.UserSearchFilter, cursor *domain.Cursor, limit int) (*domain.AdminUserListResponse, error) {
+	switch filter.Status {
+	case "", domain.UserSearchStatusActive, domain.UserSearchStatusDeleted:
+	default:
+		return nil, domain.NewAppError(domain.ErrCodeInvalidRequest, "Status must be active or deleted", 400)
+	}
+
+	// Fetch one extra item to know whether another page exists
+	users, err := s.userRepo.Search(ctx, filter, cursor, limit+1)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to search users")
+	}
+
+	response := &domain.AdminUserListResponse{
+		Users: make([]*domain.AdminUserResponse, 0, len(users)),
+		Limit: limit,
+	}
+
+	if len(users) > limit {
+		users = users[:limit]
+		last := users[len(users)-1]
+		response.NextCursor = domain.NewCursor(last.CreatedAt, last.ID).Encode()
+	}
+
+	for _, user := range users {
+		response.Users = append(response.Users, user.ToAdminResponse())
+	}
+
+	return response, nil
+}
+
+// GetUser returns a user, deleted or not
+func (s *AdminService) GetUser(ctx context.Context, userID primitive.ObjectID) (*domain.AdminUserResponse, error) {
+	user, err := s.userRepo.GetByIDIncludingDeleted(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	return user.ToAdminResponse(), nil
+}
+
+// RestoreUser restores a soft-deleted account, unless another account took its email
+// in the meantime
+func (s *AdminService) RestoreUser(ctx context.Context, userID primitive.ObjectID) (*domain.AdminUserResponse, error) {
+	user, err := s.userRepo.GetByIDIncludingDeleted(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.DeletedAt == nil {
+		return nil, domain.NewAppError(domain.ErrCodeInvalidStatusChange, "Account is not deleted", 409)
+	}
+
+	if _, err := s.userRepo.GetByEmail(ctx, user.Email); err == nil {
+		return nil, domain.ErrUserAlreadyExists(user.Email)
+	}
+
+	if err := s.userRepo.Restore(ctx, userID); err != nil {
+		// Another account may have taken the email since the check above
+		if appErr, ok := err.(*domain.AppError); ok {
+			return nil, appErr
+		}
+		logging.FromContext(ctx).Error("Failed to restore user", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to restore account")
+	}
+
+	s.audit.Record(ctx, userID, domain.AuditAccountRestored, nil)
+	logging.FromContext(ctx).Info("Account restored by support", zap.String("user_id", userID.Hex()))
+
+	return s.GetUser(ctx, userID)
+}
+
+// ForcePasswordReset clears the user's password so that it no longer logs in, signs
+// out every session and emails a link to choose a new password
+func (s *AdminService) ForcePasswordReset(ctx context.Context, userID primitive.ObjectID) error {
+	user, err := s.getActiveUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	user.PasswordHash = ""
+	if err := s.userRepo.Update(ctx, user.ID, user); err != nil {
+		logging.FromContext(ctx).Error("Failed to clear password", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return domain.ErrOperationFailedError("Failed to reset password")
+	}
+
+	if err := s.tokenFamilies.RevokeAll(ctx, user.ID); err != nil {
+		logging.FromContext(ctx).Error("Failed to revoke sessions", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return domain.ErrOperationFailedError("Failed to reset password")
+	}
+
+	if err := s.userService.ForgotPassword(ctx, &domain.ForgotPasswordRequest{Email: user.Email}); err != nil {
+		return domain.ErrOperationFailedError("Failed to send password reset email")
+	}
+
+	s.audit.Record(ctx, userID, domain.AuditPasswordResetForced, nil)
+	logging.FromContext(ctx).Info("Password reset forced by support", zap.String("user_id", userID.Hex()))
+
+	return nil
+}
+
+// ResendVerificationEmail sends the user a new email verification link
+func (s *AdminService) ResendVerificationEmail(ctx context.Context, userID primitive.ObjectID) error {
+	user, err := s.getActiveUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userService.ResendVerificationEmail(ctx, &domain.ResendVerificationRequest{Email: user.Email}); err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, userID, domain.AuditVerificationResent, nil)
+	logging.FromContext(ctx).Info("Verification email resent by support", zap.String("user_id", userID.Hex()))
+
+	return nil
+}
+
+// GetMatchState describes the user's match and the match requests still pending
+func (s *AdminService) GetMatchState(ctx context.Context, userID primitive.ObjectID) (*domain.AdminMatchStateResponse, error) {
+	user, err := s.userRepo.GetByIDIncludingDeleted(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	state := &domain.AdminMatchStateResponse{
+		User:             user.ToAdminResponse(),
+		MatchCode:        user.MatchCode,
+		MatchedAt:        user.MatchedAt,
+		AnniversaryDate:  domain.DateFromTimePtr(user.AnniversaryDate),
+		Unmatch:          user.PendingUnmatch(),
+		Consistent:       user.PartnerID == nil && user.MatchCode == "",
+		SentRequests:     []*domain.MatchRequestResponse{},
+		ReceivedRequests: []*domain.MatchRequestResponse{},
+	}
+
+	if user.PartnerID != nil {
+		partner, err := s.userRepo.GetByIDIncludingDeleted(ctx, *user.PartnerID)
+		if err == nil {
+			state.Partner = partner.ToAdminResponse()
+			state.Consistent = partner.PartnerID != nil && *partner.PartnerID == user.ID &&
+				partner.MatchCode == user.MatchCode && user.MatchCode != ""
+		}
+	}
+
+	sent, err := s.matchRequestRepo.GetBySenderIDAndStatus(userID, domain.MatchRequestStatusPending, adminMaxMatchRequests, 0)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get match requests")
+	}
+	for _, request := range sent {
+		state.SentRequests = append(state.SentRequests, request.ToResponse())
+	}
+
+	received, err := s.matchRequestRepo.GetByReceiverIDAndStatus(userID, domain.MatchRequestStatusPending, adminMaxMatchRequests, 0)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get match requests")
+	}
+	for _, request := range received {
+		state.ReceivedRequests = append(state.ReceivedRequests, request.ToResponse())
+	}
+
+	return state, nil
+}
+
+// SetRoles replaces the user's roles. Access tokens already issued keep their roles
+// until they expire.
+func (s *AdminService) SetRoles(ctx context.Context, userID primitive.ObjectID, req *domain.SetRolesRequest) (*domain.AdminUserResponse, error) {
+	user, err := s.getActiveUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// RoleUser is implied, so only the other roles are stored
+	roles := []domain.Role{}
+	for _, role := range req.Roles {
+		if role != domain.RoleUser && !containsRole(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	user.Roles = roles
+
+	if err := s.userRepo.Update(ctx, user.ID, user); err != nil {
+		logging.FromContext(ctx).Error("Failed to update roles", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to update roles")
+	}
+
+	s.audit.Record(ctx, userID, domain.AuditRolesChanged, map[string]string{
+		"roles": strings.Join(user.RoleNames(), ","),
+	})
+
+	logging.FromContext(ctx).Info("User roles changed",
+		zap.String("user_id", userID.Hex()),
+		zap.Strings("roles", user.RoleNames()))
+
+	return user.ToAdminResponse(), nil
+}
+
+// getActiveUser returns a user whose account is neither deleted nor merged
+func (s *AdminService) getActiveUser(ctx context.Context, userID primitive.ObjectID) (*domain.User, error) {
+	user, err := s.userRepo.GetByIDIncludingDeleted(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.DeletedAt != nil || !user.IsActive {
+		return nil, domain.NewAppError(domain.ErrCodeInvalidStatusChange, "Account is deleted or merged", 409)
+	}
+
+	return user, nil
+}
+
+// containsRole reports whether roles contains role
+func containsRole(roles []domain.Role, role domain.Role) bool {
+	for _, have := range roles {
+		if have == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// restoreRaceUserRepository holds a deleted account whose email another account takes
+// between the restore check and the write
+type restoreRaceUserRepository struct {
+	domain.UserRepository
+	user *domain.User
+}
+
+func (r *restoreRaceUserRepository) GetByIDIncludingDeleted(ctx context.Context, id primitive.ObjectID) (*domain.User, error) {
+	return r.user, nil
+}
+
+func (r *restoreRaceUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	return nil, errors.New("user not found")
+}
+
+func (r *restoreRaceUserRepository) Restore(ctx context.Context, id primitive.ObjectID) error {
+	return domain.NewAppError(domain.ErrCodeUserAlreadyExists, "Email is in use by another account", 409)
+}
+
+func TestRestoreUserEmailTaken(t *testing.T) {
+	deletedAt := time.Now().Add(-time.Hour)
+	user := &domain.User{ID: primitive.NewObjectID(), Email: "alex@example.com", DeletedAt: &deletedAt}
+	service := NewAdminService(&restoreRaceUserRepository{user: user}, nil, nil, nil, nil)
+
+	_, err := service.RestoreUser(context.Background(), user.ID)
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.StatusCode != 409 {
+		t.Errorf("err = %v, want a 409 error", err)
+	}
+}
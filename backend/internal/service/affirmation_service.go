@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"math/rand"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// affirmationMaxDelayDays is the latest day after upload an affirmation may be delivered on
+	affirmationMaxDelayDays = 7
+	// affirmationMorningStartHour and affirmationMorningWindow bound the delivery time of
+	// day, in the recipient's time zone
+	affirmationMorningStartHour = 7
+	affirmationMorningWindow    = 2 * time.Hour
+	// affirmationAudioURLExpiry is how long a playback URL stays valid
+	affirmationAudioURLExpiry = 1 * time.Hour
+)
+
+// AffirmationService implements domain.AffirmationService
+type AffirmationService struct {
+	affirmationRepo     domain.AffirmationRepository
+	userRepo            domain.UserRepository
+	storageService      domain.StorageService
+	scanService         domain.UploadScanService
+	notificationService domain.NotificationService
+}
+
+// NewAffirmationService creates a new affirmation service
+func NewAffirmationService(
+	affirmationRepo domain.AffirmationRepository,
+	userRepo domain.UserRepository,
+	storageService domain.StorageService,
+	scanService domain.UploadScanService,
+	notificationService domain.NotificationService,
+) domain.AffirmationService {
+	return &AffirmationService{
+		affirmationRepo:     affirmationRepo,
+		userRepo:            userRepo,
+		storageService:      storageService,
+		scanService:         scanService,
+		notificationService: notificationService,
+	}
+}
+
+// CreateAffirmation uploads an audio affirmation and queues it for delivery to the partner
+func (s *AffirmationService) CreateAffirmation(ctx context.Context, userID primitive.ObjectID, req *domain.CreateAffirmationRequest) (*domain.AffirmationResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" || user.PartnerID == nil {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	if err := domain.ValidateAudioFile(req.ContentType, req.Size); err != nil {
+		return nil, domain.ErrInvalidRequestError("Invalid file: " + err.Error())
+	}
+
+	fileInfo, err := s.storageService.Upload(ctx, &domain.UploadRequest{
+		File:        req.File,
+		Filename:    req.Filename,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		Folder:      "affirmations",
+		UserID:      userID.Hex(),
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to upload affirmation audio", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to upload file")
+	}
+	if err := s.scanService.ScanUpload(ctx, fileInfo); err != nil {
+		return nil, err
+	}
+
+	// Mornings are the recipient's
+	location := time.UTC
+	if partner, err := s.userRepo.GetByID(ctx, *user.PartnerID); err == nil {
+		location = partner.Location()
+	}
+
+	affirmation := &domain.Affirmation{
+		MatchCode:       user.MatchCode,
+		CreatedBy:       userID,
+		RecipientID:     *user.PartnerID,
+		Title:           req.Title,
+		AudioKey:        fileInfo.Key,
+		ContentType:     req.ContentType,
+		Size:            req.Size,
+		DurationSeconds: req.DurationSeconds,
+		Status:          domain.AffirmationStatusQueued,
+		ScheduledFor:    randomMorning(time.Now(), location),
+	}
+
+	if err := s.affirmationRepo.Create(ctx, affirmation); err != nil {
+		logging.FromContext(ctx).Error("Failed to create affirmation", zap.Error(err))
+		if delErr := s.storageService.Delete(ctx, fileInfo.Key); delErr != nil {
+			logging.FromContext(ctx).Warn("Failed to clean up affirmation audio", zap.Error(delErr), zap.String("key", fileInfo.Key))
+		}
+		return nil, domain.ErrOperationFailedError("Failed to create affirmation")
+	}
+
+	logging.FromContext(ctx).Info("Affirmation queued",
+		zap.String("affirmation_id", affirmation.ID.Hex()),
+		zap.String("user_id", userID.Hex()),
+		zap.Time("scheduled_for", affirmation.ScheduledFor))
+
+	return s.buildResponse(ctx, affirmation, userID), nil
+}
+
+// GetAffirmation retrieves an affirmation with a playback URL
+func (s *AffirmationService) GetAffirmation(ctx context.Context, affirmationID, userID primitive.ObjectID) (*domain.AffirmationResponse, error) {
+	affirmation, err := s.getAuthorizedAffirmation(ctx, affirmationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildResponse(ctx, affirmation, userID), nil
+}
+
+// GetLibrary retrieves the affirmations a user has received or sent
+func (s *AffirmationService) GetLibrary(ctx context.Context, userID primitive.ObjectID, box domain.AffirmationBox, page, limit int) (*domain.AffirmationListResponse, error) {
+	offset := (page - 1) * limit
+
+	var affirmations []*domain.Affirmation
+	var total int64
+	var err error
+
+	if box == domain.AffirmationBoxSent {
+		affirmations, err = s.affirmationRepo.GetByCreator(ctx, userID, limit, offset)
+		if err == nil {
+			total, err = s.affirmationRepo.CountByCreator(ctx, userID)
+		}
+	} else {
+		affirmations, err = s.affirmationRepo.GetByRecipient(ctx, userID, limit, offset)
+		if err == nil {
+			total, err = s.affirmationRepo.CountByRecipient(ctx, userID)
+		}
+	}
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get affirmation library", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to get affirmations")
+	}
+
+	responses := make([]*domain.AffirmationResponse, len(affirmations))
+	for i, affirmation := range affirmations {
+		responses[i] = s.buildResponse(ctx, affirmation, userID)
+	}
+
+	return &domain.AffirmationListResponse{
+		Affirmations: responses,
+		Total:        total,
+		Page:         page,
+		Limit:        limit,
+	}, nil
+}
+
+// RecordPlay tracks that the recipient played an affirmation
+func (s *AffirmationService) RecordPlay(ctx context.Context, affirmationID, userID primitive.ObjectID) (*domain.AffirmationResponse, error) {
+	affirmation, err := s.getAuthorizedAffirmation(ctx, affirmationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the recipient's plays count; the sender previewing their own recording does not
+	if affirmation.RecipientID != userID {
+		return s.buildResponse(ctx, affirmation, userID), nil
+	}
+
+	now := time.Now()
+	if err := s.affirmationRepo.RecordPlay(ctx, affirmationID, now); err != nil {
+		logging.FromContext(ctx).Error("Failed to record affirmation play", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to record play")
+	}
+
+	affirmation.PlayCount++
+	affirmation.LastPlayedAt = &now
+
+	return s.buildResponse(ctx, affirmation, userID), nil
+}
+
+// DeleteAffirmation deletes an affirmation; only the sender may delete it
+func (s *AffirmationService) DeleteAffirmation(ctx context.Context, affirmationID, userID primitive.ObjectID) error {
+	affirmation, err := s.getAuthorizedAffirmation(ctx, affirmationID, userID)
+	if err != nil {
+		return err
+	}
+
+	if affirmation.CreatedBy != userID {
+		return domain.ErrForbiddenError()
+	}
+
+	if err := s.affirmationRepo.Delete(ctx, affirmationID); err != nil {
+		logging.FromContext(ctx).Error("Failed to delete affirmation", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to delete affirmation")
+	}
+
+	if err := s.storageService.Delete(ctx, affirmation.AudioKey); err != nil {
+		logging.FromContext(ctx).Warn("Failed to delete affirmation audio", zap.Error(err), zap.String("key", affirmation.AudioKey))
+	}
+
+	logging.FromContext(ctx).Info("Affirmation deleted",
+		zap.String("affirmation_id", affirmationID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return nil
+}
+
+// DeliverDue delivers queued affirmations whose scheduled morning has arrived
+// and notifies the recipients. It is run periodically by the scheduler.
+func (s *AffirmationService) DeliverDue(ctx context.Context) error {
+	now := time.Now()
+
+	affirmations, err := s.affirmationRepo.GetDueForDelivery(ctx, now)
+	if err != nil {
+		return fmt.Errorf("failed to get affirmations due for delivery: %w", err)
+	}
+
+	delivered := 0
+	for _, affirmation := range affirmations {
+		if err := s.affirmationRepo.MarkDelivered(ctx, affirmation.ID, now); err != nil {
+			logging.FromContext(ctx).Error("Failed to mark affirmation as delivered",
+				zap.Error(err),
+				zap.String("affirmation_id", affirmation.ID.Hex()))
+			continue
+		}
+		delivered++
+
+		var senderName interface{} = domain.NotificationMessage("notification_your_partner")
+		if sender, err := s.userRepo.GetByID(ctx, affirmation.CreatedBy); err == nil {
+			senderName = sender.Name
+		}
+
+		data := map[string]string{
+			"affirmation_id": affirmation.ID.Hex(),
+		}
+		tmpl := domain.NotificationTemplate{
+			Key:    "affirmation",
+			Params: map[string]interface{}{"SenderName": senderName, "Title": affirmation.Title},
+		}
+		if err := s.notificationService.Notify(ctx, affirmation.RecipientID, domain.NotificationTypeAffirmation, tmpl, data); err != nil {
+			logging.FromContext(ctx).Warn("Failed to notify affirmation recipient",
+				zap.Error(err),
+				zap.String("affirmation_id", affirmation.ID.Hex()))
+		}
+	}
+
+	if delivered > 0 {
+		logging.FromContext(ctx).Info("Affirmations delivered", zap.Int("count", delivered))
+	}
+
+	return nil
+}
+
+// getAuthorizedAffirmation retrieves an affirmation visible to the user.
+// Recipients can only see an affirmation once it has been delivered.
+func (s *AffirmationService) getAuthorizedAffirmation(ctx context.Context, affirmationID, userID primitive.ObjectID) (*domain.Affirmation, error) {
+	affirmation, err := s.affirmationRepo.GetByID(ctx, affirmationID)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Affirmation")
+	}
+
+	if affirmation.CreatedBy == userID {
+		return affirmation, nil
+	}
+
+	if affirmation.RecipientID == userID {
+		if affirmation.Status != domain.AffirmationStatusDelivered {
+			return nil, domain.ErrNotFoundError("Affirmation")
+		}
+		return affirmation, nil
+	}
+
+	return nil, domain.ErrForbiddenError()
+}
+
+// buildResponse converts an affirmation to a response with a presigned playback URL
+func (s *AffirmationService) buildResponse(ctx context.Context, affirmation *domain.Affirmation, viewerID primitive.ObjectID) *domain.AffirmationResponse {
+	response := affirmation.ToResponse(viewerID)
+
+	audioURL, err := s.storageService.GeneratePresignedDownloadURL(ctx, affirmation.AudioKey, affirmationAudioURLExpiry)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to generate affirmation audio URL",
+			zap.Error(err),
+			zap.String("affirmation_id", affirmation.ID.Hex()))
+		return response
+	}
+	response.AudioURL = audioURL
+
+	return response
+}
+
+// randomMorning picks a random morning in location between one and
+// affirmationMaxDelayDays days after from
+func randomMorning(from time.Time, location *time.Location) time.Time {
+	day := from.In(location).AddDate(0, 0, 1+rand.Intn(affirmationMaxDelayDays))
+	morning := time.Date(day.Year(), day.Month(), day.Day(), affirmationMorningStartHour, 0, 0, 0, location)
+	return morning.Add(time.Duration(rand.Int63n(int64(affirmationMorningWindow))))
+}
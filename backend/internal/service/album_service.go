@@ -0,0 +1,419 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// AlbumService implements domain.AlbumService
+type AlbumService struct {
+	albumRepo      domain.AlbumRepository
+	photoRepo      domain.PhotoRepository
+	userRepo       domain.UserRepository
+	pendingActions domain.PendingActionService
+}
+
+// NewAlbumService creates a new album service. It carries out album deletions once
+// the partner approved them.
+func NewAlbumService(
+	albumRepo domain.AlbumRepository,
+	photoRepo domain.PhotoRepository,
+	userRepo domain.UserRepository,
+	pendingActions domain.PendingActionService,
+) domain.AlbumService {
+	s := &AlbumService{
+		albumRepo:      albumRepo,
+		photoRepo:      photoRepo,
+		userRepo:       userRepo,
+		pendingActions: pendingActions,
+	}
+	pendingActions.RegisterExecutor(domain.PendingActionAlbumDelete, s)
+	return s
+}
+
+// CreateAlbum creates a new album at the end of the couple's album list
+func (s *AlbumService) CreateAlbum(ctx context.Context, userID primitive.ObjectID, req *domain.CreateAlbumRequest) (*domain.AlbumResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	count, err := s.albumRepo.CountByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count albums", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create album")
+	}
+
+	album := &domain.Album{
+		MatchCode:   user.MatchCode,
+		CreatedBy:   userID,
+		Name:        req.Name,
+		Description: req.Description,
+		Position:    int(count),
+	}
+
+	if err := s.albumRepo.Create(ctx, album); err != nil {
+		logging.FromContext(ctx).Error("Failed to create album", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create album")
+	}
+
+	logging.FromContext(ctx).Info("Album created",
+		zap.String("album_id", album.ID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return album.ToResponse(), nil
+}
+
+// GetAlbum retrieves an album with its photo count
+func (s *AlbumService) GetAlbum(ctx context.Context, albumID, userID primitive.ObjectID) (*domain.AlbumResponse, error) {
+	album, err := s.getAuthorizedAlbum(ctx, albumID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.buildResponse(ctx, album, userID)
+}
+
+// GetCoupleAlbums retrieves all albums of the user's couple with photo counts
+func (s *AlbumService) GetCoupleAlbums(ctx context.Context, userID primitive.ObjectID) ([]*domain.AlbumResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return []*domain.AlbumResponse{}, nil
+	}
+
+	albums, err := s.albumRepo.GetByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get albums", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get albums")
+	}
+
+	return s.buildResponses(ctx, albums, userID)
+}
+
+// UpdateAlbum renames an album or updates its description
+func (s *AlbumService) UpdateAlbum(ctx context.Context, albumID, userID primitive.ObjectID, req *domain.UpdateAlbumRequest) (*domain.AlbumResponse, error) {
+	album, err := s.getAuthorizedAlbum(ctx, albumID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		album.Name = req.Name
+	}
+	if req.Description != "" {
+		album.Description = req.Description
+	}
+
+	if err := s.albumRepo.Update(ctx, albumID, album); err != nil {
+		logging.FromContext(ctx).Error("Failed to update album", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update album")
+	}
+
+	return s.buildResponse(ctx, album, userID)
+}
+
+// DeleteAlbum deletes an album; its photos are kept and stay linked to it, so they come
+// back with the album if it is restored. When the couple requires partner approval, the deletion is held as a pending action
+// instead and returned.
+func (s *AlbumService) DeleteAlbum(ctx context.Context, albumID, userID primitive.ObjectID) (*domain.PendingActionResponse, error) {
+	album, err := s.getAuthorizedAlbum(ctx, albumID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	required, err := s.pendingActions.ApprovalRequired(ctx, album.MatchCode)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		return s.pendingActions.Request(ctx, userID, domain.PendingActionAlbumDelete, album.ID, album.Name)
+	}
+
+	if err := s.deleteAlbum(ctx, album.ID); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("Album deleted",
+		zap.String("album_id", albumID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return nil, nil
+}
+
+// ExecutePendingAction deletes an album once the partner approved its deletion
+func (s *AlbumService) ExecutePendingAction(ctx context.Context, action *domain.PendingAction) error {
+	album, err := s.albumRepo.GetByID(ctx, action.TargetID)
+	if err != nil {
+		return domain.ErrNotFoundError("Album")
+	}
+
+	if album.MatchCode != action.MatchCode {
+		return domain.ErrForbiddenError()
+	}
+
+	if err := s.deleteAlbum(ctx, album.ID); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("Album deleted after partner approval",
+		zap.String("album_id", album.ID.Hex()),
+		zap.String("pending_action_id", action.ID.Hex()),
+		zap.String("user_id", action.RequestedBy.Hex()))
+
+	return nil
+}
+
+// deleteAlbum soft deletes an album. Its photos keep their album_id; reads skip
+// deleted albums.
+func (s *AlbumService) deleteAlbum(ctx context.Context, albumID primitive.ObjectID) error {
+	if err := s.albumRepo.Delete(ctx, albumID); err != nil {
+		logging.FromContext(ctx).Error("Failed to delete album", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to delete album")
+	}
+
+	return nil
+}
+
+// ReorderAlbums sets the order of the couple's albums
+func (s *AlbumService) ReorderAlbums(ctx context.Context, userID primitive.ObjectID, req *domain.ReorderAlbumsRequest) ([]*domain.AlbumResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	albumIDs, err := parseObjectIDs(req.AlbumIDs)
+	if err != nil {
+		return nil, domain.ErrInvalidRequestError("Invalid album ID")
+	}
+
+	if err := s.albumRepo.UpdatePositions(ctx, user.MatchCode, albumIDs); err != nil {
+		logging.FromContext(ctx).Error("Failed to reorder albums", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to reorder albums")
+	}
+
+	albums, err := s.albumRepo.GetByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get albums", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get albums")
+	}
+
+	return s.buildResponses(ctx, albums, userID)
+}
+
+// SetCoverPhoto sets an album's cover to one of the couple's photos, adding it to the album if needed
+func (s *AlbumService) SetCoverPhoto(ctx context.Context, albumID, userID primitive.ObjectID, req *domain.SetAlbumCoverRequest) (*domain.AlbumResponse, error) {
+	album, err := s.getAuthorizedAlbum(ctx, albumID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	photoID, err := primitive.ObjectIDFromHex(req.PhotoID)
+	if err != nil {
+		return nil, domain.ErrInvalidRequestError("Invalid photo ID")
+	}
+
+	photo, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil || !photo.IsVisibleTo(userID) {
+		return nil, domain.ErrNotFoundError("Photo")
+	}
+
+	if photo.MatchCode != album.MatchCode {
+		return nil, domain.ErrForbiddenError()
+	}
+
+	if photo.AlbumID == nil || *photo.AlbumID != albumID {
+		if _, err := s.photoRepo.SetAlbum(ctx, album.MatchCode, []primitive.ObjectID{photoID}, &albumID); err != nil {
+			logging.FromContext(ctx).Error("Failed to add cover photo to album", zap.Error(err))
+			return nil, domain.ErrOperationFailedError("Failed to set cover photo")
+		}
+	}
+
+	album.CoverPhotoID = &photoID
+	if err := s.albumRepo.Update(ctx, albumID, album); err != nil {
+		logging.FromContext(ctx).Error("Failed to set album cover", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to set cover photo")
+	}
+
+	return s.buildResponse(ctx, album, userID)
+}
+
+// GetAlbumPhotos retrieves the photos of an album the user can see with pagination
+func (s *AlbumService) GetAlbumPhotos(ctx context.Context, albumID, userID primitive.ObjectID, page, limit int) ([]*domain.PhotoResponse, int64, error) {
+	if _, err := s.getAuthorizedAlbum(ctx, albumID, userID); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+
+	photos, err := s.photoRepo.GetByAlbumID(ctx, albumID, userID, limit, offset)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get album photos", zap.Error(err))
+		return nil, 0, domain.ErrOperationFailedError("Failed to get photos")
+	}
+
+	total, err := s.photoRepo.CountByAlbumID(ctx, albumID, userID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count album photos", zap.Error(err))
+		return nil, 0, domain.ErrOperationFailedError("Failed to get photos")
+	}
+
+	responses := make([]*domain.PhotoResponse, len(photos))
+	for i, photo := range photos {
+		responses[i] = photo.ToResponse()
+		responses[i].IsFavorite = photo.IsFavoriteOf(userID)
+	}
+
+	return responses, total, nil
+}
+
+// AddPhotos moves the couple's photos into an album
+func (s *AlbumService) AddPhotos(ctx context.Context, albumID, userID primitive.ObjectID, req *domain.AlbumPhotosRequest) (*domain.AlbumResponse, error) {
+	album, err := s.getAuthorizedAlbum(ctx, albumID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	photoIDs, err := parseObjectIDs(req.PhotoIDs)
+	if err != nil {
+		return nil, domain.ErrInvalidRequestError("Invalid photo ID")
+	}
+
+	// Only photos of the same couple are matched, so foreign IDs are silently ignored
+	updated, err := s.photoRepo.SetAlbum(ctx, album.MatchCode, photoIDs, &albumID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to add photos to album", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to add photos to album")
+	}
+
+	logging.FromContext(ctx).Info("Photos added to album",
+		zap.String("album_id", albumID.Hex()),
+		zap.Int64("photos", updated))
+
+	return s.buildResponse(ctx, album, userID)
+}
+
+// RemovePhoto removes a photo from an album without deleting it
+func (s *AlbumService) RemovePhoto(ctx context.Context, albumID, photoID, userID primitive.ObjectID) error {
+	album, err := s.getAuthorizedAlbum(ctx, albumID, userID)
+	if err != nil {
+		return err
+	}
+
+	photo, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil || !photo.IsVisibleTo(userID) {
+		return domain.ErrNotFoundError("Photo")
+	}
+
+	if photo.AlbumID == nil || *photo.AlbumID != albumID {
+		return domain.NewAppError(domain.ErrCodeNotFound, "Photo not found in album", 404)
+	}
+
+	if _, err := s.photoRepo.SetAlbum(ctx, album.MatchCode, []primitive.ObjectID{photoID}, nil); err != nil {
+		logging.FromContext(ctx).Error("Failed to remove photo from album", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to remove photo from album")
+	}
+
+	if album.CoverPhotoID != nil && *album.CoverPhotoID == photoID {
+		if err := s.albumRepo.ClearCover(ctx, albumID); err != nil {
+			logging.FromContext(ctx).Warn("Failed to clear album cover", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// buildResponses converts albums to responses with the counts of the photos the viewer
+// can see and cover URLs
+func (s *AlbumService) buildResponses(ctx context.Context, albums []*domain.Album, viewerID primitive.ObjectID) ([]*domain.AlbumResponse, error) {
+	albumIDs := make([]primitive.ObjectID, len(albums))
+	for i, album := range albums {
+		albumIDs[i] = album.ID
+	}
+
+	counts, err := s.photoRepo.CountByAlbumIDs(ctx, albumIDs, viewerID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count album photos", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get albums")
+	}
+
+	responses := make([]*domain.AlbumResponse, len(albums))
+	for i, album := range albums {
+		responses[i] = album.ToResponse()
+		responses[i].PhotoCount = counts[album.ID]
+		responses[i].CoverImageURL = s.getCoverImageURL(ctx, album, viewerID)
+	}
+
+	return responses, nil
+}
+
+// buildResponse converts a single album to a response with photo count and cover URL
+func (s *AlbumService) buildResponse(ctx context.Context, album *domain.Album, viewerID primitive.ObjectID) (*domain.AlbumResponse, error) {
+	responses, err := s.buildResponses(ctx, []*domain.Album{album}, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return responses[0], nil
+}
+
+// getCoverImageURL returns the medium-size image URL of an album's cover photo, if any
+// and the viewer can see it
+func (s *AlbumService) getCoverImageURL(ctx context.Context, album *domain.Album, viewerID primitive.ObjectID) string {
+	if album.CoverPhotoID == nil {
+		return ""
+	}
+
+	photo, err := s.photoRepo.GetByID(ctx, *album.CoverPhotoID)
+	if err != nil || !photo.IsVisibleTo(viewerID) {
+		return ""
+	}
+
+	return photo.ToResponse().MediumURL
+}
+
+// getAuthorizedAlbum loads an album and verifies that it belongs to the user's couple
+func (s *AlbumService) getAuthorizedAlbum(ctx context.Context, albumID, userID primitive.ObjectID) (*domain.Album, error) {
+	album, err := s.albumRepo.GetByID(ctx, albumID)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Album")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if album.MatchCode != user.MatchCode {
+		return nil, domain.ErrForbiddenError()
+	}
+
+	return album, nil
+}
+
+// parseObjectIDs converts hex strings to ObjectIDs
+func parseObjectIDs(hexIDs []string) ([]primitive.ObjectID, error) {
+	ids := make([]primitive.ObjectID, 0, len(hexIDs))
+	for _, hexID := range hexIDs {
+		id, err := primitive.ObjectIDFromHex(hexID)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// AuditService implements domain.AuditService
+type AuditService struct {
+	auditRepo domain.AuditLogRepository
+}
+
+// NewAuditService creates a new audit log service
+func NewAuditService(auditRepo domain.AuditLogRepository) domain.AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+	}
+}
+
+// Record adds an audit log entry for an action on userID. Outside of a request the
+// action is attributed to the system.
+func (s *AuditService) Record(ctx context.Context, userID primitive.ObjectID, action domain.AuditAction, details map[string]string) {
+	entry := &domain.AuditLog{
+		UserID:    userID,
+		Action:    action,
+		ActorType: domain.AuditActorSystem,
+		Details:   details,
+	}
+
+	if meta := domain.RequestMetaFrom(ctx); meta != nil {
+		entry.IP = meta.IP
+		entry.UserAgent = meta.UserAgent
+		entry.TraceID = meta.TraceID
+		entry.ActorID = meta.ActorID
+		if meta.Support {
+			entry.ActorType = domain.AuditActorSupport
+		} else {
+			// Anonymous requests, such as a login or a password reset, act on the
+			// account of whoever proves to own it
+			entry.ActorType = domain.AuditActorUser
+		}
+	}
+
+	if err := s.auditRepo.Create(ctx, entry); err != nil {
+		logging.FromContext(ctx).Error("Failed to record audit log entry",
+			zap.Error(err),
+			zap.String("user_id", userID.Hex()),
+			zap.String("action", string(action)))
+	}
+}
+
+// ListForUser lists the audit log entries of an account, newest first
+func (s *AuditService) ListForUser(ctx context.Context, userID primitive.ObjectID, cursor *domain.Cursor, limit int) (*domain.AuditLogListResponse, error) {
+	// Fetch one extra item to know whether another page exists
+	entries, err := s.auditRepo.ListByUser(ctx, userID, cursor, limit+1)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to list account activity")
+	}
+
+	response := &domain.AuditLogListResponse{
+		Entries: make([]*domain.AuditLogResponse, 0, len(entries)),
+		Limit:   limit,
+	}
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+		last := entries[len(entries)-1]
+		response.NextCursor = domain.NewCursor(last.CreatedAt, last.ID).Encode()
+	}
+
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, entry.ToResponse())
+	}
+
+	return response, nil
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"strconv"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// autoMilestoneBatchSize is how many matched users ExtendAll loads at a time
+const autoMilestoneBatchSize = 100
+
+// autoMilestone is a milestone falling on a day of a couple's relationship
+type autoMilestone struct {
+	kind  domain.AutoMilestoneKind
+	key   string
+	date  time.Time
+	value int // days, years or months, matching kind
+}
+
+// AutoMilestoneService implements domain.AutoMilestoneService
+type AutoMilestoneService struct {
+	eventRepo       domain.EventRepository
+	userRepo        domain.UserRepository
+	settingsRepo    domain.CoupleSettingsRepository
+	settingsService domain.CoupleSettingsService
+	i18n            *i18n.I18n
+}
+
+// NewAutoMilestoneService creates a new milestone event generation service
+func NewAutoMilestoneService(
+	eventRepo domain.EventRepository,
+	userRepo domain.UserRepository,
+	settingsRepo domain.CoupleSettingsRepository,
+	settingsService domain.CoupleSettingsService,
+	i18n *i18n.I18n,
+) domain.AutoMilestoneService {
+	return &AutoMilestoneService{
+		eventRepo:       eventRepo,
+		userRepo:        userRepo,
+		settingsRepo:    settingsRepo,
+		settingsService: settingsService,
+		i18n:            i18n,
+	}
+}
+
+// GenerateForCouple creates the couple's missing milestone events from today up to a
+// year ahead, for the kinds the couple has enabled. Titles are in the couple's language.
+func (s *AutoMilestoneService) GenerateForCouple(ctx context.Context, matchCode string, anniversary time.Time) error {
+	settings, err := s.settingsService.GetByMatchCode(ctx, matchCode)
+	if err != nil {
+		return err
+	}
+
+	locale := settings.FormattingHints(domain.DefaultLocale).Locale
+	today := truncateToDay(time.Now())
+	now := time.Now()
+
+	created := 0
+	for _, milestone := range upcomingMilestones(anniversary, today, today.AddDate(1, 0, 0)) {
+		if !settings.AutoMilestoneEnabled(milestone.kind) {
+			continue
+		}
+
+		event := &domain.Event{
+			ID:               primitive.NewObjectID(),
+			MatchCode:        matchCode,
+			Title:            s.milestoneTitle(locale, milestone),
+			Date:             milestone.date,
+			EventType:        milestoneEventType(milestone.kind),
+			AutoMilestone:    milestone.kind,
+			AutoMilestoneKey: milestone.key,
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		}
+
+		stored, err := s.eventRepo.CreateAutoMilestone(event)
+		if err != nil {
+			return fmt.Errorf("failed to create milestone event: %w", err)
+		}
+		if stored {
+			created++
+		}
+	}
+
+	if created > 0 {
+		logging.FromContext(ctx).Info("Milestone events generated",
+			zap.String("match_code", matchCode),
+			zap.Int("events", created))
+	}
+
+	return nil
+}
+
+// RegenerateForCouple replaces the couple's upcoming milestone events after their
+// anniversary date changed. A nil anniversary only removes them.
+func (s *AutoMilestoneService) RegenerateForCouple(ctx context.Context, matchCode string, anniversary *time.Time) error {
+	if _, err := s.eventRepo.DeleteAutoMilestones(matchCode, domain.AutoMilestoneKinds, truncateToDay(time.Now())); err != nil {
+		return err
+	}
+
+	if anniversary == nil {
+		return nil
+	}
+
+	return s.GenerateForCouple(ctx, matchCode, *anniversary)
+}
+
+// GetSettings lists which milestone events are generated for the user's couple
+func (s *AutoMilestoneService) GetSettings(ctx context.Context, userID primitive.ObjectID) (*domain.AutoMilestonesResponse, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.settingsService.GetByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return autoMilestonesResponse(settings), nil
+}
+
+// UpdateSettings enables or disables kinds of milestone events for the user's couple.
+// Upcoming events of disabled kinds are removed and those of enabled kinds generated.
+func (s *AutoMilestoneService) UpdateSettings(ctx context.Context, userID primitive.ObjectID, req *domain.UpdateAutoMilestonesRequest) (*domain.AutoMilestonesResponse, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.settingsService.GetByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[domain.AutoMilestoneKind]bool, len(domain.AutoMilestoneKinds))
+	for _, kind := range domain.AutoMilestoneKinds {
+		enabled[kind] = settings.AutoMilestoneEnabled(kind)
+	}
+
+	var turnedOff []domain.AutoMilestoneKind
+	turnedOn := false
+	for _, setting := range req.Milestones {
+		if enabled[setting.Kind] == setting.Enabled {
+			continue
+		}
+		enabled[setting.Kind] = setting.Enabled
+		if setting.Enabled {
+			turnedOn = true
+		} else {
+			turnedOff = append(turnedOff, setting.Kind)
+		}
+	}
+
+	settings.DisabledAutoMilestones = []domain.AutoMilestoneKind{}
+	for _, kind := range domain.AutoMilestoneKinds {
+		if !enabled[kind] {
+			settings.DisabledAutoMilestones = append(settings.DisabledAutoMilestones, kind)
+		}
+	}
+	settings.UpdatedBy = userID
+
+	if err := s.settingsRepo.Upsert(ctx, settings); err != nil {
+		logging.FromContext(ctx).Error("Failed to update milestone settings", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update milestone settings")
+	}
+
+	if len(turnedOff) > 0 {
+		if _, err := s.eventRepo.DeleteAutoMilestones(user.MatchCode, turnedOff, truncateToDay(time.Now())); err != nil {
+			logging.FromContext(ctx).Error("Failed to remove disabled milestone events", zap.Error(err))
+			return nil, domain.ErrOperationFailedError("Failed to update milestone settings")
+		}
+	}
+	if turnedOn && user.AnniversaryDate != nil {
+		if err := s.GenerateForCouple(ctx, user.MatchCode, *user.AnniversaryDate); err != nil {
+			logging.FromContext(ctx).Error("Failed to generate milestone events", zap.Error(err))
+			return nil, domain.ErrOperationFailedError("Failed to update milestone settings")
+		}
+	}
+
+	logging.FromContext(ctx).Info("Milestone settings updated",
+		zap.String("match_code", user.MatchCode),
+		zap.String("user_id", userID.Hex()))
+
+	return autoMilestonesResponse(settings), nil
+}
+
+// ExtendAll keeps every couple's milestone events generated a year ahead. A couple
+// that fails is logged and retried on the next run.
+func (s *AutoMilestoneService) ExtendAll(ctx context.Context) error {
+	seen := make(map[string]bool)
+	afterID := primitive.NilObjectID
+
+	for {
+		users, err := s.userRepo.ListMatched(ctx, afterID, autoMilestoneBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, user := range users {
+			afterID = user.ID
+			if seen[user.MatchCode] || user.AnniversaryDate == nil {
+				continue
+			}
+			seen[user.MatchCode] = true
+
+			if err := s.GenerateForCouple(ctx, user.MatchCode, *user.AnniversaryDate); err != nil {
+				logging.FromContext(ctx).Warn("Failed to extend milestone events",
+					zap.Error(err),
+					zap.String("match_code", user.MatchCode))
+			}
+		}
+
+		if len(users) < autoMilestoneBatchSize {
+			return nil
+		}
+	}
+}
+
+// milestoneTitle returns the localized title of a milestone event
+func (s *AutoMilestoneService) milestoneTitle(locale string, milestone autoMilestone) string {
+	switch milestone.kind {
+	case domain.AutoMilestoneYearly:
+		return s.i18n.TranslatePlural(locale, "timeline_anniversary", milestone.value, map[string]interface{}{"Years": milestone.value})
+	case domain.AutoMilestoneMonthly:
+		return s.i18n.TranslatePlural(locale, "auto_milestone_months", milestone.value, map[string]interface{}{"Months": milestone.value})
+	default:
+		return s.i18n.TranslatePlural(locale, "timeline_days", milestone.value, map[string]interface{}{"Days": milestone.value})
+	}
+}
+
+// getMatchedUser returns a user who is matched with a partner
+func (s *AutoMilestoneService) getMatchedUser(ctx context.Context, userID primitive.ObjectID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	return user, nil
+}
+
+// autoMilestonesResponse lists every kind of milestone event with whether the couple
+// has it enabled
+func autoMilestonesResponse(settings *domain.CoupleSettings) *domain.AutoMilestonesResponse {
+	response := &domain.AutoMilestonesResponse{
+		Milestones: make([]*domain.AutoMilestoneSetting, len(domain.AutoMilestoneKinds)),
+	}
+	for i, kind := range domain.AutoMilestoneKinds {
+		response.Milestones[i] = &domain.AutoMilestoneSetting{
+			Kind:    kind,
+			Enabled: settings.AutoMilestoneEnabled(kind),
+		}
+	}
+	return response
+}
+
+// milestoneEventType returns the event type of generated milestone events of kind
+func milestoneEventType(kind domain.AutoMilestoneKind) string {
+	if kind == domain.AutoMilestoneDays {
+		return "milestone"
+	}
+	return "anniversary"
+}
+
+// upcomingMilestones returns the milestones of a relationship that began on
+// anniversary falling within [from, to). Every twelfth monthly anniversary is a
+// yearly one instead.
+func upcomingMilestones(anniversary, from, to time.Time) []autoMilestone {
+	start := truncateToDay(anniversary)
+
+	var milestones []autoMilestone
+	inRange := func(date time.Time) bool {
+		return !date.Before(from) && date.Before(to)
+	}
+
+	for _, days := range dayMilestones {
+		date := start.AddDate(0, 0, days)
+		if inRange(date) {
+			milestones = append(milestones, autoMilestone{
+				kind:  domain.AutoMilestoneDays,
+				key:   "days-" + strconv.Itoa(days),
+				date:  date,
+				value: days,
+			})
+		}
+	}
+
+	for months := 1; ; months++ {
+		date := addMonthsClamped(start, months)
+		if !date.Before(to) {
+			break
+		}
+		if !inRange(date) {
+			continue
+		}
+
+		if months%12 == 0 {
+			milestones = append(milestones, autoMilestone{
+				kind:  domain.AutoMilestoneYearly,
+				key:   "years-" + strconv.Itoa(months/12),
+				date:  date,
+				value: months / 12,
+			})
+		} else {
+			milestones = append(milestones, autoMilestone{
+				kind:  domain.AutoMilestoneMonthly,
+				key:   "months-" + strconv.Itoa(months),
+				date:  date,
+				value: months,
+			})
+		}
+	}
+
+	return milestones
+}
+
+// addMonthsClamped adds months to a day, keeping to the last day of shorter months
+// instead of overflowing into the next one
+func addMonthsClamped(day time.Time, months int) time.Time {
+	first := time.Date(day.Year(), day.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	dayOfMonth := day.Day()
+	if dayOfMonth > lastDay {
+		dayOfMonth = lastDay
+	}
+
+	return time.Date(first.Year(), first.Month(), dayOfMonth, 0, 0, 0, 0, time.UTC)
+}
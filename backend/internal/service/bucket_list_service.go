@@ -0,0 +1,384 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// BucketListService implements domain.BucketListService
+type BucketListService struct {
+	bucketListRepo domain.BucketListRepository
+	photoRepo      domain.PhotoRepository
+	eventRepo      domain.EventRepository
+	userRepo       domain.UserRepository
+}
+
+// NewBucketListService creates a new bucket list service
+func NewBucketListService(
+	bucketListRepo domain.BucketListRepository,
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	userRepo domain.UserRepository,
+) domain.BucketListService {
+	return &BucketListService{
+		bucketListRepo: bucketListRepo,
+		photoRepo:      photoRepo,
+		eventRepo:      eventRepo,
+		userRepo:       userRepo,
+	}
+}
+
+// CreateItem adds an item to the couple's bucket list
+func (s *BucketListService) CreateItem(ctx context.Context, userID primitive.ObjectID, req *domain.CreateBucketListItemRequest) (*domain.BucketListItemResponse, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	photoIDs, err := s.couplePhotoIDs(ctx, user.MatchCode, userID, req.PhotoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &domain.BucketListItem{
+		MatchCode:   user.MatchCode,
+		CreatedBy:   userID,
+		Title:       req.Title,
+		Description: req.Description,
+		Category:    req.Category,
+		TargetDate:  req.TargetDate.ToTimePtr(),
+		PhotoIDs:    photoIDs,
+	}
+
+	if err := s.bucketListRepo.Create(ctx, item); err != nil {
+		logging.FromContext(ctx).Error("Failed to create bucket list item", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create bucket list item")
+	}
+
+	logging.FromContext(ctx).Info("Bucket list item created",
+		zap.String("item_id", item.ID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return item.ToResponse(), nil
+}
+
+// GetItem retrieves a bucket list item by ID
+func (s *BucketListService) GetItem(ctx context.Context, itemID, userID primitive.ObjectID) (*domain.BucketListItemResponse, error) {
+	item, err := s.getAuthorizedItem(ctx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return item.ToResponse(), nil
+}
+
+// GetItems retrieves the couple's bucket list with pagination, open items first
+func (s *BucketListService) GetItems(ctx context.Context, userID primitive.ObjectID, status domain.BucketListStatus, category domain.BucketListCategory, page, limit int) (*domain.BucketListResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	response := &domain.BucketListResponse{
+		Items: []*domain.BucketListItemResponse{},
+		Page:  page,
+		Limit: limit,
+	}
+
+	if user.MatchCode == "" {
+		return response, nil
+	}
+
+	offset := (page - 1) * limit
+
+	items, err := s.bucketListRepo.GetByMatchCode(ctx, user.MatchCode, status, category, limit, offset)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get bucket list items", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get bucket list")
+	}
+
+	response.Total, err = s.bucketListRepo.CountByMatchCode(ctx, user.MatchCode, status, category)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count bucket list items", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get bucket list")
+	}
+
+	for _, item := range items {
+		response.Items = append(response.Items, item.ToResponse())
+	}
+
+	return response, nil
+}
+
+// UpdateItem updates a bucket list item; either partner may update it
+func (s *BucketListService) UpdateItem(ctx context.Context, itemID, userID primitive.ObjectID, req *domain.UpdateBucketListItemRequest) (*domain.BucketListItemResponse, error) {
+	item, err := s.getAuthorizedItem(ctx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != "" {
+		item.Title = req.Title
+	}
+	if req.Description != "" {
+		item.Description = req.Description
+	}
+	if req.Category != "" {
+		item.Category = req.Category
+	}
+	if req.TargetDate != nil {
+		item.TargetDate = req.TargetDate.ToTimePtr()
+	}
+	if req.PhotoIDs != nil {
+		if item.PhotoIDs, err = s.couplePhotoIDs(ctx, item.MatchCode, userID, req.PhotoIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.bucketListRepo.Update(ctx, item); err != nil {
+		logging.FromContext(ctx).Error("Failed to update bucket list item", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update bucket list item")
+	}
+
+	return item.ToResponse(), nil
+}
+
+// DeleteItem deletes a bucket list item. The event recorded on completion is kept.
+func (s *BucketListService) DeleteItem(ctx context.Context, itemID, userID primitive.ObjectID) error {
+	if _, err := s.getAuthorizedItem(ctx, itemID, userID); err != nil {
+		return err
+	}
+
+	if err := s.bucketListRepo.Delete(ctx, itemID); err != nil {
+		logging.FromContext(ctx).Error("Failed to delete bucket list item", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to delete bucket list item")
+	}
+
+	return nil
+}
+
+// CompleteItem ticks off a bucket list item. The item is linked to the given event,
+// or to a new milestone event on the completion date.
+func (s *BucketListService) CompleteItem(ctx context.Context, itemID, userID primitive.ObjectID, req *domain.CompleteBucketListItemRequest) (*domain.BucketListItemResponse, error) {
+	item, err := s.getAuthorizedItem(ctx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.IsCompleted() {
+		return nil, domain.ErrInvalidRequestError("Bucket list item is already completed")
+	}
+
+	now := time.Now()
+	completedOn := domain.NewDate(now).Time
+	if req.Date != nil && !req.Date.IsZero() {
+		if req.Date.Time.After(now) {
+			return nil, domain.ErrInvalidRequestError("Completion date cannot be in the future")
+		}
+		completedOn = req.Date.Time
+	}
+
+	if req.EventID != "" {
+		eventID, err := primitive.ObjectIDFromHex(req.EventID)
+		if err != nil {
+			return nil, domain.ErrInvalidRequestError("Invalid event ID")
+		}
+		event, err := s.eventRepo.GetByID(eventID)
+		if err != nil || event.MatchCode != item.MatchCode || !event.IsVisibleTo(userID) {
+			return nil, domain.ErrNotFoundError("Event")
+		}
+		item.EventID = &event.ID
+		item.EventIsOwn = false
+	} else {
+		event := &domain.Event{
+			ID:          primitive.NewObjectID(),
+			MatchCode:   item.MatchCode,
+			CreatedBy:   userID,
+			Title:       item.Title,
+			Description: item.Description,
+			Date:        completedOn,
+			EventType:   "milestone",
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := s.eventRepo.Create(event); err != nil {
+			logging.FromContext(ctx).Error("Failed to create bucket list event", zap.Error(err))
+			return nil, domain.ErrOperationFailedError("Failed to complete bucket list item")
+		}
+		item.EventID = &event.ID
+		item.EventIsOwn = true
+	}
+
+	item.CompletedAt = &completedOn
+	item.CompletedBy = &userID
+
+	if err := s.bucketListRepo.Update(ctx, item); err != nil {
+		logging.FromContext(ctx).Error("Failed to complete bucket list item", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to complete bucket list item")
+	}
+
+	logging.FromContext(ctx).Info("Bucket list item completed",
+		zap.String("item_id", itemID.Hex()),
+		zap.String("user_id", userID.Hex()),
+		zap.String("event_id", item.EventID.Hex()))
+
+	return item.ToResponse(), nil
+}
+
+// ReopenItem puts a completed bucket list item back on the list. The milestone event
+// created on completion is deleted; a linked existing event is kept.
+func (s *BucketListService) ReopenItem(ctx context.Context, itemID, userID primitive.ObjectID) (*domain.BucketListItemResponse, error) {
+	item, err := s.getAuthorizedItem(ctx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !item.IsCompleted() {
+		return nil, domain.ErrInvalidRequestError("Bucket list item is not completed")
+	}
+
+	if item.EventID != nil && item.EventIsOwn {
+		if err := s.eventRepo.Delete(*item.EventID); err != nil {
+			// The event may already have been deleted by hand
+			logging.FromContext(ctx).Warn("Failed to delete bucket list event",
+				zap.Error(err),
+				zap.String("event_id", item.EventID.Hex()))
+		}
+	}
+
+	item.CompletedAt = nil
+	item.CompletedBy = nil
+	item.EventID = nil
+	item.EventIsOwn = false
+
+	if err := s.bucketListRepo.Update(ctx, item); err != nil {
+		logging.FromContext(ctx).Error("Failed to reopen bucket list item", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to reopen bucket list item")
+	}
+
+	logging.FromContext(ctx).Info("Bucket list item reopened",
+		zap.String("item_id", itemID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return item.ToResponse(), nil
+}
+
+// GetStats summarizes the couple's progress through their bucket list
+func (s *BucketListService) GetStats(ctx context.Context, userID primitive.ObjectID) (*domain.BucketListStatsResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	stats := &domain.BucketListStatsResponse{
+		ByCategory: []*domain.BucketListCategoryStats{},
+	}
+
+	if user.MatchCode == "" {
+		return stats, nil
+	}
+
+	items, err := s.bucketListRepo.GetByMatchCode(ctx, user.MatchCode, "", "", 0, 0)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get bucket list items", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get bucket list stats")
+	}
+
+	now := time.Now()
+	byCategory := make(map[domain.BucketListCategory]*domain.BucketListCategoryStats)
+	for _, item := range items {
+		categoryStats, ok := byCategory[item.Category]
+		if !ok {
+			categoryStats = &domain.BucketListCategoryStats{Category: item.Category}
+			byCategory[item.Category] = categoryStats
+		}
+		categoryStats.Total++
+
+		if item.IsCompleted() {
+			stats.Completed++
+			categoryStats.Completed++
+			if item.CompletedAt.Year() == now.Year() {
+				stats.CompletedThisYear++
+			}
+		} else if item.TargetDate != nil && item.TargetDate.Before(now) {
+			stats.Overdue++
+		}
+	}
+
+	stats.Total = len(items)
+	stats.Open = stats.Total - stats.Completed
+	if stats.Total > 0 {
+		stats.CompletionPercent = stats.Completed * 100 / stats.Total
+	}
+	for _, category := range domain.BucketListCategories {
+		if categoryStats, ok := byCategory[category]; ok {
+			stats.ByCategory = append(stats.ByCategory, categoryStats)
+		}
+	}
+
+	return stats, nil
+}
+
+// couplePhotoIDs parses the photo IDs to attach to an item and checks that every
+// photo belongs to the couple and is visible to the user
+func (s *BucketListService) couplePhotoIDs(ctx context.Context, matchCode string, userID primitive.ObjectID, hexIDs []string) ([]primitive.ObjectID, error) {
+	ids, err := parseObjectIDs(hexIDs)
+	if err != nil {
+		return nil, domain.ErrInvalidRequestError("Invalid photo ID")
+	}
+
+	seen := make(map[primitive.ObjectID]bool, len(ids))
+	photoIDs := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		photo, err := s.photoRepo.GetByID(ctx, id)
+		if err != nil || photo.MatchCode != matchCode || !photo.IsVisibleTo(userID) {
+			return nil, domain.ErrNotFoundError("Photo")
+		}
+		photoIDs = append(photoIDs, id)
+	}
+
+	if len(photoIDs) > domain.MaxBucketListPhotos {
+		return nil, domain.ErrInvalidRequestError("Too many photos attached")
+	}
+
+	return photoIDs, nil
+}
+
+// getMatchedUser retrieves a user who has a partner
+func (s *BucketListService) getMatchedUser(ctx context.Context, userID primitive.ObjectID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	return user, nil
+}
+
+// getAuthorizedItem retrieves one of the user's couple bucket list items
+func (s *BucketListService) getAuthorizedItem(ctx context.Context, itemID, userID primitive.ObjectID) (*domain.BucketListItem, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.bucketListRepo.GetByID(ctx, user.MatchCode, itemID)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Bucket list item")
+	}
+
+	return item, nil
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/ical"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	calendarProductID = "-//EraLove//Events//EN"
+	calendarName      = "EraLove"
+	// maxCalendarEvents caps the number of events rendered into one calendar
+	maxCalendarEvents = 1000
+	// timedEventDuration is the length given to events that have a time of day
+	timedEventDuration = time.Hour
+)
+
+// rruleFrequencies lists the FREQ values of an iCalendar recurrence rule
+var rruleFrequencies = map[string]bool{
+	"SECONDLY": true, "MINUTELY": true, "HOURLY": true,
+	"DAILY": true, "WEEKLY": true, "MONTHLY": true, "YEARLY": true,
+}
+
+// rruleParts lists the parts allowed in a recurrence rule
+var rruleParts = map[string]bool{
+	"FREQ": true, "UNTIL": true, "COUNT": true, "INTERVAL": true,
+	"BYSECOND": true, "BYMINUTE": true, "BYHOUR": true, "BYDAY": true,
+	"BYMONTHDAY": true, "BYYEARDAY": true, "BYWEEKNO": true, "BYMONTH": true,
+	"BYSETPOS": true, "WKST": true,
+}
+
+// rruleValuePattern matches the characters a recurrence rule part value may contain
+var rruleValuePattern = regexp.MustCompile(`^[A-Z0-9,+\-]+$`)
+
+// CalendarService implements domain.CalendarService
+type CalendarService struct {
+	eventRepo domain.EventRepository
+	userRepo  domain.UserRepository
+	feedRepo  domain.CalendarFeedRepository
+}
+
+// NewCalendarService creates a new calendar service
+func NewCalendarService(
+	eventRepo domain.EventRepository,
+	userRepo domain.UserRepository,
+	feedRepo domain.CalendarFeedRepository,
+) domain.CalendarService {
+	return &CalendarService{
+		eventRepo: eventRepo,
+		userRepo:  userRepo,
+		feedRepo:  feedRepo,
+	}
+}
+
+// ExportEvents renders the couple's events the user can see as an iCalendar file
+func (s *CalendarService) ExportEvents(ctx context.Context, userID primitive.ObjectID) ([]byte, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	return s.renderCouple(ctx, user.MatchCode, user.ID)
+}
+
+// GetFeed retrieves the user's calendar subscription
+func (s *CalendarService) GetFeed(ctx context.Context, userID primitive.ObjectID) (*domain.CalendarFeed, error) {
+	feed, err := s.feedRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Calendar feed")
+	}
+	return feed, nil
+}
+
+// RotateFeed creates the user's calendar subscription, or gives it a new token so
+// that the previous URL stops working
+func (s *CalendarService) RotateFeed(ctx context.Context, userID primitive.ObjectID) (*domain.CalendarFeed, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to generate calendar feed token", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create calendar feed")
+	}
+
+	feed := &domain.CalendarFeed{
+		UserID: userID,
+		Token:  token,
+	}
+	if err := s.feedRepo.Replace(ctx, feed); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to create calendar feed")
+	}
+
+	logging.FromContext(ctx).Info("Calendar feed token issued", zap.String("user_id", userID.Hex()))
+
+	return feed, nil
+}
+
+// RevokeFeed deletes the user's calendar subscription
+func (s *CalendarService) RevokeFeed(ctx context.Context, userID primitive.ObjectID) error {
+	if err := s.feedRepo.DeleteByUserID(ctx, userID); err != nil {
+		return domain.ErrNotFoundError("Calendar feed")
+	}
+
+	logging.FromContext(ctx).Info("Calendar feed revoked", zap.String("user_id", userID.Hex()))
+	return nil
+}
+
+// RenderFeed renders the calendar of a subscription token. Events are read at
+// request time, so a user who is no longer matched gets an empty calendar.
+func (s *CalendarService) RenderFeed(ctx context.Context, token string) ([]byte, error) {
+	feed, err := s.feedRepo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Calendar feed")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, feed.UserID)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Calendar feed")
+	}
+
+	if err := s.feedRepo.TouchAccessed(ctx, feed.ID, time.Now()); err != nil {
+		logging.FromContext(ctx).Warn("Failed to record calendar feed access", zap.Error(err))
+	}
+
+	if user.MatchCode == "" {
+		return (&ical.Calendar{ProductID: calendarProductID, Name: calendarName}).Encode(), nil
+	}
+
+	return s.renderCouple(ctx, user.MatchCode, user.ID)
+}
+
+// renderCouple renders the events of a couple the viewer can see as an iCalendar stream
+func (s *CalendarService) renderCouple(ctx context.Context, matchCode string, viewerID primitive.ObjectID) ([]byte, error) {
+	events, err := s.eventRepo.GetByMatchCode(matchCode, viewerID, maxCalendarEvents, 0)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to export events")
+	}
+
+	calendar := &ical.Calendar{
+		ProductID: calendarProductID,
+		Name:      calendarName,
+		Events:    make([]*ical.Event, 0, len(events)),
+	}
+	for _, event := range events {
+		calendar.Events = append(calendar.Events, s.toCalendarEvent(ctx, event))
+	}
+
+	return calendar.Encode(), nil
+}
+
+// toCalendarEvent converts an event into an iCalendar event. Events without a time
+// of day become all-day events; the others start at that time in their time zone, or
+// in the viewer's zone when they have none.
+func (s *CalendarService) toCalendarEvent(ctx context.Context, event *domain.Event) *ical.Event {
+	start := domain.DateFromTime(event.Date).Time
+	allDay := true
+	zoned := false
+	if offset, ok := domain.ParseTimeOfDay(event.Time); ok {
+		start = start.Add(offset)
+		allDay = false
+		if event.Timezone != "" {
+			start = event.StartsAt()
+			zoned = true
+		}
+	}
+
+	calendarEvent := &ical.Event{
+		UID:         event.ID.Hex() + "@eralove",
+		Summary:     event.Title,
+		Description: event.Description,
+		Location:    event.Location,
+		Start:       start,
+		AllDay:      allDay,
+		UTC:         zoned,
+		Private:     event.IsPrivate,
+		Created:     event.CreatedAt,
+		Modified:    event.UpdatedAt,
+	}
+	if event.EventType != "" {
+		calendarEvent.Categories = []string{event.EventType}
+	}
+	if !allDay {
+		calendarEvent.Duration = timedEventDuration
+	}
+
+	if event.IsRecurring {
+		rule, ok := recurrenceRule(event.RecurrenceRule)
+		if ok {
+			calendarEvent.RRule = rule
+		} else {
+			logging.FromContext(ctx).Warn("Skipping invalid recurrence rule",
+				zap.String("event_id", event.ID.Hex()),
+				zap.String("rule", event.RecurrenceRule))
+		}
+	}
+
+	// The reminder time is stored as an instant, so the alarm keeps the distance
+	// between it and the instant the event starts in its time zone
+	if reminder := event.Reminder; reminder != nil && reminder.Enabled && !reminder.ReminderAt.IsZero() {
+		if before := event.StartsAt().Sub(reminder.ReminderAt); before >= 0 {
+			description := reminder.Message
+			if description == "" {
+				description = event.Title
+			}
+			calendarEvent.Alarm = &ical.Alarm{
+				Before:      before.Truncate(time.Minute),
+				Description: description,
+			}
+		}
+	}
+
+	return calendarEvent
+}
+
+// recurrenceRule turns the stored recurrence rule of a recurring event into an
+// iCalendar RRULE value. Rules may be full RRULEs ("FREQ=MONTHLY;BYMONTHDAY=14"),
+// optionally prefixed with "RRULE:", or just a frequency ("yearly"). Recurring
+// events without a rule repeat every year, like the anniversaries they mostly are.
+func recurrenceRule(rule string) (string, bool) {
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	if rule == "" {
+		return "FREQ=YEARLY", true
+	}
+	if rruleFrequencies[rule] {
+		return "FREQ=" + rule, true
+	}
+
+	hasFrequency := false
+	for _, part := range strings.Split(rule, ";") {
+		name, value, found := strings.Cut(part, "=")
+		if !found || !rruleParts[name] || !rruleValuePattern.MatchString(value) {
+			return "", false
+		}
+		if name == "FREQ" {
+			if !rruleFrequencies[value] {
+				return "", false
+			}
+			hasFrequency = true
+		}
+	}
+
+	return rule, hasFrequency
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// ChangelogService implements domain.ChangelogService
+type ChangelogService struct {
+	source   domain.ReleaseSource
+	seenRepo domain.ChangelogSeenRepository
+	cacheTTL time.Duration
+
+	mu        sync.Mutex
+	releases  []*domain.Release
+	fetchedAt time.Time
+}
+
+// NewChangelogService creates a new changelog service. Releases are read from source
+// at most once per cacheTTL; a nil source means there are no release notes.
+func NewChangelogService(
+	source domain.ReleaseSource,
+	seenRepo domain.ChangelogSeenRepository,
+	cacheTTL time.Duration,
+) domain.ChangelogService {
+	return &ChangelogService{
+		source:   source,
+		seenRepo: seenRepo,
+		cacheTTL: cacheTTL,
+	}
+}
+
+// GetChangelog retrieves the releases newer than sinceVersion, newest first, marking
+// those the user has already been shown. An empty sinceVersion returns every release.
+func (s *ChangelogService) GetChangelog(ctx context.Context, userID primitive.ObjectID, sinceVersion string) (*domain.ChangelogResponse, error) {
+	sinceVersion = strings.TrimSpace(sinceVersion)
+	if sinceVersion != "" {
+		if !domain.ValidVersion(sinceVersion) {
+			return nil, domain.ErrInvalidRequestError("since_version must be a version such as 1.4.0")
+		}
+	}
+
+	releases, err := s.listReleases(ctx)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to load changelog")
+	}
+
+	seen := map[string]bool{}
+	if record, err := s.seenRepo.GetByUserID(ctx, userID); err == nil {
+		for _, version := range record.SeenVersions {
+			seen[version] = true
+		}
+	}
+
+	response := &domain.ChangelogResponse{
+		Releases: make([]*domain.ReleaseResponse, 0, len(releases)),
+	}
+	if len(releases) > 0 {
+		response.LatestVersion = releases[0].Version
+	}
+
+	for _, release := range releases {
+		if sinceVersion != "" {
+			if cmp, _ := domain.CompareVersions(release.Version, sinceVersion); cmp <= 0 {
+				break
+			}
+		}
+
+		item := &domain.ReleaseResponse{
+			Release: release,
+			Seen:    seen[release.Version],
+		}
+		if !item.Seen {
+			response.UnseenCount++
+		}
+		response.Releases = append(response.Releases, item)
+	}
+
+	return response, nil
+}
+
+// MarkSeen records that the user has been shown the given releases
+func (s *ChangelogService) MarkSeen(ctx context.Context, userID primitive.ObjectID, req *domain.MarkChangelogSeenRequest) error {
+	releases, err := s.listReleases(ctx)
+	if err != nil {
+		return domain.ErrOperationFailedError("Failed to load changelog")
+	}
+
+	known := make(map[string]bool, len(releases))
+	for _, release := range releases {
+		known[release.Version] = true
+	}
+
+	versions := make([]string, 0, len(req.Versions))
+	for _, version := range req.Versions {
+		version = strings.TrimSpace(version)
+		if !known[version] {
+			return domain.ErrInvalidRequestError("Unknown release version: " + version)
+		}
+		versions = append(versions, version)
+	}
+
+	if err := s.seenRepo.AddSeenVersions(ctx, userID, versions); err != nil {
+		return domain.ErrOperationFailedError("Failed to mark changelog as seen")
+	}
+
+	return nil
+}
+
+// listReleases returns the published releases newest first, reading them from the
+// source when the cached copy has expired. A stale copy is served if the source fails.
+func (s *ChangelogService) listReleases(ctx context.Context) ([]*domain.Release, error) {
+	if s.source == nil {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.releases != nil && time.Since(s.fetchedAt) < s.cacheTTL {
+		return s.releases, nil
+	}
+
+	fetched, err := s.source.ListReleases(ctx)
+	if err != nil {
+		if s.releases != nil {
+			logging.FromContext(ctx).Warn("Failed to refresh changelog, serving cached releases", zap.Error(err))
+			return s.releases, nil
+		}
+		logging.FromContext(ctx).Error("Failed to load changelog", zap.Error(err))
+		return nil, err
+	}
+
+	releases := make([]*domain.Release, 0, len(fetched))
+	for _, release := range fetched {
+		if !domain.ValidVersion(release.Version) {
+			logging.FromContext(ctx).Warn("Skipping release with invalid version", zap.String("version", release.Version))
+			continue
+		}
+		releases = append(releases, release)
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		cmp, _ := domain.CompareVersions(releases[i].Version, releases[j].Version)
+		return cmp > 0
+	})
+
+	s.releases = releases
+	s.fetchedAt = time.Now()
+	return releases, nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+// maxClientClockSkew is how far in the future a reported occurred_at may be before
+// it is replaced by the time the report was received
+const maxClientClockSkew = 5 * time.Minute
+
+// ClientErrorService implements domain.ClientErrorService
+type ClientErrorService struct {
+	clientErrorRepo domain.ClientErrorRepository
+	traceRepo       domain.RequestTraceRepository
+	sampleRate      float64
+}
+
+// NewClientErrorService creates a new client error service. sampleRate is the share
+// of non-fatal reports that is stored; fatal reports are always stored.
+func NewClientErrorService(
+	clientErrorRepo domain.ClientErrorRepository,
+	traceRepo domain.RequestTraceRepository,
+	sampleRate float64,
+) domain.ClientErrorService {
+	return &ClientErrorService{
+		clientErrorRepo: clientErrorRepo,
+		traceRepo:       traceRepo,
+		sampleRate:      sampleRate,
+	}
+}
+
+// ReportError stores an error reported by a client unless it is sampled out
+func (s *ClientErrorService) ReportError(ctx context.Context, source domain.ClientErrorSource, req *domain.ReportClientErrorRequest) (*domain.ClientErrorReceipt, error) {
+	if !req.Fatal && rand.Float64() >= s.sampleRate {
+		return &domain.ClientErrorReceipt{Accepted: false}, nil
+	}
+
+	now := time.Now()
+	occurredAt := now
+	if req.OccurredAt != nil && !req.OccurredAt.IsZero() && req.OccurredAt.Before(now.Add(maxClientClockSkew)) {
+		occurredAt = *req.OccurredAt
+	}
+
+	clientError := &domain.ClientError{
+		Platform:   req.Platform,
+		AppVersion: strings.TrimSpace(req.AppVersion),
+		OSVersion:  strings.TrimSpace(req.OSVersion),
+		Device:     strings.TrimSpace(req.Device),
+		ErrorType:  strings.TrimSpace(req.ErrorType),
+		Message:    req.Message,
+		Stack:      req.Stack,
+		Screen:     strings.TrimSpace(req.Screen),
+		TraceID:    strings.TrimSpace(req.TraceID),
+		Fatal:      req.Fatal,
+		Context:    req.Context,
+		IP:         source.IP,
+		UserAgent:  source.UserAgent,
+		OccurredAt: occurredAt,
+	}
+	if !source.UserID.IsZero() {
+		userID := source.UserID
+		clientError.UserID = &userID
+	}
+
+	if err := s.clientErrorRepo.Create(ctx, clientError); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to report error")
+	}
+
+	return &domain.ClientErrorReceipt{
+		Accepted: true,
+		ID:       clientError.ID.Hex(),
+	}, nil
+}
+
+// ListClientErrors retrieves client errors for support, newest first, each with the
+// failed API request its trace ID points to when that request is still recorded
+func (s *ClientErrorService) ListClientErrors(ctx context.Context, filter domain.ClientErrorFilter, cursor *domain.Cursor, limit int) (*domain.ClientErrorListResponse, error) {
+	// Fetch one extra item to know whether another page exists
+	clientErrors, err := s.clientErrorRepo.List(ctx, filter, cursor, limit+1)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to list client errors")
+	}
+
+	response := &domain.ClientErrorListResponse{
+		Errors: make([]*domain.ClientErrorResponse, 0, len(clientErrors)),
+		Limit:  limit,
+	}
+
+	if len(clientErrors) > limit {
+		clientErrors = clientErrors[:limit]
+		last := clientErrors[len(clientErrors)-1]
+		response.NextCursor = domain.NewCursor(last.CreatedAt, last.ID).Encode()
+	}
+
+	var traceIDs []string
+	for _, clientError := range clientErrors {
+		if clientError.TraceID != "" {
+			traceIDs = append(traceIDs, clientError.TraceID)
+		}
+	}
+
+	traces, err := s.traceRepo.GetByTraceIDs(ctx, traceIDs)
+	if err != nil {
+		// The errors are still useful without their requests
+		logging.FromContext(ctx).Warn("Failed to correlate client errors with request traces", zap.Error(err))
+		traces = nil
+	}
+
+	for _, clientError := range clientErrors {
+		response.Errors = append(response.Errors, &domain.ClientErrorResponse{
+			ClientError: clientError,
+			Request:     traces[clientError.TraceID],
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// countdownWidgetEvents is how many upcoming events the countdown widget shows
+const countdownWidgetEvents = 5
+
+// CountdownService implements domain.CountdownService
+type CountdownService struct {
+	countdownRepo domain.CountdownRepository
+	eventRepo     domain.EventRepository
+	userRepo      domain.UserRepository
+}
+
+// NewCountdownService creates a new countdown service
+func NewCountdownService(
+	countdownRepo domain.CountdownRepository,
+	eventRepo domain.EventRepository,
+	userRepo domain.UserRepository,
+) domain.CountdownService {
+	return &CountdownService{
+		countdownRepo: countdownRepo,
+		eventRepo:     eventRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// GetCountdowns gathers the couple's next anniversary, upcoming events with a reminder
+// and custom countdowns that have not passed yet, counted in days from today
+func (s *CountdownService) GetCountdowns(ctx context.Context, userID primitive.ObjectID) (*domain.CountdownsResponse, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Days are counted from the day it is for the user
+	today := domain.Today(user.Location())
+	response := &domain.CountdownsResponse{
+		Today:      domain.NewDate(today),
+		Events:     []*domain.EventCountdown{},
+		Countdowns: []*domain.CountdownResponse{},
+	}
+
+	if user.AnniversaryDate != nil {
+		response.Anniversary = nextAnniversary(*user.AnniversaryDate, today)
+	}
+
+	events, err := s.eventRepo.GetUpcomingWithReminders(user.MatchCode, user.ID, today, countdownWidgetEvents)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get upcoming events", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get countdowns")
+	}
+	for _, event := range events {
+		countdown := &domain.EventCountdown{
+			ID:        event.ID.Hex(),
+			Title:     event.Title,
+			EventType: event.EventType,
+			Date:      domain.NewDate(event.Date),
+			Time:      event.Time,
+			DaysUntil: domain.DaysBetween(today, event.Date),
+		}
+		if !event.Reminder.ReminderAt.IsZero() {
+			countdown.ReminderAt = &event.Reminder.ReminderAt
+		}
+		response.Events = append(response.Events, countdown)
+	}
+
+	countdowns, err := s.countdownRepo.GetUpcoming(ctx, user.MatchCode, today)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get countdowns", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get countdowns")
+	}
+	for _, countdown := range countdowns {
+		response.Countdowns = append(response.Countdowns, countdown.ToResponse(today))
+	}
+
+	return response, nil
+}
+
+// CreateCountdown adds a custom countdown for the couple
+func (s *CountdownService) CreateCountdown(ctx context.Context, userID primitive.ObjectID, req *domain.CreateCountdownRequest) (*domain.CountdownResponse, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.countdownRepo.CountByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count countdowns", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create countdown")
+	}
+	if count >= domain.MaxCountdowns {
+		return nil, domain.ErrInvalidRequestError("Too many countdowns, delete some first")
+	}
+
+	countdown := &domain.Countdown{
+		MatchCode:  user.MatchCode,
+		CreatedBy:  userID,
+		Title:      req.Title,
+		Emoji:      req.Emoji,
+		TargetDate: req.TargetDate.Time,
+	}
+
+	if err := s.countdownRepo.Create(ctx, countdown); err != nil {
+		logging.FromContext(ctx).Error("Failed to create countdown", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create countdown")
+	}
+
+	logging.FromContext(ctx).Info("Countdown created",
+		zap.String("countdown_id", countdown.ID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return countdown.ToResponse(domain.Today(user.Location())), nil
+}
+
+// UpdateCountdown updates a custom countdown; either partner may update it
+func (s *CountdownService) UpdateCountdown(ctx context.Context, countdownID, userID primitive.ObjectID, req *domain.UpdateCountdownRequest) (*domain.CountdownResponse, error) {
+	user, countdown, err := s.getAuthorizedCountdown(ctx, countdownID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != "" {
+		countdown.Title = req.Title
+	}
+	if req.Emoji != "" {
+		countdown.Emoji = req.Emoji
+	}
+	if req.TargetDate != nil {
+		countdown.TargetDate = req.TargetDate.Time
+	}
+
+	if err := s.countdownRepo.Update(ctx, countdown); err != nil {
+		logging.FromContext(ctx).Error("Failed to update countdown", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update countdown")
+	}
+
+	return countdown.ToResponse(domain.Today(user.Location())), nil
+}
+
+// DeleteCountdown deletes a custom countdown; either partner may delete it
+func (s *CountdownService) DeleteCountdown(ctx context.Context, countdownID, userID primitive.ObjectID) error {
+	if _, _, err := s.getAuthorizedCountdown(ctx, countdownID, userID); err != nil {
+		return err
+	}
+
+	if err := s.countdownRepo.Delete(ctx, countdownID); err != nil {
+		logging.FromContext(ctx).Error("Failed to delete countdown", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to delete countdown")
+	}
+
+	return nil
+}
+
+// getMatchedUser returns a user who is matched with a partner
+func (s *CountdownService) getMatchedUser(ctx context.Context, userID primitive.ObjectID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	return user, nil
+}
+
+// getAuthorizedCountdown retrieves the user and one of their couple's countdowns
+func (s *CountdownService) getAuthorizedCountdown(ctx context.Context, countdownID, userID primitive.ObjectID) (*domain.User, *domain.Countdown, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	countdown, err := s.countdownRepo.GetByID(ctx, user.MatchCode, countdownID)
+	if err != nil {
+		return nil, nil, domain.ErrNotFoundError("Countdown")
+	}
+
+	return user, countdown, nil
+}
+
+// nextAnniversary returns the first yearly anniversary of a relationship that began
+// on anniversary falling on or after today
+func nextAnniversary(anniversary, today time.Time) *domain.AnniversaryCountdown {
+	start := truncateToDay(anniversary)
+
+	years := today.Year() - start.Year()
+	if years < 1 {
+		years = 1
+	}
+	date := addMonthsClamped(start, 12*years)
+	if date.Before(today) {
+		years++
+		date = addMonthsClamped(start, 12*years)
+	}
+
+	return &domain.AnniversaryCountdown{
+		Date:      domain.NewDate(date),
+		DaysUntil: domain.DaysBetween(today, date),
+		Years:     years,
+	}
+}
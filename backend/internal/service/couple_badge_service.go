@@ -0,0 +1,268 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// badgeSlugBytes is the number of random bytes in a badge slug, hex encoded
+	badgeSlugBytes = 10
+	// badgeCacheMaxEntries bounds the counters cache, so requests for made-up slugs
+	// cannot grow it without limit
+	badgeCacheMaxEntries = 10000
+)
+
+// cachedBadge holds the counters of a slug, or nil when the slug has no badge
+type cachedBadge struct {
+	counters  *domain.BadgeCounters
+	expiresAt time.Time
+}
+
+// CoupleBadgeService implements domain.CoupleBadgeService
+type CoupleBadgeService struct {
+	badgeRepo domain.CoupleBadgeRepository
+	userRepo  domain.UserRepository
+	cacheTTL  time.Duration
+
+	mu    sync.Mutex
+	cache map[string]*cachedBadge
+}
+
+// NewCoupleBadgeService creates a new couple badge service. Counters are computed at
+// most once per cacheTTL for each slug on this instance.
+func NewCoupleBadgeService(
+	badgeRepo domain.CoupleBadgeRepository,
+	userRepo domain.UserRepository,
+	cacheTTL time.Duration,
+) domain.CoupleBadgeService {
+	return &CoupleBadgeService{
+		badgeRepo: badgeRepo,
+		userRepo:  userRepo,
+		cacheTTL:  cacheTTL,
+		cache:     make(map[string]*cachedBadge),
+	}
+}
+
+// GetBadge retrieves the badge of the user's couple
+func (s *CoupleBadgeService) GetBadge(ctx context.Context, userID primitive.ObjectID) (*domain.CoupleBadge, error) {
+	user, err := s.matchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	badge, err := s.badgeRepo.GetByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Badge")
+	}
+	return badge, nil
+}
+
+// RotateBadge enables the couple's public badge, or gives it a new slug so that the
+// previous URLs stop working
+func (s *CoupleBadgeService) RotateBadge(ctx context.Context, userID primitive.ObjectID) (*domain.CoupleBadge, error) {
+	user, err := s.matchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.AnniversaryDate == nil {
+		return nil, domain.ErrInvalidRequestError("Set an anniversary date before enabling the badge")
+	}
+
+	slug, err := newBadgeSlug()
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to generate badge slug", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create badge")
+	}
+
+	previous, _ := s.badgeRepo.GetByMatchCode(ctx, user.MatchCode)
+
+	badge := &domain.CoupleBadge{
+		MatchCode: user.MatchCode,
+		Slug:      slug,
+		CreatedBy: userID,
+	}
+	if err := s.badgeRepo.Replace(ctx, badge); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to create badge")
+	}
+
+	if previous != nil {
+		s.forget(previous.Slug)
+	}
+
+	logging.FromContext(ctx).Info("Couple badge slug issued",
+		zap.String("user_id", userID.Hex()),
+		zap.String("match_code", user.MatchCode))
+
+	return badge, nil
+}
+
+// RevokeBadge disables the couple's public badge
+func (s *CoupleBadgeService) RevokeBadge(ctx context.Context, userID primitive.ObjectID) error {
+	user, err := s.matchedUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	badge, err := s.badgeRepo.GetByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		return domain.ErrNotFoundError("Badge")
+	}
+
+	if err := s.badgeRepo.DeleteByMatchCode(ctx, user.MatchCode); err != nil {
+		return domain.ErrNotFoundError("Badge")
+	}
+	s.forget(badge.Slug)
+
+	logging.FromContext(ctx).Info("Couple badge revoked",
+		zap.String("user_id", userID.Hex()),
+		zap.String("match_code", user.MatchCode))
+
+	return nil
+}
+
+// GetCounters retrieves the counters shown on a badge. Badges whose couple has since
+// split up, or has no anniversary date any more, are not found.
+func (s *CoupleBadgeService) GetCounters(ctx context.Context, slug string) (*domain.BadgeCounters, error) {
+	if !validBadgeSlug(slug) {
+		return nil, domain.ErrNotFoundError("Badge")
+	}
+
+	now := time.Now()
+
+	s.mu.Lock()
+	cached, ok := s.cache[slug]
+	s.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		if cached.counters == nil {
+			return nil, domain.ErrNotFoundError("Badge")
+		}
+		return cached.counters, nil
+	}
+
+	counters, err := s.computeCounters(ctx, slug, now)
+	if err != nil {
+		return nil, err
+	}
+	s.remember(slug, counters, now)
+
+	if counters == nil {
+		return nil, domain.ErrNotFoundError("Badge")
+	}
+	return counters, nil
+}
+
+// computeCounters reads the badge's couple and computes its counters, returning nil
+// when the slug has no badge to show
+func (s *CoupleBadgeService) computeCounters(ctx context.Context, slug string, now time.Time) (*domain.BadgeCounters, error) {
+	badge, err := s.badgeRepo.GetBySlug(ctx, slug)
+	if err != nil {
+		return nil, nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, badge.CreatedBy)
+	if err != nil || user.MatchCode != badge.MatchCode || user.AnniversaryDate == nil {
+		return nil, nil
+	}
+
+	return badgeCounters(*user.AnniversaryDate, now), nil
+}
+
+// remember caches the counters of a slug. When the cache is full of live entries,
+// nothing more is cached until some expire.
+func (s *CoupleBadgeService) remember(slug string, counters *domain.BadgeCounters, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.cache) >= badgeCacheMaxEntries {
+		for key, entry := range s.cache {
+			if !now.Before(entry.expiresAt) {
+				delete(s.cache, key)
+			}
+		}
+		if len(s.cache) >= badgeCacheMaxEntries {
+			return
+		}
+	}
+
+	s.cache[slug] = &cachedBadge{counters: counters, expiresAt: now.Add(s.cacheTTL)}
+}
+
+// forget drops a slug from the cache once its badge is rotated or revoked. Other
+// instances keep serving it until their cached entry expires.
+func (s *CoupleBadgeService) forget(slug string) {
+	s.mu.Lock()
+	delete(s.cache, slug)
+	s.mu.Unlock()
+}
+
+// matchedUser retrieves a user who is matched with a partner
+func (s *CoupleBadgeService) matchedUser(ctx context.Context, userID primitive.ObjectID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+	return user, nil
+}
+
+// badgeCounters computes the counters of a couple together since anniversary
+func badgeCounters(anniversary, now time.Time) *domain.BadgeCounters {
+	start := truncateToDay(anniversary)
+	today := truncateToDay(now)
+
+	counters := &domain.BadgeCounters{UpdatedAt: now.UTC()}
+	if today.Before(start) {
+		counters.DaysUntilAnniversary = int(start.Sub(today).Hours() / 24)
+		return counters
+	}
+
+	counters.DaysTogether = int(today.Sub(start).Hours() / 24)
+
+	years := today.Year() - start.Year()
+	if start.AddDate(years, 0, 0).After(today) {
+		years--
+	}
+	counters.YearsTogether = years
+
+	// On the anniversary itself the count is 0; the day they got together is not one
+	next := start.AddDate(years, 0, 0)
+	if years == 0 || next.Before(today) {
+		next = start.AddDate(years+1, 0, 0)
+	}
+	counters.DaysUntilAnniversary = int(next.Sub(today).Hours() / 24)
+
+	return counters
+}
+
+// newBadgeSlug generates a random, hex encoded badge slug
+func newBadgeSlug() (string, error) {
+	bytes := make([]byte, badgeSlugBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate random slug: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// validBadgeSlug reports whether slug could have been issued by newBadgeSlug, so
+// made-up slugs are turned away without a database lookup
+func validBadgeSlug(slug string) bool {
+	if len(slug) != 2*badgeSlugBytes {
+		return false
+	}
+	_, err := hex.DecodeString(slug)
+	return err == nil
+}
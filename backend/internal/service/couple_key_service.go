@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// envelopeFormat is the first byte of data encrypted with a couple's data key
+	envelopeFormat byte = 1
+	// envelopeHeaderSize is the format byte followed by the big-endian data key version
+	envelopeHeaderSize = 5
+	// rewrapBatchSize is the number of couples rewrapped at a time
+	rewrapBatchSize = 100
+)
+
+// CoupleKeyService implements domain.CoupleKeyService with envelope encryption: each
+// couple has AES-256-GCM data keys that are stored wrapped by a master key
+type CoupleKeyService struct {
+	settingsRepo domain.CoupleSettingsRepository
+	userRepo     domain.UserRepository
+	keyManager   domain.KeyManager // nil when no master key is configured
+}
+
+// NewCoupleKeyService creates a new couple key service. Without a key manager,
+// encrypting and decrypting fail.
+func NewCoupleKeyService(
+	settingsRepo domain.CoupleSettingsRepository,
+	userRepo domain.UserRepository,
+	keyManager domain.KeyManager,
+) domain.CoupleKeyService {
+	return &CoupleKeyService{
+		settingsRepo: settingsRepo,
+		userRepo:     userRepo,
+		keyManager:   keyManager,
+	}
+}
+
+// Encrypt encrypts plaintext with the couple's active data key, creating the key on
+// first use. The result starts with the data key version so it can still be decrypted
+// after the key is rotated.
+func (s *CoupleKeyService) Encrypt(ctx context.Context, matchCode, purpose string, plaintext []byte) ([]byte, error) {
+	if s.keyManager == nil {
+		return nil, domain.ErrOperationFailedError("Encryption is not configured")
+	}
+
+	settings, err := s.getSettings(ctx, matchCode)
+	if err != nil {
+		return nil, err
+	}
+
+	key := settings.ActiveEncryptionKey()
+	if key == nil {
+		if key, err = s.createFirstKey(ctx, matchCode); err != nil {
+			return nil, err
+		}
+	}
+
+	aead, err := s.dataKeyCipher(ctx, matchCode, key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, envelopeHeaderSize, envelopeHeaderSize+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	out[0] = envelopeFormat
+	binary.BigEndian.PutUint32(out[1:envelopeHeaderSize], uint32(key.Version))
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		logging.FromContext(ctx).Error("Failed to generate nonce", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to encrypt data")
+	}
+	out = append(out, nonce...)
+
+	return aead.Seal(out, nonce, plaintext, envelopeAAD(out[:envelopeHeaderSize], matchCode, purpose)), nil
+}
+
+// Decrypt decrypts data encrypted by Encrypt with any version of the couple's data key
+func (s *CoupleKeyService) Decrypt(ctx context.Context, matchCode, purpose string, ciphertext []byte) ([]byte, error) {
+	if s.keyManager == nil {
+		return nil, domain.ErrOperationFailedError("Encryption is not configured")
+	}
+
+	if len(ciphertext) < envelopeHeaderSize || ciphertext[0] != envelopeFormat {
+		return nil, domain.ErrInvalidRequestError("Unsupported encrypted data format")
+	}
+	version := int(binary.BigEndian.Uint32(ciphertext[1:envelopeHeaderSize]))
+
+	settings, err := s.getSettings(ctx, matchCode)
+	if err != nil {
+		return nil, err
+	}
+
+	key := settings.EncryptionKey(version)
+	if key == nil {
+		return nil, domain.ErrNotFoundError("Encryption key")
+	}
+
+	aead, err := s.dataKeyCipher(ctx, matchCode, key)
+	if err != nil {
+		return nil, err
+	}
+
+	body := ciphertext[envelopeHeaderSize:]
+	if len(body) < aead.NonceSize() {
+		return nil, domain.ErrInvalidRequestError("Encrypted data is truncated")
+	}
+
+	plaintext, err := aead.Open(nil, body[:aead.NonceSize()], body[aead.NonceSize():],
+		envelopeAAD(ciphertext[:envelopeHeaderSize], matchCode, purpose))
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to decrypt couple data",
+			zap.Error(err),
+			zap.String("match_code", matchCode),
+			zap.Int("key_version", version))
+		return nil, domain.ErrOperationFailedError("Failed to decrypt data")
+	}
+
+	return plaintext, nil
+}
+
+// GetKey describes the versions of the couple's data key
+func (s *CoupleKeyService) GetKey(ctx context.Context, userID primitive.ObjectID) (*domain.CoupleKeyResponse, error) {
+	matchCode, err := s.getMatchCode(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSettings(ctx, matchCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return keyResponse(settings), nil
+}
+
+// RotateKey creates a new version of the couple's data key. New data is encrypted with
+// it while the previous versions are kept to decrypt older data.
+func (s *CoupleKeyService) RotateKey(ctx context.Context, userID primitive.ObjectID) (*domain.CoupleKeyResponse, error) {
+	if s.keyManager == nil {
+		return nil, domain.ErrOperationFailedError("Encryption is not configured")
+	}
+
+	matchCode, err := s.getMatchCode(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.getSettings(ctx, matchCode)
+	if err != nil {
+		return nil, err
+	}
+
+	version := 1
+	if active := settings.ActiveEncryptionKey(); active != nil {
+		version = active.Version + 1
+	}
+
+	if _, err := s.addKey(ctx, matchCode, version, userID); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("Couple data key rotated",
+		zap.String("match_code", matchCode),
+		zap.String("user_id", userID.Hex()),
+		zap.Int("version", version))
+
+	settings, err = s.getSettings(ctx, matchCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return keyResponse(settings), nil
+}
+
+// RewrapKeys wraps the data keys wrapped by a previous master key with the current one
+func (s *CoupleKeyService) RewrapKeys(ctx context.Context) error {
+	if s.keyManager == nil {
+		return nil
+	}
+
+	currentID := s.keyManager.CurrentKeyID()
+	var afterID primitive.ObjectID
+	var rewrapped, failed int
+	for {
+		batch, err := s.settingsRepo.ListWithOtherMasterKey(ctx, currentID, afterID, rewrapBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, settings := range batch {
+			afterID = settings.ID
+			for _, key := range settings.EncryptionKeys {
+				if key.MasterKeyID == currentID {
+					continue
+				}
+				if err := s.rewrap(ctx, settings.MatchCode, key); err != nil {
+					logging.FromContext(ctx).Error("Failed to rewrap couple data key",
+						zap.Error(err),
+						zap.String("match_code", settings.MatchCode),
+						zap.Int("version", key.Version),
+						zap.String("master_key_id", key.MasterKeyID))
+					failed++
+					continue
+				}
+				rewrapped++
+			}
+		}
+
+		if len(batch) < rewrapBatchSize {
+			break
+		}
+	}
+
+	if rewrapped > 0 {
+		logging.FromContext(ctx).Info("Couple data keys rewrapped",
+			zap.Int("count", rewrapped),
+			zap.String("master_key_id", currentID))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d couple data keys could not be rewrapped", failed)
+	}
+	return nil
+}
+
+// rewrap wraps a data key with the current master key
+func (s *CoupleKeyService) rewrap(ctx context.Context, matchCode string, key *domain.CoupleDataKey) error {
+	plaintext, err := s.keyManager.UnwrapDataKey(ctx, key.MasterKeyID, key.WrappedKey)
+	if err != nil {
+		return err
+	}
+	defer clear(plaintext)
+
+	wrapped, masterKeyID, err := s.keyManager.WrapDataKey(ctx, plaintext)
+	if err != nil {
+		return err
+	}
+
+	return s.settingsRepo.UpdateWrappedKey(ctx, matchCode, key.Version, masterKeyID, wrapped)
+}
+
+// createFirstKey creates the first data key of a couple. When the other partner's
+// request created it at the same time, that key is used instead.
+func (s *CoupleKeyService) createFirstKey(ctx context.Context, matchCode string) (*domain.CoupleDataKey, error) {
+	key, err := s.addKey(ctx, matchCode, 1, primitive.NilObjectID)
+	if err == nil {
+		return key, nil
+	}
+
+	settings, reloadErr := s.getSettings(ctx, matchCode)
+	if reloadErr != nil || settings.ActiveEncryptionKey() == nil {
+		return nil, err
+	}
+	return settings.ActiveEncryptionKey(), nil
+}
+
+// addKey generates and stores a new version of the couple's data key
+func (s *CoupleKeyService) addKey(ctx context.Context, matchCode string, version int, createdBy primitive.ObjectID) (*domain.CoupleDataKey, error) {
+	plaintext, wrapped, masterKeyID, err := s.keyManager.GenerateDataKey(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to generate couple data key", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create encryption key")
+	}
+	clear(plaintext)
+
+	key := &domain.CoupleDataKey{
+		Version:     version,
+		WrappedKey:  wrapped,
+		MasterKeyID: masterKeyID,
+		CreatedBy:   createdBy,
+	}
+
+	if err := s.settingsRepo.AddEncryptionKey(ctx, matchCode, key); err != nil {
+		if errors.Is(err, domain.ErrEncryptionKeyVersionExists) {
+			return nil, domain.ErrOperationInProgressError("Another key rotation")
+		}
+		return nil, domain.ErrOperationFailedError("Failed to create encryption key")
+	}
+
+	return key, nil
+}
+
+// dataKeyCipher unwraps a data key and returns its AES-GCM cipher
+func (s *CoupleKeyService) dataKeyCipher(ctx context.Context, matchCode string, key *domain.CoupleDataKey) (cipher.AEAD, error) {
+	plaintext, err := s.keyManager.UnwrapDataKey(ctx, key.MasterKeyID, key.WrappedKey)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to unwrap couple data key",
+			zap.Error(err),
+			zap.String("match_code", matchCode),
+			zap.Int("version", key.Version),
+			zap.String("master_key_id", key.MasterKeyID))
+		return nil, domain.ErrOperationFailedError("Failed to access encryption key")
+	}
+	defer clear(plaintext)
+
+	block, err := aes.NewCipher(plaintext)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to access encryption key")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to access encryption key")
+	}
+	return aead, nil
+}
+
+// getSettings retrieves a couple's settings, which hold its data keys
+func (s *CoupleKeyService) getSettings(ctx context.Context, matchCode string) (*domain.CoupleSettings, error) {
+	settings, err := s.settingsRepo.GetByMatchCode(ctx, matchCode)
+	if err != nil {
+		if errors.Is(err, domain.ErrCoupleSettingsNotFound) {
+			return &domain.CoupleSettings{MatchCode: matchCode}, nil
+		}
+		return nil, domain.ErrOperationFailedError("Failed to get encryption key")
+	}
+	return settings, nil
+}
+
+// getMatchCode returns the match code of a matched user
+func (s *CoupleKeyService) getMatchCode(ctx context.Context, userID primitive.ObjectID) (string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return "", domain.ErrNotMatchedError()
+	}
+
+	return user.MatchCode, nil
+}
+
+// envelopeAAD binds encrypted data to its header, couple and purpose, so it cannot be
+// decrypted as another couple's data or as data meant for something else
+func envelopeAAD(header []byte, matchCode, purpose string) []byte {
+	aad := make([]byte, 0, len(header)+len(matchCode)+len(purpose)+1)
+	aad = append(aad, header...)
+	aad = append(aad, matchCode...)
+	aad = append(aad, 0)
+	return append(aad, purpose...)
+}
+
+// keyResponse describes the data key versions in a couple's settings
+func keyResponse(settings *domain.CoupleSettings) *domain.CoupleKeyResponse {
+	response := &domain.CoupleKeyResponse{
+		Versions: make([]*domain.CoupleKeyVersionResponse, 0, len(settings.EncryptionKeys)),
+	}
+
+	active := settings.ActiveEncryptionKey()
+	if active != nil {
+		response.ActiveVersion = active.Version
+	}
+	for _, key := range settings.EncryptionKeys {
+		response.Versions = append(response.Versions, &domain.CoupleKeyVersionResponse{
+			Version:   key.Version,
+			Active:    key == active,
+			CreatedAt: key.CreatedAt,
+		})
+	}
+
+	return response
+}
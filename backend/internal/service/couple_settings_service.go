@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"strconv"
+	"strings"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// CoupleSettingsService implements domain.CoupleSettingsService
+type CoupleSettingsService struct {
+	settingsRepo        domain.CoupleSettingsRepository
+	userRepo            domain.UserRepository
+	notificationService domain.NotificationService
+}
+
+// NewCoupleSettingsService creates a new couple settings service
+func NewCoupleSettingsService(
+	settingsRepo domain.CoupleSettingsRepository,
+	userRepo domain.UserRepository,
+	notificationService domain.NotificationService,
+) domain.CoupleSettingsService {
+	return &CoupleSettingsService{
+		settingsRepo:        settingsRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+	}
+}
+
+// GetSettings retrieves the settings of the user's couple
+func (s *CoupleSettingsService) GetSettings(ctx context.Context, userID primitive.ObjectID) (*domain.CoupleSettingsResponse, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.GetByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toResponse(settings, user), nil
+}
+
+// UpdateSettings updates the settings of the user's couple and tells the partner what
+// changed. Updates made from a revision the partner has since changed are rejected with
+// the current settings, so that both partners' edits are not silently overwritten.
+func (s *CoupleSettingsService) UpdateSettings(ctx context.Context, userID primitive.ObjectID, req *domain.UpdateCoupleSettingsRequest) (*domain.CoupleSettingsResponse, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	matchCode := user.MatchCode
+
+	settings, err := s.GetByMatchCode(ctx, matchCode)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := settings.Revision
+	if req.Revision != nil && *req.Revision != revision {
+		return nil, domain.ErrSettingsConflictError(s.toResponse(settings, user))
+	}
+
+	changed := applySettingsUpdate(settings, req)
+	if len(changed) == 0 {
+		return s.toResponse(settings, user), nil
+	}
+	settings.UpdatedBy = userID
+
+	if err := s.settingsRepo.UpdateAtRevision(ctx, settings, revision); err != nil {
+		if errors.Is(err, domain.ErrCoupleSettingsRevisionChanged) {
+			// The partner saved their changes between our read and write
+			current, err := s.GetByMatchCode(ctx, matchCode)
+			if err != nil {
+				return nil, err
+			}
+			return nil, domain.ErrSettingsConflictError(s.toResponse(current, user))
+		}
+		logging.FromContext(ctx).Error("Failed to update couple settings", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update settings")
+	}
+
+	logging.FromContext(ctx).Info("Couple settings updated",
+		zap.String("match_code", matchCode),
+		zap.String("user_id", userID.Hex()),
+		zap.Int("revision", settings.Revision),
+		zap.Strings("changed", changed))
+
+	s.notifyPartner(ctx, user, settings, changed)
+
+	return s.toResponse(settings, user), nil
+}
+
+// applySettingsUpdate applies the fields set in req to settings and returns the names
+// of those that changed
+func applySettingsUpdate(settings *domain.CoupleSettings, req *domain.UpdateCoupleSettingsRequest) []string {
+	var changed []string
+	setBool := func(name string, field *bool, value *bool) {
+		if value != nil && *field != *value {
+			*field = *value
+			changed = append(changed, name)
+		}
+	}
+	setString := func(name string, field *string, value *string) {
+		if value != nil && *field != strings.TrimSpace(*value) {
+			*field = strings.TrimSpace(*value)
+			changed = append(changed, name)
+		}
+	}
+
+	setBool("watermark_enabled", &settings.WatermarkEnabled, req.WatermarkEnabled)
+	setString("watermark_text", &settings.WatermarkText, req.WatermarkText)
+	setBool("require_partner_approval", &settings.RequirePartnerApproval, req.RequirePartnerApproval)
+	setBool("hide_presence", &settings.HidePresence, req.HidePresence)
+	setString("locale", &settings.Locale, req.Locale)
+	setString("date_format", &settings.DateFormat, req.DateFormat)
+	setString("first_day_of_week", &settings.FirstDayOfWeek, req.FirstDayOfWeek)
+	setString("theme", &settings.Theme, req.Theme)
+	setString("anniversary_display", &settings.AnniversaryDisplay, req.AnniversaryDisplay)
+	setString("timezone", &settings.Timezone, req.Timezone)
+
+	if req.ReminderDefaults != nil && settings.EffectiveReminderDefaults() != *req.ReminderDefaults {
+		defaults := *req.ReminderDefaults
+		settings.ReminderDefaults = &defaults
+		changed = append(changed, "reminder_defaults")
+	}
+
+	return changed
+}
+
+// notifyPartner tells the partner which settings were changed. Failures are logged;
+// the settings are kept.
+func (s *CoupleSettingsService) notifyPartner(ctx context.Context, author *domain.User, settings *domain.CoupleSettings, changed []string) {
+	if author.PartnerID == nil {
+		return
+	}
+
+	var authorName interface{} = domain.NotificationMessage("notification_your_partner")
+	if author.Name != "" {
+		authorName = author.Name
+	}
+
+	tmpl := domain.NotificationTemplate{
+		Key:    "couple_settings",
+		Params: map[string]interface{}{"AuthorName": authorName},
+	}
+	data := map[string]string{
+		"changed":  strings.Join(changed, ","),
+		"revision": strconv.Itoa(settings.Revision),
+	}
+	if err := s.notificationService.Notify(ctx, *author.PartnerID, domain.NotificationTypeCoupleSettings, tmpl, data); err != nil {
+		logging.FromContext(ctx).Warn("Failed to notify partner of settings change",
+			zap.Error(err),
+			zap.String("match_code", settings.MatchCode))
+	}
+}
+
+// GetByMatchCode retrieves a couple's settings, falling back to defaults when none are saved yet
+func (s *CoupleSettingsService) GetByMatchCode(ctx context.Context, matchCode string) (*domain.CoupleSettings, error) {
+	settings, err := s.settingsRepo.GetByMatchCode(ctx, matchCode)
+	if err != nil {
+		if errors.Is(err, domain.ErrCoupleSettingsNotFound) {
+			return &domain.CoupleSettings{MatchCode: matchCode}, nil
+		}
+		return nil, domain.ErrOperationFailedError("Failed to get settings")
+	}
+
+	return settings, nil
+}
+
+// FormattingHints returns the date formatting of the user's couple, or the defaults
+// of the user's locale when they are not matched or the settings cannot be read
+func (s *CoupleSettingsService) FormattingHints(ctx context.Context, userID primitive.ObjectID) *domain.FormattingHints {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return domain.DefaultFormattingHints(domain.DefaultLocale)
+	}
+
+	if user.MatchCode == "" {
+		return domain.DefaultFormattingHints(user.Locale)
+	}
+
+	settings, err := s.GetByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to get couple formatting, using defaults", zap.Error(err))
+		return domain.DefaultFormattingHints(user.Locale)
+	}
+
+	return settings.FormattingHints(user.Locale)
+}
+
+// toResponse converts settings to the response for user, whose locale fills in the
+// formatting the couple has not chosen
+func (s *CoupleSettingsService) toResponse(settings *domain.CoupleSettings, user *domain.User) *domain.CoupleSettingsResponse {
+	response := settings.ToResponse()
+	response.Formatting = settings.FormattingHints(user.Locale)
+	return response
+}
+
+// getMatchedUser returns a user who is matched with a partner
+func (s *CoupleSettingsService) getMatchedUser(ctx context.Context, userID primitive.ObjectID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	return user, nil
+}
@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// dailyQuestionHistoryMaxLimit caps how many past questions one page returns
+const dailyQuestionHistoryMaxLimit = 100
+
+// defaultDailyQuestions are asked when no question has been published in the CMS
+var defaultDailyQuestions = []*domain.QuestionPrompt{
+	{Key: "first_impression", Text: "What was your first impression of me?", Category: "memories"},
+	{Key: "favorite_date", Text: "Which of our dates is your favorite so far, and why?", Category: "memories"},
+	{Key: "fell_for_me", Text: "When did you realize you were falling for me?", Category: "memories"},
+	{Key: "funniest_moment", Text: "What is the funniest moment we have shared?", Category: "memories"},
+	{Key: "first_trip", Text: "What do you remember most about our first trip together?", Category: "memories"},
+	{Key: "dream_trip", Text: "If we could travel anywhere tomorrow, where would we go?", Category: "dreams"},
+	{Key: "ten_years", Text: "Where do you see us in ten years?", Category: "dreams"},
+	{Key: "dream_home", Text: "What does our dream home look like?", Category: "dreams"},
+	{Key: "bucket_list", Text: "What is one thing you want us to do together this year?", Category: "dreams"},
+	{Key: "lottery", Text: "What would we do first if we won the lottery?", Category: "dreams"},
+	{Key: "love_language", Text: "What makes you feel most loved by me?", Category: "us"},
+	{Key: "small_gesture", Text: "What small thing I do always makes your day better?", Category: "us"},
+	{Key: "proud_of_us", Text: "What are you most proud of about us?", Category: "us"},
+	{Key: "our_song", Text: "Which song reminds you of us?", Category: "us"},
+	{Key: "perfect_sunday", Text: "Describe our perfect lazy Sunday.", Category: "us"},
+	{Key: "grow_together", Text: "What is one way we could grow together?", Category: "us"},
+	{Key: "thank_you", Text: "What is something I did recently that you never thanked me for?", Category: "us"},
+	{Key: "comfort", Text: "What do you need most from me on a bad day?", Category: "us"},
+	{Key: "childhood_dream", Text: "What did you want to be when you grew up?", Category: "you"},
+	{Key: "comfort_food", Text: "What is your ultimate comfort food?", Category: "you"},
+	{Key: "hidden_talent", Text: "What is a talent of yours I might not know about?", Category: "you"},
+	{Key: "best_advice", Text: "What is the best advice you have ever received?", Category: "you"},
+	{Key: "happy_place", Text: "Where is your happy place?", Category: "you"},
+	{Key: "superpower", Text: "If you could have one superpower, what would it be?", Category: "fun"},
+	{Key: "movie_couple", Text: "Which movie couple are we most like?", Category: "fun"},
+	{Key: "pet_names", Text: "If we got a pet, what would we name it?", Category: "fun"},
+	{Key: "time_machine", Text: "If we had a time machine, which moment would we relive?", Category: "fun"},
+	{Key: "desert_island", Text: "Which three things would you bring to a desert island with me?", Category: "fun"},
+	{Key: "cook_together", Text: "What dish should we learn to cook together?", Category: "fun"},
+	{Key: "date_idea", Text: "What date would you love to plan for us next?", Category: "fun"},
+}
+
+// DailyQuestionService implements domain.DailyQuestionService
+type DailyQuestionService struct {
+	questionRepo        domain.DailyQuestionRepository
+	userRepo            domain.UserRepository
+	settingsService     domain.CoupleSettingsService
+	notificationService domain.NotificationService
+	source              domain.QuestionSource
+	cacheTTL            time.Duration
+
+	mu        sync.Mutex
+	questions map[string]*cachedQuestions // by locale
+}
+
+// cachedQuestions is the question bank of one locale read from the question source
+type cachedQuestions struct {
+	prompts   []*domain.QuestionPrompt
+	fetchedAt time.Time
+}
+
+// NewDailyQuestionService creates a new daily question service. Questions are read
+// from source at most once per cacheTTL and locale; a nil source means the built-in
+// questions are asked.
+func NewDailyQuestionService(
+	questionRepo domain.DailyQuestionRepository,
+	userRepo domain.UserRepository,
+	settingsService domain.CoupleSettingsService,
+	notificationService domain.NotificationService,
+	source domain.QuestionSource,
+	cacheTTL time.Duration,
+) domain.DailyQuestionService {
+	return &DailyQuestionService{
+		questionRepo:        questionRepo,
+		userRepo:            userRepo,
+		settingsService:     settingsService,
+		notificationService: notificationService,
+		source:              source,
+		cacheTTL:            cacheTTL,
+		questions:           map[string]*cachedQuestions{},
+	}
+}
+
+// GetToday returns the couple's question of today in the couple's time zone, picking
+// it on the first request of the day
+func (s *DailyQuestionService) GetToday(ctx context.Context, userID primitive.ObjectID, locale string) (*domain.DailyQuestionResponse, error) {
+	user, question, err := s.getToday(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return question.ToResponse(user.ID, s.questionText(ctx, question, locale)), nil
+}
+
+// AnswerToday saves the user's answer to today's question. The answer can be changed
+// until the partner answers too, which reveals both answers.
+func (s *DailyQuestionService) AnswerToday(ctx context.Context, userID primitive.ObjectID, locale string, req *domain.AnswerQuestionRequest) (*domain.DailyQuestionResponse, error) {
+	user, question, err := s.getToday(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if question.RevealedAt != nil {
+		return nil, domain.ErrAnswersRevealedError()
+	}
+
+	_, answeredBefore := question.Answers[userID.Hex()]
+
+	question, err = s.questionRepo.SetAnswer(ctx, question.ID, userID, domain.QuestionAnswer{
+		Answer:     strings.TrimSpace(req.Answer),
+		AnsweredAt: time.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrDailyQuestionAnswersRevealed) {
+			return nil, domain.ErrAnswersRevealedError()
+		}
+		return nil, domain.ErrOperationFailedError("Failed to save answer")
+	}
+
+	revealed := false
+	if len(question.Answers) >= 2 {
+		now := time.Now()
+		revealed, err = s.questionRepo.MarkRevealed(ctx, question.ID, now)
+		if err != nil {
+			return nil, domain.ErrOperationFailedError("Failed to save answer")
+		}
+		// Both answers are in, whichever partner's request marked them revealed
+		question.RevealedAt = &now
+	}
+
+	logging.FromContext(ctx).Info("Daily question answered",
+		zap.String("question_id", question.ID.Hex()),
+		zap.String("user_id", userID.Hex()),
+		zap.Bool("revealed", revealed))
+
+	switch {
+	case revealed:
+		s.notifyPartner(ctx, user, question, "daily_question_revealed")
+	case !answeredBefore:
+		s.notifyPartner(ctx, user, question, "daily_question_answered")
+	}
+
+	return question.ToResponse(userID, s.questionText(ctx, question, locale)), nil
+}
+
+// GetHistory retrieves the couple's questions, newest first. The partner's answers to
+// questions the user has not answered stay hidden.
+func (s *DailyQuestionService) GetHistory(ctx context.Context, userID primitive.ObjectID, locale string, page, limit int) (*domain.DailyQuestionListResponse, error) {
+	if limit < 1 || limit > dailyQuestionHistoryMaxLimit {
+		limit = 20
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	questions, err := s.questionRepo.GetByMatchCode(ctx, user.MatchCode, limit, (page-1)*limit)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get questions")
+	}
+
+	total, err := s.questionRepo.CountByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get questions")
+	}
+
+	response := &domain.DailyQuestionListResponse{
+		Questions: make([]*domain.DailyQuestionResponse, 0, len(questions)),
+		Total:     total,
+		Page:      page,
+		Limit:     limit,
+	}
+	for _, question := range questions {
+		response.Questions = append(response.Questions, question.ToResponse(userID, s.questionText(ctx, question, locale)))
+	}
+
+	return response, nil
+}
+
+// getToday returns the user and the couple's question of today, picking it unless it
+// was already
+func (s *DailyQuestionService) getToday(ctx context.Context, userID primitive.ObjectID) (*domain.User, *domain.DailyQuestion, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, domain.ErrUserNotFoundError()
+	}
+	if user.MatchCode == "" {
+		return nil, nil, domain.ErrNotMatchedError()
+	}
+
+	settings, err := s.settingsService.GetByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		return nil, nil, err
+	}
+	today := domain.Today(settings.Location())
+
+	question, err := s.questionRepo.GetByDay(ctx, user.MatchCode, today)
+	if err == nil {
+		return user, question, nil
+	}
+	if !errors.Is(err, domain.ErrDailyQuestionNotFound) {
+		return nil, nil, domain.ErrOperationFailedError("Failed to get today's question")
+	}
+
+	prompt := s.pickQuestion(ctx, user.MatchCode, today)
+	question, err = s.questionRepo.GetOrCreate(ctx, &domain.DailyQuestion{
+		MatchCode:   user.MatchCode,
+		Day:         today,
+		QuestionKey: prompt.Key,
+		Text:        prompt.Text,
+		Category:    prompt.Category,
+	})
+	if err != nil {
+		return nil, nil, domain.ErrOperationFailedError("Failed to get today's question")
+	}
+
+	return user, question, nil
+}
+
+// pickQuestion picks the question of a couple's day. Couples go through the bank in
+// their own order, so that partners of different couples are not asked the same
+// question on the same day.
+func (s *DailyQuestionService) pickQuestion(ctx context.Context, matchCode string, day time.Time) *domain.QuestionPrompt {
+	bank := s.loadQuestions(ctx, domain.DefaultLocale)
+	if len(bank) == 0 {
+		bank = defaultDailyQuestions
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(matchCode))
+	dayNumber := uint64(day.Unix() / int64(24*time.Hour/time.Second))
+
+	return bank[(uint64(hash.Sum32())+dayNumber)%uint64(len(bank))]
+}
+
+// questionText returns the text of a question in locale, falling back to English, the
+// built-in question and the text it was asked with
+func (s *DailyQuestionService) questionText(ctx context.Context, question *domain.DailyQuestion, locale string) string {
+	locales := []string{locale}
+	if locale != domain.DefaultLocale {
+		locales = append(locales, domain.DefaultLocale)
+	}
+
+	for _, loc := range locales {
+		for _, prompt := range s.loadQuestions(ctx, loc) {
+			if prompt.Key == question.QuestionKey {
+				return prompt.Text
+			}
+		}
+	}
+	for _, prompt := range defaultDailyQuestions {
+		if prompt.Key == question.QuestionKey {
+			return prompt.Text
+		}
+	}
+	return question.Text
+}
+
+// loadQuestions returns the questions of a locale published in the question source,
+// reading them when the cached ones have expired. Stale questions are served if the
+// source fails.
+func (s *DailyQuestionService) loadQuestions(ctx context.Context, locale string) []*domain.QuestionPrompt {
+	if s.source == nil || locale == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cached := s.questions[locale]
+	if cached != nil && time.Since(cached.fetchedAt) < s.cacheTTL {
+		return cached.prompts
+	}
+
+	prompts, err := s.source.ListQuestions(ctx, locale)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to load daily questions",
+			zap.Error(err),
+			zap.String("locale", locale))
+		if cached != nil {
+			return cached.prompts
+		}
+		return nil
+	}
+
+	s.questions[locale] = &cachedQuestions{prompts: prompts, fetchedAt: time.Now()}
+	return prompts
+}
+
+// notifyPartner tells the partner that the user answered today's question, or that
+// both answers are revealed. Failures are logged; the answer is kept.
+func (s *DailyQuestionService) notifyPartner(ctx context.Context, author *domain.User, question *domain.DailyQuestion, key string) {
+	if author.PartnerID == nil {
+		return
+	}
+
+	var authorName interface{} = domain.NotificationMessage("notification_your_partner")
+	if author.Name != "" {
+		authorName = author.Name
+	}
+
+	tmpl := domain.NotificationTemplate{
+		Key:    key,
+		Params: map[string]interface{}{"AuthorName": authorName},
+	}
+	data := map[string]string{
+		"question_id": question.ID.Hex(),
+		"date":        question.Day.Format("2006-01-02"),
+	}
+	if err := s.notificationService.Notify(ctx, *author.PartnerID, domain.NotificationTypeDailyQuestion, tmpl, data); err != nil {
+		logging.FromContext(ctx).Warn("Failed to notify partner of daily question answer",
+			zap.Error(err),
+			zap.String("match_code", question.MatchCode))
+	}
+}
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// freeDateCost is the cost of the built-in date ideas
+var freeDateCost = 0.0
+
+// defaultDateIdeas are suggested when no date idea has been published in the CMS.
+// They cost nothing and can be done anywhere.
+var defaultDateIdeas = []*domain.DateIdea{
+	{Key: "picnic", Title: "Picnic in the park", Description: "Pack your favorite snacks and a blanket.", Category: "outdoors", Cost: &freeDateCost},
+	{Key: "stargazing", Title: "Stargazing", Description: "Find a dark spot, bring a blanket and look for constellations.", Category: "outdoors", Cost: &freeDateCost},
+	{Key: "sunrise_walk", Title: "Sunrise walk", Description: "Wake up early and watch the sunrise together.", Category: "outdoors", Cost: &freeDateCost},
+	{Key: "cook_together", Title: "Cook a new recipe together", Description: "Pick a dish neither of you has made before.", Category: "home", Cost: &freeDateCost},
+	{Key: "movie_marathon", Title: "Movie marathon", Description: "Take turns picking films and build a blanket fort.", Category: "home", Cost: &freeDateCost},
+	{Key: "game_night", Title: "Game night", Description: "Board games, cards or a video game for two.", Category: "home", Cost: &freeDateCost},
+	{Key: "memory_lane", Title: "Memory lane night", Description: "Look through your old photos and tell the stories behind them.", Category: "home", Cost: &freeDateCost},
+	{Key: "letters", Title: "Write love letters", Description: "Write each other a letter and read them aloud.", Category: "home", Cost: &freeDateCost},
+	{Key: "free_museum", Title: "Free museum day", Description: "Visit a museum or gallery on its free admission day.", Category: "culture", Cost: &freeDateCost},
+	{Key: "explore_neighborhood", Title: "Explore a new neighborhood", Description: "Wander somewhere in your city you have never been.", Category: "outdoors", Cost: &freeDateCost},
+}
+
+// DatePlanService implements domain.DatePlanService
+type DatePlanService struct {
+	planRepo     domain.DatePlanRepository
+	userRepo     domain.UserRepository
+	eventService domain.EventService
+	source       domain.DateIdeaSource
+	cacheTTL     time.Duration
+
+	mu        sync.Mutex
+	ideas     []*domain.DateIdea
+	fetchedAt time.Time
+}
+
+// NewDatePlanService creates a new date plan service. Date ideas are read from source
+// at most once per cacheTTL; a nil source means the built-in ideas are suggested.
+func NewDatePlanService(
+	planRepo domain.DatePlanRepository,
+	userRepo domain.UserRepository,
+	eventService domain.EventService,
+	source domain.DateIdeaSource,
+	cacheTTL time.Duration,
+) domain.DatePlanService {
+	return &DatePlanService{
+		planRepo:     planRepo,
+		userRepo:     userRepo,
+		eventService: eventService,
+		source:       source,
+		cacheTTL:     cacheTTL,
+	}
+}
+
+// SuggestIdeas returns the curated date ideas matching the filter, ideas for the
+// location first and then the cheapest
+func (s *DatePlanService) SuggestIdeas(ctx context.Context, userID primitive.ObjectID, filter domain.DateIdeaFilter, limit int) (*domain.DateIdeasResponse, error) {
+	if _, err := s.getMatchedUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	ideas := s.loadIdeas(ctx)
+	if len(ideas) == 0 {
+		ideas = defaultDateIdeas
+	}
+
+	matching := make([]*domain.DateIdea, 0, len(ideas))
+	for _, idea := range ideas {
+		if idea.Matches(filter) {
+			matching = append(matching, idea)
+		}
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		if filter.Location != "" && (matching[i].Location != "") != (matching[j].Location != "") {
+			return matching[i].Location != ""
+		}
+		return dateIdeaCost(matching[i]) < dateIdeaCost(matching[j])
+	})
+
+	if len(matching) > limit {
+		matching = matching[:limit]
+	}
+
+	return &domain.DateIdeasResponse{Ideas: matching}, nil
+}
+
+// CreatePlan plans a date for the couple
+func (s *DatePlanService) CreatePlan(ctx context.Context, userID primitive.ObjectID, req *domain.CreateDatePlanRequest) (*domain.DatePlanResponse, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &domain.DatePlan{
+		MatchCode:     user.MatchCode,
+		CreatedBy:     userID,
+		Idea:          strings.TrimSpace(req.Idea),
+		Description:   req.Description,
+		IdeaKey:       req.IdeaKey,
+		Location:      strings.TrimSpace(req.Location),
+		ScheduledDate: req.ScheduledDate.ToTimePtr(),
+		Time:          req.Time,
+		Budget:        req.Budget,
+		Currency:      strings.ToUpper(req.Currency),
+		Status:        req.Status,
+	}
+	if plan.Status == "" {
+		plan.Status = domain.DatePlanStatusIdea
+		if plan.ScheduledDate != nil {
+			plan.Status = domain.DatePlanStatusPlanned
+		}
+	}
+
+	if err := s.planRepo.Create(ctx, plan); err != nil {
+		logging.FromContext(ctx).Error("Failed to create date plan", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create date plan")
+	}
+
+	logging.FromContext(ctx).Info("Date plan created",
+		zap.String("plan_id", plan.ID.Hex()),
+		zap.String("user_id", userID.Hex()),
+		zap.String("status", string(plan.Status)))
+
+	return plan.ToResponse(), nil
+}
+
+// GetPlan retrieves one of the couple's date plans by ID
+func (s *DatePlanService) GetPlan(ctx context.Context, planID, userID primitive.ObjectID) (*domain.DatePlanResponse, error) {
+	plan, err := s.getPlan(ctx, planID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.ToResponse(), nil
+}
+
+// GetPlans retrieves the couple's date plans with pagination, optionally with a status
+func (s *DatePlanService) GetPlans(ctx context.Context, userID primitive.ObjectID, status domain.DatePlanStatus, page, limit int) (*domain.DatePlanListResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	response := &domain.DatePlanListResponse{
+		Plans: []*domain.DatePlanResponse{},
+		Page:  page,
+		Limit: limit,
+	}
+
+	if user.MatchCode == "" {
+		return response, nil
+	}
+
+	offset := (page - 1) * limit
+
+	plans, err := s.planRepo.GetByMatchCode(ctx, user.MatchCode, status, limit, offset)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get date plans", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get date plans")
+	}
+
+	response.Total, err = s.planRepo.CountByMatchCode(ctx, user.MatchCode, status)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count date plans", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get date plans")
+	}
+
+	for _, plan := range plans {
+		response.Plans = append(response.Plans, plan.ToResponse())
+	}
+
+	return response, nil
+}
+
+// UpdatePlan updates one of the couple's date plans. The event a plan was turned into
+// is not changed.
+func (s *DatePlanService) UpdatePlan(ctx context.Context, planID, userID primitive.ObjectID, req *domain.UpdateDatePlanRequest) (*domain.DatePlanResponse, error) {
+	plan, err := s.getPlan(ctx, planID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Idea != "" {
+		plan.Idea = strings.TrimSpace(req.Idea)
+	}
+	if req.Description != nil {
+		plan.Description = *req.Description
+	}
+	if req.Location != nil {
+		plan.Location = strings.TrimSpace(*req.Location)
+	}
+	if req.ScheduledDate != nil {
+		plan.ScheduledDate = req.ScheduledDate.ToTimePtr()
+	}
+	if req.Time != nil {
+		if *req.Time != "" {
+			if _, ok := domain.ParseTimeOfDay(*req.Time); !ok {
+				return nil, domain.ErrInvalidRequestError("Invalid time, expected HH:MM")
+			}
+		}
+		plan.Time = *req.Time
+	}
+	if req.Budget != nil {
+		plan.Budget = req.Budget
+	}
+	if req.Currency != "" {
+		plan.Currency = strings.ToUpper(req.Currency)
+	}
+	if req.Status != "" {
+		plan.Status = req.Status
+	}
+
+	if err := s.planRepo.Update(ctx, plan); err != nil {
+		logging.FromContext(ctx).Error("Failed to update date plan", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update date plan")
+	}
+
+	return plan.ToResponse(), nil
+}
+
+// DeletePlan deletes one of the couple's date plans, keeping the event it was turned into
+func (s *DatePlanService) DeletePlan(ctx context.Context, planID, userID primitive.ObjectID) error {
+	if _, err := s.getPlan(ctx, planID, userID); err != nil {
+		return err
+	}
+
+	if err := s.planRepo.Delete(ctx, planID); err != nil {
+		logging.FromContext(ctx).Error("Failed to delete date plan", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to delete date plan")
+	}
+
+	return nil
+}
+
+// ConvertToEvent creates a date event on the couple's calendar from a scheduled date
+// plan and links it to the plan. A plan is turned into one event at most.
+func (s *DatePlanService) ConvertToEvent(ctx context.Context, planID, userID primitive.ObjectID, req *domain.ConvertDatePlanRequest) (*domain.ConvertDatePlanResponse, error) {
+	plan, err := s.getPlan(ctx, planID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan.EventID != nil {
+		return nil, domain.ErrInvalidRequestError("Date plan is already on the calendar")
+	}
+	if plan.ScheduledDate == nil {
+		return nil, domain.ErrInvalidRequestError("Date plan has no scheduled date")
+	}
+	if plan.Status == domain.DatePlanStatusCancelled {
+		return nil, domain.ErrInvalidRequestError("Date plan is cancelled")
+	}
+
+	event, err := s.eventService.CreateEvent(ctx, userID, &domain.CreateEventRequest{
+		Title:       plan.Idea,
+		Description: plan.Description,
+		Date:        domain.DateFromTime(*plan.ScheduledDate),
+		Time:        plan.Time,
+		Location:    plan.Location,
+		EventType:   "date",
+		IsPrivate:   req.IsPrivate,
+		Reminder:    req.Reminder,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	eventID, err := primitive.ObjectIDFromHex(event.ID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Invalid ID of created event", zap.Error(err), zap.String("event_id", event.ID))
+		return nil, domain.ErrOperationFailedError("Failed to link date plan to event")
+	}
+
+	plan.EventID = &eventID
+	if plan.Status == domain.DatePlanStatusIdea {
+		plan.Status = domain.DatePlanStatusPlanned
+	}
+
+	if err := s.planRepo.Update(ctx, plan); err != nil {
+		logging.FromContext(ctx).Error("Failed to link date plan to event",
+			zap.Error(err),
+			zap.String("plan_id", plan.ID.Hex()),
+			zap.String("event_id", event.ID))
+		return nil, domain.ErrOperationFailedError("Failed to link date plan to event")
+	}
+
+	logging.FromContext(ctx).Info("Date plan added to calendar",
+		zap.String("plan_id", plan.ID.Hex()),
+		zap.String("event_id", event.ID),
+		zap.String("user_id", userID.Hex()))
+
+	return &domain.ConvertDatePlanResponse{
+		Plan:  plan.ToResponse(),
+		Event: event,
+	}, nil
+}
+
+// getMatchedUser retrieves a user who has a partner
+func (s *DatePlanService) getMatchedUser(ctx context.Context, userID primitive.ObjectID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	return user, nil
+}
+
+// getPlan retrieves one of the user's couple's date plans
+func (s *DatePlanService) getPlan(ctx context.Context, planID, userID primitive.ObjectID) (*domain.DatePlan, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.planRepo.GetByID(ctx, user.MatchCode, planID)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Date plan")
+	}
+
+	return plan, nil
+}
+
+// loadIdeas returns the date ideas published in the idea source, reading them when
+// the cached ones have expired. Stale ideas are served if the source fails.
+func (s *DatePlanService) loadIdeas(ctx context.Context) []*domain.DateIdea {
+	if s.source == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ideas != nil && time.Since(s.fetchedAt) < s.cacheTTL {
+		return s.ideas
+	}
+
+	ideas, err := s.source.ListDateIdeas(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to load date ideas", zap.Error(err))
+		return s.ideas
+	}
+
+	s.ideas = ideas
+	s.fetchedAt = time.Now()
+	return ideas
+}
+
+// dateIdeaCost returns the cost of an idea for sorting, ideas of unknown cost last
+func dateIdeaCost(idea *domain.DateIdea) float64 {
+	if idea.Cost == nil {
+		return math.MaxFloat64
+	}
+	return *idea.Cost
+}
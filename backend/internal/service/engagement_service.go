@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// engagementRecordTimeout bounds recording an interaction, which runs after the
+	// request that published it may have finished
+	engagementRecordTimeout = 10 * time.Second
+	// streakFinalizeBatchSize caps the streaks finalized in one run
+	streakFinalizeBatchSize = 500
+	// streakMaxCatchUpDays caps the days counted for one couple in a run; couples whose
+	// days were not counted for longer start over
+	streakMaxCatchUpDays = 31
+)
+
+// EngagementService implements domain.EngagementService
+type EngagementService struct {
+	engagementRepo  domain.EngagementRepository
+	userRepo        domain.UserRepository
+	settingsService domain.CoupleSettingsService
+}
+
+// NewEngagementService creates a new engagement service
+func NewEngagementService(
+	engagementRepo domain.EngagementRepository,
+	userRepo domain.UserRepository,
+	settingsService domain.CoupleSettingsService,
+) domain.EngagementService {
+	return &EngagementService{
+		engagementRepo:  engagementRepo,
+		userRepo:        userRepo,
+		settingsService: settingsService,
+	}
+}
+
+// HandleEvent records the interaction of a message.sent, photo.created or
+// mood.recorded event. Events are handed over on the publisher's goroutine, so the
+// interaction is recorded in the background.
+func (s *EngagementService) HandleEvent(ctx context.Context, event *domain.DomainEvent) {
+	var (
+		matchCode string
+		userHex   string
+		kind      domain.EngagementKind
+	)
+	switch data := event.Data.(type) {
+	case *domain.MessageSentData:
+		userHex, kind = data.SenderID, domain.EngagementMessage
+	case *domain.PhotoCreatedData:
+		matchCode, userHex, kind = data.MatchCode, data.CreatedBy, domain.EngagementPhoto
+	case *domain.MoodRecordedData:
+		matchCode, userHex, kind = data.MatchCode, data.UserID, domain.EngagementCheckIn
+	default:
+		return
+	}
+
+	userID, err := primitive.ObjectIDFromHex(userHex)
+	if err != nil {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), engagementRecordTimeout)
+		defer cancel()
+
+		// Message events only identify the sender
+		if matchCode == "" {
+			user, err := s.userRepo.GetByID(ctx, userID)
+			if err != nil {
+				return
+			}
+			matchCode = user.MatchCode
+		}
+
+		if err := s.Record(ctx, matchCode, userID, kind); err != nil {
+			logging.FromContext(ctx).Warn("Failed to record couple activity",
+				zap.Error(err),
+				zap.String("event_id", event.ID),
+				zap.String("kind", string(kind)))
+		}
+	}()
+}
+
+// Record records an interaction of a partner of the couple on the couple's current
+// day, starting the couple's streak on their first interaction
+func (s *EngagementService) Record(ctx context.Context, matchCode string, userID primitive.ObjectID, kind domain.EngagementKind) error {
+	if matchCode == "" {
+		return nil
+	}
+
+	location := s.coupleLocation(ctx, matchCode)
+	today := domain.Today(location)
+
+	if err := s.engagementRepo.RecordActivity(ctx, matchCode, today, userID, kind); err != nil {
+		return err
+	}
+
+	return s.engagementRepo.CreateStreak(ctx, &domain.CoupleStreak{
+		MatchCode:    matchCode,
+		FinalizedDay: today.AddDate(0, 0, -1),
+		FinalizeAt:   endOfDay(today, location),
+	})
+}
+
+// GetStats returns the streak of the user's couple, counting today once the couple
+// has interacted today
+func (s *EngagementService) GetStats(ctx context.Context, userID primitive.ObjectID) (*domain.CoupleStatsResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	settings, err := s.settingsService.GetByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		return nil, err
+	}
+	today := domain.Today(settings.Location())
+
+	streak, err := s.engagementRepo.GetStreak(ctx, user.MatchCode)
+	if err != nil {
+		if !errors.Is(err, domain.ErrCoupleStreakNotFound) {
+			return nil, domain.ErrOperationFailedError("Failed to get stats")
+		}
+		streak = &domain.CoupleStreak{MatchCode: user.MatchCode}
+	}
+
+	activity, err := s.engagementRepo.GetActivity(ctx, user.MatchCode, today)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get stats")
+	}
+
+	response := &domain.CoupleStatsResponse{
+		Today:    domain.NewDate(today),
+		Timezone: settings.EffectiveTimezone(),
+		Streak: domain.StreakResponse{
+			Current:       streak.Current,
+			Longest:       streak.Longest,
+			TodayKinds:    []domain.EngagementKind{},
+			LastActiveDay: domain.DateFromTimePtr(streak.LastActiveDay),
+		},
+	}
+
+	if activity != nil {
+		response.Streak.ActiveToday = true
+		response.Streak.TodayKinds = activity.Kinds
+		response.Streak.LastActiveDay = &response.Today
+		if streak.FinalizedDay.Before(today) {
+			response.Streak.Current++
+		}
+	}
+	if response.Streak.Current > response.Streak.Longest {
+		response.Streak.Longest = response.Streak.Current
+	}
+
+	return response, nil
+}
+
+// FinalizeStreaks counts the days that ended in each couple's time zone into their
+// streak: a day the couple interacted on extends it, any other day ends it
+func (s *EngagementService) FinalizeStreaks(ctx context.Context) error {
+	now := time.Now()
+
+	streaks, err := s.engagementRepo.ListStreaksToFinalize(ctx, now, streakFinalizeBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, streak := range streaks {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err := s.finalize(ctx, streak, now); err != nil {
+			logging.FromContext(ctx).Warn("Failed to finalize couple streak",
+				zap.Error(err),
+				zap.String("match_code", streak.MatchCode))
+		}
+	}
+
+	if len(streaks) > 0 {
+		logging.FromContext(ctx).Info("Couple streaks finalized", zap.Int("count", len(streaks)))
+	}
+
+	return nil
+}
+
+// finalize counts the days of a streak that are over by now
+func (s *EngagementService) finalize(ctx context.Context, streak *domain.CoupleStreak, now time.Time) error {
+	location := s.coupleLocation(ctx, streak.MatchCode)
+
+	for i := 0; i < streakMaxCatchUpDays; i++ {
+		day := streak.FinalizedDay.AddDate(0, 0, 1)
+		if endOfDay(day, location).After(now) {
+			break
+		}
+
+		activity, err := s.engagementRepo.GetActivity(ctx, streak.MatchCode, day)
+		if err != nil {
+			return err
+		}
+
+		if activity != nil {
+			streak.Current++
+			streak.LastActiveDay = &day
+			if streak.Current > streak.Longest {
+				streak.Longest = streak.Current
+			}
+		} else {
+			streak.Current = 0
+		}
+		streak.FinalizedDay = day
+	}
+
+	// Days too far back to count are days without interactions
+	if !endOfDay(streak.FinalizedDay.AddDate(0, 0, 1), location).After(now) {
+		streak.Current = 0
+		streak.FinalizedDay = domain.Today(location).AddDate(0, 0, -1)
+	}
+
+	streak.FinalizeAt = endOfDay(streak.FinalizedDay.AddDate(0, 0, 1), location)
+	return s.engagementRepo.UpdateStreak(ctx, streak)
+}
+
+// coupleLocation returns the couple's time zone, or UTC when the settings cannot be read
+func (s *EngagementService) coupleLocation(ctx context.Context, matchCode string) *time.Location {
+	settings, err := s.settingsService.GetByMatchCode(ctx, matchCode)
+	if err != nil {
+		return time.UTC
+	}
+	return settings.Location()
+}
+
+// endOfDay returns the instant a calendar day, stored as midnight UTC, ends in location
+func endOfDay(day time.Time, location *time.Location) time.Time {
+	return domain.AtTimeOfDay(day.AddDate(0, 0, 1), 0, location)
+}
@@ -6,27 +6,40 @@ import (
 	"time"
 
 	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"github.com/eralove/eralove-backend/internal/infrastructure/sanitize"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
 )
 
+const (
+	// eventReminderBatchSize caps the due reminders sent in one run
+	eventReminderBatchSize = 200
+	// eventReminderMaxDelay is how late a reminder is still sent, so that reminders
+	// missed while the server was down are not all sent at once long after
+	eventReminderMaxDelay = 24 * time.Hour
+)
+
 // EventService implements domain.EventService
 type EventService struct {
-	eventRepo domain.EventRepository
-	userRepo  domain.UserRepository
-	logger    *zap.Logger
+	eventRepo           domain.EventRepository
+	userRepo            domain.UserRepository
+	settingsService     domain.CoupleSettingsService
+	notificationService domain.NotificationService
 }
 
 // NewEventService creates a new event service
 func NewEventService(
 	eventRepo domain.EventRepository,
 	userRepo domain.UserRepository,
-	logger *zap.Logger,
+	settingsService domain.CoupleSettingsService,
+	notificationService domain.NotificationService,
 ) domain.EventService {
 	return &EventService{
-		eventRepo: eventRepo,
-		userRepo:  userRepo,
-		logger:    logger,
+		eventRepo:           eventRepo,
+		userRepo:            userRepo,
+		settingsService:     settingsService,
+		notificationService: notificationService,
 	}
 }
 
@@ -36,7 +49,7 @@ func (s *EventService) CreateEvent(
 	userID primitive.ObjectID,
 	req *domain.CreateEventRequest,
 ) (*domain.EventResponse, error) {
-	s.logger.Info("Creating event",
+	logging.FromContext(ctx).Info("Creating event",
 		zap.String("user_id", userID.Hex()),
 		zap.String("title", req.Title),
 		zap.String("event_type", req.EventType))
@@ -44,13 +57,13 @@ func (s *EventService) CreateEvent(
 	// Get user to get match code
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		s.logger.Error("Failed to get user", zap.Error(err))
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		logging.FromContext(ctx).Error("Failed to get user", zap.Error(err))
+		return nil, domain.ErrUserNotFoundError()
 	}
 
 	if user.MatchCode == "" {
-		s.logger.Error("User is not matched")
-		return nil, fmt.Errorf("user is not matched with anyone")
+		logging.FromContext(ctx).Error("User is not matched")
+		return nil, domain.ErrNotMatchedError()
 	}
 
 	// Create event
@@ -59,10 +72,10 @@ func (s *EventService) CreateEvent(
 		MatchCode:      user.MatchCode,
 		CreatedBy:      userID, // Track who created this event
 		Title:          req.Title,
-		Description:    req.Description,
-		Date:           req.Date,
+		Description:    sanitize.HTML(req.Description), // served back to web clients
+		Date:           req.Date.Time,
 		Time:           req.Time,
-		Location:       req.Location,
+		Timezone:       req.Timezone,
 		EventType:      req.EventType,
 		IsRecurring:    req.IsRecurring,
 		RecurrenceRule: req.RecurrenceRule,
@@ -71,48 +84,89 @@ func (s *EventService) CreateEvent(
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}
+	setPlace(&event.Location, &event.Place, req.Location, req.Place)
+	s.applyCoupleDefaults(ctx, user, event)
 
 	// Save to database
 	if err := s.eventRepo.Create(event); err != nil {
-		s.logger.Error("Failed to create event", zap.Error(err))
-		return nil, fmt.Errorf("failed to create event: %w", err)
+		logging.FromContext(ctx).Error("Failed to create event", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create event")
 	}
 
-	s.logger.Info("Event created successfully",
+	logging.FromContext(ctx).Info("Event created successfully",
 		zap.String("event_id", event.ID.Hex()),
 		zap.String("user_id", userID.Hex()))
 
 	return event.ToResponse(), nil
 }
 
+// applyCoupleDefaults fills what a new event does not set from its creator and couple:
+// the time zone is the creator's, or else the couple's, and the reminder the couple's
+// default one
+func (s *EventService) applyCoupleDefaults(ctx context.Context, user *domain.User, event *domain.Event) {
+	if event.Timezone != "" && event.Reminder != nil {
+		return
+	}
+
+	settings, err := s.settingsService.GetByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to get couple settings, creating event without defaults", zap.Error(err))
+		settings = &domain.CoupleSettings{MatchCode: user.MatchCode}
+	}
+
+	if event.Timezone == "" {
+		event.Timezone = user.Timezone
+	}
+	if event.Timezone == "" {
+		event.Timezone = settings.Timezone
+	}
+
+	if event.Reminder == nil {
+		defaults := settings.EffectiveReminderDefaults()
+		if !defaults.Enabled {
+			return
+		}
+		offset, _ := domain.ParseTimeOfDay(defaults.Time)
+		reminderAt := domain.AtTimeOfDay(event.Date.AddDate(0, 0, -defaults.DaysBefore), offset, event.Zone())
+		if reminderAt.After(time.Now()) {
+			event.Reminder = &domain.EventReminder{Enabled: true, ReminderAt: reminderAt}
+		}
+	}
+}
+
 // GetEvent retrieves a specific event
 func (s *EventService) GetEvent(
 	ctx context.Context,
 	eventID, userID primitive.ObjectID,
 ) (*domain.EventResponse, error) {
-	s.logger.Info("Getting event",
+	logging.FromContext(ctx).Info("Getting event",
 		zap.String("event_id", eventID.Hex()),
 		zap.String("user_id", userID.Hex()))
 
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
-		s.logger.Error("Failed to get event", zap.Error(err))
-		return nil, fmt.Errorf("event not found: %w", err)
+		logging.FromContext(ctx).Error("Failed to get event", zap.Error(err))
+		return nil, domain.ErrNotFoundError("Event")
 	}
 
 	// Get user to verify match code
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		s.logger.Error("Failed to get user", zap.Error(err))
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		logging.FromContext(ctx).Error("Failed to get user", zap.Error(err))
+		return nil, domain.ErrUserNotFoundError()
 	}
 
 	// Check if user has access to this event
 	if event.MatchCode != user.MatchCode {
-		s.logger.Warn("Unauthorized access to event",
+		logging.FromContext(ctx).Warn("Unauthorized access to event",
 			zap.String("event_id", eventID.Hex()),
 			zap.String("user_id", userID.Hex()))
-		return nil, fmt.Errorf("unauthorized access to event")
+		return nil, domain.ErrForbiddenError()
+	}
+
+	// The partner's private events are not found
+	if !event.IsVisibleTo(userID) {
+		return nil, domain.ErrNotFoundError("Event")
 	}
 
 	return event.ToResponse(), nil
@@ -124,7 +178,7 @@ func (s *EventService) GetCoupleEvents(
 	userID primitive.ObjectID,
 	year, month, page, limit int,
 ) ([]*domain.EventResponse, int64, error) {
-	s.logger.Info("Getting couple events",
+	logging.FromContext(ctx).Info("Getting couple events",
 		zap.String("user_id", userID.Hex()),
 		zap.Int("year", year),
 		zap.Int("month", month),
@@ -134,32 +188,37 @@ func (s *EventService) GetCoupleEvents(
 	// Get user to get match code
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		s.logger.Error("Failed to get user", zap.Error(err))
-		return nil, 0, fmt.Errorf("failed to get user: %w", err)
+		logging.FromContext(ctx).Error("Failed to get user", zap.Error(err))
+		return nil, 0, domain.ErrUserNotFoundError()
 	}
 
 	if user.MatchCode == "" {
-		s.logger.Info("User is not matched, returning empty events")
+		logging.FromContext(ctx).Info("User is not matched, returning empty events")
 		return []*domain.EventResponse{}, 0, nil
 	}
 
 	var events []*domain.Event
+	var total int64
 
 	// If year and month are specified, filter by date range
 	if year > 0 && month > 0 {
 		startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
 		endDate := startDate.AddDate(0, 1, 0).Add(-time.Second)
 		
-		events, err = s.eventRepo.GetByMatchCodeAndDateRange(user.MatchCode, startDate, endDate)
+		events, err = s.eventRepo.GetByMatchCodeAndDateRange(user.MatchCode, user.ID, startDate, endDate)
+		total = int64(len(events))
 	} else {
 		// Get all couple events
 		offset := (page - 1) * limit
-		events, err = s.eventRepo.GetByMatchCode(user.MatchCode, limit, offset)
+		events, err = s.eventRepo.GetByMatchCode(user.MatchCode, user.ID, limit, offset)
+		if err == nil {
+			total, err = s.eventRepo.CountByMatchCode(user.MatchCode, user.ID)
+		}
 	}
 
 	if err != nil {
-		s.logger.Error("Failed to get couple events", zap.Error(err))
-		return nil, 0, fmt.Errorf("failed to get events: %w", err)
+		logging.FromContext(ctx).Error("Failed to get couple events", zap.Error(err))
+		return nil, 0, domain.ErrOperationFailedError("Failed to get events")
 	}
 
 	// Convert to responses
@@ -168,9 +227,7 @@ func (s *EventService) GetCoupleEvents(
 		responses[i] = event.ToResponse()
 	}
 
-	total := int64(len(responses))
-
-	s.logger.Info("Retrieved couple events",
+	logging.FromContext(ctx).Info("Retrieved couple events",
 		zap.String("user_id", userID.Hex()),
 		zap.Int64("total", total))
 
@@ -183,30 +240,38 @@ func (s *EventService) UpdateEvent(
 	eventID, userID primitive.ObjectID,
 	req *domain.UpdateEventRequest,
 ) (*domain.EventResponse, error) {
-	s.logger.Info("Updating event",
+	logging.FromContext(ctx).Info("Updating event",
 		zap.String("event_id", eventID.Hex()),
 		zap.String("user_id", userID.Hex()))
 
 	// Get existing event
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
-		s.logger.Error("Failed to get event for update", zap.Error(err))
-		return nil, fmt.Errorf("event not found: %w", err)
+		logging.FromContext(ctx).Error("Failed to get event for update", zap.Error(err))
+		return nil, domain.ErrNotFoundError("Event")
 	}
 
 	// Get user to verify match code
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		s.logger.Error("Failed to get user", zap.Error(err))
-		return nil, fmt.Errorf("failed to get user: %w", err)
+		logging.FromContext(ctx).Error("Failed to get user", zap.Error(err))
+		return nil, domain.ErrUserNotFoundError()
 	}
 
 	// Check ownership
 	if event.MatchCode != user.MatchCode {
-		s.logger.Warn("Unauthorized update attempt",
+		logging.FromContext(ctx).Warn("Unauthorized update attempt",
 			zap.String("event_id", eventID.Hex()),
 			zap.String("user_id", userID.Hex()))
-		return nil, fmt.Errorf("unauthorized to update this event")
+		return nil, domain.ErrForbiddenError()
+	}
+	if !event.IsVisibleTo(userID) {
+		return nil, domain.ErrNotFoundError("Event")
+	}
+
+	// Only the partner who created an event decides whether it is private
+	if req.IsPrivate != nil && *req.IsPrivate != event.IsPrivate && event.CreatedBy != userID {
+		return nil, domain.ErrForbiddenError()
 	}
 
 	// Update fields
@@ -214,16 +279,19 @@ func (s *EventService) UpdateEvent(
 		event.Title = req.Title
 	}
 	if req.Description != "" {
-		event.Description = req.Description
+		event.Description = sanitize.HTML(req.Description)
 	}
-	if req.Date != nil {
-		event.Date = *req.Date
+	if req.Date != nil && !req.Date.IsZero() {
+		event.Date = req.Date.Time
 	}
 	if req.Time != "" {
 		event.Time = req.Time
 	}
-	if req.Location != "" {
-		event.Location = req.Location
+	if req.Timezone != "" {
+		event.Timezone = req.Timezone
+	}
+	if req.Location != "" || req.Place != nil {
+		setPlace(&event.Location, &event.Place, req.Location, req.Place)
 	}
 	if req.EventType != "" {
 		event.EventType = req.EventType
@@ -245,11 +313,11 @@ func (s *EventService) UpdateEvent(
 
 	// Save updates
 	if err := s.eventRepo.Update(eventID, event); err != nil {
-		s.logger.Error("Failed to update event", zap.Error(err))
-		return nil, fmt.Errorf("failed to update event: %w", err)
+		logging.FromContext(ctx).Error("Failed to update event", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update event")
 	}
 
-	s.logger.Info("Event updated successfully",
+	logging.FromContext(ctx).Info("Event updated successfully",
 		zap.String("event_id", eventID.Hex()))
 
 	return event.ToResponse(), nil
@@ -260,40 +328,152 @@ func (s *EventService) DeleteEvent(
 	ctx context.Context,
 	eventID, userID primitive.ObjectID,
 ) error {
-	s.logger.Info("Deleting event",
+	logging.FromContext(ctx).Info("Deleting event",
 		zap.String("event_id", eventID.Hex()),
 		zap.String("user_id", userID.Hex()))
 
 	// Get event to check ownership
 	event, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
-		s.logger.Error("Failed to get event for deletion", zap.Error(err))
-		return fmt.Errorf("event not found: %w", err)
+		logging.FromContext(ctx).Error("Failed to get event for deletion", zap.Error(err))
+		return domain.ErrNotFoundError("Event")
 	}
 
 	// Get user to verify match code
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		s.logger.Error("Failed to get user", zap.Error(err))
-		return fmt.Errorf("failed to get user: %w", err)
+		logging.FromContext(ctx).Error("Failed to get user", zap.Error(err))
+		return domain.ErrUserNotFoundError()
 	}
 
 	// Check ownership
 	if event.MatchCode != user.MatchCode {
-		s.logger.Warn("Unauthorized delete attempt",
+		logging.FromContext(ctx).Warn("Unauthorized delete attempt",
 			zap.String("event_id", eventID.Hex()),
 			zap.String("user_id", userID.Hex()))
-		return fmt.Errorf("unauthorized to delete this event")
+		return domain.ErrForbiddenError()
+	}
+	if !event.IsVisibleTo(userID) {
+		return domain.ErrNotFoundError("Event")
 	}
 
 	// Delete event
 	if err := s.eventRepo.Delete(eventID); err != nil {
-		s.logger.Error("Failed to delete event", zap.Error(err))
-		return fmt.Errorf("failed to delete event: %w", err)
+		logging.FromContext(ctx).Error("Failed to delete event", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to delete event")
 	}
 
-	s.logger.Info("Event deleted successfully",
+	logging.FromContext(ctx).Info("Event deleted successfully",
 		zap.String("event_id", eventID.Hex()))
 
 	return nil
 }
+
+// BulkDeleteEvents moves several of the couple's events to the trash at once
+func (s *EventService) BulkDeleteEvents(
+	ctx context.Context,
+	userID primitive.ObjectID,
+	req *domain.BulkIDsRequest,
+) (*domain.BulkResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get user", zap.Error(err))
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	deleted, err := s.eventRepo.BulkDelete(user.MatchCode, user.ID, domain.ParseBulkIDs(req.IDs))
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to bulk delete events", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to delete events")
+	}
+
+	logging.FromContext(ctx).Info("Events bulk deleted",
+		zap.String("user_id", userID.Hex()),
+		zap.Int("requested", len(req.IDs)),
+		zap.Int("deleted", len(deleted)))
+
+	return domain.NewBulkResponse(req.IDs, deleted), nil
+}
+
+// SendDueReminders notifies couples of the events whose reminder is due: both partners,
+// or only the creator of a private event. Each reminder is sent once; reminders more
+// than a day late are dropped.
+func (s *EventService) SendDueReminders(ctx context.Context) error {
+	now := time.Now()
+
+	events, err := s.eventRepo.GetDueReminders(now, eventReminderBatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get due reminders: %w", err)
+	}
+
+	sent := 0
+	for _, event := range events {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		marked, err := s.eventRepo.MarkReminderNotified(event.ID)
+		if err != nil || !marked {
+			continue
+		}
+		if now.Sub(event.Reminder.ReminderAt) > eventReminderMaxDelay {
+			continue
+		}
+
+		if s.sendReminder(ctx, event) {
+			sent++
+		}
+	}
+
+	if sent > 0 {
+		logging.FromContext(ctx).Info("Event reminders sent", zap.Int("count", sent))
+	}
+
+	return nil
+}
+
+// sendReminder notifies the recipients of an event's reminder and reports whether any
+// of them was notified
+func (s *EventService) sendReminder(ctx context.Context, event *domain.Event) bool {
+	users, err := s.userRepo.ListByMatchCode(ctx, event.MatchCode)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to get couple for event reminder",
+			zap.Error(err),
+			zap.String("event_id", event.ID.Hex()))
+		return false
+	}
+
+	body := event.Reminder.Message
+	if body == "" {
+		body = event.Title
+	}
+	tmpl := domain.NotificationTemplate{
+		Key:    "event_reminder",
+		Params: map[string]interface{}{"Title": event.Title, "Body": body},
+	}
+	data := map[string]string{
+		"event_id":  event.ID.Hex(),
+		"starts_at": event.StartsAt().Format(time.RFC3339),
+	}
+
+	notified := false
+	for _, user := range users {
+		if !event.IsVisibleTo(user.ID) {
+			continue
+		}
+		if err := s.notificationService.Notify(ctx, user.ID, domain.NotificationTypeEventReminder, tmpl, data); err != nil {
+			logging.FromContext(ctx).Warn("Failed to notify event reminder",
+				zap.Error(err),
+				zap.String("event_id", event.ID.Hex()),
+				zap.String("user_id", user.ID.Hex()))
+			continue
+		}
+		notified = true
+	}
+
+	return notified
+}
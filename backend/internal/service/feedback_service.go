@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"strings"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// FeedbackService implements domain.FeedbackService
+type FeedbackService struct {
+	feedbackRepo domain.FeedbackRepository
+	userRepo     domain.UserRepository
+	mirror       domain.FeedbackMirror
+	rateLimit    int
+	rateWindow   time.Duration
+}
+
+// NewFeedbackService creates a new feedback service. A user may send at most
+// rateLimit pieces of feedback per rateWindow. mirror may be nil.
+func NewFeedbackService(
+	feedbackRepo domain.FeedbackRepository,
+	userRepo domain.UserRepository,
+	mirror domain.FeedbackMirror,
+	rateLimit int,
+	rateWindow time.Duration,
+) domain.FeedbackService {
+	return &FeedbackService{
+		feedbackRepo: feedbackRepo,
+		userRepo:     userRepo,
+		mirror:       mirror,
+		rateLimit:    rateLimit,
+		rateWindow:   rateWindow,
+	}
+}
+
+// SubmitFeedback stores feedback sent by a user and mirrors it in the background
+func (s *FeedbackService) SubmitFeedback(ctx context.Context, userID primitive.ObjectID, req *domain.CreateFeedbackRequest) (*domain.FeedbackResponse, error) {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	text := strings.TrimSpace(req.Text)
+	if req.Category == domain.FeedbackCategoryNPS {
+		if req.Rating == nil {
+			return nil, domain.ErrInvalidRequestError("NPS feedback needs a rating from 0 to 10")
+		}
+	} else {
+		if text == "" {
+			return nil, domain.ErrInvalidRequestError("Feedback text is required")
+		}
+		if req.Rating != nil && (*req.Rating < 1 || *req.Rating > 5) {
+			return nil, domain.ErrInvalidRequestError("Rating must be from 1 to 5")
+		}
+	}
+
+	sent, err := s.feedbackRepo.CountByUserSince(ctx, userID, time.Now().Add(-s.rateWindow))
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to send feedback")
+	}
+	if sent >= int64(s.rateLimit) {
+		logging.FromContext(ctx).Warn("Feedback rate limit reached",
+			zap.String("user_id", userID.Hex()),
+			zap.Int64("sent", sent))
+		return nil, domain.ErrTooManyRequestsError()
+	}
+
+	var attachments []string
+	for _, key := range req.Attachments {
+		if key = strings.TrimSpace(key); key != "" {
+			attachments = append(attachments, key)
+		}
+	}
+
+	feedback := &domain.Feedback{
+		UserID:      userID,
+		Category:    req.Category,
+		Rating:      req.Rating,
+		Text:        text,
+		AppVersion:  strings.TrimSpace(req.AppVersion),
+		Attachments: attachments,
+		Status:      domain.FeedbackStatusNew,
+	}
+
+	if err := s.feedbackRepo.Create(ctx, feedback); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to send feedback")
+	}
+
+	if s.mirror != nil {
+		go s.mirrorFeedback(logging.FromContext(ctx), *feedback)
+	}
+
+	logging.FromContext(ctx).Info("Feedback received",
+		zap.String("feedback_id", feedback.ID.Hex()),
+		zap.String("user_id", userID.Hex()),
+		zap.String("category", string(feedback.Category)))
+
+	return feedback.ToResponse(), nil
+}
+
+// mirrorFeedback copies feedback to the mirror and remembers the ID it got there.
+// It runs after the request has been answered, so failures are only logged, with
+// the request's logger.
+func (s *FeedbackService) mirrorFeedback(logger *zap.Logger, feedback domain.Feedback) {
+	ctx, cancel := context.WithTimeout(logging.NewContext(context.Background(), logger), 15*time.Second)
+	defer cancel()
+
+	mirrorID, err := s.mirror.MirrorFeedback(ctx, &feedback)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to mirror feedback",
+			zap.String("feedback_id", feedback.ID.Hex()),
+			zap.Error(err))
+		return
+	}
+
+	if err := s.feedbackRepo.SetMirrorID(ctx, feedback.ID, mirrorID); err != nil {
+		logging.FromContext(ctx).Warn("Failed to save feedback mirror ID",
+			zap.String("feedback_id", feedback.ID.Hex()),
+			zap.Error(err))
+	}
+}
+
+// ListFeedback retrieves feedback for the team, newest first, starting after cursor.
+// The returned next cursor is empty once the last page has been reached.
+func (s *FeedbackService) ListFeedback(ctx context.Context, filter domain.FeedbackFilter, cursor *domain.Cursor, limit int) (*domain.FeedbackListResponse, error) {
+	// Fetch one extra item to know whether another page exists
+	feedback, err := s.feedbackRepo.List(ctx, filter, cursor, limit+1)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to list feedback")
+	}
+
+	response := &domain.FeedbackListResponse{
+		Feedback: make([]*domain.FeedbackResponse, 0, len(feedback)),
+		Limit:    limit,
+	}
+
+	if len(feedback) > limit {
+		feedback = feedback[:limit]
+		last := feedback[len(feedback)-1]
+		response.NextCursor = domain.NewCursor(last.CreatedAt, last.ID).Encode()
+	}
+
+	for _, item := range feedback {
+		response.Feedback = append(response.Feedback, item.ToResponse())
+	}
+
+	return response, nil
+}
+
+// UpdateFeedbackStatus moves feedback along the triage workflow
+func (s *FeedbackService) UpdateFeedbackStatus(ctx context.Context, feedbackID primitive.ObjectID, req *domain.UpdateFeedbackStatusRequest) (*domain.FeedbackResponse, error) {
+	feedback, err := s.feedbackRepo.GetByID(ctx, feedbackID)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Feedback")
+	}
+
+	if !feedback.Status.CanTransitionTo(req.Status) {
+		return nil, domain.ErrInvalidStatusChangeError(string(feedback.Status), string(req.Status))
+	}
+
+	change := domain.FeedbackStatusChange{
+		From:      feedback.Status,
+		To:        req.Status,
+		Note:      strings.TrimSpace(req.Note),
+		ChangedAt: time.Now(),
+	}
+
+	if err := s.feedbackRepo.UpdateStatus(ctx, feedbackID, feedback.Status, change); err != nil {
+		// The feedback exists, so a miss means its status changed in the meantime
+		if errors.Is(err, domain.ErrFeedbackNotFound) {
+			return nil, domain.ErrInvalidStatusChangeError(string(feedback.Status), string(req.Status))
+		}
+		return nil, domain.ErrOperationFailedError("Failed to update feedback")
+	}
+
+	feedback.Status = change.To
+	feedback.StatusHistory = append(feedback.StatusHistory, change)
+	feedback.UpdatedAt = change.ChangedAt
+
+	logging.FromContext(ctx).Info("Feedback status changed",
+		zap.String("feedback_id", feedbackID.Hex()),
+		zap.String("from", string(change.From)),
+		zap.String("to", string(change.To)))
+
+	return feedback.ToResponse(), nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"path"
+	"strings"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// avatarFolder is the storage prefix of profile pictures, which are public
+const avatarFolder = "avatars/"
+
+// FileService implements domain.FileService
+type FileService struct {
+	photoRepo      domain.PhotoRepository
+	userRepo       domain.UserRepository
+	storageService domain.StorageService
+}
+
+// NewFileService creates a new file service
+func NewFileService(
+	photoRepo domain.PhotoRepository,
+	userRepo domain.UserRepository,
+	storageService domain.StorageService,
+) domain.FileService {
+	return &FileService{
+		photoRepo:      photoRepo,
+		userRepo:       userRepo,
+		storageService: storageService,
+	}
+}
+
+// OpenFile opens the file stored under key, if the user may read it. Files the user
+// may not read are reported as not found, so that their keys cannot be probed.
+func (s *FileService) OpenFile(ctx context.Context, userID primitive.ObjectID, key string) (*domain.StoredFile, error) {
+	// Clean the key against the storage root so that it cannot climb out of it
+	key = strings.TrimPrefix(path.Clean("/"+key), "/")
+	if key == "" {
+		return nil, domain.ErrNotFoundError("File")
+	}
+
+	allowed, err := s.canRead(ctx, userID, key)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, domain.ErrNotFoundError("File")
+	}
+
+	info, err := s.storageService.GetFileInfo(ctx, key)
+	if err != nil {
+		if errors.Is(err, domain.ErrFileNotFound) {
+			return nil, domain.ErrNotFoundError("File")
+		}
+		logging.FromContext(ctx).Error("Failed to get file info", zap.Error(err), zap.String("key", key))
+		return nil, domain.ErrOperationFailedError("Failed to get file")
+	}
+
+	content, err := s.storageService.GetObject(ctx, key)
+	if err != nil {
+		if errors.Is(err, domain.ErrFileNotFound) {
+			return nil, domain.ErrNotFoundError("File")
+		}
+		logging.FromContext(ctx).Error("Failed to open file", zap.Error(err), zap.String("key", key))
+		return nil, domain.ErrOperationFailedError("Failed to get file")
+	}
+
+	return &domain.StoredFile{
+		Content:     content,
+		ContentType: info.ContentType,
+		Size:        info.Size,
+		ModifiedAt:  info.UploadedAt,
+	}, nil
+}
+
+// canRead reports whether the user may read the file stored under key. Photos are
+// looked up by key; other files are stored under "<folder>/<uploader ID>/".
+func (s *FileService) canRead(ctx context.Context, userID primitive.ObjectID, key string) (bool, error) {
+	if strings.HasPrefix(key, avatarFolder) {
+		return true, nil
+	}
+	if userID.IsZero() {
+		return false, nil
+	}
+
+	photo, err := s.photoRepo.GetByStorageKey(ctx, key)
+	if err != nil {
+		return false, domain.ErrOperationFailedError("Failed to get file")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return false, domain.ErrUserNotFoundError()
+	}
+
+	if photo != nil {
+		return photo.CreatedBy == userID || (user.MatchCode != "" && photo.MatchCode == user.MatchCode && photo.IsVisibleTo(userID)), nil
+	}
+
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) < 3 {
+		return false, nil
+	}
+	uploaderID, err := primitive.ObjectIDFromHex(parts[1])
+	if err != nil {
+		return false, nil
+	}
+
+	return uploaderID == userID || (user.PartnerID != nil && *user.PartnerID == uploaderID), nil
+}
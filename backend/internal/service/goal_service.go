@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/email"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// goalDueSoonWindow is how far ahead of the target date a goal counts as due soon
+	goalDueSoonWindow = 7 * 24 * time.Hour
+	// goalReminderWindow is how far ahead of the target date reminders start being sent
+	goalReminderWindow = 3 * 24 * time.Hour
+	// goalReminderInterval is the minimum time between two reminders for the same goal
+	goalReminderInterval = 24 * time.Hour
+)
+
+// GoalService implements domain.GoalService
+type GoalService struct {
+	goalRepo     domain.GoalRepository
+	userRepo     domain.UserRepository
+	emailService *email.EmailService
+}
+
+// NewGoalService creates a new goal service
+func NewGoalService(
+	goalRepo domain.GoalRepository,
+	userRepo domain.UserRepository,
+	emailService *email.EmailService,
+) domain.GoalService {
+	return &GoalService{
+		goalRepo:     goalRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+	}
+}
+
+// CreateGoal creates a new couple goal
+func (s *GoalService) CreateGoal(ctx context.Context, userID primitive.ObjectID, req *domain.CreateGoalRequest) (*domain.GoalResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	goal := &domain.Goal{
+		MatchCode:   user.MatchCode,
+		CreatedBy:   userID,
+		Title:       req.Title,
+		Description: req.Description,
+		TargetDate:  req.TargetDate.ToTimePtr(),
+		Progress:    req.Progress,
+		Status:      domain.GoalStatusActive,
+		CheckIns:    []domain.GoalCheckIn{},
+	}
+	if goal.Progress == 100 {
+		now := time.Now()
+		goal.Status = domain.GoalStatusCompleted
+		goal.CompletedAt = &now
+	}
+
+	if err := s.goalRepo.Create(ctx, goal); err != nil {
+		logging.FromContext(ctx).Error("Failed to create goal", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create goal")
+	}
+
+	logging.FromContext(ctx).Info("Goal created",
+		zap.String("goal_id", goal.ID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return goal.ToResponse(), nil
+}
+
+// GetGoal retrieves a goal by ID
+func (s *GoalService) GetGoal(ctx context.Context, goalID, userID primitive.ObjectID) (*domain.GoalResponse, error) {
+	goal, err := s.getAuthorizedGoal(ctx, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return goal.ToResponse(), nil
+}
+
+// GetCoupleGoals retrieves goals for a couple with pagination
+func (s *GoalService) GetCoupleGoals(ctx context.Context, userID primitive.ObjectID, status domain.GoalStatus, page, limit int) ([]*domain.GoalResponse, int64, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, 0, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return []*domain.GoalResponse{}, 0, nil
+	}
+
+	offset := (page - 1) * limit
+
+	goals, err := s.goalRepo.GetByMatchCode(ctx, user.MatchCode, status, limit, offset)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get couple goals", zap.Error(err))
+		return nil, 0, domain.ErrOperationFailedError("Failed to get goals")
+	}
+
+	total, err := s.goalRepo.CountByMatchCode(ctx, user.MatchCode, status)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count couple goals", zap.Error(err))
+		return nil, 0, domain.ErrOperationFailedError("Failed to get goals")
+	}
+
+	responses := make([]*domain.GoalResponse, len(goals))
+	for i, goal := range goals {
+		responses[i] = goal.ToResponse()
+	}
+
+	return responses, total, nil
+}
+
+// UpdateGoal updates a goal
+func (s *GoalService) UpdateGoal(ctx context.Context, goalID, userID primitive.ObjectID, req *domain.UpdateGoalRequest) (*domain.GoalResponse, error) {
+	goal, err := s.getAuthorizedGoal(ctx, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != "" {
+		goal.Title = req.Title
+	}
+	if req.Description != "" {
+		goal.Description = req.Description
+	}
+	if req.TargetDate != nil {
+		goal.TargetDate = req.TargetDate.ToTimePtr()
+		// A new target date deserves a fresh reminder
+		goal.LastReminderAt = nil
+	}
+	if req.Status != "" && req.Status != goal.Status {
+		goal.Status = req.Status
+		if goal.Status == domain.GoalStatusCompleted {
+			now := time.Now()
+			goal.CompletedAt = &now
+		} else {
+			goal.CompletedAt = nil
+		}
+	}
+
+	if err := s.goalRepo.Update(ctx, goalID, goal); err != nil {
+		logging.FromContext(ctx).Error("Failed to update goal", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update goal")
+	}
+
+	return goal.ToResponse(), nil
+}
+
+// DeleteGoal deletes a goal
+func (s *GoalService) DeleteGoal(ctx context.Context, goalID, userID primitive.ObjectID) error {
+	if _, err := s.getAuthorizedGoal(ctx, goalID, userID); err != nil {
+		return err
+	}
+
+	if err := s.goalRepo.Delete(ctx, goalID); err != nil {
+		logging.FromContext(ctx).Error("Failed to delete goal", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to delete goal")
+	}
+
+	return nil
+}
+
+// CheckIn records a progress check-in on a goal
+func (s *GoalService) CheckIn(ctx context.Context, goalID, userID primitive.ObjectID, req *domain.GoalCheckInRequest) (*domain.GoalResponse, error) {
+	goal, err := s.getAuthorizedGoal(ctx, goalID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if goal.Status == domain.GoalStatusArchived {
+		return nil, domain.ErrInvalidRequestError("Cannot check in on an archived goal")
+	}
+
+	now := time.Now()
+	goal.CheckIns = append(goal.CheckIns, domain.GoalCheckIn{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		Progress:  *req.Progress,
+		Note:      req.Note,
+		CreatedAt: now,
+	})
+	goal.Progress = *req.Progress
+
+	if goal.Progress == 100 && goal.Status != domain.GoalStatusCompleted {
+		goal.Status = domain.GoalStatusCompleted
+		goal.CompletedAt = &now
+	} else if goal.Progress < 100 && goal.Status == domain.GoalStatusCompleted {
+		goal.Status = domain.GoalStatusActive
+		goal.CompletedAt = nil
+	}
+
+	if err := s.goalRepo.Update(ctx, goalID, goal); err != nil {
+		logging.FromContext(ctx).Error("Failed to save goal check-in", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to check in on goal")
+	}
+
+	logging.FromContext(ctx).Info("Goal check-in recorded",
+		zap.String("goal_id", goalID.Hex()),
+		zap.String("user_id", userID.Hex()),
+		zap.Int("progress", goal.Progress))
+
+	return goal.ToResponse(), nil
+}
+
+// GetSummary returns the dashboard summary of a couple's goals
+func (s *GoalService) GetSummary(ctx context.Context, userID primitive.ObjectID) (*domain.GoalSummaryResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	summary := &domain.GoalSummaryResponse{
+		DueSoon:     []*domain.GoalResponse{},
+		ActiveGoals: []*domain.GoalResponse{},
+	}
+
+	if user.MatchCode == "" {
+		return summary, nil
+	}
+
+	activeGoals, err := s.goalRepo.GetByMatchCode(ctx, user.MatchCode, domain.GoalStatusActive, 0, 0)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get active goals", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get goal summary")
+	}
+
+	completedCount, err := s.goalRepo.CountByMatchCode(ctx, user.MatchCode, domain.GoalStatusCompleted)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count completed goals", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get goal summary")
+	}
+
+	now := time.Now()
+	totalProgress := 0
+	for _, goal := range activeGoals {
+		response := goal.ToResponse()
+		summary.ActiveGoals = append(summary.ActiveGoals, response)
+		totalProgress += goal.Progress
+
+		if goal.TargetDate == nil {
+			continue
+		}
+		if goal.TargetDate.Before(now) {
+			summary.OverdueCount++
+		} else if goal.TargetDate.Before(now.Add(goalDueSoonWindow)) {
+			summary.DueSoon = append(summary.DueSoon, response)
+		}
+	}
+
+	summary.ActiveCount = int64(len(activeGoals))
+	summary.CompletedCount = completedCount
+	if len(activeGoals) > 0 {
+		summary.AverageProgress = totalProgress / len(activeGoals)
+	}
+
+	return summary, nil
+}
+
+// SendDueReminders emails both partners about active goals approaching their target date.
+// It is run periodically by the scheduler.
+func (s *GoalService) SendDueReminders(ctx context.Context) error {
+	now := time.Now()
+
+	goals, err := s.goalRepo.GetDueForReminder(ctx, now.Add(goalReminderWindow), now.Add(-goalReminderInterval))
+	if err != nil {
+		return fmt.Errorf("failed to get goals due for reminder: %w", err)
+	}
+
+	for _, goal := range goals {
+		// Don't nag about goals that are long overdue
+		if goal.TargetDate.Before(now.Add(-goalReminderWindow)) {
+			continue
+		}
+
+		for _, recipient := range s.getGoalRecipients(ctx, goal) {
+			if err := s.emailService.SendGoalReminderEmail(recipient.Name, recipient.Email, goal.Title, *goal.TargetDate, goal.Progress); err != nil {
+				logging.FromContext(ctx).Warn("Failed to send goal reminder",
+					zap.Error(err),
+					zap.String("goal_id", goal.ID.Hex()),
+					zap.String("user_id", recipient.ID.Hex()))
+			}
+		}
+
+		goal.LastReminderAt = &now
+		if err := s.goalRepo.Update(ctx, goal.ID, goal); err != nil {
+			logging.FromContext(ctx).Error("Failed to mark goal as reminded",
+				zap.Error(err),
+				zap.String("goal_id", goal.ID.Hex()))
+		}
+	}
+
+	if len(goals) > 0 {
+		logging.FromContext(ctx).Info("Goal reminders processed", zap.Int("goals", len(goals)))
+	}
+
+	return nil
+}
+
+// getGoalRecipients returns the goal creator and their partner
+func (s *GoalService) getGoalRecipients(ctx context.Context, goal *domain.Goal) []*domain.User {
+	creator, err := s.userRepo.GetByID(ctx, goal.CreatedBy)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to get goal creator", zap.Error(err), zap.String("goal_id", goal.ID.Hex()))
+		return nil
+	}
+
+	recipients := []*domain.User{creator}
+	if creator.PartnerID != nil {
+		if partner, err := s.userRepo.GetByID(ctx, *creator.PartnerID); err == nil {
+			recipients = append(recipients, partner)
+		}
+	}
+
+	return recipients
+}
+
+// getAuthorizedGoal loads a goal and verifies that it belongs to the user's couple
+func (s *GoalService) getAuthorizedGoal(ctx context.Context, goalID, userID primitive.ObjectID) (*domain.Goal, error) {
+	goal, err := s.goalRepo.GetByID(ctx, goalID)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Goal")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if goal.MatchCode != user.MatchCode {
+		return nil, domain.ErrForbiddenError()
+	}
+
+	return goal, nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"image"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/imaging"
+	"go.uber.org/zap"
+)
+
+// imageMetadataReadLimit is how much of an image is read for its metadata, which
+// comes before the image data
+const imageMetadataReadLimit = 256 * 1024
+
+// ImageService implements domain.ImageService
+type ImageService struct {
+	storageService domain.StorageService
+}
+
+// NewImageService creates a new image service
+func NewImageService(storageService domain.StorageService) domain.ImageService {
+	return &ImageService{
+		storageService: storageService,
+	}
+}
+
+// GenerateVariants downloads the image stored under key once, renders every size in
+// domain.ImageVariantSizes and stores each next to the original under a derived key
+// (e.g. "photos/u/beach.jpg" -> "photos/u/beach_thumb.jpg"). Variants are turned
+// upright as the original's metadata says, and are encoded without it.
+func (s *ImageService) GenerateVariants(ctx context.Context, key string) (map[domain.ImageVariant]string, error) {
+	img, format, err := s.loadImage(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make(map[domain.ImageVariant]string, len(domain.ImageVariantSizes))
+	for variant, maxDimension := range domain.ImageVariantSizes {
+		data, contentType, err := imaging.Encode(imaging.Resize(img, maxDimension), format)
+		if err != nil {
+			return nil, err
+		}
+
+		variantKey := imageVariantKey(key, variant, format)
+		if _, err := s.storageService.PutObject(ctx, variantKey, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+			return nil, fmt.Errorf("failed to store %s variant: %w", variant, err)
+		}
+
+		variants[variant] = variantKey
+	}
+
+	logging.FromContext(ctx).Info("Image variants generated",
+		zap.String("key", key),
+		zap.Int("variants", len(variants)))
+
+	return variants, nil
+}
+
+// ReadMetadata reads the EXIF metadata of the image stored under key
+func (s *ImageService) ReadMetadata(ctx context.Context, key string) (*domain.PhotoMetadata, error) {
+	reader, err := s.storageService.GetObject(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	defer reader.Close()
+
+	head, err := io.ReadAll(io.LimitReader(reader, imageMetadataReadLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	meta, err := imaging.ReadMetadata(head)
+	if errors.Is(err, imaging.ErrNoMetadata) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &domain.PhotoMetadata{
+		TakenAt:      meta.TakenAt,
+		Latitude:     meta.Latitude,
+		Longitude:    meta.Longitude,
+		CameraMake:   meta.Make,
+		CameraModel:  meta.Model,
+		LensModel:    meta.LensModel,
+		FocalLength:  meta.FocalLength,
+		FNumber:      meta.FNumber,
+		ExposureTime: meta.ExposureTime,
+		ISO:          meta.ISO,
+	}
+	if *metadata == (domain.PhotoMetadata{}) {
+		return nil, nil
+	}
+	return metadata, nil
+}
+
+// PerceptualHash returns the difference hash of the upright image stored under key,
+// as 16 hex digits
+func (s *ImageService) PerceptualHash(ctx context.Context, key string) (string, error) {
+	img, _, err := s.loadImage(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%016x", imaging.DifferenceHash(img)), nil
+}
+
+// loadImage downloads and decodes the image stored under key, turned upright as its
+// metadata says, with the format it should be re-encoded in
+func (s *ImageService) loadImage(ctx context.Context, key string) (image.Image, string, error) {
+	reader, err := s.storageService.GetObject(ctx, key)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read image: %w", err)
+	}
+	defer reader.Close()
+
+	original, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read image: %w", err)
+	}
+
+	img, format, err := imaging.Decode(bytes.NewReader(original))
+	if err != nil {
+		return nil, "", err
+	}
+	if meta, err := imaging.ReadMetadata(original); err == nil {
+		img = imaging.Orient(img, meta.Orientation)
+	}
+
+	return img, format, nil
+}
+
+// imageVariantKey derives the storage key of a variant from the original key
+func imageVariantKey(key string, variant domain.ImageVariant, format string) string {
+	ext := ".jpg"
+	if format == imaging.FormatPNG {
+		ext = ".png"
+	}
+
+	return fmt.Sprintf("%s_%s%s", strings.TrimSuffix(key, path.Ext(key)), variant, ext)
+}
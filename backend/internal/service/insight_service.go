@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// dayMilestones lists the number-of-days milestones celebrated from the anniversary date
+var dayMilestones = []int{100, 200, 365, 500, 730, 1000, 1095, 1500, 2000, 2500, 3000, 3650, 5000, 7300, 10000}
+
+// defaultInsightContent is used when a content key has not been published in the CMS
+var defaultInsightContent = map[string]string{
+	"insight.zodiac.aries":       "Bold and energetic, Aries charges into love head first.",
+	"insight.zodiac.taurus":      "Loyal and sensual, Taurus loves slow, steady devotion.",
+	"insight.zodiac.gemini":      "Curious and playful, Gemini keeps every conversation sparkling.",
+	"insight.zodiac.cancer":      "Caring and intuitive, Cancer builds a cozy home for love.",
+	"insight.zodiac.leo":         "Warm and generous, Leo loves with a big, bright heart.",
+	"insight.zodiac.virgo":       "Thoughtful and attentive, Virgo shows love in the little details.",
+	"insight.zodiac.libra":       "Charming and romantic, Libra seeks harmony in every moment.",
+	"insight.zodiac.scorpio":     "Passionate and devoted, Scorpio loves deeply and fiercely.",
+	"insight.zodiac.sagittarius": "Adventurous and honest, Sagittarius turns love into a journey.",
+	"insight.zodiac.capricorn":   "Steady and committed, Capricorn builds love to last.",
+	"insight.zodiac.aquarius":    "Original and free-spirited, Aquarius loves as a best friend.",
+	"insight.zodiac.pisces":      "Dreamy and tender, Pisces loves with endless empathy.",
+
+	"insight.life_path.1":  "The Leader: independent, driven and full of initiative.",
+	"insight.life_path.2":  "The Peacemaker: gentle, cooperative and deeply sensitive.",
+	"insight.life_path.3":  "The Communicator: creative, joyful and expressive.",
+	"insight.life_path.4":  "The Builder: practical, loyal and hard-working.",
+	"insight.life_path.5":  "The Adventurer: free-spirited, curious and adaptable.",
+	"insight.life_path.6":  "The Nurturer: caring, responsible and family-oriented.",
+	"insight.life_path.7":  "The Seeker: thoughtful, intuitive and wise.",
+	"insight.life_path.8":  "The Achiever: ambitious, confident and generous.",
+	"insight.life_path.9":  "The Humanitarian: compassionate, romantic and idealistic.",
+	"insight.life_path.11": "The Intuitive: inspiring, visionary and sensitive.",
+	"insight.life_path.22": "The Master Builder: turning big dreams into reality.",
+	"insight.life_path.33": "The Master Teacher: selfless, loving and uplifting.",
+
+	"insight.zodiac_compatibility.high":       "Your stars are beautifully aligned ({{score}}%).",
+	"insight.zodiac_compatibility.medium":     "Your signs balance each other nicely ({{score}}%).",
+	"insight.zodiac_compatibility.low":        "Opposites attract - your differences keep things exciting ({{score}}%).",
+	"insight.numerology_compatibility.high":   "Your life paths walk in perfect step ({{score}}%).",
+	"insight.numerology_compatibility.medium": "Your numbers complement each other ({{score}}%).",
+	"insight.numerology_compatibility.low":    "Your numbers challenge you to grow together ({{score}}%).",
+
+	"insight.milestone.reached":  "You celebrated {{days}} days together!",
+	"insight.milestone.upcoming": "{{days}} days together is coming in {{days_until}} days.",
+}
+
+// InsightService implements domain.InsightService
+type InsightService struct {
+	userRepo      domain.UserRepository
+	contentSource domain.ContentSource
+	cacheTTL      time.Duration
+
+	mu      sync.Mutex
+	content map[string]*cachedContent // by locale
+}
+
+// cachedContent is the copy of one locale read from the content source
+type cachedContent struct {
+	blocks    map[string]string
+	fetchedAt time.Time
+}
+
+// NewInsightService creates a new insight service. Copy is read from contentSource at
+// most once per cacheTTL and locale; a nil source means the built-in copy is used.
+func NewInsightService(
+	userRepo domain.UserRepository,
+	contentSource domain.ContentSource,
+	cacheTTL time.Duration,
+) domain.InsightService {
+	return &InsightService{
+		userRepo:      userRepo,
+		contentSource: contentSource,
+		cacheTTL:      cacheTTL,
+		content:       map[string]*cachedContent{},
+	}
+}
+
+// GetFunInsights computes zodiac, numerology and day milestone insights for the user's couple
+func (s *InsightService) GetFunInsights(
+	ctx context.Context,
+	userID primitive.ObjectID,
+	locale string,
+) (*domain.FunInsightsResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get user", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	var partner *domain.User
+	if user.PartnerID != nil {
+		partner, err = s.userRepo.GetByID(ctx, *user.PartnerID)
+		if err != nil {
+			// Insights about the user alone are still useful
+			logging.FromContext(ctx).Warn("Failed to get partner for insights",
+				zap.Error(err),
+				zap.String("partner_id", user.PartnerID.Hex()))
+			partner = nil
+		}
+	}
+
+	content := s.loadContent(ctx, locale)
+
+	response := &domain.FunInsightsResponse{
+		User:              s.buildPersonInsight(user, content),
+		ReachedMilestones: []domain.DayMilestone{},
+	}
+
+	if partner != nil {
+		partnerInsight := s.buildPersonInsight(partner, content)
+		response.Partner = &partnerInsight
+
+		if user.DateOfBirth != nil && partner.DateOfBirth != nil {
+			zodiacScore := domain.ZodiacCompatibilityScore(response.User.ZodiacSign, partnerInsight.ZodiacSign)
+			response.ZodiacCompatibility = &domain.CompatibilityInsight{
+				Score: zodiacScore,
+				Text:  content.render("insight.zodiac_compatibility."+compatibilityLevel(zodiacScore), map[string]int{"score": zodiacScore}),
+			}
+
+			numerologyScore := domain.NumerologyCompatibilityScore(response.User.LifePathNumber, partnerInsight.LifePathNumber)
+			response.NumerologyCompatibility = &domain.CompatibilityInsight{
+				Score: numerologyScore,
+				Text:  content.render("insight.numerology_compatibility."+compatibilityLevel(numerologyScore), map[string]int{"score": numerologyScore}),
+			}
+		}
+	}
+
+	if user.AnniversaryDate != nil {
+		response.AnniversaryDate = domain.DateFromTimePtr(user.AnniversaryDate)
+		s.applyDayMilestones(response, *user.AnniversaryDate, time.Now(), content)
+	}
+
+	return response, nil
+}
+
+// buildPersonInsight builds the zodiac and numerology profile for a user
+func (s *InsightService) buildPersonInsight(user *domain.User, content insightContent) domain.PersonInsight {
+	insight := domain.PersonInsight{
+		Name: user.Name,
+	}
+
+	if user.DateOfBirth == nil {
+		return insight
+	}
+
+	sign := domain.GetZodiacSign(*user.DateOfBirth)
+	lifePath := domain.LifePathNumber(*user.DateOfBirth)
+
+	insight.ZodiacSign = sign
+	insight.ZodiacElement = sign.Element()
+	insight.ZodiacText = content.get("insight.zodiac." + string(sign))
+	insight.LifePathNumber = lifePath
+	insight.LifePathText = content.get("insight.life_path." + strconv.Itoa(lifePath))
+
+	return insight
+}
+
+// applyDayMilestones fills days together and the reached/next milestones
+func (s *InsightService) applyDayMilestones(
+	response *domain.FunInsightsResponse,
+	anniversary time.Time,
+	now time.Time,
+	content insightContent,
+) {
+	start := truncateToDay(anniversary)
+	today := truncateToDay(now)
+
+	daysTogether := int(today.Sub(start).Hours() / 24)
+	if daysTogether < 0 {
+		daysTogether = 0
+	}
+	response.DaysTogether = daysTogether
+
+	for _, days := range dayMilestones {
+		milestone := domain.DayMilestone{
+			Days: days,
+			Date: domain.NewDate(start.AddDate(0, 0, days)),
+		}
+
+		if days <= daysTogether {
+			milestone.Text = content.render("insight.milestone.reached", map[string]int{"days": days})
+			response.ReachedMilestones = append(response.ReachedMilestones, milestone)
+			continue
+		}
+
+		milestone.DaysUntil = days - daysTogether
+		milestone.Text = content.render("insight.milestone.upcoming", map[string]int{
+			"days":       days,
+			"days_until": milestone.DaysUntil,
+		})
+		response.NextMilestone = &milestone
+		break
+	}
+}
+
+// loadContent fetches the insight copy for a locale, falling back to English and then to built-in defaults
+func (s *InsightService) loadContent(ctx context.Context, locale string) insightContent {
+	keys := make([]string, 0, len(defaultInsightContent))
+	for key := range defaultInsightContent {
+		keys = append(keys, key)
+	}
+
+	content := insightContent{}
+	locales := []string{locale}
+	if locale != "en" {
+		locales = append(locales, "en")
+	}
+
+	for _, loc := range locales {
+		for key, body := range s.contentBlocks(ctx, keys, loc) {
+			if _, exists := content[key]; !exists {
+				content[key] = body
+			}
+		}
+	}
+
+	return content
+}
+
+// contentBlocks returns the copy of a locale published in the content source, reading
+// it when the cached copy has expired. A stale copy is served if the source fails.
+func (s *InsightService) contentBlocks(ctx context.Context, keys []string, locale string) map[string]string {
+	if s.contentSource == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cached := s.content[locale]
+	if cached != nil && time.Since(cached.fetchedAt) < s.cacheTTL {
+		return cached.blocks
+	}
+
+	blocks, err := s.contentSource.GetContent(ctx, keys, locale)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to load insight content",
+			zap.Error(err),
+			zap.String("locale", locale))
+		if cached != nil {
+			return cached.blocks
+		}
+		return nil
+	}
+
+	s.content[locale] = &cachedContent{blocks: blocks, fetchedAt: time.Now()}
+	return blocks
+}
+
+// insightContent holds copy strings keyed by content key
+type insightContent map[string]string
+
+// get returns the copy for a key, falling back to the built-in default
+func (c insightContent) get(key string) string {
+	if body, ok := c[key]; ok {
+		return body
+	}
+	return defaultInsightContent[key]
+}
+
+// render returns the copy for a key with {{name}} placeholders replaced
+func (c insightContent) render(key string, data map[string]int) string {
+	text := c.get(key)
+	for name, value := range data {
+		text = strings.ReplaceAll(text, "{{"+name+"}}", strconv.Itoa(value))
+	}
+	return text
+}
+
+// compatibilityLevel maps a compatibility score to a content level
+func compatibilityLevel(score int) string {
+	switch {
+	case score >= 80:
+		return "high"
+	case score >= 60:
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// truncateToDay returns midnight UTC of the given time's calendar day
+func truncateToDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
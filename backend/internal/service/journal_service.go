@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// JournalService implements domain.JournalService
+type JournalService struct {
+	journalRepo domain.JournalRepository
+	userRepo    domain.UserRepository
+}
+
+// NewJournalService creates a new journal service
+func NewJournalService(
+	journalRepo domain.JournalRepository,
+	userRepo domain.UserRepository,
+) domain.JournalService {
+	return &JournalService{
+		journalRepo: journalRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// CreateEntry writes a journal entry, dated today unless the request gives a date
+func (s *JournalService) CreateEntry(ctx context.Context, userID primitive.ObjectID, req *domain.CreateJournalEntryRequest) (*domain.JournalEntryResponse, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	entry := &domain.JournalEntry{
+		MatchCode: user.MatchCode,
+		AuthorID:  userID,
+		Title:     req.Title,
+		Content:   req.Content,
+		Date:      domain.NewDate(now).Time,
+		IsPrivate: req.IsPrivate,
+	}
+	if req.Date != nil && !req.Date.IsZero() {
+		entry.Date = req.Date.Time
+	}
+	if entry.UnlockAt, err = unlockDate(req.UnlockAt, now); err != nil {
+		return nil, err
+	}
+
+	if err := s.journalRepo.Create(ctx, entry); err != nil {
+		logging.FromContext(ctx).Error("Failed to create journal entry", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create journal entry")
+	}
+
+	logging.FromContext(ctx).Info("Journal entry created",
+		zap.String("entry_id", entry.ID.Hex()),
+		zap.String("user_id", userID.Hex()),
+		zap.Bool("sealed", entry.UnlockAt != nil))
+
+	return entry.ToResponse(userID, now), nil
+}
+
+// GetEntry retrieves a journal entry as the user sees it
+func (s *JournalService) GetEntry(ctx context.Context, entryID, userID primitive.ObjectID) (*domain.JournalEntryResponse, error) {
+	entry, err := s.getVisibleEntry(ctx, entryID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry.ToResponse(userID, time.Now()), nil
+}
+
+// GetEntries retrieves the journal entries the user can see, newest date first
+func (s *JournalService) GetEntries(ctx context.Context, userID primitive.ObjectID, page, limit int) (*domain.JournalListResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	response := &domain.JournalListResponse{
+		Entries: []*domain.JournalEntryResponse{},
+		Page:    page,
+		Limit:   limit,
+	}
+
+	if user.MatchCode == "" {
+		return response, nil
+	}
+
+	offset := (page - 1) * limit
+
+	entries, err := s.journalRepo.GetVisible(ctx, user.MatchCode, userID, limit, offset)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get journal entries", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get journal entries")
+	}
+
+	response.Total, err = s.journalRepo.CountVisible(ctx, user.MatchCode, userID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count journal entries", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get journal entries")
+	}
+
+	now := time.Now()
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, entry.ToResponse(userID, now))
+	}
+
+	return response, nil
+}
+
+// UpdateEntry updates a journal entry; only its author may update it
+func (s *JournalService) UpdateEntry(ctx context.Context, entryID, userID primitive.ObjectID, req *domain.UpdateJournalEntryRequest) (*domain.JournalEntryResponse, error) {
+	entry, err := s.getVisibleEntry(ctx, entryID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.AuthorID != userID {
+		return nil, domain.ErrForbiddenError()
+	}
+
+	now := time.Now()
+	if req.Title != "" {
+		entry.Title = req.Title
+	}
+	if req.Content != "" {
+		entry.Content = req.Content
+	}
+	if req.Date != nil && !req.Date.IsZero() {
+		entry.Date = req.Date.Time
+	}
+	if req.IsPrivate != nil {
+		entry.IsPrivate = *req.IsPrivate
+	}
+	if req.UnlockAt != nil {
+		if entry.UnlockAt, err = unlockDate(req.UnlockAt, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.journalRepo.Update(ctx, entry); err != nil {
+		logging.FromContext(ctx).Error("Failed to update journal entry", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update journal entry")
+	}
+
+	logging.FromContext(ctx).Info("Journal entry updated",
+		zap.String("entry_id", entryID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return entry.ToResponse(userID, now), nil
+}
+
+// DeleteEntry deletes a journal entry; only its author may delete it
+func (s *JournalService) DeleteEntry(ctx context.Context, entryID, userID primitive.ObjectID) error {
+	entry, err := s.getVisibleEntry(ctx, entryID, userID)
+	if err != nil {
+		return err
+	}
+
+	if entry.AuthorID != userID {
+		return domain.ErrForbiddenError()
+	}
+
+	if err := s.journalRepo.Delete(ctx, entryID); err != nil {
+		logging.FromContext(ctx).Error("Failed to delete journal entry", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to delete journal entry")
+	}
+
+	logging.FromContext(ctx).Info("Journal entry deleted",
+		zap.String("entry_id", entryID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return nil
+}
+
+// getMatchedUser retrieves a user who has a partner
+func (s *JournalService) getMatchedUser(ctx context.Context, userID primitive.ObjectID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	return user, nil
+}
+
+// getVisibleEntry retrieves one of the couple's journal entries the user can see.
+// The partner's private entries are reported as not found.
+func (s *JournalService) getVisibleEntry(ctx context.Context, entryID, userID primitive.ObjectID) (*domain.JournalEntry, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.journalRepo.GetByID(ctx, user.MatchCode, entryID)
+	if err != nil || !entry.IsVisibleTo(userID) {
+		return nil, domain.ErrNotFoundError("Journal entry")
+	}
+
+	return entry, nil
+}
+
+// unlockDate validates the day an entry unseals on. No date, or an empty one, leaves
+// the entry unsealed; otherwise it must be a future day.
+func unlockDate(date *domain.Date, now time.Time) (*time.Time, error) {
+	if date == nil || date.IsZero() {
+		return nil, nil
+	}
+
+	if !date.Time.After(now) {
+		return nil, domain.ErrInvalidRequestError("Unlock date must be in the future")
+	}
+
+	unlockAt := date.Time
+	return &unlockAt, nil
+}
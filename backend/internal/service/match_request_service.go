@@ -2,10 +2,18 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
 	"time"
 
 	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/email"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"github.com/eralove/eralove-backend/internal/infrastructure/pii"
+	"github.com/eralove/eralove-backend/internal/infrastructure/qrcode"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
 )
@@ -13,20 +21,36 @@ import (
 // MatchRequestService implements domain.MatchRequestService
 type MatchRequestService struct {
 	matchRequestRepo domain.MatchRequestRepository
+	inviteRepo       domain.MatchInviteRepository
 	userRepo         domain.UserRepository
-	logger           *zap.Logger
+	emailService     *email.EmailService
+	autoMilestones   domain.AutoMilestoneService
+	events           domain.EventPublisher
+	inviteTTL        time.Duration
+	inviteBaseURL    string
 }
 
-// NewMatchRequestService creates a new match request service
+// NewMatchRequestService creates a new match request service. Invite links point to
+// frontendURL and expire after inviteTTL.
 func NewMatchRequestService(
 	matchRequestRepo domain.MatchRequestRepository,
+	inviteRepo domain.MatchInviteRepository,
 	userRepo domain.UserRepository,
-	logger *zap.Logger,
+	emailService *email.EmailService,
+	autoMilestones domain.AutoMilestoneService,
+	events domain.EventPublisher,
+	inviteTTL time.Duration,
+	frontendURL string,
 ) domain.MatchRequestService {
 	return &MatchRequestService{
 		matchRequestRepo: matchRequestRepo,
+		inviteRepo:       inviteRepo,
 		userRepo:         userRepo,
-		logger:           logger,
+		emailService:     emailService,
+		autoMilestones:   autoMilestones,
+		events:           events,
+		inviteTTL:        inviteTTL,
+		inviteBaseURL:    strings.TrimRight(frontendURL, "/") + "/invite/",
 	}
 }
 
@@ -36,30 +60,30 @@ func (s *MatchRequestService) SendMatchRequest(
 	senderID primitive.ObjectID,
 	req *domain.CreateMatchRequestRequest,
 ) (*domain.MatchRequestResponse, error) {
-	s.logger.Info("Sending match request",
+	logging.FromContext(ctx).Info("Sending match request",
 		zap.String("sender_id", senderID.Hex()),
-		zap.String("receiver_email", req.ReceiverEmail))
+		pii.Field("receiver_email", req.ReceiverEmail))
 
 	// Find receiver by email
 	receiver, err := s.userRepo.GetByEmail(ctx, req.ReceiverEmail)
 	if err != nil {
-		s.logger.Error("Receiver not found", zap.Error(err))
-		return nil, fmt.Errorf("receiver not found: %w", err)
+		logging.FromContext(ctx).Error("Receiver not found", zap.Error(err))
+		return nil, domain.ErrUserNotFoundError()
 	}
 
 	// Check if sender is trying to send request to themselves
 	if receiver.ID == senderID {
-		return nil, fmt.Errorf("cannot send match request to yourself")
+		return nil, domain.ErrInvalidRequestError("Cannot send a match request to yourself")
 	}
 
 	// Check if there's already a pending request
 	exists, err := s.matchRequestRepo.ExistsPendingRequest(senderID, receiver.ID)
 	if err != nil {
-		s.logger.Error("Failed to check pending request", zap.Error(err))
-		return nil, fmt.Errorf("failed to check existing requests: %w", err)
+		logging.FromContext(ctx).Error("Failed to check pending request", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to send match request")
 	}
 	if exists {
-		return nil, fmt.Errorf("you already have a pending request to this user")
+		return nil, domain.ErrMatchRequestExistsError()
 	}
 
 	// Create match request
@@ -68,7 +92,7 @@ func (s *MatchRequestService) SendMatchRequest(
 		SenderID:        senderID,
 		ReceiverID:      receiver.ID,
 		ReceiverEmail:   req.ReceiverEmail,
-		AnniversaryDate: req.AnniversaryDate,
+		AnniversaryDate: req.AnniversaryDate.Time,
 		Message:         req.Message,
 		Status:          domain.MatchRequestStatusPending,
 		CreatedAt:       time.Now(),
@@ -76,11 +100,11 @@ func (s *MatchRequestService) SendMatchRequest(
 	}
 
 	if err := s.matchRequestRepo.Create(matchRequest); err != nil {
-		s.logger.Error("Failed to create match request", zap.Error(err))
-		return nil, fmt.Errorf("failed to create match request: %w", err)
+		logging.FromContext(ctx).Error("Failed to create match request", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to send match request")
 	}
 
-	s.logger.Info("Match request sent successfully",
+	logging.FromContext(ctx).Info("Match request sent successfully",
 		zap.String("match_request_id", matchRequest.ID.Hex()))
 
 	// Get sender info for response
@@ -89,6 +113,10 @@ func (s *MatchRequestService) SendMatchRequest(
 	if sender != nil {
 		response.SenderName = sender.Name
 		response.SenderEmail = sender.Email
+
+		if err := s.emailService.SendMatchRequestReceivedEmail(receiver.Locale, receiver.Name, receiver.Email, sender.Name, matchRequest.Message); err != nil {
+			logging.FromContext(ctx).Warn("Failed to send match request email", zap.Error(err), zap.String("match_request_id", matchRequest.ID.Hex()))
+		}
 	}
 
 	return response, nil
@@ -101,12 +129,12 @@ func (s *MatchRequestService) GetMatchRequest(
 ) (*domain.MatchRequestResponse, error) {
 	matchRequest, err := s.matchRequestRepo.GetByID(requestID)
 	if err != nil {
-		return nil, err
+		return nil, domain.ErrNotFoundError("Match request")
 	}
 
 	// Verify user has access
 	if matchRequest.SenderID != userID && matchRequest.ReceiverID != userID {
-		return nil, fmt.Errorf("unauthorized access to match request")
+		return nil, domain.ErrForbiddenError()
 	}
 
 	response := matchRequest.ToResponse()
@@ -128,34 +156,28 @@ func (s *MatchRequestService) GetSentRequests(
 	status string,
 	page, limit int,
 ) ([]*domain.MatchRequestResponse, int64, error) {
-	s.logger.Info("Getting sent match requests",
+	logging.FromContext(ctx).Info("Getting sent match requests",
 		zap.String("user_id", userID.Hex()))
 
 	offset := (page - 1) * limit
-	matchRequests, err := s.matchRequestRepo.GetBySenderID(userID, limit, offset)
+	matchRequests, err := s.matchRequestRepo.GetBySenderIDAndStatus(userID, domain.MatchRequestStatus(status), limit, offset)
 	if err != nil {
-		s.logger.Error("Failed to get sent requests", zap.Error(err))
-		return nil, 0, fmt.Errorf("failed to get sent requests: %w", err)
+		logging.FromContext(ctx).Error("Failed to get sent requests", zap.Error(err))
+		return nil, 0, domain.ErrOperationFailedError("Failed to get sent match requests")
 	}
 
-	// Filter by status if provided
-	var filtered []*domain.MatchRequest
-	if status != "" {
-		for _, mr := range matchRequests {
-			if string(mr.Status) == status {
-				filtered = append(filtered, mr)
-			}
-		}
-	} else {
-		filtered = matchRequests
+	total, err := s.matchRequestRepo.CountBySenderIDAndStatus(userID, domain.MatchRequestStatus(status))
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count sent requests", zap.Error(err))
+		return nil, 0, domain.ErrOperationFailedError("Failed to get sent match requests")
 	}
 
-	responses := make([]*domain.MatchRequestResponse, len(filtered))
-	for i, mr := range filtered {
+	responses := make([]*domain.MatchRequestResponse, len(matchRequests))
+	for i, mr := range matchRequests {
 		responses[i] = mr.ToResponse()
 	}
 
-	return responses, int64(len(filtered)), nil
+	return responses, total, nil
 }
 
 // GetReceivedRequests gets match requests received by a user
@@ -165,39 +187,31 @@ func (s *MatchRequestService) GetReceivedRequests(
 	status string,
 	page, limit int,
 ) ([]*domain.MatchRequestResponse, int64, error) {
-	s.logger.Info("Getting received match requests",
+	logging.FromContext(ctx).Info("Getting received match requests",
 		zap.String("user_id", userID.Hex()),
 		zap.String("status", status),
 		zap.Int("page", page),
 		zap.Int("limit", limit))
 
 	offset := (page - 1) * limit
-	matchRequests, err := s.matchRequestRepo.GetByReceiverID(userID, limit, offset)
+	matchRequests, err := s.matchRequestRepo.GetByReceiverIDAndStatus(userID, domain.MatchRequestStatus(status), limit, offset)
 	if err != nil {
-		s.logger.Error("Failed to get received requests", zap.Error(err))
-		return nil, 0, fmt.Errorf("failed to get received requests: %w", err)
+		logging.FromContext(ctx).Error("Failed to get received requests", zap.Error(err))
+		return nil, 0, domain.ErrOperationFailedError("Failed to get received match requests")
 	}
 
-	s.logger.Info("Retrieved match requests from DB",
-		zap.Int("total_count", len(matchRequests)))
-
-	// Filter by status if provided
-	var filtered []*domain.MatchRequest
-	if status != "" {
-		for _, mr := range matchRequests {
-			if string(mr.Status) == status {
-				filtered = append(filtered, mr)
-			}
-		}
-		s.logger.Info("Filtered by status",
-			zap.String("status", status),
-			zap.Int("filtered_count", len(filtered)))
-	} else {
-		filtered = matchRequests
+	total, err := s.matchRequestRepo.CountByReceiverIDAndStatus(userID, domain.MatchRequestStatus(status))
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count received requests", zap.Error(err))
+		return nil, 0, domain.ErrOperationFailedError("Failed to get received match requests")
 	}
 
-	responses := make([]*domain.MatchRequestResponse, len(filtered))
-	for i, mr := range filtered {
+	logging.FromContext(ctx).Info("Retrieved match requests from DB",
+		zap.Int("count", len(matchRequests)),
+		zap.Int64("total", total))
+
+	responses := make([]*domain.MatchRequestResponse, len(matchRequests))
+	for i, mr := range matchRequests {
 		response := mr.ToResponse()
 		
 		// Get sender info
@@ -205,12 +219,12 @@ func (s *MatchRequestService) GetReceivedRequests(
 		if err == nil && sender != nil {
 			response.SenderName = sender.Name
 			response.SenderEmail = sender.Email
-			s.logger.Info("Populated sender info",
+			logging.FromContext(ctx).Info("Populated sender info",
 				zap.String("request_id", mr.ID.Hex()),
-				zap.String("sender_name", sender.Name),
-				zap.String("sender_email", sender.Email))
+				pii.Field("sender_name", sender.Name),
+				pii.Field("sender_email", sender.Email))
 		} else {
-			s.logger.Warn("Failed to get sender info",
+			logging.FromContext(ctx).Warn("Failed to get sender info",
 				zap.String("request_id", mr.ID.Hex()),
 				zap.String("sender_id", mr.SenderID.Hex()),
 				zap.Error(err))
@@ -219,10 +233,11 @@ func (s *MatchRequestService) GetReceivedRequests(
 		responses[i] = response
 	}
 
-	s.logger.Info("Returning received requests",
-		zap.Int("response_count", len(responses)))
+	logging.FromContext(ctx).Info("Returning received requests",
+		zap.Int("response_count", len(responses)),
+		zap.Int64("total", total))
 
-	return responses, int64(len(filtered)), nil
+	return responses, total, nil
 }
 
 // RespondToMatchRequest responds to a match request (accept/reject)
@@ -231,7 +246,7 @@ func (s *MatchRequestService) RespondToMatchRequest(
 	requestID, userID primitive.ObjectID,
 	req *domain.RespondToMatchRequestRequest,
 ) (*domain.MatchRequestResponse, error) {
-	s.logger.Info("Responding to match request",
+	logging.FromContext(ctx).Info("Responding to match request",
 		zap.String("request_id", requestID.Hex()),
 		zap.String("user_id", userID.Hex()),
 		zap.String("action", req.Action))
@@ -239,18 +254,18 @@ func (s *MatchRequestService) RespondToMatchRequest(
 	// Get the match request
 	matchRequest, err := s.matchRequestRepo.GetByID(requestID)
 	if err != nil {
-		s.logger.Error("Match request not found", zap.Error(err))
-		return nil, fmt.Errorf("match request not found: %w", err)
+		logging.FromContext(ctx).Error("Match request not found", zap.Error(err))
+		return nil, domain.ErrNotFoundError("Match request")
 	}
 
 	// Verify that the user is the receiver
 	if matchRequest.ReceiverID != userID {
-		return nil, fmt.Errorf("unauthorized to respond to this request")
+		return nil, domain.ErrForbiddenError()
 	}
 
 	// Check if already responded
 	if matchRequest.Status != domain.MatchRequestStatusPending {
-		return nil, fmt.Errorf("match request already responded to")
+		return nil, domain.ErrInvalidRequestError("Match request was already responded to")
 	}
 
 	// Update status based on action
@@ -267,72 +282,44 @@ func (s *MatchRequestService) RespondToMatchRequest(
 		// Get both users
 		sender, err := s.userRepo.GetByID(ctx, matchRequest.SenderID)
 		if err != nil {
-			s.logger.Error("Failed to get sender", zap.Error(err))
-			return nil, fmt.Errorf("failed to get sender: %w", err)
+			logging.FromContext(ctx).Error("Failed to get sender", zap.Error(err))
+			return nil, domain.ErrUserNotFoundError()
 		}
 		
 		receiver, err := s.userRepo.GetByID(ctx, matchRequest.ReceiverID)
 		if err != nil {
-			s.logger.Error("Failed to get receiver", zap.Error(err))
-			return nil, fmt.Errorf("failed to get receiver: %w", err)
+			logging.FromContext(ctx).Error("Failed to get receiver", zap.Error(err))
+			return nil, domain.ErrUserNotFoundError()
 		}
 		
 		// Determine which anniversary date to use
 		// Priority: 1. Receiver's override, 2. Original request date
 		var finalAnniversaryDate time.Time
-		if req.AnniversaryDate != nil {
+		if req.AnniversaryDate != nil && !req.AnniversaryDate.IsZero() {
 			// Receiver provided a different date when accepting
-			finalAnniversaryDate = *req.AnniversaryDate
-			s.logger.Info("Using receiver's anniversary date",
+			finalAnniversaryDate = req.AnniversaryDate.Time
+			logging.FromContext(ctx).Info("Using receiver's anniversary date",
 				zap.Time("anniversary_date", finalAnniversaryDate))
 		} else {
 			// Use the date from original match request
 			finalAnniversaryDate = matchRequest.AnniversaryDate
-			s.logger.Info("Using sender's anniversary date",
+			logging.FromContext(ctx).Info("Using sender's anniversary date",
 				zap.Time("anniversary_date", finalAnniversaryDate))
 		}
 		
-		// Update sender with match info
-		sender.PartnerID = &matchRequest.ReceiverID
-		sender.PartnerName = receiver.Name
-		sender.MatchCode = matchCode
-		sender.MatchedAt = &now
-		sender.AnniversaryDate = &finalAnniversaryDate
-		sender.UpdatedAt = now
-		
-		if err := s.userRepo.Update(ctx, sender.ID, sender); err != nil {
-			s.logger.Error("Failed to update sender", zap.Error(err))
-			return nil, fmt.Errorf("failed to update sender: %w", err)
-		}
-		
-		// Update receiver with match info
-		receiver.PartnerID = &matchRequest.SenderID
-		receiver.PartnerName = sender.Name
-		receiver.MatchCode = matchCode
-		receiver.MatchedAt = &now
-		receiver.AnniversaryDate = &finalAnniversaryDate
-		receiver.UpdatedAt = now
-		
-		if err := s.userRepo.Update(ctx, receiver.ID, receiver); err != nil {
-			s.logger.Error("Failed to update receiver", zap.Error(err))
-			return nil, fmt.Errorf("failed to update receiver: %w", err)
+		if err := s.linkPartners(ctx, sender, receiver, matchCode, finalAnniversaryDate, now); err != nil {
+			return nil, err
 		}
-		
-		s.logger.Info("Match created successfully",
-			zap.String("match_code", matchCode),
-			zap.String("sender_id", sender.ID.Hex()),
-			zap.String("receiver_id", receiver.ID.Hex()),
-			zap.Time("anniversary_date", finalAnniversaryDate))
 	} else {
 		matchRequest.Status = domain.MatchRequestStatusDeclined
 	}
 
 	if err := s.matchRequestRepo.Update(requestID, matchRequest); err != nil {
-		s.logger.Error("Failed to update match request", zap.Error(err))
-		return nil, fmt.Errorf("failed to update match request: %w", err)
+		logging.FromContext(ctx).Error("Failed to update match request", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to respond to match request")
 	}
 
-	s.logger.Info("Match request responded successfully",
+	logging.FromContext(ctx).Info("Match request responded successfully",
 		zap.String("request_id", requestID.Hex()),
 		zap.String("status", string(matchRequest.Status)))
 
@@ -342,6 +329,10 @@ func (s *MatchRequestService) RespondToMatchRequest(
 	if sender != nil {
 		response.SenderName = sender.Name
 		response.SenderEmail = sender.Email
+
+		if receiver, err := s.userRepo.GetByID(ctx, matchRequest.ReceiverID); err == nil {
+			s.sendResponseEmail(ctx, matchRequest, sender, receiver)
+		}
 	}
 
 	return response, nil
@@ -352,34 +343,295 @@ func (s *MatchRequestService) CancelMatchRequest(
 	ctx context.Context,
 	requestID, userID primitive.ObjectID,
 ) error {
-	s.logger.Info("Canceling match request",
+	logging.FromContext(ctx).Info("Canceling match request",
 		zap.String("request_id", requestID.Hex()),
 		zap.String("user_id", userID.Hex()))
 
 	// Get the match request
 	matchRequest, err := s.matchRequestRepo.GetByID(requestID)
 	if err != nil {
-		s.logger.Error("Match request not found", zap.Error(err))
-		return fmt.Errorf("match request not found: %w", err)
+		logging.FromContext(ctx).Error("Match request not found", zap.Error(err))
+		return domain.ErrNotFoundError("Match request")
 	}
 
 	// Verify that the user is the sender
 	if matchRequest.SenderID != userID {
-		return fmt.Errorf("unauthorized to cancel this request")
+		return domain.ErrForbiddenError()
 	}
 
 	// Can only cancel pending requests
 	if matchRequest.Status != domain.MatchRequestStatusPending {
-		return fmt.Errorf("can only cancel pending requests")
+		return domain.ErrInvalidRequestError("Only pending match requests can be cancelled")
 	}
 
 	if err := s.matchRequestRepo.Delete(requestID); err != nil {
-		s.logger.Error("Failed to delete match request", zap.Error(err))
-		return fmt.Errorf("failed to cancel match request: %w", err)
+		logging.FromContext(ctx).Error("Failed to delete match request", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to cancel match request")
 	}
 
-	s.logger.Info("Match request canceled successfully",
+	logging.FromContext(ctx).Info("Match request canceled successfully",
 		zap.String("request_id", requestID.Hex()))
 
 	return nil
 }
+
+// linkPartners records the match on both users
+func (s *MatchRequestService) linkPartners(
+	ctx context.Context,
+	sender, receiver *domain.User,
+	matchCode string,
+	anniversaryDate time.Time,
+	now time.Time,
+) error {
+	sender.PartnerID = &receiver.ID
+	sender.PartnerName = receiver.Name
+	sender.MatchCode = matchCode
+	sender.MatchedAt = &now
+	sender.AnniversaryDate = &anniversaryDate
+	sender.UpdatedAt = now
+
+	receiver.PartnerID = &sender.ID
+	receiver.PartnerName = sender.Name
+	receiver.MatchCode = matchCode
+	receiver.MatchedAt = &now
+	receiver.AnniversaryDate = &anniversaryDate
+	receiver.UpdatedAt = now
+
+	// Each partner is only linked while still unmatched, so a concurrent match of
+	// either of them makes this one fail instead of overwriting it
+	linked, err := s.userRepo.LinkPartner(ctx, sender)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to update sender", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to link partners")
+	}
+	if !linked {
+		return domain.ErrAlreadyMatchedError()
+	}
+
+	linked, err = s.userRepo.LinkPartner(ctx, receiver)
+	if err != nil || !linked {
+		// Free the sender again, whose link no longer has a counterpart
+		if unlinkErr := s.userRepo.UnlinkPartner(ctx, sender.ID, receiver.ID); unlinkErr != nil {
+			logging.FromContext(ctx).Error("Failed to unlink sender after a failed match",
+				zap.Error(unlinkErr),
+				zap.String("sender_id", sender.ID.Hex()))
+		}
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to update receiver", zap.Error(err))
+			return domain.ErrOperationFailedError("Failed to link partners")
+		}
+		return domain.ErrAlreadyMatchedError()
+	}
+
+	logging.FromContext(ctx).Info("Match created successfully",
+		zap.String("match_code", matchCode),
+		zap.String("sender_id", sender.ID.Hex()),
+		zap.String("receiver_id", receiver.ID.Hex()),
+		zap.Time("anniversary_date", anniversaryDate))
+
+	s.events.Publish(ctx, domain.DomainEventMatchAccepted, &domain.MatchAcceptedData{
+		MatchCode:       matchCode,
+		UserIDs:         []string{sender.ID.Hex(), receiver.ID.Hex()},
+		AnniversaryDate: domain.DateFromTimePtr(&anniversaryDate),
+	})
+
+	// The match stands even if its milestone events could not be generated; the
+	// scheduler fills them in on its next run
+	if err := s.autoMilestones.GenerateForCouple(ctx, matchCode, anniversaryDate); err != nil {
+		logging.FromContext(ctx).Warn("Failed to generate milestone events",
+			zap.Error(err),
+			zap.String("match_code", matchCode))
+	}
+
+	return nil
+}
+
+// inviteCodeAlphabet leaves out characters that are easily confused, such as 0 and O
+// or 1 and I, for codes read aloud or typed by hand. Its 32 characters make each
+// random byte map to one without bias.
+const inviteCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+const (
+	inviteCodeLength = 8
+	// inviteQRModuleSize is the width in pixels of a QR code module
+	inviteQRModuleSize = 8
+)
+
+// CreateInvite creates an invite code, replacing the user's previous one
+func (s *MatchRequestService) CreateInvite(
+	ctx context.Context,
+	senderID primitive.ObjectID,
+	req *domain.CreateMatchInviteRequest,
+) (*domain.MatchInviteResponse, error) {
+	sender, err := s.userRepo.GetByID(ctx, senderID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+	if sender.PartnerID != nil {
+		return nil, domain.ErrAlreadyMatchedError()
+	}
+
+	code, err := newInviteCode()
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to generate invite code", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create invite")
+	}
+
+	link := s.inviteBaseURL + code
+	qr, err := qrcode.Encode([]byte(link))
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to encode invite link", zap.Error(err), zap.String("link", link))
+		return nil, domain.ErrOperationFailedError("Failed to create invite")
+	}
+	qrPNG, err := qr.PNG(inviteQRModuleSize)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to render invite QR code", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create invite")
+	}
+
+	invite := &domain.MatchInvite{
+		CodeHash:        hashInviteCode(code),
+		SenderID:        senderID,
+		AnniversaryDate: req.AnniversaryDate.Time,
+		Message:         req.Message,
+		ExpiresAt:       time.Now().Add(s.inviteTTL),
+	}
+	if err := s.inviteRepo.Replace(ctx, invite); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to create invite")
+	}
+
+	logging.FromContext(ctx).Info("Match invite created",
+		zap.String("sender_id", senderID.Hex()),
+		zap.Time("expires_at", invite.ExpiresAt))
+
+	return &domain.MatchInviteResponse{
+		Code:      code,
+		Link:      link,
+		QRCode:    "data:image/png;base64," + base64.StdEncoding.EncodeToString(qrPNG),
+		ExpiresAt: invite.ExpiresAt,
+	}, nil
+}
+
+// AcceptInvite redeems an invite code. The match is recorded as an accepted match
+// request from the invite's sender.
+func (s *MatchRequestService) AcceptInvite(
+	ctx context.Context,
+	userID primitive.ObjectID,
+	req *domain.AcceptMatchInviteRequest,
+) (*domain.MatchRequestResponse, error) {
+	now := time.Now()
+
+	invite, err := s.inviteRepo.GetByCodeHash(ctx, hashInviteCode(normalizeInviteCode(req.Code)), now)
+	if err != nil {
+		return nil, domain.ErrMatchInviteExpiredError()
+	}
+	if invite.SenderID == userID {
+		return nil, domain.ErrInvalidRequestError("You cannot accept your own invite")
+	}
+
+	sender, err := s.userRepo.GetByID(ctx, invite.SenderID)
+	if err != nil {
+		return nil, domain.ErrMatchInviteExpiredError()
+	}
+	receiver, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+	if sender.PartnerID != nil || receiver.PartnerID != nil {
+		return nil, domain.ErrAlreadyMatchedError()
+	}
+
+	// Consuming the invite first keeps two partners from redeeming it at once
+	consumed, err := s.inviteRepo.Consume(ctx, invite.ID)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to accept invite")
+	}
+	if !consumed {
+		return nil, domain.ErrMatchInviteExpiredError()
+	}
+
+	anniversaryDate := invite.AnniversaryDate
+	if req.AnniversaryDate != nil && !req.AnniversaryDate.IsZero() {
+		anniversaryDate = req.AnniversaryDate.Time
+	}
+
+	matchRequest := &domain.MatchRequest{
+		ID:              primitive.NewObjectID(),
+		SenderID:        sender.ID,
+		ReceiverID:      receiver.ID,
+		ReceiverEmail:   receiver.Email,
+		AnniversaryDate: anniversaryDate,
+		Message:         invite.Message,
+		Status:          domain.MatchRequestStatusAccepted,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		RespondedAt:     &now,
+	}
+	if err := s.matchRequestRepo.Create(matchRequest); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to accept invite")
+	}
+
+	matchCode := domain.GenerateMatchCode(sender.ID, receiver.ID)
+	if err := s.linkPartners(ctx, sender, receiver, matchCode, anniversaryDate, now); err != nil {
+		return nil, err
+	}
+
+	s.sendResponseEmail(ctx, matchRequest, sender, receiver)
+
+	response := matchRequest.ToResponse()
+	response.SenderName = sender.Name
+	response.SenderEmail = sender.Email
+
+	return response, nil
+}
+
+// sendResponseEmail tells the sender of a match request that it was accepted or
+// declined, in the sender's language. Failures are logged; the response stands.
+func (s *MatchRequestService) sendResponseEmail(ctx context.Context, matchRequest *domain.MatchRequest, sender, receiver *domain.User) {
+	var err error
+	switch matchRequest.Status {
+	case domain.MatchRequestStatusAccepted:
+		err = s.emailService.SendMatchAcceptedEmail(sender.Locale, sender.Name, sender.Email, receiver.Name)
+	case domain.MatchRequestStatusDeclined:
+		err = s.emailService.SendMatchDeclinedEmail(sender.Locale, sender.Name, sender.Email, receiver.Name)
+	default:
+		return
+	}
+
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to send match response email",
+			zap.Error(err),
+			zap.String("match_request_id", matchRequest.ID.Hex()),
+			zap.String("status", string(matchRequest.Status)))
+	}
+}
+
+// newInviteCode returns a random invite code
+func newInviteCode() (string, error) {
+	bytes := make([]byte, inviteCodeLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	code := make([]byte, inviteCodeLength)
+	for i, b := range bytes {
+		code[i] = inviteCodeAlphabet[int(b)%len(inviteCodeAlphabet)]
+	}
+	return string(code), nil
+}
+
+// normalizeInviteCode accepts codes typed in lower case or with separators
+func normalizeInviteCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(code)))
+}
+
+// hashInviteCode returns the hex SHA-256 of an invite code, which is what gets stored
+func hashInviteCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
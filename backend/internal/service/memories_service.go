@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"sort"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/email"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// memoriesDigestHour is the hour of the day, in each user's time zone, from which
+// digests are sent
+const memoriesDigestHour = 8
+
+// MemoriesService implements domain.MemoriesService
+type MemoriesService struct {
+	photoRepo           domain.PhotoRepository
+	eventRepo           domain.EventRepository
+	albumRepo           domain.AlbumRepository
+	userRepo            domain.UserRepository
+	settingsService     domain.CoupleSettingsService
+	notificationService domain.NotificationService
+	emailService        *email.EmailService
+}
+
+// NewMemoriesService creates a new memories service
+func NewMemoriesService(
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	albumRepo domain.AlbumRepository,
+	userRepo domain.UserRepository,
+	settingsService domain.CoupleSettingsService,
+	notificationService domain.NotificationService,
+	emailService *email.EmailService,
+) domain.MemoriesService {
+	return &MemoriesService{
+		photoRepo:           photoRepo,
+		eventRepo:           eventRepo,
+		albumRepo:           albumRepo,
+		userRepo:            userRepo,
+		settingsService:     settingsService,
+		notificationService: notificationService,
+		emailService:        emailService,
+	}
+}
+
+// GetOnThisDay lists the couple's photos and events from the calendar day of date in
+// earlier years, grouped by year, most recent year first. A zero date is today in the
+// user's time zone.
+func (s *MemoriesService) GetOnThisDay(ctx context.Context, userID primitive.ObjectID, date time.Time) (*domain.MemoriesResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	day := domain.Today(user.Location())
+	if !date.IsZero() {
+		day = truncateToDay(date)
+	}
+	response := &domain.MemoriesResponse{
+		Date:       domain.NewDate(day),
+		Years:      []*domain.MemoryYear{},
+		Formatting: s.settingsService.FormattingHints(ctx, userID),
+	}
+
+	if user.MatchCode == "" {
+		return response, nil
+	}
+
+	photos, err := s.photoRepo.GetOnThisDay(ctx, user.MatchCode, user.ID, day)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get memories")
+	}
+	events, err := s.eventRepo.GetOnThisDay(user.MatchCode, user.ID, day)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get memories")
+	}
+
+	photoResponses := make([]*domain.PhotoResponse, len(photos))
+	for i, photo := range photos {
+		photoResponses[i] = photo.ToResponse()
+		photoResponses[i].IsFavorite = photo.IsFavoriteOf(user.ID)
+	}
+	hideDeletedAlbums(ctx, s.albumRepo, user.MatchCode, photoResponses)
+
+	years := make(map[int]*domain.MemoryYear)
+	yearOf := func(t time.Time) *domain.MemoryYear {
+		year := t.UTC().Year()
+		if years[year] == nil {
+			years[year] = &domain.MemoryYear{
+				Year:     year,
+				YearsAgo: day.Year() - year,
+				Photos:   []*domain.PhotoResponse{},
+				Events:   []*domain.EventResponse{},
+			}
+			response.Years = append(response.Years, years[year])
+		}
+		return years[year]
+	}
+
+	for i, photo := range photos {
+		memoryYear := yearOf(photo.Date)
+		memoryYear.Photos = append(memoryYear.Photos, photoResponses[i])
+	}
+	for _, event := range events {
+		memoryYear := yearOf(event.Date)
+		memoryYear.Events = append(memoryYear.Events, event.ToResponse())
+	}
+
+	sort.Slice(response.Years, func(i, j int) bool {
+		return response.Years[i].Year > response.Years[j].Year
+	})
+	response.Total = len(photos) + len(events)
+
+	return response, nil
+}
+
+// SendDigests sends today's memories digest to the partners of every couple with
+// photos or events from this day in earlier years, as they chose: in-app, by email
+// or both. Today and the sending hour are those of each user's time zone. Each user is
+// sent at most one digest a day.
+func (s *MemoriesService) SendDigests(ctx context.Context) error {
+	now := time.Now()
+
+	// Users' days range from the day before to the day after the UTC one
+	utcToday := truncateToDay(now)
+	couplesByDay := make(map[string]map[string]bool)
+	var matchCodes []string
+	seen := make(map[string]bool)
+	for _, day := range []time.Time{utcToday.AddDate(0, 0, -1), utcToday, utcToday.AddDate(0, 0, 1)} {
+		couples, err := s.couplesOnThisDay(ctx, day)
+		if err != nil {
+			return err
+		}
+		couplesByDay[domain.NewDate(day).String()] = couples
+
+		for matchCode := range couples {
+			if !seen[matchCode] {
+				seen[matchCode] = true
+				matchCodes = append(matchCodes, matchCode)
+			}
+		}
+	}
+	sort.Strings(matchCodes)
+
+	sent := 0
+	for _, matchCode := range matchCodes {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		users, err := s.userRepo.ListByMatchCode(ctx, matchCode)
+		if err != nil {
+			logging.FromContext(ctx).Warn("Failed to get couple for memories digest",
+				zap.Error(err),
+				zap.String("match_code", matchCode))
+			continue
+		}
+
+		for _, user := range users {
+			location := user.Location()
+			if now.In(location).Hour() < memoriesDigestHour {
+				continue
+			}
+			today := domain.DayIn(now, location)
+			if !couplesByDay[domain.NewDate(today).String()][matchCode] {
+				continue
+			}
+			if s.sendDigest(ctx, user, today) {
+				sent++
+			}
+		}
+	}
+
+	if sent > 0 {
+		logging.FromContext(ctx).Info("Memories digests sent", zap.Int("count", sent))
+	}
+
+	return nil
+}
+
+// couplesOnThisDay returns the couples with photos or events from the calendar day of
+// day in earlier years
+func (s *MemoriesService) couplesOnThisDay(ctx context.Context, day time.Time) (map[string]bool, error) {
+	photoCouples, err := s.photoRepo.ListMatchCodesOnThisDay(ctx, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list couples with memories: %w", err)
+	}
+	eventCouples, err := s.eventRepo.ListMatchCodesOnThisDay(day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list couples with memories: %w", err)
+	}
+
+	couples := make(map[string]bool, len(photoCouples)+len(eventCouples))
+	for _, matchCode := range append(photoCouples, eventCouples...) {
+		couples[matchCode] = true
+	}
+	return couples, nil
+}
+
+// sendDigest sends today's digest to a user who opted into it and was not sent it yet,
+// and reports whether it did
+func (s *MemoriesService) sendDigest(ctx context.Context, user *domain.User, today time.Time) bool {
+	digest := user.MemoriesDigestOrDefault()
+	if !digest.Push() && !digest.Email() {
+		return false
+	}
+
+	// The couple's memories of the day may all be the partner's private ones
+	if !s.hasMemories(ctx, user, today) {
+		return false
+	}
+
+	marked, err := s.userRepo.MarkMemoriesDigestSent(ctx, user.ID, today)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to record memories digest",
+			zap.Error(err),
+			zap.String("user_id", user.ID.Hex()))
+		return false
+	}
+	if !marked {
+		return false
+	}
+
+	date := domain.NewDate(today).String()
+
+	if digest.Push() {
+		tmpl := domain.NotificationTemplate{Key: "memories"}
+		data := map[string]string{"date": date}
+		if err := s.notificationService.Notify(ctx, user.ID, domain.NotificationTypeMemories, tmpl, data); err != nil {
+			logging.FromContext(ctx).Warn("Failed to notify memories digest",
+				zap.Error(err),
+				zap.String("user_id", user.ID.Hex()))
+		}
+	}
+
+	if digest.Email() {
+		if err := s.emailService.SendMemoriesDigestEmail(user.Locale, user.Name, user.Email, date); err != nil {
+			logging.FromContext(ctx).Warn("Failed to send memories digest email",
+				zap.Error(err),
+				zap.String("user_id", user.ID.Hex()))
+		}
+	}
+
+	return true
+}
+
+// hasMemories reports whether the user can see any of the couple's photos or events
+// from the calendar day of today in earlier years
+func (s *MemoriesService) hasMemories(ctx context.Context, user *domain.User, today time.Time) bool {
+	photos, err := s.photoRepo.GetOnThisDay(ctx, user.MatchCode, user.ID, today)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to get memories for digest",
+			zap.Error(err),
+			zap.String("user_id", user.ID.Hex()))
+		return false
+	}
+	if len(photos) > 0 {
+		return true
+	}
+
+	events, err := s.eventRepo.GetOnThisDay(user.MatchCode, user.ID, today)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to get memories for digest",
+			zap.Error(err),
+			zap.String("user_id", user.ID.Hex()))
+		return false
+	}
+	return len(events) > 0
+}
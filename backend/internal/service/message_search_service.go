@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// MessageSearchService implements domain.MessageSearchService
+type MessageSearchService struct {
+	messageRepo domain.MessageRepository
+	userRepo    domain.UserRepository
+}
+
+// NewMessageSearchService creates a new message search service
+func NewMessageSearchService(
+	messageRepo domain.MessageRepository,
+	userRepo domain.UserRepository,
+) domain.MessageSearchService {
+	return &MessageSearchService{
+		messageRepo: messageRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// SearchMessages searches the conversation between the user and their partner
+func (s *MessageSearchService) SearchMessages(ctx context.Context, userID primitive.ObjectID, query string, cursor *domain.Cursor, limit int) (*domain.MessageSearchResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.PartnerID == nil {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	// Fetch one extra message to know whether another page exists
+	messages, err := s.messageRepo.Search(ctx, userID, *user.PartnerID, query, cursor, limit+1)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to search messages")
+	}
+
+	response := &domain.MessageSearchResponse{
+		Messages: make([]*domain.MessageResponse, 0, min(len(messages), limit)),
+		Query:    query,
+		Limit:    limit,
+	}
+
+	if len(messages) > limit {
+		messages = messages[:limit]
+		last := messages[limit-1]
+		response.NextCursor = domain.NewCursor(last.CreatedAt, last.ID).Encode()
+	}
+	for _, message := range messages {
+		response.Messages = append(response.Messages, message.ToResponse())
+	}
+
+	return response, nil
+}
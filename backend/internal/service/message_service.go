@@ -0,0 +1,503 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"io"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/sanitize"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// conversationExportBatchSize is the number of messages read at a time while exporting
+	conversationExportBatchSize = 500
+	// conversationExportPurpose binds encrypted exports to what they are
+	conversationExportPurpose = "conversation_export"
+)
+
+// MessageService implements domain.MessageService
+type MessageService struct {
+	messageRepo    domain.MessageRepository
+	userRepo       domain.UserRepository
+	pendingActions domain.PendingActionService
+	storage        domain.StorageService
+	notifications  domain.NotificationService
+	coupleKeys     domain.CoupleKeyService
+	receipts       domain.MessageReceiptRepository
+	events         domain.EventPublisher
+	editWindow     time.Duration
+}
+
+// NewMessageService creates a new message service and registers it as the executor
+// of approved conversation exports. Exports are stored encrypted with the couple's
+// data key.
+func NewMessageService(
+	messageRepo domain.MessageRepository,
+	userRepo domain.UserRepository,
+	pendingActions domain.PendingActionService,
+	storage domain.StorageService,
+	notifications domain.NotificationService,
+	coupleKeys domain.CoupleKeyService,
+	receipts domain.MessageReceiptRepository,
+	events domain.EventPublisher,
+	editWindow time.Duration,
+) domain.MessageService {
+	s := &MessageService{
+		messageRepo:    messageRepo,
+		userRepo:       userRepo,
+		pendingActions: pendingActions,
+		storage:        storage,
+		notifications:  notifications,
+		coupleKeys:     coupleKeys,
+		receipts:       receipts,
+		events:         events,
+		editWindow:     editWindow,
+	}
+	pendingActions.RegisterExecutor(domain.PendingActionConversationExport, s)
+	return s
+}
+
+// SendMessage sends a message to the sender's partner
+func (s *MessageService) SendMessage(ctx context.Context, senderID primitive.ObjectID, req *domain.CreateMessageRequest) (*domain.MessageResponse, error) {
+	sender, err := s.userRepo.GetByID(ctx, senderID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if sender.PartnerID == nil {
+		return nil, domain.ErrNotMatchedError()
+	}
+	if *sender.PartnerID != req.ReceiverID {
+		return nil, domain.ErrForbiddenError()
+	}
+
+	messageType := req.MessageType
+	if messageType == "" {
+		messageType = "text"
+	}
+
+	// Messages are shown by web clients, so markup is removed before it is stored
+	content := sanitize.HTML(req.Content)
+	if content == "" {
+		return nil, domain.ErrInvalidRequestError("Message content is empty")
+	}
+
+	message := &domain.Message{
+		SenderID:    senderID,
+		ReceiverID:  req.ReceiverID,
+		Content:     content,
+		MessageType: messageType,
+	}
+
+	if err := s.messageRepo.Create(ctx, message); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to send message")
+	}
+
+	logging.FromContext(ctx).Info("Message sent",
+		zap.String("message_id", message.ID.Hex()),
+		zap.String("sender_id", senderID.Hex()))
+
+	s.events.Publish(ctx, domain.DomainEventMessageSent, &domain.MessageSentData{
+		MessageID:   message.ID.Hex(),
+		SenderID:    senderID.Hex(),
+		ReceiverID:  req.ReceiverID.Hex(),
+		MessageType: messageType,
+	})
+
+	return message.ToResponse(), nil
+}
+
+// GetConversation lists a page of the messages between the user and partnerID, newest first
+func (s *MessageService) GetConversation(ctx context.Context, userID, partnerID primitive.ObjectID, page, limit int) ([]*domain.MessageResponse, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	messages, total, err := s.messageRepo.FindConversation(ctx, userID, partnerID, page, limit)
+	if err != nil {
+		return nil, 0, domain.ErrOperationFailedError("Failed to get messages")
+	}
+
+	return toMessageResponses(messages), total, nil
+}
+
+// GetConversationCursor lists the messages between the user and partnerID after cursor,
+// newest first, and returns the cursor of the next page
+func (s *MessageService) GetConversationCursor(ctx context.Context, userID, partnerID primitive.ObjectID, cursor *domain.Cursor, limit int) ([]*domain.MessageResponse, string, error) {
+	// Fetch one extra message to know whether another page exists
+	messages, err := s.messageRepo.FindConversationCursor(ctx, userID, partnerID, cursor, limit+1)
+	if err != nil {
+		return nil, "", domain.ErrOperationFailedError("Failed to get messages")
+	}
+
+	var nextCursor string
+	if len(messages) > limit {
+		messages = messages[:limit]
+		last := messages[limit-1]
+		nextCursor = domain.NewCursor(last.CreatedAt, last.ID).Encode()
+	}
+
+	return toMessageResponses(messages), nextCursor, nil
+}
+
+// GetUserConversations lists a page of the user's conversations, most recently active first
+func (s *MessageService) GetUserConversations(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]*domain.Conversation, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	conversations, total, err := s.messageRepo.FindUserConversations(ctx, userID, page, limit)
+	if err != nil {
+		return nil, 0, domain.ErrOperationFailedError("Failed to get conversations")
+	}
+
+	for _, conversation := range conversations {
+		partner, err := s.userRepo.GetByID(ctx, conversation.PartnerID)
+		if err != nil {
+			// Conversations with deleted accounts are still listed, without a name
+			continue
+		}
+		conversation.PartnerName = partner.Name
+		conversation.PartnerAvatar = partner.Avatar
+	}
+
+	return conversations, total, nil
+}
+
+// MarkAsRead marks the messages partnerID sent to the user as read and lets the
+// partner know
+func (s *MessageService) MarkAsRead(ctx context.Context, userID, partnerID primitive.ObjectID) error {
+	messages, err := s.messageRepo.MarkAsRead(ctx, userID, partnerID)
+	if err != nil {
+		return domain.ErrOperationFailedError("Failed to mark messages as read")
+	}
+
+	s.publishReceipts(ctx, userID, domain.MessageReceiptRead, messages)
+	return nil
+}
+
+// AckMessages marks the given messages sent to the user as delivered or read and lets
+// their sender know. Messages that are unknown, deleted or already in that state are
+// skipped, so acknowledging them again is harmless.
+func (s *MessageService) AckMessages(ctx context.Context, userID primitive.ObjectID, req *domain.AckMessagesRequest) (*domain.MessageReceipt, error) {
+	var messages []*domain.Message
+	var err error
+	switch req.Status {
+	case domain.MessageReceiptDelivered:
+		messages, err = s.messageRepo.MarkDelivered(ctx, userID, req.MessageIDs)
+	case domain.MessageReceiptRead:
+		messages, err = s.messageRepo.MarkRead(ctx, userID, req.MessageIDs)
+	default:
+		return nil, domain.NewAppError(domain.ErrCodeInvalidRequest, "Status must be delivered or read", 400)
+	}
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to acknowledge messages")
+	}
+
+	s.publishReceipts(ctx, userID, req.Status, messages)
+
+	receipt := &domain.MessageReceipt{
+		Status:     req.Status,
+		MessageIDs: make([]primitive.ObjectID, 0, len(messages)),
+		ReceiverID: userID,
+		At:         time.Now(),
+	}
+	for _, message := range messages {
+		receipt.MessageIDs = append(receipt.MessageIDs, message.ID)
+	}
+	return receipt, nil
+}
+
+// SubscribeReceipts delivers the receipts of the messages the user sent until ctx is done
+func (s *MessageService) SubscribeReceipts(ctx context.Context, userID primitive.ObjectID) (<-chan *domain.MessageReceipt, error) {
+	receipts, err := s.receipts.Subscribe(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to subscribe to message receipts", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to subscribe to message receipts")
+	}
+	return receipts, nil
+}
+
+// publishReceipts sends each sender a receipt for the messages of theirs the receiver
+// acknowledged. Failures are only logged; the sender sees the state on the next fetch.
+func (s *MessageService) publishReceipts(ctx context.Context, receiverID primitive.ObjectID, status domain.MessageReceiptStatus, messages []*domain.Message) {
+	now := time.Now()
+	bySender := make(map[primitive.ObjectID]*domain.MessageReceipt)
+	for _, message := range messages {
+		receipt, ok := bySender[message.SenderID]
+		if !ok {
+			receipt = &domain.MessageReceipt{Status: status, ReceiverID: receiverID, At: now}
+			bySender[message.SenderID] = receipt
+		}
+		receipt.MessageIDs = append(receipt.MessageIDs, message.ID)
+	}
+
+	for senderID, receipt := range bySender {
+		if err := s.receipts.Publish(ctx, senderID, receipt); err != nil {
+			logging.FromContext(ctx).Warn("Failed to publish message receipt",
+				zap.Error(err),
+				zap.String("sender_id", senderID.Hex()),
+				zap.String("status", string(status)))
+		}
+	}
+}
+
+// EditMessage changes the content of a text message the user sent, as long as the
+// edit window since it was sent has not passed
+func (s *MessageService) EditMessage(ctx context.Context, messageID, userID primitive.ObjectID, req *domain.UpdateMessageRequest) (*domain.MessageResponse, error) {
+	message, err := s.messageRepo.FindByID(ctx, messageID)
+	if err != nil || message.SenderID != userID || containsObjectID(message.HiddenFor, userID) {
+		return nil, domain.ErrNotFoundError("Message")
+	}
+
+	if message.MessageType != "text" {
+		return nil, domain.ErrInvalidRequestError("Only text messages can be edited")
+	}
+
+	now := time.Now()
+	if now.Sub(message.CreatedAt) > s.editWindow {
+		return nil, domain.ErrEditWindowPassedError()
+	}
+
+	content := sanitize.HTML(req.Content)
+	if content == "" {
+		return nil, domain.ErrInvalidRequestError("Message content is empty")
+	}
+
+	message.Content = content
+	message.EditedAt = &now
+	if err := s.messageRepo.Update(ctx, message); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to edit message")
+	}
+
+	logging.FromContext(ctx).Info("Message edited",
+		zap.String("message_id", messageID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return message.ToResponse(), nil
+}
+
+// DeleteMessage soft deletes a message the user sent for both partners, or hides a
+// message the user sent or received from the user only
+func (s *MessageService) DeleteMessage(ctx context.Context, messageID, userID primitive.ObjectID, mode domain.MessageDeleteMode) error {
+	var err error
+	switch mode {
+	case "", domain.MessageDeleteForEveryone:
+		err = s.messageRepo.SoftDelete(ctx, messageID, userID)
+	case domain.MessageDeleteForMe:
+		err = s.messageRepo.HideForUser(ctx, messageID, userID)
+	default:
+		return domain.ErrInvalidRequestError("Mode must be everyone or me")
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrMessageNotFound) {
+			return domain.ErrNotFoundError("Message")
+		}
+		return domain.ErrOperationFailedError("Failed to delete message")
+	}
+
+	logging.FromContext(ctx).Info("Message deleted",
+		zap.String("message_id", messageID.Hex()),
+		zap.String("user_id", userID.Hex()),
+		zap.String("mode", string(mode)))
+
+	return nil
+}
+
+// ExportConversation exports the user's conversation with their partner. When the
+// couple requires partner approval, the export is held as a pending action instead and
+// made once the partner approves it.
+func (s *MessageService) ExportConversation(ctx context.Context, userID primitive.ObjectID) (*domain.ConversationExportResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" || user.PartnerID == nil {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	required, err := s.pendingActions.ApprovalRequired(ctx, user.MatchCode)
+	if err != nil {
+		return nil, err
+	}
+	if required {
+		// Exports are per requester, so the pending action targets the user. The action
+		// title already says what is exported, so there is nothing to summarize.
+		pending, err := s.pendingActions.Request(ctx, userID, domain.PendingActionConversationExport, userID, "")
+		if err != nil {
+			return nil, err
+		}
+		return &domain.ConversationExportResponse{PendingAction: pending}, nil
+	}
+
+	return s.exportConversation(ctx, user)
+}
+
+// ExecutePendingAction makes a conversation export once the partner approved it and
+// sends its ID to the requester
+func (s *MessageService) ExecutePendingAction(ctx context.Context, action *domain.PendingAction) error {
+	user, err := s.userRepo.GetByID(ctx, action.RequestedBy)
+	if err != nil {
+		return domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode != action.MatchCode || user.PartnerID == nil {
+		return domain.ErrNotMatchedError()
+	}
+
+	export, err := s.exportConversation(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	tmpl := domain.NotificationTemplate{Key: "conversation_export_ready"}
+	data := map[string]string{
+		"export_id":         export.ID,
+		"pending_action_id": action.ID.Hex(),
+	}
+	if err := s.notifications.Notify(ctx, user.ID, domain.NotificationTypeExportReady, tmpl, data); err != nil {
+		logging.FromContext(ctx).Warn("Failed to notify requester of conversation export",
+			zap.Error(err),
+			zap.String("export_id", export.ID))
+	}
+
+	logging.FromContext(ctx).Info("Conversation exported after partner approval",
+		zap.String("export_id", export.ID),
+		zap.String("pending_action_id", action.ID.Hex()),
+		zap.String("user_id", user.ID.Hex()))
+
+	return nil
+}
+
+// GetConversationExport returns an export of the user's couple as JSON
+func (s *MessageService) GetConversationExport(ctx context.Context, userID, exportID primitive.ObjectID) ([]byte, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	// The key is scoped to the couple, so exports of other couples are never found
+	object, err := s.storage.GetObject(ctx, conversationExportKey(user.MatchCode, exportID))
+	if err != nil {
+		if errors.Is(err, domain.ErrFileNotFound) {
+			return nil, domain.ErrNotFoundError("Export")
+		}
+		logging.FromContext(ctx).Error("Failed to open conversation export", zap.Error(err), zap.String("export_id", exportID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to get export")
+	}
+	defer object.Close()
+
+	ciphertext, err := io.ReadAll(object)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to read conversation export", zap.Error(err), zap.String("export_id", exportID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to get export")
+	}
+
+	return s.coupleKeys.Decrypt(ctx, user.MatchCode, conversationExportPurpose, ciphertext)
+}
+
+// exportConversation writes every message between the user and their partner to
+// storage, oldest first and encrypted with the couple's data key
+func (s *MessageService) exportConversation(ctx context.Context, user *domain.User) (*domain.ConversationExportResponse, error) {
+	var messages []*domain.Message
+	var cursor *domain.Cursor
+	for {
+		batch, err := s.messageRepo.FindConversationCursor(ctx, user.ID, *user.PartnerID, cursor, conversationExportBatchSize)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to read conversation for export", zap.Error(err), zap.String("user_id", user.ID.Hex()))
+			return nil, domain.ErrOperationFailedError("Failed to export conversation")
+		}
+		messages = append(messages, batch...)
+		if len(batch) < conversationExportBatchSize {
+			break
+		}
+		last := batch[len(batch)-1]
+		cursor = domain.NewCursor(last.CreatedAt, last.ID)
+	}
+
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+
+	participants := []*domain.ConversationExportParticipant{{ID: user.ID, Name: user.Name}}
+	if partner, err := s.userRepo.GetByID(ctx, *user.PartnerID); err == nil {
+		participants = append(participants, &domain.ConversationExportParticipant{ID: partner.ID, Name: partner.Name})
+	}
+
+	now := time.Now()
+	body, err := json.Marshal(&domain.ConversationExport{
+		ExportedAt:   now,
+		ExportedBy:   user.ID,
+		Participants: participants,
+		Messages:     toMessageResponses(messages),
+	})
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to encode conversation export", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to export conversation")
+	}
+
+	ciphertext, err := s.coupleKeys.Encrypt(ctx, user.MatchCode, conversationExportPurpose, body)
+	if err != nil {
+		return nil, err
+	}
+
+	exportID := primitive.NewObjectIDFromTimestamp(now)
+	key := conversationExportKey(user.MatchCode, exportID)
+	if _, err := s.storage.PutObject(ctx, key, bytes.NewReader(ciphertext), int64(len(ciphertext)), "application/octet-stream"); err != nil {
+		logging.FromContext(ctx).Error("Failed to store conversation export", zap.Error(err), zap.String("key", key))
+		return nil, domain.ErrOperationFailedError("Failed to export conversation")
+	}
+
+	logging.FromContext(ctx).Info("Conversation exported",
+		zap.String("export_id", exportID.Hex()),
+		zap.String("user_id", user.ID.Hex()),
+		zap.Int("messages", len(messages)))
+
+	return &domain.ConversationExportResponse{
+		ID:           exportID.Hex(),
+		MessageCount: len(messages),
+		CreatedAt:    &now,
+	}, nil
+}
+
+// conversationExportKey returns the storage key of a couple's conversation export
+func conversationExportKey(matchCode string, exportID primitive.ObjectID) string {
+	return "exports/conversations/" + matchCode + "/" + exportID.Hex() + ".json.enc"
+}
+
+// toMessageResponses converts messages to their responses
+func toMessageResponses(messages []*domain.Message) []*domain.MessageResponse {
+	responses := make([]*domain.MessageResponse, len(messages))
+	for i, message := range messages {
+		responses[i] = message.ToResponse()
+	}
+	return responses
+}
+
+// containsObjectID reports whether ids contains id
+func containsObjectID(ids []primitive.ObjectID, id primitive.ObjectID) bool {
+	for _, have := range ids {
+		if have == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"math"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// moodHistoryMaxLimit caps how many check-ins one history request returns
+const moodHistoryMaxLimit = 366
+
+// MoodService implements domain.MoodService
+type MoodService struct {
+	moodRepo domain.MoodRepository
+	userRepo domain.UserRepository
+	events   domain.EventPublisher
+}
+
+// NewMoodService creates a new mood service
+func NewMoodService(
+	moodRepo domain.MoodRepository,
+	userRepo domain.UserRepository,
+	events domain.EventPublisher,
+) domain.MoodService {
+	return &MoodService{
+		moodRepo: moodRepo,
+		userRepo: userRepo,
+		events:   events,
+	}
+}
+
+// RecordMood records the user's mood for a day, today unless the request gives one
+func (s *MoodService) RecordMood(ctx context.Context, userID primitive.ObjectID, req *domain.RecordMoodRequest) (*domain.MoodCheckInResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	today := domain.Today(user.Location())
+	day := today
+	if req.Date != nil && !req.Date.IsZero() {
+		day = req.Date.Time
+		// Without a time zone the user's day is taken in UTC, so allow for users
+		// whose local date is already a day ahead
+		latest := today
+		if user.Timezone == "" {
+			latest = today.AddDate(0, 0, 1)
+		}
+		if day.After(latest) {
+			return nil, domain.ErrInvalidRequestError("Cannot record a mood for a future day")
+		}
+	}
+
+	checkIn := &domain.MoodCheckIn{
+		MatchCode: user.MatchCode,
+		UserID:    userID,
+		Date:      day,
+		Score:     req.Score,
+		Emoji:     req.Emoji,
+		Note:      req.Note,
+	}
+
+	if err := s.moodRepo.Upsert(ctx, checkIn); err != nil {
+		logging.FromContext(ctx).Error("Failed to record mood", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to record mood")
+	}
+
+	logging.FromContext(ctx).Info("Mood recorded",
+		zap.String("user_id", userID.Hex()),
+		zap.Time("date", day),
+		zap.Int("score", checkIn.Score))
+
+	s.events.Publish(ctx, domain.DomainEventMoodRecorded, &domain.MoodRecordedData{
+		UserID:    userID.Hex(),
+		MatchCode: checkIn.MatchCode,
+		Date:      domain.NewDate(day),
+	})
+
+	return checkIn.ToResponse(), nil
+}
+
+// GetHistory retrieves the user's own mood check-ins, newest first
+func (s *MoodService) GetHistory(ctx context.Context, userID primitive.ObjectID, from, to *domain.Date, limit int) (*domain.MoodHistoryResponse, error) {
+	if limit < 1 || limit > moodHistoryMaxLimit {
+		limit = moodHistoryMaxLimit
+	}
+
+	checkIns, err := s.moodRepo.GetByUser(ctx, userID, from.ToTimePtr(), to.ToTimePtr(), limit)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get mood history", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get mood history")
+	}
+
+	response := &domain.MoodHistoryResponse{
+		CheckIns: make([]*domain.MoodCheckInResponse, len(checkIns)),
+	}
+	for i, checkIn := range checkIns {
+		response.CheckIns[i] = checkIn.ToResponse()
+	}
+	response.AverageScore = averageMoodScore(checkIns)
+
+	return response, nil
+}
+
+// GetCoupleMoods returns both partners' moods side by side for every day of month
+func (s *MoodService) GetCoupleMoods(ctx context.Context, userID primitive.ObjectID, month time.Time) (*domain.CoupleMoodsResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	checkIns, err := s.moodRepo.GetByMatchCode(ctx, user.MatchCode, start, end)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get couple moods", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get couple moods")
+	}
+
+	response := &domain.CoupleMoodsResponse{
+		Month: start.Format("2006-01"),
+		Days:  []*domain.CoupleMoodDay{},
+	}
+	if user.PartnerID != nil {
+		response.PartnerID = user.PartnerID.Hex()
+	}
+
+	days := make(map[int64]*domain.CoupleMoodDay)
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		moodDay := &domain.CoupleMoodDay{Date: domain.NewDate(day)}
+		days[day.Unix()] = moodDay
+		response.Days = append(response.Days, moodDay)
+	}
+
+	var mine, partner []*domain.MoodCheckIn
+	for _, checkIn := range checkIns {
+		moodDay, ok := days[checkIn.Date.Unix()]
+		if !ok {
+			continue
+		}
+		if checkIn.UserID == userID {
+			moodDay.Mine = checkIn.ToResponse()
+			mine = append(mine, checkIn)
+		} else {
+			moodDay.Partner = checkIn.ToResponse()
+			partner = append(partner, checkIn)
+		}
+	}
+	response.MyAverageScore = averageMoodScore(mine)
+	response.PartnerAverageScore = averageMoodScore(partner)
+
+	return response, nil
+}
+
+// averageMoodScore returns the mean score of the check-ins rounded to one decimal,
+// or 0 when there are none
+func averageMoodScore(checkIns []*domain.MoodCheckIn) float64 {
+	if len(checkIns) == 0 {
+		return 0
+	}
+
+	total := 0
+	for _, checkIn := range checkIns {
+		total += checkIn.Score
+	}
+
+	return math.Round(float64(total)/float64(len(checkIns))*10) / 10
+}
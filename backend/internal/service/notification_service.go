@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/push"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// NotificationService implements domain.NotificationService
+type NotificationService struct {
+	notificationRepo domain.NotificationRepository
+	userRepo         domain.UserRepository
+	renderer         *push.Renderer
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(
+	notificationRepo domain.NotificationRepository,
+	userRepo domain.UserRepository,
+	renderer *push.Renderer,
+) domain.NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+		renderer:         renderer,
+	}
+}
+
+// Notify renders tmpl in the user's language and creates an in-app notification
+func (s *NotificationService) Notify(
+	ctx context.Context,
+	userID primitive.ObjectID,
+	notificationType domain.NotificationType,
+	tmpl domain.NotificationTemplate,
+	data map[string]string,
+) error {
+	// Users without a locale get the default language
+	var locale string
+	if user, err := s.userRepo.GetByID(ctx, userID); err == nil {
+		locale = user.Locale
+	}
+
+	payload := s.renderer.Render(locale, push.PlatformInApp, tmpl)
+
+	notification := &domain.Notification{
+		UserID: userID,
+		Type:   notificationType,
+		Title:  payload.Title,
+		Body:   payload.Body,
+		Data:   data,
+	}
+
+	if err := s.notificationRepo.Create(ctx, notification); err != nil {
+		logging.FromContext(ctx).Error("Failed to create notification",
+			zap.Error(err),
+			zap.String("user_id", userID.Hex()),
+			zap.String("type", string(notificationType)))
+		return domain.ErrOperationFailedError("Failed to create notification")
+	}
+
+	logging.FromContext(ctx).Info("Notification created",
+		zap.String("notification_id", notification.ID.Hex()),
+		zap.String("user_id", userID.Hex()),
+		zap.String("type", string(notificationType)))
+
+	return nil
+}
+
+// GetNotifications retrieves a user's notifications with pagination
+func (s *NotificationService) GetNotifications(ctx context.Context, userID primitive.ObjectID, page, limit int) (*domain.NotificationListResponse, error) {
+	offset := (page - 1) * limit
+
+	notifications, err := s.notificationRepo.GetByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get notifications")
+	}
+
+	total, err := s.notificationRepo.CountByUserID(ctx, userID, false)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get notifications")
+	}
+
+	unread, err := s.notificationRepo.CountByUserID(ctx, userID, true)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get notifications")
+	}
+
+	responses := make([]*domain.NotificationResponse, len(notifications))
+	for i, notification := range notifications {
+		responses[i] = notification.ToResponse()
+	}
+
+	return &domain.NotificationListResponse{
+		Notifications: responses,
+		Total:         total,
+		UnreadCount:   unread,
+		Page:          page,
+		Limit:         limit,
+	}, nil
+}
+
+// MarkAsRead marks a notification as read
+func (s *NotificationService) MarkAsRead(ctx context.Context, notificationID, userID primitive.ObjectID) error {
+	if err := s.notificationRepo.MarkAsRead(ctx, notificationID, userID); err != nil {
+		if errors.Is(err, domain.ErrNotificationNotFound) {
+			return domain.ErrNotFoundError("Notification")
+		}
+		return domain.ErrOperationFailedError("Failed to mark notification as read")
+	}
+	return nil
+}
+
+// MarkAllAsRead marks all of a user's notifications as read
+func (s *NotificationService) MarkAllAsRead(ctx context.Context, userID primitive.ObjectID) error {
+	if err := s.notificationRepo.MarkAllAsRead(ctx, userID); err != nil {
+		return domain.ErrOperationFailedError("Failed to mark notifications as read")
+	}
+	return nil
+}
@@ -0,0 +1,428 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/auth"
+	"github.com/golang-jwt/jwt/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// authorizationCodeTTL is how long a client has to exchange an authorization code
+	authorizationCodeTTL = 5 * time.Minute
+
+	oidcScopeOpenID  = "openid"
+	oidcScopeProfile = "profile"
+	oidcScopeEmail   = "email"
+
+	pkceMethodS256        = "S256"
+	pkceVerifierMinLength = 43
+	pkceVerifierMaxLength = 128
+)
+
+// oidcScopes lists the scopes the provider understands, others are ignored
+var oidcScopes = []string{oidcScopeOpenID, oidcScopeProfile, oidcScopeEmail}
+
+// idTokenClaims are the claims of an ID token. Profile and email claims are only
+// included for the matching scopes.
+type idTokenClaims struct {
+	Nonce         string `json:"nonce,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Picture       string `json:"picture,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// OIDCService implements domain.OIDCService
+type OIDCService struct {
+	codeRepo   domain.AuthorizationCodeRepository
+	userRepo   domain.UserRepository
+	jwtManager *auth.JWTManager
+	signer     *auth.IDTokenSigner
+	issuer     string
+	loginURL   string
+	clients    map[string]*domain.OAuthClient
+}
+
+// NewOIDCService creates a new OpenID Connect provider service. issuer is the public
+// URL the provider endpoints are served under; users who are not signed in are sent
+// to the frontend login page and brought back afterwards.
+func NewOIDCService(
+	codeRepo domain.AuthorizationCodeRepository,
+	userRepo domain.UserRepository,
+	jwtManager *auth.JWTManager,
+	signer *auth.IDTokenSigner,
+	issuer string,
+	frontendURL string,
+	clients []*domain.OAuthClient,
+) domain.OIDCService {
+	clientsByID := make(map[string]*domain.OAuthClient, len(clients))
+	for _, client := range clients {
+		clientsByID[client.ID] = client
+	}
+
+	return &OIDCService{
+		codeRepo:   codeRepo,
+		userRepo:   userRepo,
+		jwtManager: jwtManager,
+		signer:     signer,
+		issuer:     strings.TrimRight(issuer, "/"),
+		loginURL:   strings.TrimRight(frontendURL, "/") + "/login",
+		clients:    clientsByID,
+	}
+}
+
+// Discovery returns the provider metadata
+func (s *OIDCService) Discovery() *domain.OIDCDiscoveryResponse {
+	return &domain.OIDCDiscoveryResponse{
+		Issuer:                            s.issuer,
+		AuthorizationEndpoint:             s.issuer + "/authorize",
+		TokenEndpoint:                     s.issuer + "/token",
+		UserInfoEndpoint:                  s.issuer + "/userinfo",
+		JWKSURI:                           s.issuer + "/jwks",
+		ScopesSupported:                   oidcScopes,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code", "refresh_token"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{"RS256"},
+		TokenEndpointAuthMethodsSupported: []string{"none"},
+		CodeChallengeMethodsSupported:     []string{pkceMethodS256},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "nonce", "email", "email_verified", "name", "picture"},
+	}
+}
+
+// JWKS returns the key that verifies ID tokens
+func (s *OIDCService) JWKS() *domain.JSONWebKeySet {
+	publicKey := s.signer.PublicKey()
+
+	return &domain.JSONWebKeySet{
+		Keys: []domain.JSONWebKey{{
+			KeyType:   "RSA",
+			Use:       "sig",
+			Algorithm: "RS256",
+			KeyID:     s.signer.KeyID(),
+			Modulus:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	}
+}
+
+// Authorize answers an authentication request
+func (s *OIDCService) Authorize(ctx context.Context, userID primitive.ObjectID, req *domain.AuthorizeRequest, rawQuery string) (string, error) {
+	client, ok := s.clients[req.ClientID]
+	if !ok {
+		return "", domain.NewOAuthError(domain.OAuthErrInvalidClient, "Unknown client_id")
+	}
+	if !redirectURIAllowed(client, req.RedirectURI) {
+		return "", domain.NewOAuthError(domain.OAuthErrInvalidRequest, "redirect_uri is not registered for this client")
+	}
+
+	// The redirect URI is trusted from here on, so errors are reported to the client
+	if req.ResponseType != "code" {
+		return oauthErrorRedirect(req, domain.OAuthErrUnsupportedResponseType, "Only the code response type is supported"), nil
+	}
+
+	scopes := parseOIDCScopes(req.Scope)
+	if !containsString(scopes, oidcScopeOpenID) {
+		return oauthErrorRedirect(req, domain.OAuthErrInvalidScope, "scope must include openid"), nil
+	}
+
+	if req.CodeChallengeMethod != pkceMethodS256 || len(req.CodeChallenge) != base64.RawURLEncoding.EncodedLen(sha256.Size) {
+		return oauthErrorRedirect(req, domain.OAuthErrInvalidRequest, "A code_challenge with the S256 method is required"), nil
+	}
+
+	if userID.IsZero() {
+		if containsString(strings.Fields(req.Prompt), "none") {
+			return oauthErrorRedirect(req, domain.OAuthErrLoginRequired, ""), nil
+		}
+		returnTo := s.issuer + "/authorize?" + rawQuery
+		return s.loginURL + "?return_to=" + url.QueryEscape(returnTo), nil
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || !user.IsActive {
+		return oauthErrorRedirect(req, domain.OAuthErrAccessDenied, "The account is not available"), nil
+	}
+
+	code, err := newAuthorizationCode()
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to generate authorization code", zap.Error(err))
+		return oauthErrorRedirect(req, domain.OAuthErrServerError, ""), nil
+	}
+
+	err = s.codeRepo.Create(ctx, &domain.AuthorizationCode{
+		CodeHash:      hashAuthorizationCode(code),
+		ClientID:      client.ID,
+		RedirectURI:   req.RedirectURI,
+		UserID:        user.ID,
+		Scopes:        scopes,
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		ExpiresAt:     time.Now().Add(authorizationCodeTTL),
+	})
+	if err != nil {
+		return oauthErrorRedirect(req, domain.OAuthErrServerError, ""), nil
+	}
+
+	logging.FromContext(ctx).Info("Authorization code issued",
+		zap.String("client_id", client.ID),
+		zap.String("user_id", user.ID.Hex()))
+
+	return withQuery(req.RedirectURI, url.Values{"code": {code}, "state": {req.State}}), nil
+}
+
+// Token exchanges an authorization code or a refresh token for tokens
+func (s *OIDCService) Token(ctx context.Context, req *domain.TokenRequest) (*domain.OIDCTokenResponse, error) {
+	client, ok := s.clients[req.ClientID]
+	if !ok {
+		return nil, domain.NewOAuthError(domain.OAuthErrInvalidClient, "Unknown client_id")
+	}
+
+	switch req.GrantType {
+	case "authorization_code":
+		return s.exchangeCode(ctx, client, req)
+	case "refresh_token":
+		return s.refresh(ctx, client, req)
+	default:
+		return nil, domain.NewOAuthError(domain.OAuthErrUnsupportedGrantType, "grant_type must be authorization_code or refresh_token")
+	}
+}
+
+// exchangeCode redeems an authorization code once its PKCE verifier checks out
+func (s *OIDCService) exchangeCode(ctx context.Context, client *domain.OAuthClient, req *domain.TokenRequest) (*domain.OIDCTokenResponse, error) {
+	if req.Code == "" || req.CodeVerifier == "" {
+		return nil, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "code and code_verifier are required")
+	}
+
+	// The code is consumed before it is checked, so a stolen code is useless even
+	// to a request that gets it wrong
+	code, err := s.codeRepo.Consume(ctx, hashAuthorizationCode(req.Code), time.Now())
+	if err != nil {
+		return nil, domain.NewOAuthError(domain.OAuthErrInvalidGrant, "The authorization code is invalid or expired")
+	}
+
+	if code.ClientID != client.ID || code.RedirectURI != req.RedirectURI {
+		return nil, domain.NewOAuthError(domain.OAuthErrInvalidGrant, "The authorization code was issued to another client or redirect_uri")
+	}
+
+	if !verifyPKCE(code.CodeChallenge, req.CodeVerifier) {
+		return nil, domain.NewOAuthError(domain.OAuthErrInvalidGrant, "code_verifier does not match the code_challenge")
+	}
+
+	return s.issueTokens(ctx, code.UserID, client.ID, code.Scopes, code.Nonce)
+}
+
+// refresh issues new tokens for a refresh token issued to the same client. The new
+// tokens carry the scopes first granted, or fewer when the client asks for a subset
+// (RFC 6749 section 6).
+func (s *OIDCService) refresh(ctx context.Context, client *domain.OAuthClient, req *domain.TokenRequest) (*domain.OIDCTokenResponse, error) {
+	if req.RefreshToken == "" {
+		return nil, domain.NewOAuthError(domain.OAuthErrInvalidRequest, "refresh_token is required")
+	}
+
+	claims, err := s.jwtManager.ValidateRefreshToken(req.RefreshToken)
+	if err != nil {
+		return nil, domain.NewOAuthError(domain.OAuthErrInvalidGrant, "The refresh token is invalid or expired")
+	}
+
+	// First-party refresh tokens have no client and are never accepted here
+	if claims.ClientID == "" || claims.ClientID != client.ID {
+		return nil, domain.NewOAuthError(domain.OAuthErrInvalidGrant, "The refresh token was issued to another client")
+	}
+
+	granted := parseOIDCScopes(claims.Scope)
+	scopes := granted
+	if req.Scope != "" {
+		scopes = nil
+		for _, requested := range strings.Fields(req.Scope) {
+			if !containsString(granted, requested) {
+				return nil, domain.NewOAuthError(domain.OAuthErrInvalidScope, "scope exceeds the scopes originally granted")
+			}
+			if !containsString(scopes, requested) {
+				scopes = append(scopes, requested)
+			}
+		}
+	}
+
+	return s.issueTokens(ctx, claims.UserID, client.ID, scopes, "")
+}
+
+// issueTokens issues the API tokens and an ID token for a client
+func (s *OIDCService) issueTokens(ctx context.Context, userID primitive.ObjectID, clientID string, scopes []string, nonce string) (*domain.OIDCTokenResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil || !user.IsActive {
+		return nil, domain.NewOAuthError(domain.OAuthErrInvalidGrant, "The account is not available")
+	}
+
+	tokens, err := s.jwtManager.GenerateClientTokenPair(user.ID, user.Email, user.Name, clientID, strings.Join(scopes, " "))
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to generate tokens", zap.Error(err), zap.String("user_id", user.ID.Hex()))
+		return nil, domain.NewOAuthError(domain.OAuthErrServerError, "")
+	}
+
+	now := time.Now()
+	claims := &idTokenClaims{
+		Nonce: nonce,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.issuer,
+			Subject:   user.ID.Hex(),
+			Audience:  jwt.ClaimStrings{clientID},
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(tokens.ExpiresIn) * time.Second)),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	if containsString(scopes, oidcScopeEmail) {
+		claims.Email = user.Email
+		claims.EmailVerified = &user.IsEmailVerified
+	}
+	if containsString(scopes, oidcScopeProfile) {
+		claims.Name = user.Name
+		claims.Picture = user.Avatar
+	}
+
+	idToken, err := s.signer.Sign(claims)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to sign ID token", zap.Error(err), zap.String("user_id", user.ID.Hex()))
+		return nil, domain.NewOAuthError(domain.OAuthErrServerError, "")
+	}
+
+	return &domain.OIDCTokenResponse{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    tokens.TokenType,
+		ExpiresIn:    tokens.ExpiresIn,
+		RefreshToken: tokens.RefreshToken,
+		IDToken:      idToken,
+		Scope:        strings.Join(scopes, " "),
+	}, nil
+}
+
+// UserInfo returns the claims about a user
+func (s *OIDCService) UserInfo(ctx context.Context, userID primitive.ObjectID) (*domain.UserInfoResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	return &domain.UserInfoResponse{
+		Subject:       user.ID.Hex(),
+		Email:         user.Email,
+		EmailVerified: user.IsEmailVerified,
+		Name:          user.Name,
+		Picture:       user.Avatar,
+	}, nil
+}
+
+// redirectURIAllowed reports whether a client registered the redirect URI. Loopback
+// URIs match on any port, since native apps listen on a port picked at runtime
+// (RFC 8252 section 7.3).
+func redirectURIAllowed(client *domain.OAuthClient, redirectURI string) bool {
+	requested, err := url.Parse(redirectURI)
+	if err != nil || redirectURI == "" {
+		return false
+	}
+
+	for _, registered := range client.RedirectURIs {
+		if redirectURI == registered {
+			return true
+		}
+
+		allowed, err := url.Parse(registered)
+		if err != nil || allowed.Scheme != "http" || requested.Scheme != "http" {
+			continue
+		}
+		host := allowed.Hostname()
+		if (host == "127.0.0.1" || host == "::1") && requested.Hostname() == host &&
+			requested.Path == allowed.Path && requested.RawQuery == allowed.RawQuery &&
+			requested.User == nil && requested.Fragment == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseOIDCScopes keeps the supported scopes of a space-separated scope parameter
+func parseOIDCScopes(scope string) []string {
+	var scopes []string
+	for _, requested := range strings.Fields(scope) {
+		if containsString(oidcScopes, requested) && !containsString(scopes, requested) {
+			scopes = append(scopes, requested)
+		}
+	}
+	return scopes
+}
+
+// verifyPKCE checks a code verifier against the S256 challenge it was derived from
+func verifyPKCE(challenge, verifier string) bool {
+	if len(verifier) < pkceVerifierMinLength || len(verifier) > pkceVerifierMaxLength {
+		return false
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// oauthErrorRedirect returns the client's redirect URI carrying an error
+func oauthErrorRedirect(req *domain.AuthorizeRequest, code, description string) string {
+	params := url.Values{"error": {code}, "state": {req.State}}
+	if description != "" {
+		params.Set("error_description", description)
+	}
+	return withQuery(req.RedirectURI, params)
+}
+
+// withQuery adds parameters to the query of a URI, dropping empty ones
+func withQuery(uri string, params url.Values) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	query := u.Query()
+	for key, values := range params {
+		if len(values) > 0 && values[0] != "" {
+			query.Set(key, values[0])
+		}
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// newAuthorizationCode returns 32 random bytes, URL-safe encoded
+func newAuthorizationCode() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// hashAuthorizationCode returns the hex SHA-256 of a code, which is what gets stored
+func hashAuthorizationCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// pendingActionTitles are the messages describing each kind of action in notifications
+var pendingActionTitles = map[domain.PendingActionType]domain.NotificationMessage{
+	domain.PendingActionAlbumDelete:        "pending_action_album_delete",
+	domain.PendingActionConversationExport: "pending_action_conversation_export",
+}
+
+// PendingActionService implements domain.PendingActionService
+type PendingActionService struct {
+	actionRepo      domain.PendingActionRepository
+	userRepo        domain.UserRepository
+	settingsService domain.CoupleSettingsService
+	notifications   domain.NotificationService
+	ttl             time.Duration
+
+	mu        sync.RWMutex
+	executors map[domain.PendingActionType]domain.PendingActionExecutor
+}
+
+// NewPendingActionService creates a new pending action service. Actions expire when
+// they are not decided on within ttl.
+func NewPendingActionService(
+	actionRepo domain.PendingActionRepository,
+	userRepo domain.UserRepository,
+	settingsService domain.CoupleSettingsService,
+	notifications domain.NotificationService,
+	ttl time.Duration,
+) domain.PendingActionService {
+	return &PendingActionService{
+		actionRepo:      actionRepo,
+		userRepo:        userRepo,
+		settingsService: settingsService,
+		notifications:   notifications,
+		ttl:             ttl,
+		executors:       make(map[domain.PendingActionType]domain.PendingActionExecutor),
+	}
+}
+
+// RegisterExecutor sets what carries out approved actions of a type
+func (s *PendingActionService) RegisterExecutor(actionType domain.PendingActionType, executor domain.PendingActionExecutor) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.executors[actionType] = executor
+}
+
+// ApprovalRequired reports whether the couple asked for destructive actions to be approved
+func (s *PendingActionService) ApprovalRequired(ctx context.Context, matchCode string) (bool, error) {
+	settings, err := s.settingsService.GetByMatchCode(ctx, matchCode)
+	if err != nil {
+		return false, err
+	}
+	return settings.RequirePartnerApproval, nil
+}
+
+// Request holds an action until the requester's partner approves it, and notifies the
+// partner. Asking again for an action that is still waiting returns the waiting one.
+func (s *PendingActionService) Request(
+	ctx context.Context,
+	requesterID primitive.ObjectID,
+	actionType domain.PendingActionType,
+	targetID primitive.ObjectID,
+	summary string,
+) (*domain.PendingActionResponse, error) {
+	requester, err := s.userRepo.GetByID(ctx, requesterID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if requester.MatchCode == "" || requester.PartnerID == nil {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	now := time.Now()
+	if existing, err := s.actionRepo.GetOpen(ctx, requester.MatchCode, actionType, targetID, now); err == nil {
+		return existing.ToResponse(requesterID), nil
+	}
+
+	action := &domain.PendingAction{
+		MatchCode:   requester.MatchCode,
+		Type:        actionType,
+		TargetID:    targetID,
+		Summary:     summary,
+		RequestedBy: requesterID,
+		Status:      domain.PendingActionStatusPending,
+		ExpiresAt:   now.Add(s.ttl),
+	}
+
+	if err := s.actionRepo.Create(ctx, action); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to request approval")
+	}
+
+	data := map[string]string{
+		"pending_action_id": action.ID.Hex(),
+		"action_type":       string(action.Type),
+	}
+	tmpl := domain.NotificationTemplate{
+		Key: "approval_request",
+		Params: map[string]interface{}{
+			"PartnerName": requester.Name,
+			"Action":      pendingActionTitles[action.Type],
+			"Summary":     summary,
+		},
+	}
+	if err := s.notifications.Notify(ctx, *requester.PartnerID, domain.NotificationTypeApprovalRequest, tmpl, data); err != nil {
+		logging.FromContext(ctx).Warn("Failed to notify partner of pending action",
+			zap.Error(err),
+			zap.String("pending_action_id", action.ID.Hex()))
+	}
+
+	logging.FromContext(ctx).Info("Partner approval requested",
+		zap.String("pending_action_id", action.ID.Hex()),
+		zap.String("type", string(action.Type)),
+		zap.String("user_id", requesterID.Hex()))
+
+	return action.ToResponse(requesterID), nil
+}
+
+// ListPending retrieves the couple's actions waiting for approval
+func (s *PendingActionService) ListPending(ctx context.Context, userID primitive.ObjectID) (*domain.PendingActionListResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	actions, err := s.actionRepo.ListOpen(ctx, user.MatchCode, time.Now())
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get pending actions")
+	}
+
+	response := &domain.PendingActionListResponse{
+		Actions: make([]*domain.PendingActionResponse, 0, len(actions)),
+	}
+	for _, action := range actions {
+		response.Actions = append(response.Actions, action.ToResponse(userID))
+	}
+
+	return response, nil
+}
+
+// Approve approves the partner's action and carries it out
+func (s *PendingActionService) Approve(ctx context.Context, actionID, userID primitive.ObjectID) (*domain.PendingActionResponse, error) {
+	action, err := s.getDecidableAction(ctx, actionID, userID, domain.PendingActionStatusApproved)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	executor, ok := s.executors[action.Type]
+	s.mu.RUnlock()
+	if !ok {
+		logging.FromContext(ctx).Error("No executor registered for pending action type", zap.String("type", string(action.Type)))
+		return nil, domain.ErrOperationFailedError("This action cannot be carried out")
+	}
+
+	// Claim the action first so that it is carried out once even when both partners'
+	// devices approve at the same time
+	if err := s.actionRepo.UpdateStatus(ctx, action.ID, domain.PendingActionStatusPending, domain.PendingActionStatusApproved, userID); err != nil {
+		return nil, s.statusChangeError(ctx, action.ID, domain.PendingActionStatusApproved)
+	}
+
+	if err := executor.ExecutePendingAction(ctx, action); err != nil {
+		logging.FromContext(ctx).Error("Failed to carry out approved action",
+			zap.Error(err),
+			zap.String("pending_action_id", action.ID.Hex()),
+			zap.String("type", string(action.Type)))
+		if err := s.actionRepo.UpdateStatus(ctx, action.ID, domain.PendingActionStatusApproved, domain.PendingActionStatusFailed, userID); err != nil {
+			logging.FromContext(ctx).Error("Failed to mark pending action as failed", zap.Error(err))
+		}
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("Pending action approved",
+		zap.String("pending_action_id", action.ID.Hex()),
+		zap.String("type", string(action.Type)),
+		zap.String("user_id", userID.Hex()))
+
+	return s.decided(ctx, action, userID, domain.PendingActionStatusApproved)
+}
+
+// Reject rejects the partner's action
+func (s *PendingActionService) Reject(ctx context.Context, actionID, userID primitive.ObjectID) (*domain.PendingActionResponse, error) {
+	action, err := s.getDecidableAction(ctx, actionID, userID, domain.PendingActionStatusRejected)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.actionRepo.UpdateStatus(ctx, action.ID, domain.PendingActionStatusPending, domain.PendingActionStatusRejected, userID); err != nil {
+		return nil, s.statusChangeError(ctx, action.ID, domain.PendingActionStatusRejected)
+	}
+
+	logging.FromContext(ctx).Info("Pending action rejected",
+		zap.String("pending_action_id", action.ID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return s.decided(ctx, action, userID, domain.PendingActionStatusRejected)
+}
+
+// Cancel withdraws an action the user asked for
+func (s *PendingActionService) Cancel(ctx context.Context, actionID, userID primitive.ObjectID) (*domain.PendingActionResponse, error) {
+	action, err := s.actionRepo.GetByID(ctx, actionID)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Pending action")
+	}
+
+	if action.RequestedBy != userID {
+		return nil, domain.ErrForbiddenError()
+	}
+
+	if status := action.EffectiveStatus(time.Now()); status != domain.PendingActionStatusPending {
+		return nil, domain.ErrInvalidStatusChangeError(string(status), string(domain.PendingActionStatusCancelled))
+	}
+
+	if err := s.actionRepo.UpdateStatus(ctx, action.ID, domain.PendingActionStatusPending, domain.PendingActionStatusCancelled, userID); err != nil {
+		return nil, s.statusChangeError(ctx, action.ID, domain.PendingActionStatusCancelled)
+	}
+
+	logging.FromContext(ctx).Info("Pending action cancelled",
+		zap.String("pending_action_id", action.ID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return s.reload(ctx, action, userID)
+}
+
+// getDecidableAction loads an action that the user, as the requester's partner, may
+// still approve or reject
+func (s *PendingActionService) getDecidableAction(ctx context.Context, actionID, userID primitive.ObjectID, to domain.PendingActionStatus) (*domain.PendingAction, error) {
+	action, err := s.actionRepo.GetByID(ctx, actionID)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Pending action")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" || action.MatchCode != user.MatchCode {
+		return nil, domain.ErrNotFoundError("Pending action")
+	}
+
+	// Only the partner can decide on an action, never the one who asked for it
+	if action.RequestedBy == userID {
+		return nil, domain.ErrForbiddenError()
+	}
+
+	switch status := action.EffectiveStatus(time.Now()); status {
+	case domain.PendingActionStatusPending:
+		return action, nil
+	case domain.PendingActionStatusExpired:
+		return nil, domain.ErrPendingActionExpiredError()
+	default:
+		return nil, domain.ErrInvalidStatusChangeError(string(status), string(to))
+	}
+}
+
+// statusChangeError explains why an action could not be moved to the to status,
+// usually because it was decided on concurrently
+func (s *PendingActionService) statusChangeError(ctx context.Context, actionID primitive.ObjectID, to domain.PendingActionStatus) error {
+	action, err := s.actionRepo.GetByID(ctx, actionID)
+	if err != nil {
+		return domain.ErrOperationFailedError("Failed to update pending action")
+	}
+	return domain.ErrInvalidStatusChangeError(string(action.EffectiveStatus(time.Now())), string(to))
+}
+
+// decided notifies the requester that their partner decided on their action
+func (s *PendingActionService) decided(ctx context.Context, action *domain.PendingAction, userID primitive.ObjectID, status domain.PendingActionStatus) (*domain.PendingActionResponse, error) {
+	var partnerName interface{} = domain.NotificationMessage("notification_your_partner")
+	if partner, err := s.userRepo.GetByID(ctx, userID); err == nil {
+		partnerName = partner.Name
+	}
+
+	tmpl := domain.NotificationTemplate{
+		Key: "approval_approved",
+		Params: map[string]interface{}{
+			"PartnerName": partnerName,
+			"Action":      pendingActionTitles[action.Type],
+			"Summary":     action.Summary,
+		},
+	}
+	if status == domain.PendingActionStatusRejected {
+		tmpl.Key = "approval_rejected"
+	}
+
+	data := map[string]string{
+		"pending_action_id": action.ID.Hex(),
+		"action_type":       string(action.Type),
+		"status":            string(status),
+	}
+	if err := s.notifications.Notify(ctx, action.RequestedBy, domain.NotificationTypeApprovalDecision, tmpl, data); err != nil {
+		logging.FromContext(ctx).Warn("Failed to notify requester of decision",
+			zap.Error(err),
+			zap.String("pending_action_id", action.ID.Hex()))
+	}
+
+	return s.reload(ctx, action, userID)
+}
+
+// reload returns the latest state of an action, falling back to the given copy
+func (s *PendingActionService) reload(ctx context.Context, action *domain.PendingAction, userID primitive.ObjectID) (*domain.PendingActionResponse, error) {
+	if updated, err := s.actionRepo.GetByID(ctx, action.ID); err == nil {
+		action = updated
+	}
+	return action.ToResponse(userID), nil
+}
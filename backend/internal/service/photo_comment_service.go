@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"strings"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// PhotoCommentService implements domain.PhotoCommentService
+type PhotoCommentService struct {
+	commentRepo         domain.PhotoCommentRepository
+	photoRepo           domain.PhotoRepository
+	userRepo            domain.UserRepository
+	notificationService domain.NotificationService
+}
+
+// NewPhotoCommentService creates a new photo comment service
+func NewPhotoCommentService(
+	commentRepo domain.PhotoCommentRepository,
+	photoRepo domain.PhotoRepository,
+	userRepo domain.UserRepository,
+	notificationService domain.NotificationService,
+) domain.PhotoCommentService {
+	return &PhotoCommentService{
+		commentRepo:         commentRepo,
+		photoRepo:           photoRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+	}
+}
+
+// AddComment comments on one of the couple's photos and notifies the partner
+func (s *PhotoCommentService) AddComment(ctx context.Context, photoID, userID primitive.ObjectID, req *domain.CreatePhotoCommentRequest) (*domain.PhotoCommentResponse, error) {
+	user, photo, err := s.getAuthorizedPhoto(ctx, photoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, domain.ErrInvalidRequestError("Comment cannot be empty")
+	}
+
+	comment := &domain.PhotoComment{
+		MatchCode: user.MatchCode,
+		PhotoID:   photo.ID,
+		AuthorID:  userID,
+		Body:      body,
+	}
+
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		logging.FromContext(ctx).Error("Failed to create photo comment", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to add comment")
+	}
+
+	logging.FromContext(ctx).Info("Photo comment added",
+		zap.String("comment_id", comment.ID.Hex()),
+		zap.String("photo_id", photoID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	s.notifyPartner(ctx, user, comment)
+
+	return comment.ToResponse(userID), nil
+}
+
+// GetComments retrieves the comment thread of one of the couple's photos, oldest first
+func (s *PhotoCommentService) GetComments(ctx context.Context, photoID, userID primitive.ObjectID, page, limit int) (*domain.PhotoCommentListResponse, error) {
+	user, _, err := s.getAuthorizedPhoto(ctx, photoID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * limit
+
+	comments, err := s.commentRepo.GetByPhoto(ctx, user.MatchCode, photoID, limit, offset)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get photo comments", zap.Error(err), zap.String("photo_id", photoID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to get comments")
+	}
+
+	total, err := s.commentRepo.CountByPhoto(ctx, user.MatchCode, photoID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count photo comments", zap.Error(err), zap.String("photo_id", photoID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to get comments")
+	}
+
+	responses := make([]*domain.PhotoCommentResponse, len(comments))
+	for i, comment := range comments {
+		responses[i] = comment.ToResponse(userID)
+	}
+
+	return &domain.PhotoCommentListResponse{
+		Comments: responses,
+		Total:    total,
+		Page:     page,
+		Limit:    limit,
+	}, nil
+}
+
+// DeleteComment deletes a comment on one of the couple's photos; only its author may
+// delete it
+func (s *PhotoCommentService) DeleteComment(ctx context.Context, photoID, commentID, userID primitive.ObjectID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return domain.ErrNotMatchedError()
+	}
+
+	comment, err := s.commentRepo.GetByID(ctx, user.MatchCode, commentID)
+	if err != nil || comment.PhotoID != photoID {
+		return domain.ErrNotFoundError("Comment")
+	}
+
+	if comment.AuthorID != userID {
+		return domain.ErrForbiddenError()
+	}
+
+	if err := s.commentRepo.Delete(ctx, commentID); err != nil {
+		logging.FromContext(ctx).Error("Failed to delete photo comment", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to delete comment")
+	}
+
+	logging.FromContext(ctx).Info("Photo comment deleted",
+		zap.String("comment_id", commentID.Hex()),
+		zap.String("photo_id", photoID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return nil
+}
+
+// getAuthorizedPhoto retrieves the user and one of the couple's photos the user can see
+func (s *PhotoCommentService) getAuthorizedPhoto(ctx context.Context, photoID, userID primitive.ObjectID) (*domain.User, *domain.Photo, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, nil, domain.ErrNotMatchedError()
+	}
+
+	photo, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		return nil, nil, domain.ErrNotFoundError("Photo")
+	}
+
+	if photo.MatchCode != user.MatchCode {
+		return nil, nil, domain.ErrForbiddenError()
+	}
+	if !photo.IsVisibleTo(userID) {
+		return nil, nil, domain.ErrNotFoundError("Photo")
+	}
+
+	return user, photo, nil
+}
+
+// notifyPartner tells the partner about a new comment. Failures are logged; the
+// comment is kept.
+func (s *PhotoCommentService) notifyPartner(ctx context.Context, author *domain.User, comment *domain.PhotoComment) {
+	if author.PartnerID == nil {
+		return
+	}
+
+	var authorName interface{} = domain.NotificationMessage("notification_your_partner")
+	if author.Name != "" {
+		authorName = author.Name
+	}
+
+	tmpl := domain.NotificationTemplate{
+		Key:    "photo_comment",
+		Params: map[string]interface{}{"AuthorName": authorName, "Body": comment.Body},
+	}
+	data := map[string]string{
+		"photo_id":   comment.PhotoID.Hex(),
+		"comment_id": comment.ID.Hex(),
+	}
+	if err := s.notificationService.Notify(ctx, *author.PartnerID, domain.NotificationTypePhotoComment, tmpl, data); err != nil {
+		logging.FromContext(ctx).Warn("Failed to notify partner of photo comment",
+			zap.Error(err),
+			zap.String("comment_id", comment.ID.Hex()))
+	}
+}
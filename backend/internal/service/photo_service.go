@@ -2,35 +2,82 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/imaging"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
 )
 
+// duplicatePhotoMaxDistance is how many bits the image hashes of two photos may differ
+// by for them to count as duplicates
+const duplicatePhotoMaxDistance = 6
+
+// photoFolder is the storage folder of photos added with a file
+const photoFolder = "photos"
+
+// Photo map limits: the deepest zoom level of map tiles, and the most clusters returned
+const (
+	photoMapMaxZoom     = 20
+	photoMapMaxClusters = 500
+)
+
 // PhotoService implements domain.PhotoService
 type PhotoService struct {
 	photoRepo      domain.PhotoRepository
 	userRepo       domain.UserRepository
-	storageService domain.StorageService
-	logger         *zap.Logger
+	albumRepo        domain.AlbumRepository
+	storageService   domain.StorageService
+	scanService      domain.UploadScanService
+	imageService     domain.ImageService
+	videoService     domain.VideoService
+	watermarkService domain.WatermarkService
+	events           domain.EventPublisher
+	uploadPolicy     *domain.UploadPolicy
+	urlExpiry        domain.PhotoURLExpiry
+	videoLimits      domain.VideoLimits
 }
 
-// NewPhotoService creates a new photo service
+// NewPhotoService creates a new photo service whose download URLs stay valid within
+// urlExpiry, and which accepts files that uploadPolicy allows, and videos within
+// videoLimits
 func NewPhotoService(
 	photoRepo domain.PhotoRepository,
 	userRepo domain.UserRepository,
+	albumRepo domain.AlbumRepository,
 	storageService domain.StorageService,
-	logger *zap.Logger,
+	scanService domain.UploadScanService,
+	imageService domain.ImageService,
+	videoService domain.VideoService,
+	watermarkService domain.WatermarkService,
+	events domain.EventPublisher,
+	uploadPolicy *domain.UploadPolicy,
+	urlExpiry domain.PhotoURLExpiry,
+	videoLimits domain.VideoLimits,
 ) domain.PhotoService {
 	return &PhotoService{
-		photoRepo:      photoRepo,
-		userRepo:       userRepo,
-		storageService: storageService,
-		logger:         logger,
+		photoRepo:        photoRepo,
+		userRepo:         userRepo,
+		albumRepo:        albumRepo,
+		storageService:   storageService,
+		scanService:      scanService,
+		imageService:     imageService,
+		videoService:     videoService,
+		watermarkService: watermarkService,
+		events:           events,
+		uploadPolicy:     uploadPolicy,
+		urlExpiry:        urlExpiry,
+		videoLimits:      videoLimits,
 	}
 }
 
@@ -39,14 +86,15 @@ func (s *PhotoService) CreatePhoto(ctx context.Context, userID primitive.ObjectI
 	// Get user to get match code
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, domain.ErrUserNotFoundError()
 	}
 
 	if user.MatchCode == "" {
-		return nil, fmt.Errorf("user is not matched with anyone")
+		return nil, domain.ErrNotMatchedError()
 	}
 
-	var imageURL string
+	var imageURL, checksum string
+	var isVideo bool
 	
 	// Handle file upload if file is provided
 	if file != nil {
@@ -54,14 +102,33 @@ func (s *PhotoService) CreatePhoto(ctx context.Context, userID primitive.ObjectI
 			// Open the uploaded file
 			src, err := fileHeader.Open()
 			if err != nil {
-				s.logger.Error("Failed to open uploaded file", zap.Error(err))
-				return nil, fmt.Errorf("failed to open file")
+				logging.FromContext(ctx).Error("Failed to open uploaded file", zap.Error(err))
+				return nil, domain.ErrFileUploadFailedError("could not read the file")
 			}
 			defer src.Close()
 
-			// Validate file type and size
-			if err := domain.ValidateImageFile(fileHeader.Header.Get("Content-Type"), fileHeader.Size); err != nil {
-				return nil, fmt.Errorf("invalid file: %w", err)
+			// Validate file type and size against the upload policy, then keep to images and videos
+			contentType := fileHeader.Header.Get("Content-Type")
+			if err := s.uploadPolicy.Validate(photoFolder, fileHeader.Filename, contentType, fileHeader.Size); err != nil {
+				return nil, err
+			}
+			isVideo = domain.IsVideoContentType(contentType)
+			if isVideo {
+				if err := domain.ValidateVideoFile(contentType, fileHeader.Size, s.videoLimits.MaxSize); err != nil {
+					return nil, domain.ErrFileTooLargeError(s.videoLimits.MaxSize)
+				}
+			} else if domain.GetFileType(contentType) != domain.FileTypeImage {
+				return nil, domain.ErrUnsupportedFileTypeError(contentType)
+			}
+
+			// Check that the content is what it was declared as before storing it
+			head, err := domain.ReadHead(src)
+			if err != nil {
+				logging.FromContext(ctx).Error("Failed to read uploaded file", zap.Error(err))
+				return nil, domain.ErrFileUploadFailedError("could not read the file")
+			}
+			if err := s.uploadPolicy.ValidateContent(contentType, head); err != nil {
+				return nil, err
 			}
 
 			// Upload to storage
@@ -69,20 +136,24 @@ func (s *PhotoService) CreatePhoto(ctx context.Context, userID primitive.ObjectI
 				File:        src,
 				Filename:    fileHeader.Filename,
 				ContentType: fileHeader.Header.Get("Content-Type"),
-				Folder:      "photos",
+				Folder:      photoFolder,
 				UserID:      userID.Hex(),
 			}
 
 			fileInfo, err := s.storageService.Upload(ctx, uploadReq)
 			if err != nil {
-				s.logger.Error("Failed to upload file to storage", zap.Error(err))
-				return nil, fmt.Errorf("failed to upload file")
+				logging.FromContext(ctx).Error("Failed to upload file to storage", zap.Error(err))
+				return nil, domain.ErrFileUploadFailedError("could not store the file")
+			}
+			if err := s.scanService.ScanUpload(ctx, fileInfo); err != nil {
+				return nil, err
 			}
 
 			// Store the MinIO key (not the full URL) so backend can proxy it
 			// Key format: "photos/userid/filename.jpg"
 			imageURL = fileInfo.Key
-			s.logger.Info("File uploaded successfully", 
+			checksum = fileInfo.Checksum
+			logging.FromContext(ctx).Info("File uploaded successfully", 
 				zap.String("key", fileInfo.Key),
 				zap.String("url", fileInfo.URL))
 		}
@@ -90,7 +161,7 @@ func (s *PhotoService) CreatePhoto(ctx context.Context, userID primitive.ObjectI
 		// Use provided URL if no file uploaded
 		imageURL = req.ImageURL
 	} else {
-		return nil, fmt.Errorf("either file or image URL is required")
+		return nil, domain.ErrInvalidRequestError("Either a file or an image URL is required")
 	}
 
 	// Set default date if not provided
@@ -109,21 +180,35 @@ func (s *PhotoService) CreatePhoto(ctx context.Context, userID primitive.ObjectI
 		Description: req.Description,
 		ImageURL:    imageURL,
 		Date:        photoDate,
-		Location:    req.Location,
 		Tags:        req.Tags,
 		IsPrivate:   req.IsPrivate,
+		Checksum:    checksum,
+	}
+	if isVideo {
+		if err := s.prepareVideo(ctx, photo); err != nil {
+			s.deleteFile(ctx, imageURL)
+			return nil, err
+		}
+		setPlace(&photo.Location, &photo.Place, req.Location, req.Place)
+	} else {
+		s.generateVariants(ctx, photo)
+		s.recordImageHash(ctx, photo)
+		setPlace(&photo.Location, &photo.Place, req.Location, req.Place)
+		s.applyMetadata(ctx, photo, req.Date == nil || req.Date.IsZero(), photo.Location == "")
 	}
 
 	if err := s.photoRepo.Create(ctx, photo); err != nil {
-		s.logger.Error("Failed to create photo", zap.Error(err))
-		return nil, fmt.Errorf("failed to create photo")
+		logging.FromContext(ctx).Error("Failed to create photo", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create photo")
 	}
 
-	s.logger.Info("Photo created successfully",
+	logging.FromContext(ctx).Info("Photo created successfully",
 		zap.String("photo_id", photo.ID.Hex()),
 		zap.String("created_by", userID.Hex()),
 		zap.String("image_url", imageURL))
 
+	s.publishPhotoCreated(ctx, photo)
+
 	return photo.ToResponse(), nil
 }
 
@@ -132,11 +217,11 @@ func (s *PhotoService) CreatePhotoWithPath(ctx context.Context, userID primitive
 	// Get user to get match code
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, domain.ErrUserNotFoundError()
 	}
 
 	if user.MatchCode == "" {
-		return nil, fmt.Errorf("user is not matched with anyone")
+		return nil, domain.ErrNotMatchedError()
 	}
 
 	// Convert file path to full URL
@@ -158,6 +243,12 @@ func (s *PhotoService) CreatePhotoWithPath(ctx context.Context, userID primitive
 		photoDate = time.Now()
 	}
 
+	// Resolve optional album
+	albumID, err := s.resolveAlbumID(ctx, user.MatchCode, req.AlbumID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create photo
 	photo := &domain.Photo{
 		MatchCode:   user.MatchCode,
@@ -166,44 +257,108 @@ func (s *PhotoService) CreatePhotoWithPath(ctx context.Context, userID primitive
 		Description: req.Description,
 		ImageURL:    imageURL,
 		Date:        photoDate,
-		Location:    req.Location,
 		Tags:        req.Tags,
 		IsPrivate:   req.IsPrivate,
+		AlbumID:     albumID,
+	}
+	if domain.IsVideoKey(photo.StorageKey()) {
+		if err := s.checkVideoSize(ctx, photo.StorageKey()); err != nil {
+			return nil, err
+		}
+		if err := s.prepareVideo(ctx, photo); err != nil {
+			return nil, err
+		}
+		setPlace(&photo.Location, &photo.Place, req.Location, req.Place)
+	} else {
+		s.generateVariants(ctx, photo)
+		s.recordImageHash(ctx, photo)
+		setPlace(&photo.Location, &photo.Place, req.Location, req.Place)
+		s.applyMetadata(ctx, photo, req.Date == nil || req.Date.IsZero(), photo.Location == "")
 	}
+	s.recordChecksum(ctx, photo)
 
 	if err := s.photoRepo.Create(ctx, photo); err != nil {
-		s.logger.Error("Failed to create photo", zap.Error(err))
-		return nil, fmt.Errorf("failed to create photo")
+		logging.FromContext(ctx).Error("Failed to create photo", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create photo")
 	}
 
-	s.logger.Info("Photo created successfully with path",
+	logging.FromContext(ctx).Info("Photo created successfully with path",
 		zap.String("photo_id", photo.ID.Hex()),
 		zap.String("created_by", userID.Hex()),
 		zap.String("file_path", req.FilePath),
 		zap.String("image_url", imageURL))
 
+	s.publishPhotoCreated(ctx, photo)
+
 	return photo.ToResponse(), nil
 }
 
+// publishPhotoCreated publishes the photo.created event of a new photo
+func (s *PhotoService) publishPhotoCreated(ctx context.Context, photo *domain.Photo) {
+	s.events.Publish(ctx, domain.DomainEventPhotoCreated, &domain.PhotoCreatedData{
+		PhotoID:   photo.ID.Hex(),
+		MatchCode: photo.MatchCode,
+		CreatedBy: photo.CreatedBy.Hex(),
+		IsPrivate: photo.IsPrivate,
+	})
+}
+
 // GetPhoto retrieves a photo by ID
 func (s *PhotoService) GetPhoto(ctx context.Context, photoID, userID primitive.ObjectID) (*domain.PhotoResponse, error) {
 	photo, err := s.photoRepo.GetByID(ctx, photoID)
 	if err != nil {
-		return nil, fmt.Errorf("photo not found")
+		return nil, domain.ErrNotFoundError("Photo")
 	}
 
 	// Get user to verify match code
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, domain.ErrUserNotFoundError()
 	}
 
 	// Check if user has access to this photo
 	if photo.MatchCode != user.MatchCode {
-		return nil, fmt.Errorf("access denied")
+		return nil, domain.ErrForbiddenError()
 	}
 
-	return photo.ToResponse(), nil
+	// The partner's private photos are not found
+	if !photo.IsVisibleTo(userID) {
+		return nil, domain.ErrNotFoundError("Photo")
+	}
+
+	return s.toResponses(ctx, user, []*domain.Photo{photo})[0], nil
+}
+
+// GetSharedPreview retrieves a photo as it appears on shared and public pages,
+// with the image pointing at the watermarked variant when watermarking is enabled
+func (s *PhotoService) GetSharedPreview(ctx context.Context, photoID, userID primitive.ObjectID) (*domain.PhotoResponse, error) {
+	photo, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Photo")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if photo.MatchCode != user.MatchCode {
+		return nil, domain.ErrForbiddenError()
+	}
+	if !photo.IsVisibleTo(userID) {
+		return nil, domain.ErrNotFoundError("Photo")
+	}
+
+	imageKey, err := s.watermarkService.SharedImageKey(ctx, photo)
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.toResponses(ctx, user, []*domain.Photo{photo})[0]
+	response.ImageURL = domain.FileURL(imageKey, photo.UpdatedAt)
+	response.Metadata = nil
+
+	return response, nil
 }
 
 // GetCouplePhotos retrieves photos for a couple with pagination
@@ -211,7 +366,7 @@ func (s *PhotoService) GetCouplePhotos(ctx context.Context, userID primitive.Obj
 	// Get user to get match code
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, 0, fmt.Errorf("user not found")
+		return nil, 0, domain.ErrUserNotFoundError()
 	}
 
 	if user.MatchCode == "" {
@@ -221,46 +376,73 @@ func (s *PhotoService) GetCouplePhotos(ctx context.Context, userID primitive.Obj
 	// Calculate offset from page
 	offset := (page - 1) * limit
 	
-	photos, err := s.photoRepo.GetByMatchCode(ctx, user.MatchCode, limit, offset)
+	photos, err := s.photoRepo.GetByMatchCode(ctx, user.MatchCode, user.ID, limit, offset)
 	if err != nil {
-		s.logger.Error("Failed to get user photos", zap.Error(err))
-		return nil, 0, fmt.Errorf("failed to get photos")
+		logging.FromContext(ctx).Error("Failed to get user photos", zap.Error(err))
+		return nil, 0, domain.ErrOperationFailedError("Failed to get photos")
 	}
 
-	// Get total count for pagination
-	// Note: You may need to add a Count method to your repository
-	total := int64(len(photos)) // This is a simplified approach
-
-	responses := make([]*domain.PhotoResponse, len(photos))
-	for i, photo := range photos {
-		responses[i] = photo.ToResponse()
+	total, err := s.photoRepo.CountByMatchCode(ctx, user.MatchCode, user.ID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count photos", zap.Error(err))
+		return nil, 0, domain.ErrOperationFailedError("Failed to get photos")
 	}
 
+	responses := s.toResponses(ctx, user, photos)
+
 	return responses, total, nil
 }
 
+// GetCouplePhotosCursor retrieves the couple's photos newest first, starting after cursor.
+// The returned next cursor is empty once the last page has been reached.
+func (s *PhotoService) GetCouplePhotosCursor(ctx context.Context, userID primitive.ObjectID, cursor *domain.Cursor, limit int) ([]*domain.PhotoResponse, string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, "", domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return []*domain.PhotoResponse{}, "", nil
+	}
+
+	// Fetch one extra photo to know whether another page exists
+	photos, err := s.photoRepo.GetByMatchCodeCursor(ctx, user.MatchCode, user.ID, cursor, limit+1)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get photos by cursor", zap.Error(err))
+		return nil, "", domain.ErrOperationFailedError("Failed to get photos")
+	}
+
+	nextCursor := ""
+	if len(photos) > limit {
+		photos = photos[:limit]
+		last := photos[len(photos)-1]
+		nextCursor = domain.NewCursor(last.CreatedAt, last.ID).Encode()
+	}
+
+	responses := s.toResponses(ctx, user, photos)
+
+	return responses, nextCursor, nil
+}
+
 // GetPhotosByDate retrieves photos by date
 func (s *PhotoService) GetPhotosByDate(ctx context.Context, userID primitive.ObjectID, date time.Time) ([]*domain.PhotoResponse, error) {
 	// Get user to get match code
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, domain.ErrUserNotFoundError()
 	}
 
 	if user.MatchCode == "" {
-		return nil, fmt.Errorf("user is not matched")
+		return nil, domain.ErrNotMatchedError()
 	}
 
-	photos, err := s.photoRepo.GetByMatchCodeAndDate(ctx, user.MatchCode, date)
+	photos, err := s.photoRepo.GetByMatchCodeAndDate(ctx, user.MatchCode, user.ID, date)
 	if err != nil {
-		s.logger.Error("Failed to get photos by date", zap.Error(err))
-		return nil, fmt.Errorf("failed to get photos")
+		logging.FromContext(ctx).Error("Failed to get photos by date", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get photos")
 	}
 
-	responses := make([]*domain.PhotoResponse, len(photos))
-	for i, photo := range photos {
-		responses[i] = photo.ToResponse()
-	}
+	responses := s.toResponses(ctx, user, photos)
 
 	return responses, nil
 }
@@ -270,18 +452,26 @@ func (s *PhotoService) UpdatePhoto(ctx context.Context, photoID, userID primitiv
 	// Get existing photo
 	photo, err := s.photoRepo.GetByID(ctx, photoID)
 	if err != nil {
-		return nil, fmt.Errorf("photo not found")
+		return nil, domain.ErrNotFoundError("Photo")
 	}
 
 	// Get user to verify match code
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, domain.ErrUserNotFoundError()
 	}
 
 	// Check authorization via match code
 	if photo.MatchCode != user.MatchCode {
-		return nil, fmt.Errorf("access denied")
+		return nil, domain.ErrForbiddenError()
+	}
+	if !photo.IsVisibleTo(userID) {
+		return nil, domain.ErrNotFoundError("Photo")
+	}
+
+	// Only the partner who uploaded a photo decides whether it is private
+	if req.IsPrivate != nil && *req.IsPrivate != photo.IsPrivate && photo.CreatedBy != userID {
+		return nil, domain.ErrForbiddenError()
 	}
 
 	// Update fields if provided
@@ -291,14 +481,17 @@ func (s *PhotoService) UpdatePhoto(ctx context.Context, photoID, userID primitiv
 	if req.Description != "" {
 		photo.Description = req.Description
 	}
-	if req.ImageURL != "" {
+	if req.ImageURL != "" && req.ImageURL != photo.ImageURL {
 		photo.ImageURL = req.ImageURL
+		s.generateVariants(ctx, photo)
+		s.recordImageHash(ctx, photo)
+		s.applyMetadata(ctx, photo, false, false)
 	}
 	if req.Date != nil && !req.Date.IsZero() {
 		photo.Date = req.Date.Time
 	}
-	if req.Location != "" {
-		photo.Location = req.Location
+	if req.Location != "" || req.Place != nil {
+		setPlace(&photo.Location, &photo.Place, req.Location, req.Place)
 	}
 	if req.Tags != nil {
 		photo.Tags = req.Tags
@@ -307,16 +500,35 @@ func (s *PhotoService) UpdatePhoto(ctx context.Context, photoID, userID primitiv
 		photo.IsPrivate = *req.IsPrivate
 	}
 
+	// Move the photo to another album (or out of its album) if requested
+	var albumChanged bool
+	if req.AlbumID != nil {
+		albumID, err := s.resolveAlbumID(ctx, user.MatchCode, *req.AlbumID)
+		if err != nil {
+			return nil, err
+		}
+		photo.AlbumID = albumID
+		albumChanged = true
+	}
+
 	if err := s.photoRepo.Update(ctx, photoID, photo); err != nil {
-		s.logger.Error("Failed to update photo", zap.Error(err))
-		return nil, fmt.Errorf("failed to update photo")
+		logging.FromContext(ctx).Error("Failed to update photo", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update photo")
 	}
 
-	s.logger.Info("Photo updated successfully",
+	// Update only sets fields, so removing the album needs an explicit unset
+	if albumChanged && photo.AlbumID == nil {
+		if _, err := s.photoRepo.SetAlbum(ctx, user.MatchCode, []primitive.ObjectID{photoID}, nil); err != nil {
+			logging.FromContext(ctx).Error("Failed to remove photo from album", zap.Error(err))
+			return nil, domain.ErrOperationFailedError("Failed to update photo")
+		}
+	}
+
+	logging.FromContext(ctx).Info("Photo updated successfully",
 		zap.String("photo_id", photoID.Hex()),
 		zap.String("user_id", userID.Hex()))
 
-	return photo.ToResponse(), nil
+	return s.toResponses(ctx, user, []*domain.Photo{photo})[0], nil
 }
 
 // DeletePhoto deletes a photo
@@ -324,54 +536,616 @@ func (s *PhotoService) DeletePhoto(ctx context.Context, photoID, userID primitiv
 	// Get existing photo
 	photo, err := s.photoRepo.GetByID(ctx, photoID)
 	if err != nil {
-		return fmt.Errorf("photo not found")
+		return domain.ErrNotFoundError("Photo")
 	}
 
 	// Get user to verify match code
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("user not found")
+		return domain.ErrUserNotFoundError()
 	}
 
 	// Check authorization via match code
 	if photo.MatchCode != user.MatchCode {
-		return fmt.Errorf("access denied")
+		return domain.ErrForbiddenError()
+	}
+	if !photo.IsVisibleTo(userID) {
+		return domain.ErrNotFoundError("Photo")
 	}
 
 	if err := s.photoRepo.Delete(ctx, photoID); err != nil {
-		s.logger.Error("Failed to delete photo", zap.Error(err))
-		return fmt.Errorf("failed to delete photo")
+		logging.FromContext(ctx).Error("Failed to delete photo", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to delete photo")
 	}
 
-	s.logger.Info("Photo deleted successfully",
+	logging.FromContext(ctx).Info("Photo deleted successfully",
 		zap.String("photo_id", photoID.Hex()),
 		zap.String("user_id", userID.Hex()))
 
 	return nil
 }
 
-// SearchPhotos searches photos by query
-func (s *PhotoService) SearchPhotos(ctx context.Context, userID primitive.ObjectID, query string, limit, offset int) ([]*domain.PhotoResponse, error) {
-	// Get user to get match code
+// BulkDeletePhotos moves several of the couple's photos to the trash at once
+func (s *PhotoService) BulkDeletePhotos(ctx context.Context, userID primitive.ObjectID, req *domain.BulkIDsRequest) (*domain.BulkResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	deleted, err := s.photoRepo.BulkDelete(ctx, user.MatchCode, user.ID, domain.ParseBulkIDs(req.IDs))
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to bulk delete photos", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to delete photos")
+	}
+
+	logging.FromContext(ctx).Info("Photos bulk deleted",
+		zap.String("user_id", userID.Hex()),
+		zap.Int("requested", len(req.IDs)),
+		zap.Int("deleted", len(deleted)))
+
+	return domain.NewBulkResponse(req.IDs, deleted), nil
+}
+
+// BulkTagPhotos adds and removes tags on several of the couple's photos at once
+func (s *PhotoService) BulkTagPhotos(ctx context.Context, userID primitive.ObjectID, req *domain.BulkTagRequest) (*domain.BulkResponse, error) {
+	add := normalizeTags(req.Add)
+	remove := normalizeTags(req.Remove)
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, domain.ErrInvalidRequestError("At least one tag to add or remove is required")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	updated, err := s.photoRepo.BulkUpdateTags(ctx, user.MatchCode, user.ID, domain.ParseBulkIDs(req.IDs), add, remove)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to bulk tag photos", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update photo tags")
+	}
+
+	logging.FromContext(ctx).Info("Photos bulk tagged",
+		zap.String("user_id", userID.Hex()),
+		zap.Int("requested", len(req.IDs)),
+		zap.Int("updated", len(updated)))
+
+	return domain.NewBulkResponse(req.IDs, updated), nil
+}
+
+// FavoritePhoto marks one of the couple's photos as a favorite of the user. Each
+// partner has their own favorites.
+func (s *PhotoService) FavoritePhoto(ctx context.Context, photoID, userID primitive.ObjectID) (*domain.PhotoResponse, error) {
+	return s.setFavorite(ctx, photoID, userID, true)
+}
+
+// UnfavoritePhoto removes one of the couple's photos from the user's favorites
+func (s *PhotoService) UnfavoritePhoto(ctx context.Context, photoID, userID primitive.ObjectID) (*domain.PhotoResponse, error) {
+	return s.setFavorite(ctx, photoID, userID, false)
+}
+
+// setFavorite marks or unmarks a photo as a favorite of the user. Doing it twice has
+// no further effect.
+func (s *PhotoService) setFavorite(ctx context.Context, photoID, userID primitive.ObjectID, favorite bool) (*domain.PhotoResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	photo, err := s.photoRepo.SetFavorite(ctx, user.MatchCode, photoID, userID, favorite)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to set photo favorite", zap.Error(err), zap.String("photo_id", photoID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to update favorites")
+	}
+	if photo == nil {
+		return nil, domain.ErrNotFoundError("Photo")
+	}
+
+	logging.FromContext(ctx).Info("Photo favorite updated",
+		zap.String("photo_id", photoID.Hex()),
+		zap.String("user_id", userID.Hex()),
+		zap.Bool("favorite", favorite))
+
+	return s.toResponses(ctx, user, []*domain.Photo{photo})[0], nil
+}
+
+// GetFavoritePhotos retrieves the couple's photos the user marked as favorites, by
+// date, with pagination
+func (s *PhotoService) GetFavoritePhotos(ctx context.Context, userID primitive.ObjectID, page, limit int) ([]*domain.PhotoResponse, int64, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, 0, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return []*domain.PhotoResponse{}, 0, nil
+	}
+
+	offset := (page - 1) * limit
+
+	photos, err := s.photoRepo.GetFavorites(ctx, user.MatchCode, userID, limit, offset)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get favorite photos", zap.Error(err))
+		return nil, 0, domain.ErrOperationFailedError("Failed to get photos")
+	}
+
+	total, err := s.photoRepo.CountFavorites(ctx, user.MatchCode, userID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count favorite photos", zap.Error(err))
+		return nil, 0, domain.ErrOperationFailedError("Failed to get photos")
+	}
+
+	return s.toResponses(ctx, user, photos), total, nil
+}
+
+// GetDuplicatePhotos groups the couple's photos whose images look near-identical, so
+// that they can clean up their gallery. Photos stored before image hashes were
+// recorded are not compared.
+func (s *PhotoService) GetDuplicatePhotos(ctx context.Context, userID primitive.ObjectID) (*domain.DuplicatePhotosResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return &domain.DuplicatePhotosResponse{Groups: []*domain.DuplicatePhotoGroup{}}, nil
+	}
+
+	photos, err := s.photoRepo.GetWithImageHash(ctx, user.MatchCode, user.ID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get photos with image hash", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get photos")
+	}
+
+	groups := make([]*domain.DuplicatePhotoGroup, 0)
+	for _, group := range groupDuplicatePhotos(photos, logging.FromContext(ctx)) {
+		groups = append(groups, &domain.DuplicatePhotoGroup{Photos: s.toResponses(ctx, user, group)})
+	}
+
+	return &domain.DuplicatePhotosResponse{Groups: groups, Total: len(groups)}, nil
+}
+
+// groupDuplicatePhotos groups photos whose image hashes are at most
+// duplicatePhotoMaxDistance bits apart, transitively. Photos keep their order within
+// and across groups, and photos without a near-identical one are left out.
+func groupDuplicatePhotos(photos []*domain.Photo, logger *zap.Logger) [][]*domain.Photo {
+	hashes := make([]uint64, 0, len(photos))
+	hashed := make([]*domain.Photo, 0, len(photos))
+	for _, photo := range photos {
+		hash, err := strconv.ParseUint(photo.ImageHash, 16, 64)
+		if err != nil {
+			logger.Warn("Invalid photo image hash", zap.String("photo_id", photo.ID.Hex()))
+			continue
+		}
+		hashes = append(hashes, hash)
+		hashed = append(hashed, photo)
+	}
+
+	// Union-find over the photos, each set rooted at its first photo
+	parent := make([]int, len(hashed))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	for i := range hashes {
+		for j := i + 1; j < len(hashes); j++ {
+			if imaging.HashDistance(hashes[i], hashes[j]) > duplicatePhotoMaxDistance {
+				continue
+			}
+			if ri, rj := find(i), find(j); ri != rj {
+				if rj < ri {
+					ri, rj = rj, ri
+				}
+				parent[rj] = ri
+			}
+		}
+	}
+
+	members := make(map[int][]*domain.Photo)
+	var roots []int
+	for i, photo := range hashed {
+		root := find(i)
+		if _, ok := members[root]; !ok {
+			roots = append(roots, root)
+		}
+		members[root] = append(members[root], photo)
+	}
+
+	var groups [][]*domain.Photo
+	for _, root := range roots {
+		if len(members[root]) > 1 {
+			groups = append(groups, members[root])
+		}
+	}
+	return groups
+}
+
+// normalizeTags trims tags and drops empty and duplicate ones
+func normalizeTags(tags []string) []string {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// SearchPhotos searches the couple's photos by words, tags, date range, location, album
+// and privacy, and counts the tags of every matching photo
+func (s *PhotoService) SearchPhotos(ctx context.Context, userID primitive.ObjectID, req *domain.PhotoSearchRequest, page, limit int) (*domain.PhotoSearchResponse, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	response := &domain.PhotoSearchResponse{
+		Photos: []*domain.PhotoResponse{},
+		Page:   page,
+		Limit:  limit,
+		Tags:   []*domain.PhotoTagCount{},
 	}
 
 	if user.MatchCode == "" {
-		return nil, fmt.Errorf("user is not matched")
+		return response, nil
+	}
+
+	filter := domain.PhotoSearchFilter{
+		Query:     strings.TrimSpace(req.Query),
+		Tags:      normalizeTags(req.Tags),
+		Location:  strings.TrimSpace(req.Location),
+		IsPrivate: req.IsPrivate,
+	}
+	if req.From != nil {
+		from := req.From.Time
+		filter.From = &from
+	}
+	if req.To != nil {
+		to := req.To.Time.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, domain.ErrInvalidRequestError("from must not be after to")
+	}
+
+	filter.AlbumID, err = s.resolveAlbumID(ctx, user.MatchCode, req.AlbumID)
+	if err != nil {
+		return nil, err
 	}
 
-	photos, err := s.photoRepo.SearchByMatchCode(ctx, user.MatchCode, query, limit, offset)
+	result, err := s.photoRepo.Search(ctx, user.MatchCode, user.ID, filter, limit, (page-1)*limit)
 	if err != nil {
-		s.logger.Error("Failed to search photos", zap.Error(err))
-		return nil, fmt.Errorf("failed to search photos")
+		logging.FromContext(ctx).Error("Failed to search photos", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to search photos")
 	}
 
+	response.Photos = s.toResponses(ctx, user, result.Photos)
+	response.Total = result.Total
+	response.Tags = result.Tags
+
+	return response, nil
+}
+
+// GetPhotoMap groups the couple's geo-tagged photos into clusters for a map at the
+// query's zoom level. Photos are clustered in cells of about a quarter of a map tile,
+// so that clusters stay apart on screen.
+func (s *PhotoService) GetPhotoMap(ctx context.Context, userID primitive.ObjectID, query *domain.PhotoMapQuery) (*domain.PhotoMapResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if query.Zoom < 0 || query.Zoom > photoMapMaxZoom {
+		return nil, domain.ErrInvalidRequestError(fmt.Sprintf("zoom must be between 0 and %d", photoMapMaxZoom))
+	}
+
+	response := &domain.PhotoMapResponse{
+		Zoom:     query.Zoom,
+		Clusters: []*domain.PhotoMapCluster{},
+	}
+
+	if user.MatchCode == "" {
+		return response, nil
+	}
+
+	cellSize := 360 / float64(int64(4)<<query.Zoom)
+	clusters, err := s.photoRepo.ClusterByLocation(ctx, user.MatchCode, user.ID, cellSize, query.Bounds, query.From, query.To, photoMapMaxClusters)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to cluster photos", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get photo map")
+	}
+
+	covers := make([]*domain.Photo, len(clusters))
+	for i, cluster := range clusters {
+		covers[i] = cluster.Cover
+	}
+	coverResponses := s.toResponses(ctx, user, covers)
+
+	for i, cluster := range clusters {
+		response.Clusters = append(response.Clusters, &domain.PhotoMapCluster{
+			Latitude:  cluster.Latitude,
+			Longitude: cluster.Longitude,
+			Count:     cluster.Count,
+			Cover:     coverResponses[i],
+		})
+		response.Total += cluster.Count
+	}
+
+	return response, nil
+}
+
+// GetPhotoURL generates a short-lived download URL for the image of one of the couple's
+// photos, or one of its variants, so that private images are never served from a
+// permanent URL
+func (s *PhotoService) GetPhotoURL(ctx context.Context, photoID, userID primitive.ObjectID, req *domain.PhotoURLRequest) (*domain.PhotoURLResponse, error) {
+	expiry := s.urlExpiry.Default
+	if req.ExpiresIn != 0 {
+		expiry = time.Duration(req.ExpiresIn) * time.Second
+		if expiry < s.urlExpiry.Min || expiry > s.urlExpiry.Max {
+			return nil, domain.ErrInvalidRequestError(fmt.Sprintf("expires_in must be between %d and %d seconds",
+				int(s.urlExpiry.Min.Seconds()), int(s.urlExpiry.Max.Seconds())))
+		}
+	}
+
+	photo, err := s.photoRepo.GetByID(ctx, photoID)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Photo")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if photo.MatchCode != user.MatchCode {
+		return nil, domain.ErrForbiddenError()
+	}
+	if !photo.IsVisibleTo(userID) {
+		return nil, domain.ErrNotFoundError("Photo")
+	}
+
+	// Variants fall back to the original for photos uploaded before they were generated
+	variant := req.Variant
+	var key string
+	switch variant {
+	case "", domain.PhotoVariantOriginal:
+		variant = domain.PhotoVariantOriginal
+		key = photo.StorageKey()
+	case string(domain.ImageVariantMedium):
+		key = photo.MediumStorageKey()
+	case string(domain.ImageVariantThumb):
+		key = photo.ThumbnailStorageKey()
+	default:
+		return nil, domain.ErrInvalidRequestError("variant must be one of original, medium, thumb")
+	}
+
+	url, err := s.storageService.GeneratePresignedDownloadURL(ctx, key, expiry)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to generate photo URL", zap.Error(err), zap.String("photo_id", photoID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to generate photo URL")
+	}
+
+	return &domain.PhotoURLResponse{
+		URL:       url,
+		Variant:   variant,
+		ExpiresAt: time.Now().Add(expiry),
+	}, nil
+}
+
+// toResponses converts the couple's photos to responses
+func (s *PhotoService) toResponses(ctx context.Context, user *domain.User, photos []*domain.Photo) []*domain.PhotoResponse {
 	responses := make([]*domain.PhotoResponse, len(photos))
 	for i, photo := range photos {
 		responses[i] = photo.ToResponse()
+		responses[i].IsFavorite = photo.IsFavoriteOf(user.ID)
 	}
 
-	return responses, nil
+	hideDeletedAlbums(ctx, s.albumRepo, user.MatchCode, responses)
+	return responses
+}
+
+// hideDeletedAlbums clears the album of photos whose album is deleted. Photos keep their
+// album_id while it is deleted, so restoring the album brings them back.
+func hideDeletedAlbums(ctx context.Context, albumRepo domain.AlbumRepository, matchCode string, responses []*domain.PhotoResponse) {
+	inAlbum := false
+	for _, response := range responses {
+		inAlbum = inAlbum || response.AlbumID != ""
+	}
+
+	if !inAlbum {
+		return
+	}
+
+	albums, err := albumRepo.GetByMatchCode(ctx, matchCode)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to get albums of photos", zap.Error(err))
+		return
+	}
+
+	active := make(map[string]bool, len(albums))
+	for _, album := range albums {
+		active[album.ID.Hex()] = true
+	}
+	for _, response := range responses {
+		if response.AlbumID != "" && !active[response.AlbumID] {
+			response.AlbumID = ""
+		}
+	}
+}
+
+// resolveAlbumID validates that an album belongs to the couple and returns its ID.
+// An empty albumID resolves to nil (no album).
+func (s *PhotoService) resolveAlbumID(ctx context.Context, matchCode, albumID string) (*primitive.ObjectID, error) {
+	if albumID == "" {
+		return nil, nil
+	}
+
+	id, err := primitive.ObjectIDFromHex(albumID)
+	if err != nil {
+		return nil, domain.ErrInvalidRequestError("Invalid album ID")
+	}
+
+	album, err := s.albumRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Album")
+	}
+
+	if album.MatchCode != matchCode {
+		return nil, domain.ErrForbiddenError()
+	}
+
+	return &id, nil
+}
+
+// generateVariants renders the resized variants of a photo's image, or a video's
+// poster, and records their keys. Failures are logged and the photo falls back to
+// serving the full-size image everywhere.
+func (s *PhotoService) generateVariants(ctx context.Context, photo *domain.Photo) {
+	variants, err := s.imageService.GenerateVariants(ctx, photo.PreviewKey())
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to generate photo variants",
+			zap.Error(err),
+			zap.String("key", photo.PreviewKey()))
+	}
+
+	photo.SetVariants(variants)
+}
+
+// prepareVideo turns a photo into a video memory: the video is checked against the
+// length limit and its poster frame is stored and resized to stand in for it
+func (s *PhotoService) prepareVideo(ctx context.Context, photo *domain.Photo) error {
+	metadata, posterKey, err := s.videoService.ProcessVideo(ctx, photo.StorageKey())
+	if err != nil {
+		if errors.Is(err, domain.ErrVideoTooLong) {
+			return domain.ErrVideoTooLongError(s.videoLimits.MaxDuration)
+		}
+		if errors.Is(err, domain.ErrFileNotFound) {
+			return domain.ErrNotFoundError("File")
+		}
+		logging.FromContext(ctx).Error("Failed to process video", zap.Error(err), zap.String("key", photo.StorageKey()))
+		return domain.ErrOperationFailedError("Failed to process video")
+	}
+
+	photo.MediaType = domain.MediaTypeVideo
+	photo.Video = metadata
+	photo.PosterKey = posterKey
+	s.generateVariants(ctx, photo)
+
+	return nil
+}
+
+// checkVideoSize checks that a pre-uploaded video fits the size limit
+func (s *PhotoService) checkVideoSize(ctx context.Context, key string) error {
+	info, err := s.storageService.GetFileInfo(ctx, key)
+	if err != nil {
+		if errors.Is(err, domain.ErrFileNotFound) {
+			return domain.ErrNotFoundError("File")
+		}
+		logging.FromContext(ctx).Error("Failed to get video info", zap.Error(err), zap.String("key", key))
+		return domain.ErrOperationFailedError("Failed to process video")
+	}
+	if info.Size > s.videoLimits.MaxSize {
+		return domain.ErrFileTooLargeError(s.videoLimits.MaxSize)
+	}
+	return nil
+}
+
+// deleteFile removes an uploaded file that no photo was created for. Failures are
+// logged and the file is left to the storage reconciliation.
+func (s *PhotoService) deleteFile(ctx context.Context, key string) {
+	if err := s.storageService.Delete(ctx, key); err != nil {
+		logging.FromContext(ctx).Warn("Failed to delete rejected upload", zap.Error(err), zap.String("key", key))
+	}
+}
+
+// recordImageHash records the perceptual hash of a photo's image for duplicate
+// detection. Failures are logged and the photo is left out of duplicate groups.
+func (s *PhotoService) recordImageHash(ctx context.Context, photo *domain.Photo) {
+	hash, err := s.imageService.PerceptualHash(ctx, photo.StorageKey())
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to compute photo image hash",
+			zap.Error(err),
+			zap.String("key", photo.StorageKey()))
+		return
+	}
+
+	photo.ImageHash = hash
+}
+
+// applyMetadata records what the camera recorded about the photo, and takes the
+// photo's date and location from it when fillDate and fillLocation are set.
+// Failures are logged and the photo keeps what the request gave.
+func (s *PhotoService) applyMetadata(ctx context.Context, photo *domain.Photo, fillDate, fillLocation bool) {
+	metadata, err := s.imageService.ReadMetadata(ctx, photo.StorageKey())
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to read photo metadata",
+			zap.Error(err),
+			zap.String("key", photo.StorageKey()))
+		return
+	}
+
+	photo.Metadata = metadata
+	if metadata == nil {
+		return
+	}
+
+	if fillDate && metadata.TakenAt != nil {
+		photo.Date = *metadata.TakenAt
+	}
+	if fillLocation && metadata.HasLocation() {
+		photo.Location = metadata.Location()
+		photo.Place = metadata.Place()
+	}
+}
+
+// recordChecksum hashes a pre-uploaded image so later integrity checks can verify it.
+// Failures are logged and the photo is picked up by the integrity job's backfill instead.
+func (s *PhotoService) recordChecksum(ctx context.Context, photo *domain.Photo) {
+	checksum, err := objectChecksum(ctx, s.storageService, photo.StorageKey())
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to compute photo checksum",
+			zap.Error(err),
+			zap.String("key", photo.StorageKey()))
+		return
+	}
+
+	photo.Checksum = checksum
+}
+
+// objectChecksum returns the hex SHA-256 of a stored object
+func objectChecksum(ctx context.Context, storage domain.StorageService, key string) (string, error) {
+	object, err := storage.GetObject(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer object.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, object); err != nil {
+		return "", fmt.Errorf("failed to read object: %w", err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
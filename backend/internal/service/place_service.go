@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+// placeCacheSize caps the number of place searches kept in the cache
+const placeCacheSize = 1000
+
+// placeCacheEntry is a cached place search
+type placeCacheEntry struct {
+	places    []*domain.Place
+	fetchedAt time.Time
+}
+
+// PlaceService implements domain.PlaceService
+type PlaceService struct {
+	provider domain.PlaceProvider
+	cacheTTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]*placeCacheEntry
+}
+
+// NewPlaceService creates a new place service. Searches are answered from a cache for
+// cacheTTL, as geocoding APIs limit how often they may be called; a nil provider means
+// places cannot be searched.
+func NewPlaceService(
+	provider domain.PlaceProvider,
+	cacheTTL time.Duration,
+) domain.PlaceService {
+	return &PlaceService{
+		provider: provider,
+		cacheTTL: cacheTTL,
+		cache:    make(map[string]*placeCacheEntry),
+	}
+}
+
+// SearchPlaces lists up to limit places matching query, best match first
+func (s *PlaceService) SearchPlaces(ctx context.Context, query, lang string, limit int) (*domain.PlaceSearchResponse, error) {
+	if s.provider == nil {
+		return nil, domain.ErrServiceUnavailableError("Places search")
+	}
+
+	query = strings.Join(strings.Fields(query), " ")
+	key := lang + "|" + strings.ToLower(query) + "|" + strconv.Itoa(limit)
+
+	if places, ok := s.cached(key); ok {
+		return &domain.PlaceSearchResponse{Places: places}, nil
+	}
+
+	places, err := s.provider.SearchPlaces(ctx, query, lang, limit)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to search places", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to search places")
+	}
+	if places == nil {
+		places = []*domain.Place{}
+	}
+
+	s.store(key, places)
+	return &domain.PlaceSearchResponse{Places: places}, nil
+}
+
+// cached returns the places of a search made less than cacheTTL ago
+func (s *PlaceService) cached(key string) ([]*domain.Place, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.cache[key]
+	if !ok || time.Since(entry.fetchedAt) >= s.cacheTTL {
+		return nil, false
+	}
+	return entry.places, true
+}
+
+// store caches the places of a search, first dropping expired searches when the cache
+// is full, and every search if none had expired
+func (s *PlaceService) store(key string, places []*domain.Place) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.cache) >= placeCacheSize {
+		for cachedKey, entry := range s.cache {
+			if time.Since(entry.fetchedAt) >= s.cacheTTL {
+				delete(s.cache, cachedKey)
+			}
+		}
+		if len(s.cache) >= placeCacheSize {
+			s.cache = make(map[string]*placeCacheEntry)
+		}
+	}
+
+	s.cache[key] = &placeCacheEntry{places: places, fetchedAt: time.Now()}
+}
+
+// setPlace sets the display location and place of a photo or event from a request.
+// A place takes precedence, and its name becomes the location; a bare location
+// drops the place, which no longer describes it.
+func setPlace(location *string, place **domain.Place, reqLocation string, reqPlace *domain.Place) {
+	if reqPlace != nil {
+		*location = reqPlace.Name
+		*place = reqPlace
+		return
+	}
+
+	*location = reqLocation
+	*place = nil
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// PresenceService implements domain.PresenceService
+type PresenceService struct {
+	presenceRepo    domain.PresenceRepository
+	userRepo        domain.UserRepository
+	settingsService domain.CoupleSettingsService
+	ttl             time.Duration
+}
+
+// NewPresenceService creates a new presence service. A user stops being shown as
+// viewing a resource ttl after they last reported it.
+func NewPresenceService(
+	presenceRepo domain.PresenceRepository,
+	userRepo domain.UserRepository,
+	settingsService domain.CoupleSettingsService,
+	ttl time.Duration,
+) domain.PresenceService {
+	return &PresenceService{
+		presenceRepo:    presenceRepo,
+		userRepo:        userRepo,
+		settingsService: settingsService,
+		ttl:             ttl,
+	}
+}
+
+// Subscribe delivers the resources the user's partner opens and closes, for as long
+// as ctx lasts. Only matched users have a partner to follow.
+func (s *PresenceService) Subscribe(ctx context.Context, userID primitive.ObjectID) (<-chan *domain.PresenceUpdate, error) {
+	if _, err := s.getMatchedUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	updates, err := s.presenceRepo.Subscribe(ctx, userID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to subscribe to presence", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to subscribe to presence")
+	}
+	return updates, nil
+}
+
+// Viewing records the resource the user has open, lets their partner know and reports
+// whether the partner has it open too. Couples that hide their presence are never
+// shown to each other.
+func (s *PresenceService) Viewing(ctx context.Context, userID primitive.ObjectID, req *domain.ViewingRequest) (*domain.PresenceResponse, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.PresenceResponse{}
+
+	settings, err := s.settingsService.GetByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		return nil, err
+	}
+	if settings.HidePresence {
+		return response, nil
+	}
+
+	resource := req.Resource()
+	if err := s.presenceRepo.SetViewing(ctx, userID, resource, s.ttl); err != nil {
+		logging.FromContext(ctx).Error("Failed to record presence", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to update presence")
+	}
+	s.notifyPartner(ctx, user, resource)
+
+	partnerResource, err := s.presenceRepo.GetViewing(ctx, *user.PartnerID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get partner presence", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to update presence")
+	}
+
+	response.PartnerViewing = partnerResource == resource
+	return response, nil
+}
+
+// StopViewing clears the user's presence once they close the resource and lets their
+// partner know
+func (s *PresenceService) StopViewing(ctx context.Context, userID primitive.ObjectID) error {
+	if err := s.presenceRepo.ClearViewing(ctx, userID); err != nil {
+		logging.FromContext(ctx).Error("Failed to clear presence", zap.Error(err), zap.String("user_id", userID.Hex()))
+		return domain.ErrOperationFailedError("Failed to update presence")
+	}
+
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		// Nobody to tell, e.g. the couple was unmatched while the resource was open
+		return nil
+	}
+	s.notifyPartner(ctx, user, "")
+	return nil
+}
+
+// RefreshInterval returns how often open connections report again, half the TTL so
+// that a late report does not let the entry lapse
+func (s *PresenceService) RefreshInterval() time.Duration {
+	if interval := s.ttl / 2; interval >= time.Second {
+		return interval
+	}
+	return time.Second
+}
+
+// getMatchedUser returns the user, who must have a partner
+func (s *PresenceService) getMatchedUser(ctx context.Context, userID primitive.ObjectID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" || user.PartnerID == nil {
+		return nil, domain.ErrNotMatchedError()
+	}
+	return user, nil
+}
+
+// notifyPartner publishes the resource the user now views to their partner. Presence
+// is best effort, so a failure is only logged.
+func (s *PresenceService) notifyPartner(ctx context.Context, user *domain.User, resource string) {
+	if err := s.presenceRepo.Publish(ctx, *user.PartnerID, &domain.PresenceUpdate{Resource: resource}); err != nil {
+		logging.FromContext(ctx).Warn("Failed to publish presence", zap.Error(err), zap.String("user_id", user.ID.Hex()))
+	}
+}
@@ -1,11 +1,19 @@
 package service
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/config"
 	"github.com/eralove/eralove-backend/internal/domain"
 	"github.com/eralove/eralove-backend/internal/infrastructure/auth"
 	"github.com/eralove/eralove-backend/internal/infrastructure/email"
+	"github.com/eralove/eralove-backend/internal/infrastructure/eventbus"
+	"github.com/eralove/eralove-backend/internal/infrastructure/i18n"
+	"github.com/eralove/eralove-backend/internal/infrastructure/push"
+	"github.com/eralove/eralove-backend/internal/infrastructure/video"
 	"github.com/google/wire"
-	"go.uber.org/zap"
 )
 
 // ServiceSet provides all service dependencies
@@ -14,8 +22,53 @@ var ServiceSet = wire.NewSet(
 	ProvidePhotoService,
 	ProvideEventService,
 	ProvideMatchRequestService,
+	ProvideInsightService,
+	ProvideGoalService,
+	ProvideAlbumService,
+	ProvideNotificationService,
+	ProvideAffirmationService,
+	ProvideCoupleSettingsService,
+	ProvideWatermarkService,
+	ProvideImageService,
+	ProvideVideoService,
+	ProvideUploadPolicy,
+	ProvideShareLinkService,
+	ProvideSearchService,
+	ProvideTrashService,
+	ProvideTimelineService,
+	ProvideFeedbackService,
+	ProvideCalendarService,
+	ProvideClientErrorService,
+	ProvideChangelogService,
+	ProvideRetentionService,
+	ProvidePendingActionService,
+	ProvideCoupleKeyService,
+	ProvideMessageSearchService,
+	ProvideOIDCService,
+	ProvideStorageIntegrityService,
+	ProvideCoupleBadgeService,
+	ProvidePresenceService,
+	ProvideAccountMergeService,
+	ProvideUsageService,
+	ProvideUploadSessionService,
+	ProvideUploadScanService,
+	ProvidePhotoCommentService,
+	ProvideJournalService,
+	ProvideBucketListService,
+	ProvideMoodService,
+	ProvideAutoMilestoneService,
+	ProvideCountdownService,
+	ProvideAdminService,
+	ProvideAuditService,
+	ProvideMemoriesService,
+	ProvidePlaceService,
+	ProvideFileService,
+	ProvideEngagementService,
+	ProvideDailyQuestionService,
+	ProvideWishlistService,
+	ProvideDatePlanService,
 	// TODO: Uncomment when services are fully implemented
-	// ProvideMessageService,
+	ProvideMessageService,
 )
 
 // ProvideUserService provides a user service
@@ -23,46 +76,572 @@ func ProvideUserService(
 	userRepo domain.UserRepository,
 	eventRepo domain.EventRepository,
 	photoRepo domain.PhotoRepository,
+	storageService domain.StorageService,
 	passwordManager *auth.PasswordManager,
 	jwtManager *auth.JWTManager,
 	emailService *email.EmailService,
-	logger *zap.Logger,
+	autoMilestoneService domain.AutoMilestoneService,
+	tokenFamilyRepo domain.TokenFamilyRepository,
+	auditService domain.AuditService,
+	events domain.EventPublisher,
 ) domain.UserService {
-	return NewUserService(userRepo, eventRepo, photoRepo, passwordManager, jwtManager, emailService, logger)
+	return NewUserService(userRepo, eventRepo, photoRepo, storageService, passwordManager, jwtManager, emailService, autoMilestoneService, tokenFamilyRepo, auditService, events)
 }
 
 // ProvidePhotoService provides a photo service
 func ProvidePhotoService(
 	photoRepo domain.PhotoRepository,
 	userRepo domain.UserRepository,
+	albumRepo domain.AlbumRepository,
 	storageService domain.StorageService,
-	logger *zap.Logger,
+	scanService domain.UploadScanService,
+	imageService domain.ImageService,
+	videoService domain.VideoService,
+	watermarkService domain.WatermarkService,
+	events domain.EventPublisher,
+	uploadPolicy *domain.UploadPolicy,
+	cfg *config.Config,
 ) domain.PhotoService {
-	return NewPhotoService(photoRepo, userRepo, storageService, logger)
+	urlExpiry := domain.PhotoURLExpiry{
+		Default: time.Duration(cfg.PhotoURLExpiry) * time.Second,
+		Min:     time.Duration(cfg.PhotoURLMinExpiry) * time.Second,
+		Max:     time.Duration(cfg.PhotoURLMaxExpiry) * time.Second,
+	}
+	videoLimits := domain.VideoLimits{
+		MaxSize:     int64(cfg.VideoMaxSize) * 1024 * 1024,
+		MaxDuration: time.Duration(cfg.VideoMaxDuration) * time.Second,
+	}
+	return NewPhotoService(photoRepo, userRepo, albumRepo, storageService, scanService, imageService, videoService, watermarkService, events, uploadPolicy, urlExpiry, videoLimits)
 }
 
 // ProvideEventService provides an event service
 func ProvideEventService(
 	eventRepo domain.EventRepository,
 	userRepo domain.UserRepository,
-	logger *zap.Logger,
+	settingsService domain.CoupleSettingsService,
+	notificationService domain.NotificationService,
 ) domain.EventService {
-	return NewEventService(eventRepo, userRepo, logger)
+	return NewEventService(eventRepo, userRepo, settingsService, notificationService)
 }
 
 // ProvideMessageService provides a message service
-// func ProvideMessageService(
-// 	messageRepo domain.MessageRepository,
-// 	logger *zap.Logger,
-// ) domain.MessageService {
-// 	return NewMessageService(messageRepo, logger)
-// }
+func ProvideMessageService(
+	messageRepo domain.MessageRepository,
+	userRepo domain.UserRepository,
+	pendingActions domain.PendingActionService,
+	storageService domain.StorageService,
+	notificationService domain.NotificationService,
+	coupleKeyService domain.CoupleKeyService,
+	receiptRepo domain.MessageReceiptRepository,
+	events domain.EventPublisher,
+	cfg *config.Config,
+) domain.MessageService {
+	return NewMessageService(messageRepo, userRepo, pendingActions, storageService, notificationService, coupleKeyService, receiptRepo, events, time.Duration(cfg.MessageEditWindow)*time.Minute)
+}
 
 // ProvideMatchRequestService provides a match request service
 func ProvideMatchRequestService(
 	matchRequestRepo domain.MatchRequestRepository,
+	inviteRepo domain.MatchInviteRepository,
 	userRepo domain.UserRepository,
-	logger *zap.Logger,
+	emailService *email.EmailService,
+	autoMilestoneService domain.AutoMilestoneService,
+	events domain.EventPublisher,
+	cfg *config.Config,
 ) domain.MatchRequestService {
-	return NewMatchRequestService(matchRequestRepo, userRepo, logger)
+	return NewMatchRequestService(matchRequestRepo, inviteRepo, userRepo, emailService, autoMilestoneService, events, time.Duration(cfg.MatchInviteTTL)*time.Hour, cfg.FrontendURL)
+}
+
+// ProvideInsightService provides a fun insights service
+func ProvideInsightService(
+	userRepo domain.UserRepository,
+	contentSource domain.ContentSource,
+	cfg *config.Config,
+) domain.InsightService {
+	return NewInsightService(userRepo, contentSource, time.Duration(cfg.ContentCacheTTL)*time.Second)
+}
+
+// ProvideGoalService provides a goal service
+func ProvideGoalService(
+	goalRepo domain.GoalRepository,
+	userRepo domain.UserRepository,
+	emailService *email.EmailService,
+) domain.GoalService {
+	return NewGoalService(goalRepo, userRepo, emailService)
+}
+
+// ProvideAlbumService provides an album service
+func ProvideAlbumService(
+	albumRepo domain.AlbumRepository,
+	photoRepo domain.PhotoRepository,
+	userRepo domain.UserRepository,
+	pendingActionService domain.PendingActionService,
+) domain.AlbumService {
+	return NewAlbumService(albumRepo, photoRepo, userRepo, pendingActionService)
+}
+
+// ProvideNotificationService provides an in-app notification service
+func ProvideNotificationService(
+	notificationRepo domain.NotificationRepository,
+	userRepo domain.UserRepository,
+	renderer *push.Renderer,
+) domain.NotificationService {
+	return NewNotificationService(notificationRepo, userRepo, renderer)
+}
+
+// ProvideAffirmationService provides an affirmation service
+func ProvideAffirmationService(
+	affirmationRepo domain.AffirmationRepository,
+	userRepo domain.UserRepository,
+	storageService domain.StorageService,
+	scanService domain.UploadScanService,
+	notificationService domain.NotificationService,
+) domain.AffirmationService {
+	return NewAffirmationService(affirmationRepo, userRepo, storageService, scanService, notificationService)
+}
+
+// ProvideCoupleSettingsService provides a couple settings service
+func ProvideCoupleSettingsService(
+	settingsRepo domain.CoupleSettingsRepository,
+	userRepo domain.UserRepository,
+	notificationService domain.NotificationService,
+) domain.CoupleSettingsService {
+	return NewCoupleSettingsService(settingsRepo, userRepo, notificationService)
+}
+
+// ProvideWatermarkService provides a photo watermark service
+func ProvideWatermarkService(
+	settingsService domain.CoupleSettingsService,
+	storageService domain.StorageService,
+) domain.WatermarkService {
+	return NewWatermarkService(settingsService, storageService)
+}
+
+// ProvideImageService provides an image resizing service
+func ProvideImageService(
+	storageService domain.StorageService,
+) domain.ImageService {
+	return NewImageService(storageService)
+}
+
+// ProvideUploadPolicy provides the policy that files uploaded through the API must meet
+func ProvideUploadPolicy(cfg *config.Config) *domain.UploadPolicy {
+	const megabyte = 1024 * 1024
+
+	// Entries are folder=MB, checked when the configuration loads
+	folderMaxSizes := make(map[string]int64, len(cfg.UploadFolderMaxSizes))
+	for _, entry := range cfg.UploadFolderMaxSizes {
+		folder, size, _ := strings.Cut(entry, "=")
+		megabytes, _ := strconv.Atoi(strings.TrimSpace(size))
+		folderMaxSizes[strings.ToLower(strings.TrimSpace(folder))] = int64(megabytes) * megabyte
+	}
+
+	return domain.NewUploadPolicy(cfg.MaxFileSize, folderMaxSizes, cfg.UploadAllowedTypes)
+}
+
+// ProvideVideoService provides a video inspection service
+func ProvideVideoService(
+	storageService domain.StorageService,
+	cfg *config.Config,
+) domain.VideoService {
+	ffmpeg := video.NewFFmpeg(cfg.FFmpegPath, cfg.FFprobePath)
+	return NewVideoService(storageService, ffmpeg, time.Duration(cfg.VideoMaxDuration)*time.Second)
+}
+
+// ProvideShareLinkService provides a share link service
+func ProvideShareLinkService(
+	shareLinkRepo domain.ShareLinkRepository,
+	photoRepo domain.PhotoRepository,
+	albumRepo domain.AlbumRepository,
+	userRepo domain.UserRepository,
+	storageService domain.StorageService,
+	watermarkService domain.WatermarkService,
+	passwordManager *auth.PasswordManager,
+) domain.ShareLinkService {
+	return NewShareLinkService(shareLinkRepo, photoRepo, albumRepo, userRepo, storageService, watermarkService, passwordManager)
+}
+
+// ProvideSearchService provides a search service
+func ProvideSearchService(
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	messageRepo domain.MessageRepository,
+	journalRepo domain.JournalRepository,
+	userRepo domain.UserRepository,
+) domain.SearchService {
+	return NewSearchService(photoRepo, eventRepo, messageRepo, journalRepo, userRepo)
+}
+
+// ProvideTrashService provides a trash service
+func ProvideTrashService(
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	messageRepo domain.MessageRepository,
+	journalRepo domain.JournalRepository,
+	userRepo domain.UserRepository,
+	storageService domain.StorageService,
+) domain.TrashService {
+	return NewTrashService(photoRepo, eventRepo, messageRepo, journalRepo, userRepo, storageService)
+}
+
+// ProvideTimelineService provides a timeline service
+func ProvideTimelineService(
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	albumRepo domain.AlbumRepository,
+	journalRepo domain.JournalRepository,
+	userRepo domain.UserRepository,
+	settingsService domain.CoupleSettingsService,
+) domain.TimelineService {
+	return NewTimelineService(photoRepo, eventRepo, albumRepo, journalRepo, userRepo, settingsService)
+}
+
+// ProvideFeedbackService provides a feedback service
+func ProvideFeedbackService(
+	feedbackRepo domain.FeedbackRepository,
+	userRepo domain.UserRepository,
+	mirror domain.FeedbackMirror,
+	cfg *config.Config,
+) domain.FeedbackService {
+	window := time.Duration(cfg.FeedbackRateWindow) * time.Second
+	return NewFeedbackService(feedbackRepo, userRepo, mirror, cfg.FeedbackRateLimit, window)
+}
+
+// ProvideCalendarService provides a calendar service
+func ProvideCalendarService(
+	eventRepo domain.EventRepository,
+	userRepo domain.UserRepository,
+	feedRepo domain.CalendarFeedRepository,
+) domain.CalendarService {
+	return NewCalendarService(eventRepo, userRepo, feedRepo)
+}
+
+// ProvideClientErrorService provides a client error service
+func ProvideClientErrorService(
+	clientErrorRepo domain.ClientErrorRepository,
+	traceRepo domain.RequestTraceRepository,
+	cfg *config.Config,
+) domain.ClientErrorService {
+	return NewClientErrorService(clientErrorRepo, traceRepo, cfg.ClientErrorSampleRate)
+}
+
+// ProvideChangelogService provides a changelog service
+func ProvideChangelogService(
+	source domain.ReleaseSource,
+	seenRepo domain.ChangelogSeenRepository,
+	cfg *config.Config,
+) domain.ChangelogService {
+	return NewChangelogService(source, seenRepo, time.Duration(cfg.ChangelogCacheTTL)*time.Second)
+}
+
+// ProvideRetentionService provides the data retention service with the policies
+// enabled in the configuration
+func ProvideRetentionService(
+	userRepo domain.UserRepository,
+	matchRequestRepo domain.MatchRequestRepository,
+	photoRepo domain.PhotoRepository,
+	storageService domain.StorageService,
+	auditRepo domain.RetentionAuditRepository,
+	cfg *config.Config,
+) domain.RetentionService {
+	day := 24 * time.Hour
+
+	var policies []domain.RetentionPolicy
+	if cfg.RetentionUnverifiedAccountDays > 0 {
+		policies = append(policies, NewUnverifiedAccountsPolicy(userRepo, time.Duration(cfg.RetentionUnverifiedAccountDays)*day))
+	}
+	if cfg.RetentionMatchRequestDays > 0 {
+		policies = append(policies, NewStaleMatchRequestsPolicy(matchRequestRepo, time.Duration(cfg.RetentionMatchRequestDays)*day))
+	}
+	if cfg.RetentionDeletedAccountDays > 0 {
+		policies = append(policies, NewDeletedAccountsPolicy(userRepo, photoRepo, storageService, time.Duration(cfg.RetentionDeletedAccountDays)*day))
+	}
+
+	return NewRetentionService(policies, auditRepo, cfg.RetentionDryRun)
+}
+
+// ProvidePendingActionService provides the partner approval service
+func ProvidePendingActionService(
+	actionRepo domain.PendingActionRepository,
+	userRepo domain.UserRepository,
+	settingsService domain.CoupleSettingsService,
+	notificationService domain.NotificationService,
+	cfg *config.Config,
+) domain.PendingActionService {
+	return NewPendingActionService(actionRepo, userRepo, settingsService, notificationService, time.Duration(cfg.PendingActionTTL)*time.Hour)
+}
+
+// ProvideCoupleKeyService provides the couple data key service
+func ProvideCoupleKeyService(
+	settingsRepo domain.CoupleSettingsRepository,
+	userRepo domain.UserRepository,
+	keyManager domain.KeyManager,
+) domain.CoupleKeyService {
+	return NewCoupleKeyService(settingsRepo, userRepo, keyManager)
+}
+
+// ProvideMessageSearchService provides a message search service
+func ProvideMessageSearchService(
+	messageRepo domain.MessageRepository,
+	userRepo domain.UserRepository,
+) domain.MessageSearchService {
+	return NewMessageSearchService(messageRepo, userRepo)
+}
+
+// ProvideOIDCService provides the OpenID Connect provider service
+func ProvideOIDCService(
+	codeRepo domain.AuthorizationCodeRepository,
+	userRepo domain.UserRepository,
+	jwtManager *auth.JWTManager,
+	signer *auth.IDTokenSigner,
+	cfg *config.Config,
+) domain.OIDCService {
+	// Entries are clientID=redirectURI|redirectURI, checked when the configuration loads
+	clients := make([]*domain.OAuthClient, 0, len(cfg.OIDCClients))
+	for _, entry := range cfg.OIDCClients {
+		clientID, redirectURIs, _ := strings.Cut(entry, "=")
+		clients = append(clients, &domain.OAuthClient{
+			ID:           strings.TrimSpace(clientID),
+			RedirectURIs: strings.Split(redirectURIs, "|"),
+		})
+	}
+
+	return NewOIDCService(codeRepo, userRepo, jwtManager, signer, cfg.OIDCIssuer, cfg.FrontendURL, clients)
+}
+
+// ProvideStorageIntegrityService provides the stored photo verification service
+func ProvideStorageIntegrityService(
+	photoRepo domain.PhotoRepository,
+	issueRepo domain.StorageIntegrityRepository,
+	reconciliationRepo domain.StorageReconciliationRepository,
+	storageService domain.StorageService,
+	cfg *config.Config,
+) domain.StorageIntegrityService {
+	return NewStorageIntegrityService(
+		photoRepo,
+		issueRepo,
+		reconciliationRepo,
+		storageService,
+		cfg.StorageIntegritySampleSize,
+		time.Duration(cfg.StorageReconcileGracePeriod)*time.Hour,
+		cfg.StorageReconcileDryRun,
+	)
+}
+
+// ProvideCoupleBadgeService provides the public relationship badge service
+func ProvideCoupleBadgeService(
+	badgeRepo domain.CoupleBadgeRepository,
+	userRepo domain.UserRepository,
+	cfg *config.Config,
+) domain.CoupleBadgeService {
+	return NewCoupleBadgeService(badgeRepo, userRepo, time.Duration(cfg.BadgeCacheTTL)*time.Second)
+}
+
+// ProvidePresenceService provides the partner presence service
+func ProvidePresenceService(
+	presenceRepo domain.PresenceRepository,
+	userRepo domain.UserRepository,
+	settingsService domain.CoupleSettingsService,
+	cfg *config.Config,
+) domain.PresenceService {
+	return NewPresenceService(presenceRepo, userRepo, settingsService, time.Duration(cfg.PresenceTTL)*time.Second)
+}
+
+// ProvideAccountMergeService provides an account merge service
+func ProvideAccountMergeService(
+	mergeRepo domain.AccountMergeRepository,
+	userRepo domain.UserRepository,
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	messageRepo domain.MessageRepository,
+	passwordManager *auth.PasswordManager,
+	emailService *email.EmailService,
+) domain.AccountMergeService {
+	return NewAccountMergeService(mergeRepo, userRepo, photoRepo, eventRepo, messageRepo, passwordManager, emailService)
+}
+
+// ProvideUsageService provides the couple usage analytics service
+func ProvideUsageService(
+	usageRepo domain.UsageRepository,
+	userRepo domain.UserRepository,
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	messageRepo domain.MessageRepository,
+) domain.UsageService {
+	return NewUsageService(usageRepo, userRepo, photoRepo, eventRepo, messageRepo)
+}
+
+// ProvideUploadSessionService provides the resumable upload service
+func ProvideUploadSessionService(
+	sessionRepo domain.UploadSessionRepository,
+	storageService domain.StorageService,
+	scanService domain.UploadScanService,
+	cfg *config.Config,
+) domain.UploadSessionService {
+	const megabyte = 1024 * 1024
+
+	// Entries are contentType=MB, checked when the configuration loads
+	maxSizes := make(domain.UploadSizeLimits, len(cfg.UploadMaxSizes))
+	for _, entry := range cfg.UploadMaxSizes {
+		contentType, size, _ := strings.Cut(entry, "=")
+		megabytes, _ := strconv.Atoi(strings.TrimSpace(size))
+		maxSizes[strings.ToLower(strings.TrimSpace(contentType))] = int64(megabytes) * megabyte
+	}
+
+	return NewUploadSessionService(
+		sessionRepo,
+		storageService,
+		scanService,
+		int64(cfg.UploadChunkSize)*megabyte,
+		time.Duration(cfg.UploadSessionTTL)*time.Hour,
+		time.Duration(cfg.UploadPresignExpiry)*time.Minute,
+		maxSizes,
+	)
+}
+
+// ProvideUploadScanService provides the content scanning of uploads
+func ProvideUploadScanService(
+	scanner domain.FileScanner,
+	storageService domain.StorageService,
+	cfg *config.Config,
+) domain.UploadScanService {
+	return NewUploadScanService(
+		scanner,
+		storageService,
+		time.Duration(cfg.UploadScanTimeout)*time.Second,
+		cfg.UploadQuarantineFolder,
+	)
+}
+
+// ProvidePhotoCommentService provides a photo comment service
+func ProvidePhotoCommentService(
+	commentRepo domain.PhotoCommentRepository,
+	photoRepo domain.PhotoRepository,
+	userRepo domain.UserRepository,
+	notificationService domain.NotificationService,
+) domain.PhotoCommentService {
+	return NewPhotoCommentService(commentRepo, photoRepo, userRepo, notificationService)
+}
+
+// ProvideJournalService provides a couple journal service
+func ProvideJournalService(journalRepo domain.JournalRepository, userRepo domain.UserRepository) domain.JournalService {
+	return NewJournalService(journalRepo, userRepo)
+}
+
+// ProvideBucketListService provides a bucket list service
+func ProvideBucketListService(
+	bucketListRepo domain.BucketListRepository,
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	userRepo domain.UserRepository,
+) domain.BucketListService {
+	return NewBucketListService(bucketListRepo, photoRepo, eventRepo, userRepo)
+}
+
+// ProvideMoodService provides a mood check-in service
+func ProvideMoodService(moodRepo domain.MoodRepository, userRepo domain.UserRepository, events domain.EventPublisher) domain.MoodService {
+	return NewMoodService(moodRepo, userRepo, events)
+}
+
+// ProvideAutoMilestoneService provides a milestone event generation service
+func ProvideAutoMilestoneService(
+	eventRepo domain.EventRepository,
+	userRepo domain.UserRepository,
+	settingsRepo domain.CoupleSettingsRepository,
+	settingsService domain.CoupleSettingsService,
+	i18n *i18n.I18n,
+) domain.AutoMilestoneService {
+	return NewAutoMilestoneService(eventRepo, userRepo, settingsRepo, settingsService, i18n)
+}
+
+// ProvideCountdownService provides a countdown service
+func ProvideCountdownService(countdownRepo domain.CountdownRepository, eventRepo domain.EventRepository, userRepo domain.UserRepository) domain.CountdownService {
+	return NewCountdownService(countdownRepo, eventRepo, userRepo)
+}
+
+// ProvideAdminService provides the service for the support staff operations on accounts
+func ProvideAdminService(
+	userRepo domain.UserRepository,
+	matchRequestRepo domain.MatchRequestRepository,
+	tokenFamilies domain.TokenFamilyRepository,
+	userService domain.UserService,
+	auditService domain.AuditService,
+) domain.AdminService {
+	return NewAdminService(userRepo, matchRequestRepo, tokenFamilies, userService, auditService)
+}
+
+// ProvideAuditService provides an audit log service
+func ProvideAuditService(auditRepo domain.AuditLogRepository) domain.AuditService {
+	return NewAuditService(auditRepo)
+}
+
+// ProvideMemoriesService provides an "on this day" memories service
+func ProvideMemoriesService(
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	albumRepo domain.AlbumRepository,
+	userRepo domain.UserRepository,
+	settingsService domain.CoupleSettingsService,
+	notificationService domain.NotificationService,
+	emailService *email.EmailService,
+) domain.MemoriesService {
+	return NewMemoriesService(photoRepo, eventRepo, albumRepo, userRepo, settingsService, notificationService, emailService)
+}
+
+// ProvidePlaceService provides a place search service
+func ProvidePlaceService(provider domain.PlaceProvider, cfg *config.Config) domain.PlaceService {
+	return NewPlaceService(provider, time.Duration(cfg.PlacesCacheTTL)*time.Second)
+}
+
+// ProvideFileService provides a service serving stored files to users
+func ProvideFileService(
+	photoRepo domain.PhotoRepository,
+	userRepo domain.UserRepository,
+	storageService domain.StorageService,
+) domain.FileService {
+	return NewFileService(photoRepo, userRepo, storageService)
+}
+
+// ProvideEngagementService provides the daily engagement service, subscribed to the
+// events of the interactions that keep couples' streaks going
+func ProvideEngagementService(
+	engagementRepo domain.EngagementRepository,
+	userRepo domain.UserRepository,
+	settingsService domain.CoupleSettingsService,
+	bus *eventbus.Bus,
+) domain.EngagementService {
+	engagementService := NewEngagementService(engagementRepo, userRepo, settingsService)
+	bus.Subscribe(engagementService.HandleEvent,
+		domain.DomainEventMessageSent,
+		domain.DomainEventPhotoCreated,
+		domain.DomainEventMoodRecorded)
+	return engagementService
+}
+
+// ProvideDailyQuestionService provides a daily question service
+func ProvideDailyQuestionService(
+	questionRepo domain.DailyQuestionRepository,
+	userRepo domain.UserRepository,
+	settingsService domain.CoupleSettingsService,
+	notificationService domain.NotificationService,
+	source domain.QuestionSource,
+	cfg *config.Config,
+) domain.DailyQuestionService {
+	return NewDailyQuestionService(questionRepo, userRepo, settingsService, notificationService, source, time.Duration(cfg.ContentCacheTTL)*time.Second)
+}
+
+// ProvideWishlistService provides a wishlist service
+func ProvideWishlistService(wishlistRepo domain.WishlistRepository, userRepo domain.UserRepository) domain.WishlistService {
+	return NewWishlistService(wishlistRepo, userRepo)
+}
+
+// ProvideDatePlanService provides a date plan service
+func ProvideDatePlanService(
+	planRepo domain.DatePlanRepository,
+	userRepo domain.UserRepository,
+	eventService domain.EventService,
+	source domain.DateIdeaSource,
+	cfg *config.Config,
+) domain.DatePlanService {
+	return NewDatePlanService(planRepo, userRepo, eventService, source, time.Duration(cfg.ContentCacheTTL)*time.Second)
 }
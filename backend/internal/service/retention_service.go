@@ -0,0 +1,440 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// retentionBatchSize is the number of records a policy finds and purges at a time
+	retentionBatchSize = 500
+	// retentionSampleSize is the number of record IDs listed in a dry-run report
+	retentionSampleSize = 20
+
+	retentionTriggerSchedule = "schedule"
+	retentionTriggerAdmin    = "admin"
+)
+
+// RetentionService implements domain.RetentionService
+type RetentionService struct {
+	policies        []domain.RetentionPolicy
+	auditRepo       domain.RetentionAuditRepository
+	scheduledDryRun bool
+
+	running sync.Mutex
+}
+
+// NewRetentionService creates a new retention service. Scheduled runs only report
+// what they would purge while scheduledDryRun is set.
+func NewRetentionService(
+	policies []domain.RetentionPolicy,
+	auditRepo domain.RetentionAuditRepository,
+	scheduledDryRun bool,
+) domain.RetentionService {
+	return &RetentionService{
+		policies:        policies,
+		auditRepo:       auditRepo,
+		scheduledDryRun: scheduledDryRun,
+	}
+}
+
+// ListPolicies describes the configured retention policies
+func (s *RetentionService) ListPolicies(ctx context.Context) []*domain.RetentionPolicyInfo {
+	policies := make([]*domain.RetentionPolicyInfo, 0, len(s.policies))
+	for _, policy := range s.policies {
+		policies = append(policies, &domain.RetentionPolicyInfo{
+			Name:        policy.Name(),
+			Description: policy.Description(),
+			MaxAgeDays:  int(policy.MaxAge() / (24 * time.Hour)),
+		})
+	}
+	return policies
+}
+
+// Run applies the requested policies now. It is a dry run unless DryRun is false.
+func (s *RetentionService) Run(ctx context.Context, req *domain.RunRetentionRequest) (*domain.RetentionReport, error) {
+	policies, err := s.selectPolicies(req.Policies)
+	if err != nil {
+		return nil, err
+	}
+
+	dryRun := req.DryRun == nil || *req.DryRun
+
+	if !s.running.TryLock() {
+		return nil, domain.ErrOperationInProgressError("A retention run")
+	}
+	defer s.running.Unlock()
+
+	return s.run(ctx, policies, dryRun, retentionTriggerAdmin), nil
+}
+
+// RunScheduled applies every policy. It is run periodically by the scheduler.
+func (s *RetentionService) RunScheduled(ctx context.Context) error {
+	if !s.running.TryLock() {
+		logging.FromContext(ctx).Info("Skipping scheduled retention run, another run is in progress")
+		return nil
+	}
+	defer s.running.Unlock()
+
+	report := s.run(ctx, s.policies, s.scheduledDryRun, retentionTriggerSchedule)
+	for _, policy := range report.Policies {
+		if policy.Error != "" {
+			return fmt.Errorf("retention policy %s failed: %s", policy.Policy, policy.Error)
+		}
+	}
+	return nil
+}
+
+// ListAudit retrieves retention audit entries, newest first
+func (s *RetentionService) ListAudit(ctx context.Context, filter domain.RetentionAuditFilter, cursor *domain.Cursor, limit int) (*domain.RetentionAuditListResponse, error) {
+	// Fetch one extra item to know whether another page exists
+	entries, err := s.auditRepo.List(ctx, filter, cursor, limit+1)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to list retention audit entries")
+	}
+
+	response := &domain.RetentionAuditListResponse{
+		Entries: entries,
+		Limit:   limit,
+	}
+	if response.Entries == nil {
+		response.Entries = []*domain.RetentionAuditEntry{}
+	}
+
+	if len(entries) > limit {
+		response.Entries = entries[:limit]
+		last := response.Entries[limit-1]
+		response.NextCursor = domain.NewCursor(last.CreatedAt, last.ID).Encode()
+	}
+
+	return response, nil
+}
+
+// selectPolicies returns the configured policies with the given names, or all of them
+func (s *RetentionService) selectPolicies(names []string) ([]domain.RetentionPolicy, error) {
+	if len(names) == 0 {
+		return s.policies, nil
+	}
+
+	byName := make(map[string]domain.RetentionPolicy, len(s.policies))
+	for _, policy := range s.policies {
+		byName[policy.Name()] = policy
+	}
+
+	selected := make([]domain.RetentionPolicy, 0, len(names))
+	for _, name := range names {
+		policy, ok := byName[name]
+		if !ok {
+			return nil, domain.ErrInvalidRequestError("Unknown or disabled retention policy: " + name)
+		}
+		selected = append(selected, policy)
+	}
+	return selected, nil
+}
+
+// run applies policies one after the other. A failing policy does not stop the others.
+func (s *RetentionService) run(ctx context.Context, policies []domain.RetentionPolicy, dryRun bool, trigger string) *domain.RetentionReport {
+	report := &domain.RetentionReport{
+		RunID:     primitive.NewObjectID().Hex(),
+		DryRun:    dryRun,
+		Trigger:   trigger,
+		StartedAt: time.Now(),
+		Policies:  make([]*domain.RetentionPolicyReport, 0, len(policies)),
+	}
+
+	for _, policy := range policies {
+		policyReport := s.apply(ctx, report, policy)
+		report.Policies = append(report.Policies, policyReport)
+
+		fields := []zap.Field{
+			zap.String("run_id", report.RunID),
+			zap.String("policy", policyReport.Policy),
+			zap.Bool("dry_run", dryRun),
+			zap.Int("matched", policyReport.Matched),
+			zap.Int64("purged", policyReport.Purged),
+		}
+		if policyReport.Error != "" {
+			logging.FromContext(ctx).Error("Retention policy failed", append(fields, zap.String("error", policyReport.Error))...)
+		} else if policyReport.Matched > 0 {
+			logging.FromContext(ctx).Info("Retention policy applied", fields...)
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	return report
+}
+
+// apply finds the records a policy has expired and, unless the run is a dry run,
+// purges them batch by batch with an audit entry for each batch. A dry run records a
+// single audit entry with the first records it would purge.
+func (s *RetentionService) apply(ctx context.Context, run *domain.RetentionReport, policy domain.RetentionPolicy) *domain.RetentionPolicyReport {
+	report := &domain.RetentionPolicyReport{
+		Policy: policy.Name(),
+		Cutoff: time.Now().Add(-policy.MaxAge()),
+	}
+
+	filePolicy, ownsFiles := policy.(domain.RetentionFilePolicy)
+
+	var afterID primitive.ObjectID
+	var wouldPurge []primitive.ObjectID
+	for {
+		ids, err := policy.FindExpired(ctx, report.Cutoff, afterID, retentionBatchSize)
+		if err != nil {
+			report.Error = err.Error()
+			break
+		}
+		if len(ids) == 0 {
+			break
+		}
+		afterID = ids[len(ids)-1]
+		report.Matched += len(ids)
+
+		var files []string
+		if ownsFiles {
+			files, err = filePolicy.FindFiles(ctx, ids)
+			if err != nil {
+				report.Error = err.Error()
+				break
+			}
+			report.Files += len(files)
+		}
+
+		if run.DryRun {
+			if room := retentionBatchSize - len(wouldPurge); room > 0 {
+				wouldPurge = append(wouldPurge, ids[:min(len(ids), room)]...)
+			}
+		} else {
+			var filesPurged int64
+			if ownsFiles {
+				// Delete the files first, so that none outlives the records leading to it
+				filesPurged, err = filePolicy.DeleteFiles(ctx, files)
+				report.FilesPurged += filesPurged
+				if err != nil {
+					report.Error = err.Error()
+					break
+				}
+			}
+
+			purged, err := policy.Purge(ctx, ids, report.Cutoff)
+			if err != nil {
+				report.Error = err.Error()
+				break
+			}
+			report.Purged += purged
+
+			entry := s.auditEntry(run, report, len(ids), purged, ids)
+			entry.Files = len(files)
+			entry.FilesPurged = filesPurged
+			if err := s.auditRepo.Create(ctx, entry); err != nil {
+				// Stop rather than keep purging without an audit trail
+				report.Error = "purged records could not be audited: " + err.Error()
+				break
+			}
+		}
+
+		if len(ids) < retentionBatchSize {
+			break
+		}
+	}
+
+	if run.DryRun {
+		for i := 0; i < len(wouldPurge) && i < retentionSampleSize; i++ {
+			report.SampleIDs = append(report.SampleIDs, wouldPurge[i].Hex())
+		}
+		entry := s.auditEntry(run, report, report.Matched, 0, wouldPurge)
+		entry.Files = report.Files
+		if err := s.auditRepo.Create(ctx, entry); err != nil && report.Error == "" {
+			report.Error = "dry run could not be audited: " + err.Error()
+		}
+	}
+
+	return report
+}
+
+// auditEntry builds the audit entry of a purge, or of a dry run
+func (s *RetentionService) auditEntry(run *domain.RetentionReport, report *domain.RetentionPolicyReport, matched int, purged int64, ids []primitive.ObjectID) *domain.RetentionAuditEntry {
+	if ids == nil {
+		ids = []primitive.ObjectID{}
+	}
+	return &domain.RetentionAuditEntry{
+		RunID:     run.RunID,
+		Policy:    report.Policy,
+		DryRun:    run.DryRun,
+		Cutoff:    report.Cutoff,
+		Matched:   matched,
+		Purged:    purged,
+		RecordIDs: ids,
+		Trigger:   run.Trigger,
+	}
+}
+
+// unverifiedAccountsPolicy purges accounts that never verified their email and were
+// never matched once they have been inactive for maxAge
+type unverifiedAccountsPolicy struct {
+	userRepo domain.UserRepository
+	maxAge   time.Duration
+}
+
+// NewUnverifiedAccountsPolicy creates the retention policy for unverified accounts
+func NewUnverifiedAccountsPolicy(userRepo domain.UserRepository, maxAge time.Duration) domain.RetentionPolicy {
+	return &unverifiedAccountsPolicy{userRepo: userRepo, maxAge: maxAge}
+}
+
+func (p *unverifiedAccountsPolicy) Name() string { return domain.RetentionPolicyUnverifiedAccounts }
+
+func (p *unverifiedAccountsPolicy) Description() string {
+	return "Accounts that never verified their email and were never matched, once inactive"
+}
+
+func (p *unverifiedAccountsPolicy) MaxAge() time.Duration { return p.maxAge }
+
+func (p *unverifiedAccountsPolicy) FindExpired(ctx context.Context, cutoff time.Time, afterID primitive.ObjectID, limit int) ([]primitive.ObjectID, error) {
+	return p.userRepo.ListUnverifiedInactiveIDs(ctx, cutoff, afterID, limit)
+}
+
+func (p *unverifiedAccountsPolicy) Purge(ctx context.Context, ids []primitive.ObjectID, cutoff time.Time) (int64, error) {
+	return p.userRepo.PurgeUnverifiedInactive(ctx, ids, cutoff)
+}
+
+// staleMatchRequestsPolicy purges match requests that were never accepted once they
+// have not changed for maxAge
+type staleMatchRequestsPolicy struct {
+	matchRequestRepo domain.MatchRequestRepository
+	maxAge           time.Duration
+}
+
+// NewStaleMatchRequestsPolicy creates the retention policy for stale match requests
+func NewStaleMatchRequestsPolicy(matchRequestRepo domain.MatchRequestRepository, maxAge time.Duration) domain.RetentionPolicy {
+	return &staleMatchRequestsPolicy{matchRequestRepo: matchRequestRepo, maxAge: maxAge}
+}
+
+func (p *staleMatchRequestsPolicy) Name() string { return domain.RetentionPolicyStaleMatchRequests }
+
+func (p *staleMatchRequestsPolicy) Description() string {
+	return "Pending, declined and ignored match requests, once unchanged"
+}
+
+func (p *staleMatchRequestsPolicy) MaxAge() time.Duration { return p.maxAge }
+
+func (p *staleMatchRequestsPolicy) FindExpired(ctx context.Context, cutoff time.Time, afterID primitive.ObjectID, limit int) ([]primitive.ObjectID, error) {
+	return p.matchRequestRepo.ListStaleIDs(cutoff, afterID, limit)
+}
+
+func (p *staleMatchRequestsPolicy) Purge(ctx context.Context, ids []primitive.ObjectID, cutoff time.Time) (int64, error) {
+	return p.matchRequestRepo.PurgeStale(ids, cutoff)
+}
+
+// accountFileFolders are the storage folders, besides avatars, that files are stored in
+// under "<folder>/<uploader ID>/": the folder of photos and those the upload endpoints offer
+var accountFileFolders = []string{photoFolder, "uploads", "documents"}
+
+// deletedAccountsPolicy purges accounts once they have been deleted, or merged into
+// another one, for maxAge, along with their avatars and the files they uploaded that no
+// photo uses. Photos, events and messages belong to the couple and go through the trash
+// instead.
+type deletedAccountsPolicy struct {
+	userRepo       domain.UserRepository
+	photoRepo      domain.PhotoRepository
+	storageService domain.StorageService
+	maxAge         time.Duration
+}
+
+// NewDeletedAccountsPolicy creates the retention policy for deleted accounts
+func NewDeletedAccountsPolicy(
+	userRepo domain.UserRepository,
+	photoRepo domain.PhotoRepository,
+	storageService domain.StorageService,
+	maxAge time.Duration,
+) domain.RetentionPolicy {
+	return &deletedAccountsPolicy{
+		userRepo:       userRepo,
+		photoRepo:      photoRepo,
+		storageService: storageService,
+		maxAge:         maxAge,
+	}
+}
+
+func (p *deletedAccountsPolicy) Name() string { return domain.RetentionPolicyDeletedAccounts }
+
+func (p *deletedAccountsPolicy) Description() string {
+	return "Deleted and merged accounts, once deleted"
+}
+
+func (p *deletedAccountsPolicy) MaxAge() time.Duration { return p.maxAge }
+
+func (p *deletedAccountsPolicy) FindExpired(ctx context.Context, cutoff time.Time, afterID primitive.ObjectID, limit int) ([]primitive.ObjectID, error) {
+	return p.userRepo.ListDeletedIDs(ctx, cutoff, afterID, limit)
+}
+
+func (p *deletedAccountsPolicy) Purge(ctx context.Context, ids []primitive.ObjectID, cutoff time.Time) (int64, error) {
+	return p.userRepo.PurgeDeleted(ctx, ids, cutoff)
+}
+
+// FindFiles lists the avatars of the accounts and the files they uploaded that no photo,
+// in the trash or not, is stored under
+func (p *deletedAccountsPolicy) FindFiles(ctx context.Context, ids []primitive.ObjectID) ([]string, error) {
+	var keys []string
+	for _, id := range ids {
+		avatars, err := p.listFiles(ctx, avatarFolder+id.Hex()+"/")
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, avatars...)
+
+		for _, folder := range accountFileFolders {
+			uploads, err := p.listFiles(ctx, folder+"/"+id.Hex()+"/")
+			if err != nil {
+				return nil, err
+			}
+			for _, key := range uploads {
+				photo, err := p.photoRepo.GetByStorageKey(ctx, key)
+				if err != nil {
+					return nil, fmt.Errorf("failed to look up the photo of %s: %w", key, err)
+				}
+				if photo == nil {
+					keys = append(keys, key)
+				}
+			}
+		}
+	}
+	return keys, nil
+}
+
+// DeleteFiles deletes the files stored under keys. Files already gone count as deleted.
+func (p *deletedAccountsPolicy) DeleteFiles(ctx context.Context, keys []string) (int64, error) {
+	var deleted int64
+	for _, key := range keys {
+		if err := p.storageService.Delete(ctx, key); err != nil && !errors.Is(err, domain.ErrFileNotFound) {
+			return deleted, fmt.Errorf("failed to delete file %s: %w", key, err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// listFiles lists the keys of every file stored in folder
+func (p *deletedAccountsPolicy) listFiles(ctx context.Context, folder string) ([]string, error) {
+	var keys []string
+	after := ""
+	for {
+		files, err := p.storageService.ListFiles(ctx, folder, after, retentionBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files in %s: %w", folder, err)
+		}
+		for _, file := range files {
+			keys = append(keys, file.Key)
+		}
+		if len(files) < retentionBatchSize {
+			return keys, nil
+		}
+		after = files[len(files)-1].Key
+	}
+}
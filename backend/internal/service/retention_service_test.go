@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeStorageService keeps the keys of stored files in memory
+type fakeStorageService struct {
+	domain.StorageService
+	keys map[string]bool
+}
+
+func (s *fakeStorageService) ListFiles(ctx context.Context, folder string, startAfter string, limit int) ([]*domain.FileInfo, error) {
+	var keys []string
+	for key := range s.keys {
+		if strings.HasPrefix(key, folder) && key > startAfter {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	files := []*domain.FileInfo{}
+	for i := 0; i < len(keys) && i < limit; i++ {
+		files = append(files, &domain.FileInfo{Key: keys[i]})
+	}
+	return files, nil
+}
+
+func (s *fakeStorageService) Delete(ctx context.Context, key string) error {
+	if !s.keys[key] {
+		return domain.ErrFileNotFound
+	}
+	delete(s.keys, key)
+	return nil
+}
+
+// deletedUserRepository holds accounts deleted long ago
+type deletedUserRepository struct {
+	domain.UserRepository
+	ids    []primitive.ObjectID
+	purged []primitive.ObjectID
+}
+
+func (r *deletedUserRepository) ListDeletedIDs(ctx context.Context, before time.Time, afterID primitive.ObjectID, limit int) ([]primitive.ObjectID, error) {
+	var ids []primitive.ObjectID
+	for _, id := range r.ids {
+		if id.Hex() > afterID.Hex() && len(ids) < limit {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *deletedUserRepository) PurgeDeleted(ctx context.Context, ids []primitive.ObjectID, before time.Time) (int64, error) {
+	r.purged = append(r.purged, ids...)
+	return int64(len(ids)), nil
+}
+
+// storageKeyPhotoRepository holds the storage keys photos use
+type storageKeyPhotoRepository struct {
+	domain.PhotoRepository
+	keys map[string]bool
+}
+
+func (r *storageKeyPhotoRepository) GetByStorageKey(ctx context.Context, key string) (*domain.Photo, error) {
+	if r.keys[key] {
+		return &domain.Photo{ImageURL: key}, nil
+	}
+	return nil, nil
+}
+
+type fakeRetentionAuditRepository struct {
+	domain.RetentionAuditRepository
+	entries []*domain.RetentionAuditEntry
+}
+
+func (r *fakeRetentionAuditRepository) Create(ctx context.Context, entry *domain.RetentionAuditEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// deletedAccountFixture is a deleted account with an avatar, an upload, a photo of the
+// couple and a photo it uploaded but never used, next to another account's avatar
+type deletedAccountFixture struct {
+	service domain.RetentionService
+	users   *deletedUserRepository
+	storage *fakeStorageService
+	audit   *fakeRetentionAuditRepository
+	owned   []string
+	kept    []string
+}
+
+func newDeletedAccountFixture() *deletedAccountFixture {
+	id := primitive.NewObjectID().Hex()
+	owned := []string{
+		"avatars/" + id + "/a_256.jpg",
+		"avatars/" + id + "/a_64.jpg",
+		"uploads/" + id + "/notes.pdf",
+		"photos/" + id + "/unused.jpg",
+	}
+	kept := []string{
+		"photos/" + id + "/couple.jpg",
+		"avatars/" + primitive.NewObjectID().Hex() + "/b_256.jpg",
+	}
+
+	storage := &fakeStorageService{keys: map[string]bool{}}
+	for _, key := range append(append([]string{}, owned...), kept...) {
+		storage.keys[key] = true
+	}
+	userID, _ := primitive.ObjectIDFromHex(id)
+	users := &deletedUserRepository{ids: []primitive.ObjectID{userID}}
+	photos := &storageKeyPhotoRepository{keys: map[string]bool{kept[0]: true}}
+	audit := &fakeRetentionAuditRepository{}
+
+	policy := NewDeletedAccountsPolicy(users, photos, storage, 30*24*time.Hour)
+	return &deletedAccountFixture{
+		service: NewRetentionService([]domain.RetentionPolicy{policy}, audit, true),
+		users:   users,
+		storage: storage,
+		audit:   audit,
+		owned:   owned,
+		kept:    kept,
+	}
+}
+
+func TestDeletedAccountsPolicyDeletesFiles(t *testing.T) {
+	f := newDeletedAccountFixture()
+	dryRun := false
+
+	report, err := f.service.Run(context.Background(), &domain.RunRetentionRequest{DryRun: &dryRun})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := report.Policies[0]
+	if policy.Error != "" {
+		t.Fatalf("error = %s", policy.Error)
+	}
+	if policy.Purged != 1 || len(f.users.purged) != 1 {
+		t.Errorf("purged = %d, want 1", policy.Purged)
+	}
+	if policy.Files != len(f.owned) || policy.FilesPurged != int64(len(f.owned)) {
+		t.Errorf("files = %d, files purged = %d, want %d", policy.Files, policy.FilesPurged, len(f.owned))
+	}
+	for _, key := range f.owned {
+		if f.storage.keys[key] {
+			t.Errorf("%s was not deleted", key)
+		}
+	}
+	for _, key := range f.kept {
+		if !f.storage.keys[key] {
+			t.Errorf("%s was deleted", key)
+		}
+	}
+	if entry := f.audit.entries[0]; entry.FilesPurged != int64(len(f.owned)) {
+		t.Errorf("audited files purged = %d, want %d", entry.FilesPurged, len(f.owned))
+	}
+}
+
+func TestDeletedAccountsPolicyDryRunCountsFiles(t *testing.T) {
+	f := newDeletedAccountFixture()
+
+	report, err := f.service.Run(context.Background(), &domain.RunRetentionRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := report.Policies[0]
+	if policy.Files != len(f.owned) || policy.FilesPurged != 0 {
+		t.Errorf("files = %d, files purged = %d, want %d and 0", policy.Files, policy.FilesPurged, len(f.owned))
+	}
+	if len(f.storage.keys) != len(f.owned)+len(f.kept) {
+		t.Errorf("%d files stored, want %d", len(f.storage.keys), len(f.owned)+len(f.kept))
+	}
+	if len(f.users.purged) != 0 {
+		t.Errorf("%d accounts purged, want 0", len(f.users.purged))
+	}
+	if entry := f.audit.entries[0]; entry.Files != len(f.owned) {
+		t.Errorf("audited files = %d, want %d", entry.Files, len(f.owned))
+	}
+}
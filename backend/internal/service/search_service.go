@@ -0,0 +1,280 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// searchMaxPerType caps how many hits each module contributes before ranking
+	searchMaxPerType = 100
+	// searchSnippetLength is the maximum length of a result snippet
+	searchSnippetLength = 160
+)
+
+// Relevance scores for where the query matched
+const (
+	searchScoreTitleExact  = 100
+	searchScoreTitlePrefix = 80
+	searchScoreTitle       = 60
+	searchScoreTag         = 50
+	searchScoreOther       = 30
+)
+
+// SearchService implements domain.SearchService
+type SearchService struct {
+	photoRepo   domain.PhotoRepository
+	eventRepo   domain.EventRepository
+	messageRepo domain.MessageRepository
+	journalRepo domain.JournalRepository
+	userRepo    domain.UserRepository
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	messageRepo domain.MessageRepository,
+	journalRepo domain.JournalRepository,
+	userRepo domain.UserRepository,
+) domain.SearchService {
+	return &SearchService{
+		photoRepo:   photoRepo,
+		eventRepo:   eventRepo,
+		messageRepo: messageRepo,
+		journalRepo: journalRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// Search queries every requested module concurrently, ranks the merged hits and
+// returns the requested page. A module that fails is logged and left out so one slow
+// or broken collection does not fail the whole search.
+func (s *SearchService) Search(
+	ctx context.Context,
+	userID primitive.ObjectID,
+	query string,
+	types []domain.SearchResultType,
+	page, limit int,
+) (*domain.SearchResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	response := &domain.SearchResponse{
+		Query:   query,
+		Results: []*domain.SearchResult{},
+		Counts:  make(map[domain.SearchResultType]int),
+		Page:    page,
+		Limit:   limit,
+	}
+
+	if user.MatchCode == "" {
+		return response, nil
+	}
+
+	if len(types) == 0 {
+		types = domain.SearchResultTypes
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results []*domain.SearchResult
+	)
+
+	for _, resultType := range types {
+		wg.Add(1)
+		go func(resultType domain.SearchResultType) {
+			defer wg.Done()
+
+			hits, err := s.searchType(ctx, user, query, resultType)
+			if err != nil {
+				logging.FromContext(ctx).Warn("Search module failed",
+					zap.String("type", string(resultType)),
+					zap.String("user_id", userID.Hex()),
+					zap.Error(err))
+				return
+			}
+
+			mu.Lock()
+			results = append(results, hits...)
+			response.Counts[resultType] = len(hits)
+			mu.Unlock()
+		}(resultType)
+	}
+	wg.Wait()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Date.After(results[j].Date.Time)
+	})
+
+	response.Total = len(results)
+
+	offset := (page - 1) * limit
+	if offset < len(results) {
+		end := offset + limit
+		if end > len(results) {
+			end = len(results)
+		}
+		response.Results = results[offset:end]
+	}
+
+	return response, nil
+}
+
+// searchType runs the search of a single module and converts the hits
+func (s *SearchService) searchType(ctx context.Context, user *domain.User, query string, resultType domain.SearchResultType) ([]*domain.SearchResult, error) {
+	matchCode := user.MatchCode
+
+	switch resultType {
+	case domain.SearchResultPhoto:
+		photos, err := s.photoRepo.SearchByMatchCode(ctx, matchCode, user.ID, query, searchMaxPerType, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		results := make([]*domain.SearchResult, len(photos))
+		for i, photo := range photos {
+			response := photo.ToResponse()
+			score := scoreMatch(query, photo.Title, photo.Description)
+			for _, tag := range photo.Tags {
+				if strings.EqualFold(tag, query) && score < searchScoreTag {
+					score = searchScoreTag
+				}
+			}
+
+			results[i] = &domain.SearchResult{
+				Type:         domain.SearchResultPhoto,
+				ID:           response.ID,
+				Title:        photo.Title,
+				Snippet:      snippet(photo.Description),
+				ThumbnailURL: response.ThumbnailURL,
+				Date:         domain.DateFromTime(photo.Date),
+				Score:        score,
+			}
+		}
+		return results, nil
+
+	case domain.SearchResultEvent:
+		events, err := s.eventRepo.SearchByMatchCode(matchCode, user.ID, query, searchMaxPerType)
+		if err != nil {
+			return nil, err
+		}
+
+		results := make([]*domain.SearchResult, len(events))
+		for i, event := range events {
+			results[i] = &domain.SearchResult{
+				Type:    domain.SearchResultEvent,
+				ID:      event.ID.Hex(),
+				Title:   event.Title,
+				Snippet: snippet(event.Description),
+				Date:    domain.DateFromTime(event.Date),
+				Score:   scoreMatch(query, event.Title, event.Description+" "+event.Location),
+			}
+		}
+		return results, nil
+
+	case domain.SearchResultMessage:
+		if user.PartnerID == nil {
+			return nil, nil
+		}
+
+		messages, err := s.messageRepo.Search(ctx, user.ID, *user.PartnerID, query, nil, searchMaxPerType)
+		if err != nil {
+			return nil, err
+		}
+
+		results := make([]*domain.SearchResult, len(messages))
+		for i, message := range messages {
+			// Messages have no title; they are labelled with their sender
+			sender := user.PartnerName
+			if message.SenderID == user.ID {
+				sender = user.Name
+			}
+
+			// The text index already matched every term, ignoring diacritics
+			score := scoreMatch(query, "", message.Content)
+			if score < searchScoreOther {
+				score = searchScoreOther
+			}
+
+			results[i] = &domain.SearchResult{
+				Type:    domain.SearchResultMessage,
+				ID:      message.ID.Hex(),
+				Title:   sender,
+				Snippet: snippet(message.Content),
+				Date:    domain.DateFromTime(message.CreatedAt),
+				Score:   score,
+			}
+		}
+		return results, nil
+
+	case domain.SearchResultJournal:
+		now := time.Now()
+		entries, err := s.journalRepo.Search(ctx, matchCode, user.ID, query, now, searchMaxPerType)
+		if err != nil {
+			return nil, err
+		}
+
+		results := make([]*domain.SearchResult, len(entries))
+		for i, entry := range entries {
+			// Sealed entries matched on their title only; their content stays hidden
+			response := entry.ToResponse(user.ID, now)
+
+			results[i] = &domain.SearchResult{
+				Type:    domain.SearchResultJournal,
+				ID:      response.ID,
+				Title:   entry.Title,
+				Snippet: snippet(response.Content),
+				Date:    response.Date,
+				Score:   scoreMatch(query, entry.Title, response.Content),
+			}
+		}
+		return results, nil
+
+	default:
+		return nil, fmt.Errorf("invalid search type %q", resultType)
+	}
+}
+
+// scoreMatch ranks a hit by how closely its title matches the query
+func scoreMatch(query, title, other string) int {
+	q := strings.ToLower(query)
+	t := strings.ToLower(title)
+
+	switch {
+	case t == q:
+		return searchScoreTitleExact
+	case strings.HasPrefix(t, q):
+		return searchScoreTitlePrefix
+	case strings.Contains(t, q):
+		return searchScoreTitle
+	case strings.Contains(strings.ToLower(other), q):
+		return searchScoreOther
+	default:
+		return 0
+	}
+}
+
+// snippet shortens text for display in search results
+func snippet(text string) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= searchSnippetLength {
+		return string(runes)
+	}
+	return string(runes[:searchSnippetLength]) + "…"
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSearchIncludesJournalEntries(t *testing.T) {
+	user := &domain.User{ID: primitive.NewObjectID(), MatchCode: "ABC123"}
+	partnerID := primitive.NewObjectID()
+	unlockAt := time.Now().Add(24 * time.Hour)
+	open := &domain.JournalEntry{ID: primitive.NewObjectID(), MatchCode: "ABC123", AuthorID: partnerID, Title: "Beach trip", Content: "The beach at sunset", Date: time.Now()}
+	sealed := &domain.JournalEntry{ID: primitive.NewObjectID(), MatchCode: "ABC123", AuthorID: partnerID, Title: "Beach letter", Content: "A secret", Date: time.Now(), UnlockAt: &unlockAt}
+
+	journal := &fakeJournalRepository{entries: map[primitive.ObjectID]*domain.JournalEntry{open.ID: open, sealed.ID: sealed}}
+	users := &fakeUserRepository{users: map[primitive.ObjectID]*domain.User{user.ID: user}}
+	service := NewSearchService(&fakePhotoRepository{}, &fakeEventRepository{}, &fakeMessageRepository{}, journal, users)
+
+	response, err := service.Search(context.Background(), user.ID, "beach", nil, 1, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := response.Counts[domain.SearchResultJournal]; got != 2 {
+		t.Fatalf("journal count = %d, want 2", got)
+	}
+	for _, result := range response.Results {
+		if result.Type != domain.SearchResultJournal {
+			t.Errorf("result type = %s, want %s", result.Type, domain.SearchResultJournal)
+		}
+		if result.ID == sealed.ID.Hex() && result.Snippet != "" {
+			t.Errorf("sealed entry snippet = %q, want it hidden", result.Snippet)
+		}
+		if result.ID == open.ID.Hex() && result.Snippet != open.Content {
+			t.Errorf("snippet = %q, want %q", result.Snippet, open.Content)
+		}
+	}
+}
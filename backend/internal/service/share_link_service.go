@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/auth"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// shareLinkDefaultExpiry is used when a link is created without an explicit lifetime
+	shareLinkDefaultExpiry = 7 * 24 * time.Hour
+	// shareLinkImageURLExpiry is how long image URLs served through a share link stay valid
+	shareLinkImageURLExpiry = 1 * time.Hour
+	// shareLinkMaxAlbumPhotos caps the number of photos served for a shared album
+	shareLinkMaxAlbumPhotos = 200
+)
+
+// ShareLinkService implements domain.ShareLinkService
+type ShareLinkService struct {
+	shareLinkRepo    domain.ShareLinkRepository
+	photoRepo        domain.PhotoRepository
+	albumRepo        domain.AlbumRepository
+	userRepo         domain.UserRepository
+	storageService   domain.StorageService
+	watermarkService domain.WatermarkService
+	passwordManager  *auth.PasswordManager
+}
+
+// NewShareLinkService creates a new share link service
+func NewShareLinkService(
+	shareLinkRepo domain.ShareLinkRepository,
+	photoRepo domain.PhotoRepository,
+	albumRepo domain.AlbumRepository,
+	userRepo domain.UserRepository,
+	storageService domain.StorageService,
+	watermarkService domain.WatermarkService,
+	passwordManager *auth.PasswordManager,
+) domain.ShareLinkService {
+	return &ShareLinkService{
+		shareLinkRepo:    shareLinkRepo,
+		photoRepo:        photoRepo,
+		albumRepo:        albumRepo,
+		userRepo:         userRepo,
+		storageService:   storageService,
+		watermarkService: watermarkService,
+		passwordManager:  passwordManager,
+	}
+}
+
+// CreateShareLink creates a public link to one of the couple's photos or albums
+func (s *ShareLinkService) CreateShareLink(
+	ctx context.Context,
+	userID primitive.ObjectID,
+	targetType domain.ShareTargetType,
+	targetID primitive.ObjectID,
+	req *domain.CreateShareLinkRequest,
+) (*domain.ShareLinkResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	if err := s.verifyTarget(ctx, user.MatchCode, user.ID, targetType, targetID); err != nil {
+		return nil, err
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to generate share token", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create share link")
+	}
+
+	expiry := shareLinkDefaultExpiry
+	if req.ExpiresInHours > 0 {
+		expiry = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+
+	link := &domain.ShareLink{
+		Token:      token,
+		MatchCode:  user.MatchCode,
+		CreatedBy:  userID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		ExpiresAt:  time.Now().Add(expiry),
+	}
+
+	if req.Password != "" {
+		hash, err := s.passwordManager.HashPassword(req.Password)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to hash share link password", zap.Error(err))
+			return nil, domain.ErrOperationFailedError("Failed to create share link")
+		}
+		link.PasswordHash = hash
+	}
+
+	if err := s.shareLinkRepo.Create(ctx, link); err != nil {
+		logging.FromContext(ctx).Error("Failed to create share link", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create share link")
+	}
+
+	logging.FromContext(ctx).Info("Share link created",
+		zap.String("share_link_id", link.ID.Hex()),
+		zap.String("target_type", string(targetType)),
+		zap.String("target_id", targetID.Hex()),
+		zap.String("user_id", userID.Hex()),
+		zap.Time("expires_at", link.ExpiresAt))
+
+	return link.ToResponse(), nil
+}
+
+// GetShareLinks retrieves all share links created by the couple
+func (s *ShareLinkService) GetShareLinks(ctx context.Context, userID primitive.ObjectID) ([]*domain.ShareLinkResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return []*domain.ShareLinkResponse{}, nil
+	}
+
+	links, err := s.shareLinkRepo.GetByMatchCode(ctx, user.MatchCode)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get share links")
+	}
+
+	responses := make([]*domain.ShareLinkResponse, len(links))
+	for i, link := range links {
+		responses[i] = link.ToResponse()
+	}
+
+	return responses, nil
+}
+
+// RevokeShareLink revokes one of the couple's share links
+func (s *ShareLinkService) RevokeShareLink(ctx context.Context, linkID, userID primitive.ObjectID) error {
+	link, err := s.shareLinkRepo.GetByID(ctx, linkID)
+	if err != nil {
+		return domain.ErrNotFoundError("Share link")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return domain.ErrUserNotFoundError()
+	}
+
+	if link.MatchCode != user.MatchCode {
+		return domain.ErrForbiddenError()
+	}
+
+	if link.RevokedAt != nil {
+		return nil
+	}
+
+	if err := s.shareLinkRepo.Revoke(ctx, linkID, time.Now()); err != nil {
+		logging.FromContext(ctx).Error("Failed to revoke share link", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to revoke share link")
+	}
+
+	logging.FromContext(ctx).Info("Share link revoked",
+		zap.String("share_link_id", linkID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return nil
+}
+
+// OpenShareLink serves the read-only content behind a share link and counts the view
+func (s *ShareLinkService) OpenShareLink(ctx context.Context, token, password string) (*domain.SharedContentResponse, error) {
+	link, err := s.shareLinkRepo.GetByToken(ctx, token)
+	if err != nil {
+		return nil, domain.ErrNotFoundError("Share link")
+	}
+
+	if !link.IsActive(time.Now()) {
+		return nil, domain.ErrShareLinkExpiredError()
+	}
+
+	if link.PasswordHash != "" {
+		if password == "" {
+			return nil, domain.ErrPasswordRequiredError()
+		}
+		if err := s.passwordManager.VerifyPassword(link.PasswordHash, password); err != nil {
+			return nil, domain.ErrInvalidSharePasswordError()
+		}
+	}
+
+	response := &domain.SharedContentResponse{
+		TargetType: link.TargetType,
+		ExpiresAt:  link.ExpiresAt,
+	}
+
+	switch link.TargetType {
+	case domain.ShareTargetPhoto:
+		photo, err := s.photoRepo.GetByID(ctx, link.TargetID)
+		if err != nil || photo.MatchCode != link.MatchCode {
+			return nil, domain.ErrNotFoundError("Shared photo")
+		}
+		response.Photo = s.buildSharedPhoto(ctx, photo)
+	case domain.ShareTargetAlbum:
+		album, err := s.buildSharedAlbum(ctx, link)
+		if err != nil {
+			return nil, err
+		}
+		response.Album = album
+	default:
+		return nil, domain.ErrNotFoundError("Share link")
+	}
+
+	if err := s.shareLinkRepo.RecordView(ctx, link.ID, time.Now()); err != nil {
+		logging.FromContext(ctx).Warn("Failed to record share link view", zap.Error(err), zap.String("share_link_id", link.ID.Hex()))
+	}
+
+	return response, nil
+}
+
+// verifyTarget checks that the shared photo or album belongs to the couple and, for a
+// photo, that the user can see it
+func (s *ShareLinkService) verifyTarget(ctx context.Context, matchCode string, userID primitive.ObjectID, targetType domain.ShareTargetType, targetID primitive.ObjectID) error {
+	switch targetType {
+	case domain.ShareTargetPhoto:
+		photo, err := s.photoRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return domain.ErrNotFoundError("Photo")
+		}
+		if photo.MatchCode != matchCode {
+			return domain.ErrForbiddenError()
+		}
+		if !photo.IsVisibleTo(userID) {
+			return domain.ErrNotFoundError("Photo")
+		}
+	case domain.ShareTargetAlbum:
+		album, err := s.albumRepo.GetByID(ctx, targetID)
+		if err != nil {
+			return domain.ErrNotFoundError("Album")
+		}
+		if album.MatchCode != matchCode {
+			return domain.ErrForbiddenError()
+		}
+	default:
+		return domain.ErrInvalidRequestError("Invalid share target")
+	}
+
+	return nil
+}
+
+// buildSharedAlbum builds the read-only album view; private photos are left out
+func (s *ShareLinkService) buildSharedAlbum(ctx context.Context, link *domain.ShareLink) (*domain.SharedAlbumResponse, error) {
+	album, err := s.albumRepo.GetByID(ctx, link.TargetID)
+	if err != nil || album.MatchCode != link.MatchCode {
+		return nil, domain.ErrNotFoundError("Shared album")
+	}
+
+	// Nobody views a shared album as a partner, so no private photo is listed
+	photos, err := s.photoRepo.GetByAlbumID(ctx, album.ID, primitive.NilObjectID, shareLinkMaxAlbumPhotos, 0)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get shared album photos", zap.Error(err), zap.String("album_id", album.ID.Hex()))
+		return nil, domain.ErrOperationFailedError("Failed to get shared album")
+	}
+
+	shared := make([]*domain.SharedPhotoResponse, 0, len(photos))
+	for _, photo := range photos {
+		shared = append(shared, s.buildSharedPhoto(ctx, photo))
+	}
+
+	return &domain.SharedAlbumResponse{
+		Name:        album.Name,
+		Description: album.Description,
+		Photos:      shared,
+	}, nil
+}
+
+// buildSharedPhoto builds the read-only photo view with short-lived image URLs.
+// When the couple watermarks shared photos, the thumbnail is the watermarked image too
+// so the unmarked original never leaves the couple.
+func (s *ShareLinkService) buildSharedPhoto(ctx context.Context, photo *domain.Photo) *domain.SharedPhotoResponse {
+	response := &domain.SharedPhotoResponse{
+		Title:       photo.Title,
+		Description: photo.Description,
+		Date:        domain.DateFromTime(photo.Date),
+		Location:    photo.Location,
+	}
+
+	imageKey, err := s.watermarkService.SharedImageKey(ctx, photo)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to prepare shared photo", zap.Error(err), zap.String("photo_id", photo.ID.Hex()))
+		return response
+	}
+
+	thumbnailKey := imageKey
+	if imageKey == photo.PreviewKey() || imageKey == photo.PublicKey {
+		thumbnailKey = photo.ThumbnailStorageKey()
+	}
+
+	response.ImageURL = s.presign(ctx, imageKey)
+	response.ThumbnailURL = s.presign(ctx, thumbnailKey)
+
+	return response
+}
+
+// presign generates a short-lived download URL for a storage key
+func (s *ShareLinkService) presign(ctx context.Context, key string) string {
+	url, err := s.storageService.GeneratePresignedDownloadURL(ctx, key, shareLinkImageURLExpiry)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Failed to generate shared image URL", zap.Error(err), zap.String("key", key))
+		return ""
+	}
+	return url
+}
+
+// generateShareToken generates an unguessable public token for a share link
+func generateShareToken() (string, error) {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// reconcileBatchSize is the number of files or photos reconciliation reads at a time
+	reconcileBatchSize = 500
+	// reconcileSampleSize is the number of orphaned keys listed in a reconciliation report
+	reconcileSampleSize = 50
+	// reconcileFolder is the storage prefix of photo files and their variants
+	reconcileFolder = "photos/"
+
+	reconcileTriggerSchedule = "schedule"
+	reconcileTriggerAdmin    = "admin"
+)
+
+// StorageIntegrityService implements domain.StorageIntegrityService
+type StorageIntegrityService struct {
+	photoRepo          domain.PhotoRepository
+	issueRepo          domain.StorageIntegrityRepository
+	reconciliationRepo domain.StorageReconciliationRepository
+	storageService     domain.StorageService
+	sampleSize         int
+	gracePeriod        time.Duration
+	scheduledDryRun    bool
+
+	mu sync.Mutex
+	// backfillAfter is the last photo the checksum backfill reached, so photos whose
+	// object is missing do not hold it up on every run
+	backfillAfter primitive.ObjectID
+
+	reconciling sync.Mutex
+}
+
+// NewStorageIntegrityService creates a new storage integrity service that verifies
+// sampleSize photos, and backfills the checksums of as many older ones, per run.
+// Reconciliation leaves files younger than gracePeriod alone, and scheduled
+// reconciliations only report orphans while scheduledDryRun is set.
+func NewStorageIntegrityService(
+	photoRepo domain.PhotoRepository,
+	issueRepo domain.StorageIntegrityRepository,
+	reconciliationRepo domain.StorageReconciliationRepository,
+	storageService domain.StorageService,
+	sampleSize int,
+	gracePeriod time.Duration,
+	scheduledDryRun bool,
+) domain.StorageIntegrityService {
+	return &StorageIntegrityService{
+		photoRepo:          photoRepo,
+		issueRepo:          issueRepo,
+		reconciliationRepo: reconciliationRepo,
+		storageService:     storageService,
+		sampleSize:         sampleSize,
+		gracePeriod:        gracePeriod,
+		scheduledDryRun:    scheduledDryRun,
+	}
+}
+
+// CheckSample records the checksums of photos uploaded before checksums existed, then
+// verifies a random sample of stored photos against theirs
+func (s *StorageIntegrityService) CheckSample(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.backfill(ctx); err != nil {
+		return err
+	}
+
+	photos, err := s.photoRepo.SampleWithChecksum(ctx, s.sampleSize)
+	if err != nil {
+		return err
+	}
+
+	var corrupt, missing int
+	for _, photo := range photos {
+		checksum, err := objectChecksum(ctx, s.storageService, photo.StorageKey())
+		switch {
+		case errors.Is(err, domain.ErrFileNotFound):
+			missing++
+			s.recordIssue(ctx, photo, domain.StorageIssueMissing, "")
+		case err != nil:
+			// Storage may be briefly unavailable; the photo is checked again in a later sample
+			logging.FromContext(ctx).Warn("Failed to verify photo checksum",
+				zap.Error(err),
+				zap.String("photo_id", photo.ID.Hex()))
+		case checksum != photo.Checksum:
+			corrupt++
+			s.recordIssue(ctx, photo, domain.StorageIssueCorrupt, checksum)
+		default:
+			if err := s.issueRepo.Resolve(ctx, photo.ID); err != nil {
+				logging.FromContext(ctx).Warn("Failed to resolve storage integrity issue", zap.Error(err))
+			}
+		}
+	}
+
+	logging.FromContext(ctx).Info("Storage integrity check completed",
+		zap.Int("checked", len(photos)),
+		zap.Int("corrupt", corrupt),
+		zap.Int("missing", missing))
+
+	return nil
+}
+
+// ListIssues retrieves open storage integrity issues, most recently detected first
+func (s *StorageIntegrityService) ListIssues(ctx context.Context, status string, cursor *domain.Cursor, limit int) (*domain.StorageIntegrityListResponse, error) {
+	if status != "" && status != domain.StorageIssueCorrupt && status != domain.StorageIssueMissing {
+		return nil, domain.ErrInvalidRequestError("Status must be corrupt or missing")
+	}
+
+	// Fetch one extra item to know whether another page exists
+	issues, err := s.issueRepo.List(ctx, status, cursor, limit+1)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to list storage integrity issues")
+	}
+
+	counts, err := s.issueRepo.CountByStatus(ctx)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to count storage integrity issues")
+	}
+
+	response := &domain.StorageIntegrityListResponse{
+		Issues: issues,
+		Counts: counts,
+		Limit:  limit,
+	}
+	if response.Issues == nil {
+		response.Issues = []*domain.StorageIntegrityIssue{}
+	}
+
+	if len(issues) > limit {
+		response.Issues = issues[:limit]
+		last := response.Issues[limit-1]
+		response.NextCursor = domain.NewCursor(last.DetectedAt, last.ID).Encode()
+	}
+
+	return response, nil
+}
+
+// Reconcile cross-references the stored photo files with the photo records now. It is
+// a dry run unless DryRun is false.
+func (s *StorageIntegrityService) Reconcile(ctx context.Context, req *domain.RunStorageReconciliationRequest) (*domain.StorageReconciliation, error) {
+	dryRun := req.DryRun == nil || *req.DryRun
+
+	if !s.reconciling.TryLock() {
+		return nil, domain.ErrOperationInProgressError("A storage reconciliation")
+	}
+	defer s.reconciling.Unlock()
+
+	return s.reconcile(ctx, dryRun, reconcileTriggerAdmin), nil
+}
+
+// ReconcileScheduled cross-references the stored photo files with the photo records.
+// It is run periodically by the scheduler.
+func (s *StorageIntegrityService) ReconcileScheduled(ctx context.Context) error {
+	if !s.reconciling.TryLock() {
+		logging.FromContext(ctx).Info("Skipping scheduled storage reconciliation, another one is in progress")
+		return nil
+	}
+	defer s.reconciling.Unlock()
+
+	report := s.reconcile(ctx, s.scheduledDryRun, reconcileTriggerSchedule)
+	if report.Error != "" {
+		return fmt.Errorf("storage reconciliation failed: %s", report.Error)
+	}
+	return nil
+}
+
+// ListReconciliations retrieves reconciliation reports, newest first
+func (s *StorageIntegrityService) ListReconciliations(ctx context.Context, cursor *domain.Cursor, limit int) (*domain.StorageReconciliationListResponse, error) {
+	// Fetch one extra item to know whether another page exists
+	reconciliations, err := s.reconciliationRepo.List(ctx, cursor, limit+1)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to list storage reconciliations")
+	}
+
+	response := &domain.StorageReconciliationListResponse{
+		Reconciliations: reconciliations,
+		Limit:           limit,
+	}
+	if response.Reconciliations == nil {
+		response.Reconciliations = []*domain.StorageReconciliation{}
+	}
+
+	if len(reconciliations) > limit {
+		response.Reconciliations = reconciliations[:limit]
+		last := response.Reconciliations[limit-1]
+		response.NextCursor = domain.NewCursor(last.CreatedAt, last.ID).Encode()
+	}
+
+	return response, nil
+}
+
+// reconcile finds orphaned files and missing objects and records its report. A failing
+// half does not stop the other.
+func (s *StorageIntegrityService) reconcile(ctx context.Context, dryRun bool, trigger string) *domain.StorageReconciliation {
+	report := &domain.StorageReconciliation{
+		DryRun:     dryRun,
+		Trigger:    trigger,
+		OrphanKeys: []string{},
+		StartedAt:  time.Now(),
+	}
+
+	var errs []string
+	if err := s.removeOrphans(ctx, report); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if err := s.findMissing(ctx, report); err != nil {
+		errs = append(errs, err.Error())
+	}
+	report.Error = strings.Join(errs, "; ")
+	report.FinishedAt = time.Now()
+
+	fields := []zap.Field{
+		zap.String("trigger", trigger),
+		zap.Bool("dry_run", dryRun),
+		zap.Int("files_scanned", report.FilesScanned),
+		zap.Int("orphans", report.Orphans),
+		zap.Int("orphans_deleted", report.OrphansDeleted),
+		zap.Int("photos_scanned", report.PhotosScanned),
+		zap.Int("missing", report.Missing),
+	}
+	if report.Error != "" {
+		logging.FromContext(ctx).Error("Storage reconciliation failed", append(fields, zap.String("error", report.Error))...)
+	} else {
+		logging.FromContext(ctx).Info("Storage reconciliation completed", fields...)
+	}
+
+	if err := s.reconciliationRepo.Create(ctx, report); err != nil {
+		logging.FromContext(ctx).Warn("Failed to record storage reconciliation", zap.Error(err))
+	}
+
+	return report
+}
+
+// removeOrphans walks the photo files and deletes, unless the run is a dry run, those
+// that no photo refers to. Files younger than the grace period may belong to an upload
+// whose photo is not created yet and are left alone.
+func (s *StorageIntegrityService) removeOrphans(ctx context.Context, report *domain.StorageReconciliation) error {
+	cutoff := report.StartedAt.Add(-s.gracePeriod)
+
+	var after string
+	for {
+		files, err := s.storageService.ListFiles(ctx, reconcileFolder, after, reconcileBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+		if len(files) == 0 {
+			return nil
+		}
+		after = files[len(files)-1].Key
+		report.FilesScanned += len(files)
+
+		for _, file := range files {
+			if file.UploadedAt.After(cutoff) {
+				continue
+			}
+
+			photo, err := s.photoRepo.GetByStorageKey(ctx, file.Key)
+			if err != nil {
+				return fmt.Errorf("failed to look up photo of %s: %w", file.Key, err)
+			}
+			if photo != nil {
+				continue
+			}
+
+			report.Orphans++
+			if len(report.OrphanKeys) < reconcileSampleSize {
+				report.OrphanKeys = append(report.OrphanKeys, file.Key)
+			}
+			if report.DryRun {
+				continue
+			}
+
+			if err := s.storageService.Delete(ctx, file.Key); err != nil && !errors.Is(err, domain.ErrFileNotFound) {
+				logging.FromContext(ctx).Warn("Failed to delete orphaned file", zap.Error(err), zap.String("key", file.Key))
+				continue
+			}
+			report.OrphansDeleted++
+		}
+
+		if len(files) < reconcileBatchSize {
+			return nil
+		}
+	}
+}
+
+// findMissing checks that the image of every photo, including photos in the trash, is
+// still stored and records a missing issue for those that are not
+func (s *StorageIntegrityService) findMissing(ctx context.Context, report *domain.StorageReconciliation) error {
+	var afterID primitive.ObjectID
+	for {
+		photos, err := s.photoRepo.ListAfter(ctx, afterID, reconcileBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list photos: %w", err)
+		}
+		if len(photos) == 0 {
+			return nil
+		}
+		afterID = photos[len(photos)-1].ID
+		report.PhotosScanned += len(photos)
+
+		for _, photo := range photos {
+			_, err := s.storageService.GetFileInfo(ctx, photo.StorageKey())
+			switch {
+			case errors.Is(err, domain.ErrFileNotFound):
+				report.Missing++
+				s.recordIssue(ctx, photo, domain.StorageIssueMissing, "")
+			case err != nil:
+				// Storage may be briefly unavailable; the photo is checked again next run
+				logging.FromContext(ctx).Warn("Failed to check photo file",
+					zap.Error(err),
+					zap.String("photo_id", photo.ID.Hex()))
+			}
+		}
+
+		if len(photos) < reconcileBatchSize {
+			return nil
+		}
+	}
+}
+
+// backfill records the checksums of the next batch of photos that have none. Their
+// objects are trusted as they are now; missing ones are reported.
+func (s *StorageIntegrityService) backfill(ctx context.Context) error {
+	photos, err := s.photoRepo.ListWithoutChecksum(ctx, s.backfillAfter, s.sampleSize)
+	if err != nil {
+		return err
+	}
+
+	if len(photos) < s.sampleSize {
+		// Start over next run, for photos whose objects have since been restored
+		s.backfillAfter = primitive.NilObjectID
+	} else {
+		s.backfillAfter = photos[len(photos)-1].ID
+	}
+
+	for _, photo := range photos {
+		checksum, err := objectChecksum(ctx, s.storageService, photo.StorageKey())
+		if errors.Is(err, domain.ErrFileNotFound) {
+			s.recordIssue(ctx, photo, domain.StorageIssueMissing, "")
+			continue
+		}
+		if err != nil {
+			logging.FromContext(ctx).Warn("Failed to compute photo checksum",
+				zap.Error(err),
+				zap.String("photo_id", photo.ID.Hex()))
+			continue
+		}
+
+		if err := s.photoRepo.SetChecksum(ctx, photo.ID, checksum); err != nil {
+			logging.FromContext(ctx).Warn("Failed to record photo checksum", zap.Error(err))
+			continue
+		}
+		if err := s.issueRepo.Resolve(ctx, photo.ID); err != nil {
+			logging.FromContext(ctx).Warn("Failed to resolve storage integrity issue", zap.Error(err))
+		}
+	}
+
+	return nil
+}
+
+// recordIssue stores a corrupt or missing object for remediation
+func (s *StorageIntegrityService) recordIssue(ctx context.Context, photo *domain.Photo, status, actualChecksum string) {
+	now := time.Now()
+
+	logging.FromContext(ctx).Warn("Stored photo failed integrity check",
+		zap.String("photo_id", photo.ID.Hex()),
+		zap.String("key", photo.StorageKey()),
+		zap.String("status", status))
+
+	issue := &domain.StorageIntegrityIssue{
+		PhotoID:          photo.ID,
+		MatchCode:        photo.MatchCode,
+		Key:              photo.StorageKey(),
+		Status:           status,
+		ExpectedChecksum: photo.Checksum,
+		ActualChecksum:   actualChecksum,
+		DetectedAt:       now,
+		CheckedAt:        now,
+	}
+	if err := s.issueRepo.Upsert(ctx, issue); err != nil {
+		logging.FromContext(ctx).Warn("Failed to record storage integrity issue", zap.Error(err))
+	}
+}
@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// maxObjectID sorts after every real ObjectID
+var maxObjectID = primitive.ObjectID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+
+// timelineEntry is a timeline item with the values it is sorted by
+type timelineEntry struct {
+	item *domain.TimelineItem
+	at   time.Time
+	key  string
+}
+
+// TimelineService implements domain.TimelineService
+type TimelineService struct {
+	photoRepo       domain.PhotoRepository
+	eventRepo       domain.EventRepository
+	albumRepo       domain.AlbumRepository
+	journalRepo     domain.JournalRepository
+	userRepo        domain.UserRepository
+	settingsService domain.CoupleSettingsService
+}
+
+// NewTimelineService creates a new timeline service
+func NewTimelineService(
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	albumRepo domain.AlbumRepository,
+	journalRepo domain.JournalRepository,
+	userRepo domain.UserRepository,
+	settingsService domain.CoupleSettingsService,
+) domain.TimelineService {
+	return &TimelineService{
+		photoRepo:       photoRepo,
+		albumRepo:       albumRepo,
+		eventRepo:       eventRepo,
+		journalRepo:     journalRepo,
+		userRepo:        userRepo,
+		settingsService: settingsService,
+	}
+}
+
+// GetTimeline merges the couple's photos, events, milestones and the journal entries
+// the user can see into one feed, newest first. Each source is read from the cursor position with one item more
+// than the page size, so the merged page is always complete.
+func (s *TimelineService) GetTimeline(ctx context.Context, userID primitive.ObjectID, query *domain.TimelineQuery) (*domain.TimelineResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	response := &domain.TimelineResponse{
+		Items:      []*domain.TimelineItem{},
+		Limit:      query.Limit,
+		Formatting: s.settingsService.FormattingHints(ctx, userID),
+	}
+
+	if user.MatchCode == "" {
+		return response, nil
+	}
+
+	if cursor := query.Cursor; cursor != nil && cursor.Type != domain.TimelineItemMilestone {
+		if _, err := primitive.ObjectIDFromHex(cursor.ID); err != nil {
+			return nil, domain.ErrInvalidRequestError("Invalid cursor")
+		}
+	}
+
+	types := query.Types
+	if len(types) == 0 {
+		types = domain.TimelineItemTypes
+	}
+
+	from := query.From.ToTimePtr()
+	var to *time.Time
+	if query.To != nil && !query.To.IsZero() {
+		end := query.To.AddDate(0, 0, 1)
+		to = &end
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		entries  []timelineEntry
+		firstErr error
+	)
+
+	for _, itemType := range types {
+		wg.Add(1)
+		go func(itemType domain.TimelineItemType) {
+			defer wg.Done()
+
+			found, err := s.listType(ctx, user, itemType, from, to, query.Cursor, query.Limit+1)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			entries = append(entries, found...)
+		}(itemType)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		logging.FromContext(ctx).Error("Failed to build timeline",
+			zap.String("user_id", userID.Hex()),
+			zap.Error(firstErr))
+		return nil, domain.ErrOperationFailedError("Failed to get timeline")
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].at.Equal(entries[j].at) {
+			return entries[i].at.After(entries[j].at)
+		}
+		return entries[i].key > entries[j].key
+	})
+
+	if len(entries) > query.Limit {
+		entries = entries[:query.Limit]
+		last := entries[len(entries)-1]
+		next := &domain.TimelineCursor{Date: last.at, Type: last.item.Type, ID: last.item.ID}
+		response.NextCursor = next.Encode()
+	}
+
+	for _, entry := range entries {
+		response.Items = append(response.Items, entry.item)
+	}
+
+	return response, nil
+}
+
+// listType reads up to limit timeline entries of one type after the cursor
+func (s *TimelineService) listType(
+	ctx context.Context,
+	user *domain.User,
+	itemType domain.TimelineItemType,
+	from, to *time.Time,
+	cursor *domain.TimelineCursor,
+	limit int,
+) ([]timelineEntry, error) {
+	switch itemType {
+	case domain.TimelineItemPhoto:
+		photos, err := s.photoRepo.ListByDate(ctx, user.MatchCode, user.ID, from, to, sourceCursor(cursor, itemType), limit)
+		if err != nil {
+			return nil, err
+		}
+
+		responses := make([]*domain.PhotoResponse, len(photos))
+		for i, photo := range photos {
+			responses[i] = photo.ToResponse()
+		}
+		hideDeletedAlbums(ctx, s.albumRepo, user.MatchCode, responses)
+
+		entries := make([]timelineEntry, len(photos))
+		for i, photo := range photos {
+			response := responses[i]
+			entries[i] = newTimelineEntry(&domain.TimelineItem{
+				Type:  domain.TimelineItemPhoto,
+				ID:    response.ID,
+				Date:  response.Date,
+				Title: photo.Title,
+				Photo: response,
+			}, photo.Date)
+		}
+		return entries, nil
+
+	case domain.TimelineItemEvent:
+		events, err := s.eventRepo.ListByDate(user.MatchCode, user.ID, from, to, sourceCursor(cursor, itemType), limit)
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]timelineEntry, len(events))
+		for i, event := range events {
+			response := event.ToResponse()
+			entries[i] = newTimelineEntry(&domain.TimelineItem{
+				Type:  domain.TimelineItemEvent,
+				ID:    response.ID,
+				Date:  response.Date,
+				Title: event.Title,
+				Event: response,
+			}, event.Date)
+		}
+		return entries, nil
+
+	case domain.TimelineItemJournal:
+		journal, err := s.journalRepo.ListByDate(ctx, user.MatchCode, user.ID, from, to, sourceCursor(cursor, itemType), limit)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		entries := make([]timelineEntry, len(journal))
+		for i, entry := range journal {
+			response := entry.ToResponse(user.ID, now)
+			entries[i] = newTimelineEntry(&domain.TimelineItem{
+				Type:    domain.TimelineItemJournal,
+				ID:      response.ID,
+				Date:    response.Date,
+				Title:   entry.Title,
+				Journal: response,
+			}, entry.Date)
+		}
+		return entries, nil
+
+	case domain.TimelineItemMilestone:
+		var entries []timelineEntry
+		for _, entry := range milestoneEntries(user, time.Now()) {
+			if from != nil && entry.at.Before(*from) {
+				continue
+			}
+			if to != nil && !entry.at.Before(*to) {
+				continue
+			}
+			if cursor != nil && !isAfterCursor(entry, cursor) {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		return entries, nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// milestoneEntries lists the milestones the couple has reached: the day they
+// matched, the day they got together with every anniversary since, and the
+// day-count milestones also shown in the insights.
+func milestoneEntries(user *domain.User, now time.Time) []timelineEntry {
+	today := truncateToDay(now)
+	var entries []timelineEntry
+
+	add := func(id string, date time.Time, kind domain.MilestoneKind, value int) {
+		date = truncateToDay(date)
+		if date.After(today) {
+			return
+		}
+		entries = append(entries, newTimelineEntry(&domain.TimelineItem{
+			Type:      domain.TimelineItemMilestone,
+			ID:        id,
+			Date:      domain.NewDate(date),
+			Milestone: &domain.TimelineMilestone{Kind: kind, Value: value},
+		}, date))
+	}
+
+	if user.MatchedAt != nil {
+		add("matched", *user.MatchedAt, domain.MilestoneMatched, 0)
+	}
+
+	if user.AnniversaryDate != nil {
+		start := truncateToDay(*user.AnniversaryDate)
+		for years := 0; !start.AddDate(years, 0, 0).After(today); years++ {
+			add("anniversary-"+strconv.Itoa(years), start.AddDate(years, 0, 0), domain.MilestoneAnniversary, years)
+		}
+		for _, days := range dayMilestones {
+			add("days-"+strconv.Itoa(days), start.AddDate(0, 0, days), domain.MilestoneDays, days)
+		}
+	}
+
+	return entries
+}
+
+// newTimelineEntry wraps an item with its sort values
+func newTimelineEntry(item *domain.TimelineItem, at time.Time) timelineEntry {
+	return timelineEntry{
+		item: item,
+		at:   at.UTC(),
+		key:  domain.TimelineSortKey(item.Type, item.ID),
+	}
+}
+
+// isAfterCursor reports whether entry sorts after the cursor position
+func isAfterCursor(entry timelineEntry, cursor *domain.TimelineCursor) bool {
+	if !entry.at.Equal(cursor.Date) {
+		return entry.at.Before(cursor.Date)
+	}
+	return entry.key < cursor.Key()
+}
+
+// sourceCursor translates the timeline cursor into the repository cursor of one
+// item type. Items on the cursor date are ordered by type first, so a type that
+// sorts before the cursor's type still has all of that date ahead of it and a type
+// that sorts after it has none.
+func sourceCursor(cursor *domain.TimelineCursor, itemType domain.TimelineItemType) *domain.Cursor {
+	if cursor == nil {
+		return nil
+	}
+
+	switch {
+	case itemType == cursor.Type:
+		id, _ := primitive.ObjectIDFromHex(cursor.ID)
+		return domain.NewCursor(cursor.Date, id)
+	case itemType < cursor.Type:
+		return domain.NewCursor(cursor.Date, maxObjectID)
+	default:
+		return domain.NewCursor(cursor.Date, primitive.NilObjectID)
+	}
+}
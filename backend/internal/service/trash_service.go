@@ -0,0 +1,459 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"sort"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+const (
+	// trashMaxItemsPerType caps how many deleted items of each type the overview lists
+	trashMaxItemsPerType = 500
+	// trashPurgeBatchSize is how many expired photos are purged per batch
+	trashPurgeBatchSize = 100
+)
+
+// TrashService implements domain.TrashService
+type TrashService struct {
+	photoRepo      domain.PhotoRepository
+	eventRepo      domain.EventRepository
+	messageRepo    domain.MessageRepository
+	journalRepo    domain.JournalRepository
+	userRepo       domain.UserRepository
+	storageService domain.StorageService
+}
+
+// NewTrashService creates a new trash service
+func NewTrashService(
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	messageRepo domain.MessageRepository,
+	journalRepo domain.JournalRepository,
+	userRepo domain.UserRepository,
+	storageService domain.StorageService,
+) domain.TrashService {
+	return &TrashService{
+		photoRepo:      photoRepo,
+		eventRepo:      eventRepo,
+		messageRepo:    messageRepo,
+		journalRepo:    journalRepo,
+		userRepo:       userRepo,
+		storageService: storageService,
+	}
+}
+
+// GetTrash lists the couple's soft-deleted photos and events and the messages and
+// journal entries the user deleted, most recently deleted first
+func (s *TrashService) GetTrash(ctx context.Context, userID primitive.ObjectID) (*domain.TrashResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	response := newTrashResponse()
+
+	if user.MatchCode == "" {
+		return response, nil
+	}
+
+	deleted, err := s.listDeleted(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	response.Items = append(response.Items, photoTrashItems(deleted.photos, now)...)
+	response.Items = append(response.Items, eventTrashItems(deleted.events, now)...)
+	for _, message := range deleted.messages {
+		if message.DeletedAt == nil {
+			continue
+		}
+		response.Items = append(response.Items, domain.NewTrashItem(domain.TrashItemMessage, message.ID, snippet(message.Content), *message.DeletedAt, now))
+	}
+	response.Items = append(response.Items, journalTrashItems(deleted.journals, now)...)
+
+	sort.SliceStable(response.Items, func(i, j int) bool {
+		return response.Items[i].DeletedAt.After(response.Items[j].DeletedAt)
+	})
+	response.Total = len(response.Items)
+
+	return response, nil
+}
+
+// Restore restores the requested items. Items that are not in the couple's trash are
+// reported as failed rather than failing the whole request.
+func (s *TrashService) Restore(ctx context.Context, userID primitive.ObjectID, req *domain.RestoreTrashRequest) (*domain.RestoreTrashResponse, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := s.listDeleted(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	inTrash := make(map[domain.TrashItemRef]bool, len(deleted.photos)+len(deleted.events)+len(deleted.messages)+len(deleted.journals))
+	for _, photo := range deleted.photos {
+		inTrash[domain.TrashItemRef{Type: domain.TrashItemPhoto, ID: photo.ID.Hex()}] = true
+	}
+	for _, event := range deleted.events {
+		inTrash[domain.TrashItemRef{Type: domain.TrashItemEvent, ID: event.ID.Hex()}] = true
+	}
+	for _, message := range deleted.messages {
+		inTrash[domain.TrashItemRef{Type: domain.TrashItemMessage, ID: message.ID.Hex()}] = true
+	}
+	for _, entry := range deleted.journals {
+		inTrash[domain.TrashItemRef{Type: domain.TrashItemJournal, ID: entry.ID.Hex()}] = true
+	}
+
+	response := &domain.RestoreTrashResponse{Failed: []domain.TrashItemRef{}}
+	for _, ref := range req.Items {
+		if !inTrash[ref] {
+			response.Failed = append(response.Failed, ref)
+			continue
+		}
+
+		id, _ := primitive.ObjectIDFromHex(ref.ID)
+		switch ref.Type {
+		case domain.TrashItemPhoto:
+			err = s.photoRepo.Restore(ctx, id)
+		case domain.TrashItemEvent:
+			err = s.eventRepo.Restore(id)
+		case domain.TrashItemMessage:
+			err = s.messageRepo.Restore(ctx, id, userID)
+		case domain.TrashItemJournal:
+			err = s.journalRepo.Restore(ctx, id)
+		}
+
+		if err != nil {
+			logging.FromContext(ctx).Warn("Failed to restore trash item",
+				zap.String("type", string(ref.Type)),
+				zap.String("id", ref.ID),
+				zap.Error(err))
+			response.Failed = append(response.Failed, ref)
+			continue
+		}
+
+		response.Restored++
+	}
+
+	logging.FromContext(ctx).Info("Trash items restored",
+		zap.String("user_id", userID.Hex()),
+		zap.Int("restored", response.Restored),
+		zap.Int("failed", len(response.Failed)))
+
+	return response, nil
+}
+
+// GetTrashOfType lists the couple's soft-deleted photos or events, or the journal
+// entries the user deleted, most recently deleted first
+func (s *TrashService) GetTrashOfType(ctx context.Context, userID primitive.ObjectID, itemType domain.TrashItemType) (*domain.TrashResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	response := newTrashResponse()
+
+	if user.MatchCode == "" {
+		return response, nil
+	}
+
+	now := time.Now()
+	switch itemType {
+	case domain.TrashItemPhoto:
+		photos, err := s.photoRepo.ListDeleted(ctx, user.MatchCode, user.ID, trashMaxItemsPerType, 0)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to list deleted photos", zap.Error(err))
+			return nil, domain.ErrOperationFailedError("Failed to get trash")
+		}
+		response.Items = photoTrashItems(photos, now)
+	case domain.TrashItemEvent:
+		events, err := s.eventRepo.ListDeleted(user.MatchCode, user.ID, trashMaxItemsPerType, 0)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to list deleted events", zap.Error(err))
+			return nil, domain.ErrOperationFailedError("Failed to get trash")
+		}
+		response.Items = eventTrashItems(events, now)
+	case domain.TrashItemJournal:
+		entries, err := s.journalRepo.ListDeleted(ctx, user.MatchCode, user.ID, trashMaxItemsPerType)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to list deleted journal entries", zap.Error(err))
+			return nil, domain.ErrOperationFailedError("Failed to get trash")
+		}
+		response.Items = journalTrashItems(entries, now)
+	default:
+		return nil, domain.ErrInvalidRequestError("Unsupported trash item type")
+	}
+	response.Total = len(response.Items)
+
+	return response, nil
+}
+
+// RestoreItem restores one of the couple's photos or events, or one of the user's
+// journal entries, from the trash
+func (s *TrashService) RestoreItem(ctx context.Context, userID primitive.ObjectID, itemType domain.TrashItemType, id primitive.ObjectID) error {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	switch itemType {
+	case domain.TrashItemPhoto:
+		if photo, err := s.photoRepo.GetDeleted(ctx, user.MatchCode, id); err != nil || !photo.IsVisibleTo(userID) {
+			return domain.ErrNotFoundError("Photo")
+		}
+		err = s.photoRepo.Restore(ctx, id)
+	case domain.TrashItemEvent:
+		if event, err := s.eventRepo.GetDeleted(user.MatchCode, id); err != nil || !event.IsVisibleTo(userID) {
+			return domain.ErrNotFoundError("Event")
+		}
+		err = s.eventRepo.Restore(id)
+	case domain.TrashItemJournal:
+		if entry, err := s.journalRepo.GetDeleted(ctx, user.MatchCode, id); err != nil || entry.AuthorID != userID {
+			return domain.ErrNotFoundError("Journal entry")
+		}
+		err = s.journalRepo.Restore(ctx, id)
+	default:
+		return domain.ErrInvalidRequestError("Unsupported trash item type")
+	}
+
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to restore trash item",
+			zap.String("type", string(itemType)),
+			zap.String("id", id.Hex()),
+			zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to restore item")
+	}
+
+	logging.FromContext(ctx).Info("Trash item restored",
+		zap.String("type", string(itemType)),
+		zap.String("id", id.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return nil
+}
+
+// PurgeItem permanently deletes one of the couple's photos or events, or one of the
+// user's journal entries, from the trash without waiting for the retention period,
+// including the stored photo files
+func (s *TrashService) PurgeItem(ctx context.Context, userID primitive.ObjectID, itemType domain.TrashItemType, id primitive.ObjectID) error {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	switch itemType {
+	case domain.TrashItemPhoto:
+		photo, getErr := s.photoRepo.GetDeleted(ctx, user.MatchCode, id)
+		if getErr != nil || !photo.IsVisibleTo(userID) {
+			return domain.ErrNotFoundError("Photo")
+		}
+		s.deletePhotoFiles(ctx, photo)
+		err = s.photoRepo.HardDelete(ctx, id)
+	case domain.TrashItemEvent:
+		if event, getErr := s.eventRepo.GetDeleted(user.MatchCode, id); getErr != nil || !event.IsVisibleTo(userID) {
+			return domain.ErrNotFoundError("Event")
+		}
+		err = s.eventRepo.HardDelete(id)
+	case domain.TrashItemJournal:
+		if entry, getErr := s.journalRepo.GetDeleted(ctx, user.MatchCode, id); getErr != nil || entry.AuthorID != userID {
+			return domain.ErrNotFoundError("Journal entry")
+		}
+		err = s.journalRepo.HardDelete(ctx, id)
+	default:
+		return domain.ErrInvalidRequestError("Unsupported trash item type")
+	}
+
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to purge trash item",
+			zap.String("type", string(itemType)),
+			zap.String("id", id.Hex()),
+			zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to purge item")
+	}
+
+	logging.FromContext(ctx).Info("Trash item purged",
+		zap.String("type", string(itemType)),
+		zap.String("id", id.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return nil
+}
+
+// PurgeExpired permanently deletes content that has been in the trash longer than the
+// retention period, including the stored photo files. It is run periodically by the scheduler.
+func (s *TrashService) PurgeExpired(ctx context.Context) error {
+	cutoff := time.Now().Add(-domain.TrashRetention)
+
+	purgedPhotos := 0
+	for {
+		photos, err := s.photoRepo.ListDeletedBefore(ctx, cutoff, trashPurgeBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list expired photos: %w", err)
+		}
+
+		for _, photo := range photos {
+			s.deletePhotoFiles(ctx, photo)
+			if err := s.photoRepo.HardDelete(ctx, photo.ID); err != nil {
+				return fmt.Errorf("failed to purge photo %s: %w", photo.ID.Hex(), err)
+			}
+			purgedPhotos++
+		}
+
+		if len(photos) < trashPurgeBatchSize {
+			break
+		}
+	}
+
+	purgedEvents, err := s.eventRepo.PurgeDeletedBefore(cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to purge expired events: %w", err)
+	}
+
+	purgedMessages, err := s.messageRepo.PurgeDeletedBefore(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to purge expired messages: %w", err)
+	}
+
+	purgedJournals, err := s.journalRepo.PurgeDeletedBefore(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to purge expired journal entries: %w", err)
+	}
+
+	if purgedPhotos > 0 || purgedEvents > 0 || purgedMessages > 0 || purgedJournals > 0 {
+		logging.FromContext(ctx).Info("Purged expired trash",
+			zap.Int("photos", purgedPhotos),
+			zap.Int64("events", purgedEvents),
+			zap.Int64("messages", purgedMessages),
+			zap.Int64("journal_entries", purgedJournals))
+	}
+
+	return nil
+}
+
+// getMatchedUser retrieves a user who has a partner
+func (s *TrashService) getMatchedUser(ctx context.Context, userID primitive.ObjectID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	return user, nil
+}
+
+// newTrashResponse creates an empty trash overview
+func newTrashResponse() *domain.TrashResponse {
+	return &domain.TrashResponse{
+		Items:         []*domain.TrashItem{},
+		RetentionDays: int(domain.TrashRetention.Hours() / 24),
+	}
+}
+
+// photoTrashItems converts deleted photos to trash items
+func photoTrashItems(photos []*domain.Photo, now time.Time) []*domain.TrashItem {
+	items := make([]*domain.TrashItem, 0, len(photos))
+	for _, photo := range photos {
+		if photo.DeletedAt == nil {
+			continue
+		}
+		item := domain.NewTrashItem(domain.TrashItemPhoto, photo.ID, photo.Title, *photo.DeletedAt, now)
+		item.ThumbnailURL = photo.ToResponse().ThumbnailURL
+		items = append(items, item)
+	}
+	return items
+}
+
+// eventTrashItems converts deleted events to trash items
+func eventTrashItems(events []*domain.Event, now time.Time) []*domain.TrashItem {
+	items := make([]*domain.TrashItem, 0, len(events))
+	for _, event := range events {
+		if event.DeletedAt == nil {
+			continue
+		}
+		items = append(items, domain.NewTrashItem(domain.TrashItemEvent, event.ID, event.Title, *event.DeletedAt, now))
+	}
+	return items
+}
+
+// journalTrashItems converts deleted journal entries to trash items
+func journalTrashItems(entries []*domain.JournalEntry, now time.Time) []*domain.TrashItem {
+	items := make([]*domain.TrashItem, 0, len(entries))
+	for _, entry := range entries {
+		if entry.DeletedAt == nil {
+			continue
+		}
+		items = append(items, domain.NewTrashItem(domain.TrashItemJournal, entry.ID, entry.Title, *entry.DeletedAt, now))
+	}
+	return items
+}
+
+// deletedItems is the content of a user's trash
+type deletedItems struct {
+	photos   []*domain.Photo
+	events   []*domain.Event
+	messages []*domain.Message
+	journals []*domain.JournalEntry
+}
+
+// listDeleted retrieves the couple's deleted photos and events and the messages and
+// journal entries the user deleted
+func (s *TrashService) listDeleted(ctx context.Context, user *domain.User) (*deletedItems, error) {
+	photos, err := s.photoRepo.ListDeleted(ctx, user.MatchCode, user.ID, trashMaxItemsPerType, 0)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to list deleted photos", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get trash")
+	}
+
+	events, err := s.eventRepo.ListDeleted(user.MatchCode, user.ID, trashMaxItemsPerType, 0)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to list deleted events", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get trash")
+	}
+
+	messages, err := s.messageRepo.ListDeleted(ctx, user.ID, trashMaxItemsPerType)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to list deleted messages", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get trash")
+	}
+
+	journals, err := s.journalRepo.ListDeleted(ctx, user.MatchCode, user.ID, trashMaxItemsPerType)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to list deleted journal entries", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get trash")
+	}
+
+	return &deletedItems{photos: photos, events: events, messages: messages, journals: journals}, nil
+}
+
+// deletePhotoFiles removes the original, poster and variant files of a purged photo.
+// Failures are logged only; an orphaned file must not keep the record around.
+func (s *TrashService) deletePhotoFiles(ctx context.Context, photo *domain.Photo) {
+	original := photo.StorageKey()
+	keys := []string{original}
+	for _, key := range []string{photo.ThumbnailKey, photo.MediumKey, photo.PublicKey, photo.PosterKey} {
+		if key != "" && key != original {
+			keys = append(keys, key)
+		}
+	}
+
+	for _, key := range keys {
+		if err := s.storageService.Delete(ctx, key); err != nil {
+			logging.FromContext(ctx).Warn("Failed to delete purged photo file",
+				zap.String("photo_id", photo.ID.Hex()),
+				zap.String("key", key),
+				zap.Error(err))
+		}
+	}
+}
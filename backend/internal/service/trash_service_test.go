@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// The fakes below implement the repository methods the trash and search services
+// call. Calling any other method panics.
+
+type fakeUserRepository struct {
+	domain.UserRepository
+	users map[primitive.ObjectID]*domain.User
+}
+
+func (r *fakeUserRepository) GetByID(ctx context.Context, id primitive.ObjectID) (*domain.User, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, errors.New("user not found")
+}
+
+type fakePhotoRepository struct {
+	domain.PhotoRepository
+}
+
+func (r *fakePhotoRepository) ListDeleted(ctx context.Context, matchCode string, viewerID primitive.ObjectID, limit, offset int) ([]*domain.Photo, error) {
+	return nil, nil
+}
+
+func (r *fakePhotoRepository) ListDeletedBefore(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Photo, error) {
+	return nil, nil
+}
+
+func (r *fakePhotoRepository) SearchByMatchCode(ctx context.Context, matchCode string, viewerID primitive.ObjectID, query string, limit, offset int) ([]*domain.Photo, error) {
+	return nil, nil
+}
+
+type fakeEventRepository struct {
+	domain.EventRepository
+}
+
+func (r *fakeEventRepository) ListDeleted(matchCode string, viewerID primitive.ObjectID, limit, offset int) ([]*domain.Event, error) {
+	return nil, nil
+}
+
+func (r *fakeEventRepository) PurgeDeletedBefore(cutoff time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeEventRepository) SearchByMatchCode(matchCode string, viewerID primitive.ObjectID, query string, limit int) ([]*domain.Event, error) {
+	return nil, nil
+}
+
+type fakeMessageRepository struct {
+	domain.MessageRepository
+}
+
+func (r *fakeMessageRepository) ListDeleted(ctx context.Context, senderID primitive.ObjectID, limit int) ([]*domain.Message, error) {
+	return nil, nil
+}
+
+func (r *fakeMessageRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeMessageRepository) Search(ctx context.Context, userID, partnerID primitive.ObjectID, query string, cursor *domain.Cursor, limit int) ([]*domain.Message, error) {
+	return nil, nil
+}
+
+// fakeJournalRepository keeps journal entries in memory
+type fakeJournalRepository struct {
+	domain.JournalRepository
+	entries      map[primitive.ObjectID]*domain.JournalEntry
+	purgedBefore time.Time
+}
+
+func (r *fakeJournalRepository) Restore(ctx context.Context, id primitive.ObjectID) error {
+	entry, ok := r.entries[id]
+	if !ok || entry.DeletedAt == nil {
+		return errors.New("journal entry not found or not deleted")
+	}
+	entry.DeletedAt = nil
+	return nil
+}
+
+func (r *fakeJournalRepository) HardDelete(ctx context.Context, id primitive.ObjectID) error {
+	if _, ok := r.entries[id]; !ok {
+		return errors.New("journal entry not found")
+	}
+	delete(r.entries, id)
+	return nil
+}
+
+func (r *fakeJournalRepository) GetDeleted(ctx context.Context, matchCode string, id primitive.ObjectID) (*domain.JournalEntry, error) {
+	entry, ok := r.entries[id]
+	if !ok || entry.DeletedAt == nil || entry.MatchCode != matchCode {
+		return nil, errors.New("journal entry not found or not deleted")
+	}
+	return entry, nil
+}
+
+func (r *fakeJournalRepository) ListDeleted(ctx context.Context, matchCode string, authorID primitive.ObjectID, limit int) ([]*domain.JournalEntry, error) {
+	var entries []*domain.JournalEntry
+	for _, entry := range r.entries {
+		if entry.DeletedAt != nil && entry.MatchCode == matchCode && entry.AuthorID == authorID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (r *fakeJournalRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	r.purgedBefore = cutoff
+	return 0, nil
+}
+
+func (r *fakeJournalRepository) Search(ctx context.Context, matchCode string, viewerID primitive.ObjectID, query string, now time.Time, limit int) ([]*domain.JournalEntry, error) {
+	var entries []*domain.JournalEntry
+	for _, entry := range r.entries {
+		if entry.DeletedAt == nil && entry.MatchCode == matchCode && entry.IsVisibleTo(viewerID) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// trashFixture is a couple with a deleted journal entry of each partner
+type trashFixture struct {
+	service       domain.TrashService
+	journal       *fakeJournalRepository
+	user, partner *domain.User
+	own, partners *domain.JournalEntry
+}
+
+func newTrashFixture() *trashFixture {
+	deletedAt := time.Now().Add(-time.Hour)
+	user := &domain.User{ID: primitive.NewObjectID(), MatchCode: "ABC123"}
+	partner := &domain.User{ID: primitive.NewObjectID(), MatchCode: "ABC123"}
+	own := &domain.JournalEntry{ID: primitive.NewObjectID(), MatchCode: "ABC123", AuthorID: user.ID, Title: "Mine", DeletedAt: &deletedAt}
+	partners := &domain.JournalEntry{ID: primitive.NewObjectID(), MatchCode: "ABC123", AuthorID: partner.ID, Title: "Theirs", DeletedAt: &deletedAt}
+
+	journal := &fakeJournalRepository{entries: map[primitive.ObjectID]*domain.JournalEntry{own.ID: own, partners.ID: partners}}
+	users := &fakeUserRepository{users: map[primitive.ObjectID]*domain.User{user.ID: user, partner.ID: partner}}
+
+	return &trashFixture{
+		service:  NewTrashService(&fakePhotoRepository{}, &fakeEventRepository{}, &fakeMessageRepository{}, journal, users, nil),
+		journal:  journal,
+		user:     user,
+		partner:  partner,
+		own:      own,
+		partners: partners,
+	}
+}
+
+func TestGetTrashListsOwnJournalEntries(t *testing.T) {
+	f := newTrashFixture()
+
+	trash, err := f.service.GetTrash(context.Background(), f.user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if trash.Total != 1 {
+		t.Fatalf("total = %d, want 1", trash.Total)
+	}
+	item := trash.Items[0]
+	if item.Type != domain.TrashItemJournal || item.ID != f.own.ID.Hex() {
+		t.Errorf("item = %s %s, want %s %s", item.Type, item.ID, domain.TrashItemJournal, f.own.ID.Hex())
+	}
+}
+
+func TestRestoreJournalEntry(t *testing.T) {
+	f := newTrashFixture()
+
+	result, err := f.service.Restore(context.Background(), f.user.ID, &domain.RestoreTrashRequest{Items: []domain.TrashItemRef{
+		{Type: domain.TrashItemJournal, ID: f.own.ID.Hex()},
+		{Type: domain.TrashItemJournal, ID: f.partners.ID.Hex()},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Restored != 1 || len(result.Failed) != 1 {
+		t.Errorf("restored = %d, failed = %d, want 1 and 1", result.Restored, len(result.Failed))
+	}
+	if f.own.DeletedAt != nil {
+		t.Error("own entry is still deleted")
+	}
+	if f.partners.DeletedAt == nil {
+		t.Error("partner's entry was restored")
+	}
+}
+
+func TestRestoreItemJournalEntryOfPartner(t *testing.T) {
+	f := newTrashFixture()
+
+	err := f.service.RestoreItem(context.Background(), f.user.ID, domain.TrashItemJournal, f.partners.ID)
+
+	var appErr *domain.AppError
+	if !errors.As(err, &appErr) || appErr.StatusCode != 404 {
+		t.Errorf("err = %v, want a 404 error", err)
+	}
+	if f.partners.DeletedAt == nil {
+		t.Error("partner's entry was restored")
+	}
+}
+
+func TestPurgeItemJournalEntry(t *testing.T) {
+	f := newTrashFixture()
+
+	if err := f.service.PurgeItem(context.Background(), f.user.ID, domain.TrashItemJournal, f.own.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.journal.entries[f.own.ID]; ok {
+		t.Error("entry was not purged")
+	}
+}
+
+func TestPurgeExpiredPurgesJournalEntries(t *testing.T) {
+	f := newTrashFixture()
+
+	if err := f.service.PurgeExpired(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := time.Now().Add(-domain.TrashRetention)
+	if d := want.Sub(f.journal.purgedBefore); d < 0 || d > time.Minute {
+		t.Errorf("journal entries purged before %v, want %v", f.journal.purgedBefore, want)
+	}
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.uber.org/zap"
+)
+
+// UploadScanService implements domain.UploadScanService with a content scanner
+type UploadScanService struct {
+	scanner          domain.FileScanner
+	storageService   domain.StorageService
+	timeout          time.Duration
+	quarantineFolder string
+}
+
+// NewUploadScanService creates a new upload scan service. Every file passes when
+// scanner is nil. Rejected files are moved under quarantineFolder, or deleted when it
+// is empty.
+func NewUploadScanService(
+	scanner domain.FileScanner,
+	storageService domain.StorageService,
+	timeout time.Duration,
+	quarantineFolder string,
+) domain.UploadScanService {
+	return &UploadScanService{
+		scanner:          scanner,
+		storageService:   storageService,
+		timeout:          timeout,
+		quarantineFolder: quarantineFolder,
+	}
+}
+
+// ScanUpload scans a stored file
+func (s *UploadScanService) ScanUpload(ctx context.Context, file *domain.FileInfo) error {
+	if s.scanner == nil {
+		return nil
+	}
+
+	result, err := s.scan(ctx, file.Key)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to scan upload", zap.Error(err), zap.String("key", file.Key))
+		s.delete(ctx, file.Key)
+		return domain.ErrFileUploadFailedError("the file could not be scanned")
+	}
+
+	if result.Clean {
+		return nil
+	}
+
+	logging.FromContext(ctx).Warn("Upload rejected by content scan",
+		zap.String("key", file.Key),
+		zap.String("threat", result.Threat))
+	s.quarantine(ctx, file)
+	return domain.ErrFileRejectedError()
+}
+
+// scan reads the stored file through the scanner within the timeout
+func (s *UploadScanService) scan(ctx context.Context, key string) (*domain.ScanResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	reader, err := s.storageService.GetObject(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return s.scanner.Scan(ctx, reader)
+}
+
+// quarantine moves a rejected file out of reach of the app, or deletes it when there
+// is no quarantine folder. The file is deleted if it cannot be moved.
+func (s *UploadScanService) quarantine(ctx context.Context, file *domain.FileInfo) {
+	if s.quarantineFolder != "" {
+		if err := s.copy(ctx, file, s.quarantineFolder+"/"+file.Key); err != nil {
+			logging.FromContext(ctx).Error("Failed to quarantine upload", zap.Error(err), zap.String("key", file.Key))
+		}
+	}
+	s.delete(ctx, file.Key)
+}
+
+// copy stores the content of a file under another key
+func (s *UploadScanService) copy(ctx context.Context, file *domain.FileInfo, key string) error {
+	reader, err := s.storageService.GetObject(ctx, file.Key)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = s.storageService.PutObject(ctx, key, reader, file.Size, file.ContentType)
+	return err
+}
+
+// delete removes a file that must not be used
+func (s *UploadScanService) delete(ctx context.Context, key string) {
+	if err := s.storageService.Delete(ctx, key); err != nil {
+		logging.FromContext(ctx).Error("Failed to delete upload", zap.Error(err), zap.String("key", key))
+	}
+}
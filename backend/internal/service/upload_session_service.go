@@ -0,0 +1,435 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// UploadSessionService implements domain.UploadSessionService on the multipart uploads
+// and presigned URLs of the storage
+type UploadSessionService struct {
+	sessionRepo    domain.UploadSessionRepository
+	storageService domain.StorageService
+	scanService    domain.UploadScanService
+	chunkSize      int64
+	sessionTTL     time.Duration
+	presignExpiry  time.Duration
+	maxSizes       domain.UploadSizeLimits
+}
+
+// NewUploadSessionService creates a new upload service. Resumable uploads are sent in
+// chunks of chunkSize bytes, presigned ones within presignExpiry, and both must be
+// completed within sessionTTL.
+func NewUploadSessionService(
+	sessionRepo domain.UploadSessionRepository,
+	storageService domain.StorageService,
+	scanService domain.UploadScanService,
+	chunkSize int64,
+	sessionTTL time.Duration,
+	presignExpiry time.Duration,
+	maxSizes domain.UploadSizeLimits,
+) domain.UploadSessionService {
+	return &UploadSessionService{
+		sessionRepo:    sessionRepo,
+		storageService: storageService,
+		scanService:    scanService,
+		chunkSize:      chunkSize,
+		sessionTTL:     sessionTTL,
+		presignExpiry:  presignExpiry,
+		maxSizes:       maxSizes,
+	}
+}
+
+// Init starts a resumable upload of a file of the requested type and size
+func (s *UploadSessionService) Init(ctx context.Context, userID primitive.ObjectID, req *domain.InitUploadRequest) (*domain.UploadSessionResponse, error) {
+	session, err := s.newSession(userID, req, false)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadID, err := s.storageService.CreateMultipartUpload(ctx, session.Key, session.ContentType)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to start multipart upload", zap.Error(err), zap.String("key", session.Key))
+		return nil, domain.ErrFileUploadFailedError("could not start the upload")
+	}
+	session.StorageUploadID = uploadID
+
+	if err := s.sessionRepo.Create(ctx, session, s.sessionTTL); err != nil {
+		logging.FromContext(ctx).Error("Failed to store upload session", zap.Error(err), zap.String("session_id", session.ID))
+		s.abortStorageUpload(ctx, session)
+		return nil, domain.ErrOperationFailedError("Failed to start upload")
+	}
+
+	logging.FromContext(ctx).Info("Resumable upload started",
+		zap.String("session_id", session.ID),
+		zap.String("user_id", userID.Hex()),
+		zap.String("key", session.Key),
+		zap.Int64("size", session.Size))
+
+	return uploadSessionResponse(session, nil), nil
+}
+
+// Presign starts an upload of a file of the requested type and size that the client
+// sends straight to the storage, so that it does not go through the API
+func (s *UploadSessionService) Presign(ctx context.Context, userID primitive.ObjectID, req *domain.InitUploadRequest) (*domain.PresignedUploadResponse, error) {
+	session, err := s.newSession(userID, req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	uploadURL, err := s.storageService.GeneratePresignedUploadURL(ctx, session.Key, session.ContentType, s.presignExpiry)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to presign upload", zap.Error(err), zap.String("key", session.Key))
+		return nil, domain.ErrFileUploadFailedError("could not start the upload")
+	}
+
+	if err := s.sessionRepo.Create(ctx, session, s.sessionTTL); err != nil {
+		logging.FromContext(ctx).Error("Failed to store upload session", zap.Error(err), zap.String("session_id", session.ID))
+		return nil, domain.ErrOperationFailedError("Failed to start upload")
+	}
+
+	logging.FromContext(ctx).Info("Presigned upload started",
+		zap.String("session_id", session.ID),
+		zap.String("user_id", userID.Hex()),
+		zap.String("key", session.Key),
+		zap.Int64("size", session.Size))
+
+	return &domain.PresignedUploadResponse{
+		ID:          session.ID,
+		Key:         session.Key,
+		UploadURL:   uploadURL,
+		Method:      "PUT",
+		ContentType: session.ContentType,
+		Size:        session.Size,
+		ExpiresAt:   session.CreatedAt.Add(s.presignExpiry),
+	}, nil
+}
+
+// ConfirmPresigned checks that the file of a presigned upload was stored as it was
+// declared and registers it. A file that does not match is deleted.
+func (s *UploadSessionService) ConfirmPresigned(ctx context.Context, userID primitive.ObjectID, sessionID string) (*domain.FileInfo, error) {
+	session, err := s.getSession(ctx, userID, sessionID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	fileInfo, err := s.storageService.GetFileInfo(ctx, session.Key)
+	if err != nil {
+		if errors.Is(err, domain.ErrFileNotFound) {
+			return nil, domain.ErrInvalidRequestError("The file has not been uploaded")
+		}
+		logging.FromContext(ctx).Error("Failed to get uploaded file", zap.Error(err), zap.String("session_id", session.ID))
+		return nil, domain.ErrOperationFailedError("Failed to confirm upload")
+	}
+
+	var mismatch *domain.AppError
+	switch {
+	case fileInfo.Size != session.Size:
+		mismatch = domain.ErrInvalidRequestError(fmt.Sprintf("The uploaded file is %d bytes, not %d", fileInfo.Size, session.Size))
+	case fileInfo.ContentType != "" && !strings.EqualFold(fileInfo.ContentType, session.ContentType):
+		mismatch = domain.ErrUnsupportedFileTypeError(fileInfo.ContentType)
+	}
+	if mismatch == nil {
+		mismatch = s.checkStoredContent(ctx, session)
+	}
+	if mismatch != nil {
+		if err := s.storageService.Delete(ctx, session.Key); err != nil {
+			logging.FromContext(ctx).Warn("Failed to delete mismatched upload", zap.Error(err), zap.String("key", session.Key))
+		}
+		if err := s.sessionRepo.Delete(ctx, session.ID); err != nil {
+			logging.FromContext(ctx).Warn("Failed to delete upload session", zap.Error(err), zap.String("session_id", session.ID))
+		}
+		return nil, mismatch
+	}
+
+	fileInfo.Filename = session.Filename
+	fileInfo.ContentType = session.ContentType
+
+	if err := s.sessionRepo.Delete(ctx, session.ID); err != nil {
+		logging.FromContext(ctx).Warn("Failed to delete confirmed upload session", zap.Error(err), zap.String("session_id", session.ID))
+	}
+
+	if err := s.scanService.ScanUpload(ctx, fileInfo); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("Presigned upload confirmed",
+		zap.String("session_id", session.ID),
+		zap.String("user_id", userID.Hex()),
+		zap.String("key", fileInfo.Key),
+		zap.Int64("size", fileInfo.Size))
+
+	return fileInfo, nil
+}
+
+// checkStoredContent checks that the first bytes of the file of a presigned upload
+// show the content type it was declared as
+func (s *UploadSessionService) checkStoredContent(ctx context.Context, session *domain.UploadSession) *domain.AppError {
+	object, err := s.storageService.GetObject(ctx, session.Key)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to read uploaded file", zap.Error(err), zap.String("session_id", session.ID))
+		return domain.ErrOperationFailedError("Failed to confirm upload")
+	}
+	defer object.Close()
+
+	head := make([]byte, domain.SniffLength)
+	n, err := io.ReadFull(object, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		logging.FromContext(ctx).Error("Failed to read uploaded file", zap.Error(err), zap.String("session_id", session.ID))
+		return domain.ErrOperationFailedError("Failed to confirm upload")
+	}
+
+	if err := domain.CheckContentType(session.ContentType, head[:n]); err != nil {
+		if appErr, ok := err.(*domain.AppError); ok {
+			return appErr
+		}
+		return domain.ErrUnsupportedFileTypeError(session.ContentType)
+	}
+	return nil
+}
+
+// GetStatus describes an upload and the chunks received so far, so that an
+// interrupted upload can be resumed
+func (s *UploadSessionService) GetStatus(ctx context.Context, userID primitive.ObjectID, sessionID string) (*domain.UploadSessionResponse, error) {
+	session, err := s.getSession(ctx, userID, sessionID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := s.sessionRepo.ListParts(ctx, session.ID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to list upload parts", zap.Error(err), zap.String("session_id", session.ID))
+		return nil, domain.ErrOperationFailedError("Failed to get upload")
+	}
+
+	return uploadSessionResponse(session, parts), nil
+}
+
+// UploadChunk stores the chunk at index, replacing it if it was already sent
+func (s *UploadSessionService) UploadChunk(ctx context.Context, userID primitive.ObjectID, sessionID string, index int, data io.Reader, size int64) (*domain.UploadSessionResponse, error) {
+	session, err := s.getSession(ctx, userID, sessionID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if index < 0 || index >= session.ChunkCount() {
+		return nil, domain.ErrInvalidRequestError(fmt.Sprintf("Chunk index must be between 0 and %d", session.ChunkCount()-1))
+	}
+	if expected := session.ChunkLength(index); size != expected {
+		return nil, domain.ErrInvalidRequestError(fmt.Sprintf("Chunk %d must be %d bytes", index, expected))
+	}
+
+	// The first chunk shows what the file really is
+	if index == 0 {
+		head := make([]byte, domain.SniffLength)
+		n, err := io.ReadFull(data, head)
+		if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+			return nil, domain.ErrFileUploadFailedError("could not read the chunk")
+		}
+		if err := domain.CheckContentType(session.ContentType, head[:n]); err != nil {
+			return nil, err
+		}
+		data = io.MultiReader(bytes.NewReader(head[:n]), data)
+	}
+
+	part, err := s.storageService.UploadPart(ctx, session.Key, session.StorageUploadID, index+1, data, size)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to store upload chunk", zap.Error(err),
+			zap.String("session_id", session.ID),
+			zap.Int("index", index))
+		return nil, domain.ErrFileUploadFailedError("could not store the chunk")
+	}
+
+	if err := s.sessionRepo.SetPart(ctx, session.ID, part, time.Until(session.ExpiresAt)); err != nil {
+		logging.FromContext(ctx).Error("Failed to record upload chunk", zap.Error(err), zap.String("session_id", session.ID))
+		return nil, domain.ErrOperationFailedError("Failed to record chunk")
+	}
+
+	parts, err := s.sessionRepo.ListParts(ctx, session.ID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to list upload parts", zap.Error(err), zap.String("session_id", session.ID))
+		return nil, domain.ErrOperationFailedError("Failed to get upload")
+	}
+
+	return uploadSessionResponse(session, parts), nil
+}
+
+// Complete joins the chunks into the file once every one of them was received
+func (s *UploadSessionService) Complete(ctx context.Context, userID primitive.ObjectID, sessionID string) (*domain.FileInfo, error) {
+	session, err := s.getSession(ctx, userID, sessionID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	parts, err := s.sessionRepo.ListParts(ctx, session.ID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to list upload parts", zap.Error(err), zap.String("session_id", session.ID))
+		return nil, domain.ErrOperationFailedError("Failed to complete upload")
+	}
+
+	if missing := missingChunks(session, parts); len(missing) > 0 {
+		return nil, domain.ErrInvalidRequestError(fmt.Sprintf("%d chunks have not been received", len(missing))).
+			WithDetails(map[string]interface{}{"missing_chunks": missing})
+	}
+
+	fileInfo, err := s.storageService.CompleteMultipartUpload(ctx, session.Key, session.StorageUploadID, parts)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to complete multipart upload", zap.Error(err), zap.String("session_id", session.ID))
+		return nil, domain.ErrFileUploadFailedError("could not assemble the file")
+	}
+	fileInfo.Filename = session.Filename
+	fileInfo.ContentType = session.ContentType
+
+	if err := s.sessionRepo.Delete(ctx, session.ID); err != nil {
+		logging.FromContext(ctx).Warn("Failed to delete completed upload session", zap.Error(err), zap.String("session_id", session.ID))
+	}
+
+	if err := s.scanService.ScanUpload(ctx, fileInfo); err != nil {
+		return nil, err
+	}
+
+	logging.FromContext(ctx).Info("Resumable upload completed",
+		zap.String("session_id", session.ID),
+		zap.String("user_id", userID.Hex()),
+		zap.String("key", fileInfo.Key),
+		zap.Int64("size", fileInfo.Size))
+
+	return fileInfo, nil
+}
+
+// Abort discards an upload and the chunks received so far
+func (s *UploadSessionService) Abort(ctx context.Context, userID primitive.ObjectID, sessionID string) error {
+	session, err := s.getSession(ctx, userID, sessionID, false)
+	if err != nil {
+		return err
+	}
+
+	if err := s.storageService.AbortMultipartUpload(ctx, session.Key, session.StorageUploadID); err != nil {
+		logging.FromContext(ctx).Error("Failed to abort multipart upload", zap.Error(err), zap.String("session_id", session.ID))
+		return domain.ErrOperationFailedError("Failed to abort upload")
+	}
+
+	if err := s.sessionRepo.Delete(ctx, session.ID); err != nil {
+		logging.FromContext(ctx).Error("Failed to delete upload session", zap.Error(err), zap.String("session_id", session.ID))
+		return domain.ErrOperationFailedError("Failed to abort upload")
+	}
+
+	return nil
+}
+
+// newSession creates an upload session for a file of the requested type and size,
+// which must be allowed
+func (s *UploadSessionService) newSession(userID primitive.ObjectID, req *domain.InitUploadRequest, presigned bool) (*domain.UploadSession, error) {
+	maxSize, ok := s.maxSizes.MaxSize(req.ContentType)
+	if !ok {
+		return nil, domain.ErrUnsupportedFileTypeError(req.ContentType)
+	}
+	if req.Size > maxSize {
+		return nil, domain.ErrFileTooLargeError(maxSize)
+	}
+
+	folder := req.Folder
+	if folder == "" {
+		folder = "uploads"
+	}
+
+	now := time.Now()
+	session := &domain.UploadSession{
+		ID:          primitive.NewObjectID().Hex(),
+		UserID:      userID,
+		Filename:    req.Filename,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		ChunkSize:   s.chunkSize,
+		Presigned:   presigned,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.sessionTTL),
+	}
+	session.Key = fmt.Sprintf("%s/%s/%s%s", folder, userID.Hex(), session.ID, uploadExtension(req.Filename))
+
+	return session, nil
+}
+
+// getSession returns the user's resumable or presigned upload session. Sessions of
+// other users, or of the other kind, are reported as not found.
+func (s *UploadSessionService) getSession(ctx context.Context, userID primitive.ObjectID, sessionID string, presigned bool) (*domain.UploadSession, error) {
+	session, err := s.sessionRepo.Get(ctx, sessionID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get upload session", zap.Error(err), zap.String("session_id", sessionID))
+		return nil, domain.ErrOperationFailedError("Failed to get upload")
+	}
+	if session == nil || session.UserID != userID || session.Presigned != presigned {
+		return nil, domain.ErrNotFoundError("Upload session")
+	}
+	return session, nil
+}
+
+// abortStorageUpload discards the storage upload of a session that could not be
+// started. Failures are logged only.
+func (s *UploadSessionService) abortStorageUpload(ctx context.Context, session *domain.UploadSession) {
+	if err := s.storageService.AbortMultipartUpload(ctx, session.Key, session.StorageUploadID); err != nil {
+		logging.FromContext(ctx).Warn("Failed to abort multipart upload", zap.Error(err), zap.String("key", session.Key))
+	}
+}
+
+// missingChunks returns the indexes of the chunks of session not among parts
+func missingChunks(session *domain.UploadSession, parts []*domain.UploadedPart) []int {
+	received := make(map[int]bool, len(parts))
+	for _, part := range parts {
+		received[part.Number-1] = true
+	}
+
+	missing := []int{}
+	for i := 0; i < session.ChunkCount(); i++ {
+		if !received[i] {
+			missing = append(missing, i)
+		}
+	}
+	return missing
+}
+
+// uploadSessionResponse describes session with the chunks stored as parts
+func uploadSessionResponse(session *domain.UploadSession, parts []*domain.UploadedPart) *domain.UploadSessionResponse {
+	received := make([]int, 0, len(parts))
+	for _, part := range parts {
+		received = append(received, part.Number-1)
+	}
+
+	return &domain.UploadSessionResponse{
+		ID:             session.ID,
+		Key:            session.Key,
+		Filename:       session.Filename,
+		ContentType:    session.ContentType,
+		Size:           session.Size,
+		ChunkSize:      session.ChunkSize,
+		ChunkCount:     session.ChunkCount(),
+		ReceivedChunks: received,
+		ExpiresAt:      session.ExpiresAt,
+	}
+}
+
+// uploadExtension returns the lowercased extension of filename, or nothing when it is
+// not made of letters and digits only
+func uploadExtension(filename string) string {
+	ext := strings.ToLower(path.Ext(filename))
+	if len(ext) < 2 || len(ext) > 10 {
+		return ""
+	}
+	for _, r := range ext[1:] {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			return ""
+		}
+	}
+	return ext
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// UsageService implements domain.UsageService
+type UsageService struct {
+	usageRepo   domain.UsageRepository
+	userRepo    domain.UserRepository
+	photoRepo   domain.PhotoRepository
+	eventRepo   domain.EventRepository
+	messageRepo domain.MessageRepository
+}
+
+// NewUsageService creates a new usage analytics service
+func NewUsageService(
+	usageRepo domain.UsageRepository,
+	userRepo domain.UserRepository,
+	photoRepo domain.PhotoRepository,
+	eventRepo domain.EventRepository,
+	messageRepo domain.MessageRepository,
+) domain.UsageService {
+	return &UsageService{
+		usageRepo:   usageRepo,
+		userRepo:    userRepo,
+		photoRepo:   photoRepo,
+		eventRepo:   eventRepo,
+		messageRepo: messageRepo,
+	}
+}
+
+// RollUp recounts every day since the last run, starting with the day that run ended
+// in as it was only partly counted. The first run counts the longest range that can be
+// requested. Counts replace the stored ones, so overlapping runs do no harm.
+func (s *UsageService) RollUp(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	rolledUpTo, err := s.usageRepo.GetRolledUpTo(ctx)
+	if err != nil {
+		return err
+	}
+
+	from := domain.NewDate(rolledUpTo.UTC()).Time
+	if rolledUpTo.IsZero() {
+		from = domain.NewDate(now).AddDate(0, 0, -(domain.MaxUsageRangeDays - 1))
+	}
+
+	counters := []struct {
+		metric string
+		count  func() ([]*domain.DailyCount, error)
+	}{
+		{domain.UsageMetricPhotos, func() ([]*domain.DailyCount, error) { return s.photoRepo.CountCreatedPerDay(ctx, from, now) }},
+		{domain.UsageMetricEvents, func() ([]*domain.DailyCount, error) { return s.eventRepo.CountCreatedPerDay(from, now) }},
+		{domain.UsageMetricMessages, func() ([]*domain.DailyCount, error) { return s.messageRepo.CountCreatedPerDay(ctx, from, now) }},
+	}
+
+	rows := 0
+	for _, counter := range counters {
+		counts, err := counter.count()
+		if err != nil {
+			return err
+		}
+		if err := s.usageRepo.SetDailyCounts(ctx, counter.metric, counts); err != nil {
+			return err
+		}
+		rows += len(counts)
+	}
+
+	if err := s.usageRepo.SetRolledUpTo(ctx, now); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("Usage rolled up",
+		zap.Time("from", from),
+		zap.Time("to", now),
+		zap.Int("counts", rows))
+	return nil
+}
+
+// GetCoupleUsage returns the usage of the user's couple over the last days days,
+// today included
+func (s *UsageService) GetCoupleUsage(ctx context.Context, userID primitive.ObjectID, days int) (*domain.CoupleUsageResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	to := domain.NewDate(time.Now().UTC())
+	from := domain.NewDate(to.AddDate(0, 0, -(days - 1)))
+
+	stored, err := s.usageRepo.ListByMatchCode(ctx, user.MatchCode, from, to)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get usage")
+	}
+
+	byDay := make(map[string]*domain.CoupleUsageDay, len(stored))
+	for _, day := range stored {
+		byDay[day.Day.String()] = day
+	}
+
+	response := &domain.CoupleUsageResponse{
+		From: from,
+		To:   to,
+		Days: make([]*domain.CoupleUsageDay, 0, days),
+	}
+	for i := 0; i < days; i++ {
+		date := domain.NewDate(from.AddDate(0, 0, i))
+		day, ok := byDay[date.String()]
+		if !ok {
+			day = &domain.CoupleUsageDay{Day: date}
+		}
+
+		response.Days = append(response.Days, day)
+		response.Totals.Photos += day.Photos
+		response.Totals.Events += day.Events
+		response.Totals.Messages += day.Messages
+	}
+
+	rolledUpTo, err := s.usageRepo.GetRolledUpTo(ctx)
+	if err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to get usage")
+	}
+	if !rolledUpTo.IsZero() {
+		response.UpdatedAt = &rolledUpTo
+	}
+
+	return response, nil
+}
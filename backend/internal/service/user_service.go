@@ -1,15 +1,21 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/eralove/eralove-backend/internal/domain"
 	"github.com/eralove/eralove-backend/internal/infrastructure/auth"
 	"github.com/eralove/eralove-backend/internal/infrastructure/email"
+	"github.com/eralove/eralove-backend/internal/infrastructure/imaging"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"github.com/eralove/eralove-backend/internal/infrastructure/pii"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.uber.org/zap"
 )
@@ -19,10 +25,14 @@ type UserService struct {
 	userRepo        domain.UserRepository
 	eventRepo       domain.EventRepository
 	photoRepo       domain.PhotoRepository
+	storageService  domain.StorageService
 	passwordManager *auth.PasswordManager
 	jwtManager      *auth.JWTManager
 	emailService    *email.EmailService
-	logger          *zap.Logger
+	autoMilestones  domain.AutoMilestoneService
+	tokenFamilies   domain.TokenFamilyRepository
+	audit           domain.AuditService
+	events          domain.EventPublisher
 }
 
 // NewUserService creates a new user service
@@ -30,19 +40,27 @@ func NewUserService(
 	userRepo domain.UserRepository,
 	eventRepo domain.EventRepository,
 	photoRepo domain.PhotoRepository,
+	storageService domain.StorageService,
 	passwordManager *auth.PasswordManager,
 	jwtManager *auth.JWTManager,
 	emailService *email.EmailService,
-	logger *zap.Logger,
+	autoMilestones domain.AutoMilestoneService,
+	tokenFamilies domain.TokenFamilyRepository,
+	audit domain.AuditService,
+	events domain.EventPublisher,
 ) domain.UserService {
 	return &UserService{
 		userRepo:        userRepo,
 		eventRepo:       eventRepo,
 		photoRepo:       photoRepo,
+		storageService:  storageService,
 		passwordManager: passwordManager,
 		jwtManager:      jwtManager,
 		emailService:    emailService,
-		logger:          logger,
+		autoMilestones:  autoMilestones,
+		tokenFamilies:   tokenFamilies,
+		audit:           audit,
+		events:          events,
 	}
 }
 
@@ -50,26 +68,26 @@ func NewUserService(
 func (s *UserService) Register(ctx context.Context, req *domain.CreateUserRequest) (*domain.UserResponse, error) {
 	// Validate password
 	if err := s.passwordManager.IsValidPassword(req.Password); err != nil {
-		return nil, fmt.Errorf("invalid password: %w", err)
+		return nil, domain.NewAppError(domain.ErrCodeWeakPassword, err.Error(), 400)
 	}
 
 	// Check if user already exists
 	existingUser, _ := s.userRepo.GetByEmail(ctx, req.Email)
 	if existingUser != nil {
-		return nil, fmt.Errorf("user with email %s already exists", req.Email)
+		return nil, domain.ErrUserAlreadyExists(req.Email)
 	}
 
 	// Hash password
 	hashedPassword, err := s.passwordManager.HashPassword(req.Password)
 	if err != nil {
-		s.logger.Error("Failed to hash password", zap.Error(err))
+		logging.FromContext(ctx).Error("Failed to hash password", zap.Error(err))
 		return nil, fmt.Errorf("failed to process password")
 	}
 
 	// Generate email verification token
 	verificationToken, err := s.generateSecureToken()
 	if err != nil {
-		s.logger.Error("Failed to generate verification token", zap.Error(err))
+		logging.FromContext(ctx).Error("Failed to generate verification token", zap.Error(err))
 		return nil, fmt.Errorf("failed to generate verification token")
 	}
 
@@ -90,28 +108,38 @@ func (s *UserService) Register(ctx context.Context, req *domain.CreateUserReques
 		DateOfBirth:             dateOfBirth,
 		Gender:                  req.Gender,
 		Avatar:                  req.Avatar,
+		Locale:                  req.Locale,
 		IsEmailVerified:         false,
 		EmailVerificationToken:  verificationToken,
 		EmailVerificationExpiry: &verificationExpiry,
 	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
-		s.logger.Error("Failed to create user", zap.Error(err))
+		// Another registration may have taken the email since the check above
+		if appErr, ok := err.(*domain.AppError); ok {
+			return nil, appErr
+		}
+		logging.FromContext(ctx).Error("Failed to create user", zap.Error(err))
 		return nil, fmt.Errorf("failed to create user")
 	}
 
 	// Send verification email
 	if err := s.emailService.SendVerificationEmail(user.Name, user.Email, verificationToken); err != nil {
-		s.logger.Error("Failed to send verification email",
+		logging.FromContext(ctx).Error("Failed to send verification email",
 			zap.Error(err),
 			zap.String("user_id", user.ID.Hex()),
-			zap.String("email", user.Email))
+			pii.Field("email", user.Email))
 		// Don't fail registration if email fails, just log it
 	}
 
-	s.logger.Info("User registered successfully",
+	logging.FromContext(ctx).Info("User registered successfully",
 		zap.String("user_id", user.ID.Hex()),
-		zap.String("email", user.Email))
+		pii.Field("email", user.Email))
+
+	s.events.Publish(ctx, domain.DomainEventUserRegistered, &domain.UserRegisteredData{
+		UserID: user.ID.Hex(),
+		Locale: user.Locale,
+	})
 
 	return user.ToResponse(), nil
 }
@@ -121,22 +149,28 @@ func (s *UserService) Login(ctx context.Context, req *domain.LoginRequest) (*dom
 	// Get user by email
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
-		s.logger.Warn("Login attempt with non-existent email", zap.String("email", req.Email))
+		logging.FromContext(ctx).Warn("Login attempt with non-existent email", pii.Field("email", req.Email))
 		return nil, nil, fmt.Errorf("invalid credentials")
 	}
 
 	// Verify password
 	if err := s.passwordManager.VerifyPassword(user.PasswordHash, req.Password); err != nil {
-		s.logger.Warn("Login attempt with invalid password",
+		logging.FromContext(ctx).Warn("Login attempt with invalid password",
 			zap.String("user_id", user.ID.Hex()),
-			zap.String("email", req.Email))
+			pii.Field("email", req.Email))
+		s.audit.Record(ctx, user.ID, domain.AuditLoginFailed, nil)
 		return nil, nil, fmt.Errorf("invalid credentials")
 	}
 
 	// Generate token pair (access + refresh tokens)
-	authTokenPair, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email, user.Name)
+	authTokenPair, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email, user.Name, user.Locale, user.RoleNames())
 	if err != nil {
-		s.logger.Error("Failed to generate token pair", zap.Error(err))
+		logging.FromContext(ctx).Error("Failed to generate token pair", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to generate tokens")
+	}
+
+	if err := s.tokenFamilies.Start(ctx, authTokenPair.FamilyID, user.ID, s.jwtManager.RefreshExpiration()); err != nil {
+		logging.FromContext(ctx).Error("Failed to start refresh token family", zap.Error(err))
 		return nil, nil, fmt.Errorf("failed to generate tokens")
 	}
 
@@ -148,9 +182,11 @@ func (s *UserService) Login(ctx context.Context, req *domain.LoginRequest) (*dom
 		ExpiresIn:    authTokenPair.ExpiresIn,
 	}
 
-	s.logger.Info("User logged in successfully",
+	s.audit.Record(ctx, user.ID, domain.AuditLogin, nil)
+
+	logging.FromContext(ctx).Info("User logged in successfully",
 		zap.String("user_id", user.ID.Hex()),
-		zap.String("email", user.Email))
+		pii.Field("email", user.Email))
 
 	return user.ToResponse(), tokenPair, nil
 }
@@ -159,10 +195,10 @@ func (s *UserService) Login(ctx context.Context, req *domain.LoginRequest) (*dom
 func (s *UserService) GetProfile(ctx context.Context, userID primitive.ObjectID) (*domain.UserResponse, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		s.logger.Error("Failed to get user profile",
+		logging.FromContext(ctx).Error("Failed to get user profile",
 			zap.Error(err),
 			zap.String("user_id", userID.Hex()))
-		return nil, fmt.Errorf("failed to get user profile")
+		return nil, domain.ErrUserNotFoundError()
 	}
 
 	return user.ToResponse(), nil
@@ -173,7 +209,7 @@ func (s *UserService) UpdateProfile(ctx context.Context, userID primitive.Object
 	// Get existing user
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found")
+		return nil, domain.ErrUserNotFoundError()
 	}
 
 	// Update fields if provided
@@ -192,13 +228,22 @@ func (s *UserService) UpdateProfile(ctx context.Context, userID primitive.Object
 	if req.PartnerName != "" {
 		user.PartnerName = req.PartnerName
 	}
+	if req.Locale != "" {
+		user.Locale = req.Locale
+	}
+	if req.Timezone != "" {
+		user.Timezone = req.Timezone
+	}
+	if req.MemoriesDigest != "" {
+		user.MemoriesDigest = req.MemoriesDigest
+	}
 	
 	// Update anniversary date if provided and user is matched
 	if req.AnniversaryDate != nil {
 		if user.MatchCode == "" {
-			s.logger.Warn("Attempted to update anniversary date for unmatched user",
+			logging.FromContext(ctx).Warn("Attempted to update anniversary date for unmatched user",
 				zap.String("user_id", userID.Hex()))
-			return nil, fmt.Errorf("cannot set anniversary date: user is not matched")
+			return nil, domain.ErrNotMatchedError()
 		}
 		
 		user.AnniversaryDate = req.AnniversaryDate.ToTimePtr()
@@ -210,18 +255,18 @@ func (s *UserService) UpdateProfile(ctx context.Context, userID primitive.Object
 				partner.AnniversaryDate = req.AnniversaryDate.ToTimePtr()
 				partner.UpdatedAt = time.Now()
 				if err := s.userRepo.Update(ctx, partner.ID, partner); err != nil {
-					s.logger.Error("Failed to update partner's anniversary date",
+					logging.FromContext(ctx).Error("Failed to update partner's anniversary date",
 						zap.Error(err),
 						zap.String("partner_id", partner.ID.Hex()))
 					// Continue anyway, we'll update the current user
 				} else {
-					s.logger.Info("Partner's anniversary date updated",
+					logging.FromContext(ctx).Info("Partner's anniversary date updated",
 						zap.String("partner_id", partner.ID.Hex()))
 				}
 			}
 		}
 		
-		s.logger.Info("Anniversary date updated",
+		logging.FromContext(ctx).Info("Anniversary date updated",
 			zap.String("user_id", userID.Hex()),
 			zap.Time("anniversary_date", *user.AnniversaryDate))
 	}
@@ -230,13 +275,21 @@ func (s *UserService) UpdateProfile(ctx context.Context, userID primitive.Object
 
 	// Update user
 	if err := s.userRepo.Update(ctx, userID, user); err != nil {
-		s.logger.Error("Failed to update user profile",
+		logging.FromContext(ctx).Error("Failed to update user profile",
 			zap.Error(err),
 			zap.String("user_id", userID.Hex()))
-		return nil, fmt.Errorf("failed to update profile")
+		return nil, domain.ErrOperationFailedError("Failed to update profile")
 	}
 
-	s.logger.Info("User profile updated successfully",
+	if req.AnniversaryDate != nil {
+		if err := s.autoMilestones.RegenerateForCouple(ctx, user.MatchCode, user.AnniversaryDate); err != nil {
+			logging.FromContext(ctx).Warn("Failed to regenerate milestone events",
+				zap.Error(err),
+				zap.String("match_code", user.MatchCode))
+		}
+	}
+
+	logging.FromContext(ctx).Info("User profile updated successfully",
 		zap.String("user_id", userID.Hex()))
 
 	return user.ToResponse(), nil
@@ -256,12 +309,20 @@ func (s *UserService) AuthenticateUser(ctx context.Context, req *domain.LoginReq
 	return user, tokenPair.AccessToken, nil
 }
 
-// RefreshToken refreshes an access token using a refresh token
+// RefreshToken rotates a refresh token: the presented token is redeemed and a new pair
+// issued in its family. Redeeming a token twice means it was stolen or leaked, so its
+// whole family is revoked and every session continuing it must log in again.
 func (s *UserService) RefreshToken(ctx context.Context, refreshToken string) (*domain.TokenPair, *domain.UserResponse, error) {
 	// Validate refresh token and extract user info
 	claims, err := s.jwtManager.ValidateRefreshToken(refreshToken)
 	if err != nil {
-		s.logger.Warn("Invalid refresh token", zap.Error(err))
+		logging.FromContext(ctx).Warn("Invalid refresh token", zap.Error(err))
+		return nil, nil, fmt.Errorf("invalid refresh token")
+	}
+
+	// Refresh tokens issued to OAuth clients are redeemed at the token endpoint only
+	if claims.ClientID != "" {
+		logging.FromContext(ctx).Warn("OAuth client refresh token used for a session", zap.String("client_id", claims.ClientID))
 		return nil, nil, fmt.Errorf("invalid refresh token")
 	}
 
@@ -269,10 +330,43 @@ func (s *UserService) RefreshToken(ctx context.Context, refreshToken string) (*d
 	email := claims.Email
 	name := claims.Name
 
+	if claims.FamilyID != "" {
+		active, err := s.tokenFamilies.IsActive(ctx, claims.FamilyID)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to check refresh token family", zap.Error(err))
+			return nil, nil, fmt.Errorf("failed to refresh token")
+		}
+		if !active {
+			logging.FromContext(ctx).Warn("Refresh token of a revoked family used", zap.String("user_id", userID.Hex()))
+			return nil, nil, fmt.Errorf("invalid refresh token")
+		}
+	}
+
+	// A redeemed token is remembered for as long as it could still be presented
+	redeemTTL := s.jwtManager.RefreshExpiration()
+	if claims.ExpiresAt != nil {
+		redeemTTL = time.Until(claims.ExpiresAt.Time)
+	}
+	fresh, err := s.tokenFamilies.Redeem(ctx, refreshTokenID(claims, refreshToken), redeemTTL)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to redeem refresh token", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to refresh token")
+	}
+	if !fresh {
+		logging.FromContext(ctx).Warn("Refresh token reused, revoking its family", zap.String("user_id", userID.Hex()))
+		s.audit.Record(ctx, userID, domain.AuditRefreshTokenReused, nil)
+		if claims.FamilyID != "" {
+			if err := s.tokenFamilies.Revoke(ctx, claims.FamilyID); err != nil {
+				logging.FromContext(ctx).Error("Failed to revoke refresh token family", zap.Error(err))
+			}
+		}
+		return nil, nil, fmt.Errorf("invalid refresh token")
+	}
+
 	// Get user to ensure they still exist and are active
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		s.logger.Error("Failed to get user for token refresh",
+		logging.FromContext(ctx).Error("Failed to get user for token refresh",
 			zap.Error(err),
 			zap.String("user_id", userID.Hex()))
 		return nil, nil, fmt.Errorf("user not found")
@@ -282,56 +376,165 @@ func (s *UserService) RefreshToken(ctx context.Context, refreshToken string) (*d
 		return nil, nil, fmt.Errorf("user account is inactive")
 	}
 
-	// Generate new token pair
-	accessToken, err := s.jwtManager.GenerateToken(userID, email, name)
-	if err != nil {
-		s.logger.Error("Failed to generate new access token", zap.Error(err))
-		return nil, nil, fmt.Errorf("failed to generate token")
+	// Generate new token pair, starting a family for tokens issued before rotation
+	var authTokenPair *auth.TokenPair
+	if claims.FamilyID == "" {
+		authTokenPair, err = s.jwtManager.GenerateTokenPair(userID, email, name, user.Locale, user.RoleNames())
+		if err == nil {
+			err = s.tokenFamilies.Start(ctx, authTokenPair.FamilyID, userID, s.jwtManager.RefreshExpiration())
+		}
+	} else {
+		authTokenPair, err = s.jwtManager.GenerateFamilyTokenPair(userID, email, name, user.Locale, claims.FamilyID, user.RoleNames())
+		if err == nil {
+			err = s.tokenFamilies.Extend(ctx, claims.FamilyID, s.jwtManager.RefreshExpiration())
+		}
 	}
-
-	newRefreshToken, err := s.jwtManager.GenerateRefreshToken(userID, email, name)
 	if err != nil {
-		s.logger.Error("Failed to generate new refresh token", zap.Error(err))
-		return nil, nil, fmt.Errorf("failed to generate refresh token")
+		logging.FromContext(ctx).Error("Failed to generate new token pair", zap.Error(err))
+		return nil, nil, fmt.Errorf("failed to generate token")
 	}
 
 	// Return new tokens and user info
 	return &domain.TokenPair{
-		AccessToken:  accessToken,
-		RefreshToken: newRefreshToken,
+		AccessToken:  authTokenPair.AccessToken,
+		RefreshToken: authTokenPair.RefreshToken,
+		TokenType:    authTokenPair.TokenType,
+		ExpiresIn:    authTokenPair.ExpiresIn,
 	}, user.ToResponse(), nil
 }
 
-// Logout revokes a refresh token
+// Logout revokes the family of the session's refresh token, so that neither it nor any
+// token rotated from it can be redeemed again
 func (s *UserService) Logout(ctx context.Context, refreshToken string) error {
 	// Validate refresh token and extract user info
 	claims, err := s.jwtManager.ValidateRefreshToken(refreshToken)
 	if err != nil {
-		s.logger.Warn("Invalid refresh token during logout", zap.Error(err))
+		logging.FromContext(ctx).Warn("Invalid refresh token during logout", zap.Error(err))
 		return fmt.Errorf("invalid refresh token")
 	}
 
-	// In a production system, you would typically:
-	// 1. Add the refresh token to a blacklist/revocation list
-	// 2. Store revoked tokens in Redis with expiration
-	// For now, we'll just log the logout event
+	if claims.FamilyID != "" {
+		if err := s.tokenFamilies.Revoke(ctx, claims.FamilyID); err != nil {
+			logging.FromContext(ctx).Error("Failed to revoke refresh token family", zap.Error(err))
+			return fmt.Errorf("failed to log out")
+		}
+	}
+
+	s.audit.Record(ctx, claims.UserID, domain.AuditLogout, nil)
 
-	s.logger.Info("User logged out successfully",
+	logging.FromContext(ctx).Info("User logged out successfully",
 		zap.String("user_id", claims.UserID.Hex()))
 
 	return nil
 }
 
+// refreshTokenID returns the ID a refresh token is redeemed under. Tokens issued before
+// rotation have no ID and are identified by their hash.
+func refreshTokenID(claims *auth.JWTClaims, refreshToken string) string {
+	if claims.ID != "" {
+		return claims.ID
+	}
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// UploadAvatar crops the uploaded image to a square, stores it in every avatar size
+// and makes it the user's avatar, deleting the files of the one it replaces
+func (s *UserService) UploadAvatar(ctx context.Context, userID primitive.ObjectID, req *domain.UploadAvatarRequest) (*domain.UserResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if err := domain.ValidateImageFile(req.ContentType, req.Size); err != nil {
+		if errors.Is(err, domain.ErrFileTooLarge) {
+			return nil, domain.ErrFileTooLargeError(domain.MaxImageSize)
+		}
+		return nil, domain.ErrUnsupportedFileTypeError(req.ContentType)
+	}
+
+	img, format, err := imaging.Decode(req.File)
+	if err != nil {
+		return nil, domain.ErrUnsupportedFileTypeError(req.ContentType)
+	}
+	img = imaging.CropSquare(img)
+
+	// Each upload gets new keys so the current avatar stays valid until it is replaced
+	uploadID := primitive.NewObjectID().Hex()
+	sizes := []int{domain.AvatarSize, domain.AvatarSmallSize}
+	urls := make([]string, 0, len(sizes))
+	keys := make([]string, 0, len(sizes))
+	for _, size := range sizes {
+		data, contentType, err := imaging.Encode(imaging.Resize(img, size), format)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to encode avatar", zap.Error(err))
+			return nil, domain.ErrFileUploadFailedError("could not process the image")
+		}
+
+		key := avatarKey(userID, uploadID, size, format)
+		fileInfo, err := s.storageService.PutObject(ctx, key, bytes.NewReader(data), int64(len(data)), contentType)
+		if err != nil {
+			logging.FromContext(ctx).Error("Failed to store avatar", zap.Error(err), zap.String("key", key))
+			s.deleteAvatarFiles(ctx, userID, keys)
+			return nil, domain.ErrFileUploadFailedError("could not store the file")
+		}
+
+		urls = append(urls, fileInfo.URL)
+		keys = append(keys, key)
+	}
+
+	previousKeys, err := s.userRepo.SetAvatar(ctx, userID, urls[0], urls[1], keys)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to set avatar", zap.Error(err), zap.String("user_id", userID.Hex()))
+		s.deleteAvatarFiles(ctx, userID, keys)
+		return nil, domain.ErrOperationFailedError("Failed to update avatar")
+	}
+	s.deleteAvatarFiles(ctx, userID, previousKeys)
+
+	user.Avatar = urls[0]
+	user.AvatarSmall = urls[1]
+	user.AvatarKeys = keys
+	user.UpdatedAt = time.Now()
+
+	logging.FromContext(ctx).Info("Avatar uploaded", zap.String("user_id", userID.Hex()))
+
+	return user.ToResponse(), nil
+}
+
+// deleteAvatarFiles removes the stored files of an avatar. Failures are logged only.
+func (s *UserService) deleteAvatarFiles(ctx context.Context, userID primitive.ObjectID, keys []string) {
+	for _, key := range keys {
+		if err := s.storageService.Delete(ctx, key); err != nil {
+			logging.FromContext(ctx).Warn("Failed to delete avatar file",
+				zap.String("user_id", userID.Hex()),
+				zap.String("key", key),
+				zap.Error(err))
+		}
+	}
+}
+
+// avatarKey returns the storage key of one size of an uploaded avatar
+func avatarKey(userID primitive.ObjectID, uploadID string, size int, format string) string {
+	ext := ".jpg"
+	if format == imaging.FormatPNG {
+		ext = ".png"
+	}
+
+	return fmt.Sprintf("avatars/%s/%s_%d%s", userID.Hex(), uploadID, size, ext)
+}
+
 // DeleteAccount soft deletes a user account
 func (s *UserService) DeleteAccount(ctx context.Context, userID primitive.ObjectID) error {
 	if err := s.userRepo.Delete(ctx, userID); err != nil {
-		s.logger.Error("Failed to delete user account",
+		logging.FromContext(ctx).Error("Failed to delete user account",
 			zap.Error(err),
 			zap.String("user_id", userID.Hex()))
 		return fmt.Errorf("failed to delete account")
 	}
 
-	s.logger.Info("User account deleted successfully",
+	s.audit.Record(ctx, userID, domain.AuditAccountDeleted, nil)
+
+	logging.FromContext(ctx).Info("User account deleted successfully",
 		zap.String("user_id", userID.Hex()))
 
 	return nil
@@ -351,16 +554,16 @@ func (s *UserService) VerifyEmail(ctx context.Context, req *domain.EmailVerifica
 	// Get user by verification token
 	user, err := s.userRepo.GetByEmailVerificationToken(ctx, req.Token)
 	if err != nil {
-		s.logger.Warn("Email verification attempt with invalid token", zap.String("token", req.Token))
-		return fmt.Errorf("invalid or expired verification token")
+		logging.FromContext(ctx).Warn("Email verification attempt with invalid token")
+		return domain.ErrInvalidVerificationTokenError()
 	}
 
 	// Check if token is expired
 	if user.EmailVerificationExpiry != nil && time.Now().After(*user.EmailVerificationExpiry) {
-		s.logger.Warn("Email verification attempt with expired token", 
+		logging.FromContext(ctx).Warn("Email verification attempt with expired token", 
 			zap.String("user_id", user.ID.Hex()),
 			zap.Time("expiry", *user.EmailVerificationExpiry))
-		return fmt.Errorf("verification token has expired")
+		return domain.ErrInvalidVerificationTokenError()
 	}
 
 	// Update user to mark email as verified and clear verification token
@@ -369,15 +572,15 @@ func (s *UserService) VerifyEmail(ctx context.Context, req *domain.EmailVerifica
 	user.EmailVerificationExpiry = nil
 
 	if err := s.userRepo.Update(ctx, user.ID, user); err != nil {
-		s.logger.Error("Failed to update user after email verification",
+		logging.FromContext(ctx).Error("Failed to update user after email verification",
 			zap.Error(err),
 			zap.String("user_id", user.ID.Hex()))
-		return fmt.Errorf("failed to verify email")
+		return domain.ErrOperationFailedError("Failed to verify email")
 	}
 
-	s.logger.Info("Email verified successfully",
+	logging.FromContext(ctx).Info("Email verified successfully",
 		zap.String("user_id", user.ID.Hex()),
-		zap.String("email", user.Email))
+		pii.Field("email", user.Email))
 
 	return nil
 }
@@ -387,24 +590,24 @@ func (s *UserService) ResendVerificationEmail(ctx context.Context, req *domain.R
 	// Get user by email
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
-		s.logger.Warn("Resend verification attempt for non-existent email", zap.String("email", req.Email))
+		logging.FromContext(ctx).Warn("Resend verification attempt for non-existent email", pii.Field("email", req.Email))
 		// Don't reveal if email exists or not for security
 		return nil
 	}
 
 	// Check if email is already verified
 	if user.IsEmailVerified {
-		s.logger.Info("Resend verification attempt for already verified email", 
+		logging.FromContext(ctx).Info("Resend verification attempt for already verified email", 
 			zap.String("user_id", user.ID.Hex()),
-			zap.String("email", req.Email))
-		return fmt.Errorf("email is already verified")
+			pii.Field("email", req.Email))
+		return domain.ErrEmailAlreadyVerifiedError()
 	}
 
 	// Generate new verification token
 	token, err := s.generateSecureToken()
 	if err != nil {
-		s.logger.Error("Failed to generate verification token", zap.Error(err))
-		return fmt.Errorf("failed to generate verification token")
+		logging.FromContext(ctx).Error("Failed to generate verification token", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to send verification email")
 	}
 
 	// Set token expiry (24 hours)
@@ -414,24 +617,24 @@ func (s *UserService) ResendVerificationEmail(ctx context.Context, req *domain.R
 
 	// Update user with new token
 	if err := s.userRepo.Update(ctx, user.ID, user); err != nil {
-		s.logger.Error("Failed to update user with new verification token",
+		logging.FromContext(ctx).Error("Failed to update user with new verification token",
 			zap.Error(err),
 			zap.String("user_id", user.ID.Hex()))
-		return fmt.Errorf("failed to generate new verification token")
+		return domain.ErrOperationFailedError("Failed to send verification email")
 	}
 
 	// Send verification email
 	if err := s.emailService.SendVerificationEmail(user.Name, user.Email, token); err != nil {
-		s.logger.Error("Failed to send verification email",
+		logging.FromContext(ctx).Error("Failed to send verification email",
 			zap.Error(err),
 			zap.String("user_id", user.ID.Hex()),
-			zap.String("email", user.Email))
-		return fmt.Errorf("failed to send verification email")
+			pii.Field("email", user.Email))
+		return domain.ErrOperationFailedError("Failed to send verification email")
 	}
 
-	s.logger.Info("Verification email resent successfully",
+	logging.FromContext(ctx).Info("Verification email resent successfully",
 		zap.String("user_id", user.ID.Hex()),
-		zap.String("email", user.Email))
+		pii.Field("email", user.Email))
 
 	return nil
 }
@@ -441,7 +644,7 @@ func (s *UserService) ForgotPassword(ctx context.Context, req *domain.ForgotPass
 	// Get user by email
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
-		s.logger.Warn("Password reset attempt for non-existent email", zap.String("email", req.Email))
+		logging.FromContext(ctx).Warn("Password reset attempt for non-existent email", pii.Field("email", req.Email))
 		// Don't reveal if email exists or not for security
 		return nil
 	}
@@ -449,7 +652,7 @@ func (s *UserService) ForgotPassword(ctx context.Context, req *domain.ForgotPass
 	// Generate password reset token
 	token, err := s.generateSecureToken()
 	if err != nil {
-		s.logger.Error("Failed to generate password reset token", zap.Error(err))
+		logging.FromContext(ctx).Error("Failed to generate password reset token", zap.Error(err))
 		return fmt.Errorf("failed to generate reset token")
 	}
 
@@ -460,7 +663,7 @@ func (s *UserService) ForgotPassword(ctx context.Context, req *domain.ForgotPass
 
 	// Update user with reset token
 	if err := s.userRepo.Update(ctx, user.ID, user); err != nil {
-		s.logger.Error("Failed to update user with password reset token",
+		logging.FromContext(ctx).Error("Failed to update user with password reset token",
 			zap.Error(err),
 			zap.String("user_id", user.ID.Hex()))
 		return fmt.Errorf("failed to generate reset token")
@@ -468,16 +671,18 @@ func (s *UserService) ForgotPassword(ctx context.Context, req *domain.ForgotPass
 
 	// Send password reset email
 	if err := s.emailService.SendPasswordResetEmail(user.Name, user.Email, token); err != nil {
-		s.logger.Error("Failed to send password reset email",
+		logging.FromContext(ctx).Error("Failed to send password reset email",
 			zap.Error(err),
 			zap.String("user_id", user.ID.Hex()),
-			zap.String("email", user.Email))
+			pii.Field("email", user.Email))
 		return fmt.Errorf("failed to send reset email")
 	}
 
-	s.logger.Info("Password reset email sent successfully",
+	s.audit.Record(ctx, user.ID, domain.AuditPasswordResetRequested, nil)
+
+	logging.FromContext(ctx).Info("Password reset email sent successfully",
 		zap.String("user_id", user.ID.Hex()),
-		zap.String("email", user.Email))
+		pii.Field("email", user.Email))
 
 	return nil
 }
@@ -486,29 +691,29 @@ func (s *UserService) ForgotPassword(ctx context.Context, req *domain.ForgotPass
 func (s *UserService) ResetPassword(ctx context.Context, req *domain.ResetPasswordRequest) error {
 	// Validate new password
 	if err := s.passwordManager.IsValidPassword(req.NewPassword); err != nil {
-		return fmt.Errorf("invalid password: %w", err)
+		return domain.NewAppError(domain.ErrCodeWeakPassword, err.Error(), 400)
 	}
 
 	// Get user by reset token
 	user, err := s.userRepo.GetByPasswordResetToken(ctx, req.Token)
 	if err != nil {
-		s.logger.Warn("Password reset attempt with invalid token", zap.String("token", req.Token))
-		return fmt.Errorf("invalid or expired reset token")
+		logging.FromContext(ctx).Warn("Password reset attempt with invalid token")
+		return domain.ErrInvalidResetTokenError()
 	}
 
 	// Check if token is expired
 	if user.PasswordResetExpiry != nil && time.Now().After(*user.PasswordResetExpiry) {
-		s.logger.Warn("Password reset attempt with expired token",
+		logging.FromContext(ctx).Warn("Password reset attempt with expired token",
 			zap.String("user_id", user.ID.Hex()),
 			zap.Time("expiry", *user.PasswordResetExpiry))
-		return fmt.Errorf("reset token has expired")
+		return domain.ErrInvalidResetTokenError()
 	}
 
 	// Hash new password
 	hashedPassword, err := s.passwordManager.HashPassword(req.NewPassword)
 	if err != nil {
-		s.logger.Error("Failed to hash new password", zap.Error(err))
-		return fmt.Errorf("failed to process new password")
+		logging.FromContext(ctx).Error("Failed to hash new password", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to reset password")
 	}
 
 	// Update user with new password and clear reset token
@@ -517,81 +722,190 @@ func (s *UserService) ResetPassword(ctx context.Context, req *domain.ResetPasswo
 	user.PasswordResetExpiry = nil
 
 	if err := s.userRepo.Update(ctx, user.ID, user); err != nil {
-		s.logger.Error("Failed to update user password",
+		logging.FromContext(ctx).Error("Failed to update user password",
 			zap.Error(err),
 			zap.String("user_id", user.ID.Hex()))
-		return fmt.Errorf("failed to reset password")
+		return domain.ErrOperationFailedError("Failed to reset password")
 	}
 
-	s.logger.Info("Password reset successfully",
+	// Whoever knew the old password may hold a session
+	if err := s.tokenFamilies.RevokeAll(ctx, user.ID); err != nil {
+		logging.FromContext(ctx).Error("Failed to revoke sessions after password reset",
+			zap.Error(err),
+			zap.String("user_id", user.ID.Hex()))
+	}
+
+	s.audit.Record(ctx, user.ID, domain.AuditPasswordReset, nil)
+
+	logging.FromContext(ctx).Info("Password reset successfully",
 		zap.String("user_id", user.ID.Hex()),
-		zap.String("email", user.Email))
+		pii.Field("email", user.Email))
 
 	return nil
 }
 
-// UnmatchPartner breaks the match between user and partner, deleting all shared data
-func (s *UserService) UnmatchPartner(ctx context.Context, userID primitive.ObjectID) error {
-	s.logger.Info("Unmatching partner", zap.String("user_id", userID.Hex()))
+// ChangePassword changes the password of a logged-in user who knows the current one.
+// Every existing session is signed out and a new one returned for the caller.
+func (s *UserService) ChangePassword(ctx context.Context, userID primitive.ObjectID, req *domain.ChangePasswordRequest) (*domain.UserResponse, *domain.TokenPair, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, domain.ErrUserNotFoundError()
+	}
+
+	if err := s.passwordManager.VerifyPassword(user.PasswordHash, req.CurrentPassword); err != nil {
+		logging.FromContext(ctx).Warn("Password change with an incorrect current password",
+			zap.String("user_id", userID.Hex()))
+		return nil, nil, domain.NewAppError(domain.ErrCodePasswordMismatch, "Current password is incorrect", 400)
+	}
+
+	if req.NewPassword == req.CurrentPassword {
+		return nil, nil, domain.ErrInvalidRequestError("New password must differ from the current one")
+	}
+
+	if err := s.passwordManager.IsValidPassword(req.NewPassword); err != nil {
+		return nil, nil, domain.NewAppError(domain.ErrCodeWeakPassword, err.Error(), 400)
+	}
+
+	hashedPassword, err := s.passwordManager.HashPassword(req.NewPassword)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to hash new password", zap.Error(err))
+		return nil, nil, domain.ErrOperationFailedError("Failed to change password")
+	}
+
+	user.PasswordHash = hashedPassword
+	user.UpdatedAt = time.Now()
+
+	if err := s.userRepo.Update(ctx, user.ID, user); err != nil {
+		logging.FromContext(ctx).Error("Failed to update user password",
+			zap.Error(err),
+			zap.String("user_id", userID.Hex()))
+		return nil, nil, domain.ErrOperationFailedError("Failed to change password")
+	}
+
+	// The password changed, so sign out every session, then open one for the caller
+	if err := s.tokenFamilies.RevokeAll(ctx, user.ID); err != nil {
+		logging.FromContext(ctx).Error("Failed to revoke sessions after password change",
+			zap.Error(err),
+			zap.String("user_id", userID.Hex()))
+		return nil, nil, domain.ErrOperationFailedError("Failed to sign out other sessions")
+	}
+
+	authTokenPair, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email, user.Name, user.Locale, user.RoleNames())
+	if err == nil {
+		err = s.tokenFamilies.Start(ctx, authTokenPair.FamilyID, user.ID, s.jwtManager.RefreshExpiration())
+	}
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to generate token pair", zap.Error(err))
+		return nil, nil, domain.ErrOperationFailedError("Failed to generate tokens")
+	}
+
+	s.audit.Record(ctx, userID, domain.AuditPasswordChanged, nil)
+
+	logging.FromContext(ctx).Info("Password changed successfully",
+		zap.String("user_id", userID.Hex()))
+
+	return user.ToResponse(), &domain.TokenPair{
+		AccessToken:  authTokenPair.AccessToken,
+		RefreshToken: authTokenPair.RefreshToken,
+		TokenType:    authTokenPair.TokenType,
+		ExpiresIn:    authTokenPair.ExpiresIn,
+	}, nil
+}
+
+// UnmatchPartner schedules the end of the user's match. The couple stays matched during
+// the grace period, during which the user can cancel it; afterwards the match is
+// broken and all shared data deleted.
+func (s *UserService) UnmatchPartner(ctx context.Context, userID primitive.ObjectID) (*domain.PendingUnmatch, error) {
+	logging.FromContext(ctx).Info("Unmatching partner", zap.String("user_id", userID.Hex()))
 
-	// Get user
 	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
-		s.logger.Error("Failed to get user", zap.Error(err))
-		return fmt.Errorf("user not found")
+		return nil, domain.ErrUserNotFoundError()
 	}
 
 	if user.MatchCode == "" {
-		s.logger.Warn("User is not matched", zap.String("user_id", userID.Hex()))
-		return fmt.Errorf("user is not matched with anyone")
+		return nil, domain.ErrNotMatchedError()
 	}
 
-	matchCode := user.MatchCode
-	partnerID := user.PartnerID
+	if pending := user.PendingUnmatch(); pending != nil {
+		return nil, domain.ErrOperationInProgressError("Unmatch")
+	}
 
-	// Delete all events with match code
-	if err := s.eventRepo.DeleteByMatchCode(matchCode); err != nil {
-		s.logger.Error("Failed to delete events", zap.Error(err))
-		return fmt.Errorf("failed to delete shared events")
+	now := time.Now()
+	if err := s.userRepo.SetUnmatchRequest(ctx, user.MatchCode, &userID, now); err != nil {
+		return nil, domain.ErrOperationFailedError("Failed to unmatch")
 	}
 
-	// Delete all photos with match code
-	if err := s.photoRepo.DeleteByMatchCode(ctx, matchCode); err != nil {
-		s.logger.Error("Failed to delete photos", zap.Error(err))
-		return fmt.Errorf("failed to delete shared photos")
+	s.audit.Record(ctx, userID, domain.AuditUnmatchRequested, map[string]string{"match_code": user.MatchCode})
+
+	logging.FromContext(ctx).Info("Unmatch scheduled",
+		zap.String("user_id", userID.Hex()),
+		zap.String("match_code", user.MatchCode))
+
+	return &domain.PendingUnmatch{
+		RequestedBy: userID,
+		RequestedAt: now,
+		PurgeAt:     now.Add(domain.UnmatchGracePeriod),
+	}, nil
+}
+
+// CancelUnmatch keeps the couple matched. Only the partner who asked to unmatch can
+// cancel it.
+func (s *UserService) CancelUnmatch(ctx context.Context, userID primitive.ObjectID) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return domain.ErrUserNotFoundError()
 	}
 
-	// Clear partner's match fields if partner exists
-	if partnerID != nil {
-		partner, err := s.userRepo.GetByID(ctx, *partnerID)
-		if err == nil && partner != nil {
-			partner.PartnerID = nil
-			partner.MatchCode = ""
-			partner.MatchedAt = nil
-			partner.AnniversaryDate = nil
-			partner.UpdatedAt = time.Now()
-			
-			if err := s.userRepo.Update(ctx, partner.ID, partner); err != nil {
-				s.logger.Error("Failed to update partner", zap.Error(err))
-			}
-		}
+	if user.MatchCode == "" {
+		return domain.ErrNotMatchedError()
 	}
 
-	// Clear user's match fields
-	user.PartnerID = nil
-	user.MatchCode = ""
-	user.MatchedAt = nil
-	user.AnniversaryDate = nil
-	user.UpdatedAt = time.Now()
+	pending := user.PendingUnmatch()
+	if pending == nil {
+		return domain.ErrInvalidRequestError("No unmatch is pending")
+	}
+	if pending.RequestedBy != userID {
+		return domain.ErrForbiddenError()
+	}
 
-	if err := s.userRepo.Update(ctx, userID, user); err != nil {
-		s.logger.Error("Failed to update user", zap.Error(err))
-		return fmt.Errorf("failed to unmatch")
+	if err := s.userRepo.SetUnmatchRequest(ctx, user.MatchCode, nil, time.Time{}); err != nil {
+		return domain.ErrOperationFailedError("Failed to cancel unmatch")
 	}
 
-	s.logger.Info("Successfully unmatched partner",
+	s.audit.Record(ctx, userID, domain.AuditUnmatchCancelled, map[string]string{"match_code": user.MatchCode})
+
+	logging.FromContext(ctx).Info("Unmatch cancelled",
 		zap.String("user_id", userID.Hex()),
-		zap.String("match_code", matchCode))
+		zap.String("match_code", user.MatchCode))
+
+	return nil
+}
+
+// PurgeExpiredUnmatches breaks the matches whose grace period has ended, deleting the
+// couples' shared events and photos
+func (s *UserService) PurgeExpiredUnmatches(ctx context.Context) error {
+	matchCodes, err := s.userRepo.ListUnmatchesRequestedBefore(ctx, time.Now().Add(-domain.UnmatchGracePeriod))
+	if err != nil {
+		return err
+	}
+
+	for _, matchCode := range matchCodes {
+		if err := s.eventRepo.DeleteByMatchCode(matchCode); err != nil {
+			return fmt.Errorf("failed to delete shared events of %s: %w", matchCode, err)
+		}
+
+		if err := s.photoRepo.DeleteByMatchCode(ctx, matchCode); err != nil {
+			return fmt.Errorf("failed to delete shared photos of %s: %w", matchCode, err)
+		}
+
+		// Cleared last, so a failed run is retried with the couple still listed
+		if err := s.userRepo.ClearMatch(ctx, matchCode); err != nil {
+			return fmt.Errorf("failed to clear match %s: %w", matchCode, err)
+		}
+
+		logging.FromContext(ctx).Info("Unmatch completed", zap.String("match_code", matchCode))
+	}
 
 	return nil
 }
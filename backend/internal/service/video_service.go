@@ -0,0 +1,115 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/video"
+	"go.uber.org/zap"
+)
+
+// videoPosterOffset is how far into a video its poster frame is taken from, unless the
+// video is shorter than twice that
+const videoPosterOffset = 1 * time.Second
+
+// VideoService implements domain.VideoService
+type VideoService struct {
+	storageService domain.StorageService
+	ffmpeg         *video.FFmpeg
+	maxDuration    time.Duration
+}
+
+// NewVideoService creates a new video service
+func NewVideoService(
+	storageService domain.StorageService,
+	ffmpeg *video.FFmpeg,
+	maxDuration time.Duration,
+) domain.VideoService {
+	return &VideoService{
+		storageService: storageService,
+		ffmpeg:         ffmpeg,
+		maxDuration:    maxDuration,
+	}
+}
+
+// ProcessVideo downloads the video stored under key once to a temporary file, checks
+// its length and stores its poster frame next to it (e.g. "photos/u/trip.mp4" ->
+// "photos/u/trip_poster.jpg")
+func (s *VideoService) ProcessVideo(ctx context.Context, key string) (*domain.VideoMetadata, string, error) {
+	file, err := s.download(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+	defer os.Remove(file)
+
+	info, err := s.ffmpeg.Probe(ctx, file)
+	if err != nil {
+		return nil, "", err
+	}
+	if info.Duration > s.maxDuration {
+		return nil, "", domain.ErrVideoTooLong
+	}
+
+	offset := videoPosterOffset
+	if info.Duration < 2*offset {
+		offset = info.Duration / 2
+	}
+	frame, err := s.ffmpeg.Frame(ctx, file, offset)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(frame) == 0 {
+		return nil, "", fmt.Errorf("no frame could be extracted")
+	}
+
+	posterKey := videoPosterKey(key)
+	if _, err := s.storageService.PutObject(ctx, posterKey, bytes.NewReader(frame), int64(len(frame)), "image/jpeg"); err != nil {
+		return nil, "", fmt.Errorf("failed to store poster: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("Video processed",
+		zap.String("key", key),
+		zap.Duration("duration", info.Duration))
+
+	return &domain.VideoMetadata{
+		Duration: info.Duration.Seconds(),
+		Width:    info.Width,
+		Height:   info.Height,
+	}, posterKey, nil
+}
+
+// download copies the video stored under key to a temporary file, which ffmpeg needs
+// to seek in, and returns its path
+func (s *VideoService) download(ctx context.Context, key string) (string, error) {
+	object, err := s.storageService.GetObject(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer object.Close()
+
+	file, err := os.CreateTemp("", "video-*"+path.Ext(key))
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, object); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to download video: %w", err)
+	}
+
+	return file.Name(), nil
+}
+
+// videoPosterKey derives the storage key of a video's poster frame
+func videoPosterKey(key string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + "_poster.jpg"
+}
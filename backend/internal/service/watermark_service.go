@@ -0,0 +1,103 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"github.com/eralove/eralove-backend/internal/infrastructure/imaging"
+	"go.uber.org/zap"
+)
+
+// watermarkFolder is the storage prefix under which rendered watermark variants are cached
+const watermarkFolder = "watermarked"
+
+// WatermarkService implements domain.WatermarkService
+type WatermarkService struct {
+	settingsService domain.CoupleSettingsService
+	storageService  domain.StorageService
+}
+
+// NewWatermarkService creates a new watermark service
+func NewWatermarkService(
+	settingsService domain.CoupleSettingsService,
+	storageService domain.StorageService,
+) domain.WatermarkService {
+	return &WatermarkService{
+		settingsService: settingsService,
+		storageService:  storageService,
+	}
+}
+
+// SharedImageKey returns the key of the image to expose for a photo outside the couple.
+// That is the public variant, without the original's metadata, when the photo has one.
+// Watermarked variants are rendered on first request and cached in storage; the cache key
+// includes the watermark text so changing it produces fresh variants.
+func (s *WatermarkService) SharedImageKey(ctx context.Context, photo *domain.Photo) (string, error) {
+	originalKey := photo.PreviewKey()
+	if photo.PublicKey != "" {
+		originalKey = photo.PublicKey
+	}
+
+	settings, err := s.settingsService.GetByMatchCode(ctx, photo.MatchCode)
+	if err != nil {
+		return "", err
+	}
+
+	if !settings.WatermarkEnabled {
+		return originalKey, nil
+	}
+
+	text := settings.EffectiveWatermarkText()
+	variantKey := watermarkVariantKey(originalKey, text)
+
+	if _, err := s.storageService.GetFileInfo(ctx, variantKey); err == nil {
+		return variantKey, nil
+	}
+
+	if err := s.render(ctx, originalKey, variantKey, text); err != nil {
+		logging.FromContext(ctx).Error("Failed to render watermarked photo",
+			zap.Error(err),
+			zap.String("photo_id", photo.ID.Hex()),
+			zap.String("key", originalKey))
+		return "", domain.ErrOperationFailedError("Failed to watermark photo")
+	}
+
+	logging.FromContext(ctx).Info("Watermarked photo variant created",
+		zap.String("photo_id", photo.ID.Hex()),
+		zap.String("key", variantKey))
+
+	return variantKey, nil
+}
+
+// render downloads the original image, stamps the watermark and stores the result
+func (s *WatermarkService) render(ctx context.Context, originalKey, variantKey, text string) error {
+	reader, err := s.storageService.GetObject(ctx, originalKey)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	img, format, err := imaging.Decode(reader)
+	if err != nil {
+		return err
+	}
+
+	data, contentType, err := imaging.Encode(imaging.Watermark(img, text), format)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.storageService.PutObject(ctx, variantKey, bytes.NewReader(data), int64(len(data)), contentType)
+	return err
+}
+
+// watermarkVariantKey derives the cache key of a watermarked variant
+func watermarkVariantKey(originalKey, text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return fmt.Sprintf("%s/%s/%s", watermarkFolder, hex.EncodeToString(sum[:])[:12], originalKey)
+}
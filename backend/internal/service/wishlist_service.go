@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"github.com/eralove/eralove-backend/internal/infrastructure/logging"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/eralove/eralove-backend/internal/domain"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/zap"
+)
+
+// WishlistService implements domain.WishlistService
+type WishlistService struct {
+	wishlistRepo domain.WishlistRepository
+	userRepo     domain.UserRepository
+}
+
+// NewWishlistService creates a new wishlist service
+func NewWishlistService(
+	wishlistRepo domain.WishlistRepository,
+	userRepo domain.UserRepository,
+) domain.WishlistService {
+	return &WishlistService{
+		wishlistRepo: wishlistRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// CreateItem adds an item to the user's wishlist
+func (s *WishlistService) CreateItem(ctx context.Context, userID primitive.ObjectID, req *domain.CreateWishlistItemRequest) (*domain.WishlistItemResponse, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &domain.WishlistItem{
+		MatchCode: user.MatchCode,
+		OwnerID:   userID,
+		Title:     strings.TrimSpace(req.Title),
+		URL:       strings.TrimSpace(req.URL),
+		Price:     req.Price,
+		Currency:  strings.ToUpper(req.Currency),
+		Notes:     req.Notes,
+		IsSecret:  req.IsSecret,
+	}
+
+	if err := s.wishlistRepo.Create(ctx, item); err != nil {
+		logging.FromContext(ctx).Error("Failed to create wishlist item", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to create wishlist item")
+	}
+
+	logging.FromContext(ctx).Info("Wishlist item created",
+		zap.String("item_id", item.ID.Hex()),
+		zap.String("user_id", userID.Hex()),
+		zap.Bool("is_secret", item.IsSecret))
+
+	return item.ToResponse(userID), nil
+}
+
+// GetItem retrieves a wishlist item by ID. The partner's secret items are not found
+// until they are purchased.
+func (s *WishlistService) GetItem(ctx context.Context, itemID, userID primitive.ObjectID) (*domain.WishlistItemResponse, error) {
+	item, err := s.getVisibleItem(ctx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return item.ToResponse(userID), nil
+}
+
+// GetItems retrieves the wishlist items the user can see with pagination, open items
+// first, optionally only the user's own or the partner's
+func (s *WishlistService) GetItems(ctx context.Context, userID primitive.ObjectID, owner domain.WishlistOwner, status domain.WishlistStatus, page, limit int) (*domain.WishlistResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	response := &domain.WishlistResponse{
+		Items: []*domain.WishlistItemResponse{},
+		Page:  page,
+		Limit: limit,
+	}
+
+	if user.MatchCode == "" {
+		return response, nil
+	}
+
+	filter := domain.WishlistFilter{Status: status}
+	switch owner {
+	case domain.WishlistOwnerMine:
+		filter.OwnerID = &user.ID
+	case domain.WishlistOwnerPartner:
+		if user.PartnerID == nil {
+			return response, nil
+		}
+		filter.OwnerID = user.PartnerID
+	}
+
+	offset := (page - 1) * limit
+
+	items, err := s.wishlistRepo.GetVisible(ctx, user.MatchCode, userID, filter, limit, offset)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to get wishlist items", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get wishlist")
+	}
+
+	response.Total, err = s.wishlistRepo.CountVisible(ctx, user.MatchCode, userID, filter)
+	if err != nil {
+		logging.FromContext(ctx).Error("Failed to count wishlist items", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to get wishlist")
+	}
+
+	for _, item := range items {
+		response.Items = append(response.Items, item.ToResponse(userID))
+	}
+
+	return response, nil
+}
+
+// UpdateItem updates a wishlist item; only its owner may update it
+func (s *WishlistService) UpdateItem(ctx context.Context, itemID, userID primitive.ObjectID, req *domain.UpdateWishlistItemRequest) (*domain.WishlistItemResponse, error) {
+	item, err := s.getOwnItem(ctx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != "" {
+		item.Title = strings.TrimSpace(req.Title)
+	}
+	if req.URL != nil {
+		link := strings.TrimSpace(*req.URL)
+		if link != "" {
+			if parsed, err := url.ParseRequestURI(link); err != nil || parsed.Host == "" {
+				return nil, domain.ErrInvalidRequestError("Invalid URL")
+			}
+		}
+		item.URL = link
+	}
+	if req.Price != nil {
+		item.Price = req.Price
+	}
+	if req.Currency != "" {
+		item.Currency = strings.ToUpper(req.Currency)
+	}
+	if req.Notes != nil {
+		item.Notes = *req.Notes
+	}
+	if req.IsSecret != nil {
+		item.IsSecret = *req.IsSecret
+	}
+
+	if err := s.wishlistRepo.Update(ctx, item); err != nil {
+		logging.FromContext(ctx).Error("Failed to update wishlist item", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update wishlist item")
+	}
+
+	return item.ToResponse(userID), nil
+}
+
+// DeleteItem deletes a wishlist item; only its owner may delete it
+func (s *WishlistService) DeleteItem(ctx context.Context, itemID, userID primitive.ObjectID) error {
+	if _, err := s.getOwnItem(ctx, itemID, userID); err != nil {
+		return err
+	}
+
+	if err := s.wishlistRepo.Delete(ctx, itemID); err != nil {
+		logging.FromContext(ctx).Error("Failed to delete wishlist item", zap.Error(err))
+		return domain.ErrOperationFailedError("Failed to delete wishlist item")
+	}
+
+	return nil
+}
+
+// MarkPurchased marks a wishlist item purchased by the user. A secret item becomes
+// visible to the partner once purchased.
+func (s *WishlistService) MarkPurchased(ctx context.Context, itemID, userID primitive.ObjectID) (*domain.WishlistItemResponse, error) {
+	item, err := s.getVisibleItem(ctx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.IsPurchased() {
+		return nil, domain.ErrInvalidRequestError("Wishlist item is already purchased")
+	}
+
+	now := time.Now()
+	item.PurchasedAt = &now
+	item.PurchasedBy = &userID
+
+	if err := s.wishlistRepo.Update(ctx, item); err != nil {
+		logging.FromContext(ctx).Error("Failed to mark wishlist item purchased", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update wishlist item")
+	}
+
+	logging.FromContext(ctx).Info("Wishlist item purchased",
+		zap.String("item_id", item.ID.Hex()),
+		zap.String("user_id", userID.Hex()))
+
+	return item.ToResponse(userID), nil
+}
+
+// UnmarkPurchased puts a purchased wishlist item back on the list; only its owner or
+// the partner who purchased it may. A secret item is hidden from the partner again.
+func (s *WishlistService) UnmarkPurchased(ctx context.Context, itemID, userID primitive.ObjectID) (*domain.WishlistItemResponse, error) {
+	item, err := s.getVisibleItem(ctx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !item.IsPurchased() {
+		return nil, domain.ErrInvalidRequestError("Wishlist item is not purchased")
+	}
+	if item.OwnerID != userID && (item.PurchasedBy == nil || *item.PurchasedBy != userID) {
+		return nil, domain.ErrForbiddenError()
+	}
+
+	item.PurchasedAt = nil
+	item.PurchasedBy = nil
+
+	if err := s.wishlistRepo.Update(ctx, item); err != nil {
+		logging.FromContext(ctx).Error("Failed to unmark wishlist item purchased", zap.Error(err))
+		return nil, domain.ErrOperationFailedError("Failed to update wishlist item")
+	}
+
+	return item.ToResponse(userID), nil
+}
+
+// getMatchedUser retrieves a user who has a partner
+func (s *WishlistService) getMatchedUser(ctx context.Context, userID primitive.ObjectID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFoundError()
+	}
+
+	if user.MatchCode == "" {
+		return nil, domain.ErrNotMatchedError()
+	}
+
+	return user, nil
+}
+
+// getVisibleItem retrieves one of the couple's wishlist items the user can see
+func (s *WishlistService) getVisibleItem(ctx context.Context, itemID, userID primitive.ObjectID) (*domain.WishlistItem, error) {
+	user, err := s.getMatchedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.wishlistRepo.GetByID(ctx, user.MatchCode, itemID)
+	if err != nil || !item.IsVisibleTo(userID) {
+		return nil, domain.ErrNotFoundError("Wishlist item")
+	}
+
+	return item, nil
+}
+
+// getOwnItem retrieves one of the user's own wishlist items
+func (s *WishlistService) getOwnItem(ctx context.Context, itemID, userID primitive.ObjectID) (*domain.WishlistItem, error) {
+	item, err := s.getVisibleItem(ctx, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if item.OwnerID != userID {
+		return nil, domain.ErrForbiddenError()
+	}
+
+	return item, nil
+}